@@ -19,6 +19,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -43,27 +46,49 @@ var (
 	doShardReplications bool
 	doTablets           bool
 	doRoutingRules      bool
+	cells               []string
 
 	Main = &cobra.Command{
 		Use:   "topo2topo",
 		Short: "topo2topo copies Vitess topology data from one topo server to another.",
 		Long: `topo2topo copies Vitess topology data from one topo server to another.
 It can also be used to compare data between two topologies.`,
-		Args:    cobra.NoArgs,
-		PreRunE: servenv.CobraPreRunE,
-		RunE:    run,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: servenv.CobraPreRunE,
+		RunE:              run,
+	}
+
+	syncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Continuously replicates the topology from one topo server to another, until interrupted.",
+		Long: `Continuously replicates the topology from one topo server to another, until interrupted.
+
+The global topo, and the topos of the cells, are watched, then the files that differ are copied and the files that only exist in the destination topo are deleted. The changes are then replicated as they happen, so that the destination topo can replace the source topo after a short cutover.
+The cells must be added to the destination topo, with the addresses of their new topo servers, before starting the replication, since their CellInfo records are not replicated. Locks and elections are not replicated either.`,
+		Args: cobra.NoArgs,
+		RunE: runSync,
+	}
+
+	checkCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Checks that two topo servers have the same topology, and lists the differences otherwise.",
+		Long: `Checks that two topo servers have the same topology, and lists the differences otherwise.
+
+All the files of the global topo, and of the topos of the cells, are compared, except for the CellInfo records, locks and elections. While the source topo changes, the differences may just be changes that "sync" did not replicate yet.`,
+		Args: cobra.NoArgs,
+		RunE: runCheck,
 	}
 )
 
 func init() {
-	servenv.MoveFlagsToCobraCommand(Main)
-
-	Main.Flags().StringVar(&fromImplementation, "from_implementation", fromImplementation, "topology implementation to copy data from")
-	Main.Flags().StringVar(&fromServerAddress, "from_server", fromServerAddress, "topology server address to copy data from")
-	Main.Flags().StringVar(&fromRoot, "from_root", fromRoot, "topology server root to copy data from")
-	Main.Flags().StringVar(&toImplementation, "to_implementation", toImplementation, "topology implementation to copy data to")
-	Main.Flags().StringVar(&toServerAddress, "to_server", toServerAddress, "topology server address to copy data to")
-	Main.Flags().StringVar(&toRoot, "to_root", toRoot, "topology server root to copy data to")
+	servenv.MovePersistentFlagsToCobraCommand(Main)
+
+	Main.PersistentFlags().StringVar(&fromImplementation, "from_implementation", fromImplementation, "topology implementation to copy data from")
+	Main.PersistentFlags().StringVar(&fromServerAddress, "from_server", fromServerAddress, "topology server address to copy data from")
+	Main.PersistentFlags().StringVar(&fromRoot, "from_root", fromRoot, "topology server root to copy data from")
+	Main.PersistentFlags().StringVar(&toImplementation, "to_implementation", toImplementation, "topology implementation to copy data to")
+	Main.PersistentFlags().StringVar(&toServerAddress, "to_server", toServerAddress, "topology server address to copy data to")
+	Main.PersistentFlags().StringVar(&toRoot, "to_root", toRoot, "topology server root to copy data to")
 	Main.Flags().BoolVar(&compare, "compare", compare, "compares data between topologies")
 	Main.Flags().BoolVar(&doKeyspaces, "do-keyspaces", doKeyspaces, "copies the keyspace information")
 	Main.Flags().BoolVar(&doShards, "do-shards", doShards, "copies the shard information")
@@ -71,21 +96,35 @@ func init() {
 	Main.Flags().BoolVar(&doTablets, "do-tablets", doTablets, "copies the tablet information")
 	Main.Flags().BoolVar(&doRoutingRules, "do-routing-rules", doRoutingRules, "copies the routing rules")
 
-	acl.RegisterFlags(Main.Flags())
-	grpccommon.RegisterFlags(Main.Flags())
+	acl.RegisterFlags(Main.PersistentFlags())
+	grpccommon.RegisterFlags(Main.PersistentFlags())
+
+	syncCmd.Flags().StringSliceVar(&cells, "cells", cells, "cells whose topos are replicated, along with the global topo (default all the cells of the source topo)")
+	Main.AddCommand(syncCmd)
+
+	checkCmd.Flags().StringSliceVar(&cells, "cells", cells, "cells whose topos are compared, along with the global topo (default all the cells of the source topo)")
+	Main.AddCommand(checkCmd)
+}
+
+func openTopos() (fromTS, toTS *topo.Server, err error) {
+	fromTS, err = topo.OpenServer(fromImplementation, fromServerAddress, fromRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Cannot open 'from' topo %v: %w", fromImplementation, err)
+	}
+	toTS, err = topo.OpenServer(toImplementation, toServerAddress, toRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Cannot open 'to' topo %v: %w", toImplementation, err)
+	}
+	return fromTS, toTS, nil
 }
 
 func run(cmd *cobra.Command, args []string) error {
 	defer logutil.Flush()
 	servenv.Init()
 
-	fromTS, err := topo.OpenServer(fromImplementation, fromServerAddress, fromRoot)
-	if err != nil {
-		return fmt.Errorf("Cannot open 'from' topo %v: %w", fromImplementation, err)
-	}
-	toTS, err := topo.OpenServer(toImplementation, toServerAddress, toRoot)
+	fromTS, toTS, err := openTopos()
 	if err != nil {
-		return fmt.Errorf("Cannot open 'to' topo %v: %w", toImplementation, err)
+		return err
 	}
 
 	ctx := context.Background()
@@ -156,3 +195,39 @@ func compareTopos(ctx context.Context, fromTS, toTS *topo.Server) (err error) {
 	fmt.Println("Topologies are in sync")
 	return nil
 }
+
+func runSync(cmd *cobra.Command, args []string) error {
+	defer logutil.Flush()
+	servenv.Init()
+
+	fromTS, toTS, err := openTopos()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	return helpers.SyncTopos(ctx, fromTS, toTS, cells)
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	defer logutil.Flush()
+	servenv.Init()
+
+	fromTS, toTS, err := openTopos()
+	if err != nil {
+		return err
+	}
+
+	diffs, err := helpers.CompareTopoFiles(context.Background(), fromTS, toTS, cells)
+	if err != nil {
+		return err
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("Topologies differ in %d files:\n%s", len(diffs), strings.Join(diffs, "\n"))
+	}
+
+	fmt.Println("Topologies are in sync")
+	return nil
+}
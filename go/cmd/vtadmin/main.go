@@ -20,6 +20,8 @@ import (
 	"context"
 	"flag"
 	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -29,12 +31,14 @@ import (
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/vtadmin"
+	"vitess.io/vitess/go/vt/vtadmin/audit"
 	"vitess.io/vitess/go/vt/vtadmin/cache"
 	"vitess.io/vitess/go/vt/vtadmin/cluster"
 	"vitess.io/vitess/go/vt/vtadmin/grpcserver"
 	vtadminhttp "vitess.io/vitess/go/vt/vtadmin/http"
 	"vitess.io/vitess/go/vt/vtadmin/http/debug"
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
+	"vitess.io/vitess/go/vt/vtadmin/savedqueries"
 
 	_flag "vitess.io/vitess/go/internal/flag"
 )
@@ -53,6 +57,12 @@ var (
 
 	cacheRefreshKey string
 
+	auditOpts audit.Options
+
+	savedQueriesFile       string
+	reportOpts             savedqueries.Options
+	reportSMTPPasswordFile string
+
 	traceCloser io.Closer = &noopCloser{}
 
 	rootCmd = &cobra.Command{
@@ -139,10 +149,36 @@ func run(cmd *cobra.Command, args []string) {
 	}
 	cache.SetCacheRefreshKey(cacheRefreshKey)
 
+	auditLog, err := audit.New(auditOpts)
+	if err != nil {
+		bootSpan.Finish()
+		fatal(err)
+	}
+	defer auditLog.Close()
+
+	savedQueries, err := savedqueries.NewStore(savedQueriesFile)
+	if err != nil {
+		bootSpan.Finish()
+		fatal(err)
+	}
+
+	if reportSMTPPasswordFile != "" {
+		password, err := os.ReadFile(reportSMTPPasswordFile)
+		if err != nil {
+			bootSpan.Finish()
+			fatal(err)
+		}
+
+		reportOpts.SMTP.Password = strings.TrimSpace(string(password))
+	}
+
 	s := vtadmin.NewAPI(clusters, vtadmin.Options{
 		GRPCOpts:              opts,
 		HTTPOpts:              httpOpts,
 		RBAC:                  rbacConfig,
+		AuditLog:              auditLog,
+		SavedQueries:          savedQueries,
+		ReportOpts:            reportOpts,
 		EnableDynamicClusters: enableDynamicClusters,
 	})
 	bootSpan.Finish()
@@ -195,6 +231,19 @@ func main() {
 	rootCmd.Flags().BoolVar(&enableRBAC, "rbac", false, "whether to enable RBAC. must be set if not passing --rbac")
 	rootCmd.Flags().BoolVar(&disableRBAC, "no-rbac", false, "whether to disable RBAC. must be set if not passing --no-rbac")
 
+	// Audit log flags
+	rootCmd.Flags().IntVar(&auditOpts.MaxEvents, "audit-log-max-events", audit.DefaultMaxEvents, "number of most recent audit events to keep in memory and serve from the audit log API")
+	rootCmd.Flags().StringVar(&auditOpts.File, "audit-log-file", "", "path to a file every audit event is appended to, as a line of JSON. the most recent events of an existing file are reloaded at startup. omit to only keep events in memory")
+
+	// Saved query and scheduled report flags
+	rootCmd.Flags().StringVar(&savedQueriesFile, "saved-queries-file", "", "path to a file saved queries are stored in, so that they survive restarts. omit to only keep saved queries in memory")
+	rootCmd.Flags().DurationVar(&reportOpts.CheckInterval, "reports-check-interval", savedqueries.DefaultCheckInterval, "how often to check for scheduled saved queries that are due to run")
+	rootCmd.Flags().StringSliceVar(&reportOpts.WebhookAllowedHosts, "reports-webhook-allowed-hosts", nil, "hostnames that scheduled reports may be sent to by webhook. reports cannot be sent to webhooks unless set")
+	rootCmd.Flags().StringVar(&reportOpts.SMTP.Addr, "reports-smtp-addr", "", "host:port of the SMTP server to email scheduled reports through. omit to disable emailing reports")
+	rootCmd.Flags().StringVar(&reportOpts.SMTP.From, "reports-smtp-from", "", "address scheduled reports are emailed from")
+	rootCmd.Flags().StringVar(&reportOpts.SMTP.Username, "reports-smtp-username", "", "username to authenticate to the SMTP server with, if any")
+	rootCmd.Flags().StringVar(&reportSMTPPasswordFile, "reports-smtp-password-file", "", "path to a file containing the password to authenticate to the SMTP server with")
+
 	// Global cache flags (N.B. there are also cluster-specific cache flags)
 	cacheRefreshHelp := "instructs a request to ignore any cached data (if applicable) and refresh the cache;" +
 		"usable as an HTTP header named 'X-<key>' and as a gRPC metadata key '<key>'\n" +
@@ -63,6 +63,7 @@ const (
 	phaseNameInitialBackup               = "InitialBackup"
 	phaseNameRestoreLastBackup           = "RestoreLastBackup"
 	phaseNameTakeNewBackup               = "TakeNewBackup"
+	phaseNameValidateBackup              = "ValidateBackup"
 	phaseStatusCatchupReplicationStalled = "Stalled"
 	phaseStatusCatchupReplicationStopped = "Stopped"
 )
@@ -71,10 +72,14 @@ var (
 	minBackupInterval   time.Duration
 	minRetentionTime    time.Duration
 	minRetentionCount   = 1
+	retentionKeepFull   int
+	retentionKeepDaily  int
 	initialBackup       bool
 	allowFirstBackup    bool
 	restartBeforeBackup bool
 	upgradeSafe         bool
+	validateBackup      bool
+	validateChecksums   bool
 
 	// vttablet-like flags
 	initDbNameOverride string
@@ -119,6 +124,7 @@ var (
 		phaseNameInitialBackup,
 		phaseNameRestoreLastBackup,
 		phaseNameTakeNewBackup,
+		phaseNameValidateBackup,
 	}
 	phaseStatus = stats.NewGaugesWithMultiLabels(
 		"PhaseStatus",
@@ -173,7 +179,12 @@ to the handling of the query path.
 The command-line parameters to vtbackup specify a policy for when a new backup
 is needed, and when old backups should be removed. If the existing backups
 already satisfy the policy, then vtbackup will do nothing and return success
-immediately.`,
+immediately.
+
+With --validate-backup, vtbackup instead restores the latest backup into its
+mysqld, checks that the restored data is consistent with the backup manifest,
+and returns an error if it is not, so that backups are known to be restorable
+before they are needed.`,
 		Version: servenv.AppVersion.String(),
 		Args:    cobra.NoArgs,
 		PreRunE: servenv.CobraPreRunE,
@@ -191,10 +202,14 @@ func init() {
 	Main.Flags().DurationVar(&minBackupInterval, "min_backup_interval", minBackupInterval, "Only take a new backup if it's been at least this long since the most recent backup.")
 	Main.Flags().DurationVar(&minRetentionTime, "min_retention_time", minRetentionTime, "Keep each old backup for at least this long before removing it. Set to 0 to disable pruning of old backups.")
 	Main.Flags().IntVar(&minRetentionCount, "min_retention_count", minRetentionCount, "Always keep at least this many of the most recent backups in this backup storage location, even if some are older than the min_retention_time. This must be at least 1 since a backup must always exist to allow new backups to be made")
+	Main.Flags().IntVar(&retentionKeepFull, "retention-keep-full", retentionKeepFull, "Prune old backups with a retention policy that keeps this many of the most recent complete full backups, instead of --min_retention_time and --min_retention_count. Incremental backups are pruned with the full backup they build upon. Set to 0 to disable the retention policy.")
+	Main.Flags().IntVar(&retentionKeepDaily, "retention-keep-daily-days", retentionKeepDaily, "With --retention-keep-full, also keep the most recent complete full backup of each of the last N days.")
 	Main.Flags().BoolVar(&initialBackup, "initial_backup", initialBackup, "Instead of restoring from backup, initialize an empty database with the provided init_db_sql_file and upload a backup of that for the shard, if the shard has no backups yet. This can be used to seed a brand new shard with an initial, empty backup. If any backups already exist for the shard, this will be considered a successful no-op. This can only be done before the shard exists in topology (i.e. before any tablets are deployed).")
 	Main.Flags().BoolVar(&allowFirstBackup, "allow_first_backup", allowFirstBackup, "Allow this job to take the first backup of an existing shard.")
 	Main.Flags().BoolVar(&restartBeforeBackup, "restart_before_backup", restartBeforeBackup, "Perform a mysqld clean/full restart after applying binlogs, but before taking the backup. Only makes sense to work around xtrabackup bugs.")
 	Main.Flags().BoolVar(&upgradeSafe, "upgrade-safe", upgradeSafe, "Whether to use innodb_fast_shutdown=0 for the backup so it is safe to use for MySQL upgrades.")
+	Main.Flags().BoolVar(&validateBackup, "validate-backup", validateBackup, "Instead of taking a new backup, restore the latest backup of the shard into the local mysqld and check that it is consistent with its manifest. Exits with an error if the backup is not valid. Old backups are not pruned.")
+	Main.Flags().BoolVar(&validateChecksums, "validate-backup-checksum-tables", validateChecksums, "With --validate-backup, also run CHECKSUM TABLE on every table of the restored database, which reads all of its data.")
 
 	// vttablet-like flags
 	Main.Flags().StringVar(&initDbNameOverride, "init_db_name_override", initDbNameOverride, "(init parameter) override the name of the db used by vttablet")
@@ -247,6 +262,10 @@ func run(_ *cobra.Command, args []string) error {
 		log.Errorf("min_retention_count must be at least 1 to allow restores to succeed")
 		exit.Return(1)
 	}
+	if retentionKeepDaily > 0 && retentionKeepFull < 1 {
+		log.Errorf("retention-keep-daily-days requires retention-keep-full to be at least 1")
+		exit.Return(1)
+	}
 
 	// Open connection backup storage.
 	backupStorage, err := backupstorage.GetBackupStorage()
@@ -268,6 +287,14 @@ func run(_ *cobra.Command, args []string) error {
 		}
 	}
 
+	if validateBackup {
+		if err := validateLatestBackup(ctx); err != nil {
+			return fmt.Errorf("Failed to validate backup: %w", err)
+		}
+		log.Info("Exiting.")
+		return nil
+	}
+
 	// Try to take a backup, if it's been long enough since the last one.
 	// Skip pruning if backup wasn't fully successful. We don't want to be
 	// deleting things if the backup process is not healthy.
@@ -299,19 +326,25 @@ func run(_ *cobra.Command, args []string) error {
 	return nil
 }
 
-func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage backupstorage.BackupStorage) error {
-	// This is an imaginary tablet alias. The value doesn't matter for anything,
-	// except that we generate a random UID to ensure the target backup
-	// directory is unique if multiple vtbackup instances are launched for the
-	// same shard, at exactly the same second, pointed at the same backup
-	// storage location.
+// randomTabletAlias returns an imaginary tablet alias. The value doesn't matter
+// for anything, except that we generate a random UID to ensure the target backup
+// directory is unique if multiple vtbackup instances are launched for the same
+// shard, at exactly the same second, pointed at the same backup storage location.
+func randomTabletAlias() (*topodatapb.TabletAlias, error) {
 	bigN, err := rand.Int(rand.Reader, big.NewInt(math.MaxUint32))
 	if err != nil {
-		return fmt.Errorf("can't generate random tablet UID: %v", err)
+		return nil, fmt.Errorf("can't generate random tablet UID: %v", err)
 	}
-	tabletAlias := &topodatapb.TabletAlias{
+	return &topodatapb.TabletAlias{
 		Cell: "vtbackup",
 		Uid:  uint32(bigN.Uint64()),
+	}, nil
+}
+
+func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage backupstorage.BackupStorage) error {
+	tabletAlias, err := randomTabletAlias()
+	if err != nil {
+		return err
 	}
 
 	// Clean up our temporary data dir if we exit for any reason, to make sure
@@ -613,6 +646,72 @@ func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage back
 	return nil
 }
 
+// validateLatestBackup restores the latest backup of the shard into a local
+// mysqld, checks the restored data and fails if the backup is not valid.
+func validateLatestBackup(ctx context.Context) error {
+	tabletAlias, err := randomTabletAlias()
+	if err != nil {
+		return err
+	}
+
+	// Clean up our temporary data dir when we're done, like takeBackup does.
+	tabletDir := mysqlctl.TabletDir(tabletAlias.Uid)
+	defer func() {
+		log.Infof("Removing temporary tablet directory: %v", tabletDir)
+		if err := os.RemoveAll(tabletDir); err != nil {
+			log.Warningf("Failed to remove temporary tablet directory: %v", err)
+		}
+	}()
+
+	// The restore deletes the data dir, so there is no need to initialize
+	// one: the restore starts mysqld itself once done.
+	mysqld, mycnf, err := mysqlctl.CreateMysqldAndMycnf(tabletAlias.Uid, mysqlSocket, mysqlPort)
+	if err != nil {
+		return fmt.Errorf("failed to initialize mysql config: %v", err)
+	}
+	if err := mysqld.InitConfig(mycnf); err != nil {
+		return fmt.Errorf("failed to initialize mysql config: %v", err)
+	}
+	defer func() {
+		mysqlShutdownCtx, mysqlShutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer mysqlShutdownCancel()
+		if err := mysqld.Shutdown(mysqlShutdownCtx, mycnf, false); err != nil {
+			log.Errorf("failed to shutdown mysqld: %v", err)
+		}
+	}()
+
+	dbName := initDbNameOverride
+	if dbName == "" {
+		dbName = fmt.Sprintf("vt_%s", initKeyspace)
+	}
+
+	phase.Set(phaseNameValidateBackup, int64(1))
+	defer phase.Set(phaseNameValidateBackup, int64(0))
+	log.Infof("Validating latest backup from directory %v", mysqlctl.GetBackupDir(initKeyspace, initShard))
+	validation, err := mysqlctl.ValidateBackup(ctx, mysqlctl.ValidateBackupParams{
+		Cnf:         mycnf,
+		Mysqld:      mysqld,
+		Logger:      logutil.NewConsoleLogger(),
+		Concurrency: concurrency,
+		HookExtraEnv: map[string]string{
+			"TABLET_ALIAS": topoproto.TabletAliasString(tabletAlias),
+		},
+		DbName:         dbName,
+		Keyspace:       initKeyspace,
+		Shard:          initShard,
+		ChecksumTables: validateChecksums,
+		Stats:          backupstats.RestoreStats(),
+	})
+	if err != nil {
+		return err
+	}
+	if !validation.Valid() {
+		return fmt.Errorf("backup %v is not valid: %v", validation.BackupName, strings.Join(validation.Errors, "; "))
+	}
+	log.Infof("Backup %v is valid", validation.BackupName)
+	return nil
+}
+
 func resetReplication(ctx context.Context, pos replication.Position, mysqld mysqlctl.MysqlDaemon) error {
 	cmds := []string{
 		"STOP SLAVE",
@@ -710,6 +809,18 @@ func retryOnError(ctx context.Context, fn func() error) error {
 }
 
 func pruneBackups(ctx context.Context, backupStorage backupstorage.BackupStorage, backupDir string) error {
+	if retentionKeepFull > 0 {
+		policy := mysqlctl.BackupRetentionPolicy{
+			KeepFull:      retentionKeepFull,
+			KeepDailyDays: retentionKeepDaily,
+		}
+		pruning, err := mysqlctl.PruneBackups(ctx, backupStorage, backupDir, policy, false, logutil.NewConsoleLogger())
+		if err != nil {
+			return err
+		}
+		log.Infof("Pruned %v backups from %v, kept %v.", len(pruning.Remove), backupDir, len(pruning.Keep))
+		return nil
+	}
 	if minRetentionTime == 0 {
 		log.Info("Pruning of old backups is disabled.")
 		return nil
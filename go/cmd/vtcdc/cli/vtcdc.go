@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/grpccommon"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtcdc"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	// Include the vtgate grpc client.
+	_ "vitess.io/vitess/go/vt/vtgate/grpcvtgateconn"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	server            string
+	keyspace          string
+	shard             string
+	tabletType        = "primary"
+	tables            []string
+	position          = "current"
+	topicPrefix       string
+	format            = vtcdc.FormatJSON
+	schemaRegistryURL string
+	sink              = "kafka-rest"
+	sinkAddress       string
+	checkpointFile    string
+	stopOnReshard     bool
+	retryDelay        = 5 * time.Second
+
+	Main = &cobra.Command{
+		Use:   "vtcdc",
+		Short: "vtcdc publishes the row changes of a keyspace, streamed from vtgate, to Kafka.",
+		Long: `vtcdc publishes the row changes of a keyspace, streamed from vtgate, to Kafka.
+
+Each row change is published as a message on the "<topic-prefix><keyspace>.<table>" topic,
+serialized as JSON or as Avro with its schema registered in a schema registry.
+Messages are keyed by the keyspace_id of the row when the table query selects
+keyspace_id(), and by the shard otherwise, so that the changes to a row keep their order.
+
+The VGTID of the published changes is checkpointed to a file after every
+transaction, and vtcdc resumes from it when restarted, publishing every
+change at least once.
+
+Messages are published through a Kafka REST Proxy by the kafka-rest sink.
+Other sinks can be linked in with vtcdc.RegisterSink.`,
+		Example: `vtcdc --server vtgate:15991 --keyspace commerce --checkpoint-file /vt/vtcdc/commerce.json \
+	--sink-address http://kafka-rest:8082
+
+vtcdc --server vtgate:15991 --keyspace customer --tablet-type replica --checkpoint-file /vt/vtcdc/customer.json \
+	--tables "select keyspace_id(), customer_id, email from customer" --tables corder \
+	--format avro --schema-registry-url http://schema-registry:8081 --sink-address http://kafka-rest:8082`,
+		Args:    cobra.NoArgs,
+		Version: servenv.AppVersion.String(),
+		PreRunE: servenv.CobraPreRunE,
+		RunE:    run,
+	}
+)
+
+func init() {
+	servenv.MoveFlagsToCobraCommand(Main)
+
+	Main.Flags().StringVar(&server, "server", server, "vtgate server to connect to")
+	Main.Flags().StringVar(&keyspace, "keyspace", keyspace, "Keyspace to stream the changes of")
+	Main.Flags().StringVar(&shard, "shard", shard, "Only stream the changes of this shard. All the shards are streamed if not set")
+	Main.Flags().StringVar(&tabletType, "tablet-type", tabletType, "Type of the tablets to stream from")
+	Main.Flags().StringSliceVar(&tables, "tables", tables, "Tables to stream: table names, regular expressions starting with '/', or select queries on a single table. All the tables are streamed if not set")
+	Main.Flags().StringVar(&position, "position", position, "Position to start from when there is no checkpoint: 'current' to stream new changes only, or '' to copy the tables first")
+	Main.Flags().StringVar(&topicPrefix, "topic-prefix", topicPrefix, "Prefix of the topics the changes are published to")
+	Main.Flags().StringVar(&format, "format", format, "Serialization format of the messages: json or avro")
+	Main.Flags().StringVar(&schemaRegistryURL, "schema-registry-url", schemaRegistryURL, "URL of the schema registry used by the avro format")
+	Main.Flags().StringVar(&sink, "sink", sink, fmt.Sprintf("Sink to publish the messages to: %s", strings.Join(vtcdc.SinkNames(), ", ")))
+	Main.Flags().StringVar(&sinkAddress, "sink-address", sinkAddress, "Address of the sink, e.g. the URL of the Kafka REST Proxy")
+	Main.Flags().StringVar(&checkpointFile, "checkpoint-file", checkpointFile, "File the VGTID of the published changes is checkpointed to")
+	Main.Flags().BoolVar(&stopOnReshard, "stop-on-reshard", stopOnReshard, "Stop streaming when the keyspace is resharded")
+	Main.Flags().DurationVar(&retryDelay, "retry-delay", retryDelay, "How long to wait before restarting the stream after an error")
+
+	Main.MarkFlagRequired("server")
+	Main.MarkFlagRequired("keyspace")
+	Main.MarkFlagRequired("checkpoint-file")
+
+	acl.RegisterFlags(Main.Flags())
+	grpccommon.RegisterFlags(Main.Flags())
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	defer logutil.Flush()
+
+	servenv.Init()
+	defer servenv.Close()
+
+	tt, err := topoproto.ParseTabletType(tabletType)
+	if err != nil {
+		return err
+	}
+	serializer, err := vtcdc.NewSerializer(format, schemaRegistryURL)
+	if err != nil {
+		return err
+	}
+	s, err := vtcdc.NewSink(sink, sinkAddress)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	conn, err := vtgateconn.Dial(ctx, server)
+	if err != nil {
+		return fmt.Errorf("cannot connect to vtgate %s: %w", server, err)
+	}
+	defer conn.Close()
+
+	connector := vtcdc.NewConnector(vtcdc.Config{
+		Keyspace:    keyspace,
+		Shard:       shard,
+		TabletType:  tt,
+		Tables:      tables,
+		Position:    position,
+		TopicPrefix: topicPrefix,
+		Flags:       &vtgatepb.VStreamFlags{StopOnReshard: stopOnReshard},
+		RetryDelay:  retryDelay,
+	}, conn, s, serializer, &vtcdc.FileCheckpointer{Path: checkpointFile})
+
+	log.Infof("Publishing the changes of keyspace %s to the %s sink", keyspace, sink)
+	return connector.Run(ctx)
+}
@@ -32,6 +32,7 @@ import (
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
@@ -304,7 +305,9 @@ func run(cmd *cobra.Command, args []string) (err error) {
 		log.Error("Terminating")
 		// FIXME(alainjobart): stop vtgate
 	})
+	stopClusterSettings := clustersettings.Start(ts)
 	servenv.OnClose(func() {
+		stopClusterSettings()
 		// We will still use the topo server during lameduck period
 		// to update our state, so closing it in OnClose()
 		ts.Close()
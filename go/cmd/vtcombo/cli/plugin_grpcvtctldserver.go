@@ -17,14 +17,22 @@ limitations under the License.
 package cli
 
 import (
+	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 )
 
 func init() {
+	servenv.OnInit(func() {
+		grpcvtctldserver.InitAuditLog()
+		if err := grpcvtctldserver.InitRBAC(); err != nil {
+			log.Exitf("Cannot enable the vtctld RBAC: %v", err)
+		}
+	})
 	servenv.OnRun(func() {
 		if servenv.GRPCCheckServiceMap("vtctld") {
 			grpcvtctldserver.StartServer(servenv.GRPCServer, ts)
 		}
+		servenv.OnTerm(grpcvtctldserver.StartMaintenanceScheduler(ts))
 	})
 }
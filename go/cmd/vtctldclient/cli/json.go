@@ -19,6 +19,7 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"google.golang.org/protobuf/encoding/protojson"
 
@@ -71,6 +72,65 @@ func MarshalJSON(obj any, marshalOptions ...protojson.MarshalOptions) ([]byte, e
 	}
 }
 
+// marshalProtoJSON works the same as MarshalJSON, except that the elements of
+// slices and maps of proto.Message types are marshaled with the jsonpb
+// marshaler too, so that they have their proto field names. It is used by the
+// YAML and table formats, which have no existing output to keep compatible
+// with.
+func marshalProtoJSON(obj any) ([]byte, error) {
+	obj, err := marshalProtoElements(obj, DefaultMarshalOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return MarshalJSON(obj)
+}
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// marshalProtoElements marshals the elements of obj with the jsonpb marshaler
+// if it is a slice or a map of proto.Message types, and returns them as
+// json.RawMessage values, to be marshaled by the standard Go marshaler.
+// Otherwise, it returns obj unchanged.
+func marshalProtoElements(obj any, m protojson.MarshalOptions) (any, error) {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() || !v.Type().Elem().Implements(protoMessageType) {
+			return obj, nil
+		}
+
+		elements := make([]json.RawMessage, v.Len())
+		for i := range elements {
+			data, err := m.Marshal(v.Index(i).Interface().(proto.Message))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = data
+		}
+
+		return elements, nil
+	case reflect.Map:
+		if v.IsNil() || !v.Type().Elem().Implements(protoMessageType) {
+			return obj, nil
+		}
+
+		elements := make(map[string]json.RawMessage, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			data, err := m.Marshal(iter.Value().Interface().(proto.Message))
+			if err != nil {
+				return nil, err
+			}
+			elements[fmt.Sprint(iter.Key().Interface())] = data
+		}
+
+		return elements, nil
+	default:
+		return obj, nil
+	}
+}
+
 // MarshalJSONPretty works the same as MarshalJSON but uses ENUM names
 // instead of numbers.
 func MarshalJSONPretty(obj any) ([]byte, error) {
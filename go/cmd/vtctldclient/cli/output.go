@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// OutputFormat is the format in which commands write their results. It
+// implements pflag.Value.
+type OutputFormat string
+
+const (
+	// OutputFormatText is the default output of each command: the text
+	// output of the commands which have one, and JSON for the others.
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON is the JSON output of the commands, as marshaled by
+	// MarshalJSON.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatYAML is YAML, with the proto field names.
+	OutputFormatYAML OutputFormat = "yaml"
+	// OutputFormatTable is a table for humans, see MarshalTable.
+	OutputFormatTable OutputFormat = "table"
+)
+
+// Format is the output format of the commands, set with the global --format
+// flag.
+var Format = OutputFormatText
+
+// Set is part of the pflag.Value interface.
+func (f *OutputFormat) Set(s string) error {
+	switch format := OutputFormat(strings.ToLower(strings.TrimSpace(s))); format {
+	case OutputFormatText, OutputFormatJSON, OutputFormatYAML, OutputFormatTable:
+		*f = format
+	case "awk":
+		// GetTablets used to have its own --format flag, whose default
+		// text output was "awk".
+		*f = OutputFormatText
+	default:
+		return fmt.Errorf("invalid output format %q, valid formats are text, json, yaml and table", s)
+	}
+	return nil
+}
+
+// String is part of the pflag.Value interface.
+func (f *OutputFormat) String() string {
+	return string(*f)
+}
+
+// Type is part of the pflag.Value interface.
+func (f *OutputFormat) Type() string {
+	return "string"
+}
+
+// Marshal marshals obj in the given format. The text format is JSON, for the
+// commands which have no text output of their own, and both are marshaled as
+// by MarshalJSON, so that the JSON output of the commands is unchanged. The
+// YAML output, like JSON, has no trailing newline.
+func Marshal(obj any, format OutputFormat) ([]byte, error) {
+	switch format {
+	case OutputFormatYAML:
+		data, err := marshalProtoJSON(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return bytes.TrimSuffix(data, []byte("\n")), nil
+	case OutputFormatTable:
+		return MarshalTable(obj)
+	default:
+		return MarshalJSON(obj)
+	}
+}
+
+// MarshalOutput marshals obj in the format set with the --format flag.
+func MarshalOutput(obj any) ([]byte, error) {
+	return Marshal(obj, Format)
+}
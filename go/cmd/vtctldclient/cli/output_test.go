@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestOutputFormatSet(t *testing.T) {
+	var format OutputFormat
+
+	require.NoError(t, format.Set("YAML"))
+	assert.Equal(t, OutputFormatYAML, format)
+
+	require.NoError(t, format.Set("awk"))
+	assert.Equal(t, OutputFormatText, format)
+
+	assert.Error(t, format.Set("xml"))
+	assert.Equal(t, OutputFormatText, format)
+}
+
+func TestMarshal(t *testing.T) {
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Hostname: "host1",
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Hostname: "host2",
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+	}
+
+	// The text and JSON formats are the JSON output the commands always had.
+	want, err := MarshalJSON(tablets)
+	require.NoError(t, err)
+	for _, format := range []OutputFormat{OutputFormatText, OutputFormatJSON} {
+		data, err := Marshal(tablets, format)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(data), "format %s", format)
+	}
+
+	// In YAML, the protos in slices are marshaled with their proto field names
+	// and enum names, as on their own. Like JSON, the output has no trailing
+	// newline, as the commands print it with one.
+	data, err := Marshal(tablets, OutputFormatYAML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- alias:\n    cell: zone1\n    uid: 100\n")
+	assert.Contains(t, string(data), "  type: REPLICA")
+	assert.False(t, strings.HasSuffix(string(data), "\n"))
+
+	data, err = Marshal(tablets, OutputFormatTable)
+	require.NoError(t, err)
+	lines := splitTableLines(string(data))
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"ALIAS", "HOSTNAME"}, lines[0][:2])
+	assert.Contains(t, lines[0], "TYPE")
+	assert.NotContains(t, lines[0], "MYSQL_HOSTNAME", "empty columns should be left out")
+	assert.Equal(t, []string{"zone1-0000000100", "host1"}, lines[1][:2])
+	assert.Contains(t, lines[2], "REPLICA")
+
+	// A map of records is rendered with their keys.
+	data, err = Marshal(map[string]*topodatapb.CellsAlias{
+		"east": {Cells: []string{"zone1", "zone2"}},
+	}, OutputFormatTable)
+	require.NoError(t, err)
+	lines = splitTableLines(string(data))
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAME", "CELLS"}, lines[0])
+	assert.Equal(t, []string{"east", "zone1,zone2"}, lines[1])
+
+	// Any other record is rendered as its fields.
+	data, err = Marshal(&topodatapb.CellInfo{ServerAddress: "localhost:2379", Root: "/vitess/zone1"}, OutputFormatTable)
+	require.NoError(t, err)
+	lines = splitTableLines(string(data))
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"FIELD", "VALUE"}, lines[0])
+	assert.Equal(t, []string{"server_address", "localhost:2379"}, lines[1])
+}
+
+// splitTableLines returns the cells of the header and rows of a table.
+func splitTableLines(table string) [][]string {
+	var lines [][]string
+	for _, line := range strings.Split(table, "\n") {
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		var cells []string
+		for _, cell := range strings.Split(strings.Trim(line, "|"), "|") {
+			cells = append(cells, strings.TrimSpace(cell))
+		}
+		lines = append(lines, cells)
+	}
+	return lines
+}
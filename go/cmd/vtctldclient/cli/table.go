@@ -0,0 +1,304 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// jsonObject is a decoded JSON object, which keeps the order of its fields,
+// so the columns of a table are in the order of the fields of the protos.
+type jsonObject []jsonField
+
+type jsonField struct {
+	name  string
+	value any
+}
+
+// MarshalJSON is part of the json.Marshaler interface.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSON decodes the next JSON value of dec, with jsonObject for the
+// objects and json.Number for the numbers.
+func decodeJSON(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := jsonObject{}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonField{name: tok.(string), value: value})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case '[':
+		list := []any{}
+		for dec.More() {
+			value, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = dec.Token()
+		return list, err
+	default:
+		return nil, fmt.Errorf("unexpected JSON delimiter %v", delim)
+	}
+}
+
+// asRecords returns the elements of list, if they are all objects.
+func asRecords(list []any) ([]jsonObject, bool) {
+	records := make([]jsonObject, 0, len(list))
+	for _, value := range list {
+		record, ok := value.(jsonObject)
+		if !ok {
+			return nil, false
+		}
+		records = append(records, record)
+	}
+	return records, true
+}
+
+// MarshalTable renders obj, marshaled as by marshalProtoJSON, as a table. The rows
+// are:
+//   - the elements of obj if it is a list, or the elements of its only field
+//     if that field is a list, as in the responses with a list of workflows;
+//   - the values of obj if it is a map of records, as the cells aliases, with
+//     their keys in a first "NAME" column;
+//   - otherwise the fields of obj, with their values.
+//
+// The columns are the fields of the records, except the ones which are empty
+// in all of them. The nested records are rendered as compact JSON, except for
+// the tablet aliases, times and durations.
+func MarshalTable(obj any) ([]byte, error) {
+	data, err := marshalProtoJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeJSON(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []jsonObject
+	switch v := value.(type) {
+	case []any:
+		var ok bool
+		if records, ok = asRecords(v); !ok {
+			for _, value := range v {
+				records = append(records, jsonObject{{name: "value", value: value}})
+			}
+		}
+	case jsonObject:
+		if len(v) == 1 {
+			if list, ok := v[0].value.([]any); ok {
+				records, _ = asRecords(list)
+			}
+		}
+
+		if records == nil {
+			keyed := len(v) > 0
+			for _, field := range v {
+				record, ok := field.value.(jsonObject)
+				if !ok {
+					keyed = false
+					break
+				}
+				records = append(records, append(jsonObject{{name: "name", value: field.name}}, record...))
+			}
+
+			if !keyed {
+				records = nil
+				for _, field := range v {
+					records = append(records, jsonObject{{name: "field", value: field.name}, {name: "value", value: field.value}})
+				}
+			}
+		}
+	default:
+		records = []jsonObject{{{name: "value", value: value}}}
+	}
+
+	// The columns are in the order in which they first appear.
+	var columns []string
+	seen := map[string]bool{}
+	for _, record := range records {
+		for _, field := range record {
+			if seen[field.name] || isEmptyJSON(field.value) {
+				continue
+			}
+			seen[field.name] = true
+			columns = append(columns, field.name)
+		}
+	}
+
+	buf := bytes.Buffer{}
+	if len(columns) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	table := tablewriter.NewWriter(&buf)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, strings.ToUpper(column))
+	}
+	table.SetHeader(header)
+
+	for _, record := range records {
+		values := make(map[string]any, len(record))
+		for _, field := range record {
+			values[field.name] = field.value
+		}
+
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, formatTableValue(values[column]))
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// isEmptyJSON returns true for the values which are left out of the tables
+// when all of the records have them.
+func isEmptyJSON(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case jsonObject:
+		return len(v) == 0
+	}
+	return false
+}
+
+// formatTableValue formats a value for a cell of a table.
+func formatTableValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			switch value.(type) {
+			case []any, jsonObject:
+				return compactJSON(v)
+			}
+			values = append(values, formatTableValue(value))
+		}
+		return strings.Join(values, ",")
+	case jsonObject:
+		fields := make(map[string]any, len(v))
+		for _, field := range v {
+			fields[field.name] = field.value
+		}
+		if len(v) == 2 {
+			switch {
+			case fields["cell"] != nil && fields["uid"] != nil:
+				// A topodata.TabletAlias.
+				if uid, err := strconv.ParseUint(formatTableValue(fields["uid"]), 10, 32); err == nil {
+					return fmt.Sprintf("%v-%010d", fields["cell"], uid)
+				}
+			case fields["seconds"] != nil && fields["nanoseconds"] != nil:
+				// A vttime.Time.
+				seconds, err1 := strconv.ParseInt(formatTableValue(fields["seconds"]), 10, 64)
+				nanoseconds, err2 := strconv.ParseInt(formatTableValue(fields["nanoseconds"]), 10, 64)
+				if err1 == nil && err2 == nil {
+					return time.Unix(seconds, nanoseconds).UTC().Format(time.RFC3339)
+				}
+			case fields["seconds"] != nil && fields["nanos"] != nil:
+				// A vttime.Duration.
+				seconds, err1 := strconv.ParseInt(formatTableValue(fields["seconds"]), 10, 64)
+				nanos, err2 := strconv.ParseInt(formatTableValue(fields["nanos"]), 10, 64)
+				if err1 == nil && err2 == nil {
+					return (time.Duration(seconds)*time.Second + time.Duration(nanos)).String()
+				}
+			}
+		}
+		return compactJSON(v)
+	}
+	return fmt.Sprint(value)
+}
+
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
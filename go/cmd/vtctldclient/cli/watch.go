@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// WatchOptions are the options of the commands which can keep showing a
+// status until it settles, rather than showing it once.
+type WatchOptions struct {
+	Watch    bool
+	Interval time.Duration
+}
+
+// AddWatchFlags adds the --watch and --watch-interval flags to a command.
+// until describes when the command stops watching.
+func AddWatchFlags(cmd *cobra.Command, opts *WatchOptions, until string) {
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, fmt.Sprintf("Keep showing the status every --watch-interval, until %s.", until))
+	cmd.Flags().DurationVar(&opts.Interval, "watch-interval", 10*time.Second, "How often to show the status with --watch.")
+}
+
+// Watch calls show, which shows a status and returns true once it has
+// settled. Without --watch, show is only called once. With it, show is
+// called every --watch-interval until it returns true or an error, or until
+// ctx is done, e.g. because of the --action_timeout.
+func Watch(ctx context.Context, opts WatchOptions, show func(ctx context.Context) (done bool, err error)) error {
+	if !opts.Watch {
+		_, err := show(ctx)
+		return err
+	}
+	if opts.Interval <= 0 {
+		return fmt.Errorf("--watch-interval must be positive, got %v", opts.Interval)
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		done, err := show(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped watching: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+
+	// show is done after its third call, or fails with err if it is set.
+	var (
+		calls int
+		err   error
+	)
+	show := func(ctx context.Context) (bool, error) {
+		calls++
+		return calls == 3, err
+	}
+
+	calls = 0
+	assert.NoError(t, Watch(ctx, WatchOptions{Interval: time.Millisecond}, show))
+	assert.Equal(t, 1, calls, "without --watch, the status is shown once")
+
+	calls = 0
+	assert.NoError(t, Watch(ctx, WatchOptions{Watch: true, Interval: time.Millisecond}, show))
+	assert.Equal(t, 3, calls, "with --watch, the status is shown until it settles")
+
+	calls = 0
+	err = errors.New("show failed")
+	assert.ErrorIs(t, Watch(ctx, WatchOptions{Watch: true, Interval: time.Millisecond}, show), err)
+	assert.Equal(t, 1, calls)
+	err = nil
+
+	calls = 0
+	assert.Error(t, Watch(ctx, WatchOptions{Watch: true}, show))
+	assert.Equal(t, 0, calls)
+
+	// The watch stops when the context is done.
+	calls = -100
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, Watch(ctx, WatchOptions{Watch: true, Interval: time.Millisecond}, show), context.DeadlineExceeded)
+	assert.Less(t, calls, 3)
+}
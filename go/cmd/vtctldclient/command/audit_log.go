@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// GetAuditLog makes a GetAuditLog gRPC call to a vtctld.
+	GetAuditLog = &cobra.Command{
+		Use:   "GetAuditLog [--limit <limit>] [--component <component>] [--identity <identity>] [--action <action>] [--since <duration>]",
+		Short: "Lists the most recent entries of the audit log of the actions which changed the cluster.",
+		Long: `Lists the most recent entries of the audit log of the actions which changed the cluster, from the most recent one.
+
+The entries are read from the file sink of the audit log of the vtctld, which
+records the calls to its RPCs, other than the read-only ones, and the recoveries
+of the vtorcs which write to the same file.`,
+		Example: `GetAuditLog --identity oncall --since 24h
+GetAuditLog --action PlannedReparentShard --limit 10`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetAuditLog,
+	}
+)
+
+var getAuditLogOptions = struct {
+	Limit     uint32
+	Component string
+	Identity  string
+	Action    string
+	Since     time.Duration
+}{}
+
+func commandGetAuditLog(cmd *cobra.Command, args []string) error {
+	if getAuditLogOptions.Since < 0 {
+		return fmt.Errorf("--since must not be negative, got %v", getAuditLogOptions.Since)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.GetAuditLogRequest{
+		Limit:     getAuditLogOptions.Limit,
+		Component: getAuditLogOptions.Component,
+		Identity:  getAuditLogOptions.Identity,
+		Action:    getAuditLogOptions.Action,
+	}
+	if getAuditLogOptions.Since > 0 {
+		req.Since = protoutil.TimeToProto(time.Now().Add(-getAuditLogOptions.Since))
+	}
+
+	resp, err := client.GetAuditLog(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp.Entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func init() {
+	GetAuditLog.Flags().Uint32Var(&getAuditLogOptions.Limit, "limit", 100, "Maximum number of entries to list, the most recent ones. Zero lists all of them.")
+	GetAuditLog.Flags().StringVar(&getAuditLogOptions.Component, "component", "", "Only list the actions of this component, e.g. vtctld or vtorc.")
+	GetAuditLog.Flags().StringVar(&getAuditLogOptions.Identity, "identity", "", "Only list the actions requested by this identity.")
+	GetAuditLog.Flags().StringVar(&getAuditLogOptions.Action, "action", "", "Only list these actions, e.g. PlannedReparentShard.")
+	GetAuditLog.Flags().DurationVar(&getAuditLogOptions.Since, "since", 0, "Only list the actions started within this duration, e.g. 24h.")
+	Root.AddCommand(GetAuditLog)
+}
@@ -19,7 +19,9 @@ package command
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -29,6 +31,8 @@ import (
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
+	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -54,12 +58,31 @@ If no replica-type tablet can be found, the backup can be taken on the primary i
 	}
 	// GetBackups makes a GetBackups gRPC call to a vtctld.
 	GetBackups = &cobra.Command{
-		Use:                   "GetBackups [--limit <limit>] [--json] <keyspace/shard>",
-		Short:                 "Lists backups for the given shard.",
+		Use:   "GetBackups [--limit <limit>] [--detailed [--detailed-limit <limit>]] [--json] <keyspace/shard>",
+		Short: "Lists backups for the given shard.",
+		Long: `Lists backups for the given shard.
+
+With --detailed, the MANIFEST of each backup is read from the backup storage to
+also list its status, size, duration, engine, compression, and the backup an
+incremental backup continues. A backup without a MANIFEST is INCOMPLETE, and
+one is VALID or INVALID if it was checked by ValidateBackup.`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandGetBackups,
 	}
+	// PruneBackups makes a PruneBackups gRPC call to a vtctld.
+	PruneBackups = &cobra.Command{
+		Use:   "PruneBackups --keep-full <count> [--keep-daily-days <days>] [--dry-run] <keyspace/shard>",
+		Short: "Removes the backups of the given shard that are not kept by a retention policy.",
+		Long: `Removes the backups of the given shard that are not kept by a retention policy.
+
+The most recent --keep-full complete full backups are kept and, with --keep-daily-days, so is the most recent complete full backup of each of the last days.
+An incremental backup is kept as long as the last full backup taken before it is kept, and is removed with it.
+Incomplete backups are removed, unless they are more recent than every complete backup, as they may still be in progress.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandPruneBackups,
+	}
 	// RemoveBackup makes a RemoveBackup gRPC call to a vtctld.
 	RemoveBackup = &cobra.Command{
 		Use:                   "RemoveBackup <keyspace/shard> <backup name>",
@@ -70,19 +93,50 @@ If no replica-type tablet can be found, the backup can be taken on the primary i
 	}
 	// RestoreFromBackup makes a RestoreFromBackup gRPC call to a vtctld.
 	RestoreFromBackup = &cobra.Command{
-		Use:                   "RestoreFromBackup [--backup-timestamp|-t <YYYY-mm-DD.HHMMSS>] [--restore-to-pos <pos>] [--dry-run] <tablet_alias>",
-		Short:                 "Stops mysqld on the specified tablet and restores the data from either the latest backup or closest before `backup-timestamp`.",
+		Use:                   "RestoreFromBackup [--backup-timestamp|-t <YYYY-mm-DD.HHMMSS>] [--restore-to-pos <pos>] [--source-tablet <tablet_alias>] [--dry-run] <tablet_alias>",
+		Short:                 "Stops mysqld on the specified tablet and restores the data from either the latest backup or closest before `backup-timestamp`, or from a backup streamed by `source-tablet`.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandRestoreFromBackup,
 	}
+	// RestoreToPointInTime makes a RestoreToPointInTime gRPC call to a vtctld.
+	RestoreToPointInTime = &cobra.Command{
+		Use:   "RestoreToPointInTime {--restore-to-timestamp <timestamp>|--restore-to-pos <pos>} [--tablet <tablet_alias>] [--binlog-source-tablet <tablet_alias>|--binlog-server <host:port>] [--dry-run] <keyspace/shard>",
+		Short: "Restores a tablet of the given shard from a full backup and applies binary logs from a binlog source up to the given timestamp or position.",
+		Long: `Restores a tablet of the given shard from a full backup and applies binary logs from a binlog source up to the given timestamp or position.
+
+The binary logs are read from the given binlog source tablet or binlog server. If neither is given, they are read from a tablet of the shard running a binlog server, or else from the shard primary.
+The binlog server of a tablet is used over its MySQL server, if it runs one.
+If no tablet is given, a SPARE, DRAINED, RDONLY or REPLICA tablet of the shard is restored, in that order of preference.
+Replication remains disabled on the restored tablet.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandRestoreToPointInTime,
+	}
+	// ValidateBackup makes a ValidateBackup gRPC call to a vtctld.
+	ValidateBackup = &cobra.Command{
+		Use:   "ValidateBackup [--backup-timestamp|-t <YYYY-mm-DD.HHMMSS>] [--tablet <tablet_alias>] [--checksum-tables] [--concurrency <concurrency>] <keyspace/shard>",
+		Short: "Restores a backup of the given shard into a scratch MySQL instance, checks the restored data, and reports whether the backup is valid.",
+		Long: `Restores a backup of the given shard into a scratch MySQL instance, checks the restored data, and reports whether the backup is valid.
+
+The backup is restored by a tablet of the shard into a scratch directory next to its own data, which is not touched, so the tablet keeps serving.
+If no tablet is given, a SPARE, DRAINED, RDONLY or REPLICA tablet of the shard is used, in that order of preference.
+The GTID position of the restored MySQL instance is checked against the backup manifest and, with --checksum-tables, every table is read with CHECKSUM TABLE.
+The command fails if the backup is not valid.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandValidateBackup,
+	}
 )
 
 var backupOptions = struct {
-	AllowPrimary       bool
-	Concurrency        uint64
-	IncrementalFromPos string
-	UpgradeSafe        bool
+	AllowPrimary          bool
+	Concurrency           uint64
+	IncrementalFromPos    string
+	UpgradeSafe           bool
+	CompressionEngine     string
+	CompressionLevel      int64
+	CompressionDictionary string
 }{}
 
 func commandBackup(cmd *cobra.Command, args []string) error {
@@ -94,11 +148,14 @@ func commandBackup(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	stream, err := client.Backup(commandCtx, &vtctldatapb.BackupRequest{
-		TabletAlias:        tabletAlias,
-		AllowPrimary:       backupOptions.AllowPrimary,
-		Concurrency:        backupOptions.Concurrency,
-		IncrementalFromPos: backupOptions.IncrementalFromPos,
-		UpgradeSafe:        backupOptions.UpgradeSafe,
+		TabletAlias:           tabletAlias,
+		AllowPrimary:          backupOptions.AllowPrimary,
+		Concurrency:           backupOptions.Concurrency,
+		IncrementalFromPos:    backupOptions.IncrementalFromPos,
+		UpgradeSafe:           backupOptions.UpgradeSafe,
+		CompressionEngine:     backupOptions.CompressionEngine,
+		CompressionLevel:      backupOptions.CompressionLevel,
+		CompressionDictionary: backupOptions.CompressionDictionary,
 	})
 	if err != nil {
 		return err
@@ -108,7 +165,10 @@ func commandBackup(cmd *cobra.Command, args []string) error {
 		resp, err := stream.Recv()
 		switch err {
 		case nil:
-			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			// Progress is also logged as an event, so there is no need to print it.
+			if resp.Event != nil {
+				fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			}
 		case io.EOF:
 			return nil
 		default:
@@ -118,10 +178,13 @@ func commandBackup(cmd *cobra.Command, args []string) error {
 }
 
 var backupShardOptions = struct {
-	AllowPrimary       bool
-	Concurrency        uint64
-	IncrementalFromPos string
-	UpgradeSafe        bool
+	AllowPrimary          bool
+	Concurrency           uint64
+	IncrementalFromPos    string
+	UpgradeSafe           bool
+	CompressionEngine     string
+	CompressionLevel      int64
+	CompressionDictionary string
 }{}
 
 func commandBackupShard(cmd *cobra.Command, args []string) error {
@@ -133,12 +196,15 @@ func commandBackupShard(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	stream, err := client.BackupShard(commandCtx, &vtctldatapb.BackupShardRequest{
-		Keyspace:           keyspace,
-		Shard:              shard,
-		AllowPrimary:       backupShardOptions.AllowPrimary,
-		Concurrency:        backupShardOptions.Concurrency,
-		IncrementalFromPos: backupShardOptions.IncrementalFromPos,
-		UpgradeSafe:        backupShardOptions.UpgradeSafe,
+		Keyspace:              keyspace,
+		Shard:                 shard,
+		AllowPrimary:          backupShardOptions.AllowPrimary,
+		Concurrency:           backupShardOptions.Concurrency,
+		IncrementalFromPos:    backupShardOptions.IncrementalFromPos,
+		UpgradeSafe:           backupShardOptions.UpgradeSafe,
+		CompressionEngine:     backupShardOptions.CompressionEngine,
+		CompressionLevel:      backupShardOptions.CompressionLevel,
+		CompressionDictionary: backupShardOptions.CompressionDictionary,
 	})
 	if err != nil {
 		return err
@@ -148,7 +214,9 @@ func commandBackupShard(cmd *cobra.Command, args []string) error {
 		resp, err := stream.Recv()
 		switch err {
 		case nil:
-			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			if resp.Event != nil {
+				fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			}
 		case io.EOF:
 			return nil
 		default:
@@ -158,8 +226,10 @@ func commandBackupShard(cmd *cobra.Command, args []string) error {
 }
 
 var getBackupsOptions = struct {
-	Limit      uint32
-	OutputJSON bool
+	Limit         uint32
+	Detailed      bool
+	DetailedLimit uint32
+	OutputJSON    bool
 }{}
 
 func commandGetBackups(cmd *cobra.Command, args []string) error {
@@ -171,16 +241,18 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.GetBackups(commandCtx, &vtctldatapb.GetBackupsRequest{
-		Keyspace: keyspace,
-		Shard:    shard,
-		Limit:    getBackupsOptions.Limit,
+		Keyspace:      keyspace,
+		Shard:         shard,
+		Limit:         getBackupsOptions.Limit,
+		Detailed:      getBackupsOptions.Detailed,
+		DetailedLimit: getBackupsOptions.DetailedLimit,
 	})
 	if err != nil {
 		return err
 	}
 
-	if getBackupsOptions.OutputJSON {
-		data, err := cli.MarshalJSON(resp)
+	if getBackupsOptions.OutputJSON || cli.Format != cli.OutputFormatText {
+		data, err := cli.MarshalOutput(resp)
 		if err != nil {
 			return err
 		}
@@ -189,6 +261,10 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if getBackupsOptions.Detailed {
+		return printDetailedBackups(os.Stdout, resp.Backups)
+	}
+
 	names := make([]string, len(resp.Backups))
 	for i, b := range resp.Backups {
 		names[i] = b.Name
@@ -199,6 +275,93 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatBackupSize formats a number of bytes in the largest unit it has at
+// least one of.
+func formatBackupSize(size int64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10}} {
+		if size >= unit.size {
+			return fmt.Sprintf("%.1f%s", float64(size)/float64(unit.size), unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+// printDetailedBackups prints a table of the backups listed by GetBackups
+// with --detailed.
+func printDetailedBackups(w io.Writer, backups []*mysqlctlpb.BackupInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSTATUS\tSIZE\tDURATION\tENGINE\tCOMPRESSION\tFROM BACKUP")
+	for _, b := range backups {
+		size, duration, compression, from := "-", "-", "-", "-"
+		if b.Size > 0 {
+			size = formatBackupSize(b.Size)
+		}
+		if d, ok, err := protoutil.DurationFromProto(b.Duration); ok && err == nil {
+			duration = d.String()
+		}
+		if b.CompressionEngine != "" {
+			compression = b.CompressionEngine
+		}
+		switch {
+		case b.FromBackup != "":
+			from = b.FromBackup
+		case b.Incremental:
+			from = "?"
+		}
+		if b.Encrypted {
+			compression += " (encrypted)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", b.Name, b.Status, size, duration, b.Engine, compression, from)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, b := range backups {
+		for _, e := range b.ValidationErrors {
+			fmt.Fprintf(w, "%s: %s\n", b.Name, e)
+		}
+	}
+	return nil
+}
+
+var pruneBackupsOptions = struct {
+	KeepFull      uint32
+	KeepDailyDays uint32
+	DryRun        bool
+}{}
+
+func commandPruneBackups(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.PruneBackups(commandCtx, &vtctldatapb.PruneBackupsRequest{
+		Keyspace:      keyspace,
+		Shard:         shard,
+		KeepFull:      pruneBackupsOptions.KeepFull,
+		KeepDailyDays: pruneBackupsOptions.KeepDailyDays,
+		DryRun:        pruneBackupsOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandRemoveBackup(cmd *cobra.Command, args []string) error {
 	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
 	if err != nil {
@@ -221,6 +384,7 @@ var restoreFromBackupOptions = struct {
 	BackupTimestamp    string
 	RestoreToPos       string
 	RestoreToTimestamp string
+	SourceTablet       string
 	DryRun             bool
 }{}
 
@@ -258,6 +422,13 @@ func commandRestoreFromBackup(cmd *cobra.Command, args []string) error {
 		req.BackupTime = protoutil.TimeToProto(t)
 	}
 
+	if restoreFromBackupOptions.SourceTablet != "" {
+		req.SourceTabletAlias, err = topoproto.ParseTabletAlias(restoreFromBackupOptions.SourceTablet)
+		if err != nil {
+			return err
+		}
+	}
+
 	cli.FinishedParsing(cmd)
 
 	stream, err := client.RestoreFromBackup(commandCtx, req)
@@ -278,29 +449,209 @@ func commandRestoreFromBackup(cmd *cobra.Command, args []string) error {
 	}
 }
 
+var restoreToPointInTimeOptions = struct {
+	TabletAlias        string
+	RestoreToPos       string
+	RestoreToTimestamp string
+	BinlogSourceTablet string
+	BinlogServer       string
+	DryRun             bool
+}{}
+
+func commandRestoreToPointInTime(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if restoreToPointInTimeOptions.RestoreToPos == "" && restoreToPointInTimeOptions.RestoreToTimestamp == "" {
+		return fmt.Errorf("one of --restore-to-pos or --restore-to-timestamp is required")
+	}
+	if restoreToPointInTimeOptions.RestoreToPos != "" && restoreToPointInTimeOptions.RestoreToTimestamp != "" {
+		return fmt.Errorf("--restore-to-pos and --restore-to-timestamp are mutually exclusive")
+	}
+	if restoreToPointInTimeOptions.BinlogSourceTablet != "" && restoreToPointInTimeOptions.BinlogServer != "" {
+		return fmt.Errorf("--binlog-source-tablet and --binlog-server are mutually exclusive")
+	}
+
+	req := &vtctldatapb.RestoreToPointInTimeRequest{
+		Keyspace:     keyspace,
+		Shard:        shard,
+		RestoreToPos: restoreToPointInTimeOptions.RestoreToPos,
+		BinlogServer: restoreToPointInTimeOptions.BinlogServer,
+		DryRun:       restoreToPointInTimeOptions.DryRun,
+	}
+
+	if restoreToPointInTimeOptions.RestoreToTimestamp != "" {
+		restoreToTimestamp, err := mysqlctl.ParseRFC3339(restoreToPointInTimeOptions.RestoreToTimestamp)
+		if err != nil {
+			return err
+		}
+
+		req.RestoreToTimestamp = protoutil.TimeToProto(restoreToTimestamp)
+	}
+
+	if restoreToPointInTimeOptions.TabletAlias != "" {
+		req.TabletAlias, err = topoproto.ParseTabletAlias(restoreToPointInTimeOptions.TabletAlias)
+		if err != nil {
+			return err
+		}
+	}
+
+	if restoreToPointInTimeOptions.BinlogSourceTablet != "" {
+		req.BinlogSourceTabletAlias, err = topoproto.ParseTabletAlias(restoreToPointInTimeOptions.BinlogSourceTablet)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	stream, err := client.RestoreToPointInTime(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch err {
+		case nil:
+			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+var validateBackupOptions = struct {
+	BackupTimestamp string
+	TabletAlias     string
+	ChecksumTables  bool
+	Concurrency     int64
+}{}
+
+func commandValidateBackup(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	req := &vtctldatapb.ValidateBackupRequest{
+		Keyspace:       keyspace,
+		Shard:          shard,
+		ChecksumTables: validateBackupOptions.ChecksumTables,
+		Concurrency:    validateBackupOptions.Concurrency,
+	}
+
+	if validateBackupOptions.BackupTimestamp != "" {
+		t, err := time.Parse(mysqlctl.BackupTimestampFormat, validateBackupOptions.BackupTimestamp)
+		if err != nil {
+			return err
+		}
+
+		req.BackupTime = protoutil.TimeToProto(t)
+	}
+
+	if validateBackupOptions.TabletAlias != "" {
+		req.TabletAlias, err = topoproto.ParseTabletAlias(validateBackupOptions.TabletAlias)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	stream, err := client.ValidateBackup(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	var validation *tabletmanagerdatapb.BackupValidation
+	for {
+		resp, err := stream.Recv()
+		switch err {
+		case nil:
+			if resp.Event != nil {
+				fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			}
+			if resp.Validation != nil {
+				validation = resp.Validation
+			}
+		case io.EOF:
+			if validation == nil {
+				return fmt.Errorf("no validation result received")
+			}
+
+			data, err := cli.MarshalOutput(validation)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", data)
+
+			if !validation.Valid {
+				return fmt.Errorf("backup %s is not valid", validation.BackupName)
+			}
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
 func init() {
 	Backup.Flags().BoolVar(&backupOptions.AllowPrimary, "allow-primary", false, "Allow the primary of a shard to be used for the backup. WARNING: If using the builtin backup engine, this will shutdown mysqld on the primary and stop writes for the duration of the backup.")
 	Backup.Flags().Uint64Var(&backupOptions.Concurrency, "concurrency", 4, "Specifies the number of compression/checksum jobs to run simultaneously.")
 	Backup.Flags().StringVar(&backupOptions.IncrementalFromPos, "incremental-from-pos", "", "Position of previous backup. Default: empty. If given, then this backup becomes an incremental backup from given position. If value is 'auto', backup taken from last successful backup position")
 
 	Backup.Flags().BoolVar(&backupOptions.UpgradeSafe, "upgrade-safe", false, "Whether to use innodb_fast_shutdown=0 for the backup so it is safe to use for MySQL upgrades.")
+	Backup.Flags().StringVar(&backupOptions.CompressionEngine, "compression-engine", "", "Compression engine to use for this backup. Defaults to the tablet's --compression-engine-name.")
+	Backup.Flags().Int64Var(&backupOptions.CompressionLevel, "compression-level", 0, "Compression level to use for this backup. Defaults to the tablet's --compression-level.")
+	Backup.Flags().StringVar(&backupOptions.CompressionDictionary, "compression-dictionary", "", "Path, on the tablet, of a pre-trained zstd dictionary to compress this backup with. Defaults to the tablet's --compression-zstd-dictionary.")
 	Root.AddCommand(Backup)
 
 	BackupShard.Flags().BoolVar(&backupShardOptions.AllowPrimary, "allow-primary", false, "Allow the primary of a shard to be used for the backup. WARNING: If using the builtin backup engine, this will shutdown mysqld on the primary and stop writes for the duration of the backup.")
 	BackupShard.Flags().Uint64Var(&backupShardOptions.Concurrency, "concurrency", 4, "Specifies the number of compression/checksum jobs to run simultaneously.")
 	BackupShard.Flags().StringVar(&backupShardOptions.IncrementalFromPos, "incremental-from-pos", "", "Position of previous backup. Default: empty. If given, then this backup becomes an incremental backup from given position. If value is 'auto', backup taken from last successful backup position")
 	BackupShard.Flags().BoolVar(&backupOptions.UpgradeSafe, "upgrade-safe", false, "Whether to use innodb_fast_shutdown=0 for the backup so it is safe to use for MySQL upgrades.")
+	BackupShard.Flags().StringVar(&backupShardOptions.CompressionEngine, "compression-engine", "", "Compression engine to use for this backup. Defaults to the tablet's --compression-engine-name.")
+	BackupShard.Flags().Int64Var(&backupShardOptions.CompressionLevel, "compression-level", 0, "Compression level to use for this backup. Defaults to the tablet's --compression-level.")
+	BackupShard.Flags().StringVar(&backupShardOptions.CompressionDictionary, "compression-dictionary", "", "Path, on the tablet, of a pre-trained zstd dictionary to compress this backup with. Defaults to the tablet's --compression-zstd-dictionary.")
 	Root.AddCommand(BackupShard)
 
 	GetBackups.Flags().Uint32VarP(&getBackupsOptions.Limit, "limit", "l", 0, "Retrieve only the most recent N backups.")
+	GetBackups.Flags().BoolVar(&getBackupsOptions.Detailed, "detailed", false, "Read the MANIFEST of the backups to list their status, size, duration, engine, compression and incremental chain.")
+	GetBackups.Flags().Uint32Var(&getBackupsOptions.DetailedLimit, "detailed-limit", 0, "With --detailed, only read the MANIFEST of the most recent N backups.")
 	GetBackups.Flags().BoolVarP(&getBackupsOptions.OutputJSON, "json", "j", false, "Output backup info in JSON format rather than a list of backups.")
 	Root.AddCommand(GetBackups)
 
+	PruneBackups.Flags().Uint32Var(&pruneBackupsOptions.KeepFull, "keep-full", 0, "Number of most recent complete full backups to keep. Must be at least 1.")
+	PruneBackups.Flags().Uint32Var(&pruneBackupsOptions.KeepDailyDays, "keep-daily-days", 0, "Also keep the most recent complete full backup of each of the last N days.")
+	PruneBackups.Flags().BoolVar(&pruneBackupsOptions.DryRun, "dry-run", false, "Only list the backups that would be removed, without removing them.")
+	PruneBackups.MarkFlagRequired("keep-full")
+	Root.AddCommand(PruneBackups)
+
 	Root.AddCommand(RemoveBackup)
 
 	RestoreFromBackup.Flags().StringVarP(&restoreFromBackupOptions.BackupTimestamp, "backup-timestamp", "t", "", "Use the backup taken at, or closest before, this timestamp. Omit to use the latest backup. Timestamp format is \"YYYY-mm-DD.HHMMSS\".")
 	RestoreFromBackup.Flags().StringVar(&restoreFromBackupOptions.RestoreToPos, "restore-to-pos", "", "Run a point in time recovery that ends with the given position. This will attempt to use one full backup followed by zero or more incremental backups")
 	RestoreFromBackup.Flags().StringVar(&restoreFromBackupOptions.RestoreToTimestamp, "restore-to-timestamp", "", "Run a point in time recovery that restores up to, and excluding, given timestamp in RFC3339 format (`2006-01-02T15:04:05Z07:00`). This will attempt to use one full backup followed by zero or more incremental backups")
+	RestoreFromBackup.Flags().StringVar(&restoreFromBackupOptions.SourceTablet, "source-tablet", "", "Alias of a tablet of the same shard that takes a backup and streams it directly to the restored tablet, instead of restoring a backup from the backup storage. It shuts down its mysqld like an offline backup.")
 	RestoreFromBackup.Flags().BoolVar(&restoreFromBackupOptions.DryRun, "dry-run", false, "Only validate restore steps, do not actually restore data")
 	Root.AddCommand(RestoreFromBackup)
+
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.TabletAlias, "tablet", "", "Alias of the tablet to restore. Omit to pick a non-primary tablet of the shard.")
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.RestoreToPos, "restore-to-pos", "", "Apply binary logs up to, and including, the given position.")
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.RestoreToTimestamp, "restore-to-timestamp", "", "Apply binary logs up to, and excluding, the given timestamp in RFC3339 format (`2006-01-02T15:04:05Z07:00`).")
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.BinlogSourceTablet, "binlog-source-tablet", "", "Alias of the tablet whose MySQL server, or binlog server if it runs one, the binary logs are read from. Omit to use a tablet of the shard running a binlog server, or else the shard primary.")
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.BinlogServer, "binlog-server", "", "Address (host:port) of a binlog server the binary logs are read from, instead of a tablet of the shard.")
+	RestoreToPointInTime.Flags().BoolVar(&restoreToPointInTimeOptions.DryRun, "dry-run", false, "Only validate restore steps, do not actually restore data")
+	Root.AddCommand(RestoreToPointInTime)
+
+	ValidateBackup.Flags().StringVarP(&validateBackupOptions.BackupTimestamp, "backup-timestamp", "t", "", "Validate the backup taken at, or closest before, this timestamp. Omit to validate the latest backup. Timestamp format is \"YYYY-mm-DD.HHMMSS\".")
+	ValidateBackup.Flags().StringVar(&validateBackupOptions.TabletAlias, "tablet", "", "Alias of the tablet that restores the backup. Omit to pick a non-primary tablet of the shard.")
+	ValidateBackup.Flags().BoolVar(&validateBackupOptions.ChecksumTables, "checksum-tables", false, "Run CHECKSUM TABLE on every table of the restored database, which reads all of its data.")
+	ValidateBackup.Flags().Int64Var(&validateBackupOptions.Concurrency, "concurrency", 0, "Number of files to restore in parallel. Defaults to the tablet's --restore_concurrency.")
+	Root.AddCommand(ValidateBackup)
 }
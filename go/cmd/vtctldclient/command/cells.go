@@ -19,10 +19,13 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -97,6 +100,25 @@ the same group (alias).`,
 		Args:                  cobra.NoArgs,
 		RunE:                  commandGetCellsAliases,
 	}
+	// RemoveCell makes a RemoveCell gRPC call to a vtctld.
+	RemoveCell = &cobra.Command{
+		Use:   "RemoveCell [--drain] [--dry-run] [--wait-replicas-timeout <duration>] <cell>",
+		Short: "Removes a cell, and all of its topology data.",
+		Long: `Removes a cell, and all of its topology data.
+
+The cell cannot be referenced by any tablet, SrvKeyspace, shard primary or
+cells alias, unless --drain is set. With --drain, the shards whose primary is
+in the cell are reparented to a replica in another cell, the records of the
+tablets of the cell are deleted, as well as its SrvKeyspaces and replication
+graphs, and the cell is removed from the cells aliases. The tablets of the
+cell should be shut down first.
+
+The records are removed one at a time, and the CellInfo last, so RemoveCell
+can be run again if it fails midway.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandRemoveCell,
+	}
 	// UpdateCellInfo makes an UpdateCellInfo gRPC call to a vtctld.
 	UpdateCellInfo = &cobra.Command{
 		Use:   "UpdateCellInfo [--root <root>] [--server-address <addr>] <cell>",
@@ -213,7 +235,7 @@ func commandGetCellInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.CellInfo)
+	data, err := cli.MarshalOutput(resp.CellInfo)
 	if err != nil {
 		return err
 	}
@@ -231,7 +253,7 @@ func commandGetCellsAliases(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Aliases)
+	data, err := cli.MarshalOutput(resp.Aliases)
 	if err != nil {
 		return err
 	}
@@ -241,6 +263,34 @@ func commandGetCellsAliases(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var removeCellOptions = struct {
+	Drain               bool
+	DryRun              bool
+	WaitReplicasTimeout time.Duration
+}{}
+
+func commandRemoveCell(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RemoveCell(commandCtx, &vtctldatapb.RemoveCellRequest{
+		Cell:                cmd.Flags().Arg(0),
+		Drain:               removeCellOptions.Drain,
+		DryRun:              removeCellOptions.DryRun,
+		WaitReplicasTimeout: protoutil.DurationToProto(removeCellOptions.WaitReplicasTimeout),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 var updateCellInfoOptions topodatapb.CellInfo
 
 func commandUpdateCellInfo(cmd *cobra.Command, args []string) error {
@@ -255,7 +305,7 @@ func commandUpdateCellInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.CellInfo)
+	data, err := cli.MarshalOutput(resp.CellInfo)
 	if err != nil {
 		return err
 	}
@@ -278,7 +328,7 @@ func commandUpdateCellsAlias(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.CellsAlias)
+	data, err := cli.MarshalOutput(resp.CellsAlias)
 	if err != nil {
 		return err
 	}
@@ -304,6 +354,11 @@ func init() {
 	Root.AddCommand(GetCellInfo)
 	Root.AddCommand(GetCellsAliases)
 
+	RemoveCell.Flags().BoolVar(&removeCellOptions.Drain, "drain", false, "Removes the records referencing the cell, reparenting the shards whose primary is in the cell to another cell.")
+	RemoveCell.Flags().BoolVar(&removeCellOptions.DryRun, "dry-run", false, "Only lists the records referencing the cell, and the actions removing it would take.")
+	RemoveCell.Flags().DurationVar(&removeCellOptions.WaitReplicasTimeout, "wait-replicas-timeout", topo.RemoteOperationTimeout, "Time to wait for replicas to catch up on replication when reparenting shards away from the cell.")
+	Root.AddCommand(RemoveCell)
+
 	UpdateCellInfo.Flags().StringVarP(&updateCellInfoOptions.ServerAddress, "server-address", "a", "", "The address the topology server will connect to for this cell.")
 	UpdateCellInfo.Flags().StringVarP(&updateCellInfoOptions.Root, "root", "r", "", "The root path the topology server will use for this cell.")
 	Root.AddCommand(UpdateCellInfo)
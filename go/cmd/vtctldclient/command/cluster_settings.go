@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// GetClusterSetting makes a GetClusterSetting gRPC call to a vtctld.
+	GetClusterSetting = &cobra.Command{
+		Use:   "GetClusterSetting [<name>]",
+		Short: "Lists the runtime settings of the cluster, or gets one of them.",
+		Long: `Lists the runtime settings of the cluster, with their type, their description and
+their value if they are set, or gets one of them.
+
+A setting which is not set has its default value, usually that of the flag it
+overrides.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MaximumNArgs(1),
+		RunE:                  commandGetClusterSetting,
+	}
+	// SetClusterSetting makes a SetClusterSetting gRPC call to a vtctld.
+	SetClusterSetting = &cobra.Command{
+		Use:   "SetClusterSetting {<name> <value> | --unset <name>}",
+		Short: "Sets or unsets a runtime setting of the cluster, which the vtgates and vttablets apply without a restart.",
+		Long: `Sets or unsets a runtime setting of the cluster, which the vtgates and vttablets
+apply without a restart.
+
+The value is checked against the type and the validation of the setting before
+it is stored in the global topo, which the vtgates and vttablets watch. With
+--unset, the setting gets its default value back.`,
+		Example: `SetClusterSetting vtgate.max_memory_rows 500000
+SetClusterSetting --unset vtgate.max_memory_rows`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.RangeArgs(1, 2),
+		RunE:                  commandSetClusterSetting,
+	}
+)
+
+func commandGetClusterSetting(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetClusterSetting(commandCtx, &vtctldatapb.GetClusterSettingRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	var obj any = resp.Settings
+	if cmd.Flags().NArg() == 1 && len(resp.Settings) == 1 {
+		obj = resp.Settings[0]
+	}
+	data, err := cli.MarshalOutput(obj)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var setClusterSettingOptions = struct {
+	Unset bool
+}{}
+
+func commandSetClusterSetting(cmd *cobra.Command, args []string) error {
+	req := &vtctldatapb.SetClusterSettingRequest{
+		Name:  cmd.Flags().Arg(0),
+		Unset: setClusterSettingOptions.Unset,
+	}
+	switch {
+	case req.Unset && cmd.Flags().NArg() != 1:
+		return fmt.Errorf("no value can be given with --unset")
+	case !req.Unset && cmd.Flags().NArg() != 2:
+		return fmt.Errorf("a value is required, or --unset")
+	case !req.Unset:
+		req.Value = cmd.Flags().Arg(1)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetClusterSetting(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp.Setting)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func init() {
+	Root.AddCommand(GetClusterSetting)
+
+	SetClusterSetting.Flags().BoolVar(&setClusterSettingOptions.Unset, "unset", false, "Unset the setting, so that it has its default value again.")
+	Root.AddCommand(SetClusterSetting)
+}
@@ -111,6 +111,25 @@ SetKeyspaceDurabilityPolicy --durability-policy='semi_sync' customer`,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandSetKeyspaceDurabilityPolicy,
 	}
+	// SetKeyspaceFailoverPreferences makes a SetKeyspaceFailoverPreferences gRPC call to a vtctld.
+	SetKeyspaceFailoverPreferences = &cobra.Command{
+		Use:   "SetKeyspaceFailoverPreferences [--prefer-same-cell] [--never-promote-cells=<cell1,cell2,...>] <keyspace name>",
+		Short: "Sets the preferences of the keyspace for the cells of the tablets which VTOrc promotes.",
+		Long: `Sets the preferences of the keyspace for the cells of the tablets which VTOrc promotes
+when it fails over the primary of one of its shards, replacing the previous ones.
+
+With --prefer-same-cell, VTOrc promotes a tablet in the cell of the failed primary,
+unless none of them can be promoted under the durability policy of the keyspace.
+The tablets of the --never-promote-cells are never promoted. Passing neither flag
+removes the preferences.
+
+To keep the failovers of the customer keyspace in the cell of their primary, and never
+promote the tablets of the dr cell, you would use the following command:
+SetKeyspaceFailoverPreferences --prefer-same-cell --never-promote-cells=dr customer`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetKeyspaceFailoverPreferences,
+	}
 	// ValidateSchemaKeyspace makes a ValidateSchemaKeyspace gRPC call to a vtctld.
 	ValidateSchemaKeyspace = &cobra.Command{
 		Use:                   "ValidateSchemaKeyspace [--exclude-tables=<exclude_tables>] [--include-views] [--skip-no-primary] [--include-vschema] <keyspace>",
@@ -220,7 +239,7 @@ func commandCreateKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Keyspace)
+	data, err := cli.MarshalOutput(resp.Keyspace)
 	if err != nil {
 		return err
 	}
@@ -266,7 +285,7 @@ func commandFindAllShardsInKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -286,7 +305,7 @@ func commandGetKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Keyspace)
+	data, err := cli.MarshalOutput(resp.Keyspace)
 	if err != nil {
 		return err
 	}
@@ -304,7 +323,7 @@ func commandGetKeyspaces(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Keyspaces)
+	data, err := cli.MarshalOutput(resp.Keyspaces)
 	if err != nil {
 		return err
 	}
@@ -357,7 +376,36 @@ func commandSetKeyspaceDurabilityPolicy(cmd *cobra.Command, args []string) error
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var setKeyspaceFailoverPreferencesOptions = struct {
+	PreferSameCell    bool
+	NeverPromoteCells []string
+}{}
+
+func commandSetKeyspaceFailoverPreferences(cmd *cobra.Command, args []string) error {
+	keyspace := cmd.Flags().Arg(0)
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetKeyspaceFailoverPreferences(commandCtx, &vtctldatapb.SetKeyspaceFailoverPreferencesRequest{
+		Keyspace: keyspace,
+		FailoverPreferences: &topodatapb.FailoverPreferences{
+			PreferSameCell:    setKeyspaceFailoverPreferencesOptions.PreferSameCell,
+			NeverPromoteCells: setKeyspaceFailoverPreferencesOptions.NeverPromoteCells,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -389,7 +437,7 @@ func commandValidateSchemaKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -410,7 +458,7 @@ func commandValidateVersionKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -445,6 +493,10 @@ func init() {
 	SetKeyspaceDurabilityPolicy.Flags().StringVar(&setKeyspaceDurabilityPolicyOptions.DurabilityPolicy, "durability-policy", "none", "Type of durability to enforce for this keyspace. Default is none. Other values include 'semi_sync' and others as dictated by registered plugins.")
 	Root.AddCommand(SetKeyspaceDurabilityPolicy)
 
+	SetKeyspaceFailoverPreferences.Flags().BoolVar(&setKeyspaceFailoverPreferencesOptions.PreferSameCell, "prefer-same-cell", false, "Promote a tablet in the cell of the failed primary, unless none of them can be promoted under the durability policy.")
+	SetKeyspaceFailoverPreferences.Flags().StringSliceVar(&setKeyspaceFailoverPreferencesOptions.NeverPromoteCells, "never-promote-cells", nil, "Cells whose tablets are never promoted.")
+	Root.AddCommand(SetKeyspaceFailoverPreferences)
+
 	ValidateSchemaKeyspace.Flags().BoolVar(&validateSchemaKeyspaceOptions.IncludeViews, "include-views", false, "Includes views in compared schemas.")
 	ValidateSchemaKeyspace.Flags().BoolVar(&validateSchemaKeyspaceOptions.IncludeVSchema, "include-vschema", false, "Includes VSchema validation in validation results.")
 	ValidateSchemaKeyspace.Flags().BoolVar(&validateSchemaKeyspaceOptions.SkipNoPrimary, "skip-no-primary", false, "Skips validation on whether or not a primary exists in shards.")
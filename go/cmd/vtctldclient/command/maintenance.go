@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// CancelMaintenance makes a CancelMaintenance gRPC call to a vtctld.
+	CancelMaintenance = &cobra.Command{
+		Use:   "CancelMaintenance <id>",
+		Short: "Cancels a scheduled maintenance.",
+		Long: `Cancels a maintenance scheduled with ScheduleMaintenance.
+
+A pending maintenance never starts. A running tablet drain ends early, and the
+tablet gets its previous type back right away. A running planned reparent cannot
+be cancelled.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandCancelMaintenance,
+	}
+	// GetMaintenances makes a GetMaintenances gRPC call to a vtctld.
+	GetMaintenances = &cobra.Command{
+		Use:   "GetMaintenances [--keyspace <keyspace> [--shard <shard>]] [--states <state>[,<state>...]]",
+		Short: "Lists the scheduled maintenances, by the start of their window.",
+		Long: `Lists the maintenances scheduled with ScheduleMaintenance, by the start of their
+window. The finished ones are listed until vtctld prunes them, after
+--maintenance_history_retention.`,
+		Example:               `GetMaintenances --keyspace commerce --states pending,running`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetMaintenances,
+	}
+	// ScheduleMaintenance makes a ScheduleMaintenance gRPC call to a vtctld.
+	ScheduleMaintenance = &cobra.Command{
+		Use:   "ScheduleMaintenance --action {planned-reparent|drain-tablet} [--tablet <alias>] [--start <time>] {--end <time> | --duration <duration>} <keyspace/shard>",
+		Short: "Schedules a planned reparent or a tablet drain of a shard during a window, which vtctld runs unattended.",
+		Long: `Schedules a planned reparent or a tablet drain of a shard during a window, which
+vtctld runs unattended, e.g. to rotate the primaries off-peak.
+
+A planned-reparent runs a PlannedReparentShard at the start of the window. It
+promotes --tablet if it is set, and otherwise the best replica other than the
+current primary.
+
+A drain-tablet changes the type of --tablet to DRAINED at the start of the
+window, and back to its previous type at the end of it.
+
+A maintenance whose window ends before any vtctld could start it is missed. The
+times are in RFC 3339 format.`,
+		Example: `ScheduleMaintenance --action planned-reparent --start 2023-12-01T03:00:00Z --duration 1h commerce/0
+ScheduleMaintenance --action drain-tablet --tablet zone1-101 --start 2023-12-01T03:00:00Z --end 2023-12-01T05:00:00Z commerce/0`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandScheduleMaintenance,
+	}
+)
+
+func commandCancelMaintenance(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.CancelMaintenance(commandCtx, &vtctldatapb.CancelMaintenanceRequest{
+		Id: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp.Maintenance)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var getMaintenancesOptions = struct {
+	Keyspace string
+	Shard    string
+	States   []string
+}{}
+
+func commandGetMaintenances(cmd *cobra.Command, args []string) error {
+	req := &vtctldatapb.GetMaintenancesRequest{
+		Keyspace: getMaintenancesOptions.Keyspace,
+		Shard:    getMaintenancesOptions.Shard,
+	}
+	for _, s := range getMaintenancesOptions.States {
+		state, ok := topodatapb.ScheduledMaintenance_State_value[strings.ToUpper(s)]
+		if !ok {
+			return fmt.Errorf("unknown maintenance state %q", s)
+		}
+		req.States = append(req.States, topodatapb.ScheduledMaintenance_State(state))
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetMaintenances(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp.Maintenances)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var scheduleMaintenanceOptions = struct {
+	Action         string
+	TabletAliasStr string
+	Start          string
+	End            string
+	Duration       time.Duration
+}{}
+
+func commandScheduleMaintenance(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	action, ok := topodatapb.ScheduledMaintenance_Action_value[strings.ToUpper(strings.ReplaceAll(scheduleMaintenanceOptions.Action, "-", "_"))]
+	if !ok {
+		return fmt.Errorf("unknown --action %q, expected planned-reparent or drain-tablet", scheduleMaintenanceOptions.Action)
+	}
+
+	req := &vtctldatapb.ScheduleMaintenanceRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Action:   topodatapb.ScheduledMaintenance_Action(action),
+	}
+
+	if scheduleMaintenanceOptions.TabletAliasStr != "" {
+		req.TabletAlias, err = topoproto.ParseTabletAlias(scheduleMaintenanceOptions.TabletAliasStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	if scheduleMaintenanceOptions.Start != "" {
+		start, err = time.Parse(time.RFC3339, scheduleMaintenanceOptions.Start)
+		if err != nil {
+			return fmt.Errorf("invalid --start: %w", err)
+		}
+	}
+	req.WindowStart = protoutil.TimeToProto(start)
+
+	switch {
+	case scheduleMaintenanceOptions.End != "" && scheduleMaintenanceOptions.Duration != 0:
+		return fmt.Errorf("only one of --end and --duration may be set")
+	case scheduleMaintenanceOptions.End != "":
+		end, err := time.Parse(time.RFC3339, scheduleMaintenanceOptions.End)
+		if err != nil {
+			return fmt.Errorf("invalid --end: %w", err)
+		}
+		req.WindowEnd = protoutil.TimeToProto(end)
+	case scheduleMaintenanceOptions.Duration > 0:
+		req.WindowEnd = protoutil.TimeToProto(start.Add(scheduleMaintenanceOptions.Duration))
+	default:
+		return fmt.Errorf("--end or a positive --duration is required")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ScheduleMaintenance(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp.Maintenance)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func init() {
+	Root.AddCommand(CancelMaintenance)
+
+	GetMaintenances.Flags().StringVar(&getMaintenancesOptions.Keyspace, "keyspace", "", "Only list the maintenances of this keyspace.")
+	GetMaintenances.Flags().StringVar(&getMaintenancesOptions.Shard, "shard", "", "Only list the maintenances of this shard of --keyspace.")
+	GetMaintenances.Flags().StringSliceVar(&getMaintenancesOptions.States, "states", nil, "Only list the maintenances in these states: pending, running, done, failed, cancelled or missed.")
+	Root.AddCommand(GetMaintenances)
+
+	ScheduleMaintenance.Flags().StringVar(&scheduleMaintenanceOptions.Action, "action", "", "Maintenance to run: planned-reparent or drain-tablet.")
+	ScheduleMaintenance.MarkFlagRequired("action")
+	ScheduleMaintenance.Flags().StringVar(&scheduleMaintenanceOptions.TabletAliasStr, "tablet", "", "Tablet to promote for a planned-reparent, which is optional, or to drain for a drain-tablet.")
+	ScheduleMaintenance.Flags().StringVar(&scheduleMaintenanceOptions.Start, "start", "", "Start of the window, which defaults to now.")
+	ScheduleMaintenance.Flags().StringVar(&scheduleMaintenanceOptions.End, "end", "", "End of the window.")
+	ScheduleMaintenance.Flags().DurationVar(&scheduleMaintenanceOptions.Duration, "duration", 0, "Duration of the window, instead of --end.")
+	Root.AddCommand(ScheduleMaintenance)
+}
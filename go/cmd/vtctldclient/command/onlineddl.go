@@ -16,6 +16,7 @@ limitations under the License.
 package command
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -112,7 +113,8 @@ OnlineDDL show --limit 10 test_keyspace all
 OnlineDDL show --skip 5 --limit 10 test_keyspace all
 OnlineDDL show test_keyspace running
 OnlineDDL show test_keyspace complete
-OnlineDDL show test_keyspace failed`,
+OnlineDDL show test_keyspace failed
+OnlineDDL show --watch --watch-interval 30s test_keyspace 82fa54ac_e83e_11ea_96b7_f875a4d24e90`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.RangeArgs(1, 2),
 		RunE:                  commandOnlineDDLShow,
@@ -149,7 +151,7 @@ func commandOnlineDDLCancel(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -175,7 +177,7 @@ func commandOnlineDDLCleanup(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -199,7 +201,7 @@ func commandOnlineDDLComplete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -223,7 +225,7 @@ func commandOnlineDDLLaunch(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -249,7 +251,7 @@ func commandOnlineDDLRetry(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -293,7 +295,7 @@ func throttleCommandHelper(cmd *cobra.Command, throttleType bool) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -321,6 +323,7 @@ var onlineDDLShowArgs = struct {
 	OrderStr string
 	Limit    uint64
 	Skip     uint64
+	Watch    cli.WatchOptions
 }{
 	OrderStr: "ascending",
 }
@@ -362,27 +365,42 @@ func commandOnlineDDLShow(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	resp, err := client.GetSchemaMigrations(commandCtx, req)
-	if err != nil {
-		return err
-	}
-
-	switch {
-	case onlineDDLShowArgs.JSON:
-		data, err := cli.MarshalJSON(resp)
+	return cli.Watch(commandCtx, onlineDDLShowArgs.Watch, func(ctx context.Context) (bool, error) {
+		resp, err := client.GetSchemaMigrations(ctx, req)
 		if err != nil {
-			return err
+			return false, err
 		}
-		fmt.Printf("%s\n", data)
-	default:
-		res, err := sqltypes.MarshalResult(schematools.MarshallableSchemaMigrations(resp.Migrations))
-		if err != nil {
-			return err
+
+		switch {
+		case onlineDDLShowArgs.JSON:
+			data, err := cli.MarshalOutput(resp)
+			if err != nil {
+				return false, err
+			}
+			fmt.Printf("%s\n", data)
+		default:
+			res, err := sqltypes.MarshalResult(schematools.MarshallableSchemaMigrations(resp.Migrations))
+			if err != nil {
+				return false, err
+			}
+
+			cli.WriteQueryResultTable(os.Stdout, res)
 		}
+		return schemaMigrationsConcluded(resp.Migrations), nil
+	})
+}
 
-		cli.WriteQueryResultTable(os.Stdout, res)
+// schemaMigrationsConcluded returns true if all the migrations are complete,
+// failed or cancelled.
+func schemaMigrationsConcluded(migrations []*vtctldatapb.SchemaMigration) bool {
+	for _, migration := range migrations {
+		switch migration.Status {
+		case vtctldatapb.SchemaMigration_COMPLETE, vtctldatapb.SchemaMigration_FAILED, vtctldatapb.SchemaMigration_CANCELLED:
+		default:
+			return false
+		}
 	}
-	return nil
+	return true
 }
 
 func init() {
@@ -398,6 +416,7 @@ func init() {
 	OnlineDDLShow.Flags().StringVar(&onlineDDLShowArgs.OrderStr, "order", "asc", "Sort the results by `id` property of the Schema migration.")
 	OnlineDDLShow.Flags().Uint64Var(&onlineDDLShowArgs.Limit, "limit", 0, "Limit number of rows returned in output.")
 	OnlineDDLShow.Flags().Uint64Var(&onlineDDLShowArgs.Skip, "skip", 0, "Skip specified number of rows returned in output.")
+	cli.AddWatchFlags(OnlineDDLShow, &onlineDDLShowArgs.Watch, "all the migrations are complete, failed or cancelled")
 
 	OnlineDDL.AddCommand(OnlineDDLShow)
 	Root.AddCommand(OnlineDDL)
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/plugin"
+)
+
+// RegisterPlugins adds a command to Root for each plugin in the PATH, which
+// is an executable named vtctldclient-<name>, unless vtctldclient already has
+// a <name> command. The first plugin with a name in the PATH is used, like
+// for the executables run by a shell.
+//
+// It is not called by init, so that the documentation generated from Root
+// doesn't depend on the PATH.
+func RegisterPlugins() {
+	for name, file := range findPlugins(filepath.SplitList(os.Getenv("PATH"))) {
+		if hasCommand(Root, name) {
+			continue
+		}
+		Root.AddCommand(newPluginCommand(name, file))
+	}
+}
+
+// findPlugins returns the executables of the plugins in the given
+// directories, by the name of their command.
+func findPlugins(dirs []string) map[string]string {
+	plugins := map[string]string{}
+	for _, dir := range dirs {
+		if dir == "" {
+			// Unlike a shell, don't look for plugins in the working
+			// directory.
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), plugin.Prefix)
+			if !ok || name == "" {
+				continue
+			}
+			if _, ok := plugins[name]; ok {
+				continue
+			}
+
+			file := filepath.Join(dir, entry.Name())
+			info, err := os.Stat(file)
+			if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+				continue
+			}
+			plugins[name] = file
+		}
+	}
+	return plugins
+}
+
+func hasCommand(cmd *cobra.Command, name string) bool {
+	for _, c := range cmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func newPluginCommand(name, file string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name,
+		Short: fmt.Sprintf("Runs the %s plugin.", filepath.Base(file)),
+		Long: fmt.Sprintf(`Runs the %s plugin, with the arguments after %s.
+
+The flags of vtctldclient must come before %s, and are passed to the plugin in
+its environment.`, file, name, name),
+		DisableFlagParsing: true,
+		// The plugin connects to the vtctld itself.
+		Annotations: map[string]string{
+			skipClientCreationKey: "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(cmd, file, args)
+		},
+	}
+}
+
+// runPlugin runs the executable of a plugin, with the flags of vtctldclient
+// in its environment as documented in the plugin package.
+func runPlugin(cmd *cobra.Command, file string, args []string) error {
+	var flags []string
+	cmd.Root().PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			for _, item := range v.GetSlice() {
+				flags = append(flags, fmt.Sprintf("--%s=%s", f.Name, item))
+			}
+			return
+		}
+		flags = append(flags, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+	data, err := json.Marshal(flags)
+	if err != nil {
+		return err
+	}
+
+	c := exec.CommandContext(cmd.Context(), file, args...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	c.Env = append(os.Environ(),
+		plugin.ServerEnv+"="+server,
+		plugin.FormatEnv+"="+cli.Format.String(),
+		plugin.FlagsEnv+"="+string(data),
+	)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("plugin %s failed: %w", filepath.Base(file), err)
+	}
+	return nil
+}
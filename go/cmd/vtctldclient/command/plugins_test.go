@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPlugins(t *testing.T) {
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	write := func(dir, name string, perm os.FileMode) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), perm))
+	}
+	write(dir1, "vtctldclient-foo", 0o755)
+	write(dir1, "vtctldclient-noexec", 0o644)
+	write(dir1, "vtctldclient-", 0o755)
+	write(dir1, "vtctl-bar", 0o755)
+	write(dir2, "vtctldclient-foo", 0o755)
+	write(dir2, "vtctldclient-bar-baz", 0o755)
+	require.NoError(t, os.Mkdir(filepath.Join(dir2, "vtctldclient-dir"), 0o755))
+
+	plugins := findPlugins([]string{"", dir1, filepath.Join(dir1, "missing"), dir2})
+	assert.Equal(t, map[string]string{
+		"foo":     filepath.Join(dir1, "vtctldclient-foo"),
+		"bar-baz": filepath.Join(dir2, "vtctldclient-bar-baz"),
+	}, plugins)
+
+	assert.True(t, hasCommand(Root, "GetKeyspaces"))
+	assert.False(t, hasCommand(Root, "foo"))
+}
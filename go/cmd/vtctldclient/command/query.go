@@ -77,16 +77,16 @@ func commandExecuteFetchAsApp(cmd *cobra.Command, args []string) error {
 	}
 
 	qr := sqltypes.Proto3ToResult(resp.Result)
-	switch executeFetchAsAppOptions.JSON {
-	case true:
-		data, err := cli.MarshalJSON(qr)
+	switch {
+	case cli.Format == cli.OutputFormatTable, cli.Format == cli.OutputFormatText && !executeFetchAsAppOptions.JSON:
+		cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
+	default:
+		data, err := cli.MarshalOutput(qr)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("%s\n", data)
-	default:
-		cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
 	}
 
 	return nil
@@ -123,16 +123,16 @@ func commandExecuteFetchAsDBA(cmd *cobra.Command, args []string) error {
 	}
 
 	qr := sqltypes.Proto3ToResult(resp.Result)
-	switch executeFetchAsDBAOptions.JSON {
-	case true:
-		data, err := cli.MarshalJSON(qr)
+	switch {
+	case cli.Format == cli.OutputFormatTable, cli.Format == cli.OutputFormatText && !executeFetchAsDBAOptions.JSON:
+		cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
+	default:
+		data, err := cli.MarshalOutput(qr)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("%s\n", data)
-	default:
-		cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
 	}
 
 	return nil
@@ -95,8 +95,38 @@ var emergencyReparentShardOptions = struct {
 	IgnoreReplicaAliasStrList []string
 	PreventCrossCellPromotion bool
 	WaitForAllTablets         bool
+	reparentPreferenceOptions
 }{}
 
+// reparentPreferenceOptions are the preferences of PlannedReparentShard and
+// EmergencyReparentShard for the tablet to promote.
+type reparentPreferenceOptions struct {
+	PreferCell           string
+	PreferTabletTags     cli.StringMapValue
+	AvoidTabletAliasStrs []string
+}
+
+func addReparentPreferenceFlags(cmd *cobra.Command, opts *reparentPreferenceOptions) {
+	cmd.Flags().StringVar(&opts.PreferCell, "prefer-cell", "", "Cell of the tablets to promote in priority, if --new-primary is not specified.")
+	cmd.Flags().Var(&opts.PreferTabletTags, "prefer-tablet-tags", "Comma-separated list of key:value tablet tags which the tablets to promote in priority all have, after the ones in --prefer-cell, if --new-primary is not specified.")
+	cmd.Flags().StringSliceVar(&opts.AvoidTabletAliasStrs, "avoid-tablets", nil, "Comma-separated, repeated list of aliases of tablets never to promote.")
+}
+
+// avoidTablets parses the aliases of the tablets to avoid.
+func (opts *reparentPreferenceOptions) avoidTablets() ([]*topodatapb.TabletAlias, error) {
+	aliases := make([]*topodatapb.TabletAlias, 0, len(opts.AvoidTabletAliasStrs))
+	for _, aliasStr := range opts.AvoidTabletAliasStrs {
+		alias, err := topoproto.ParseTabletAlias(aliasStr)
+		if err != nil {
+			return nil, err
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, nil
+}
+
 func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
 	if err != nil {
@@ -124,6 +154,11 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 		ignoreReplicaAliases[i] = alias
 	}
 
+	avoidTablets, err := emergencyReparentShardOptions.avoidTablets()
+	if err != nil {
+		return err
+	}
+
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.EmergencyReparentShard(commandCtx, &vtctldatapb.EmergencyReparentShardRequest{
@@ -134,6 +169,9 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 		WaitReplicasTimeout:       protoutil.DurationToProto(emergencyReparentShardOptions.WaitReplicasTimeout),
 		PreventCrossCellPromotion: emergencyReparentShardOptions.PreventCrossCellPromotion,
 		WaitForAllTablets:         emergencyReparentShardOptions.WaitForAllTablets,
+		PreferCell:                emergencyReparentShardOptions.PreferCell,
+		PreferTabletTags:          emergencyReparentShardOptions.PreferTabletTags.StringMapValue,
+		AvoidTablets:              avoidTablets,
 	})
 	if err != nil {
 		return err
@@ -186,6 +224,7 @@ var plannedReparentShardOptions = struct {
 	NewPrimaryAliasStr   string
 	AvoidPrimaryAliasStr string
 	WaitReplicasTimeout  time.Duration
+	reparentPreferenceOptions
 }{}
 
 func commandPlannedReparentShard(cmd *cobra.Command, args []string) error {
@@ -213,6 +252,11 @@ func commandPlannedReparentShard(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	avoidTablets, err := plannedReparentShardOptions.avoidTablets()
+	if err != nil {
+		return err
+	}
+
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.PlannedReparentShard(commandCtx, &vtctldatapb.PlannedReparentShardRequest{
@@ -221,6 +265,9 @@ func commandPlannedReparentShard(cmd *cobra.Command, args []string) error {
 		NewPrimary:          newPrimaryAlias,
 		AvoidPrimary:        avoidPrimaryAlias,
 		WaitReplicasTimeout: protoutil.DurationToProto(plannedReparentShardOptions.WaitReplicasTimeout),
+		PreferCell:          plannedReparentShardOptions.PreferCell,
+		PreferTabletTags:    plannedReparentShardOptions.PreferTabletTags.StringMapValue,
+		AvoidTablets:        avoidTablets,
 	})
 	if err != nil {
 		return err
@@ -246,7 +293,7 @@ func commandReparentTablet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -269,7 +316,7 @@ func commandTabletExternallyReparented(cmd *cobra.Command, args []string) error
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -285,6 +332,7 @@ func init() {
 	EmergencyReparentShard.Flags().BoolVar(&emergencyReparentShardOptions.PreventCrossCellPromotion, "prevent-cross-cell-promotion", false, "Only promotes a new primary from the same cell as the previous primary.")
 	EmergencyReparentShard.Flags().BoolVar(&emergencyReparentShardOptions.WaitForAllTablets, "wait-for-all-tablets", false, "Should ERS wait for all the tablets to respond. Useful when all the tablets are reachable.")
 	EmergencyReparentShard.Flags().StringSliceVarP(&emergencyReparentShardOptions.IgnoreReplicaAliasStrList, "ignore-replicas", "i", nil, "Comma-separated, repeated list of replica tablet aliases to ignore during the emergency reparent.")
+	addReparentPreferenceFlags(EmergencyReparentShard, &emergencyReparentShardOptions.reparentPreferenceOptions)
 	Root.AddCommand(EmergencyReparentShard)
 
 	InitShardPrimary.Flags().DurationVar(&initShardPrimaryOptions.WaitReplicasTimeout, "wait-replicas-timeout", 30*time.Second, "Time to wait for replicas to catch up in reparenting.")
@@ -294,6 +342,7 @@ func init() {
 	PlannedReparentShard.Flags().DurationVar(&plannedReparentShardOptions.WaitReplicasTimeout, "wait-replicas-timeout", topo.RemoteOperationTimeout, "Time to wait for replicas to catch up on replication both before and after reparenting.")
 	PlannedReparentShard.Flags().StringVar(&plannedReparentShardOptions.NewPrimaryAliasStr, "new-primary", "", "Alias of a tablet that should be the new primary.")
 	PlannedReparentShard.Flags().StringVar(&plannedReparentShardOptions.AvoidPrimaryAliasStr, "avoid-primary", "", "Alias of a tablet that should not be the primary; i.e. \"reparent to any other tablet if this one is the primary\".")
+	addReparentPreferenceFlags(PlannedReparentShard, &plannedReparentShardOptions.reparentPreferenceOptions)
 	Root.AddCommand(PlannedReparentShard)
 
 	Root.AddCommand(ReparentTablet)
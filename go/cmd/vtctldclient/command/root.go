@@ -34,6 +34,7 @@ import (
 	// These imports ensure init()s within them get called and they register their commands/subcommands.
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	vreplcommon "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/delayedreplica"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/lookupvindex"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/materialize"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/migrate"
@@ -84,7 +85,8 @@ var (
 		// PersistentPreRun.
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) (err error) {
 			commandCancel()
-			if client != nil {
+			// The client of the shell is kept open for its next commands.
+			if client != nil && client != shellClient {
 				err = client.Close()
 			}
 			trace.LogErrorsWhenClosing(traceCloser)
@@ -148,6 +150,10 @@ func getClientForCommand(cmd *cobra.Command) (vtctldclient.VtctldClient, error)
 		return nil, nil
 	}
 
+	if shellClient != nil {
+		return shellClient, nil
+	}
+
 	if VtctldClientProtocol != "local" && server == "" {
 		return nil, errNoServer
 	}
@@ -159,5 +165,6 @@ func init() {
 	Root.PersistentFlags().StringVar(&server, "server", "", "server to use for the connection (required)")
 	Root.PersistentFlags().DurationVar(&actionTimeout, "action_timeout", time.Hour, "timeout to use for the command")
 	Root.PersistentFlags().BoolVar(&compactOutput, "compact", false, "use compact format for otherwise verbose outputs")
+	Root.PersistentFlags().Var(&cli.Format, "format", "output format of the commands: text (the default output of each command), json, yaml or table")
 	vreplcommon.RegisterCommands(Root)
 }
@@ -87,7 +87,7 @@ func commandApplyRoutingRules(cmd *cobra.Command, args []string) error {
 	}
 
 	// Round-trip so when we display the result it's readable.
-	data, err := cli.MarshalJSON(rr)
+	data, err := cli.MarshalOutput(rr)
 	if err != nil {
 		return err
 	}
@@ -135,7 +135,7 @@ func commandGetRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.RoutingRules)
+	data, err := cli.MarshalOutput(resp.RoutingRules)
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -33,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 )
@@ -40,7 +42,7 @@ import (
 var (
 	// ApplySchema makes an ApplySchema gRPC call to a vtctld.
 	ApplySchema = &cobra.Command{
-		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] {--sql-file <file> | --sql <sql>} <keyspace>",
+		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] [--preflight] {--sql-file <file> | --sql <sql>} <keyspace>",
 		Short: "Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.",
 		Long: `Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.
 
@@ -48,6 +50,9 @@ If --allow-long-unavailability is set, schema changes affecting a large number o
 --ddl-strategy is used to instruct migrations via vreplication, gh-ost or pt-osc with optional parameters.
 --migration-context allows the user to specify a custom migration context for online DDL migrations.
 If --skip-preflight, SQL goes directly to shards without going through sanity checks.
+If --preflight, nothing is applied: instead, the impact of each statement on the schema of the primary of each shard is reported,
+with the canonical DDL it amounts to, the size of the table, whether it can be applied with ALGORITHM=INSTANT,
+the estimated duration of a vitess migration, and the ways in which it may lose data.
 
 The --uuid and --sql flags are repeatable, so they can be passed multiple times to build a list of values.
 For --uuid, this is used like "--uuid $first_uuid --uuid $second_uuid".
@@ -69,10 +74,10 @@ For --sql, semi-colons and repeated values may be mixed, for example:
 	}
 	// ReloadSchema makes a ReloadSchema gRPC call to a vtctld.
 	ReloadSchema = &cobra.Command{
-		Use:                   "ReloadSchema <tablet_alias>",
+		Use:                   "ReloadSchema {<tablet_alias> | [--cell <cell> ...] [--keyspace <keyspace> [--shard <shard>]] [--tablet-type <type>] [--concurrency <n>]}",
 		Short:                 "Reloads the schema on a remote tablet.",
 		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(1),
+		Args:                  cobra.MaximumNArgs(1),
 		RunE:                  commandReloadSchema,
 	}
 	// ReloadSchemaKeyspace makes a ReloadSchemaKeyspace gRPC call to a vtctld.
@@ -104,6 +109,7 @@ var applySchemaOptions = struct {
 	SkipPreflight           bool
 	CallerID                string
 	BatchSize               int64
+	Preflight               bool
 }{}
 
 func commandApplySchema(cmd *cobra.Command, args []string) error {
@@ -146,11 +152,22 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 		WaitReplicasTimeout: protoutil.DurationToProto(applySchemaOptions.WaitReplicasTimeout),
 		CallerId:            cid,
 		BatchSize:           applySchemaOptions.BatchSize,
+		Preflight:           applySchemaOptions.Preflight,
 	})
 	if err != nil {
 		return err
 	}
 
+	if applySchemaOptions.Preflight {
+		data, err := cli.MarshalOutput(resp)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+
 	fmt.Println(strings.Join(resp.UuidList, "\n"))
 	return nil
 }
@@ -201,7 +218,7 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	data, err := cli.MarshalJSON(resp.Schema)
+	data, err := cli.MarshalOutput(resp.Schema)
 	if err != nil {
 		return err
 	}
@@ -211,22 +228,33 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var reloadSchemaOptions tabletSelectorOptions
+
 func commandReloadSchema(cmd *cobra.Command, args []string) error {
-	tabletAlias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
-	if err != nil {
+	reload := func(ctx context.Context, alias *topodatapb.TabletAlias) error {
+		_, err := client.ReloadSchema(ctx, &vtctldatapb.ReloadSchemaRequest{
+			TabletAlias: alias,
+		})
 		return err
 	}
 
-	cli.FinishedParsing(cmd)
+	if tabletsSelected(cmd) {
+		if cmd.Flags().NArg() > 0 {
+			return fmt.Errorf("a tablet alias cannot be passed with the selector flags")
+		}
 
-	_, err = client.ReloadSchema(commandCtx, &vtctldatapb.ReloadSchemaRequest{
-		TabletAlias: tabletAlias,
-	})
+		cli.FinishedParsing(cmd)
+		return runOnSelectedTablets(commandCtx, &reloadSchemaOptions, reload)
+	}
+
+	tabletAlias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
 		return err
 	}
 
-	return nil
+	cli.FinishedParsing(cmd)
+
+	return reload(commandCtx, tabletAlias)
 }
 
 var reloadSchemaKeyspaceOptions = struct {
@@ -296,6 +324,7 @@ func init() {
 	ApplySchema.Flags().StringArrayVar(&applySchemaOptions.SQL, "sql", nil, "Semicolon-delimited, repeatable SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().StringVar(&applySchemaOptions.SQLFile, "sql-file", "", "Path to a file containing semicolon-delimited SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().Int64Var(&applySchemaOptions.BatchSize, "batch-size", 0, "How many queries to batch together. Only applicable when all queries are CREATE TABLE|VIEW")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.Preflight, "preflight", false, "Reports the impact of the schema change on every shard instead of applying it.")
 
 	Root.AddCommand(ApplySchema)
 
@@ -308,6 +337,7 @@ func init() {
 
 	Root.AddCommand(GetSchema)
 
+	addTabletSelectorFlags(ReloadSchema, &reloadSchemaOptions)
 	Root.AddCommand(ReloadSchema)
 
 	ReloadSchemaKeyspace.Flags().Uint32Var(&reloadSchemaKeyspaceOptions.Concurrency, "concurrency", 10, "Number of tablets to reload in parallel. Set to zero for unbounded concurrency.")
@@ -107,7 +107,7 @@ func commandGetSrvKeyspaceNames(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Names)
+	data, err := cli.MarshalOutput(resp.Names)
 	if err != nil {
 		return err
 	}
@@ -130,7 +130,7 @@ func commandGetSrvKeyspaces(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.SrvKeyspaces)
+	data, err := cli.MarshalOutput(resp.SrvKeyspaces)
 	if err != nil {
 		return err
 	}
@@ -152,7 +152,7 @@ func commandGetSrvVSchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.SrvVSchema)
+	data, err := cli.MarshalOutput(resp.SrvVSchema)
 	if err != nil {
 		return err
 	}
@@ -178,7 +178,7 @@ func commandGetSrvVSchemas(cmd *cobra.Command, args []string) error {
 	data := []byte("[]")
 
 	if len(resp.SrvVSchemas) > 0 {
-		data, err = cli.MarshalJSON(resp.SrvVSchemas)
+		data, err = cli.MarshalOutput(resp.SrvVSchemas)
 		if err != nil {
 			return err
 		}
@@ -91,7 +91,7 @@ func commandApplyShardRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	// Round-trip so when we display the result it's readable.
-	data, err := cli.MarshalJSON(srr)
+	data, err := cli.MarshalOutput(srr)
 	if err != nil {
 		return err
 	}
@@ -139,7 +139,7 @@ func commandGetShardRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.ShardRoutingRules)
+	data, err := cli.MarshalOutput(resp.ShardRoutingRules)
 	if err != nil {
 		return err
 	}
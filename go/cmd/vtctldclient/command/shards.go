@@ -17,8 +17,14 @@ limitations under the License.
 package command
 
 import (
+	"bufio"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/spf13/cobra"
 
@@ -54,33 +60,23 @@ that shard.`,
 		RunE:                  commandDeleteShards,
 	}
 	// GenerateShardRanges outputs a set of shard ranges assuming a (mostly)
-	// equal distribution of N shards.
+	// equal distribution of N shards, or the distribution of a histogram.
 	GenerateShardRanges = &cobra.Command{
-		Use:                   "GenerateShardRanges <num_shards>",
-		Short:                 "Print a set of shard ranges assuming a keyspace with N shards.",
+		Use:   "GenerateShardRanges [--histogram <file>] [--key-bytes <bytes>] [--reshard-format] <num_shards>",
+		Short: "Print a set of shard ranges assuming a keyspace with N shards.",
+		Long: `Print a set of shard ranges assuming a keyspace with N shards.
+
+By default, the ranges split the keyspace ids evenly. With --histogram, they
+split the weight of the rows of the keyspace as evenly as possible instead, e.g.
+their count, their size or their QPS. The histogram file, or - for stdin, has a
+keyspace id in hex and its weight on each line, separated by spaces, tabs or a
+comma, like the output of a sample query run with mysql -N. The weight defaults
+to 1, and the lines starting with # are ignored.`,
+		Example: `GenerateShardRanges 4
+GenerateShardRanges --histogram histogram.tsv --reshard-format 4`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			n, err := strconv.Atoi(cmd.Flags().Arg(0))
-			if err != nil {
-				return err
-			}
-
-			cli.FinishedParsing(cmd)
-
-			shards, err := key.GenerateShardRanges(n)
-			if err != nil {
-				return err
-			}
-
-			data, err := cli.MarshalJSON(shards)
-			if err != nil {
-				return err
-			}
-
-			fmt.Printf("%s\n", data)
-			return nil
-		},
+		RunE:                  commandGenerateShardRanges,
 		Annotations: map[string]string{
 			skipClientCreationKey: "true",
 		},
@@ -220,7 +216,7 @@ func commandCreateShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -260,6 +256,103 @@ func commandDeleteShards(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var generateShardRangesOptions = struct {
+	Histogram     string
+	KeyBytes      int
+	ReshardFormat bool
+}{}
+
+func commandGenerateShardRanges(cmd *cobra.Command, args []string) error {
+	n, err := strconv.Atoi(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var histogram []key.KeyspaceIDWeight
+	if generateShardRangesOptions.Histogram != "" {
+		histogram, err = readKeyspaceIDHistogram(generateShardRangesOptions.Histogram)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var shards []string
+	if histogram != nil {
+		shards, err = key.GenerateWeightedShardRanges(n, histogram, generateShardRangesOptions.KeyBytes)
+	} else {
+		shards, err = key.GenerateShardRanges(n)
+	}
+	if err != nil {
+		return err
+	}
+
+	if generateShardRangesOptions.ReshardFormat {
+		fmt.Println(strings.Join(shards, ","))
+		return nil
+	}
+
+	data, err := cli.MarshalOutput(shards)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+// readKeyspaceIDHistogram reads the histogram of GenerateShardRanges from a
+// file, or from stdin for -.
+func readKeyspaceIDHistogram(file string) ([]key.KeyspaceIDWeight, error) {
+	r := io.Reader(os.Stdin)
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var histogram []key.KeyspaceIDWeight
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("%s:%d: expected a keyspace id and a weight, got %q", file, lineno, line)
+		}
+
+		ksid, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(fields[0]), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid keyspace id %q: %w", file, lineno, fields[0], err)
+		}
+		bucket := key.KeyspaceIDWeight{KeyspaceID: ksid, Weight: 1}
+		if len(fields) == 2 {
+			bucket.Weight, err = strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid weight %q: %w", file, lineno, fields[1], err)
+			}
+		}
+		histogram = append(histogram, bucket)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(histogram) == 0 {
+		return nil, fmt.Errorf("the histogram %s is empty", file)
+	}
+
+	return histogram, nil
+}
+
 func commandGetShard(cmd *cobra.Command, args []string) error {
 	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
 	if err != nil {
@@ -276,7 +369,7 @@ func commandGetShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Shard)
+	data, err := cli.MarshalOutput(resp.Shard)
 	if err != nil {
 		return err
 	}
@@ -340,7 +433,7 @@ func commandSetShardIsPrimaryServing(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Shard)
+	data, err := cli.MarshalOutput(resp.Shard)
 	if err != nil {
 		return err
 	}
@@ -382,7 +475,7 @@ func commandSetShardTabletControl(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Shard)
+	data, err := cli.MarshalOutput(resp.Shard)
 	if err != nil {
 		return err
 	}
@@ -541,7 +634,7 @@ func commandSourceShardAdd(cmd *cobra.Command, args []string) error {
 	case nil:
 		fmt.Printf("SourceShard with uid %v already exists for %s/%s, not adding it.\n", uid, ks, shard)
 	default:
-		data, err := cli.MarshalJSON(resp.Shard)
+		data, err := cli.MarshalOutput(resp.Shard)
 		if err != nil {
 			return err
 		}
@@ -578,7 +671,7 @@ func commandSourceShardDelete(cmd *cobra.Command, args []string) error {
 	case nil:
 		fmt.Printf("No SourceShard with uid %v.\n", uid)
 	default:
-		data, err := cli.MarshalJSON(resp.Shard)
+		data, err := cli.MarshalOutput(resp.Shard)
 		if err != nil {
 			return err
 		}
@@ -604,7 +697,7 @@ func commandValidateVersionShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -624,6 +717,9 @@ func init() {
 	Root.AddCommand(DeleteShards)
 
 	Root.AddCommand(GetShard)
+	GenerateShardRanges.Flags().StringVar(&generateShardRangesOptions.Histogram, "histogram", "", "File with a histogram of the keyspace ids, whose weight the ranges split evenly, or - for stdin.")
+	GenerateShardRanges.Flags().IntVar(&generateShardRangesOptions.KeyBytes, "key-bytes", 2, "Length in bytes of the bounds of the ranges generated from --histogram.")
+	GenerateShardRanges.Flags().BoolVar(&generateShardRangesOptions.ReshardFormat, "reshard-format", false, "Print the ranges as the comma-separated list of the --target-shards of Reshard.")
 	Root.AddCommand(GenerateShardRanges)
 
 	RemoveShardCell.Flags().BoolVarP(&removeShardCellOptions.Force, "force", "f", false, "Proceed even if the cell's topology server cannot be reached. The assumption is that you turned down the entire cell, and just need to update the global topo data.")
@@ -0,0 +1,622 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// Shell runs an interactive shell of vtctldclient commands.
+	Shell = &cobra.Command{
+		Use:   "shell [--history-file <file>]",
+		Short: "Runs an interactive shell of vtctldclient commands, over a single connection to the vtctld.",
+		Long: `Runs an interactive shell of vtctldclient commands, over a single connection to the vtctld.
+
+Each line is a command, with its flags and arguments, as they would follow
+"vtctldclient" on the command line. The flags set before "shell", such as
+--server, --action_timeout or --format, apply to all the commands, which can
+set them again for themselves.
+
+Tab completes the commands and their flags, then the keyspaces, shards, cells
+and tablet aliases, which are fetched from the vtctld. The up and down arrows
+browse the history of the commands, which is kept in --history-file.
+
+A command continues on the next lines while a quote is left open, or if the line
+ends with a backslash. ApplySchema without --sql or --sql-file reads the SQL of
+the schema change on the next lines, until an empty line.
+
+"history" lists the history of the commands, and "exit", "quit" or Ctrl-D end
+the shell. Ctrl-C cancels the running command. If the input is not a terminal,
+the commands are read from it, one per line.`,
+		Example: `vtctldclient --server localhost:15999 shell
+vtctldclient> GetTablets --keyspace commerce --tablet-type replica
+vtctldclient> ApplySchema commerce
+sql> CREATE TABLE customer (
+sql>   id bigint NOT NULL,
+sql>   PRIMARY KEY (id)
+sql> )
+sql>`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandShell,
+	}
+)
+
+var shellOptions = struct {
+	HistoryFile string
+}{}
+
+// shellClient is the client of the running shell, which the commands of the
+// shell use rather than connecting to the vtctld again.
+var shellClient vtctldclient.VtctldClient
+
+const (
+	shellPrompt             = "vtctldclient> "
+	shellContinuationPrompt = "... "
+	shellSQLPrompt          = "sql> "
+
+	// shellHistorySize is the number of commands kept in the history file.
+	shellHistorySize = 1000
+	// shellCompletionsTTL is how long the keyspaces, shards, cells and tablet
+	// aliases fetched from the vtctld are used for the completions.
+	shellCompletionsTTL = 30 * time.Second
+)
+
+func commandShell(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	shellClient = client
+	defer func() {
+		// Root closes the client of the shell once it exits.
+		client = shellClient
+		shellClient = nil
+	}()
+
+	sh := &shell{
+		restoreFlags: snapshotFlags(Root),
+		completer:    &shellCompleter{client: shellClient},
+		historyFile:  shellOptions.HistoryFile,
+	}
+	sh.loadHistory()
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		r := bufio.NewReader(os.Stdin)
+		return sh.run(ctx, func(prompt string) (string, error) {
+			line, err := r.ReadString('\n')
+			if errors.Is(err, io.EOF) && line != "" {
+				err = nil
+			}
+			return strings.TrimRight(line, "\r\n"), err
+		})
+	}
+
+	// x/term has no API to load a history, so the commands of the history
+	// file are typed into the terminal, with its output muted, before it reads
+	// from the input.
+	out := &shellOutput{w: os.Stdout, muted: true}
+	replay := strings.Join(sh.history, "\r") + "\r"
+	if len(sh.history) == 0 {
+		replay = ""
+	}
+	sh.term = term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{io.MultiReader(strings.NewReader(replay), os.Stdin), out}, shellPrompt)
+	sh.term.AutoCompleteCallback = sh.complete
+	for range sh.history {
+		if _, err := sh.term.ReadLine(); err != nil {
+			return err
+		}
+	}
+	out.muted = false
+
+	return sh.run(ctx, func(prompt string) (string, error) {
+		// The terminal is only raw while reading a line, so that the output of
+		// the commands is not garbled.
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return "", err
+		}
+		defer term.Restore(fd, state) // nolint:errcheck
+
+		if width, height, err := term.GetSize(fd); err == nil {
+			sh.term.SetSize(width, height) // nolint:errcheck
+		}
+		sh.term.SetPrompt(prompt)
+		return sh.term.ReadLine()
+	})
+}
+
+// shellOutput is the output of the terminal of the shell, which discards what
+// is written to it while it is muted.
+type shellOutput struct {
+	w     io.Writer
+	muted bool
+}
+
+// Write is part of the io.Writer interface.
+func (out *shellOutput) Write(p []byte) (int, error) {
+	if out.muted {
+		return len(p), nil
+	}
+	return out.w.Write(p)
+}
+
+type shell struct {
+	// restoreFlags restores the flags of the commands to their values when
+	// the shell started.
+	restoreFlags func()
+	completer    *shellCompleter
+	term         *term.Terminal
+
+	historyFile string
+	history     []string
+}
+
+// run reads and runs the commands until the end of the input, or until the
+// shell is exited.
+func (sh *shell) run(ctx context.Context, readLine func(prompt string) (string, error)) error {
+	for {
+		args, line, err := sh.readCommand(readLine)
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		case len(args) == 0:
+			continue
+		}
+
+		sh.addHistory(line)
+		switch args[0] {
+		case "exit", "quit":
+			return nil
+		case "history":
+			for i, line := range sh.history {
+				fmt.Printf("%5d  %s\n", i+1, line)
+			}
+		case "shell":
+			fmt.Fprintln(os.Stderr, "Error: the shell is already running")
+		default:
+			sh.execute(ctx, args)
+		}
+	}
+}
+
+// readCommand reads the next command. It returns its arguments, and its line
+// for the history.
+func (sh *shell) readCommand(readLine func(prompt string) (string, error)) (args []string, line string, err error) {
+	text, err := readLine(shellPrompt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for {
+		if strings.HasSuffix(text, "\\") {
+			text = strings.TrimSuffix(text, "\\")
+		} else {
+			args, err = shlex.Split(text)
+			if err == nil || !strings.HasPrefix(err.Error(), "EOF found") {
+				break
+			}
+			// A quote is left open.
+			text += "\n"
+		}
+
+		next, err := readLine(shellContinuationPrompt)
+		if err != nil {
+			return nil, "", err
+		}
+		text += next
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	line = strings.Join(strings.Fields(text), " ")
+
+	if isShellSQLEditing(args) {
+		var sql []string
+		for {
+			next, err := readLine(shellSQLPrompt)
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, "", err
+			}
+			if strings.TrimSpace(next) != "" {
+				sql = append(sql, next)
+			}
+			if strings.TrimSpace(next) == "" || err != nil {
+				break
+			}
+		}
+		if len(sql) == 0 {
+			return nil, "", errors.New("no SQL for ApplySchema")
+		}
+		args = append(args, "--sql", strings.Join(sql, "\n"))
+		line += " --sql " + shellQuote(strings.Join(strings.Fields(strings.Join(sql, " ")), " "))
+	}
+
+	return args, line, nil
+}
+
+// isShellSQLEditing returns true for ApplySchema without its SQL, which the
+// shell then reads on the next lines.
+func isShellSQLEditing(args []string) bool {
+	if len(args) == 0 || args[0] != ApplySchema.Name() {
+		return false
+	}
+	for _, arg := range args[1:] {
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && slices.Contains([]string{"sql", "sql-file", "help", "h"}, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// shellQuote quotes s for shlex.Split.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// execute runs a command, which is cancelled on Ctrl-C.
+func (sh *shell) execute(ctx context.Context, args []string) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	sh.restoreFlags()
+	setContext(Root, ctx)
+	Root.SetArgs(args)
+	if err := Root.ExecuteContext(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+}
+
+// setContext sets the context of cmd and its subcommands, since cobra only
+// sets it the first time a command is executed.
+func setContext(cmd *cobra.Command, ctx context.Context) {
+	cmd.SetContext(ctx)
+	for _, sub := range cmd.Commands() {
+		setContext(sub, ctx)
+	}
+}
+
+// snapshotFlags returns a function which restores the flags of cmd and of its
+// subcommands to their current values, since cobra keeps the values that a
+// command sets for the next commands.
+func snapshotFlags(cmd *cobra.Command) func() {
+	type flagValue struct {
+		flag    *pflag.Flag
+		value   string
+		slice   []string
+		changed bool
+	}
+
+	var values []*flagValue
+	seen := map[*pflag.Flag]bool{}
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		// The help flags are otherwise added when the commands are executed.
+		cmd.InitDefaultHelpFlag()
+		for _, fs := range []*pflag.FlagSet{cmd.Flags(), cmd.PersistentFlags()} {
+			fs.VisitAll(func(f *pflag.Flag) {
+				if seen[f] {
+					return
+				}
+				seen[f] = true
+				v := &flagValue{flag: f, value: f.Value.String(), changed: f.Changed}
+				if sv, ok := f.Value.(pflag.SliceValue); ok {
+					v.slice = sv.GetSlice()
+				}
+				values = append(values, v)
+			})
+		}
+		for _, sub := range cmd.Commands() {
+			visit(sub)
+		}
+	}
+	visit(cmd)
+
+	return func() {
+		for _, v := range values {
+			if v.flag.Value.String() != v.value {
+				if sv, ok := v.flag.Value.(pflag.SliceValue); ok {
+					sv.Replace(v.slice) // nolint:errcheck
+				} else {
+					v.flag.Value.Set(v.value) // nolint:errcheck
+				}
+			}
+			v.flag.Changed = v.changed
+		}
+	}
+}
+
+// loadHistory loads the history file, if any, keeping its last commands.
+func (sh *shell) loadHistory() {
+	if sh.historyFile == "" {
+		return
+	}
+	data, err := os.ReadFile(sh.historyFile)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sh.history = append(sh.history, line)
+		}
+	}
+	if len(sh.history) > shellHistorySize {
+		sh.history = sh.history[len(sh.history)-shellHistorySize:]
+		os.WriteFile(sh.historyFile, []byte(strings.Join(sh.history, "\n")+"\n"), 0o600) // nolint:errcheck
+	}
+}
+
+// addHistory adds a command to the history, and appends it to the history
+// file, if any.
+func (sh *shell) addHistory(line string) {
+	sh.history = append(sh.history, line)
+	if sh.historyFile == "" {
+		return
+	}
+	f, err := os.OpenFile(sh.historyFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
+
+// complete is the AutoCompleteCallback of the terminal, which completes the
+// word before the cursor on Tab. If there are several completions, it
+// completes their common prefix, or else lists them.
+func (sh *shell) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	before := line[:pos]
+	start := strings.LastIndexAny(before, " \t") + 1
+	word := before[start:]
+
+	var matches []string
+	for _, candidate := range sh.completer.candidates(strings.Fields(before[:start]), word) {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	matches = slices.Compact(matches)
+
+	var completion string
+	switch len(matches) {
+	case 0:
+		return "", 0, false
+	case 1:
+		completion = matches[0]
+		if !strings.HasSuffix(completion, "=") && !strings.HasSuffix(completion, "/") {
+			completion += " "
+		}
+	default:
+		completion = commonPrefix(matches)
+		if completion == word && sh.term != nil {
+			fmt.Fprintln(sh.term, strings.Join(matches, "  "))
+		}
+	}
+	return before[:start] + completion + line[pos:], start + len(completion), true
+}
+
+// commonPrefix returns the longest common prefix of the words.
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// shellCompleter returns the candidate completions of the words of the shell.
+type shellCompleter struct {
+	client vtctldclient.VtctldClient
+
+	fetched   time.Time
+	keyspaces []string
+	shards    []string // as keyspace/shard
+	cells     []string
+	tablets   []string
+}
+
+// candidates returns the candidate completions of word, after the previous
+// words of the command.
+func (c *shellCompleter) candidates(previous []string, word string) []string {
+	if len(previous) == 0 {
+		names := []string{"exit", "history", "quit"}
+		for _, cmd := range Root.Commands() {
+			if cmd.IsAvailableCommand() && cmd.Name() != "shell" {
+				names = append(names, cmd.Name())
+				names = append(names, cmd.Aliases...)
+			}
+		}
+		return names
+	}
+
+	cmd, _, err := Root.Find(previous)
+	if err != nil || cmd == Root {
+		return nil
+	}
+
+	// The value of a flag, as --keyspace=commerce or --keyspace commerce.
+	if strings.HasPrefix(word, "-") && strings.Contains(word, "=") {
+		name, _, _ := strings.Cut(word, "=")
+		var candidates []string
+		for _, value := range c.flagValues(strings.TrimLeft(name, "-")) {
+			candidates = append(candidates, name+"="+value)
+		}
+		return candidates
+	}
+	if last := previous[len(previous)-1]; strings.HasPrefix(last, "-") && !strings.Contains(last, "=") {
+		if f := lookupShellFlag(cmd, strings.TrimLeft(last, "-")); f != nil && f.NoOptDefVal == "" {
+			return c.flagValues(f.Name)
+		}
+	}
+
+	if strings.HasPrefix(word, "-") {
+		var flags []string
+		cmd.NonInheritedFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Hidden {
+				return
+			}
+			if f.NoOptDefVal == "" {
+				flags = append(flags, "--"+f.Name+"=")
+			} else {
+				flags = append(flags, "--"+f.Name)
+			}
+		})
+		return flags
+	}
+
+	if cmd.HasAvailableSubCommands() {
+		var names []string
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() {
+				names = append(names, sub.Name())
+			}
+		}
+		return names
+	}
+
+	c.refresh()
+	candidates := append([]string{}, c.keyspaces...)
+	candidates = append(candidates, c.shards...)
+	return append(candidates, c.tablets...)
+}
+
+// lookupShellFlag returns the flag of cmd with the name or shorthand, if any.
+func lookupShellFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	for _, fs := range []*pflag.FlagSet{cmd.Flags(), cmd.InheritedFlags()} {
+		if f := fs.Lookup(name); f != nil {
+			return f
+		}
+		if len(name) == 1 {
+			if f := fs.ShorthandLookup(name); f != nil {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// flagValues returns the candidate values of a flag, according to its name.
+func (c *shellCompleter) flagValues(name string) []string {
+	switch {
+	case name == "tablet-type" || name == "tablet-types" || name == "tablet_type":
+		return topoproto.MakeUniqueStringTypeList(topoproto.AllTabletTypes)
+	case strings.Contains(name, "keyspace"):
+		c.refresh()
+		return c.keyspaces
+	case name == "shard":
+		c.refresh()
+		var shards []string
+		for _, shard := range c.shards {
+			_, name, _ := strings.Cut(shard, "/")
+			shards = append(shards, name)
+		}
+		return shards
+	case strings.Contains(name, "cell"):
+		c.refresh()
+		return c.cells
+	case strings.Contains(name, "tablet"):
+		c.refresh()
+		return c.tablets
+	}
+	return nil
+}
+
+// refresh fetches the keyspaces, shards, cells and tablet aliases from the
+// vtctld, unless they were fetched recently. They are left as they are if
+// they cannot be fetched.
+func (c *shellCompleter) refresh() {
+	if c.client == nil || time.Since(c.fetched) < shellCompletionsTTL {
+		return
+	}
+	c.fetched = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if resp, err := c.client.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{}); err == nil {
+		c.keyspaces, c.shards = nil, nil
+		for _, ks := range resp.Keyspaces {
+			c.keyspaces = append(c.keyspaces, ks.Name)
+			shards, err := c.client.FindAllShardsInKeyspace(ctx, &vtctldatapb.FindAllShardsInKeyspaceRequest{Keyspace: ks.Name})
+			if err != nil {
+				continue
+			}
+			for name := range shards.Shards {
+				c.shards = append(c.shards, ks.Name+"/"+name)
+			}
+		}
+	}
+	if resp, err := c.client.GetCellInfoNames(ctx, &vtctldatapb.GetCellInfoNamesRequest{}); err == nil {
+		c.cells = resp.Names
+	}
+	if resp, err := c.client.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{}); err == nil {
+		c.tablets = nil
+		for _, tablet := range resp.Tablets {
+			c.tablets = append(c.tablets, topoproto.TabletAliasString(tablet.Alias))
+		}
+	}
+}
+
+func init() {
+	historyFile := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		historyFile = filepath.Join(home, ".vtctldclient_history")
+	}
+	Shell.Flags().StringVar(&shellOptions.HistoryFile, "history-file", historyFile, "File in which the history of the commands of the shell is kept. The history is not kept if empty.")
+	Root.AddCommand(Shell)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellReadCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		args  []string
+		line  string
+	}{
+		{
+			name:  "single line",
+			lines: []string{"GetTablets --keyspace commerce"},
+			args:  []string{"GetTablets", "--keyspace", "commerce"},
+			line:  "GetTablets --keyspace commerce",
+		},
+		{
+			name:  "backslash continuation",
+			lines: []string{`GetTablets \`, "--keyspace commerce"},
+			args:  []string{"GetTablets", "--keyspace", "commerce"},
+			line:  "GetTablets --keyspace commerce",
+		},
+		{
+			name:  "open quote",
+			lines: []string{`ApplySchema --sql "CREATE TABLE t (`, `id bigint)" commerce`},
+			args:  []string{"ApplySchema", "--sql", "CREATE TABLE t (\nid bigint)", "commerce"},
+			line:  `ApplySchema --sql "CREATE TABLE t ( id bigint)" commerce`,
+		},
+		{
+			name:  "ApplySchema SQL",
+			lines: []string{"ApplySchema commerce", "CREATE TABLE t (", "  id bigint", ")", ""},
+			args:  []string{"ApplySchema", "commerce", "--sql", "CREATE TABLE t (\n  id bigint\n)"},
+			line:  "ApplySchema commerce --sql 'CREATE TABLE t ( id bigint )'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := tt.lines
+			readLine := func(prompt string) (string, error) {
+				if len(lines) == 0 {
+					return "", io.EOF
+				}
+				line := lines[0]
+				lines = lines[1:]
+				return line, nil
+			}
+
+			sh := &shell{}
+			args, line, err := sh.readCommand(readLine)
+			require.NoError(t, err)
+			assert.Equal(t, tt.args, args)
+			assert.Equal(t, tt.line, line)
+		})
+	}
+}
+
+func TestShellComplete(t *testing.T) {
+	sh := &shell{
+		completer: &shellCompleter{
+			fetched:   time.Now(),
+			keyspaces: []string{"commerce", "customer"},
+			shards:    []string{"commerce/0", "customer/-80", "customer/80-"},
+			cells:     []string{"zone1"},
+			tablets:   []string{"zone1-0000000100"},
+		},
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"GetTabl", "GetTablet"},
+		{"GetTablets --keyspace c", "GetTablets --keyspace c"},
+		{"GetTablets --keyspace cu", "GetTablets --keyspace customer "},
+		{"GetTablets --cell z", "GetTablets --cell zone1 "},
+		{"GetTablets --keyspace=com", "GetTablets --keyspace=commerce "},
+		{"PingTablet zone", "PingTablet zone1-0000000100 "},
+		{"GetShard customer/8", "GetShard customer/80- "},
+	}
+	for _, tt := range tests {
+		line, pos, ok := sh.complete(tt.line, len(tt.line), '\t')
+		if !ok {
+			line, pos = tt.line, len(tt.line)
+		}
+		assert.Equal(t, tt.want, line, tt.line)
+		assert.Equal(t, len(line), pos, tt.line)
+	}
+}
+
+func TestSnapshotFlags(t *testing.T) {
+	restore := snapshotFlags(Root)
+	defer restore()
+
+	require.NoError(t, Root.ParseFlags([]string{"--format", "json"}))
+	require.NoError(t, GetTablets.ParseFlags([]string{"--keyspace", "commerce", "--cell", "zone1,zone2"}))
+
+	restore()
+	assert.Equal(t, "", GetTablets.Flags().Lookup("keyspace").Value.String())
+	assert.False(t, GetTablets.Flags().Lookup("keyspace").Changed)
+	assert.Equal(t, "[]", GetTablets.Flags().Lookup("cell").Value.String())
+	assert.Equal(t, "text", Root.PersistentFlags().Lookup("format").Value.String())
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// tabletSelectorOptions select the tablets on which the commands accepting
+// selectors run, instead of a single tablet alias.
+type tabletSelectorOptions struct {
+	Cells       []string
+	Keyspace    string
+	Shard       string
+	TabletType  topodatapb.TabletType
+	Concurrency int
+}
+
+var tabletSelectorFlags = []string{"cell", "keyspace", "shard", "tablet-type"}
+
+// addTabletSelectorFlags adds the flags selecting the tablets of a command, and
+// documents them in its long description.
+func addTabletSelectorFlags(cmd *cobra.Command, opts *tabletSelectorOptions) {
+	cmd.Flags().StringSliceVarP(&opts.Cells, "cell", "c", nil, "Run on the tablets in these cells, instead of the tablet alias.")
+	cmd.Flags().StringVarP(&opts.Keyspace, "keyspace", "k", "", "Run on the tablets in this keyspace, instead of the tablet alias.")
+	cmd.Flags().StringVarP(&opts.Shard, "shard", "s", "", "Run on the tablets in this shard of --keyspace.")
+	cmd.Flags().Var((*topoproto.TabletTypeFlag)(&opts.TabletType), "tablet-type", "Run on the tablets of this type, instead of the tablet alias.")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 8, "Number of selected tablets to run on in parallel.")
+
+	cmd.Long = cmd.Short + `
+
+Instead of a tablet alias, the tablets may be selected with any of --cell,
+--keyspace, --shard and --tablet-type, e.g. "--cell zone1 --keyspace commerce
+--tablet-type replica". The command then runs on the selected tablets, up to
+--concurrency at a time, and writes a summary of the results on each of them.`
+}
+
+// tabletsSelected returns true if any of the selector flags of cmd were passed.
+func tabletsSelected(cmd *cobra.Command) bool {
+	for _, name := range tabletSelectorFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// tabletOperationResult is the result of a command on one of the selected
+// tablets.
+type tabletOperationResult struct {
+	TabletAlias string `json:"tablet_alias"`
+	Keyspace    string `json:"keyspace"`
+	Shard       string `json:"shard"`
+	TabletType  string `json:"tablet_type"`
+	Result      string `json:"result"`
+	Error       string `json:"error,omitempty"`
+}
+
+// runOnSelectedTablets runs op on the tablets selected by opts, up to
+// opts.Concurrency at a time, then writes the results in the format set with
+// the --format flag, or as a table by default. It returns an error if op
+// failed on any tablet.
+func runOnSelectedTablets(ctx context.Context, opts *tabletSelectorOptions, op func(ctx context.Context, alias *topodatapb.TabletAlias) error) error {
+	if opts.Keyspace == "" && opts.Shard != "" {
+		return fmt.Errorf("--shard (= %s) cannot be passed without also passing --keyspace", opts.Shard)
+	}
+	if opts.Concurrency <= 0 {
+		return fmt.Errorf("--concurrency must be positive, got %d", opts.Concurrency)
+	}
+
+	resp, err := client.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Cells:      opts.Cells,
+		Keyspace:   opts.Keyspace,
+		Shard:      opts.Shard,
+		TabletType: opts.TabletType,
+		Strict:     true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Tablets) == 0 {
+		return fmt.Errorf("no tablets match the selectors")
+	}
+
+	results := make([]*tabletOperationResult, len(resp.Tablets))
+	sem := make(chan struct{}, opts.Concurrency)
+	wg := sync.WaitGroup{}
+	for i, tablet := range resp.Tablets {
+		results[i] = &tabletOperationResult{
+			TabletAlias: topoproto.TabletAliasString(tablet.Alias),
+			Keyspace:    tablet.Keyspace,
+			Shard:       tablet.Shard,
+			TabletType:  topoproto.TabletTypeLString(tablet.Type),
+		}
+
+		wg.Add(1)
+		go func(result *tabletOperationResult, alias *topodatapb.TabletAlias) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := op(ctx, alias); err != nil {
+				result.Result = "failed"
+				result.Error = err.Error()
+				return
+			}
+			result.Result = "ok"
+		}(results[i], tablet.Alias)
+	}
+	wg.Wait()
+
+	format := cli.Format
+	if format == cli.OutputFormatText {
+		format = cli.OutputFormatTable
+	}
+	data, err := cli.Marshal(results, format)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+
+	var failed []string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result.TabletAlias)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed on %d of %d tablets: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package command
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +36,19 @@ import (
 )
 
 var (
+	// ChangeTabletTags makes a ChangeTabletTags gRPC call to a vtctld.
+	ChangeTabletTags = &cobra.Command{
+		Use:   "ChangeTabletTags [--replace] <alias> <key=value> [<key=value> ...]",
+		Short: "Changes the tags of the specified tablet.",
+		Long: `Changes the tags of the specified tablet.
+
+The given tags are merged into the tags of the tablet, and tags given with an
+empty value (e.g. "key=") are removed. With --replace, all the tags of the
+tablet are replaced with the given ones instead. The tablet must be running.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE:                  commandChangeTabletTags,
+	}
 	// ChangeTabletType makes a ChangeTabletType gRPC call to a vtctld.
 	ChangeTabletType = &cobra.Command{
 		Use:   "ChangeTabletType [--dry-run] <alias> <tablet-type>",
@@ -118,7 +133,8 @@ Passing --cell limits the set of tablets to those in the specified cells. The
 --cell flag accepts a CSV argument (e.g. --cell "c1,c2") and may be repeated
 (e.g. --cell "c1" --cell "c2").
 
-Valid output formats are "awk" and "json".`, strings.Join(topoproto.MakeUniqueStringTypeList(topoproto.AllTabletTypes), "\", \"")),
+The tablets are listed in an AWK-friendly format by default, or in the format
+set with the global --format flag.`, strings.Join(topoproto.MakeUniqueStringTypeList(topoproto.AllTabletTypes), "\", \"")),
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.NoArgs,
 		RunE:                  commandGetTablets,
@@ -134,18 +150,18 @@ Valid output formats are "awk" and "json".`, strings.Join(topoproto.MakeUniqueSt
 	}
 	// PingTablet makes a PingTablet gRPC call to a vtctld.
 	PingTablet = &cobra.Command{
-		Use:                   "PingTablet <alias>",
+		Use:                   "PingTablet {<alias> | [--cell <cell> ...] [--keyspace <keyspace> [--shard <shard>]] [--tablet-type <type>] [--concurrency <n>]}",
 		Short:                 "Checks that the specified tablet is awake and responding to RPCs. This command can be blocked by other in-flight operations.",
 		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(1),
+		Args:                  cobra.MaximumNArgs(1),
 		RunE:                  commandPingTablet,
 	}
 	// RefreshState makes a RefreshState gRPC call to a vtctld.
 	RefreshState = &cobra.Command{
-		Use:                   "RefreshState <alias>",
+		Use:                   "RefreshState {<alias> | [--cell <cell> ...] [--keyspace <keyspace> [--shard <shard>]] [--tablet-type <type>] [--concurrency <n>]}",
 		Short:                 "Reloads the tablet record on the specified tablet.",
 		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(1),
+		Args:                  cobra.MaximumNArgs(1),
 		RunE:                  commandRefreshState,
 	}
 	// RefreshStateByShard makes a RefreshStateByShard gRPC call to a vtcld.
@@ -156,6 +172,20 @@ Valid output formats are "awk" and "json".`, strings.Join(topoproto.MakeUniqueSt
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandRefreshStateByShard,
 	}
+	// RotateTabletCertificates makes a RotateTabletCertificates gRPC call to a vtctld.
+	RotateTabletCertificates = &cobra.Command{
+		Use:   "RotateTabletCertificates [--cert <file>] [--key <file>] [--ca <file>] <alias> [ <alias> ... ]",
+		Short: "Rotates the gRPC TLS certificates of the specified tablets, without restarting them.",
+		Long: `Rotates the gRPC TLS certificates of the specified tablets, without restarting them.
+
+The PEM files given with --cert, --key and --ca are installed as the --grpc_cert, --grpc_key and --grpc_ca of the tablets.
+Without any of them, the tablets re-read their certificate files, for instance after a secret store has renewed them.
+The new connections to the tablets are then checked to be served the new certificate.
+The command fails if the rotation failed, or could not be checked, on any of the tablets.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE:                  commandRotateTabletCertificates,
+	}
 	// RunHealthCheck makes a RunHealthCheck gRPC call to a vtctld.
 	RunHealthCheck = &cobra.Command{
 		Use:                   "RunHealthCheck <tablet_alias>",
@@ -167,10 +197,10 @@ Valid output formats are "awk" and "json".`, strings.Join(topoproto.MakeUniqueSt
 	}
 	// SetWritable makes a SetWritable gRPC call to a vtctld.
 	SetWritable = &cobra.Command{
-		Use:                   "SetWritable <alias> <true/false>",
+		Use:                   "SetWritable {<alias> | [--cell <cell> ...] [--keyspace <keyspace> [--shard <shard>]] [--tablet-type <type>] [--concurrency <n>]} <true/false>",
 		Short:                 "Sets the specified tablet as writable or read-only.",
 		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(2),
+		Args:                  cobra.RangeArgs(1, 2),
 		RunE:                  commandSetWritable,
 	}
 	// SleepTablet makes a SleepTablet gRPC call to a vtctld.
@@ -212,6 +242,50 @@ Note that, in the SleepTablet implementation, the value should be positively-sig
 	}
 )
 
+var changeTabletTagsOptions = struct {
+	Replace bool
+}{}
+
+func commandChangeTabletTags(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]string, cmd.Flags().NArg()-1)
+	for _, arg := range cmd.Flags().Args()[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid tag %q, expected <key>=<value>", arg)
+		}
+
+		tags[key] = value
+	}
+
+	if len(tags) == 0 && !changeTabletTagsOptions.Replace {
+		return fmt.Errorf("at least one tag is required, unless --replace is set")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ChangeTabletTags(commandCtx, &vtctldatapb.ChangeTabletTagsRequest{
+		TabletAlias: alias,
+		Tags:        tags,
+		Replace:     changeTabletTagsOptions.Replace,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 var changeTabletTypeOptions = struct {
 	DryRun bool
 }{}
@@ -302,7 +376,7 @@ func commandExecuteHook(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -325,7 +399,7 @@ func commandGetFullStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Status)
+	data, err := cli.MarshalOutput(resp.Status)
 	if err != nil {
 		return err
 	}
@@ -349,7 +423,7 @@ func commandGetPermissions(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	p, err := cli.MarshalJSON(resp.Permissions)
+	p, err := cli.MarshalOutput(resp.Permissions)
 	if err != nil {
 		return err
 	}
@@ -372,7 +446,7 @@ func commandGetTablet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Tablet)
+	data, err := cli.MarshalOutput(resp.Tablet)
 	if err != nil {
 		return err
 	}
@@ -390,19 +464,10 @@ var getTabletsOptions = struct {
 
 	TabletAliasStrings []string
 
-	Format string
 	Strict bool
 }{}
 
 func commandGetTablets(cmd *cobra.Command, args []string) error {
-	format := strings.ToLower(getTabletsOptions.Format)
-
-	switch format {
-	case "awk", "json":
-	default:
-		return fmt.Errorf("invalid output format, got %s", getTabletsOptions.Format)
-	}
-
 	var aliases []*topodatapb.TabletAlias
 
 	if len(getTabletsOptions.TabletAliasStrings) > 0 {
@@ -442,13 +507,13 @@ func commandGetTablets(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	switch format {
-	case "awk":
+	switch cli.Format {
+	case cli.OutputFormatText:
 		for _, t := range resp.Tablets {
 			fmt.Println(cli.MarshalTabletAWK(t))
 		}
-	case "json":
-		data, err := cli.MarshalJSON(resp.Tablets)
+	default:
+		data, err := cli.MarshalOutput(resp.Tablets)
 		if err != nil {
 			return err
 		}
@@ -478,7 +543,25 @@ func commandGetTabletVersion(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var pingTabletOptions tabletSelectorOptions
+
 func commandPingTablet(cmd *cobra.Command, args []string) error {
+	ping := func(ctx context.Context, alias *topodatapb.TabletAlias) error {
+		_, err := client.PingTablet(ctx, &vtctldatapb.PingTabletRequest{
+			TabletAlias: alias,
+		})
+		return err
+	}
+
+	if tabletsSelected(cmd) {
+		if cmd.Flags().NArg() > 0 {
+			return fmt.Errorf("a tablet alias cannot be passed with the selector flags")
+		}
+
+		cli.FinishedParsing(cmd)
+		return runOnSelectedTablets(commandCtx, &pingTabletOptions, ping)
+	}
+
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
 		return err
@@ -486,13 +569,28 @@ func commandPingTablet(cmd *cobra.Command, args []string) error {
 
 	cli.FinishedParsing(cmd)
 
-	_, err = client.PingTablet(commandCtx, &vtctldatapb.PingTabletRequest{
-		TabletAlias: alias,
-	})
-	return err
+	return ping(commandCtx, alias)
 }
 
+var refreshStateOptions tabletSelectorOptions
+
 func commandRefreshState(cmd *cobra.Command, args []string) error {
+	refresh := func(ctx context.Context, alias *topodatapb.TabletAlias) error {
+		_, err := client.RefreshState(ctx, &vtctldatapb.RefreshStateRequest{
+			TabletAlias: alias,
+		})
+		return err
+	}
+
+	if tabletsSelected(cmd) {
+		if cmd.Flags().NArg() > 0 {
+			return fmt.Errorf("a tablet alias cannot be passed with the selector flags")
+		}
+
+		cli.FinishedParsing(cmd)
+		return runOnSelectedTablets(commandCtx, &refreshStateOptions, refresh)
+	}
+
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
 		return err
@@ -500,10 +598,7 @@ func commandRefreshState(cmd *cobra.Command, args []string) error {
 
 	cli.FinishedParsing(cmd)
 
-	_, err = client.RefreshState(commandCtx, &vtctldatapb.RefreshStateRequest{
-		TabletAlias: alias,
-	})
-	if err != nil {
+	if err := refresh(commandCtx, alias); err != nil {
 		return err
 	}
 
@@ -546,6 +641,62 @@ func commandRefreshStateByShard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var rotateTabletCertificatesOptions = struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}{}
+
+func commandRotateTabletCertificates(cmd *cobra.Command, args []string) error {
+	aliases, err := cli.TabletAliasesFromPosArgs(cmd.Flags().Args())
+	if err != nil {
+		return err
+	}
+
+	req := &vtctldatapb.RotateTabletCertificatesRequest{
+		TabletAliases: aliases,
+	}
+	for _, file := range []struct {
+		path string
+		data *[]byte
+	}{
+		{rotateTabletCertificatesOptions.CertFile, &req.Cert},
+		{rotateTabletCertificatesOptions.KeyFile, &req.Key},
+		{rotateTabletCertificatesOptions.CAFile, &req.Ca},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if *file.data, err = os.ReadFile(file.path); err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RotateTabletCertificates(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+
+	var failed []string
+	for _, rotation := range resp.Rotations {
+		if !rotation.Verified {
+			failed = append(failed, topoproto.TabletAliasString(rotation.TabletAlias))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("the certificates of %d of %d tablets were not rotated: %s", len(failed), len(resp.Rotations), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
 func commandRunHealthCheck(cmd *cobra.Command, args []string) error {
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
@@ -560,24 +711,42 @@ func commandRunHealthCheck(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+var setWritableOptions tabletSelectorOptions
+
 func commandSetWritable(cmd *cobra.Command, args []string) error {
-	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	selected := tabletsSelected(cmd)
+	if nargs := cmd.Flags().NArg(); selected && nargs != 1 {
+		return fmt.Errorf("only <true/false> can be passed with the selector flags, got %d arguments", nargs)
+	} else if !selected && nargs != 2 {
+		return fmt.Errorf("<alias> and <true/false> are required, got %d arguments", nargs)
+	}
+
+	isWritable, err := strconv.ParseBool(cmd.Flags().Arg(cmd.Flags().NArg() - 1))
 	if err != nil {
 		return err
 	}
 
-	isWritable, err := strconv.ParseBool(cmd.Flags().Arg(1))
+	setWritable := func(ctx context.Context, alias *topodatapb.TabletAlias) error {
+		_, err := client.SetWritable(ctx, &vtctldatapb.SetWritableRequest{
+			TabletAlias: alias,
+			Writable:    isWritable,
+		})
+		return err
+	}
+
+	if selected {
+		cli.FinishedParsing(cmd)
+		return runOnSelectedTablets(commandCtx, &setWritableOptions, setWritable)
+	}
+
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
 		return err
 	}
 
 	cli.FinishedParsing(cmd)
 
-	_, err = client.SetWritable(commandCtx, &vtctldatapb.SetWritableRequest{
-		TabletAlias: alias,
-		Writable:    isWritable,
-	})
-	return err
+	return setWritable(commandCtx, alias)
 }
 
 func commandSleepTablet(cmd *cobra.Command, args []string) error {
@@ -629,6 +798,9 @@ func commandStopReplication(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	ChangeTabletTags.Flags().BoolVar(&changeTabletTagsOptions.Replace, "replace", false, "Replaces all the tags of the tablet with the given ones, instead of merging them.")
+	Root.AddCommand(ChangeTabletTags)
+
 	ChangeTabletType.Flags().BoolVarP(&changeTabletTypeOptions.DryRun, "dry-run", "d", false, "Shows the proposed change without actually executing it.")
 	Root.AddCommand(ChangeTabletType)
 
@@ -645,18 +817,31 @@ func init() {
 	GetTablets.Flags().Var((*topoproto.TabletTypeFlag)(&getTabletsOptions.TabletType), "tablet-type", "Tablet type to filter by (e.g. primary or replica).")
 	GetTablets.Flags().StringVarP(&getTabletsOptions.Keyspace, "keyspace", "k", "", "Keyspace to filter tablets by.")
 	GetTablets.Flags().StringVarP(&getTabletsOptions.Shard, "shard", "s", "", "Shard to filter tablets by.")
-	GetTablets.Flags().StringVar(&getTabletsOptions.Format, "format", "awk", "Output format to use; valid choices are (json, awk).")
+	// GetTablets used to have its own --format flag, which is kept as an
+	// alias of the global one, so that its "awk" format keeps working.
+	GetTablets.Flags().Var(&cli.Format, "format", "Deprecated: use the global --format flag instead. Output format to use; valid choices are (json, awk).")
 	GetTablets.Flags().BoolVar(&getTabletsOptions.Strict, "strict", false, "Require all cells to return successful tablet data. Without --strict, tablet listings may be partial.")
 	Root.AddCommand(GetTablets)
 
 	Root.AddCommand(GetTabletVersion)
+
+	addTabletSelectorFlags(PingTablet, &pingTabletOptions)
 	Root.AddCommand(PingTablet)
+
+	addTabletSelectorFlags(RefreshState, &refreshStateOptions)
 	Root.AddCommand(RefreshState)
 
 	RefreshStateByShard.Flags().StringSliceVarP(&refreshStateByShardOptions.Cells, "cells", "c", nil, "If specified, only call RefreshState on tablets in the specified cells. If empty, all cells are considered.")
 	Root.AddCommand(RefreshStateByShard)
 
+	RotateTabletCertificates.Flags().StringVar(&rotateTabletCertificatesOptions.CertFile, "cert", "", "PEM file of the new certificate of the tablets. Without --key, it must match their current key.")
+	RotateTabletCertificates.Flags().StringVar(&rotateTabletCertificatesOptions.KeyFile, "key", "", "PEM file of the new private key of the tablets. Without --cert, it must match their current certificate.")
+	RotateTabletCertificates.Flags().StringVar(&rotateTabletCertificatesOptions.CAFile, "ca", "", "PEM file of the new CA of the tablets, which verifies the certificates of their clients.")
+	Root.AddCommand(RotateTabletCertificates)
+
 	Root.AddCommand(RunHealthCheck)
+
+	addTabletSelectorFlags(SetWritable, &setWritableOptions)
 	Root.AddCommand(SetWritable)
 	Root.AddCommand(SleepTablet)
 	Root.AddCommand(StartReplication)
@@ -18,15 +18,46 @@ package command
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
 var (
+	// ForceUnlock makes a ForceUnlock gRPC call to a vtctld.
+	ForceUnlock = &cobra.Command{
+		Use:   "ForceUnlock --lock-id <id> {<keyspace> | <keyspace/shard>}",
+		Short: "Releases a keyspace or shard lock held by a crashed or stuck process.",
+		Long: `Releases a keyspace or shard lock held by a crashed or stuck process.
+
+The lock is only released if it is still held by the lock holder with the given id, as listed by GetLocks, so that a lock taken again by another process in the meantime is left alone.
+Only use this when the process holding the lock is known not to run anymore: otherwise it may keep changing the keyspace or shard while another process takes the lock.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandForceUnlock,
+	}
+	// GetLocks makes a GetLocks gRPC call to a vtctld.
+	GetLocks = &cobra.Command{
+		Use:   "GetLocks [<keyspace>]",
+		Short: "Lists the holders of the keyspace and shard locks, and the processes waiting for them.",
+		Long: `Lists the holders of the keyspace and shard locks, and the processes waiting for them, of the given keyspace or of all the keyspaces.
+
+Each lock is listed with the action, host and user of the process that requested it, its age, and the id to pass to ForceUnlock.
+Not all topo implementations list the processes waiting for a lock.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MaximumNArgs(1),
+		RunE:                  commandGetLocks,
+	}
 	// GetTopologyPath makes a GetTopologyPath gRPC call to a vtctld.
 	GetTopologyPath = &cobra.Command{
 		Use:                   "GetTopologyPath <path>",
@@ -35,8 +66,127 @@ var (
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandGetTopologyPath,
 	}
+	// GetTopoReadOnly makes a GetTopoReadOnly gRPC call to a vtctld.
+	GetTopoReadOnly = &cobra.Command{
+		Use:                   "GetTopoReadOnly",
+		Short:                 "Shows whether the topology is in read-only mode, since when and why.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetTopoReadOnly,
+	}
+	// SetTopoReadOnly makes a SetTopoReadOnly gRPC call to a vtctld.
+	SetTopoReadOnly = &cobra.Command{
+		Use:   "SetTopoReadOnly [--reason <reason>] <true/false>",
+		Short: "Enables or disables the read-only mode of the topology, e.g. for a topo server migration.",
+		Long: `Enables or disables the read-only mode of the topology, e.g. for a topo server migration.
+
+While the topology is read-only, all the components reject the changes to it, like reparents, resharding and tablet record changes, with an error that includes --reason, which is required to enable it. They keep serving, from the topology as it is.
+The mode is stored in the global topo, and applies to each component as soon as its watch of the global topo sees the change.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetTopoReadOnly,
+	}
+	// TopoBackup makes a TopoBackup gRPC call to a vtctld.
+	TopoBackup = &cobra.Command{
+		Use:   "TopoBackup [--cells <cell1,cell2,...>] [--output-file <file>] [--store]",
+		Short: "Takes a snapshot of all the files of the global topo and of the cells, for disaster recovery.",
+		Long: `Takes a snapshot of all the files of the global topo and of the cells, for disaster recovery.
+
+The snapshot is written as JSON to --output-file and, with --store, stored in the backup storage of the vtctld, under the name printed by the command.
+Locks and other ephemeral files are skipped. Files are read one at a time, so the snapshot is only consistent if the topo is not changed while it is taken.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandTopoBackup,
+	}
+	// TopoGC makes a TopoGC gRPC call to a vtctld.
+	TopoGC = &cobra.Command{
+		Use:   "TopoGC [--cells <cell1,cell2,...>] [--min-age <duration>] [--dry-run]",
+		Short: "Finds the orphaned records of the topo, and removes the ones found orphaned long enough ago.",
+		Long: `Finds the orphaned records of the topo, and removes the ones found orphaned long enough ago.
+
+The orphaned records are:
+  - the records of the tablets of the shards that don't exist.
+  - the replication graphs of the shards that don't exist, and the entries of the replication graphs for the tablets that don't exist or are in another shard.
+  - the serving graphs of the keyspaces that don't exist.
+  - the routing rules and shard routing rules to the keyspaces that don't exist, e.g. left by workflows.
+When each record was first found orphaned is stored in the global topo, and a record is only removed once it was first found at least --min-age ago, so run TopoGC periodically, or twice --min-age apart.
+With --dry-run, the records are only listed, with whether they would be removed, and when they were first found is not stored.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandTopoGC,
+	}
+	// TopoRestore makes a TopoRestore gRPC call to a vtctld.
+	TopoRestore = &cobra.Command{
+		Use:   "TopoRestore {--input-file <file> | --backup-name <name>} [--cells <cell1,cell2,...> | --global-only] [--conflict-policy fail|skip|overwrite] [--dry-run]",
+		Short: "Restores a topo snapshot taken by TopoBackup, e.g. into a new topo server.",
+		Long: `Restores a topo snapshot taken by TopoBackup, e.g. into a new topo server.
+
+The files of the global topo are restored first, including the CellInfo of the cells, and then the files of each cell, through the topo server of the cell as set in its CellInfo.
+Restore the global topo alone with --global-only, and update the CellInfo of the cells with UpdateCellInfo, if their topo servers moved.
+Files that exist with the contents of the snapshot are left as is. Files that exist with different contents are handled according to --conflict-policy:
+  - fail: nothing is restored, and the conflicting files are reported.
+  - skip: the existing files are kept.
+  - overwrite: the existing files are replaced, unless they change during the restore.
+Restored files get new versions from the topo server.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandTopoRestore,
+	}
 )
 
+var forceUnlockOptions = struct {
+	LockID string
+}{}
+
+func commandForceUnlock(cmd *cobra.Command, args []string) error {
+	keyspace, shard := cmd.Flags().Arg(0), ""
+	if strings.Contains(keyspace, "/") {
+		var err error
+		keyspace, shard, err = topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ForceUnlock(commandCtx, &vtctldatapb.ForceUnlockRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		LockId:   forceUnlockOptions.LockID,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp.Lock)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func commandGetLocks(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetLocks(commandCtx, &vtctldatapb.GetLocksRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandGetTopologyPath(cmd *cobra.Command, args []string) error {
 	path := cmd.Flags().Arg(0)
 
@@ -49,7 +199,7 @@ func commandGetTopologyPath(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.Cell)
+	data, err := cli.MarshalOutput(resp.Cell)
 	if err != nil {
 		return err
 	}
@@ -59,6 +209,204 @@ func commandGetTopologyPath(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetTopoReadOnly(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTopoReadOnly(commandCtx, &vtctldatapb.GetTopoReadOnlyRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var setTopoReadOnlyOptions = struct {
+	Reason string
+}{}
+
+func commandSetTopoReadOnly(cmd *cobra.Command, args []string) error {
+	readOnly, err := strconv.ParseBool(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+	if readOnly && setTopoReadOnlyOptions.Reason == "" {
+		return fmt.Errorf("--reason is required to make the topology read-only")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetTopoReadOnly(commandCtx, &vtctldatapb.SetTopoReadOnlyRequest{
+		ReadOnly: readOnly,
+		Reason:   setTopoReadOnlyOptions.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var topoBackupOptions = struct {
+	Cells      []string
+	OutputFile string
+	Store      bool
+}{}
+
+func commandTopoBackup(cmd *cobra.Command, args []string) error {
+	if topoBackupOptions.OutputFile == "" && !topoBackupOptions.Store {
+		return fmt.Errorf("at least one of --output-file and --store is required")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.TopoBackup(commandCtx, &vtctldatapb.TopoBackupRequest{
+		Cells:                topoBackupOptions.Cells,
+		StoreInBackupStorage: topoBackupOptions.Store,
+	})
+	if err != nil {
+		return err
+	}
+
+	if topoBackupOptions.OutputFile != "" {
+		data, err := cli.MarshalJSON(resp.Snapshot)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(topoBackupOptions.OutputFile, data, 0o600); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote a snapshot of %d files to %s\n", len(resp.Snapshot.Files), topoBackupOptions.OutputFile)
+	}
+	if resp.BackupName != "" {
+		fmt.Printf("Stored a snapshot of %d files in the backup storage as %s\n", len(resp.Snapshot.Files), resp.BackupName)
+	}
+	return nil
+}
+
+var topoGCOptions = struct {
+	Cells  []string
+	MinAge time.Duration
+	DryRun bool
+}{}
+
+func commandTopoGC(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.TopoGC(commandCtx, &vtctldatapb.TopoGCRequest{
+		Cells:  topoGCOptions.Cells,
+		MinAge: protoutil.DurationToProto(topoGCOptions.MinAge),
+		DryRun: topoGCOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var topoRestoreOptions = struct {
+	InputFile      string
+	BackupName     string
+	Cells          []string
+	GlobalOnly     bool
+	ConflictPolicy string
+	DryRun         bool
+}{}
+
+func commandTopoRestore(cmd *cobra.Command, args []string) error {
+	if topoRestoreOptions.InputFile == "" && topoRestoreOptions.BackupName == "" {
+		return fmt.Errorf("one of --input-file and --backup-name is required")
+	}
+
+	req := &vtctldatapb.TopoRestoreRequest{
+		BackupName: topoRestoreOptions.BackupName,
+		Cells:      topoRestoreOptions.Cells,
+		GlobalOnly: topoRestoreOptions.GlobalOnly,
+		DryRun:     topoRestoreOptions.DryRun,
+	}
+
+	policy, ok := vtctldatapb.TopoRestoreRequest_ConflictPolicy_value[strings.ToUpper(topoRestoreOptions.ConflictPolicy)]
+	if !ok {
+		return fmt.Errorf("invalid --conflict-policy %q, must be one of fail, skip or overwrite", topoRestoreOptions.ConflictPolicy)
+	}
+	req.ConflictPolicy = vtctldatapb.TopoRestoreRequest_ConflictPolicy(policy)
+
+	if topoRestoreOptions.InputFile != "" {
+		data, err := os.ReadFile(topoRestoreOptions.InputFile)
+		if err != nil {
+			return err
+		}
+		req.Snapshot = &vtctldatapb.TopoSnapshot{}
+		if err := json2.Unmarshal(data, req.Snapshot); err != nil {
+			return fmt.Errorf("cannot decode the topo snapshot in %s: %w", topoRestoreOptions.InputFile, err)
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.TopoRestore(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func init() {
+	ForceUnlock.Flags().StringVar(&forceUnlockOptions.LockID, "lock-id", "", "Id of the lock holder whose lock is released, as listed by GetLocks.")
+	ForceUnlock.MarkFlagRequired("lock-id")
+	Root.AddCommand(ForceUnlock)
+
+	Root.AddCommand(GetLocks)
+
 	Root.AddCommand(GetTopologyPath)
+
+	Root.AddCommand(GetTopoReadOnly)
+
+	SetTopoReadOnly.Flags().StringVar(&setTopoReadOnlyOptions.Reason, "reason", "", "Why the topology is made read-only, e.g. the maintenance being done. Required to make it read-only.")
+	Root.AddCommand(SetTopoReadOnly)
+
+	TopoBackup.Flags().StringSliceVarP(&topoBackupOptions.Cells, "cells", "c", nil, "Cells whose topo is included in the snapshot, along with the global topo. All the cells are included if empty.")
+	TopoBackup.Flags().StringVar(&topoBackupOptions.OutputFile, "output-file", "", "File to write the snapshot to.")
+	TopoBackup.Flags().BoolVar(&topoBackupOptions.Store, "store", false, "Also store the snapshot in the backup storage of the vtctld.")
+	Root.AddCommand(TopoBackup)
+
+	TopoGC.Flags().StringSliceVarP(&topoGCOptions.Cells, "cells", "c", nil, "Cells whose topo is checked, along with the global topo. All the cells are checked if empty.")
+	TopoGC.Flags().DurationVar(&topoGCOptions.MinAge, "min-age", time.Hour, "How long ago a record must have been first found orphaned to be removed.")
+	TopoGC.Flags().BoolVar(&topoGCOptions.DryRun, "dry-run", false, "Only list the orphaned records.")
+	Root.AddCommand(TopoGC)
+
+	TopoRestore.Flags().StringVar(&topoRestoreOptions.InputFile, "input-file", "", "File holding the snapshot to restore, as written by TopoBackup --output-file.")
+	TopoRestore.Flags().StringVar(&topoRestoreOptions.BackupName, "backup-name", "", "Name of the snapshot to restore from the backup storage of the vtctld, as printed by TopoBackup --store.")
+	TopoRestore.Flags().StringSliceVarP(&topoRestoreOptions.Cells, "cells", "c", nil, "Cells whose files are restored, along with the ones of the global topo. All the cells of the snapshot are restored if empty.")
+	TopoRestore.Flags().BoolVar(&topoRestoreOptions.GlobalOnly, "global-only", false, "Only restore the files of the global topo.")
+	TopoRestore.Flags().StringVar(&topoRestoreOptions.ConflictPolicy, "conflict-policy", "fail", "What to do with the files that exist with different contents: fail, skip or overwrite.")
+	TopoRestore.Flags().BoolVar(&topoRestoreOptions.DryRun, "dry-run", false, "Only report what would be restored.")
+	TopoRestore.MarkFlagsMutuallyExclusive("input-file", "backup-name")
+	TopoRestore.MarkFlagsMutuallyExclusive("cells", "global-only")
+	Root.AddCommand(TopoRestore)
 }
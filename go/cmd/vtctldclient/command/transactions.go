@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ConcludeTransaction makes a ConcludeTransaction gRPC call to a vtctld.
+	ConcludeTransaction = &cobra.Command{
+		Use:   "ConcludeTransaction <dtid>",
+		Short: "Resolves an unresolved distributed transaction on all of its participants and deletes its metadata.",
+		Long: `Resolves an unresolved distributed transaction on all of its participants and deletes its metadata.
+
+The participants commit their prepared transaction if the metadata manager recorded a commit decision,
+and roll it back otherwise.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandConcludeTransaction,
+	}
+	// GetUnresolvedTransactions makes a GetUnresolvedTransactions gRPC call to a vtctld.
+	GetUnresolvedTransactions = &cobra.Command{
+		Use:                   "GetUnresolvedTransactions [--abandon-age <duration>] <keyspace>",
+		Short:                 "Outputs a JSON structure with the unresolved distributed transactions of the keyspace.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetUnresolvedTransactions,
+	}
+)
+
+func commandConcludeTransaction(cmd *cobra.Command, args []string) error {
+	dtid := cmd.Flags().Arg(0)
+	cli.FinishedParsing(cmd)
+
+	_, err := client.ConcludeTransaction(commandCtx, &vtctldatapb.ConcludeTransactionRequest{
+		Dtid: dtid,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully concluded distributed transaction %s\n", dtid)
+	return nil
+}
+
+var getUnresolvedTransactionsOptions = struct {
+	AbandonAge time.Duration
+}{}
+
+func commandGetUnresolvedTransactions(cmd *cobra.Command, args []string) error {
+	keyspace := cmd.Flags().Arg(0)
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetUnresolvedTransactions(commandCtx, &vtctldatapb.GetUnresolvedTransactionsRequest{
+		Keyspace:   keyspace,
+		AbandonAge: int64(getUnresolvedTransactionsOptions.AbandonAge.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func init() {
+	Root.AddCommand(ConcludeTransaction)
+
+	GetUnresolvedTransactions.Flags().DurationVar(&getUnresolvedTransactionsOptions.AbandonAge, "abandon-age", 0, "Only show the transactions that have been unresolved for at least this long.")
+	Root.AddCommand(GetUnresolvedTransactions)
+}
@@ -19,11 +19,14 @@ package command
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -40,22 +43,58 @@ var (
 	}
 	// ValidateKeyspace makes a ValidateKeyspace gRPC call to a vtctld.
 	ValidateKeyspace = &cobra.Command{
-		Use:                   "ValidateKeyspace [--ping-tablets] <keyspace>",
-		Short:                 "Validates that all nodes reachable from the specified keyspace are consistent.",
+		Use:   "ValidateKeyspace [--ping-tablets] [--checks <check>[,<check>...]] [--max-replication-lag <duration>] [--min-disk-free-percent <percent>] <keyspace>",
+		Short: "Validates that all nodes reachable from the specified keyspace are consistent.",
+		Long: `Validates that all nodes reachable from the specified keyspace are consistent.
+
+` + validationChecksHelp,
+		Example:               "ValidateKeyspace --checks replication,semi_sync,vschema,disk commerce",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandValidateKeyspace,
 	}
 	// ValidateShard makes a ValidateShard gRPC call to a vtctld.
 	ValidateShard = &cobra.Command{
-		Use:                   "ValidateShard [--ping-tablets] <keyspace/shard>",
-		Short:                 "Validates that all nodes reachable from the specified shard are consistent.",
+		Use:   "ValidateShard [--ping-tablets] [--checks <check>[,<check>...]] [--max-replication-lag <duration>] [--min-disk-free-percent <percent>] <keyspace/shard>",
+		Short: "Validates that all nodes reachable from the specified shard are consistent.",
+		Long: `Validates that all nodes reachable from the specified shard are consistent.
+
+` + validationChecksHelp,
+		Example:               "ValidateShard --checks replication --max-replication-lag 1m commerce/0",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandValidateShard,
 	}
 )
 
+const validationChecksHelp = `With --checks, the following checks are also run in parallel on each shard, and
+their results reported, as a pass or a fail with its issues:
+
+  replication: the replicas replicate from the primary, lag by at most
+               --max-replication-lag, and have no transactions which the
+               primary does not have.
+  semi_sync:   the semi-sync settings of the tablets are the ones of the
+               durability policy of the keyspace.
+  vschema:     the tables of the vschema are in the schema of the primary and,
+               for a sharded keyspace, the tables of the schema are in the
+               vschema.
+  disk:        the tablets have at least --min-disk-free-percent of the disk of
+               their data directory free.`
+
+// validationChecksOptions are the options of the checks of ValidateKeyspace
+// and ValidateShard.
+type validationChecksOptions struct {
+	Checks             []string
+	MaxReplicationLag  time.Duration
+	MinDiskFreePercent uint32
+}
+
+func addValidationChecksFlags(cmd *cobra.Command, opts *validationChecksOptions) {
+	cmd.Flags().StringSliceVar(&opts.Checks, "checks", nil, "Checks to run on each shard: any of replication, semi_sync, vschema and disk.")
+	cmd.Flags().DurationVar(&opts.MaxReplicationLag, "max-replication-lag", 30*time.Second, "Replication lag above which a replica fails the replication check.")
+	cmd.Flags().Uint32Var(&opts.MinDiskFreePercent, "min-disk-free-percent", 10, "Free disk space, as a percentage, below which a tablet fails the disk check.")
+}
+
 var validateOptions = struct {
 	PingTablets bool
 }{}
@@ -82,6 +121,7 @@ func commandValidate(cmd *cobra.Command, args []string) error {
 
 var validateKeyspaceOptions = struct {
 	PingTablets bool
+	validationChecksOptions
 }{}
 
 func commandValidateKeyspace(cmd *cobra.Command, args []string) error {
@@ -89,13 +129,22 @@ func commandValidateKeyspace(cmd *cobra.Command, args []string) error {
 
 	keyspace := cmd.Flags().Arg(0)
 	resp, err := client.ValidateKeyspace(commandCtx, &vtctldatapb.ValidateKeyspaceRequest{
-		Keyspace:    keyspace,
-		PingTablets: validateKeyspaceOptions.PingTablets,
+		Keyspace:           keyspace,
+		PingTablets:        validateKeyspaceOptions.PingTablets,
+		Checks:             validateKeyspaceOptions.Checks,
+		MaxReplicationLag:  protoutil.DurationToProto(validateKeyspaceOptions.MaxReplicationLag),
+		MinDiskFreePercent: validateKeyspaceOptions.MinDiskFreePercent,
 	})
 	if err != nil {
 		return err
 	}
 
+	if cli.Format != cli.OutputFormatText {
+		return printValidationResponse(resp)
+	}
+
+	printValidationChecks(keyspace, resp.ResultsByShard)
+
 	buf := &strings.Builder{}
 	if err := consumeKeyspaceValidationResults(keyspace, resp, buf); err != nil {
 		fmt.Printf("Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
@@ -108,6 +157,7 @@ func commandValidateKeyspace(cmd *cobra.Command, args []string) error {
 
 var validateShardOptions = struct {
 	PingTablets bool
+	validationChecksOptions
 }{}
 
 func commandValidateShard(cmd *cobra.Command, args []string) error {
@@ -119,14 +169,23 @@ func commandValidateShard(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.ValidateShard(commandCtx, &vtctldatapb.ValidateShardRequest{
-		Keyspace:    keyspace,
-		Shard:       shard,
-		PingTablets: validateShardOptions.PingTablets,
+		Keyspace:           keyspace,
+		Shard:              shard,
+		PingTablets:        validateShardOptions.PingTablets,
+		Checks:             validateShardOptions.Checks,
+		MaxReplicationLag:  protoutil.DurationToProto(validateShardOptions.MaxReplicationLag),
+		MinDiskFreePercent: validateShardOptions.MinDiskFreePercent,
 	})
 	if err != nil {
 		return err
 	}
 
+	if cli.Format != cli.OutputFormatText {
+		return printValidationResponse(resp)
+	}
+
+	printValidationChecks(keyspace, map[string]*vtctldatapb.ValidateShardResponse{shard: resp})
+
 	buf := &strings.Builder{}
 	if err := consumeShardValidationResults(keyspace, shard, resp, buf); err != nil {
 		fmt.Printf("Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
@@ -162,7 +221,7 @@ func consumeKeyspaceValidationResults(keyspace string, resp *vtctldatapb.Validat
 	}
 
 	for shard, shardResults := range resp.ResultsByShard {
-		if len(shardResults.Results) == 0 {
+		if len(shardResults.Results) == 0 && !failedValidationChecks(shardResults) {
 			continue
 		}
 
@@ -182,6 +241,12 @@ func consumeShardValidationResults(keyspace string, shard string, resp *vtctldat
 		fmt.Fprintf(buf, "- %s\n", result)
 	}
 
+	for _, check := range resp.CheckResults {
+		for _, issue := range check.Issues {
+			fmt.Fprintf(buf, "- [%s] %s\n", check.Check, issue)
+		}
+	}
+
 	if buf.Len() > 0 {
 		return fmt.Errorf("shard %s/%s had validation issues; see above for details", keyspace, shard)
 	}
@@ -189,6 +254,71 @@ func consumeShardValidationResults(keyspace string, shard string, resp *vtctldat
 	return nil
 }
 
+// failedValidationChecks returns true if any of the checks of the response
+// failed.
+func failedValidationChecks(resp *vtctldatapb.ValidateShardResponse) bool {
+	for _, check := range resp.CheckResults {
+		if !check.Passed {
+			return true
+		}
+	}
+	return false
+}
+
+// printValidationChecks prints whether each check passed on each shard, if
+// any checks were run.
+func printValidationChecks(keyspace string, resultsByShard map[string]*vtctldatapb.ValidateShardResponse) {
+	shards := make([]string, 0, len(resultsByShard))
+	for shard, shardResp := range resultsByShard {
+		if len(shardResp.CheckResults) > 0 {
+			shards = append(shards, shard)
+		}
+	}
+	if len(shards) == 0 {
+		return
+	}
+	sort.Strings(shards)
+
+	fmt.Println("Checks:")
+	for _, shard := range shards {
+		for _, check := range resultsByShard[shard].CheckResults {
+			result := "PASS"
+			if !check.Passed {
+				result = fmt.Sprintf("FAIL (%d issues)", len(check.Issues))
+			}
+			fmt.Printf("  %s/%s %s: %s\n", keyspace, shard, check.Check, result)
+		}
+	}
+}
+
+// printValidationResponse prints the response of ValidateKeyspace or
+// ValidateShard in the format of --format, and returns an error if the
+// validation found issues.
+func printValidationResponse(resp any) error {
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+
+	switch resp := resp.(type) {
+	case *vtctldatapb.ValidateKeyspaceResponse:
+		if len(resp.Results) > 0 {
+			return errors.New("some issues were found during validation; see above for details")
+		}
+		for _, shardResp := range resp.ResultsByShard {
+			if len(shardResp.Results) > 0 || failedValidationChecks(shardResp) {
+				return errors.New("some issues were found during validation; see above for details")
+			}
+		}
+	case *vtctldatapb.ValidateShardResponse:
+		if len(resp.Results) > 0 || failedValidationChecks(resp) {
+			return errors.New("some issues were found during validation; see above for details")
+		}
+	}
+	return nil
+}
+
 func init() {
 	pingTabletsName := "ping-tablets"
 	pingTabletsShort := "p"
@@ -199,6 +329,9 @@ func init() {
 	ValidateKeyspace.Flags().BoolVarP(&validateKeyspaceOptions.PingTablets, pingTabletsName, pingTabletsShort, pingTabletsDefault, pingTabletsUsage)
 	ValidateShard.Flags().BoolVarP(&validateShardOptions.PingTablets, pingTabletsName, pingTabletsShort, pingTabletsDefault, pingTabletsUsage)
 
+	addValidationChecksFlags(ValidateKeyspace, &validateKeyspaceOptions.validationChecksOptions)
+	addValidationChecksFlags(ValidateShard, &validateShardOptions.validationChecksOptions)
+
 	Root.AddCommand(Validate)
 	Root.AddCommand(ValidateKeyspace)
 	Root.AddCommand(ValidateShard)
@@ -65,12 +65,12 @@ func commandCancel(cmd *cobra.Command, args []string) error {
 	}
 
 	var output []byte
-	if format == "json" {
+	if format != "text" {
 		// Sort the inner TabletInfo slice for deterministic output.
 		sort.Slice(resp.Details, func(i, j int) bool {
 			return resp.Details[i].Tablet.String() < resp.Details[j].Tablet.String()
 		})
-		output, err = cli.MarshalJSONPretty(resp)
+		output, err = cli.Marshal(resp, cli.OutputFormat(format))
 		if err != nil {
 			return err
 		}
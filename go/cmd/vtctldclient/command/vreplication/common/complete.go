@@ -53,8 +53,8 @@ func commandComplete(cmd *cobra.Command, args []string) error {
 	}
 
 	var output []byte
-	if format == "json" {
-		output, err = cli.MarshalJSONPretty(resp)
+	if format != "text" {
+		output, err = cli.Marshal(resp, cli.OutputFormat(format))
 		if err != nil {
 			return err
 		}
@@ -27,7 +27,8 @@ import (
 )
 
 var showOptions = struct {
-	IncludeLogs bool
+	IncludeLogs         bool
+	IncludeCopyProgress bool
 }{}
 
 func GetShowCommand(opts *SubCommandsOpts) *cobra.Command {
@@ -41,6 +42,7 @@ func GetShowCommand(opts *SubCommandsOpts) *cobra.Command {
 		RunE:                  commandShow,
 	}
 	cmd.Flags().BoolVar(&showOptions.IncludeLogs, "include-logs", true, "Include recent logs for the workflow.")
+	cmd.Flags().BoolVar(&showOptions.IncludeCopyProgress, "include-copy-progress", false, "Include the copy phase progress, throughput and estimated time to completion for the workflow.")
 	return cmd
 }
 
@@ -48,16 +50,17 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	req := &vtctldatapb.GetWorkflowsRequest{
-		Keyspace:    BaseOptions.TargetKeyspace,
-		Workflow:    BaseOptions.Workflow,
-		IncludeLogs: showOptions.IncludeLogs,
+		Keyspace:            BaseOptions.TargetKeyspace,
+		Workflow:            BaseOptions.Workflow,
+		IncludeLogs:         showOptions.IncludeLogs,
+		IncludeCopyProgress: showOptions.IncludeCopyProgress,
 	}
 	resp, err := GetClient().GetWorkflows(GetCommandCtx(), req)
 	if err != nil {
 		return err
 	}
 
-	data, err := cli.MarshalJSONPretty(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
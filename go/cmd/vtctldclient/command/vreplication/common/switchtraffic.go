@@ -98,6 +98,12 @@ func commandSwitchTraffic(cmd *cobra.Command, args []string) error {
 		EnableReverseReplication:  SwitchTrafficOptions.EnableReverseReplication,
 		InitializeTargetSequences: SwitchTrafficOptions.InitializeTargetSequences,
 		Direction:                 int32(SwitchTrafficOptions.Direction),
+		Force:                     SwitchTrafficOptions.Force,
+		RequireVdiff:              SwitchTrafficOptions.RequireVDiff,
+		RequireNoStreamErrors:     SwitchTrafficOptions.RequireNoStreamErrors,
+	}
+	if SwitchTrafficOptions.MaxVDiffAge > 0 {
+		req.MaxVdiffAge = protoutil.DurationToProto(SwitchTrafficOptions.MaxVDiffAge)
 	}
 	resp, err := GetClient().WorkflowSwitchTraffic(GetCommandCtx(), req)
 	if err != nil {
@@ -105,8 +111,8 @@ func commandSwitchTraffic(cmd *cobra.Command, args []string) error {
 	}
 
 	var output []byte
-	if format == "json" {
-		output, err = cli.MarshalJSONPretty(resp)
+	if format != "text" {
+		output, err = cli.Marshal(resp, cli.OutputFormat(format))
 		if err != nil {
 			return err
 		}
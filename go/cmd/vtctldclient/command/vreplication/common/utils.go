@@ -20,12 +20,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/textutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
 	"vitess.io/vitess/go/vt/vtctl/workflow"
@@ -51,7 +55,6 @@ var (
 	BaseOptions = struct {
 		Workflow       string
 		TargetKeyspace string
-		Format         string
 	}{}
 
 	CreateOptions = struct {
@@ -62,6 +65,7 @@ var (
 		DeferSecondaryKeys           bool
 		AutoStart                    bool
 		StopAfterCopy                bool
+		DryRun                       bool
 	}{}
 )
 
@@ -137,14 +141,10 @@ func ParseAndValidateCreateOptions(cmd *cobra.Command) error {
 	return nil
 }
 
+// GetOutputFormat returns the output format set with the global --format
+// flag.
 func GetOutputFormat(cmd *cobra.Command) (string, error) {
-	format := strings.ToLower(strings.TrimSpace(BaseOptions.Format))
-	switch format {
-	case "text", "json":
-		return format, nil
-	default:
-		return "", fmt.Errorf("invalid output format, got %s", BaseOptions.Format)
-	}
+	return string(cli.Format), nil
 }
 
 func GetTabletSelectionPreference(cmd *cobra.Command) tabletmanagerdatapb.TabletSelectionPreference {
@@ -155,11 +155,281 @@ func GetTabletSelectionPreference(cmd *cobra.Command) tabletmanagerdatapb.Tablet
 	return tsp
 }
 
+// ThrottlerOptions are the throttler settings flags of a workflow.
+type ThrottlerOptions struct {
+	AppName  string
+	Ratio    float64
+	Priority string
+}
+
+// AddThrottlerFlags adds the flags for the throttler settings of a workflow
+// to the given command.
+func AddThrottlerFlags(cmd *cobra.Command, opts *ThrottlerOptions) {
+	cmd.Flags().StringVar(&opts.AppName, "throttler-app-name", "", "Additional throttler app name for the workflow, which allows throttling or exempting the workflow on its own.")
+	cmd.Flags().Float64Var(&opts.Ratio, "throttler-ratio", 0, "Ratio of throttler checks, between 0 and 1, that the workflow fails on its own so that it progresses slower.")
+	cmd.Flags().StringVar(&opts.Priority, "throttler-priority", "normal", "Priority of the workflow when checking the throttler. Normal priority workflows yield while high priority workflows on the same tablet are throttled. Possible values are normal and high.")
+}
+
+// GetThrottlerSettings returns the throttler settings given by the
+// throttler flags of the command, or nil if none of them were provided.
+func GetThrottlerSettings(cmd *cobra.Command, opts *ThrottlerOptions) (*binlogdatapb.VReplicationThrottlerSettings, error) {
+	if !throttlerFlagsChanged(cmd) {
+		return nil, nil
+	}
+	if err := validateThrottlerRatio(opts.Ratio); err != nil {
+		return nil, err
+	}
+	priority, err := parseThrottlerPriority(opts.Priority)
+	if err != nil {
+		return nil, err
+	}
+	return &binlogdatapb.VReplicationThrottlerSettings{
+		AppName:  opts.AppName,
+		Ratio:    opts.Ratio,
+		Priority: priority,
+	}, nil
+}
+
+// GetThrottlerSettingsUpdate returns the throttler settings to update given
+// by the throttler flags of the command, or nil if none of them were
+// provided. The settings whose flags were not provided are set to simulated
+// NULL values, so that their current values are kept.
+func GetThrottlerSettingsUpdate(cmd *cobra.Command, opts *ThrottlerOptions) (*binlogdatapb.VReplicationThrottlerSettings, error) {
+	if !throttlerFlagsChanged(cmd) {
+		return nil, nil
+	}
+	settings := &binlogdatapb.VReplicationThrottlerSettings{
+		AppName:  textutil.SimulatedNullString,
+		Ratio:    float64(textutil.SimulatedNullInt),
+		Priority: binlogdatapb.VReplicationThrottlerPriority(textutil.SimulatedNullInt),
+	}
+	if cmd.Flags().Lookup("throttler-app-name").Changed {
+		settings.AppName = opts.AppName
+	}
+	if cmd.Flags().Lookup("throttler-ratio").Changed {
+		if err := validateThrottlerRatio(opts.Ratio); err != nil {
+			return nil, err
+		}
+		settings.Ratio = opts.Ratio
+	}
+	if cmd.Flags().Lookup("throttler-priority").Changed {
+		priority, err := parseThrottlerPriority(opts.Priority)
+		if err != nil {
+			return nil, err
+		}
+		settings.Priority = priority
+	}
+	return settings, nil
+}
+
+func throttlerFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"throttler-app-name", "throttler-ratio", "throttler-priority"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+func validateThrottlerRatio(ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return fmt.Errorf("invalid throttler-ratio value: %v, it must be between 0 and 1", ratio)
+	}
+	return nil
+}
+
+func parseThrottlerPriority(priority string) (binlogdatapb.VReplicationThrottlerPriority, error) {
+	switch strings.ToLower(priority) {
+	case "normal":
+		return binlogdatapb.VReplicationThrottlerPriority_Normal, nil
+	case "high":
+		return binlogdatapb.VReplicationThrottlerPriority_High, nil
+	default:
+		return 0, fmt.Errorf("invalid throttler-priority value: %s", priority)
+	}
+}
+
+// ScheduleOptions are the schedule flags of a workflow.
+type ScheduleOptions struct {
+	CopyWindows      []string
+	BandwidthWindows []string
+	TimeZone         string
+}
+
+// AddScheduleFlags adds the flags for the schedule of a workflow to the
+// given command.
+func AddScheduleFlags(cmd *cobra.Command, opts *ScheduleOptions) {
+	cmd.Flags().StringSliceVar(&opts.CopyWindows, "copy-window", nil, "Daily window of time, as HH:MM-HH:MM, during which the copy phase runs. The copy phase is paused outside of the copy windows. Can be repeated.")
+	cmd.Flags().StringSliceVar(&opts.BandwidthWindows, "bandwidth-window", nil, "Daily window of time with the maximum bandwidth of the workflow during that window, as HH:MM-HH:MM=<bytes per second>, with an optional KB, MB or GB unit (e.g. 09:00-17:00=10MB). Can be repeated.")
+	cmd.Flags().StringVar(&opts.TimeZone, "schedule-time-zone", "", "Time zone of the copy and bandwidth windows. Defaults to UTC.")
+}
+
+// GetSchedule returns the schedule given by the schedule flags of the
+// command, or nil if none of them were provided.
+func GetSchedule(cmd *cobra.Command, opts *ScheduleOptions) (*binlogdatapb.VReplicationSchedule, error) {
+	changed := false
+	for _, name := range []string{"copy-window", "bandwidth-window", "schedule-time-zone"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	if opts.TimeZone != "" {
+		if _, err := time.LoadLocation(opts.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid schedule-time-zone value: %s", opts.TimeZone)
+		}
+	}
+	schedule := &binlogdatapb.VReplicationSchedule{TimeZone: opts.TimeZone}
+	for _, value := range opts.CopyWindows {
+		window, err := parseScheduleWindow(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid copy-window value: %s, %v", value, err)
+		}
+		schedule.CopyWindows = append(schedule.CopyWindows, window)
+	}
+	for _, value := range opts.BandwidthWindows {
+		times, limit, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid bandwidth-window value: %s, expected HH:MM-HH:MM=<bytes per second>", value)
+		}
+		window, err := parseScheduleWindow(times)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bandwidth-window value: %s, %v", value, err)
+		}
+		if window.MaxBytesPerSecond, err = parseBytes(limit); err != nil {
+			return nil, fmt.Errorf("invalid bandwidth-window value: %s, %v", value, err)
+		}
+		schedule.BandwidthWindows = append(schedule.BandwidthWindows, window)
+	}
+	return schedule, nil
+}
+
+func parseScheduleWindow(value string) (*binlogdatapb.VReplicationScheduleWindow, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return nil, fmt.Errorf("expected HH:MM-HH:MM")
+	}
+	for _, t := range []string{start, end} {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return nil, fmt.Errorf("invalid time %s, expected HH:MM", t)
+		}
+	}
+	return &binlogdatapb.VReplicationScheduleWindow{Start: start, End: end}, nil
+}
+
+func parseBytes(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, multiplier = strings.TrimSuffix(value, unit.suffix), unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth %s, expected a positive number of bytes per second", value)
+	}
+	return n * multiplier, nil
+}
+
+// AddColumnTransformFlag adds the flag for the column transformations of a
+// workflow to the given command.
+func AddColumnTransformFlag(cmd *cobra.Command, transforms *[]string) {
+	cmd.Flags().StringArrayVar(transforms, "column-transform", nil, "Transformation of a column while its rows are copied and replicated, as <table>.<column>=<expression> (e.g. customer.email=sha2(email, 256)). The expression is evaluated on the source tablets and primary key and vindex columns cannot be transformed. Can be repeated.")
+}
+
+// GetColumnTransforms returns the column transformations given by the
+// column-transform flag, keyed by <table>.<column>.
+func GetColumnTransforms(transforms []string) (map[string]string, error) {
+	if len(transforms) == 0 {
+		return nil, nil
+	}
+	columnTransforms := make(map[string]string, len(transforms))
+	for _, value := range transforms {
+		column, expr, ok := strings.Cut(value, "=")
+		column, expr = strings.TrimSpace(column), strings.TrimSpace(expr)
+		if !ok || expr == "" {
+			return nil, fmt.Errorf("invalid column-transform value: %s, expected <table>.<column>=<expression>", value)
+		}
+		if table, col, ok := strings.Cut(column, "."); !ok || table == "" || col == "" {
+			return nil, fmt.Errorf("invalid column-transform value: %s, expected <table>.<column>=<expression>", value)
+		}
+		if _, ok := columnTransforms[column]; ok {
+			return nil, fmt.Errorf("invalid column-transform value: %s, column %s is transformed more than once", value, column)
+		}
+		columnTransforms[column] = expr
+	}
+	return columnTransforms, nil
+}
+
+// AddCreateDryRunFlag adds the flag for the dry run of the creation of a
+// workflow to the given command.
+func AddCreateDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&CreateOptions.DryRun, "dry-run", false, "Validate the workflow and report the streams it would create, the tables they would copy and any known errors that would have occurred, without creating it.")
+}
+
+// OutputCreateDryRunReport prints the report of the dry run of the creation
+// of a workflow.
+func OutputCreateDryRunReport(report *vtctldatapb.WorkflowCreateDryRunReport, format string) error {
+	var output []byte
+	var err error
+	if format != "text" {
+		output, err = cli.Marshal(report, cli.OutputFormat(format))
+		if err != nil {
+			return err
+		}
+	} else {
+		tout := bytes.Buffer{}
+		tout.WriteString(fmt.Sprintf("Dry run of the creation of workflow %s.%s, the following vreplication streams would be created:\n\n",
+			BaseOptions.TargetKeyspace, BaseOptions.Workflow))
+		for _, stream := range report.Streams {
+			tout.WriteString(fmt.Sprintf("%s/%s from source shards %s.\n",
+				BaseOptions.TargetKeyspace, stream.TargetShard, strings.Join(stream.SourceShards, ",")))
+		}
+		if len(report.Tables) > 0 {
+			tout.WriteString("\nThe following tables would be copied:\n\n")
+			for _, table := range report.Tables {
+				tout.WriteString(fmt.Sprintf("%s: estimated rows %d, estimated bytes %d", table.Name, table.EstimatedRows, table.EstimatedBytes))
+				if table.ExistsOnTarget {
+					tout.WriteString(", exists on target")
+				} else {
+					tout.WriteString(", created on target")
+				}
+				if table.Vindex != "" {
+					tout.WriteString(fmt.Sprintf(", sharded by %s(%s)", table.Vindex, strings.Join(table.VindexColumns, ",")))
+				}
+				if table.SourceExpression != "" {
+					tout.WriteString(fmt.Sprintf(", source expression: %s", table.SourceExpression))
+				}
+				tout.WriteString(".\n")
+			}
+		}
+		tout.WriteString(fmt.Sprintf("\nEstimated Data Volume: rows %d, bytes %d.\n", report.EstimatedRows, report.EstimatedBytes))
+		if len(report.Errors) > 0 {
+			tout.WriteString("\nThe following errors would have occurred:\n\n")
+			for _, e := range report.Errors {
+				tout.WriteString(e + "\n")
+			}
+		} else {
+			tout.WriteString("\nNo errors found.")
+		}
+		output = tout.Bytes()
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
 func OutputStatusResponse(resp *vtctldatapb.WorkflowStatusResponse, format string) error {
 	var output []byte
 	var err error
-	if format == "json" {
-		output, err = cli.MarshalJSONPretty(resp)
+	if format != "text" {
+		output, err = cli.Marshal(resp, cli.OutputFormat(format))
 		if err != nil {
 			return err
 		}
@@ -174,6 +444,47 @@ func OutputStatusResponse(resp *vtctldatapb.WorkflowStatusResponse, format strin
 					shardstream.Id, BaseOptions.TargetKeyspace, tablet, shardstream.Status, shardstream.Info))
 			}
 		}
+		if len(resp.TableCopyState) > 0 {
+			tables := make([]string, 0, len(resp.TableCopyState))
+			for table := range resp.TableCopyState {
+				tables = append(tables, table)
+			}
+			sort.Strings(tables)
+			tout.WriteString("\nThe following tables are being copied:\n\n")
+			for _, table := range tables {
+				tcs := resp.TableCopyState[table]
+				tout.WriteString(fmt.Sprintf("%s: rows copied %d/%d (%.2f%%), bytes copied %d/%d (%.2f%%).\n",
+					table, tcs.RowsCopied, tcs.RowsTotal, tcs.RowsPercentage, tcs.BytesCopied, tcs.BytesTotal, tcs.BytesPercentage))
+			}
+		}
+		if cp := resp.CopyProgress; cp != nil {
+			eta := "unknown"
+			if d, ok, err := protoutil.DurationFromProto(cp.Eta); err == nil && ok {
+				eta = d.Round(time.Second).String()
+			}
+			tout.WriteString(fmt.Sprintf("\nCopy Progress: rows copied %d/%d (%.2f%%) at %.0f rows/s, ETA: %s.\n",
+				cp.RowsCopied, cp.RowsTotal, cp.RowsPercentage, cp.RowsPerSecond, eta))
+		}
+		if m := resp.Metrics; m != nil {
+			var eventRate float64
+			for _, rate := range m.EventRates {
+				eventRate += rate
+			}
+			classes := make([]string, 0, len(m.ErrorCounts))
+			for class := range m.ErrorCounts {
+				classes = append(classes, class)
+			}
+			sort.Strings(classes)
+			errs := make([]string, 0, len(classes))
+			for _, class := range classes {
+				errs = append(errs, fmt.Sprintf("%s: %d", class, m.ErrorCounts[class]))
+			}
+			if len(errs) == 0 {
+				errs = append(errs, "none")
+			}
+			tout.WriteString(fmt.Sprintf("\nMetrics: %.0f events/s, %.0f rows copied/s, errors: %s.\n",
+				eventRate, m.CopyRowRate, strings.Join(errs, ", ")))
+		}
 		tout.WriteString("\nTraffic State: ")
 		tout.WriteString(resp.TrafficState)
 		output = tout.Bytes()
@@ -187,7 +498,6 @@ func AddCommonFlags(cmd *cobra.Command) {
 	cmd.MarkPersistentFlagRequired("target-keyspace")
 	cmd.PersistentFlags().StringVarP(&BaseOptions.Workflow, "workflow", "w", "", "The workflow you want to perform the command on.")
 	cmd.MarkPersistentFlagRequired("workflow")
-	cmd.PersistentFlags().StringVar(&BaseOptions.Format, "format", "text", "The format of the output; supported formats are: text,json.")
 }
 
 func AddCommonCreateFlags(cmd *cobra.Command) {
@@ -209,6 +519,10 @@ var SwitchTrafficOptions = struct {
 	DryRun                    bool
 	Direction                 workflow.TrafficSwitchDirection
 	InitializeTargetSequences bool
+	Force                     bool
+	RequireVDiff              bool
+	MaxVDiffAge               time.Duration
+	RequireNoStreamErrors     bool
 }{}
 
 func AddCommonSwitchTrafficFlags(cmd *cobra.Command, initializeTargetSequences bool) {
@@ -218,6 +532,10 @@ func AddCommonSwitchTrafficFlags(cmd *cobra.Command, initializeTargetSequences b
 	cmd.Flags().DurationVar(&SwitchTrafficOptions.MaxReplicationLagAllowed, "max-replication-lag-allowed", MaxReplicationLagDefault, "Allow traffic to be switched only if VReplication lag is below this.")
 	cmd.Flags().BoolVar(&SwitchTrafficOptions.EnableReverseReplication, "enable-reverse-replication", true, "Setup replication going back to the original source keyspace to support rolling back the traffic cutover.")
 	cmd.Flags().BoolVar(&SwitchTrafficOptions.DryRun, "dry-run", false, "Print the actions that would be taken and report any known errors that would have occurred.")
+	cmd.Flags().BoolVar(&SwitchTrafficOptions.Force, "force", false, "Switch traffic without checking the replication lag, the errors of the streams and the VDiff of the workflow.")
+	cmd.Flags().BoolVar(&SwitchTrafficOptions.RequireVDiff, "require-vdiff", false, "Allow traffic to be switched only if the last VDiff of the workflow completed on all of the target shards without finding any differences.")
+	cmd.Flags().DurationVar(&SwitchTrafficOptions.MaxVDiffAge, "max-vdiff-age", 0, "Allow traffic to be switched only if the VDiff required by --require-vdiff completed less than this long ago. 0 means any age.")
+	cmd.Flags().BoolVar(&SwitchTrafficOptions.RequireNoStreamErrors, "require-no-stream-errors", false, "Allow traffic to be switched only if no running stream of the workflow is retrying after an error, as reported by the message of the stream.")
 	if initializeTargetSequences {
 		cmd.Flags().BoolVar(&SwitchTrafficOptions.InitializeTargetSequences, "initialize-target-sequences", false, "When moving tables from an unsharded keyspace to a sharded keyspace, initialize any sequences that are being used on the target when switching writes.")
 	}
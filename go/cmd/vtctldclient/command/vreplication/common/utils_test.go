@@ -20,6 +20,13 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/textutil"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 )
 
 func TestParseAndValidateCreateOptions(t *testing.T) {
@@ -80,3 +87,192 @@ func TestParseAndValidateCreateOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    *binlogdatapb.VReplicationSchedule
+		wantErr string
+	}{
+		{
+			name: "no schedule flags",
+		},
+		{
+			name: "copy and bandwidth windows",
+			flags: map[string]string{
+				"copy-window":        "22:00-06:00,12:00-13:00",
+				"bandwidth-window":   "09:00-17:00=10MB",
+				"schedule-time-zone": "Europe/Paris",
+			},
+			want: &binlogdatapb.VReplicationSchedule{
+				CopyWindows: []*binlogdatapb.VReplicationScheduleWindow{
+					{Start: "22:00", End: "06:00"},
+					{Start: "12:00", End: "13:00"},
+				},
+				BandwidthWindows: []*binlogdatapb.VReplicationScheduleWindow{
+					{Start: "09:00", End: "17:00", MaxBytesPerSecond: 10 << 20},
+				},
+				TimeZone: "Europe/Paris",
+			},
+		},
+		{
+			name:  "bandwidth in bytes",
+			flags: map[string]string{"bandwidth-window": "09:00-17:00=5000"},
+			want: &binlogdatapb.VReplicationSchedule{
+				BandwidthWindows: []*binlogdatapb.VReplicationScheduleWindow{
+					{Start: "09:00", End: "17:00", MaxBytesPerSecond: 5000},
+				},
+			},
+		},
+		{
+			name:    "invalid copy window",
+			flags:   map[string]string{"copy-window": "22:00"},
+			wantErr: "invalid copy-window value: 22:00",
+		},
+		{
+			name:    "invalid time",
+			flags:   map[string]string{"copy-window": "22:00-24:30"},
+			wantErr: "invalid time 24:30",
+		},
+		{
+			name:    "bandwidth window without a limit",
+			flags:   map[string]string{"bandwidth-window": "09:00-17:00"},
+			wantErr: "invalid bandwidth-window value: 09:00-17:00",
+		},
+		{
+			name:    "invalid bandwidth",
+			flags:   map[string]string{"bandwidth-window": "09:00-17:00=fast"},
+			wantErr: "invalid bandwidth FAST",
+		},
+		{
+			name:    "invalid time zone",
+			flags:   map[string]string{"schedule-time-zone": "Nowhere/Special"},
+			wantErr: "invalid schedule-time-zone value: Nowhere/Special",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			opts := &ScheduleOptions{}
+			AddScheduleFlags(cmd, opts)
+			for name, value := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+			schedule, err := GetSchedule(cmd, opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.want, schedule)
+		})
+	}
+}
+
+func TestGetThrottlerSettingsUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    *binlogdatapb.VReplicationThrottlerSettings
+		wantErr string
+	}{
+		{
+			name: "no throttler flags",
+		},
+		{
+			name:  "ratio only",
+			flags: map[string]string{"throttler-ratio": "0.25"},
+			want: &binlogdatapb.VReplicationThrottlerSettings{
+				AppName:  textutil.SimulatedNullString,
+				Ratio:    0.25,
+				Priority: binlogdatapb.VReplicationThrottlerPriority(textutil.SimulatedNullInt),
+			},
+		},
+		{
+			name: "all settings",
+			flags: map[string]string{
+				"throttler-app-name": "",
+				"throttler-ratio":    "0",
+				"throttler-priority": "high",
+			},
+			want: &binlogdatapb.VReplicationThrottlerSettings{
+				Priority: binlogdatapb.VReplicationThrottlerPriority_High,
+			},
+		},
+		{
+			name:    "invalid ratio",
+			flags:   map[string]string{"throttler-ratio": "2"},
+			wantErr: "invalid throttler-ratio value: 2",
+		},
+		{
+			name:    "invalid priority",
+			flags:   map[string]string{"throttler-priority": "urgent"},
+			wantErr: "invalid throttler-priority value: urgent",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			opts := &ThrottlerOptions{}
+			AddThrottlerFlags(cmd, opts)
+			for name, value := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+			settings, err := GetThrottlerSettingsUpdate(cmd, opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.want, settings)
+		})
+	}
+}
+
+func TestGetColumnTransforms(t *testing.T) {
+	tests := []struct {
+		name       string
+		transforms []string
+		want       map[string]string
+		wantErr    string
+	}{
+		{
+			name: "no transforms",
+		},
+		{
+			name:       "transforms",
+			transforms: []string{"customer.email=sha2(email, 256)", "customer.name = upper(name)"},
+			want: map[string]string{
+				"customer.email": "sha2(email, 256)",
+				"customer.name":  "upper(name)",
+			},
+		},
+		{
+			name:       "no expression",
+			transforms: []string{"customer.email"},
+			wantErr:    "invalid column-transform value: customer.email",
+		},
+		{
+			name:       "no table",
+			transforms: []string{"email=sha2(email, 256)"},
+			wantErr:    "invalid column-transform value: email=sha2(email, 256)",
+		},
+		{
+			name:       "duplicate column",
+			transforms: []string{"customer.email=upper(email)", "customer.email=lower(email)"},
+			wantErr:    "column customer.email is transformed more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transforms, err := GetColumnTransforms(tt.transforms)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, transforms)
+		})
+	}
+}
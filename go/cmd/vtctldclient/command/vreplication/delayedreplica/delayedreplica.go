@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delayedreplica
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// delayedReplica is the base command for all actions related to DelayedReplica workflows.
+	delayedReplica = &cobra.Command{
+		Use:   "DelayedReplica --workflow <workflow> --target-keyspace <keyspace> [command] [command-flags]",
+		Short: "DelayedReplica maintains a copy of a keyspace that lags behind the source keyspace by a configurable delay.",
+		Long: `DelayedReplica maintains a copy of a keyspace that lags behind the source keyspace by a configurable delay.
+The delayed copy can be used to recover from accidental data changes: fast-forward the workflow to the position
+right before the change, then complete the workflow to make the tables of the target keyspace servable.`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"delayedreplica"},
+		Args:                  cobra.ExactArgs(1),
+	}
+)
+
+var createOptions = struct {
+	SourceKeyspace string
+	Delay          time.Duration
+	ExcludeTables  []string
+}{}
+
+var createCommand = &cobra.Command{
+	Use:                   "create",
+	Short:                 "Create and optionally run a DelayedReplica VReplication workflow.",
+	Example:               `vtctldclient --server localhost:15999 delayedreplica --workflow delayed --target-keyspace commerce_delayed create --source-keyspace commerce --delay 1h --tablet-types replica`,
+	SilenceUsage:          true,
+	DisableFlagsInUseLine: true,
+	Aliases:               []string{"Create"},
+	Args:                  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if createOptions.Delay < time.Second {
+			return fmt.Errorf("delay must be at least 1s, got %v", createOptions.Delay)
+		}
+		return common.ParseAndValidateCreateOptions(cmd)
+	},
+	RunE: commandCreate,
+}
+
+func commandCreate(cmd *cobra.Command, args []string) error {
+	format, err := common.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	tsp := common.GetTabletSelectionPreference(cmd)
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.DelayedReplicaCreateRequest{
+		Workflow:                  common.BaseOptions.Workflow,
+		SourceKeyspace:            createOptions.SourceKeyspace,
+		TargetKeyspace:            common.BaseOptions.TargetKeyspace,
+		Cells:                     common.CreateOptions.Cells,
+		TabletTypes:               common.CreateOptions.TabletTypes,
+		TabletSelectionPreference: tsp,
+		Delay:                     protoutil.DurationToProto(createOptions.Delay),
+		ExcludeTables:             createOptions.ExcludeTables,
+		OnDdl:                     common.CreateOptions.OnDDL,
+		DeferSecondaryKeys:        common.CreateOptions.DeferSecondaryKeys,
+		AutoStart:                 common.CreateOptions.AutoStart,
+	}
+
+	resp, err := common.GetClient().DelayedReplicaCreate(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	return common.OutputStatusResponse(resp, format)
+}
+
+func addCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&createOptions.SourceKeyspace, "source-keyspace", "", "Keyspace to maintain a delayed copy of.")
+	cmd.MarkFlagRequired("source-keyspace")
+	cmd.Flags().DurationVar(&createOptions.Delay, "delay", time.Hour, "How far behind the source keyspace the changes are applied to the target keyspace.")
+	cmd.Flags().StringSliceVar(&createOptions.ExcludeTables, "exclude-tables", nil, "Source tables to exclude from the delayed copy.")
+	cmd.Flags().StringSliceVarP(&common.CreateOptions.Cells, "cells", "c", nil, "Cells and/or CellAliases to copy table data from.")
+	cmd.Flags().Var((*topoproto.TabletTypeListFlag)(&common.CreateOptions.TabletTypes), "tablet-types", "Source tablet types to replicate table data from (e.g. PRIMARY,REPLICA,RDONLY).")
+	cmd.Flags().BoolVar(&common.CreateOptions.TabletTypesInPreferenceOrder, "tablet-types-in-preference-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
+	cmd.Flags().StringVar(&common.CreateOptions.OnDDL, "on-ddl", binlogdatapb.OnDDLAction_IGNORE.String(), "What to do when DDL is encountered in the VReplication stream. Possible values are IGNORE, STOP, EXEC, and EXEC_IGNORE.")
+	cmd.Flags().BoolVar(&common.CreateOptions.DeferSecondaryKeys, "defer-secondary-keys", false, "Defer secondary index creation for a table until after it has been copied.")
+	cmd.Flags().BoolVar(&common.CreateOptions.AutoStart, "auto-start", true, "Start the workflow after creating it.")
+}
+
+var fastForwardOptions = struct {
+	Position    string
+	SourceShard string
+}{}
+
+var fastForwardCommand = &cobra.Command{
+	Use:   "fast-forward",
+	Short: "Apply the delayed changes of a DelayedReplica workflow up to the given position and stop.",
+	Long: `Apply the delayed changes of a DelayedReplica workflow up to the given position and stop.
+The delay is removed from the streams of the workflow, which then stop once they reach the position.
+The position must be a position of the source shard, e.g. the GTID set right before an accidental change.`,
+	Example:               `vtctldclient --server localhost:15999 delayedreplica --workflow delayed --target-keyspace commerce_delayed fast-forward --position "MySQL56/e9ba1ec8-6a79-11ee-9b83-0242ac120002:1-1234"`,
+	SilenceUsage:          true,
+	DisableFlagsInUseLine: true,
+	Aliases:               []string{"FastForward"},
+	Args:                  cobra.NoArgs,
+	RunE:                  commandFastForward,
+}
+
+func commandFastForward(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().DelayedReplicaFastForward(common.GetCommandCtx(), &vtctldatapb.DelayedReplicaFastForwardRequest{
+		Keyspace:    common.BaseOptions.TargetKeyspace,
+		Workflow:    common.BaseOptions.Workflow,
+		Position:    fastForwardOptions.Position,
+		SourceShard: fastForwardOptions.SourceShard,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func registerCommands(root *cobra.Command) {
+	common.AddCommonFlags(delayedReplica)
+	root.AddCommand(delayedReplica)
+	addCreateFlags(createCommand)
+	delayedReplica.AddCommand(createCommand)
+
+	fastForwardCommand.Flags().StringVar(&fastForwardOptions.Position, "position", "", "Source position to apply the delayed changes up to.")
+	fastForwardCommand.MarkFlagRequired("position")
+	fastForwardCommand.Flags().StringVar(&fastForwardOptions.SourceShard, "source-shard", "", "Source shard the position belongs to. Required when the source keyspace has more than one shard.")
+	delayedReplica.AddCommand(fastForwardCommand)
+
+	opts := &common.SubCommandsOpts{
+		SubCommand: "DelayedReplica",
+		Workflow:   "delayed",
+	}
+	delayedReplica.AddCommand(common.GetCompleteCommand(opts))
+	delayedReplica.AddCommand(common.GetCancelCommand(opts))
+	delayedReplica.AddCommand(common.GetShowCommand(opts))
+	delayedReplica.AddCommand(common.GetStatusCommand(opts))
+	delayedReplica.AddCommand(common.GetStartCommand(opts))
+	delayedReplica.AddCommand(common.GetStopCommand(opts))
+}
+
+func init() {
+	common.RegisterCommandHandler("DelayedReplica", registerCommands)
+}
@@ -263,7 +263,7 @@ func commandShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSONPretty(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -33,8 +33,11 @@ import (
 
 var (
 	createOptions = struct {
-		SourceKeyspace string
-		TableSettings  tableSettings
+		SourceKeyspace  string
+		ExternalCluster string
+		TableSettings   tableSettings
+		Throttler       common.ThrottlerOptions
+		Schedule        common.ScheduleOptions
 	}{}
 
 	// create makes a MaterializeCreate gRPC call to a vtctld.
@@ -89,29 +92,44 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	tsp := common.GetTabletSelectionPreference(cmd)
+	throttlerSettings, err := common.GetThrottlerSettings(cmd, &createOptions.Throttler)
+	if err != nil {
+		return err
+	}
+	schedule, err := common.GetSchedule(cmd, &createOptions.Schedule)
+	if err != nil {
+		return err
+	}
 	cli.FinishedParsing(cmd)
 
 	ms := &vtctldatapb.MaterializeSettings{
 		Workflow:                  common.BaseOptions.Workflow,
 		TargetKeyspace:            common.BaseOptions.TargetKeyspace,
 		SourceKeyspace:            createOptions.SourceKeyspace,
+		ExternalCluster:           createOptions.ExternalCluster,
 		TableSettings:             createOptions.TableSettings.val,
 		StopAfterCopy:             common.CreateOptions.StopAfterCopy,
 		Cell:                      strings.Join(common.CreateOptions.Cells, ","),
 		TabletTypes:               topoproto.MakeStringTypeCSV(common.CreateOptions.TabletTypes),
 		TabletSelectionPreference: tsp,
+		ThrottlerSettings:         throttlerSettings,
+		Schedule:                  schedule,
 	}
 
 	req := &vtctldatapb.MaterializeCreateRequest{
 		Settings: ms,
+		DryRun:   common.CreateOptions.DryRun,
 	}
 
-	_, err = common.GetClient().MaterializeCreate(common.GetCommandCtx(), req)
+	resp, err := common.GetClient().MaterializeCreate(common.GetCommandCtx(), req)
 	if err != nil {
 		return err
 	}
+	if req.DryRun {
+		return common.OutputCreateDryRunReport(resp.DryRunReport, format)
+	}
 
-	if format == "json" {
+	if format != "text" {
 		resp := struct {
 			Action string
 			Status string
@@ -119,7 +137,7 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 			Action: "create",
 			Status: "success",
 		}
-		jsonText, _ := cli.MarshalJSONPretty(resp)
+		jsonText, _ := cli.Marshal(resp, cli.OutputFormat(format))
 		fmt.Println(string(jsonText))
 	} else {
 		fmt.Printf("Materialization workflow %s successfully created in the %s keyspace. Use show to view the status.\n",
@@ -43,9 +43,13 @@ func registerCommands(root *cobra.Command) {
 	create.Flags().BoolVar(&common.CreateOptions.TabletTypesInPreferenceOrder, "tablet-types-in-preference-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
 	create.Flags().StringVar(&createOptions.SourceKeyspace, "source-keyspace", "", "Keyspace where the tables queried in the 'source_expression' values within table-settings live.")
 	create.MarkFlagRequired("source-keyspace")
+	create.Flags().StringVar(&createOptions.ExternalCluster, "mount-name", "", "Name the external Vitess cluster of the source keyspace is mounted as, if any.")
 	create.Flags().Var(&createOptions.TableSettings, "table-settings", "A JSON array defining what tables to materialize using what select statements. See the --help output for more details.")
 	create.MarkFlagRequired("table-settings")
 	create.Flags().BoolVar(&common.CreateOptions.StopAfterCopy, "stop-after-copy", false, "Stop the workflow after it's finished copying the existing rows and before it starts replicating changes.")
+	common.AddThrottlerFlags(create, &createOptions.Throttler)
+	common.AddScheduleFlags(create, &createOptions.Schedule)
+	common.AddCreateDryRunFlag(create)
 	base.AddCommand(create)
 
 	// Generic workflow commands.
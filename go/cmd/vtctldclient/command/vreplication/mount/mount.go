@@ -40,10 +40,11 @@ var (
 )
 
 var mountOptions struct {
-	Name       string
-	TopoType   string
-	TopoServer string
-	TopoRoot   string
+	Name          string
+	TopoType      string
+	TopoServer    string
+	TopoRoot      string
+	VtgateAddress string
 }
 
 var register = &cobra.Command{
@@ -60,10 +61,11 @@ func commandRegister(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	req := &vtctldatapb.MountRegisterRequest{
-		Name:       mountOptions.Name,
-		TopoType:   mountOptions.TopoType,
-		TopoServer: mountOptions.TopoServer,
-		TopoRoot:   mountOptions.TopoRoot,
+		Name:          mountOptions.Name,
+		TopoType:      mountOptions.TopoType,
+		TopoServer:    mountOptions.TopoServer,
+		TopoRoot:      mountOptions.TopoRoot,
+		VtgateAddress: mountOptions.VtgateAddress,
 	}
 	_, err := common.GetClient().MountRegister(common.GetCommandCtx(), req)
 	if err != nil {
@@ -117,7 +119,7 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	data, err := json.Marshal(resp)
+	data, err := marshalMountOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -146,7 +148,7 @@ func commandList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	data, err := json.Marshal(resp)
+	data, err := marshalMountOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -154,6 +156,17 @@ func commandList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// marshalMountOutput marshals the response of a mount command. Their JSON
+// output has always been compact, so it is kept that way.
+func marshalMountOutput(resp any) ([]byte, error) {
+	switch cli.Format {
+	case cli.OutputFormatText, cli.OutputFormatJSON:
+		return json.Marshal(resp)
+	default:
+		return cli.MarshalOutput(resp)
+	}
+}
+
 func registerCommands(root *cobra.Command) {
 	root.AddCommand(base)
 
@@ -165,6 +178,7 @@ func registerCommands(root *cobra.Command) {
 	register.MarkFlagRequired("topo-server")
 	register.Flags().StringVar(&mountOptions.TopoRoot, "topo-root", "", "Topo server root path.")
 	register.MarkFlagRequired("topo-root")
+	register.Flags().StringVar(&mountOptions.VtgateAddress, "vtgate-address", "", "Address of a vtgate in the external cluster. If specified, workflows stream from the external cluster through this vtgate instead of connecting to its tablets directly.")
 	base.AddCommand(register)
 
 	unregister.Flags().StringVar(&mountOptions.Name, "name", "", "Name of the mount.")
@@ -39,6 +39,10 @@ var (
 		SourceTimeZone      string
 		NoRoutingRules      bool
 		AtomicCopy          bool
+		TenantID            string
+		Throttler           common.ThrottlerOptions
+		Schedule            common.ScheduleOptions
+		ColumnTransforms    []string
 	}{}
 
 	// create makes a MoveTablesCreate gRPC call to a vtctld.
@@ -89,6 +93,18 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	tsp := common.GetTabletSelectionPreference(cmd)
+	throttlerSettings, err := common.GetThrottlerSettings(cmd, &createOptions.Throttler)
+	if err != nil {
+		return err
+	}
+	schedule, err := common.GetSchedule(cmd, &createOptions.Schedule)
+	if err != nil {
+		return err
+	}
+	columnTransforms, err := common.GetColumnTransforms(createOptions.ColumnTransforms)
+	if err != nil {
+		return err
+	}
 	cli.FinishedParsing(cmd)
 
 	req := &vtctldatapb.MoveTablesCreateRequest{
@@ -96,6 +112,7 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		TargetKeyspace:            common.BaseOptions.TargetKeyspace,
 		SourceKeyspace:            createOptions.SourceKeyspace,
 		SourceShards:              createOptions.SourceShards,
+		ExternalClusterName:       createOptions.ExternalClusterName,
 		SourceTimeZone:            createOptions.SourceTimeZone,
 		Cells:                     common.CreateOptions.Cells,
 		TabletTypes:               common.CreateOptions.TabletTypes,
@@ -109,12 +126,20 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		StopAfterCopy:             common.CreateOptions.StopAfterCopy,
 		NoRoutingRules:            createOptions.NoRoutingRules,
 		AtomicCopy:                createOptions.AtomicCopy,
+		TenantId:                  createOptions.TenantID,
+		ThrottlerSettings:         throttlerSettings,
+		Schedule:                  schedule,
+		ColumnTransforms:          columnTransforms,
+		DryRun:                    common.CreateOptions.DryRun,
 	}
 
 	resp, err := common.GetClient().MoveTablesCreate(common.GetCommandCtx(), req)
 	if err != nil {
 		return err
 	}
+	if req.DryRun {
+		return common.OutputCreateDryRunReport(resp.DryRunReport, format)
+	}
 	if err = common.OutputStatusResponse(resp, format); err != nil {
 		return err
 	}
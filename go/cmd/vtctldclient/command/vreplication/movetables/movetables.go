@@ -38,15 +38,21 @@ func registerCommands(root *cobra.Command) {
 	root.AddCommand(base)
 
 	common.AddCommonCreateFlags(create)
+	common.AddThrottlerFlags(create, &createOptions.Throttler)
+	common.AddScheduleFlags(create, &createOptions.Schedule)
+	common.AddColumnTransformFlag(create, &createOptions.ColumnTransforms)
+	common.AddCreateDryRunFlag(create)
 	create.PersistentFlags().StringVar(&createOptions.SourceKeyspace, "source-keyspace", "", "Keyspace where the tables are being moved from.")
 	create.MarkPersistentFlagRequired("source-keyspace")
 	create.Flags().StringSliceVar(&createOptions.SourceShards, "source-shards", nil, "Source shards to copy data from when performing a partial MoveTables (experimental).")
+	create.Flags().StringVar(&createOptions.ExternalClusterName, "mount-name", "", "Name the external Vitess cluster of the source keyspace is mounted as, if any.")
 	create.Flags().StringVar(&createOptions.SourceTimeZone, "source-time-zone", "", "Specifying this causes any DATETIME fields to be converted from the given time zone into UTC.")
 	create.Flags().BoolVar(&createOptions.AllTables, "all-tables", false, "Copy all tables from the source.")
 	create.Flags().StringSliceVar(&createOptions.IncludeTables, "tables", nil, "Source tables to copy.")
 	create.Flags().StringSliceVar(&createOptions.ExcludeTables, "exclude-tables", nil, "Source tables to exclude from copying.")
 	create.Flags().BoolVar(&createOptions.NoRoutingRules, "no-routing-rules", false, "(Advanced) Do not create routing rules while creating the workflow. See the reference documentation for limitations if you use this flag.")
 	create.Flags().BoolVar(&createOptions.AtomicCopy, "atomic-copy", false, "(EXPERIMENTAL) A single copy phase is run for all tables from the source. Use this, for example, if your source keyspace has tables which use foreign key constraints.")
+	create.Flags().StringVar(&createOptions.TenantID, "tenant-id", "", "(EXPERIMENTAL) Move only the rows of this tenant. The tenant is identified by the value of the primary vindex column of the tables in the sharded source keyspace.")
 	base.AddCommand(create)
 
 	opts := &common.SubCommandsOpts{
@@ -27,9 +27,12 @@ import (
 
 var (
 	reshardCreateOptions = struct {
-		sourceShards   []string
-		targetShards   []string
-		skipSchemaCopy bool
+		sourceShards     []string
+		targetShards     []string
+		skipSchemaCopy   bool
+		throttler        common.ThrottlerOptions
+		schedule         common.ScheduleOptions
+		columnTransforms []string
 	}{}
 
 	// reshardCreate makes a ReshardCreate gRPC call to a vtctld.
@@ -57,6 +60,18 @@ func commandReshardCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	tsp := common.GetTabletSelectionPreference(cmd)
+	throttlerSettings, err := common.GetThrottlerSettings(cmd, &reshardCreateOptions.throttler)
+	if err != nil {
+		return err
+	}
+	schedule, err := common.GetSchedule(cmd, &reshardCreateOptions.schedule)
+	if err != nil {
+		return err
+	}
+	columnTransforms, err := common.GetColumnTransforms(reshardCreateOptions.columnTransforms)
+	if err != nil {
+		return err
+	}
 	cli.FinishedParsing(cmd)
 
 	req := &vtctldatapb.ReshardCreateRequest{
@@ -70,15 +85,22 @@ func commandReshardCreate(cmd *cobra.Command, args []string) error {
 		DeferSecondaryKeys:        common.CreateOptions.DeferSecondaryKeys,
 		AutoStart:                 common.CreateOptions.AutoStart,
 		StopAfterCopy:             common.CreateOptions.StopAfterCopy,
+		ThrottlerSettings:         throttlerSettings,
+		Schedule:                  schedule,
+		ColumnTransforms:          columnTransforms,
 
 		SourceShards:   reshardCreateOptions.sourceShards,
 		TargetShards:   reshardCreateOptions.targetShards,
 		SkipSchemaCopy: reshardCreateOptions.skipSchemaCopy,
+		DryRun:         common.CreateOptions.DryRun,
 	}
 	resp, err := common.GetClient().ReshardCreate(common.GetCommandCtx(), req)
 	if err != nil {
 		return err
 	}
+	if req.DryRun {
+		return common.OutputCreateDryRunReport(resp.DryRunReport, format)
+	}
 	if err = common.OutputStatusResponse(resp, format); err != nil {
 		return err
 	}
@@ -87,6 +109,10 @@ func commandReshardCreate(cmd *cobra.Command, args []string) error {
 
 func registerCreateCommand(root *cobra.Command) {
 	common.AddCommonCreateFlags(reshardCreate)
+	common.AddThrottlerFlags(reshardCreate, &reshardCreateOptions.throttler)
+	common.AddScheduleFlags(reshardCreate, &reshardCreateOptions.schedule)
+	common.AddColumnTransformFlag(reshardCreate, &reshardCreateOptions.columnTransforms)
+	common.AddCreateDryRunFlag(reshardCreate)
 	reshardCreate.Flags().StringSliceVar(&reshardCreateOptions.sourceShards, "source-shards", nil, "Source shards.")
 	reshardCreate.Flags().StringSliceVar(&reshardCreateOptions.targetShards, "target-shards", nil, "Target shards.")
 	reshardCreate.Flags().BoolVar(&reshardCreateOptions.skipSchemaCopy, "skip-schema-copy", false, "Skip copying the schema from the source shards to the target shards.")
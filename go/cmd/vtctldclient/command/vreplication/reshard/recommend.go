@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reshard
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	reshardRecommendOptions = struct {
+		sourceShards      []string
+		shardCount        uint32
+		maxShardSizeBytes uint64
+		maxShardQPS       uint64
+		sampleSize        uint32
+	}{}
+
+	// reshardRecommend makes a ReshardRecommend gRPC call to a vtctld.
+	reshardRecommend = &cobra.Command{
+		Use:   "recommend",
+		Short: "Recommend the target shards of a Reshard workflow from the size, key distribution and QPS of the source shards.",
+		Long: `Recommend the target shards of a Reshard workflow. The size of the tables and the QPS of the source shards are
+estimated, and keyspace ids are sampled from the rows of the tables, to split the source key range into target
+shards of similar size and QPS. The number of target shards is either given, or derived from the maximum size and
+QPS of a target shard.`,
+		Example:               `vtctldclient --server localhost:15999 reshard --workflow customer2customer --target-keyspace customer recommend --source-shards="0" --max-shard-size-bytes 107374182400`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Recommend"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandReshardRecommend,
+	}
+)
+
+func commandReshardRecommend(cmd *cobra.Command, args []string) error {
+	format, err := common.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if reshardRecommendOptions.shardCount == 0 && reshardRecommendOptions.maxShardSizeBytes == 0 && reshardRecommendOptions.maxShardQPS == 0 {
+		return fmt.Errorf("one of --shard-count, --max-shard-size-bytes or --max-shard-qps must be specified")
+	}
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.ReshardRecommendRequest{
+		Keyspace:          common.BaseOptions.TargetKeyspace,
+		SourceShards:      reshardRecommendOptions.sourceShards,
+		ShardCount:        reshardRecommendOptions.shardCount,
+		MaxShardSizeBytes: reshardRecommendOptions.maxShardSizeBytes,
+		MaxShardQps:       reshardRecommendOptions.maxShardQPS,
+		SampleSize:        reshardRecommendOptions.sampleSize,
+	}
+	resp, err := common.GetClient().ReshardRecommend(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	var output []byte
+	if format != "text" {
+		output, err = cli.Marshal(resp, cli.OutputFormat(format))
+		if err != nil {
+			return err
+		}
+	} else {
+		tout := bytes.Buffer{}
+		tout.WriteString(fmt.Sprintf("Source shards %s of keyspace %s: estimated bytes %d, estimated QPS %.1f.\n",
+			strings.Join(resp.SourceShards, ","), common.BaseOptions.TargetKeyspace, resp.TotalBytes, resp.TotalQps))
+		if len(resp.Tables) > 0 {
+			tout.WriteString("\nTables:\n\n")
+			for _, table := range resp.Tables {
+				tout.WriteString(fmt.Sprintf("%s: estimated rows %d, estimated bytes %d, sampled rows %d", table.Name, table.Rows, table.Bytes, table.Samples))
+				if table.Vindex != "" {
+					tout.WriteString(fmt.Sprintf(", sharded by %s", table.Vindex))
+				}
+				tout.WriteString(".\n")
+			}
+		}
+		tout.WriteString("\nKey distribution:\n\n")
+		for _, weight := range resp.KeyDistribution {
+			tout.WriteString(fmt.Sprintf("%s: %.1f%%\n", weight.KeyRange, weight.Weight*100))
+		}
+		tout.WriteString("\nRecommended target shards:\n\n")
+		var names []string
+		for _, shard := range resp.TargetShards {
+			names = append(names, shard.Name)
+			tout.WriteString(fmt.Sprintf("%s: estimated rows %d, estimated bytes %d, estimated QPS %.1f.\n",
+				shard.Name, shard.EstimatedRows, shard.EstimatedBytes, shard.EstimatedQps))
+		}
+		if len(resp.Warnings) > 0 {
+			tout.WriteString("\nWarnings:\n\n")
+			for _, warning := range resp.Warnings {
+				tout.WriteString(warning + "\n")
+			}
+		}
+		tout.WriteString(fmt.Sprintf("\nThe workflow can be created with: vtctldclient Reshard --workflow %s --target-keyspace %s create --source-shards=%q --target-shards=%q",
+			common.BaseOptions.Workflow, common.BaseOptions.TargetKeyspace, strings.Join(resp.SourceShards, ","), strings.Join(names, ",")))
+		output = tout.Bytes()
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func registerRecommendCommand(root *cobra.Command) {
+	reshardRecommend.Flags().StringSliceVar(&reshardRecommendOptions.sourceShards, "source-shards", nil, "Source shards. Defaults to the serving shards of the keyspace.")
+	reshardRecommend.Flags().Uint32Var(&reshardRecommendOptions.shardCount, "shard-count", 0, "Number of target shards to recommend. If not set, it is derived from --max-shard-size-bytes and --max-shard-qps.")
+	reshardRecommend.Flags().Uint64Var(&reshardRecommendOptions.maxShardSizeBytes, "max-shard-size-bytes", 0, "Maximum estimated size of a target shard, in bytes.")
+	reshardRecommend.Flags().Uint64Var(&reshardRecommendOptions.maxShardQPS, "max-shard-qps", 0, "Maximum estimated QPS of a target shard.")
+	reshardRecommend.Flags().Uint32Var(&reshardRecommendOptions.sampleSize, "sample-size", 1000, "Maximum number of rows sampled per table and source shard to estimate the key distribution.")
+	root.AddCommand(reshardRecommend)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package vdiff
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -66,6 +67,8 @@ var (
 		Wait                        bool
 		WaitUpdateInterval          time.Duration
 		AutoRetry                   bool
+		ScheduleInterval            time.Duration
+		HistoryRetention            uint32 // We only accept positive values but pass on an int64
 	}{}
 
 	deleteOptions = struct {
@@ -79,6 +82,8 @@ var (
 	showOptions = struct {
 		Arg     string
 		Verbose bool
+		All     bool
+		Watch   cli.WatchOptions
 	}{}
 
 	stopOptions = struct {
@@ -188,7 +193,8 @@ vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --targe
 		Short: "Show the status of a VDiff.",
 		Example: `vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace show last
 vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace show a037a9e2-5628-11ee-8c99-0242ac120002
-vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace show all`,
+vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace show all
+vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace show --all last`,
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"Show"},
 		Args:                  cobra.ExactArgs(1),
@@ -202,6 +208,9 @@ vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --targe
 						args[0])
 				}
 			}
+			if showOptions.All && larg == "all" {
+				return fmt.Errorf("the --all flag can only be used with 'last' or a valid UUID")
+			}
 			showOptions.Arg = larg
 			return nil
 		},
@@ -241,12 +250,12 @@ func displaySimpleResponse(out io.Writer, format string, action vdiff.VDiffActio
 	if action == vdiff.ResumeAction {
 		status = "scheduled"
 	}
-	if format == "json" {
+	if format != "text" {
 		resp := &simpleResponse{
 			Action: action,
 			Status: status,
 		}
-		jsonText, _ := cli.MarshalJSONPretty(resp)
+		jsonText, _ := cli.Marshal(resp, cli.OutputFormat(format))
 		fmt.Fprintln(out, string(jsonText))
 	} else {
 		fmt.Fprintf(out, "VDiff %s %s\n", action, status)
@@ -279,6 +288,8 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		Wait:                        createOptions.Wait,
 		WaitUpdateInterval:          protoutil.DurationToProto(createOptions.WaitUpdateInterval),
 		AutoRetry:                   createOptions.AutoRetry,
+		ScheduleInterval:            protoutil.DurationToProto(createOptions.ScheduleInterval),
+		HistoryRetention:            int64(createOptions.HistoryRetention),
 	})
 
 	if err != nil {
@@ -315,8 +326,8 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		var data []byte
-		if format == "json" {
-			data, err = cli.MarshalJSONPretty(resp)
+		if format != "text" {
+			data, err = cli.Marshal(resp, cli.OutputFormat(format))
 			if err != nil {
 				return err
 			}
@@ -399,6 +410,18 @@ type summary struct {
 	Reports            map[string]map[string]vdiff.DiffReport `json:"Reports,omitempty"`
 	Errors             map[string]string                      `json:"Errors,omitempty"`
 	Progress           *vdiff.ProgressReport                  `json:"Progress,omitempty"`
+	History            []*historySummary                      `json:"History,omitempty"`
+}
+
+// historySummary is the report of a previous run of a scheduled vdiff on a shard.
+type historySummary struct {
+	Shard       string
+	State       vdiff.VDiffState
+	HasMismatch bool
+	StartedAt   string                      `json:"StartedAt,omitempty"`
+	CompletedAt string                      `json:"CompletedAt,omitempty"`
+	Error       string                      `json:"Error,omitempty"`
+	Reports     map[string]vdiff.DiffReport `json:"Reports,omitempty"`
 }
 
 const summaryTextTemplate = `
@@ -423,6 +446,11 @@ Table {{$table.TableName}}:
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
 {{end}}
+{{if .History}}History:
+{{- range $run := .History}}
+	{{$run.StartedAt}} (shard {{$run.Shard}}) State: {{$run.State}}, HasMismatch: {{$run.HasMismatch}}{{if $run.Error}}, Error: {{$run.Error}}{{end}}
+{{- end}}
+{{end}}
  
 Use "--format=json" for more detailed output.
 `
@@ -471,22 +499,25 @@ func buildListings(listings []*listing) string {
 	return result
 }
 
-func displayShowResponse(out io.Writer, format, keyspace, workflowName, actionArg string, resp *vtctldatapb.VDiffShowResponse, verbose bool) error {
+// displayShowResponse displays the response of a show command and returns
+// the state of the vdiff it shows, which is unknown if it shows the list of
+// the recent vdiffs or if there is no vdiff to show.
+func displayShowResponse(out io.Writer, format, keyspace, workflowName, actionArg string, resp *vtctldatapb.VDiffShowResponse, verbose bool) (vdiff.VDiffState, error) {
 	var vdiffUUID uuid.UUID
 	var err error
 	switch actionArg {
 	case vdiff.AllActionArg:
-		return displayShowRecent(out, format, keyspace, workflowName, actionArg, resp)
+		return vdiff.UnknownState, displayShowRecent(out, format, keyspace, workflowName, actionArg, resp)
 	case vdiff.LastActionArg:
 		for _, resp := range resp.TabletResponses {
 			vdiffUUID, err = uuid.Parse(resp.VdiffUuid)
 			if err != nil {
-				if format == "json" {
+				if format != "text" {
 					fmt.Fprintln(out, "{}")
 				} else {
 					fmt.Fprintf(out, "No previous vdiff found for %s.%s\n", keyspace, workflowName)
 				}
-				return nil
+				return vdiff.UnknownState, nil
 			}
 			break
 		}
@@ -495,14 +526,13 @@ func displayShowResponse(out io.Writer, format, keyspace, workflowName, actionAr
 		if vdiffUUID == uuid.Nil { // Then it must be passed as the action arg
 			vdiffUUID, err = uuid.Parse(actionArg)
 			if err != nil {
-				return err
+				return vdiff.UnknownState, err
 			}
 		}
 		if len(resp.TabletResponses) == 0 {
-			return fmt.Errorf("no response received for vdiff show of %s.%s (%s)", keyspace, workflowName, vdiffUUID.String())
+			return vdiff.UnknownState, fmt.Errorf("no response received for vdiff show of %s.%s (%s)", keyspace, workflowName, vdiffUUID.String())
 		}
-		_, err = displayShowSingleSummary(out, format, keyspace, workflowName, vdiffUUID.String(), resp, verbose)
-		return err
+		return displayShowSingleSummary(out, format, keyspace, workflowName, vdiffUUID.String(), resp, verbose)
 	}
 }
 
@@ -512,8 +542,8 @@ func displayShowRecent(out io.Writer, format, keyspace, workflowName, subCommand
 	if err != nil {
 		return err
 	}
-	if format == "json" {
-		jsonText, err := cli.MarshalJSONPretty(recentListings)
+	if format != "text" {
+		jsonText, err := cli.Marshal(recentListings, cli.OutputFormat(format))
 		if err != nil {
 			return err
 		}
@@ -561,8 +591,8 @@ func displayShowSingleSummary(out io.Writer, format, keyspace, workflowName, uui
 		return state, fmt.Errorf("no report to show for vdiff %s.%s (%s)", keyspace, workflowName, uuid)
 	}
 	state = summary.State
-	if format == "json" {
-		jsonText, err := cli.MarshalJSONPretty(summary)
+	if format != "text" {
+		jsonText, err := cli.Marshal(summary, cli.OutputFormat(format))
 		if err != nil {
 			return state, err
 		}
@@ -779,9 +809,56 @@ func buildSingleSummary(keyspace, workflow, uuid string, resp *vtctldatapb.VDiff
 	if summary.State != vdiff.CompletedState {
 		summary.CompletedAt = ""
 	}
+	history, err := buildHistory(resp, verbose)
+	if err != nil {
+		return nil, err
+	}
+	summary.History = history
 	return summary, nil
 }
 
+// buildHistory builds the summaries of the previous runs of a scheduled vdiff
+// across all shards, most recent first.
+func buildHistory(resp *vtctldatapb.VDiffShowResponse, verbose bool) ([]*historySummary, error) {
+	var history []*historySummary
+	for shard, resp := range resp.TabletResponses {
+		if resp == nil || resp.History == nil {
+			continue
+		}
+		qr := sqltypes.Proto3ToResult(resp.History)
+		for _, row := range qr.Named().Rows {
+			hs := &historySummary{
+				Shard:       shard,
+				State:       vdiff.VDiffState(strings.ToLower(row.AsString("state", ""))),
+				StartedAt:   row.AsString("started_at", ""),
+				CompletedAt: row.AsString("completed_at", ""),
+				Error:       row.AsString("last_error", ""),
+			}
+			if report := row.AsString("report", ""); report != "" {
+				if err := json.Unmarshal([]byte(report), &hs.Reports); err != nil {
+					return nil, err
+				}
+			}
+			for _, dr := range hs.Reports {
+				if dr.MismatchedRows > 0 || dr.ExtraRowsSource > 0 || dr.ExtraRowsTarget > 0 {
+					hs.HasMismatch = true
+				}
+			}
+			if !hs.HasMismatch && !verbose {
+				hs.Reports = nil
+			}
+			history = append(history, hs)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].StartedAt == history[j].StartedAt {
+			return history[i].Shard < history[j].Shard
+		}
+		return history[i].StartedAt > history[j].StartedAt
+	})
+	return history, nil
+}
+
 func buildProgressReport(summary *summary, rowsToCompare int64) {
 	report := &vdiff.ProgressReport{}
 	if summary.RowsCompared >= 1 {
@@ -813,21 +890,31 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	}
 	cli.FinishedParsing(cmd)
 
-	resp, err := common.GetClient().VDiffShow(common.GetCommandCtx(), &vtctldatapb.VDiffShowRequest{
-		Workflow:       common.BaseOptions.Workflow,
-		TargetKeyspace: common.BaseOptions.TargetKeyspace,
-		Arg:            showOptions.Arg,
-	})
-
-	if err != nil {
-		return err
-	}
-
-	if err = displayShowResponse(cmd.OutOrStdout(), format, common.BaseOptions.TargetKeyspace, common.BaseOptions.Workflow, showOptions.Arg, resp, showOptions.Verbose); err != nil {
-		return err
+	if showOptions.Watch.Watch && showOptions.Arg == vdiff.AllActionArg {
+		return fmt.Errorf("--watch can only be used with 'last' or a valid UUID")
 	}
+	return cli.Watch(common.GetCommandCtx(), showOptions.Watch, func(ctx context.Context) (bool, error) {
+		resp, err := common.GetClient().VDiffShow(ctx, &vtctldatapb.VDiffShowRequest{
+			Workflow:       common.BaseOptions.Workflow,
+			TargetKeyspace: common.BaseOptions.TargetKeyspace,
+			Arg:            showOptions.Arg,
+			IncludeHistory: showOptions.All,
+		})
+		if err != nil {
+			return false, err
+		}
 
-	return nil
+		state, err := displayShowResponse(cmd.OutOrStdout(), format, common.BaseOptions.TargetKeyspace, common.BaseOptions.Workflow, showOptions.Arg, resp, showOptions.Verbose)
+		if err != nil {
+			return false, err
+		}
+		switch state {
+		case vdiff.PendingState, vdiff.StartedState:
+			return false, nil
+		default:
+			return true, nil
+		}
+	})
 }
 
 func commandStop(cmd *cobra.Command, args []string) error {
@@ -869,6 +956,8 @@ func registerCommands(root *cobra.Command) {
 	create.Flags().BoolVar(&createOptions.Wait, "wait", false, "When creating or resuming a vdiff, wait for it to finish before exiting.")
 	create.Flags().DurationVar(&createOptions.WaitUpdateInterval, "wait-update-interval", time.Duration(1*time.Minute), "When waiting on a vdiff to finish, check and display the current status this often.")
 	create.Flags().BoolVar(&createOptions.AutoRetry, "auto-retry", true, "Should this vdiff automatically retry and continue in case of recoverable errors.")
+	create.Flags().DurationVar(&createOptions.ScheduleInterval, "schedule-interval", 0, "Run the vdiff again this often, for as long as the workflow is running. The reports of the previous runs are kept and can be seen using 'show --all'.")
+	create.Flags().Uint32Var(&createOptions.HistoryRetention, "history-retention", 10, "The number of reports of previous runs of a scheduled vdiff to keep.")
 	create.Flags().BoolVar(&createOptions.UpdateTableStats, "update-table-stats", false, "Update the table statistics, using ANALYZE TABLE, on each table involved in the VDiff during initialization. This will ensure that progress estimates are as accurate as possible -- but it does involve locks and can potentially impact query processing on the target keyspace.")
 	base.AddCommand(create)
 
@@ -877,6 +966,8 @@ func registerCommands(root *cobra.Command) {
 	base.AddCommand(resume)
 
 	show.Flags().BoolVar(&showOptions.Verbose, "verbose", false, "Show verbose output in summaries")
+	show.Flags().BoolVar(&showOptions.All, "all", false, "Also show the reports of the previous runs of a scheduled vdiff.")
+	cli.AddWatchFlags(show, &showOptions.Watch, "the vdiff is completed, stopped or has failed")
 	base.AddCommand(show)
 
 	base.AddCommand(stop)
@@ -528,3 +528,44 @@ func TestBuildProgressReport(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildHistory(t *testing.T) {
+	historyFields := sqltypes.MakeTestFields(
+		"id|state|last_error|started_at|completed_at|report",
+		"int64|varbinary|varbinary|timestamp|timestamp|json",
+	)
+	resp := &vtctldatapb.VDiffShowResponse{
+		TabletResponses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": {
+				History: sqltypes.ResultToProto3(sqltypes.MakeTestResult(historyFields,
+					`2|completed||2023-10-02 10:00:00|2023-10-02 10:05:00|{"t1": {"TableName": "t1", "ProcessedRows": 10, "MatchingRows": 9, "MismatchedRows": 1}}`,
+					`1|completed||2023-10-01 10:00:00|2023-10-01 10:05:00|{"t1": {"TableName": "t1", "ProcessedRows": 10, "MatchingRows": 10}}`,
+				)),
+			},
+			"80-": {
+				History: sqltypes.ResultToProto3(sqltypes.MakeTestResult(historyFields,
+					`3|error|lock wait timeout exceeded|2023-10-02 10:00:00||`,
+				)),
+			},
+		},
+	}
+
+	history, err := buildHistory(resp, false)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	require.Equal(t, "-80", history[0].Shard)
+	require.Equal(t, vdiff.CompletedState, history[0].State)
+	require.True(t, history[0].HasMismatch)
+	require.EqualValues(t, 1, history[0].Reports["t1"].MismatchedRows)
+
+	require.Equal(t, "80-", history[1].Shard)
+	require.Equal(t, vdiff.ErrorState, history[1].State)
+	require.Equal(t, "lock wait timeout exceeded", history[1].Error)
+	require.Empty(t, history[1].CompletedAt)
+
+	require.Equal(t, "-80", history[2].Shard)
+	require.Equal(t, "2023-10-01 10:00:00", history[2].StartedAt)
+	require.False(t, history[2].HasMismatch)
+	require.Nil(t, history[2].Reports)
+}
@@ -65,7 +65,7 @@ func commandDelete(cmd *cobra.Command, args []string) error {
 		return resp.Details[i].Tablet.String() < resp.Details[j].Tablet.String()
 	})
 
-	data, err := cli.MarshalJSONPretty(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ func commandGetWorkflows(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSONPretty(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
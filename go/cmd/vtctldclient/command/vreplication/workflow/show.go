@@ -17,6 +17,7 @@ limitations under the License.
 package workflow
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -56,30 +58,51 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	req := &vtctldatapb.GetWorkflowsRequest{
-		Keyspace:    baseOptions.Keyspace,
-		Workflow:    baseOptions.Workflow,
-		IncludeLogs: workflowShowOptions.IncludeLogs,
-	}
-	resp, err := common.GetClient().GetWorkflows(common.GetCommandCtx(), req)
-	if err != nil {
-		return err
+		Keyspace:            baseOptions.Keyspace,
+		Workflow:            baseOptions.Workflow,
+		IncludeLogs:         workflowShowOptions.IncludeLogs,
+		IncludeCopyProgress: workflowShowOptions.IncludeCopyProgress,
 	}
+	return cli.Watch(common.GetCommandCtx(), workflowShowOptions.Watch, func(ctx context.Context) (bool, error) {
+		resp, err := common.GetClient().GetWorkflows(ctx, req)
+		if err != nil {
+			return false, err
+		}
 
-	var data []byte
-	if strings.ToLower(cmd.Name()) == "list" {
-		// We only want the names.
-		Names := make([]string, len(resp.Workflows))
-		for i, wf := range resp.Workflows {
-			Names[i] = wf.Name
+		var data []byte
+		if strings.ToLower(cmd.Name()) == "list" {
+			// We only want the names.
+			Names := make([]string, len(resp.Workflows))
+			for i, wf := range resp.Workflows {
+				Names[i] = wf.Name
+			}
+			data, err = cli.MarshalOutput(Names)
+		} else {
+			data, err = cli.MarshalOutput(resp)
 		}
-		data, err = cli.MarshalJSONPretty(Names)
-	} else {
-		data, err = cli.MarshalJSONPretty(resp)
-	}
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
+		if err != nil {
+			return false, err
+		}
+		fmt.Println(string(data))
 
-	return nil
+		return workflowsSettled(resp.Workflows), nil
+	})
+}
+
+// workflowsSettled returns true if none of the streams of the workflows is
+// still starting, copying or catching up.
+func workflowsSettled(workflows []*vtctldatapb.Workflow) bool {
+	for _, wf := range workflows {
+		for _, streams := range wf.ShardStreams {
+			for _, stream := range streams.Streams {
+				switch stream.State {
+				case binlogdatapb.VReplicationWorkflowState_Init.String(),
+					binlogdatapb.VReplicationWorkflowState_Copying.String(),
+					binlogdatapb.VReplicationWorkflowState_Lagging.String():
+					return false
+				}
+			}
+		}
+	}
+	return true
 }
@@ -95,7 +95,7 @@ func commandUpdateState(cmd *cobra.Command, args []string) error {
 		return resp.Details[i].Tablet.String() < resp.Details[j].Tablet.String()
 	})
 
-	data, err := cli.MarshalJSONPretty(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -39,6 +39,8 @@ var (
 		TabletTypes                  []topodatapb.TabletType
 		TabletTypesInPreferenceOrder bool
 		OnDDL                        string
+		Throttler                    common.ThrottlerOptions
+		Schedule                     common.ScheduleOptions
 	}{}
 
 	// update makes a WorkflowUpdate gRPC call to a vtctld.
@@ -73,6 +75,11 @@ var (
 					return fmt.Errorf("invalid on-ddl value: %s", updateOptions.OnDDL)
 				}
 			} // Simulated NULL will need to be handled in command
+			for _, name := range []string{"throttler-app-name", "throttler-ratio", "throttler-priority", "copy-window", "bandwidth-window", "schedule-time-zone"} {
+				if cmd.Flags().Lookup(name).Changed {
+					changes = true
+				}
+			}
 			if !changes {
 				return fmt.Errorf("no configuration options specified to update")
 			}
@@ -103,6 +110,19 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Nil when no throttler flags are provided, which keeps the current
+	// throttler settings. Otherwise only the provided settings are changed.
+	throttlerSettings, err := common.GetThrottlerSettingsUpdate(cmd, &updateOptions.Throttler)
+	if err != nil {
+		return err
+	}
+	// Nil when no schedule flags are provided, which keeps the current
+	// schedule.
+	schedule, err := common.GetSchedule(cmd, &updateOptions.Schedule)
+	if err != nil {
+		return err
+	}
+
 	req := &vtctldatapb.WorkflowUpdateRequest{
 		Keyspace: baseOptions.Keyspace,
 		TabletRequest: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
@@ -111,6 +131,8 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 			TabletTypes:               updateOptions.TabletTypes,
 			TabletSelectionPreference: tsp,
 			OnDdl:                     binlogdatapb.OnDDLAction(onddl),
+			ThrottlerSettings:         throttlerSettings,
+			Schedule:                  schedule,
 		},
 	}
 
@@ -124,7 +146,7 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 		return resp.Details[i].Tablet.String() < resp.Details[j].Tablet.String()
 	})
 
-	data, err := cli.MarshalJSONPretty(resp)
+	data, err := cli.MarshalOutput(resp)
 	if err != nil {
 		return err
 	}
@@ -19,6 +19,7 @@ package workflow
 import (
 	"github.com/spf13/cobra"
 
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 )
@@ -42,7 +43,9 @@ var (
 	}{}
 
 	workflowShowOptions = struct {
-		IncludeLogs bool
+		IncludeLogs         bool
+		IncludeCopyProgress bool
+		Watch               cli.WatchOptions
 	}{}
 )
 
@@ -66,6 +69,8 @@ func registerCommands(root *cobra.Command) {
 	show.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want the details for.")
 	show.MarkFlagRequired("workflow")
 	show.Flags().BoolVar(&workflowShowOptions.IncludeLogs, "include-logs", true, "Include recent logs for the workflow.")
+	show.Flags().BoolVar(&workflowShowOptions.IncludeCopyProgress, "include-copy-progress", false, "Include the copy phase progress, throughput and estimated time to completion for the workflow.")
+	cli.AddWatchFlags(show, &workflowShowOptions.Watch, "none of the streams of the workflow is starting, copying or lagging")
 	base.AddCommand(show)
 
 	start.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to start.")
@@ -82,6 +87,8 @@ func registerCommands(root *cobra.Command) {
 	update.Flags().VarP((*topoproto.TabletTypeListFlag)(&updateOptions.TabletTypes), "tablet-types", "t", "New source tablet types to replicate from (e.g. PRIMARY,REPLICA,RDONLY).")
 	update.Flags().BoolVar(&updateOptions.TabletTypesInPreferenceOrder, "tablet-types-in-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
 	update.Flags().StringVar(&updateOptions.OnDDL, "on-ddl", "", "New instruction on what to do when DDL is encountered in the VReplication stream. Possible values are IGNORE, STOP, EXEC, and EXEC_IGNORE.")
+	common.AddThrottlerFlags(update, &updateOptions.Throttler)
+	common.AddScheduleFlags(update, &updateOptions.Schedule)
 	base.AddCommand(update)
 }
 
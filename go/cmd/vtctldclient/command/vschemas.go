@@ -113,7 +113,7 @@ func commandApplyVSchema(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	data, err := cli.MarshalJSON(res.VSchema)
+	data, err := cli.MarshalOutput(res.VSchema)
 	if err != nil {
 		return err
 	}
@@ -133,7 +133,7 @@ func commandGetVSchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.VSchema)
+	data, err := cli.MarshalOutput(resp.VSchema)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,237 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// GetVtorcRecoveries queries the recoveries API of a VTOrc.
+var GetVtorcRecoveries = &cobra.Command{
+	Use:   "GetVtorcRecoveries --vtorc <vtorc_host:vtorc_port> [--detections] [--analysis <analysis>] [--since <time>] [--until <time>] [--limit <limit>] [<keyspace|keyspace/shard>]",
+	Short: "Lists the recoveries, or the failure detections, of a VTOrc, from the most recent one.",
+	Long: `Lists the recoveries, or with --detections the failure detections, of a VTOrc,
+from the most recent one, optionally only those of a keyspace or a shard.
+
+The recoveries are read from the /api/recoveries endpoint, and the detections
+from the /api/detections endpoint, of the VTOrc at --vtorc, rather than from a
+vtctld. Each recovery has its steps, and the ID of the detection which led to it.
+
+--since and --until bound the time at which the recoveries or the detections
+started. They are either RFC 3339 times, or durations before now.`,
+	Example: `GetVtorcRecoveries --vtorc vtorc:15000 commerce/-80
+GetVtorcRecoveries --vtorc vtorc:15000 --analysis DeadPrimary --since 2023-10-01T10:00:00Z --until 2023-10-01T11:00:00Z
+GetVtorcRecoveries --vtorc vtorc:15000 --detections --since 24h`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.MaximumNArgs(1),
+	RunE:                  commandGetVtorcRecoveries,
+	// The recoveries are read from VTOrc rather than from a vtctld.
+	Annotations: map[string]string{
+		skipClientCreationKey: "true",
+	},
+}
+
+var getVtorcRecoveriesOptions = struct {
+	VTOrc      string
+	Detections bool
+	Analysis   string
+	Since      string
+	Until      string
+	Limit      uint32
+}{}
+
+func commandGetVtorcRecoveries(cmd *cobra.Command, args []string) error {
+	if getVtorcRecoveriesOptions.VTOrc == "" {
+		return fmt.Errorf("--vtorc is required")
+	}
+
+	query := url.Values{}
+	if len(args) == 1 {
+		keyspace, shard := args[0], ""
+		if strings.Contains(keyspace, "/") {
+			var err error
+			keyspace, shard, err = topoproto.ParseKeyspaceShard(keyspace)
+			if err != nil {
+				return err
+			}
+		}
+		query.Set("keyspace", keyspace)
+		if shard != "" {
+			query.Set("shard", shard)
+		}
+	}
+	if getVtorcRecoveriesOptions.Analysis != "" {
+		query.Set("analysis", getVtorcRecoveriesOptions.Analysis)
+	}
+	for name, value := range map[string]string{"since": getVtorcRecoveriesOptions.Since, "until": getVtorcRecoveriesOptions.Until} {
+		if value == "" {
+			continue
+		}
+		t, err := parseVtorcHistoryTime(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		query.Set(name, t.UTC().Format(time.RFC3339))
+	}
+	if getVtorcRecoveriesOptions.Limit > 0 {
+		query.Set("limit", strconv.FormatUint(uint64(getVtorcRecoveriesOptions.Limit), 10))
+	}
+
+	cli.FinishedParsing(cmd)
+
+	addr := getVtorcRecoveriesOptions.VTOrc
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	path := "/api/recoveries"
+	if getVtorcRecoveriesOptions.Detections {
+		path = "/api/detections"
+	}
+	req, err := http.NewRequestWithContext(commandCtx, http.MethodGet, strings.TrimSuffix(addr, "/")+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", req.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var entries json.RawMessage
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", req.URL.Redacted(), err)
+	}
+
+	data, err := cli.MarshalOutput(entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+// parseVtorcHistoryTime parses a time given either in RFC 3339 format, or as
+// a duration before now.
+func parseVtorcHistoryTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration must not be negative, got %v", d)
+		}
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// SetVtorcMaintenance makes a SetVtorcMaintenance gRPC call to a vtctld.
+var SetVtorcMaintenance = &cobra.Command{
+	Use:   "SetVtorcMaintenance [--reason <reason>] [--duration <duration>] <keyspace|keyspace/shard> <true/false>",
+	Short: "Starts or ends a maintenance of a keyspace or a shard, during which VTOrc runs no automated recoveries on it.",
+	Long: `Starts or ends a maintenance of a keyspace or a shard, during which VTOrc runs no
+automated recoveries on it, e.g. while its tablets are worked on.
+
+The maintenance is recorded, with --reason, which is required to start it, in
+the keyspace or shard record, and ends on its own after --duration. VTOrc keeps
+detecting the problems of the keyspace or shard during the maintenance, and
+recovers them once it ends.`,
+	Example: `SetVtorcMaintenance --reason "MySQL upgrade" --duration 2h commerce/-80 true
+SetVtorcMaintenance commerce/-80 false`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.ExactArgs(2),
+	RunE:                  commandSetVtorcMaintenance,
+}
+
+var setVtorcMaintenanceOptions = struct {
+	Reason   string
+	Duration time.Duration
+}{}
+
+func commandSetVtorcMaintenance(cmd *cobra.Command, args []string) error {
+	keyspace, shard := cmd.Flags().Arg(0), ""
+	if strings.Contains(keyspace, "/") {
+		var err error
+		keyspace, shard, err = topoproto.ParseKeyspaceShard(keyspace)
+		if err != nil {
+			return err
+		}
+	}
+	enabled, err := strconv.ParseBool(cmd.Flags().Arg(1))
+	if err != nil {
+		return err
+	}
+	if enabled && setVtorcMaintenanceOptions.Reason == "" {
+		return fmt.Errorf("--reason is required to start a maintenance")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetVtorcMaintenance(commandCtx, &vtctldatapb.SetVtorcMaintenanceRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Enabled:  enabled,
+		Reason:   setVtorcMaintenanceOptions.Reason,
+		Duration: protoutil.DurationToProto(setVtorcMaintenanceOptions.Duration),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalOutput(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func init() {
+	GetVtorcRecoveries.Flags().StringVar(&getVtorcRecoveriesOptions.VTOrc, "vtorc", "", "The address of the VTOrc, as <host:port> of its HTTP server.")
+	GetVtorcRecoveries.Flags().BoolVar(&getVtorcRecoveriesOptions.Detections, "detections", false, "List the failure detections instead of the recoveries.")
+	GetVtorcRecoveries.Flags().StringVar(&getVtorcRecoveriesOptions.Analysis, "analysis", "", "Only list the recoveries or detections of this analysis, e.g. DeadPrimary.")
+	GetVtorcRecoveries.Flags().StringVar(&getVtorcRecoveriesOptions.Since, "since", "", "Only list the recoveries or detections started at or after this time, e.g. 2023-10-01T10:00:00Z or 24h.")
+	GetVtorcRecoveries.Flags().StringVar(&getVtorcRecoveriesOptions.Until, "until", "", "Only list the recoveries or detections started at or before this time, e.g. 2023-10-01T11:00:00Z or 1h.")
+	GetVtorcRecoveries.Flags().Uint32Var(&getVtorcRecoveriesOptions.Limit, "limit", 100, "Maximum number of entries to list, the most recent ones. Zero lists all of them.")
+	Root.AddCommand(GetVtorcRecoveries)
+
+	SetVtorcMaintenance.Flags().StringVar(&setVtorcMaintenanceOptions.Reason, "reason", "", "Why VTOrc must not run recoveries, e.g. the maintenance being done. Required to start a maintenance.")
+	SetVtorcMaintenance.Flags().DurationVar(&setVtorcMaintenanceOptions.Duration, "duration", time.Hour, "How long the maintenance lasts, after which VTOrc runs recoveries again.")
+	Root.AddCommand(SetVtorcMaintenance)
+}
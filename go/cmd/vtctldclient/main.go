@@ -51,6 +51,8 @@ func main() {
 	// hack to get rid of an "ERROR: logging before flag.Parse"
 	_flag.TrickGlog()
 
+	command.RegisterPlugins()
+
 	// back to your regularly scheduled cobra programming
 	if err := command.Root.Execute(); err != nil {
 		log.Error(err)
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin helps writing plugins of vtctldclient in Go.
+//
+// A plugin is an executable named vtctldclient-<name> in the PATH, which
+// vtctldclient runs for `vtctldclient [<flags>] <name> [<args>]`, with <args>
+// as its arguments and the flags of vtctldclient in its environment:
+//
+//   - VTCTLDCLIENT_SERVER is the --server to connect to,
+//   - VTCTLDCLIENT_FORMAT is the --format of the output,
+//   - VTCTLDCLIENT_FLAGS is the JSON array of all the flags set, e.g. the TLS
+//     options of the connection.
+//
+// A plugin written in Go calls Init, and then uses NewClient and PrintOutput
+// to connect to the vtctld and print its results like the commands of
+// vtctldclient do:
+//
+//	func main() {
+//		if err := plugin.Init(); err != nil {
+//			log.Fatal(err)
+//		}
+//		ctx, cancel := plugin.Context()
+//		defer cancel()
+//		client, err := plugin.NewClient()
+//		...
+//		resp, err := client.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+//		...
+//		plugin.PrintOutput(resp.Keyspaces)
+//	}
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/grpccommon"
+	"vitess.io/vitess/go/vt/vtctl/grpcclientcommon"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+
+	// Registers the gRPC vtctld client used by NewClient.
+	_ "vitess.io/vitess/go/vt/vtctl/grpcvtctldclient"
+)
+
+const (
+	// Prefix is the prefix of the names of the plugin executables.
+	Prefix = "vtctldclient-"
+
+	// ServerEnv is the environment variable with the --server of vtctldclient.
+	ServerEnv = "VTCTLDCLIENT_SERVER"
+	// FormatEnv is the environment variable with the --format of
+	// vtctldclient.
+	FormatEnv = "VTCTLDCLIENT_FORMAT"
+	// FlagsEnv is the environment variable with the JSON array of the flags
+	// set on vtctldclient, as --name=value arguments.
+	FlagsEnv = "VTCTLDCLIENT_FLAGS"
+)
+
+var (
+	server        string
+	actionTimeout = time.Hour
+	compactOutput bool
+)
+
+// Init applies the flags of vtctldclient from the environment, so that
+// NewClient connects to the same vtctld in the same way, and PrintOutput uses
+// the same format. The flags of vtctldclient which don't apply to plugins are
+// ignored.
+func Init() error {
+	fs := pflag.NewFlagSet("vtctldclient", pflag.ContinueOnError)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	fs.StringVar(&server, "server", os.Getenv(ServerEnv), "server to use for the connection")
+	fs.DurationVar(&actionTimeout, "action_timeout", actionTimeout, "timeout to use for the command")
+	fs.BoolVar(&compactOutput, "compact", compactOutput, "use compact format for otherwise verbose outputs")
+	fs.Var(&cli.Format, "format", "output format of the commands")
+	grpcclient.RegisterFlags(fs)
+	grpccommon.RegisterFlags(fs)
+	grpcclientcommon.RegisterFlags(fs)
+
+	if format := os.Getenv(FormatEnv); format != "" {
+		if err := cli.Format.Set(format); err != nil {
+			return fmt.Errorf("invalid %s: %w", FormatEnv, err)
+		}
+	}
+
+	var args []string
+	if data := os.Getenv(FlagsEnv); data != "" {
+		if err := json.Unmarshal([]byte(data), &args); err != nil {
+			return fmt.Errorf("invalid %s: %w", FlagsEnv, err)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("invalid %s: %w", FlagsEnv, err)
+	}
+
+	if compactOutput {
+		cli.DefaultMarshalOptions.EmitUnpopulated = false
+	}
+	return nil
+}
+
+// Context returns the context of the plugin, which times out after the
+// --action_timeout of vtctldclient.
+func Context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), actionTimeout)
+}
+
+// NewClient connects to the vtctld of vtctldclient.
+func NewClient() (vtctldclient.VtctldClient, error) {
+	if server == "" {
+		return nil, errors.New("please specify --server <vtctld_host:vtctld_port> to vtctldclient to specify the vtctld server to connect to")
+	}
+	return vtctldclient.New("grpc", server)
+}
+
+// PrintOutput prints a result in the --format of vtctldclient.
+func PrintOutput(obj any) error {
+	data, err := cli.MarshalOutput(obj)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
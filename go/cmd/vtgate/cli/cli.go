@@ -25,6 +25,7 @@ import (
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/exit"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/srvtopo"
@@ -167,7 +168,9 @@ func run(cmd *cobra.Command, args []string) error {
 		discovery.ParseTabletURLTemplateFromFlag()
 		addStatusParts(vtg)
 	})
+	stopClusterSettings := clustersettings.Start(ts)
 	servenv.OnClose(func() {
+		stopClusterSettings()
 		_ = vtg.Gateway().Close(context.Background())
 	})
 	servenv.RunDefault()
@@ -27,6 +27,7 @@ import (
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/vt/binlog"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/mysqlctl"
@@ -35,6 +36,7 @@ import (
 	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/binlogserver"
 	"vitess.io/vitess/go/vt/vttablet/onlineddl"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
@@ -141,6 +143,9 @@ func run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse --tablet-path: %w", err)
 	}
+	if binlogserver.Enabled() {
+		tablet.PortMap[binlogserver.PortName] = int32(binlogserver.Port())
+	}
 	tm = &tabletmanager.TabletManager{
 		BatchCtx:            context.Background(),
 		TopoServer:          ts,
@@ -156,7 +161,19 @@ func run(cmd *cobra.Command, args []string) error {
 		ts.Close()
 		return fmt.Errorf("failed to parse --tablet-path or initialize DB credentials: %w", err)
 	}
+	if binlogserver.Enabled() {
+		bs, err := binlogserver.NewServerFromFlags(config.DB)
+		if err != nil {
+			tm.Close()
+			ts.Close()
+			return fmt.Errorf("failed to start the binlog server: %w", err)
+		}
+		servenv.OnClose(bs.Close)
+	}
+	stopClusterSettings := clustersettings.Start(ts)
 	servenv.OnClose(func() {
+		stopClusterSettings()
+
 		// Close the tm so that our topo entry gets pruned properly and any
 		// background goroutines that use the topo connection are stopped.
 		tm.Close()
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+ThrottledReader and ThrottledWriter are wrappers around Reader and Writer that
+limit their throughput with a token bucket, where each byte is a token.
+*/
+
+package ioutil
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+type throttledReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+// NewThrottledReader returns a Reader that reads from r no faster than the
+// limiter allows, one token per byte. The limiter can be shared by several
+// readers and writers, to limit their combined throughput. Waits for tokens
+// are aborted when ctx is done. If limiter is nil or unlimited, r is returned
+// as is. Otherwise, its burst must be positive.
+func NewThrottledReader(ctx context.Context, r io.Reader, limiter *rate.Limiter) io.Reader {
+	if limiter == nil || limiter.Limit() == rate.Inf {
+		return r
+	}
+	return &throttledReader{ctx: ctx, r: r, limiter: limiter}
+}
+
+// Read reads at most the burst of the limiter from r, and then waits for as
+// many tokens as bytes were read, so that short reads are not overcharged.
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	if burst := tr.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := tr.r.Read(p)
+	if n > 0 {
+		if werr := tr.limiter.WaitN(tr.ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+type throttledWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	limiter *rate.Limiter
+}
+
+// NewThrottledWriter returns a Writer that writes to w no faster than the
+// limiter allows, one token per byte. The limiter can be shared by several
+// readers and writers, to limit their combined throughput. Waits for tokens
+// are aborted when ctx is done. If limiter is nil or unlimited, w is returned
+// as is. Otherwise, its burst must be positive.
+func NewThrottledWriter(ctx context.Context, w io.Writer, limiter *rate.Limiter) io.Writer {
+	if limiter == nil || limiter.Limit() == rate.Inf {
+		return w
+	}
+	return &throttledWriter{ctx: ctx, w: w, limiter: limiter}
+}
+
+// Write writes p to w in pieces of at most the burst of the limiter, waiting
+// for tokens before each piece.
+func (tw *throttledWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), tw.limiter.Burst())
+		if err := tw.limiter.WaitN(tw.ctx, n); err != nil {
+			return written, err
+		}
+		nn, err := tw.w.Write(p[:n])
+		written += nn
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
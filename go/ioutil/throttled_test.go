@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ioutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestThrottledReader(t *testing.T) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("x"), 3000)
+
+	// No limiter, no wrapper.
+	r := bytes.NewReader(data)
+	assert.Equal(t, io.Reader(r), NewThrottledReader(ctx, r, nil))
+
+	// The burst is read right away, the rest at 10000 bytes/s.
+	limiter := rate.NewLimiter(10000, 1000)
+	start := time.Now()
+	got, err := io.ReadAll(NewThrottledReader(ctx, bytes.NewReader(data), limiter))
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = io.ReadAll(NewThrottledReader(ctx, bytes.NewReader(data), rate.NewLimiter(10000, 1000)))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestThrottledWriter(t *testing.T) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("x"), 3000)
+
+	limiter := rate.NewLimiter(10000, 1000)
+	start := time.Now()
+	var buf bytes.Buffer
+	n, err := NewThrottledWriter(ctx, &buf, limiter).Write(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, data, buf.Bytes())
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	buf.Reset()
+	n, err = NewThrottledWriter(ctx, &buf, rate.NewLimiter(10000, 1000)).Write(data)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"crypto/sha512"
+	"math/big"
+)
+
+// The ed25519 plugin of MariaDB signs the nonce sent by the server with
+// Ed25519, using the SHA-512 hash of the password where the standard uses
+// the hash of a 32 bytes seed. As crypto/ed25519 only takes seeds, the
+// signature is computed here on the curve itself. Speed doesn't matter,
+// as a client signs a single nonce per connection.
+
+var (
+	// ed25519P is the prime of the field, 2^255 - 19.
+	ed25519P = bigFromString("57896044618658097711785492504343953926634992332820282019728792003956564819949")
+	// ed25519L is the order of the base point, 2^252 + 27742317777372353535851937790883648493.
+	ed25519L = bigFromString("7237005577332262213973186563042994240857116359379907606001950938285454250989")
+	// ed25519D is the d parameter of the curve, -121665/121666.
+	ed25519D = bigFromString("37095705934669439343138083508754565189542113879843219016388785533085940283555")
+
+	// ed25519B is the base point.
+	ed25519B = ed25519Point{
+		x: bigFromString("15112221349535400772501151409588531511454012693041857206046113283949847762202"),
+		y: bigFromString("46316835694926478169428394003475163141307993866256225615783033603165251855960"),
+	}
+)
+
+func bigFromString(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+// ed25519Point is a point of the curve, in affine coordinates.
+type ed25519Point struct {
+	x, y *big.Int
+}
+
+// add returns p + q. The addition law of the curve is complete, so it also
+// doubles points.
+func (p ed25519Point) add(q ed25519Point) ed25519Point {
+	x1y2 := new(big.Int).Mul(p.x, q.y)
+	y1x2 := new(big.Int).Mul(p.y, q.x)
+	x1x2 := new(big.Int).Mul(p.x, q.x)
+	y1y2 := new(big.Int).Mul(p.y, q.y)
+	dxy := new(big.Int).Mul(ed25519D, x1x2)
+	dxy.Mul(dxy, y1y2).Mod(dxy, ed25519P)
+
+	// x3 = (x1*y2 + y1*x2) / (1 + d*x1*x2*y1*y2)
+	// y3 = (y1*y2 + x1*x2) / (1 - d*x1*x2*y1*y2)
+	xDen := new(big.Int).Add(big.NewInt(1), dxy)
+	yDen := new(big.Int).Sub(big.NewInt(1), dxy)
+	x := x1y2.Add(x1y2, y1x2)
+	x.Mul(x, xDen.ModInverse(xDen.Mod(xDen, ed25519P), ed25519P)).Mod(x, ed25519P)
+	y := y1y2.Add(y1y2, x1x2)
+	y.Mul(y, yDen.ModInverse(yDen.Mod(yDen, ed25519P), ed25519P)).Mod(y, ed25519P)
+	return ed25519Point{x: x, y: y}
+}
+
+// scalarMult returns k * p.
+func (p ed25519Point) scalarMult(k *big.Int) ed25519Point {
+	result := ed25519Point{x: big.NewInt(0), y: big.NewInt(1)}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = result.add(result)
+		if k.Bit(i) == 1 {
+			result = result.add(p)
+		}
+	}
+	return result
+}
+
+// encode returns the 32 bytes encoding of the point: y in little endian,
+// with the parity of x in the top bit.
+func (p ed25519Point) encode() []byte {
+	out := make([]byte, 32)
+	p.y.FillBytes(out)
+	reverseBytes(out)
+	out[31] |= byte(p.x.Bit(0) << 7)
+	return out
+}
+
+// ed25519Scalar returns the little endian bytes as a scalar, modulo L.
+func ed25519Scalar(le []byte) *big.Int {
+	be := append([]byte(nil), le...)
+	reverseBytes(be)
+	n := new(big.Int).SetBytes(be)
+	return n.Mod(n, ed25519L)
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// ed25519PasswordKey returns the secret scalar derived from the password, the
+// prefix used for the signatures, and the public key, which MariaDB stores
+// in base64 as the password of the user.
+func ed25519PasswordKey(password []byte) (*big.Int, []byte, []byte) {
+	h := sha512.Sum512(password)
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	scalarBytes := append([]byte(nil), h[:32]...)
+	reverseBytes(scalarBytes)
+	s := new(big.Int).SetBytes(scalarBytes)
+	return s, h[32:], ed25519B.scalarMult(s).encode()
+}
+
+// ScrambleEd25519Password computes the answer of the client_ed25519 plugin
+// of MariaDB to the nonce sent by the server: the Ed25519 signature of the
+// nonce with the key derived from the password.
+func ScrambleEd25519Password(nonce, password []byte) []byte {
+	s, prefix, publicKey := ed25519PasswordKey(password)
+
+	hash := sha512.New()
+	hash.Write(prefix)
+	hash.Write(nonce)
+	r := ed25519Scalar(hash.Sum(nil))
+	encodedR := ed25519B.scalarMult(r).encode()
+
+	hash.Reset()
+	hash.Write(encodedR)
+	hash.Write(publicKey)
+	hash.Write(nonce)
+	k := ed25519Scalar(hash.Sum(nil))
+
+	// S = (r + k*s) mod L
+	sig := k.Mul(k, s)
+	sig.Add(sig, r).Mod(sig, ed25519L)
+	encodedS := make([]byte, 32)
+	sig.FillBytes(encodedS)
+	reverseBytes(encodedS)
+
+	return append(encodedR, encodedS...)
+}
@@ -22,8 +22,11 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/subtle"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"net"
+	"os"
 	"sync"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -193,7 +196,8 @@ const (
 	mysqlDialogDefaultMessage = "Enter password: "
 
 	// Dialog plugin is similar to clear text, but can respond to multiple
-	// prompts in a row. This is not yet implemented.
+	// prompts in a row. The server only asks for the password, while the
+	// client answers the password questions of the servers, e.g. for PAM.
 	// Follow questions should be prepended with a `cmd` byte:
 	// 0x02 - ordinary question
 	// 0x03 - last question
@@ -226,16 +230,11 @@ func NewMysqlDialogAuthMethod(layer PlainTextStorage, validator UserValidator, m
 // be called if the return of the first layer indicates the full auth dance is
 // needed.
 //
-// Right now we only support caching_sha2_password over TLS or a Unix socket.
-//
-// If TLS is not enabled, the client needs to encrypt it with the public
-// key of the server. In that case, Vitess is already configured with
-// a certificate anyway, so we recommend to use TLS if you want to use
-// caching_sha2_password in that case instead of allowing the plain
-// text fallback path here.
-//
-// This might change in the future if there's a good argument and implementation
-// for allowing the plain text path here as well.
+// The full auth dance exchanges the password in clear text over TLS or a Unix
+// socket. Over other connections, the client encrypts the password with the
+// RSA public key of the server, which is only possible if the listener has a
+// CachingSha2RSAKey. Without it, caching_sha2_password is not offered over
+// these connections.
 func NewSha2CachingAuthMethod(layer1 CachingStorage, layer2 PlainTextStorage, validator UserValidator) AuthMethod {
 	authMethod := mysqlCachingSha2AuthMethod{
 		cache:     layer1,
@@ -245,6 +244,33 @@ func NewSha2CachingAuthMethod(layer1 CachingStorage, layer2 PlainTextStorage, va
 	return &authMethod
 }
 
+// LoadCachingSha2RSAKey reads the RSA private key, in PEM format, which the
+// clients encrypt their password with for caching_sha2_password over
+// connections without TLS. It is meant to be set as the CachingSha2RSAKey
+// of a Listener.
+func LoadCachingSha2RSAKey(file string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "no PEM data found in %v", file)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot parse the private key in %v: %v", file, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "the private key in %v is not an RSA key", file)
+	}
+	return rsaKey, nil
+}
+
 // ScrambleMysqlNativePassword computes the hash of the password using 4.1+ method.
 //
 // This can be used for example inside a `mysql_native_password` plugin implementation
@@ -349,6 +375,22 @@ func VerifyHashedCachingSha2Password(reply, salt, hashedCachingSha2Password []by
 	return subtle.ConstantTimeCompare(candidateHash2, hashedCachingSha2Password) == 1
 }
 
+// hashMysqlNativePassword computes the SHA1(SHA1(password)) hash of the
+// password, as stored for mysql_native_password.
+func hashMysqlNativePassword(password []byte) []byte {
+	stage1 := sha1.Sum(password)
+	stage2 := sha1.Sum(stage1[:])
+	return stage2[:]
+}
+
+// hashCachingSha2Password computes the SHA256(SHA256(password)) hash of the
+// password, as cached for the fast caching_sha2_password authentication.
+func hashCachingSha2Password(password []byte) []byte {
+	stage1 := sha256.Sum256(password)
+	stage2 := sha256.Sum256(stage1[:])
+	return stage2[:]
+}
+
 // ScrambleCachingSha2Password computes the hash of the password using SHA256 as required by
 // caching_sha2_password plugin for "fast" authentication
 func ScrambleCachingSha2Password(salt []byte, password []byte) []byte {
@@ -500,7 +542,7 @@ func (n *mysqlCachingSha2AuthMethod) Name() AuthMethodDescription {
 }
 
 func (n *mysqlCachingSha2AuthMethod) HandleUser(conn *Conn, user string) bool {
-	if !conn.TLSEnabled() && !conn.IsUnixSocket() {
+	if !conn.TLSEnabled() && !conn.IsUnixSocket() && conn.cachingSha2RSAKey() == nil {
 		return false
 	}
 	return n.validator.HandleUser(user)
@@ -546,7 +588,9 @@ func (n *mysqlCachingSha2AuthMethod) HandleAuthPluginData(c *Conn, user string,
 		}
 		return result, nil
 	case AuthNeedMoreData:
-		if !c.TLSEnabled() && !c.IsUnixSocket() {
+		secure := c.TLSEnabled() || c.IsUnixSocket()
+		rsaKey := c.cachingSha2RSAKey()
+		if !secure && rsaKey == nil {
 			return nil, sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 		}
 
@@ -555,7 +599,12 @@ func (n *mysqlCachingSha2AuthMethod) HandleAuthPluginData(c *Conn, user string,
 		writeByte(data, pos, CachingSha2FullAuth)
 		c.writeEphemeralPacket()
 
-		password, err := readPacketPasswordString(c)
+		var password string
+		if secure {
+			password, err = readPacketPasswordString(c)
+		} else {
+			password, err = readPacketEncryptedPassword(c, salt, rsaKey)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -620,6 +669,52 @@ func negotiateAuthMethod(conn *Conn, as AuthServer, user string, requestedAuth A
 	return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "unknown auth method requested: %s", string(requestedAuth))
 }
 
+// readPacketEncryptedPassword reads the password of the caching_sha2_password
+// full authentication over an insecure connection. The client sends it XORed
+// with the salt and encrypted with the RSA public key of the server, which it
+// can first request.
+func readPacketEncryptedPassword(c *Conn, salt []byte, rsaKey *rsa.PrivateKey) (string, error) {
+	data, err := c.ReadPacket()
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 1 && data[0] == CachingSha2RequestPublicKey {
+		if err := c.writePublicKey(&rsaKey.PublicKey); err != nil {
+			return "", err
+		}
+		if data, err = c.ReadPacket(); err != nil {
+			return "", err
+		}
+	}
+
+	password, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, rsaKey, data, nil)
+	if err != nil {
+		return "", vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot decrypt the password sent by the client: %v", err)
+	}
+	for i := range password {
+		password[i] ^= salt[i%len(salt)]
+	}
+	if len(password) == 0 || password[len(password)-1] != 0 {
+		return "", vterrors.Errorf(vtrpc.Code_INTERNAL, "received invalid encrypted password, datalen=%v", len(password))
+	}
+	return string(password[:len(password)-1]), nil
+}
+
+// writePublicKey writes the RSA public key of the server in PEM format, in an
+// AuthMoreDataPacket.
+func (c *Conn) writePublicKey(publicKey *rsa.PublicKey) error {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	data, pos := c.startEphemeralPacketWithHeader(1 + len(pemKey))
+	pos = writeByte(data, pos, AuthMoreDataPacket)
+	copy(data[pos:], pemKey)
+	return c.writeEphemeralPacket()
+}
+
 func readPacketPasswordString(c *Conn) (string, error) {
 	// Read a packet, the password is the payload, as a
 	// zero terminated string.
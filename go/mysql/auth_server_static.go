@@ -68,6 +68,11 @@ type AuthServerStatic struct {
 	mu sync.Mutex
 	// entries contains the users, passwords and user data.
 	entries map[string][]*AuthServerStaticEntry
+	// cachingSha2Hashes caches the SHA256(SHA256(password)) of the entries
+	// which only have a MysqlNativePassword, once their password has been
+	// received in a full authentication, for the fast caching_sha2_password
+	// authentication. It is cleared when the entries are reloaded.
+	cachingSha2Hashes map[*AuthServerStaticEntry][]byte
 
 	sigChan chan os.Signal
 	ticker  *time.Ticker
@@ -125,13 +130,14 @@ func RegisterAuthServerStaticFromParams(file, jsonConfig string, reloadInterval
 // NewAuthServerStatic returns a new empty AuthServerStatic.
 func NewAuthServerStatic(file, jsonConfig string, reloadInterval time.Duration) *AuthServerStatic {
 	a := &AuthServerStatic{
-		file:           file,
-		jsonConfig:     jsonConfig,
-		reloadInterval: reloadInterval,
-		entries:        make(map[string][]*AuthServerStaticEntry),
+		file:              file,
+		jsonConfig:        jsonConfig,
+		reloadInterval:    reloadInterval,
+		entries:           make(map[string][]*AuthServerStaticEntry),
+		cachingSha2Hashes: make(map[*AuthServerStaticEntry][]byte),
 	}
 
-	a.methods = []AuthMethod{NewMysqlNativeAuthMethod(a, a)}
+	a.methods = []AuthMethod{NewMysqlNativeAuthMethod(a, a), NewSha2CachingAuthMethod(a, a, a)}
 
 	a.reload()
 	a.installSignalHandlers()
@@ -142,10 +148,11 @@ func NewAuthServerStatic(file, jsonConfig string, reloadInterval time.Duration)
 // but with support for a different auth method. Mostly used for testing purposes.
 func NewAuthServerStaticWithAuthMethodDescription(file, jsonConfig string, reloadInterval time.Duration, authMethodDescription AuthMethodDescription) *AuthServerStatic {
 	a := &AuthServerStatic{
-		file:           file,
-		jsonConfig:     jsonConfig,
-		reloadInterval: reloadInterval,
-		entries:        make(map[string][]*AuthServerStaticEntry),
+		file:              file,
+		jsonConfig:        jsonConfig,
+		reloadInterval:    reloadInterval,
+		entries:           make(map[string][]*AuthServerStaticEntry),
+		cachingSha2Hashes: make(map[*AuthServerStaticEntry][]byte),
 	}
 
 	var authMethod AuthMethod
@@ -185,8 +192,25 @@ func (a *AuthServerStatic) UserEntryWithPassword(conn *Conn, user string, passwo
 	}
 
 	for _, entry := range entries {
-		// Validate the password.
-		if MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1 {
+		if !MatchSourceHost(remoteAddr, entry.SourceHost) {
+			continue
+		}
+		if entry.MysqlNativePassword != "" {
+			// Validate the password against its hash, and cache it for
+			// the next caching_sha2_password authentications.
+			hash, err := DecodeMysqlNativePasswordHex(entry.MysqlNativePassword)
+			if err != nil {
+				return &StaticUserData{entry.UserData, entry.Groups}, sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+			}
+			if subtle.ConstantTimeCompare(hashMysqlNativePassword([]byte(password)), hash) == 1 {
+				a.mu.Lock()
+				if a.cachingSha2Hashes != nil {
+					a.cachingSha2Hashes[entry] = hashCachingSha2Password([]byte(password))
+				}
+				a.mu.Unlock()
+				return &StaticUserData{entry.UserData, entry.Groups}, nil
+			}
+		} else if subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1 {
 			return &StaticUserData{entry.UserData, entry.Groups}, nil
 		}
 	}
@@ -237,7 +261,25 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 		return &StaticUserData{}, AuthRejected, sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 	}
 
+	needMoreData := false
 	for _, entry := range entries {
+		if entry.MysqlNativePassword != "" {
+			// The password can only be validated against the hash that was
+			// cached after a previous full authentication. Without it, the
+			// full authentication is needed.
+			a.mu.Lock()
+			hash, ok := a.cachingSha2Hashes[entry]
+			a.mu.Unlock()
+			if !ok {
+				needMoreData = true
+				continue
+			}
+			if MatchSourceHost(remoteAddr, entry.SourceHost) && VerifyHashedCachingSha2Password(authResponse, salt, hash) {
+				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
+			}
+			continue
+		}
+
 		computedAuthResponse := ScrambleCachingSha2Password(salt, []byte(entry.Password))
 
 		// Validate the password.
@@ -245,6 +287,9 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 			return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
 		}
 	}
+	if needMoreData {
+		return &StaticUserData{}, AuthNeedMoreData, nil
+	}
 	return &StaticUserData{}, AuthRejected, sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 }
 
@@ -278,6 +323,7 @@ func (a *AuthServerStatic) reload() {
 
 	a.mu.Lock()
 	a.entries = entries
+	a.cachingSha2Hashes = make(map[*AuthServerStaticEntry][]byte)
 	a.mu.Unlock()
 }
 
@@ -17,9 +17,16 @@ limitations under the license.
 package mysql
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVerifyHashedMysqlNativePassword(t *testing.T) {
@@ -53,3 +60,37 @@ func TestVerifyHashedCachingSha2Password(t *testing.T) {
 	passwordHash[0] = 0x00
 	assert.False(t, VerifyHashedMysqlNativePassword(reply, salt, passwordHash), "password hash match")
 }
+
+func TestHashPasswords(t *testing.T) {
+	// Double SHA256 of "secret"
+	assert.Equal(t, []byte{0x38, 0x81, 0x21, 0x9d, 0x08, 0x7d, 0xd9, 0xc6, 0x34, 0x37, 0x3f, 0xd3,
+		0x3d, 0xfa, 0x33, 0xa2, 0xcb, 0x6b, 0xfc, 0x6c, 0x52, 0x0b, 0x64, 0xb8,
+		0xbb, 0x60, 0xef, 0x2c, 0xeb, 0x53, 0x4a, 0xe7}, hashCachingSha2Password([]byte("secret")))
+
+	// Double SHA1 of "secret"
+	assert.Equal(t, []byte{0x14, 0xe6, 0x55, 0x67, 0xab, 0xdb, 0x51, 0x35, 0xd0, 0xcf, 0xd9, 0xa7,
+		0x0b, 0x30, 0x32, 0xc1, 0x79, 0xa4, 0x9e, 0xe7}, hashMysqlNativePassword([]byte("secret")))
+}
+
+func TestLoadCachingSha2RSAKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	pkcs1File := filepath.Join(dir, "pkcs1.pem")
+	require.NoError(t, os.WriteFile(pkcs1File, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	pkcs8File := filepath.Join(dir, "pkcs8.pem")
+	require.NoError(t, os.WriteFile(pkcs8File, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), 0o600))
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not a key"), 0o600))
+
+	for _, file := range []string{pkcs1File, pkcs8File} {
+		loaded, err := LoadCachingSha2RSAKey(file)
+		require.NoError(t, err)
+		assert.True(t, key.Equal(loaded))
+	}
+	_, err = LoadCachingSha2RSAKey(invalidFile)
+	assert.ErrorContains(t, err, "no PEM data")
+}
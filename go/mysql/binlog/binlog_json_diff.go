@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"encoding/binary"
+
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+/*
+References:
+
+* Docs for MySQL partial JSON updates in the binary log:
+https://dev.mysql.com/doc/refman/8.0/en/replication-options-binary-log.html#sysvar_binlog_row_value_options
+
+* Serialization of the JSON diffs, see Json_diff::write_binary:
+https://github.com/mysql/mysql-server/blob/8.0/sql/json_diff.cc
+*/
+
+// jsonDiffOperation is the operation of a JSON diff.
+type jsonDiffOperation byte
+
+// operations as defined by enum_json_diff_operation
+const (
+	jsonDiffReplace jsonDiffOperation = 0
+	jsonDiffInsert  jsonDiffOperation = 1
+	jsonDiffRemove  jsonDiffOperation = 2
+)
+
+// JSONDiffLength returns the length of the value of a JSON column that is
+// logged as a partial update: a list of JSON diffs preceded by its length
+// on 4 bytes.
+func JSONDiffLength(data []byte, pos int) (int, error) {
+	if pos+4 > len(data) {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "expected JSON diff length at position %v (data: %v)", pos, data)
+	}
+	return 4 + int(binary.LittleEndian.Uint32(data[pos:pos+4])), nil
+}
+
+// JSONDiffValue applies the JSON diffs of a partial update at the given
+// position to the before value of the JSON column, and returns the full
+// value of the column along with the length of the diffs. The value has
+// the same type as the one CellValue returns for JSON columns.
+func JSONDiffValue(data []byte, pos int, before *json.Value) (sqltypes.Value, int, error) {
+	l, err := JSONDiffLength(data, pos)
+	if err != nil {
+		return sqltypes.NULL, 0, err
+	}
+	if pos+l > len(data) {
+		return sqltypes.NULL, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "JSON diffs of length %v overflow the row data at position %v", l, pos)
+	}
+	if before == nil {
+		return sqltypes.NULL, 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "partial JSON update without a before image value: ensure binlog_row_image is set to 'full'")
+	}
+	doc, err := ApplyBinaryJSONDiffs(before, data[pos+4:pos+l])
+	if err != nil {
+		return sqltypes.NULL, 0, err
+	}
+	return sqltypes.MakeTrusted(sqltypes.Expression, doc.MarshalTo(nil)), l, nil
+}
+
+// ApplyBinaryJSONDiffs applies the given list of binary JSON diffs to the
+// document, and returns the updated document. The document is modified in
+// place, unless the root of the document is replaced.
+//
+// Expected format of each diff:
+//
+//	# bytes   field
+//	1         operation
+//	<var>     path length (var-len encoded)
+//	pl        path
+//	-- if operation != REMOVE
+//	<var>     value length (var-len encoded)
+//	vl        value, in the MySQL JSON binary format
+//	-- endif
+func ApplyBinaryJSONDiffs(doc *json.Value, diffs []byte) (*json.Value, error) {
+	pos := 0
+	for pos < len(diffs) {
+		op := jsonDiffOperation(diffs[pos])
+		pos++
+
+		pathLength, read, err := readLenEncLength(diffs, pos)
+		if err != nil {
+			return nil, err
+		}
+		pos = read
+		rawPath := diffs[pos : pos+pathLength]
+		pos += pathLength
+
+		var p json.PathParser
+		path, err := p.ParseBytes(rawPath)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid path %q in JSON diff", rawPath)
+		}
+
+		var value *json.Value
+		if op != jsonDiffRemove {
+			valueLength, read, err := readLenEncLength(diffs, pos)
+			if err != nil {
+				return nil, err
+			}
+			pos = read
+			value, err = ParseBinaryJSON(diffs[pos : pos+valueLength])
+			if err != nil {
+				return nil, err
+			}
+			pos += valueLength
+		}
+
+		var transformation json.Transformation
+		switch op {
+		case jsonDiffReplace:
+			if path.String() == "$" {
+				// The whole document is replaced.
+				doc = value
+				continue
+			}
+			transformation = json.Replace
+		case jsonDiffInsert:
+			// MySQL inserts array elements at the index of the path, like
+			// JSON_ARRAY_INSERT, and object members like JSON_INSERT.
+			transformation = json.ArrayInsert
+		case jsonDiffRemove:
+			transformation = json.Remove
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unsupported JSON diff operation %v", op)
+		}
+		if err := json.ApplyTransform(transformation, doc, []*json.Path{path}, []*json.Value{value}); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// readLenEncLength reads a length encoded the way it is by net_store_length,
+// and checks that as many bytes follow it.
+func readLenEncLength(data []byte, pos int) (int, int, error) {
+	if pos >= len(data) {
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "expected length at position %v in JSON diff (data: %v)", pos, data)
+	}
+	size := 0
+	switch data[pos] {
+	case 0xfc:
+		size = 2
+	case 0xfd:
+		size = 3
+	case 0xfe:
+		size = 8
+	}
+	length := uint64(data[pos])
+	if size > 0 {
+		if pos+1+size > len(data) {
+			return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "expected length at position %v in JSON diff (data: %v)", pos, data)
+		}
+		length = 0
+		for i := size; i > 0; i-- {
+			length = length<<8 | uint64(data[pos+i])
+		}
+	}
+	next := pos + 1 + size
+	if length > uint64(len(data)-next) {
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "length %v at position %v overflows the JSON diff", length, pos)
+	}
+	return int(length), next, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/json"
+)
+
+func jsonDiff(op jsonDiffOperation, path string, value []byte) []byte {
+	diff := []byte{byte(op), byte(len(path))}
+	diff = append(diff, path...)
+	if op != jsonDiffRemove {
+		diff = append(diff, byte(len(value)))
+		diff = append(diff, value...)
+	}
+	return diff
+}
+
+func TestApplyBinaryJSONDiffs(t *testing.T) {
+	testcases := []struct {
+		name     string
+		doc      string
+		diffs    [][]byte
+		expected string
+		err      string
+	}{
+		{
+			name:     "replace member",
+			doc:      `{"a": 1, "b": 2}`,
+			diffs:    [][]byte{jsonDiff(jsonDiffReplace, "$.a", []byte{12, 1, 120})},
+			expected: `{"a": "x", "b": 2}`,
+		},
+		{
+			name:     "insert member",
+			doc:      `{"a": 1}`,
+			diffs:    [][]byte{jsonDiff(jsonDiffInsert, "$.c", []byte{4, 1})},
+			expected: `{"a": 1, "c": true}`,
+		},
+		{
+			name:     "insert first array element",
+			doc:      `{"a": [1, 2, 3]}`,
+			diffs:    [][]byte{jsonDiff(jsonDiffInsert, "$.a[0]", []byte{5, 0, 0})},
+			expected: `{"a": [0, 1, 2, 3]}`,
+		},
+		{
+			name:     "insert array element in the middle",
+			doc:      `[1, 2, 3]`,
+			diffs:    [][]byte{jsonDiff(jsonDiffInsert, "$[1]", []byte{12, 1, 120})},
+			expected: `[1, "x", 2, 3]`,
+		},
+		{
+			name:     "insert array element past the end",
+			doc:      `[1, 2]`,
+			diffs:    [][]byte{jsonDiff(jsonDiffInsert, "$[5]", []byte{4, 1})},
+			expected: `[1, 2, true]`,
+		},
+		{
+			name:     "remove array element",
+			doc:      `{"a": [1, 2, 3]}`,
+			diffs:    [][]byte{jsonDiff(jsonDiffRemove, "$.a[0]", nil)},
+			expected: `{"a": [2, 3]}`,
+		},
+		{
+			name: "several diffs",
+			doc:  `{"a": {"b": [1, 2]}, "c": "d"}`,
+			diffs: [][]byte{
+				jsonDiff(jsonDiffReplace, "$.a.b[1]", []byte{5, 7, 0}),
+				jsonDiff(jsonDiffInsert, "$.a.b[2]", []byte{4, 0}),
+				jsonDiff(jsonDiffRemove, "$.c", nil),
+			},
+			expected: `{"a": {"b": [1, 7, null]}}`,
+		},
+		{
+			name:     "replace document",
+			doc:      `{"a": 1}`,
+			diffs:    [][]byte{jsonDiff(jsonDiffReplace, "$", []byte{4, 2})},
+			expected: `false`,
+		},
+		{
+			name:  "invalid operation",
+			doc:   `{"a": 1}`,
+			diffs: [][]byte{jsonDiff(3, "$.a", []byte{4, 1})},
+			err:   "unsupported JSON diff operation 3",
+		},
+		{
+			name:  "truncated",
+			doc:   `{"a": 1}`,
+			diffs: [][]byte{jsonDiff(jsonDiffReplace, "$.a", []byte{4, 1})[:6]},
+			err:   "overflows the JSON diff",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var p json.Parser
+			doc, err := p.Parse(tc.doc)
+			require.NoError(t, err)
+			var diffs []byte
+			for _, diff := range tc.diffs {
+				diffs = append(diffs, diff...)
+			}
+			doc, err = ApplyBinaryJSONDiffs(doc, diffs)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, doc.String())
+		})
+	}
+}
+
+func TestJSONDiffValue(t *testing.T) {
+	diffs := jsonDiff(jsonDiffReplace, "$.a", []byte{5, 2, 0})
+	data := binary.LittleEndian.AppendUint32([]byte{0xff}, uint32(len(diffs)))
+	data = append(data, diffs...)
+
+	l, err := JSONDiffLength(data, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4+len(diffs), l)
+
+	var p json.Parser
+	before, err := p.Parse(`{"a": 1}`)
+	require.NoError(t, err)
+	value, l, err := JSONDiffValue(data, 1, before)
+	require.NoError(t, err)
+	assert.Equal(t, 4+len(diffs), l)
+	assert.Equal(t, `{"a": 2}`, string(value.Raw()))
+
+	_, _, err = JSONDiffValue(data, 1, nil)
+	assert.ErrorContains(t, err, "partial JSON update without a before image value")
+	_, _, err = JSONDiffValue(data[:len(data)-1], 1, before)
+	assert.ErrorContains(t, err, "overflow the row data")
+}
@@ -86,6 +86,16 @@ func (c *Conn) parseComBinlogDumpGTID(data []byte) (logFile string, logPos uint6
 				return logFile, logPos, position, err
 			}
 		}
+	} else if dataSize, pos, ok := readUint32(data, pos); ok && dataSize > 0 {
+		// MySQL replicas send the GTID set they executed as a SID block.
+		if pos+int(dataSize) > len(data) {
+			return logFile, logPos, position, readPacketErr
+		}
+		gtidSet, err := replication.NewMysql56GTIDSetFromSIDBlock(data[pos : pos+int(dataSize)])
+		if err != nil {
+			return logFile, logPos, position, err
+		}
+		position = replication.Position{GTIDSet: gtidSet}
 	}
 
 	return logFile, logPos, position, nil
@@ -82,7 +82,8 @@ type BinlogEvent interface {
 	IsTableMap() bool
 	// IsWriteRows returns true if this is a WRITE_ROWS_EVENT.
 	IsWriteRows() bool
-	// IsUpdateRows returns true if this is a UPDATE_ROWS_EVENT.
+	// IsUpdateRows returns true if this is a UPDATE_ROWS_EVENT or a
+	// PARTIAL_UPDATE_ROWS_EVENT.
 	IsUpdateRows() bool
 	// IsDeleteRows returns true if this is a DELETE_ROWS_EVENT.
 	IsDeleteRows() bool
@@ -255,6 +256,12 @@ type Row struct {
 	// Data is the raw data.
 	// It is only set for WRITE and UPDATE events.
 	Data []byte
+
+	// JSONPartialValues describes which of the present JSON columns
+	// are partial updates, logged as a list of JSON diffs to apply to
+	// the value of the column in the before image.
+	// It is only set for PARTIAL_UPDATE_ROWS events.
+	JSONPartialValues Bitmap
 }
 
 // Bitmap is used by the previous structures.
@@ -186,7 +186,8 @@ func (ev binlogEvent) IsWriteRows() bool {
 // We do not support v0.
 func (ev binlogEvent) IsUpdateRows() bool {
 	return ev.Type() == eUpdateRowsEventV1 ||
-		ev.Type() == eUpdateRowsEventV2
+		ev.Type() == eUpdateRowsEventV2 ||
+		ev.Type() == ePartialUpdateRowsEvent
 }
 
 // IsDeleteRows implements BinlogEvent.IsDeleteRows().
@@ -17,6 +17,8 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
+	"compress/zlib"
 	"encoding/binary"
 	"hash/crc32"
 
@@ -213,6 +215,23 @@ func NewHeartbeatEventWithLogFile(f BinlogFormat, s *FakeBinlogStream, filename
 
 // NewQueryEvent makes up a QueryEvent based on the Query structure.
 func NewQueryEvent(f BinlogFormat, s *FakeBinlogStream, q Query) BinlogEvent {
+	data, _ := queryEventData(q)
+	ev := s.Packetize(f, eQueryEvent, 0, data)
+	return NewMysql56BinlogEvent(ev)
+}
+
+// NewMariaDBCompressedQueryEvent returns a MariaDB QUERY_COMPRESSED_EVENT,
+// as written with log_bin_compress=ON.
+func NewMariaDBCompressedQueryEvent(f BinlogFormat, s *FakeBinlogStream, q Query) BinlogEvent {
+	data, sqlPos := queryEventData(q)
+	data = append(data[:sqlPos:sqlPos], mariadbCompress(data[sqlPos:])...)
+	ev := s.Packetize(f, eMariaQueryCompressedEvent, 0, data)
+	return NewMariadbBinlogEvent(ev)
+}
+
+// queryEventData returns the data of a QueryEvent, and the position of
+// the SQL statement in it.
+func queryEventData(q Query) ([]byte, int) {
 	statusVarLength := 0
 	if q.Charset != nil {
 		statusVarLength += 1 + 2 + 2 + 2
@@ -248,9 +267,7 @@ func NewQueryEvent(f BinlogFormat, s *FakeBinlogStream, q Query) BinlogEvent {
 	data[pos] = 0
 	pos++
 	copy(data[pos:], q.SQL)
-
-	ev := s.Packetize(f, eQueryEvent, 0, data)
-	return NewMysql56BinlogEvent(ev)
+	return data, pos
 }
 
 // NewInvalidQueryEvent returns an invalid QueryEvent. IsValid is however true.
@@ -324,6 +341,49 @@ func NewMariaDBGTIDEvent(f BinlogFormat, s *FakeBinlogStream, gtid replication.M
 	return NewMariadbBinlogEvent(ev)
 }
 
+// NewMariaDBGTIDListEvent returns a MariaDB GTID_LIST_EVENT, which starts
+// each binlog file with the binlog state.
+func NewMariaDBGTIDListEvent(f BinlogFormat, s *FakeBinlogStream, gtids []replication.MariadbGTID) BinlogEvent {
+	data := make([]byte, 4+16*len(gtids))
+	binary.LittleEndian.PutUint32(data[:4], uint32(len(gtids)))
+	pos := 4
+	for _, gtid := range gtids {
+		binary.LittleEndian.PutUint32(data[pos:pos+4], gtid.Domain)
+		binary.LittleEndian.PutUint32(data[pos+4:pos+8], gtid.Server)
+		binary.LittleEndian.PutUint64(data[pos+8:pos+16], gtid.Sequence)
+		pos += 16
+	}
+
+	ev := s.Packetize(f, eMariaGTIDListEvent, 0, data)
+	return NewMariadbBinlogEvent(ev)
+}
+
+// NewMySQL56GTIDEvent returns a MySQL 5.6+ GTID_EVENT, for a transaction
+// without logical clock information.
+func NewMySQL56GTIDEvent(f BinlogFormat, s *FakeBinlogStream, gtid replication.Mysql56GTID) BinlogEvent {
+	length := 1 + // flags
+		16 + // SID
+		8 + // GNO
+		1 + // logical clock type
+		8 + // last committed
+		8 // sequence number
+	data := make([]byte, length)
+	data[0] = 1 // commit flag
+	copy(data[1:17], gtid.Server[:])
+	binary.LittleEndian.PutUint64(data[17:25], uint64(gtid.Sequence))
+	data[25] = 2 // logical timestamps
+
+	ev := s.Packetize(f, eGTIDEvent, 0, data)
+	return NewMysql56BinlogEvent(ev)
+}
+
+// NewMySQL56PreviousGTIDsEvent returns a MySQL 5.6+ PREVIOUS_GTIDS_EVENT,
+// which starts each binlog file with the GTIDs of the previous files.
+func NewMySQL56PreviousGTIDsEvent(f BinlogFormat, s *FakeBinlogStream, gtidSet replication.Mysql56GTIDSet) BinlogEvent {
+	ev := s.Packetize(f, ePreviousGTIDsEvent, 0, gtidSet.SIDBlock())
+	return NewMysql56BinlogEvent(ev)
+}
+
 // NewTableMapEvent returns a TableMap event.
 // Only works with post_header_length=8.
 func NewTableMapEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, tm *TableMap) BinlogEvent {
@@ -397,19 +457,47 @@ func NewDeleteRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, row
 	return newRowsEvent(f, s, eDeleteRowsEventV2, tableID, rows)
 }
 
+// NewPartialUpdateRowsEvent returns a PartialUpdateRows event. The rows
+// that have JSONPartialValues set are logged with the PARTIAL_JSON value
+// option. The format must include the header size of the event, like the
+// format of MySQL 8.0 does.
+func NewPartialUpdateRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, rows Rows) BinlogEvent {
+	return newRowsEvent(f, s, ePartialUpdateRowsEvent, tableID, rows)
+}
+
 // newRowsEvent can create an event of type:
 // eWriteRowsEventV1, eWriteRowsEventV2,
 // eUpdateRowsEventV1, eUpdateRowsEventV2,
-// eDeleteRowsEventV1, eDeleteRowsEventV2.
+// eDeleteRowsEventV1, eDeleteRowsEventV2,
+// ePartialUpdateRowsEvent.
 func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64, rows Rows) BinlogEvent {
+	data, _ := rowsEventData(f, typ, tableID, rows)
+	ev := s.Packetize(f, typ, 0, data)
+	return NewMysql56BinlogEvent(ev)
+}
+
+// NewMariaDBCompressedWriteRowsEvent returns a MariaDB
+// WRITE_ROWS_COMPRESSED_EVENT, as written with log_bin_compress=ON.
+// Uses v2.
+func NewMariaDBCompressedWriteRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, rows Rows) BinlogEvent {
+	data, rowsPos := rowsEventData(f, eWriteRowsEventV2, tableID, rows)
+	data = append(data[:rowsPos:rowsPos], mariadbCompress(data[rowsPos:])...)
+	ev := s.Packetize(f, eMariaWriteRowsCompressedEventV2, 0, data)
+	return NewMariadbBinlogEvent(ev)
+}
+
+// rowsEventData returns the data of a rows event, and the position of the
+// rows in it.
+func rowsEventData(f BinlogFormat, typ byte, tableID uint64, rows Rows) ([]byte, int) {
 	if f.HeaderSize(typ) == 6 {
 		panic("Not implemented, post_header_length==6")
 	}
 
+	isPartial := typ == ePartialUpdateRowsEvent
 	hasIdentify := typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 ||
-		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2
+		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2 || isPartial
 	hasData := typ == eWriteRowsEventV1 || typ == eWriteRowsEventV2 ||
-		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2
+		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 || isPartial
 
 	rowLen := rows.DataColumns.Count()
 	if hasIdentify {
@@ -427,6 +515,10 @@ func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64,
 			len(row.NullColumns.data) +
 			len(row.Identify) +
 			len(row.Data)
+		if isPartial {
+			length += 1 + // value options
+				len(row.JSONPartialValues.data)
+		}
 	}
 	data := make([]byte, length)
 
@@ -449,6 +541,7 @@ func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64,
 	if hasData {
 		pos += copy(data[pos:], rows.DataColumns.data)
 	}
+	rowsPos := pos
 
 	for _, row := range rows.Rows {
 		if hasIdentify {
@@ -456,11 +549,34 @@ func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64,
 			pos += copy(data[pos:], row.Identify)
 		}
 		if hasData {
+			if isPartial {
+				data[pos] = 0
+				if row.JSONPartialValues.Count() > 0 {
+					data[pos] = binlogRowValueOptionsPartialJSON
+				}
+				pos++
+				pos += copy(data[pos:], row.JSONPartialValues.data)
+			}
 			pos += copy(data[pos:], row.NullColumns.data)
 			pos += copy(data[pos:], row.Data)
 		}
 	}
+	return data, rowsPos
+}
 
-	ev := s.Packetize(f, typ, 0, data)
-	return NewMysql56BinlogEvent(ev)
+// mariadbCompress compresses the data of an event like MariaDB does with
+// log_bin_compress=ON.
+func mariadbCompress(data []byte) []byte {
+	var length []byte
+	for n := len(data); n > 0; n >>= 8 {
+		length = append([]byte{byte(n)}, length...)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(0x80 | byte(len(length)))
+	buf.Write(length)
+	w := zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
 }
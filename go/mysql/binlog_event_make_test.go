@@ -189,6 +189,42 @@ func TestMariadDBGTIDEVent(t *testing.T) {
 	}
 }
 
+func TestMySQL56GTIDEvent(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	s := NewFakeBinlogStream()
+
+	sid, err := replication.ParseSID("00010203-0405-0607-0809-0a0b0c0d0e0f")
+	require.NoError(t, err)
+	input := replication.Mysql56GTID{Server: sid, Sequence: 0x123456789abcdef}
+	event := NewMySQL56GTIDEvent(f, s, input)
+	require.True(t, event.IsValid(), "NewMySQL56GTIDEvent().IsValid() is false")
+	require.True(t, event.IsGTID(), "NewMySQL56GTIDEvent().IsGTID() is false")
+
+	event, _, err = event.StripChecksum(f)
+	require.NoError(t, err)
+	gtid, hasBegin, err := event.GTID(f)
+	require.NoError(t, err)
+	assert.False(t, hasBegin)
+	assert.Equal(t, input, gtid)
+}
+
+func TestMySQL56PreviousGTIDsEvent(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	s := NewFakeBinlogStream()
+
+	input, err := replication.ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:7")
+	require.NoError(t, err)
+	event := NewMySQL56PreviousGTIDsEvent(f, s, input)
+	require.True(t, event.IsValid(), "NewMySQL56PreviousGTIDsEvent().IsValid() is false")
+	require.True(t, event.IsPreviousGTIDs(), "NewMySQL56PreviousGTIDsEvent().IsPreviousGTIDs() is false")
+
+	event, _, err = event.StripChecksum(f)
+	require.NoError(t, err)
+	pos, err := event.PreviousGTIDs(f)
+	require.NoError(t, err)
+	assert.Equal(t, input, pos.GTIDSet)
+}
+
 func TestTableMapEvent(t *testing.T) {
 	f := NewMySQL56BinlogFormat()
 	s := NewFakeBinlogStream()
@@ -373,6 +409,83 @@ func TestRowsEvent(t *testing.T) {
 	assert.NotZero(t, event.Timestamp())
 }
 
+func TestPartialUpdateRowsEvent(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	// MySQL 5.6 does not have the event, its post header has the same
+	// size as the one of UPDATE_ROWS_EVENT v2.
+	f.HeaderSizes = append(f.HeaderSizes, 0, 0, 0, 10)
+	s := NewFakeBinlogStream()
+
+	tm := &TableMap{
+		Flags:    0x8090,
+		Database: "my_database",
+		Name:     "my_table",
+		Types: []byte{
+			binlog.TypeLong,
+			binlog.TypeJSON,
+		},
+		CanBeNull: NewServerBitmap(2),
+		Metadata: []uint16{
+			0,
+			4,
+		},
+	}
+	tm.CanBeNull.Set(1, true)
+
+	rows := Rows{
+		Flags:           0x1234,
+		IdentifyColumns: NewServerBitmap(2),
+		DataColumns:     NewServerBitmap(2),
+		Rows: []Row{
+			{
+				NullIdentifyColumns: NewServerBitmap(2),
+				NullColumns:         NewServerBitmap(2),
+				JSONPartialValues:   NewServerBitmap(1),
+				Identify: []byte{
+					0x10, 0x20, 0x30, 0x40, // long
+					0x0d, 0x00, 0x00, 0x00, // len({"a": 2})
+					0, 1, 0, 12, 0, 11, 0, 1, 0, 5, 2, 0, 97, // {"a": 2}
+				},
+				Data: []byte{
+					0x10, 0x20, 0x30, 0x40, // long
+					0x09, 0x00, 0x00, 0x00, // len(diffs)
+					0, 3, '$', '.', 'a', 3, 5, 3, 0, // replace $.a with 3
+				},
+			},
+			{
+				NullIdentifyColumns: NewServerBitmap(2),
+				NullColumns:         NewServerBitmap(2),
+				Identify: []byte{
+					0x11, 0x20, 0x30, 0x40, // long
+					0x0d, 0x00, 0x00, 0x00, // len({"a": 2})
+					0, 1, 0, 12, 0, 11, 0, 1, 0, 5, 2, 0, 97, // {"a": 2}
+				},
+				Data: []byte{
+					0x11, 0x20, 0x30, 0x40, // long
+					0x0d, 0x00, 0x00, 0x00, // len({"a": 4})
+					0, 1, 0, 12, 0, 11, 0, 1, 0, 5, 4, 0, 97, // {"a": 4}
+				},
+			},
+		},
+	}
+	rows.IdentifyColumns.Set(0, true)
+	rows.IdentifyColumns.Set(1, true)
+	rows.DataColumns.Set(0, true)
+	rows.DataColumns.Set(1, true)
+	rows.Rows[0].JSONPartialValues.Set(0, true)
+
+	event := NewPartialUpdateRowsEvent(f, s, 0x102030405060, rows)
+	require.True(t, event.IsValid(), "NewPartialUpdateRowsEvent().IsValid() is false")
+	require.True(t, event.IsUpdateRows(), "NewPartialUpdateRowsEvent().IsUpdateRows() if false")
+
+	event, _, err := event.StripChecksum(f)
+	require.NoError(t, err, "StripChecksum failed: %v", err)
+
+	gotRows, err := event.Rows(f, tm)
+	require.NoError(t, err, "NewPartialUpdateRowsEvent().Rows() returned error: %v", err)
+	require.True(t, reflect.DeepEqual(gotRows, rows), "NewPartialUpdateRowsEvent().Rows() got Rows:\n%v\nexpected:\n%v", gotRows, rows)
+}
+
 func TestHeartbeatEvent(t *testing.T) {
 	// MySQL 5.6
 	f := NewMySQL56BinlogFormat()
@@ -17,7 +17,10 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
+	"compress/zlib"
 	"encoding/binary"
+	"io"
 
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
@@ -73,9 +76,202 @@ func (ev mariadbBinlogEvent) GTID(f BinlogFormat) (replication.GTID, bool, error
 	}, flags2&FLStandalone == 0, nil
 }
 
-// PreviousGTIDs implements BinlogEvent.PreviousGTIDs().
+// IsPreviousGTIDs implements BinlogEvent.IsPreviousGTIDs().
+// MariaDB writes a GTID_LIST_EVENT at the start of each binlog file
+// instead of the PREVIOUS_GTIDS_EVENT of MySQL.
+func (ev mariadbBinlogEvent) IsPreviousGTIDs() bool {
+	return ev.Type() == eMariaGTIDListEvent
+}
+
+// PreviousGTIDs implements BinlogEvent.PreviousGTIDs(). It returns the
+// binlog state of the GTID_LIST_EVENT, keeping the last GTID of each domain.
+//
+// Expected format:
+//
+//	# bytes   field
+//	4         number of GTIDs (lower 28 bits) and flags (upper 4 bits)
+//	16*N      GTIDs:
+//	            4         domain ID
+//	            4         server ID
+//	            8         sequence number
 func (ev mariadbBinlogEvent) PreviousGTIDs(f BinlogFormat) (replication.Position, error) {
-	return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "MariaDB should not provide PREVIOUS_GTIDS_EVENT events")
+	if ev.Type() != eMariaGTIDListEvent {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "MariaDB should not provide PREVIOUS_GTIDS_EVENT events")
+	}
+
+	data := ev.Bytes()[f.HeaderLength:]
+	if len(data) < 4 {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "GTID_LIST_EVENT too short (%v bytes)", len(data))
+	}
+	count := int(binary.LittleEndian.Uint32(data[:4]) & 0x0fffffff)
+	if len(data) < 4+count*16 {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "GTID_LIST_EVENT with %v GTIDs overflows buffer (%v bytes)", count, len(data))
+	}
+
+	gtidSet := make(replication.MariadbGTIDSet, count)
+	for pos := 4; count > 0; count-- {
+		gtid := replication.MariadbGTID{
+			Domain:   binary.LittleEndian.Uint32(data[pos : pos+4]),
+			Server:   binary.LittleEndian.Uint32(data[pos+4 : pos+8]),
+			Sequence: binary.LittleEndian.Uint64(data[pos+8 : pos+16]),
+		}
+		pos += 16
+		if last, ok := gtidSet[gtid.Domain]; ok && last.Sequence >= gtid.Sequence {
+			continue
+		}
+		gtidSet[gtid.Domain] = gtid
+	}
+	return replication.Position{GTIDSet: gtidSet}, nil
+}
+
+// IsQuery implements BinlogEvent.IsQuery().
+func (ev mariadbBinlogEvent) IsQuery() bool {
+	return ev.Type() == eQueryEvent || ev.Type() == eMariaQueryCompressedEvent
+}
+
+// IsWriteRows implements BinlogEvent.IsWriteRows().
+func (ev mariadbBinlogEvent) IsWriteRows() bool {
+	return ev.binlogEvent.IsWriteRows() ||
+		ev.Type() == eMariaWriteRowsCompressedEventV1 ||
+		ev.Type() == eMariaWriteRowsCompressedEventV2
+}
+
+// IsUpdateRows implements BinlogEvent.IsUpdateRows().
+func (ev mariadbBinlogEvent) IsUpdateRows() bool {
+	return ev.binlogEvent.IsUpdateRows() ||
+		ev.Type() == eMariaUpdateRowsCompressedEventV1 ||
+		ev.Type() == eMariaUpdateRowsCompressedEventV2
+}
+
+// IsDeleteRows implements BinlogEvent.IsDeleteRows().
+func (ev mariadbBinlogEvent) IsDeleteRows() bool {
+	return ev.binlogEvent.IsDeleteRows() ||
+		ev.Type() == eMariaDeleteRowsCompressedEventV1 ||
+		ev.Type() == eMariaDeleteRowsCompressedEventV2
+}
+
+// Query implements BinlogEvent.Query(). The SQL statement of a
+// QUERY_COMPRESSED_EVENT is uncompressed.
+func (ev mariadbBinlogEvent) Query(f BinlogFormat) (Query, error) {
+	if ev.Type() != eMariaQueryCompressedEvent {
+		return ev.binlogEvent.Query(f)
+	}
+
+	// The statement follows the same fields as in a QUERY_EVENT.
+	data := ev.Bytes()[f.HeaderLength:]
+	const varsPos = 4 + 4 + 1 + 2 + 2
+	if len(data) < varsPos {
+		return Query{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "QUERY_COMPRESSED_EVENT too short (%v bytes)", len(data))
+	}
+	dbLen := int(data[4+4])
+	varsLen := int(binary.LittleEndian.Uint16(data[4+4+1+2 : 4+4+1+2+2]))
+	sqlPos := varsPos + varsLen + dbLen + 1
+	uncompressed, err := ev.uncompress(f, int(f.HeaderLength)+sqlPos, eQueryEvent)
+	if err != nil {
+		return Query{}, err
+	}
+	return uncompressed.Query(f)
+}
+
+// TableID implements BinlogEvent.TableID().
+func (ev mariadbBinlogEvent) TableID(f BinlogFormat) uint64 {
+	if typ, ok := mariadbUncompressedRowsEventTypes[ev.Type()]; ok {
+		// The post-header is not compressed, so only the type needs changing
+		// to read it with the header size of the uncompressed event.
+		data := append([]byte(nil), ev.Bytes()...)
+		data[4] = typ
+		return binlogEvent(data).TableID(f)
+	}
+	return ev.binlogEvent.TableID(f)
+}
+
+// Rows implements BinlogEvent.Rows(). The rows of the compressed rows
+// events are uncompressed.
+func (ev mariadbBinlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
+	typ, ok := mariadbUncompressedRowsEventTypes[ev.Type()]
+	if !ok {
+		return ev.binlogEvent.Rows(f, tm)
+	}
+
+	// The column count and the column bitmaps are not compressed.
+	data := ev.Bytes()
+	pos := int(f.HeaderLength) + int(f.HeaderSize(typ))
+	if pos > len(data) {
+		return Rows{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "compressed rows event too short (%v bytes)", len(data))
+	}
+	if typ == eWriteRowsEventV2 || typ == eUpdateRowsEventV2 || typ == eDeleteRowsEventV2 {
+		// The header size of the v2 events includes the 2 bytes of the extra
+		// data length, which also counts them.
+		pos += int(binary.LittleEndian.Uint16(data[pos-2:pos])) - 2
+	}
+	columnCount, pos, ok := readLenEncInt(data, pos)
+	if !ok {
+		return Rows{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "expected column count at position %v (data=%v)", pos, data)
+	}
+	pos += (int(columnCount) + 7) / 8
+	if typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 {
+		pos += (int(columnCount) + 7) / 8
+	}
+	uncompressed, err := ev.uncompress(f, pos, typ)
+	if err != nil {
+		return Rows{}, err
+	}
+	return uncompressed.Rows(f, tm)
+}
+
+// mariadbUncompressedRowsEventTypes maps the types of the compressed rows
+// events of MariaDB to the types of the rows events they compress.
+var mariadbUncompressedRowsEventTypes = map[byte]byte{
+	eMariaWriteRowsCompressedEventV1:  eWriteRowsEventV1,
+	eMariaUpdateRowsCompressedEventV1: eUpdateRowsEventV1,
+	eMariaDeleteRowsCompressedEventV1: eDeleteRowsEventV1,
+	eMariaWriteRowsCompressedEventV2:  eWriteRowsEventV2,
+	eMariaUpdateRowsCompressedEventV2: eUpdateRowsEventV2,
+	eMariaDeleteRowsCompressedEventV2: eDeleteRowsEventV2,
+}
+
+// uncompress returns the event of the given type made of the bytes of the
+// compressed event up to pos, followed by the uncompressed remaining bytes.
+//
+// Expected format of the compressed bytes:
+//
+//	# bytes   field
+//	1         0x80 | number of bytes of the uncompressed length (N)
+//	N         uncompressed length, big endian
+//	          zlib compressed data
+func (ev mariadbBinlogEvent) uncompress(f BinlogFormat, pos int, typ byte) (binlogEvent, error) {
+	data := ev.Bytes()
+	if pos >= len(data) {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "compressed data position overflows buffer (%v >= %v)", pos, len(data))
+	}
+	compressed := data[pos:]
+	lenLen := int(compressed[0] & 0x07)
+	if len(compressed) < 1+lenLen {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "compressed data too short (%v bytes)", len(compressed))
+	}
+	var length int
+	for _, b := range compressed[1 : 1+lenLen] {
+		length = length<<8 | int(b)
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(compressed[1+lenLen:]))
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot uncompress event of type %v", ev.Type())
+	}
+	defer reader.Close()
+	result := bytes.NewBuffer(make([]byte, 0, pos+length))
+	result.Write(data[:pos])
+	if _, err := io.Copy(result, reader); err != nil {
+		return nil, vterrors.Wrapf(err, "cannot uncompress event of type %v", ev.Type())
+	}
+	if result.Len() != pos+length {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "uncompressed %v bytes instead of %v for event of type %v", result.Len()-pos, length, ev.Type())
+	}
+
+	uncompressed := result.Bytes()
+	uncompressed[4] = typ
+	binary.LittleEndian.PutUint32(uncompressed[9:13], uint32(len(uncompressed)))
+	return binlogEvent(uncompressed), nil
 }
 
 // StripChecksum implements BinlogEvent.StripChecksum().
@@ -22,8 +22,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/mysql/binlog"
 	"vitess.io/vitess/go/mysql/replication"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 )
 
 // sample event data
@@ -213,3 +217,95 @@ func TestMariaDBSemiSyncAck(t *testing.T) {
 		assert.True(t, e.IsQuery())
 	}
 }
+
+func TestMariadbGTIDListEventPreviousGTIDs(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+
+	event := NewMariaDBGTIDListEvent(f, s, []replication.MariadbGTID{
+		{Domain: 0, Server: 1, Sequence: 10},
+		{Domain: 0, Server: 2, Sequence: 12},
+		{Domain: 1, Server: 1, Sequence: 5},
+	})
+	require.True(t, event.IsValid())
+	require.True(t, event.IsPreviousGTIDs())
+
+	pos, err := event.PreviousGTIDs(f)
+	require.NoError(t, err)
+	assert.Equal(t, "0-2-12,1-1-5", pos.GTIDSet.String())
+
+	_, err = NewMariadbBinlogEvent(mariadbInsertEvent).PreviousGTIDs(f)
+	assert.EqualError(t, err, "MariaDB should not provide PREVIOUS_GTIDS_EVENT events")
+}
+
+func TestMariadbCompressedQueryEvent(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+
+	q := Query{
+		Database: "my database",
+		SQL:      "insert into t values (repeat('a', 1000))",
+		Charset: &binlogdatapb.Charset{
+			Client: 33,
+			Conn:   33,
+			Server: 8,
+		},
+	}
+	event := NewMariaDBCompressedQueryEvent(f, s, q)
+	require.True(t, event.IsValid())
+	require.True(t, event.IsQuery())
+
+	got, err := event.Query(f)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(got.Charset, q.Charset))
+	got.Charset = q.Charset
+	assert.Equal(t, q, got)
+}
+
+func TestMariadbCompressedWriteRowsEvent(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+
+	tm := &TableMap{
+		Database: "my_database",
+		Name:     "my_table",
+		Types: []byte{
+			binlog.TypeLong,
+			binlog.TypeVarchar,
+		},
+		CanBeNull: NewServerBitmap(2),
+		Metadata: []uint16{
+			0,
+			384,
+		},
+	}
+	rows := Rows{
+		Flags:       0x1234,
+		DataColumns: NewServerBitmap(2),
+		Rows: []Row{
+			{
+				NullColumns: NewServerBitmap(2),
+				Data: []byte{
+					0x10, 0x20, 0x30, 0x40, // long
+					0x04, 0x00, // len('abcd')
+					'a', 'b', 'c', 'd', // 'abcd'
+				},
+			},
+		},
+	}
+	rows.DataColumns.Set(0, true)
+	rows.DataColumns.Set(1, true)
+
+	event := NewMariaDBCompressedWriteRowsEvent(f, s, 0x102030405060, rows)
+	require.True(t, event.IsValid())
+	require.True(t, event.IsWriteRows())
+	assert.False(t, event.IsUpdateRows())
+	assert.Equal(t, uint64(0x102030405060), event.TableID(f))
+
+	gotRows, err := event.Rows(f, tm)
+	require.NoError(t, err)
+	assert.Equal(t, rows, gotRows)
+	values, err := gotRows.StringValuesForTests(tm, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1076895760", "abcd"}, values)
+}
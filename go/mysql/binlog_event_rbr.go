@@ -26,6 +26,10 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
+// binlogRowValueOptionsPartialJSON is the PARTIAL_JSON_UPDATES bit of the
+// value options of the rows of a PARTIAL_UPDATE_ROWS_EVENT.
+const binlogRowValueOptionsPartialJSON = 1
+
 // TableMap implements BinlogEvent.TableMap().
 //
 // Expected format (L = total length of event data):
@@ -207,16 +211,24 @@ func metadataWrite(data []byte, pos int, typ byte, value uint16) int {
 // -- for each row
 // <var>      null bitmap for identify for present rows
 // <var>      values for each identify field
+// -- if PARTIAL_UPDATE_ROWS_EVENT
+// <var>      value options (var-len encoded)
+// <var>      partial bitmap for the present JSON fields, if PARTIAL_JSON is set
+// -- endif
 // <var>      null bitmap for data for present rows
 // <var>      values for each data field
 // --
+//
+// The value of a JSON field that has its bit set in the partial bitmap is
+// a list of JSON diffs, preceded by its length on 4 bytes.
 func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 	typ := ev.Type()
 	data := ev.Bytes()[f.HeaderLength:]
+	isPartial := typ == ePartialUpdateRowsEvent
 	hasIdentify := typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 ||
-		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2
+		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2 || isPartial
 	hasData := typ == eWriteRowsEventV1 || typ == eWriteRowsEventV2 ||
-		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2
+		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 || isPartial
 
 	result := Rows{}
 	pos := 6
@@ -227,7 +239,7 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 	pos += 2
 
 	// version=2 have extra data here.
-	if typ == eWriteRowsEventV2 || typ == eUpdateRowsEventV2 || typ == eDeleteRowsEventV2 {
+	if typ == eWriteRowsEventV2 || typ == eUpdateRowsEventV2 || typ == eDeleteRowsEventV2 || isPartial {
 		// This extraDataLength contains the 2 bytes length.
 		extraDataLength := binary.LittleEndian.Uint16(data[pos : pos+2])
 		pos += int(extraDataLength)
@@ -248,10 +260,16 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 		numIdentifyColumns = result.IdentifyColumns.BitCount()
 	}
 
+	numJSONColumns := 0
 	if hasData {
 		// Bitmap of columns that are present.
 		result.DataColumns, pos = newBitmap(data, pos, int(columnCount))
 		numDataColumns = result.DataColumns.BitCount()
+		for c := 0; c < int(columnCount); c++ {
+			if result.DataColumns.Bit(c) && tm.Types[c] == binlog.TypeJSON {
+				numJSONColumns++
+			}
+		}
 	}
 
 	// One row at a time.
@@ -289,18 +307,37 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 		}
 
 		if hasData {
+			if isPartial {
+				valueOptions, read, ok := readLenEncInt(data, pos)
+				if !ok {
+					return result, vterrors.Errorf(vtrpc.Code_INTERNAL, "expected value options at position %v (data=%v)", pos, data)
+				}
+				pos = read
+				if valueOptions&binlogRowValueOptionsPartialJSON != 0 {
+					// Bitmap of the JSON columns that are partial updates (amongst the ones that are present).
+					row.JSONPartialValues, pos = newBitmap(data, pos, numJSONColumns)
+				}
+			}
+
 			// Bitmap of columns that are null (amongst the ones that are present).
 			row.NullColumns, pos = newBitmap(data, pos, numDataColumns)
 
 			// Get the values.
 			startPos := pos
 			valueIndex := 0
+			jsonIndex := 0
 			for c := 0; c < int(columnCount); c++ {
 				if !result.DataColumns.Bit(c) {
 					// This column is not represented.
 					continue
 				}
 
+				partialJSON := false
+				if tm.Types[c] == binlog.TypeJSON {
+					partialJSON = row.JSONPartialValues.Count() > 0 && row.JSONPartialValues.Bit(jsonIndex)
+					jsonIndex++
+				}
+
 				if row.NullColumns.Bit(valueIndex) {
 					// This column is represented, but its value is NULL.
 					valueIndex++
@@ -308,6 +345,15 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 				}
 
 				// This column is represented now. We need to skip its length.
+				if partialJSON {
+					l, err := binlog.JSONDiffLength(data, pos)
+					if err != nil {
+						return result, err
+					}
+					pos += l
+					valueIndex++
+					continue
+				}
 				l, err := binlog.CellLength(data, pos, tm.Types[c], tm.Metadata[c])
 				if err != nil {
 					return result, err
@@ -174,7 +174,7 @@ func Connect(ctx context.Context, params *ConnParams) (*Conn, error) {
 // Ping implements mysql ping command.
 func (c *Conn) Ping() error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 	data, pos := c.startEphemeralPacketWithHeader(1)
 	data[pos] = ComPing
 
@@ -229,6 +229,21 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		c.Capabilities = capabilities & (CapabilityClientDeprecateEOF)
 	}
 
+	// Use compression if the server supports the algorithm we want.
+	if err := ValidateCompression(params.Compression, params.CompressionLevel); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRUnknownError, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	switch params.Compression {
+	case CompressionZlib:
+		c.Capabilities |= capabilities & CapabilityClientCompress
+	case CompressionZstd:
+		c.Capabilities |= capabilities & CapabilityClientZstdCompressionAlgorithm
+	}
+
+	// If the server supports query attributes, we send them with our
+	// queries, so that it can get the ones set on the connection.
+	c.Capabilities |= capabilities & CapabilityClientQueryAttributes
+
 	charset, err := collations.Local().ParseConnectionCharset(params.Charset)
 	if err != nil {
 		return err
@@ -281,6 +296,13 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		c.Capabilities |= CapabilityClientSSL
 	}
 
+	// The plugins which need data the initial handshake doesn't carry are
+	// only used after an auth switch, which the server requests when the
+	// client answers with another plugin.
+	if c.authPluginName == ClientEd25519 || c.authPluginName == MysqlDialog {
+		c.authPluginName = MysqlNativePassword
+	}
+
 	// Password encryption.
 	var scrambledPassword []byte
 	if c.authPluginName == CachingSha2Password {
@@ -311,6 +333,13 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		return err
 	}
 
+	// The packets following the handshake are compressed if it was negotiated.
+	if c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) != 0 {
+		if err := c.enableCompression(params.Compression, params.CompressionLevel); err != nil {
+			return sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "cannot enable compression: %v", err)
+		}
+	}
+
 	// If the server didn't support DbName in its handshake, set
 	// it now. This is what the 'mysql' client does.
 	if capabilities&CapabilityClientConnectWithDB == 0 && params.DbName != "" {
@@ -483,6 +512,11 @@ func (c *Conn) writeSSLRequest(capabilities uint32, characterSet uint8, params *
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
 		c.Capabilities&CapabilityClientSessionTrack |
+		// The compression we negotiated.
+		c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) |
+		// If the server supported
+		// CapabilityClientQueryAttributes, we also support it.
+		c.Capabilities&CapabilityClientQueryAttributes |
 		// Pass-through ClientFoundRows flag.
 		CapabilityClientFoundRows&uint32(params.Flags)
 
@@ -540,9 +574,17 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		c.Capabilities&CapabilityClientDeprecateEOF |
 		// Pass-through ClientFoundRows flag.
 		CapabilityClientFoundRows&uint32(params.Flags) |
+		// Pass-through ClientLocalFiles flag, for clients answering
+		// the LOCAL INFILE requests themselves.
+		CapabilityClientLocalFiles&uint32(params.Flags) |
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
-		c.Capabilities&CapabilityClientSessionTrack
+		c.Capabilities&CapabilityClientSessionTrack |
+		// The compression we negotiated.
+		c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) |
+		// If the server supported
+		// CapabilityClientQueryAttributes, we also support it.
+		c.Capabilities&CapabilityClientQueryAttributes
 
 	// FIXME(alainjobart) add multi statement.
 
@@ -569,6 +611,15 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		length++
 	}
 
+	// The zstd compression level, if we use zstd.
+	zstdLevel := params.CompressionLevel
+	if capabilityFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		if zstdLevel == 0 {
+			zstdLevel = DefaultZstdCompressionLevel
+		}
+		length++
+	}
+
 	data, pos := c.startEphemeralPacketWithHeader(length)
 
 	// Client capability flags.
@@ -605,6 +656,11 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 	// Assume native client during response
 	pos = writeNullString(data, pos, string(c.authPluginName))
 
+	// zstd compression level, only if we use zstd.
+	if capabilityFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		pos = writeByte(data, pos, byte(zstdLevel))
+	}
+
 	// Sanity-check the length.
 	if pos != len(data) {
 		return sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "writeHandshakeResponse41: only packed %v bytes, out of %v allocated", pos, len(data))
@@ -634,6 +690,13 @@ func (c *Conn) handleAuthResponse(params *ConnParams) error {
 			return err
 		}
 	case AuthMoreDataPacket:
+		if c.authPluginName == MysqlDialog {
+			// Server is asking another question of the dialog
+			if err := c.writeDialogAnswer(params, response[1:]); err != nil {
+				return err
+			}
+			return c.handleAuthResponse(params)
+		}
 		// Server is requesting more data - maybe un-scrambled password
 		if err := c.handleAuthMoreDataPacket(response[1], params); err != nil {
 			return err
@@ -673,6 +736,16 @@ func (c *Conn) handleAuthSwitchPacket(params *ConnParams, response []byte) error
 		if err := c.writeScrambledPassword(scrambledPassword); err != nil {
 			return err
 		}
+	case ClientEd25519:
+		scrambledPassword := ScrambleEd25519Password(c.salt, []byte(params.Pass))
+		if err := c.writeScrambledPassword(scrambledPassword); err != nil {
+			return err
+		}
+	case MysqlDialog:
+		// The data is the first question of the dialog.
+		if err := c.writeDialogAnswer(params, salt); err != nil {
+			return err
+		}
 	default:
 		return sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "server asked for unsupported auth method: %v", c.authPluginName)
 	}
@@ -727,19 +800,35 @@ func parseAuthSwitchRequest(data []byte) (AuthMethodDescription, []byte, error)
 		return "", nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "cannot get plugin name from AuthSwitchRequest: %v", data)
 	}
 
-	// If this was a request with a salt in it, max 20 bytes
+	// The data of the dialog is its first question, and the nonce of
+	// client_ed25519 is 32 bytes.
 	salt := data[pos:]
+	if pluginName == string(MysqlDialog) || pluginName == string(ClientEd25519) {
+		return AuthMethodDescription(pluginName), salt, nil
+	}
+
+	// If this was a request with a salt in it, max 20 bytes
 	if len(salt) > 20 {
 		salt = salt[:20]
 	}
 	return AuthMethodDescription(pluginName), salt, nil
 }
 
+// writeDialogAnswer answers a question of the dialog plugin, which servers use
+// for PAM authentication, with the password. The client is not interactive,
+// so it can only answer the questions asking for the password.
+func (c *Conn) writeDialogAnswer(params *ConnParams, question []byte) error {
+	if len(question) == 0 || question[0]&^1 != mysqlDialogAskPassword {
+		return sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "server asked an unsupported dialog question: %q", question)
+	}
+	return c.writeClearTextPassword(params)
+}
+
 // requestPublicKey requests a public key from the server
 func (c *Conn) requestPublicKey() (rsaKey *rsa.PublicKey, err error) {
 	// get public key from server
 	data, pos := c.startEphemeralPacketWithHeader(1)
-	data[pos] = 0x02
+	data[pos] = CachingSha2RequestPublicKey
 	if err := c.writeEphemeralPacket(); err != nil {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error sending public key request packet: %v", err)
 	}
@@ -18,6 +18,7 @@ package mysql
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/tls"
 	"fmt"
 	"net"
@@ -501,3 +502,135 @@ func TestTLSClientVerifyIdentity(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Certificate revoked: CommonName=server.example.com")
 }
+
+func TestScrambleEd25519Password(t *testing.T) {
+	nonce := []byte("0123456789abcdef0123456789abcdef")
+	signature := ScrambleEd25519Password(nonce, []byte("password1"))
+	require.Len(t, signature, ed25519.SignatureSize)
+
+	// The signature is a standard Ed25519 signature with the public key
+	// derived from the password.
+	_, _, publicKey := ed25519PasswordKey([]byte("password1"))
+	assert.True(t, ed25519.Verify(publicKey, nonce, signature))
+	_, _, publicKey = ed25519PasswordKey([]byte("password2"))
+	assert.False(t, ed25519.Verify(publicKey, nonce, signature))
+}
+
+// ed25519TestAuthServer authenticates the users with the ed25519 plugin of
+// MariaDB, their public key being derived from their name.
+type ed25519TestAuthServer struct{}
+
+func (ed25519TestAuthServer) AuthMethods() []AuthMethod {
+	return []AuthMethod{ed25519TestAuthMethod{}}
+}
+
+func (ed25519TestAuthServer) DefaultAuthMethodDescription() AuthMethodDescription {
+	return MysqlNativePassword
+}
+
+type ed25519TestAuthMethod struct{}
+
+func (ed25519TestAuthMethod) Name() AuthMethodDescription {
+	return ClientEd25519
+}
+
+func (ed25519TestAuthMethod) HandleUser(conn *Conn, user string) bool {
+	return true
+}
+
+func (ed25519TestAuthMethod) AuthPluginData() ([]byte, error) {
+	return []byte("0123456789abcdef0123456789abcdef"), nil
+}
+
+func (ed25519TestAuthMethod) AllowClearTextWithoutTLS() bool {
+	return true
+}
+
+func (ed25519TestAuthMethod) HandleAuthPluginData(conn *Conn, user string, serverAuthPluginData []byte, clientAuthPluginData []byte, remoteAddr net.Addr) (Getter, error) {
+	_, _, publicKey := ed25519PasswordKey([]byte(user + "-password"))
+	if !ed25519.Verify(publicKey, serverAuthPluginData, clientAuthPluginData) {
+		return nil, sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+	}
+	return &StaticUserData{}, nil
+}
+
+func TestClientEd25519Auth(t *testing.T) {
+	th := &testHandler{}
+	l, err := NewListener("tcp", "127.0.0.1:", ed25519TestAuthServer{}, th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "user1-password",
+	}
+
+	// The server switches to client_ed25519, whose nonce the client signs.
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err)
+	defer c.Close()
+	assert.Equal(t, ClientEd25519, c.authPluginName)
+	_, err = c.ExecuteFetch("select rows", 1000, true)
+	require.NoError(t, err)
+
+	params.Pass = "bad"
+	_, err = Connect(context.Background(), params)
+	assert.ErrorContains(t, err, "Access denied for user 'user1'")
+}
+
+func TestClientDialogAuth(t *testing.T) {
+	th := &testHandler{}
+	authServer := NewAuthServerStaticWithAuthMethodDescription("", "", 0, MysqlDialog)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+	}}
+	defer authServer.close()
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	l.AllowClearTextWithoutTLS.Store(true)
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+
+	// The server asks for the password with the dialog plugin, as for PAM.
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err)
+	defer c.Close()
+	assert.Equal(t, MysqlDialog, c.authPluginName)
+
+	params.Pass = "bad"
+	_, err = Connect(context.Background(), params)
+	assert.ErrorContains(t, err, "Access denied for user 'user1'")
+}
+
+func TestWriteDialogAnswer(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	params := &ConnParams{Pass: "password1"}
+	for _, question := range []string{"\x04Password: ", "\x05Password: "} {
+		require.NoError(t, cConn.writeDialogAnswer(params, []byte(question)))
+		data, err := sConn.ReadPacket()
+		require.NoError(t, err)
+		assert.Equal(t, "password1\x00", string(data))
+	}
+
+	// Only the password can be given.
+	err := cConn.writeDialogAnswer(params, []byte("\x02Verification code: "))
+	assert.ErrorContains(t, err, "server asked an unsupported dialog question")
+}
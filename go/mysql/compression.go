@@ -0,0 +1,328 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// Compression algorithms of the protocol. zlib is negotiated with
+// CapabilityClientCompress, and zstd with CapabilityClientZstdCompressionAlgorithm.
+const (
+	CompressionZlib = "zlib"
+	CompressionZstd = "zstd"
+)
+
+const (
+	// DefaultZlibCompressionLevel is the zlib level used when none is configured.
+	DefaultZlibCompressionLevel = 6
+
+	// DefaultZstdCompressionLevel is the zstd level used when none is configured,
+	// it is the default of the MySQL clients.
+	DefaultZstdCompressionLevel = 3
+
+	// compressedHeaderSize is the 7 bytes of header of the compressed packets:
+	// the length of the payload as sent (3 bytes), the compressed sequence
+	// number (1 byte), and the length of the payload before compression
+	// (3 bytes), which is 0 if the payload is sent uncompressed.
+	compressedHeaderSize = 7
+
+	// minCompressLength is the length under which the payloads are sent
+	// uncompressed, as compressing them isn't worth it. It is the same as MySQL's.
+	minCompressLength = 50
+
+	// maxRetainedCompressionBuffer is the size over which the buffers of a
+	// connection are released after use instead of being kept for reuse, so
+	// that an occasional big packet doesn't pin memory for the connection lifetime.
+	maxRetainedCompressionBuffer = 4 * connBufferSize
+)
+
+var (
+	// zstdDecoderForProtocol decodes the zstd compressed packets. DecodeAll can
+	// be called concurrently, and the memory it allocates is bounded by the
+	// maximum length of a compressed packet.
+	zstdDecoderForProtocol, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxPacketSize))
+
+	// zstdEncoders are the zstd encoders of the compressed packets, by level.
+	// EncodeAll can be called concurrently, so they are shared by the connections.
+	zstdEncodersMu sync.Mutex
+	zstdEncoders   = make(map[zstd.EncoderLevel]*zstd.Encoder)
+)
+
+// ValidateCompression checks the compression algorithm and level. An empty
+// algorithm means no compression, and a zero level the default level of
+// the algorithm.
+func ValidateCompression(algorithm string, level int) error {
+	switch algorithm {
+	case "":
+		return nil
+	case CompressionZlib:
+		if level < 0 || level > zlib.BestCompression {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid zlib compression level %d, must be between 1 and %d", level, zlib.BestCompression)
+		}
+	case CompressionZstd:
+		if level < 0 || level > 22 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid zstd compression level %d, must be between 1 and 22", level)
+		}
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid compression algorithm %q, must be %s or %s", algorithm, CompressionZlib, CompressionZstd)
+	}
+	return nil
+}
+
+func zstdEncoder(level int) (*zstd.Encoder, error) {
+	encoderLevel := zstd.EncoderLevelFromZstd(level)
+
+	zstdEncodersMu.Lock()
+	defer zstdEncodersMu.Unlock()
+	if encoder, ok := zstdEncoders[encoderLevel]; ok {
+		return encoder, nil
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel))
+	if err != nil {
+		return nil, err
+	}
+	zstdEncoders[encoderLevel] = encoder
+	return encoder, nil
+}
+
+// compressedConn implements the compressed protocol, once it has been
+// negotiated during the handshake. The packets are still built and parsed
+// as usual, but the bytes are carried in compressed packets, each one
+// holding a part of the packets stream.
+type compressedConn struct {
+	algorithm string
+	level     int
+
+	r io.Reader
+	w io.Writer
+
+	// sequence is the sequence number of the compressed packets. It is
+	// reset with the sequence number of the packets for each command, but
+	// is incremented separately.
+	sequence uint8
+
+	header [compressedHeaderSize]byte
+
+	// readBuf holds the payload of the last compressed packet read, and
+	// readPos the position of the first byte of it not consumed yet.
+	readBuf     []byte
+	readPos     int
+	compressBuf []byte
+	writeBuf    []byte
+	zlibReader  io.ReadCloser
+	zlibWriter  *zlib.Writer
+	zstdEncoder *zstd.Encoder
+	bytesReader bytes.Reader
+}
+
+func newCompressedConn(r io.Reader, w io.Writer, algorithm string, level int) (*compressedConn, error) {
+	cc := &compressedConn{
+		algorithm: algorithm,
+		level:     level,
+		r:         r,
+		w:         w,
+	}
+	switch algorithm {
+	case CompressionZlib:
+		if cc.level == 0 {
+			cc.level = DefaultZlibCompressionLevel
+		}
+	case CompressionZstd:
+		if cc.level == 0 {
+			cc.level = DefaultZstdCompressionLevel
+		}
+		encoder, err := zstdEncoder(cc.level)
+		if err != nil {
+			return nil, err
+		}
+		cc.zstdEncoder = encoder
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unknown compression algorithm %q", algorithm)
+	}
+	return cc, nil
+}
+
+// Read implements io.Reader, returning the bytes of the packets stream
+// carried by the compressed packets.
+func (cc *compressedConn) Read(p []byte) (int, error) {
+	if cc.readPos == len(cc.readBuf) {
+		if err := cc.readCompressedPacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, cc.readBuf[cc.readPos:])
+	cc.readPos += n
+	return n, nil
+}
+
+func (cc *compressedConn) readCompressedPacket() error {
+	// The errors reading the header are returned as is, so that the
+	// callers can recognize the connection being closed.
+	if _, err := io.ReadFull(cc.r, cc.header[:]); err != nil {
+		return err
+	}
+	length := int(uint32(cc.header[0]) | uint32(cc.header[1])<<8 | uint32(cc.header[2])<<16)
+	uncompressedLength := int(uint32(cc.header[4]) | uint32(cc.header[5])<<8 | uint32(cc.header[6])<<16)
+	// Like MySQL, we don't check the sequence number of the compressed
+	// packets we read, we only follow it for the ones we write.
+	cc.sequence = cc.header[3] + 1
+
+	cc.readPos = 0
+	cc.readBuf = releaseCompressionBuffer(cc.readBuf)
+	if uncompressedLength == 0 {
+		cc.readBuf = resizeCompressionBuffer(cc.readBuf, length)
+		if _, err := io.ReadFull(cc.r, cc.readBuf); err != nil {
+			return vterrors.Wrapf(err, "io.ReadFull(compressed packet body of length %v) failed", length)
+		}
+		return nil
+	}
+
+	cc.compressBuf = resizeCompressionBuffer(cc.compressBuf, length)
+	if _, err := io.ReadFull(cc.r, cc.compressBuf); err != nil {
+		return vterrors.Wrapf(err, "io.ReadFull(compressed packet body of length %v) failed", length)
+	}
+	cc.readBuf = resizeCompressionBuffer(cc.readBuf, uncompressedLength)
+	switch cc.algorithm {
+	case CompressionZlib:
+		cc.bytesReader.Reset(cc.compressBuf)
+		var err error
+		if cc.zlibReader == nil {
+			cc.zlibReader, err = zlib.NewReader(&cc.bytesReader)
+		} else {
+			err = cc.zlibReader.(zlib.Resetter).Reset(&cc.bytesReader, nil)
+		}
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot decompress packet")
+		}
+		if _, err := io.ReadFull(cc.zlibReader, cc.readBuf); err != nil {
+			return vterrors.Wrapf(err, "cannot decompress packet of length %v", uncompressedLength)
+		}
+	case CompressionZstd:
+		data, err := zstdDecoderForProtocol.DecodeAll(cc.compressBuf, cc.readBuf[:0])
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot decompress packet")
+		}
+		if len(data) != uncompressedLength {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "decompressed packet has length %v, expected %v", len(data), uncompressedLength)
+		}
+		cc.readBuf = data
+	}
+	cc.compressBuf = releaseCompressionBuffer(cc.compressBuf)
+	return nil
+}
+
+// Write implements io.Writer, sending the bytes of the packets stream in
+// compressed packets.
+func (cc *compressedConn) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		payload := p[written:]
+		if len(payload) > MaxPacketSize {
+			payload = payload[:MaxPacketSize]
+		}
+		if err := cc.writeCompressedPacket(payload); err != nil {
+			return written, err
+		}
+		written += len(payload)
+	}
+	return written, nil
+}
+
+func (cc *compressedConn) writeCompressedPacket(payload []byte) error {
+	data := cc.writeBuf[:0]
+	data = append(data, make([]byte, compressedHeaderSize)...)
+	uncompressedLength := 0
+	if len(payload) >= minCompressLength {
+		var err error
+		data, err = cc.compress(data, payload)
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot compress packet")
+		}
+		uncompressedLength = len(payload)
+		// Like MySQL, the payload is sent uncompressed if compression didn't make it smaller.
+		if len(data)-compressedHeaderSize >= len(payload) {
+			data = data[:compressedHeaderSize]
+			uncompressedLength = 0
+		}
+	}
+	if uncompressedLength == 0 {
+		data = append(data, payload...)
+	}
+
+	length := len(data) - compressedHeaderSize
+	data[0] = byte(length)
+	data[1] = byte(length >> 8)
+	data[2] = byte(length >> 16)
+	data[3] = cc.sequence
+	data[4] = byte(uncompressedLength)
+	data[5] = byte(uncompressedLength >> 8)
+	data[6] = byte(uncompressedLength >> 16)
+	cc.sequence++
+
+	_, err := cc.w.Write(data)
+	cc.writeBuf = releaseCompressionBuffer(data)
+	return err
+}
+
+// compress appends the compressed payload to data.
+func (cc *compressedConn) compress(data, payload []byte) ([]byte, error) {
+	if cc.algorithm == CompressionZstd {
+		return cc.zstdEncoder.EncodeAll(payload, data), nil
+	}
+
+	buf := bytes.NewBuffer(data)
+	if cc.zlibWriter == nil {
+		var err error
+		if cc.zlibWriter, err = zlib.NewWriterLevel(buf, cc.level); err != nil {
+			return nil, err
+		}
+	} else {
+		cc.zlibWriter.Reset(buf)
+	}
+	if _, err := cc.zlibWriter.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := cc.zlibWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeCompressionBuffer returns a buffer of the given length, reusing buf if it is big enough.
+func resizeCompressionBuffer(buf []byte, length int) []byte {
+	if cap(buf) < length {
+		return make([]byte, length)
+	}
+	return buf[:length]
+}
+
+// releaseCompressionBuffer returns the buffer to keep for reuse once buf was used.
+func releaseCompressionBuffer(buf []byte) []byte {
+	if cap(buf) > maxRetainedCompressionBuffer {
+		return nil
+	}
+	return buf[:0]
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressedConn(t *testing.T) {
+	random := make([]byte, 1000)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+
+	payloads := map[string][]byte{
+		"small":        []byte("select 1"),
+		"compressible": bytes.Repeat([]byte("select * from t"), 1000),
+		"random":       random,
+	}
+	for _, algorithm := range []string{CompressionZlib, CompressionZstd} {
+		for name, payload := range payloads {
+			t.Run(algorithm+"-"+name, func(t *testing.T) {
+				var buf bytes.Buffer
+				writer, err := newCompressedConn(nil, &buf, algorithm, 0)
+				require.NoError(t, err)
+				n, err := writer.Write(payload)
+				require.NoError(t, err)
+				assert.Equal(t, len(payload), n)
+				n, err = writer.Write(payload)
+				require.NoError(t, err)
+				assert.Equal(t, len(payload), n)
+				assert.EqualValues(t, 2, writer.sequence)
+
+				data := buf.Bytes()
+				uncompressedLength := int(uint32(data[4]) | uint32(data[5])<<8 | uint32(data[6])<<16)
+				if name == "compressible" {
+					assert.Equal(t, len(payload), uncompressedLength)
+					assert.Less(t, buf.Len(), len(payload))
+				} else {
+					// The payload is sent as is, if it is small or compressing it doesn't help.
+					assert.Zero(t, uncompressedLength)
+				}
+
+				reader, err := newCompressedConn(&buf, nil, algorithm, 0)
+				require.NoError(t, err)
+				got := make([]byte, 2*len(payload))
+				_, err = io.ReadFull(reader, got)
+				require.NoError(t, err)
+				assert.Equal(t, append(payload, payload...), got)
+				assert.EqualValues(t, 2, reader.sequence)
+
+				_, err = reader.Read(got)
+				assert.Equal(t, io.EOF, err)
+			})
+		}
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	assert.NoError(t, ValidateCompression("", 0))
+	assert.NoError(t, ValidateCompression(CompressionZlib, 0))
+	assert.NoError(t, ValidateCompression(CompressionZlib, 9))
+	assert.NoError(t, ValidateCompression(CompressionZstd, 22))
+	assert.ErrorContains(t, ValidateCompression(CompressionZlib, 10), "invalid zlib compression level")
+	assert.ErrorContains(t, ValidateCompression(CompressionZstd, -1), "invalid zstd compression level")
+	assert.ErrorContains(t, ValidateCompression("lz4", 0), "invalid compression algorithm")
+}
@@ -19,6 +19,7 @@ package mysql
 import (
 	"bufio"
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -131,6 +132,19 @@ type Conn struct {
 	flushTimer     *time.Timer
 	header         [packetHeaderSize]byte
 
+	// compressed carries the packets in compressed packets once
+	// compression was negotiated during the handshake. It is nil
+	// for uncompressed connections.
+	compressed *compressedConn
+	// compressionLevel is the zstd compression level requested by
+	// the client during the handshake. It is only used by the server.
+	compressionLevel int
+
+	// sessionStateChanges are the session state changes recorded by the
+	// handler for the statement being run, once encoded. They are sent to
+	// the client with the next OK packet. It is only used by the server.
+	sessionStateChanges []byte
+
 	// Keep track of how and of the buffer we allocated for an
 	// ephemeral packet on the read and write sides.
 	// These fields are used by:
@@ -157,10 +171,27 @@ type Conn struct {
 	// the client and the server, and currently in use.
 	// It is set during the initial handshake.
 	//
-	// It is only used for CapabilityClientDeprecateEOF
-	// and CapabilityClientFoundRows.
+	// It is only used for CapabilityClientDeprecateEOF,
+	// CapabilityClientFoundRows, CapabilityClientQueryAttributes
+	// and the compression capabilities.
 	Capabilities uint32
 
+	// QueryAttributes are the query attributes of the query, when
+	// CapabilityClientQueryAttributes was negotiated:
+	// - for the server, they are the ones sent by the client with
+	// the query being run, and are only set while it runs.
+	// - for clients, they are sent with the queries written, and
+	// are left untouched.
+	// The attributes with a NULL value are ignored.
+	QueryAttributes map[string]string
+
+	// ConnectionAttributes are the connection attributes, such as
+	// program_name, sent by the client during the handshake when
+	// CapabilityClientConnAttr was negotiated:
+	// - for the server, they are the ones sent by the client.
+	// - for clients, they are the ones of ConnParams.
+	ConnectionAttributes map[string]string
+
 	// closed is set to true when Close() is called on the connection.
 	closed atomic.Bool
 
@@ -213,6 +244,12 @@ type Conn struct {
 	// this is used to mark the connection to be closed so that the command phase for the connection can be stopped and
 	// the connection gets closed.
 	closing bool
+
+	// processMu protects process.
+	processMu sync.Mutex
+	// process is what the connection is doing, as reported by
+	// ProcessInfo. It is only used by the server.
+	process ProcessInfo
 }
 
 // splitStatementFunciton is the function that is used to split the statement in case of a multi-statement query.
@@ -275,6 +312,10 @@ func newServerConn(conn net.Conn, listener *Listener) *Conn {
 		listener:    listener,
 		PrepareData: make(map[uint32]*PrepareData),
 		keepAliveOn: enabledKeepAlive,
+		process: ProcessInfo{
+			Command: ProcessCommandConnect,
+			Since:   time.Now(),
+		},
 	}
 
 	if listener.connReadBufferSize > 0 {
@@ -315,7 +356,11 @@ func (c *Conn) startWriterBuffering() {
 	defer c.bufMu.Unlock()
 
 	c.bufferedWriter = writersPool.Get().(*bufio.Writer)
-	c.bufferedWriter.Reset(c.conn)
+	if c.compressed != nil {
+		c.bufferedWriter.Reset(c.compressed)
+	} else {
+		c.bufferedWriter.Reset(c.conn)
+	}
 }
 
 // endWriterBuffering must be called to terminate startWriteBuffering.
@@ -337,6 +382,19 @@ func (c *Conn) endWriterBuffering() error {
 	return c.bufferedWriter.Flush()
 }
 
+// flush writes out the buffered packets right away, when the server
+// waits for the client to answer them in the middle of a command.
+func (c *Conn) flush() error {
+	c.bufMu.Lock()
+	defer c.bufMu.Unlock()
+
+	if c.bufferedWriter == nil {
+		return nil
+	}
+	c.stopFlushTimer()
+	return c.bufferedWriter.Flush()
+}
+
 func (c *Conn) returnReader() {
 	if c.bufferedReader == nil {
 		return
@@ -359,6 +417,9 @@ func (c *Conn) getWriter() (w io.Writer, unget func()) {
 		}
 	}
 	c.bufMu.Unlock()
+	if c.compressed != nil {
+		return c.compressed, func() {}
+	}
 	return c.conn, func() {}
 }
 
@@ -385,9 +446,34 @@ func (c *Conn) stopFlushTimer() {
 	}
 }
 
+// enableCompression starts carrying the packets in compressed packets,
+// using the algorithm negotiated during the handshake. It must be called
+// right after the handshake, on both sides of the connection.
+func (c *Conn) enableCompression(algorithm string, level int) error {
+	compressed, err := newCompressedConn(c.getReader(), c.conn, algorithm, level)
+	if err != nil {
+		return err
+	}
+	c.compressed = compressed
+	return nil
+}
+
+// resetSequence resets the sequence number of the packets, and of the
+// compressed packets if compression is in use, for a new command.
+func (c *Conn) resetSequence() {
+	c.sequence = 0
+	if c.compressed != nil {
+		c.compressed.sequence = 0
+	}
+}
+
 // getReader returns reader for connection. It can be *bufio.Reader or net.Conn
-// depending on which buffer size was passed to newServerConn.
+// depending on which buffer size was passed to newServerConn, or the
+// compressedConn if compression is in use.
 func (c *Conn) getReader() io.Reader {
+	if c.compressed != nil {
+		return c.compressed
+	}
 	if c.bufferedReader != nil {
 		return c.bufferedReader
 	}
@@ -415,6 +501,11 @@ func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	}
 
 	sequence := uint8(c.header[3])
+	if c.compressed != nil {
+		// Like MySQL, the sequence numbers of the packets carried in
+		// compressed packets are not checked, only followed.
+		c.sequence = sequence
+	}
 	if sequence != c.sequence {
 		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
 	}
@@ -719,7 +810,7 @@ func (c *Conn) recycleWritePacket() {
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) writeComQuit() error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
 	data, pos := c.startEphemeralPacketWithHeader(1)
 	data[pos] = ComQuit
@@ -791,14 +882,23 @@ func (c *Conn) writeOKPacketWithHeader(packetOk *PacketOK, headerType byte) erro
 	// assuming CapabilityClientProtocol41
 	length += 4 // status_flags + warnings
 
+	statusFlags := packetOk.statusFlags
 	var gtidData []byte
 	if c.Capabilities&CapabilityClientSessionTrack == CapabilityClientSessionTrack {
 		length += lenEncStringSize(packetOk.info) // info
-		if packetOk.statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
+		if statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
 			gtidData = getLenEncString([]byte(packetOk.sessionStateData))
 			gtidData = append([]byte{0x00}, gtidData...)
 			gtidData = getLenEncString(gtidData)
 			gtidData = append([]byte{0x03}, gtidData...)
+		}
+		// The session state changes recorded by the handler follow the GTIDs.
+		if len(c.sessionStateChanges) > 0 {
+			statusFlags |= ServerSessionStateChanged
+			gtidData = append(gtidData, c.sessionStateChanges...)
+			c.sessionStateChanges = nil
+		}
+		if statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
 			gtidData = append(getLenEncInt(uint64(len(gtidData))), gtidData...)
 			length += len(gtidData)
 		}
@@ -811,11 +911,11 @@ func (c *Conn) writeOKPacketWithHeader(packetOk *PacketOK, headerType byte) erro
 	data.writeByte(headerType) // header - OK or EOF
 	data.writeLenEncInt(packetOk.affectedRows)
 	data.writeLenEncInt(packetOk.lastInsertID)
-	data.writeUint16(packetOk.statusFlags)
+	data.writeUint16(statusFlags)
 	data.writeUint16(packetOk.warnings)
 	if c.Capabilities&CapabilityClientSessionTrack == CapabilityClientSessionTrack {
 		data.writeLenEncString(packetOk.info)
-		if packetOk.statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
+		if statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
 			data.writeEOFString(string(gtidData))
 		}
 	} else {
@@ -925,7 +1025,7 @@ func (c *Conn) writeEOFPacket(flags uint16, warnings uint16) error {
 // handleNextCommand is called in the server loop to process
 // incoming packets.
 func (c *Conn) handleNextCommand(handler Handler) bool {
-	c.sequence = 0
+	c.resetSequence()
 	data, err := c.readEphemeralPacket()
 	if err != nil {
 		// Don't log EOF errors. They cause too much spam.
@@ -974,6 +1074,8 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 	case ComResetConnection:
 		c.handleComResetConnection(handler)
 		return true
+	case ComChangeUser:
+		return c.handleComChangeUser(handler, data)
 	case ComFieldList:
 		c.recycleReadPacket()
 		if !c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "command handling not implemented yet: %v", data[0]) {
@@ -1057,6 +1159,9 @@ func (c *Conn) handleComBinlogDumpGTID(handler Handler, data []byte) (kontinue b
 	}
 	if err := handler.ComBinlogDumpGTID(c, logFile, logPos, position.GTIDSet); err != nil {
 		log.Error(err.Error())
+		// As mysqld, report the error to the replica, e.g. when
+		// the binary logs it requires are purged.
+		c.writeErrorPacketFromError(err)
 		return false
 	}
 	return kontinue
@@ -1074,6 +1179,53 @@ func (c *Conn) handleComResetConnection(handler Handler) {
 	}
 }
 
+func (c *Conn) handleComChangeUser(handler Handler, data []byte) bool {
+	user, authMethod, authResponse, schemaName, err := c.parseComChangeUser(data)
+	c.recycleReadPacket()
+	if err != nil {
+		log.Errorf("conn %v: parseComChangeUser failed: %v", c.ID(), err)
+		return false
+	}
+
+	// Authenticate the new user against the salt of the initial handshake.
+	// On failure, the error is sent to the client and the connection closed.
+	userData, salt, ok := c.listener.authenticate(c, user, authMethod, authResponse, c.salt)
+	if !ok {
+		return false
+	}
+
+	if c.User != "" {
+		connCountPerUser.Add(c.User, -1)
+	}
+	c.User = user
+	c.UserData = userData
+	c.salt = salt
+	c.setProcessUser(user)
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+	}
+
+	// The new user starts from a clean session, without prepared statements.
+	handler.ComChangeUser(c)
+	c.PrepareData = make(map[uint32]*PrepareData)
+
+	if schemaName != "" {
+		err := handler.ComQuery(c, "use "+sqlescape.EscapeID(schemaName), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			c.writeErrorPacketFromError(err)
+			return false
+		}
+	}
+
+	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
+		log.Errorf("Error writing ComChangeUser result to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
 func (c *Conn) handleComStmtReset(data []byte) bool {
 	stmtID, ok := c.parseComStmtReset(data)
 	c.recycleReadPacket()
@@ -1146,6 +1298,9 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 	queryStart := time.Now()
 	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
+	defer func() {
+		c.QueryAttributes = nil
+	}()
 
 	if stmtID != uint32(0) {
 		defer func() {
@@ -1163,6 +1318,8 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
 	prepare := c.PrepareData[stmtID]
+	c.startProcessCommand(ProcessCommandExecute, prepare.PrepareStmt)
+	defer c.endProcessCommand()
 	err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
@@ -1362,11 +1519,16 @@ func (c *Conn) handleComQuery(handler Handler, data []byte) (kontinue bool) {
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	defer func() {
+		c.QueryAttributes = nil
+	}()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
 
 	var queries []string
-	var err error
 	if c.Capabilities&CapabilityClientMultiStatements != 0 {
 		queries, err = splitStatementFunction(query)
 		if err != nil {
@@ -1401,6 +1563,8 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
 
+	c.startProcessCommand(ProcessCommandQuery, query)
+	defer c.endProcessCommand()
 	err := handler.ComQuery(c, query, func(qr *sqltypes.Result) error {
 		flag := c.StatusFlags
 		if more {
@@ -1412,8 +1576,18 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 		}
 
 		if !callbackCalled {
+			if len(qr.MoreResults) > 0 && c.Capabilities&CapabilityClientMultiResults == 0 {
+				return sqlerror.NewSQLError(sqlerror.ERSPBadSelect, sqlerror.SSClientError, "the query returned several result sets, which the client doesn't support")
+			}
 			callbackCalled = true
 
+			// When the query returned several result sets, all of them but
+			// the last one are written here, and the last one as usual.
+			var err error
+			if qr, err = c.writeLeadingResultSets(qr, handler); err != nil {
+				return err
+			}
+
 			if len(qr.Fields) == 0 {
 				sendFinished = true
 
@@ -1472,6 +1646,81 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 	return execSuccess
 }
 
+// writeLeadingResultSets writes the result sets of qr but the last one, with
+// the SERVER_MORE_RESULTS_EXISTS flag, and returns the last one.
+func (c *Conn) writeLeadingResultSets(qr *sqltypes.Result, handler Handler) (*sqltypes.Result, error) {
+	if len(qr.MoreResults) == 0 {
+		return qr, nil
+	}
+	results := append([]*sqltypes.Result{qr}, qr.MoreResults...)
+	for _, result := range results[:len(results)-1] {
+		if len(result.Fields) == 0 {
+			ok := PacketOK{
+				affectedRows:     result.RowsAffected,
+				lastInsertID:     result.InsertID,
+				statusFlags:      c.StatusFlags | ServerMoreResultsExists,
+				warnings:         handler.WarningCount(c),
+				sessionStateData: result.SessionStateChanges,
+			}
+			if err := c.writeOKPacket(&ok); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := c.writeFields(result); err != nil {
+			return nil, err
+		}
+		if err := c.writeRows(result); err != nil {
+			return nil, err
+		}
+		if err := c.writeEndResult(true, 0, 0, handler.WarningCount(c)); err != nil {
+			return nil, err
+		}
+	}
+	return results[len(results)-1], nil
+}
+
+// ReadLocalInfile requests the content of the given file from the client, for
+// a LOAD DATA LOCAL INFILE statement, and calls the callback with each chunk
+// of it the client sends. The chunks are only valid until the callback
+// returns. If the callback fails, the rest of the file is still read from the
+// client, for the connection to stay usable, and the error is returned.
+// It must be called by the handler while executing a query.
+// Server -> Client -> Server.
+func (c *Conn) ReadLocalInfile(fileName string, callback func([]byte) error) error {
+	if c.Capabilities&CapabilityClientLocalFiles == 0 {
+		return sqlerror.NewSQLError(sqlerror.ERNotAllowedCommand, sqlerror.SSClientError, "Loading local data is disabled; this must be enabled on both the client and server sides")
+	}
+
+	data, pos := c.startEphemeralPacketWithHeader(1 + len(fileName))
+	pos = writeByte(data, pos, LocalInfilePacket)
+	copy(data[pos:], fileName)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+	if err := c.flush(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+
+	// The client sends the file in as many packets as it wants,
+	// followed by an empty packet.
+	var callbackErr error
+	for {
+		chunk, err := c.readEphemeralPacket()
+		if err != nil {
+			return sqlerror.NewSQLError(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+		}
+		if len(chunk) == 0 {
+			c.recycleReadPacket()
+			return callbackErr
+		}
+		if callbackErr == nil {
+			callbackErr = callback(chunk)
+		}
+		c.recycleReadPacket()
+	}
+}
+
 //
 // Packet parsing methods, for generic packets.
 //
@@ -1673,6 +1922,15 @@ func (c *Conn) IsUnixSocket() bool {
 	return ok
 }
 
+// cachingSha2RSAKey returns the RSA private key which the clients encrypt their
+// password with for caching_sha2_password, if the listener has one.
+func (c *Conn) cachingSha2RSAKey() *rsa.PrivateKey {
+	if c.listener == nil {
+		return nil
+	}
+	return c.listener.CachingSha2RSAKey.Load()
+}
+
 // GetRawConn returns the raw net.Conn for nefarious purposes.
 func (c *Conn) GetRawConn() net.Conn {
 	return c.conn
@@ -225,6 +225,49 @@ func TestPackets(t *testing.T) {
 	verifyPacketComms(t, cConn, sConn, data)
 }
 
+func TestSessionTrack(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Without CLIENT_SESSION_TRACK, the changes are not recorded.
+	sConn.TrackSchema("test")
+	require.NoError(t, sConn.writeOKPacket(&PacketOK{statusFlags: ServerStatusAutocommit}))
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{OKPacket, 0, 0, 0x02, 0, 0, 0}, data)
+
+	sConn.Capabilities |= CapabilityClientSessionTrack
+	cConn.Capabilities |= CapabilityClientSessionTrack
+	sConn.TrackSchema("test")
+	sConn.TrackSystemVariable("autocommit", "OFF")
+	sConn.TrackTransactionState(TransactionStateExplicit)
+	sConn.TrackStateChange()
+	require.NoError(t, sConn.writeOKPacket(&PacketOK{statusFlags: ServerStatusInTrans}))
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	expected := []byte{OKPacket, 0, 0, 0x01, 0x40, 0, 0, 0, 0x26}
+	expected = append(expected, 0x01, 0x05, 0x04, 't', 'e', 's', 't')
+	expected = append(expected, 0x00, 0x0f, 0x0a, 'a', 'u', 't', 'o', 'c', 'o', 'm', 'm', 'i', 't', 0x03, 'O', 'F', 'F')
+	expected = append(expected, 0x05, 0x09, 0x08, 'T', '_', '_', '_', '_', '_', '_', '_')
+	expected = append(expected, 0x02, 0x01, '1')
+	assert.Equal(t, expected, data)
+
+	// The client still reads the packet.
+	packetOk, err := cConn.parseOKPacket(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, ServerStatusInTrans|ServerSessionStateChanged, packetOk.statusFlags)
+
+	// The changes are only sent once.
+	require.NoError(t, sConn.writeOKPacket(&PacketOK{}))
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{OKPacket, 0, 0, 0, 0, 0, 0, 0}, data)
+}
+
 func TestBasicPackets(t *testing.T) {
 	require := require.New(t)
 	assert := assert.New(t)
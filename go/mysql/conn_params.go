@@ -57,6 +57,15 @@ type ConnParams struct {
 	// for informative purposes. It has no programmatic value. Returning this field is
 	// disabled by default.
 	EnableQueryInfo bool
+
+	// Compression is the compression algorithm of the protocol to use,
+	// CompressionZlib or CompressionZstd, if the server supports it.
+	// The connection is not compressed if it is empty, or if the server
+	// doesn't support the algorithm.
+	Compression string `json:"compression,omitempty"`
+	// CompressionLevel is the level to compress with, the default level
+	// of the algorithm if zero.
+	CompressionLevel int `json:"compression_level,omitempty"`
 }
 
 // EnableSSL will set the right flag on the parameters.
@@ -51,6 +51,11 @@ const (
 	// MysqlDialog uses the dialog plugin on the client side.
 	// It transmits data in the clear.
 	MysqlDialog = AuthMethodDescription("dialog")
+
+	// ClientEd25519 is the client side of the ed25519 plugin of MariaDB.
+	// It transmits an Ed25519 signature of a nonce with a key derived
+	// from the password.
+	ClientEd25519 = AuthMethodDescription("client_ed25519")
 )
 
 // Capability flags.
@@ -76,15 +81,17 @@ const (
 	// CLIENT_NO_SCHEMA 1 << 4
 	// Do not permit database.table.column. We do permit it.
 
-	// CLIENT_COMPRESS 1 << 5
-	// We do not support compression. CPU is usually our bottleneck.
+	// CapabilityClientCompress is CLIENT_COMPRESS.
+	// Use the zlib compressed protocol after the handshake.
+	CapabilityClientCompress = 1 << 5
 
 	// CLIENT_ODBC 1 << 6
 	// No special behavior since 3.22.
 
-	// CLIENT_LOCAL_FILES 1 << 7
+	// CapabilityClientLocalFiles is CLIENT_LOCAL_FILES.
 	// Client can use LOCAL INFILE request of LOAD DATA|XML.
-	// We do not set it.
+	// We set it only if the listener allows LOAD DATA LOCAL INFILE.
+	CapabilityClientLocalFiles = 1 << 7
 
 	// CLIENT_IGNORE_SPACE 1 << 8
 	// Parser can ignore spaces before '('.
@@ -148,8 +155,27 @@ const (
 	// CapabilityClientDeprecateEOF is CLIENT_DEPRECATE_EOF
 	// Expects an OK (instead of EOF) after the resultset rows of a Text Resultset.
 	CapabilityClientDeprecateEOF = 1 << 24
+
+	// CLIENT_OPTIONAL_RESULTSET_METADATA 1 << 25
+	// Not supported.
+
+	// CapabilityClientZstdCompressionAlgorithm is CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	// Use the zstd compressed protocol after the handshake. The client
+	// sends the compression level it wants in Protocol::HandshakeResponse41.
+	CapabilityClientZstdCompressionAlgorithm = 1 << 26
+
+	// CapabilityClientQueryAttributes is CLIENT_QUERY_ATTRIBUTES.
+	// Can send query attributes, named values attached to a query,
+	// in COM_QUERY and COM_STMT_EXECUTE.
+	CapabilityClientQueryAttributes = 1 << 27
 )
 
+// parameterCountAvailable is the PARAMETER_COUNT_AVAILABLE flag of the
+// cursor type of COM_STMT_EXECUTE. It is set when the parameter count is
+// sent even if the statement has no parameters, so that query attributes
+// can be sent with it.
+const parameterCountAvailable = 0x08
+
 // Status flags. They are returned by the server in a few cases.
 // Originally found in include/mysql/mysql_com.h
 // See http://dev.mysql.com/doc/internals/en/status-flags.html
@@ -189,6 +215,10 @@ const (
 	SessionTrackStateChange uint8 = 0x02
 	// "track GTIDs" changed.
 	SessionTrackGtids uint8 = 0x03
+	// transaction characteristics changed.
+	SessionTrackTransactionCharacteristics uint8 = 0x04
+	// transaction state changed.
+	SessionTrackTransactionState uint8 = 0x05
 )
 
 // Packet types.
@@ -209,6 +239,9 @@ const (
 	// ComPing is COM_PING.
 	ComPing = 0x0e
 
+	// ComChangeUser is COM_CHANGE_USER.
+	ComChangeUser = 0x11
+
 	// ComBinlogDump is COM_BINLOG_DUMP.
 	ComBinlogDump = 0x12
 
@@ -257,6 +290,10 @@ const (
 
 	// NullValue is the encoded value of NULL.
 	NullValue = 0xfb
+
+	// LocalInfilePacket is the header of the packet requesting the
+	// content of a file from the client, for LOAD DATA LOCAL INFILE.
+	LocalInfilePacket = 0xfb
 )
 
 // Auth packet types
@@ -264,6 +301,10 @@ const (
 	// AuthMoreDataPacket is sent when server requires more data to authenticate
 	AuthMoreDataPacket = 0x01
 
+	// CachingSha2RequestPublicKey is sent by the client to request the public key of the server,
+	// to encrypt the password with, when the server requests it over an insecure connection
+	CachingSha2RequestPublicKey = 0x02
+
 	// CachingSha2FastAuth is sent before OKPacket when server authenticates using cache
 	CachingSha2FastAuth = 0x03
 
@@ -177,7 +177,8 @@ func (flv *filePosFlavor) readBinlogEvent(c *Conn) (BinlogEvent, error) {
 		case eXIDEvent, eTableMapEvent,
 			eWriteRowsEventV0, eWriteRowsEventV1, eWriteRowsEventV2,
 			eDeleteRowsEventV0, eDeleteRowsEventV1, eDeleteRowsEventV2,
-			eUpdateRowsEventV0, eUpdateRowsEventV1, eUpdateRowsEventV2:
+			eUpdateRowsEventV0, eUpdateRowsEventV1, eUpdateRowsEventV2,
+			ePartialUpdateRowsEvent:
 			flv.savedEvent = event
 			return newFilePosGTIDEvent(flv.file, event.nextPosition(flv.format), event.Timestamp()), nil
 		case eQueryEvent:
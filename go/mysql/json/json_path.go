@@ -262,6 +262,10 @@ const (
 	Insert
 	Replace
 	Remove
+	// ArrayInsert inserts array items at the given index, shifting the
+	// following items, like JSON_ARRAY_INSERT. Object members are inserted
+	// like with Insert.
+	ArrayInsert
 )
 
 func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) error {
@@ -277,9 +281,12 @@ func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value
 					if from != to {
 						return
 					}
-					if t == Remove {
+					switch t {
+					case Remove:
 						vv.DelArrayItem(from)
-					} else {
+					case ArrayInsert:
+						vv.InsertArrayItem(from, values[i])
+					default:
 						vv.SetArrayItem(from, values[i], t)
 					}
 				}
@@ -171,6 +171,13 @@ func TestTransformations(t *testing.T) {
 			Paths:    []string{`$[2]`, `$[1].b[1]`, `$[1].b[1]`},
 			Expected: `["a", {"b": [true]}]`,
 		},
+		{
+			T:        ArrayInsert,
+			Document: Document1,
+			Paths:    []string{Path1, Path2, `$[1].c`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `["a", {"b": [1, true, false], "c": 3}, [10, 20, 2]]`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -57,7 +57,7 @@ func (o *Object) Set(key string, value *Value, t Transformation) {
 		if found {
 			o.kvs[i].v = value
 		}
-	case Insert:
+	case Insert, ArrayInsert:
 		if !found {
 			o.kvs = slices.Insert(o.kvs, i, kv{key, value})
 		}
@@ -87,6 +87,20 @@ func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 	}
 }
 
+// InsertArrayItem inserts the value in the array v at idx position, shifting
+// the following items. The value is appended if idx is past the end of v.
+//
+// The value must be unchanged during v lifetime.
+func (v *Value) InsertArrayItem(idx int, value *Value) {
+	if v == nil || v.t != TypeArray || idx < 0 {
+		return
+	}
+	if value == nil {
+		value = ValueNull
+	}
+	v.a = slices.Insert(v.a, min(idx, len(v.a)), value)
+}
+
 func (v *Value) DelArrayItem(n int) {
 	if v == nil || v.t != TypeArray {
 		return
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import "time"
+
+// Commands of ProcessInfo, named as in the Command column of the
+// SHOW PROCESSLIST of MySQL.
+const (
+	// ProcessCommandConnect is the command of a connection during the handshake.
+	ProcessCommandConnect = "Connect"
+	// ProcessCommandSleep is the command of an idle connection.
+	ProcessCommandSleep = "Sleep"
+	// ProcessCommandQuery is the command of a connection running a query.
+	ProcessCommandQuery = "Query"
+	// ProcessCommandExecute is the command of a connection running a prepared statement.
+	ProcessCommandExecute = "Execute"
+)
+
+// ProcessInfo describes what a server-side connection is doing, for
+// SHOW PROCESSLIST and the like. As it is read by other goroutines than
+// the one serving the connection, it is a copy of the connection state.
+type ProcessInfo struct {
+	// User is the user of the connection, once authenticated.
+	User string
+	// DB is the default database of the connection, as last set
+	// by the handshake or SetProcessDB.
+	DB string
+	// Command is what the connection is doing, e.g. ProcessCommandQuery.
+	Command string
+	// Since is when the connection started doing the command.
+	Since time.Time
+	// Info is the statement being run, if any.
+	Info string
+}
+
+// ProcessInfo returns what the connection is currently doing. It is
+// safe to call from any goroutine.
+func (c *Conn) ProcessInfo() ProcessInfo {
+	c.processMu.Lock()
+	defer c.processMu.Unlock()
+	return c.process
+}
+
+// SetProcessDB sets the default database reported by ProcessInfo. The
+// handlers keeping the default database themselves call it once it changes.
+func (c *Conn) SetProcessDB(db string) {
+	c.processMu.Lock()
+	defer c.processMu.Unlock()
+	c.process.DB = db
+}
+
+// setProcessUser sets the user reported by ProcessInfo, once authenticated,
+// and marks the connection as idle.
+func (c *Conn) setProcessUser(user string) {
+	c.processMu.Lock()
+	defer c.processMu.Unlock()
+	c.process.User = user
+	if c.process.Command == ProcessCommandConnect {
+		c.process.DB = c.schemaName
+		c.process.Command = ProcessCommandSleep
+		c.process.Since = time.Now()
+	}
+}
+
+// startProcessCommand records that the connection started running the
+// statement, until endProcessCommand is called.
+func (c *Conn) startProcessCommand(command, info string) {
+	c.processMu.Lock()
+	defer c.processMu.Unlock()
+	c.process.Command = command
+	c.process.Since = time.Now()
+	c.process.Info = info
+}
+
+// endProcessCommand records that the connection is idle again.
+func (c *Conn) endProcessCommand() {
+	c.startProcessCommand(ProcessCommandSleep, "")
+}
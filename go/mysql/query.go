@@ -19,6 +19,7 @@ package mysql
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -42,7 +43,11 @@ import (
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) WriteComQuery(query string) error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
+
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+		return c.writeComQueryWithAttributes(query)
+	}
 
 	data, pos := c.startEphemeralPacketWithHeader(len(query) + 1)
 	data[pos] = ComQuery
@@ -54,6 +59,55 @@ func (c *Conn) WriteComQuery(query string) error {
 	return nil
 }
 
+// writeComQueryWithAttributes writes a query prefixed by the query
+// attributes of the connection, as CapabilityClientQueryAttributes
+// requires. The attributes are all sent as strings.
+func (c *Conn) writeComQueryWithAttributes(query string) error {
+	names := make([]string, 0, len(c.QueryAttributes))
+	for name := range c.QueryAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	count := uint64(len(names))
+	length := 1 + // ComQuery
+		lenEncIntSize(count) + // Parameter count.
+		1 + // Parameter set count, always 1.
+		len(query)
+	if count > 0 {
+		length += (len(names)+7)/8 + // NULL-bitmap.
+			1 // New params bound flag.
+		for _, name := range names {
+			length += 2 + // Type and flags.
+				lenEncStringSize(name) +
+				lenEncStringSize(c.QueryAttributes[name])
+		}
+	}
+
+	mysqlType, flags := sqltypes.TypeToMySQL(sqltypes.VarChar)
+	data, pos := c.startEphemeralPacketWithHeader(length)
+	pos = writeByte(data, pos, ComQuery)
+	pos = writeLenEncInt(data, pos, count)
+	pos = writeLenEncInt(data, pos, 1)
+	if count > 0 {
+		pos = writeZeroes(data, pos, (len(names)+7)/8)
+		pos = writeByte(data, pos, 0x01)
+		for _, name := range names {
+			pos = writeByte(data, pos, byte(mysqlType))
+			pos = writeByte(data, pos, byte(flags))
+			pos = writeLenEncString(data, pos, name)
+		}
+		for _, name := range names {
+			pos = writeLenEncString(data, pos, c.QueryAttributes[name])
+		}
+	}
+	copy(data[pos:], query)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+	return nil
+}
+
 // writeComInitDB changes the default database to use.
 // Client -> Server.
 // Returns SQLError(CRServerGone) if it can't.
@@ -531,8 +585,85 @@ func (c *Conn) readComQueryResponse() (int, *PacketOK, error) {
 // Server side methods.
 //
 
-func (c *Conn) parseComQuery(data []byte) string {
-	return string(data[1:])
+func (c *Conn) parseComQuery(data []byte) (string, error) {
+	c.QueryAttributes = nil
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return string(data[1:]), nil
+	}
+
+	// With CapabilityClientQueryAttributes, the query is prefixed
+	// by the query attributes, sent as named parameters.
+	paramsCount, pos, ok := readLenEncInt(data, 1)
+	if !ok {
+		return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attributes count failed")
+	}
+	// The parameter set count, which is always 1.
+	if _, pos, ok = readLenEncInt(data, pos); !ok {
+		return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attributes set count failed")
+	}
+	if paramsCount == 0 {
+		return string(data[pos:]), nil
+	}
+
+	bitMap, pos, ok := readBytes(data, pos, (int(paramsCount)+7)/8)
+	if !ok {
+		return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+	}
+	newParamsBoundFlag, pos, ok := readByte(data, pos)
+	if !ok || newParamsBoundFlag != 0x01 {
+		return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attributes types failed")
+	}
+	types := make([]querypb.Type, paramsCount)
+	names := make([]string, paramsCount)
+	for i := range types {
+		var err error
+		if types[i], pos, err = parseParamType(data, pos); err != nil {
+			return "", err
+		}
+		if names[i], pos, ok = readLenEncString(data, pos); !ok {
+			return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute name failed")
+		}
+	}
+	for i := range types {
+		if (bitMap[i/8] & (1 << uint(i%8))) > 0 {
+			continue
+		}
+		var val sqltypes.Value
+		if val, pos, ok = c.parseStmtArgs(data, types[i], pos); !ok {
+			return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", types[i])
+		}
+		c.addQueryAttribute(names[i], val)
+	}
+	return string(data[pos:]), nil
+}
+
+// addQueryAttribute records a query attribute sent by the client.
+func (c *Conn) addQueryAttribute(name string, val sqltypes.Value) {
+	if c.QueryAttributes == nil {
+		c.QueryAttributes = make(map[string]string)
+	}
+	c.QueryAttributes[name] = val.ToString()
+}
+
+// parseParamType reads the type of a parameter, sent with COM_QUERY
+// query attributes or COM_STMT_EXECUTE.
+func parseParamType(data []byte, pos int) (querypb.Type, int, error) {
+	mysqlType, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
+	}
+
+	flags, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
+	}
+
+	// convert MySQL type to internal type.
+	valType, err := sqltypes.MySQLToType(int64(mysqlType), int64(flags))
+	if err != nil {
+		return 0, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+	}
+	return valType, pos, nil
 }
 
 func (c *Conn) parseComSetOption(data []byte) (uint16, bool) {
@@ -574,35 +705,56 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
 	}
 
-	if prepare.ParamsCount > 0 {
-		bitMap, pos, ok = readBytes(payload, pos, (int(prepare.ParamsCount)+7)/8)
+	// With CapabilityClientQueryAttributes, the parameters count is sent, and the
+	// parameters past the ones of the statement are query attributes.
+	paramsCount := int(prepare.ParamsCount)
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 && (paramsCount > 0 || cursorType&parameterCountAvailable != 0) {
+		var count uint64
+		count, pos, ok = readLenEncInt(payload, pos)
+		if !ok {
+			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+		}
+		if count < uint64(prepare.ParamsCount) {
+			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "parameter count %v is lower than the statement's %v", count, prepare.ParamsCount)
+		}
+		paramsCount = int(count)
+	}
+
+	if paramsCount > 0 {
+		bitMap, pos, ok = readBytes(payload, pos, (paramsCount+7)/8)
 		if !ok {
 			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
 		}
 	}
 
+	var attributeTypes []querypb.Type
+	var attributeNames []string
 	newParamsBoundFlag, pos, ok := readByte(payload, pos)
 	if ok && newParamsBoundFlag == 0x01 {
-		var mysqlType, flags byte
-		for i := uint16(0); i < prepare.ParamsCount; i++ {
-			mysqlType, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
+		for i := 0; i < paramsCount; i++ {
+			valType, newPos, err := parseParamType(payload, pos)
+			if err != nil {
+				return stmtID, 0, err
 			}
+			pos = newPos
 
-			flags, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
+			var name string
+			if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+				name, pos, ok = readLenEncString(payload, pos)
+				if !ok {
+					return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter name failed")
+				}
 			}
 
-			// convert MySQL type to internal type.
-			valType, err := sqltypes.MySQLToType(int64(mysqlType), int64(flags))
-			if err != nil {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+			if i < int(prepare.ParamsCount) {
+				prepare.ParamsType[i] = int32(valType)
+			} else {
+				attributeTypes = append(attributeTypes, valType)
+				attributeNames = append(attributeNames, name)
 			}
-
-			prepare.ParamsType[i] = int32(valType)
 		}
+	} else if paramsCount > int(prepare.ParamsCount) {
+		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes sent without their types")
 	}
 
 	for i := 0; i < len(prepare.ParamsType); i++ {
@@ -626,6 +778,19 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		prepare.BindVars[parameterID] = sqltypes.ValueBindVariable(val)
 	}
 
+	c.QueryAttributes = nil
+	for i, valType := range attributeTypes {
+		paramIndex := int(prepare.ParamsCount) + i
+		if (bitMap[paramIndex/8] & (1 << uint(paramIndex%8))) > 0 {
+			continue
+		}
+		var val sqltypes.Value
+		if val, pos, ok = c.parseStmtArgs(payload, valType, pos); !ok {
+			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", valType)
+		}
+		c.addQueryAttribute(attributeNames[i], val)
+	}
+
 	return stmtID, cursorType, nil
 }
 
@@ -889,6 +1054,53 @@ func (c *Conn) parseComInitDB(data []byte) string {
 	return string(data[1:])
 }
 
+// parseComChangeUser parses a COM_CHANGE_USER packet. The auth response is
+// encoded as in the handshake response of the client, according to the
+// capabilities it announced then.
+func (c *Conn) parseComChangeUser(data []byte) (user string, authMethod AuthMethodDescription, authResponse []byte, schemaName string, err error) {
+	pos := 1
+	user, pos, ok := readNullString(data, pos)
+	if !ok {
+		return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read user")
+	}
+
+	if c.Capabilities&CapabilityClientSecureConnection != 0 {
+		var l byte
+		l, pos, ok = readByte(data, pos)
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response length")
+		}
+		authResponse, pos, ok = readBytesCopy(data, pos, int(l))
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response")
+		}
+	} else {
+		var a string
+		a, pos, ok = readNullString(data, pos)
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response")
+		}
+		authResponse = []byte(a)
+	}
+
+	schemaName, pos, ok = readNullString(data, pos)
+	if !ok {
+		return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read schema name")
+	}
+
+	// The character set and the auth method are optional.
+	authMethod = MysqlNativePassword
+	if _, pos, ok = readUint16(data, pos); !ok {
+		return user, authMethod, authResponse, schemaName, nil
+	}
+	if c.Capabilities&CapabilityClientPluginAuth != 0 {
+		if method, _, ok := readNullString(data, pos); ok && method != "" {
+			authMethod = AuthMethodDescription(method)
+		}
+	}
+	return user, authMethod, authResponse, schemaName, nil
+}
+
 func (c *Conn) sendColumnCount(count uint64) error {
 	length := lenEncIntSize(count)
 	data, pos := c.startEphemeralPacketWithHeader(length)
@@ -19,6 +19,7 @@ package mysql
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -331,6 +332,110 @@ func TestComStmtExecuteUpdStmt(t *testing.T) {
 	assert.EqualValues(t, querypb.Type_CHAR, prepData.ParamsType[28], "got: %s", querypb.Type(prepData.ParamsType[28]))
 }
 
+func TestComQueryAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		attributes map[string]string
+		// packets is the number of packets the query is split into.
+		packets int
+	}{{
+		name:    "no attributes",
+		query:   "select 1",
+		packets: 1,
+	}, {
+		name:       "attributes",
+		query:      "select 1",
+		attributes: map[string]string{"app": "billing", "trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"},
+		packets:    1,
+	}, {
+		name:       "attributes with a query over the packet size",
+		query:      "select '" + strings.Repeat("x", MaxPacketSize) + "'",
+		attributes: map[string]string{"app": "billing"},
+		packets:    2,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each query is the first command of a new connection, so
+			// that the server reads it as it reads any new command.
+			listener, sConn, cConn := createSocketPair(t)
+			defer func() {
+				listener.Close()
+				sConn.Close()
+				cConn.Close()
+			}()
+			cConn.Capabilities |= CapabilityClientQueryAttributes
+			sConn.Capabilities |= CapabilityClientQueryAttributes
+			cConn.QueryAttributes = tt.attributes
+
+			// The client writes in the background, as large queries
+			// don't fit in the socket buffers.
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- cConn.WriteComQuery(tt.query)
+			}()
+
+			data, err := sConn.ReadPacket()
+			require.NoError(t, err)
+			require.NoError(t, <-errCh)
+			require.EqualValues(t, ComQuery, data[0])
+
+			// The command starts a new sequence, and the response is
+			// expected right after its last packet.
+			assert.EqualValues(t, tt.packets, sConn.sequence)
+			assert.EqualValues(t, tt.packets, cConn.sequence)
+
+			query, err := sConn.parseComQuery(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.query, query)
+			assert.Equal(t, tt.attributes, sConn.QueryAttributes)
+		})
+	}
+}
+
+func TestComStmtExecuteQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	prepareDataMap := map[uint32]*PrepareData{
+		1: {
+			StatementID: 1,
+			ParamsCount: 1,
+			ParamsType:  make([]int32, 1),
+			BindVars:    map[string]*querypb.BindVariable{},
+		}}
+
+	// This is a simulated packet for a statement with one parameter, sent
+	// with the 'app' query attribute.
+	data := []byte{
+		ComStmtExecute, 0x01, 0x00, 0x00, 0x00, // statement ID
+		0x00,                   // cursor type flags
+		0x01, 0x00, 0x00, 0x00, // iteration count
+		0x02,       // parameter count
+		0x00,       // NULL-bitmap
+		0x01,       // new params bound flag
+		0x08, 0x00, // parameter type
+		0x00,       // parameter name
+		0xfd, 0x00, // query attribute type
+		0x03, 'a', 'p', 'p', // query attribute name
+		0x2a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // parameter value
+		0x05, 'b', 'a', 't', 'c', 'h', // query attribute value
+	}
+
+	stmtID, _, err := sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stmtID)
+	assert.EqualValues(t, querypb.Type_INT64, prepareDataMap[1].ParamsType[0])
+	assert.Equal(t, sqltypes.Int64BindVariable(42), prepareDataMap[1].BindVars["v1"])
+	assert.Equal(t, map[string]string{"app": "batch"}, sConn.QueryAttributes)
+}
+
 func TestComStmtClose(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	defer func() {
@@ -75,8 +75,9 @@ func ParseMariadbGTIDSet(s string) (GTIDSet, error) {
 		if err != nil {
 			return nil, err
 		}
-		mdbGTID := gtid.(MariadbGTID)
-		gtidSet[mdbGTID.Domain] = mdbGTID
+		// A binlog state, like gtid_binlog_state, can have several GTIDs
+		// per domain, one per server: the set keeps the last one.
+		gtidSet.addGTID(gtid.(MariadbGTID))
 	}
 	return gtidSet, nil
 }
@@ -239,6 +240,9 @@ func (gtidSet MariadbGTIDSet) Union(other GTIDSet) GTIDSet {
 
 // Last returns the last gtid
 func (gtidSet MariadbGTIDSet) Last() string {
+	if len(gtidSet) == 0 {
+		return ""
+	}
 	// Sort domains so the string format is deterministic.
 	domains := make([]uint32, 0, len(gtidSet))
 	for domain := range gtidSet {
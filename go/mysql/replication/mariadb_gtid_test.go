@@ -87,6 +87,19 @@ func TestParseMariaGTIDSet(t *testing.T) {
 
 }
 
+func TestParseMariaGTIDSetBinlogState(t *testing.T) {
+	// gtid_binlog_state has the last GTID of each server of each domain.
+	input := "0-1-10,0-2-12,0-3-11,1-1-5"
+	want := MariadbGTIDSet{
+		0: MariadbGTID{Domain: 0, Server: 2, Sequence: 12},
+		1: MariadbGTID{Domain: 1, Server: 1, Sequence: 5},
+	}
+
+	got, err := ParseMariadbGTIDSet(input)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
 func TestParseInvalidMariaGTIDSet(t *testing.T) {
 	input := "12-34-5678,11-22-33e33"
 	want := "invalid MariaDB GTID Sequence number"
@@ -619,6 +632,7 @@ func TestMariaGTIDSetLast(t *testing.T) {
 	testCases := map[string]string{
 		"12-34-5678,11-22-3333,24-52-4523": "24-52-4523",
 		"12-34-5678":                       "12-34-5678",
+		"":                                 "",
 	}
 	for input, want := range testCases {
 		got, err := ParseMariadbGTIDSet(input)
@@ -110,6 +110,9 @@ const (
 	//eViewChangeEvent         = 37
 	//eXAPrepareLogEvent       = 38
 
+	// Partial_update_rows_event when binlog_row_value_options=PARTIAL_JSON.
+	ePartialUpdateRowsEvent = 39
+
 	// Transaction_payload_event when binlog_transaction_compression=ON.
 	eTransactionPayloadEvent = 40
 
@@ -121,6 +124,16 @@ const (
 	eMariaGTIDListEvent = 163
 	// Unused
 	//eMariaStartEncryptionEvent  = 164
+
+	// MariaDB compressed events, written instead of the query and rows
+	// events when log_bin_compress=ON.
+	eMariaQueryCompressedEvent        = 165
+	eMariaWriteRowsCompressedEventV1  = 166
+	eMariaUpdateRowsCompressedEventV1 = 167
+	eMariaDeleteRowsCompressedEventV1 = 168
+	eMariaWriteRowsCompressedEventV2  = 169
+	eMariaUpdateRowsCompressedEventV2 = 170
+	eMariaDeleteRowsCompressedEventV2 = 171
 )
 
 // These constants describe the type of status variables in q Query packet.
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/mysql/replication"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 )
 
@@ -119,6 +120,24 @@ func TestComBinlogDumpGTID(t *testing.T) {
 		}
 		assert.Equal(t, expectedData, data)
 	})
+	sConn.sequence = 0
+
+	t.Run("parseComBinlogDumpGTID with SID block", func(t *testing.T) {
+		// MySQL replicas send their executed GTID set as a SID block.
+		gtidSet, err := replication.ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0e0f:1-5")
+		require.NoError(t, err)
+		err = cConn.WriteComBinlogDumpGTID(0x01020304, "", 4, 0, gtidSet.SIDBlock())
+		assert.NoError(t, err)
+		data, err := sConn.ReadPacket()
+		require.NoError(t, err)
+
+		logFile, logPos, position, err := sConn.parseComBinlogDumpGTID(data)
+		require.NoError(t, err)
+		assert.Equal(t, "", logFile)
+		assert.EqualValues(t, 4, logPos)
+		assert.Equal(t, gtidSet, position.GTIDSet)
+	})
+
 	f := NewMySQL56BinlogFormat()
 	s := NewFakeBinlogStream()
 
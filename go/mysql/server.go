@@ -18,6 +18,7 @@ package mysql
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"io"
 	"net"
@@ -133,6 +134,12 @@ type Handler interface {
 	WarningCount(c *Conn) uint16
 
 	ComResetConnection(c *Conn)
+
+	// ComChangeUser is called when a connection receives a COM_CHANGE_USER
+	// request, once the new user is authenticated and set on the connection.
+	// The state of the connection must be reset, as for a new connection:
+	// the database of the request, if any, is then selected with ComQuery.
+	ComChangeUser(c *Conn)
 }
 
 // UnimplementedHandler implemnts all of the optional callbacks so as to satisy
@@ -145,6 +152,7 @@ func (UnimplementedHandler) NewConnection(*Conn)      {}
 func (UnimplementedHandler) ConnectionReady(*Conn)    {}
 func (UnimplementedHandler) ConnectionClosed(*Conn)   {}
 func (UnimplementedHandler) ComResetConnection(*Conn) {}
+func (UnimplementedHandler) ComChangeUser(*Conn)      {}
 
 // Listener is the MySQL server protocol listener.
 type Listener struct {
@@ -177,6 +185,32 @@ type Listener struct {
 	// by the server when TLS is not in use.
 	AllowClearTextWithoutTLS atomic.Bool
 
+	// CachingSha2RSAKey is the RSA private key of the server which the
+	// clients encrypt their password with, for the full authentication
+	// of caching_sha2_password over connections without TLS. If not set,
+	// caching_sha2_password is only offered over TLS or Unix sockets.
+	CachingSha2RSAKey atomic.Pointer[rsa.PrivateKey]
+
+	// CompressionAlgorithms are the compression algorithms of the protocol
+	// the server offers to the clients, among CompressionZlib and
+	// CompressionZstd. The connections are not compressed if empty.
+	CompressionAlgorithms []string
+
+	// ZlibCompressionLevel is the level the server compresses with on the
+	// connections using zlib, DefaultZlibCompressionLevel if zero. The
+	// connections using zstd use the level requested by the client.
+	ZlibCompressionLevel int
+
+	// AllowLocalInfile makes the server advertise CLIENT_LOCAL_FILES, so that
+	// the clients send the content of their files for LOAD DATA LOCAL INFILE
+	// when the handler requests it with Conn.ReadLocalInfile.
+	AllowLocalInfile bool
+
+	// SessionTrack makes the server advertise CLIENT_SESSION_TRACK, so that
+	// the session state changes recorded by the handler with the Conn.Track
+	// methods are sent to the clients which ask for them, in the OK packets.
+	SessionTrack bool
+
 	// SlowConnectWarnThreshold if non-zero specifies an amount of time
 	// beyond which a warning is logged to identify the slow connection
 	SlowConnectWarnThreshold atomic.Int64
@@ -375,7 +409,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	defer connCount.Add(-1)
 
 	// First build and send the server handshake packet.
-	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, l.TLSConfig.Load() != nil)
+	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, l.TLSConfig.Load() != nil, l.optionalCapabilities())
 	if err != nil {
 		if err != io.EOF {
 			log.Errorf("Cannot send HandshakeV10 packet to %s: %v", c, err)
@@ -434,6 +468,83 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		defer connCountByTLSVer.Add(versionNoTLS, -1)
 	}
 
+	userData, salt, ok := l.authenticate(c, user, clientAuthMethod, clientAuthResponse, serverAuthPluginData)
+	if !ok {
+		return
+	}
+
+	c.User = user
+	c.UserData = userData
+	c.salt = salt
+	c.setProcessUser(user)
+
+	// The user can change with COM_CHANGE_USER.
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+	}
+	defer func() {
+		if c.User != "" {
+			connCountPerUser.Add(c.User, -1)
+		}
+	}()
+
+	// Set initial db name.
+	if c.schemaName != "" {
+		err = l.handler.ComQuery(c, "use "+sqlescape.EscapeID(c.schemaName), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			c.writeErrorPacketFromError(err)
+			return
+		}
+	}
+
+	// Negotiation worked, send OK packet.
+	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
+		log.Errorf("Cannot write OK packet to %s: %v", c, err)
+		return
+	}
+
+	// The packets following the handshake are compressed if it was negotiated.
+	if c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) != 0 {
+		algorithm, level := CompressionZlib, l.ZlibCompressionLevel
+		if c.Capabilities&CapabilityClientZstdCompressionAlgorithm != 0 {
+			algorithm, level = CompressionZstd, c.compressionLevel
+		}
+		if err := c.enableCompression(algorithm, level); err != nil {
+			log.Errorf("Cannot enable compression for %s: %v", c, err)
+			return
+		}
+	}
+
+	// Record how long we took to establish the connection
+	timings.Record(connectTimingKey, acceptTime)
+
+	// Log a warning if it took too long to connect
+	connectTime := time.Since(acceptTime).Nanoseconds()
+	if threshold := l.SlowConnectWarnThreshold.Load(); threshold != 0 && connectTime > threshold {
+		connSlow.Add(1)
+		log.Warningf("Slow connection from %s: %v", c, connectTime)
+	}
+
+	// Tell our handler that we're finished handshake and are ready to
+	// process commands.
+	l.handler.ConnectionReady(c)
+
+	for {
+		kontinue := c.handleNextCommand(l.handler)
+		// before going for next command check if the connection should be closed or not.
+		if !kontinue || c.IsMarkedForClose() {
+			return
+		}
+	}
+}
+
+// authenticate authenticates the user with the auth method the AuthServer
+// wants to use for them, asking the client to switch to it if needed. It
+// returns the user data, and the auth plugin data the client used. On
+// failure, the error is sent to the client, and false is returned.
+func (l *Listener) authenticate(c *Conn, user string, clientAuthMethod AuthMethodDescription, clientAuthResponse, serverAuthPluginData []byte) (Getter, []byte, bool) {
 	// See what auth method the AuthServer wants to use for that user.
 	negotiatedAuthMethod, err := negotiateAuthMethod(c, l.authServer, user, clientAuthMethod)
 
@@ -459,86 +570,41 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 
 		if negotiatedAuthMethod == nil {
 			c.writeErrorPacket(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "No authentication methods available for authentication.")
-			return
+			return nil, nil, false
 		}
 
 		if !l.AllowClearTextWithoutTLS.Load() && !c.TLSEnabled() && !negotiatedAuthMethod.AllowClearTextWithoutTLS() {
 			c.writeErrorPacket(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
-			return
+			return nil, nil, false
 		}
 
 		serverAuthPluginData, err = negotiatedAuthMethod.AuthPluginData()
 		if err != nil {
 			log.Errorf("Error generating auth switch packet for %s: %v", c, err)
-			return
+			return nil, nil, false
 		}
 
 		if err := c.writeAuthSwitchRequest(string(negotiatedAuthMethod.Name()), serverAuthPluginData); err != nil {
 			log.Errorf("Error writing auth switch packet for %s: %v", c, err)
-			return
+			return nil, nil, false
 		}
 
 		clientAuthResponse, err = c.readEphemeralPacket()
 		if err != nil {
 			log.Errorf("Error reading auth switch response for %s: %v", c, err)
-			return
+			return nil, nil, false
 		}
 		c.recycleReadPacket()
 	}
 
-	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, conn.RemoteAddr())
+	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, c.RemoteAddr())
 	if err != nil {
 		log.Warningf("Error authenticating user %s using: %s", user, negotiatedAuthMethod.Name())
 		c.writeErrorPacketFromError(err)
-		return
-	}
-
-	c.User = user
-	c.UserData = userData
-
-	if c.User != "" {
-		connCountPerUser.Add(c.User, 1)
-		defer connCountPerUser.Add(c.User, -1)
+		return nil, nil, false
 	}
 
-	// Set initial db name.
-	if c.schemaName != "" {
-		err = l.handler.ComQuery(c, "use "+sqlescape.EscapeID(c.schemaName), func(result *sqltypes.Result) error {
-			return nil
-		})
-		if err != nil {
-			c.writeErrorPacketFromError(err)
-			return
-		}
-	}
-
-	// Negotiation worked, send OK packet.
-	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
-		log.Errorf("Cannot write OK packet to %s: %v", c, err)
-		return
-	}
-
-	// Record how long we took to establish the connection
-	timings.Record(connectTimingKey, acceptTime)
-
-	// Log a warning if it took too long to connect
-	connectTime := time.Since(acceptTime).Nanoseconds()
-	if threshold := l.SlowConnectWarnThreshold.Load(); threshold != 0 && connectTime > threshold {
-		connSlow.Add(1)
-		log.Warningf("Slow connection from %s: %v", c, connectTime)
-	}
-
-	// Tell our handler that we're finished handshake and are ready to
-	// process commands.
-	l.handler.ConnectionReady(c)
-
-	for {
-		kontinue := c.handleNextCommand(l.handler)
-		// before going for next command check if the connection should be closed or not.
-		if !kontinue || c.IsMarkedForClose() {
-			return
-		}
-	}
+	return userData, serverAuthPluginData, true
 }
 
 // Close stops the listener, which prevents accept of any new connections. Existing connections won't be closed.
@@ -554,9 +620,37 @@ func (l *Listener) Shutdown() {
 	}
 }
 
+// compressionCapabilities returns the capabilities advertised for the
+// compression algorithms the server offers.
+func (l *Listener) compressionCapabilities() uint32 {
+	var capabilities uint32
+	for _, algorithm := range l.CompressionAlgorithms {
+		switch algorithm {
+		case CompressionZlib:
+			capabilities |= CapabilityClientCompress
+		case CompressionZstd:
+			capabilities |= CapabilityClientZstdCompressionAlgorithm
+		}
+	}
+	return capabilities
+}
+
+// optionalCapabilities returns the capabilities advertised for the
+// features enabled on the listener.
+func (l *Listener) optionalCapabilities() uint32 {
+	capabilities := l.compressionCapabilities()
+	if l.AllowLocalInfile {
+		capabilities |= CapabilityClientLocalFiles
+	}
+	if l.SessionTrack {
+		capabilities |= CapabilityClientSessionTrack
+	}
+	return capabilities
+}
+
 // writeHandshakeV10 writes the Initial Handshake Packet, server side.
 // It returns the salt data.
-func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, enableTLS bool) ([]byte, error) {
+func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, enableTLS bool, optionalCapabilities uint32) ([]byte, error) {
 	capabilities := CapabilityClientLongPassword |
 		CapabilityClientFoundRows |
 		CapabilityClientLongFlag |
@@ -569,7 +663,9 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, en
 		CapabilityClientPluginAuth |
 		CapabilityClientPluginAuthLenencClientData |
 		CapabilityClientDeprecateEOF |
-		CapabilityClientConnAttr
+		CapabilityClientConnAttr |
+		CapabilityClientQueryAttributes |
+		optionalCapabilities
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
@@ -698,6 +794,31 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 		c.Capabilities |= CapabilityClientMultiStatements
 	}
 
+	// The client can read several result sets, as it must when it
+	// can send multi statements.
+	if clientFlags&(CapabilityClientMultiResults|CapabilityClientMultiStatements) != 0 {
+		c.Capabilities |= CapabilityClientMultiResults
+	}
+
+	// Remember how the client encodes the auth response and the auth
+	// method, which it also sends with COM_CHANGE_USER.
+	c.Capabilities |= clientFlags & (CapabilityClientSecureConnection | CapabilityClientPluginAuth | CapabilityClientConnAttr)
+
+	// The client sends query attributes with its queries.
+	if clientFlags&CapabilityClientQueryAttributes != 0 {
+		c.Capabilities |= CapabilityClientQueryAttributes
+	}
+
+	// The client sends the content of its files for LOAD DATA LOCAL INFILE.
+	if l.AllowLocalInfile && clientFlags&CapabilityClientLocalFiles != 0 {
+		c.Capabilities |= CapabilityClientLocalFiles
+	}
+
+	// The client reads the session state changes in the OK packets.
+	if l.SessionTrack && clientFlags&CapabilityClientSessionTrack != 0 {
+		c.Capabilities |= CapabilityClientSessionTrack
+	}
+
 	// Max packet size. Don't do anything with this now.
 	// See doc.go for more information.
 	_, pos, ok = readUint32(data, pos)
@@ -790,9 +911,29 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 
 	// Decode connection attributes send by the client
 	if clientFlags&CapabilityClientConnAttr != 0 {
-		if _, _, err := parseConnAttrs(data, pos); err != nil {
+		var err error
+		if c.ConnectionAttributes, pos, err = parseConnAttrs(data, pos); err != nil {
 			log.Warningf("Decode connection attributes send by the client: %v", err)
+			pos = len(data)
+		}
+	}
+
+	// Negotiate the compression among the algorithms we offer,
+	// preferring zstd if the client asks for both.
+	compressionFlags := clientFlags & l.compressionCapabilities()
+	if compressionFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		c.Capabilities |= CapabilityClientZstdCompressionAlgorithm
+		// The zstd compression level, if the client sent it.
+		level, _, ok := readByte(data, pos)
+		if !ok {
+			level = DefaultZstdCompressionLevel
+		}
+		if err := ValidateCompression(CompressionZstd, int(level)); err != nil {
+			return "", "", nil, vterrors.Wrapf(err, "parseClientHandshakePacket")
 		}
+		c.compressionLevel = int(level)
+	} else if compressionFlags&CapabilityClientCompress != 0 {
+		c.Capabilities |= CapabilityClientCompress
 	}
 
 	return username, AuthMethodDescription(authMethod), authResponse, nil
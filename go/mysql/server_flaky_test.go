@@ -17,8 +17,12 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"os"
@@ -76,11 +80,12 @@ var selectRowsResult = &sqltypes.Result{
 
 type testHandler struct {
 	UnimplementedHandler
-	mu       sync.Mutex
-	lastConn *Conn
-	result   *sqltypes.Result
-	err      error
-	warnings uint16
+	mu          sync.Mutex
+	lastConn    *Conn
+	result      *sqltypes.Result
+	err         error
+	warnings    uint16
+	localInfile []byte
 }
 
 func (th *testHandler) LastConn() *Conn {
@@ -140,6 +145,30 @@ func (th *testHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.R
 			RowsAffected: 123,
 			InsertID:     123456789,
 		})
+	case "multiple results":
+		callback(&sqltypes.Result{
+			Fields: selectRowsResult.Fields,
+			Rows:   selectRowsResult.Rows[:1],
+			MoreResults: []*sqltypes.Result{
+				{RowsAffected: 2},
+				selectRowsResult,
+				{RowsAffected: 1},
+			},
+		})
+	case "load local":
+		var data []byte
+		if err := c.ReadLocalInfile("data.csv", func(chunk []byte) error {
+			data = append(data, chunk...)
+			return nil
+		}); err != nil {
+			return err
+		}
+		th.mu.Lock()
+		th.localInfile = data
+		th.mu.Unlock()
+		callback(&sqltypes.Result{
+			RowsAffected: uint64(bytes.Count(data, []byte("\n"))),
+		})
 	case "schema echo":
 		callback(&sqltypes.Result{
 			Fields: []*querypb.Field{
@@ -155,6 +184,28 @@ func (th *testHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.R
 				},
 			},
 		})
+	case "process info":
+		process := c.ProcessInfo()
+		callback(&sqltypes.Result{
+			Fields: []*querypb.Field{
+				{
+					Name:    "command",
+					Type:    querypb.Type_VARCHAR,
+					Charset: uint32(collations.Default()),
+				},
+				{
+					Name:    "info",
+					Type:    querypb.Type_VARCHAR,
+					Charset: uint32(collations.Default()),
+				},
+			},
+			Rows: [][]sqltypes.Value{
+				{
+					sqltypes.MakeTrusted(querypb.Type_VARCHAR, []byte(process.Command)),
+					sqltypes.MakeTrusted(querypb.Type_VARCHAR, []byte(process.Info)),
+				},
+			},
+		})
 	case "ssl echo":
 		value := "OFF"
 		if c.Capabilities&CapabilityClientSSL > 0 {
@@ -525,6 +576,73 @@ func TestConnCounts(t *testing.T) {
 	// checkCountsForUser(t, user, 0)
 }
 
+func TestChangeUser(t *testing.T) {
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["changeUser1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	authServer.entries["changeUser2"] = []*AuthServerStaticEntry{{
+		Password: "password2",
+		UserData: "userData2",
+	}}
+	defer authServer.close()
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	c, err := Connect(context.Background(), &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "changeUser1",
+		Pass:  "password1",
+	})
+	require.NoError(t, err)
+	defer c.Close()
+	checkCountsForUser(t, "changeUser1", 1)
+
+	writeComChangeUser := func(user, passwd, db string) {
+		// The scramble is computed with the salt of the initial handshake.
+		authResponse := ScrambleMysqlNativePassword(c.salt, []byte(passwd))
+		c.sequence = 0
+		length := 1 + len(user) + 1 + 1 + len(authResponse) + len(db) + 1 + 2 + len(MysqlNativePassword) + 1
+		data, pos := c.startEphemeralPacketWithHeader(length)
+		pos = writeByte(data, pos, ComChangeUser)
+		pos = writeNullString(data, pos, user)
+		pos = writeByte(data, pos, byte(len(authResponse)))
+		pos += copy(data[pos:], authResponse)
+		pos = writeNullString(data, pos, db)
+		pos = writeUint16(data, pos, uint16(collations.Default()))
+		_ = writeNullString(data, pos, string(MysqlNativePassword))
+		require.NoError(t, c.writeEphemeralPacket())
+	}
+
+	writeComChangeUser("changeUser2", "password2", "db2")
+	data, err := c.readPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, OKPacket, data[0], "expected OK packet, got %v", data)
+	assert.Equal(t, "changeUser2", th.LastConn().User)
+	assert.Equal(t, "userData2", th.LastConn().UserData.Get().Username)
+	checkCountsForUser(t, "changeUser1", 0)
+	checkCountsForUser(t, "changeUser2", 1)
+
+	// The connection can still be used by the new user.
+	qr, err := c.ExecuteFetch("select rows", 1000, true)
+	require.NoError(t, err)
+	assert.Equal(t, selectRowsResult.Rows, qr.Rows)
+
+	// A wrong password fails and closes the connection.
+	writeComChangeUser("changeUser1", "bad", "")
+	data, err = c.readPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(data), "expected error packet, got %v", data)
+	assert.Contains(t, ParseErrorPacket(data).Error(), "Access denied for user 'changeUser1'")
+}
+
 func checkCountsForUser(t *testing.T, user string, expected int64) {
 	connCounts := connCountPerUser.Counts()
 
@@ -1208,6 +1326,61 @@ func TestCachingSha2PasswordAuthWithoutTLS(t *testing.T) {
 	}
 }
 
+func TestCachingSha2PasswordAuthWithRSA(t *testing.T) {
+	th := &testHandler{}
+
+	// The user only has a mysql_native_password hash, so the first
+	// authentication needs the full authentication, which caches the
+	// password for the fast authentication of the next ones.
+	authServer := NewAuthServerStaticWithAuthMethodDescription("", "", 0, CachingSha2Password)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{
+		{MysqlNativePassword: "*" + strings.ToUpper(hex.EncodeToString(hashMysqlNativePassword([]byte("password1"))))},
+	}
+	defer authServer.close()
+
+	// Create the listener, without TLS but with an RSA key.
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0)
+	require.NoError(t, err, "NewListener failed: %v", err)
+	defer l.Close()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	l.CachingSha2RSAKey.Store(rsaKey)
+	host := l.Addr().(*net.TCPAddr).IP.String()
+	port := l.Addr().(*net.TCPAddr).Port
+	go func() {
+		l.Accept()
+	}()
+
+	params := &ConnParams{
+		Host:    host,
+		Port:    port,
+		Uname:   "user1",
+		Pass:    "password1",
+		SslMode: vttls.Disabled,
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		conn, err := Connect(ctx, params)
+		require.NoError(t, err, "unexpected connection error: %v", err)
+
+		result, err := conn.ExecuteFetch("select rows", 10000, true)
+		require.NoError(t, err, "ExecuteFetch failed: %v", err)
+		utils.MustMatch(t, result, selectRowsResult)
+
+		conn.writeComQuit()
+		conn.Close()
+
+		authServer.mu.Lock()
+		assert.Len(t, authServer.cachingSha2Hashes, 1)
+		authServer.mu.Unlock()
+	}
+
+	params.Pass = "wrong"
+	_, err = Connect(ctx, params)
+	assert.ErrorContains(t, err, "Access denied for user 'user1'")
+}
+
 func checkCountForTLSVer(t *testing.T, version string, expected int64) {
 	connCounts := connCountByTLSVer.Counts()
 	count, ok := connCounts[version]
@@ -1469,6 +1642,77 @@ func TestParseConnAttrs(t *testing.T) {
 	}
 }
 
+func TestConnectionAttributes(t *testing.T) {
+	attrs := []byte{
+		0x0c, 'p', 'r', 'o', 'g', 'r', 'a', 'm', '_', 'n', 'a', 'm', 'e',
+		0x06, 'v', 't', 't', 'e', 's', 't',
+		0x0c, '_', 'c', 'l', 'i', 'e', 'n', 't', '_', 'n', 'a', 'm', 'e',
+		0x06, 'v', 'i', 't', 'e', 's', 's',
+	}
+
+	// The handshake response of user1, without password, and with the connection attributes.
+	data := make([]byte, 4+4+1+23)
+	writeUint32(data, 0, CapabilityClientProtocol41|CapabilityClientSecureConnection|CapabilityClientPluginAuth|CapabilityClientConnAttr)
+	data = append(data, "user1\x00"...)
+	data = append(data, 0)
+	data = append(data, MysqlNativePassword+"\x00"...)
+	data = append(data, byte(len(attrs)))
+	data = append(data, attrs...)
+
+	l := &Listener{}
+	c := &Conn{}
+	user, _, _, err := l.parseClientHandshakePacket(c, true, data)
+	require.NoError(t, err)
+	assert.Equal(t, "user1", user)
+	assert.Equal(t, map[string]string{
+		"program_name": "vttest",
+		"_client_name": "vitess",
+	}, c.ConnectionAttributes)
+}
+
+func TestProcessInfo(t *testing.T) {
+	th := &testHandler{}
+
+	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:   host,
+		Port:   port,
+		Uname:  "user1",
+		DbName: "ks",
+	}
+
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The connection is idle once connected.
+	process := th.LastConn().ProcessInfo()
+	assert.Equal(t, "user1", process.User)
+	assert.Equal(t, "ks", process.DB)
+	assert.Equal(t, ProcessCommandSleep, process.Command)
+	assert.Empty(t, process.Info)
+
+	// The query being run is reported while it runs.
+	result, err := c.ExecuteFetch("process info", 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, ProcessCommandQuery, result.Rows[0][0].ToString())
+	assert.Equal(t, "process info", result.Rows[0][1].ToString())
+
+	// The connection is idle again once the result is written.
+	assert.Eventually(t, func() bool {
+		process := th.LastConn().ProcessInfo()
+		return process.Command == ProcessCommandSleep && process.Info == ""
+	}, 5*time.Second, 10*time.Millisecond)
+
+	th.LastConn().SetProcessDB("other")
+	assert.Equal(t, "other", th.LastConn().ProcessInfo().DB)
+}
+
 func TestServerFlush(t *testing.T) {
 	defer func(saved time.Duration) { mysqlServerFlushDelay = saved }(mysqlServerFlushDelay)
 	mysqlServerFlushDelay = 10 * time.Millisecond
@@ -1519,6 +1763,179 @@ func TestServerFlush(t *testing.T) {
 	assert.Nil(t, row)
 }
 
+func TestCompression(t *testing.T) {
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name:    "value",
+			Type:    querypb.Type_VARCHAR,
+			Charset: uint32(collations.Default()),
+		}},
+	}
+	for i := 0; i < 100; i++ {
+		result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar(strings.Repeat(fmt.Sprintf("row %d ", i), 1000))})
+	}
+	th := &testHandler{result: result}
+
+	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	l.CompressionAlgorithms = []string{CompressionZlib, CompressionZstd}
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	tests := []struct {
+		compression string
+		level       int
+		capability  uint32
+	}{
+		{compression: "", capability: 0},
+		{compression: CompressionZlib, capability: CapabilityClientCompress},
+		{compression: CompressionZlib, level: 1, capability: CapabilityClientCompress},
+		{compression: CompressionZstd, capability: CapabilityClientZstdCompressionAlgorithm},
+		{compression: CompressionZstd, level: 19, capability: CapabilityClientZstdCompressionAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s-%d", tt.compression, tt.level), func(t *testing.T) {
+			params := &ConnParams{
+				Host:             host,
+				Port:             port,
+				Compression:      tt.compression,
+				CompressionLevel: tt.level,
+			}
+			c, err := Connect(context.Background(), params)
+			require.NoError(t, err)
+			defer c.Close()
+
+			compression := uint32(CapabilityClientCompress | CapabilityClientZstdCompressionAlgorithm)
+			assert.Equal(t, tt.capability, c.Capabilities&compression)
+			assert.Equal(t, tt.capability, th.LastConn().Capabilities&compression)
+			if tt.capability == CapabilityClientZstdCompressionAlgorithm {
+				wantLevel := tt.level
+				if wantLevel == 0 {
+					wantLevel = DefaultZstdCompressionLevel
+				}
+				assert.Equal(t, wantLevel, th.LastConn().compressionLevel)
+			}
+
+			// Run a few queries, to check the sequence numbers are reset for each command.
+			for i := 0; i < 3; i++ {
+				got, err := c.ExecuteFetch("select rows", 1000, true)
+				require.NoError(t, err)
+				utils.MustMatch(t, result, got)
+				require.NoError(t, c.Ping())
+			}
+		})
+	}
+
+	// A server not offering the algorithm leaves the connection uncompressed.
+	l.CompressionAlgorithms = []string{CompressionZlib}
+	c, err := Connect(context.Background(), &ConnParams{Host: host, Port: port, Compression: CompressionZstd})
+	require.NoError(t, err)
+	defer c.Close()
+	assert.Zero(t, c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm))
+	got, err := c.ExecuteFetch("select rows", 1000, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, result, got)
+
+	_, err = Connect(context.Background(), &ConnParams{Host: host, Port: port, Compression: "lz4"})
+	assert.ErrorContains(t, err, "invalid compression algorithm")
+}
+
+func TestMultipleResultSets(t *testing.T) {
+	th := &testHandler{}
+
+	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host: host,
+		Port: port,
+	}
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err)
+	defer c.Close()
+	assert.NotZero(t, th.LastConn().Capabilities&CapabilityClientMultiResults)
+
+	qr, more, err := c.ExecuteFetchMulti("multiple results", 1000, true)
+	require.NoError(t, err)
+	require.True(t, more)
+	utils.MustMatch(t, selectRowsResult.Fields, qr.Fields)
+	assert.Equal(t, selectRowsResult.Rows[:1], qr.Rows)
+
+	qr, more, _, err = c.ReadQueryResult(1000, true)
+	require.NoError(t, err)
+	require.True(t, more)
+	assert.EqualValues(t, 2, qr.RowsAffected)
+
+	qr, more, _, err = c.ReadQueryResult(1000, true)
+	require.NoError(t, err)
+	require.True(t, more)
+	utils.MustMatch(t, selectRowsResult.Fields, qr.Fields)
+	assert.Equal(t, selectRowsResult.Rows, qr.Rows)
+
+	qr, more, _, err = c.ReadQueryResult(1000, true)
+	require.NoError(t, err)
+	require.False(t, more)
+	assert.EqualValues(t, 1, qr.RowsAffected)
+
+	// The connection can still be used once all the result sets are read.
+	qr, err = c.ExecuteFetch("select rows", 1000, true)
+	require.NoError(t, err)
+	assert.Equal(t, selectRowsResult.Rows, qr.Rows)
+}
+
+func TestLocalInfile(t *testing.T) {
+	th := &testHandler{}
+
+	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	l.AllowLocalInfile = true
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Flags: CapabilityClientLocalFiles,
+	}
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err)
+	defer c.Close()
+	assert.NotZero(t, th.LastConn().Capabilities&CapabilityClientLocalFiles)
+
+	// The server requests the file, which the client sends in several
+	// packets, followed by an empty one.
+	require.NoError(t, c.WriteComQuery("load local"))
+	data, err := c.readPacket()
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{LocalInfilePacket}, "data.csv"...), data)
+	for _, chunk := range []string{"1,a\n2,", "b\n", ""} {
+		data, pos := c.startEphemeralPacketWithHeader(len(chunk))
+		copy(data[pos:], chunk)
+		require.NoError(t, c.writeEphemeralPacket())
+	}
+	qr, _, _, err := c.ReadQueryResult(1000, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, qr.RowsAffected)
+	assert.Equal(t, "1,a\n2,b\n", string(th.localInfile))
+
+	// The connection can still be used once the file is read.
+	qr, err = c.ExecuteFetch("select rows", 1000, true)
+	require.NoError(t, err)
+	assert.Equal(t, selectRowsResult.Rows, qr.Rows)
+
+	// Without CLIENT_LOCAL_FILES, the server refuses to request files.
+	c2, err := Connect(context.Background(), &ConnParams{Host: host, Port: port})
+	require.NoError(t, err)
+	defer c2.Close()
+	_, err = c2.ExecuteFetch("load local", 1000, true)
+	assert.ErrorContains(t, err, "Loading local data is disabled")
+}
+
 func TestTcpKeepAlive(t *testing.T) {
 	th := &testHandler{}
 	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0)
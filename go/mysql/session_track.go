@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+// Transaction states sent with SessionTrackTransactionState. MySQL describes
+// the transaction with 8 characters, of which Vitess only sets the first one.
+const (
+	// TransactionStateNone is the state outside of a transaction.
+	TransactionStateNone = "________"
+	// TransactionStateExplicit is the state of an explicitly started transaction.
+	TransactionStateExplicit = "T_______"
+)
+
+// SessionTrackEnabled returns true if the client asked for the changes of the
+// session state with CLIENT_SESSION_TRACK, and the server offers them.
+func (c *Conn) SessionTrackEnabled() bool {
+	return c.Capabilities&CapabilityClientSessionTrack != 0
+}
+
+// TrackSchema records that the statement being run changed the default schema
+// of the session.
+func (c *Conn) TrackSchema(schema string) {
+	c.trackSessionState(SessionTrackSchema, getLenEncString([]byte(schema)))
+}
+
+// TrackSystemVariable records that the statement being run changed the value
+// of a system variable of the session.
+func (c *Conn) TrackSystemVariable(name, value string) {
+	data := getLenEncString([]byte(name))
+	data = append(data, getLenEncString([]byte(value))...)
+	c.trackSessionState(SessionTrackSystemVariables, data)
+}
+
+// TrackTransactionState records that the statement being run changed the
+// transaction state of the session, e.g. to TransactionStateExplicit.
+func (c *Conn) TrackTransactionState(state string) {
+	c.trackSessionState(SessionTrackTransactionState, getLenEncString([]byte(state)))
+}
+
+// TrackStateChange records that the statement being run changed the state of
+// the session, so that the clients multiplexing their connections know that
+// it can't be reused for another session.
+func (c *Conn) TrackStateChange() {
+	// Unlike the other changes, the flag is not a length encoded string.
+	c.trackSessionState(SessionTrackStateChange, []byte("1"))
+}
+
+// trackSessionState records a change of the session state, which is sent to
+// the client with the next OK packet. It does nothing if the client didn't
+// ask for these changes.
+func (c *Conn) trackSessionState(typ uint8, data []byte) {
+	if !c.SessionTrackEnabled() {
+		return
+	}
+	c.sessionStateChanges = append(c.sessionStateChanges, typ)
+	c.sessionStateChanges = append(c.sessionStateChanges, getLenEncString(data)...)
+}
@@ -117,6 +117,7 @@ const (
 	EROptionPreventsStatement       = ErrorCode(1290)
 	ERDuplicatedValueInType         = ErrorCode(1291)
 	ERSPDoesNotExist                = ErrorCode(1305)
+	ERSPBadSelect                   = ErrorCode(1312)
 	ERNoDefaultForField             = ErrorCode(1364)
 	ErSPNotVarArg                   = ErrorCode(1414)
 	ERRowIsReferenced2              = ErrorCode(1451)
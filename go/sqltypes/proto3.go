@@ -106,9 +106,21 @@ func ResultToProto3(qr *Result) *querypb.QueryResult {
 		Rows:                RowsToProto3(qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		MoreResults:         moreResultsToProto3(qr.MoreResults),
 	}
 }
 
+func moreResultsToProto3(results []*Result) []*querypb.QueryResult {
+	if len(results) == 0 {
+		return nil
+	}
+	out := make([]*querypb.QueryResult, len(results))
+	for i, r := range results {
+		out[i] = ResultToProto3(r)
+	}
+	return out
+}
+
 // Proto3ToResult converts a proto3 Result to an internal data structure. This function
 // should be used only if the field info is populated in qr.
 func Proto3ToResult(qr *querypb.QueryResult) *Result {
@@ -122,7 +134,19 @@ func Proto3ToResult(qr *querypb.QueryResult) *Result {
 		Rows:                proto3ToRows(qr.Fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		MoreResults:         proto3ToMoreResults(qr.MoreResults),
+	}
+}
+
+func proto3ToMoreResults(results []*querypb.QueryResult) []*Result {
+	if len(results) == 0 {
+		return nil
+	}
+	out := make([]*Result, len(results))
+	for i, r := range results {
+		out[i] = Proto3ToResult(r)
 	}
+	return out
 }
 
 // CustomProto3ToResult converts a proto3 Result to an internal data structure. This function
@@ -139,6 +163,7 @@ func CustomProto3ToResult(fields []*querypb.Field, qr *querypb.QueryResult) *Res
 		Rows:                proto3ToRows(fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		MoreResults:         proto3ToMoreResults(qr.MoreResults),
 	}
 }
 
@@ -83,6 +83,54 @@ func TestResult(t *testing.T) {
 	}
 }
 
+func TestResultMoreResults(t *testing.T) {
+	fields := []*querypb.Field{{
+		Name: "col1",
+		Type: Int64,
+	}}
+	sqlResult := &Result{
+		Fields: fields,
+		Rows:   [][]Value{{TestValue(Int64, "1")}},
+		MoreResults: []*Result{{
+			Fields: fields,
+			Rows:   [][]Value{{TestValue(Int64, "2")}},
+		}, {
+			RowsAffected: 3,
+			Rows:         [][]Value{},
+		}},
+	}
+	p3Result := &querypb.QueryResult{
+		Fields: fields,
+		Rows: []*querypb.Row{{
+			Lengths: []int64{1},
+			Values:  []byte("1"),
+		}},
+		MoreResults: []*querypb.QueryResult{{
+			Fields: fields,
+			Rows: []*querypb.Row{{
+				Lengths: []int64{1},
+				Values:  []byte("2"),
+			}},
+		}, {
+			RowsAffected: 3,
+		}},
+	}
+	p3converted := ResultToProto3(sqlResult)
+	if !proto.Equal(p3converted, p3Result) {
+		t.Errorf("P3:\n%v, want\n%v", p3converted, p3Result)
+	}
+
+	reverse := Proto3ToResult(p3Result)
+	if !reverse.Equal(sqlResult) {
+		t.Errorf("reverse:\n%#v, want\n%#v", reverse, sqlResult)
+	}
+
+	reverse.MoreResults[1].RowsAffected = 4
+	if reverse.Equal(sqlResult) {
+		t.Errorf("results with different result sets are equal:\n%#v\n%#v", reverse, sqlResult)
+	}
+}
+
 func TestResults(t *testing.T) {
 	fields1 := []*querypb.Field{{
 		Name: "col1",
@@ -35,6 +35,9 @@ type Result struct {
 	SessionStateChanges string           `json:"session_state_changes"`
 	StatusFlags         uint16           `json:"status_flags"`
 	Info                string           `json:"info"`
+	// MoreResults are the result sets following this one, when the query
+	// returned several of them, like a call to a stored procedure.
+	MoreResults []*Result `json:"more_results,omitempty"`
 }
 
 //goland:noinspection GoUnusedConst
@@ -108,6 +111,12 @@ func (result *Result) Copy() *Result {
 			out.Rows = append(out.Rows, CopyRow(r))
 		}
 	}
+	if result.MoreResults != nil {
+		out.MoreResults = make([]*Result, 0, len(result.MoreResults))
+		for _, r := range result.MoreResults {
+			out.MoreResults = append(out.MoreResults, r.Copy())
+		}
+	}
 	return out
 }
 
@@ -120,6 +129,7 @@ func (result *Result) ShallowCopy() *Result {
 		Info:                result.Info,
 		SessionStateChanges: result.SessionStateChanges,
 		Rows:                result.Rows,
+		MoreResults:         result.MoreResults,
 	}
 }
 
@@ -194,11 +204,20 @@ func (result *Result) Equal(other *Result) bool {
 		return false
 	}
 
-	// Compare Fields, RowsAffected, InsertID, Rows.
-	return FieldsEqual(result.Fields, other.Fields) &&
-		result.RowsAffected == other.RowsAffected &&
-		result.InsertID == other.InsertID &&
-		reflect.DeepEqual(result.Rows, other.Rows)
+	// Compare Fields, RowsAffected, InsertID, Rows, MoreResults.
+	if !FieldsEqual(result.Fields, other.Fields) ||
+		result.RowsAffected != other.RowsAffected ||
+		result.InsertID != other.InsertID ||
+		!reflect.DeepEqual(result.Rows, other.Rows) ||
+		len(result.MoreResults) != len(other.MoreResults) {
+		return false
+	}
+	for i, r := range result.MoreResults {
+		if !r.Equal(other.MoreResults[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 // ResultsEqual compares two arrays of Result.
@@ -302,17 +321,25 @@ func IncludeFieldsOrDefault(options *querypb.ExecuteOptions) querypb.ExecuteOpti
 // proto.Copy each Field for performance reasons, but we only copy the
 // individual fields.
 func (result *Result) StripMetadata(incl querypb.ExecuteOptions_IncludedFields) *Result {
-	if incl == querypb.ExecuteOptions_ALL || len(result.Fields) == 0 {
+	if incl == querypb.ExecuteOptions_ALL || (len(result.Fields) == 0 && len(result.MoreResults) == 0) {
 		return result
 	}
 	r := *result
-	r.Fields = make([]*querypb.Field, len(result.Fields))
-	newFieldsArray := make([]querypb.Field, len(result.Fields))
-	for i, f := range result.Fields {
-		r.Fields[i] = &newFieldsArray[i]
-		newFieldsArray[i].Type = f.Type
-		if incl == querypb.ExecuteOptions_TYPE_AND_NAME {
-			newFieldsArray[i].Name = f.Name
+	if len(result.Fields) > 0 {
+		r.Fields = make([]*querypb.Field, len(result.Fields))
+		newFieldsArray := make([]querypb.Field, len(result.Fields))
+		for i, f := range result.Fields {
+			r.Fields[i] = &newFieldsArray[i]
+			newFieldsArray[i].Type = f.Type
+			if incl == querypb.ExecuteOptions_TYPE_AND_NAME {
+				newFieldsArray[i].Name = f.Name
+			}
+		}
+	}
+	if len(result.MoreResults) > 0 {
+		r.MoreResults = make([]*Result, len(result.MoreResults))
+		for i, more := range result.MoreResults {
+			r.MoreResults[i] = more.StripMetadata(incl)
 		}
 	}
 	return &r
@@ -322,6 +349,9 @@ func (result *Result) StripMetadata(incl querypb.ExecuteOptions_IncludedFields)
 // to another result.Note currently it doesn't handle cases like
 // if two results have different fields.We will enhance this function.
 func (result *Result) AppendResult(src *Result) {
+	// The following result sets are only kept as is, they are
+	// only returned by the queries sent to a single shard.
+	result.MoreResults = append(result.MoreResults, src.MoreResults...)
 	if src.RowsAffected == 0 && len(src.Rows) == 0 && len(src.Fields) == 0 {
 		return
 	}
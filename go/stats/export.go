@@ -60,6 +60,8 @@ func RegisterFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&statsBackend, "stats_backend", statsBackend, "The name of the registered push-based monitoring/stats backend to use")
 	fs.StringVar(&combineDimensions, "stats_combine_dimensions", combineDimensions, `List of dimensions to be combined into a single "all" value in exported stats vars`)
 	fs.StringVar(&dropVariables, "stats_drop_variables", dropVariables, `Variables to be dropped from the list of exported variables.`)
+	fs.Var(&timingsBuckets, "timings-buckets", "Comma-separated list of the upper bounds of the buckets of the latency histograms of the timings stats.")
+	fs.Var(&timingsBucketsOverrides, "timings-buckets-override", "Buckets of the latency histograms of a timings stat, overriding --timings-buckets for it, as name=buckets. Example: Queries=1ms,2ms,5ms,10ms,20ms. Can be repeated.")
 	fs.StringSliceVar(&CommonTags, "stats_common_tags", CommonTags, `Comma-separated list of common tags for the stats backend. It provides both label and values. Example: label1:value1,label2:value2`)
 }
 
@@ -19,7 +19,9 @@ package stats
 import (
 	"bytes"
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Histogram tracks counts and totals while
@@ -36,6 +38,20 @@ type Histogram struct {
 
 	buckets []atomic.Int64
 	total   atomic.Int64
+
+	// exemplars holds the latest exemplar of each bucket. It is allocated
+	// by the first call to AddWithExemplar.
+	exemplarsMu sync.Mutex
+	exemplars   []Exemplar
+}
+
+// Exemplar is a value added to a Histogram along with the ID of the trace
+// of the request it was measured for, so that sample traces can be looked
+// up from the buckets of the histogram.
+type Exemplar struct {
+	Value     int64
+	TraceID   string
+	Timestamp time.Time
 }
 
 // NewHistogram creates a histogram with auto-generated labels
@@ -76,13 +92,8 @@ func NewGenericHistogram(name, help string, cutoffs []int64, labels []string, co
 
 // Add adds a new measurement to the Histogram.
 func (h *Histogram) Add(value int64) {
-	for i := range h.labels {
-		if i == len(h.labels)-1 || value <= h.cutoffs[i] {
-			h.buckets[i].Add(1)
-			h.total.Add(value)
-			break
-		}
-	}
+	h.buckets[h.bucket(value)].Add(1)
+	h.total.Add(value)
 	if h.hook != nil {
 		h.hook(value)
 	}
@@ -91,6 +102,32 @@ func (h *Histogram) Add(value int64) {
 	}
 }
 
+// AddWithExemplar adds a new measurement to the Histogram and, if traceID
+// is set, keeps it as the exemplar of its bucket.
+func (h *Histogram) AddWithExemplar(value int64, traceID string) {
+	h.Add(value)
+	if traceID == "" {
+		return
+	}
+	i := h.bucket(value)
+	h.exemplarsMu.Lock()
+	defer h.exemplarsMu.Unlock()
+	if h.exemplars == nil {
+		h.exemplars = make([]Exemplar, len(h.buckets))
+	}
+	h.exemplars[i] = Exemplar{Value: value, TraceID: traceID, Timestamp: time.Now()}
+}
+
+// bucket returns the index of the bucket of the value.
+func (h *Histogram) bucket(value int64) int {
+	for i, cutoff := range h.cutoffs {
+		if value <= cutoff {
+			return i
+		}
+	}
+	return len(h.cutoffs)
+}
+
 // String returns a string representation of the Histogram.
 // Note that sum of all buckets may not be equal to the total temporarily,
 // because Add() increments bucket and total with two atomic operations.
@@ -168,7 +205,98 @@ func (h *Histogram) Buckets() []int64 {
 	return buckets
 }
 
+// Exemplars returns the latest exemplar of each bucket that has one,
+// in the order of the buckets.
+func (h *Histogram) Exemplars() []Exemplar {
+	h.exemplarsMu.Lock()
+	defer h.exemplarsMu.Unlock()
+	var exemplars []Exemplar
+	for _, e := range h.exemplars {
+		if e.TraceID != "" {
+			exemplars = append(exemplars, e)
+		}
+	}
+	return exemplars
+}
+
 // Help returns the help string.
 func (h *Histogram) Help() string {
 	return h.help
 }
+
+// Merge adds the counts and the total of the other Histogram to this
+// Histogram. Both histograms must have been created with the same cutoffs.
+func (h *Histogram) Merge(other *Histogram) {
+	if len(h.buckets) != len(other.buckets) {
+		panic("mismatched histogram cutoffs")
+	}
+	for i := range other.buckets {
+		h.buckets[i].Add(other.buckets[i].Load())
+	}
+	h.total.Add(other.total.Load())
+}
+
+// HistogramsFuncWithMultiLabels is a multidimensional collection of
+// histograms that are computed on the fly by the provided function,
+// e.g. by merging the histograms of several objects. All the histograms
+// must have been created with the cutoffs of the collection.
+// The map keys are the label values joined with '.'.
+type HistogramsFuncWithMultiLabels struct {
+	f       func() map[string]*Histogram
+	help    string
+	labels  []string
+	cutoffs []int64
+}
+
+// NewHistogramsFuncWithMultiLabels creates a new HistogramsFuncWithMultiLabels
+// mapping to the provided function.
+func NewHistogramsFuncWithMultiLabels(name, help string, labels []string, cutoffs []int64, f func() map[string]*Histogram) *HistogramsFuncWithMultiLabels {
+	h := &HistogramsFuncWithMultiLabels{
+		f:       f,
+		help:    help,
+		labels:  labels,
+		cutoffs: cutoffs,
+	}
+	if name != "" {
+		publish(name, h)
+	}
+	return h
+}
+
+// Histograms returns the histograms computed by the function.
+func (h *HistogramsFuncWithMultiLabels) Histograms() map[string]*Histogram {
+	return h.f()
+}
+
+// Labels returns the list of labels.
+func (h *HistogramsFuncWithMultiLabels) Labels() []string {
+	return h.labels
+}
+
+// Cutoffs returns the cutoffs of the histograms.
+func (h *HistogramsFuncWithMultiLabels) Cutoffs() []int64 {
+	return h.cutoffs
+}
+
+// Help returns the help string.
+func (h *HistogramsFuncWithMultiLabels) Help() string {
+	return h.help
+}
+
+// String implements the expvar.Var interface.
+func (h *HistogramsFuncWithMultiLabels) String() string {
+	m := h.f()
+	b := bytes.NewBuffer(make([]byte, 0, 4096))
+	fmt.Fprintf(b, "{")
+	firstValue := true
+	for k, v := range m {
+		if firstValue {
+			firstValue = false
+		} else {
+			fmt.Fprintf(b, ", ")
+		}
+		fmt.Fprintf(b, "%q: %s", k, v.String())
+	}
+	fmt.Fprintf(b, "}")
+	return b.String()
+}
@@ -87,3 +87,58 @@ func TestHistogramHook(t *testing.T) {
 		t.Errorf("got %#v, want %#v", gotv, v)
 	}
 }
+
+func TestHistogramMerge(t *testing.T) {
+	h1 := NewHistogram("", "help", []int64{1, 5})
+	h2 := NewHistogram("", "help", []int64{1, 5})
+	h1.Add(1)
+	h2.Add(3)
+	h2.Add(7)
+	h1.Merge(h2)
+	want := `{"1": 1, "5": 1, "inf": 1, "Count": 3, "Total": 11}`
+	if got := h1.String(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestHistogramExemplars(t *testing.T) {
+	h := NewHistogram("", "help", []int64{1, 5})
+	h.Add(1)
+	if got := h.Exemplars(); len(got) != 0 {
+		t.Errorf("got %v, want no exemplars", got)
+	}
+	h.AddWithExemplar(3, "trace1")
+	h.AddWithExemplar(4, "trace2")
+	h.AddWithExemplar(7, "trace3")
+	h.AddWithExemplar(8, "")
+	want := `{"1": 1, "5": 2, "inf": 2, "Count": 5, "Total": 23}`
+	if got := h.String(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	exemplars := h.Exemplars()
+	if len(exemplars) != 2 {
+		t.Fatalf("got %v, want 2 exemplars", exemplars)
+	}
+	if got := exemplars[0]; got.Value != 4 || got.TraceID != "trace2" {
+		t.Errorf("got %v, want the exemplar of trace2", got)
+	}
+	if got := exemplars[1]; got.Value != 7 || got.TraceID != "trace3" {
+		t.Errorf("got %v, want the exemplar of trace3", got)
+	}
+}
+
+func TestHistogramsFuncWithMultiLabels(t *testing.T) {
+	clearStats()
+	h := NewHistogram("", "help", []int64{1})
+	h.Add(2)
+	f := NewHistogramsFuncWithMultiLabels("histfunc", "help", []string{"workflow"}, []int64{1}, func() map[string]*Histogram {
+		return map[string]*Histogram{"wf1": h}
+	})
+	want := `{"wf1": {"1": 0, "inf": 1, "Count": 1, "Total": 2}}`
+	if got := f.String(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := expvar.Get("histfunc"); got != f {
+		t.Errorf("got %v, want %v", got, f)
+	}
+}
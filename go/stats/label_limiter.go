@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"sort"
+	"sync"
+)
+
+// OverflowLabel is the label value under which the values past the limit of
+// a LabelLimiter are recorded.
+const OverflowLabel = "other"
+
+// LabelLimiter caps the cardinality of a label of multi-dimensional stats,
+// such as a table or a query fingerprint, so that they don't grow without
+// bounds. The first distinct values it sees are tracked, up to its limit,
+// and all the following ones are recorded under OverflowLabel.
+//
+// As the values are tracked for the lifetime of the process, the hot values
+// are the ones the limiter usually tracks, since they are seen soon after
+// the process starts.
+type LabelLimiter struct {
+	limit int
+
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+// NewLabelLimiter returns a LabelLimiter tracking up to limit values. A limit
+// of 0 or less records all the values under OverflowLabel.
+func NewLabelLimiter(limit int) *LabelLimiter {
+	return &LabelLimiter{
+		limit:  limit,
+		values: make(map[string]struct{}),
+	}
+}
+
+// Limit returns the value if it is tracked, or can be, and OverflowLabel
+// otherwise.
+func (l *LabelLimiter) Limit(value string) string {
+	l.mu.RLock()
+	_, ok := l.values[value]
+	l.mu.RUnlock()
+	if ok {
+		return value
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.values[value]; ok {
+		return value
+	}
+	if len(l.values) >= l.limit {
+		return OverflowLabel
+	}
+	l.values[value] = struct{}{}
+	return value
+}
+
+// Tracked returns true if the value is tracked.
+func (l *LabelLimiter) Tracked(value string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.values[value]
+	return ok
+}
+
+// Values returns the tracked values, sorted.
+func (l *LabelLimiter) Values() []string {
+	l.mu.RLock()
+	values := make([]string, 0, len(l.values))
+	for value := range l.values {
+		values = append(values, value)
+	}
+	l.mu.RUnlock()
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelLimiter(t *testing.T) {
+	l := NewLabelLimiter(2)
+	assert.Equal(t, "a", l.Limit("a"))
+	assert.Equal(t, "b", l.Limit("b"))
+	assert.Equal(t, OverflowLabel, l.Limit("c"))
+	assert.Equal(t, "a", l.Limit("a"), "tracked values stay tracked")
+	assert.True(t, l.Tracked("b"))
+	assert.False(t, l.Tracked("c"))
+	assert.Equal(t, []string{"a", "b"}, l.Values())
+
+	l = NewLabelLimiter(0)
+	assert.Equal(t, OverflowLabel, l.Limit("a"))
+	assert.Empty(t, l.Values())
+}
+
+func TestLabelLimiterConcurrency(t *testing.T) {
+	l := NewLabelLimiter(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.Limit(strconv.Itoa(i))
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, l.Values(), 10)
+}
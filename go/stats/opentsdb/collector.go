@@ -127,6 +127,10 @@ func (dc *collector) addExpVar(kv expvar.KeyValue) {
 		dc.addTimings([]string{v.Label()}, v, k)
 	case *stats.Histogram:
 		dc.addHistogram(v, 1, k, make(map[string]string))
+	case *stats.HistogramsFuncWithMultiLabels:
+		for labelVals, histogram := range v.Histograms() {
+			dc.addHistogram(histogram, 1, k, makeLabels(v.Labels(), labelVals))
+		}
 	case *stats.CountersWithSingleLabel:
 		for labelVal, val := range v.Counts() {
 			dc.addInt(k, val, makeLabel(v.Label(), labelVal))
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otel
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+// scopeName is the name of the instrumentation scope of the metrics.
+const scopeName = "vitess.io/vitess/go/stats"
+
+// backend implements stats.PushBackend
+type backend struct {
+	// The prefix is the name of the binary (vtgate, vttablet, etc.), which is
+	// the service name of the resource, and is prepended to the name of all
+	// the metrics, as the Prometheus backend does.
+	prefix string
+	// resource describes the process reporting the metrics.
+	resource Resource
+	// startTime is the start time of the cumulative metrics.
+	startTime time.Time
+	// writer is used to send the metrics.
+	writer writer
+}
+
+// writer sends metrics to an OpenTelemetry collector.
+type writer interface {
+	Write(request *MetricsRequest) error
+}
+
+// PushAll pushes all stats to the OpenTelemetry collector
+func (b *backend) PushAll() error {
+	collector := b.collector()
+	collector.collectAll()
+	return b.writer.Write(b.request(collector.metrics))
+}
+
+// PushOne pushes a single stat to the OpenTelemetry collector
+func (b *backend) PushOne(name string, v stats.Variable) error {
+	collector := b.collector()
+	collector.collectOne(name, v)
+	return b.writer.Write(b.request(collector.metrics))
+}
+
+func (b *backend) collector() *collector {
+	return &collector{
+		prefix:    b.prefix,
+		startTime: int64String(b.startTime.UnixNano()),
+		timestamp: int64String(time.Now().UnixNano()),
+	}
+}
+
+func (b *backend) request(metrics []*Metric) *MetricsRequest {
+	return &MetricsRequest{
+		ResourceMetrics: []*ResourceMetrics{{
+			Resource: b.resource,
+			ScopeMetrics: []*ScopeMetrics{{
+				Scope: Scope{
+					Name:    scopeName,
+					Version: servenv.AppVersion.ToStringMap()["version"],
+				},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+// newResource returns the resource of the process, with the attributes of
+// the OpenTelemetry semantic conventions from servenv, and the common tags
+// of the stats.
+func newResource(serviceName string, commonTags map[string]string) Resource {
+	version := servenv.AppVersion.ToStringMap()
+	hostname, err := netutil.FullyQualifiedHostname()
+	if err != nil {
+		hostname, _ = os.Hostname()
+	}
+	instanceID := servenv.ListeningURL.Host
+	if instanceID == "" {
+		instanceID = hostname
+	}
+
+	attributes := []*KeyValue{
+		stringAttribute("service.name", serviceName),
+		stringAttribute("service.namespace", "vitess"),
+		stringAttribute("service.version", version["version"]),
+		stringAttribute("service.instance.id", instanceID),
+		stringAttribute("host.name", hostname),
+		intAttribute("process.pid", int64(os.Getpid())),
+		stringAttribute("process.runtime.version", version["go_version"]),
+		stringAttribute("vitess.build_git_rev", version["build_git_rev"]),
+	}
+	tags := make([]string, 0, len(commonTags))
+	for tag := range commonTags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		attributes = append(attributes, stringAttribute(tag, commonTags[tag]))
+	}
+	return Resource{Attributes: attributes}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otel
+
+import (
+	"expvar"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+)
+
+// collector tracks state for a single pass of stats reporting.
+type collector struct {
+	prefix    string
+	startTime int64String
+	timestamp int64String
+	metrics   []*Metric
+}
+
+func (c *collector) collectAll() {
+	expvar.Do(func(kv expvar.KeyValue) {
+		c.addExpVar(kv.Key, kv.Value)
+	})
+}
+
+func (c *collector) collectOne(name string, v expvar.Var) {
+	c.addExpVar(name, v)
+}
+
+// addExpVar adds the metric of a stats variable. Counters are sent as
+// monotonic sums, gauges as gauges, and histograms and timings as histograms,
+// durations being in seconds. Strings and rates are not sent, nor are the
+// expvars not from the stats package.
+func (c *collector) addExpVar(name string, v expvar.Var) {
+	switch st := v.(type) {
+	case *stats.Counter:
+		c.addSum(name, st.Help(), "", c.intPoint(st.Get(), nil))
+	case *stats.CounterFunc:
+		c.addSum(name, st.Help(), "", c.intPoint(st.F(), nil))
+	case *stats.Gauge:
+		c.addGauge(name, st.Help(), "", c.intPoint(st.Get(), nil))
+	case *stats.GaugeFloat64:
+		c.addGauge(name, st.Help(), "", c.doublePoint(st.Get(), nil))
+	case *stats.GaugeFunc:
+		c.addGauge(name, st.Help(), "", c.intPoint(st.F(), nil))
+	case stats.FloatFunc:
+		c.addGauge(name, st.Help(), "", c.doublePoint(st(), nil))
+	case *stats.CounterDuration:
+		c.addSum(name, st.Help(), "s", c.doublePoint(st.Get().Seconds(), nil))
+	case *stats.CounterDurationFunc:
+		c.addSum(name, st.Help(), "s", c.doublePoint(st.F().Seconds(), nil))
+	case *stats.GaugeDuration:
+		c.addGauge(name, st.Help(), "s", c.doublePoint(st.Get().Seconds(), nil))
+	case *stats.GaugeDurationFunc:
+		c.addGauge(name, st.Help(), "s", c.doublePoint(st.F().Seconds(), nil))
+	case *stats.CountersWithSingleLabel:
+		c.addSum(name, st.Help(), "", c.intPoints(st.Counts(), []string{st.Label()})...)
+	case *stats.CountersWithMultiLabels:
+		c.addSum(name, st.Help(), "", c.intPoints(st.Counts(), st.Labels())...)
+	case *stats.CountersFuncWithMultiLabels:
+		c.addSum(name, st.Help(), "", c.intPoints(st.Counts(), st.Labels())...)
+	case *stats.GaugesWithSingleLabel:
+		c.addGauge(name, st.Help(), "", c.intPoints(st.Counts(), []string{st.Label()})...)
+	case *stats.GaugesWithMultiLabels:
+		c.addGauge(name, st.Help(), "", c.intPoints(st.Counts(), st.Labels())...)
+	case *stats.GaugesFuncWithMultiLabels:
+		c.addGauge(name, st.Help(), "", c.intPoints(st.Counts(), st.Labels())...)
+	case *stats.Timings:
+		c.addHistograms(name, st.Help(), "s", st.Histograms(), []string{st.Label()})
+	case *stats.MultiTimings:
+		c.addHistograms(name, st.Help(), "s", st.Histograms(), st.Labels())
+	case *stats.Histogram:
+		c.addHistogram(name, st.Help(), "", c.histogramPoint(st, 1, nil))
+	case *stats.HistogramsFuncWithMultiLabels:
+		c.addHistograms(name, st.Help(), "", st.Histograms(), st.Labels())
+	}
+}
+
+func (c *collector) addSum(name, help, unit string, points ...*NumberDataPoint) {
+	c.metrics = append(c.metrics, &Metric{
+		Name:        c.metricName(name),
+		Description: help,
+		Unit:        unit,
+		Sum: &Sum{
+			DataPoints:             points,
+			AggregationTemporality: aggregationTemporalityCumulative,
+			IsMonotonic:            true,
+		},
+	})
+}
+
+func (c *collector) addGauge(name, help, unit string, points ...*NumberDataPoint) {
+	c.metrics = append(c.metrics, &Metric{
+		Name:        c.metricName(name),
+		Description: help,
+		Unit:        unit,
+		Gauge:       &Gauge{DataPoints: points},
+	})
+}
+
+func (c *collector) addHistogram(name, help, unit string, points ...*HistogramDataPoint) {
+	c.metrics = append(c.metrics, &Metric{
+		Name:        c.metricName(name),
+		Description: help,
+		Unit:        unit,
+		Histogram: &Histogram{
+			DataPoints:             points,
+			AggregationTemporality: aggregationTemporalityCumulative,
+		},
+	})
+}
+
+// addHistograms adds a histogram metric with a data point per label values.
+// Histograms in seconds are recorded in nanoseconds.
+func (c *collector) addHistograms(name, help, unit string, histograms map[string]*stats.Histogram, labels []string) {
+	divideBy := 1.0
+	if unit == "s" {
+		divideBy = float64(time.Second)
+	}
+	points := make([]*HistogramDataPoint, 0, len(histograms))
+	for _, labelValues := range sortedKeys(histograms) {
+		points = append(points, c.histogramPoint(histograms[labelValues], divideBy, makeAttributes(labels, labelValues)))
+	}
+	c.addHistogram(name, help, unit, points...)
+}
+
+func (c *collector) intPoint(value int64, attributes []*KeyValue) *NumberDataPoint {
+	v := int64String(value)
+	return &NumberDataPoint{
+		Attributes:        attributes,
+		StartTimeUnixNano: c.startTime,
+		TimeUnixNano:      c.timestamp,
+		AsInt:             &v,
+	}
+}
+
+func (c *collector) doublePoint(value float64, attributes []*KeyValue) *NumberDataPoint {
+	return &NumberDataPoint{
+		Attributes:        attributes,
+		StartTimeUnixNano: c.startTime,
+		TimeUnixNano:      c.timestamp,
+		AsDouble:          &value,
+	}
+}
+
+// intPoints returns a data point per label values of counts.
+func (c *collector) intPoints(counts map[string]int64, labels []string) []*NumberDataPoint {
+	points := make([]*NumberDataPoint, 0, len(counts))
+	for _, labelValues := range sortedKeys(counts) {
+		points = append(points, c.intPoint(counts[labelValues], makeAttributes(labels, labelValues)))
+	}
+	return points
+}
+
+func (c *collector) histogramPoint(h *stats.Histogram, divideBy float64, attributes []*KeyValue) *HistogramDataPoint {
+	cutoffs := h.Cutoffs()
+	bounds := make([]float64, 0, len(cutoffs))
+	for _, cutoff := range cutoffs {
+		bounds = append(bounds, float64(cutoff)/divideBy)
+	}
+	buckets := h.Buckets()
+	bucketCounts := make([]int64String, 0, len(buckets))
+	for _, count := range buckets {
+		bucketCounts = append(bucketCounts, int64String(count))
+	}
+	return &HistogramDataPoint{
+		Attributes:        attributes,
+		StartTimeUnixNano: c.startTime,
+		TimeUnixNano:      c.timestamp,
+		Count:             int64String(h.Count()),
+		Sum:               float64(h.Total()) / divideBy,
+		BucketCounts:      bucketCounts,
+		ExplicitBounds:    bounds,
+	}
+}
+
+// metricName returns the name of the metric of a stats variable, which is
+// the one the Prometheus backend exports.
+func (c *collector) metricName(name string) string {
+	name = normalize(name)
+	if c.prefix == "" {
+		return name
+	}
+	return c.prefix + "_" + name
+}
+
+// normalize converts a stats name to snake case, as the Prometheus backend
+// does.
+func normalize(name string) string {
+	name = strings.NewReplacer("VSchema", "vschema", "VtGate", "vtgate").Replace(name)
+	return stats.GetSnakeName(name)
+}
+
+// makeAttributes takes the stats representation of label values
+// ("."-separated list) and returns the attributes of the labels.
+func makeAttributes(labels []string, labelValues string) []*KeyValue {
+	values := strings.Split(labelValues, ".")
+	attributes := make([]*KeyValue, 0, len(labels))
+	for i, label := range labels {
+		if i >= len(values) {
+			break
+		}
+		attributes = append(attributes, stringAttribute(normalize(label), values[i]))
+	}
+	return attributes
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
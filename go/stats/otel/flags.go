@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otel
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var (
+	endpoint string
+	headers  map[string]string
+	timeout  = 10 * time.Second
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&endpoint, "otel-metrics-endpoint", endpoint, "URL of the OTLP/HTTP metrics endpoint of an OpenTelemetry collector, e.g. http://localhost:4318/v1/metrics")
+	fs.StringToStringVar(&headers, "otel-metrics-headers", headers, "Comma-separated list of headers sent with the metrics to --otel-metrics-endpoint. Example: header1=value1,header2=value2")
+	fs.DurationVar(&timeout, "otel-metrics-timeout", timeout, "Timeout of the requests sending the metrics to --otel-metrics-endpoint")
+}
+
+func init() {
+	servenv.OnParseFor("vtbackup", registerFlags)
+	servenv.OnParseFor("vtctld", registerFlags)
+	servenv.OnParseFor("vtgate", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var singletonBackend stats.PushBackend
+
+// Init attempts to create a singleton *otel.backend and register it as a PushBackend.
+// If it fails to create one, this is a noop. The serviceName argument is the name
+// of the binary, reported as the service name of the resource and prepended to the
+// name of every metric.
+func Init(serviceName string) {
+	// Needs to happen in servenv.OnRun() instead of init because it requires flag parsing and logging
+	servenv.OnRun(func() {
+		log.Info("Initializing otel backend...")
+		backend, err := InitWithoutServenv(serviceName)
+		if err != nil {
+			log.Infof("Failed to initialize singleton otel backend: %v", err)
+		} else {
+			singletonBackend = backend
+			log.Info("Initialized otel backend.")
+		}
+	})
+}
+
+// InitWithoutServenv initializes the otel backend without servenv
+func InitWithoutServenv(serviceName string) (stats.PushBackend, error) {
+	b, err := newBackend(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	stats.RegisterPushBackend("otel", b)
+	servenv.HTTPHandleFunc("/debug/otel", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		collector := b.collector()
+		collector.collectAll()
+		if b, err := json.MarshalIndent(b.request(collector.metrics), "", "  "); err != nil {
+			w.Write([]byte(err.Error()))
+		} else {
+			w.Write(b)
+		}
+	})
+	return b, nil
+}
+
+func newBackend(serviceName string) (*backend, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("cannot create otel PushBackend with empty --otel-metrics-endpoint")
+	}
+	if u, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse --otel-metrics-endpoint %s: %v", endpoint, err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("failed to parse --otel-metrics-endpoint %s: not an http or https URL", endpoint)
+	}
+	startTime := servenv.GetInitStartTime()
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+	return &backend{
+		prefix:    serviceName,
+		resource:  newResource(serviceName, stats.ParseCommonTags(stats.CommonTags)),
+		startTime: startTime,
+		writer:    newHTTPWriter(&http.Client{Timeout: timeout}, endpoint, headers),
+	}, nil
+}
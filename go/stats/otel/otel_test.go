@@ -0,0 +1,205 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otel
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+)
+
+// startCollector starts an OTLP/HTTP endpoint, sets --otel-metrics-endpoint
+// to it, and returns the channel of the bodies it receives.
+func startCollector(t *testing.T, status int) <-chan map[string]any {
+	t.Helper()
+	bodies := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(b, &body))
+		bodies <- body
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	oldEndpoint, oldHeaders := endpoint, headers
+	endpoint = server.URL + "/v1/metrics"
+	headers = map[string]string{"Authorization": "secret"}
+	t.Cleanup(func() { endpoint, headers = oldEndpoint, oldHeaders })
+	return bodies
+}
+
+// pushOne pushes a stats variable, and returns the metric received by the
+// collector, and the resource attributes.
+func pushOne(t *testing.T, name string, v stats.Variable) (metric map[string]any, resource map[string]any) {
+	t.Helper()
+	bodies := startCollector(t, http.StatusOK)
+	b, err := newBackend("vtgate")
+	require.NoError(t, err)
+	require.NoError(t, b.PushOne(name, v))
+
+	body := <-bodies
+	resourceMetrics := body["resourceMetrics"].([]any)[0].(map[string]any)
+	scopeMetrics := resourceMetrics["scopeMetrics"].([]any)[0].(map[string]any)
+	assert.Equal(t, scopeName, scopeMetrics["scope"].(map[string]any)["name"])
+	metrics := scopeMetrics["metrics"].([]any)
+	require.Len(t, metrics, 1)
+	return metrics[0].(map[string]any), resourceMetrics["resource"].(map[string]any)
+}
+
+// dataPoints returns the data points of a metric, without their times.
+func dataPoints(t *testing.T, metric map[string]any, kind string) []any {
+	t.Helper()
+	require.Contains(t, metric, kind)
+	points := metric[kind].(map[string]any)["dataPoints"].([]any)
+	for _, point := range points {
+		point := point.(map[string]any)
+		assert.NotEmpty(t, point["startTimeUnixNano"])
+		assert.NotEmpty(t, point["timeUnixNano"])
+		delete(point, "startTimeUnixNano")
+		delete(point, "timeUnixNano")
+	}
+	return points
+}
+
+func TestCounter(t *testing.T) {
+	c := stats.NewCounter("OtelCounter", "counter description")
+	c.Add(3)
+
+	metric, resource := pushOne(t, "OtelCounter", c)
+	assert.Equal(t, "vtgate_otel_counter", metric["name"])
+	assert.Equal(t, "counter description", metric["description"])
+	assert.Equal(t, []any{map[string]any{"asInt": "3"}}, dataPoints(t, metric, "sum"))
+	assert.Equal(t, true, metric["sum"].(map[string]any)["isMonotonic"])
+	assert.EqualValues(t, aggregationTemporalityCumulative, metric["sum"].(map[string]any)["aggregationTemporality"])
+
+	attributes := make(map[string]any)
+	for _, attribute := range resource["attributes"].([]any) {
+		attribute := attribute.(map[string]any)
+		attributes[attribute["key"].(string)] = attribute["value"]
+	}
+	assert.Equal(t, map[string]any{"stringValue": "vtgate"}, attributes["service.name"])
+	assert.Equal(t, map[string]any{"stringValue": "vitess"}, attributes["service.namespace"])
+	assert.Contains(t, attributes["process.pid"], "intValue")
+	assert.Contains(t, attributes, "service.instance.id")
+}
+
+func TestGaugesWithMultiLabels(t *testing.T) {
+	g := stats.NewGaugesWithMultiLabels("OtelGauges", "help", []string{"Keyspace", "TabletType"})
+	g.Set([]string{"commerce", "primary"}, 2)
+	g.Set([]string{"commerce", "replica"}, 4)
+
+	metric, _ := pushOne(t, "OtelGauges", g)
+	assert.Equal(t, "vtgate_otel_gauges", metric["name"])
+	assert.Equal(t, []any{
+		map[string]any{
+			"asInt": "2",
+			"attributes": []any{
+				map[string]any{"key": "keyspace", "value": map[string]any{"stringValue": "commerce"}},
+				map[string]any{"key": "tablet_type", "value": map[string]any{"stringValue": "primary"}},
+			},
+		},
+		map[string]any{
+			"asInt": "4",
+			"attributes": []any{
+				map[string]any{"key": "keyspace", "value": map[string]any{"stringValue": "commerce"}},
+				map[string]any{"key": "tablet_type", "value": map[string]any{"stringValue": "replica"}},
+			},
+		},
+	}, dataPoints(t, metric, "gauge"))
+}
+
+func TestCounterDuration(t *testing.T) {
+	c := stats.NewCounterDuration("OtelCounterDuration", "help")
+	c.Add(1500 * time.Millisecond)
+
+	metric, _ := pushOne(t, "OtelCounterDuration", c)
+	assert.Equal(t, "s", metric["unit"])
+	assert.Equal(t, []any{map[string]any{"asDouble": 1.5}}, dataPoints(t, metric, "sum"))
+}
+
+func TestTimings(t *testing.T) {
+	timings := stats.NewTimings("OtelTimings", "help", "Operation")
+	timings.Add("read", 2*time.Millisecond)
+	timings.Add("read", 20*time.Millisecond)
+
+	metric, _ := pushOne(t, "OtelTimings", timings)
+	assert.Equal(t, "vtgate_otel_timings", metric["name"])
+	assert.Equal(t, "s", metric["unit"])
+	points := dataPoints(t, metric, "histogram")
+	require.Len(t, points, 1)
+	point := points[0].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"key": "operation", "value": map[string]any{"stringValue": "read"}}}, point["attributes"])
+	assert.Equal(t, "2", point["count"])
+	assert.InDelta(t, 0.022, point["sum"], 1e-9)
+
+	bounds := point["explicitBounds"].([]any)
+	bucketCounts := point["bucketCounts"].([]any)
+	require.Len(t, bucketCounts, len(bounds)+1)
+	assert.Equal(t, 0.0005, bounds[0])
+	// The buckets are not cumulative: the timings fall in the buckets of
+	// 5ms and 50ms.
+	var total int
+	for i, count := range bucketCounts {
+		if count != "0" {
+			assert.Equal(t, "1", count)
+			assert.Contains(t, []float64{0.005, 0.05}, bounds[i])
+			total++
+		}
+	}
+	assert.Equal(t, 2, total)
+}
+
+func TestIgnoredVariables(t *testing.T) {
+	b := &backend{prefix: "vtgate"}
+	collector := b.collector()
+	collector.collectOne("OtelString", stats.NewString("OtelString"))
+	assert.Empty(t, collector.metrics)
+}
+
+func TestPushError(t *testing.T) {
+	startCollector(t, http.StatusBadRequest)
+	b, err := newBackend("vtgate")
+	require.NoError(t, err)
+	err = b.PushOne("OtelErrorCounter", stats.NewCounter("OtelErrorCounter", "help"))
+	assert.ErrorContains(t, err, "400 Bad Request")
+}
+
+func TestNewBackend(t *testing.T) {
+	oldEndpoint := endpoint
+	defer func() { endpoint = oldEndpoint }()
+
+	endpoint = ""
+	_, err := newBackend("vtgate")
+	assert.ErrorContains(t, err, "empty --otel-metrics-endpoint")
+
+	endpoint = "localhost:4318"
+	_, err = newBackend("vtgate")
+	assert.ErrorContains(t, err, "failed to parse --otel-metrics-endpoint")
+}
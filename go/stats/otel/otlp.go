@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otel
+
+import (
+	"strconv"
+)
+
+// The types of this file are the subset of the OTLP metrics protocol the
+// backend sends, with the JSON encoding of OTLP/HTTP:
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/metrics/v1/metrics.proto
+
+// aggregationTemporalityCumulative is the temporality of all the sums and
+// histograms, as the stats accumulate from the start of the process.
+const aggregationTemporalityCumulative = 2
+
+// int64String is a 64-bit integer of OTLP, encoded in a JSON string as
+// protobuf encodes them.
+type int64String int64
+
+// MarshalJSON implements json.Marshaler.
+func (i int64String) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatInt(int64(i), 10))), nil
+}
+
+// MetricsRequest is the body of an OTLP/HTTP export of metrics.
+type MetricsRequest struct {
+	ResourceMetrics []*ResourceMetrics `json:"resourceMetrics"`
+}
+
+// ResourceMetrics are the metrics of a resource, i.e. a Vitess process.
+type ResourceMetrics struct {
+	Resource     Resource        `json:"resource"`
+	ScopeMetrics []*ScopeMetrics `json:"scopeMetrics"`
+}
+
+// Resource describes the process reporting the metrics.
+type Resource struct {
+	Attributes []*KeyValue `json:"attributes"`
+}
+
+// ScopeMetrics are the metrics of an instrumentation scope.
+type ScopeMetrics struct {
+	Scope   Scope     `json:"scope"`
+	Metrics []*Metric `json:"metrics"`
+}
+
+// Scope is the instrumentation scope of the metrics, i.e. the stats package.
+type Scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// KeyValue is an attribute of a resource or data point.
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+// AnyValue is the value of an attribute.
+type AnyValue struct {
+	StringValue *string      `json:"stringValue,omitempty"`
+	IntValue    *int64String `json:"intValue,omitempty"`
+}
+
+// Metric is a metric, with one of Sum, Gauge or Histogram set.
+type Metric struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Unit        string     `json:"unit,omitempty"`
+	Sum         *Sum       `json:"sum,omitempty"`
+	Gauge       *Gauge     `json:"gauge,omitempty"`
+	Histogram   *Histogram `json:"histogram,omitempty"`
+}
+
+// Sum is a metric of counters.
+type Sum struct {
+	DataPoints             []*NumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                `json:"aggregationTemporality"`
+	IsMonotonic            bool               `json:"isMonotonic"`
+}
+
+// Gauge is a metric of gauges.
+type Gauge struct {
+	DataPoints []*NumberDataPoint `json:"dataPoints"`
+}
+
+// NumberDataPoint is a data point of a Sum or Gauge, with one of AsInt or
+// AsDouble set.
+type NumberDataPoint struct {
+	Attributes        []*KeyValue  `json:"attributes,omitempty"`
+	StartTimeUnixNano int64String  `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      int64String  `json:"timeUnixNano"`
+	AsInt             *int64String `json:"asInt,omitempty"`
+	AsDouble          *float64     `json:"asDouble,omitempty"`
+}
+
+// Histogram is a metric of histograms.
+type Histogram struct {
+	DataPoints             []*HistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+}
+
+// HistogramDataPoint is a data point of a Histogram. As in the stats
+// package, BucketCounts are not cumulative, and have one more element than
+// ExplicitBounds, for the values above the last bound.
+type HistogramDataPoint struct {
+	Attributes        []*KeyValue   `json:"attributes,omitempty"`
+	StartTimeUnixNano int64String   `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      int64String   `json:"timeUnixNano"`
+	Count             int64String   `json:"count"`
+	Sum               float64       `json:"sum"`
+	BucketCounts      []int64String `json:"bucketCounts"`
+	ExplicitBounds    []float64     `json:"explicitBounds"`
+}
+
+// stringAttribute returns an attribute with a string value.
+func stringAttribute(key, value string) *KeyValue {
+	return &KeyValue{Key: key, Value: AnyValue{StringValue: &value}}
+}
+
+// intAttribute returns an attribute with an integer value.
+func intAttribute(key string, value int64) *KeyValue {
+	v := int64String(value)
+	return &KeyValue{Key: key, Value: AnyValue{IntValue: &v}}
+}
@@ -289,7 +289,7 @@ func (c *timingsCollector) Collect(ch chan<- prometheus.Metric) {
 		if err != nil {
 			log.Errorf("Error adding metric: %s", c.desc)
 		} else {
-			ch <- metric
+			ch <- withExemplars(metric, his)
 		}
 	}
 }
@@ -348,9 +348,35 @@ func (c *multiTimingsCollector) Collect(ch chan<- prometheus.Metric) {
 		if err != nil {
 			log.Errorf("Error adding metric: %s", c.desc)
 		} else {
-			ch <- metric
+			ch <- withExemplars(metric, his)
+		}
+	}
+}
+
+// withExemplars attaches the exemplars of the timings histogram to its
+// metric, if they are enabled, with their trace IDs as trace_id labels.
+func withExemplars(metric prometheus.Metric, his *stats.Histogram) prometheus.Metric {
+	if !exemplars {
+		return metric
+	}
+	exs := his.Exemplars()
+	if len(exs) == 0 {
+		return metric
+	}
+	promExemplars := make([]prometheus.Exemplar, len(exs))
+	for i, e := range exs {
+		promExemplars[i] = prometheus.Exemplar{
+			Value:     float64(e.Value) / 1000000000,
+			Labels:    prometheus.Labels{"trace_id": e.TraceID},
+			Timestamp: e.Timestamp,
 		}
 	}
+	m, err := prometheus.NewMetricWithExemplars(metric, promExemplars...)
+	if err != nil {
+		log.Errorf("Error adding exemplars to metric %s: %v", metric.Desc(), err)
+		return metric
+	}
+	return m
 }
 
 type histogramCollector struct {
@@ -396,6 +422,54 @@ func (c *histogramCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
+type histogramsFuncWithMultiLabelsCollector struct {
+	hf      *stats.HistogramsFuncWithMultiLabels
+	cutoffs []float64
+	desc    *prometheus.Desc
+}
+
+func newHistogramsFuncWithMultiLabelsCollector(hf *stats.HistogramsFuncWithMultiLabels, name string) {
+	cutoffs := make([]float64, len(hf.Cutoffs()))
+	for i, val := range hf.Cutoffs() {
+		cutoffs[i] = float64(val)
+	}
+
+	collector := &histogramsFuncWithMultiLabelsCollector{
+		hf:      hf,
+		cutoffs: cutoffs,
+		desc: prometheus.NewDesc(
+			name,
+			hf.Help(),
+			labelsToSnake(hf.Labels()),
+			nil),
+	}
+
+	prometheus.MustRegister(collector)
+}
+
+// Describe implements Collector.
+func (c *histogramsFuncWithMultiLabelsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements Collector.
+func (c *histogramsFuncWithMultiLabelsCollector) Collect(ch chan<- prometheus.Metric) {
+	for lvs, his := range c.hf.Histograms() {
+		labelValues := strings.Split(lvs, ".")
+		metric, err := prometheus.NewConstHistogram(
+			c.desc,
+			uint64(his.Count()),
+			float64(his.Total()),
+			makeCumulativeBuckets(c.cutoffs, his.Buckets()),
+			labelValues...)
+		if err != nil {
+			log.Errorf("Error adding metric: %s", c.desc)
+		} else {
+			ch <- metric
+		}
+	}
+}
+
 type stringMapFuncWithMultiLabelsCollector struct {
 	smf  *stats.StringMapFuncWithMultiLabels
 	desc *prometheus.Desc
@@ -18,10 +18,12 @@ package prometheusbackend
 
 import (
 	"expvar"
+	"net/http"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
 
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/log"
@@ -35,15 +37,37 @@ type PromBackend struct {
 
 var (
 	be PromBackend
+
+	// exemplars enables the exemplars of the histograms of the timings,
+	// which are only part of the OpenMetrics exposition format.
+	exemplars bool
 )
 
+func registerFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&exemplars, "prometheus-exemplars", exemplars, "Attach the trace IDs of sample queries as exemplars to the latency histograms on /metrics, and serve them in the OpenMetrics format when the scraper requests it. Requires a tracer, see --tracer.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+}
+
 // Init initializes the Prometheus be with the given namespace.
 func Init(namespace string) {
-	servenv.HTTPHandle("/metrics", promhttp.Handler())
+	servenv.HTTPHandle("/metrics", handler())
 	be.namespace = namespace
 	stats.Register(be.publishPrometheusMetric)
 }
 
+// handler returns the handler of /metrics, which negotiates the OpenMetrics
+// format if the exemplars are enabled.
+func handler() http.Handler {
+	return promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: exemplars}),
+	)
+}
+
 // publishPrometheusMetric is used to publish the metric to Prometheus.
 func (be PromBackend) publishPrometheusMetric(name string, v expvar.Var) {
 	switch st := v.(type) {
@@ -85,6 +109,8 @@ func (be PromBackend) publishPrometheusMetric(name string, v expvar.Var) {
 		newMultiTimingsCollector(st, be.buildPromName(name))
 	case *stats.Histogram:
 		newHistogramCollector(st, be.buildPromName(name))
+	case *stats.HistogramsFuncWithMultiLabels:
+		newHistogramsFuncWithMultiLabelsCollector(st, be.buildPromName(name))
 	case *stats.StringMapFuncWithMultiLabels:
 		newStringMapFuncWithMultiLabelsCollector(st, be.buildPromName(name))
 	case *stats.String, stats.StringFunc, stats.StringMapFunc, *stats.Rates, *stats.RatesFunc:
@@ -372,6 +372,57 @@ func TestPrometheusHistogram(t *testing.T) {
 	}
 }
 
+func TestPrometheusHistogramsFuncWithMultiLabels(t *testing.T) {
+	name := "blah_histogramsfunc"
+	labels := []string{"label1", "label2"}
+	cutoffs := []int64{1, 5}
+	hist := stats.NewHistogram("", "help", cutoffs)
+	hist.Add(2)
+	hist.Add(6)
+	stats.NewHistogramsFuncWithMultiLabels(name, "help", labels, cutoffs, func() map[string]*stats.Histogram {
+		return map[string]*stats.Histogram{"foo.bar": hist}
+	})
+
+	response := testMetricsHandler(t)
+	var s []string
+
+	s = append(s, fmt.Sprintf("%s_%s_bucket{label1=\"foo\",label2=\"bar\",le=\"1\"} %d", namespace, name, 0))
+	s = append(s, fmt.Sprintf("%s_%s_bucket{label1=\"foo\",label2=\"bar\",le=\"5\"} %d", namespace, name, 1))
+	s = append(s, fmt.Sprintf("%s_%s_bucket{label1=\"foo\",label2=\"bar\",le=\"+Inf\"} %d", namespace, name, 2))
+	s = append(s, fmt.Sprintf("%s_%s_sum{label1=\"foo\",label2=\"bar\"} %d", namespace, name, 8))
+	s = append(s, fmt.Sprintf("%s_%s_count{label1=\"foo\",label2=\"bar\"} %d", namespace, name, 2))
+
+	for _, line := range s {
+		if !strings.Contains(response.Body.String(), line) {
+			t.Fatalf("Expected result to contain %s, got %s", line, response.Body.String())
+		}
+	}
+}
+
+func TestPrometheusTimingsExemplars(t *testing.T) {
+	exemplars = true
+	defer func() { exemplars = false }()
+
+	name := "blah_exemplar_timings"
+	timing := stats.NewMultiTimings(name, "help", []string{"category"})
+	timing.AddWithExemplar([]string{"cat1"}, 30*time.Millisecond, "4bf92f3577b34da6")
+	timing.Add([]string{"cat1"}, 200*time.Millisecond)
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+	response := httptest.NewRecorder()
+	handler().ServeHTTP(response, req)
+
+	expected := fmt.Sprintf("%s_%s_bucket{category=\"cat1\",le=\"0.05\"} 1 # {trace_id=\"4bf92f3577b34da6\"} 0.03 ", namespace, name)
+	if !strings.Contains(response.Body.String(), expected) {
+		t.Fatalf("Expected result to contain %s, got %s", expected, response.Body.String())
+	}
+	unexpected := fmt.Sprintf("%s_%s_bucket{category=\"cat1\",le=\"0.5\"} 2 #", namespace, name)
+	if strings.Contains(response.Body.String(), unexpected) {
+		t.Fatalf("Expected result not to contain %s, got %s", unexpected, response.Body.String())
+	}
+}
+
 func testMetricsHandler(t *testing.T) *httptest.ResponseRecorder {
 	req, _ := http.NewRequest("GET", "/metrics", nil)
 	response := httptest.NewRecorder()
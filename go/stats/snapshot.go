@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"expvar"
+	"sort"
+	"time"
+)
+
+// Snapshot is the values of the counters and gauges of the stats at a point
+// in time. The values of the multi-dimensional stats are keyed by the name of
+// the stat and their label values, joined with '.', e.g. "Queries.Select",
+// and durations are in seconds.
+type Snapshot struct {
+	Name   string
+	Time   time.Time
+	Values map[string]float64
+}
+
+// TakeSnapshot returns a snapshot of the current values of all the published
+// counters, gauges and timings counts. Strings, rates and histograms are left
+// out.
+func TakeSnapshot(name string) *Snapshot {
+	s := &Snapshot{
+		Name:   name,
+		Time:   time.Now(),
+		Values: make(map[string]float64),
+	}
+	expvar.Do(func(kv expvar.KeyValue) {
+		s.add(kv.Key, kv.Value)
+	})
+	return s
+}
+
+func (s *Snapshot) add(name string, v expvar.Var) {
+	switch st := v.(type) {
+	case *Counter:
+		s.Values[name] = float64(st.Get())
+	case *CounterFunc:
+		s.Values[name] = float64(st.F())
+	case *Gauge:
+		s.Values[name] = float64(st.Get())
+	case *GaugeFloat64:
+		s.Values[name] = st.Get()
+	case *GaugeFunc:
+		s.Values[name] = float64(st.F())
+	case FloatFunc:
+		s.Values[name] = st()
+	case *CounterDuration:
+		s.Values[name] = st.Get().Seconds()
+	case *CounterDurationFunc:
+		s.Values[name] = st.F().Seconds()
+	case *GaugeDuration:
+		s.Values[name] = st.Get().Seconds()
+	case *GaugeDurationFunc:
+		s.Values[name] = st.F().Seconds()
+	case *CountersWithSingleLabel:
+		s.addCounts(name, st.Counts())
+	case *CountersWithMultiLabels:
+		s.addCounts(name, st.Counts())
+	case *CountersFuncWithMultiLabels:
+		s.addCounts(name, st.Counts())
+	case *GaugesWithSingleLabel:
+		s.addCounts(name, st.Counts())
+	case *GaugesWithMultiLabels:
+		s.addCounts(name, st.Counts())
+	case *GaugesFuncWithMultiLabels:
+		s.addCounts(name, st.Counts())
+	case *Timings:
+		s.addCounts(name, st.Counts())
+	case *MultiTimings:
+		s.addCounts(name, st.Counts())
+	}
+}
+
+func (s *Snapshot) addCounts(name string, counts map[string]int64) {
+	for labelValues, count := range counts {
+		s.Values[name+"."+labelValues] = float64(count)
+	}
+}
+
+// SnapshotDiff is the changes of the values of the stats between two
+// snapshots. Deltas only holds the values that changed, the values missing
+// from the first snapshot counting as zero. Missing holds the values that
+// are not in the second snapshot anymore.
+type SnapshotDiff struct {
+	Snapshot string
+	From     time.Time
+	To       time.Time
+	Deltas   map[string]float64
+	Missing  []string `json:",omitempty"`
+}
+
+// Diff returns the changes of the values between the snapshot and a later one.
+func (s *Snapshot) Diff(later *Snapshot) *SnapshotDiff {
+	d := &SnapshotDiff{
+		Snapshot: s.Name,
+		From:     s.Time,
+		To:       later.Time,
+		Deltas:   make(map[string]float64),
+	}
+	for name, value := range later.Values {
+		if delta := value - s.Values[name]; delta != 0 {
+			d.Deltas[name] = delta
+		}
+	}
+	for name := range s.Values {
+		if _, ok := later.Values[name]; !ok {
+			d.Missing = append(d.Missing, name)
+		}
+	}
+	sort.Strings(d.Missing)
+	return d
+}
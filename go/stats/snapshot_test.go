@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	clearStats()
+	c := NewCounter("SnapshotCounter", "help")
+	g := NewGaugeFloat64("SnapshotGauge", "help")
+	cd := NewCounterDuration("SnapshotDuration", "help")
+	cl := NewCountersWithMultiLabels("SnapshotCounters", "help", []string{"a", "b"})
+	tm := NewTimings("SnapshotTimings", "help", "category")
+	NewString("SnapshotString").Set("ignored")
+	c.Add(1)
+	g.Set(2.5)
+	cl.Add([]string{"x", "y"}, 3)
+	cl.Add([]string{"x", "z"}, 4)
+
+	before := TakeSnapshot("incident")
+	assert.Equal(t, "incident", before.Name)
+	assert.Equal(t, map[string]float64{
+		"SnapshotCounter":      1,
+		"SnapshotGauge":        2.5,
+		"SnapshotDuration":     0,
+		"SnapshotCounters.x.y": 3,
+		"SnapshotCounters.x.z": 4,
+		"SnapshotTimings.All":  0,
+	}, snapshotValues(before.Values))
+
+	c.Add(2)
+	g.Set(1)
+	cd.Add(1500 * time.Millisecond)
+	cl.reset()
+	cl.Add([]string{"x", "y"}, 4)
+	tm.Add("select", time.Millisecond)
+
+	diff := before.Diff(TakeSnapshot("now"))
+	assert.Equal(t, "incident", diff.Snapshot)
+	assert.Equal(t, before.Time, diff.From)
+	assert.Equal(t, map[string]float64{
+		"SnapshotCounter":        2,
+		"SnapshotGauge":          -1.5,
+		"SnapshotDuration":       1.5,
+		"SnapshotCounters.x.y":   1,
+		"SnapshotTimings.All":    1,
+		"SnapshotTimings.select": 1,
+	}, snapshotValues(diff.Deltas))
+	assert.Equal(t, []string{"SnapshotCounters.x.z"}, diff.Missing)
+}
+
+// snapshotValues returns the values of the stats of TestSnapshotDiff, leaving
+// out the ones published by the other tests.
+func snapshotValues(values map[string]float64) map[string]float64 {
+	filtered := make(map[string]float64)
+	for name, value := range values {
+		if strings.HasPrefix(name, "Snapshot") {
+			filtered[name] = value
+		}
+	}
+	return filtered
+}
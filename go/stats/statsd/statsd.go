@@ -5,6 +5,7 @@ import (
 	"expvar"
 	"fmt"
 	"hash/crc32"
+	"sort"
 	"strings"
 	"sync"
 
@@ -17,13 +18,17 @@ import (
 )
 
 var (
-	statsdAddress    string
-	statsdSampleRate = 1.0
+	statsdAddress       string
+	statsdSampleRate    = 1.0
+	statsdTagFormat     = "dogstatsd"
+	statsdTagsAllowlist []string
 )
 
 func registerFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&statsdAddress, "statsd_address", statsdAddress, "Address for statsd client")
 	fs.Float64Var(&statsdSampleRate, "statsd_sample_rate", statsdSampleRate, "Sample rate for statsd metrics")
+	fs.StringVar(&statsdTagFormat, "statsd_tag_format", statsdTagFormat, "Format of the tags of the statsd metrics: dogstatsd, influxdb, or none to append the label values to the metric names instead")
+	fs.StringSliceVar(&statsdTagsAllowlist, "statsd_tags_allowlist", statsdTagsAllowlist, "Comma-separated list of the labels exported as tags of the statsd metrics, the values of the metrics being summed over the other labels. Exports all the labels if empty")
 }
 
 func init() {
@@ -31,11 +36,43 @@ func init() {
 	servenv.OnParseFor("vttablet", registerFlags)
 }
 
+// tagFormat is the format of the tags of the metrics.
+type tagFormat int
+
+const (
+	// tagFormatDogStatsD sends the tags the DogStatsD way: "name:1|c|#tag:value".
+	tagFormatDogStatsD tagFormat = iota
+	// tagFormatInfluxDB sends the tags the InfluxDB way: "name,tag=value:1|c".
+	tagFormatInfluxDB
+	// tagFormatNone appends the tag values to the metric names: "name.value:1|c".
+	tagFormatNone
+)
+
+func parseTagFormat(format string) (tagFormat, error) {
+	switch format {
+	case "dogstatsd":
+		return tagFormatDogStatsD, nil
+	case "influxdb":
+		return tagFormatInfluxDB, nil
+	case "none":
+		return tagFormatNone, nil
+	}
+	return 0, fmt.Errorf("invalid --statsd_tag_format %q, must be dogstatsd, influxdb or none", format)
+}
+
 // StatsBackend implements PullBackend using statsd
 type StatsBackend struct {
 	namespace    string
 	statsdClient *statsd.Client
 	sampleRate   float64
+
+	tagFormat tagFormat
+	// commonTags are sent with every metric. With the DogStatsD format,
+	// they are the tags of the client instead.
+	commonTags []string
+	// tagsAllowlist is the set of the labels exported as tags, or nil to
+	// export all of them.
+	tagsAllowlist map[string]bool
 }
 
 var (
@@ -81,104 +118,227 @@ func InitWithoutServenv(namespace string) {
 		log.Info("statsdAddress is empty")
 		return
 	}
+	format, err := parseTagFormat(statsdTagFormat)
+	if err != nil {
+		log.Errorf("Failed to create statsd client %v", err)
+		return
+	}
 	statsdC, err := statsd.NewBuffered(statsdAddress, 100)
 	if err != nil {
 		log.Errorf("Failed to create statsd client %v", err)
 		return
 	}
 	statsdC.Namespace = namespace + "."
+	sb.tagFormat = format
 	if tags := stats.ParseCommonTags(stats.CommonTags); len(tags) > 0 {
-		statsdC.Tags = makeCommonTags(tags)
+		if format == tagFormatDogStatsD {
+			statsdC.Tags = makeCommonTags(tags)
+		} else {
+			sb.commonTags = makeCommonTags(tags)
+			sort.Strings(sb.commonTags)
+		}
+	}
+	if len(statsdTagsAllowlist) > 0 {
+		sb.tagsAllowlist = make(map[string]bool, len(statsdTagsAllowlist))
+		for _, label := range statsdTagsAllowlist {
+			sb.tagsAllowlist[label] = true
+		}
 	}
 	sb.namespace = namespace
 	sb.statsdClient = statsdC
 	sb.sampleRate = statsdSampleRate
 	stats.RegisterPushBackend("statsd", sb)
 	stats.RegisterTimerHook(func(statsName, name string, value int64, timings *stats.Timings) {
-		tags := makeLabels(strings.Split(timings.Label(), "."), name)
-		if err := statsdC.TimeInMilliseconds(statsName, float64(value), tags, sb.sampleRate); err != nil {
+		labelNames := strings.Split(timings.Label(), ".")
+		tags := sb.filterTags(labelNames, makeLabels(labelNames, name))
+		if err := sb.timeInMilliseconds(statsName, float64(value), tags); err != nil {
 			log.Errorf("Fail to TimeInMilliseconds %v: %v", statsName, err)
 		}
 	})
 	stats.RegisterHistogramHook(func(statsName string, val int64) {
-		if err := statsdC.Histogram(statsName, float64(val), []string{}, sb.sampleRate); err != nil {
+		if err := sb.histogram(statsName, float64(val), nil); err != nil {
 			log.Errorf("Fail to Histogram for %v: %v", statsName, err)
 		}
 	})
 }
 
+// filterTags drops the tags of the labels that are not in the allowlist.
+// The tags must be in the order of the labels.
+func (sb StatsBackend) filterTags(labelNames []string, tags []string) []string {
+	if sb.tagsAllowlist == nil {
+		return tags
+	}
+	filtered := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if sb.tagsAllowlist[labelNames[i]] {
+			filtered = append(filtered, tag)
+		}
+	}
+	return filtered
+}
+
+// taggedValue is the value of a metric for a set of tags.
+type taggedValue struct {
+	tags  []string
+	value int64
+}
+
+// taggedCounts returns the values of a multi-dimensional metric by tags,
+// summing the values of the dimensions whose labels are not in the allowlist.
+func (sb StatsBackend) taggedCounts(labelNames []string, counts map[string]int64) []taggedValue {
+	return sumByTags(counts, func(labelVals string) []string {
+		return sb.filterTags(labelNames, makeLabels(labelNames, labelVals))
+	})
+}
+
+// taggedCountsWithSingleLabel is like taggedCounts for a metric with a single
+// label, whose values may contain dots.
+func (sb StatsBackend) taggedCountsWithSingleLabel(labelName string, counts map[string]int64) []taggedValue {
+	return sumByTags(counts, func(labelVal string) []string {
+		return sb.filterTags([]string{labelName}, makeLabel(labelName, labelVal))
+	})
+}
+
+func sumByTags(counts map[string]int64, tagsOf func(labelVals string) []string) []taggedValue {
+	values := make([]taggedValue, 0, len(counts))
+	indexes := make(map[string]int, len(counts))
+	for labelVals, val := range counts {
+		tags := tagsOf(labelVals)
+		key := strings.Join(tags, ",")
+		if i, ok := indexes[key]; ok {
+			values[i].value += val
+			continue
+		}
+		indexes[key] = len(values)
+		values = append(values, taggedValue{tags: tags, value: val})
+	}
+	return values
+}
+
+var influxDBEscaper = strings.NewReplacer(",", "\\,", "=", "\\=", " ", "\\ ")
+
+// formatMetric returns the name and the tags to send a metric with, in the
+// format of --statsd_tag_format.
+func (sb StatsBackend) formatMetric(name string, tags []string) (string, []string) {
+	switch sb.tagFormat {
+	case tagFormatInfluxDB:
+		var b strings.Builder
+		b.WriteString(name)
+		for _, list := range [][]string{sb.commonTags, tags} {
+			for _, tag := range list {
+				k, v, _ := strings.Cut(tag, ":")
+				b.WriteString(",")
+				b.WriteString(influxDBEscaper.Replace(k))
+				b.WriteString("=")
+				b.WriteString(influxDBEscaper.Replace(v))
+			}
+		}
+		return b.String(), nil
+	case tagFormatNone:
+		var b strings.Builder
+		b.WriteString(name)
+		for _, tag := range tags {
+			_, v, _ := strings.Cut(tag, ":")
+			b.WriteString(".")
+			b.WriteString(v)
+		}
+		return b.String(), nil
+	}
+	return name, tags
+}
+
+func (sb StatsBackend) count(name string, value int64, tags []string) error {
+	name, tags = sb.formatMetric(name, tags)
+	return sb.statsdClient.Count(name, value, tags, sb.sampleRate)
+}
+
+func (sb StatsBackend) gauge(name string, value float64, tags []string) error {
+	name, tags = sb.formatMetric(name, tags)
+	return sb.statsdClient.Gauge(name, value, tags, sb.sampleRate)
+}
+
+func (sb StatsBackend) timeInMilliseconds(name string, value float64, tags []string) error {
+	name, tags = sb.formatMetric(name, tags)
+	return sb.statsdClient.TimeInMilliseconds(name, value, tags, sb.sampleRate)
+}
+
+func (sb StatsBackend) histogram(name string, value float64, tags []string) error {
+	name, tags = sb.formatMetric(name, tags)
+	return sb.statsdClient.Histogram(name, value, tags, sb.sampleRate)
+}
+
 func (sb StatsBackend) addExpVar(kv expvar.KeyValue) {
 	k := kv.Key
 	switch v := kv.Value.(type) {
 	case *stats.Counter:
-		if err := sb.statsdClient.Count(k, v.Get(), nil, sb.sampleRate); err != nil {
+		if err := sb.count(k, v.Get(), nil); err != nil {
 			log.Errorf("Failed to add Counter %v for key %v", v, k)
 		}
 	case *stats.Gauge:
-		if err := sb.statsdClient.Gauge(k, float64(v.Get()), nil, sb.sampleRate); err != nil {
+		if err := sb.gauge(k, float64(v.Get()), nil); err != nil {
 			log.Errorf("Failed to add Gauge %v for key %v", v, k)
 		}
 	case *stats.GaugeFloat64:
-		if err := sb.statsdClient.Gauge(k, v.Get(), nil, sb.sampleRate); err != nil {
+		if err := sb.gauge(k, v.Get(), nil); err != nil {
 			log.Errorf("Failed to add GaugeFloat64 %v for key %v", v, k)
 		}
 	case *stats.GaugeFunc:
-		if err := sb.statsdClient.Gauge(k, float64(v.F()), nil, sb.sampleRate); err != nil {
+		if err := sb.gauge(k, float64(v.F()), nil); err != nil {
 			log.Errorf("Failed to add GaugeFunc %v for key %v", v, k)
 		}
 	case *stats.CounterFunc:
-		if err := sb.statsdClient.Gauge(k, float64(v.F()), nil, sb.sampleRate); err != nil {
+		if err := sb.gauge(k, float64(v.F()), nil); err != nil {
 			log.Errorf("Failed to add CounterFunc %v for key %v", v, k)
 		}
 	case *stats.CounterDuration:
-		if err := sb.statsdClient.TimeInMilliseconds(k, float64(v.Get().Milliseconds()), nil, sb.sampleRate); err != nil {
+		if err := sb.timeInMilliseconds(k, float64(v.Get().Milliseconds()), nil); err != nil {
 			log.Errorf("Failed to add CounterDuration %v for key %v", v, k)
 		}
 	case *stats.CounterDurationFunc:
-		if err := sb.statsdClient.TimeInMilliseconds(k, float64(v.F().Milliseconds()), nil, sb.sampleRate); err != nil {
+		if err := sb.timeInMilliseconds(k, float64(v.F().Milliseconds()), nil); err != nil {
 			log.Errorf("Failed to add CounterDuration %v for key %v", v, k)
 		}
 	case *stats.GaugeDuration:
-		if err := sb.statsdClient.TimeInMilliseconds(k, float64(v.Get().Milliseconds()), nil, sb.sampleRate); err != nil {
+		if err := sb.timeInMilliseconds(k, float64(v.Get().Milliseconds()), nil); err != nil {
 			log.Errorf("Failed to add GaugeDuration %v for key %v", v, k)
 		}
 	case *stats.GaugeDurationFunc:
-		if err := sb.statsdClient.TimeInMilliseconds(k, float64(v.F().Milliseconds()), nil, sb.sampleRate); err != nil {
+		if err := sb.timeInMilliseconds(k, float64(v.F().Milliseconds()), nil); err != nil {
 			log.Errorf("Failed to add GaugeDuration %v for key %v", v, k)
 		}
 	case *stats.CountersWithSingleLabel:
-		for labelVal, val := range v.Counts() {
-			if err := sb.statsdClient.Count(k, val, makeLabel(v.Label(), labelVal), sb.sampleRate); err != nil {
+		for _, tv := range sb.taggedCountsWithSingleLabel(v.Label(), v.Counts()) {
+			if err := sb.count(k, tv.value, tv.tags); err != nil {
 				log.Errorf("Failed to add CountersWithSingleLabel %v for key %v", v, k)
 			}
 		}
 	case *stats.CountersWithMultiLabels:
-		for labelVals, val := range v.Counts() {
-			if err := sb.statsdClient.Count(k, val, makeLabels(v.Labels(), labelVals), sb.sampleRate); err != nil {
+		for _, tv := range sb.taggedCounts(v.Labels(), v.Counts()) {
+			if err := sb.count(k, tv.value, tv.tags); err != nil {
 				log.Errorf("Failed to add CountersFuncWithMultiLabels %v for key %v", v, k)
 			}
 		}
 	case *stats.CountersFuncWithMultiLabels:
-		for labelVals, val := range v.Counts() {
-			if err := sb.statsdClient.Count(k, val, makeLabels(v.Labels(), labelVals), sb.sampleRate); err != nil {
+		for _, tv := range sb.taggedCounts(v.Labels(), v.Counts()) {
+			if err := sb.count(k, tv.value, tv.tags); err != nil {
 				log.Errorf("Failed to add CountersFuncWithMultiLabels %v for key %v", v, k)
 			}
 		}
 	case *stats.GaugesWithMultiLabels:
-		for labelVals, val := range v.Counts() {
-			if err := sb.statsdClient.Gauge(k, float64(val), makeLabels(v.Labels(), labelVals), sb.sampleRate); err != nil {
+		for _, tv := range sb.taggedCounts(v.Labels(), v.Counts()) {
+			if err := sb.gauge(k, float64(tv.value), tv.tags); err != nil {
 				log.Errorf("Failed to add GaugesWithMultiLabels %v for key %v", v, k)
 			}
 		}
 	case *stats.GaugesFuncWithMultiLabels:
-		for labelVals, val := range v.Counts() {
-			if err := sb.statsdClient.Gauge(k, float64(val), makeLabels(v.Labels(), labelVals), sb.sampleRate); err != nil {
+		for _, tv := range sb.taggedCounts(v.Labels(), v.Counts()) {
+			if err := sb.gauge(k, float64(tv.value), tv.tags); err != nil {
 				log.Errorf("Failed to add GaugesFuncWithMultiLabels %v for key %v", v, k)
 			}
 		}
 	case *stats.GaugesWithSingleLabel:
-		for labelVal, val := range v.Counts() {
-			if err := sb.statsdClient.Gauge(k, float64(val), makeLabel(v.Label(), labelVal), sb.sampleRate); err != nil {
+		for _, tv := range sb.taggedCountsWithSingleLabel(v.Label(), v.Counts()) {
+			if err := sb.gauge(k, float64(tv.value), tv.tags); err != nil {
 				log.Errorf("Failed to add GaugesWithSingleLabel %v for key %v", v, k)
 			}
 		}
@@ -196,7 +356,7 @@ func (sb StatsBackend) addExpVar(kv expvar.KeyValue) {
 				memstatsVal, ok := v.(float64)
 				if ok {
 					memstatsKey := "memstats." + k
-					if err := sb.statsdClient.Gauge(memstatsKey, memstatsVal, []string{}, sb.sampleRate); err != nil {
+					if err := sb.gauge(memstatsKey, memstatsVal, nil); err != nil {
 						log.Errorf("Failed to export %v %v", k, v)
 					}
 				}
@@ -206,13 +366,13 @@ func (sb StatsBackend) addExpVar(kv expvar.KeyValue) {
 		if k == "BuildGitRev" {
 			buildGitRecOnce.Do(func() {
 				checksum := crc32.ChecksumIEEE([]byte(v.Get()))
-				if err := sb.statsdClient.Gauge(k, float64(checksum), []string{}, sb.sampleRate); err != nil {
+				if err := sb.gauge(k, float64(checksum), nil); err != nil {
 					log.Errorf("Failed to export %v %v", k, v)
 				}
 			})
 		}
 	case *stats.Rates, *stats.RatesFunc, *stats.StringFunc, *stats.StringMapFunc,
-		stats.StringFunc, stats.StringMapFunc:
+		stats.StringFunc, stats.StringMapFunc, *stats.HistogramsFuncWithMultiLabels:
 		// Silently ignore metrics that does not make sense to be exported to statsd
 	default:
 		log.Warningf("Silently ignore metrics with key %v [%T]", k, kv.Value)
@@ -487,3 +487,74 @@ func TestMakeCommonTags(t *testing.T) {
 	res2 := makeCommonTags(map[string]string{"a": "b", "c": "d"})
 	assert.ElementsMatch(t, expected2, res2)
 }
+
+func readPacket(t *testing.T, server *net.UDPConn) string {
+	bytes := make([]byte, 4096)
+	n, err := server.Read(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes[:n])
+}
+
+func TestStatsdInfluxDBTagFormat(t *testing.T) {
+	sb, server := getBackend(t)
+	defer server.Close()
+	sb.tagFormat = tagFormatInfluxDB
+	sb.commonTags = []string{"cell:zone1"}
+	name := "counter_with_influxdb_tags"
+	s := stats.NewCountersWithMultiLabels(name, "help", []string{"label1", "label2"})
+	s.Add([]string{"foo", "b r"}, 1)
+	err := sb.PushOne(name, s)
+	assert.NoError(t, err)
+	assert.Equal(t, "test.counter_with_influxdb_tags,cell=zone1,label1=foo,label2=b\\ r:1|c\n", readPacket(t, server))
+}
+
+func TestStatsdNoTagFormat(t *testing.T) {
+	sb, server := getBackend(t)
+	defer server.Close()
+	sb.tagFormat = tagFormatNone
+	sb.commonTags = []string{"cell:zone1"}
+	name := "gauge_without_tags"
+	s := stats.NewGaugesWithMultiLabels(name, "help", []string{"label1", "label2"})
+	s.Set([]string{"foo", "bar"}, 3)
+	err := sb.PushOne(name, s)
+	assert.NoError(t, err)
+	assert.Equal(t, "test.gauge_without_tags.foo.bar:3|g\n", readPacket(t, server))
+}
+
+func TestStatsdTagsAllowlist(t *testing.T) {
+	sb, server := getBackend(t)
+	defer server.Close()
+	sb.tagsAllowlist = map[string]bool{"label1": true}
+
+	name := "gauges_with_allowlisted_labels"
+	s := stats.NewGaugesWithMultiLabels(name, "help", []string{"label1", "label2"})
+	s.Set([]string{"foo", "bar"}, 1)
+	s.Set([]string{"foo", "baz"}, 2)
+	err := sb.PushOne(name, s)
+	assert.NoError(t, err)
+	assert.Equal(t, "test.gauges_with_allowlisted_labels:3|g|#label1:foo\n", readPacket(t, server))
+
+	name = "counters_without_allowlisted_label"
+	c := stats.NewCountersWithSingleLabel(name, "help", "label2", "a.b", "c")
+	c.Add("a.b", 1)
+	c.Add("c", 2)
+	err = sb.PushOne(name, c)
+	assert.NoError(t, err)
+	assert.Equal(t, "test.counters_without_allowlisted_label:3|c\n", readPacket(t, server))
+}
+
+func TestParseTagFormat(t *testing.T) {
+	for format, want := range map[string]tagFormat{
+		"dogstatsd": tagFormatDogStatsD,
+		"influxdb":  tagFormatInfluxDB,
+		"none":      tagFormatNone,
+	} {
+		got, err := parseTagFormat(format)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := parseTagFormat("graphite")
+	assert.EqualError(t, err, `invalid --statsd_tag_format "graphite", must be dogstatsd, influxdb or none`)
+}
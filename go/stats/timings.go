@@ -18,7 +18,6 @@ package stats
 
 import (
 	"encoding/json"
-	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -37,6 +36,12 @@ type Timings struct {
 	help          string
 	label         string
 	labelCombined bool
+
+	// cutoffs and cutoffLabels are the buckets of the histograms, resolved
+	// from the flags the first time they are needed.
+	bucketsOnce  sync.Once
+	cutoffs      []int64
+	cutoffLabels []string
 }
 
 // NewTimings creates a new Timings object, and publishes it if name is set.
@@ -52,7 +57,7 @@ func NewTimings(name, help, label string, categories ...string) *Timings {
 		labelCombined: IsDimensionCombined(label),
 	}
 	for _, cat := range categories {
-		t.histograms[cat] = NewGenericHistogram("", "", bucketCutoffs, bucketLabels, "Count", "Time")
+		t.histograms[cat] = t.newHistogram()
 	}
 	if name != "" {
 		publish(name, t)
@@ -72,6 +77,12 @@ func (t *Timings) Reset() {
 
 // Add will add a new value to the named histogram.
 func (t *Timings) Add(name string, elapsed time.Duration) {
+	t.AddWithExemplar(name, elapsed, "")
+}
+
+// AddWithExemplar will add a new value to the named histogram and, if traceID
+// is set, keep it as the exemplar of its bucket.
+func (t *Timings) AddWithExemplar(name string, elapsed time.Duration, traceID string) {
 	if t.labelCombined {
 		name = StatsAllStr
 	}
@@ -85,7 +96,7 @@ func (t *Timings) Add(name string, elapsed time.Duration) {
 		t.mu.Lock()
 		hist, ok = t.histograms[name]
 		if !ok {
-			hist = NewGenericHistogram("", "", bucketCutoffs, bucketLabels, "Count", "Time")
+			hist = t.newHistogram()
 			t.histograms[name] = hist
 		}
 		t.mu.Unlock()
@@ -95,7 +106,7 @@ func (t *Timings) Add(name string, elapsed time.Duration) {
 	}
 
 	elapsedNs := int64(elapsed)
-	hist.Add(elapsedNs)
+	hist.AddWithExemplar(elapsedNs, traceID)
 	t.totalCount.Add(1)
 	t.totalTime.Add(elapsedNs)
 }
@@ -109,6 +120,12 @@ func (t *Timings) Record(name string, startTime time.Time) {
 	t.Add(name, time.Since(startTime))
 }
 
+// RecordWithExemplar is like Record, keeping the value as the exemplar of its
+// bucket if traceID is set.
+func (t *Timings) RecordWithExemplar(name string, startTime time.Time, traceID string) {
+	t.AddWithExemplar(name, time.Since(startTime), traceID)
+}
+
 // String is for expvar.
 func (t *Timings) String() string {
 	t.mu.RLock()
@@ -168,7 +185,21 @@ func (t *Timings) Counts() map[string]int64 {
 // Cutoffs returns the cutoffs used in the component histograms.
 // Do not change the returned slice.
 func (t *Timings) Cutoffs() []int64 {
-	return bucketCutoffs
+	t.resolveBuckets()
+	return t.cutoffs
+}
+
+// resolveBuckets sets the buckets of the histograms from --timings-buckets,
+// or from --timings-buckets-override for the name of the timings.
+func (t *Timings) resolveBuckets() {
+	t.bucketsOnce.Do(func() {
+		t.cutoffs, t.cutoffLabels = timingsCutoffs(t.name)
+	})
+}
+
+func (t *Timings) newHistogram() *Histogram {
+	t.resolveBuckets()
+	return NewGenericHistogram("", "", t.cutoffs, t.cutoffLabels, "Count", "Time")
 }
 
 // Help returns the help string.
@@ -181,18 +212,6 @@ func (t *Timings) Label() string {
 	return t.label
 }
 
-var bucketCutoffs = []int64{5e5, 1e6, 5e6, 1e7, 5e7, 1e8, 5e8, 1e9, 5e9, 1e10}
-
-var bucketLabels []string
-
-func init() {
-	bucketLabels = make([]string, len(bucketCutoffs)+1)
-	for i, v := range bucketCutoffs {
-		bucketLabels[i] = fmt.Sprintf("%d", v)
-	}
-	bucketLabels[len(bucketLabels)-1] = "inf"
-}
-
 // MultiTimings is meant to tracks timing data by categories as well
 // as histograms. The names of the categories are compound names made
 // with joining multiple strings with '.'.
@@ -238,6 +257,15 @@ func (mt *MultiTimings) Add(names []string, elapsed time.Duration) {
 	mt.Timings.Add(safeJoinLabels(names, mt.combinedLabels), elapsed)
 }
 
+// AddWithExemplar will add a new value to the named histogram and, if traceID
+// is set, keep it as the exemplar of its bucket.
+func (mt *MultiTimings) AddWithExemplar(names []string, elapsed time.Duration, traceID string) {
+	if len(names) != len(mt.labels) {
+		panic("MultiTimings: wrong number of values in AddWithExemplar")
+	}
+	mt.Timings.AddWithExemplar(safeJoinLabels(names, mt.combinedLabels), elapsed, traceID)
+}
+
 // Record is a convenience function that records completion
 // timing data based on the provided start time of an event.
 func (mt *MultiTimings) Record(names []string, startTime time.Time) {
@@ -247,8 +275,17 @@ func (mt *MultiTimings) Record(names []string, startTime time.Time) {
 	mt.Timings.Record(safeJoinLabels(names, mt.combinedLabels), startTime)
 }
 
+// RecordWithExemplar is like Record, keeping the value as the exemplar of its
+// bucket if traceID is set.
+func (mt *MultiTimings) RecordWithExemplar(names []string, startTime time.Time, traceID string) {
+	if len(names) != len(mt.labels) {
+		panic("MultiTimings: wrong number of values in RecordWithExemplar")
+	}
+	mt.Timings.RecordWithExemplar(safeJoinLabels(names, mt.combinedLabels), startTime, traceID)
+}
+
 // Cutoffs returns the cutoffs used in the component histograms.
 // Do not change the returned slice.
 func (mt *MultiTimings) Cutoffs() []int64 {
-	return bucketCutoffs
+	return mt.Timings.Cutoffs()
 }
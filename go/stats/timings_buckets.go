@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// timingsBuckets are the upper bounds of the buckets of the histograms of
+	// the timings.
+	timingsBuckets = durationBuckets{
+		500 * time.Microsecond,
+		time.Millisecond,
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		5 * time.Second,
+		10 * time.Second,
+	}
+	// timingsBucketsOverrides are the buckets of specific timings, by name.
+	timingsBucketsOverrides = bucketsOverrides{}
+)
+
+// timingsCutoffs returns the cutoffs, in nanoseconds, and the labels of the
+// buckets of the histograms of the timings of the name.
+func timingsCutoffs(name string) ([]int64, []string) {
+	varsMu.Lock()
+	buckets, ok := timingsBucketsOverrides[name]
+	if !ok {
+		buckets = timingsBuckets
+	}
+	varsMu.Unlock()
+
+	cutoffs := make([]int64, len(buckets))
+	labels := make([]string, len(buckets)+1)
+	for i, bucket := range buckets {
+		cutoffs[i] = int64(bucket)
+		labels[i] = fmt.Sprintf("%d", cutoffs[i])
+	}
+	labels[len(labels)-1] = "inf"
+	return cutoffs, labels
+}
+
+// durationBuckets is a flag with the upper bounds of histogram buckets, as a
+// comma-separated list of increasing durations.
+type durationBuckets []time.Duration
+
+// String implements pflag.Value.
+func (b *durationBuckets) String() string {
+	buckets := make([]string, len(*b))
+	for i, bucket := range *b {
+		buckets[i] = bucket.String()
+	}
+	return strings.Join(buckets, ",")
+}
+
+// Set implements pflag.Value.
+func (b *durationBuckets) Set(value string) error {
+	var buckets durationBuckets
+	for _, s := range strings.Split(value, ",") {
+		bucket, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		if bucket <= 0 {
+			return fmt.Errorf("bucket %v is not positive", bucket)
+		}
+		if len(buckets) > 0 && bucket <= buckets[len(buckets)-1] {
+			return fmt.Errorf("buckets are not increasing: %v after %v", bucket, buckets[len(buckets)-1])
+		}
+		buckets = append(buckets, bucket)
+	}
+	varsMu.Lock()
+	defer varsMu.Unlock()
+	*b = buckets
+	return nil
+}
+
+// Type implements pflag.Value.
+func (b *durationBuckets) Type() string {
+	return "durations"
+}
+
+// bucketsOverrides is a repeatable flag overriding the histogram buckets of
+// the timings of a name, as name=buckets.
+type bucketsOverrides map[string]durationBuckets
+
+// String implements pflag.Value.
+func (o *bucketsOverrides) String() string {
+	overrides := make([]string, 0, len(*o))
+	for name, buckets := range *o {
+		overrides = append(overrides, name+"="+buckets.String())
+	}
+	sort.Strings(overrides)
+	return "[" + strings.Join(overrides, " ") + "]"
+}
+
+// Set implements pflag.Value.
+func (o *bucketsOverrides) Set(value string) error {
+	name, value, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("invalid buckets override %q: expected name=buckets", value)
+	}
+	var buckets durationBuckets
+	if err := buckets.Set(value); err != nil {
+		return fmt.Errorf("invalid buckets of %s: %v", name, err)
+	}
+	varsMu.Lock()
+	defer varsMu.Unlock()
+	(*o)[name] = buckets
+	return nil
+}
+
+// Type implements pflag.Value.
+func (o *bucketsOverrides) Type() string {
+	return "name=durations"
+}
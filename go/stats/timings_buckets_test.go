@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationBuckets(t *testing.T) {
+	var buckets durationBuckets
+	require.NoError(t, buckets.Set("1ms, 2.5ms,1s"))
+	assert.Equal(t, durationBuckets{time.Millisecond, 2500 * time.Microsecond, time.Second}, buckets)
+	assert.Equal(t, "1ms,2.5ms,1s", buckets.String())
+
+	assert.ErrorContains(t, buckets.Set("1ms,1ms"), "not increasing")
+	assert.ErrorContains(t, buckets.Set("0s,1ms"), "not positive")
+	assert.Error(t, buckets.Set("1 ms"))
+	assert.Equal(t, durationBuckets{time.Millisecond, 2500 * time.Microsecond, time.Second}, buckets, "invalid buckets are not set")
+}
+
+func TestBucketsOverrides(t *testing.T) {
+	overrides := bucketsOverrides{}
+	require.NoError(t, overrides.Set("Queries=1ms,2ms"))
+	require.NoError(t, overrides.Set("Transactions=1s"))
+	assert.Equal(t, "[Queries=1ms,2ms Transactions=1s]", overrides.String())
+
+	assert.ErrorContains(t, overrides.Set("1ms,2ms"), "expected name=buckets")
+	assert.ErrorContains(t, overrides.Set("Queries=2ms,1ms"), "invalid buckets of Queries")
+}
+
+func TestTimingsBuckets(t *testing.T) {
+	clearStats()
+	defer func(buckets durationBuckets, overrides bucketsOverrides) {
+		timingsBuckets, timingsBucketsOverrides = buckets, overrides
+	}(timingsBuckets, timingsBucketsOverrides)
+
+	// The buckets are resolved when the timings are first used, after the
+	// flags are parsed.
+	timings := NewTimings("TimingsBuckets", "help", "label")
+	multiTimings := NewMultiTimings("MultiTimingsBuckets", "help", []string{"label"})
+	timingsBucketsOverrides = bucketsOverrides{}
+	require.NoError(t, timingsBuckets.Set("1ms,2ms,3ms"))
+	require.NoError(t, timingsBucketsOverrides.Set("MultiTimingsBuckets=10ms,20ms"))
+
+	timings.Add("a", 2*time.Millisecond)
+	assert.Equal(t, []int64{1e6, 2e6, 3e6}, timings.Cutoffs())
+	assert.Equal(t, []int64{0, 1, 0, 0}, timings.Histograms()["a"].Buckets())
+	assert.Equal(t, []string{"1000000", "2000000", "3000000", "inf"}, timings.Histograms()["a"].Labels())
+
+	multiTimings.Add([]string{"a"}, 15*time.Millisecond)
+	assert.Equal(t, []int64{10e6, 20e6}, multiTimings.Cutoffs())
+	assert.Equal(t, []int64{0, 1, 0}, multiTimings.Histograms()["a"].Buckets())
+}
@@ -37,6 +37,24 @@ func TestTimings(t *testing.T) {
 	}
 }
 
+func TestTimingsExemplars(t *testing.T) {
+	clearStats()
+	mt := NewMultiTimings("mapexemplars", "help", []string{"label1", "label2"})
+	mt.AddWithExemplar([]string{"a", "b"}, 2*time.Millisecond, "trace1")
+	mt.RecordWithExemplar([]string{"a", "b"}, time.Now().Add(-time.Minute), "trace2")
+	mt.Add([]string{"a", "c"}, 2*time.Millisecond)
+
+	hists := mt.Histograms()
+	assert.EqualValues(t, 2, hists["a.b"].Count())
+	exemplars := hists["a.b"].Exemplars()
+	if assert.Len(t, exemplars, 2) {
+		assert.Equal(t, "trace1", exemplars[0].TraceID)
+		assert.EqualValues(t, 2*time.Millisecond, exemplars[0].Value)
+		assert.Equal(t, "trace2", exemplars[1].TraceID)
+	}
+	assert.Empty(t, hists["a.c"].Exemplars())
+}
+
 func TestMultiTimings(t *testing.T) {
 	clearStats()
 	mtm := NewMultiTimings("maptimings1", "help", []string{"dim1", "dim2"})
@@ -332,13 +332,21 @@ func TestCallProcedure(t *testing.T) {
 
 	utils.AssertMatches(t, conn, "show warnings", `[[VARCHAR("Warning") UINT16(1235) VARCHAR("'CALL' not supported in sharded mode")]]`)
 
-	_, err = conn.ExecuteFetch(`CALL sp_select()`, 1000, true)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "Multi-Resultset not supported in stored procedure")
+	qr, more, err := conn.ExecuteFetchMulti(`CALL sp_select()`, 1000, true)
+	require.NoError(t, err)
+	require.True(t, more)
+	require.NotEmpty(t, qr.Fields)
+	qr, more, _, err = conn.ReadQueryResult(1000, true)
+	require.NoError(t, err)
+	require.False(t, more)
+	require.Empty(t, qr.Fields)
 
-	_, err = conn.ExecuteFetch(`CALL sp_all()`, 1000, true)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "Multi-Resultset not supported in stored procedure")
+	_, more, err = conn.ExecuteFetchMulti(`CALL sp_all()`, 1000, true)
+	require.NoError(t, err)
+	for more {
+		_, more, _, err = conn.ReadQueryResult(1000, true)
+		require.NoError(t, err)
+	}
 
 	qr = utils.Exec(t, conn, `CALL sp_delete()`)
 	require.GreaterOrEqual(t, 1, int(qr.RowsAffected))
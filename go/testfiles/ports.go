@@ -44,6 +44,10 @@ var (
 	// GoVtTopoConsultopoPort is used by the go/vt/topo/consultopo package.
 	// Takes four ports.
 	GoVtTopoConsultopoPort = GoVtTopoZk2topoPort + 3
+
+	// GoVtTopoPostgrestopoPort is used by the go/vt/topo/postgrestopo package.
+	// Takes one port.
+	GoVtTopoPostgrestopoPort = GoVtTopoConsultopoPort + 4
 )
 
 // Zookeeper server ID definitions. Unit tests may run at the
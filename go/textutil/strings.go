@@ -95,12 +95,16 @@ func ValueIsSimulatedNull(val any) bool {
 		return len(cval) == 1 && cval[0] == sqltypes.NULL.String()
 	case binlogdata.OnDDLAction:
 		return int32(cval) == int32(SimulatedNullInt)
+	case binlogdata.VReplicationThrottlerPriority:
+		return int32(cval) == int32(SimulatedNullInt)
 	case int:
 		return cval == SimulatedNullInt
 	case int32:
 		return int32(cval) == int32(SimulatedNullInt)
 	case int64:
 		return int64(cval) == int64(SimulatedNullInt)
+	case float64:
+		return cval == float64(SimulatedNullInt)
 	case []topodatapb.TabletType:
 		return len(cval) == 1 && cval[0] == topodatapb.TabletType(SimulatedNullInt)
 	default:
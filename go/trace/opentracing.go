@@ -44,6 +44,22 @@ func (js openTracingSpan) Annotate(key string, value any) {
 	js.otSpan.SetTag(key, value)
 }
 
+// TraceID returns the ID of the trace of the span, if the tracing plugin
+// knows how to extract it from the span context and the trace is sampled.
+func (js openTracingSpan) TraceID() (string, bool) {
+	sc := js.otSpan.Context()
+	for _, extract := range traceIDExtractors {
+		if traceID, ok := extract(sc); ok {
+			return traceID, true
+		}
+	}
+	return "", false
+}
+
+// traceIDExtractors should be added to by the tracing plugins during init()
+// to extract the trace IDs from the span contexts of their tracers.
+var traceIDExtractors []func(sc opentracing.SpanContext) (string, bool)
+
 var _ tracingService = (*openTracingService)(nil)
 
 type tracer interface {
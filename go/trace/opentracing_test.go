@@ -23,6 +23,7 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-client-go"
 )
 
 func TestExtractMapFromString(t *testing.T) {
@@ -47,3 +48,18 @@ func TestErrorConditions(t *testing.T) {
 	_, err = extractMapFromString("this is not base64") // malformed base64
 	assert.Error(t, err)
 }
+
+func TestJaegerTraceID(t *testing.T) {
+	tracer, closer := jaeger.NewTracer("test", jaeger.NewConstSampler(true), jaeger.NewNullReporter())
+	defer closer.Close()
+	span := openTracingSpan{otSpan: tracer.StartSpan("sampled")}
+	traceID, ok := span.TraceID()
+	assert.True(t, ok)
+	assert.Equal(t, span.otSpan.Context().(jaeger.SpanContext).TraceID().String(), traceID)
+
+	tracer, closer = jaeger.NewTracer("test", jaeger.NewConstSampler(false), jaeger.NewNullReporter())
+	defer closer.Close()
+	span = openTracingSpan{otSpan: tracer.StartSpan("not sampled")}
+	_, ok = span.TraceID()
+	assert.False(t, ok)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/spf13/pflag"
+	"github.com/uber/jaeger-client-go"
 	"github.com/uber/jaeger-client-go/config"
 
 	"vitess.io/vitess/go/viperutil"
@@ -128,6 +129,16 @@ func newJagerTracerFromEnv(serviceName string) (tracingService, io.Closer, error
 
 func init() {
 	tracingBackendFactories["opentracing-jaeger"] = newJagerTracerFromEnv
+	traceIDExtractors = append(traceIDExtractors, jaegerTraceID)
+}
+
+// jaegerTraceID returns the trace ID of a Jaeger span context, if it is sampled.
+func jaegerTraceID(sc opentracing.SpanContext) (string, bool) {
+	jsc, ok := sc.(jaeger.SpanContext)
+	if !ok || !jsc.IsSampled() {
+		return "", false
+	}
+	return jsc.TraceID().String(), true
 }
 
 var _ tracer = (*jaegerTracer)(nil)
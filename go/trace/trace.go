@@ -77,6 +77,26 @@ func FromContext(ctx context.Context) (Span, bool) {
 	return currentTracer.FromContext(ctx)
 }
 
+// TraceID returns the ID of the trace of the Span in the Context, or an empty
+// string if there is none, the tracing plugin does not expose it or the trace
+// is not sampled, i.e. cannot be looked up in the tracing backend.
+func TraceID(ctx context.Context) string {
+	span, ok := currentTracer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ts, ok := span.(tracedSpan); ok {
+		traceID, _ := ts.TraceID()
+		return traceID
+	}
+	return ""
+}
+
+// tracedSpan is implemented by the Spans that expose the ID of their trace.
+type tracedSpan interface {
+	TraceID() (string, bool)
+}
+
 // NewContext returns a context based on parent with a new Span value.
 func NewContext(parent context.Context, span Span) context.Context {
 	return currentTracer.NewContext(parent, span)
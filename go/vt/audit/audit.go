@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the actions which change the cluster, such as the
+// mutating vtctld RPCs and the vtorc recoveries, in an audit log. The entries
+// are written to the sinks selected with --audit_log_sinks, which are
+// registered with RegisterSink: a file of JSON lines, syslog or a webhook.
+package audit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+// Entry is an action recorded in the audit log.
+type Entry struct {
+	// Time is when the action started.
+	Time time.Time `json:"time"`
+	// Component is the binary which ran the action, e.g. vtctld or vtorc.
+	Component string `json:"component"`
+	// Identity is who requested the action, e.g. the identity of the caller
+	// of a vtctld RPC, or the component itself for its own actions.
+	Identity string `json:"identity,omitempty"`
+	// Action is what was done, e.g. the name of a vtctld RPC.
+	Action string `json:"action"`
+	// Args are the arguments of the action, as JSON.
+	Args string `json:"args,omitempty"`
+	// Error is the error of the action, if it failed.
+	Error string `json:"error,omitempty"`
+	// Duration is how long the action took.
+	Duration time.Duration `json:"duration"`
+}
+
+// Sink writes the entries of the audit log somewhere.
+type Sink interface {
+	// Write writes an entry. It is not called concurrently.
+	Write(entry *Entry) error
+	// Close flushes and releases the sink.
+	Close() error
+}
+
+// sinkFactories are the sinks which can be selected with --audit_log_sinks.
+var sinkFactories = map[string]func() (Sink, error){}
+
+// RegisterSink registers a sink, which can then be selected with
+// --audit_log_sinks. It must be called in an init function.
+func RegisterSink(name string, factory func() (Sink, error)) {
+	if _, ok := sinkFactories[name]; ok {
+		log.Fatalf("audit log sink %v is already registered", name)
+	}
+	sinkFactories[name] = factory
+}
+
+var (
+	sinkNames []string
+	component string
+
+	mu    sync.Mutex
+	sinks map[string]Sink
+
+	entriesCounter = stats.NewCountersWithSingleLabel(
+		"AuditLogEntries",
+		"Entries recorded in the audit log, by action",
+		"Action")
+	writeErrorsCounter = stats.NewCountersWithSingleLabel(
+		"AuditLogWriteErrors",
+		"Entries of the audit log which could not be written, by sink",
+		"Sink")
+)
+
+// FlagBinaries are the binaries which record their actions in the audit log.
+var FlagBinaries = []string{"vtctld", "vtcombo", "vtorc"}
+
+func init() {
+	for _, cmd := range FlagBinaries {
+		cmd := cmd
+		servenv.OnParseFor(cmd, func(fs *pflag.FlagSet) {
+			registerFlags(fs, cmd)
+		})
+	}
+
+	servenv.OnInit(func() {
+		if err := Open(); err != nil {
+			log.Exitf("Cannot open the audit log: %v", err)
+		}
+	})
+	servenv.OnClose(Close)
+}
+
+func registerFlags(fs *pflag.FlagSet, cmd string) {
+	component = cmd
+	fs.StringSliceVar(&sinkNames, "audit_log_sinks", sinkNames, "Sinks of the audit log of the actions which change the cluster: file, syslog or webhook. The audit log is disabled if empty.")
+}
+
+// Enabled returns true if the audit log has sinks, so that the actions should
+// be recorded.
+func Enabled() bool {
+	return len(sinkNames) > 0
+}
+
+// Open opens the sinks selected with --audit_log_sinks.
+func Open() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	opened := make(map[string]Sink, len(sinkNames))
+	for _, name := range sinkNames {
+		if _, ok := opened[name]; ok {
+			continue
+		}
+		factory, ok := sinkFactories[name]
+		if !ok {
+			closeSinks(opened)
+			return fmt.Errorf("unknown audit log sink %q", name)
+		}
+		sink, err := factory()
+		if err != nil {
+			closeSinks(opened)
+			return fmt.Errorf("cannot open the %v audit log sink: %w", name, err)
+		}
+		opened[name] = sink
+	}
+	if len(opened) > 0 {
+		log.Infof("Recording the actions of %v in the audit log sinks %v", component, sinkNames)
+	}
+	sinks = opened
+	return nil
+}
+
+// Close closes the sinks of the audit log.
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	closeSinks(sinks)
+	sinks = nil
+}
+
+func closeSinks(sinks map[string]Sink) {
+	for name, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			log.Errorf("Cannot close the %v audit log sink: %v", name, err)
+		}
+	}
+}
+
+// Record writes an entry to all the sinks of the audit log, with the
+// component of the process if it has none. The errors of the sinks are
+// logged rather than returned, since they should not fail the action.
+func Record(entry *Entry) {
+	if entry.Component == "" {
+		entry.Component = component
+	}
+	entriesCounter.Add(entry.Action, 1)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for name, sink := range sinks {
+		if err := sink.Write(entry); err != nil {
+			writeErrorsCounter.Add(name, 1)
+			log.Errorf("Cannot write %v of %v to the %v audit log sink: %v", entry.Action, entry.Identity, name, err)
+		}
+	}
+}
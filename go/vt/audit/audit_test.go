@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	file := path.Join(t.TempDir(), "audit.log")
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	sink, err := OpenFileSink(file)
+	require.NoError(t, err)
+	for i, entry := range []*Entry{
+		{Component: "vtctld", Identity: "oncall", Action: "PlannedReparentShard", Args: `{"keyspace":"commerce"}`},
+		{Component: "vtorc", Identity: "vtorc", Action: "RecoverDeadPrimary", Error: "no candidate"},
+		{Component: "vtctld", Identity: "deployer", Action: "ApplySchema"},
+		{Component: "vtctld", Identity: "oncall", Action: "SetWritable"},
+	} {
+		entry.Time = start.Add(time.Duration(i) * time.Minute)
+		entry.Duration = time.Second
+		require.NoError(t, sink.Write(entry))
+	}
+	require.NoError(t, sink.Close())
+
+	// A crash may leave a line cut short, which is skipped, and the next
+	// entries are appended after it.
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"time":"2023-10-01T12:`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	entries, err := ReadFile(file, &Filter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "SetWritable", entries[0].Action, "the most recent entry comes first")
+	assert.Equal(t, "no candidate", entries[2].Error)
+	assert.Equal(t, start.Add(time.Minute), entries[2].Time.UTC())
+
+	entries, err = ReadFile(file, &Filter{Identity: "oncall"}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "SetWritable", entries[0].Action)
+	assert.Equal(t, "PlannedReparentShard", entries[1].Action)
+
+	entries, err = ReadFile(file, &Filter{Component: "vtctld", Since: start.Add(time.Minute)}, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "SetWritable", entries[0].Action)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []*Entry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entry := &Entry{}
+		if err := json.NewDecoder(r.Body).Decode(entry); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if entry.Action == "Reject" {
+			http.Error(w, "rejected", http.StatusInternalServerError)
+			return
+		}
+		received = append(received, entry)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, time.Second)
+	defer sink.Close()
+
+	require.NoError(t, sink.Write(&Entry{Component: "vtctld", Identity: "oncall", Action: "SetWritable"}))
+	assert.ErrorContains(t, sink.Write(&Entry{Action: "Reject"}), "500")
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "oncall", received[0].Identity)
+	assert.Equal(t, "SetWritable", received[0].Action)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var auditLogFile string
+
+func init() {
+	for _, cmd := range FlagBinaries {
+		servenv.OnParseFor(cmd, registerFileFlags)
+	}
+	RegisterSink("file", func() (Sink, error) {
+		if auditLogFile == "" {
+			return nil, errors.New("--audit_log_file is required")
+		}
+		return OpenFileSink(auditLogFile)
+	})
+}
+
+func registerFileFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&auditLogFile, "audit_log_file", auditLogFile, "File to which the file sink of the audit log appends the entries, as JSON lines.")
+}
+
+// File returns the file of the file sink of the audit log, or an empty string
+// if it is not enabled.
+func File() string {
+	for _, name := range sinkNames {
+		if name == "file" {
+			return auditLogFile
+		}
+	}
+	return ""
+}
+
+// FileSink appends the entries of the audit log to a file, as JSON lines. Each
+// entry is synced to disk before Write returns, so that it is not lost if the
+// process or its host crashes.
+type FileSink struct {
+	f *os.File
+}
+
+// OpenFileSink opens a FileSink, which appends to the file if it exists.
+func OpenFileSink(file string) (*FileSink, error) {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{f: f}, nil
+}
+
+// Write is part of the Sink interface.
+func (sink *FileSink) Write(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := sink.f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return sink.f.Sync()
+}
+
+// Close is part of the Sink interface.
+func (sink *FileSink) Close() error {
+	return sink.f.Close()
+}
+
+// Filter selects entries of the audit log. The zero Filter selects all of
+// them.
+type Filter struct {
+	Component string
+	Identity  string
+	Action    string
+	// Since selects the entries of the actions started at or after it.
+	Since time.Time
+}
+
+// Matches returns true if the filter selects the entry.
+func (filter *Filter) Matches(entry *Entry) bool {
+	switch {
+	case filter.Component != "" && filter.Component != entry.Component:
+		return false
+	case filter.Identity != "" && filter.Identity != entry.Identity:
+		return false
+	case filter.Action != "" && filter.Action != entry.Action:
+		return false
+	case !filter.Since.IsZero() && entry.Time.Before(filter.Since):
+		return false
+	}
+	return true
+}
+
+// ReadFile returns the last limit entries of a file written by a FileSink
+// which match the filter, from the most recent one, or all of them if limit
+// is zero. The lines which are not entries, e.g. because they were cut short
+// by a crash, are skipped.
+func ReadFile(file string, filter *Filter, limit int) ([]*Entry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*Entry
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			entry := &Entry{}
+			if json.Unmarshal(line, entry) == nil && filter.Matches(entry) {
+				entries = append(entries, entry)
+				if limit > 0 && len(entries) > limit {
+					entries = entries[1:]
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the audit log in %v: %w", file, err)
+		}
+	}
+
+	slices.Reverse(entries)
+	return entries, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+func init() {
+	RegisterSink("syslog", func() (Sink, error) {
+		w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, "vitess-audit")
+		if err != nil {
+			return nil, err
+		}
+		return &syslogSink{w: w}, nil
+	})
+}
+
+// syslogSink writes the entries of the audit log to the local syslog, as
+// JSON, with the auth facility. The failed actions have the warning severity.
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+// Write is part of the Sink interface.
+func (sink *syslogSink) Write(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if entry.Error != "" {
+		return sink.w.Warning(string(data))
+	}
+	return sink.w.Info(string(data))
+}
+
+// Close is part of the Sink interface.
+func (sink *syslogSink) Close() error {
+	return sink.w.Close()
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var (
+	webhookURL     string
+	webhookTimeout = 5 * time.Second
+)
+
+func init() {
+	for _, cmd := range FlagBinaries {
+		servenv.OnParseFor(cmd, registerWebhookFlags)
+	}
+	RegisterSink("webhook", func() (Sink, error) {
+		if webhookURL == "" {
+			return nil, errors.New("--audit_log_webhook_url is required")
+		}
+		return NewWebhookSink(webhookURL, webhookTimeout), nil
+	})
+}
+
+func registerWebhookFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&webhookURL, "audit_log_webhook_url", webhookURL, "URL to which the webhook sink of the audit log POSTs each entry, as JSON.")
+	fs.DurationVar(&webhookTimeout, "audit_log_webhook_timeout", webhookTimeout, "Timeout of the POSTs of the webhook sink of the audit log.")
+}
+
+// WebhookSink POSTs each entry of the audit log to a URL, as a JSON object.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink posting to the URL.
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Write is part of the Sink interface.
+func (sink *WebhookSink) Write(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	resp, err := sink.client.Post(sink.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%v returned %v", sink.url, resp.Status)
+	}
+	return nil
+}
+
+// Close is part of the Sink interface.
+func (sink *WebhookSink) Close() error {
+	sink.client.CloseIdleConnections()
+	return nil
+}
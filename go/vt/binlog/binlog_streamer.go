@@ -445,8 +445,8 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 					}
 				}
 			}
-		case ev.IsPreviousGTIDs(): // PREVIOUS_GTIDS_EVENT
-			// MySQL 5.6 only: The Binlogs contain an
+		case ev.IsPreviousGTIDs(): // PREVIOUS_GTIDS_EVENT or MariaDB GTID_LIST_EVENT
+			// MySQL 5.6 and MariaDB: The Binlogs contain an
 			// event that gives us all the previously
 			// applied commits. It is *not* an
 			// authoritative value, unless we started from
@@ -565,6 +565,11 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 			if err != nil {
 				return pos, err
 			}
+			for _, row := range rows.Rows {
+				if row.JSONPartialValues.Count() > 0 {
+					return pos, fmt.Errorf("partial JSON updates are not supported in UpdateRows event for table %v: ensure binlog_row_value_options is empty", tce.tm.Name)
+				}
+			}
 
 			statements = bls.appendUpdates(statements, tce, &rows)
 
@@ -100,8 +100,21 @@ type Stats struct {
 
 	PartialQueryCount     *stats.CountersWithMultiLabels
 	PartialQueryCacheSize *stats.CountersWithMultiLabels
+
+	ConflictCounts *stats.CountersWithSingleLabel
+
+	// LagHistogram is the distribution of the replication lag, in seconds.
+	LagHistogram *stats.Histogram
+	// EventCounts is the number of events received from the source, by type.
+	EventCounts *stats.CountersWithSingleLabel
+	EventRates  *stats.Rates
+	// CopyRowRates is the rate of rows copied in the copy phase, by table.
+	CopyRowRates *stats.Rates
 }
 
+// LagHistogramCutoffs are the cutoffs of the replication lag histograms, in seconds.
+var LagHistogramCutoffs = []int64{1, 5, 10, 30, 60, 300, 900, 1800, 3600}
+
 // RecordHeartbeat updates the time the last heartbeat from vstreamer was seen
 func (bps *Stats) RecordHeartbeat(tm int64) {
 	bps.heartbeatMutex.Lock()
@@ -145,6 +158,8 @@ func (bps *Stats) MessageHistory() []string {
 func (bps *Stats) Stop() {
 	bps.Rates.Stop()
 	bps.VReplicationLagRates.Stop()
+	bps.EventRates.Stop()
+	bps.CopyRowRates.Stop()
 }
 
 // NewStats creates a new Stats structure.
@@ -167,6 +182,11 @@ func NewStats() *Stats {
 	bps.TableCopyTimings = stats.NewTimings("", "", "Table")
 	bps.PartialQueryCacheSize = stats.NewCountersWithMultiLabels("", "", []string{"type"})
 	bps.PartialQueryCount = stats.NewCountersWithMultiLabels("", "", []string{"type"})
+	bps.ConflictCounts = stats.NewCountersWithSingleLabel("", "", "Table", "")
+	bps.LagHistogram = stats.NewHistogram("", "", LagHistogramCutoffs)
+	bps.EventCounts = stats.NewCountersWithSingleLabel("", "", "Type")
+	bps.EventRates = stats.NewRates("", bps.EventCounts, 15*60/5, 5*time.Second)
+	bps.CopyRowRates = stats.NewRates("", bps.TableCopyRowCounts, 15*60/5, 5*time.Second)
 	return bps
 }
 
@@ -32,14 +32,29 @@ import (
 // only for Mysql contexts.
 func MysqlCallInfo(ctx context.Context, c *mysql.Conn) context.Context {
 	return NewContext(ctx, &mysqlCallInfoImpl{
-		remoteAddr: c.RemoteAddr().String(),
-		user:       c.User,
+		remoteAddr:           c.RemoteAddr().String(),
+		user:                 c.User,
+		connectionAttributes: c.ConnectionAttributes,
 	})
 }
 
+// MysqlConnectionAttributes returns the connection attributes sent by the
+// MySQL client of the call, or nil if it is not a Mysql context.
+func MysqlConnectionAttributes(ctx context.Context) map[string]string {
+	ci, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if mci, ok := ci.(*mysqlCallInfoImpl); ok {
+		return mci.connectionAttributes
+	}
+	return nil
+}
+
 type mysqlCallInfoImpl struct {
-	remoteAddr string
-	user       string
+	remoteAddr           string
+	user                 string
+	connectionAttributes map[string]string
 }
 
 func (mci *mysqlCallInfoImpl) RemoteAddr() string {
@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clustersettings defines the runtime settings of the cluster, which
+// are set with `vtctldclient SetClusterSetting`, stored in the global topo,
+// and applied by the vtgates and vttablets which watch them, without a
+// restart.
+//
+// Each setting has a name, prefixed with the component which uses it, a type
+// and an optional validation, which vtctld checks before storing a value. A
+// setting which is not set has its default value, which is usually that of a
+// flag, so the components read it with GetOr:
+//
+//	maxRows := clustersettings.VTGateMaxMemoryRows.GetOr(maxMemoryRows)
+//
+// All the settings are defined in this package, so that every binary, and
+// vtctld in particular, knows all of them.
+package clustersettings
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+)
+
+// Type is the type of the value of a setting.
+type Type string
+
+const (
+	TypeBool     Type = "bool"
+	TypeInt      Type = "int"
+	TypeFloat    Type = "float"
+	TypeDuration Type = "duration"
+	TypeString   Type = "string"
+)
+
+// Definition is the part of a Setting which doesn't depend on the type of its
+// value.
+type Definition interface {
+	// Name returns the name of the setting.
+	Name() string
+	// Type returns the type of the value of the setting.
+	Type() Type
+	// Help returns the description of the setting.
+	Help() string
+	// Validate checks that the value is valid for the setting.
+	Validate(value string) error
+
+	// apply sets the setting to value if ok is true, and unsets it otherwise.
+	apply(value string, ok bool) error
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Definition{}
+
+	nameRE = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)+$`)
+)
+
+// Lookup returns the setting with the given name.
+func Lookup(name string) (Definition, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	def, ok := registry[name]
+	return def, ok
+}
+
+// All returns all the settings, by name.
+func All() []Definition {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	defs := make([]Definition, 0, len(registry))
+	for _, def := range registry {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name() < defs[j].Name() })
+	return defs
+}
+
+func register(def Definition) {
+	if !nameRE.MatchString(def.Name()) {
+		panic(fmt.Sprintf("invalid cluster setting name %q, expected <component>.<name>", def.Name()))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[def.Name()]; ok {
+		panic(fmt.Sprintf("cluster setting %q is defined twice", def.Name()))
+	}
+	registry[def.Name()] = def
+}
+
+// Setting is a runtime setting of the cluster, whose value is of type T.
+type Setting[T comparable] struct {
+	name     string
+	typ      Type
+	help     string
+	parse    func(string) (T, error)
+	validate func(T) error
+
+	value atomic.Pointer[T]
+
+	mu       sync.Mutex
+	onChange []func()
+}
+
+func newSetting[T comparable](name string, typ Type, help string, parse func(string) (T, error), validate func(T) error) *Setting[T] {
+	s := &Setting[T]{
+		name:     name,
+		typ:      typ,
+		help:     help,
+		parse:    parse,
+		validate: validate,
+	}
+	register(s)
+	return s
+}
+
+// NewBool defines a setting whose value is a bool.
+func NewBool(name, help string) *Setting[bool] {
+	return newSetting(name, TypeBool, help, strconv.ParseBool, nil)
+}
+
+// NewInt defines a setting whose value is an int. If validate is not nil, it
+// checks the values which are set.
+func NewInt(name, help string, validate func(int) error) *Setting[int] {
+	return newSetting(name, TypeInt, help, strconv.Atoi, validate)
+}
+
+// NewFloat defines a setting whose value is a float64. If validate is not
+// nil, it checks the values which are set.
+func NewFloat(name, help string, validate func(float64) error) *Setting[float64] {
+	return newSetting(name, TypeFloat, help, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }, validate)
+}
+
+// NewDuration defines a setting whose value is a time.Duration. If validate
+// is not nil, it checks the values which are set.
+func NewDuration(name, help string, validate func(time.Duration) error) *Setting[time.Duration] {
+	return newSetting(name, TypeDuration, help, time.ParseDuration, validate)
+}
+
+// NewString defines a setting whose value is a string. If validate is not
+// nil, it checks the values which are set.
+func NewString(name, help string, validate func(string) error) *Setting[string] {
+	return newSetting(name, TypeString, help, func(s string) (string, error) { return s, nil }, validate)
+}
+
+// Name is part of the Definition interface.
+func (s *Setting[T]) Name() string { return s.name }
+
+// Type is part of the Definition interface.
+func (s *Setting[T]) Type() Type { return s.typ }
+
+// Help is part of the Definition interface.
+func (s *Setting[T]) Help() string { return s.help }
+
+// Validate is part of the Definition interface.
+func (s *Setting[T]) Validate(value string) error {
+	_, err := s.parseAndValidate(value)
+	return err
+}
+
+func (s *Setting[T]) parseAndValidate(value string) (T, error) {
+	v, err := s.parse(value)
+	if err != nil {
+		return v, fmt.Errorf("invalid %s value %q for cluster setting %s", s.typ, value, s.name)
+	}
+	if s.validate != nil {
+		if err := s.validate(v); err != nil {
+			return v, fmt.Errorf("invalid value %q for cluster setting %s: %w", value, s.name, err)
+		}
+	}
+	return v, nil
+}
+
+// Get returns the value of the setting, and whether it is set.
+func (s *Setting[T]) Get() (value T, ok bool) {
+	if v := s.value.Load(); v != nil {
+		return *v, true
+	}
+	return value, false
+}
+
+// GetOr returns the value of the setting if it is set, and def otherwise.
+func (s *Setting[T]) GetOr(def T) T {
+	if v, ok := s.Get(); ok {
+		return v
+	}
+	return def
+}
+
+// OnChange registers a function which is called after the setting is set,
+// changed or unset by the cluster settings in the topo.
+func (s *Setting[T]) OnChange(f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, f)
+}
+
+func (s *Setting[T]) apply(value string, ok bool) error {
+	var next *T
+	if ok {
+		v, err := s.parseAndValidate(value)
+		if err != nil {
+			return err
+		}
+		next = &v
+	}
+
+	prev := s.value.Swap(next)
+	if (prev == nil && next == nil) || (prev != nil && next != nil && *prev == *next) {
+		return nil
+	}
+	if ok {
+		log.Infof("Cluster setting %s set to %q", s.name, value)
+	} else {
+		log.Infof("Cluster setting %s unset", s.name)
+	}
+
+	s.mu.Lock()
+	callbacks := s.onChange
+	s.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+	return nil
+}
+
+// Apply applies the values of the settings stored in the topo. The settings
+// which have no value are unset. An invalid value, e.g. one set by a newer
+// version of vtctld, is logged and the setting is unset. Names which are not
+// defined are ignored.
+func Apply(settings map[string]string) {
+	for _, def := range All() {
+		value, ok := settings[def.Name()]
+		if err := def.apply(value, ok); err != nil {
+			log.Warningf("Ignoring cluster setting: %v", err)
+			_ = def.apply("", false)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustersettings
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	testInt      = NewInt("test.int", "An int.", positive)
+	testDuration = NewDuration("test.duration", "A duration.", nil)
+)
+
+func TestSettings(t *testing.T) {
+	defer Apply(nil)
+
+	def, ok := Lookup("test.int")
+	require.True(t, ok)
+	assert.Equal(t, TypeInt, def.Type())
+	assert.NoError(t, def.Validate("10"))
+	assert.ErrorContains(t, def.Validate("ten"), `invalid int value "ten" for cluster setting test.int`)
+	assert.ErrorContains(t, def.Validate("-1"), "must be positive")
+
+	assert.Panics(t, func() { NewBool("test.int", "Defined twice.") })
+	assert.Panics(t, func() { NewBool("nocomponent", "Invalid name.") })
+
+	var changes atomic.Int32
+	testInt.OnChange(func() { changes.Add(1) })
+
+	assert.Equal(t, 5, testInt.GetOr(5))
+
+	Apply(map[string]string{"test.int": "10", "test.duration": "1m", "unknown.setting": "x"})
+	assert.Equal(t, 10, testInt.GetOr(5))
+	d, ok := testDuration.Get()
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, d)
+	assert.EqualValues(t, 1, changes.Load())
+
+	// Unchanged values don't call OnChange again.
+	Apply(map[string]string{"test.int": "10"})
+	assert.EqualValues(t, 1, changes.Load())
+	_, ok = testDuration.Get()
+	assert.False(t, ok)
+
+	// Invalid values are ignored.
+	Apply(map[string]string{"test.int": "-1"})
+	assert.Equal(t, 5, testInt.GetOr(5))
+	assert.EqualValues(t, 2, changes.Load())
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer Apply(nil)
+
+	saved := watchRetryDelay
+	watchRetryDelay = 10 * time.Millisecond
+	defer func() { watchRetryDelay = saved }()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	stop := Start(ts)
+	defer stop()
+
+	set := func(value string) {
+		_, err := ts.UpdateClusterSettings(ctx, func(settings *topodatapb.ClusterSettings) error {
+			settings.Settings = map[string]string{"test.int": value}
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	// The settings are applied once they are first set, and then on every
+	// change.
+	set("10")
+	assert.Eventually(t, func() bool { return testInt.GetOr(0) == 10 }, 5*time.Second, 10*time.Millisecond)
+	set("20")
+	assert.Eventually(t, func() bool { return testInt.GetOr(0) == 20 }, 5*time.Second, 10*time.Millisecond)
+}
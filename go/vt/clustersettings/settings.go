@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustersettings
+
+import "errors"
+
+// The settings of the cluster. A setting which overrides a flag has the
+// value of the flag while it is not set.
+var (
+	// VTGateMaxMemoryRows overrides --max_memory_rows of the vtgates.
+	VTGateMaxMemoryRows = NewInt("vtgate.max_memory_rows", "Maximum number of rows that will be held in memory for intermediate results as well as the final result, instead of --max_memory_rows.", positive)
+	// VTGateWarnMemoryRows overrides --warn_memory_rows of the vtgates.
+	VTGateWarnMemoryRows = NewInt("vtgate.warn_memory_rows", "Warning threshold for in-memory results, instead of --warn_memory_rows.", positive)
+
+	// VTTabletMaxResultSize overrides --queryserver-config-max-result-size of
+	// the vttablets.
+	VTTabletMaxResultSize = NewInt("vttablet.max_result_size", "Maximum number of rows a query can return, instead of --queryserver-config-max-result-size.", positive)
+	// VTTabletWarnResultSize overrides --queryserver-config-warn-result-size
+	// of the vttablets.
+	VTTabletWarnResultSize = NewInt("vttablet.warn_result_size", "Number of rows of a query result above which a warning is logged, instead of --queryserver-config-warn-result-size.", positive)
+)
+
+func positive(v int) error {
+	if v <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustersettings
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// watchRetryDelay is how long to wait before watching the cluster settings
+// again after an error, or while no setting was ever set.
+var watchRetryDelay = 10 * time.Second
+
+// Start applies the cluster settings stored in the global topo, and their
+// changes, in the background until the returned function is called.
+func Start(ts *topo.Server) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watch(ctx, ts)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func watch(ctx context.Context, ts *topo.Server) {
+	for {
+		current, changes, err := ts.WatchClusterSettings(ctx)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			// No setting was ever set.
+			Apply(nil)
+		case err != nil:
+			log.Warningf("Cannot watch the cluster settings, retrying in %v: %v", watchRetryDelay, err)
+		default:
+			Apply(current.Value.Settings)
+			for change := range changes {
+				if change.Err != nil {
+					if topo.IsErrType(change.Err, topo.NoNode) {
+						Apply(nil)
+					} else if ctx.Err() == nil {
+						log.Warningf("Watch of the cluster settings failed, retrying in %v: %v", watchRetryDelay, change.Err)
+					}
+					continue
+				}
+				Apply(change.Value.Settings)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryDelay):
+		}
+	}
+}
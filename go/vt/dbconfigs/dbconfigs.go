@@ -89,6 +89,8 @@ type DBConfigs struct {
 	ConnectTimeoutMilliseconds int           `json:"connectTimeoutMilliseconds,omitempty"`
 	DBName                     string        `json:"dbName,omitempty"`
 	EnableQueryInfo            bool          `json:"enableQueryInfo,omitempty"`
+	Compression                string        `json:"compression,omitempty"`
+	CompressionLevel           int           `json:"compressionLevel,omitempty"`
 
 	App          UserConfig `json:"app,omitempty"`
 	Dba          UserConfig `json:"dba,omitempty"`
@@ -145,6 +147,8 @@ func registerBaseFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&GlobalDBConfigs.ServerName, "db_server_name", "", "server name of the DB we are connecting to.")
 	fs.IntVar(&GlobalDBConfigs.ConnectTimeoutMilliseconds, "db_connect_timeout_ms", 0, "connection timeout to mysqld in milliseconds (0 for no timeout)")
 	fs.BoolVar(&GlobalDBConfigs.EnableQueryInfo, "db_conn_query_info", false, "enable parsing and processing of QUERY_OK info fields")
+	fs.StringVar(&GlobalDBConfigs.Compression, "db_compression", "", "Compression algorithm of the MySQL protocol used to connect to mysqld, zlib or zstd, if mysqld supports it. The connections are not compressed if empty.")
+	fs.IntVar(&GlobalDBConfigs.CompressionLevel, "db_compression_level", 0, "Level of the compression of the MySQL protocol used to connect to mysqld, between 1 and 9 for zlib and 1 and 22 for zstd (0 for the default level of the algorithm)")
 }
 
 // The flags will change the global singleton
@@ -350,6 +354,8 @@ func (dbcfgs *DBConfigs) InitWithSocket(defaultSocketFile string) {
 		}
 		cp.ConnectTimeoutMs = uint64(dbcfgs.ConnectTimeoutMilliseconds)
 		cp.EnableQueryInfo = dbcfgs.EnableQueryInfo
+		cp.Compression = dbcfgs.Compression
+		cp.CompressionLevel = dbcfgs.CompressionLevel
 
 		cp.Uname = uc.User
 		cp.Pass = uc.Password
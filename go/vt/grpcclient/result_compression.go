@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+
+	"vitess.io/vitess/go/vt/grpccommon"
+)
+
+// ResultCompressionDialOptions returns the dial options to advertise to the
+// server, on every call of the connection, that the client accepts query
+// results compressed with any of the given compressors. The server picks one
+// of them, based on its own preferences, and only for large enough results.
+func ResultCompressionDialOptions(compressors []string) ([]grpc.DialOption, error) {
+	if len(compressors) == 0 {
+		return nil, nil
+	}
+	for _, name := range compressors {
+		if encoding.GetCompressor(name) == nil {
+			return nil, fmt.Errorf("unknown gRPC compressor %q", name)
+		}
+	}
+	value := strings.Join(compressors, ",")
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			ctx = metadata.AppendToOutgoingContext(ctx, grpccommon.ResultCompressionMetadataKey, value)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			ctx = metadata.AppendToOutgoingContext(ctx, grpccommon.ResultCompressionMetadataKey, value)
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	}, nil
+}
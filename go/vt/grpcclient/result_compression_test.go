@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+
+	"vitess.io/vitess/go/vt/grpccommon"
+)
+
+func TestResultCompressionDialOptions(t *testing.T) {
+	opts, err := ResultCompressionDialOptions(nil)
+	require.NoError(t, err)
+	assert.Nil(t, opts)
+
+	_, err = ResultCompressionDialOptions([]string{"zstd", "unknown"})
+	assert.EqualError(t, err, `unknown gRPC compressor "unknown"`)
+
+	// The server sends its responses compressed with the compressor it
+	// negotiated from the advertised ones.
+	var advertised []string
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		advertised = md.Get(grpccommon.ResultCompressionMetadataKey)
+		if name := grpccommon.NegotiateResultCompressor(ctx, []string{"zstd"}); name != "" {
+			if err := grpc.SetSendCompressor(ctx, name); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	opts, err = ResultCompressionDialOptions([]string{"snappy", "zstd"})
+	require.NoError(t, err)
+	recorder := &compressionRecorder{}
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithStatsHandler(recorder))
+	cc, err := grpc.Dial(listener.Addr().String(), opts...)
+	require.NoError(t, err)
+	defer cc.Close()
+
+	resp, err := healthpb.NewHealthClient(cc).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	assert.Equal(t, []string{"snappy,zstd"}, advertised)
+	assert.Equal(t, "zstd", recorder.compression)
+}
+
+// compressionRecorder records the compressor of the responses received by
+// a client.
+type compressionRecorder struct {
+	compression string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if h, ok := s.(*stats.InHeader); ok {
+		r.compression = h.Compression
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpccommon
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// ResultCompressionMetadataKey is the gRPC metadata key used by clients to
+// advertise the compressors they accept for query results. Its value is a
+// comma-separated list of compressor names.
+const ResultCompressionMetadataKey = "vt-result-compression"
+
+var (
+	// resultCompression is the list of compressors, in order of preference,
+	// that can be used to send query results to clients that accept them.
+	resultCompression []string
+	// resultCompressionThreshold is the minimum size in bytes of a result
+	// for it to be compressed.
+	resultCompressionThreshold = 64 * 1024
+
+	resultCompressionCount = stats.NewCountersWithSingleLabel("GRPCResultCompression", "Number of query responses sent compressed, per compressor", "Compressor")
+)
+
+// RegisterResultCompressionFlags installs the flags of the gRPC servers
+// sending query results, vttablet and vtgate, on the given FlagSet.
+func RegisterResultCompressionFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&resultCompression, "grpc-result-compression", resultCompression, "comma-separated list of gRPC compressors (snappy, zstd), in order of preference, that can be used to send query results to clients advertising them, e.g. vtgate with --tablet_grpc_result_compression.")
+	fs.IntVar(&resultCompressionThreshold, "grpc-result-compression-threshold", resultCompressionThreshold, "minimum size in bytes of a non-streaming query result for it to be sent compressed. Streaming query results are always compressed when a compressor is negotiated.")
+}
+
+// NegotiateResultCompressor returns the first of the given server-preferred
+// compressors that the client advertised under ResultCompressionMetadataKey
+// in the incoming context, and that is registered with gRPC. It returns an
+// empty string if there is none.
+func NegotiateResultCompressor(ctx context.Context, preferred []string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	accepted := make(map[string]bool)
+	for _, v := range md.Get(ResultCompressionMetadataKey) {
+		for _, name := range strings.Split(v, ",") {
+			accepted[strings.TrimSpace(name)] = true
+		}
+	}
+	for _, name := range preferred {
+		if accepted[name] && encoding.GetCompressor(name) != nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// MaybeCompressResult sets the compressor used for the response of the
+// current unary call if the client accepts one of the configured compressors
+// and the result, of the given size in bytes, is big enough.
+func MaybeCompressResult(ctx context.Context, size int) {
+	if size < resultCompressionThreshold {
+		return
+	}
+	setResultCompressor(ctx)
+}
+
+// MaybeCompressStream sets the compressor used for the messages of the
+// current stream if the client accepts one of the configured compressors.
+// The compressor of a stream cannot change once its first message, usually
+// the fields of the result, is sent, so the threshold does not apply here.
+func MaybeCompressStream(ctx context.Context) {
+	setResultCompressor(ctx)
+}
+
+func setResultCompressor(ctx context.Context) {
+	if len(resultCompression) == 0 {
+		return
+	}
+	name := NegotiateResultCompressor(ctx, resultCompression)
+	if name == "" {
+		return
+	}
+	if err := grpc.SetSendCompressor(ctx, name); err != nil {
+		log.Warningf("cannot compress query results with %v: %v", name, err)
+		return
+	}
+	resultCompressionCount.Add(name, 1)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpccommon
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+func TestNegotiateResultCompressor(t *testing.T) {
+	tests := []struct {
+		name      string
+		md        metadata.MD
+		preferred []string
+		want      string
+	}{{
+		name:      "no metadata",
+		preferred: []string{gzip.Name},
+		want:      "",
+	}, {
+		name:      "client accepts preferred compressor",
+		md:        metadata.Pairs(ResultCompressionMetadataKey, "snappy, gzip"),
+		preferred: []string{gzip.Name},
+		want:      gzip.Name,
+	}, {
+		name:      "server preference wins",
+		md:        metadata.Pairs(ResultCompressionMetadataKey, "gzip,unregistered"),
+		preferred: []string{"unregistered", gzip.Name},
+		want:      gzip.Name,
+	}, {
+		name:      "no common compressor",
+		md:        metadata.Pairs(ResultCompressionMetadataKey, "snappy"),
+		preferred: []string{gzip.Name},
+		want:      "",
+	}, {
+		name: "server compression disabled",
+		md:   metadata.Pairs(ResultCompressionMetadataKey, gzip.Name),
+		want: "",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			assert.Equal(t, tt.want, NegotiateResultCompressor(ctx, tt.preferred))
+		})
+	}
+}
+
+func TestMaybeCompressResult(t *testing.T) {
+	defer func(compression []string, threshold int) {
+		resultCompression = compression
+		resultCompressionThreshold = threshold
+	}(resultCompression, resultCompressionThreshold)
+	resultCompressionThreshold = 100
+
+	tests := []struct {
+		name        string
+		compression []string
+		accepted    string
+		size        int
+		want        string
+	}{{
+		name:        "compressed",
+		compression: []string{gzip.Name},
+		accepted:    gzip.Name,
+		size:        100,
+		want:        gzip.Name,
+	}, {
+		name:        "below threshold",
+		compression: []string{gzip.Name},
+		accepted:    gzip.Name,
+		size:        99,
+	}, {
+		name:        "client does not advertise compressors",
+		compression: []string{gzip.Name},
+		size:        100,
+	}, {
+		name:        "no common compressor",
+		compression: []string{gzip.Name},
+		accepted:    "snappy",
+		size:        100,
+	}, {
+		name:     "server compression disabled",
+		accepted: gzip.Name,
+		size:     100,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultCompression = tt.compression
+			before := resultCompressionCount.Counts()[gzip.Name]
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				MaybeCompressResult(ctx, tt.size)
+				return handler(ctx, req)
+			}))
+			healthpb.RegisterHealthServer(server, health.NewServer())
+			go server.Serve(listener)
+			defer server.Stop()
+
+			recorder := &compressionRecorder{}
+			cc, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithStatsHandler(recorder))
+			require.NoError(t, err)
+			defer cc.Close()
+
+			ctx := context.Background()
+			if tt.accepted != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, ResultCompressionMetadataKey, tt.accepted)
+			}
+			_, err = healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, recorder.compression)
+
+			wantCount := before
+			if tt.want != "" {
+				wantCount++
+			}
+			assert.Equal(t, wantCount, resultCompressionCount.Counts()[gzip.Name])
+		})
+	}
+}
+
+// compressionRecorder records the compressor of the responses received by
+// a client.
+type compressionRecorder struct {
+	compression string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if h, ok := s.(*stats.InHeader); ok {
+		r.compression = h.Compression
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
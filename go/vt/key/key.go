@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strings"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -435,3 +436,74 @@ func GenerateShardRanges(shards int) ([]string, error) {
 
 	return shardRanges, nil
 }
+
+// KeyspaceIDWeight is a keyspace id of a histogram of the rows of a keyspace,
+// with the weight of its rows, e.g. their count, their size or their QPS.
+type KeyspaceIDWeight struct {
+	KeyspaceID []byte
+	Weight     float64
+}
+
+// GenerateWeightedShardRanges returns the ranges of N shards which split the
+// weight of a histogram of the keyspace ids as evenly as possible, instead of
+// the keyspace ids themselves. The bounds of the ranges are keyBytes long, so
+// the keyspace ids of the histogram are only compared on their first
+// keyBytes bytes.
+func GenerateWeightedShardRanges(shards int, histogram []KeyspaceIDWeight, keyBytes int) ([]string, error) {
+	switch {
+	case shards <= 0:
+		return nil, errors.New("shards must be greater than zero")
+	case keyBytes < 1 || keyBytes > 8:
+		return nil, fmt.Errorf("the bounds of the ranges must be 1 to 8 bytes long: %v", keyBytes)
+	}
+
+	weights := make(map[string]float64)
+	for _, bucket := range histogram {
+		if bucket.Weight < 0 || math.IsNaN(bucket.Weight) {
+			return nil, fmt.Errorf("keyspace id %x has an invalid weight: %v", bucket.KeyspaceID, bucket.Weight)
+		}
+		if bucket.Weight == 0 {
+			continue
+		}
+		prefix := make([]byte, keyBytes)
+		copy(prefix, bucket.KeyspaceID)
+		weights[string(prefix)] += bucket.Weight
+	}
+
+	prefixes := make([]string, 0, len(weights))
+	for prefix := range weights {
+		prefixes = append(prefixes, prefix)
+	}
+	if len(prefixes) < shards {
+		return nil, fmt.Errorf("the histogram has %v distinct keyspace ids on %v bytes, which is not enough for %v shards", len(prefixes), keyBytes, shards)
+	}
+	sort.Strings(prefixes)
+
+	// cumulative[i] is the weight of the ranges which end at prefixes[i].
+	cumulative := make([]float64, len(prefixes)+1)
+	for i, prefix := range prefixes {
+		cumulative[i+1] = cumulative[i] + weights[prefix]
+	}
+	total := cumulative[len(prefixes)]
+
+	shardRanges := make([]string, 0, shards)
+	start, prev := "", 0
+	for i := 1; i < shards; i++ {
+		// End the range at the prefix which leaves the weight of all the
+		// previous ranges the closest to i/N of the total, while leaving at
+		// least one prefix to each range.
+		target := total * float64(i) / float64(shards)
+		end := sort.SearchFloat64s(cumulative, target)
+		if end > 0 && target-cumulative[end-1] < cumulative[end]-target {
+			end--
+		}
+		end = min(max(end, prev+1), len(prefixes)-(shards-i))
+
+		endKid := hex.EncodeToString([]byte(prefixes[end]))
+		shardRanges = append(shardRanges, fmt.Sprintf("%s-%s", start, endKid))
+		start, prev = endKid, end
+	}
+	shardRanges = append(shardRanges, fmt.Sprintf("%s-", start))
+
+	return shardRanges, nil
+}
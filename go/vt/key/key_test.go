@@ -1578,6 +1578,103 @@ func TestGenerateShardRanges(t *testing.T) {
 	}
 }
 
+func TestGenerateWeightedShardRanges(t *testing.T) {
+	uniform := make([]KeyspaceIDWeight, 0, 256)
+	skewed := make([]KeyspaceIDWeight, 0, 256)
+	for i := 0; i < 256; i++ {
+		uniform = append(uniform, KeyspaceIDWeight{KeyspaceID: []byte{byte(i), 0xff}, Weight: 1})
+		weight := 1.0
+		if i < 16 {
+			weight = 10
+		}
+		skewed = append(skewed, KeyspaceIDWeight{KeyspaceID: []byte{byte(i)}, Weight: weight})
+	}
+
+	tests := []struct {
+		name      string
+		shards    int
+		histogram []KeyspaceIDWeight
+		keyBytes  int
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "single shard",
+			shards:    1,
+			histogram: uniform,
+			keyBytes:  1,
+			want:      []string{"-"},
+		},
+		{
+			name:      "uniform histogram",
+			shards:    4,
+			histogram: uniform,
+			keyBytes:  1,
+			want:      []string{"-40", "40-80", "80-c0", "c0-"},
+		},
+		{
+			name:      "uniform histogram on two bytes",
+			shards:    2,
+			histogram: uniform,
+			keyBytes:  2,
+			want:      []string{"-80ff", "80ff-"},
+		},
+		{
+			name:      "skewed histogram",
+			shards:    2,
+			histogram: skewed,
+			keyBytes:  2,
+			want:      []string{"-3800", "3800-"},
+		},
+		{
+			name:   "each shard gets a keyspace id",
+			shards: 3,
+			histogram: []KeyspaceIDWeight{
+				{KeyspaceID: []byte{0x10}, Weight: 100},
+				{KeyspaceID: []byte{0x20}, Weight: 1},
+				{KeyspaceID: []byte{0x30}, Weight: 1},
+				{KeyspaceID: []byte{0x40}, Weight: 0},
+			},
+			keyBytes: 1,
+			want:     []string{"-20", "20-30", "30-"},
+		},
+		{
+			name:      "too few keyspace ids",
+			shards:    3,
+			histogram: []KeyspaceIDWeight{{KeyspaceID: []byte{0x10, 0x01}, Weight: 1}, {KeyspaceID: []byte{0x10, 0x02}, Weight: 1}},
+			keyBytes:  1,
+			wantErr:   "the histogram has 1 distinct keyspace ids on 1 bytes, which is not enough for 3 shards",
+		},
+		{
+			name:      "negative weight",
+			shards:    2,
+			histogram: []KeyspaceIDWeight{{KeyspaceID: []byte{0x10}, Weight: -1}},
+			keyBytes:  1,
+			wantErr:   "invalid weight",
+		},
+		{
+			name:      "invalid key bytes",
+			shards:    2,
+			histogram: uniform,
+			keyBytes:  9,
+			wantErr:   "1 to 8 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateWeightedShardRanges(tt.shards, tt.histogram, tt.keyBytes)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestShardCalculatorForShardsGreaterThan512(t *testing.T) {
 	got, err := GenerateShardRanges(512)
 	assert.NoError(t, err)
@@ -20,10 +20,13 @@ package azblobbackupstorage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -88,6 +91,79 @@ var (
 			FlagName: "azblob_backup_parallelism",
 		},
 	)
+
+	// This is an optional file containing a SAS token to use instead of the account key
+	sasTokenFile = viperutil.Configure(
+		configKey("sas_token_file"),
+		viperutil.Options[string]{
+			FlagName: "azblob_backup_sas_token_file",
+		},
+	)
+
+	// This tells whether to authenticate with the managed identity of the host
+	useManagedIdentity = viperutil.Configure(
+		configKey("managed_identity.enabled"),
+		viperutil.Options[bool]{
+			FlagName: "azblob_backup_use_managed_identity",
+		},
+	)
+
+	// This is the client ID of a user-assigned managed identity
+	managedIdentityClientID = viperutil.Configure(
+		configKey("managed_identity.client_id"),
+		viperutil.Options[string]{
+			FlagName: "azblob_backup_managed_identity_client_id",
+		},
+	)
+
+	// This is the access tier of the uploaded blobs
+	accessTier = viperutil.Configure(
+		configKey("access_tier"),
+		viperutil.Options[string]{
+			FlagName: "azblob_backup_access_tier",
+		},
+	)
+
+	retryPolicy = viperutil.Configure(
+		configKey("retry.policy"),
+		viperutil.Options[string]{
+			Default:  "fixed",
+			FlagName: "azblob_backup_retry_policy",
+		},
+	)
+
+	maxTries = viperutil.Configure(
+		configKey("retry.max_tries"),
+		viperutil.Options[int]{
+			Default:  defaultRetryCount,
+			FlagName: "azblob_backup_max_tries",
+		},
+	)
+
+	tryTimeout = viperutil.Configure(
+		configKey("retry.try_timeout"),
+		viperutil.Options[time.Duration]{
+			// Per https://godoc.org/github.com/Azure/azure-storage-blob-go/azblob#RetryOptions
+			// this should be set to a very nigh number (they claim 60s per MB).
+			// That could end up being days so we are limiting this to four hours.
+			Default:  4 * time.Hour,
+			FlagName: "azblob_backup_try_timeout",
+		},
+	)
+
+	retryDelay = viperutil.Configure(
+		configKey("retry.delay"),
+		viperutil.Options[time.Duration]{
+			FlagName: "azblob_backup_retry_delay",
+		},
+	)
+
+	maxRetryDelay = viperutil.Configure(
+		configKey("retry.max_delay"),
+		viperutil.Options[time.Duration]{
+			FlagName: "azblob_backup_max_retry_delay",
+		},
+	)
 )
 
 const configKeyPrefix = "backup.storage.azblob"
@@ -101,8 +177,17 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.String("azblob_backup_storage_root", storageRoot.Default(), "Root prefix for all backup-related Azure Blobs; this should exclude both initial and trailing '/' (e.g. just 'a/b' not '/a/b/').")
 	fs.Int("azblob_backup_buffer_size", azBlobBufferSize.Default(), "The memory buffer size to use in bytes, per file or stripe, when streaming to Azure Blob Service.")
 	fs.Int("azblob_backup_parallelism", azBlobParallelism.Default(), "Azure Blob operation parallelism (requires extra memory when increased -- a multiple of azblob_backup_buffer_size).")
-
-	viperutil.BindFlags(fs, accountName, accountKeyFile, containerName, storageRoot, azBlobParallelism)
+	fs.String("azblob_backup_sas_token_file", sasTokenFile.Default(), "Path to a file containing a SAS token to authenticate with instead of the account key; if this flag is unset, the environment variable VT_AZBLOB_SAS_TOKEN will be used as the token itself (NOT a file path).")
+	fs.Bool("azblob_backup_use_managed_identity", useManagedIdentity.Default(), "Authenticate with the managed identity of the host instead of the account key.")
+	fs.String("azblob_backup_managed_identity_client_id", managedIdentityClientID.Default(), "Client ID of the user-assigned managed identity to authenticate with; if unset, the system-assigned managed identity is used.")
+	fs.String("azblob_backup_access_tier", accessTier.Default(), "Access tier of the uploaded backup blobs (Hot, Cool, Cold or Archive); if unset, the default access tier of the storage account is used.")
+	fs.String("azblob_backup_retry_policy", retryPolicy.Default(), "Retry policy of Azure Blob operations (fixed or exponential).")
+	fs.Int("azblob_backup_max_tries", maxTries.Default(), "Maximum number of tries of an Azure Blob operation, including the first one.")
+	fs.Duration("azblob_backup_try_timeout", tryTimeout.Default(), "Maximum time allowed for a single try of an Azure Blob operation.")
+	fs.Duration("azblob_backup_retry_delay", retryDelay.Default(), "Delay before retrying an Azure Blob operation, which grows up to azblob_backup_max_retry_delay with the exponential retry policy; if unset, the Azure SDK default is used.")
+	fs.Duration("azblob_backup_max_retry_delay", maxRetryDelay.Default(), "Maximum delay before retrying an Azure Blob operation; if unset, the Azure SDK default is used.")
+
+	viperutil.BindFlags(fs, accountName, accountKeyFile, containerName, storageRoot, azBlobParallelism, sasTokenFile, useManagedIdentity, managedIdentityClientID, accessTier, retryPolicy, maxTries, tryTimeout, retryDelay, maxRetryDelay)
 }
 
 func init() {
@@ -142,24 +227,220 @@ func azInternalCredentials() (string, string, error) {
 	return actName, actKey, nil
 }
 
-func azCredentials() (*azblob.SharedKeyCredential, error) {
+// azSASToken returns the SAS token from the file given with azblob_backup_sas_token_file,
+// or else from the VT_AZBLOB_SAS_TOKEN environment variable, if any.
+func azSASToken() (string, error) {
+	var token string
+	if tokenFile := sasTokenFile.Get(); tokenFile != "" {
+		log.Infof("Getting Azure Storage SAS token from file: %s", tokenFile)
+		dat, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", err
+		}
+		token = string(dat)
+	} else {
+		token = os.Getenv("VT_AZBLOB_SAS_TOKEN")
+	}
+	return strings.TrimPrefix(strings.TrimSpace(token), "?"), nil
+}
+
+// azCredentials returns the credentials to authenticate with, along with the SAS token
+// to add to the service URL, if any. We will use, in the following order:
+// 1. A SAS token (azblob_backup_sas_token_file or VT_AZBLOB_SAS_TOKEN)
+// 2. The managed identity of the host (azblob_backup_use_managed_identity)
+// 3. The account key (see azInternalCredentials)
+func azCredentials() (azblob.Credential, string, error) {
+	if accountName.Get() == "" {
+		return nil, "", fmt.Errorf("Azure Storage Account name not found in command-line flags or environment variables")
+	}
+
+	sasToken, err := azSASToken()
+	if err != nil {
+		return nil, "", err
+	}
+	if sasToken != "" {
+		return azblob.NewAnonymousCredential(), sasToken, nil
+	}
+
+	if useManagedIdentity.Get() {
+		credential, err := managedIdentityCredential(managedIdentityClientID.Get())
+		if err != nil {
+			return nil, "", err
+		}
+		return credential, "", nil
+	}
+
 	actName, actKey, err := azInternalCredentials()
+	if err != nil {
+		return nil, "", err
+	}
+	credential, err := azblob.NewSharedKeyCredential(actName, actKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return credential, "", nil
+}
+
+const (
+	// storageResource is the resource managed identity tokens are requested for.
+	storageResource = "https://storage.azure.com/"
+	// managedIdentityRefreshMargin is how long before its expiry a managed identity token is refreshed.
+	managedIdentityRefreshMargin = 5 * time.Minute
+	// managedIdentityRetryInterval is how long to wait before retrying to refresh a managed identity token.
+	managedIdentityRetryInterval = time.Minute
+)
+
+// imdsTokenEndpoint is the endpoint of the Azure Instance Metadata Service that issues
+// managed identity tokens. It is a variable so that tests can override it.
+var imdsTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// managedIdentityToken is the response of the Azure Instance Metadata Service token endpoint.
+type managedIdentityToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   string `json:"expires_in"`
+}
+
+// fetchManagedIdentityToken requests an access token to the storage service for the managed identity
+// with the given client ID, or for the system-assigned managed identity if the client ID is empty.
+// It returns the token and how long it is valid for.
+func fetchManagedIdentityToken(clientID string) (string, time.Duration, error) {
+	query := url.Values{}
+	query.Set("api-version", "2018-02-01")
+	query.Set("resource", storageResource)
+	if clientID != "" {
+		query.Set("client_id", clientID)
+	}
+	req, err := http.NewRequest(http.MethodGet, imdsTokenEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("cannot get managed identity token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", 0, fmt.Errorf("cannot get managed identity token: %s: %s", resp.Status, body)
+	}
+
+	var token managedIdentityToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", 0, fmt.Errorf("cannot decode managed identity token: %w", err)
+	}
+	expiresIn, err := strconv.Atoi(token.ExpiresIn)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid managed identity token expiry %q: %w", token.ExpiresIn, err)
+	}
+	return token.AccessToken, time.Duration(expiresIn) * time.Second, nil
+}
+
+var (
+	managedIdentityCredentialsMu sync.Mutex
+	// managedIdentityCredentials caches the credentials of the managed identities by client ID,
+	// so that their token is refreshed in the background rather than requested for each operation.
+	managedIdentityCredentials = map[string]azblob.TokenCredential{}
+)
+
+// managedIdentityCredential returns the cached credential of the managed identity with the
+// given client ID, creating it on first use.
+func managedIdentityCredential(clientID string) (azblob.TokenCredential, error) {
+	managedIdentityCredentialsMu.Lock()
+	defer managedIdentityCredentialsMu.Unlock()
+	if credential, ok := managedIdentityCredentials[clientID]; ok {
+		return credential, nil
+	}
+	credential, err := newManagedIdentityCredential(clientID)
 	if err != nil {
 		return nil, err
 	}
-	return azblob.NewSharedKeyCredential(actName, actKey)
+	managedIdentityCredentials[clientID] = credential
+	return credential, nil
+}
+
+// newManagedIdentityCredential returns a token credential for the managed identity with the
+// given client ID, which refreshes its token before it expires.
+func newManagedIdentityCredential(clientID string) (azblob.TokenCredential, error) {
+	token, expiresIn, err := fetchManagedIdentityToken(clientID)
+	if err != nil {
+		return nil, err
+	}
+	// The refresher is first called right away, so we skip that call and only refresh the token once
+	// it is about to expire.
+	first := true
+	return azblob.NewTokenCredential(token, func(credential azblob.TokenCredential) time.Duration {
+		if first {
+			first = false
+			return managedIdentityRefreshAfter(expiresIn)
+		}
+		token, expiresIn, err := fetchManagedIdentityToken(clientID)
+		if err != nil {
+			log.Errorf("Failed to refresh Azure managed identity token, retrying in %v: %v", managedIdentityRetryInterval, err)
+			return managedIdentityRetryInterval
+		}
+		credential.SetToken(token)
+		return managedIdentityRefreshAfter(expiresIn)
+	}), nil
+}
+
+// managedIdentityRefreshAfter returns how long to wait before refreshing a token valid for expiresIn.
+func managedIdentityRefreshAfter(expiresIn time.Duration) time.Duration {
+	if refreshAfter := expiresIn - managedIdentityRefreshMargin; refreshAfter > managedIdentityRetryInterval {
+		return refreshAfter
+	}
+	return managedIdentityRetryInterval
 }
 
-func azServiceURL(credentials *azblob.SharedKeyCredential) azblob.ServiceURL {
+// azRetryOptions returns the retry options of the Azure Blob pipeline from the azblob_backup_* flags.
+func azRetryOptions() (azblob.RetryOptions, error) {
+	opts := azblob.RetryOptions{
+		MaxTries:      int32(maxTries.Get()),
+		TryTimeout:    tryTimeout.Get(),
+		RetryDelay:    retryDelay.Get(),
+		MaxRetryDelay: maxRetryDelay.Get(),
+	}
+	switch policy := strings.ToLower(retryPolicy.Get()); policy {
+	case "fixed":
+		opts.Policy = azblob.RetryPolicyFixed
+	case "exponential":
+		opts.Policy = azblob.RetryPolicyExponential
+	default:
+		return opts, fmt.Errorf("invalid azblob_backup_retry_policy %q, expected fixed or exponential", policy)
+	}
+	if opts.MaxTries < 1 {
+		return opts, fmt.Errorf("invalid azblob_backup_max_tries %v, expected at least 1", opts.MaxTries)
+	}
+	// The Azure SDK requires either both or none of the delays to be set.
+	if (opts.RetryDelay == 0) != (opts.MaxRetryDelay == 0) || opts.RetryDelay > opts.MaxRetryDelay {
+		return opts, fmt.Errorf("azblob_backup_retry_delay (%v) and azblob_backup_max_retry_delay (%v) must both be set, with the former at most the latter", opts.RetryDelay, opts.MaxRetryDelay)
+	}
+	return opts, nil
+}
+
+// azAccessTier returns the access tier of the uploaded blobs from azblob_backup_access_tier.
+func azAccessTier() (azblob.AccessTierType, error) {
+	tier := accessTier.Get()
+	if tier == "" {
+		return azblob.AccessTierNone, nil
+	}
+	for _, t := range []azblob.AccessTierType{azblob.AccessTierHot, azblob.AccessTierCool, azblob.AccessTierType("Cold"), azblob.AccessTierArchive} {
+		if strings.EqualFold(tier, string(t)) {
+			return t, nil
+		}
+	}
+	return azblob.AccessTierNone, fmt.Errorf("invalid azblob_backup_access_tier %q, expected Hot, Cool, Cold or Archive", tier)
+}
+
+func azServiceURL(credentials azblob.Credential, sasToken string) (azblob.ServiceURL, error) {
+	retryOptions, err := azRetryOptions()
+	if err != nil {
+		return azblob.ServiceURL{}, err
+	}
 	pipeline := azblob.NewPipeline(credentials, azblob.PipelineOptions{
-		Retry: azblob.RetryOptions{
-			Policy:   azblob.RetryPolicyFixed,
-			MaxTries: defaultRetryCount,
-			// Per https://godoc.org/github.com/Azure/azure-storage-blob-go/azblob#RetryOptions
-			// this should be set to a very nigh number (they claim 60s per MB).
-			// That could end up being days so we are limiting this to four hours.
-			TryTimeout: 4 * time.Hour,
-		},
+		Retry: retryOptions,
 		Log: pipeline.LogOptions{
 			Log: func(level pipeline.LogLevel, message string) {
 				switch level {
@@ -189,11 +470,12 @@ func azServiceURL(credentials *azblob.SharedKeyCredential) azblob.ServiceURL {
 		},
 	})
 	u := url.URL{
-		Scheme: "https",
-		Host:   credentials.AccountName() + ".blob.core.windows.net",
-		Path:   "/",
+		Scheme:   "https",
+		Host:     accountName.Get() + ".blob.core.windows.net",
+		Path:     "/",
+		RawQuery: sasToken,
 	}
-	return azblob.NewServiceURL(u, pipeline)
+	return azblob.NewServiceURL(u, pipeline), nil
 }
 
 // AZBlobBackupHandle implements BackupHandle for Azure Blob service.
@@ -248,6 +530,10 @@ func (bh *AZBlobBackupHandle) AddFile(ctx context.Context, filename string, file
 	if err != nil {
 		return nil, err
 	}
+	tier, err := azAccessTier()
+	if err != nil {
+		return nil, err
+	}
 
 	blockBlobURL := containerURL.NewBlockBlobURL(obj)
 
@@ -257,8 +543,9 @@ func (bh *AZBlobBackupHandle) AddFile(ctx context.Context, filename string, file
 	go func() {
 		defer bh.waitGroup.Done()
 		_, err := azblob.UploadStreamToBlockBlob(bh.ctx, reader, blockBlobURL, azblob.UploadStreamToBlockBlobOptions{
-			BufferSize: azBlobBufferSize.Get(),
-			MaxBuffers: azBlobParallelism.Get(),
+			BufferSize:     azBlobBufferSize.Get(),
+			MaxBuffers:     azBlobParallelism.Get(),
+			BlobAccessTier: tier,
 		})
 		if err != nil {
 			reader.CloseWithError(err)
@@ -305,10 +592,13 @@ func (bh *AZBlobBackupHandle) ReadFile(ctx context.Context, filename string) (io
 
 	resp, err := blobURL.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
 	if err != nil {
+		if serr, ok := err.(azblob.StorageError); ok && serr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+			return nil, fmt.Errorf("%v: %w", err, os.ErrNotExist)
+		}
 		return nil, err
 	}
 	return resp.Body(azblob.RetryReaderOptions{
-		MaxRetryRequests: defaultRetryCount,
+		MaxRetryRequests: maxTries.Get(),
 		NotifyFailedRead: func(failureCount int, lastError error, offset int64, count int64, willRetry bool) {
 			log.Warningf("ReadFile: [azblob] container: %s, directory: %s, filename: %s, error: %v", containerName, objName(bh.dir, ""), filename, lastError)
 		},
@@ -321,11 +611,15 @@ type AZBlobBackupStorage struct {
 }
 
 func (bs *AZBlobBackupStorage) containerURL() (*azblob.ContainerURL, error) {
-	credentials, err := azCredentials()
+	credentials, sasToken, err := azCredentials()
+	if err != nil {
+		return nil, err
+	}
+	serviceURL, err := azServiceURL(credentials, sasToken)
 	if err != nil {
 		return nil, err
 	}
-	u := azServiceURL(credentials).NewContainerURL(containerName.Get())
+	u := serviceURL.NewContainerURL(containerName.Get())
 	return &u, nil
 }
 
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azblobbackupstorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchManagedIdentityToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") != storageResource {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("client_id") == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"access_token": "token", "expires_in": "3600", "token_type": "Bearer"}`))
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { imdsTokenEndpoint = endpoint }(imdsTokenEndpoint)
+	imdsTokenEndpoint = server.URL
+
+	token, expiresIn, err := fetchManagedIdentityToken("")
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, time.Hour, expiresIn)
+	assert.Equal(t, 55*time.Minute, managedIdentityRefreshAfter(expiresIn))
+
+	_, _, err = fetchManagedIdentityToken("unknown")
+	assert.Error(t, err)
+}
+
+func TestAzRetryOptions(t *testing.T) {
+	defer func() {
+		retryPolicy.Set(retryPolicy.Default())
+		retryDelay.Set(retryDelay.Default())
+		maxRetryDelay.Set(maxRetryDelay.Default())
+	}()
+
+	opts, err := azRetryOptions()
+	require.NoError(t, err)
+	assert.Equal(t, azblob.RetryPolicyFixed, opts.Policy)
+	assert.EqualValues(t, defaultRetryCount, opts.MaxTries)
+	assert.Equal(t, 4*time.Hour, opts.TryTimeout)
+
+	retryPolicy.Set("exponential")
+	retryDelay.Set(time.Second)
+	maxRetryDelay.Set(time.Minute)
+	opts, err = azRetryOptions()
+	require.NoError(t, err)
+	assert.Equal(t, azblob.RetryPolicyExponential, opts.Policy)
+	assert.Equal(t, time.Second, opts.RetryDelay)
+	assert.Equal(t, time.Minute, opts.MaxRetryDelay)
+
+	maxRetryDelay.Set(0)
+	_, err = azRetryOptions()
+	assert.Error(t, err)
+
+	retryPolicy.Set("linear")
+	_, err = azRetryOptions()
+	assert.Error(t, err)
+}
+
+func TestAzAccessTier(t *testing.T) {
+	defer accessTier.Set(accessTier.Default())
+
+	tier, err := azAccessTier()
+	require.NoError(t, err)
+	assert.Equal(t, azblob.AccessTierNone, tier)
+
+	accessTier.Set("cool")
+	tier, err = azAccessTier()
+	require.NoError(t, err)
+	assert.Equal(t, azblob.AccessTierCool, tier)
+
+	accessTier.Set("frozen")
+	_, err = azAccessTier()
+	assert.Error(t, err)
+}
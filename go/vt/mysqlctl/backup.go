@@ -51,6 +51,8 @@ const (
 
 	// backupManifestFileName is the MANIFEST file name within a backup.
 	backupManifestFileName = "MANIFEST"
+	// compressionDictionaryFileName is the name of the zstd dictionary file within a backup.
+	compressionDictionaryFileName = "COMPRESSION_DICTIONARY"
 	// RestoreState is the name of the sentinel file used to detect whether a previous restore
 	// terminated abnormally
 	RestoreState = "restore_in_progress"
@@ -128,11 +130,15 @@ func Backup(ctx context.Context, params BackupParams) error {
 	backupDir := GetBackupDir(params.Keyspace, params.Shard)
 	name := fmt.Sprintf("%v.%v", params.BackupTime.UTC().Format(BackupTimestampFormat), params.TabletAlias)
 	// Start the backup with the BackupStorage.
-	bs, err := backupstorage.GetBackupStorage()
-	if err != nil {
-		return vterrors.Wrap(err, "unable to get backup storage")
+	bs := params.BackupStorage
+	if bs == nil {
+		var err error
+		bs, err = backupstorage.GetBackupStorage()
+		if err != nil {
+			return vterrors.Wrap(err, "unable to get backup storage")
+		}
+		defer bs.Close()
 	}
-	defer bs.Close()
 
 	// Scope bsStats to selected storage engine.
 	bsStats := params.Stats.Scope(
@@ -151,6 +157,8 @@ func Backup(ctx context.Context, params BackupParams) error {
 		return vterrors.Wrap(err, "StartBackup failed")
 	}
 	params.Logger.Infof("Starting backup %v", bh.Name())
+	params.progress = newBackupProgress(params.Logger, params.ProgressReporter, builtinBackupProgress)
+	defer params.progress.finish()
 
 	// Scope stats to selected backup engine.
 	beParams := params.Copy()
@@ -364,11 +372,15 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 	startTs := time.Now()
 	// find the right backup handle: most recent one, with a MANIFEST
 	params.Logger.Infof("Restore: looking for a suitable backup to restore")
-	bs, err := backupstorage.GetBackupStorage()
-	if err != nil {
-		return nil, err
+	bs := params.BackupStorage
+	if bs == nil {
+		var err error
+		bs, err = backupstorage.GetBackupStorage()
+		if err != nil {
+			return nil, err
+		}
+		defer bs.Close()
 	}
-	defer bs.Close()
 
 	// Scope bsStats to selected storage engine.
 	bsStats := params.Stats.Scope(
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// ErrIncompleteBackup is returned by GetBackupDetails for a backup without a
+// MANIFEST, which is either still in progress or failed.
+var ErrIncompleteBackup = errors.New("backup has no MANIFEST")
+
+// BackupDetails are the details of a backup recorded in its MANIFEST, for
+// the engines which record them.
+type BackupDetails struct {
+	*BackupManifest
+
+	// Size is the total size of the files of the backup in the backup
+	// storage. It is 0 if the engine did not record it.
+	Size int64
+
+	// CompressionEngine is the engine the files were compressed with. It is
+	// empty if they were not compressed.
+	CompressionEngine string
+
+	// Encrypted is true if the files are encrypted.
+	Encrypted bool
+}
+
+// Duration returns how long the backup took, or 0 if the MANIFEST does not
+// record when it finished.
+func (d *BackupDetails) Duration() time.Duration {
+	start, err := time.Parse(time.RFC3339, d.BackupTime)
+	if err != nil {
+		return 0
+	}
+	finish, err := time.Parse(time.RFC3339, d.FinishedTime)
+	if err != nil || finish.Before(start) {
+		return 0
+	}
+	return finish.Sub(start)
+}
+
+// backupDetailsManifest decodes the fields of the MANIFEST files of all the
+// engines that BackupDetails reports.
+type backupDetailsManifest struct {
+	BackupManifest
+
+	CompressionEngine string
+	SkipCompress      bool
+	Encryption        *BackupEncryption
+	FileEntries       []FileEntry
+}
+
+// GetBackupDetails reads the details of a backup from its MANIFEST. It fails
+// with ErrIncompleteBackup if the backup has no MANIFEST.
+func GetBackupDetails(ctx context.Context, backup backupstorage.BackupHandle) (*BackupDetails, error) {
+	file, err := backup.ReadFile(ctx, backupManifestFileName)
+	if err != nil {
+		return nil, ErrIncompleteBackup
+	}
+	defer file.Close()
+
+	manifest := &backupDetailsManifest{}
+	if err := json.NewDecoder(file).Decode(manifest); err != nil {
+		return nil, vterrors.Wrap(err, "can't decode MANIFEST")
+	}
+
+	details := &BackupDetails{
+		BackupManifest: &manifest.BackupManifest,
+		Encrypted:      manifest.Encryption != nil,
+	}
+	if details.BackupMethod == "" {
+		// The builtin engine is the only one that ever left BackupMethod unset.
+		details.BackupMethod = builtinBackupEngineName
+	}
+	if !manifest.SkipCompress {
+		details.CompressionEngine = manifest.CompressionEngine
+		if details.CompressionEngine == "" {
+			// Backups taken before the engine was recorded used pgzip.
+			details.CompressionEngine = PgzipCompressor
+		}
+	}
+	for _, fe := range manifest.FileEntries {
+		details.Size += fe.Size
+	}
+	return details, nil
+}
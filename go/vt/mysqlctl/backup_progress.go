@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// Phases of a backup, as reported by its progress.
+const (
+	// BackupPhaseStarting is the phase before files are copied, e.g. while
+	// mysqld is shut down.
+	BackupPhaseStarting = "Starting"
+	// BackupPhaseCopyingFiles is the phase during which files are copied to
+	// the backup storage.
+	BackupPhaseCopyingFiles = "CopyingFiles"
+	// BackupPhaseFinishing is the phase after files are copied, e.g. while
+	// the MANIFEST is written and mysqld is restarted.
+	BackupPhaseFinishing = "Finishing"
+	// BackupPhaseDone is the phase of a backup that is over, whether it
+	// succeeded or not.
+	BackupPhaseDone = "Done"
+)
+
+var (
+	backupProgressPhases = []string{BackupPhaseStarting, BackupPhaseCopyingFiles, BackupPhaseFinishing, BackupPhaseDone}
+
+	backupProgressPhase          = stats.NewGaugesWithSingleLabel("BackupProgressPhase", "Current phase of the last backup taken by this process.", "phase")
+	backupProgressFilesTotal     = stats.NewGauge("BackupProgressFilesTotal", "How many files the last backup copies.")
+	backupProgressFilesRemaining = stats.NewGauge("BackupProgressFilesRemaining", "How many files the last backup has yet to copy.")
+	backupProgressBytesTotal     = stats.NewGauge("BackupProgressBytesTotal", "How many bytes the last backup copies.")
+	backupProgressBytesCopied    = stats.NewGauge("BackupProgressBytesCopied", "How many bytes the last backup has copied.")
+	backupProgressBytesPerSecond = stats.NewGaugeFloat64("BackupProgressBytesPerSecond", "Average throughput of the last backup since it started copying files, in bytes per second.")
+	backupProgressEtaSeconds     = stats.NewGauge("BackupProgressEtaSeconds", "Estimated time left to copy the files of the last backup, in seconds.")
+)
+
+// backupProgress tracks the progress of a backup. It exports it as stats and
+// reports it periodically, and whenever the phase changes. A nil
+// *backupProgress tracks nothing, so that backup engines don't need to check
+// whether they are given one.
+type backupProgress struct {
+	logger logutil.Logger
+	report func(*tabletmanagerdatapb.BackupProgress)
+
+	mu         sync.Mutex
+	phase      string
+	filesTotal int64
+	filesDone  int64
+	bytesTotal int64
+	copyStart  time.Time
+
+	bytesCopied atomic.Int64
+
+	done chan struct{}
+}
+
+// newBackupProgress starts tracking the progress of a backup, which is
+// reported to report, if not nil, every period. finish must be called once
+// the backup is over.
+func newBackupProgress(logger logutil.Logger, report func(*tabletmanagerdatapb.BackupProgress), period time.Duration) *backupProgress {
+	p := &backupProgress{
+		logger: logger,
+		report: report,
+		phase:  BackupPhaseStarting,
+		done:   make(chan struct{}),
+	}
+	p.update()
+	if period <= 0 {
+		return p
+	}
+
+	go func() {
+		tick := time.NewTicker(period)
+		defer tick.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-tick.C:
+				p.update()
+			}
+		}
+	}()
+	return p
+}
+
+// setPhase moves the backup to the given phase.
+func (p *backupProgress) setPhase(phase string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.phase = phase
+	p.mu.Unlock()
+	p.update()
+}
+
+// startCopy moves the backup to the BackupPhaseCopyingFiles phase, with the
+// given number of files and bytes to copy.
+func (p *backupProgress) startCopy(files int, bytes int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.filesTotal = int64(files)
+	p.bytesTotal = bytes
+	p.copyStart = time.Now()
+	p.mu.Unlock()
+	p.setPhase(BackupPhaseCopyingFiles)
+}
+
+// addBytesCopied has the signature of an ioutil meter callback, so that it can
+// count the bytes read from the files to copy.
+func (p *backupProgress) addBytesCopied(n int, _ time.Duration) {
+	if p == nil {
+		return
+	}
+	p.bytesCopied.Add(int64(n))
+}
+
+// fileCopied records that a file was completely copied.
+func (p *backupProgress) fileCopied() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.filesDone++
+	p.mu.Unlock()
+}
+
+// finish moves the backup to the BackupPhaseDone phase and stops reporting.
+func (p *backupProgress) finish() {
+	if p == nil {
+		return
+	}
+	p.setPhase(BackupPhaseDone)
+	close(p.done)
+}
+
+// snapshot returns the current progress of the backup.
+func (p *backupProgress) snapshot() *tabletmanagerdatapb.BackupProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	progress := &tabletmanagerdatapb.BackupProgress{
+		Phase:          p.phase,
+		FilesTotal:     p.filesTotal,
+		FilesRemaining: p.filesTotal - p.filesDone,
+		BytesTotal:     p.bytesTotal,
+		BytesCopied:    p.bytesCopied.Load(),
+	}
+	if p.copyStart.IsZero() {
+		return progress
+	}
+	elapsed := time.Since(p.copyStart)
+	if elapsed <= 0 || progress.BytesCopied == 0 {
+		return progress
+	}
+	progress.BytesPerSecond = float64(progress.BytesCopied) / elapsed.Seconds()
+	if remaining := progress.BytesTotal - progress.BytesCopied; remaining > 0 && p.phase == BackupPhaseCopyingFiles {
+		eta := time.Duration(float64(remaining) / progress.BytesPerSecond * float64(time.Second))
+		progress.Eta = protoutil.DurationToProto(eta)
+	}
+	return progress
+}
+
+// update exports the current progress as stats, and reports it.
+func (p *backupProgress) update() {
+	progress := p.snapshot()
+
+	for _, phase := range backupProgressPhases {
+		active := int64(0)
+		if phase == progress.Phase {
+			active = 1
+		}
+		backupProgressPhase.Set(phase, active)
+	}
+	backupProgressFilesTotal.Set(progress.FilesTotal)
+	backupProgressFilesRemaining.Set(progress.FilesRemaining)
+	backupProgressBytesTotal.Set(progress.BytesTotal)
+	backupProgressBytesCopied.Set(progress.BytesCopied)
+	backupProgressBytesPerSecond.Set(progress.BytesPerSecond)
+	eta, _, _ := protoutil.DurationFromProto(progress.Eta)
+	backupProgressEtaSeconds.Set(int64(eta.Seconds()))
+
+	if progress.Phase == BackupPhaseCopyingFiles {
+		p.logger.Infof("Backup progress: %v/%v files remaining, %v/%v bytes copied, %.0f bytes/s, ETA %v",
+			progress.FilesRemaining, progress.FilesTotal, progress.BytesCopied, progress.BytesTotal, progress.BytesPerSecond, eta.Round(time.Second))
+	}
+	if p.report != nil {
+		p.report(progress)
+	}
+}
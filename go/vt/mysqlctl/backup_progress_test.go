@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/logutil"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestBackupProgress(t *testing.T) {
+	// A nil backupProgress tracks nothing.
+	var none *backupProgress
+	none.startCopy(1, 1)
+	none.addBytesCopied(1, 0)
+	none.fileCopied()
+	none.setPhase(BackupPhaseFinishing)
+	none.finish()
+
+	var reported []*tabletmanagerdatapb.BackupProgress
+	p := newBackupProgress(logutil.NewMemoryLogger(), func(progress *tabletmanagerdatapb.BackupProgress) {
+		reported = append(reported, progress)
+	}, 0)
+	require.Len(t, reported, 1)
+	assert.Equal(t, BackupPhaseStarting, reported[0].Phase)
+	assert.Equal(t, int64(1), backupProgressPhase.Counts()[BackupPhaseStarting])
+
+	p.startCopy(4, 1000)
+	require.Len(t, reported, 2)
+	assert.Equal(t, BackupPhaseCopyingFiles, reported[1].Phase)
+	assert.Equal(t, int64(4), reported[1].FilesRemaining)
+	assert.Nil(t, reported[1].Eta)
+
+	// Pretend the copy started 10s ago.
+	p.mu.Lock()
+	p.copyStart = time.Now().Add(-10 * time.Second)
+	p.mu.Unlock()
+	p.addBytesCopied(200, 0)
+	p.addBytesCopied(50, 0)
+	p.fileCopied()
+
+	progress := p.snapshot()
+	assert.Equal(t, int64(4), progress.FilesTotal)
+	assert.Equal(t, int64(3), progress.FilesRemaining)
+	assert.Equal(t, int64(1000), progress.BytesTotal)
+	assert.Equal(t, int64(250), progress.BytesCopied)
+	assert.InDelta(t, 25, progress.BytesPerSecond, 1)
+	eta, ok, err := protoutil.DurationFromProto(progress.Eta)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.InDelta(t, 30, eta.Seconds(), 2)
+
+	p.update()
+	assert.Equal(t, int64(250), backupProgressBytesCopied.Get())
+	assert.Equal(t, int64(3), backupProgressFilesRemaining.Get())
+
+	// Once the files are copied, there is no ETA.
+	p.setPhase(BackupPhaseFinishing)
+	assert.Nil(t, reported[len(reported)-1].Eta)
+	assert.Equal(t, int64(0), backupProgressPhase.Counts()[BackupPhaseCopyingFiles])
+	assert.Equal(t, int64(1), backupProgressPhase.Counts()[BackupPhaseFinishing])
+
+	p.finish()
+	assert.Equal(t, BackupPhaseDone, reported[len(reported)-1].Phase)
+}
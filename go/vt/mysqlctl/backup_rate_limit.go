@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"math"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var (
+	// backupDiskRateLimit is the maximum rate, in bytes per second, of the
+	// local disk reads of backups and writes of restores.
+	backupDiskRateLimit int64
+	// backupStorageRateLimit is the maximum rate, in bytes per second, of the
+	// writes to the backup storage of backups and reads of restores.
+	backupStorageRateLimit int64
+)
+
+func init() {
+	for _, cmd := range []string{"vtbackup", "vttablet"} {
+		servenv.OnParseFor(cmd, registerBackupRateLimitFlags)
+	}
+}
+
+func registerBackupRateLimitFlags(fs *pflag.FlagSet) {
+	fs.Int64Var(&backupDiskRateLimit, "backup-disk-rate-limit", backupDiskRateLimit, "Maximum rate, in bytes per second, at which backups read files from the local disk, and restores write them, across all the files copied concurrently. 0 means unlimited. Only supported by the builtin and clone backup engines.")
+	fs.Int64Var(&backupStorageRateLimit, "backup-storage-rate-limit", backupStorageRateLimit, "Maximum rate, in bytes per second, at which backups write files to the backup storage, and restores read them, across all the files copied concurrently. The rate applies to the compressed and encrypted data. 0 means unlimited. Only supported by the builtin and clone backup engines.")
+}
+
+// newBackupRateLimiter returns a token bucket limiter, with one token per byte,
+// that allows bytesPerSecond with bursts of up to one second. It returns nil if
+// bytesPerSecond is not positive, which ioutil.NewThrottledReader and
+// ioutil.NewThrottledWriter take as unlimited.
+func newBackupRateLimiter(bytesPerSecond int64) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(min(bytesPerSecond, math.MaxInt32)))
+}
+
+// backupRateLimiters holds the limiters shared by the files copied by a backup
+// or a restore.
+type backupRateLimiters struct {
+	// disk limits the reads of the files to back up, and the writes of the
+	// restored files.
+	disk *rate.Limiter
+	// storage limits the writes to the backup storage, and the reads from it.
+	storage *rate.Limiter
+}
+
+// newBackupRateLimiters returns the limiters of a backup or a restore, as
+// configured by --backup-disk-rate-limit and --backup-storage-rate-limit.
+func newBackupRateLimiters() backupRateLimiters {
+	return backupRateLimiters{
+		disk:    newBackupRateLimiter(backupDiskRateLimit),
+		storage: newBackupRateLimiter(backupStorageRateLimit),
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// BackupRetentionPolicy decides which backups of a shard are kept when
+// pruning backups.
+//
+// Retention is decided for complete full backups. An incremental backup can
+// only be restored on top of the full backup it builds upon, so it is kept as
+// long as the last full backup taken before it is kept, and removed with it.
+// Incomplete backups are removed, unless they are more recent than every
+// complete backup, in which case they may still be in progress.
+type BackupRetentionPolicy struct {
+	// KeepFull is the number of most recent complete full backups to keep.
+	// It must be at least 1, so that the shard can always be restored.
+	KeepFull int
+	// KeepDailyDays, if nonzero, also keeps the most recent complete full
+	// backup of each of the last KeepDailyDays days, in UTC.
+	KeepDailyDays int
+}
+
+// Validate returns an error if the policy could remove every backup.
+func (p BackupRetentionPolicy) Validate() error {
+	if p.KeepFull < 1 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a backup retention policy must keep at least 1 full backup, got %v", p.KeepFull)
+	}
+	if p.KeepDailyDays < 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid number of days to keep daily backups: %v", p.KeepDailyDays)
+	}
+	return nil
+}
+
+// BackupPruning lists the backups that a BackupRetentionPolicy keeps and
+// removes, sorted by time like ListBackups.
+type BackupPruning struct {
+	Keep   []backupstorage.BackupHandle
+	Remove []backupstorage.BackupHandle
+}
+
+// PruneBackups removes the backups of dir that the policy does not keep. If
+// dryRun is true, it only returns the backups that would be removed.
+func PruneBackups(ctx context.Context, bs backupstorage.BackupStorage, dir string, policy BackupRetentionPolicy, dryRun bool, logger logutil.Logger) (*BackupPruning, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	bhs, err := bs.ListBackups(ctx, dir)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "ListBackups failed")
+	}
+	pruning, err := PlanBackupPruning(ctx, bhs, policy, time.Now(), logger)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return pruning, nil
+	}
+	for _, bh := range pruning.Remove {
+		logger.Infof("PruneBackups: removing backup %v/%v", dir, bh.Name())
+		if err := bs.RemoveBackup(ctx, dir, bh.Name()); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot remove backup %v/%v", dir, bh.Name())
+		}
+	}
+	return pruning, nil
+}
+
+// PlanBackupPruning applies the policy to the given backups, which must be
+// sorted by time like ListBackups returns them, as of now.
+func PlanBackupPruning(ctx context.Context, bhs []backupstorage.BackupHandle, policy BackupRetentionPolicy, now time.Time, logger logutil.Logger) (*BackupPruning, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	type backup struct {
+		bh          backupstorage.BackupHandle
+		time        time.Time
+		unknownTime bool
+		complete    bool
+		incremental bool
+		keep        bool
+		reason      string
+	}
+	backups := make([]*backup, 0, len(bhs))
+	lastComplete := -1
+	lastFull := -1
+	for i, bh := range bhs {
+		b := &backup{bh: bh}
+		backups = append(backups, b)
+		backupTime, _, err := ParseBackupName(bh.Directory(), bh.Name())
+		if err != nil || backupTime == nil {
+			// We can't tell how old it is, so we'd rather not remove it.
+			b.unknownTime = true
+			b.keep, b.reason = true, "unknown backup time"
+			continue
+		}
+		b.time = *backupTime
+		if manifest, err := GetBackupManifest(ctx, bh); err == nil {
+			b.complete, b.incremental = true, manifest.Incremental
+			lastComplete = i
+			if !b.incremental {
+				lastFull = i
+			}
+		}
+	}
+	if lastFull < 0 {
+		// Without a complete full backup, no backup can be restored, and
+		// it is not safe to decide what is needed.
+		logger.Warningf("PruneBackups: no complete full backup found, not removing any backup")
+		return &BackupPruning{Keep: bhs}, nil
+	}
+
+	// Decide which full backups to keep, from the most recent one.
+	keptFull := 0
+	days := make(map[string]bool)
+	for i := len(backups) - 1; i >= 0; i-- {
+		b := backups[i]
+		if b.unknownTime || !b.complete || b.incremental {
+			continue
+		}
+		if keptFull < policy.KeepFull {
+			keptFull++
+			b.keep, b.reason = true, "one of the most recent full backups"
+		}
+		day := b.time.UTC().Format(time.DateOnly)
+		if policy.KeepDailyDays > 0 && !days[day] && now.Sub(b.time) < time.Duration(policy.KeepDailyDays)*24*time.Hour {
+			days[day] = true
+			if !b.keep {
+				b.keep, b.reason = true, "most recent full backup of "+day
+			}
+		}
+	}
+
+	// Incremental backups follow the last full backup taken before them, and
+	// incomplete backups are kept if they may still be in progress.
+	var base *backup
+	for i, b := range backups {
+		switch {
+		case b.unknownTime:
+		case b.complete && !b.incremental:
+			base = b
+		case b.complete:
+			b.keep = base != nil && base.keep
+			b.reason = "incremental backup of a kept full backup"
+		case i > lastComplete:
+			b.keep, b.reason = true, "may still be in progress"
+		}
+	}
+
+	pruning := &BackupPruning{}
+	for _, b := range backups {
+		if b.keep {
+			logger.Infof("PruneBackups: keeping backup %v: %v", b.bh.Name(), b.reason)
+			pruning.Keep = append(pruning.Keep, b.bh)
+			continue
+		}
+		pruning.Remove = append(pruning.Remove, b.bh)
+	}
+	return pruning, nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+)
+
+// retentionTestBackup returns a backup taken at the given time. kind is one
+// of "full", "incremental" or "incomplete".
+func retentionTestBackup(backupTime string, kind string) backupstorage.BackupHandle {
+	t, err := time.Parse(time.DateTime, backupTime)
+	if err != nil {
+		panic(err)
+	}
+	return &FakeBackupHandle{
+		Dir:   "ks/0",
+		NameV: fmt.Sprintf("%s.zone1-0000000101", t.Format(BackupTimestampFormat)),
+		ReadFileReturnF: func(ctx context.Context, filename string) (io.ReadCloser, error) {
+			switch kind {
+			case "full":
+				return io.NopCloser(strings.NewReader(`{"Incremental": false}`)), nil
+			case "incremental":
+				return io.NopCloser(strings.NewReader(`{"Incremental": true}`)), nil
+			}
+			return nil, fmt.Errorf("no MANIFEST")
+		},
+	}
+}
+
+func TestPlanBackupPruning(t *testing.T) {
+	now, err := time.Parse(time.DateTime, "2023-10-11 12:00:00")
+	require.NoError(t, err)
+
+	bhs := []backupstorage.BackupHandle{
+		retentionTestBackup("2023-10-01 06:00:00", "incremental"),
+		retentionTestBackup("2023-10-08 06:00:00", "full"),
+		retentionTestBackup("2023-10-08 07:00:00", "incremental"),
+		retentionTestBackup("2023-10-08 18:00:00", "full"),
+		retentionTestBackup("2023-10-09 06:00:00", "incomplete"),
+		retentionTestBackup("2023-10-09 18:00:00", "full"),
+		retentionTestBackup("2023-10-10 06:00:00", "full"),
+		retentionTestBackup("2023-10-10 07:00:00", "incremental"),
+		retentionTestBackup("2023-10-10 18:00:00", "full"),
+		retentionTestBackup("2023-10-10 19:00:00", "incremental"),
+		retentionTestBackup("2023-10-11 06:00:00", "incomplete"),
+	}
+	names := func(bhs []backupstorage.BackupHandle) []string {
+		var names []string
+		for _, bh := range bhs {
+			names = append(names, bh.Name()[:17])
+		}
+		return names
+	}
+
+	tcases := []struct {
+		name         string
+		policy       BackupRetentionPolicy
+		expectKeep   []string
+		expectRemove []string
+	}{
+		{
+			name:   "keep full",
+			policy: BackupRetentionPolicy{KeepFull: 2},
+			expectKeep: []string{
+				"2023-10-10.060000",
+				"2023-10-10.070000",
+				"2023-10-10.180000",
+				"2023-10-10.190000",
+				"2023-10-11.060000",
+			},
+			expectRemove: []string{
+				"2023-10-01.060000",
+				"2023-10-08.060000",
+				"2023-10-08.070000",
+				"2023-10-08.180000",
+				"2023-10-09.060000",
+				"2023-10-09.180000",
+			},
+		},
+		{
+			name:   "keep dailies",
+			policy: BackupRetentionPolicy{KeepFull: 1, KeepDailyDays: 3},
+			expectKeep: []string{
+				"2023-10-08.180000",
+				"2023-10-09.180000",
+				"2023-10-10.180000",
+				"2023-10-10.190000",
+				"2023-10-11.060000",
+			},
+			expectRemove: []string{
+				"2023-10-01.060000",
+				"2023-10-08.060000",
+				"2023-10-08.070000",
+				"2023-10-09.060000",
+				"2023-10-10.060000",
+				"2023-10-10.070000",
+			},
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			pruning, err := PlanBackupPruning(context.Background(), bhs, tcase.policy, now, logutil.NewMemoryLogger())
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectKeep, names(pruning.Keep))
+			assert.Equal(t, tcase.expectRemove, names(pruning.Remove))
+		})
+	}
+
+	t.Run("no complete full backup", func(t *testing.T) {
+		bhs := []backupstorage.BackupHandle{
+			retentionTestBackup("2023-10-08 06:00:00", "incomplete"),
+			retentionTestBackup("2023-10-09 06:00:00", "incremental"),
+		}
+		pruning, err := PlanBackupPruning(context.Background(), bhs, BackupRetentionPolicy{KeepFull: 1}, now, logutil.NewMemoryLogger())
+		require.NoError(t, err)
+		assert.Equal(t, bhs, pruning.Keep)
+		assert.Empty(t, pruning.Remove)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := PlanBackupPruning(context.Background(), bhs, BackupRetentionPolicy{KeepDailyDays: 7}, now, logutil.NewMemoryLogger())
+		assert.ErrorContains(t, err, "must keep at least 1 full backup")
+	})
+}
+
+func TestPruneBackups(t *testing.T) {
+	bs := &FakeBackupStorage{}
+	bs.ListBackupsReturn.BackupHandles = []backupstorage.BackupHandle{
+		retentionTestBackup("2023-10-08 06:00:00", "full"),
+		retentionTestBackup("2023-10-09 06:00:00", "full"),
+	}
+
+	pruning, err := PruneBackups(context.Background(), bs, "ks/0", BackupRetentionPolicy{KeepFull: 1}, true, logutil.NewMemoryLogger())
+	require.NoError(t, err)
+	require.Len(t, pruning.Remove, 1)
+	assert.Empty(t, bs.RemoveBackupCalls)
+
+	pruning, err = PruneBackups(context.Background(), bs, "ks/0", BackupRetentionPolicy{KeepFull: 1}, false, logutil.NewMemoryLogger())
+	require.NoError(t, err)
+	require.Len(t, pruning.Remove, 1)
+	require.Len(t, bs.RemoveBackupCalls, 1)
+	assert.Equal(t, "ks/0", bs.RemoveBackupCalls[0].Dir)
+	assert.Equal(t, "2023-10-08.060000.zone1-0000000101", bs.RemoveBackupCalls[0].Name)
+}
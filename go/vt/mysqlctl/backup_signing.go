@@ -0,0 +1,300 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// backupManifestSignatureFileName is the file, within a backup, that
+	// holds the signature of its MANIFEST.
+	backupManifestSignatureFileName = "MANIFEST.sig"
+
+	// backupSignatureAlgorithm is the only supported signature algorithm.
+	backupSignatureAlgorithm = "HMAC-SHA256"
+
+	backupSigningKeySize = 32
+)
+
+var (
+	// backupSigningKMS is the name of the KMS that encrypts the signing keys of backups
+	backupSigningKMS string
+	// backupSigningKeyID is the ID of the KMS key that encrypts the signing keys of backups
+	backupSigningKeyID string
+	// backupSigningKeyFile is the path to a file holding the key that signs backups
+	backupSigningKeyFile string
+	// backupRequireSignature refuses to restore backups that are not signed
+	backupRequireSignature bool
+)
+
+func init() {
+	for _, cmd := range []string{"vtbackup", "vttablet"} {
+		servenv.OnParseFor(cmd, registerBackupSigningFlags)
+	}
+}
+
+func registerBackupSigningFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&backupSigningKMS, "backup-signing-kms", backupSigningKMS, "KMS used to encrypt the keys that sign the MANIFEST of backups: aws, gcp or vault. Each backup is signed with its own key, which is stored in the backup encrypted by the KMS. Only supported by the builtin and clone backup engines.")
+	fs.StringVar(&backupSigningKeyID, "backup-signing-key-id", backupSigningKeyID, "ID of the KMS key used to encrypt the signing keys of backups.")
+	fs.StringVar(&backupSigningKeyFile, "backup-signing-key-file", backupSigningKeyFile, "Path to a file holding a secret key of at least 32 bytes, used to sign the MANIFEST of backups, and to verify the signature of backups signed with it. Cannot be used with --backup-signing-kms. Only supported by the builtin and clone backup engines.")
+	fs.BoolVar(&backupRequireSignature, "backup-require-signature", backupRequireSignature, "Refuse to restore backups whose MANIFEST is not signed. Signed backups are always verified.")
+}
+
+// BackupSignature is the signature of the MANIFEST of a backup, stored in the
+// MANIFEST.sig file of the backup. The MANIFEST holds the SHA-256 checksum of
+// every file of the backup, so the signature covers the whole backup.
+type BackupSignature struct {
+	// Algorithm is the signature algorithm, HMAC-SHA256.
+	Algorithm string
+
+	// KMS is the name of the KMS implementation that encrypted the signing
+	// key. It is empty when the backup was signed with a key file.
+	KMS string `json:",omitempty"`
+
+	// KeyID identifies the KMS key that encrypted the signing key or, for a
+	// key file, the fingerprint of the key.
+	KeyID string
+
+	// EncryptedKey is the signing key, as encrypted by the KMS.
+	EncryptedKey []byte `json:",omitempty"`
+
+	// Signature is the HMAC-SHA256 of the MANIFEST.
+	Signature []byte
+}
+
+// readBackupSigningKeyFile reads the key of --backup-signing-key-file, and
+// returns it with its fingerprint.
+func readBackupSigningKeyFile() (key []byte, fingerprint string, err error) {
+	key, err = os.ReadFile(backupSigningKeyFile)
+	if err != nil {
+		return nil, "", vterrors.Wrap(err, "cannot read --backup-signing-key-file")
+	}
+	if len(key) < backupSigningKeySize {
+		return nil, "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "--backup-signing-key-file must hold at least %d bytes, got %d", backupSigningKeySize, len(key))
+	}
+	sum := sha256.Sum256(key)
+	return key, "sha256:" + hex.EncodeToString(sum[:8]), nil
+}
+
+// backupSigningEnabled returns true if backups are signed.
+func backupSigningEnabled() bool {
+	return backupSigningKMS != "" || backupSigningKeyFile != ""
+}
+
+// validateBackupSigningFlags checks the signing flags before a backup starts,
+// rather than once its files are copied.
+func validateBackupSigningFlags() error {
+	switch {
+	case backupSigningKMS != "" && backupSigningKeyFile != "":
+		return errors.New("--backup-signing-kms and --backup-signing-key-file are mutually exclusive")
+	case backupSigningKMS != "":
+		if backupSigningKeyID == "" {
+			return errors.New("--backup-signing-key-id is required with --backup-signing-kms")
+		}
+		_, err := backupencryption.GetKMS(backupSigningKMS)
+		return err
+	case backupSigningKeyFile != "":
+		_, _, err := readBackupSigningKeyFile()
+		return err
+	}
+	return nil
+}
+
+// signBackupManifest signs the given MANIFEST with the configured key. It
+// returns nil if backups are not signed.
+func signBackupManifest(ctx context.Context, manifest []byte) (*BackupSignature, error) {
+	if err := validateBackupSigningFlags(); err != nil {
+		return nil, err
+	}
+
+	sig := &BackupSignature{Algorithm: backupSignatureAlgorithm}
+	var key []byte
+	switch {
+	case backupSigningKMS != "":
+		kms, err := backupencryption.GetKMS(backupSigningKMS)
+		if err != nil {
+			return nil, err
+		}
+		key = make([]byte, backupSigningKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, vterrors.Wrap(err, "cannot generate signing key")
+		}
+		if sig.EncryptedKey, err = kms.Encrypt(ctx, backupSigningKeyID, key); err != nil {
+			return nil, err
+		}
+		sig.KMS = backupSigningKMS
+		sig.KeyID = backupSigningKeyID
+	case backupSigningKeyFile != "":
+		var err error
+		if key, sig.KeyID, err = readBackupSigningKeyFile(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(manifest)
+	sig.Signature = mac.Sum(nil)
+	return sig, nil
+}
+
+// verify checks that the signature is the one of the given MANIFEST.
+func (sig *BackupSignature) verify(ctx context.Context, manifest []byte) error {
+	if sig.Algorithm != backupSignatureAlgorithm {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "unsupported backup signature algorithm %q", sig.Algorithm)
+	}
+
+	var key []byte
+	if sig.KMS != "" {
+		kms, err := backupencryption.GetKMS(sig.KMS)
+		if err != nil {
+			return vterrors.Wrapf(err, "backup is signed with a key encrypted by %v KMS key %v", sig.KMS, sig.KeyID)
+		}
+		if key, err = kms.Decrypt(ctx, sig.KeyID, sig.EncryptedKey); err != nil {
+			return vterrors.Wrap(err, "cannot decrypt the signing key of the backup")
+		}
+	} else {
+		if backupSigningKeyFile == "" {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "backup is signed with key %v, but --backup-signing-key-file is not set", sig.KeyID)
+		}
+		var fingerprint string
+		var err error
+		if key, fingerprint, err = readBackupSigningKeyFile(); err != nil {
+			return err
+		}
+		if fingerprint != sig.KeyID {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "backup is signed with key %v, but --backup-signing-key-file holds key %v", sig.KeyID, fingerprint)
+		}
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(manifest)
+	if !hmac.Equal(mac.Sum(nil), sig.Signature) {
+		return vterrors.New(vtrpcpb.Code_DATA_LOSS, "the MANIFEST does not match its signature, the backup was tampered with or corrupted")
+	}
+	return nil
+}
+
+// writeBackupManifest writes the MANIFEST of a backup and, if backups are
+// signed, its signature. The signature is written first, so that a backup
+// with a MANIFEST always has its signature.
+func writeBackupManifest(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, manifest any) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot JSON encode %v", backupManifestFileName)
+	}
+
+	sig, err := signBackupManifest(ctx, data)
+	if err != nil {
+		return vterrors.Wrap(err, "cannot sign backup")
+	}
+	if sig != nil {
+		params.Logger.Infof("Signing backup MANIFEST with key %v", sig.KeyID)
+		sigData, err := json.MarshalIndent(sig, "", "  ")
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot JSON encode %v", backupManifestSignatureFileName)
+		}
+		if err := writeBackupFile(ctx, bh, backupManifestSignatureFileName, sigData, params.Logger); err != nil {
+			return err
+		}
+	}
+	return writeBackupFile(ctx, bh, backupManifestFileName, data, params.Logger)
+}
+
+func writeBackupFile(ctx context.Context, bh backupstorage.BackupHandle, name string, data []byte, logger logutil.Logger) (finalErr error) {
+	wc, err := bh.AddFile(ctx, name, int64(len(data)))
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot add %v to backup", name)
+	}
+	defer closeFile(wc, name, logger, &finalErr)
+
+	if _, err := wc.Write(data); err != nil {
+		return vterrors.Wrapf(err, "cannot write %v", name)
+	}
+	return nil
+}
+
+// getVerifiedBackupManifestInto fetches and decodes a MANIFEST file into the
+// specified object, like getBackupManifestInto, after verifying its signature
+// if the backup is signed. Unsigned backups are refused if
+// --backup-require-signature is set.
+func getVerifiedBackupManifestInto(ctx context.Context, bh backupstorage.BackupHandle, outManifest any, logger logutil.Logger) error {
+	data, err := readBackupFile(ctx, bh, backupManifestFileName)
+	if err != nil {
+		return err
+	}
+
+	// Only a missing signature means the backup is not signed, any other
+	// error could hide a signature that must be verified.
+	sigData, err := readBackupFile(ctx, bh, backupManifestSignatureFileName)
+	switch {
+	case err != nil && !errors.Is(vterrors.UnwrapAll(err), os.ErrNotExist):
+		return err
+	case err == nil:
+		var sig BackupSignature
+		if err := json.Unmarshal(sigData, &sig); err != nil {
+			return vterrors.Wrapf(err, "can't decode %v", backupManifestSignatureFileName)
+		}
+		if err := sig.verify(ctx, data); err != nil {
+			return vterrors.Wrapf(err, "cannot verify the signature of backup %v", bh.Name())
+		}
+		logger.Infof("Verified the signature of backup %v with key %v", bh.Name(), sig.KeyID)
+	case backupRequireSignature:
+		return vterrors.Wrapf(err, "backup %v is not signed, and --backup-require-signature is set", bh.Name())
+	default:
+		logger.Infof("Backup %v is not signed: %v", bh.Name(), err)
+	}
+
+	if err := json.Unmarshal(data, outManifest); err != nil {
+		return vterrors.Wrap(err, "can't decode MANIFEST")
+	}
+	return nil
+}
+
+func readBackupFile(ctx context.Context, bh backupstorage.BackupHandle, name string) ([]byte, error) {
+	rc, err := bh.ReadFile(ctx, name)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "can't read %v", name)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "can't read %v", name)
+	}
+	return data, nil
+}
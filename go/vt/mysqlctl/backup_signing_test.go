@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// signedTestBackup returns a backup handle holding the given MANIFEST and,
+// if not nil, its signature.
+func signedTestBackup(manifest []byte, sig *BackupSignature) *FakeBackupHandle {
+	return &FakeBackupHandle{
+		Dir:   "ks/0",
+		NameV: "2023-10-11.120000.zone1-0000000101",
+		ReadFileReturnF: func(ctx context.Context, filename string) (io.ReadCloser, error) {
+			switch {
+			case filename == backupManifestFileName:
+				return io.NopCloser(bytes.NewReader(manifest)), nil
+			case filename == backupManifestSignatureFileName && sig != nil:
+				data, err := json.Marshal(sig)
+				if err != nil {
+					return nil, err
+				}
+				return io.NopCloser(bytes.NewReader(data)), nil
+			}
+			return nil, fmt.Errorf("no file %v: %w", filename, os.ErrNotExist)
+		},
+	}
+}
+
+func TestBackupSigning(t *testing.T) {
+	backupencryption.KMSMap["fake"] = fakeKMS{}
+	defer delete(backupencryption.KMSMap, "fake")
+	defer func(kms, keyID, keyFile string, required bool) {
+		backupSigningKMS, backupSigningKeyID, backupSigningKeyFile, backupRequireSignature = kms, keyID, keyFile, required
+	}(backupSigningKMS, backupSigningKeyID, backupSigningKeyFile, backupRequireSignature)
+
+	ctx := context.Background()
+	logger := logutil.NewMemoryLogger()
+	manifest := []byte(`{"BackupMethod": "builtin", "Keyspace": "ks", "Shard": "0"}`)
+	tampered := []byte(`{"BackupMethod": "builtin", "Keyspace": "ks", "Shard": "1"}`)
+
+	// Not signed by default.
+	sig, err := signBackupManifest(ctx, manifest)
+	require.NoError(t, err)
+	assert.Nil(t, sig)
+
+	var bm BackupManifest
+	require.NoError(t, getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, nil), &bm, logger))
+	assert.Equal(t, "ks", bm.Keyspace)
+
+	// A signature that can't be read is not handled like a missing one.
+	unreadable := signedTestBackup(manifest, nil)
+	unreadable.ReadFileReturnF = func(ctx context.Context, filename string) (io.ReadCloser, error) {
+		if filename == backupManifestSignatureFileName {
+			return nil, os.ErrPermission
+		}
+		return signedTestBackup(manifest, nil).ReadFileReturnF(ctx, filename)
+	}
+	err = getVerifiedBackupManifestInto(ctx, unreadable, &bm, logger)
+	assert.ErrorIs(t, vterrors.UnwrapAll(err), os.ErrPermission)
+
+	backupRequireSignature = true
+	err = getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, nil), &bm, logger)
+	assert.ErrorContains(t, err, "is not signed, and --backup-require-signature is set")
+
+	t.Run("key file", func(t *testing.T) {
+		dir := t.TempDir()
+		key1 := path.Join(dir, "key1")
+		require.NoError(t, os.WriteFile(key1, bytes.Repeat([]byte("1"), 32), 0o600))
+		key2 := path.Join(dir, "key2")
+		require.NoError(t, os.WriteFile(key2, bytes.Repeat([]byte("2"), 32), 0o600))
+		short := path.Join(dir, "short")
+		require.NoError(t, os.WriteFile(short, []byte("short"), 0o600))
+
+		backupSigningKeyFile = short
+		assert.ErrorContains(t, validateBackupSigningFlags(), "must hold at least 32 bytes, got 5")
+
+		backupSigningKeyFile = key1
+		require.NoError(t, validateBackupSigningFlags())
+		sig, err := signBackupManifest(ctx, manifest)
+		require.NoError(t, err)
+		require.NotNil(t, sig)
+		assert.Empty(t, sig.KMS)
+		assert.Contains(t, sig.KeyID, "sha256:")
+
+		var bm BackupManifest
+		require.NoError(t, getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, sig), &bm, logger))
+		assert.Equal(t, "0", bm.Shard)
+
+		err = getVerifiedBackupManifestInto(ctx, signedTestBackup(tampered, sig), &bm, logger)
+		assert.ErrorContains(t, err, "the MANIFEST does not match its signature")
+
+		backupSigningKeyFile = key2
+		err = getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, sig), &bm, logger)
+		assert.ErrorContains(t, err, "but --backup-signing-key-file holds key")
+
+		backupSigningKeyFile = ""
+		err = getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, sig), &bm, logger)
+		assert.ErrorContains(t, err, "but --backup-signing-key-file is not set")
+	})
+
+	t.Run("KMS", func(t *testing.T) {
+		backupSigningKMS = "fake"
+		assert.ErrorContains(t, validateBackupSigningFlags(), "--backup-signing-key-id is required")
+
+		backupSigningKeyFile = "key"
+		assert.ErrorContains(t, validateBackupSigningFlags(), "mutually exclusive")
+		backupSigningKeyFile = ""
+
+		backupSigningKeyID = "key1"
+		require.NoError(t, validateBackupSigningFlags())
+		sig, err := signBackupManifest(ctx, manifest)
+		require.NoError(t, err)
+		require.NotNil(t, sig)
+		assert.Equal(t, "fake", sig.KMS)
+		assert.Equal(t, "key1", sig.KeyID)
+
+		// Verifying only needs the KMS, not the signing flags.
+		backupSigningKMS, backupSigningKeyID = "", ""
+		var bm BackupManifest
+		require.NoError(t, getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, sig), &bm, logger))
+
+		err = getVerifiedBackupManifestInto(ctx, signedTestBackup(tampered, sig), &bm, logger)
+		assert.ErrorContains(t, err, "the MANIFEST does not match its signature")
+
+		sig.KeyID = "key2"
+		err = getVerifiedBackupManifestInto(ctx, signedTestBackup(manifest, sig), &bm, logger)
+		assert.ErrorContains(t, err, "cannot decrypt the signing key of the backup")
+	})
+}
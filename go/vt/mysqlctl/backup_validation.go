@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstats"
+)
+
+// ValidateBackupParams are the parameters of a trial restore of a backup.
+type ValidateBackupParams struct {
+	// Cnf and Mysqld are those of the scratch MySQL instance the backup is
+	// restored into. All its data is deleted by the restore.
+	Cnf    *Mycnf
+	Mysqld MysqlDaemon
+	Logger logutil.Logger
+	// Concurrency is the number of files restored in parallel.
+	Concurrency int
+	// Extra env variables for pre-restore and post-restore transform hooks
+	HookExtraEnv map[string]string
+	// DbName is the name of the managed database / schema
+	DbName string
+	// Keyspace and Shard are used to infer the directory where backups are stored
+	Keyspace string
+	Shard    string
+	// StartTime: if non-zero, validate the backup that was taken at or before this time
+	// Otherwise, validate the most recent backup
+	StartTime time.Time
+	// ChecksumTables runs CHECKSUM TABLE on every table of DbName once restored.
+	ChecksumTables bool
+	// Stats let's restore engines report detailed restore timings.
+	Stats backupstats.Stats
+}
+
+// BackupValidation is the verdict of a trial restore of a backup.
+type BackupValidation struct {
+	// BackupName is the name of the validated backup.
+	BackupName string
+	// Position is the replication position recorded in the backup manifest.
+	Position replication.Position
+	// RestoredPosition is the GTID set executed by the restored MySQL instance.
+	RestoredPosition replication.Position
+	// TableChecksums maps the tables of the restored database to the result of
+	// CHECKSUM TABLE, if requested.
+	TableChecksums map[string]uint64
+	// Errors lists the problems found by the validation.
+	Errors []string
+}
+
+// Valid returns true if the backup was restored and no problem was found.
+func (v *BackupValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+func (v *BackupValidation) addError(format string, args ...any) {
+	v.Errors = append(v.Errors, fmt.Sprintf(format, args...))
+}
+
+// ValidateBackup restores a backup into a scratch MySQL instance and checks
+// that the restored data is consistent with the backup manifest, so that
+// backups are known to be restorable before they are needed.
+//
+// Problems with the backup itself, including a failed restore, are reported
+// in the returned BackupValidation. An error is only returned if the backup
+// could not be validated at all, e.g. because there is no backup. The scratch
+// mysqld is left running.
+func ValidateBackup(ctx context.Context, params ValidateBackupParams) (*BackupValidation, error) {
+	if params.Stats == nil {
+		params.Stats = backupstats.NoStats()
+	}
+
+	manifest, err := Restore(ctx, RestoreParams{
+		Cnf:                 params.Cnf,
+		Mysqld:              params.Mysqld,
+		Logger:              params.Logger,
+		Concurrency:         params.Concurrency,
+		HookExtraEnv:        params.HookExtraEnv,
+		DeleteBeforeRestore: true,
+		DbName:              params.DbName,
+		Keyspace:            params.Keyspace,
+		Shard:               params.Shard,
+		StartTime:           params.StartTime,
+		Stats:               params.Stats,
+	})
+	switch {
+	case err == ErrNoBackup:
+		return nil, err
+	case err != nil:
+		validation := &BackupValidation{}
+		validation.addError("restore failed: %v", err)
+		return validation, nil
+	}
+	return validateRestoredBackup(ctx, params, manifest), nil
+}
+
+// validateRestoredBackup checks the data restored from the backup with the
+// given manifest.
+func validateRestoredBackup(ctx context.Context, params ValidateBackupParams, manifest *BackupManifest) *BackupValidation {
+	validation := &BackupValidation{
+		BackupName: manifestBackupName(manifest),
+		Position:   manifest.Position,
+	}
+	params.Logger.Infof("ValidateBackup: checking restored backup %v", validation.BackupName)
+
+	// When restoring a full backup, @@gtid_executed is set to the position of
+	// the backup, so anything else means the restored data does not match it.
+	restoredPos, err := params.Mysqld.PrimaryPosition()
+	if err != nil {
+		validation.addError("cannot read the GTID position of the restored backup: %v", err)
+		return validation
+	}
+	validation.RestoredPosition = restoredPos
+	if !restoredPos.Equal(manifest.Position) {
+		validation.addError("restored GTID position %v does not match the backup position %v", restoredPos, manifest.Position)
+	}
+
+	if params.ChecksumTables {
+		checksums, err := checksumTables(ctx, params.Mysqld, params.DbName)
+		if err != nil {
+			validation.addError("cannot checksum the tables of %v: %v", params.DbName, err)
+		}
+		validation.TableChecksums = checksums
+		for table, checksum := range checksums {
+			params.Logger.Infof("ValidateBackup: table %v has checksum %v", table, checksum)
+		}
+	}
+
+	if validation.Valid() {
+		params.Logger.Infof("ValidateBackup: backup %v is valid", validation.BackupName)
+	} else {
+		params.Logger.Errorf("ValidateBackup: backup %v is not valid: %v", validation.BackupName, validation.Errors)
+	}
+	return validation
+}
+
+// checksumTables returns the CHECKSUM TABLE of every base table of dbName.
+// Reading every row of every table makes sure none of them is corrupted.
+func checksumTables(ctx context.Context, mysqld MysqlDaemon, dbName string) (map[string]uint64, error) {
+	qr, err := mysqld.FetchSuperQuery(ctx, fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = %s AND table_type = 'BASE TABLE' ORDER BY table_name", sqltypes.EncodeStringSQL(dbName)))
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]uint64, len(qr.Rows))
+	for _, row := range qr.Rows {
+		table := row[0].ToString()
+		cqr, err := mysqld.FetchSuperQuery(ctx, fmt.Sprintf("CHECKSUM TABLE %s.%s", sqlescape.EscapeID(dbName), sqlescape.EscapeID(table)))
+		if err != nil {
+			return checksums, err
+		}
+		// CHECKSUM TABLE returns NULL rather than an error for a table that
+		// cannot be read.
+		if len(cqr.Rows) != 1 || cqr.Rows[0][1].IsNull() {
+			return checksums, fmt.Errorf("table %v cannot be read", table)
+		}
+		checksum, err := cqr.Rows[0][1].ToUint64()
+		if err != nil {
+			return checksums, err
+		}
+		checksums[table] = checksum
+	}
+	return checksums, nil
+}
+
+// manifestBackupName returns the name of the backup with the given manifest,
+// which is derived from its time and the tablet that took it.
+func manifestBackupName(manifest *BackupManifest) string {
+	backupTime, err := ParseRFC3339(manifest.BackupTime)
+	if err != nil {
+		return manifest.BackupTime
+	}
+	return fmt.Sprintf("%v.%v", backupTime.UTC().Format(BackupTimestampFormat), manifest.TabletAlias)
+}
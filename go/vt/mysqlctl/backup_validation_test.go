@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+)
+
+func TestValidateRestoredBackup(t *testing.T) {
+	backupPos := replication.MustParsePosition(replication.Mysql56FlavorID, "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100")
+	manifest := &BackupManifest{
+		Position:    backupPos,
+		BackupTime:  "2023-10-11T12:13:14Z",
+		TabletAlias: "zone1-0000000101",
+	}
+	tablesQuery := "SELECT table_name FROM information_schema.tables WHERE table_schema = 'vt_ks' AND table_type = 'BASE TABLE' ORDER BY table_name"
+	tables := sqltypes.MakeTestResult(sqltypes.MakeTestFields("table_name", "varchar"), "t1", "t2")
+	checksumFields := sqltypes.MakeTestFields("Table|Checksum", "varchar|uint64")
+
+	tcases := []struct {
+		name             string
+		restoredPos      string
+		checksumTables   bool
+		queries          map[string]*sqltypes.Result
+		expectChecksums  map[string]uint64
+		expectErrorMatch string
+	}{
+		{
+			name:        "valid",
+			restoredPos: "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+		},
+		{
+			name:             "position mismatch",
+			restoredPos:      "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-90",
+			expectErrorMatch: "does not match the backup position",
+		},
+		{
+			name:           "checksums",
+			restoredPos:    "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+			checksumTables: true,
+			queries: map[string]*sqltypes.Result{
+				tablesQuery:                   tables,
+				"CHECKSUM TABLE `vt_ks`.`t1`": sqltypes.MakeTestResult(checksumFields, "vt_ks.t1|1234"),
+				"CHECKSUM TABLE `vt_ks`.`t2`": sqltypes.MakeTestResult(checksumFields, "vt_ks.t2|5678"),
+			},
+			expectChecksums: map[string]uint64{"t1": 1234, "t2": 5678},
+		},
+		{
+			name:           "unreadable table",
+			restoredPos:    "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+			checksumTables: true,
+			queries: map[string]*sqltypes.Result{
+				tablesQuery:                   tables,
+				"CHECKSUM TABLE `vt_ks`.`t1`": sqltypes.MakeTestResult(checksumFields, "vt_ks.t1|1234"),
+				"CHECKSUM TABLE `vt_ks`.`t2`": sqltypes.MakeTestResult(checksumFields, "vt_ks.t2|null"),
+			},
+			expectChecksums:  map[string]uint64{"t1": 1234},
+			expectErrorMatch: "table t2 cannot be read",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			mysqld := NewFakeMysqlDaemon(nil)
+			mysqld.CurrentPrimaryPosition = replication.MustParsePosition(replication.Mysql56FlavorID, tcase.restoredPos)
+			mysqld.FetchSuperQueryMap = tcase.queries
+
+			validation := validateRestoredBackup(context.Background(), ValidateBackupParams{
+				Mysqld:         mysqld,
+				Logger:         logutil.NewMemoryLogger(),
+				DbName:         "vt_ks",
+				ChecksumTables: tcase.checksumTables,
+			}, manifest)
+
+			assert.Equal(t, "2023-10-11.121314.zone1-0000000101", validation.BackupName)
+			assert.Equal(t, backupPos, validation.Position)
+			assert.Equal(t, mysqld.CurrentPrimaryPosition, validation.RestoredPosition)
+			assert.Equal(t, tcase.expectChecksums, validation.TableChecksums)
+			if tcase.expectErrorMatch == "" {
+				assert.True(t, validation.Valid())
+				assert.Empty(t, validation.Errors)
+				return
+			}
+			assert.False(t, validation.Valid())
+			require.Len(t, validation.Errors, 1)
+			assert.Contains(t, validation.Errors[0], tcase.expectErrorMatch)
+		})
+	}
+}
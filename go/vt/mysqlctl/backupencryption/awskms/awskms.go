@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package awskms implements the backupencryption.KMS interface with AWS KMS.
+//
+// The key ID is any key identifier accepted by AWS KMS: a key ID, a key ARN,
+// an alias name or an alias ARN. Credentials are found by the AWS SDK the same
+// way as for the S3 backup storage, e.g. from the environment, the shared
+// credentials file or the instance role.
+package awskms
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var (
+	// region is the AWS region of the KMS keys
+	region string
+
+	// endpoint overrides the AWS KMS endpoint
+	endpoint string
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&region, "backup-encryption-aws-kms-region", "", "AWS region of the KMS key used to encrypt backups. Defaults to the region configured for the AWS SDK.")
+	fs.StringVar(&endpoint, "backup-encryption-aws-kms-endpoint", "", "endpoint of the AWS KMS service, if not the default one of the region.")
+}
+
+func init() {
+	servenv.OnParseFor("vtbackup", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+
+	backupencryption.KMSMap["aws"] = &AWSKMS{}
+}
+
+// AWSKMS encrypts and decrypts data keys with AWS KMS.
+type AWSKMS struct {
+	mu      sync.Mutex
+	_client *kms.KMS
+}
+
+// Encrypt is part of the backupencryption.KMS interface.
+func (k *AWSKMS) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	client, err := k.client()
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot encrypt data key with AWS KMS key %v", keyID)
+	}
+	return out.CiphertextBlob, nil
+}
+
+// Decrypt is part of the backupencryption.KMS interface.
+func (k *AWSKMS) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	client, err := k.client()
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot decrypt data key with AWS KMS key %v", keyID)
+	}
+	return out.Plaintext, nil
+}
+
+func (k *AWSKMS) client() (*kms.KMS, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k._client == nil {
+		session, err := session.NewSession()
+		if err != nil {
+			return nil, vterrors.Wrap(err, "cannot create AWS session")
+		}
+
+		awsConfig := aws.NewConfig()
+		if region != "" {
+			awsConfig = awsConfig.WithRegion(region)
+		}
+		if endpoint != "" {
+			awsConfig = awsConfig.WithEndpoint(endpoint)
+		}
+		k._client = kms.New(session, awsConfig)
+	}
+	return k._client, nil
+}
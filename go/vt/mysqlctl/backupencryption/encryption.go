@@ -0,0 +1,264 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backupencryption contains the client-side envelope encryption of
+// backup files, and the interface of the KMS implementations that protect
+// the data keys.
+//
+// Each backup is encrypted with its own random data key. The data key is
+// encrypted by a KMS and stored, encrypted, in the backup manifest, so that
+// it can be decrypted again by the same KMS on restore.
+//
+// Files are encrypted with AES-256-GCM, in chunks of 64KiB. Each chunk is
+// sealed with a nonce made of a random per-file prefix, the index of the
+// chunk and a flag marking the last chunk, so that chunks can't be reordered,
+// dropped or truncated without the decryption failing.
+package backupencryption
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// DataKeySize is the size of the data keys, for AES-256.
+	DataKeySize = 32
+
+	formatVersion   = 1
+	chunkSize       = 64 * 1024
+	noncePrefixSize = 7
+	headerSize      = 1 + noncePrefixSize
+)
+
+// KMS encrypts and decrypts the data keys of backups with keys it manages.
+type KMS interface {
+	// Encrypt encrypts the plaintext data key with the given KMS key.
+	Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error)
+
+	// Decrypt decrypts a data key that was encrypted with the given KMS key.
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// KMSMap contains the registered implementations for KMS.
+var KMSMap = make(map[string]KMS)
+
+// GetKMS returns the KMS implementation registered with the given name.
+func GetKMS(name string) (KMS, error) {
+	kms, ok := KMSMap[name]
+	if !ok {
+		return nil, fmt.Errorf("no registered implementation of KMS %q", name)
+	}
+	return kms, nil
+}
+
+// NewDataKey returns a new random data key.
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != DataKeySize {
+		return nil, fmt.Errorf("invalid data key size %d, expected %d", len(dataKey), DataKeySize)
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce holds the nonce of the chunks of a file: the per-file prefix,
+// followed by the big endian index of the chunk and the last chunk flag.
+type chunkNonce [noncePrefixSize + 4 + 1]byte
+
+func (n *chunkNonce) set(index uint32, last bool) []byte {
+	binary.BigEndian.PutUint32(n[noncePrefixSize:], index)
+	n[len(n)-1] = 0
+	if last {
+		n[len(n)-1] = 1
+	}
+	return n[:]
+}
+
+type encryptingWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	nonce  chunkNonce
+	index  uint32
+	buf    []byte
+	sealed []byte
+	closed bool
+}
+
+// NewEncryptingWriter returns a writer that encrypts the data written to it
+// with the data key, and writes it to w. Close must be called to write the
+// last chunk; it does not close w.
+func NewEncryptingWriter(w io.Writer, dataKey []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	ew := &encryptingWriter{
+		w:      w,
+		aead:   aead,
+		buf:    make([]byte, 0, chunkSize),
+		sealed: make([]byte, 0, chunkSize+aead.Overhead()),
+	}
+	if _, err := rand.Read(ew.nonce[:noncePrefixSize]); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, formatVersion)
+	header = append(header, ew.nonce[:noncePrefixSize]...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+func (ew *encryptingWriter) Write(p []byte) (int, error) {
+	if ew.closed {
+		return 0, errors.New("write to closed encrypting writer")
+	}
+	n := 0
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data comes in, so that
+		// Close can always mark the last chunk as such.
+		if len(ew.buf) == chunkSize {
+			if err := ew.seal(false); err != nil {
+				return n, err
+			}
+		}
+		copied := copy(ew.buf[len(ew.buf):chunkSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+copied]
+		p = p[copied:]
+		n += copied
+	}
+	return n, nil
+}
+
+func (ew *encryptingWriter) seal(last bool) error {
+	if ew.index == ^uint32(0) {
+		return errors.New("too many chunks to encrypt")
+	}
+	ew.sealed = ew.aead.Seal(ew.sealed[:0], ew.nonce.set(ew.index, last), ew.buf, nil)
+	if _, err := ew.w.Write(ew.sealed); err != nil {
+		return err
+	}
+	ew.index++
+	ew.buf = ew.buf[:0]
+	return nil
+}
+
+// Close seals and writes the last chunk.
+func (ew *encryptingWriter) Close() error {
+	if ew.closed {
+		return nil
+	}
+	ew.closed = true
+	return ew.seal(true)
+}
+
+type decryptingReader struct {
+	r     *bufio.Reader
+	aead  cipher.AEAD
+	nonce chunkNonce
+	index uint32
+	in    []byte
+	out   []byte
+	plain []byte
+	done  bool
+}
+
+// NewDecryptingReader returns a reader that decrypts the data read from r,
+// which must have been written by a writer returned by NewEncryptingWriter
+// with the same data key.
+func NewDecryptingReader(r io.Reader, dataKey []byte) (io.Reader, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	dr := &decryptingReader{
+		r:    bufio.NewReader(r),
+		aead: aead,
+		in:   make([]byte, chunkSize+aead.Overhead()),
+		out:  make([]byte, 0, chunkSize),
+	}
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(dr.r, header); err != nil {
+		return nil, fmt.Errorf("cannot read encryption header: %w", err)
+	}
+	if header[0] != formatVersion {
+		return nil, fmt.Errorf("unsupported encryption format version %d", header[0])
+	}
+	copy(dr.nonce[:noncePrefixSize], header[1:])
+	return dr, nil
+}
+
+func (dr *decryptingReader) Read(p []byte) (int, error) {
+	for len(dr.plain) == 0 {
+		if dr.done {
+			return 0, io.EOF
+		}
+		if err := dr.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, dr.plain)
+	dr.plain = dr.plain[n:]
+	return n, nil
+}
+
+func (dr *decryptingReader) open() error {
+	n, err := io.ReadFull(dr.r, dr.in)
+	last := false
+	switch err {
+	case nil:
+		if _, err := dr.r.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		last = true
+	case io.EOF:
+		return fmt.Errorf("encrypted data is truncated after chunk %d", dr.index)
+	default:
+		return err
+	}
+
+	dr.out, err = dr.aead.Open(dr.out[:0], dr.nonce.set(dr.index, last), dr.in[:n], nil)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt chunk %d: %w", dr.index, err)
+	}
+	dr.index++
+	dr.plain = dr.out
+	dr.done = last
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupencryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encrypt(t *testing.T, dataKey []byte, data []byte) []byte {
+	var encrypted bytes.Buffer
+	ew, err := NewEncryptingWriter(&encrypted, dataKey)
+	require.NoError(t, err)
+	// Write in odd sized pieces to exercise the chunking.
+	for len(data) > 0 {
+		n := min(len(data), 10000)
+		_, err := ew.Write(data[:n])
+		require.NoError(t, err)
+		data = data[n:]
+	}
+	require.NoError(t, ew.Close())
+	return encrypted.Bytes()
+}
+
+func decrypt(dataKey []byte, encrypted []byte) ([]byte, error) {
+	dr, err := NewDecryptingReader(bytes.NewReader(encrypted), dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(dr)
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	dataKey, err := NewDataKey()
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize, 3*chunkSize + 12345} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		encrypted := encrypt(t, dataKey, data)
+		assert.False(t, size > 0 && bytes.Contains(encrypted, data), "data should not appear in the encrypted output")
+
+		decrypted, err := decrypt(dataKey, encrypted)
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, data, decrypted, "size %d", size)
+	}
+}
+
+func TestDecryptionFailures(t *testing.T) {
+	dataKey, err := NewDataKey()
+	require.NoError(t, err)
+	otherKey, err := NewDataKey()
+	require.NoError(t, err)
+
+	data := make([]byte, 2*chunkSize+100)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	encrypted := encrypt(t, dataKey, data)
+	overhead := (len(encrypted) - headerSize - len(data)) / 3
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := decrypt(otherKey, encrypted)
+		assert.ErrorContains(t, err, "cannot decrypt chunk 0")
+	})
+	t.Run("tampered", func(t *testing.T) {
+		tampered := bytes.Clone(encrypted)
+		tampered[headerSize+chunkSize+10] ^= 1
+		_, err := decrypt(dataKey, tampered)
+		assert.ErrorContains(t, err, "cannot decrypt chunk 0")
+	})
+	t.Run("truncated at chunk boundary", func(t *testing.T) {
+		_, err := decrypt(dataKey, encrypted[:headerSize+2*(chunkSize+overhead)])
+		assert.ErrorContains(t, err, "cannot decrypt chunk 1")
+	})
+	t.Run("truncated inside chunk", func(t *testing.T) {
+		_, err := decrypt(dataKey, encrypted[:len(encrypted)-1])
+		assert.ErrorContains(t, err, "cannot decrypt chunk 2")
+	})
+	t.Run("missing last chunk", func(t *testing.T) {
+		_, err := decrypt(dataKey, encrypted[:headerSize])
+		assert.ErrorContains(t, err, "encrypted data is truncated after chunk 0")
+	})
+	t.Run("invalid key size", func(t *testing.T) {
+		_, err := NewEncryptingWriter(io.Discard, dataKey[:16])
+		assert.ErrorContains(t, err, "invalid data key size 16")
+	})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcpkms implements the backupencryption.KMS interface with Google
+// Cloud KMS.
+//
+// The key ID is the resource name of a symmetric crypto key, i.e.
+// projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>.
+// Credentials are the application default credentials, as for the GCS backup
+// storage, unless a credentials file is given.
+package gcpkms
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var (
+	// credentialsFile is the path to a service account key file
+	credentialsFile string
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&credentialsFile, "backup-encryption-gcp-kms-credentials-file", "", "path to the service account key file used to access Google Cloud KMS. Defaults to the application default credentials.")
+}
+
+func init() {
+	servenv.OnParseFor("vtbackup", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+
+	backupencryption.KMSMap["gcp"] = &GCPKMS{}
+}
+
+// GCPKMS encrypts and decrypts data keys with Google Cloud KMS.
+type GCPKMS struct {
+	mu       sync.Mutex
+	_service *cloudkms.Service
+}
+
+// Encrypt is part of the backupencryption.KMS interface.
+func (k *GCPKMS) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	service, err := k.service(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.Projects.Locations.KeyRings.CryptoKeys.Encrypt(keyID, &cloudkms.EncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(plaintext),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot encrypt data key with Cloud KMS key %v", keyID)
+	}
+	return base64.StdEncoding.DecodeString(resp.Ciphertext)
+}
+
+// Decrypt is part of the backupencryption.KMS interface.
+func (k *GCPKMS) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	service, err := k.service(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.Projects.Locations.KeyRings.CryptoKeys.Decrypt(keyID, &cloudkms.DecryptRequest{
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot decrypt data key with Cloud KMS key %v", keyID)
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+func (k *GCPKMS) service(ctx context.Context) (*cloudkms.Service, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k._service == nil {
+		// The service outlives the context of the backup or restore that
+		// creates it, so we create a new context, but keep the span information.
+		ctx = trace.CopySpan(context.Background(), ctx)
+		opts := []option.ClientOption{option.WithScopes(cloudkms.CloudkmsScope)}
+		if credentialsFile != "" {
+			opts = append(opts, option.WithCredentialsFile(credentialsFile))
+		}
+		service, err := cloudkms.NewService(ctx, opts...)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "cannot create Cloud KMS client")
+		}
+		k._service = service
+	}
+	return k._service, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vaultkms implements the backupencryption.KMS interface with the
+// transit secrets engine of HashiCorp Vault.
+//
+// The key ID is the name of a transit key. The Vault client can also be
+// configured with the usual VAULT_* environment variables, which take
+// precedence over the flags.
+package vaultkms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	vaultapi "github.com/aquarapid/vaultlib"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var (
+	vaultAddr      string
+	vaultTimeout   = 10 * time.Second
+	vaultCACert    string
+	vaultTokenFile string
+	transitMount   = "transit"
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&vaultAddr, "backup-encryption-vault-addr", vaultAddr, "URL of the Vault server used to encrypt backups.")
+	fs.DurationVar(&vaultTimeout, "backup-encryption-vault-timeout", vaultTimeout, "timeout of the requests to the Vault server used to encrypt backups.")
+	fs.StringVar(&vaultCACert, "backup-encryption-vault-tls-ca", vaultCACert, "path to the CA certificate of the Vault server used to encrypt backups.")
+	fs.StringVar(&vaultTokenFile, "backup-encryption-vault-token-file", vaultTokenFile, "path to a file containing the Vault token used to encrypt backups.")
+	fs.StringVar(&transitMount, "backup-encryption-vault-transit-mount", transitMount, "mount path of the Vault transit secrets engine used to encrypt backups.")
+}
+
+func init() {
+	servenv.OnParseFor("vtbackup", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+
+	backupencryption.KMSMap["vault"] = &VaultKMS{}
+}
+
+// VaultKMS encrypts and decrypts data keys with the Vault transit secrets engine.
+type VaultKMS struct {
+	mu      sync.Mutex
+	_client *vaultapi.Client
+}
+
+type transitResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+}
+
+// Encrypt is part of the backupencryption.KMS interface.
+func (k *VaultKMS) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	resp, err := k.transit(ctx, "encrypt", keyID, map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot encrypt data key with Vault transit key %v", keyID)
+	}
+	if resp.Data.Ciphertext == "" {
+		return nil, fmt.Errorf("no ciphertext returned by Vault transit key %v", keyID)
+	}
+	// The ciphertext is an opaque string such as "vault:v1:...", which
+	// includes the version of the key it was encrypted with.
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+// Decrypt is part of the backupencryption.KMS interface.
+func (k *VaultKMS) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	resp, err := k.transit(ctx, "decrypt", keyID, map[string]string{
+		"ciphertext": string(ciphertext),
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot decrypt data key with Vault transit key %v", keyID)
+	}
+	return base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+}
+
+func (k *VaultKMS) transit(ctx context.Context, op string, keyID string, payload any) (*transitResponse, error) {
+	client, err := k.client()
+	if err != nil {
+		return nil, err
+	}
+
+	// The Vault client doesn't take a context, so we only check it upfront.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/v1/%s/%s/%s", strings.Trim(transitMount, "/"), op, url.PathEscape(keyID))
+	raw, err := client.RawRequest(http.MethodPost, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	resp := &transitResponse{}
+	if err := json.Unmarshal(raw, resp); err != nil {
+		return nil, vterrors.Wrap(err, "cannot parse Vault response")
+	}
+	return resp, nil
+}
+
+func (k *VaultKMS) client() (*vaultapi.Client, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k._client == nil {
+		// NewConfig reads the VAULT_* environment variables, which
+		// take precedence over the flags.
+		config := vaultapi.NewConfig()
+		if os.Getenv("VAULT_ADDR") == "" && vaultAddr != "" {
+			config.Address = vaultAddr
+		}
+		if os.Getenv("VAULT_CLIENT_TIMEOUT") == "" {
+			config.Timeout = vaultTimeout
+		}
+		if config.CACert == "" && vaultCACert != "" {
+			config.CACert = vaultCACert
+			config.InsecureSSL = false
+		}
+		if config.Token == "" && vaultTokenFile != "" {
+			token, err := os.ReadFile(vaultTokenFile)
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "cannot read Vault token file %v", vaultTokenFile)
+			}
+			config.Token = strings.TrimSpace(string(token))
+		}
+
+		client, err := vaultapi.NewClient(config)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "cannot create Vault client")
+		}
+		k._client = client
+	}
+	return k._client, nil
+}
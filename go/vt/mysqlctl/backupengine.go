@@ -77,6 +77,26 @@ type BackupParams struct {
 	Stats backupstats.Stats
 	// UpgradeSafe indicates whether the backup is safe for upgrade and created with innodb_fast_shutdown=0
 	UpgradeSafe bool
+	// CompressionEngine, CompressionLevel and CompressionDictionaryPath select how the backup files
+	// are compressed. When unset they default to --compression-engine-name, --compression-level and
+	// --compression-zstd-dictionary respectively.
+	CompressionEngine         string
+	CompressionLevel          int
+	CompressionDictionaryPath string
+	// ProgressReporter, if set, is called with the progress of the backup periodically, and
+	// whenever its phase changes.
+	ProgressReporter func(*tabletmanagerdatapb.BackupProgress)
+	// BackupStorage, if set, is where the backup is stored instead of the --backup_storage_implementation.
+	BackupStorage backupstorage.BackupStorage
+
+	// compressionDictionary is the content of CompressionDictionaryPath, loaded by resolveCompressionParams.
+	compressionDictionary []byte
+	// encryption holds the data key of the backup, set by resolveEncryptionParams if backups are encrypted.
+	encryption *BackupEncryption
+	// progress tracks the progress of the backup, set by Backup.
+	progress *backupProgress
+	// rateLimiters throttle the copy of the files, set by the backup engine.
+	rateLimiters backupRateLimiters
 }
 
 func (b *BackupParams) Copy() BackupParams {
@@ -94,6 +114,16 @@ func (b *BackupParams) Copy() BackupParams {
 		IncrementalFromPos: b.IncrementalFromPos,
 		Stats:              b.Stats,
 		UpgradeSafe:        b.UpgradeSafe,
+
+		CompressionEngine:         b.CompressionEngine,
+		CompressionLevel:          b.CompressionLevel,
+		CompressionDictionaryPath: b.CompressionDictionaryPath,
+		ProgressReporter:          b.ProgressReporter,
+		BackupStorage:             b.BackupStorage,
+		compressionDictionary:     b.compressionDictionary,
+		encryption:                b.encryption,
+		progress:                  b.progress,
+		rateLimiters:              b.rateLimiters,
 	}
 }
 
@@ -130,6 +160,12 @@ type RestoreParams struct {
 	DryRun bool
 	// Stats let's restore engines report detailed restore timings.
 	Stats backupstats.Stats
+	// BackupStorage, if set, is where the backup is restored from instead of the
+	// --backup_storage_implementation.
+	BackupStorage backupstorage.BackupStorage
+
+	// rateLimiters throttle the copy of the files, set by the restore engine.
+	rateLimiters backupRateLimiters
 }
 
 func (p *RestoreParams) Copy() RestoreParams {
@@ -148,6 +184,8 @@ func (p *RestoreParams) Copy() RestoreParams {
 		RestoreToTimestamp:  p.RestoreToTimestamp,
 		DryRun:              p.DryRun,
 		Stats:               p.Stats,
+		BackupStorage:       p.BackupStorage,
+		rateLimiters:        p.rateLimiters,
 	}
 }
 
@@ -189,7 +227,7 @@ func isIncrementalBackup(params BackupParams) bool {
 }
 
 func registerBackupEngineFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&backupEngineImplementation, "backup_engine_implementation", backupEngineImplementation, "Specifies which implementation to use for creating new backups (builtin or xtrabackup). Restores will always be done with whichever engine created a given backup.")
+	fs.StringVar(&backupEngineImplementation, "backup_engine_implementation", backupEngineImplementation, "Specifies which implementation to use for creating new backups (builtin, xtrabackup or clone). Restores will always be done with whichever engine created a given backup.")
 }
 
 // GetBackupEngine returns the BackupEngine implementation that should be used
@@ -87,6 +87,8 @@ type BackupHandle interface {
 	// Only works for read-only backups (created by ListBackups).
 	// The context is valid for the duration of the reads, until the
 	// ReadCloser is closed.
+	// If the file does not exist, the returned error wraps
+	// os.ErrNotExist.
 	ReadFile(ctx context.Context, filename string) (io.ReadCloser, error)
 
 	// concurrency.ErrorRecorder is embedded here to coordinate reporting and
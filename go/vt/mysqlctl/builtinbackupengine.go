@@ -19,8 +19,8 @@ package mysqlctl
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"hash"
@@ -43,6 +43,7 @@ import (
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
 	stats "vitess.io/vitess/go/vt/mysqlctl/backupstats"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/servenv"
@@ -80,6 +81,10 @@ var (
 	// engines during backups.  The backupstorage may be a physical file,
 	// network, or something else.
 	builtinBackupStorageWriteBufferSize = 2 * 1024 * 1024 /* 2 MiB */
+
+	// builtinBackupRestoreResume resumes interrupted restores of full backups,
+	// instead of deleting the files restored so far and starting over.
+	builtinBackupRestoreResume bool
 )
 
 // BuiltinBackupEngine encapsulates the logic of the builtin engine
@@ -101,6 +106,29 @@ type builtinBackupManifest struct {
 	// get a hint about what kind of compression was used.
 	CompressionEngine string `json:",omitempty"`
 
+	// CompressionLevel is the level the builtin compressor was run with. It is
+	// informational only, decompression does not depend on it.
+	CompressionLevel int `json:",omitempty"`
+
+	// CompressionDictionary is the name of the file, within the backup, that
+	// holds the zstd dictionary the files were compressed with. It is empty
+	// when no dictionary was used. Restores read the dictionary back from the
+	// backup, so it does not need to be available on the restoring tablet.
+	CompressionDictionary string `json:",omitempty"`
+
+	// CompressionDictionaryHash is the hash of the CompressionDictionary file.
+	CompressionDictionaryHash string `json:",omitempty"`
+
+	// CompressionDictionarySHA256 is the SHA-256 checksum of the
+	// CompressionDictionary file. It is empty for backups taken before it was
+	// added.
+	CompressionDictionarySHA256 string `json:",omitempty"`
+
+	// Encryption is set when the files of the backup, including the
+	// CompressionDictionary, are encrypted. It holds the encrypted data key
+	// and the KMS key to decrypt it with.
+	Encryption *BackupEncryption `json:",omitempty"`
+
 	// FileEntries contains all the files in the backup
 	FileEntries []FileEntry
 
@@ -141,6 +169,15 @@ type FileEntry struct {
 	// compressed if specified) stored in the BackupStorage.
 	Hash string
 
+	// SHA256 is the SHA-256 checksum of the same data as Hash. Unlike Hash,
+	// it can't be forged, so that signing the MANIFEST covers the files too.
+	// It is empty for backups taken before it was added.
+	SHA256 string `json:",omitempty"`
+
+	// Size is the size of the same data as Hash. It is 0 for backups taken
+	// before it was added.
+	Size int64 `json:",omitempty"`
+
 	// ParentPath is an optional prefix to the Base path. If empty, it is ignored. Useful
 	// for writing files in a temporary directory
 	ParentPath string
@@ -157,6 +194,7 @@ func registerBuiltinBackupEngineFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&builtinBackupProgress, "builtinbackup_progress", builtinBackupProgress, "how often to send progress updates when backing up large files.")
 	fs.UintVar(&builtinBackupFileReadBufferSize, "builtinbackup-file-read-buffer-size", builtinBackupFileReadBufferSize, "read files using an IO buffer of this many bytes. Golang defaults are used when set to 0.")
 	fs.UintVar(&builtinBackupFileWriteBufferSize, "builtinbackup-file-write-buffer-size", builtinBackupFileWriteBufferSize, "write files using an IO buffer of this many bytes. Golang defaults are used when set to 0.")
+	fs.BoolVar(&builtinBackupRestoreResume, "builtinbackup-restore-resume", builtinBackupRestoreResume, "resume an interrupted restore of a full backup by only restoring the files that were not completely restored, instead of starting over.")
 }
 
 // fullPath returns the full path of the entry, based on its type
@@ -208,6 +246,17 @@ func (be *BuiltinBackupEngine) ExecuteBackup(ctx context.Context, params BackupP
 	params.Logger.Infof("Executing Backup at %v for keyspace/shard %v/%v on tablet %v, concurrency: %v, compress: %v, incrementalFromPos: %v",
 		params.BackupTime, params.Keyspace, params.Shard, params.TabletAlias, params.Concurrency, backupStorageCompress, params.IncrementalFromPos)
 
+	if err := resolveCompressionParams(&params); err != nil {
+		return false, vterrors.Wrap(err, "invalid compression settings")
+	}
+	if err := resolveEncryptionParams(ctx, &params); err != nil {
+		return false, vterrors.Wrap(err, "cannot set up backup encryption")
+	}
+	if err := validateBackupSigningFlags(); err != nil {
+		return false, vterrors.Wrap(err, "invalid backup signing settings")
+	}
+	params.rateLimiters = newBackupRateLimiters()
+
 	if isIncrementalBackup(params) {
 		return be.executeIncrementalBackup(ctx, params, bh)
 	}
@@ -564,19 +613,69 @@ func (be *BuiltinBackupEngine) backupFiles(
 	incrDetails *IncrementalBackupDetails,
 ) (finalErr error) {
 	// Get the files to backup.
-	// We don't care about totalSize because we add each file separately.
+	// totalSize is only used to report progress, since we add each file separately.
 	var fes []FileEntry
+	var totalSize int64
 	var err error
 	if isIncrementalBackup(params) {
-		fes, _, err = binlogFilesToBackup(params.Cnf, binlogFiles)
+		fes, totalSize, err = binlogFilesToBackup(params.Cnf, binlogFiles)
 	} else {
-		fes, _, err = findFilesToBackup(params.Cnf)
+		fes, totalSize, err = findFilesToBackup(params.Cnf)
 	}
 	if err != nil {
 		return vterrors.Wrap(err, "can't find files to backup")
 	}
 	params.Logger.Infof("found %v files to backup", len(fes))
 
+	params.progress.startCopy(len(fes), totalSize)
+	if err := be.backupFileEntries(ctx, params, bh, fes); err != nil {
+		return err
+	}
+	params.progress.setPhase(BackupPhaseFinishing)
+
+	dictionaryName, dictionaryHash, dictionarySHA256, err := backupCompressionDictionary(ctx, params, bh)
+	if err != nil {
+		return err
+	}
+
+	// JSON-encode and write the MANIFEST
+	bm := &builtinBackupManifest{
+		// Common base fields
+		BackupManifest: BackupManifest{
+			BackupMethod:       builtinBackupEngineName,
+			Position:           backupPosition,
+			PurgedPosition:     purgedPosition,
+			FromPosition:       fromPosition,
+			FromBackup:         fromBackupName,
+			Incremental:        !fromPosition.IsZero(),
+			ServerUUID:         serverUUID,
+			TabletAlias:        params.TabletAlias,
+			Keyspace:           params.Keyspace,
+			Shard:              params.Shard,
+			BackupTime:         params.BackupTime.UTC().Format(time.RFC3339),
+			FinishedTime:       time.Now().UTC().Format(time.RFC3339),
+			MySQLVersion:       mysqlVersion,
+			UpgradeSafe:        params.UpgradeSafe,
+			IncrementalDetails: incrDetails,
+		},
+
+		// Builtin-specific fields
+		FileEntries:                 fes,
+		SkipCompress:                !backupStorageCompress,
+		CompressionEngine:           params.CompressionEngine,
+		CompressionLevel:            params.CompressionLevel,
+		CompressionDictionary:       dictionaryName,
+		CompressionDictionaryHash:   dictionaryHash,
+		CompressionDictionarySHA256: dictionarySHA256,
+		Encryption:                  params.encryption,
+		ExternalDecompressor:        ManifestExternalDecompressorCmd,
+	}
+	return writeBackupManifest(ctx, params, bh, bm)
+}
+
+// backupFileEntries backs up the given files with the provided concurrency, and
+// records their hash in the entries.
+func (be *BuiltinBackupEngine) backupFileEntries(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, fes []FileEntry) error {
 	// Backup with the provided concurrency.
 	sema := semaphore.NewWeighted(int64(params.Concurrency))
 	wg := sync.WaitGroup{}
@@ -612,7 +711,11 @@ func (be *BuiltinBackupEngine) backupFiles(
 
 			// Backup the individual file.
 			name := fmt.Sprintf("%v", i)
-			bh.RecordError(be.backupFile(ctx, params, bh, fe, name))
+			err := be.backupFile(ctx, params, bh, fe, name)
+			if err == nil {
+				params.progress.fileCopied()
+			}
+			bh.RecordError(err)
 		}(i)
 	}
 
@@ -629,55 +732,81 @@ func (be *BuiltinBackupEngine) backupFiles(
 	if bh.HasErrors() {
 		return bh.Error()
 	}
+	return nil
+}
+
+// backupCompressionDictionary stores the zstd dictionary the backup files were
+// compressed with, if any, in the backup. It returns the name and hash of the
+// stored file and its SHA-256 checksum, to be recorded in the manifest.
+func backupCompressionDictionary(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle) (name string, hash string, checksum string, finalErr error) {
+	if len(params.compressionDictionary) == 0 {
+		return "", "", "", nil
+	}
 
-	// open the MANIFEST
-	wc, err := bh.AddFile(ctx, backupManifestFileName, backupstorage.FileSizeUnknown)
+	params.Logger.Infof("Backing up compression dictionary %v", params.CompressionDictionaryPath)
+	wc, err := bh.AddFile(ctx, compressionDictionaryFileName, int64(len(params.compressionDictionary)))
 	if err != nil {
-		return vterrors.Wrapf(err, "cannot add %v to backup", backupManifestFileName)
+		return "", "", "", vterrors.Wrapf(err, "cannot add %v to backup", compressionDictionaryFileName)
 	}
-	defer func() {
-		closeErr := wc.Close()
-		if finalErr == nil {
-			finalErr = closeErr
+	defer closeFile(wc, compressionDictionaryFileName, params.Logger, &finalErr)
+
+	var writer io.Writer = wc
+	if params.encryption != nil {
+		encryptor, err := backupencryption.NewEncryptingWriter(wc, params.encryption.dataKey)
+		if err != nil {
+			return "", "", "", vterrors.Wrap(err, "can't create encryptor")
 		}
-	}()
+		defer closeFile(encryptor, compressionDictionaryFileName+" encryptor", params.Logger, &finalErr)
+		writer = encryptor
+	}
+	if _, err := writer.Write(params.compressionDictionary); err != nil {
+		return "", "", "", vterrors.Wrapf(err, "cannot write %v", compressionDictionaryFileName)
+	}
+	return compressionDictionaryFileName, compressionDictionaryHash(params.compressionDictionary), sha256Hex(params.compressionDictionary), nil
+}
 
-	// JSON-encode and write the MANIFEST
-	bm := &builtinBackupManifest{
-		// Common base fields
-		BackupManifest: BackupManifest{
-			BackupMethod:       builtinBackupEngineName,
-			Position:           backupPosition,
-			PurgedPosition:     purgedPosition,
-			FromPosition:       fromPosition,
-			FromBackup:         fromBackupName,
-			Incremental:        !fromPosition.IsZero(),
-			ServerUUID:         serverUUID,
-			TabletAlias:        params.TabletAlias,
-			Keyspace:           params.Keyspace,
-			Shard:              params.Shard,
-			BackupTime:         params.BackupTime.UTC().Format(time.RFC3339),
-			FinishedTime:       time.Now().UTC().Format(time.RFC3339),
-			MySQLVersion:       mysqlVersion,
-			UpgradeSafe:        params.UpgradeSafe,
-			IncrementalDetails: incrDetails,
-		},
+// restoreCompressionDictionary reads back the zstd dictionary recorded in the
+// manifest, if any, and verifies its hash.
+func restoreCompressionDictionary(ctx context.Context, bh backupstorage.BackupHandle, bm builtinBackupManifest, dataKey []byte) ([]byte, error) {
+	if bm.CompressionDictionary == "" {
+		return nil, nil
+	}
 
-		// Builtin-specific fields
-		FileEntries:          fes,
-		SkipCompress:         !backupStorageCompress,
-		CompressionEngine:    CompressionEngineName,
-		ExternalDecompressor: ManifestExternalDecompressorCmd,
+	rc, err := bh.ReadFile(ctx, bm.CompressionDictionary)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot read compression dictionary %v", bm.CompressionDictionary)
+	}
+	defer rc.Close()
+
+	var reader io.Reader = rc
+	if dataKey != nil {
+		reader, err = backupencryption.NewDecryptingReader(rc, dataKey)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "can't create decryptor")
+		}
 	}
-	data, err := json.MarshalIndent(bm, "", "  ")
+	dictionary, err := io.ReadAll(reader)
 	if err != nil {
-		return vterrors.Wrapf(err, "cannot JSON encode %v", backupManifestFileName)
+		return nil, vterrors.Wrapf(err, "cannot read compression dictionary %v", bm.CompressionDictionary)
+	}
+	if hash := compressionDictionaryHash(dictionary); hash != bm.CompressionDictionaryHash {
+		return nil, vterrors.Errorf(vtrpc.Code_DATA_LOSS, "compression dictionary %v hash mismatch: got %v, expected %v", bm.CompressionDictionary, hash, bm.CompressionDictionaryHash)
 	}
-	if _, err := wc.Write([]byte(data)); err != nil {
-		return vterrors.Wrapf(err, "cannot write %v", backupManifestFileName)
+	if checksum := sha256Hex(dictionary); bm.CompressionDictionarySHA256 != "" && checksum != bm.CompressionDictionarySHA256 {
+		return nil, vterrors.Errorf(vtrpc.Code_DATA_LOSS, "compression dictionary %v SHA-256 mismatch: got %v, expected %v", bm.CompressionDictionary, checksum, bm.CompressionDictionarySHA256)
 	}
+	return dictionary, nil
+}
 
-	return nil
+func compressionDictionaryHash(dictionary []byte) string {
+	h := crc32.NewIEEE()
+	_, _ = h.Write(dictionary)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
 
 type backupPipe struct {
@@ -688,6 +817,7 @@ type backupPipe struct {
 	w *bufio.Writer
 
 	crc32  hash.Hash32
+	sha256 hash.Hash
 	nn     int64
 	done   chan struct{}
 	closed int32
@@ -696,6 +826,7 @@ type backupPipe struct {
 func newBackupWriter(filename string, writerBufferSize int, maxSize int64, w io.Writer) *backupPipe {
 	return &backupPipe{
 		crc32:    crc32.NewIEEE(),
+		sha256:   sha256.New(),
 		w:        bufio.NewWriterSize(w, writerBufferSize),
 		filename: filename,
 		maxSize:  maxSize,
@@ -706,6 +837,7 @@ func newBackupWriter(filename string, writerBufferSize int, maxSize int64, w io.
 func newBackupReader(filename string, maxSize int64, r io.Reader) *backupPipe {
 	return &backupPipe{
 		crc32:    crc32.NewIEEE(),
+		sha256:   sha256.New(),
 		r:        r,
 		filename: filename,
 		done:     make(chan struct{}),
@@ -716,6 +848,7 @@ func newBackupReader(filename string, maxSize int64, r io.Reader) *backupPipe {
 func (bp *backupPipe) Read(p []byte) (int, error) {
 	nn, err := bp.r.Read(p)
 	_, _ = bp.crc32.Write(p[:nn])
+	_, _ = bp.sha256.Write(p[:nn])
 	atomic.AddInt64(&bp.nn, int64(nn))
 	return nn, err
 }
@@ -723,6 +856,7 @@ func (bp *backupPipe) Read(p []byte) (int, error) {
 func (bp *backupPipe) Write(p []byte) (int, error) {
 	nn, err := bp.w.Write(p)
 	_, _ = bp.crc32.Write(p[:nn])
+	_, _ = bp.sha256.Write(p[:nn])
 	atomic.AddInt64(&bp.nn, int64(nn))
 	return nn, err
 }
@@ -743,6 +877,11 @@ func (bp *backupPipe) HashString() string {
 	return hex.EncodeToString(bp.crc32.Sum(nil))
 }
 
+// SHA256String returns the SHA-256 checksum of the data, in hex.
+func (bp *backupPipe) SHA256String() string {
+	return hex.EncodeToString(bp.sha256.Sum(nil))
+}
+
 func (bp *backupPipe) ReportProgress(period time.Duration, logger logutil.Logger) {
 	tick := time.NewTicker(period)
 	defer tick.Stop()
@@ -782,14 +921,14 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 	}()
 
 	readStats := params.Stats.Scope(stats.Operation("Source:Read"))
-	timedSource := ioutil.NewMeteredReadCloser(source, readStats.TimedIncrementBytes)
+	timedSource := ioutil.NewMeteredReadCloser(source, readStats.TimedIncrementBytes, params.progress.addBytesCopied)
 
 	fi, err := source.Stat()
 	if err != nil {
 		return err
 	}
 
-	br := newBackupReader(fe.Name, fi.Size(), timedSource)
+	br := newBackupReader(fe.Name, fi.Size(), ioutil.NewThrottledReader(ctx, timedSource, params.rateLimiters.disk))
 	go br.ReportProgress(builtinBackupProgress, params.Logger)
 
 	// Open the destination file for writing, and a buffer.
@@ -814,7 +953,7 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 	destStats := params.Stats.Scope(stats.Operation("Destination:Write"))
 	timedDest := ioutil.NewMeteredWriteCloser(dest, destStats.TimedIncrementBytes)
 
-	bw := newBackupWriter(fe.Name, builtinBackupStorageWriteBufferSize, fi.Size(), timedDest)
+	bw := newBackupWriter(fe.Name, builtinBackupStorageWriteBufferSize, fi.Size(), ioutil.NewThrottledWriter(ctx, timedDest, params.rateLimiters.storage))
 
 	// We create the following inner function because:
 	// - we must `defer` the compressor's Close() function
@@ -823,13 +962,32 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 		var reader io.Reader = br
 		var writer io.Writer = bw
 
+		// Create the encryption pipe, if necessary. It must come after the
+		// compressor, since encrypted data doesn't compress.
+		if params.encryption != nil {
+			encryptor, err := backupencryption.NewEncryptingWriter(writer, params.encryption.dataKey)
+			if err != nil {
+				return vterrors.Wrap(err, "can't create encryptor")
+			}
+			writer = encryptor
+
+			defer func() {
+				// Close the encryptor to write the last chunk, after the compressor was flushed.
+				if cerr := encryptor.Close(); cerr != nil {
+					cerr = vterrors.Wrapf(cerr, "failed to close encryptor %v", name)
+					params.Logger.Error(cerr)
+					createAndCopyErr = errors.Join(createAndCopyErr, cerr)
+				}
+			}()
+		}
+
 		// Create the gzip compression pipe, if necessary.
 		if backupStorageCompress {
 			var compressor io.WriteCloser
 			if ExternalCompressorCmd != "" {
 				compressor, err = newExternalCompressor(ctx, ExternalCompressorCmd, writer, params.Logger)
 			} else {
-				compressor, err = newBuiltinCompressor(CompressionEngineName, writer, params.Logger)
+				compressor, err = newBuiltinCompressor(params.CompressionEngine, params.CompressionLevel, params.compressionDictionary, writer, params.Logger)
 			}
 			if err != nil {
 				return vterrors.Wrap(err, "can't create compressor")
@@ -878,24 +1036,42 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 		return vterrors.Wrap(err, "failed to close the source reader")
 	}
 
-	// Save the hash.
+	// Save the hashes.
 	fe.Hash = bw.HashString()
+	fe.SHA256 = bw.SHA256String()
+	fe.Size = atomic.LoadInt64(&bw.nn)
 	return nil
 }
 
 // executeRestoreFullBackup restores the files from a full backup. The underlying mysql database service is expected to be stopped.
-func (be *BuiltinBackupEngine) executeRestoreFullBackup(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest) error {
-	if err := prepareToRestore(ctx, params.Cnf, params.Mysqld, params.Logger); err != nil {
+// If resume is true, the files that an interrupted restore of the same backup completely restored are kept.
+func (be *BuiltinBackupEngine) executeRestoreFullBackup(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest, resume bool) error {
+	progress, err := openRestoreProgress(params.Cnf, path.Join(bh.Directory(), bh.Name()), resume)
+	if err != nil {
+		return vterrors.Wrap(err, "failed to open restore progress")
+	}
+	defer progress.close()
+
+	if progress.resumed() {
+		params.Logger.Infof("Restore: resuming interrupted restore, %v of %v files were already restored", progress.doneCount(bm.FileEntries, params.Cnf), len(bm.FileEntries))
+		params.Logger.Infof("Restore: shutdown mysqld")
+		if err := params.Mysqld.Shutdown(ctx, params.Cnf, true); err != nil {
+			return err
+		}
+	} else if err := prepareToRestore(ctx, params.Cnf, params.Mysqld, params.Logger); err != nil {
 		return err
 	}
 
 	params.Logger.Infof("Restore: copying %v files", len(bm.FileEntries))
 
-	if _, err := be.restoreFiles(ctx, params, bh, bm); err != nil {
+	if _, err := be.restoreFiles(ctx, params, bh, bm, progress); err != nil {
 		// don't delete the file here because that is how we detect an interrupted restore
 		return vterrors.Wrap(err, "failed to restore files")
 	}
-	return nil
+
+	// mysqld is about to be started on the restored files, which are then no
+	// longer those of the backup, so a later restore has to start over.
+	return progress.remove()
 }
 
 // executeRestoreIncrementalBackup executes a restore of an incremental backup, and expect to run on top of a full backup's restore.
@@ -904,7 +1080,7 @@ func (be *BuiltinBackupEngine) executeRestoreFullBackup(ctx context.Context, par
 // The underlying mysql database is expected to be up and running.
 func (be *BuiltinBackupEngine) executeRestoreIncrementalBackup(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest) error {
 	params.Logger.Infof("Restoring incremental backup to position: %v", bm.Position)
-	createdDir, err := be.restoreFiles(ctx, params, bh, bm)
+	createdDir, err := be.restoreFiles(ctx, params, bh, bm, nil)
 	defer os.RemoveAll(createdDir)
 	mysqld, ok := params.Mysqld.(*Mysqld)
 	if !ok {
@@ -944,9 +1120,13 @@ func (be *BuiltinBackupEngine) executeRestoreIncrementalBackup(ctx context.Conte
 func (be *BuiltinBackupEngine) ExecuteRestore(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle) (*BackupManifest, error) {
 
 	var bm builtinBackupManifest
-	if err := getBackupManifestInto(ctx, bh, &bm); err != nil {
+	if err := getVerifiedBackupManifestInto(ctx, bh, &bm, params.Logger); err != nil {
 		return nil, err
 	}
+	params.rateLimiters = newBackupRateLimiters()
+
+	// A full restore can only be resumed if a previous one was interrupted.
+	resume := builtinBackupRestoreResume && RestoreWasInterrupted(params.Cnf)
 
 	// mark restore as in progress
 	if err := createStateFile(params.Cnf); err != nil {
@@ -957,7 +1137,7 @@ func (be *BuiltinBackupEngine) ExecuteRestore(ctx context.Context, params Restor
 	if bm.Incremental {
 		err = be.executeRestoreIncrementalBackup(ctx, params, bh, bm)
 	} else {
-		err = be.executeRestoreFullBackup(ctx, params, bh, bm)
+		err = be.executeRestoreFullBackup(ctx, params, bh, bm, resume)
 	}
 	if err != nil {
 		return nil, err
@@ -967,8 +1147,10 @@ func (be *BuiltinBackupEngine) ExecuteRestore(ctx context.Context, params Restor
 }
 
 // restoreFiles will copy all the files from the BackupStorage to the
-// right place.
-func (be *BuiltinBackupEngine) restoreFiles(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest) (createdDir string, err error) {
+// right place. Files are restored by params.Concurrency workers. If progress
+// is not nil, the files it lists as done are skipped, and the restored ones are
+// added to it.
+func (be *BuiltinBackupEngine) restoreFiles(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest, progress *restoreProgress) (createdDir string, err error) {
 	// For optimization, we are replacing pargzip with pgzip, so newBuiltinDecompressor doesn't have to compare and print warning for every file
 	// since newBuiltinDecompressor is helper method and does not hold any state, it was hard to do it in that method itself.
 	if bm.CompressionEngine == PargzipCompressor {
@@ -979,6 +1161,15 @@ func (be *BuiltinBackupEngine) restoreFiles(ctx context.Context, params RestoreP
 		}()
 	}
 
+	dataKey, err := bm.Encryption.decryptDataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	dictionary, err := restoreCompressionDictionary(ctx, bh, bm, dataKey)
+	if err != nil {
+		return "", err
+	}
+
 	if bm.Incremental {
 		createdDir, err = os.MkdirTemp("", "restore-incremental-*")
 		if err != nil {
@@ -986,55 +1177,69 @@ func (be *BuiltinBackupEngine) restoreFiles(ctx context.Context, params RestoreP
 		}
 	}
 	fes := bm.FileEntries
-	sema := semaphore.NewWeighted(int64(params.Concurrency))
+	workers := params.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(fes) {
+		workers = len(fes)
+	}
+
+	// Rather than starting a goroutine per file, which all wait for their turn,
+	// a bounded number of workers pick the next file to restore in order.
+	indexes := make(chan int)
 	rec := concurrency.AllErrorRecorder{}
 	wg := sync.WaitGroup{}
-	for i := range fes {
+	for w := 0; w < workers; w++ {
 		wg.Add(1)
-		go func(i int) {
+		go func() {
 			defer wg.Done()
-			fe := &fes[i]
-			// Wait until we are ready to go, return if we encounter an error
-			acqErr := sema.Acquire(ctx, 1)
-			if acqErr != nil {
-				log.Errorf("Unable to acquire semaphore needed to restore file: %s, err: %s", fe.Name, acqErr.Error())
-				rec.RecordError(acqErr)
-				return
-			}
-			defer sema.Release(1)
-			// Check for context cancellation explicitly because, the way semaphore code is written, theoretically we might
-			// end up not throwing an error even after cancellation. Please see https://cs.opensource.google/go/x/sync/+/refs/tags/v0.1.0:semaphore/semaphore.go;l=66,
-			// which suggests that if the context is already done, `Acquire()` may still succeed without blocking. This introduces
-			// unpredictability in my test cases, so in order to avoid that, I am adding this cancellation check.
-			select {
-			case <-ctx.Done():
-				log.Errorf("Context canceled or timed out during %q restore", fe.Name)
-				rec.RecordError(vterrors.Errorf(vtrpc.Code_CANCELED, "context canceled"))
-				return
-			default:
-			}
+			for i := range indexes {
+				fe := &fes[i]
+				// Check for context cancellation explicitly, so that no new file
+				// is restored once the context is done.
+				select {
+				case <-ctx.Done():
+					log.Errorf("Context canceled or timed out during %q restore", fe.Name)
+					rec.RecordError(vterrors.Errorf(vtrpc.Code_CANCELED, "context canceled"))
+					continue
+				default:
+				}
 
-			if rec.HasErrors() {
-				params.Logger.Infof("Failed to restore files due to error.")
-				return
-			}
+				if rec.HasErrors() {
+					params.Logger.Infof("Failed to restore files due to error.")
+					continue
+				}
 
-			fe.ParentPath = createdDir
-			// And restore the file.
-			name := fmt.Sprintf("%v", i)
-			params.Logger.Infof("Copying file %v: %v", name, fe.Name)
-			err := be.restoreFile(ctx, params, bh, fe, bm, name)
-			if err != nil {
-				rec.RecordError(vterrors.Wrapf(err, "can't restore file %v to %v", name, fe.Name))
+				fe.ParentPath = createdDir
+				name := fmt.Sprintf("%v", i)
+				if progress.isDone(params.Cnf, fe) {
+					params.Logger.Infof("Skipping file %v: %v, already restored", name, fe.Name)
+					continue
+				}
+
+				// And restore the file.
+				params.Logger.Infof("Copying file %v: %v", name, fe.Name)
+				if err := be.restoreFile(ctx, params, bh, fe, bm, dataKey, dictionary, name); err != nil {
+					rec.RecordError(vterrors.Wrapf(err, "can't restore file %v to %v", name, fe.Name))
+					continue
+				}
+				if err := progress.markDone(params.Cnf, fe); err != nil {
+					rec.RecordError(vterrors.Wrapf(err, "can't record restore progress of file %v", fe.Name))
+				}
 			}
-		}(i)
+		}()
 	}
+	for i := range fes {
+		indexes <- i
+	}
+	close(indexes)
 	wg.Wait()
 	return createdDir, rec.Error()
 }
 
 // restoreFile restores an individual file.
-func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, fe *FileEntry, bm builtinBackupManifest, name string) (finalErr error) {
+func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, fe *FileEntry, bm builtinBackupManifest, dataKey []byte, dictionary []byte, name string) (finalErr error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	// Open the source file for reading.
@@ -1054,7 +1259,7 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 		params.Stats.Scope(stats.Operation("Source:Close")).TimedIncrement(time.Since(closeSourceAt))
 	}()
 
-	br := newBackupReader(name, 0, timedSource)
+	br := newBackupReader(name, 0, ioutil.NewThrottledReader(ctx, timedSource, params.rateLimiters.storage))
 	go br.ReportProgress(builtinBackupProgress, params.Logger)
 	var reader io.Reader = br
 
@@ -1077,7 +1282,15 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 	writeStats := params.Stats.Scope(stats.Operation("Destination:Write"))
 	timedDest := ioutil.NewMeteredWriter(dest, writeStats.TimedIncrementBytes)
 
-	bufferedDest := bufio.NewWriterSize(timedDest, int(builtinBackupFileWriteBufferSize))
+	bufferedDest := bufio.NewWriterSize(ioutil.NewThrottledWriter(ctx, timedDest, params.rateLimiters.disk), int(builtinBackupFileWriteBufferSize))
+
+	// Create the decryptor if needed.
+	if dataKey != nil {
+		reader, err = backupencryption.NewDecryptingReader(reader, dataKey)
+		if err != nil {
+			return vterrors.Wrap(err, "can't create decryptor")
+		}
+	}
 
 	// Create the uncompresser if needed.
 	if !bm.SkipCompress {
@@ -1097,13 +1310,13 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 				deCompressionEngine = externalDecompressorCmd
 				decompressor, err = newExternalDecompressor(ctx, deCompressionEngine, reader, params.Logger)
 			} else {
-				decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, params.Logger)
+				decompressor, err = newBuiltinDecompressor(deCompressionEngine, dictionary, reader, params.Logger)
 			}
 		} else {
 			if deCompressionEngine == ExternalCompressor {
 				return fmt.Errorf("%w value: %q", errUnsupportedDeCompressionEngine, ExternalCompressor)
 			}
-			decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, params.Logger)
+			decompressor, err = newBuiltinDecompressor(deCompressionEngine, dictionary, reader, params.Logger)
 		}
 		if err != nil {
 			return vterrors.Wrap(err, "can't create decompressor")
@@ -1135,6 +1348,9 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 	if hash != fe.Hash {
 		return vterrors.Errorf(vtrpc.Code_INTERNAL, "hash mismatch for %v, got %v expected %v", fe.Name, hash, fe.Hash)
 	}
+	if checksum := br.SHA256String(); fe.SHA256 != "" && checksum != fe.SHA256 {
+		return vterrors.Errorf(vtrpc.Code_DATA_LOSS, "SHA-256 mismatch for %v, got %v expected %v", fe.Name, checksum, fe.SHA256)
+	}
 
 	// Flush the buffer.
 	if err := bufferedDest.Flush(); err != nil {
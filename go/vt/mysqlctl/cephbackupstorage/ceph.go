@@ -153,7 +153,20 @@ func (bh *CephBackupHandle) ReadFile(ctx context.Context, filename string) (io.R
 	// ceph bucket name
 	bucket := alterBucketName(bh.dir)
 	object := objName(bh.dir, bh.name, filename)
-	return bh.client.GetObjectWithContext(ctx, bucket, object, minio.GetObjectOptions{})
+	obj, err := bh.client.GetObjectWithContext(ctx, bucket, object, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject does not send any request until the object is read, so
+	// stat it to report missing files right away.
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%v: %w", err, os.ErrNotExist)
+		}
+		return nil, err
+	}
+	return obj, nil
 }
 
 // CephBackupStorage implements BackupStorage for Ceph Cloud Storage.
@@ -0,0 +1,310 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	cloneBackupEngineName = "clone"
+	// cloneStatusDir is where mysqld keeps the bookkeeping of a clone operation in
+	// the cloned data directory. It is not needed to start mysqld, so it is not backed up.
+	cloneStatusDir = "#clone"
+)
+
+// CloneBackupEngine encapsulates the logic of the clone engine.
+// It implements the BackupEngine interface and takes a backup by cloning the
+// running mysqld into a local directory with the MySQL 8 clone plugin, and then
+// copying the cloned files to the backup storage. Backups are stored with the
+// same MANIFEST and file layout as the builtin engine, which is used to restore them.
+type CloneBackupEngine struct {
+}
+
+// ExecuteBackup runs a backup based on given params. Only full backups are supported.
+// The function returns a boolean that indicates if the backup is usable, and an overall error.
+func (be *CloneBackupEngine) ExecuteBackup(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle) (bool, error) {
+	params.Logger.Infof("Executing Backup at %v for keyspace/shard %v/%v on tablet %v, concurrency: %v, compress: %v",
+		params.BackupTime, params.Keyspace, params.Shard, params.TabletAlias, params.Concurrency, backupStorageCompress)
+
+	if isIncrementalBackup(params) {
+		return false, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "incremental backups not supported in clone engine.")
+	}
+	if params.UpgradeSafe {
+		return false, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "upgrade safe backups not supported in clone engine.")
+	}
+	if err := resolveCompressionParams(&params); err != nil {
+		return false, vterrors.Wrap(err, "invalid compression settings")
+	}
+	if err := resolveEncryptionParams(ctx, &params); err != nil {
+		return false, vterrors.Wrap(err, "cannot set up backup encryption")
+	}
+	if err := validateBackupSigningFlags(); err != nil {
+		return false, vterrors.Wrap(err, "invalid backup signing settings")
+	}
+	params.rateLimiters = newBackupRateLimiters()
+
+	if err := checkClonePluginActive(ctx, params.Mysqld); err != nil {
+		return false, err
+	}
+	serverUUID, err := params.Mysqld.GetServerUUID(ctx)
+	if err != nil {
+		return false, vterrors.Wrap(err, "can't get server uuid")
+	}
+	mysqlVersion, err := params.Mysqld.GetVersionString(ctx)
+	if err != nil {
+		return false, vterrors.Wrap(err, "can't get MySQL version")
+	}
+
+	// The cloned files are laid out under cloneDir like the files of mysqld are laid out
+	// under the root directory, so that they can be backed up by the builtin engine
+	// with cloneDir as the ParentPath of their FileEntry.
+	cloneDir := path.Join(params.Cnf.TmpDir, time.Now().UTC().Format("clone-2006-01-02.150405"))
+	defer func() {
+		if err := os.RemoveAll(cloneDir); err != nil {
+			params.Logger.Errorf("error deleting clone directory %v: %v", cloneDir, err)
+		}
+	}()
+
+	replicationPosition, err := be.cloneLocal(ctx, params, cloneDir)
+	if err != nil {
+		return false, err
+	}
+	fes, err := cloneFilesToBackup(params.Cnf, cloneDir)
+	if err != nil {
+		return false, vterrors.Wrap(err, "can't find cloned files to backup")
+	}
+	params.Logger.Infof("found %v cloned files to backup", len(fes))
+
+	builtin := &BuiltinBackupEngine{}
+	if err := builtin.backupFileEntries(ctx, params, bh, fes); err != nil {
+		return false, err
+	}
+	for i := range fes {
+		fes[i].ParentPath = ""
+	}
+	dictionaryName, dictionaryHash, dictionarySHA256, err := backupCompressionDictionary(ctx, params, bh)
+	if err != nil {
+		return false, err
+	}
+
+	if err := be.writeManifest(ctx, params, bh, fes, dictionaryName, dictionaryHash, dictionarySHA256, replicationPosition, serverUUID, mysqlVersion); err != nil {
+		return false, err
+	}
+
+	params.Logger.Infof("Backup completed")
+	return true, nil
+}
+
+// checkClonePluginActive returns an error if the clone plugin is not loaded in mysqld.
+func checkClonePluginActive(ctx context.Context, mysqld MysqlDaemon) error {
+	qr, err := mysqld.FetchSuperQuery(ctx, "SELECT PLUGIN_STATUS FROM information_schema.PLUGINS WHERE PLUGIN_NAME = 'clone'")
+	if err != nil {
+		return vterrors.Wrap(err, "can't get clone plugin status")
+	}
+	if len(qr.Rows) == 0 || qr.Rows[0][0].ToString() != "ACTIVE" {
+		return vterrors.New(vtrpc.Code_FAILED_PRECONDITION, "the clone plugin is not active, add plugin-load-add=mysql_clone.so to the mysqld configuration")
+	}
+	return nil
+}
+
+// cloneLocal clones mysqld into cloneDir, and returns the replication position of the clone.
+func (be *CloneBackupEngine) cloneLocal(ctx context.Context, params BackupParams, cloneDir string) (replication.Position, error) {
+	// CLONE LOCAL creates the data directory itself, and fails if it exists.
+	dataDir := path.Join(cloneDir, params.Cnf.DataDir)
+	if err := os.MkdirAll(path.Dir(dataDir), os.ModePerm); err != nil {
+		return replication.Position{}, err
+	}
+
+	params.Logger.Infof("Cloning mysqld into %v", dataDir)
+	query := fmt.Sprintf("CLONE LOCAL DATA DIRECTORY = '%s'", dataDir)
+	if err := params.Mysqld.ExecuteSuperQueryList(ctx, []string{query}); err != nil {
+		return replication.Position{}, vterrors.Wrap(err, "clone failed")
+	}
+
+	qr, err := params.Mysqld.FetchSuperQuery(ctx, "SELECT STATE, ERROR_NO, ERROR_MESSAGE, GTID_EXECUTED FROM performance_schema.clone_status")
+	if err != nil {
+		return replication.Position{}, vterrors.Wrap(err, "can't get clone status")
+	}
+	if len(qr.Rows) == 0 {
+		return replication.Position{}, vterrors.New(vtrpc.Code_INTERNAL, "no clone status found")
+	}
+	row := qr.Rows[0]
+	if state := row[0].ToString(); state != "Completed" {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "clone is in state %v: error %v: %v", state, row[1].ToString(), row[2].ToString())
+	}
+
+	// Remove all spaces and newlines from the GTID set.
+	gtidExecuted := strings.Join(strings.Fields(row[3].ToString()), "")
+	replicationPosition, err := replication.ParsePosition(replication.Mysql56FlavorID, gtidExecuted)
+	if err != nil {
+		return replication.Position{}, vterrors.Wrapf(err, "can't parse replication position of the clone: %v", gtidExecuted)
+	}
+	params.Logger.Infof("using replication position: %v", replicationPosition)
+
+	if err := relocateClonedInnodbFiles(params.Cnf, cloneDir); err != nil {
+		return replication.Position{}, vterrors.Wrap(err, "can't relocate cloned innodb files")
+	}
+	return replicationPosition, nil
+}
+
+// relocateClonedInnodbFiles moves the InnoDB system tablespace and redo log files, which the
+// clone plugin puts in the cloned data directory, to their cloned InnodbDataHomeDir and
+// InnodbLogGroupHomeDir when those differ from the DataDir.
+func relocateClonedInnodbFiles(cnf *Mycnf, cloneDir string) error {
+	dataDir := path.Join(cloneDir, cnf.DataDir)
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		var homeDir string
+		switch name := entry.Name(); {
+		case strings.HasPrefix(name, "ibdata"):
+			homeDir = cnf.InnodbDataHomeDir
+		case strings.HasPrefix(name, "ib_logfile"), name == mysql.DynamicRedoLogSubdir:
+			homeDir = cnf.InnodbLogGroupHomeDir
+		default:
+			continue
+		}
+		if homeDir == "" || path.Clean(homeDir) == path.Clean(cnf.DataDir) {
+			continue
+		}
+		dir := path.Join(cloneDir, homeDir)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.Rename(path.Join(dataDir, entry.Name()), path.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneFilesToBackup returns the file entries of all the files cloned under cloneDir.
+func cloneFilesToBackup(cnf *Mycnf, cloneDir string) ([]FileEntry, error) {
+	roots := []struct {
+		base string
+		dir  string
+	}{
+		{backupData, cnf.DataDir},
+		{backupInnodbDataHomeDir, cnf.InnodbDataHomeDir},
+		{backupInnodbLogGroupHomeDir, cnf.InnodbLogGroupHomeDir},
+	}
+
+	var fes []FileEntry
+	seen := map[string]bool{}
+	for _, root := range roots {
+		if root.dir == "" || seen[path.Clean(root.dir)] {
+			continue
+		}
+		seen[path.Clean(root.dir)] = true
+
+		rootDir := path.Join(cloneDir, root.dir)
+		err := filepath.WalkDir(rootDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && p == rootDir {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			name, err := filepath.Rel(rootDir, p)
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if name == cloneStatusDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			fes = append(fes, FileEntry{
+				Base:       root.base,
+				Name:       filepath.ToSlash(name),
+				ParentPath: cloneDir,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fes, nil
+}
+
+// writeManifest writes the MANIFEST of the backup, in the format of the builtin engine.
+func (be *CloneBackupEngine) writeManifest(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, fes []FileEntry, dictionaryName, dictionaryHash, dictionarySHA256 string, replicationPosition replication.Position, serverUUID string, mysqlVersion string) error {
+	params.Logger.Infof("Writing backup MANIFEST")
+	bm := &builtinBackupManifest{
+		// Common base fields
+		BackupManifest: BackupManifest{
+			BackupMethod: cloneBackupEngineName,
+			Position:     replicationPosition,
+			// The clone has no binary logs, so everything it executed is purged.
+			PurgedPosition: replicationPosition,
+			ServerUUID:     serverUUID,
+			TabletAlias:    params.TabletAlias,
+			Keyspace:       params.Keyspace,
+			Shard:          params.Shard,
+			BackupTime:     FormatRFC3339(params.BackupTime.UTC()),
+			FinishedTime:   FormatRFC3339(time.Now().UTC()),
+			MySQLVersion:   mysqlVersion,
+		},
+
+		// Builtin-specific fields
+		FileEntries:                 fes,
+		SkipCompress:                !backupStorageCompress,
+		CompressionEngine:           params.CompressionEngine,
+		CompressionLevel:            params.CompressionLevel,
+		CompressionDictionary:       dictionaryName,
+		CompressionDictionaryHash:   dictionaryHash,
+		CompressionDictionarySHA256: dictionarySHA256,
+		Encryption:                  params.encryption,
+		ExternalDecompressor:        ManifestExternalDecompressorCmd,
+	}
+	return writeBackupManifest(ctx, params, bh, bm)
+}
+
+// ExecuteRestore restores from a backup. Clone backups have the MANIFEST and
+// file layout of the builtin engine, so they are restored like builtin backups.
+func (be *CloneBackupEngine) ExecuteRestore(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle) (*BackupManifest, error) {
+	return (&BuiltinBackupEngine{}).ExecuteRestore(ctx, params, bh)
+}
+
+// ShouldDrainForBackup satisfies the BackupEngine interface
+// clone can run while tablet is serving, hence false
+func (be *CloneBackupEngine) ShouldDrainForBackup(req *tabletmanagerdatapb.BackupRequest) bool {
+	return false
+}
+
+func init() {
+	BackupRestoreEngineMap[cloneBackupEngineName] = &CloneBackupEngine{}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestCloneFilesToBackup(t *testing.T) {
+	cnf := &Mycnf{
+		DataDir:               "/vt/vt_0000000100/data",
+		InnodbDataHomeDir:     "/vt/vt_0000000100/innodb/data",
+		InnodbLogGroupHomeDir: "/vt/vt_0000000100/innodb/logs",
+	}
+	cloneDir := t.TempDir()
+	dataDir := path.Join(cloneDir, cnf.DataDir)
+	for _, name := range []string{
+		"ibdata1",
+		"mysql.ibd",
+		"undo_001",
+		"vt_commerce/customer.ibd",
+		mysql.DynamicRedoLogSubdir + "/#ib_redo0",
+		cloneStatusDir + "/#view_status",
+	} {
+		p := path.Join(dataDir, name)
+		require.NoError(t, os.MkdirAll(path.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(name), 0644))
+	}
+
+	require.NoError(t, relocateClonedInnodbFiles(cnf, cloneDir))
+	fes, err := cloneFilesToBackup(cnf, cloneDir)
+	require.NoError(t, err)
+
+	want := []FileEntry{
+		{Base: backupData, Name: "mysql.ibd", ParentPath: cloneDir},
+		{Base: backupData, Name: "undo_001", ParentPath: cloneDir},
+		{Base: backupData, Name: "vt_commerce/customer.ibd", ParentPath: cloneDir},
+		{Base: backupInnodbDataHomeDir, Name: "ibdata1", ParentPath: cloneDir},
+		{Base: backupInnodbLogGroupHomeDir, Name: mysql.DynamicRedoLogSubdir + "/#ib_redo0", ParentPath: cloneDir},
+	}
+	assert.Equal(t, want, fes)
+
+	// Each entry is read from where it was cloned.
+	for _, fe := range fes {
+		p, err := fe.fullPath(cnf)
+		require.NoError(t, err)
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, path.Base(fe.Name), path.Base(string(data)))
+	}
+}
+
+func TestShouldDrainForBackupClone(t *testing.T) {
+	be := &CloneBackupEngine{}
+
+	assert.False(t, be.ShouldDrainForBackup(nil))
+	assert.False(t, be.ShouldDrainForBackup(&tabletmanagerdatapb.BackupRequest{}))
+}
@@ -50,6 +50,29 @@ func CreateMysqldAndMycnf(tabletUID uint32, mysqlSocket string, mysqlPort int) (
 	return NewMysqld(&dbconfigs.GlobalDBConfigs), mycnf, nil
 }
 
+// CreateScratchMysqldAndMycnf returns a Mysqld and a Mycnf object to use for working with
+// a throwaway MySQL installation under dir, such as one a backup is restored into next to a
+// running tablet. The Mysqld connects to the scratch instance with the credentials of dbcfgs,
+// which are not modified.
+func CreateScratchMysqldAndMycnf(dir string, tabletUID uint32, mysqlPort int, dbcfgs *dbconfigs.DBConfigs) (*Mysqld, *Mycnf, error) {
+	mycnf := newMycnfInDir(dir, tabletUID, mysqlPort)
+	if err := mycnf.RandomizeMysqlServerID(); err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate random MySQL server_id: %v", err)
+	}
+
+	// Always connect through the socket of the scratch instance, even if
+	// the tablet is configured to connect to its mysqld over TCP.
+	scratchCfgs := dbcfgs.Clone()
+	scratchCfgs.Host = ""
+	scratchCfgs.Port = 0
+	scratchCfgs.Socket = mycnf.SocketFile
+	for _, uc := range []*dbconfigs.UserConfig{&scratchCfgs.App, &scratchCfgs.Dba, &scratchCfgs.Filtered, &scratchCfgs.Repl, &scratchCfgs.Appdebug, &scratchCfgs.Allprivs} {
+		uc.UseTCP = false
+	}
+	scratchCfgs.InitWithSocket(mycnf.SocketFile)
+	return NewMysqld(scratchCfgs), mycnf, nil
+}
+
 // OpenMysqldAndMycnf returns a Mysqld and a Mycnf object to use for working with a MySQL
 // installation that already exists. The Mycnf will be built based on the my.cnf file
 // of the MySQL instance.
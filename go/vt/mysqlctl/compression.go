@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"sync"
 
@@ -53,6 +54,8 @@ var (
 	ExternalCompressorExt           string
 	ExternalDecompressorCmd         string
 	ManifestExternalDecompressorCmd string
+	// compressionZstdDictionary is the path to a pre-trained zstd dictionary to compress backups with
+	compressionZstdDictionary string
 
 	errUnsupportedDeCompressionEngine = errors.New("unsupported engine in MANIFEST. You need to provide --external-decompressor if using 'external' compression engine")
 	errUnsupportedCompressionEngine   = errors.New("unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'lz4'")
@@ -78,6 +81,41 @@ func registerBackupCompressionFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&ExternalCompressorExt, "external-compressor-extension", ExternalCompressorExt, "extension to use when using an external compressor.")
 	fs.StringVar(&ExternalDecompressorCmd, "external-decompressor", ExternalDecompressorCmd, "command with arguments to use when decompressing a backup.")
 	fs.StringVar(&ManifestExternalDecompressorCmd, "manifest-external-decompressor", ManifestExternalDecompressorCmd, "command with arguments to store in the backup manifest when compressing a backup with an external compression engine.")
+	fs.StringVar(&compressionZstdDictionary, "compression-zstd-dictionary", compressionZstdDictionary, "path to a pre-trained zstd dictionary to compress backups with. Only supported with the zstd compression engine; the dictionary is stored along with the backup so restores don't need it.")
+}
+
+// resolveCompressionParams fills in the compression settings that were not
+// set for this backup from the compression flags, and loads the zstd
+// dictionary if one is configured.
+func resolveCompressionParams(params *BackupParams) error {
+	if params.CompressionEngine == "" {
+		params.CompressionEngine = CompressionEngineName
+	}
+	if params.CompressionLevel == 0 {
+		params.CompressionLevel = compressionLevel
+	}
+	if params.CompressionDictionaryPath == "" {
+		params.CompressionDictionaryPath = compressionZstdDictionary
+	}
+	if params.CompressionDictionaryPath == "" || !backupStorageCompress || params.compressionDictionary != nil {
+		return nil
+	}
+
+	if ExternalCompressorCmd != "" {
+		return errors.New("a compression dictionary cannot be used with an external compressor")
+	}
+	if params.CompressionEngine != ZstdCompressor {
+		return fmt.Errorf("compression dictionaries are only supported by the %q engine, got %q", ZstdCompressor, params.CompressionEngine)
+	}
+	dictionary, err := os.ReadFile(params.CompressionDictionaryPath)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot read compression dictionary %v", params.CompressionDictionaryPath)
+	}
+	if len(dictionary) == 0 {
+		return fmt.Errorf("compression dictionary %v is empty", params.CompressionDictionaryPath)
+	}
+	params.compressionDictionary = dictionary
+	return nil
 }
 
 func getExtensionFromEngine(engine string) (string, error) {
@@ -193,12 +231,17 @@ func newExternalDecompressor(ctx context.Context, cmdStr string, reader io.Reade
 }
 
 // This returns a reader that will decompress the underlying provided reader and will use the specified supported engine.
-func newBuiltinDecompressor(engine string, reader io.Reader, logger logutil.Logger) (decompressor io.ReadCloser, err error) {
+// The dictionary, if any, must be the one the data was compressed with and is only supported by zstd.
+func newBuiltinDecompressor(engine string, dictionary []byte, reader io.Reader, logger logutil.Logger) (decompressor io.ReadCloser, err error) {
 	if engine == PargzipCompressor {
 		logger.Warningf(`engine "pargzip" doesn't support decompression, using "pgzip" instead`)
 		engine = PgzipCompressor
 	}
 
+	if len(dictionary) > 0 && engine != ZstdCompressor {
+		return nil, fmt.Errorf("compression dictionaries are not supported by engine %q", engine)
+	}
+
 	switch engine {
 	case PgzipCompressor:
 		d, err := pgzip.NewReader(reader)
@@ -209,7 +252,11 @@ func newBuiltinDecompressor(engine string, reader io.Reader, logger logutil.Logg
 	case Lz4Compressor:
 		decompressor = io.NopCloser(lz4.NewReader(reader))
 	case ZstdCompressor:
-		d, err := zstd.NewReader(reader)
+		var opts []zstd.DOption
+		if len(dictionary) > 0 {
+			opts = append(opts, zstd.WithDecoderDicts(dictionary))
+		}
+		d, err := zstd.NewReader(reader, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -223,11 +270,16 @@ func newBuiltinDecompressor(engine string, reader io.Reader, logger logutil.Logg
 	return decompressor, err
 }
 
-// This returns a writer that will compress the data using the specified engine before writing to the underlying writer.
-func newBuiltinCompressor(engine string, writer io.Writer, logger logutil.Logger) (compressor io.WriteCloser, err error) {
+// This returns a writer that will compress the data using the specified engine and level before writing to the underlying writer.
+// The dictionary, if any, is only supported by zstd.
+func newBuiltinCompressor(engine string, level int, dictionary []byte, writer io.Writer, logger logutil.Logger) (compressor io.WriteCloser, err error) {
+	if len(dictionary) > 0 && engine != ZstdCompressor {
+		return nil, fmt.Errorf("compression dictionaries are not supported by engine %q", engine)
+	}
+
 	switch engine {
 	case PgzipCompressor:
-		gzip, err := pgzip.NewWriterLevel(writer, compressionLevel)
+		gzip, err := pgzip.NewWriterLevel(writer, level)
 		if err != nil {
 			return compressor, vterrors.Wrap(err, "cannot create gzip compressor")
 		}
@@ -237,16 +289,20 @@ func newBuiltinCompressor(engine string, writer io.Writer, logger logutil.Logger
 		gzip := pargzip.NewWriter(writer)
 		gzip.ChunkSize = backupCompressBlockSize
 		gzip.Parallel = backupCompressBlocks
-		gzip.CompressionLevel = compressionLevel
+		gzip.CompressionLevel = level
 		compressor = gzip
 	case Lz4Compressor:
 		lz4Writer := lz4.NewWriter(writer).WithConcurrency(backupCompressBlocks)
 		lz4Writer.Header = lz4.Header{
-			CompressionLevel: compressionLevel,
+			CompressionLevel: level,
 		}
 		compressor = lz4Writer
 	case ZstdCompressor:
-		zst, err := zstd.NewWriter(writer, zstd.WithEncoderLevel(zstd.EncoderLevel(compressionLevel)))
+		opts := []zstd.EOption{zstd.WithEncoderLevel(zstd.EncoderLevel(level))}
+		if len(dictionary) > 0 {
+			opts = append(opts, zstd.WithEncoderDict(dictionary))
+		}
+		zst, err := zstd.NewWriter(writer, opts...)
 		if err != nil {
 			return compressor, vterrors.Wrap(err, "cannot create zstd compressor")
 		}
@@ -256,7 +312,7 @@ func newBuiltinCompressor(engine string, writer io.Writer, logger logutil.Logger
 		return compressor, err
 	}
 
-	logger.Infof("Compressing backup using engine %q", engine)
+	logger.Infof("Compressing backup using engine %q at level %d", engine, level)
 	return
 }
 
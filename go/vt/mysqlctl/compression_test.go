@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path"
 	"reflect"
 	"strings"
 	"testing"
@@ -68,7 +70,7 @@ func TestBuiltinCompressors(t *testing.T) {
 		t.Run(engine, func(t *testing.T) {
 			var compressed, decompressed bytes.Buffer
 			reader := bytes.NewReader(data)
-			compressor, err := newBuiltinCompressor(engine, &compressed, logger)
+			compressor, err := newBuiltinCompressor(engine, compressionLevel, nil, &compressed, logger)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -78,7 +80,7 @@ func TestBuiltinCompressors(t *testing.T) {
 				return
 			}
 			compressor.Close()
-			decompressor, err := newBuiltinDecompressor(engine, &compressed, logger)
+			decompressor, err := newBuiltinDecompressor(engine, nil, &compressed, logger)
 			if err != nil {
 				t.Error(err)
 				return
@@ -105,12 +107,83 @@ func TestUnSupportedBuiltinCompressors(t *testing.T) {
 
 	for _, engine := range []string{"external", "foobar"} {
 		t.Run(engine, func(t *testing.T) {
-			_, err := newBuiltinCompressor(engine, nil, logger)
+			_, err := newBuiltinCompressor(engine, compressionLevel, nil, nil, logger)
 			require.ErrorContains(t, err, "unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'lz4' value:")
 		})
 	}
 }
 
+func TestBuiltinCompressorsDictionary(t *testing.T) {
+	logger := logutil.NewMemoryLogger()
+
+	for _, engine := range []string{"pgzip", "pargzip", "lz4"} {
+		t.Run(engine, func(t *testing.T) {
+			_, err := newBuiltinCompressor(engine, compressionLevel, []byte("dictionary"), nil, logger)
+			require.ErrorContains(t, err, "compression dictionaries are not supported by engine")
+			_, err = newBuiltinDecompressor(engine, []byte("dictionary"), nil, logger)
+			require.ErrorContains(t, err, "compression dictionaries are not supported by engine")
+		})
+	}
+}
+
+func TestResolveCompressionParams(t *testing.T) {
+	dictionaryPath := path.Join(t.TempDir(), "zstd.dict")
+	require.NoError(t, os.WriteFile(dictionaryPath, []byte("dictionary"), 0644))
+
+	tests := []struct {
+		name               string
+		params             BackupParams
+		expectedEngine     string
+		expectedLevel      int
+		expectedDictionary []byte
+		expectedErr        string
+	}{
+		{
+			name:           "flag defaults",
+			expectedEngine: CompressionEngineName,
+			expectedLevel:  compressionLevel,
+		},
+		{
+			name:           "per backup settings",
+			params:         BackupParams{CompressionEngine: ZstdCompressor, CompressionLevel: 3},
+			expectedEngine: ZstdCompressor,
+			expectedLevel:  3,
+		},
+		{
+			name:               "zstd dictionary",
+			params:             BackupParams{CompressionEngine: ZstdCompressor, CompressionDictionaryPath: dictionaryPath},
+			expectedEngine:     ZstdCompressor,
+			expectedLevel:      compressionLevel,
+			expectedDictionary: []byte("dictionary"),
+		},
+		{
+			name:        "dictionary with another engine",
+			params:      BackupParams{CompressionEngine: PgzipCompressor, CompressionDictionaryPath: dictionaryPath},
+			expectedErr: `compression dictionaries are only supported by the "zstd" engine, got "pgzip"`,
+		},
+		{
+			name:        "missing dictionary",
+			params:      BackupParams{CompressionEngine: ZstdCompressor, CompressionDictionaryPath: dictionaryPath + ".missing"},
+			expectedErr: "cannot read compression dictionary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			err := resolveCompressionParams(&params)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedEngine, params.CompressionEngine)
+			require.Equal(t, tt.expectedLevel, params.CompressionLevel)
+			require.Equal(t, tt.expectedDictionary, params.compressionDictionary)
+		})
+	}
+}
+
 func TestExternalCompressors(t *testing.T) {
 	data := []byte("foo bar foobar")
 	logger := logutil.NewMemoryLogger()
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var (
+	// backupEncryptionKMS is the name of the KMS that encrypts the data keys of backups
+	backupEncryptionKMS string
+	// backupEncryptionKeyID is the ID of the KMS key that encrypts the data keys of backups
+	backupEncryptionKeyID string
+)
+
+func init() {
+	for _, cmd := range []string{"vtbackup", "vttablet"} {
+		servenv.OnParseFor(cmd, registerBackupEncryptionFlags)
+	}
+}
+
+func registerBackupEncryptionFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&backupEncryptionKMS, "backup-encryption-kms", backupEncryptionKMS, "KMS used to encrypt the data keys of backups: aws, gcp or vault. Backups are not encrypted if empty. Only supported by the builtin and clone backup engines.")
+	fs.StringVar(&backupEncryptionKeyID, "backup-encryption-key-id", backupEncryptionKeyID, "ID of the KMS key used to encrypt the data keys of backups, e.g. an AWS KMS key ARN, a Cloud KMS crypto key resource name or a Vault transit key name.")
+}
+
+// BackupEncryption describes the envelope encryption of the files of a backup.
+// The files are encrypted with a data key generated for the backup, which is
+// stored in the MANIFEST encrypted by a KMS.
+type BackupEncryption struct {
+	// KMS is the name of the KMS implementation that encrypted the data key.
+	KMS string
+
+	// KeyID identifies the KMS key that encrypted the data key.
+	KeyID string
+
+	// EncryptedDataKey is the data key of the backup, as encrypted by the KMS.
+	EncryptedDataKey []byte
+
+	// dataKey is the plaintext data key. It is never written to the MANIFEST.
+	dataKey []byte
+}
+
+// resolveEncryptionParams generates the data key of the backup and encrypts it
+// with the configured KMS, if backups are encrypted.
+func resolveEncryptionParams(ctx context.Context, params *BackupParams) error {
+	if backupEncryptionKMS == "" || params.encryption != nil {
+		return nil
+	}
+	if backupEncryptionKeyID == "" {
+		return errors.New("--backup-encryption-key-id is required with --backup-encryption-kms")
+	}
+
+	kms, err := backupencryption.GetKMS(backupEncryptionKMS)
+	if err != nil {
+		return err
+	}
+	dataKey, err := backupencryption.NewDataKey()
+	if err != nil {
+		return vterrors.Wrap(err, "cannot generate data key")
+	}
+	encryptedDataKey, err := kms.Encrypt(ctx, backupEncryptionKeyID, dataKey)
+	if err != nil {
+		return err
+	}
+
+	params.Logger.Infof("Encrypting backup with data key encrypted by %v KMS key %v", backupEncryptionKMS, backupEncryptionKeyID)
+	params.encryption = &BackupEncryption{
+		KMS:              backupEncryptionKMS,
+		KeyID:            backupEncryptionKeyID,
+		EncryptedDataKey: encryptedDataKey,
+		dataKey:          dataKey,
+	}
+	return nil
+}
+
+// decryptDataKey returns the plaintext data key of the backup, decrypted by the
+// KMS that encrypted it. It returns nil if the backup is not encrypted.
+func (e *BackupEncryption) decryptDataKey(ctx context.Context) ([]byte, error) {
+	if e == nil {
+		return nil, nil
+	}
+	kms, err := backupencryption.GetKMS(e.KMS)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "backup is encrypted with %v KMS key %v", e.KMS, e.KeyID)
+	}
+	return kms.Decrypt(ctx, e.KeyID, e.EncryptedDataKey)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupencryption"
+)
+
+// fakeKMS "encrypts" data keys by prefixing them with the key ID.
+type fakeKMS struct{}
+
+func (fakeKMS) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	return append([]byte(keyID+":"), plaintext...), nil
+}
+
+func (fakeKMS) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	plaintext, ok := bytes.CutPrefix(ciphertext, []byte(keyID+":"))
+	if !ok {
+		return nil, fmt.Errorf("data key was not encrypted with key %v", keyID)
+	}
+	return plaintext, nil
+}
+
+func TestBackupEncryption(t *testing.T) {
+	backupencryption.KMSMap["fake"] = fakeKMS{}
+	defer delete(backupencryption.KMSMap, "fake")
+	defer func(kms, keyID string) {
+		backupEncryptionKMS, backupEncryptionKeyID = kms, keyID
+	}(backupEncryptionKMS, backupEncryptionKeyID)
+
+	ctx := context.Background()
+	params := BackupParams{Logger: logutil.NewMemoryLogger()}
+
+	// Not encrypted by default.
+	require.NoError(t, resolveEncryptionParams(ctx, &params))
+	assert.Nil(t, params.encryption)
+
+	backupEncryptionKMS = "fake"
+	require.ErrorContains(t, resolveEncryptionParams(ctx, &params), "--backup-encryption-key-id is required")
+
+	backupEncryptionKeyID = "key1"
+	require.NoError(t, resolveEncryptionParams(ctx, &params))
+	require.NotNil(t, params.encryption)
+	assert.Len(t, params.encryption.dataKey, backupencryption.DataKeySize)
+
+	// The plaintext data key must not be written to the MANIFEST.
+	data, err := json.Marshal(&builtinBackupManifest{Encryption: params.encryption})
+	require.NoError(t, err)
+	bm := builtinBackupManifest{}
+	require.NoError(t, json.Unmarshal(data, &bm))
+	require.NotNil(t, bm.Encryption)
+	assert.Nil(t, bm.Encryption.dataKey)
+	assert.Equal(t, "fake", bm.Encryption.KMS)
+	assert.Equal(t, "key1", bm.Encryption.KeyID)
+
+	dataKey, err := bm.Encryption.decryptDataKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, params.encryption.dataKey, dataKey)
+
+	// Unencrypted backups have no data key.
+	dataKey, err = (&builtinBackupManifest{}).Encryption.decryptDataKey(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, dataKey)
+
+	bm.Encryption.KMS = "unknown"
+	_, err = bm.Encryption.decryptDataKey(ctx)
+	assert.ErrorContains(t, err, "backup is encrypted with unknown KMS key key1")
+}
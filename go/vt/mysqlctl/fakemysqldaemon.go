@@ -174,6 +174,8 @@ type FakeMysqlDaemon struct {
 	SemiSyncPrimaryEnabled bool
 	// SemiSyncReplicaEnabled represents the state of rpl_semi_sync_slave_enabled.
 	SemiSyncReplicaEnabled bool
+	// SemiSyncPrimaryClients represents the value of Rpl_semi_sync_master_clients.
+	SemiSyncPrimaryClients uint32
 
 	// TimeoutHook is a func that can be called at the beginning of
 	// any method to fake a timeout.
@@ -684,7 +686,7 @@ func (fmd *FakeMysqlDaemon) SemiSyncStatus() (bool, bool) {
 
 // SemiSyncClients is part of the MysqlDaemon interface.
 func (fmd *FakeMysqlDaemon) SemiSyncClients() uint32 {
-	return 0
+	return fmd.SemiSyncPrimaryClients
 }
 
 // SemiSyncExtensionLoaded is part of the MysqlDaemon interface.
@@ -18,7 +18,9 @@ package filebackupstorage
 
 import (
 	"context"
+	"errors"
 	"io"
+	"os"
 	"testing"
 
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
@@ -191,4 +193,8 @@ func TestFileContents(t *testing.T) {
 	if err := rc.Close(); err != nil {
 		t.Fatalf("rc.Close failed: %v", err)
 	}
+	// missing files are reported as such
+	if _, err := bhs[0].ReadFile(ctx, "missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("bhs[0].ReadFile of a missing file returned wrong error: %v", err)
+	}
 }
@@ -20,8 +20,10 @@ package gcsbackupstorage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -124,7 +126,11 @@ func (bh *GCSBackupHandle) ReadFile(ctx context.Context, filename string) (io.Re
 		return nil, fmt.Errorf("ReadFile cannot be called on read-write backup")
 	}
 	object := objName(bh.dir, bh.name, filename)
-	return bh.client.Bucket(bucket).Object(object).NewReader(ctx)
+	reader, err := bh.client.Bucket(bucket).Object(object).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, fmt.Errorf("%v: %w", err, os.ErrNotExist)
+	}
+	return reader, err
 }
 
 // GCSBackupStorage implements BackupStorage for Google Cloud Storage.
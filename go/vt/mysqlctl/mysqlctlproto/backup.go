@@ -17,6 +17,7 @@ limitations under the License.
 package mysqlctlproto
 
 import (
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
@@ -44,3 +45,24 @@ func BackupHandleToProto(bh backupstorage.BackupHandle) *mysqlctlpb.BackupInfo {
 
 	return bi
 }
+
+// SetBackupDetails sets the detailed fields of a BackupInfo proto from the
+// details of its backup, which is complete.
+func SetBackupDetails(bi *mysqlctlpb.BackupInfo, details *mysqlctl.BackupDetails) {
+	bi.Engine = details.BackupMethod
+	bi.Status = mysqlctlpb.BackupInfo_COMPLETE
+	bi.Size = details.Size
+	if d := details.Duration(); d > 0 {
+		bi.Duration = protoutil.DurationToProto(d)
+	}
+	bi.CompressionEngine = details.CompressionEngine
+	bi.Encrypted = details.Encrypted
+	bi.Incremental = details.Incremental
+	bi.FromBackup = details.FromBackup
+	if details.Incremental {
+		bi.FromPosition = replication.EncodePosition(details.FromPosition)
+	}
+	bi.Position = replication.EncodePosition(details.Position)
+	bi.MysqlVersion = details.MySQLVersion
+	bi.UpgradeSafe = details.UpgradeSafe
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+// RestoreProgress is the name of the file, next to the RestoreState file,
+// that lists the files of a full backup that were completely restored.
+const RestoreProgress = "restore_progress"
+
+// restoreProgressEntry is a line of the RestoreProgress file. The first line
+// only has Backup set, the following ones describe a restored file.
+type restoreProgressEntry struct {
+	Backup string `json:",omitempty"`
+
+	Base string `json:",omitempty"`
+	Name string `json:",omitempty"`
+	Hash string `json:",omitempty"`
+	// Size and ModTime are those of the restored file, so that a file that
+	// was modified since, e.g. by a mysqld started on a partial restore, is
+	// restored again.
+	Size    int64 `json:",omitempty"`
+	ModTime int64 `json:",omitempty"`
+}
+
+// restoreProgress records the files restored from a full backup, so that an
+// interrupted restore of the same backup can skip them. A nil *restoreProgress
+// records nothing.
+type restoreProgress struct {
+	fname   string
+	resume  bool
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]restoreProgressEntry
+}
+
+// openRestoreProgress starts recording the restore of the given backup. If
+// resume is true and the RestoreProgress file is about the same backup, the
+// files it lists are kept. Otherwise it is truncated.
+func openRestoreProgress(cnf *Mycnf, backup string, resume bool) (*restoreProgress, error) {
+	p := &restoreProgress{
+		fname:   filepath.Join(cnf.TabletDir(), RestoreProgress),
+		entries: make(map[string]restoreProgressEntry),
+	}
+	if resume {
+		// A missing file, or one about another backup, means starting over.
+		entries := readRestoreProgress(p.fname)
+		if len(entries) > 0 && entries[0].Backup == backup {
+			for _, entry := range entries[1:] {
+				p.entries[path.Join(entry.Base, entry.Name)] = entry
+			}
+			p.resume = true
+		}
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !p.resume {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(p.fname, flags, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	p.file = file
+	if !p.resume {
+		if err := p.write(restoreProgressEntry{Backup: backup}); err != nil {
+			p.close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// readRestoreProgress returns the entries of the RestoreProgress file, up to
+// the first one that cannot be parsed, such as a line that was being written
+// when the restore was interrupted.
+func readRestoreProgress(fname string) []restoreProgressEntry {
+	file, err := os.Open(fname)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var entries []restoreProgressEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry restoreProgressEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// resumed returns true if an interrupted restore is resumed.
+func (p *restoreProgress) resumed() bool {
+	return p != nil && p.resume
+}
+
+// isDone returns true if the file was completely restored and not modified since.
+func (p *restoreProgress) isDone(cnf *Mycnf, fe *FileEntry) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	entry, ok := p.entries[path.Join(fe.Base, fe.Name)]
+	p.mu.Unlock()
+	if !ok || entry.Hash != fe.Hash {
+		return false
+	}
+
+	name, err := fe.fullPath(cnf)
+	if err != nil {
+		return false
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	return fi.Size() == entry.Size && fi.ModTime().UnixNano() == entry.ModTime
+}
+
+// doneCount returns how many of the files were already restored.
+func (p *restoreProgress) doneCount(fes []FileEntry, cnf *Mycnf) int {
+	count := 0
+	for i := range fes {
+		if p.isDone(cnf, &fes[i]) {
+			count++
+		}
+	}
+	return count
+}
+
+// markDone records that the file was completely restored. The file is synced
+// first, so that it is only skipped by a resumed restore once it is durable.
+func (p *restoreProgress) markDone(cnf *Mycnf, fe *FileEntry) error {
+	if p == nil {
+		return nil
+	}
+	name, err := fe.fullPath(cnf)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	err = file.Sync()
+	file.Close()
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	entry := restoreProgressEntry{
+		Base:    fe.Base,
+		Name:    fe.Name,
+		Hash:    fe.Hash,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+	}
+	if err := p.write(entry); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.entries[path.Join(fe.Base, fe.Name)] = entry
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *restoreProgress) write(entry restoreProgressEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+func (p *restoreProgress) close() {
+	if p == nil || p.file == nil {
+		return
+	}
+	p.file.Close()
+	p.file = nil
+}
+
+// remove deletes the RestoreProgress file once the restore is complete.
+func (p *restoreProgress) remove() error {
+	if p == nil {
+		return nil
+	}
+	p.close()
+	if err := os.Remove(p.fname); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
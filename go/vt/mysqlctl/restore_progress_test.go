@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreProgress(t *testing.T) {
+	tabletDir := t.TempDir()
+	cnf := &Mycnf{DataDir: path.Join(tabletDir, "data")}
+	require.NoError(t, os.MkdirAll(cnf.DataDir, 0o755))
+
+	fes := []FileEntry{
+		{Base: backupData, Name: "ks/t1.ibd", Hash: "hash1"},
+		{Base: backupData, Name: "ks/t2.ibd", Hash: "hash2"},
+		{Base: backupData, Name: "ks/t3.ibd", Hash: "hash3"},
+	}
+	for _, fe := range fes {
+		name, err := fe.fullPath(cnf)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(path.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(fe.Name), 0o644))
+	}
+
+	// A nil restoreProgress records nothing.
+	var none *restoreProgress
+	assert.False(t, none.resumed())
+	assert.False(t, none.isDone(cnf, &fes[0]))
+	assert.NoError(t, none.markDone(cnf, &fes[0]))
+
+	p, err := openRestoreProgress(cnf, "ks/0/backup1", true)
+	require.NoError(t, err)
+	assert.False(t, p.resumed())
+	require.NoError(t, p.markDone(cnf, &fes[0]))
+	require.NoError(t, p.markDone(cnf, &fes[1]))
+	assert.Equal(t, 2, p.doneCount(fes, cnf))
+	p.close()
+
+	// Simulate a line that was being written when the restore was interrupted.
+	f, err := os.OpenFile(filepath.Join(tabletDir, RestoreProgress), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"Base":"Data","Name":"ks/t3.i`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Files modified since they were restored are restored again.
+	name, err := fes[1].fullPath(cnf)
+	require.NoError(t, err)
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(name, future, future))
+
+	p, err = openRestoreProgress(cnf, "ks/0/backup1", true)
+	require.NoError(t, err)
+	assert.True(t, p.resumed())
+	assert.True(t, p.isDone(cnf, &fes[0]))
+	assert.False(t, p.isDone(cnf, &fes[1]))
+	assert.False(t, p.isDone(cnf, &fes[2]))
+
+	// Files with another hash are from another backup.
+	other := fes[0]
+	other.Hash = "other"
+	assert.False(t, p.isDone(cnf, &other))
+	p.close()
+
+	// The progress of another backup is discarded.
+	p, err = openRestoreProgress(cnf, "ks/0/backup2", true)
+	require.NoError(t, err)
+	assert.False(t, p.resumed())
+	assert.Zero(t, p.doneCount(fes, cnf))
+	require.NoError(t, p.remove())
+	assert.NoFileExists(t, filepath.Join(tabletDir, RestoreProgress))
+
+	// Without resume, the progress is discarded too.
+	p, err = openRestoreProgress(cnf, "ks/0/backup2", false)
+	require.NoError(t, err)
+	require.NoError(t, p.markDone(cnf, &fes[0]))
+	p.close()
+	p, err = openRestoreProgress(cnf, "ks/0/backup2", false)
+	require.NoError(t, err)
+	assert.False(t, p.resumed())
+	assert.Zero(t, p.doneCount(fes, cnf))
+	p.close()
+}
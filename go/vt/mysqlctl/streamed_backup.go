@@ -0,0 +1,349 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// StreamedBackupDir is the name of the directory, in the tablet directory,
+// where a backup streamed by another tablet is staged before it is restored.
+const StreamedBackupDir = "streamed_backup"
+
+// streamedBackupChunkSize is the maximum size of the data of a single
+// StreamBackupResponse.
+const streamedBackupChunkSize = 1024 * 1024
+
+// streamingBackupStorage is a write-only BackupStorage that sends the files of
+// a backup as StreamBackupResponse messages, so that a tablet can take a backup
+// for another tablet to restore, without storing it.
+type streamingBackupStorage struct {
+	send func(*tabletmanagerdatapb.StreamBackupResponse) error
+}
+
+// NewStreamingBackupStorage returns a BackupStorage that sends the backups
+// started with it to send, which must be safe for concurrent use. Backups
+// streamed this way are received with ReceiveStreamedBackup.
+func NewStreamingBackupStorage(send func(*tabletmanagerdatapb.StreamBackupResponse) error) backupstorage.BackupStorage {
+	return &streamingBackupStorage{send: send}
+}
+
+// ListBackups is part of the BackupStorage interface.
+func (sbs *streamingBackupStorage) ListBackups(ctx context.Context, dir string) ([]backupstorage.BackupHandle, error) {
+	return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot list streamed backups")
+}
+
+// StartBackup is part of the BackupStorage interface.
+func (sbs *streamingBackupStorage) StartBackup(ctx context.Context, dir, name string) (backupstorage.BackupHandle, error) {
+	if err := sbs.send(&tabletmanagerdatapb.StreamBackupResponse{BackupDirectory: dir, BackupName: name}); err != nil {
+		return nil, err
+	}
+	return &streamingBackupHandle{sbs: sbs, dir: dir, name: name}, nil
+}
+
+// RemoveBackup is part of the BackupStorage interface.
+func (sbs *streamingBackupStorage) RemoveBackup(ctx context.Context, dir, name string) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot remove streamed backups")
+}
+
+// Close is part of the BackupStorage interface.
+func (sbs *streamingBackupStorage) Close() error {
+	return nil
+}
+
+// WithParams is part of the BackupStorage interface.
+func (sbs *streamingBackupStorage) WithParams(params backupstorage.Params) backupstorage.BackupStorage {
+	return sbs
+}
+
+// streamingBackupHandle is a BackupHandle of a streamingBackupStorage.
+type streamingBackupHandle struct {
+	sbs    *streamingBackupStorage
+	dir    string
+	name   string
+	errors concurrency.AllErrorRecorder
+}
+
+// RecordError is part of the concurrency.ErrorRecorder interface.
+func (sbh *streamingBackupHandle) RecordError(err error) {
+	sbh.errors.RecordError(err)
+}
+
+// HasErrors is part of the concurrency.ErrorRecorder interface.
+func (sbh *streamingBackupHandle) HasErrors() bool {
+	return sbh.errors.HasErrors()
+}
+
+// Error is part of the concurrency.ErrorRecorder interface.
+func (sbh *streamingBackupHandle) Error() error {
+	return sbh.errors.Error()
+}
+
+// Directory is part of the BackupHandle interface.
+func (sbh *streamingBackupHandle) Directory() string {
+	return sbh.dir
+}
+
+// Name is part of the BackupHandle interface.
+func (sbh *streamingBackupHandle) Name() string {
+	return sbh.name
+}
+
+// AddFile is part of the BackupHandle interface.
+func (sbh *streamingBackupHandle) AddFile(ctx context.Context, filename string, filesize int64) (io.WriteCloser, error) {
+	return &streamingBackupFile{sbs: sbh.sbs, name: filename}, nil
+}
+
+// EndBackup is part of the BackupHandle interface. The backup is complete once
+// the stream ends without an error.
+func (sbh *streamingBackupHandle) EndBackup(ctx context.Context) error {
+	return nil
+}
+
+// AbortBackup is part of the BackupHandle interface. The receiver discards the
+// backup when the stream ends with an error.
+func (sbh *streamingBackupHandle) AbortBackup(ctx context.Context) error {
+	return nil
+}
+
+// ReadFile is part of the BackupHandle interface.
+func (sbh *streamingBackupHandle) ReadFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot read a streamed backup")
+}
+
+// streamingBackupFile sends what is written to it in chunks of at most
+// streamedBackupChunkSize bytes.
+type streamingBackupFile struct {
+	sbs  *streamingBackupStorage
+	name string
+}
+
+func (f *streamingBackupFile) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		n := min(len(p)-written, streamedBackupChunkSize)
+		// The message may be marshaled after send returns, while the caller
+		// reuses p, so the data is copied.
+		data := make([]byte, n)
+		copy(data, p[written:written+n])
+		if err := f.sbs.send(&tabletmanagerdatapb.StreamBackupResponse{File: f.name, Data: data}); err != nil {
+			return written, err
+		}
+		written += n
+	}
+	return written, nil
+}
+
+func (f *streamingBackupFile) Close() error {
+	return f.sbs.send(&tabletmanagerdatapb.StreamBackupResponse{File: f.name, EndOfFile: true})
+}
+
+// ReceiveStreamedBackup receives a backup streamed by a tablet with a
+// streaming BackupStorage, and stages its files in the StreamedBackupDir of
+// the tablet. It returns a read-only BackupStorage that only has this backup,
+// and which removes the staged files when closed. Log events of the stream are
+// sent to logger.
+func ReceiveStreamedBackup(ctx context.Context, cnf *Mycnf, recv func() (*tabletmanagerdatapb.StreamBackupResponse, error), logger logutil.Logger) (_ backupstorage.BackupStorage, finalErr error) {
+	dir := filepath.Join(cnf.TabletDir(), StreamedBackupDir)
+	// Files left by a previous attempt are of no use, as the source takes a
+	// new backup every time.
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if finalErr != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	var bh *stagedBackupHandle
+	open := make(map[string]*os.File)
+	complete := make(map[string]bool)
+	defer func() {
+		for _, f := range open {
+			f.Close()
+		}
+	}()
+	var received int64
+	for {
+		msg, err := recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, vterrors.Wrap(err, "failed to receive the streamed backup")
+		}
+
+		switch {
+		case msg.Event != nil:
+			logutil.LogEvent(logger, msg.Event)
+			continue
+		case msg.BackupName != "":
+			if bh != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "received a second backup %v/%v while receiving %v/%v", msg.BackupDirectory, msg.BackupName, bh.directory, bh.name)
+			}
+			bh = &stagedBackupHandle{stagingDir: dir, directory: msg.BackupDirectory, name: msg.BackupName}
+			logger.Infof("Receiving streamed backup %v/%v into %v", bh.directory, bh.name, dir)
+			continue
+		case bh == nil:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "received data of file %q before the backup name", msg.File)
+		}
+
+		// The name comes from the source, make sure it stays in dir.
+		if msg.File == "" || msg.File != filepath.Base(msg.File) || msg.File == "." || msg.File == ".." {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid streamed backup file name %q", msg.File)
+		}
+		if complete[msg.File] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "received data of file %q after its end", msg.File)
+		}
+		f, ok := open[msg.File]
+		if !ok {
+			f, err = os.Create(path.Join(dir, msg.File))
+			if err != nil {
+				return nil, err
+			}
+			open[msg.File] = f
+		}
+		if _, err := f.Write(msg.Data); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot write streamed backup file %v", msg.File)
+		}
+		received += int64(len(msg.Data))
+		if msg.EndOfFile {
+			delete(open, msg.File)
+			complete[msg.File] = true
+			if err := f.Close(); err != nil {
+				return nil, vterrors.Wrapf(err, "cannot write streamed backup file %v", msg.File)
+			}
+		}
+	}
+
+	if bh == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "the stream ended before a backup was received")
+	}
+	for name := range open {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "the stream ended before file %v of backup %v was complete", name, bh.name)
+	}
+	if !complete[backupManifestFileName] {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "the stream ended without the %v of backup %v", backupManifestFileName, bh.name)
+	}
+	logger.Infof("Received streamed backup %v/%v: %v files, %v bytes", bh.directory, bh.name, len(complete), received)
+	return &stagedBackupStorage{bh: bh}, nil
+}
+
+// stagedBackupStorage is a read-only BackupStorage with a single backup,
+// received with ReceiveStreamedBackup.
+type stagedBackupStorage struct {
+	bh *stagedBackupHandle
+}
+
+// ListBackups is part of the BackupStorage interface.
+func (sbs *stagedBackupStorage) ListBackups(ctx context.Context, dir string) ([]backupstorage.BackupHandle, error) {
+	// Listing no backup would make a restoring tablet start up empty.
+	if dir != sbs.bh.directory {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the streamed backup is a backup of %v, not %v", sbs.bh.directory, dir)
+	}
+	return []backupstorage.BackupHandle{sbs.bh}, nil
+}
+
+// StartBackup is part of the BackupStorage interface.
+func (sbs *stagedBackupStorage) StartBackup(ctx context.Context, dir, name string) (backupstorage.BackupHandle, error) {
+	return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot start a backup in a streamed backup storage")
+}
+
+// RemoveBackup is part of the BackupStorage interface.
+func (sbs *stagedBackupStorage) RemoveBackup(ctx context.Context, dir, name string) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot remove a streamed backup")
+}
+
+// Close is part of the BackupStorage interface. It removes the staged files.
+func (sbs *stagedBackupStorage) Close() error {
+	return os.RemoveAll(sbs.bh.stagingDir)
+}
+
+// WithParams is part of the BackupStorage interface.
+func (sbs *stagedBackupStorage) WithParams(params backupstorage.Params) backupstorage.BackupStorage {
+	return sbs
+}
+
+// stagedBackupHandle is the read-only BackupHandle of a stagedBackupStorage.
+type stagedBackupHandle struct {
+	stagingDir string
+	directory  string
+	name       string
+	errors     concurrency.AllErrorRecorder
+}
+
+// RecordError is part of the concurrency.ErrorRecorder interface.
+func (sbh *stagedBackupHandle) RecordError(err error) {
+	sbh.errors.RecordError(err)
+}
+
+// HasErrors is part of the concurrency.ErrorRecorder interface.
+func (sbh *stagedBackupHandle) HasErrors() bool {
+	return sbh.errors.HasErrors()
+}
+
+// Error is part of the concurrency.ErrorRecorder interface.
+func (sbh *stagedBackupHandle) Error() error {
+	return sbh.errors.Error()
+}
+
+// Directory is part of the BackupHandle interface.
+func (sbh *stagedBackupHandle) Directory() string {
+	return sbh.directory
+}
+
+// Name is part of the BackupHandle interface.
+func (sbh *stagedBackupHandle) Name() string {
+	return sbh.name
+}
+
+// AddFile is part of the BackupHandle interface.
+func (sbh *stagedBackupHandle) AddFile(ctx context.Context, filename string, filesize int64) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("AddFile cannot be called on read-only backup")
+}
+
+// EndBackup is part of the BackupHandle interface.
+func (sbh *stagedBackupHandle) EndBackup(ctx context.Context) error {
+	return fmt.Errorf("EndBackup cannot be called on read-only backup")
+}
+
+// AbortBackup is part of the BackupHandle interface.
+func (sbh *stagedBackupHandle) AbortBackup(ctx context.Context) error {
+	return fmt.Errorf("AbortBackup cannot be called on read-only backup")
+}
+
+// ReadFile is part of the BackupHandle interface.
+func (sbh *stagedBackupHandle) ReadFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	return os.Open(path.Join(sbh.stagingDir, filename))
+}
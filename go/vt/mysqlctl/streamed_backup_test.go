@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// streamBackup runs a backup with a streaming BackupStorage and returns the
+// messages it sent.
+func streamBackup(t *testing.T, files map[string][]byte) []*tabletmanagerdatapb.StreamBackupResponse {
+	ctx := context.Background()
+	var msgs []*tabletmanagerdatapb.StreamBackupResponse
+	bs := NewStreamingBackupStorage(func(msg *tabletmanagerdatapb.StreamBackupResponse) error {
+		msgs = append(msgs, msg)
+		return nil
+	})
+	bh, err := bs.StartBackup(ctx, "ks/0", "2023-10-11.120000.zone1-0000000101")
+	require.NoError(t, err)
+	for name, data := range files {
+		w, err := bh.AddFile(ctx, name, int64(len(data)))
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	}
+	require.NoError(t, bh.EndBackup(ctx))
+	return msgs
+}
+
+func receiveStreamedBackup(cnf *Mycnf, msgs []*tabletmanagerdatapb.StreamBackupResponse) (*stagedBackupStorage, error) {
+	bs, err := ReceiveStreamedBackup(context.Background(), cnf, func() (*tabletmanagerdatapb.StreamBackupResponse, error) {
+		if len(msgs) == 0 {
+			return nil, io.EOF
+		}
+		msg := msgs[0]
+		msgs = msgs[1:]
+		return msg, nil
+	}, logutil.NewMemoryLogger())
+	if err != nil {
+		return nil, err
+	}
+	return bs.(*stagedBackupStorage), nil
+}
+
+func TestStreamedBackup(t *testing.T) {
+	ctx := context.Background()
+	cnf := &Mycnf{DataDir: path.Join(t.TempDir(), "data")}
+	large := bytes.Repeat([]byte("0123456789"), streamedBackupChunkSize/4)
+	files := map[string][]byte{
+		"0":                    large,
+		"1":                    []byte("small"),
+		backupManifestFileName: []byte(`{"BackupMethod": "builtin"}`),
+	}
+
+	msgs := streamBackup(t, files)
+	assert.Equal(t, "ks/0", msgs[0].BackupDirectory)
+	for _, msg := range msgs {
+		assert.LessOrEqual(t, len(msg.Data), streamedBackupChunkSize)
+	}
+
+	bs, err := receiveStreamedBackup(cnf, msgs)
+	require.NoError(t, err)
+	bhs, err := bs.ListBackups(ctx, "ks/0")
+	require.NoError(t, err)
+	require.Len(t, bhs, 1)
+	assert.Equal(t, "2023-10-11.120000.zone1-0000000101", bhs[0].Name())
+	for name, data := range files {
+		r, err := bhs[0].ReadFile(ctx, name)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		assert.Equal(t, data, got, name)
+	}
+
+	_, err = bs.ListBackups(ctx, "ks/80-")
+	assert.ErrorContains(t, err, "the streamed backup is a backup of ks/0, not ks/80-")
+
+	require.NoError(t, bs.Close())
+	assert.NoDirExists(t, filepath.Join(cnf.TabletDir(), StreamedBackupDir))
+
+	t.Run("incomplete file", func(t *testing.T) {
+		msgs := streamBackup(t, files)
+		_, err := receiveStreamedBackup(cnf, msgs[:len(msgs)-1])
+		assert.ErrorContains(t, err, "the stream ended before file")
+		assert.NoDirExists(t, filepath.Join(cnf.TabletDir(), StreamedBackupDir))
+	})
+
+	t.Run("no manifest", func(t *testing.T) {
+		msgs := streamBackup(t, map[string][]byte{"0": []byte("data")})
+		_, err := receiveStreamedBackup(cnf, msgs)
+		assert.ErrorContains(t, err, "the stream ended without the MANIFEST")
+	})
+
+	t.Run("invalid file name", func(t *testing.T) {
+		msgs := streamBackup(t, map[string][]byte{"../escape": []byte("data")})
+		_, err := receiveStreamedBackup(cnf, msgs)
+		assert.ErrorContains(t, err, `invalid streamed backup file name "../escape"`)
+	})
+}
@@ -184,6 +184,12 @@ func (be *XtrabackupEngine) executeFullBackup(ctx context.Context, params Backup
 	if xtrabackupUser == "" {
 		return false, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "xtrabackupUser must be specified.")
 	}
+	if backupEncryptionKMS != "" {
+		return false, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "--backup-encryption-kms is not supported in xtrabackup engine, use the encryption options of xtrabackup instead.")
+	}
+	if backupSigningEnabled() {
+		return false, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "backup signing is not supported in xtrabackup engine.")
+	}
 
 	// an extension is required when using an external compressor
 	if backupStorageCompress && ExternalCompressorCmd != "" && ExternalCompressorExt == "" {
@@ -375,7 +381,7 @@ func (be *XtrabackupEngine) backupFiles(
 			if ExternalCompressorCmd != "" {
 				compressor, err = newExternalCompressor(ctx, ExternalCompressorCmd, writer, params.Logger)
 			} else {
-				compressor, err = newBuiltinCompressor(CompressionEngineName, writer, params.Logger)
+				compressor, err = newBuiltinCompressor(CompressionEngineName, compressionLevel, nil, writer, params.Logger)
 			}
 			if err != nil {
 				return replicationPosition, vterrors.Wrap(err, "can't create compressor")
@@ -475,7 +481,7 @@ func (be *XtrabackupEngine) ExecuteRestore(ctx context.Context, params RestorePa
 
 	var bm xtraBackupManifest
 
-	if err := getBackupManifestInto(ctx, bh, &bm); err != nil {
+	if err := getVerifiedBackupManifestInto(ctx, bh, &bm, params.Logger); err != nil {
 		return nil, err
 	}
 
@@ -652,13 +658,13 @@ func (be *XtrabackupEngine) extractFiles(ctx context.Context, logger logutil.Log
 					deCompressionEngine = externalDecompressorCmd
 					decompressor, err = newExternalDecompressor(ctx, deCompressionEngine, reader, logger)
 				} else {
-					decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, logger)
+					decompressor, err = newBuiltinDecompressor(deCompressionEngine, nil, reader, logger)
 				}
 			} else {
 				if deCompressionEngine == ExternalCompressor {
 					return fmt.Errorf("%w %q", errUnsupportedCompressionEngine, ExternalCompressor)
 				}
-				decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, logger)
+				decompressor, err = newBuiltinDecompressor(deCompressionEngine, nil, reader, logger)
 			}
 			if err != nil {
 				return vterrors.Wrap(err, "can't create decompressor")
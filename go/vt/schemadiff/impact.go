@@ -0,0 +1,198 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// integralTypeSizes are the storage sizes of the integral types, in bytes.
+var integralTypeSizes = map[string]int{
+	"tinyint":   1,
+	"smallint":  2,
+	"mediumint": 3,
+	"int":       4,
+	"integer":   4,
+	"bigint":    8,
+}
+
+// textualTypeLengths are the maximum lengths of the textual types which
+// do not have a declared length.
+var textualTypeLengths = map[string]int64{
+	"tinytext":   255,
+	"text":       65535,
+	"mediumtext": 16777215,
+	"longtext":   4294967295,
+	"tinyblob":   255,
+	"blob":       65535,
+	"mediumblob": 16777215,
+	"longblob":   4294967295,
+}
+
+// DataLossRisks returns the ways in which applying the given diff, and its subsequent diffs, may lose data:
+// dropped tables, columns and partitions, and modified columns whose new type may not hold all of their
+// existing values. It returns an empty list if the diff is not known to lose any data.
+func DataLossRisks(diff EntityDiff) (risks []string) {
+	for _, diff := range AllSubsequent(diff) {
+		switch diff := diff.(type) {
+		case *DropTableEntityDiff:
+			risks = append(risks, fmt.Sprintf("table %s is dropped", sqlescape.EscapeID(diff.from.Name())))
+		case *AlterTableEntityDiff:
+			risks = append(risks, alterTableDataLossRisks(diff.from, diff.alterTable)...)
+		}
+	}
+	return risks
+}
+
+// alterTableDataLossRisks returns the data loss risks of an ALTER TABLE statement on the given table.
+func alterTableDataLossRisks(from *CreateTableEntity, alterTable *sqlparser.AlterTable) (risks []string) {
+	findColumn := func(name string) *sqlparser.ColumnDefinition {
+		for _, col := range from.TableSpec.Columns {
+			if strings.EqualFold(col.Name.String(), name) {
+				return col
+			}
+		}
+		return nil
+	}
+	for _, alterOption := range alterTable.AlterOptions {
+		switch opt := alterOption.(type) {
+		case *sqlparser.DropColumn:
+			risks = append(risks, fmt.Sprintf("column %s is dropped", sqlescape.EscapeID(opt.Name.Name.String())))
+		case *sqlparser.ModifyColumn:
+			if col := findColumn(opt.NewColDefinition.Name.String()); col != nil {
+				risks = append(risks, modifyColumnDataLossRisks(col, opt.NewColDefinition)...)
+			}
+		case *sqlparser.ChangeColumn:
+			if col := findColumn(opt.OldColumn.Name.String()); col != nil {
+				risks = append(risks, modifyColumnDataLossRisks(col, opt.NewColDefinition)...)
+			}
+		}
+	}
+	if spec := alterTable.PartitionSpec; spec != nil {
+		switch spec.Action {
+		case sqlparser.DropAction:
+			risks = append(risks, fmt.Sprintf("partitions %s are dropped", sqlparser.String(spec.Names)))
+		case sqlparser.TruncateAction:
+			risks = append(risks, fmt.Sprintf("partitions %s are truncated", sqlparser.String(spec.Names)))
+		}
+	}
+	return risks
+}
+
+// modifyColumnDataLossRisks returns the data loss risks of changing the definition of a column.
+func modifyColumnDataLossRisks(from *sqlparser.ColumnDefinition, to *sqlparser.ColumnDefinition) (risks []string) {
+	name := sqlescape.EscapeID(from.Name.String())
+	if !columnTypeHoldsValues(from.Type, to.Type) {
+		risks = append(risks, fmt.Sprintf("column %s changes from %s to %s, which may not hold all of its values",
+			name, columnTypeString(from.Type), columnTypeString(to.Type)))
+	}
+	fromNullable := from.Type.Options == nil || from.Type.Options.Null == nil || *from.Type.Options.Null
+	toNullable := to.Type.Options == nil || to.Type.Options.Null == nil || *to.Type.Options.Null
+	if fromNullable && !toNullable {
+		risks = append(risks, fmt.Sprintf("column %s becomes NOT NULL, its NULL values are replaced", name))
+	}
+	return risks
+}
+
+// columnTypeString returns the type of a column, without its options.
+func columnTypeString(t *sqlparser.ColumnType) string {
+	typ := *t
+	typ.Options = nil
+	return sqlparser.CanonicalString(&typ)
+}
+
+// columnTypeHoldsValues returns true if the column type `to` is known to hold all of the values of the
+// column type `from`.
+func columnTypeHoldsValues(from *sqlparser.ColumnType, to *sqlparser.ColumnType) bool {
+	fromType, toType := strings.ToLower(from.Type), strings.ToLower(to.Type)
+	literalInt := func(l *sqlparser.Literal, defaultValue int64) int64 {
+		if l == nil {
+			return defaultValue
+		}
+		val, err := strconv.ParseInt(l.Val, 10, 64)
+		if err != nil {
+			return defaultValue
+		}
+		return val
+	}
+	textualLength := func(t *sqlparser.ColumnType, typ string) (int64, bool) {
+		switch typ {
+		case "char", "binary":
+			return literalInt(t.Length, 1), true
+		case "varchar", "varbinary":
+			return literalInt(t.Length, 0), true
+		}
+		length, ok := textualTypeLengths[typ]
+		return length, ok
+	}
+	isBinary := func(typ string) bool {
+		return strings.Contains(typ, "binary") || strings.Contains(typ, "blob")
+	}
+
+	if fromSize, ok := integralTypeSizes[fromType]; ok {
+		toSize, ok := integralTypeSizes[toType]
+		switch {
+		case !ok:
+			return false
+		case from.Unsigned == to.Unsigned:
+			return toSize >= fromSize
+		case to.Unsigned:
+			return false
+		default:
+			// An unsigned column fits in a larger signed column.
+			return toSize > fromSize
+		}
+	}
+	if fromLength, ok := textualLength(from, fromType); ok {
+		toLength, ok := textualLength(to, toType)
+		return ok && isBinary(fromType) == isBinary(toType) && toLength >= fromLength
+	}
+	switch fromType {
+	case "decimal", "numeric":
+		if toType != "decimal" && toType != "numeric" {
+			return false
+		}
+		fromPrecision, fromScale := literalInt(from.Length, 10), literalInt(from.Scale, 0)
+		toPrecision, toScale := literalInt(to.Length, 10), literalInt(to.Scale, 0)
+		return toScale >= fromScale && toPrecision-toScale >= fromPrecision-fromScale && (to.Unsigned == from.Unsigned || from.Unsigned)
+	case "float":
+		return toType == "float" || toType == "double" || toType == "real"
+	case "enum", "set":
+		if toType != fromType {
+			return false
+		}
+		for _, fromValue := range from.EnumValues {
+			found := false
+			for _, toValue := range to.EnumValues {
+				if fromValue == toValue {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return fromType == toType && from.Unsigned == to.Unsigned && literalInt(to.Length, 0) >= literalInt(from.Length, 0)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataLossRisks(t *testing.T) {
+	tt := []struct {
+		name   string
+		from   string
+		to     string
+		expect []string
+	}{
+		{
+			name: "create table",
+			to:   "create table t (id int primary key)",
+		},
+		{
+			name:   "drop table",
+			from:   "create table t (id int primary key)",
+			expect: []string{"table `t` is dropped"},
+		},
+		{
+			name: "add column",
+			from: "create table t (id int primary key)",
+			to:   "create table t (id int primary key, i int)",
+		},
+		{
+			name:   "drop column",
+			from:   "create table t (id int primary key, i int)",
+			to:     "create table t (id int primary key)",
+			expect: []string{"column `i` is dropped"},
+		},
+		{
+			name: "widen columns",
+			from: "create table t (id int primary key, i int, u int unsigned, v varchar(32), c char(8), d decimal(10,2), e enum('a','b'), f float)",
+			to:   "create table t (id bigint primary key, i bigint, u bigint, v text, c varchar(8), d decimal(12,3), e enum('a','b','c'), f double)",
+		},
+		{
+			name: "narrow columns",
+			from: "create table t (id bigint primary key, i int, v varchar(32), b varbinary(32), d decimal(10,2), e enum('a','b'), f double)",
+			to:   "create table t (id int primary key, i int unsigned, v varchar(16), b varchar(32), d decimal(10,3), e enum('a','c'), f float)",
+			expect: []string{
+				"column `id` changes from bigint to int, which may not hold all of its values",
+				"column `i` changes from int to int unsigned, which may not hold all of its values",
+				"column `v` changes from varchar(32) to varchar(16), which may not hold all of its values",
+				"column `b` changes from varbinary(32) to varchar(32), which may not hold all of its values",
+				"column `d` changes from decimal(10,2) to decimal(10,3), which may not hold all of its values",
+				"column `e` changes from enum('a', 'b') to enum('a', 'c'), which may not hold all of its values",
+				"column `f` changes from double to float, which may not hold all of its values",
+			},
+		},
+		{
+			name:   "not null column",
+			from:   "create table t (id int primary key, i int)",
+			to:     "create table t (id int primary key, i int not null)",
+			expect: []string{"column `i` becomes NOT NULL, its NULL values are replaced"},
+		},
+	}
+	hints := &DiffHints{}
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			diff, err := DiffCreateTablesQueries(ts.from, ts.to, hints)
+			require.NoError(t, err)
+			assert.Equal(t, ts.expect, DataLossRisks(diff))
+		})
+	}
+}
@@ -767,6 +767,104 @@ func (s *Schema) Apply(diffs []EntityDiff) (*Schema, error) {
 	return dup, nil
 }
 
+// ApplyStatement applies the given CREATE, ALTER or DROP TABLE statement, or CREATE or DROP VIEW
+// statement, to the schema described by this object, as MySQL would.
+// The operation does not modify this object. Instead, if successful, a new (modified) Schema is returned,
+// which can be diffed with this schema to see what the statement amounts to.
+func (s *Schema) ApplyStatement(stmt sqlparser.DDLStatement) (*Schema, error) {
+	entities := make([]Entity, len(s.sorted))
+	copy(entities, s.sorted)
+	indexOf := func(name string) int {
+		for i, e := range entities {
+			if e.Name() == name {
+				return i
+			}
+		}
+		return -1
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.CreateTable:
+		name := stmt.Table.Name.String()
+		if indexOf(name) >= 0 {
+			if stmt.IfNotExists {
+				return s.copy(), nil
+			}
+			return nil, &ApplyDuplicateEntityError{Entity: name}
+		}
+		c, err := NewCreateTableEntity(sqlparser.CloneRefOfCreateTable(stmt))
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, c)
+	case *sqlparser.AlterTable:
+		name := stmt.Table.Name.String()
+		i := indexOf(name)
+		if i < 0 {
+			return nil, &ApplyTableNotFoundError{Table: name}
+		}
+		from, ok := entities[i].(*CreateTableEntity)
+		if !ok {
+			return nil, ErrEntityTypeMismatch
+		}
+		to, err := from.Apply(&AlterTableEntityDiff{from: from, alterTable: sqlparser.CloneRefOfAlterTable(stmt)})
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = to
+	case *sqlparser.DropTable:
+		for _, table := range stmt.FromTables {
+			i := indexOf(table.Name.String())
+			if i < 0 {
+				if stmt.IfExists {
+					continue
+				}
+				return nil, &ApplyTableNotFoundError{Table: table.Name.String()}
+			}
+			if _, ok := entities[i].(*CreateTableEntity); !ok {
+				return nil, ErrEntityTypeMismatch
+			}
+			entities = append(entities[:i], entities[i+1:]...)
+		}
+	case *sqlparser.CreateView:
+		// The entity is the view as it ends up in the schema, which is not
+		// a replacement of anything.
+		createView := sqlparser.CloneRefOfCreateView(stmt)
+		createView.IsReplace = false
+		v, err := NewCreateViewEntity(createView)
+		if err != nil {
+			return nil, err
+		}
+		switch i := indexOf(v.Name()); {
+		case i < 0:
+			entities = append(entities, v)
+		case !stmt.IsReplace:
+			return nil, &ApplyDuplicateEntityError{Entity: v.Name()}
+		default:
+			if _, ok := entities[i].(*CreateViewEntity); !ok {
+				return nil, ErrEntityTypeMismatch
+			}
+			entities[i] = v
+		}
+	case *sqlparser.DropView:
+		for _, view := range stmt.FromTables {
+			i := indexOf(view.Name.String())
+			if i < 0 {
+				if stmt.IfExists {
+					continue
+				}
+				return nil, &ApplyViewNotFoundError{View: view.Name.String()}
+			}
+			if _, ok := entities[i].(*CreateViewEntity); !ok {
+				return nil, ErrEntityTypeMismatch
+			}
+			entities = append(entities[:i], entities[i+1:]...)
+		}
+	default:
+		return nil, &UnsupportedApplyOperationError{Statement: sqlparser.CanonicalString(stmt)}
+	}
+	return NewSchemaFromEntities(entities)
+}
+
 // SchemaDiff calulates a rich diff between this schema and the given schema. It builds on top of diff():
 // on top of the list of diffs that can take this schema into the given schema, this function also
 // evaluates the dependencies between those diffs, if any, and the resulting SchemaDiff object offers OrderedDiffs(),
@@ -823,3 +823,113 @@ func TestMassiveSchema(t *testing.T) {
 	elapsed := time.Since(startTime)
 	assert.Less(t, elapsed, time.Minute)
 }
+
+func TestApplyStatement(t *testing.T) {
+	tt := []struct {
+		name      string
+		from      string
+		statement string
+		to        string
+		expectErr error
+	}{
+		{
+			name:      "create table",
+			from:      "create table t1 (id int primary key)",
+			statement: "create table t2 (id int primary key)",
+			to:        "create table t1 (id int primary key); create table t2 (id int primary key)",
+		},
+		{
+			name:      "create existing table",
+			from:      "create table t1 (id int primary key)",
+			statement: "create table t1 (id bigint primary key)",
+			expectErr: &ApplyDuplicateEntityError{Entity: "t1"},
+		},
+		{
+			name:      "create existing table if not exists",
+			from:      "create table t1 (id int primary key)",
+			statement: "create table if not exists t1 (id bigint primary key)",
+			to:        "create table t1 (id int primary key)",
+		},
+		{
+			name:      "alter table",
+			from:      "create table t1 (id int primary key, i int)",
+			statement: "alter table t1 add column v varchar(32), drop column i, add key v_idx (v)",
+			to:        "create table t1 (id int primary key, v varchar(32), key v_idx (v))",
+		},
+		{
+			name:      "alter missing table",
+			from:      "create table t1 (id int primary key)",
+			statement: "alter table t2 add column i int",
+			expectErr: &ApplyTableNotFoundError{Table: "t2"},
+		},
+		{
+			name:      "drop tables",
+			from:      "create table t1 (id int primary key); create table t2 (id int primary key); create table t3 (id int primary key)",
+			statement: "drop table t1, t3",
+			to:        "create table t2 (id int primary key)",
+		},
+		{
+			name:      "drop missing table",
+			from:      "create table t1 (id int primary key)",
+			statement: "drop table t2",
+			expectErr: &ApplyTableNotFoundError{Table: "t2"},
+		},
+		{
+			name:      "drop missing table if exists",
+			from:      "create table t1 (id int primary key)",
+			statement: "drop table if exists t1, t2",
+			to:        "",
+		},
+		{
+			name:      "create view",
+			from:      "create table t1 (id int primary key)",
+			statement: "create view v1 as select id from t1",
+			to:        "create table t1 (id int primary key); create view v1 as select id from t1",
+		},
+		{
+			name:      "replace view",
+			from:      "create table t1 (id int primary key, i int); create view v1 as select id from t1",
+			statement: "create or replace view v1 as select id, i from t1",
+			to:        "create table t1 (id int primary key, i int); create view v1 as select id, i from t1",
+		},
+		{
+			name:      "drop view",
+			from:      "create table t1 (id int primary key); create view v1 as select id from t1",
+			statement: "drop view v1",
+			to:        "create table t1 (id int primary key)",
+		},
+		{
+			name:      "drop table as view",
+			from:      "create table t1 (id int primary key)",
+			statement: "drop view t1",
+			expectErr: ErrEntityTypeMismatch,
+		},
+	}
+	hints := &DiffHints{}
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			schema, err := NewSchemaFromSQL(ts.from)
+			require.NoError(t, err)
+			stmt, err := sqlparser.ParseStrictDDL(ts.statement)
+			require.NoError(t, err)
+
+			applied, err := schema.ApplyStatement(stmt.(sqlparser.DDLStatement))
+			if ts.expectErr != nil {
+				assert.Equal(t, ts.expectErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			expected, err := NewSchemaFromSQL(ts.to)
+			require.NoError(t, err)
+			diff, err := applied.SchemaDiff(expected, hints)
+			require.NoError(t, err)
+			assert.True(t, diff.Empty(), "unexpected diff: %v", diff.UnorderedDiffs())
+
+			// The original schema is unmodified.
+			fromSchema, err := NewSchemaFromSQL(ts.from)
+			require.NoError(t, err)
+			assert.Equal(t, fromSchema.ToSQL(), schema.ToSQL())
+		})
+	}
+}
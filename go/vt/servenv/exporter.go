@@ -644,6 +644,24 @@ func (tw *TimingsWrapper) Record(name string, startTime time.Time) {
 	tw.timings.Record([]string{tw.name, name}, startTime)
 }
 
+// AddWithExemplar behaves like Timings.AddWithExemplar.
+func (tw *TimingsWrapper) AddWithExemplar(name string, elapsed time.Duration, traceID string) {
+	if tw.name == "" {
+		tw.timings.AddWithExemplar([]string{name}, elapsed, traceID)
+		return
+	}
+	tw.timings.AddWithExemplar([]string{tw.name, name}, elapsed, traceID)
+}
+
+// RecordWithExemplar behaves like Timings.RecordWithExemplar.
+func (tw *TimingsWrapper) RecordWithExemplar(name string, startTime time.Time, traceID string) {
+	if tw.name == "" {
+		tw.timings.RecordWithExemplar([]string{name}, startTime, traceID)
+		return
+	}
+	tw.timings.RecordWithExemplar([]string{tw.name, name}, startTime, traceID)
+}
+
 // Counts behaves like Timings.Counts.
 func (tw *TimingsWrapper) Counts() map[string]int64 {
 	return tw.timings.Counts()
@@ -682,6 +700,26 @@ func (tw *MultiTimingsWrapper) Record(names []string, startTime time.Time) {
 	tw.timings.Record(newlabels, startTime)
 }
 
+// AddWithExemplar behaves like MultiTimings.AddWithExemplar.
+func (tw *MultiTimingsWrapper) AddWithExemplar(names []string, elapsed time.Duration, traceID string) {
+	if tw.name == "" {
+		tw.timings.AddWithExemplar(names, elapsed, traceID)
+		return
+	}
+	newlabels := combineLabels(tw.name, names)
+	tw.timings.AddWithExemplar(newlabels, elapsed, traceID)
+}
+
+// RecordWithExemplar behaves like MultiTimings.RecordWithExemplar.
+func (tw *MultiTimingsWrapper) RecordWithExemplar(names []string, startTime time.Time, traceID string) {
+	if tw.name == "" {
+		tw.timings.RecordWithExemplar(names, startTime, traceID)
+		return
+	}
+	newlabels := combineLabels(tw.name, names)
+	tw.timings.RecordWithExemplar(newlabels, startTime, traceID)
+}
+
 // Counts behaves lie MultiTimings.Counts.
 func (tw *MultiTimingsWrapper) Counts() map[string]int64 {
 	return tw.timings.Counts()
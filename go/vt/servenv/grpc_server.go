@@ -65,6 +65,10 @@ var (
 	GRPCServer *grpc.Server
 
 	authPlugin Authenticator
+
+	// grpcServerInterceptors are the interceptors added with
+	// AddGRPCServerInterceptors.
+	grpcServerInterceptors serverInterceptorBuilder
 )
 
 // Misc. server variables.
@@ -194,8 +198,9 @@ func createGRPCServer() {
 			log.Exitf("Failed to log gRPC cert/key/ca: %v", err)
 		}
 
-		// create the creds server options
-		creds := credentials.NewTLS(config)
+		// create the creds server options, with a config which can be
+		// replaced when the certificates are reloaded
+		creds := credentials.NewTLS(reloadableGRPCServerTLSConfig(config))
 		if gRPCEnableOptionalTLS {
 			log.Warning("Optional TLS is active. Plain-text connections will be accepted")
 			creds = grpcoptionaltls.New(creds)
@@ -256,6 +261,9 @@ func interceptors() []grpc.ServerOption {
 		interceptors.Add(authenticatingStreamInterceptor, authenticatingUnaryInterceptor)
 	}
 
+	interceptors.streamInterceptors = append(interceptors.streamInterceptors, grpcServerInterceptors.streamInterceptors...)
+	interceptors.unaryInterceptors = append(interceptors.unaryInterceptors, grpcServerInterceptors.unaryInterceptors...)
+
 	if grpccommon.EnableGRPCPrometheus() {
 		interceptors.Add(grpc_prometheus.StreamServerInterceptor, grpc_prometheus.UnaryServerInterceptor)
 	}
@@ -313,6 +321,13 @@ func serveGRPC() {
 	})
 }
 
+// AddGRPCServerInterceptors adds interceptors to GRPCServer, which run after
+// the authentication of the calls, e.g. to authorize them. It must be called
+// before GRPCServer is created, e.g. in an OnInit hook.
+func AddGRPCServerInterceptors(s grpc.StreamServerInterceptor, u grpc.UnaryServerInterceptor) {
+	grpcServerInterceptors.Add(s, u)
+}
+
 // GRPCCheckServiceMap returns if we should register a gRPC service
 // (and also logs how to enable / disable it)
 func GRPCCheckServiceMap(name string) bool {
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttls"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	// gRPCServerTLSConfig is the TLS config of the new connections of the
+	// gRPC server, if it uses TLS. It is replaced when the certificates are
+	// reloaded.
+	gRPCServerTLSConfig atomic.Pointer[tls.Config]
+	// gRPCServerTLSMu serializes the reloads of the certificates.
+	gRPCServerTLSMu sync.Mutex
+)
+
+// reloadableGRPCServerTLSConfig returns a TLS config which serves config to
+// the new connections, until it is replaced by a reload of the certificates.
+func reloadableGRPCServerTLSConfig(config *tls.Config) *tls.Config {
+	storeGRPCServerTLSConfig(config)
+	return &tls.Config{
+		MinVersion: config.MinVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return gRPCServerTLSConfig.Load(), nil
+		},
+	}
+}
+
+func storeGRPCServerTLSConfig(config *tls.Config) {
+	// The config returned by GetConfigForClient is used as is for the
+	// handshake, so it needs the HTTP/2 protocol which credentials.NewTLS
+	// only adds to the config it is given.
+	config = config.Clone()
+	if !slices.Contains(config.NextProtos, "h2") {
+		config.NextProtos = append(config.NextProtos, "h2")
+	}
+	gRPCServerTLSConfig.Store(config)
+}
+
+// GRPCServerCertificate returns the certificate served by the gRPC server to
+// the new connections, or nil if it does not use TLS.
+func GRPCServerCertificate() (*x509.Certificate, error) {
+	config := gRPCServerTLSConfig.Load()
+	if config == nil || len(config.Certificates) == 0 {
+		return nil, nil
+	}
+	return x509.ParseCertificate(config.Certificates[0].Certificate[0])
+}
+
+// ReloadGRPCServerCertificates re-reads the certificate, key and CAs of the
+// gRPC server from the files of the --grpc_cert, --grpc_key, --grpc_ca and
+// --grpc_server_ca flags, and serves them to the new connections. The
+// established connections are not interrupted.
+func ReloadGRPCServerCertificates() error {
+	gRPCServerTLSMu.Lock()
+	defer gRPCServerTLSMu.Unlock()
+
+	return reloadGRPCServerCertificates()
+}
+
+func reloadGRPCServerCertificates() error {
+	if gRPCServerTLSConfig.Load() == nil {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the gRPC server does not use TLS")
+	}
+
+	config, err := vttls.ReloadServerConfig(gRPCCert, gRPCKey, gRPCCA, gRPCCRL, gRPCServerCA, tls.VersionTLS12)
+	if err != nil {
+		return err
+	}
+	storeGRPCServerTLSConfig(config)
+
+	log.Infof("Reloaded the gRPC server certificate from %s", gRPCCert)
+	return nil
+}
+
+// UpdateGRPCServerCertificates writes the given PEM encoded certificate, key
+// and CA of the gRPC server, for the ones which are not empty, to the files
+// of the --grpc_cert, --grpc_key and --grpc_ca flags, then reloads them as
+// ReloadGRPCServerCertificates. The files are left unchanged if the new
+// certificates are invalid or cannot be loaded.
+func UpdateGRPCServerCertificates(cert []byte, key []byte, ca []byte) error {
+	gRPCServerTLSMu.Lock()
+	defer gRPCServerTLSMu.Unlock()
+
+	if gRPCServerTLSConfig.Load() == nil {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the gRPC server does not use TLS")
+	}
+
+	if len(cert) > 0 || len(key) > 0 {
+		// The new certificate must match the current key, and the other way
+		// around, if only one of them is replaced.
+		certPEM, keyPEM := cert, key
+		var err error
+		if len(certPEM) == 0 {
+			if certPEM, err = os.ReadFile(gRPCCert); err != nil {
+				return err
+			}
+		}
+		if len(keyPEM) == 0 {
+			if keyPEM, err = os.ReadFile(gRPCKey); err != nil {
+				return err
+			}
+		}
+		if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid certificate and key: %v", err)
+		}
+	}
+	if len(ca) > 0 {
+		if gRPCCA == "" {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the gRPC server does not check client certificates, it has no --grpc_ca")
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(ca) {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid CA: no certificate found")
+		}
+	}
+
+	type file struct {
+		path string
+		data []byte
+	}
+	var updates, backups []file
+	for _, update := range []file{{gRPCCert, cert}, {gRPCKey, key}, {gRPCCA, ca}} {
+		if len(update.data) > 0 {
+			updates = append(updates, update)
+		}
+	}
+	restore := func() {
+		for _, backup := range backups {
+			if err := writeFileAtomically(backup.path, backup.data); err != nil {
+				log.Errorf("Failed to restore %s: %v", backup.path, err)
+			}
+		}
+	}
+	for _, update := range updates {
+		data, err := os.ReadFile(update.path)
+		if err != nil {
+			restore()
+			return err
+		}
+		if err := writeFileAtomically(update.path, update.data); err != nil {
+			restore()
+			return err
+		}
+		backups = append(backups, file{update.path, data})
+	}
+
+	if err := reloadGRPCServerCertificates(); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// writeFileAtomically replaces the contents of a file, keeping its
+// permissions, so that it is never read partially written.
+func writeFileAtomically(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
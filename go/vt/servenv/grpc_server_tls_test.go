@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/tlstest"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+func TestUpdateGRPCServerCertificates(t *testing.T) {
+	root := t.TempDir()
+	keyPairs := tlstest.CreateClientServerCertPairs(root)
+
+	oldCert, oldKey, oldCA := gRPCCert, gRPCKey, gRPCCA
+	gRPCCert, gRPCKey, gRPCCA = keyPairs.ServerCert, keyPairs.ServerKey, keyPairs.ClientCA
+	defer func() {
+		gRPCCert, gRPCKey, gRPCCA = oldCert, oldKey, oldCA
+		gRPCServerTLSConfig.Store(nil)
+	}()
+
+	// Without TLS, there is nothing to update.
+	gRPCServerTLSConfig.Store(nil)
+	assert.Error(t, ReloadGRPCServerCertificates())
+	cert, err := GRPCServerCertificate()
+	require.NoError(t, err)
+	assert.Nil(t, cert)
+
+	config, err := vttls.ServerConfig(gRPCCert, gRPCKey, gRPCCA, "", "", tls.VersionTLS12)
+	require.NoError(t, err)
+	serverConfig := reloadableGRPCServerTLSConfig(config)
+
+	served := func() *tls.Config {
+		config, err := serverConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		return config
+	}
+	servedCert := func() []byte {
+		cert, err := GRPCServerCertificate()
+		require.NoError(t, err)
+		require.NotNil(t, cert)
+		assert.Equal(t, cert.Raw, served().Certificates[0].Certificate[0])
+		assert.Contains(t, served().NextProtos, "h2")
+		return cert.Raw
+	}
+	certDER := func(certPEM []byte) []byte {
+		block, _ := pem.Decode(certPEM)
+		require.NotNil(t, block)
+		return block.Bytes
+	}
+
+	oldCertPEM, err := os.ReadFile(keyPairs.ServerCert)
+	require.NoError(t, err)
+	oldKeyPEM, err := os.ReadFile(keyPairs.ServerKey)
+	require.NoError(t, err)
+	newCertPEM, err := os.ReadFile(keyPairs.RevokedServerCert)
+	require.NoError(t, err)
+	newKeyPEM, err := os.ReadFile(keyPairs.RevokedServerKey)
+	require.NoError(t, err)
+	assert.Equal(t, certDER(oldCertPEM), servedCert())
+
+	// A certificate which does not match the key is refused, and the files
+	// are left unchanged.
+	err = UpdateGRPCServerCertificates(newCertPEM, nil, nil)
+	assert.ErrorContains(t, err, "invalid certificate and key")
+	data, err := os.ReadFile(keyPairs.ServerCert)
+	require.NoError(t, err)
+	assert.Equal(t, oldCertPEM, data)
+
+	err = UpdateGRPCServerCertificates(nil, nil, []byte("not a CA"))
+	assert.ErrorContains(t, err, "invalid CA")
+
+	require.NoError(t, UpdateGRPCServerCertificates(newCertPEM, newKeyPEM, nil))
+	data, err = os.ReadFile(keyPairs.ServerCert)
+	require.NoError(t, err)
+	assert.Equal(t, newCertPEM, data)
+	assert.Equal(t, certDER(newCertPEM), servedCert())
+
+	// A reload serves whatever the files contain.
+	require.NoError(t, os.WriteFile(keyPairs.ServerCert, oldCertPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyPairs.ServerKey, oldKeyPEM, 0o600))
+	require.NoError(t, ReloadGRPCServerCertificates())
+	assert.Equal(t, certDER(oldCertPEM), servedCert())
+}
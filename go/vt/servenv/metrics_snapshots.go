@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/stats"
+)
+
+// This file registers /debug/metrics_snapshots, which captures named snapshots
+// of the counters and gauges of the process and returns what changed since
+// them, e.g. during an incident window, without a metrics backend:
+//
+//	/debug/metrics_snapshots?take=before  takes a snapshot named "before"
+//	/debug/metrics_snapshots?diff=before  returns the changes since "before"
+//	/debug/metrics_snapshots?diff=before&to=after
+//	                                      returns the changes between two snapshots
+//	/debug/metrics_snapshots?delete=before
+//	                                      deletes a snapshot
+//	/debug/metrics_snapshots              lists the snapshots
+
+// maxMetricsSnapshots is the number of snapshots kept, the oldest one being
+// dropped when a new one is taken.
+const maxMetricsSnapshots = 16
+
+var metricsSnapshots = struct {
+	mu        sync.Mutex
+	snapshots map[string]*stats.Snapshot
+}{
+	snapshots: make(map[string]*stats.Snapshot),
+}
+
+// metricsSnapshotSummary describes a snapshot in the list of snapshots.
+type metricsSnapshotSummary struct {
+	Name   string
+	Time   time.Time
+	Values int
+}
+
+func init() {
+	HTTPHandleFunc("/debug/metrics_snapshots", metricsSnapshotsHandler)
+}
+
+func metricsSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	role := acl.MONITORING
+	if r.FormValue("take") != "" || r.FormValue("delete") != "" {
+		role = acl.DEBUGGING
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+
+	var (
+		result any
+		err    error
+	)
+	switch {
+	case r.FormValue("take") != "":
+		result = takeMetricsSnapshot(r.FormValue("take"))
+	case r.FormValue("delete") != "":
+		err = deleteMetricsSnapshot(r.FormValue("delete"))
+		result = listMetricsSnapshots()
+	case r.FormValue("diff") != "":
+		result, err = diffMetricsSnapshots(r.FormValue("diff"), r.FormValue("to"))
+	default:
+		result = listMetricsSnapshots()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(data)
+}
+
+// takeMetricsSnapshot takes a snapshot, replacing the one of the same name.
+func takeMetricsSnapshot(name string) metricsSnapshotSummary {
+	snapshot := stats.TakeSnapshot(name)
+
+	metricsSnapshots.mu.Lock()
+	defer metricsSnapshots.mu.Unlock()
+	if _, ok := metricsSnapshots.snapshots[name]; !ok && len(metricsSnapshots.snapshots) >= maxMetricsSnapshots {
+		var oldest *stats.Snapshot
+		for _, s := range metricsSnapshots.snapshots {
+			if oldest == nil || s.Time.Before(oldest.Time) {
+				oldest = s
+			}
+		}
+		delete(metricsSnapshots.snapshots, oldest.Name)
+	}
+	metricsSnapshots.snapshots[name] = snapshot
+	return metricsSnapshotSummary{Name: name, Time: snapshot.Time, Values: len(snapshot.Values)}
+}
+
+func deleteMetricsSnapshot(name string) error {
+	metricsSnapshots.mu.Lock()
+	defer metricsSnapshots.mu.Unlock()
+	if _, ok := metricsSnapshots.snapshots[name]; !ok {
+		return fmt.Errorf("no metrics snapshot named %q", name)
+	}
+	delete(metricsSnapshots.snapshots, name)
+	return nil
+}
+
+// diffMetricsSnapshots returns the changes between the snapshots named from
+// and to, or between the snapshot named from and now if to is empty.
+func diffMetricsSnapshots(from, to string) (*stats.SnapshotDiff, error) {
+	metricsSnapshots.mu.Lock()
+	fromSnapshot, ok := metricsSnapshots.snapshots[from]
+	toSnapshot := metricsSnapshots.snapshots[to]
+	metricsSnapshots.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no metrics snapshot named %q", from)
+	}
+	if to == "" {
+		toSnapshot = stats.TakeSnapshot("")
+	} else if toSnapshot == nil {
+		return nil, fmt.Errorf("no metrics snapshot named %q", to)
+	}
+	return fromSnapshot.Diff(toSnapshot), nil
+}
+
+func listMetricsSnapshots() []metricsSnapshotSummary {
+	metricsSnapshots.mu.Lock()
+	defer metricsSnapshots.mu.Unlock()
+	summaries := make([]metricsSnapshotSummary, 0, len(metricsSnapshots.snapshots))
+	for _, s := range metricsSnapshots.snapshots {
+		summaries = append(summaries, metricsSnapshotSummary{Name: s.Name, Time: s.Time, Values: len(s.Values)})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Time.Before(summaries[j].Time)
+	})
+	return summaries
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/servenv/testutils"
+)
+
+func TestMetricsSnapshotsHandler(t *testing.T) {
+	server := testutils.HTTPTestServer()
+	defer server.Close()
+
+	get := func(query string, result any) int {
+		resp, err := http.Get(server.URL + "/debug/metrics_snapshots" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(body, result), string(body))
+		}
+		return resp.StatusCode
+	}
+
+	counter := stats.NewCounter("MetricsSnapshotsTestCounter", "help")
+	counter.Add(1)
+
+	var summary metricsSnapshotSummary
+	require.Equal(t, http.StatusOK, get("?take=before", &summary))
+	assert.Equal(t, "before", summary.Name)
+
+	counter.Add(2)
+	var diff stats.SnapshotDiff
+	require.Equal(t, http.StatusOK, get("?diff=before", &diff))
+	assert.Equal(t, "before", diff.Snapshot)
+	assert.EqualValues(t, 2, diff.Deltas["MetricsSnapshotsTestCounter"])
+
+	require.Equal(t, http.StatusOK, get("?take=after", &summary))
+	counter.Add(4)
+	require.Equal(t, http.StatusOK, get("?diff=before&to=after", &diff))
+	assert.EqualValues(t, 2, diff.Deltas["MetricsSnapshotsTestCounter"])
+
+	var list []metricsSnapshotSummary
+	require.Equal(t, http.StatusOK, get("", &list))
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, "before", list[0].Name)
+		assert.Equal(t, "after", list[1].Name)
+	}
+
+	require.Equal(t, http.StatusOK, get("?delete=before", &list))
+	assert.Len(t, list, 1)
+	assert.Equal(t, http.StatusNotFound, get("?diff=before", &diff))
+	assert.Equal(t, http.StatusNotFound, get("?delete=before", &list))
+	assert.Equal(t, http.StatusNotFound, get("?diff=after&to=unknown", &diff))
+}
+
+func TestMetricsSnapshotsLimit(t *testing.T) {
+	defer func() {
+		for _, s := range listMetricsSnapshots() {
+			deleteMetricsSnapshot(s.Name)
+		}
+	}()
+	for i := 0; i <= maxMetricsSnapshots; i++ {
+		takeMetricsSnapshot(fmt.Sprintf("snapshot%d", i))
+	}
+	list := listMetricsSnapshots()
+	require.Len(t, list, maxMetricsSnapshots)
+	assert.Equal(t, "snapshot1", list[0].Name)
+	assert.Equal(t, fmt.Sprintf("snapshot%d", maxMetricsSnapshots), list[maxMetricsSnapshots-1].Name)
+}
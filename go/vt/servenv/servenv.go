@@ -473,6 +473,7 @@ func init() {
 	// These are the binaries that call trace.StartTracing.
 	for _, cmd := range []string{
 		"vtadmin",
+		"vtcdc",
 		"vtclient",
 		"vtcombo",
 		"vtctl",
@@ -0,0 +1,29 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+CREATE TABLE IF NOT EXISTS vdiff_history
+(
+    `id`           bigint(20)     NOT NULL AUTO_INCREMENT,
+    `vdiff_id`     bigint(20)     NOT NULL,
+    `state`        varbinary(64)           DEFAULT NULL,
+    `last_error`   varbinary(512)          DEFAULT NULL,
+    `started_at`   timestamp      NULL     DEFAULT NULL,
+    `completed_at` timestamp      NULL     DEFAULT NULL,
+    `report`       json                    DEFAULT NULL,
+    `created_at`   timestamp      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `vdiff_idx` (`vdiff_id`)
+) ENGINE = InnoDB
@@ -558,8 +558,29 @@ type (
 	// DDLAction is an enum for DDL.Action
 	DDLAction int8
 
-	// Load represents a LOAD statement
+	// Load represents a LOAD statement. Only LOAD DATA LOCAL INFILE is
+	// parsed into its parts, the other forms are passed through as is.
 	Load struct {
+		Local       bool
+		FileName    string
+		Action      InsertAction
+		Ignore      Ignore
+		Table       TableName
+		Charset     ColumnCharset
+		Options     *LoadDataOptions
+		IgnoreLines *Literal
+		Columns     Columns
+	}
+
+	// LoadDataOptions represents the FIELDS and LINES clauses of a LOAD DATA
+	// statement. The options left nil keep the default of MySQL.
+	LoadDataOptions struct {
+		FieldsTerminatedBy       *Literal
+		FieldsEnclosedBy         *Literal
+		FieldsOptionallyEnclosed bool
+		FieldsEscapedBy          *Literal
+		LinesStartingBy          *Literal
+		LinesTerminatedBy        *Literal
 	}
 
 	// PurgeBinaryLogs represents a PURGE BINARY LOGS statement
@@ -2054,6 +2054,11 @@ func CloneRefOfLoad(n *Load) *Load {
 		return nil
 	}
 	out := *n
+	out.Table = CloneTableName(n.Table)
+	out.Charset = CloneColumnCharset(n.Charset)
+	out.Options = CloneRefOfLoadDataOptions(n.Options)
+	out.IgnoreLines = CloneRefOfLiteral(n.IgnoreLines)
+	out.Columns = CloneColumns(n.Columns)
 	return &out
 }
 
@@ -4474,6 +4479,20 @@ func CloneRefOfJtNestedPathColDef(n *JtNestedPathColDef) *JtNestedPathColDef {
 	return &out
 }
 
+// CloneRefOfLoadDataOptions creates a deep clone of the input.
+func CloneRefOfLoadDataOptions(n *LoadDataOptions) *LoadDataOptions {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.FieldsTerminatedBy = CloneRefOfLiteral(n.FieldsTerminatedBy)
+	out.FieldsEnclosedBy = CloneRefOfLiteral(n.FieldsEnclosedBy)
+	out.FieldsEscapedBy = CloneRefOfLiteral(n.FieldsEscapedBy)
+	out.LinesStartingBy = CloneRefOfLiteral(n.LinesStartingBy)
+	out.LinesTerminatedBy = CloneRefOfLiteral(n.LinesTerminatedBy)
+	return &out
+}
+
 // CloneTableAndLockTypes creates a deep clone of the input.
 func CloneTableAndLockTypes(n TableAndLockTypes) TableAndLockTypes {
 	if n == nil {
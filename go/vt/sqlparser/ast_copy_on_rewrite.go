@@ -3767,6 +3767,20 @@ func (c *cow) copyOnRewriteRefOfLoad(n *Load, parent SQLNode) (out SQLNode, chan
 	}
 	out = n
 	if c.pre == nil || c.pre(n, parent) {
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_IgnoreLines, changedIgnoreLines := c.copyOnRewriteRefOfLiteral(n.IgnoreLines, n)
+		_Columns, changedColumns := c.copyOnRewriteColumns(n.Columns, n)
+		if changedTable || changedIgnoreLines || changedColumns {
+			res := *n
+			res.Table, _ = _Table.(TableName)
+			res.IgnoreLines, _ = _IgnoreLines.(*Literal)
+			res.Columns, _ = _Columns.(Columns)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
 	}
 	if c.post != nil {
 		out, changed = c.postVisit(out, parent, changed)
@@ -3368,7 +3368,15 @@ func (cmp *Comparator) RefOfLoad(a, b *Load) bool {
 	if a == nil || b == nil {
 		return false
 	}
-	return true
+	return a.Local == b.Local &&
+		a.FileName == b.FileName &&
+		a.Action == b.Action &&
+		a.Ignore == b.Ignore &&
+		cmp.TableName(a.Table, b.Table) &&
+		cmp.ColumnCharset(a.Charset, b.Charset) &&
+		cmp.RefOfLoadDataOptions(a.Options, b.Options) &&
+		cmp.RefOfLiteral(a.IgnoreLines, b.IgnoreLines) &&
+		cmp.Columns(a.Columns, b.Columns)
 }
 
 // RefOfLocateExpr does deep equals between the two objects.
@@ -7332,6 +7340,22 @@ func (cmp *Comparator) RefOfJtNestedPathColDef(a, b *JtNestedPathColDef) bool {
 		cmp.SliceOfRefOfJtColumnDefinition(a.Columns, b.Columns)
 }
 
+// RefOfLoadDataOptions does deep equals between the two objects.
+func (cmp *Comparator) RefOfLoadDataOptions(a, b *LoadDataOptions) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.FieldsOptionallyEnclosed == b.FieldsOptionallyEnclosed &&
+		cmp.RefOfLiteral(a.FieldsTerminatedBy, b.FieldsTerminatedBy) &&
+		cmp.RefOfLiteral(a.FieldsEnclosedBy, b.FieldsEnclosedBy) &&
+		cmp.RefOfLiteral(a.FieldsEscapedBy, b.FieldsEscapedBy) &&
+		cmp.RefOfLiteral(a.LinesStartingBy, b.LinesStartingBy) &&
+		cmp.RefOfLiteral(a.LinesTerminatedBy, b.LinesTerminatedBy)
+}
+
 // TableAndLockTypes does deep equals between the two objects.
 func (cmp *Comparator) TableAndLockTypes(a, b TableAndLockTypes) bool {
 	if len(a) != len(b) {
@@ -2006,7 +2006,50 @@ func (node IdentifierCS) Format(buf *TrackedBuffer) {
 
 // Format formats the node.
 func (node *Load) Format(buf *TrackedBuffer) {
-	buf.literal("AST node missing for Load type")
+	if !node.Local {
+		buf.literal("AST node missing for Load type")
+		return
+	}
+	buf.astPrintf(node, "load data local infile %#s ", encodeSQLString(node.FileName))
+	if node.Action == ReplaceAct {
+		buf.literal("replace ")
+	}
+	buf.astPrintf(node, "%sinto table %v", node.Ignore.ToString(), node.Table)
+	if node.Charset.Name != "" {
+		buf.astPrintf(node, " character set %#s", node.Charset.Name)
+	}
+	if opts := node.Options; opts != nil {
+		if opts.FieldsTerminatedBy != nil || opts.FieldsEnclosedBy != nil || opts.FieldsEscapedBy != nil {
+			buf.literal(" fields")
+			if opts.FieldsTerminatedBy != nil {
+				buf.astPrintf(node, " terminated by %v", opts.FieldsTerminatedBy)
+			}
+			if opts.FieldsEnclosedBy != nil {
+				if opts.FieldsOptionallyEnclosed {
+					buf.literal(" optionally")
+				}
+				buf.astPrintf(node, " enclosed by %v", opts.FieldsEnclosedBy)
+			}
+			if opts.FieldsEscapedBy != nil {
+				buf.astPrintf(node, " escaped by %v", opts.FieldsEscapedBy)
+			}
+		}
+		if opts.LinesStartingBy != nil || opts.LinesTerminatedBy != nil {
+			buf.literal(" lines")
+			if opts.LinesStartingBy != nil {
+				buf.astPrintf(node, " starting by %v", opts.LinesStartingBy)
+			}
+			if opts.LinesTerminatedBy != nil {
+				buf.astPrintf(node, " terminated by %v", opts.LinesTerminatedBy)
+			}
+		}
+	}
+	if node.IgnoreLines != nil {
+		buf.astPrintf(node, " ignore %v lines", node.IgnoreLines)
+	}
+	if node.Columns != nil {
+		buf.astPrintf(node, " %v", node.Columns)
+	}
 }
 
 // Format formats the node.
@@ -2677,7 +2677,63 @@ func (node IdentifierCS) formatFast(buf *TrackedBuffer) {
 
 // formatFast formats the node.
 func (node *Load) formatFast(buf *TrackedBuffer) {
-	buf.WriteString("AST node missing for Load type")
+	if !node.Local {
+		buf.WriteString("AST node missing for Load type")
+		return
+	}
+	buf.WriteString("load data local infile ")
+	buf.WriteString(encodeSQLString(node.FileName))
+	buf.WriteByte(' ')
+	if node.Action == ReplaceAct {
+		buf.WriteString("replace ")
+	}
+	buf.WriteString(node.Ignore.ToString())
+	buf.WriteString("into table ")
+	node.Table.formatFast(buf)
+	if node.Charset.Name != "" {
+		buf.WriteString(" character set ")
+		buf.WriteString(node.Charset.Name)
+	}
+	if opts := node.Options; opts != nil {
+		if opts.FieldsTerminatedBy != nil || opts.FieldsEnclosedBy != nil || opts.FieldsEscapedBy != nil {
+			buf.WriteString(" fields")
+			if opts.FieldsTerminatedBy != nil {
+				buf.WriteString(" terminated by ")
+				opts.FieldsTerminatedBy.formatFast(buf)
+			}
+			if opts.FieldsEnclosedBy != nil {
+				if opts.FieldsOptionallyEnclosed {
+					buf.WriteString(" optionally")
+				}
+				buf.WriteString(" enclosed by ")
+				opts.FieldsEnclosedBy.formatFast(buf)
+			}
+			if opts.FieldsEscapedBy != nil {
+				buf.WriteString(" escaped by ")
+				opts.FieldsEscapedBy.formatFast(buf)
+			}
+		}
+		if opts.LinesStartingBy != nil || opts.LinesTerminatedBy != nil {
+			buf.WriteString(" lines")
+			if opts.LinesStartingBy != nil {
+				buf.WriteString(" starting by ")
+				opts.LinesStartingBy.formatFast(buf)
+			}
+			if opts.LinesTerminatedBy != nil {
+				buf.WriteString(" terminated by ")
+				opts.LinesTerminatedBy.formatFast(buf)
+			}
+		}
+	}
+	if node.IgnoreLines != nil {
+		buf.WriteString(" ignore ")
+		node.IgnoreLines.formatFast(buf)
+		buf.WriteString(" lines")
+	}
+	if node.Columns != nil {
+		buf.WriteByte(' ')
+		node.Columns.formatFast(buf)
+	}
 }
 
 // formatFast formats the node.
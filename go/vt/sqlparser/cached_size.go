@@ -2633,6 +2633,53 @@ func (cached *Literal) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.Val)))
 	return size
 }
+func (cached *Load) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(128)
+	}
+	// field FileName string
+	size += hack.RuntimeAllocSize(int64(len(cached.FileName)))
+	// field Table vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Table.CachedSize(false)
+	// field Charset vitess.io/vitess/go/vt/sqlparser.ColumnCharset
+	size += cached.Charset.CachedSize(false)
+	// field Options *vitess.io/vitess/go/vt/sqlparser.LoadDataOptions
+	size += cached.Options.CachedSize(true)
+	// field IgnoreLines *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.IgnoreLines.CachedSize(true)
+	// field Columns vitess.io/vitess/go/vt/sqlparser.Columns
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Columns)) * int64(32))
+		for _, elem := range cached.Columns {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
+func (cached *LoadDataOptions) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field FieldsTerminatedBy *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.FieldsTerminatedBy.CachedSize(true)
+	// field FieldsEnclosedBy *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.FieldsEnclosedBy.CachedSize(true)
+	// field FieldsEscapedBy *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.FieldsEscapedBy.CachedSize(true)
+	// field LinesStartingBy *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.LinesStartingBy.CachedSize(true)
+	// field LinesTerminatedBy *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.LinesTerminatedBy.CachedSize(true)
+	return size
+}
 func (cached *LocateExpr) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -4559,6 +4559,48 @@ func TestLoadData(t *testing.T) {
 	}
 }
 
+func TestLoadDataLocalInfile(t *testing.T) {
+	validSQL := []struct {
+		input  string
+		output string
+	}{{
+		input: "load data local infile 'x.csv' into table t",
+	}, {
+		input:  "LOAD DATA LOCAL INFILE '/tmp/x.csv' REPLACE INTO TABLE ks.t CHARACTER SET utf8mb4",
+		output: "load data local infile '/tmp/x.csv' replace into table ks.t character set utf8mb4",
+	}, {
+		input:  "load data local infile 'x.csv' ignore into table t columns terminated by ',' optionally enclosed by '\"' escaped by '\\\\' lines starting by 'x' terminated by '\\r\\n' ignore 1 rows (a, b, c)",
+		output: "load data local infile 'x.csv' ignore into table t fields terminated by ',' optionally enclosed by '\\\"' escaped by '\\\\' lines starting by 'x' terminated by '\\r\\n' ignore 1 lines (a, b, c)",
+	}, {
+		input: "load data local infile 'x.csv' into table t lines terminated by '\\n' ignore 2 lines",
+	}}
+	for _, tcase := range validSQL {
+		t.Run(tcase.input, func(t *testing.T) {
+			if tcase.output == "" {
+				tcase.output = tcase.input
+			}
+			tree, err := Parse(tcase.input)
+			require.NoError(t, err)
+			load, ok := tree.(*Load)
+			require.True(t, ok)
+			assert.True(t, load.Local)
+			assert.Equal(t, tcase.output, String(tree))
+		})
+	}
+
+	load, err := Parse("load data local infile 'x.csv' into table t fields terminated by ';' enclosed by '\"'")
+	require.NoError(t, err)
+	opts := load.(*Load).Options
+	assert.Equal(t, ";", opts.FieldsTerminatedBy.Val)
+	assert.Equal(t, "\"", opts.FieldsEnclosedBy.Val)
+	assert.False(t, opts.FieldsOptionallyEnclosed)
+	assert.Nil(t, opts.FieldsEscapedBy)
+	assert.Nil(t, opts.LinesTerminatedBy)
+
+	_, err = Parse("load data local infile 'x.csv' into table t set a = 1")
+	assert.Error(t, err)
+}
+
 func TestCreateTable(t *testing.T) {
 	createTableQueries := []struct {
 		input, output string
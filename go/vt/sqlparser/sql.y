@@ -68,6 +68,8 @@ func markBindVariable(yylex yyLexer, bvar string) {
   alterOption      AlterOption
 
   ins           *Insert
+  load          *Load
+  loadDataOptions *LoadDataOptions
   colName       *ColName
   colNames      []*ColName
   indexHint    *IndexHint
@@ -230,7 +232,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> SELECT STREAM VSTREAM INSERT UPDATE DELETE FROM WHERE GROUP HAVING ORDER BY LIMIT OFFSET FOR
 %token <str> ALL DISTINCT AS EXISTS ASC DESC INTO DUPLICATE DEFAULT SET LOCK UNLOCK KEYS DO CALL
 %token <str> DISTINCTROW PARSER GENERATED ALWAYS
-%token <str> OUTFILE S3 DATA LOAD LINES TERMINATED ESCAPED ENCLOSED
+%token <str> OUTFILE INFILE S3 DATA LOAD LINES TERMINATED ESCAPED ENCLOSED
 %token <str> DUMPFILE CSV HEADER MANIFEST OVERWRITE STARTING OPTIONALLY
 %token <str> VALUES LAST_INSERT_ID
 %token <str> NEXT VALUE SHARE MODE
@@ -446,6 +448,8 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <namedWindow> named_window
 %type <namedWindows> named_windows_list named_windows_list_opt
 %type <insertAction> insert_or_replace
+%type <load> load_duplicate_opt
+%type <loadDataOptions> load_data_options load_fields_opts load_fields_opt_list load_lines_opts load_lines_opt_list
 %type <str> explain_synonyms
 %type <partitionOption> partitions_options_opt partitions_options_beginning
 %type <partitionDefinitionOptions> partition_definition_attribute_list_opt
@@ -508,7 +512,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <str> header_opt export_options manifest_opt overwrite_opt format_opt optionally_opt regexp_symbol
 %type <str> fields_opts fields_opt_list fields_opt lines_opts lines_opt lines_opt_list
 %type <lock> locking_clause
-%type <columns> ins_column_list column_list column_list_opt column_list_empty index_list
+%type <columns> ins_column_list column_list column_list_opt column_list_empty index_list load_columns_opt
 %type <variable> variable_expr set_variable user_defined_variable
 %type <variables> at_id_list execute_statement_list_opt
 %type <partitions> opt_partition_clause partition_list
@@ -586,7 +590,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <referenceDefinition> reference_definition reference_definition_opt
 %type <str> underscore_charsets
 %type <str> expire_opt
-%type <literal> ratio_opt
+%type <literal> ratio_opt load_ignore_lines_opt
 %type <txAccessModes> tx_chacteristics_opt tx_chars
 %type <txAccessMode> tx_char
 %type <killType> kill_type_opt
@@ -695,10 +699,125 @@ do_statement:
   }
 
 load_statement:
-  LOAD DATA skip_to_end
+  LOAD DATA LOCAL INFILE STRING load_duplicate_opt INTO TABLE table_name charset_opt load_data_options load_ignore_lines_opt load_columns_opt
+  {
+    $6.FileName = $5
+    $6.Table = $9
+    $6.Charset = $10
+    $6.Options = $11
+    $6.IgnoreLines = $12
+    $6.Columns = $13
+    $$ = $6
+  }
+| LOAD DATA INFILE skip_to_end
+  {
+    $$ = &Load{}
+  }
+| LOAD DATA FROM skip_to_end
   {
     $$ = &Load{}
   }
+| LOAD DATA LOW_PRIORITY skip_to_end
+  {
+    $$ = &Load{}
+  }
+
+load_duplicate_opt:
+  {
+    $$ = &Load{Local: true}
+  }
+| REPLACE
+  {
+    $$ = &Load{Local: true, Action: ReplaceAct}
+  }
+| IGNORE
+  {
+    $$ = &Load{Local: true, Ignore: true}
+  }
+
+load_data_options:
+  load_fields_opts load_lines_opts
+  {
+    $$ = $1
+    $$.LinesStartingBy = $2.LinesStartingBy
+    $$.LinesTerminatedBy = $2.LinesTerminatedBy
+  }
+
+load_fields_opts:
+  {
+    $$ = &LoadDataOptions{}
+  }
+| columns_or_fields load_fields_opt_list
+  {
+    $$ = $2
+  }
+
+load_fields_opt_list:
+  {
+    $$ = &LoadDataOptions{}
+  }
+| load_fields_opt_list TERMINATED BY STRING
+  {
+    $$ = $1
+    $$.FieldsTerminatedBy = NewStrLiteral($4)
+  }
+| load_fields_opt_list optionally_opt ENCLOSED BY STRING
+  {
+    $$ = $1
+    $$.FieldsEnclosedBy = NewStrLiteral($5)
+    $$.FieldsOptionallyEnclosed = $2 != ""
+  }
+| load_fields_opt_list ESCAPED BY STRING
+  {
+    $$ = $1
+    $$.FieldsEscapedBy = NewStrLiteral($4)
+  }
+
+load_lines_opts:
+  {
+    $$ = &LoadDataOptions{}
+  }
+| LINES load_lines_opt_list
+  {
+    $$ = $2
+  }
+
+load_lines_opt_list:
+  {
+    $$ = &LoadDataOptions{}
+  }
+| load_lines_opt_list STARTING BY STRING
+  {
+    $$ = $1
+    $$.LinesStartingBy = NewStrLiteral($4)
+  }
+| load_lines_opt_list TERMINATED BY STRING
+  {
+    $$ = $1
+    $$.LinesTerminatedBy = NewStrLiteral($4)
+  }
+
+load_ignore_lines_opt:
+  {
+    $$ = nil
+  }
+| IGNORE INTEGRAL LINES
+  {
+    $$ = NewIntLiteral($2)
+  }
+| IGNORE INTEGRAL ROWS
+  {
+    $$ = NewIntLiteral($2)
+  }
+
+load_columns_opt:
+  {
+    $$ = nil
+  }
+| openb column_list closeb
+  {
+    $$ = $2
+  }
 
 with_clause:
   WITH with_list
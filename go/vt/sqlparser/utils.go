@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cespare/xxhash/v2"
+
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
@@ -160,3 +162,11 @@ func ReplaceTableQualifiers(query, olddb, newdb string) (string, error) {
 	}
 	return query, nil
 }
+
+// QueryFingerprint returns the fingerprint of a normalized query, i.e. a
+// query with its values replaced by bind variables and without its leading
+// and trailing comments, such as the ones of the plan caches. Queries with
+// different values have the same fingerprint once normalized.
+func QueryFingerprint(normalizedQuery string) string {
+	return fmt.Sprintf("%016x", xxhash.Sum64String(normalizedQuery))
+}
@@ -275,3 +275,10 @@ func TestReplaceTableQualifiers(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryFingerprint(t *testing.T) {
+	fingerprint := QueryFingerprint("select * from t where id = :vtg1")
+	assert.Len(t, fingerprint, 16)
+	assert.Equal(t, fingerprint, QueryFingerprint("select * from t where id = :vtg1"))
+	assert.NotEqual(t, fingerprint, QueryFingerprint("select * from t where name = :vtg1"))
+}
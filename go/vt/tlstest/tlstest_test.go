@@ -17,17 +17,24 @@ limitations under the License.
 package tlstest
 
 import (
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
 
 	"vitess.io/vitess/go/vt/vttls"
 )
@@ -309,6 +316,68 @@ func TestNumberOfCertsWithCombining(t *testing.T) {
 	testNumberOfCertsWithOrWithoutCombining(t, 2, true)
 }
 
+func TestReloadServerConfig(t *testing.T) {
+	root := t.TempDir()
+	clientServerKeyPairs := CreateClientServerCertPairs(root)
+
+	getServerConfig := func(reload bool) (*tls.Config, error) {
+		serverConfig := vttls.ServerConfig
+		if reload {
+			serverConfig = vttls.ReloadServerConfig
+		}
+		return serverConfig(
+			clientServerKeyPairs.ServerCert,
+			clientServerKeyPairs.ServerKey,
+			clientServerKeyPairs.ClientCA,
+			"",
+			"",
+			tls.VersionTLS12)
+	}
+	served := func(config *tls.Config) []byte {
+		require.Len(t, config.Certificates, 1)
+		return config.Certificates[0].Certificate[0]
+	}
+
+	serverConfig, err := getServerConfig(false)
+	require.NoError(t, err)
+	oldCert := served(serverConfig)
+
+	// Renew the certificate in place.
+	for src, dst := range map[string]string{
+		clientServerKeyPairs.RevokedServerCert: clientServerKeyPairs.ServerCert,
+		clientServerKeyPairs.RevokedServerKey:  clientServerKeyPairs.ServerKey,
+	} {
+		data, err := os.ReadFile(src)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(dst, data, 0o600))
+	}
+
+	// The certificate which was loaded first is still served, until the
+	// config is reloaded.
+	serverConfig, err = getServerConfig(false)
+	require.NoError(t, err)
+	assert.Equal(t, oldCert, served(serverConfig))
+
+	serverConfig, err = getServerConfig(true)
+	require.NoError(t, err)
+	newCert := served(serverConfig)
+	assert.NotEqual(t, oldCert, newCert)
+
+	serverConfig, err = getServerConfig(false)
+	require.NoError(t, err)
+	assert.Equal(t, newCert, served(serverConfig))
+
+	// A broken certificate fails the reload, and keeps the one loaded
+	// previously.
+	require.NoError(t, os.WriteFile(clientServerKeyPairs.ServerCert, []byte("not a certificate"), 0o600))
+	_, err = getServerConfig(true)
+	assert.Error(t, err)
+
+	serverConfig, err = getServerConfig(false)
+	require.NoError(t, err)
+	assert.Equal(t, newCert, served(serverConfig))
+}
+
 func assertTLSHandshakeFails(t *testing.T, serverConfig, clientConfig *tls.Config) {
 	// Create a TLS server listener.
 	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
@@ -468,3 +537,144 @@ func TestClientServerWithRevokedClientCert(t *testing.T) {
 
 	assertTLSHandshakeFails(t, serverConfig, clientConfig)
 }
+
+// tlsHandshake runs a TLS handshake between a server and a client with the
+// given configs. It returns the state of the client connection, and the
+// error of the server side of the handshake.
+func tlsHandshake(t *testing.T, serverConfig, clientConfig *tls.Config) (tls.ConnectionState, error) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	var state tls.ConnectionState
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		clientConn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+		if err == nil {
+			state = clientConn.ConnectionState()
+			clientConn.Close()
+		}
+	}()
+
+	serverConn, err := listener.Accept()
+	require.NoError(t, err)
+	err = serverConn.(*tls.Conn).Handshake()
+	serverConn.Close()
+	wg.Wait()
+	return state, err
+}
+
+func TestServerHardeningCipherSuites(t *testing.T) {
+	root := t.TempDir()
+	keypairs := CreateClientServerCertPairs(root)
+
+	serverConfig, err := vttls.ServerConfig(keypairs.ServerCert, keypairs.ServerKey, "", "", "", tls.VersionTLS12)
+	require.NoError(t, err)
+	hardening := &vttls.ServerHardening{CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}}
+	require.NoError(t, hardening.Apply(serverConfig))
+
+	clientConfig, err := vttls.ClientConfig(vttls.VerifyIdentity, "", "", keypairs.ServerCA, "", keypairs.ServerName, tls.VersionTLS12)
+	require.NoError(t, err)
+	clientConfig.MaxVersion = tls.VersionTLS12
+
+	clientConfig.CipherSuites = []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}
+	_, err = tlsHandshake(t, serverConfig, clientConfig)
+	assert.ErrorContains(t, err, "no cipher suite supported")
+
+	clientConfig.CipherSuites = []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}
+	state, err := tlsHandshake(t, serverConfig, clientConfig)
+	require.NoError(t, err)
+	assert.Equal(t, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, state.CipherSuite)
+
+	hardening = &vttls.ServerHardening{CipherSuites: []string{"TLS_UNKNOWN"}}
+	assert.EqualError(t, hardening.Apply(serverConfig), "Invalid cipher suite specified: TLS_UNKNOWN")
+}
+
+func TestServerHardeningSNICertificates(t *testing.T) {
+	root := t.TempDir()
+	keypairs := CreateClientServerCertPairs(root)
+
+	serverConfig, err := vttls.ServerConfig(keypairs.ServerCert, keypairs.ServerKey, "", "", "", tls.VersionTLS12)
+	require.NoError(t, err)
+	hardening := &vttls.ServerHardening{SNICertificates: []string{keypairs.RevokedServerCert + ":" + keypairs.RevokedServerKey}}
+	require.NoError(t, hardening.Apply(serverConfig))
+
+	for _, serverName := range []string{keypairs.ServerName, keypairs.RevokedServerName} {
+		clientConfig, err := vttls.ClientConfig(vttls.VerifyIdentity, "", "", keypairs.ServerCA, "", serverName, tls.VersionTLS12)
+		require.NoError(t, err)
+		state, err := tlsHandshake(t, serverConfig, clientConfig)
+		require.NoError(t, err)
+		require.NotEmpty(t, state.PeerCertificates)
+		assert.Equal(t, serverName, state.PeerCertificates[0].Subject.CommonName)
+	}
+
+	hardening = &vttls.ServerHardening{SNICertificates: []string{keypairs.RevokedServerCert}}
+	assert.ErrorContains(t, hardening.Apply(serverConfig), "expected cert:key")
+}
+
+func TestServerHardeningOCSP(t *testing.T) {
+	root := t.TempDir()
+	keypairs := CreateClientServerCertPairs(root)
+
+	caCert, err := loadCert(keypairs.ClientCA)
+	require.NoError(t, err)
+	caKey, err := loadKey(strings.TrimSuffix(keypairs.ClientCA, "-cert.pem") + "-key.pem")
+	require.NoError(t, err)
+	revokedCert, err := loadCert(keypairs.RevokedClientCert)
+	require.NoError(t, err)
+
+	// The OCSP responder of the client CA, which only revoked the revoked client cert.
+	var requests atomic.Int32
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		template := ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}
+		if req.SerialNumber.Cmp(revokedCert.SerialNumber) == 0 {
+			template.Status = ocsp.Revoked
+			template.RevokedAt = time.Now().Add(-time.Minute)
+		}
+		resp, err := ocsp.CreateResponse(caCert, caCert, template, caKey.(crypto.Signer))
+		require.NoError(t, err)
+		w.Write(resp)
+	}))
+	defer responder.Close()
+
+	serverConfig, err := vttls.ServerConfig(keypairs.ServerCert, keypairs.ServerKey, keypairs.ClientCA, "", "", tls.VersionTLS12)
+	require.NoError(t, err)
+	hardening := &vttls.ServerHardening{OCSP: true, OCSPResponder: responder.URL, OCSPTimeout: 5 * time.Second}
+	require.NoError(t, hardening.Apply(serverConfig))
+
+	clientConfig, err := vttls.ClientConfig(vttls.VerifyIdentity, keypairs.ClientCert, keypairs.ClientKey, keypairs.ServerCA, "", keypairs.ServerName, tls.VersionTLS12)
+	require.NoError(t, err)
+	_, err = tlsHandshake(t, serverConfig, clientConfig)
+	require.NoError(t, err)
+
+	// The response is cached until its next update.
+	_, err = tlsHandshake(t, serverConfig, clientConfig)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, requests.Load())
+
+	clientConfig, err = vttls.ClientConfig(vttls.VerifyIdentity, keypairs.RevokedClientCert, keypairs.RevokedClientKey, keypairs.ServerCA, "", keypairs.ServerName, tls.VersionTLS12)
+	require.NoError(t, err)
+	_, err = tlsHandshake(t, serverConfig, clientConfig)
+	assert.ErrorContains(t, err, "Certificate revoked: CommonName="+keypairs.RevokedClientName)
+
+	// The client certs are rejected when the responder cannot be reached.
+	responder.Close()
+	hardening = &vttls.ServerHardening{OCSP: true, OCSPResponder: responder.URL, OCSPTimeout: time.Second}
+	serverConfig, err = vttls.ServerConfig(keypairs.ServerCert, keypairs.ServerKey, keypairs.ClientCA, "", "", tls.VersionTLS12)
+	require.NoError(t, err)
+	require.NoError(t, hardening.Apply(serverConfig))
+	_, err = tlsHandshake(t, serverConfig, clientConfig)
+	assert.ErrorContains(t, err, "Cannot check the revocation of the certificate with OCSP")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the verdicts of the last ValidateBackup of the backups
+// of the shards, which are stored in the global topo since the backup storage
+// is only written by the tablets taking the backups.
+
+func backupValidationFilePath(keyspace, shard, backupName string) string {
+	return path.Join(BackupValidationsPath, keyspace, shard, backupName, BackupValidationFile)
+}
+
+// SaveBackupValidation stores the verdict of a ValidateBackup of a backup of
+// a shard, replacing the previous one.
+func (ts *Server) SaveBackupValidation(ctx context.Context, keyspace, shard string, validation *tabletmanagerdatapb.BackupValidation) error {
+	if validation.BackupName == "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the backup validation has no backup name")
+	}
+	data, err := validation.MarshalVT()
+	if err != nil {
+		return err
+	}
+	_, err = ts.globalCell.Update(ctx, backupValidationFilePath(keyspace, shard, validation.BackupName), data, nil)
+	return err
+}
+
+// GetBackupValidation reads the verdict of the last ValidateBackup of a
+// backup of a shard. It fails with NoNode if the backup was never validated.
+func (ts *Server) GetBackupValidation(ctx context.Context, keyspace, shard, backupName string) (*tabletmanagerdatapb.BackupValidation, error) {
+	data, _, err := ts.globalCell.Get(ctx, backupValidationFilePath(keyspace, shard, backupName))
+	if err != nil {
+		return nil, err
+	}
+	validation := &tabletmanagerdatapb.BackupValidation{}
+	if err := validation.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrap(err, "bad BackupValidation data")
+	}
+	return validation, nil
+}
+
+// DeleteBackupValidation deletes the verdict of the last ValidateBackup of a
+// backup of a shard, once the backup is removed. It is not an error if the
+// backup was never validated.
+func (ts *Server) DeleteBackupValidation(ctx context.Context, keyspace, shard, backupName string) error {
+	err := ts.globalCell.Delete(ctx, backupValidationFilePath(keyspace, shard, backupName), nil)
+	if IsErrType(err, NoNode) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var _ Conn = (*CachedConn)(nil)
+
+var (
+	// topoReadCache enables the read cache of tablet and shard records.
+	topoReadCache bool
+
+	// topoReadCacheMaxAge is how long a cached record is served without
+	// being read again, in case a change was missed by the watch.
+	topoReadCacheMaxAge = 10 * time.Minute
+
+	// cachedConnWatchRetryDelay is how long a CachedConn waits before
+	// restarting its watch after an error.
+	cachedConnWatchRetryDelay = 5 * time.Second
+
+	topoCacheHits = stats.NewCountersWithSingleLabel(
+		"TopologyCacheHits",
+		"Reads served by the topo read cache",
+		"Cell")
+
+	topoCacheMisses = stats.NewCountersWithSingleLabel(
+		"TopologyCacheMisses",
+		"Reads of cacheable paths that were not served by the topo read cache",
+		"Cell")
+
+	topoCacheWatchErrors = stats.NewCountersWithSingleLabel(
+		"TopologyCacheWatchErrors",
+		"Errors of the watches that keep the topo read cache up to date, which empty it",
+		"Cell")
+
+	topoCacheWatching = stats.NewGaugesWithSingleLabel(
+		"TopologyCacheWatching",
+		"Whether the watch of the topo read cache is running, in which case the cache is used",
+		"Cell")
+
+	topoCacheEntries = stats.NewGaugesWithSingleLabel(
+		"TopologyCacheEntries",
+		"Number of records in the topo read cache",
+		"Cell")
+
+	topoCacheHitAge = stats.NewMultiTimings(
+		"TopologyCacheHitAge",
+		"Time since the records served by the topo read cache were last read or updated by its watch",
+		[]string{"Cell"})
+)
+
+func init() {
+	for _, cmd := range FlagBinaries {
+		servenv.OnParseFor(cmd, registerCachedConnFlags)
+	}
+}
+
+func registerCachedConnFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&topoReadCache, "topo-read-cache", topoReadCache, "Cache the tablet records of each cell and the shard records of the global topo in memory, kept up to date by watching them, to reduce the reads of the topo server.")
+	fs.DurationVar(&topoReadCacheMaxAge, "topo-read-cache-max-age", topoReadCacheMaxAge, "How long a record is served by the topo read cache before being read again from the topo server, in case a change was missed. 0 means no limit.")
+}
+
+// CachedConn is a wrapper for a Conn that serves Get calls on some paths from
+// memory. It watches all the files under a directory, with WatchRecursive,
+// so that the cache is updated when they change. The cache is only used
+// while the watch is running: when it fails, the cache is emptied and Get
+// calls go through until the watch is restarted.
+//
+// Writes go through to the wrapped Conn, and update the cache when they
+// succeed, so that a process reads its own writes. A write that fails because
+// the cached version is out of date removes the record from the cache.
+type CachedConn struct {
+	Conn
+
+	cell   string
+	dir    string
+	match  func(filePath string) bool
+	maxAge time.Duration
+
+	mu sync.Mutex
+	// watching is true while the watch is running, and the cache is used.
+	watching bool
+	// starting is true while the watch is being started.
+	starting bool
+	// unsupported is true if the wrapped Conn doesn't support WatchRecursive.
+	unsupported bool
+	lastStart   time.Time
+	cancel      context.CancelFunc
+	closed      bool
+	entries     map[string]*cachedEntry
+}
+
+type cachedEntry struct {
+	contents []byte
+	version  Version
+	// deleted records that the file was deleted, so that a Get that read
+	// it before doesn't put it back in the cache.
+	deleted   bool
+	refreshed time.Time
+}
+
+// NewCachedConn returns a CachedConn that caches the files under dir for
+// which match returns true.
+func NewCachedConn(cell string, conn Conn, dir string, match func(filePath string) bool) *CachedConn {
+	return &CachedConn{
+		Conn:    conn,
+		cell:    cell,
+		dir:     dir,
+		match:   match,
+		maxAge:  topoReadCacheMaxAge,
+		entries: make(map[string]*cachedEntry),
+	}
+}
+
+// newReadCacheConn wraps conn with the topo read cache, if it is enabled. The
+// global cell caches the shard records, and the other cells their tablet
+// records.
+func newReadCacheConn(cell string, conn Conn) Conn {
+	if !topoReadCache {
+		return conn
+	}
+	if cell == GlobalCell {
+		return NewCachedConn(cell, conn, KeyspacesPath, func(filePath string) bool {
+			ok, _ := path.Match(path.Join(KeyspacesPath, "*", ShardsPath, "*", ShardFile), filePath)
+			return ok
+		})
+	}
+	return NewCachedConn(cell, conn, TabletsPath, func(filePath string) bool {
+		ok, _ := path.Match(path.Join(TabletsPath, "*", TabletFile), filePath)
+		return ok
+	})
+}
+
+// Get is part of the Conn interface.
+func (c *CachedConn) Get(ctx context.Context, filePath string) ([]byte, Version, error) {
+	if !c.match(filePath) {
+		return c.Conn.Get(ctx, filePath)
+	}
+
+	if entry := c.lookup(filePath); entry != nil {
+		topoCacheHits.Add(c.cell, 1)
+		topoCacheHitAge.Record([]string{c.cell}, entry.refreshed)
+		if entry.deleted {
+			return nil, nil, NewError(NoNode, filePath)
+		}
+		return entry.contents, entry.version, nil
+	}
+
+	topoCacheMisses.Add(c.cell, 1)
+	contents, version, err := c.Conn.Get(ctx, filePath)
+	if err == nil {
+		c.fill(filePath, contents, version)
+	}
+	return contents, version, err
+}
+
+// Create is part of the Conn interface.
+func (c *CachedConn) Create(ctx context.Context, filePath string, contents []byte) (Version, error) {
+	version, err := c.Conn.Create(ctx, filePath, contents)
+	c.written(filePath, contents, version, err)
+	return version, err
+}
+
+// Update is part of the Conn interface.
+func (c *CachedConn) Update(ctx context.Context, filePath string, contents []byte, version Version) (Version, error) {
+	newVersion, err := c.Conn.Update(ctx, filePath, contents, version)
+	c.written(filePath, contents, newVersion, err)
+	return newVersion, err
+}
+
+// Delete is part of the Conn interface.
+func (c *CachedConn) Delete(ctx context.Context, filePath string, version Version) error {
+	err := c.Conn.Delete(ctx, filePath, version)
+	c.written(filePath, nil, nil, err)
+	return err
+}
+
+// Close is part of the Conn interface.
+func (c *CachedConn) Close() {
+	c.mu.Lock()
+	c.closed = true
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.mu.Unlock()
+	c.Conn.Close()
+}
+
+// lookup returns the cached entry of filePath, or nil if it is not cached. It
+// starts the watch if needed.
+func (c *CachedConn) lookup(filePath string) *cachedEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.watching {
+		c.startWatchLocked()
+		return nil
+	}
+	entry, ok := c.entries[filePath]
+	if !ok {
+		return nil
+	}
+	if c.maxAge > 0 && time.Since(entry.refreshed) > c.maxAge {
+		c.removeLocked(filePath)
+		return nil
+	}
+	return entry
+}
+
+// fill caches a record that was read from the wrapped Conn, unless the watch
+// updated it in the meantime, which is more recent.
+func (c *CachedConn) fill(filePath string, contents []byte, version Version) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.watching {
+		return
+	}
+	if _, ok := c.entries[filePath]; ok {
+		return
+	}
+	c.setLocked(filePath, &cachedEntry{contents: contents, version: version})
+}
+
+// written updates the cache after a write of filePath. contents is nil for a
+// deletion.
+func (c *CachedConn) written(filePath string, contents []byte, version Version, err error) {
+	if !c.match(filePath) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.watching {
+		return
+	}
+	switch {
+	case err != nil:
+		// The cached record may be out of date, e.g. for BadVersion
+		// errors, so let the next Get read it again.
+		c.removeLocked(filePath)
+	case contents == nil && version == nil:
+		c.setLocked(filePath, &cachedEntry{deleted: true})
+	default:
+		c.setLocked(filePath, &cachedEntry{contents: contents, version: version})
+	}
+}
+
+func (c *CachedConn) setLocked(filePath string, entry *cachedEntry) {
+	entry.refreshed = time.Now()
+	c.entries[filePath] = entry
+	topoCacheEntries.Set(c.cell, int64(len(c.entries)))
+}
+
+func (c *CachedConn) removeLocked(filePath string) {
+	delete(c.entries, filePath)
+	topoCacheEntries.Set(c.cell, int64(len(c.entries)))
+}
+
+// startWatchLocked starts the watch in the background, unless it is already
+// starting, or was started too recently.
+func (c *CachedConn) startWatchLocked() {
+	if c.starting || c.unsupported || c.closed || time.Since(c.lastStart) < cachedConnWatchRetryDelay {
+		return
+	}
+	c.starting = true
+	c.lastStart = time.Now()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go c.watch(ctx, cancel)
+}
+
+// watch runs the watch of the cache until it fails, or ctx is canceled.
+func (c *CachedConn) watch(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	current, changes, err := c.Conn.WatchRecursive(ctx, c.dir)
+	c.mu.Lock()
+	c.starting = false
+	if err != nil {
+		if IsErrType(err, NoImplementation) {
+			log.Warningf("The topo server of cell %v does not support WatchRecursive, the topo read cache is disabled for it", c.cell)
+			c.unsupported = true
+		} else {
+			log.Warningf("Failed to watch %v in cell %v for the topo read cache: %v", c.dir, c.cell, err)
+			topoCacheWatchErrors.Add(c.cell, 1)
+		}
+		c.mu.Unlock()
+		return
+	}
+	c.entries = make(map[string]*cachedEntry)
+	for _, wd := range current {
+		if c.match(wd.Path) {
+			c.setLocked(wd.Path, &cachedEntry{contents: wd.Contents, version: wd.Version})
+		}
+	}
+	c.watching = true
+	topoCacheWatching.Set(c.cell, 1)
+	c.mu.Unlock()
+
+	for wd := range changes {
+		switch {
+		case wd.Err == nil:
+			if c.match(wd.Path) {
+				c.mu.Lock()
+				c.setLocked(wd.Path, &cachedEntry{contents: wd.Contents, version: wd.Version})
+				c.mu.Unlock()
+			}
+		case IsErrType(wd.Err, NoNode) && wd.Path != "":
+			if c.match(wd.Path) {
+				c.mu.Lock()
+				c.setLocked(wd.Path, &cachedEntry{deleted: true})
+				c.mu.Unlock()
+			}
+		default:
+			// The watch is over, the channel will be closed.
+			if !IsErrType(wd.Err, Interrupted) {
+				log.Warningf("The watch of %v in cell %v for the topo read cache failed: %v", c.dir, c.cell, wd.Err)
+				topoCacheWatchErrors.Add(c.cell, 1)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.watching = false
+	c.entries = make(map[string]*cachedEntry)
+	topoCacheWatching.Set(c.cell, 0)
+	topoCacheEntries.Set(c.cell, 0)
+	c.mu.Unlock()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the runtime settings of the cluster, which are stored in
+// the global topo and watched by the vtgates and vttablets. See the
+// clustersettings package for their definitions.
+
+// GetClusterSettings reads the settings of the cluster, along with their
+// version. There are no settings if the version is nil.
+func (ts *Server) GetClusterSettings(ctx context.Context) (*topodatapb.ClusterSettings, Version, error) {
+	data, version, err := ts.globalCell.Get(ctx, ClusterSettingsFile)
+	switch {
+	case IsErrType(err, NoNode):
+		return &topodatapb.ClusterSettings{}, nil, nil
+	case err != nil:
+		return nil, nil, err
+	}
+	settings := &topodatapb.ClusterSettings{}
+	if err := settings.UnmarshalVT(data); err != nil {
+		return nil, nil, vterrors.Wrap(err, "bad ClusterSettings data")
+	}
+	return settings, version, nil
+}
+
+// UpdateClusterSettings changes the settings of the cluster with update, and
+// retries if they were changed concurrently. If update returns NoUpdateNeeded,
+// nothing is written.
+func (ts *Server) UpdateClusterSettings(ctx context.Context, update func(*topodatapb.ClusterSettings) error) (*topodatapb.ClusterSettings, error) {
+	for {
+		settings, version, err := ts.GetClusterSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := update(settings); err != nil {
+			if IsErrType(err, NoUpdateNeeded) {
+				return settings, nil
+			}
+			return nil, err
+		}
+		data, err := settings.MarshalVT()
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			_, err = ts.globalCell.Create(ctx, ClusterSettingsFile, data)
+		} else {
+			_, err = ts.globalCell.Update(ctx, ClusterSettingsFile, data, version)
+		}
+		if !IsErrType(err, BadVersion) && !IsErrType(err, NodeExists) {
+			return settings, err
+		}
+	}
+}
+
+// WatchClusterSettingsData wraps the data we receive on the watch channel.
+// The WatchClusterSettings API guarantees exactly one of Value or Err will be
+// set.
+type WatchClusterSettingsData struct {
+	Value *topodatapb.ClusterSettings
+	Err   error
+}
+
+// WatchClusterSettings will set a watch on the settings of the cluster.
+// It has the same contract as conn.Watch, but it also unpacks the contents
+// into a ClusterSettings object. It fails with NoNode if no setting was ever
+// set.
+func (ts *Server) WatchClusterSettings(ctx context.Context) (*WatchClusterSettingsData, <-chan *WatchClusterSettingsData, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	current, wdChannel, err := ts.globalCell.Watch(ctx, ClusterSettingsFile)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	value := &topodatapb.ClusterSettings{}
+	if err := value.UnmarshalVT(current.Contents); err != nil {
+		// Cancel the watch, drain channel.
+		cancel()
+		for range wdChannel {
+		}
+		return nil, nil, vterrors.Wrapf(err, "error unpacking initial ClusterSettings object")
+	}
+
+	changes := make(chan *WatchClusterSettingsData, 10)
+	// The background routine reads any event from the watch channel,
+	// translates it, and sends it to the caller.
+	// If cancel() is called, the underlying Watch() code will
+	// send an ErrInterrupted and then close the channel. We'll
+	// just propagate that back to our caller.
+	go func() {
+		defer cancel()
+		defer close(changes)
+
+		for wd := range wdChannel {
+			if wd.Err != nil {
+				// Last error value, we're done.
+				// wdChannel will be closed right after
+				// this, no need to do anything.
+				changes <- &WatchClusterSettingsData{Err: wd.Err}
+				return
+			}
+
+			value := &topodatapb.ClusterSettings{}
+			if err := value.UnmarshalVT(wd.Contents); err != nil {
+				cancel()
+				for range wdChannel {
+				}
+				changes <- &WatchClusterSettingsData{Err: vterrors.Wrapf(err, "error unpacking ClusterSettings object")}
+				return
+			}
+
+			changes <- &WatchClusterSettingsData{Value: value}
+		}
+	}()
+
+	return &WatchClusterSettingsData{Value: value}, changes, nil
+}
@@ -125,6 +125,28 @@ type Conn interface {
 	// and acquiring is not under the same mutex in current implementation of `TryLock`.
 	TryLock(ctx context.Context, dirPath, contents string) (LockDescriptor, error)
 
+	// ListLocks returns the holders of the locks taken on dirPath and
+	// on the directories under it. For each directory, the holder of
+	// the lock comes first, followed by the processes waiting for it,
+	// if the implementation can list them, in the order in which they
+	// will get it.
+	// Returns ErrNoImplementation if the implementation cannot list
+	// its locks.
+	ListLocks(ctx context.Context, dirPath string) ([]*LockHolder, error)
+
+	// ForceUnlock releases the lock on dirPath of the lock holder with
+	// the given id, as returned by ListLocks, even if it was taken by
+	// another process. It is meant to clear the locks of processes that
+	// crashed or are stuck: the process holding the lock is not told,
+	// besides LockDescriptor.Check failing for implementations that can
+	// tell.
+	// Returns ErrNoNode if that lock holder is not in the list of
+	// holders of the lock on dirPath anymore, so a lock taken again by
+	// another process is never released by mistake.
+	// Returns ErrNoImplementation if the implementation cannot release
+	// the locks of other processes.
+	ForceUnlock(ctx context.Context, dirPath, id string) error
+
 	//
 	// Watches
 	//
@@ -295,6 +317,24 @@ type LockDescriptor interface {
 	Unlock(ctx context.Context) error
 }
 
+// LockHolder describes a process that holds, or waits for, a lock. It is
+// returned by ListLocks.
+type LockHolder struct {
+	// Path is the path of the locked directory, relative to the root
+	// directory of the cell.
+	Path string
+
+	// ID identifies the lock holder, for ForceUnlock.
+	ID string
+
+	// Contents is what the process passed to Lock.
+	Contents string
+
+	// Held is true for the process that holds the lock, and false for
+	// the ones waiting for it.
+	Held bool
+}
+
 // CancelFunc is returned by the Watch method.
 type CancelFunc func()
 
@@ -326,7 +366,8 @@ type WatchData struct {
 // path of the entry that the recursive watch applies to, since an entire
 // file prefix can be watched.
 type WatchDataRecursive struct {
-	// Path is the path that has changed
+	// Path is the path that has changed, relative to the root directory of
+	// the cell, like the paths given to the Conn methods.
 	Path string
 
 	WatchData
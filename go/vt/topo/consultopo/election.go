@@ -136,7 +136,6 @@ func (mp *consulLeaderParticipation) GetCurrentLeaderID(ctx context.Context) (st
 // WaitForNewLeader is part of the topo.LeaderParticipation interface
 func (mp *consulLeaderParticipation) WaitForNewLeader(context.Context) (<-chan string, error) {
 	// This isn't implemented yet, but likely can be implemented using List
-	// with blocking logic on election path.
-	// See also how WatchRecursive could be implemented as well.
+	// with blocking logic on election path, like WatchRecursive.
 	return nil, topo.NewError(topo.NoImplementation, "wait for leader not supported in Consul topo")
 }
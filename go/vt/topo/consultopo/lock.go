@@ -195,3 +195,45 @@ func (s *Server) unlock(ctx context.Context, lockPath string) error {
 
 	return unlockErr
 }
+
+// ListLocks is part of the topo.Conn interface. The locks are the lock files
+// that are held by a session, whose ID identifies the lock holder. The
+// processes waiting for a lock are not listed.
+func (s *Server) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	nodePath := path.Join(s.root, dirPath) + "/"
+	pairs, _, err := s.kv.List(nodePath, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, convertError(err, nodePath)
+	}
+
+	var holders []*topo.LockHolder
+	for _, pair := range pairs {
+		if path.Base(pair.Key) != locksFilename || pair.Session == "" {
+			continue
+		}
+		holders = append(holders, &topo.LockHolder{
+			Path:     path.Dir(s.relativePath(pair.Key)),
+			ID:       pair.Session,
+			Contents: string(pair.Value),
+			Held:     true,
+		})
+	}
+	return holders, nil
+}
+
+// ForceUnlock is part of the topo.Conn interface. It destroys the session of
+// the lock holder, which releases the lock, and makes its Check calls fail.
+func (s *Server) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	lockPath := path.Join(s.root, dirPath, locksFilename)
+	pair, _, err := s.kv.Get(lockPath, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return convertError(err, lockPath)
+	}
+	if pair == nil || pair.Session != id {
+		return topo.NewError(topo.NoNode, path.Join(lockPath, id))
+	}
+	if _, err := s.client.Session().Destroy(id, (&api.WriteOptions{}).WithContext(ctx)); err != nil {
+		return convertError(err, lockPath)
+	}
+	return nil
+}
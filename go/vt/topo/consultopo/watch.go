@@ -19,6 +19,7 @@ package consultopo
 import (
 	"context"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/consul/api"
@@ -139,10 +140,126 @@ func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <
 }
 
 // WatchRecursive is part of the topo.Conn interface.
-func (s *Server) WatchRecursive(_ context.Context, path string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
-	// This isn't implemented yet, but likely can be implemented using List
-	// with blocking logic like how we use Get with blocking for regular Watch.
-	// See also how https://www.consul.io/docs/dynamic-app-config/watches#keyprefix
-	// works under the hood.
-	return nil, nil, topo.NewError(topo.NoImplementation, path)
+//
+// Consul has no recursive watches, so the keys under dirPath are listed with
+// blocking queries, like the keyprefix watches of Consul, and the changes
+// between two listings are reported. Locks and elections are skipped.
+func (s *Server) WatchRecursive(ctx context.Context, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
+	nodePath := path.Join(s.root, dirPath) + "/"
+	if nodePath == "//" {
+		nodePath = "/"
+	}
+
+	initialCtx, initialCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer initialCancel()
+
+	pairs, meta, err := s.kv.List(nodePath, (&api.QueryOptions{}).WithContext(initialCtx))
+	if err != nil {
+		return nil, nil, convertError(err, nodePath)
+	}
+
+	// current holds the version of the files, to tell the changes apart.
+	current := make(map[string]uint64)
+	var initial []*topo.WatchDataRecursive
+	for _, pair := range pairs {
+		if s.skipWatchRecursiveKey(pair.Key) {
+			continue
+		}
+		current[pair.Key] = pair.ModifyIndex
+		initial = append(initial, &topo.WatchDataRecursive{
+			Path: s.relativePath(pair.Key),
+			WatchData: topo.WatchData{
+				Contents: pair.Value,
+				Version:  ConsulVersion(pair.ModifyIndex),
+			},
+		})
+	}
+
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+
+		waitIndex := meta.LastIndex
+		for {
+			opts := &api.QueryOptions{
+				WaitIndex: waitIndex,
+				WaitTime:  watchPollDuration,
+			}
+
+			// As for Watch, assume we've lost contact if the server
+			// doesn't answer within twice WaitTime.
+			getCtx, cancelGetCtx := context.WithTimeout(ctx, 2*opts.WaitTime)
+			pairs, meta, err := s.kv.List(nodePath, opts.WithContext(getCtx))
+			cancelGetCtx()
+			if err != nil {
+				// Serious error or context timeout/cancelled.
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(err, nodePath)},
+				}
+				return
+			}
+
+			if meta.LastIndex != waitIndex {
+				latest := make(map[string]uint64, len(pairs))
+				for _, pair := range pairs {
+					if s.skipWatchRecursiveKey(pair.Key) {
+						continue
+					}
+					latest[pair.Key] = pair.ModifyIndex
+					if version, ok := current[pair.Key]; ok && version == pair.ModifyIndex {
+						continue
+					}
+					notifications <- &topo.WatchDataRecursive{
+						Path: s.relativePath(pair.Key),
+						WatchData: topo.WatchData{
+							Contents: pair.Value,
+							Version:  ConsulVersion(pair.ModifyIndex),
+						},
+					}
+				}
+				for key := range current {
+					if _, ok := latest[key]; !ok {
+						notifications <- &topo.WatchDataRecursive{
+							Path:      s.relativePath(key),
+							WatchData: topo.WatchData{Err: topo.NewError(topo.NoNode, key)},
+						}
+					}
+				}
+				current = latest
+
+				// The index can go backwards, e.g. when the Consul
+				// servers are restored, in which case we start over.
+				waitIndex = meta.LastIndex
+				if waitIndex < opts.WaitIndex {
+					waitIndex = 0
+				}
+			}
+
+			// See if the watch was canceled.
+			select {
+			case <-ctx.Done():
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(ctx.Err(), nodePath)},
+				}
+				return
+			default:
+			}
+		}
+	}()
+
+	return initial, notifications, nil
+}
+
+// skipWatchRecursiveKey returns true for the keys of the locks and elections,
+// which WatchRecursive doesn't report, as ListDir reports them as ephemeral.
+func (s *Server) skipWatchRecursiveKey(key string) bool {
+	rel := s.relativePath(key)
+	return path.Base(rel) == locksFilename || rel == electionsPath || strings.HasPrefix(rel, electionsPath+"/")
+}
+
+// relativePath returns the path of the given Consul key, relative to the root
+// directory of the cell, like the paths given to the Conn methods.
+func (s *Server) relativePath(key string) string {
+	root := strings.TrimPrefix(path.Clean(s.root), "/")
+	return strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(key, "/"), root), "/")
 }
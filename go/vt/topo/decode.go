@@ -53,6 +53,8 @@ func DecodeContent(filename string, data []byte, json bool) (string, error) {
 		p = new(topodatapb.SrvKeyspace)
 	case RoutingRulesFile:
 		p = new(vschemapb.RoutingRules)
+	case ClusterSettingsFile:
+		p = new(topodatapb.ClusterSettings)
 	default:
 		switch dir {
 		case "/" + GetExternalVitessClusterDir():
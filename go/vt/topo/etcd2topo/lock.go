@@ -236,3 +236,50 @@ func (ld *etcdLockDescriptor) Unlock(ctx context.Context) error {
 	}
 	return nil
 }
+
+// ListLocks is part of the topo.Conn interface. The lock holders are the
+// files in the locks directories, and hold the lock in the order in which
+// they were created.
+func (s *Server) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	prefix := path.Join(s.root, dirPath) + "/"
+	resp, err := s.cli.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByCreateRevision, clientv3.SortAscend))
+	if err != nil {
+		return nil, convertError(err, dirPath)
+	}
+
+	var holders []*topo.LockHolder
+	held := make(map[string]bool)
+	for _, kv := range resp.Kvs {
+		key := string(kv.Key)
+		locksDir := path.Dir(key)
+		if path.Base(locksDir) != locksPath {
+			continue
+		}
+		lockPath := s.relativePath(path.Dir(locksDir))
+		holders = append(holders, &topo.LockHolder{
+			Path:     lockPath,
+			ID:       path.Base(key),
+			Contents: string(kv.Value),
+			Held:     !held[lockPath],
+		})
+		held[lockPath] = true
+	}
+	return holders, nil
+}
+
+// ForceUnlock is part of the topo.Conn interface. It revokes the lease of the
+// lock holder, which deletes its file, and makes its Check calls fail.
+func (s *Server) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	key := path.Join(s.root, dirPath, locksPath, id)
+	resp, err := s.cli.Get(ctx, key)
+	if err != nil {
+		return convertError(err, key)
+	}
+	if len(resp.Kvs) != 1 {
+		return topo.NewError(topo.NoNode, key)
+	}
+	if _, err := s.cli.Revoke(ctx, clientv3.LeaseID(resp.Kvs[0].Lease)); err != nil {
+		return convertError(err, key)
+	}
+	return nil
+}
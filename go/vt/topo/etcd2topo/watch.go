@@ -133,7 +133,7 @@ func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <
 					case mvccpb.PUT:
 						notifications <- &topo.WatchData{
 							Contents: ev.Kv.Value,
-							Version:  EtcdVersion(ev.Kv.Version),
+							Version:  EtcdVersion(ev.Kv.ModRevision),
 						}
 					case mvccpb.DELETE:
 						// Node is gone, send a final notice.
@@ -172,9 +172,9 @@ func (s *Server) WatchRecursive(ctx context.Context, dirpath string) ([]*topo.Wa
 
 	for _, kv := range initial.Kvs {
 		var wd topo.WatchDataRecursive
-		wd.Path = string(kv.Key)
+		wd.Path = s.relativePath(string(kv.Key))
 		wd.Contents = kv.Value
-		wd.Version = EtcdVersion(initial.Kvs[0].ModRevision)
+		wd.Version = EtcdVersion(kv.ModRevision)
 		initialwd = append(initialwd, &wd)
 	}
 
@@ -253,15 +253,15 @@ func (s *Server) WatchRecursive(ctx context.Context, dirpath string) ([]*topo.Wa
 					switch ev.Type {
 					case mvccpb.PUT:
 						notifications <- &topo.WatchDataRecursive{
-							Path: string(ev.Kv.Key),
+							Path: s.relativePath(string(ev.Kv.Key)),
 							WatchData: topo.WatchData{
 								Contents: ev.Kv.Value,
-								Version:  EtcdVersion(ev.Kv.Version),
+								Version:  EtcdVersion(ev.Kv.ModRevision),
 							},
 						}
 					case mvccpb.DELETE:
 						notifications <- &topo.WatchDataRecursive{
-							Path: string(ev.Kv.Key),
+							Path: s.relativePath(string(ev.Kv.Key)),
 							WatchData: topo.WatchData{
 								Err: topo.NewError(topo.NoNode, nodePath),
 							},
@@ -274,3 +274,9 @@ func (s *Server) WatchRecursive(ctx context.Context, dirpath string) ([]*topo.Wa
 
 	return initialwd, notifications, nil
 }
+
+// relativePath returns the path of the given etcd key, relative to the root
+// directory of the cell, like the paths given to the Conn methods.
+func (s *Server) relativePath(key string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(key, path.Clean(s.root)), "/")
+}
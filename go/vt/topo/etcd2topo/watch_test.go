@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd2topo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		root string
+		key  string
+		want string
+	}{{
+		root: "/vitess/zone1",
+		key:  "/vitess/zone1/tablets/zone1-0000000100/Tablet",
+		want: "tablets/zone1-0000000100/Tablet",
+	}, {
+		root: "/vitess/zone1/",
+		key:  "/vitess/zone1/tablets/zone1-0000000100/Tablet",
+		want: "tablets/zone1-0000000100/Tablet",
+	}, {
+		root: "/",
+		key:  "/keyspaces/ks/Keyspace",
+		want: "keyspaces/ks/Keyspace",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.root, func(t *testing.T) {
+			s := &Server{root: tt.root}
+			assert.Equal(t, tt.want, s.relativePath(tt.key))
+		})
+	}
+}
@@ -292,6 +292,16 @@ func (f *FakeConn) TryLock(ctx context.Context, dirPath, contents string) (topo.
 	return f.Lock(ctx, dirPath, contents)
 }
 
+// ListLocks implements the Conn interface
+func (f *FakeConn) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	return nil, topo.NewError(topo.NoImplementation, dirPath)
+}
+
+// ForceUnlock implements the Conn interface
+func (f *FakeConn) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	return topo.NewError(topo.NoImplementation, dirPath)
+}
+
 // Watch implements the Conn interface
 func (f *FakeConn) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, error) {
 	f.mu.Lock()
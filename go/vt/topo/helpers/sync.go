@@ -0,0 +1,368 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the continuous replication of the files of a topo to
+// another one, and the check that both have the same files, to migrate from
+// one topo implementation to another with a short cutover.
+//
+// The CellInfo and CellsAlias records of the global topo are not replicated,
+// since they point to the topo servers of the cells, which differ between the
+// two topos. The cells have to be added to the destination topo beforehand.
+// Ephemeral files, like locks and elections, are not replicated either.
+
+// SyncRetryDelay is how long SyncTopos waits before replicating the topo of a
+// cell again after its watch failed.
+var SyncRetryDelay = 5 * time.Second
+
+// SyncTopos replicates the files of the global topo, and of the topos of the
+// given cells, or of all the cells if none is given, from fromTS to toTS,
+// until ctx is canceled.
+//
+// The topo of each cell is watched, then the files that differ are copied,
+// and the files that only exist in toTS are deleted. The changes seen by the
+// watch are then applied one at a time, in order. If the watch fails, the
+// files are compared and copied again before applying the changes anew.
+func SyncTopos(ctx context.Context, fromTS, toTS *topo.Server, cells []string) error {
+	cells, err := syncedCells(ctx, fromTS, toTS, cells)
+	if err != nil {
+		return err
+	}
+
+	syncers := make([]*cellSyncer, 0, len(cells))
+	for _, cell := range cells {
+		from, err := fromTS.ConnForCell(ctx, cell)
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot connect to the source topo of cell %v", cell)
+		}
+		to, err := toTS.ConnForCell(ctx, cell)
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot connect to the destination topo of cell %v", cell)
+		}
+		syncers = append(syncers, &cellSyncer{cell: cell, from: from, to: to})
+	}
+
+	var wg sync.WaitGroup
+	for _, cs := range syncers {
+		wg.Add(1)
+		go func(cs *cellSyncer) {
+			defer wg.Done()
+			cs.run(ctx)
+		}(cs)
+	}
+	wg.Wait()
+	return nil
+}
+
+// CompareTopoFiles compares the files of the global topo, and of the topos of
+// the given cells, or of all the cells if none is given, of fromTS and toTS.
+// It returns the differences, as "<cell>:<path>: <difference>", sorted.
+//
+// While the source topo changes, the differences may just be the changes that
+// SyncTopos did not replicate yet.
+func CompareTopoFiles(ctx context.Context, fromTS, toTS *topo.Server, cells []string) ([]string, error) {
+	cells, err := syncedCells(ctx, fromTS, toTS, cells)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	for _, cell := range cells {
+		fromFiles, err := listSyncedFiles(ctx, fromTS, cell)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot read the source topo of cell %v", cell)
+		}
+		toFiles, err := listSyncedFiles(ctx, toTS, cell)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot read the destination topo of cell %v", cell)
+		}
+
+		for filePath, contents := range fromFiles {
+			toContents, ok := toFiles[filePath]
+			switch {
+			case !ok:
+				diffs = append(diffs, fmt.Sprintf("%v:%v: missing in the destination topo", cell, filePath))
+			case !bytes.Equal(contents, toContents):
+				diffs = append(diffs, fmt.Sprintf("%v:%v: different contents", cell, filePath))
+			}
+		}
+		for filePath := range toFiles {
+			if _, ok := fromFiles[filePath]; !ok {
+				diffs = append(diffs, fmt.Sprintf("%v:%v: not in the source topo", cell, filePath))
+			}
+		}
+	}
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+// syncedCells returns the global cell followed by the given cells, or by all
+// the cells of fromTS if none is given. All of them must exist in both topos.
+func syncedCells(ctx context.Context, fromTS, toTS *topo.Server, cells []string) ([]string, error) {
+	fromCells, err := fromTS.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot read the cells of the source topo")
+	}
+	toCells, err := toTS.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot read the cells of the destination topo")
+	}
+	if len(cells) == 0 {
+		cells = fromCells
+	}
+	for _, cell := range cells {
+		if !slices.Contains(fromCells, cell) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "cell %v does not exist in the source topo", cell)
+		}
+		if !slices.Contains(toCells, cell) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v does not exist in the destination topo, it must be added with the address of its new topo server first", cell)
+		}
+	}
+	return append([]string{topo.GlobalCell}, cells...), nil
+}
+
+// isSyncedPath returns false for the files that are not replicated.
+func isSyncedPath(cell, filePath string) bool {
+	if cell != topo.GlobalCell {
+		return true
+	}
+	dir, _, _ := strings.Cut(filePath, "/")
+	return dir != topo.CellsPath && dir != topo.CellsAliasesPath
+}
+
+// listSyncedFiles returns the contents of the replicated files of the topo of
+// a cell, by path.
+func listSyncedFiles(ctx context.Context, ts *topo.Server, cell string) (map[string][]byte, error) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	if err := listSyncedDir(ctx, conn, cell, "", files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func listSyncedDir(ctx context.Context, conn topo.Conn, cell, dir string, files map[string][]byte) error {
+	entries, err := conn.ListDir(ctx, "/"+dir, true /* full */)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		// The directory was deleted, or the topo is empty.
+		return nil
+	case err != nil:
+		return err
+	}
+
+	for _, entry := range entries {
+		filePath := path.Join(dir, entry.Name)
+		switch {
+		case entry.Ephemeral || !isSyncedPath(cell, filePath):
+			continue
+		case entry.Type == topo.TypeDirectory:
+			if err := listSyncedDir(ctx, conn, cell, filePath, files); err != nil {
+				return err
+			}
+		default:
+			contents, _, err := conn.Get(ctx, filePath)
+			switch {
+			case topo.IsErrType(err, topo.NoNode):
+				continue
+			case err != nil:
+				return err
+			}
+			files[filePath] = contents
+		}
+	}
+	return nil
+}
+
+// cellSyncer replicates the topo of a cell.
+type cellSyncer struct {
+	cell string
+	from topo.Conn
+	to   topo.Conn
+
+	// synced has the paths of the files of the source topo that are
+	// replicated, so that the changes of the other ones, like locks, are
+	// ignored.
+	synced map[string]bool
+}
+
+// run replicates the topo of the cell until ctx is canceled.
+func (cs *cellSyncer) run(ctx context.Context) {
+	for {
+		err := cs.sync(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("Replication of the topo of cell %v stopped, restarting it in %v: %v", cs.cell, SyncRetryDelay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(SyncRetryDelay):
+		}
+	}
+}
+
+// sync watches the source topo, copies the files that differ, then applies
+// the changes until the watch fails. The watch is started first, so that no
+// change is missed.
+func (cs *cellSyncer) sync(ctx context.Context) error {
+	watchCtx, cancel := context.WithCancel(ctx)
+	_, changes, err := cs.from.WatchRecursive(watchCtx, "/")
+	if err != nil {
+		cancel()
+		return err
+	}
+	defer func() {
+		cancel()
+		for range changes {
+		}
+	}()
+
+	if err := cs.catchUp(ctx); err != nil {
+		return err
+	}
+
+	for wd := range changes {
+		if wd.Err != nil && !topo.IsErrType(wd.Err, topo.NoNode) {
+			return wd.Err
+		}
+		if err := cs.apply(ctx, wd); err != nil {
+			return err
+		}
+	}
+	return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "the watch of the topo of cell %v ended", cs.cell)
+}
+
+// catchUp copies the files that differ between the two topos, and deletes
+// the ones that only exist in the destination topo.
+func (cs *cellSyncer) catchUp(ctx context.Context) error {
+	fromFiles := map[string][]byte{}
+	if err := listSyncedDir(ctx, cs.from, cs.cell, "", fromFiles); err != nil {
+		return vterrors.Wrap(err, "cannot read the source topo")
+	}
+	toFiles := map[string][]byte{}
+	if err := listSyncedDir(ctx, cs.to, cs.cell, "", toFiles); err != nil {
+		return vterrors.Wrap(err, "cannot read the destination topo")
+	}
+
+	cs.synced = make(map[string]bool, len(fromFiles))
+	var copied, deleted int
+	for filePath, contents := range fromFiles {
+		cs.synced[filePath] = true
+		if toContents, ok := toFiles[filePath]; ok && bytes.Equal(contents, toContents) {
+			continue
+		}
+		if err := cs.update(ctx, filePath, contents); err != nil {
+			return err
+		}
+		copied++
+	}
+	for filePath := range toFiles {
+		if _, ok := fromFiles[filePath]; ok {
+			continue
+		}
+		if err := cs.delete(ctx, filePath); err != nil {
+			return err
+		}
+		deleted++
+	}
+	log.Infof("Replicating the topo of cell %v: %d files, %d copied and %d deleted to catch up", cs.cell, len(fromFiles), copied, deleted)
+	return nil
+}
+
+// apply replicates a change seen by the watch.
+func (cs *cellSyncer) apply(ctx context.Context, wd *topo.WatchDataRecursive) error {
+	filePath := strings.Trim(wd.Path, "/")
+	if !isSyncedPath(cs.cell, filePath) {
+		return nil
+	}
+
+	if wd.Err != nil {
+		// The file was deleted.
+		if !cs.synced[filePath] {
+			return nil
+		}
+		delete(cs.synced, filePath)
+		return cs.delete(ctx, filePath)
+	}
+
+	if !cs.synced[filePath] {
+		synced, err := cs.isSyncedFile(ctx, filePath)
+		if err != nil || !synced {
+			return err
+		}
+		cs.synced[filePath] = true
+	}
+	return cs.update(ctx, filePath, wd.Contents)
+}
+
+// isSyncedFile returns whether a new file of the source topo is replicated,
+// which it isn't if it is ephemeral, or already deleted.
+func (cs *cellSyncer) isSyncedFile(ctx context.Context, filePath string) (bool, error) {
+	dir, name := path.Split(filePath)
+	entries, err := cs.from.ListDir(ctx, "/"+dir, true /* full */)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.Name == name {
+			return !entry.Ephemeral && entry.Type == topo.TypeFile, nil
+		}
+	}
+	return false, nil
+}
+
+func (cs *cellSyncer) update(ctx context.Context, filePath string, contents []byte) error {
+	if _, err := cs.to.Update(ctx, filePath, contents, nil); err != nil {
+		return vterrors.Wrapf(err, "cannot copy %v", filePath)
+	}
+	log.V(1).Infof("Copied %v:%v", cs.cell, filePath)
+	return nil
+}
+
+func (cs *cellSyncer) delete(ctx context.Context, filePath string) error {
+	if err := cs.to.Delete(ctx, filePath, nil); err != nil && !topo.IsErrType(err, topo.NoNode) {
+		return vterrors.Wrapf(err, "cannot delete %v", filePath)
+	}
+	log.V(1).Infof("Deleted %v:%v", cs.cell, filePath)
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestSyncTopos(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fromTS, toTS := createSetup(ctx, t)
+
+	// A file that only exists in the destination topo is deleted.
+	require.NoError(t, toTS.CreateKeyspace(ctx, "stale_keyspace", &topodatapb.Keyspace{}))
+
+	diffs, err := CompareTopoFiles(ctx, fromTS, toTS, nil)
+	require.NoError(t, err)
+	assert.Contains(t, diffs, "global:keyspaces/test_keyspace/Keyspace: missing in the destination topo")
+	assert.Contains(t, diffs, "global:keyspaces/stale_keyspace/Keyspace: not in the source topo")
+	assert.Contains(t, diffs, "test_cell:tablets/test_cell-0000000123/Tablet: missing in the destination topo")
+
+	syncCtx, syncCancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- SyncTopos(syncCtx, fromTS, toTS, nil)
+	}()
+
+	waitForSync := func() {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			diffs, err := CompareTopoFiles(ctx, fromTS, toTS, nil)
+			require.NoError(t, err)
+			return len(diffs) == 0
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	waitForSync()
+
+	_, err = toTS.GetKeyspace(ctx, "stale_keyspace")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), err)
+	ti, err := toTS.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "test_cell", Uid: 123})
+	require.NoError(t, err)
+	assert.Equal(t, "primaryhost", ti.Hostname)
+
+	// The changes are replicated as they happen, but the locks are not.
+	_, unlock, err := fromTS.LockKeyspace(ctx, "test_keyspace", "sync test")
+	require.NoError(t, err)
+	require.NoError(t, fromTS.CreateKeyspace(ctx, "new_keyspace", &topodatapb.Keyspace{}))
+	_, err = fromTS.UpdateShardFields(ctx, "test_keyspace", "0", func(si *topo.ShardInfo) error {
+		si.IsPrimaryServing = false
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, fromTS.DeleteTablet(ctx, &topodatapb.TabletAlias{Cell: "test_cell", Uid: 234}))
+	unlock(&err)
+	require.NoError(t, err)
+	waitForSync()
+
+	_, err = toTS.GetKeyspace(ctx, "new_keyspace")
+	require.NoError(t, err)
+	si, err := toTS.GetShard(ctx, "test_keyspace", "0")
+	require.NoError(t, err)
+	assert.False(t, si.IsPrimaryServing)
+	_, err = toTS.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "test_cell", Uid: 234})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), err)
+	locks, err := toTS.ListLocks(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+
+	syncCancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("SyncTopos did not return after its context was canceled")
+	}
+}
+
+func TestSyncToposMissingCell(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fromTS, toTS := createSetup(ctx, t)
+	require.NoError(t, fromTS.CreateCellInfo(ctx, "other_cell", &topodatapb.CellInfo{}))
+
+	err := SyncTopos(ctx, fromTS, toTS, nil)
+	assert.ErrorContains(t, err, "cell other_cell does not exist in the destination topo")
+
+	_, err = CompareTopoFiles(ctx, fromTS, toTS, []string{"unknown_cell"})
+	assert.ErrorContains(t, err, "cell unknown_cell does not exist in the source topo")
+}
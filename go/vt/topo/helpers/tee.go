@@ -244,6 +244,18 @@ func (ld *teeTopoLockDescriptor) Unlock(ctx context.Context) error {
 	return ferr
 }
 
+// ListLocks is part of the topo.Conn interface. It lists the locks of
+// lockFirst, as they are taken before the ones of lockSecond.
+func (c *TeeConn) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	return c.lockFirst.ListLocks(ctx, dirPath)
+}
+
+// ForceUnlock is part of the topo.Conn interface. It only releases the lock
+// of lockFirst, the ids returned by ListLocks being the ones of lockFirst.
+func (c *TeeConn) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	return c.lockFirst.ForceUnlock(ctx, dirPath, id)
+}
+
 // NewLeaderParticipation is part of the topo.Conn interface.
 func (c *TeeConn) NewLeaderParticipation(name, id string) (topo.LeaderParticipation, error) {
 	return c.primary.NewLeaderParticipation(name, id)
@@ -487,3 +487,68 @@ func getLockTimeout() time.Duration {
 	}
 	return LockTimeout
 }
+
+// ParseLock returns the Lock of the contents of a keyspace or shard lock, as
+// returned by ListLocks.
+func ParseLock(contents string) (*Lock, error) {
+	l := &Lock{}
+	if err := json.Unmarshal([]byte(contents), l); err != nil {
+		return nil, vterrors.Wrapf(err, "cannot JSON-unmarshal lock")
+	}
+	return l, nil
+}
+
+// lockDirPath returns the directory that is locked by LockKeyspace, or by
+// LockShard if shard is set.
+func lockDirPath(keyspace, shard string) string {
+	if shard == "" {
+		return path.Join(KeyspacesPath, keyspace)
+	}
+	return path.Join(KeyspacesPath, keyspace, ShardsPath, shard)
+}
+
+// ListLocks returns the holders of the keyspace and shard locks of the given
+// keyspace, or of all the keyspaces if it is empty. Their contents can be
+// parsed with ParseLock.
+func (ts *Server) ListLocks(ctx context.Context, keyspace string) ([]*LockHolder, error) {
+	dirPath := KeyspacesPath
+	if keyspace != "" {
+		dirPath = lockDirPath(keyspace, "")
+	}
+	holders, err := ts.globalCell.ListLocks(ctx, dirPath)
+	if keyspace == "" && IsErrType(err, NoNode) {
+		// There is no keyspace.
+		return nil, nil
+	}
+	return holders, err
+}
+
+// ForceUnlock releases the lock of a keyspace, or of a shard if shard is set,
+// if it is held by the lock holder with the given id, as returned by
+// ListLocks. It returns the lock holder.
+//
+// This is meant to clear the locks of processes that crashed or are stuck,
+// and is only safe when the lock holder is known not to be running anymore:
+// otherwise it may keep changing the keyspace or shard while another process
+// takes the lock.
+func (ts *Server) ForceUnlock(ctx context.Context, keyspace, shard, id string) (*LockHolder, error) {
+	dirPath := lockDirPath(keyspace, shard)
+	holders, err := ts.globalCell.ListLocks(ctx, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, holder := range holders {
+		if holder.Path != dirPath || holder.ID != id {
+			continue
+		}
+		if !holder.Held {
+			return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "lock holder %v is waiting for the lock on %v, it does not hold it", id, dirPath)
+		}
+		if err := ts.globalCell.ForceUnlock(ctx, dirPath, id); err != nil {
+			return nil, err
+		}
+		log.Warningf("Force-unlocked %v, that was locked by %v: %v", dirPath, id, holder.Contents)
+		return holder, nil
+	}
+	return nil, NewError(NoNode, path.Join(dirPath, id))
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the maintenances of the shards scheduled in vtctld,
+// which are stored in the global topo so that any vtctld can run them.
+
+func maintenanceFilePath(id string) string {
+	return path.Join(MaintenancePath, id, MaintenanceFile)
+}
+
+// CreateScheduledMaintenance stores a new scheduled maintenance. It fails
+// with NodeExists if there already is one with the same id.
+func (ts *Server) CreateScheduledMaintenance(ctx context.Context, maintenance *topodatapb.ScheduledMaintenance) error {
+	if maintenance.Id == "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the scheduled maintenance has no id")
+	}
+	data, err := maintenance.MarshalVT()
+	if err != nil {
+		return err
+	}
+	_, err = ts.globalCell.Create(ctx, maintenanceFilePath(maintenance.Id), data)
+	return err
+}
+
+// GetScheduledMaintenance reads a scheduled maintenance, along with its
+// version for UpdateScheduledMaintenance.
+func (ts *Server) GetScheduledMaintenance(ctx context.Context, id string) (*topodatapb.ScheduledMaintenance, Version, error) {
+	data, version, err := ts.globalCell.Get(ctx, maintenanceFilePath(id))
+	if err != nil {
+		return nil, nil, err
+	}
+	maintenance := &topodatapb.ScheduledMaintenance{}
+	if err := maintenance.UnmarshalVT(data); err != nil {
+		return nil, nil, vterrors.Wrap(err, "bad ScheduledMaintenance data")
+	}
+	return maintenance, version, nil
+}
+
+// GetScheduledMaintenanceIDs returns the sorted ids of all the scheduled
+// maintenances.
+func (ts *Server) GetScheduledMaintenanceIDs(ctx context.Context) ([]string, error) {
+	entries, err := ts.globalCell.ListDir(ctx, MaintenancePath, false /* full */)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	ids := DirEntriesToStringArray(entries)
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// UpdateScheduledMaintenance writes a scheduled maintenance if it is still at
+// the given version, so that only one vtctld runs it. It fails with
+// BadVersion otherwise. It returns the new version.
+func (ts *Server) UpdateScheduledMaintenance(ctx context.Context, maintenance *topodatapb.ScheduledMaintenance, version Version) (Version, error) {
+	data, err := maintenance.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	return ts.globalCell.Update(ctx, maintenanceFilePath(maintenance.Id), data, version)
+}
+
+// DeleteScheduledMaintenance deletes a scheduled maintenance.
+func (ts *Server) DeleteScheduledMaintenance(ctx context.Context, id string, version Version) error {
+	return ts.globalCell.Delete(ctx, maintenanceFilePath(id), version)
+}
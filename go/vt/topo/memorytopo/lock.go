@@ -19,6 +19,10 @@ package memorytopo
 import (
 	"context"
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 
 	"vitess.io/vitess/go/vt/topo"
 )
@@ -38,6 +42,7 @@ func convertError(err error, nodePath string) error {
 type memoryTopoLockDescriptor struct {
 	c       *Conn
 	dirPath string
+	id      string
 }
 
 // TryLock is part of the topo.Conn interface. Its implementation is same as Lock
@@ -86,6 +91,7 @@ func (c *Conn) lock(ctx context.Context, dirPath, contents string) (topo.LockDes
 		// No one has the lock, grab it.
 		n.lock = make(chan struct{})
 		n.lockContents = contents
+		n.lockID = strconv.FormatUint(c.factory.getNextVersion(), 10)
 		for _, w := range n.watches {
 			if w.lock == nil {
 				continue
@@ -96,6 +102,7 @@ func (c *Conn) lock(ctx context.Context, dirPath, contents string) (topo.LockDes
 		return &memoryTopoLockDescriptor{
 			c:       c,
 			dirPath: dirPath,
+			id:      n.lockID,
 		}, nil
 	}
 }
@@ -108,10 +115,12 @@ func (ld *memoryTopoLockDescriptor) Check(ctx context.Context) error {
 
 // Unlock is part of the topo.LockDescriptor interface.
 func (ld *memoryTopoLockDescriptor) Unlock(ctx context.Context) error {
-	return ld.c.unlock(ctx, ld.dirPath)
+	return ld.c.unlock(ctx, ld.dirPath, ld.id)
 }
 
-func (c *Conn) unlock(ctx context.Context, dirPath string) error {
+// unlock releases the lock on dirPath, if it is held by the lock holder
+// with the given id.
+func (c *Conn) unlock(ctx context.Context, dirPath, id string) error {
 	if c.closed {
 		return ErrConnectionClosed
 	}
@@ -123,11 +132,76 @@ func (c *Conn) unlock(ctx context.Context, dirPath string) error {
 	if n == nil {
 		return topo.NewError(topo.NoNode, dirPath)
 	}
-	if n.lock == nil {
+	if n.lock == nil || n.lockID != id {
 		return fmt.Errorf("node %v is not locked", dirPath)
 	}
 	close(n.lock)
 	n.lock = nil
 	n.lockContents = ""
+	n.lockID = ""
+	return nil
+}
+
+// ListLocks is part of the topo.Conn interface. The processes waiting for
+// a lock are not listed.
+func (c *Conn) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	if err := c.dial(ctx); err != nil {
+		return nil, err
+	}
+
+	c.factory.mu.Lock()
+	defer c.factory.mu.Unlock()
+
+	if c.factory.err != nil {
+		return nil, c.factory.err
+	}
+
+	n := c.factory.nodeByPath(c.cell, dirPath)
+	if n == nil {
+		return nil, topo.NewError(topo.NoNode, dirPath)
+	}
+	var holders []*topo.LockHolder
+	var recurse func(dirPath string, n *node)
+	recurse = func(dirPath string, n *node) {
+		if n.lock != nil {
+			holders = append(holders, &topo.LockHolder{
+				Path:     dirPath,
+				ID:       n.lockID,
+				Contents: n.lockContents,
+				Held:     true,
+			})
+		}
+		for _, child := range n.children {
+			recurse(path.Join(dirPath, child.name), child)
+		}
+	}
+	recurse(strings.Trim(path.Clean(dirPath), "/"), n)
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].Path < holders[j].Path
+	})
+	return holders, nil
+}
+
+// ForceUnlock is part of the topo.Conn interface.
+func (c *Conn) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	if err := c.dial(ctx); err != nil {
+		return err
+	}
+
+	c.factory.mu.Lock()
+	defer c.factory.mu.Unlock()
+
+	if c.factory.err != nil {
+		return c.factory.err
+	}
+
+	n := c.factory.nodeByPath(c.cell, dirPath)
+	if n == nil || n.lock == nil || n.lockID != id {
+		return topo.NewError(topo.NoNode, path.Join(dirPath, id))
+	}
+	close(n.lock)
+	n.lock = nil
+	n.lockContents = ""
+	n.lockID = ""
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"path"
 	"strings"
 	"sync"
 
@@ -173,19 +174,24 @@ type node struct {
 	// For regular locks, it has the contents that was passed in.
 	// For primary election, it has the id of the election leader.
 	lockContents string
+
+	// lockID identifies the current lock holder, for ForceUnlock.
+	lockID string
 }
 
 func (n *node) isDirectory() bool {
 	return n.children != nil
 }
 
-func (n *node) recurseContents(callback func(n *node)) {
+// recurseContents calls callback with all the files under n, and their path.
+// dirPath is the path of n.
+func (n *node) recurseContents(dirPath string, callback func(filePath string, n *node)) {
 	if n.isDirectory() {
 		for _, child := range n.children {
-			child.recurseContents(callback)
+			child.recurseContents(path.Join(dirPath, child.name), callback)
 		}
 	} else {
-		callback(n)
+		callback(dirPath, n)
 	}
 }
 
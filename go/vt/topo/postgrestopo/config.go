@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"time"
+)
+
+const (
+	// Path components
+	electionsPath = "elections"
+
+	// Notification channels, for the changes of the files and of the
+	// lock holders.
+	filesChannel = "vitess_topo"
+	locksChannel = "vitess_topo_locks"
+
+	// listenerPingInterval is how often the connection listening to
+	// the notifications is checked, so that a dead one is replaced.
+	listenerPingInterval = 90 * time.Second
+
+	// electionCheckInterval is how often the leader checks that it still
+	// holds its lock, and the processes waiting for a new leader check
+	// for a leader whose backend is gone.
+	electionCheckInterval = 10 * time.Second
+)
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+const listDirQuery = `SELECT path FROM vitess_topo
+	WHERE path >= $1 AND ($2 = '' OR path < $2) AND starts_with(path, $1)
+	ORDER BY path`
+
+// ListDir is part of the topo.Conn interface. The directories are the
+// prefixes of the paths of the files, so there are no empty directories,
+// and the locks, which are not files, are never listed.
+func (s *Server) ListDir(ctx context.Context, dirPath string, full bool) ([]topo.DirEntry, error) {
+	nodePath := dirPrefix(path.Join(s.root, dirPath))
+	rows, err := s.db.QueryContext(ctx, listDirQuery, nodePath, prefixEnd(nodePath))
+	if err != nil {
+		return nil, convertError(ctx, err, dirPath)
+	}
+	defer rows.Close()
+
+	var result []topo.DirEntry
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, convertError(ctx, err, dirPath)
+		}
+		p = p[len(nodePath):]
+
+		// Keep only the part until the first '/'.
+		t := topo.TypeFile
+		if i := strings.Index(p, "/"); i >= 0 {
+			p = p[:i]
+			t = topo.TypeDirectory
+		}
+
+		// Remove duplicates, add to list.
+		if len(result) == 0 || result[len(result)-1].Name != p {
+			e := topo.DirEntry{
+				Name: p,
+			}
+			if full {
+				e.Type = t
+			}
+			result = append(result, e)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, convertError(ctx, err, dirPath)
+	}
+	if len(result) == 0 {
+		// No file starts with this prefix, means the directory
+		// doesn't exist.
+		return nil, topo.NewError(topo.NoNode, nodePath)
+	}
+	return result, nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+const leaderQuery = `SELECT contents FROM vitess_topo_locks l
+	WHERE path = $1 AND held AND ` + aliveCondition + `
+	ORDER BY id LIMIT 1`
+
+// NewLeaderParticipation is part of the topo.Server interface
+func (s *Server) NewLeaderParticipation(name, id string) (topo.LeaderParticipation, error) {
+	return &postgresLeaderParticipation{
+		s:    s,
+		name: name,
+		id:   id,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}, nil
+}
+
+// postgresLeaderParticipation implements topo.LeaderParticipation.
+//
+// The leader is the holder of the lock on the election path, with the id
+// as the contents of the lock.
+type postgresLeaderParticipation struct {
+	// s is our parent postgres topo Server
+	s *Server
+
+	// name is the name of this LeaderParticipation
+	name string
+
+	// id is the process's current id.
+	id string
+
+	// stop is a channel closed when Stop is called.
+	stop chan struct{}
+
+	// done is a channel closed when we're done processing the Stop
+	done chan struct{}
+}
+
+// WaitForLeadership is part of the topo.LeaderParticipation interface.
+func (mp *postgresLeaderParticipation) WaitForLeadership() (context.Context, error) {
+	// If Stop was already called, mp.done is closed, so we are interrupted.
+	select {
+	case <-mp.done:
+		return nil, topo.NewError(topo.Interrupted, "Leadership")
+	default:
+	}
+
+	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
+
+	// We use a cancelable context here. If stop is closed, or the lock
+	// is lost, we just cancel that context.
+	lockCtx, lockCancel := context.WithCancel(context.Background())
+	locked := make(chan *postgresLockDescriptor, 1)
+	go func() {
+		var ld *postgresLockDescriptor
+		ticker := time.NewTicker(electionCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-mp.s.running:
+				return
+			case <-mp.stop:
+				lockCancel()
+				if ld != nil {
+					if err := ld.Unlock(context.Background()); err != nil {
+						log.Errorf("failed to unlock electionPath %v: %v", electionPath, err)
+					}
+				}
+				close(mp.done)
+				return
+			case ld = <-locked:
+			case <-ticker.C:
+				if ld == nil {
+					continue
+				}
+				if err := ld.Check(lockCtx); err != nil {
+					log.Errorf("lost the lock on electionPath %v: %v", electionPath, err)
+					lockCancel()
+					_ = ld.Unlock(context.Background())
+					ld = nil
+				}
+			}
+		}
+	}()
+
+	// Try to get the primaryship, by getting a lock.
+	ld, err := mp.s.lock(lockCtx, electionPath, mp.id, false /*try*/)
+	if err != nil {
+		// It can be that we were interrupted.
+		return nil, err
+	}
+	locked <- ld
+
+	// We got the lock. Return the lockContext. If Stop() is called,
+	// it will cancel the lockCtx, and cancel the returned context.
+	return lockCtx, nil
+}
+
+// Stop is part of the topo.LeaderParticipation interface
+func (mp *postgresLeaderParticipation) Stop() {
+	close(mp.stop)
+	<-mp.done
+}
+
+// GetCurrentLeaderID is part of the topo.LeaderParticipation interface
+func (mp *postgresLeaderParticipation) GetCurrentLeaderID(ctx context.Context) (string, error) {
+	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
+
+	var leader string
+	if err := mp.s.db.QueryRowContext(ctx, leaderQuery, electionPath).Scan(&leader); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Nobody is the primary.
+			return "", nil
+		}
+		return "", convertError(ctx, err, electionPath)
+	}
+	return leader, nil
+}
+
+// WaitForNewLeader is part of the topo.LeaderParticipation interface. The
+// changes of the lock holders are notified, but the election is also checked
+// periodically, for leaders whose backend is gone.
+func (mp *postgresLeaderParticipation) WaitForNewLeader(ctx context.Context) (<-chan string, error) {
+	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
+
+	w := mp.s.addWatcher(locksChannel, electionPath, false /*recursive*/)
+	leader, err := mp.GetCurrentLeaderID(ctx)
+	if err != nil {
+		mp.s.removeWatcher(w)
+		return nil, err
+	}
+
+	notifications := make(chan string, 8)
+	if leader != "" {
+		notifications <- leader
+	}
+
+	go func() {
+		defer close(notifications)
+		defer mp.s.removeWatcher(w)
+		ticker := time.NewTicker(electionCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-mp.s.running:
+				return
+			case <-mp.done:
+				return
+			case <-ctx.Done():
+				return
+			case <-w.signal:
+				w.changes()
+			case <-ticker.C:
+			}
+
+			currentLeader, err := mp.GetCurrentLeaderID(ctx)
+			if err != nil || currentLeader == "" || currentLeader == leader {
+				continue
+			}
+			leader = currentLeader
+			notifications <- leader
+		}
+	}()
+
+	return notifications, nil
+}
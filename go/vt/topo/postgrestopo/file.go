@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// The writes select from pg_notify for each changed row, so the watches are
+// notified when the statement commits.
+const (
+	createQuery = `WITH w AS (
+		INSERT INTO vitess_topo (path, contents) VALUES ($1, $2)
+		ON CONFLICT (path) DO NOTHING
+		RETURNING path, version
+	) SELECT w.version FROM w, pg_notify('` + filesChannel + `', w.path)`
+
+	upsertQuery = `WITH w AS (
+		INSERT INTO vitess_topo (path, contents) VALUES ($1, $2)
+		ON CONFLICT (path) DO UPDATE SET contents = excluded.contents, version = excluded.version
+		RETURNING path, version
+	) SELECT w.version FROM w, pg_notify('` + filesChannel + `', w.path)`
+
+	updateQuery = `WITH w AS (
+		UPDATE vitess_topo SET contents = $2, version = nextval('vitess_topo_version')
+		WHERE path = $1 AND version = $3
+		RETURNING path, version
+	) SELECT w.version FROM w, pg_notify('` + filesChannel + `', w.path)`
+
+	deleteQuery = `WITH w AS (
+		DELETE FROM vitess_topo WHERE path = $1 AND ($2::bigint IS NULL OR version = $2)
+		RETURNING path
+	) SELECT w.path FROM w, pg_notify('` + filesChannel + `', w.path)`
+
+	getQuery = `SELECT contents, version FROM vitess_topo WHERE path = $1`
+
+	listQuery = `SELECT path, contents, version FROM vitess_topo
+		WHERE path >= $1 AND ($2 = '' OR path < $2) AND starts_with(path, $1)
+		ORDER BY path`
+)
+
+// Create is part of the topo.Conn interface.
+func (s *Server) Create(ctx context.Context, filePath string, contents []byte) (topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	var version int64
+	if err := s.db.QueryRowContext(ctx, createQuery, nodePath, contents).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, topo.NewError(topo.NodeExists, nodePath)
+		}
+		return nil, convertError(ctx, err, nodePath)
+	}
+	return PostgresVersion(version), nil
+}
+
+// Update is part of the topo.Conn interface.
+func (s *Server) Update(ctx context.Context, filePath string, contents []byte, version topo.Version) (topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	var row *sql.Row
+	if version != nil {
+		// Only update the file if its version is what we expect.
+		row = s.db.QueryRowContext(ctx, updateQuery, nodePath, contents, int64(version.(PostgresVersion)))
+	} else {
+		row = s.db.QueryRowContext(ctx, upsertQuery, nodePath, contents)
+	}
+	var newVersion int64
+	if err := row.Scan(&newVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, topo.NewError(topo.BadVersion, nodePath)
+		}
+		return nil, convertError(ctx, err, nodePath)
+	}
+	return PostgresVersion(newVersion), nil
+}
+
+// Get is part of the topo.Conn interface.
+func (s *Server) Get(ctx context.Context, filePath string) ([]byte, topo.Version, error) {
+	return s.get(ctx, path.Join(s.root, filePath))
+}
+
+func (s *Server) get(ctx context.Context, nodePath string) ([]byte, topo.Version, error) {
+	var contents []byte
+	var version int64
+	if err := s.db.QueryRowContext(ctx, getQuery, nodePath).Scan(&contents, &version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, topo.NewError(topo.NoNode, nodePath)
+		}
+		return nil, nil, convertError(ctx, err, nodePath)
+	}
+	return contents, PostgresVersion(version), nil
+}
+
+// List is part of the topo.Conn interface.
+func (s *Server) List(ctx context.Context, filePathPrefix string) ([]topo.KVInfo, error) {
+	nodePathPrefix := path.Join(s.root, filePathPrefix)
+
+	results, err := s.list(ctx, nodePathPrefix)
+	if err != nil {
+		return []topo.KVInfo{}, err
+	}
+	if len(results) == 0 {
+		return []topo.KVInfo{}, topo.NewError(topo.NoNode, nodePathPrefix)
+	}
+	return results, nil
+}
+
+// list returns the files whose path starts with the prefix, sorted by path.
+func (s *Server) list(ctx context.Context, nodePathPrefix string) ([]topo.KVInfo, error) {
+	rows, err := s.db.QueryContext(ctx, listQuery, nodePathPrefix, prefixEnd(nodePathPrefix))
+	if err != nil {
+		return nil, convertError(ctx, err, nodePathPrefix)
+	}
+	defer rows.Close()
+
+	var results []topo.KVInfo
+	for rows.Next() {
+		var key string
+		var version int64
+		var kv topo.KVInfo
+		if err := rows.Scan(&key, &kv.Value, &version); err != nil {
+			return nil, convertError(ctx, err, nodePathPrefix)
+		}
+		kv.Key = []byte(key)
+		kv.Version = PostgresVersion(version)
+		results = append(results, kv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, convertError(ctx, err, nodePathPrefix)
+	}
+	return results, nil
+}
+
+// Delete is part of the topo.Conn interface.
+func (s *Server) Delete(ctx context.Context, filePath string, version topo.Version) error {
+	nodePath := path.Join(s.root, filePath)
+
+	var expected sql.NullInt64
+	if version != nil {
+		expected = sql.NullInt64{Int64: int64(version.(PostgresVersion)), Valid: true}
+	}
+	var deleted string
+	err := s.db.QueryRowContext(ctx, deleteQuery, nodePath, expected).Scan(&deleted)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return convertError(ctx, err, nodePath)
+	}
+	if version != nil {
+		// Nothing was deleted, let's find out if it is because the
+		// file doesn't exist, or because the version was wrong.
+		if _, _, err := s.get(ctx, nodePath); err == nil {
+			return topo.NewError(topo.BadVersion, nodePath)
+		}
+	}
+	return topo.NewError(topo.NoNode, nodePath)
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// The rows of vitess_topo_locks are only valid while their backend is alive:
+// its advisory locks are released when it is gone. The start of the backends
+// of other roles is not visible without pg_read_all_stats, in which case only
+// their pid is compared.
+const aliveCondition = `EXISTS (SELECT 1 FROM pg_stat_activity a WHERE a.pid = l.pid AND (a.backend_start IS NULL OR a.backend_start = l.backend_start))`
+
+const (
+	deleteDeadLocksQuery = `DELETE FROM vitess_topo_locks l WHERE NOT ` + aliveCondition
+
+	insertLockQuery = `INSERT INTO vitess_topo_locks (path, contents, pid, backend_start)
+		SELECT $1::text, $2::text, pid, backend_start FROM pg_stat_activity WHERE pid = pg_backend_pid()
+		RETURNING id`
+
+	advisoryLockQuery    = `SELECT pg_advisory_lock(hashtextextended($1, 0))`
+	advisoryTryLockQuery = `SELECT pg_try_advisory_lock(hashtextextended($1, 0))`
+	advisoryUnlockQuery  = `SELECT pg_advisory_unlock(hashtextextended($1, 0))`
+
+	heldLockQuery = `WITH w AS (
+		UPDATE vitess_topo_locks SET held = true WHERE id = $1 RETURNING path
+	) SELECT w.path FROM w, pg_notify('` + locksChannel + `', w.path)`
+
+	deleteLockQuery = `WITH w AS (
+		DELETE FROM vitess_topo_locks WHERE id = $1 RETURNING path
+	) SELECT w.path FROM w, pg_notify('` + locksChannel + `', w.path)`
+
+	checkLockQuery = `SELECT held FROM vitess_topo_locks WHERE id = $1`
+
+	listLocksQuery = `SELECT id, path, contents, held FROM vitess_topo_locks l
+		WHERE (path = $1 OR (path >= $2 AND ($3 = '' OR path < $3) AND starts_with(path, $2)))
+		AND ` + aliveCondition + `
+		ORDER BY path, held DESC, id`
+
+	lockPIDQuery = `SELECT pid FROM vitess_topo_locks l WHERE id = $1 AND path = $2 AND ` + aliveCondition
+
+	terminateQuery = `SELECT pg_terminate_backend($1)`
+)
+
+// postgresLockDescriptor implements topo.LockDescriptor.
+type postgresLockDescriptor struct {
+	s        *Server
+	nodePath string
+	id       int64
+
+	// mu protects conn, which is the connection holding the advisory
+	// lock, and is nil once the lock is released.
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// TryLock is part of the topo.Conn interface.
+func (s *Server) TryLock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	// We list the directory first to make sure it exists.
+	if _, err := s.ListDir(ctx, dirPath, false /*full*/); err != nil {
+		return nil, err
+	}
+
+	return s.lock(ctx, path.Join(s.root, dirPath), contents, true /*try*/)
+}
+
+// Lock is part of the topo.Conn interface.
+func (s *Server) Lock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	// We list the directory first to make sure it exists.
+	if _, err := s.ListDir(ctx, dirPath, false /*full*/); err != nil {
+		return nil, err
+	}
+
+	return s.lock(ctx, path.Join(s.root, dirPath), contents, false /*try*/)
+}
+
+// lock is used by Lock(), TryLock() and primary election. The row of the
+// lock holder is inserted before waiting for the advisory lock, so the
+// processes waiting for it are listed too.
+func (s *Server) lock(ctx context.Context, nodePath, contents string, try bool) (*postgresLockDescriptor, error) {
+	if _, err := s.db.ExecContext(ctx, deleteDeadLocksQuery); err != nil {
+		return nil, convertError(ctx, err, nodePath)
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err, nodePath)
+	}
+	ld := &postgresLockDescriptor{
+		s:        s,
+		nodePath: nodePath,
+		conn:     conn,
+	}
+	if err := conn.QueryRowContext(ctx, insertLockQuery, nodePath, contents).Scan(&ld.id); err != nil {
+		ld.abandon()
+		return nil, convertError(ctx, err, nodePath)
+	}
+
+	if try {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, advisoryTryLockQuery, nodePath).Scan(&locked); err != nil {
+			ld.abandon()
+			return nil, convertError(ctx, err, nodePath)
+		}
+		if !locked {
+			ld.abandon()
+			return nil, topo.NewError(topo.NodeExists, fmt.Sprintf("lock already exists at path %s", nodePath))
+		}
+	} else {
+		if _, err := conn.ExecContext(ctx, advisoryLockQuery, nodePath); err != nil {
+			// We don't know if we got the lock before the query
+			// was canceled, abandon resets the connection.
+			ld.abandon()
+			return nil, convertError(ctx, err, nodePath)
+		}
+	}
+
+	var lockPath string
+	if err := conn.QueryRowContext(ctx, heldLockQuery, ld.id).Scan(&lockPath); err != nil {
+		ld.abandon()
+		return nil, convertError(ctx, err, nodePath)
+	}
+	return ld, nil
+}
+
+// abandon gives up on the lock, and discards its connection, which ends its
+// session: this releases the advisory lock if we got it, whatever state the
+// connection is in.
+func (ld *postgresLockDescriptor) abandon() {
+	conn := ld.conn
+	ld.conn = nil
+	if err := conn.Raw(func(any) error { return driver.ErrBadConn }); !errors.Is(err, driver.ErrBadConn) {
+		log.Warningf("cannot discard the connection of the lock on %v: %v", ld.nodePath, err)
+	}
+	conn.Close()
+
+	if ld.id != 0 {
+		if _, err := ld.s.db.ExecContext(context.Background(), deleteLockQuery, ld.id); err != nil {
+			log.Warningf("cannot delete the lock holder %v of %v, it will be ignored: %v", ld.id, ld.nodePath, err)
+		}
+	}
+}
+
+// Check is part of the topo.LockDescriptor interface.
+// We use the connection holding the lock, which fails if its session was
+// terminated by ForceUnlock or lost.
+func (ld *postgresLockDescriptor) Check(ctx context.Context) error {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	if ld.conn == nil {
+		return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "lock on %v was released", ld.nodePath)
+	}
+	var held bool
+	if err := ld.conn.QueryRowContext(ctx, checkLockQuery, ld.id).Scan(&held); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return topo.NewError(topo.NoNode, ld.nodePath)
+		}
+		return convertError(ctx, err, ld.nodePath)
+	}
+	return nil
+}
+
+// Unlock is part of the topo.LockDescriptor interface.
+func (ld *postgresLockDescriptor) Unlock(ctx context.Context) error {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	if ld.conn == nil {
+		return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "lock on %v was already released", ld.nodePath)
+	}
+
+	var lockPath string
+	err := ld.conn.QueryRowContext(ctx, deleteLockQuery, ld.id).Scan(&lockPath)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = topo.NewError(topo.NoNode, ld.nodePath)
+	}
+	var unlocked bool
+	if err == nil {
+		err = ld.conn.QueryRowContext(ctx, advisoryUnlockQuery, ld.nodePath).Scan(&unlocked)
+	}
+	if err != nil {
+		ld.abandon()
+		return convertError(ctx, err, ld.nodePath)
+	}
+	ld.conn.Close()
+	ld.conn = nil
+	if !unlocked {
+		return vterrors.Errorf(vtrpc.Code_INTERNAL, "lock on %v was not held", ld.nodePath)
+	}
+	return nil
+}
+
+// ListLocks is part of the topo.Conn interface. The lock holders are the
+// rows of the live backends in vitess_topo_locks, and get the lock in the
+// order in which they were inserted.
+func (s *Server) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	nodePath := path.Join(s.root, dirPath)
+	prefix := dirPrefix(nodePath)
+	rows, err := s.db.QueryContext(ctx, listLocksQuery, nodePath, prefix, prefixEnd(prefix))
+	if err != nil {
+		return nil, convertError(ctx, err, dirPath)
+	}
+	defer rows.Close()
+
+	var holders []*topo.LockHolder
+	for rows.Next() {
+		var id int64
+		var lockPath string
+		holder := &topo.LockHolder{}
+		if err := rows.Scan(&id, &lockPath, &holder.Contents, &holder.Held); err != nil {
+			return nil, convertError(ctx, err, dirPath)
+		}
+		holder.Path = s.relativePath(lockPath)
+		holder.ID = strconv.FormatInt(id, 10)
+		holders = append(holders, holder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, convertError(ctx, err, dirPath)
+	}
+	return holders, nil
+}
+
+// ForceUnlock is part of the topo.Conn interface. It terminates the backend
+// of the lock holder, which releases its advisory lock, and makes its Check
+// calls fail. This requires the role of the process to be a member of the
+// role of the lock holder, or of pg_signal_backend.
+func (s *Server) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	nodePath := path.Join(s.root, dirPath)
+	lockID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return topo.NewError(topo.NoNode, path.Join(nodePath, id))
+	}
+
+	var pid int
+	if err := s.db.QueryRowContext(ctx, lockPIDQuery, lockID, nodePath).Scan(&pid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return topo.NewError(topo.NoNode, path.Join(nodePath, id))
+		}
+		return convertError(ctx, err, nodePath)
+	}
+	var terminated bool
+	if err := s.db.QueryRowContext(ctx, terminateQuery, pid).Scan(&terminated); err != nil {
+		return convertError(ctx, err, nodePath)
+	}
+	if !terminated {
+		return vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot terminate backend %v holding the lock on %v", pid, nodePath)
+	}
+	var lockPath string
+	if err := s.db.QueryRowContext(ctx, deleteLockQuery, lockID).Scan(&lockPath); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return convertError(ctx, err, nodePath)
+	}
+	return nil
+}
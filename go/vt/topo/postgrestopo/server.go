@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package postgrestopo implements topo.Server with PostgreSQL as the backend.
+
+The server address is a PostgreSQL connection string, in the URL or the
+key=value format, e.g. "postgres://vitess@pg1:5432/topo?sslmode=verify-full".
+Passwords are better given with the PGPASSWORD environment variable, or a
+.pgpass file, than in the server address, which is stored in the topo.
+
+The files of all the cells are stored in the vitess_topo table, which is
+created if it doesn't exist, with their path prefixed by the root of the cell:
+several cells, or clusters, can share a database as long as they use different
+roots. We follow these conventions within this package:
+
+  - Each write of a file gets a new version from the vitess_topo_version
+    sequence, which is what the conditional updates and deletes compare.
+  - Each write also sends the path of the file to the vitess_topo channel,
+    with pg_notify in the same statement, which the watches LISTEN to.
+  - Locks are session-level advisory locks, held by a connection dedicated to
+    the lock, so they are released if the process dies. The lock holders, and
+    the processes waiting for them, are listed in the vitess_topo_locks table,
+    along with their backend, and their rows are ignored once their backend
+    is gone.
+  - Call convertError(ctx, err, nodePath) on any errors returned from the
+    database. Functions defined in this package can be assumed to have
+    already converted errors as necessary.
+*/
+package postgrestopo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// Factory is the postgres topo.Factory implementation.
+type Factory struct{}
+
+// HasGlobalReadOnlyCell is part of the topo.Factory interface.
+func (f Factory) HasGlobalReadOnlyCell(serverAddr, root string) bool {
+	return false
+}
+
+// Create is part of the topo.Factory interface.
+func (f Factory) Create(cell, serverAddr, root string) (topo.Conn, error) {
+	return NewServer(serverAddr, root)
+}
+
+// Server is the implementation of topo.Server for PostgreSQL.
+type Server struct {
+	// db is the pool of connections to the database.
+	db *sql.DB
+
+	// root is the root path for this client.
+	root string
+
+	// listener receives the notifications of the changes, for the
+	// watches.
+	listener *pq.Listener
+
+	// mu protects watchers.
+	mu       sync.Mutex
+	watchers map[*watcher]bool
+
+	running chan struct{}
+}
+
+func init() {
+	topo.RegisterFactory("postgres", Factory{})
+}
+
+// schema creates the tables of the topo, if they don't exist. It is run by
+// all the servers, under an advisory lock so they don't race each other.
+var schema = []string{
+	`SELECT pg_advisory_xact_lock(hashtext('vitess_topo_schema'))`,
+	`CREATE SEQUENCE IF NOT EXISTS vitess_topo_version`,
+	`CREATE TABLE IF NOT EXISTS vitess_topo (
+		path text COLLATE "C" PRIMARY KEY,
+		contents bytea NOT NULL,
+		version bigint NOT NULL DEFAULT nextval('vitess_topo_version')
+	)`,
+	`CREATE TABLE IF NOT EXISTS vitess_topo_locks (
+		id bigserial PRIMARY KEY,
+		path text COLLATE "C" NOT NULL,
+		contents text NOT NULL,
+		held boolean NOT NULL DEFAULT false,
+		pid integer NOT NULL,
+		backend_start timestamptz NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS vitess_topo_locks_path ON vitess_topo_locks (path)`,
+}
+
+// NewServer returns a new postgrestopo.Server.
+func NewServer(serverAddr, root string) (*Server, error) {
+	db, err := sql.Open("postgres", serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	if err := createSchema(ctx, db); err != nil {
+		db.Close()
+		return nil, convertError(ctx, err, root)
+	}
+
+	s := &Server{
+		db:       db,
+		root:     root,
+		watchers: make(map[*watcher]bool),
+		running:  make(chan struct{}),
+	}
+	s.listener = pq.NewListener(serverAddr, time.Second, time.Minute, s.listenerEvent)
+	for _, channel := range []string{filesChannel, locksChannel} {
+		if err := s.listener.Listen(channel); err != nil {
+			s.listener.Close()
+			db.Close()
+			return nil, err
+		}
+	}
+	go s.dispatchNotifications()
+	return s, nil
+}
+
+func createSchema(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, query := range schema {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *Server) listenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		log.Warningf("lost the connection listening to the changes of the postgres topo, reconnecting: %v", err)
+	case pq.ListenerEventConnectionAttemptFailed:
+		log.Warningf("cannot reconnect to listen to the changes of the postgres topo: %v", err)
+	}
+}
+
+// Close implements topo.Server.Close.
+// It will nil out the db field, so any attempt to re-use this server
+// will panic.
+func (s *Server) Close() {
+	close(s.running)
+	s.listener.Close()
+	s.db.Close()
+	s.db = nil
+}
+
+// dirPrefix returns the prefix of the paths of the files under the directory
+// at nodePath.
+func dirPrefix(nodePath string) string {
+	if strings.HasSuffix(nodePath, "/") {
+		// Special case where s.root is "/", and the directory is
+		// the root.
+		return nodePath
+	}
+	return nodePath + "/"
+}
+
+// prefixEnd returns the smallest path greater than all the paths that start
+// with prefix, to use the index on the paths, or "" if there is none. Only
+// ASCII bytes are incremented, so the result remains valid UTF-8.
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0x7f {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
+
+// relativePath returns the path of the given node, relative to the root
+// directory of the cell, like the paths given to the Conn methods.
+func (s *Server) relativePath(nodePath string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(nodePath, strings.TrimSuffix(s.root, "/")), "/")
+}
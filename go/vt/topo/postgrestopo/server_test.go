@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os/exec"
+	"path"
+	"testing"
+	"time"
+
+	"vitess.io/vitess/go/testfiles"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/test"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// startPostgres starts a postgres subprocess, and waits for it to be ready.
+// Returns the server address to connect to.
+func startPostgres(t *testing.T) string {
+	if _, err := exec.LookPath("initdb"); err != nil {
+		t.Skip("initdb not found, PostgreSQL is not installed")
+	}
+
+	// Create the database cluster in a temporary directory.
+	dataDir := t.TempDir()
+	if out, err := exec.Command("initdb", "-D", dataDir, "-U", "vitess", "--auth=trust").CombinedOutput(); err != nil {
+		t.Fatalf("initdb failed: %v, %s", err, out)
+	}
+
+	port := testfiles.GoVtTopoPostgrestopoPort
+	cmd := exec.Command("postgres",
+		"-D", dataDir,
+		"-p", fmt.Sprint(port),
+		"-k", dataDir,
+		"-c", "listen_addresses=localhost")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start postgres: %v", err)
+	}
+	t.Cleanup(func() {
+		// log error
+		if err := cmd.Process.Kill(); err != nil {
+			log.Errorf("cmd.Process.Kill() failed : %v", err)
+		}
+		// log error
+		if err := cmd.Wait(); err != nil {
+			log.Errorf("cmd.wait() failed : %v", err)
+		}
+	})
+
+	serverAddr := fmt.Sprintf("host=localhost port=%v user=vitess dbname=postgres sslmode=disable", port)
+	db, err := sql.Open("postgres", serverAddr)
+	if err != nil {
+		t.Fatalf("sql.Open(%v) failed: %v", serverAddr, err)
+	}
+	defer db.Close()
+
+	// Wait until we can connect, or timeout.
+	start := time.Now()
+	for {
+		if err := db.Ping(); err == nil {
+			break
+		}
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("Failed to start postgres daemon in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return serverAddr
+}
+
+func TestPostgresTopo(t *testing.T) {
+	// Start a single postgres in the background.
+	serverAddr := startPostgres(t)
+
+	testIndex := 0
+	newServer := func() *topo.Server {
+		// Each test will use its own sub-directories.
+		testRoot := fmt.Sprintf("/test-%v", testIndex)
+		testIndex++
+
+		// Create the server on the new root.
+		ts, err := topo.OpenServer("postgres", serverAddr, path.Join(testRoot, topo.GlobalCell))
+		if err != nil {
+			t.Fatalf("OpenServer() failed: %v", err)
+		}
+
+		// Create the CellInfo.
+		if err := ts.CreateCellInfo(context.Background(), test.LocalCellName, &topodatapb.CellInfo{
+			ServerAddress: serverAddr,
+			Root:          path.Join(testRoot, test.LocalCellName),
+		}); err != nil {
+			t.Fatalf("CreateCellInfo() failed: %v", err)
+		}
+
+		return ts
+	}
+
+	// Run the TopoServerTestSuite tests.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	test.TopoServerTestSuite(t, ctx, func() *topo.Server {
+		return newServer()
+	}, []string{})
+
+	// Run postgres-specific tests.
+	ts := newServer()
+	testLockReleasedWithSession(t, ts)
+	ts.Close()
+}
+
+// testLockReleasedWithSession makes sure a lock is released when the session
+// holding it is gone, and that its holder is not listed anymore.
+func testLockReleasedWithSession(t *testing.T, ts *topo.Server) {
+	ctx := context.Background()
+	keyspacePath := path.Join(topo.KeyspacesPath, "test_keyspace")
+	if err := ts.CreateKeyspace(ctx, "test_keyspace", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace: %v", err)
+	}
+
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		t.Fatalf("ConnForCell failed: %v", err)
+	}
+	lockDescriptor, err := conn.Lock(ctx, keyspacePath, "lost")
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+
+	// Closing the connection of the lock ends its session, as when the
+	// process dies.
+	ld := lockDescriptor.(*postgresLockDescriptor)
+	ld.mu.Lock()
+	ld.conn.Raw(func(driverConn any) error {
+		return driverConn.(interface{ Close() error }).Close()
+	})
+	ld.mu.Unlock()
+	if err := lockDescriptor.Check(ctx); err == nil {
+		t.Fatalf("Check of a lost lock worked")
+	}
+
+	lockCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	lockDescriptor, err = conn.Lock(lockCtx, keyspacePath, "again")
+	if err != nil {
+		t.Fatalf("Lock was not released with its session: %v", err)
+	}
+	holders, err := conn.ListLocks(ctx, keyspacePath)
+	if err != nil {
+		t.Fatalf("ListLocks failed: %v", err)
+	}
+	if len(holders) != 1 || holders[0].Contents != "again" || !holders[0].Held {
+		t.Fatalf("ListLocks returned unexpected lock holders: %v", holders)
+	}
+	if err := lockDescriptor.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+}
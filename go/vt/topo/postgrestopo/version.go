@@ -0,0 +1,30 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"fmt"
+)
+
+// PostgresVersion is the version of a file, from the vitess_topo_version
+// sequence. It implements topo.Version.
+type PostgresVersion int64
+
+// String is part of the topo.Version interface.
+func (v PostgresVersion) String() string {
+	return fmt.Sprintf("%v", int64(v))
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgrestopo
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// watcher receives the notifications of the changes of a path, or of the
+// paths under a directory, on a channel. The notifications only carry the
+// paths, so the watches read the files again, and they are merged until the
+// watch handles them, so sending them never blocks.
+type watcher struct {
+	channel  string
+	nodePath string
+	// prefix is set for the watchers of a directory.
+	prefix string
+
+	// mu protects changed and resync.
+	mu      sync.Mutex
+	changed map[string]bool
+	// resync is set when notifications may have been missed, while the
+	// listener was reconnecting.
+	resync bool
+
+	// signal has a value when changed or resync is set.
+	signal chan struct{}
+}
+
+func (w *watcher) matches(n *pq.Notification) bool {
+	if n.Channel != w.channel {
+		return false
+	}
+	if w.prefix != "" {
+		return strings.HasPrefix(n.Extra, w.prefix)
+	}
+	return n.Extra == w.nodePath
+}
+
+func (w *watcher) notify(nodePath string, resync bool) {
+	w.mu.Lock()
+	if resync {
+		w.resync = true
+	} else {
+		w.changed[nodePath] = true
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// changes returns the paths that changed, sorted, and whether all of them
+// should be read again, since the last call.
+func (w *watcher) changes() ([]string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	changed := make([]string, 0, len(w.changed))
+	for nodePath := range w.changed {
+		changed = append(changed, nodePath)
+	}
+	sort.Strings(changed)
+	resync := w.resync
+	w.changed = make(map[string]bool)
+	w.resync = false
+	return changed, resync
+}
+
+// addWatcher starts sending the notifications of the channel for nodePath,
+// or for the paths under it if recursive is true, to a new watcher.
+func (s *Server) addWatcher(channel, nodePath string, recursive bool) *watcher {
+	w := &watcher{
+		channel:  channel,
+		nodePath: nodePath,
+		changed:  make(map[string]bool),
+		signal:   make(chan struct{}, 1),
+	}
+	if recursive {
+		w.prefix = dirPrefix(nodePath)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchers[w] = true
+	return w
+}
+
+func (s *Server) removeWatcher(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers, w)
+}
+
+// dispatchNotifications sends the notifications of the listener to the
+// watchers, until the listener is closed.
+func (s *Server) dispatchNotifications() {
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case n, ok := <-s.listener.Notify:
+			if !ok {
+				return
+			}
+			s.mu.Lock()
+			for w := range s.watchers {
+				// A nil notification is sent after the listener
+				// reconnected.
+				if n == nil {
+					w.notify("", true)
+				} else if w.matches(n) {
+					w.notify(n.Extra, false)
+				}
+			}
+			s.mu.Unlock()
+		case <-ticker.C:
+			go func() {
+				// A dead connection is replaced when this fails,
+				// which is logged by listenerEvent.
+				_ = s.listener.Ping()
+			}()
+		}
+	}
+}
+
+// Watch is part of the topo.Conn interface.
+func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	// Start listening to the changes before getting the initial version
+	// of the file, so none is missed.
+	w := s.addWatcher(filesChannel, nodePath, false /*recursive*/)
+	initialCtx, initialCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer initialCancel()
+	contents, version, err := s.get(initialCtx, nodePath)
+	if err != nil {
+		s.removeWatcher(w)
+		return nil, nil, err
+	}
+	wd := &topo.WatchData{
+		Contents: contents,
+		Version:  version,
+	}
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchData, 10)
+	go func() {
+		defer close(notifications)
+		defer s.removeWatcher(w)
+
+		currVersion := version
+		for {
+			select {
+			case <-s.running:
+				return
+			case <-ctx.Done():
+				// This includes context cancellation errors.
+				notifications <- &topo.WatchData{
+					Err: convertError(ctx, ctx.Err(), nodePath),
+				}
+				return
+			case <-w.signal:
+			}
+
+			w.changes()
+			contents, version, err := s.get(ctx, nodePath)
+			if err != nil {
+				if ctx.Err() != nil {
+					continue
+				}
+				// This is the final notice, including when the node is
+				// gone.
+				notifications <- &topo.WatchData{Err: err}
+				return
+			}
+			if version == currVersion {
+				continue
+			}
+			currVersion = version
+			notifications <- &topo.WatchData{
+				Contents: contents,
+				Version:  version,
+			}
+		}
+	}()
+
+	return wd, notifications, nil
+}
+
+// WatchRecursive is part of the topo.Conn interface.
+func (s *Server) WatchRecursive(ctx context.Context, dirpath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
+	nodePath := path.Join(s.root, dirpath)
+
+	w := s.addWatcher(filesChannel, nodePath, true /*recursive*/)
+	initial, err := s.list(ctx, w.prefix)
+	if err != nil {
+		s.removeWatcher(w)
+		return nil, nil, err
+	}
+
+	var initialwd []*topo.WatchDataRecursive
+	versions := make(map[string]topo.Version)
+	for _, kv := range initial {
+		initialwd = append(initialwd, &topo.WatchDataRecursive{
+			Path: s.relativePath(string(kv.Key)),
+			WatchData: topo.WatchData{
+				Contents: kv.Value,
+				Version:  kv.Version,
+			},
+		})
+		versions[string(kv.Key)] = kv.Version
+	}
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+		defer s.removeWatcher(w)
+
+		// send sends the change of a file, if its version changed, or
+		// its deletion if it existed.
+		send := func(nodePath string, contents []byte, version topo.Version) {
+			if version == nil {
+				if _, ok := versions[nodePath]; ok {
+					delete(versions, nodePath)
+					notifications <- &topo.WatchDataRecursive{
+						Path: s.relativePath(nodePath),
+						WatchData: topo.WatchData{
+							Err: topo.NewError(topo.NoNode, nodePath),
+						},
+					}
+				}
+				return
+			}
+			if versions[nodePath] == version {
+				return
+			}
+			versions[nodePath] = version
+			notifications <- &topo.WatchDataRecursive{
+				Path: s.relativePath(nodePath),
+				WatchData: topo.WatchData{
+					Contents: contents,
+					Version:  version,
+				},
+			}
+		}
+
+		for {
+			select {
+			case <-s.running:
+				return
+			case <-ctx.Done():
+				// This includes context cancellation errors.
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(ctx, ctx.Err(), nodePath)},
+				}
+				return
+			case <-w.signal:
+			}
+
+			changed, resync := w.changes()
+			if resync {
+				// Notifications may have been missed, read all the
+				// files again.
+				kvs, err := s.list(ctx, w.prefix)
+				if err != nil {
+					if ctx.Err() != nil {
+						continue
+					}
+					notifications <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: err}}
+					return
+				}
+				found := make(map[string]bool)
+				for _, kv := range kvs {
+					found[string(kv.Key)] = true
+					send(string(kv.Key), kv.Value, kv.Version)
+				}
+				for nodePath := range versions {
+					if !found[nodePath] {
+						send(nodePath, nil, nil)
+					}
+				}
+				continue
+			}
+
+			for _, nodePath := range changed {
+				contents, version, err := s.get(ctx, nodePath)
+				if err != nil && !topo.IsErrType(err, topo.NoNode) {
+					if ctx.Err() != nil {
+						break
+					}
+					log.Warningf("cannot read %v changed under watched %v, reading all the files again: %v", nodePath, w.prefix, err)
+					w.notify("", true)
+					break
+				}
+				send(nodePath, contents, version)
+			}
+		}
+	}()
+
+	return initialwd, notifications, nil
+}
@@ -46,6 +46,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/spf13/pflag"
 
@@ -67,18 +68,24 @@ const (
 
 // Filenames for all object types.
 const (
-	CellInfoFile          = "CellInfo"
-	CellsAliasFile        = "CellsAlias"
-	KeyspaceFile          = "Keyspace"
-	ShardFile             = "Shard"
-	VSchemaFile           = "VSchema"
-	ShardReplicationFile  = "ShardReplication"
-	TabletFile            = "Tablet"
-	SrvVSchemaFile        = "SrvVSchema"
-	SrvKeyspaceFile       = "SrvKeyspace"
-	RoutingRulesFile      = "RoutingRules"
-	ExternalClustersFile  = "ExternalClusters"
-	ShardRoutingRulesFile = "ShardRoutingRules"
+	CellInfoFile           = "CellInfo"
+	CellsAliasFile         = "CellsAlias"
+	KeyspaceFile           = "Keyspace"
+	ShardFile              = "Shard"
+	VSchemaFile            = "VSchema"
+	ShardReplicationFile   = "ShardReplication"
+	TabletFile             = "Tablet"
+	SrvVSchemaFile         = "SrvVSchema"
+	SrvKeyspaceFile        = "SrvKeyspace"
+	RoutingRulesFile       = "RoutingRules"
+	ExternalClustersFile   = "ExternalClusters"
+	ShardRoutingRulesFile  = "ShardRoutingRules"
+	TenantRoutingRulesFile = "TenantRoutingRules"
+	TopoReadOnlyFile       = "TopoReadOnly"
+	TopoGCStateFile        = "TopoGCState"
+	MaintenanceFile        = "ScheduledMaintenance"
+	BackupValidationFile   = "BackupValidation"
+	ClusterSettingsFile    = "ClusterSettings"
 )
 
 // Path for all object types.
@@ -90,6 +97,10 @@ const (
 	TabletsPath           = "tablets"
 	MetadataPath          = "metadata"
 	ExternalClusterVitess = "vitess"
+	TopoReadOnlyPath      = "topo_read_only"
+	TopoGCPath            = "topo_gc"
+	MaintenancePath       = "maintenance"
+	BackupValidationsPath = "backup_validations"
 )
 
 // Factory is a factory interface to create Conn objects.
@@ -141,6 +152,17 @@ type Server struct {
 	// will read the list of addresses for that cell from the
 	// global cluster and create clients as needed.
 	cellConns map[string]cellConn
+	// cancelTopoReadOnlyWatch stops the watch started by
+	// StartTopoReadOnlyWatch.
+	cancelTopoReadOnlyWatch context.CancelFunc
+
+	// topoReadOnly is the read-only mode of the topology, or nil if it
+	// is not enabled. It is shared with the StatsConns, which reject
+	// the writes while it is set.
+	topoReadOnly atomic.Pointer[topodata.TopoReadOnly]
+
+	// topoACL is the ACL enforced by the StatsConns, or nil.
+	topoACL atomic.Pointer[TopoACL]
 }
 
 type cellConn struct {
@@ -206,7 +228,11 @@ func NewWithFactory(factory Factory, serverAddress, root string) (*Server, error
 	if err != nil {
 		return nil, err
 	}
-	conn = NewStatsConn(GlobalCell, conn)
+	ts := &Server{
+		factory:   factory,
+		cellConns: make(map[string]cellConn),
+	}
+	conn = ts.newStatsConn(GlobalCell, conn)
 
 	var connReadOnly Conn
 	if factory.HasGlobalReadOnlyCell(serverAddress, root) {
@@ -214,17 +240,23 @@ func NewWithFactory(factory Factory, serverAddress, root string) (*Server, error
 		if err != nil {
 			return nil, err
 		}
-		connReadOnly = NewStatsConn(GlobalReadOnlyCell, connReadOnly)
+		connReadOnly = ts.newStatsConn(GlobalReadOnlyCell, connReadOnly)
 	} else {
 		connReadOnly = conn
 	}
 
-	return &Server{
-		globalCell:         conn,
-		globalReadOnlyCell: connReadOnly,
-		factory:            factory,
-		cellConns:          make(map[string]cellConn),
-	}, nil
+	ts.globalCell = newReadCacheConn(GlobalCell, conn)
+	ts.globalReadOnlyCell = connReadOnly
+	return ts, nil
+}
+
+// newStatsConn returns a StatsConn that rejects the writes while the
+// topology is in read-only mode, and enforces the topo ACL.
+func (ts *Server) newStatsConn(cell string, conn Conn) *StatsConn {
+	sc := NewStatsConn(cell, conn)
+	sc.topoReadOnly = &ts.topoReadOnly
+	sc.topoACL = &ts.topoACL
+	return sc
 }
 
 // OpenServer returns a Server using the provided implementation,
@@ -250,6 +282,10 @@ func Open() *Server {
 	if err != nil {
 		log.Exitf("Failed to open topo server (%v,%v,%v): %v", topoImplementation, topoGlobalServerAddress, topoGlobalRoot, err)
 	}
+	if err := ts.openTopoACL(); err != nil {
+		log.Exitf("Failed to set up the topo ACL: %v", err)
+	}
+	ts.StartTopoReadOnlyWatch()
 	return ts
 }
 
@@ -295,7 +331,7 @@ func (ts *Server) ConnForCell(ctx context.Context, cell string) (Conn, error) {
 	conn, err := ts.factory.Create(cell, ci.ServerAddress, ci.Root)
 	switch {
 	case err == nil:
-		conn = NewStatsConn(cell, conn)
+		conn = newReadCacheConn(cell, ts.newStatsConn(cell, conn))
 		ts.cellConns[cell] = cellConn{ci, conn}
 		return conn, nil
 	case IsErrType(err, NoNode):
@@ -337,6 +373,11 @@ func GetAliasByCell(ctx context.Context, ts *Server, cell string) string {
 // Close will close all connections to underlying topo Server.
 // It will nil all member variables, so any further access will panic.
 func (ts *Server) Close() {
+	ts.mu.Lock()
+	if ts.cancelTopoReadOnlyWatch != nil {
+		ts.cancelTopoReadOnlyWatch()
+	}
+	ts.mu.Unlock()
 	ts.globalCell.Close()
 	if ts.globalReadOnlyCell != ts.globalCell {
 		ts.globalReadOnlyCell.Close()
@@ -377,16 +418,26 @@ func (ts *Server) OpenExternalVitessClusterServer(ctx context.Context, clusterNa
 	return externalTopo, nil
 }
 
+// asStatsConn returns the StatsConn of conn, which may be wrapped by a
+// CachedConn.
+func asStatsConn(conn Conn) (*StatsConn, bool) {
+	if cc, ok := conn.(*CachedConn); ok {
+		conn = cc.Conn
+	}
+	sc, ok := conn.(*StatsConn)
+	return sc, ok
+}
+
 // SetReadOnly is initially ONLY implemented by StatsConn and used in ReadOnlyServer
 func (ts *Server) SetReadOnly(readOnly bool) error {
-	globalCellConn, ok := ts.globalCell.(*StatsConn)
+	globalCellConn, ok := asStatsConn(ts.globalCell)
 	if !ok {
 		return fmt.Errorf("invalid global cell connection type, expected StatsConn but found: %T", ts.globalCell)
 	}
 	globalCellConn.SetReadOnly(readOnly)
 
 	for _, cc := range ts.cellConns {
-		localCellConn, ok := cc.conn.(*StatsConn)
+		localCellConn, ok := asStatsConn(cc.conn)
 		if !ok {
 			return fmt.Errorf("invalid local cell connection type, expected StatsConn but found: %T", cc.conn)
 		}
@@ -398,7 +449,7 @@ func (ts *Server) SetReadOnly(readOnly bool) error {
 
 // IsReadOnly is initially ONLY implemented by StatsConn and used in ReadOnlyServer
 func (ts *Server) IsReadOnly() (bool, error) {
-	globalCellConn, ok := ts.globalCell.(*StatsConn)
+	globalCellConn, ok := asStatsConn(ts.globalCell)
 	if !ok {
 		return false, fmt.Errorf("invalid global cell connection type, expected StatsConn but found: %T", ts.globalCell)
 	}
@@ -407,7 +458,7 @@ func (ts *Server) IsReadOnly() (bool, error) {
 	}
 
 	for _, cc := range ts.cellConns {
-		localCellConn, ok := cc.conn.(*StatsConn)
+		localCellConn, ok := asStatsConn(cc.conn)
 		if !ok {
 			return false, fmt.Errorf("invalid local cell connection type, expected StatsConn but found: %T", cc.conn)
 		}
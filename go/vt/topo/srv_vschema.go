@@ -204,6 +204,16 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 	}
 	srvVSchema.ShardRoutingRules = srr
 
+	trr, err := ts.GetTenantRoutingRules(ctx)
+	if err != nil {
+		return fmt.Errorf("GetTenantRoutingRules failed: %v", err)
+	}
+	// Tenant routing rules are only set once some tenant has been migrated,
+	// so that the SrvVSchema of other clusters is left as it was.
+	if len(trr.Rules) > 0 {
+		srvVSchema.TenantRoutingRules = trr
+	}
+
 	// now save the SrvVSchema in all cells in parallel
 	for _, cell := range cells {
 		wg.Add(1)
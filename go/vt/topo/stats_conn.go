@@ -18,11 +18,15 @@ package topo
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var _ Conn = (*StatsConn)(nil)
@@ -39,13 +43,24 @@ var (
 		[]string{"Operation", "Cell"})
 )
 
-const readOnlyErrorStrFormat = "cannot perform %s on %s as the topology server connection is read-only"
+const (
+	readOnlyErrorStrFormat     = "cannot perform %s on %s as the topology server connection is read-only"
+	topoReadOnlyErrorStrFormat = "cannot perform %s on %s as the topology is in read-only mode since %v: %s"
+)
 
 // The StatsConn is a wrapper for a Conn that emits stats for every operation
 type StatsConn struct {
 	cell     string
 	conn     Conn
 	readOnly bool
+
+	// topoReadOnly is the read-only mode of the topology, shared by all
+	// the StatsConns of a Server. It may be nil.
+	topoReadOnly *atomic.Pointer[topodatapb.TopoReadOnly]
+
+	// topoACL is the ACL of the topo, shared by all the StatsConns of a
+	// Server. It may be nil.
+	topoACL *atomic.Pointer[TopoACL]
 }
 
 // NewStatsConn returns a StatsConn
@@ -59,6 +74,9 @@ func NewStatsConn(cell string, conn Conn) *StatsConn {
 
 // ListDir is part of the Conn interface
 func (st *StatsConn) ListDir(ctx context.Context, dirPath string, full bool) ([]DirEntry, error) {
+	if err := st.acl().check(TopoVerbRead, "ListDir", st.cell, dirPath); err != nil {
+		return nil, err
+	}
 	startTime := time.Now()
 	statsKey := []string{"ListDir", st.cell}
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -73,12 +91,17 @@ func (st *StatsConn) ListDir(ctx context.Context, dirPath string, full bool) ([]
 // Create is part of the Conn interface
 func (st *StatsConn) Create(ctx context.Context, filePath string, contents []byte) (Version, error) {
 	statsKey := []string{"Create", st.cell}
-	if st.readOnly {
-		return nil, vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, statsKey[0], filePath)
+	if err := st.checkWritable(statsKey[0], filePath); err != nil {
+		return nil, err
+	}
+	acl := st.acl()
+	if err := acl.check(TopoVerbWrite, statsKey[0], st.cell, filePath); err != nil {
+		return nil, err
 	}
 	startTime := time.Now()
 	defer topoStatsConnTimings.Record(statsKey, startTime)
 	res, err := st.conn.Create(ctx, filePath, contents)
+	acl.auditMutation(statsKey[0], st.cell, filePath, err)
 	if err != nil {
 		topoStatsConnErrors.Add(statsKey, int64(1))
 		return res, err
@@ -89,12 +112,17 @@ func (st *StatsConn) Create(ctx context.Context, filePath string, contents []byt
 // Update is part of the Conn interface
 func (st *StatsConn) Update(ctx context.Context, filePath string, contents []byte, version Version) (Version, error) {
 	statsKey := []string{"Update", st.cell}
-	if st.readOnly {
-		return nil, vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, statsKey[0], filePath)
+	if err := st.checkWritable(statsKey[0], filePath); err != nil {
+		return nil, err
+	}
+	acl := st.acl()
+	if err := acl.check(TopoVerbWrite, statsKey[0], st.cell, filePath); err != nil {
+		return nil, err
 	}
 	startTime := time.Now()
 	defer topoStatsConnTimings.Record(statsKey, startTime)
 	res, err := st.conn.Update(ctx, filePath, contents, version)
+	acl.auditMutation(statsKey[0], st.cell, filePath, err)
 	if err != nil {
 		topoStatsConnErrors.Add(statsKey, int64(1))
 		return res, err
@@ -104,6 +132,9 @@ func (st *StatsConn) Update(ctx context.Context, filePath string, contents []byt
 
 // Get is part of the Conn interface
 func (st *StatsConn) Get(ctx context.Context, filePath string) ([]byte, Version, error) {
+	if err := st.acl().check(TopoVerbRead, "Get", st.cell, filePath); err != nil {
+		return nil, nil, err
+	}
 	startTime := time.Now()
 	statsKey := []string{"Get", st.cell}
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -117,6 +148,9 @@ func (st *StatsConn) Get(ctx context.Context, filePath string) ([]byte, Version,
 
 // List is part of the Conn interface
 func (st *StatsConn) List(ctx context.Context, filePathPrefix string) ([]KVInfo, error) {
+	if err := st.acl().check(TopoVerbRead, "List", st.cell, filePathPrefix); err != nil {
+		return nil, err
+	}
 	startTime := time.Now()
 	statsKey := []string{"List", st.cell}
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -131,12 +165,17 @@ func (st *StatsConn) List(ctx context.Context, filePathPrefix string) ([]KVInfo,
 // Delete is part of the Conn interface
 func (st *StatsConn) Delete(ctx context.Context, filePath string, version Version) error {
 	statsKey := []string{"Delete", st.cell}
-	if st.readOnly {
-		return vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, statsKey[0], filePath)
+	if err := st.checkWritable(statsKey[0], filePath); err != nil {
+		return err
+	}
+	acl := st.acl()
+	if err := acl.check(TopoVerbWrite, statsKey[0], st.cell, filePath); err != nil {
+		return err
 	}
 	startTime := time.Now()
 	defer topoStatsConnTimings.Record(statsKey, startTime)
 	err := st.conn.Delete(ctx, filePath, version)
+	acl.auditMutation(statsKey[0], st.cell, filePath, err)
 	if err != nil {
 		topoStatsConnErrors.Add(statsKey, int64(1))
 		return err
@@ -157,8 +196,12 @@ func (st *StatsConn) TryLock(ctx context.Context, dirPath, contents string) (Loc
 // TryLock is part of the topo.Conn interface. Its implementation is same as Lock
 func (st *StatsConn) internalLock(ctx context.Context, dirPath, contents string, isBlocking bool) (LockDescriptor, error) {
 	statsKey := []string{"Lock", st.cell}
-	if st.readOnly {
-		return nil, vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, statsKey[0], dirPath)
+	if err := st.checkWritable(statsKey[0], dirPath); err != nil {
+		return nil, err
+	}
+	acl := st.acl()
+	if err := acl.check(TopoVerbLock, statsKey[0], st.cell, dirPath); err != nil {
+		return nil, err
 	}
 	startTime := time.Now()
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -169,6 +212,23 @@ func (st *StatsConn) internalLock(ctx context.Context, dirPath, contents string,
 	} else {
 		res, err = st.conn.TryLock(ctx, dirPath, contents)
 	}
+	acl.auditMutation(statsKey[0], st.cell, dirPath, err)
+	if err != nil {
+		topoStatsConnErrors.Add(statsKey, int64(1))
+		return res, err
+	}
+	return res, err
+}
+
+// ListLocks is part of the Conn interface
+func (st *StatsConn) ListLocks(ctx context.Context, dirPath string) ([]*LockHolder, error) {
+	if err := st.acl().check(TopoVerbRead, "ListLocks", st.cell, dirPath); err != nil {
+		return nil, err
+	}
+	startTime := time.Now()
+	statsKey := []string{"ListLocks", st.cell}
+	defer topoStatsConnTimings.Record(statsKey, startTime)
+	res, err := st.conn.ListLocks(ctx, dirPath)
 	if err != nil {
 		topoStatsConnErrors.Add(statsKey, int64(1))
 		return res, err
@@ -176,8 +236,32 @@ func (st *StatsConn) internalLock(ctx context.Context, dirPath, contents string,
 	return res, err
 }
 
+// ForceUnlock is part of the Conn interface
+func (st *StatsConn) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	statsKey := []string{"ForceUnlock", st.cell}
+	if err := st.checkWritable(statsKey[0], dirPath); err != nil {
+		return err
+	}
+	acl := st.acl()
+	if err := acl.check(TopoVerbLock, statsKey[0], st.cell, dirPath); err != nil {
+		return err
+	}
+	startTime := time.Now()
+	defer topoStatsConnTimings.Record(statsKey, startTime)
+	err := st.conn.ForceUnlock(ctx, dirPath, id)
+	acl.auditMutation(statsKey[0], st.cell, dirPath, err)
+	if err != nil {
+		topoStatsConnErrors.Add(statsKey, int64(1))
+		return err
+	}
+	return err
+}
+
 // Watch is part of the Conn interface
 func (st *StatsConn) Watch(ctx context.Context, filePath string) (current *WatchData, changes <-chan *WatchData, err error) {
+	if err := st.acl().check(TopoVerbWatch, "Watch", st.cell, filePath); err != nil {
+		return nil, nil, err
+	}
 	startTime := time.Now()
 	statsKey := []string{"Watch", st.cell}
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -185,6 +269,9 @@ func (st *StatsConn) Watch(ctx context.Context, filePath string) (current *Watch
 }
 
 func (st *StatsConn) WatchRecursive(ctx context.Context, path string) ([]*WatchDataRecursive, <-chan *WatchDataRecursive, error) {
+	if err := st.acl().check(TopoVerbWatch, "WatchRecursive", st.cell, path); err != nil {
+		return nil, nil, err
+	}
 	startTime := time.Now()
 	statsKey := []string{"WatchRecursive", st.cell}
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -193,6 +280,9 @@ func (st *StatsConn) WatchRecursive(ctx context.Context, path string) ([]*WatchD
 
 // NewLeaderParticipation is part of the Conn interface
 func (st *StatsConn) NewLeaderParticipation(name, id string) (LeaderParticipation, error) {
+	if err := st.acl().check(TopoVerbElection, "NewLeaderParticipation", st.cell, name); err != nil {
+		return nil, err
+	}
 	startTime := time.Now()
 	statsKey := []string{"NewLeaderParticipation", st.cell}
 	defer topoStatsConnTimings.Record(statsKey, startTime)
@@ -212,6 +302,31 @@ func (st *StatsConn) Close() {
 	st.conn.Close()
 }
 
+// checkWritable returns an error if the connection is read-only, or if the
+// topology is in read-only mode, unless filePath is the record of the
+// read-only mode itself, so that it can be disabled.
+func (st *StatsConn) checkWritable(operation, filePath string) error {
+	if st.readOnly {
+		return vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, operation, filePath)
+	}
+	if st.topoReadOnly == nil {
+		return nil
+	}
+	mode := st.topoReadOnly.Load()
+	if mode == nil || (st.cell == GlobalCell && isTopoReadOnlyPath(filePath)) {
+		return nil
+	}
+	return vterrors.Errorf(vtrpc.Code_READ_ONLY, topoReadOnlyErrorStrFormat, operation, filePath, protoutil.TimeFromProto(mode.Since).UTC(), mode.Reason)
+}
+
+// acl returns the ACL of the topo, or nil.
+func (st *StatsConn) acl() *TopoACL {
+	if st.topoACL == nil {
+		return nil
+	}
+	return st.topoACL.Load()
+}
+
 // SetReadOnly with true prevents any write operations from being made on the topo connection
 func (st *StatsConn) SetReadOnly(readOnly bool) {
 	st.readOnly = readOnly
@@ -117,6 +117,25 @@ func (st *fakeConn) TryLock(ctx context.Context, dirPath, contents string) (lock
 	return lock, err
 }
 
+// ListLocks is part of the Conn interface
+func (st *fakeConn) ListLocks(ctx context.Context, dirPath string) (holders []*LockHolder, err error) {
+	if dirPath == "error" {
+		return holders, fmt.Errorf("dummy error")
+	}
+	return holders, err
+}
+
+// ForceUnlock is part of the Conn interface
+func (st *fakeConn) ForceUnlock(ctx context.Context, dirPath, id string) (err error) {
+	if st.readOnly {
+		return vterrors.Errorf(vtrpc.Code_READ_ONLY, "topo server connection is read-only")
+	}
+	if dirPath == "error" {
+		return fmt.Errorf("dummy error")
+	}
+	return err
+}
+
 // Watch is part of the Conn interface
 func (st *fakeConn) Watch(ctx context.Context, filePath string) (current *WatchData, changes <-chan *WatchData, err error) {
 	return current, changes, err
@@ -312,6 +331,48 @@ func TestStatsConnTopoLock(t *testing.T) {
 	}
 }
 
+// TestStatsConnTopoListLocks emits stats on ListLocks
+func TestStatsConnTopoListLocks(t *testing.T) {
+	conn := &fakeConn{}
+	statsConn := NewStatsConn("global", conn)
+	ctx := context.Background()
+
+	statsConn.ListLocks(ctx, "")
+	timingCounts := topoStatsConnTimings.Counts()["ListLocks.global"]
+	if got, want := timingCounts, int64(1); got != want {
+		t.Errorf("stats were not properly recorded: got = %d, want = %d", got, want)
+	}
+
+	statsConn.ListLocks(ctx, "error")
+
+	// error stats gets emitted
+	errorCount := topoStatsConnErrors.Counts()["ListLocks.global"]
+	if got, want := errorCount, int64(1); got != want {
+		t.Errorf("stats were not properly recorded: got = %d, want = %d", got, want)
+	}
+}
+
+// TestStatsConnTopoForceUnlock emits stats on ForceUnlock
+func TestStatsConnTopoForceUnlock(t *testing.T) {
+	conn := &fakeConn{}
+	statsConn := NewStatsConn("global", conn)
+	ctx := context.Background()
+
+	statsConn.ForceUnlock(ctx, "", "")
+	timingCounts := topoStatsConnTimings.Counts()["ForceUnlock.global"]
+	if got, want := timingCounts, int64(1); got != want {
+		t.Errorf("stats were not properly recorded: got = %d, want = %d", got, want)
+	}
+
+	statsConn.ForceUnlock(ctx, "error", "")
+
+	// error stats gets emitted
+	errorCount := topoStatsConnErrors.Counts()["ForceUnlock.global"]
+	if got, want := errorCount, int64(1); got != want {
+		t.Errorf("stats were not properly recorded: got = %d, want = %d", got, want)
+	}
+}
+
 // TestStatsConnTopoWatch emits stats on Watch
 func TestStatsConnTopoWatch(t *testing.T) {
 	conn := &fakeConn{}
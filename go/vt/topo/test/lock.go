@@ -52,6 +52,9 @@ func checkLock(t *testing.T, ctx context.Context, ts *topo.Server) {
 
 	t.Log("===      checkLockUnblocks")
 	checkLockUnblocks(ctx, t, conn)
+
+	t.Log("===      checkForceUnlock")
+	checkForceUnlock(ctx, t, conn)
 }
 
 func checkLockTimeout(ctx context.Context, t *testing.T, conn topo.Conn) {
@@ -172,3 +175,69 @@ func checkLockUnblocks(ctx context.Context, t *testing.T, conn topo.Conn) {
 		t.Fatalf("Unlock(test_keyspace) timed out")
 	}
 }
+
+// checkForceUnlock makes sure the locks are listed, and that ForceUnlock
+// releases the lock of the given holder only.
+func checkForceUnlock(ctx context.Context, t *testing.T, conn topo.Conn) {
+	keyspacePath := path.Join(topo.KeyspacesPath, "test_keyspace")
+	lockDescriptor, err := conn.Lock(ctx, keyspacePath, "stuck")
+	if err != nil {
+		t.Fatalf("Lock(test_keyspace) failed: %v", err)
+	}
+
+	// Another routine waits for the lock.
+	locked := make(chan topo.LockDescriptor)
+	go func() {
+		lockDescriptor, err := conn.Lock(ctx, keyspacePath, "waiting")
+		if err != nil {
+			t.Errorf("Lock(test_keyspace) failed: %v", err)
+		}
+		locked <- lockDescriptor
+	}()
+	time.Sleep(timeUntilLockIsTaken)
+
+	holders, err := conn.ListLocks(ctx, topo.KeyspacesPath)
+	if err != nil {
+		t.Fatalf("ListLocks failed: %v", err)
+	}
+	if len(holders) == 0 || holders[0].Path != keyspacePath || holders[0].Contents != "stuck" || !holders[0].Held {
+		t.Fatalf("ListLocks returned unexpected lock holders: %v", holders)
+	}
+	for _, holder := range holders[1:] {
+		if holder.Held {
+			t.Errorf("ListLocks returned more than one lock holder: %v", holders)
+		}
+	}
+	stuckID := holders[0].ID
+
+	if err := conn.ForceUnlock(ctx, keyspacePath, stuckID+"666"); !topo.IsErrType(err, topo.NoNode) {
+		t.Fatalf("ForceUnlock(unknown id): %v", err)
+	}
+	if err := conn.ForceUnlock(ctx, keyspacePath, stuckID); err != nil {
+		t.Fatalf("ForceUnlock failed: %v", err)
+	}
+	if err := conn.ForceUnlock(ctx, keyspacePath, stuckID); !topo.IsErrType(err, topo.NoNode) {
+		t.Fatalf("ForceUnlock(again): %v", err)
+	}
+
+	// The stuck process unlocking later doesn't release the lock of the
+	// waiting one, whether it got it already or not.
+	_ = lockDescriptor.Unlock(ctx)
+
+	var waitingDescriptor topo.LockDescriptor
+	select {
+	case waitingDescriptor = <-locked:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Lock(test_keyspace) was not unblocked by ForceUnlock")
+	}
+	holders, err = conn.ListLocks(ctx, keyspacePath)
+	if err != nil {
+		t.Fatalf("ListLocks failed: %v", err)
+	}
+	if len(holders) != 1 || holders[0].Contents != "waiting" || !holders[0].Held {
+		t.Fatalf("ListLocks returned unexpected lock holders: %v", holders)
+	}
+	if err := waitingDescriptor.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock(test_keyspace): %v", err)
+	}
+}
@@ -99,6 +99,11 @@ func waitForInitialValueRecursive(t *testing.T, conn topo.Conn, srvKeyspace *top
 		// we got a valid result
 		break
 	}
+	if current[0].Path != "keyspaces/test_keyspace/SrvKeyspace" {
+		cancel()
+		t.Fatalf("got bad path: %v expected: keyspaces/test_keyspace/SrvKeyspace", current[0].Path)
+	}
+	checkWatchVersion(t, ctx, conn, current[0].Path, current[0].Version)
 	got := &topodatapb.SrvKeyspace{}
 	if err := got.UnmarshalVT(current[0].Contents); err != nil {
 		cancel()
@@ -112,6 +117,19 @@ func waitForInitialValueRecursive(t *testing.T, conn topo.Conn, srvKeyspace *top
 	return changes, cancel, nil
 }
 
+// checkWatchVersion checks that the version returned by a watch for filePath
+// is the one returned by Get, so that it can be used to update the file.
+func checkWatchVersion(t *testing.T, ctx context.Context, conn topo.Conn, filePath string, version topo.Version) {
+	t.Helper()
+	_, getVersion, err := conn.Get(ctx, filePath)
+	if err != nil {
+		t.Fatalf("Get(%v): %v", filePath, err)
+	}
+	if version.String() != getVersion.String() {
+		t.Fatalf("got version %v from the watch of %v, expected the version %v returned by Get", version, filePath, getVersion)
+	}
+}
+
 // checkWatch runs the tests on the Watch part of the Conn API.
 // We use a SrvKeyspace object.
 func checkWatch(t *testing.T, ctx context.Context, ts *topo.Server) {
@@ -183,6 +201,7 @@ func checkWatch(t *testing.T, ctx context.Context, ts *topo.Server) {
 		}
 		if got.Partitions[0].ShardReferences[0].Name == "new_name" {
 			// watch worked, good
+			checkWatchVersion(t, ctx, conn, "keyspaces/test_keyspace/SrvKeyspace", wd.Version)
 			break
 		}
 		t.Fatalf("got unknown SrvKeyspace: %v", got)
@@ -365,11 +384,56 @@ func checkWatchRecursive(t *testing.T, ctx context.Context, ts *topo.Server) {
 		}
 		if got.Partitions[0].ShardReferences[0].Name == "new_name" {
 			// watch worked, good
+			checkWatchVersion(t, ctx, conn, "keyspaces/test_keyspace/SrvKeyspace", wd.Version)
 			break
 		}
 		t.Fatalf("got unknown SrvKeyspace: %v", got)
 	}
 
+	// create and delete a file in a new sub-directory, and make sure
+	// we see both eventually. Other paths, like the new directory, may
+	// be seen in between.
+	subFile := "keyspaces/test_keyspace/sub/File"
+	subFileVersion, err := conn.Create(ctx, subFile, []byte("contents"))
+	if err != nil {
+		t.Fatalf("Create(%v): %v", subFile, err)
+	}
+	for {
+		wd, ok := <-changes
+		if !ok {
+			t.Fatalf("watch channel unexpectedly closed")
+		}
+		if wd.Path != subFile {
+			continue
+		}
+		if wd.Err != nil {
+			t.Fatalf("watch interrupted: %v", wd.Err)
+		}
+		if string(wd.Contents) != "contents" {
+			t.Fatalf("got unexpected contents for %v: %q", subFile, wd.Contents)
+		}
+		if wd.Version.String() != subFileVersion.String() {
+			t.Fatalf("got version %v for %v, expected the version %v returned by Create", wd.Version, subFile, subFileVersion)
+		}
+		break
+	}
+	if err := conn.Delete(ctx, subFile, nil); err != nil {
+		t.Fatalf("Delete(%v): %v", subFile, err)
+	}
+	for {
+		wd, ok := <-changes
+		if !ok {
+			t.Fatalf("watch channel unexpectedly closed")
+		}
+		if wd.Path != subFile {
+			continue
+		}
+		if !topo.IsErrType(wd.Err, topo.NoNode) {
+			t.Fatalf("got unexpected event for deleted %v: %v", subFile, wd)
+		}
+		break
+	}
+
 	// remove the SrvKeyspace
 	if err := ts.DeleteSrvKeyspace(ctx, LocalCellName, "test_keyspace"); err != nil {
 		t.Fatalf("DeleteSrvKeyspace: %v", err)
@@ -0,0 +1,268 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the ACLs of the topo: each process has an identity,
+// which should match the credentials it uses to connect to the topo server,
+// and is only permitted the verbs on the topo paths that the policy grants
+// it. The StatsConns enforce them, and log the mutations of the topology for
+// auditing.
+
+// The verbs of the topo ACLs.
+const (
+	// TopoVerbRead is for ListDir, Get, List and ListLocks.
+	TopoVerbRead = "read"
+	// TopoVerbWatch is for Watch and WatchRecursive.
+	TopoVerbWatch = "watch"
+	// TopoVerbWrite is for Create, Update and Delete.
+	TopoVerbWrite = "write"
+	// TopoVerbLock is for Lock, TryLock and ForceUnlock.
+	TopoVerbLock = "lock"
+	// TopoVerbElection is for NewLeaderParticipation, whose path is the name
+	// of the election.
+	TopoVerbElection = "election"
+	// TopoVerbAll grants all the verbs.
+	TopoVerbAll = "*"
+)
+
+var topoVerbs = []string{TopoVerbRead, TopoVerbWatch, TopoVerbWrite, TopoVerbLock, TopoVerbElection, TopoVerbAll}
+
+var (
+	topoACLFile        string
+	topoACLIdentity    string
+	topoAuditMutations bool
+
+	topoACLDenied = stats.NewCountersWithMultiLabels(
+		"TopologyACLDenied",
+		"Topology operations denied by the topo ACLs",
+		[]string{"Operation", "Cell"})
+)
+
+func init() {
+	for _, cmd := range FlagBinaries {
+		cmd := cmd
+		servenv.OnParseFor(cmd, func(fs *pflag.FlagSet) {
+			registerTopoACLFlags(fs, cmd)
+		})
+	}
+}
+
+func registerTopoACLFlags(fs *pflag.FlagSet, cmd string) {
+	fs.StringVar(&topoACLFile, "topo_acl_file", topoACLFile, "JSON file with the policy of the topo ACLs, which grants each identity verbs on topo paths. Everything is permitted if empty.")
+	fs.StringVar(&topoACLIdentity, "topo_acl_identity", cmd, "identity of this process in the topo ACLs and audit logs, which should match the credentials it uses to connect to the topo server")
+	fs.BoolVar(&topoAuditMutations, "topo_audit_mutations", topoAuditMutations, "log the changes to the topology, and the locks, made by this process, with its identity")
+}
+
+// TopoACLPolicy is the policy of the topo ACLs, as read from --topo_acl_file.
+type TopoACLPolicy struct {
+	Identities map[string]*TopoACLIdentity `json:"identities"`
+}
+
+// TopoACLIdentity holds the rules of an identity. An operation is permitted
+// if any rule permits it.
+type TopoACLIdentity struct {
+	Rules []*TopoACLRule `json:"rules"`
+}
+
+// TopoACLRule grants verbs on paths of the topo.
+type TopoACLRule struct {
+	// Cells are the cells the rule applies to, global for the global topo.
+	// It applies to all of them if empty.
+	Cells []string `json:"cells,omitempty"`
+	// Paths are patterns, as in path.Match, of the paths relative to the root
+	// of the cells. A pattern that matches a directory matches everything
+	// under it, and "/" matches all the paths.
+	Paths []string `json:"paths"`
+	// Verbs are the TopoVerbs granted.
+	Verbs []string `json:"verbs"`
+}
+
+// LoadTopoACLPolicy reads and validates a policy from a JSON file.
+func LoadTopoACLPolicy(file string) (*TopoACLPolicy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	policy := &TopoACLPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("cannot parse the topo ACL policy in %v: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid topo ACL policy in %v: %w", file, err)
+	}
+	return policy, nil
+}
+
+func (policy *TopoACLPolicy) validate() error {
+	for name, identity := range policy.Identities {
+		if identity == nil {
+			return fmt.Errorf("identity %v has no rules", name)
+		}
+		for _, rule := range identity.Rules {
+			for _, verb := range rule.Verbs {
+				if !slices.Contains(topoVerbs, verb) {
+					return fmt.Errorf("identity %v has unknown verb %q, must be one of %v", name, verb, strings.Join(topoVerbs, ", "))
+				}
+			}
+			for _, pattern := range rule.Paths {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("identity %v has bad path pattern %q: %w", name, pattern, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// TopoACL is what the StatsConns of a Server enforce and audit for the
+// identity of the process.
+type TopoACL struct {
+	identity string
+	// rules are the rules of the identity. All the operations are permitted
+	// if enforce is false.
+	rules   []*TopoACLRule
+	enforce bool
+	// audit is true to log the mutations.
+	audit bool
+}
+
+// NewTopoACL returns the TopoACL of an identity of the policy, which may be
+// nil to permit everything and only audit the mutations.
+func NewTopoACL(policy *TopoACLPolicy, identity string, audit bool) (*TopoACL, error) {
+	acl := &TopoACL{identity: identity, audit: audit}
+	if policy != nil {
+		rules, ok := policy.Identities[identity]
+		if !ok {
+			return nil, fmt.Errorf("identity %v is not in the topo ACL policy", identity)
+		}
+		acl.rules = rules.Rules
+		acl.enforce = true
+	}
+	return acl, nil
+}
+
+// SetTopoACL sets the ACL enforced by all the connections of the Server, or
+// removes it if acl is nil.
+func (ts *Server) SetTopoACL(acl *TopoACL) {
+	ts.topoACL.Store(acl)
+}
+
+// openTopoACL sets the ACL configured by the flags, if any.
+func (ts *Server) openTopoACL() error {
+	if topoACLFile == "" && !topoAuditMutations {
+		return nil
+	}
+	var policy *TopoACLPolicy
+	if topoACLFile != "" {
+		var err error
+		if policy, err = LoadTopoACLPolicy(topoACLFile); err != nil {
+			return err
+		}
+	}
+	acl, err := NewTopoACL(policy, topoACLIdentity, topoAuditMutations)
+	if err != nil {
+		return err
+	}
+	ts.SetTopoACL(acl)
+	return nil
+}
+
+// check returns a PERMISSION_DENIED error if the verb is not permitted on the
+// path in the cell.
+func (acl *TopoACL) check(verb, operation, cell, filePath string) error {
+	if acl == nil || !acl.enforce {
+		return nil
+	}
+	if cell == GlobalReadOnlyCell {
+		cell = GlobalCell
+	}
+	filePath = strings.Trim(path.Clean("/"+filePath), "/")
+	for _, rule := range acl.rules {
+		if rule.permits(verb, cell, filePath) {
+			return nil
+		}
+	}
+	topoACLDenied.Add([]string{operation, cell}, 1)
+	err := vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "topo identity %v is not permitted to %v %v in cell %v", acl.identity, verb, filePath, cell)
+	if verb == TopoVerbWrite || verb == TopoVerbLock {
+		acl.auditMutation(operation, cell, filePath, err)
+	}
+	return err
+}
+
+func (rule *TopoACLRule) permits(verb, cell, filePath string) bool {
+	if len(rule.Cells) > 0 && !slices.Contains(rule.Cells, cell) {
+		return false
+	}
+	if !slices.Contains(rule.Verbs, verb) && !slices.Contains(rule.Verbs, TopoVerbAll) {
+		return false
+	}
+	for _, pattern := range rule.Paths {
+		if matchTopoPath(strings.Trim(pattern, "/"), filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTopoPath returns true if the pattern matches the path, or one of its
+// parent directories.
+func matchTopoPath(pattern, filePath string) bool {
+	for {
+		if ok, _ := path.Match(pattern, filePath); ok {
+			return true
+		}
+		if filePath == "" {
+			return false
+		}
+		if i := strings.LastIndex(filePath, "/"); i >= 0 {
+			filePath = filePath[:i]
+		} else {
+			filePath = ""
+		}
+	}
+}
+
+// auditMutation logs a mutation of the topology, if enabled.
+func (acl *TopoACL) auditMutation(operation, cell, filePath string, err error) {
+	if acl == nil || !acl.audit {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+	log.Infof("Topo audit: identity=%v operation=%v cell=%v path=%v result=%q", acl.identity, operation, cell, filePath, result)
+}
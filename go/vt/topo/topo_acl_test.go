@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestMatchTopoPath(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		filePath string
+		want     bool
+	}{
+		{"", "keyspaces/ks/Keyspace", true},
+		{"keyspaces", "keyspaces/ks/shards/0/Shard", true},
+		{"keyspaces/*/Keyspace", "keyspaces/ks/Keyspace", true},
+		{"keyspaces/*/Keyspace", "keyspaces/ks/shards/0/Shard", false},
+		{"keyspaces/ks", "keyspaces/ks2/Keyspace", false},
+		{"tablets/zone1-*", "tablets/zone1-0000000100/Tablet", true},
+		{"tablets/zone1-*", "tablets", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchTopoPath(tt.pattern, tt.filePath), "%v %v", tt.pattern, tt.filePath)
+	}
+}
+
+func TestLoadTopoACLPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		file := path.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(contents), 0o644))
+		return file
+	}
+
+	policy, err := LoadTopoACLPolicy(write("ok.json", `{"identities": {"vtgate": {"rules": [{"paths": ["/"], "verbs": ["read", "watch"]}]}}}`))
+	require.NoError(t, err)
+	require.Contains(t, policy.Identities, "vtgate")
+
+	_, err = LoadTopoACLPolicy(write("verb.json", `{"identities": {"vtgate": {"rules": [{"paths": ["/"], "verbs": ["delete"]}]}}}`))
+	assert.ErrorContains(t, err, `identity vtgate has unknown verb "delete"`)
+
+	_, err = LoadTopoACLPolicy(write("pattern.json", `{"identities": {"vtgate": {"rules": [{"paths": ["["], "verbs": ["read"]}]}}}`))
+	assert.ErrorContains(t, err, `identity vtgate has bad path pattern "["`)
+
+	_, err = NewTopoACL(policy, "vttablet", false)
+	assert.ErrorContains(t, err, "identity vttablet is not in the topo ACL policy")
+}
+
+// TestStatsConnTopoACL checks that the StatsConns enforce the topo ACLs.
+func TestStatsConnTopoACL(t *testing.T) {
+	ctx := context.Background()
+	policy := &TopoACLPolicy{Identities: map[string]*TopoACLIdentity{
+		"vttablet": {Rules: []*TopoACLRule{
+			{Paths: []string{"/"}, Verbs: []string{TopoVerbRead, TopoVerbWatch}},
+			{Cells: []string{"zone1"}, Paths: []string{"tablets"}, Verbs: []string{TopoVerbAll}},
+			{Cells: []string{GlobalCell}, Paths: []string{"keyspaces/*"}, Verbs: []string{TopoVerbLock}},
+		}},
+	}}
+	acl, err := NewTopoACL(policy, "vttablet", true)
+	require.NoError(t, err)
+	var topoACL atomic.Pointer[TopoACL]
+	topoACL.Store(acl)
+
+	newConn := func(cell string) *StatsConn {
+		statsConn := NewStatsConn(cell, &fakeConn{})
+		statsConn.topoACL = &topoACL
+		return statsConn
+	}
+	zone1 := newConn("zone1")
+	global := newConn(GlobalCell)
+	globalReadOnly := newConn(GlobalReadOnlyCell)
+
+	assertDenied := func(err error) {
+		t.Helper()
+		assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err), err)
+	}
+
+	_, _, err = global.Get(ctx, "keyspaces/ks/Keyspace")
+	require.NoError(t, err)
+	_, _, err = globalReadOnly.Get(ctx, "keyspaces/ks/Keyspace")
+	require.NoError(t, err)
+	_, _, err = zone1.Watch(ctx, "SrvVSchema")
+	require.NoError(t, err)
+
+	_, err = zone1.Create(ctx, "tablets/zone1-0000000100/Tablet", nil)
+	require.NoError(t, err)
+	_, err = zone1.Update(ctx, "/tablets/zone1-0000000100/Tablet", nil, nil)
+	require.NoError(t, err)
+
+	deniedBefore := topoACLDenied.Counts()["Update.global"]
+	_, err = global.Update(ctx, "keyspaces/ks/Keyspace", nil, nil)
+	assertDenied(err)
+	assert.Equal(t, deniedBefore+1, topoACLDenied.Counts()["Update.global"])
+	_, err = newConn("zone2").Create(ctx, "tablets/zone2-0000000100/Tablet", nil)
+	assertDenied(err)
+	assertDenied(zone1.Delete(ctx, "SrvVSchema", nil))
+
+	_, err = global.Lock(ctx, "keyspaces/ks", "test")
+	require.NoError(t, err)
+	_, err = zone1.Lock(ctx, "keyspaces/ks", "test")
+	assertDenied(err)
+	_, err = global.NewLeaderParticipation("vtctld", "id")
+	assertDenied(err)
+
+	// Without an ACL, everything is permitted.
+	topoACL.Store(nil)
+	_, err = global.Update(ctx, "keyspaces/ks/Keyspace", nil, nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the read-only mode of the topology. While it is
+// enabled, the StatsConns of the Servers that watch it reject all the writes
+// to the topology, and the locks, with a READ_ONLY error. Reads are not
+// affected, so the components keep serving.
+
+var (
+	// topoReadOnlyWatchRetryDelay is how long the watch of the read-only
+	// mode waits before being restarted after an error.
+	topoReadOnlyWatchRetryDelay = 5 * time.Second
+
+	topoReadOnlyGauge = stats.NewGauge(
+		"TopologyReadOnly",
+		"Whether the topology is in read-only mode, in which case the changes to it are rejected")
+)
+
+// topoReadOnlyFilePath returns the path of the TopoReadOnly record in the
+// global topo.
+func topoReadOnlyFilePath() string {
+	return path.Join(TopoReadOnlyPath, TopoReadOnlyFile)
+}
+
+// isTopoReadOnlyPath returns true for the paths of the global topo that can
+// be written while the topology is in read-only mode, so that it can be
+// disabled.
+func isTopoReadOnlyPath(filePath string) bool {
+	filePath = strings.Trim(path.Clean(filePath), "/")
+	return filePath == TopoReadOnlyPath || strings.HasPrefix(filePath, TopoReadOnlyPath+"/")
+}
+
+// GetTopoReadOnly reads the read-only mode of the topology from the global
+// topo. It returns nil if it is not enabled.
+func (ts *Server) GetTopoReadOnly(ctx context.Context) (*topodatapb.TopoReadOnly, error) {
+	data, _, err := ts.globalCell.Get(ctx, topoReadOnlyFilePath())
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	mode := &topodatapb.TopoReadOnly{}
+	if err := mode.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrap(err, "bad TopoReadOnly data")
+	}
+	return mode, nil
+}
+
+// EnableTopoReadOnly puts the topology in read-only mode, for the given
+// reason. It applies to this process right away, and to the other ones as
+// soon as their watch sees the change.
+func (ts *Server) EnableTopoReadOnly(ctx context.Context, reason string) (*topodatapb.TopoReadOnly, error) {
+	if reason == "" {
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "a reason is required to put the topology in read-only mode")
+	}
+	mode := &topodatapb.TopoReadOnly{
+		Reason: reason,
+		Since:  protoutil.TimeToProto(time.Now()),
+	}
+	data, err := mode.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ts.globalCell.Update(ctx, topoReadOnlyFilePath(), data, nil); err != nil {
+		return nil, err
+	}
+	ts.setTopoReadOnly(mode)
+	return mode, nil
+}
+
+// DisableTopoReadOnly takes the topology out of read-only mode.
+func (ts *Server) DisableTopoReadOnly(ctx context.Context) error {
+	err := ts.globalCell.Delete(ctx, topoReadOnlyFilePath(), nil)
+	if err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+	ts.setTopoReadOnly(nil)
+	return nil
+}
+
+// StartTopoReadOnlyWatch starts watching the read-only mode of the topology
+// in the global topo, so that the writes of this process are rejected while
+// another process enabled it. Open calls it, and Close stops the watch.
+func (ts *Server) StartTopoReadOnlyWatch() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.cancelTopoReadOnlyWatch != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ts.cancelTopoReadOnlyWatch = cancel
+	go ts.watchTopoReadOnly(ctx)
+}
+
+// watchTopoReadOnly watches the directory of the TopoReadOnly record, which
+// works even if it doesn't exist, until ctx is canceled.
+func (ts *Server) watchTopoReadOnly(ctx context.Context) {
+	filePath := topoReadOnlyFilePath()
+	for {
+		current, changes, err := ts.globalCell.WatchRecursive(ctx, TopoReadOnlyPath)
+		if err != nil {
+			log.Warningf("Failed to watch the read-only mode of the topology, retrying in %v: %v", topoReadOnlyWatchRetryDelay, err)
+		} else {
+			var data []byte
+			for _, wd := range current {
+				if wd.Path == filePath {
+					data = wd.Contents
+				}
+			}
+			ts.setTopoReadOnlyData(data)
+
+			for wd := range changes {
+				switch {
+				case wd.Path != filePath:
+					if wd.Err != nil && !IsErrType(wd.Err, Interrupted) {
+						log.Warningf("The watch of the read-only mode of the topology failed, retrying in %v: %v", topoReadOnlyWatchRetryDelay, wd.Err)
+					}
+				case wd.Err == nil:
+					ts.setTopoReadOnlyData(wd.Contents)
+				case IsErrType(wd.Err, NoNode):
+					ts.setTopoReadOnlyData(nil)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(topoReadOnlyWatchRetryDelay):
+		}
+	}
+}
+
+// setTopoReadOnlyData sets the read-only mode from the contents of the
+// TopoReadOnly record, nil if it doesn't exist. Records that can't be parsed
+// are ignored.
+func (ts *Server) setTopoReadOnlyData(data []byte) {
+	if data == nil {
+		ts.setTopoReadOnly(nil)
+		return
+	}
+	mode := &topodatapb.TopoReadOnly{}
+	if err := mode.UnmarshalVT(data); err != nil {
+		log.Errorf("Ignoring bad TopoReadOnly data: %v", err)
+		return
+	}
+	ts.setTopoReadOnly(mode)
+}
+
+func (ts *Server) setTopoReadOnly(mode *topodatapb.TopoReadOnly) {
+	previous := ts.topoReadOnly.Swap(mode)
+	switch {
+	case mode == nil && previous != nil:
+		log.Infof("The topology is not in read-only mode anymore")
+		topoReadOnlyGauge.Set(0)
+	case mode != nil && !proto.Equal(mode, previous):
+		log.Warningf("The topology is in read-only mode since %v: %v", protoutil.TimeFromProto(mode.Since).UTC(), mode.Reason)
+		topoReadOnlyGauge.Set(1)
+	}
+}
+
+// IsTopoReadOnly returns the read-only mode of the topology, as last seen by
+// this process, or nil if it is not enabled.
+func (ts *Server) IsTopoReadOnly() *topodatapb.TopoReadOnly {
+	return ts.topoReadOnly.Load()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotests
+
+import (
+	"context"
+	"expvar"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+// This file contains tests for the cached_conn.go file.
+
+func cacheHits(cell string) int64 {
+	return expvar.Get("TopologyCacheHits").(*stats.CountersWithSingleLabel).Counts()[cell]
+}
+
+func TestCachedConn(t *testing.T) {
+	cell := "cached_conn_cell"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts, factory := memorytopo.NewServerAndFactory(ctx, cell)
+	defer ts.Close()
+
+	conn, err := factory.Create(cell, "", "")
+	require.NoError(t, err)
+	other, err := factory.Create(cell, "", "")
+	require.NoError(t, err)
+	defer other.Close()
+
+	cached := topo.NewCachedConn(cell, conn, topo.TabletsPath, func(filePath string) bool {
+		ok, _ := path.Match("tablets/*/Tablet", filePath)
+		return ok
+	})
+	defer cached.Close()
+
+	tablet1 := "tablets/cell-0000000001/Tablet"
+	version1, err := other.Create(ctx, tablet1, []byte("one"))
+	require.NoError(t, err)
+
+	// The first read goes through, and starts the watch.
+	contents, version, err := cached.Get(ctx, tablet1)
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(contents))
+	assert.Equal(t, version1, version)
+
+	// Once the watch runs, reads are served by the cache.
+	hits := cacheHits(cell)
+	require.Eventually(t, func() bool {
+		_, _, err := cached.Get(ctx, tablet1)
+		return err == nil && cacheHits(cell) > hits
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Changes made by other clients are seen through the watch.
+	_, err = other.Update(ctx, tablet1, []byte("two"), version1)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		contents, _, err := cached.Get(ctx, tablet1)
+		return err == nil && string(contents) == "two"
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Updates with the cached version work, so it is the real one.
+	_, version, err = cached.Get(ctx, tablet1)
+	require.NoError(t, err)
+	version2, err := cached.Update(ctx, tablet1, []byte("three"), version)
+	require.NoError(t, err)
+
+	// Our own writes are seen right away.
+	contents, version, err = cached.Get(ctx, tablet1)
+	require.NoError(t, err)
+	assert.Equal(t, "three", string(contents))
+	assert.Equal(t, version2, version)
+
+	// Updates with an old version fail.
+	_, err = cached.Update(ctx, tablet1, []byte("four"), version1)
+	assert.True(t, topo.IsErrType(err, topo.BadVersion), "unexpected error: %v", err)
+
+	// Deletions are seen too.
+	require.NoError(t, other.Delete(ctx, tablet1, nil))
+	require.Eventually(t, func() bool {
+		_, _, err := cached.Get(ctx, tablet1)
+		return topo.IsErrType(err, topo.NoNode)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Other paths are not cached.
+	_, err = cached.Create(ctx, "other/file", []byte("other"))
+	require.NoError(t, err)
+	hits = cacheHits(cell)
+	contents, _, err = cached.Get(ctx, "other/file")
+	require.NoError(t, err)
+	assert.Equal(t, "other", string(contents))
+	assert.Equal(t, hits, cacheHits(cell))
+}
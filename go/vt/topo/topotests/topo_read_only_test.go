@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestTopoReadOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts, factory := memorytopo.NewServerAndFactory(ctx, "cell1")
+	defer ts.Close()
+	ts.StartTopoReadOnlyWatch()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}))
+
+	// A second server of the same topo, which only learns about the
+	// read-only mode through its watch.
+	other, err := topo.NewWithFactory(factory, "", "")
+	require.NoError(t, err)
+	defer other.Close()
+	other.StartTopoReadOnlyWatch()
+
+	mode, err := ts.GetTopoReadOnly(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, mode)
+
+	_, err = ts.EnableTopoReadOnly(ctx, "")
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+
+	mode, err = ts.EnableTopoReadOnly(ctx, "topo migration")
+	require.NoError(t, err)
+	assert.Equal(t, "topo migration", mode.Reason)
+	assert.NotNil(t, ts.IsTopoReadOnly())
+
+	got, err := ts.GetTopoReadOnly(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "topo migration", got.Reason)
+
+	require.Eventually(t, func() bool {
+		return other.IsTopoReadOnly() != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Writes and locks are rejected, in the global topo and in the cells.
+	for _, server := range []*topo.Server{ts, other} {
+		err = server.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{})
+		assert.Equal(t, vtrpcpb.Code_READ_ONLY, vterrors.Code(err))
+		assert.ErrorContains(t, err, "read-only mode")
+		assert.ErrorContains(t, err, "topo migration")
+
+		err = server.CreateTablet(ctx, &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 100},
+			Keyspace: "ks1",
+			Shard:    "0",
+		})
+		assert.Equal(t, vtrpcpb.Code_READ_ONLY, vterrors.Code(err))
+
+		_, _, err = server.LockKeyspace(ctx, "ks1", "test")
+		assert.Equal(t, vtrpcpb.Code_READ_ONLY, vterrors.Code(err))
+	}
+
+	// Reads are not affected.
+	_, err = other.GetKeyspace(ctx, "ks1")
+	require.NoError(t, err)
+
+	// The mode can be disabled from any server.
+	require.NoError(t, other.DisableTopoReadOnly(ctx))
+	assert.Nil(t, other.IsTopoReadOnly())
+	require.Eventually(t, func() bool {
+		return ts.IsTopoReadOnly() == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{}))
+
+	// Disabling it again is a no-op.
+	require.NoError(t, ts.DisableTopoReadOnly(ctx))
+}
@@ -158,3 +158,38 @@ func (ts *Server) GetShardRoutingRules(ctx context.Context) (*vschemapb.ShardRou
 	}
 	return srr, nil
 }
+
+// SaveTenantRoutingRules saves the tenant routing rules into the topo.
+func (ts *Server) SaveTenantRoutingRules(ctx context.Context, tenantRoutingRules *vschemapb.TenantRoutingRules) error {
+	data, err := tenantRoutingRules.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, TenantRoutingRulesFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, TenantRoutingRulesFile, data, nil)
+	return err
+}
+
+// GetTenantRoutingRules fetches the tenant routing rules from the topo.
+func (ts *Server) GetTenantRoutingRules(ctx context.Context) (*vschemapb.TenantRoutingRules, error) {
+	trr := &vschemapb.TenantRoutingRules{}
+	data, _, err := ts.globalCell.Get(ctx, TenantRoutingRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return trr, nil
+		}
+		return nil, err
+	}
+	err = trr.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid tenant routing rules: %q", data)
+	}
+	return trr, nil
+}
@@ -200,7 +200,7 @@ func (mp *zkLeaderParticipation) GetCurrentLeaderID(ctx context.Context) (string
 
 // WaitForNewLeader is part of the topo.LeaderParticipation interface
 func (mp *zkLeaderParticipation) WaitForNewLeader(context.Context) (<-chan string, error) {
-	// This isn't implemented yet, but likely can be implemented in the same way
-	// as how WatchRecursive could be implemented as well.
+	// This isn't implemented yet, but likely can be implemented with children
+	// watches on the election path, like WatchRecursive.
 	return nil, topo.NewError(topo.NoImplementation, "wait for leader not supported in ZK2 topo")
 }
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"sort"
+	"strings"
 
 	"github.com/z-division/go-zookeeper/zk"
 
@@ -145,3 +147,71 @@ func (ld *zkLockDescriptor) Check(ctx context.Context) error {
 func (ld *zkLockDescriptor) Unlock(ctx context.Context) error {
 	return ld.zs.Delete(ctx, ld.nodePath, nil)
 }
+
+// ListLocks is part of the topo.Conn interface. The lock holders are the
+// sequential nodes in the locks directories, and hold the lock in the order
+// of their sequence numbers.
+func (zs *Server) ListLocks(ctx context.Context, dirPath string) ([]*topo.LockHolder, error) {
+	var holders []*topo.LockHolder
+	if err := zs.listLocks(ctx, strings.Trim(path.Clean(dirPath), "/"), &holders); err != nil {
+		return nil, err
+	}
+	return holders, nil
+}
+
+// listLocks appends the lock holders of dirPath and of the directories under
+// it to holders.
+func (zs *Server) listLocks(ctx context.Context, dirPath string, holders *[]*topo.LockHolder) error {
+	zkPath := path.Join(zs.root, dirPath)
+	children, _, err := zs.conn.Children(ctx, zkPath)
+	switch {
+	case err == zk.ErrNoNode && dirPath != "":
+		// The directory was deleted since its parent was listed.
+		return nil
+	case err != nil:
+		return convertError(err, zkPath)
+	}
+	sort.Strings(children)
+
+	for _, child := range children {
+		if child != locksPath {
+			if err := zs.listLocks(ctx, path.Join(dirPath, child), holders); err != nil {
+				return err
+			}
+			continue
+		}
+
+		locksDir := path.Join(zkPath, locksPath)
+		nodes, _, err := zs.conn.Children(ctx, locksDir)
+		switch {
+		case err == zk.ErrNoNode:
+			continue
+		case err != nil:
+			return convertError(err, locksDir)
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			data, _, err := zs.conn.Get(ctx, path.Join(locksDir, node))
+			switch {
+			case err == zk.ErrNoNode:
+				// The lock was released since it was listed.
+				continue
+			case err != nil:
+				return convertError(err, path.Join(locksDir, node))
+			}
+			*holders = append(*holders, &topo.LockHolder{
+				Path:     dirPath,
+				ID:       node,
+				Contents: string(data),
+				Held:     len(*holders) == 0 || (*holders)[len(*holders)-1].Path != dirPath,
+			})
+		}
+	}
+	return nil
+}
+
+// ForceUnlock is part of the topo.Conn interface. It deletes the sequential
+// node of the lock holder, like its Unlock would.
+func (zs *Server) ForceUnlock(ctx context.Context, dirPath, id string) error {
+	return zs.Delete(ctx, path.Join(dirPath, locksPath, id), nil)
+}
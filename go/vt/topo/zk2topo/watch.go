@@ -20,9 +20,13 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strings"
+
+	"github.com/z-division/go-zookeeper/zk"
 
 	"vitess.io/vitess/go/vt/vterrors"
 
+	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo"
 )
 
@@ -97,9 +101,254 @@ func (zs *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData,
 }
 
 // WatchRecursive is part of the topo.Conn interface.
-func (zs *Server) WatchRecursive(_ context.Context, path string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
-	// This isn't implemented yet, but potentially can be implemented if we want
-	// to update the minimum ZooKeeper requirement to 3.6.0 and use recursive watches.
-	// Also see https://zookeeper.apache.org/doc/r3.6.3/zookeeperProgrammers.html#sc_WatchPersistentRecursive
-	return nil, nil, topo.NewError(topo.NoImplementation, path)
+//
+// ZooKeeper watches only cover one node, and fire once, so the whole subtree
+// is watched with a data watch and a children watch on each of its nodes,
+// which are set again each time they fire. Persistent recursive watches
+// would need ZooKeeper 3.6, and a client that supports them.
+//
+// As for ListDir, the nodes without children are files. Locks and elections
+// are skipped. A node that gets children, like a directory seen before its
+// first file is created, is reported as deleted.
+func (zs *Server) WatchRecursive(ctx context.Context, dirPath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
+	// The watch context stops the goroutines that wait for the ZooKeeper
+	// watches to fire.
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	w := &zkRecursiveWatch{
+		zs:     zs,
+		root:   path.Join(zs.root, dirPath),
+		ctx:    watchCtx,
+		events: make(chan zkRecursiveEvent),
+		nodes:  make(map[string]*zkWatchedNode),
+	}
+
+	var initial []*topo.WatchDataRecursive
+	if err := w.addNode(w.root, &initial); err != nil {
+		watchCancel()
+		return nil, nil, convertError(err, w.root)
+	}
+
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+		defer watchCancel()
+
+		for {
+			var changes []*topo.WatchDataRecursive
+			var err error
+			select {
+			case <-watchCtx.Done():
+				err = topo.NewError(topo.Interrupted, "watch")
+			case ev := <-w.events:
+				err = w.handle(ev, &changes)
+			}
+			for _, wd := range changes {
+				notifications <- wd
+			}
+			if err != nil {
+				notifications <- &topo.WatchDataRecursive{WatchData: topo.WatchData{Err: err}}
+				return
+			}
+		}
+	}()
+
+	return initial, notifications, nil
+}
+
+// zkRecursiveWatch holds the state of a WatchRecursive. Its nodes are only
+// used by the goroutine that handles the events, once initialized.
+type zkRecursiveWatch struct {
+	zs     *Server
+	root   string
+	ctx    context.Context
+	events chan zkRecursiveEvent
+	nodes  map[string]*zkWatchedNode
+}
+
+type zkWatchedNode struct {
+	file    bool
+	skipped bool
+}
+
+// zkRecursiveEvent is an event of the ZooKeeper watch of a node.
+type zkRecursiveEvent struct {
+	path  string
+	event zk.Event
+}
+
+// forward sends the event of a ZooKeeper watch to the events channel.
+func (w *zkRecursiveWatch) forward(zkPath string, watch <-chan zk.Event) {
+	go func() {
+		select {
+		case event := <-watch:
+			select {
+			case w.events <- zkRecursiveEvent{path: zkPath, event: event}:
+			case <-w.ctx.Done():
+			}
+		case <-w.ctx.Done():
+		}
+	}()
+}
+
+// relativePath returns the path of a node relative to the root of the cell.
+func (w *zkRecursiveWatch) relativePath(zkPath string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(zkPath, path.Clean(w.zs.root)), "/")
+}
+
+// addNode watches a node and its descendants, and appends the files found to
+// changes. If the root doesn't exist, it waits for its creation.
+func (w *zkRecursiveWatch) addNode(zkPath string, changes *[]*topo.WatchDataRecursive) error {
+	children, _, childrenWatch, err := w.zs.conn.ChildrenW(w.ctx, zkPath)
+	switch {
+	case err == zk.ErrNoNode && zkPath == w.root:
+		exists, _, existsWatch, err := w.zs.conn.ExistsW(w.ctx, zkPath)
+		if err != nil {
+			return err
+		}
+		w.forward(zkPath, existsWatch)
+		if exists {
+			// Created in the meantime.
+			return w.addNode(zkPath, changes)
+		}
+		return nil
+	case err == zk.ErrNoNode:
+		// Deleted in the meantime.
+		return nil
+	case err != nil:
+		return err
+	}
+	w.forward(zkPath, childrenWatch)
+
+	data, stat, dataWatch, err := w.zs.conn.GetW(w.ctx, zkPath)
+	switch {
+	case err == zk.ErrNoNode:
+		// Deleted in the meantime, the children watch fires.
+		return nil
+	case err != nil:
+		return err
+	}
+	w.forward(zkPath, dataWatch)
+
+	node := &zkWatchedNode{
+		file: zkPath != w.root && len(children) == 0,
+		// Skip the locks and elections, and their directories.
+		skipped: stat.EphemeralOwner != 0 || path.Base(zkPath) == locksPath || zkPath == path.Join(w.zs.root, electionsPath),
+	}
+	w.nodes[zkPath] = node
+	if node.skipped {
+		return nil
+	}
+	if node.file {
+		*changes = append(*changes, &topo.WatchDataRecursive{
+			Path: w.relativePath(zkPath),
+			WatchData: topo.WatchData{
+				Contents: data,
+				Version:  ZKVersion(stat.Version),
+			},
+		})
+	}
+	for _, child := range children {
+		if err := w.addNode(path.Join(zkPath, child), changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeNode stops tracking a deleted node and its descendants, and appends
+// the deleted files to changes. Their ZooKeeper watches fire on deletion.
+func (w *zkRecursiveWatch) removeNode(zkPath string, changes *[]*topo.WatchDataRecursive) {
+	for p, node := range w.nodes {
+		if p != zkPath && !strings.HasPrefix(p, zkPath+"/") {
+			continue
+		}
+		delete(w.nodes, p)
+		if node.file && !node.skipped {
+			*changes = append(*changes, w.deleted(p))
+		}
+	}
+}
+
+func (w *zkRecursiveWatch) deleted(zkPath string) *topo.WatchDataRecursive {
+	return &topo.WatchDataRecursive{
+		Path:      w.relativePath(zkPath),
+		WatchData: topo.WatchData{Err: topo.NewError(topo.NoNode, zkPath)},
+	}
+}
+
+// handle processes the event of the watch of a node, and appends the
+// resulting changes. It returns an error if the watch can't go on.
+func (w *zkRecursiveWatch) handle(ev zkRecursiveEvent, changes *[]*topo.WatchDataRecursive) error {
+	if ev.event.Err != nil {
+		return vterrors.Wrapf(ev.event.Err, "received a non-OK event for %v", ev.path)
+	}
+
+	node, ok := w.nodes[ev.path]
+	switch ev.event.Type {
+	case zk.EventNodeCreated:
+		// The root was created.
+		if !ok {
+			return convertError(w.addNode(ev.path, changes), ev.path)
+		}
+	case zk.EventNodeDeleted:
+		// Both watches of the node fire.
+		if !ok {
+			return nil
+		}
+		w.removeNode(ev.path, changes)
+		if ev.path == w.root {
+			// Wait for it to be created again.
+			return convertError(w.addNode(ev.path, changes), ev.path)
+		}
+	case zk.EventNodeDataChanged:
+		if !ok || node.skipped {
+			return nil
+		}
+		data, stat, dataWatch, err := w.zs.conn.GetW(w.ctx, ev.path)
+		switch {
+		case err == zk.ErrNoNode:
+			return nil
+		case err != nil:
+			return convertError(err, ev.path)
+		}
+		w.forward(ev.path, dataWatch)
+		if node.file {
+			*changes = append(*changes, &topo.WatchDataRecursive{
+				Path: w.relativePath(ev.path),
+				WatchData: topo.WatchData{
+					Contents: data,
+					Version:  ZKVersion(stat.Version),
+				},
+			})
+		}
+	case zk.EventNodeChildrenChanged:
+		if !ok || node.skipped {
+			return nil
+		}
+		children, _, childrenWatch, err := w.zs.conn.ChildrenW(w.ctx, ev.path)
+		switch {
+		case err == zk.ErrNoNode:
+			return nil
+		case err != nil:
+			return convertError(err, ev.path)
+		}
+		w.forward(ev.path, childrenWatch)
+		if node.file && len(children) > 0 {
+			node.file = false
+			*changes = append(*changes, w.deleted(ev.path))
+		}
+		// The deleted children are removed by their own watches.
+		for _, child := range children {
+			childPath := path.Join(ev.path, child)
+			if _, ok := w.nodes[childPath]; !ok {
+				if err := w.addNode(childPath, changes); err != nil {
+					return convertError(err, childPath)
+				}
+			}
+		}
+	default:
+		// EventNotWatching: the watches were lost with the session.
+		return vterrors.Errorf(vtrpc.Code_UNAVAILABLE, "watch on %v was lost: %v", ev.path, ev.event.Type)
+	}
+	return nil
 }
@@ -0,0 +1,447 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+// This file contains the garbage collection of the orphaned records of the
+// topo, which long-lived clusters accumulate, e.g. when a shard is deleted
+// while some of its tablets are down.
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
+)
+
+// topoOrphan is an orphaned record, and how to remove it.
+type topoOrphan struct {
+	*vtctldatapb.TopoOrphan
+	remove func(ctx context.Context) error
+}
+
+// id identifies the record in the TopoGCState.
+func (o *topoOrphan) id() string {
+	return fmt.Sprintf("%v:%v:%v", o.Kind, o.Cell, o.Name)
+}
+
+// TopoGC finds the orphaned records of the global topo and of the given
+// cells, or of all the cells if none is given, and removes the ones that were
+// first found at least minAge ago, unless dryRun is set.
+//
+// When each record was first found is stored in the global topo, so that the
+// records that are only orphaned for a short while, like the record of a
+// tablet created right before its shard, are not removed. The SrvVSchemas are
+// rebuilt if routing rules were removed.
+func TopoGC(ctx context.Context, ts *topo.Server, cells []string, minAge time.Duration, dryRun bool) (*vtctldatapb.TopoGCResponse, error) {
+	allCells, err := ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(cells) == 0 {
+		cells = allCells
+	}
+	for _, cell := range cells {
+		if !slices.Contains(allCells, cell) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "cell %v does not exist", cell)
+		}
+	}
+
+	shards, err := existingShards(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []*topoOrphan
+	for _, cell := range cells {
+		cellOrphans, err := findCellOrphans(ctx, ts, cell, shards)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot check the topo of cell %v", cell)
+		}
+		orphans = append(orphans, cellOrphans...)
+	}
+	ruleOrphans, err := findRoutingRuleOrphans(ctx, ts, shards)
+	if err != nil {
+		return nil, err
+	}
+	orphans = append(orphans, ruleOrphans...)
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Kind != orphans[j].Kind {
+			return orphans[i].Kind < orphans[j].Kind
+		}
+		if orphans[i].Cell != orphans[j].Cell {
+			return orphans[i].Cell < orphans[j].Cell
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+
+	state, version, err := getTopoGCState(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	newState := &topodatapb.TopoGCState{FirstFound: map[string]*vttimepb.Time{}}
+	resp := &vtctldatapb.TopoGCResponse{}
+	for _, o := range orphans {
+		firstFound, ok := state.FirstFound[o.id()]
+		if !ok {
+			firstFound = protoutil.TimeToProto(now)
+		}
+		o.FirstFound = firstFound
+		o.Removed = now.Sub(protoutil.TimeFromProto(firstFound)) >= minAge
+		newState.FirstFound[o.id()] = firstFound
+		resp.Orphans = append(resp.Orphans, o.TopoOrphan)
+	}
+	if dryRun {
+		return resp, nil
+	}
+
+	// The state is saved first, so that the records that can't be removed
+	// below are remembered. The ones that are removed are forgotten the next
+	// time, since they are not found anymore.
+	if err := saveTopoGCState(ctx, ts, newState, version); err != nil {
+		return nil, err
+	}
+
+	var rebuildSrvVSchema bool
+	for i, o := range orphans {
+		if !o.Removed {
+			continue
+		}
+		if err := o.remove(ctx); err != nil && !topo.IsErrType(err, topo.NoNode) {
+			for _, o := range orphans[i:] {
+				o.Removed = false
+			}
+			return resp, vterrors.Wrapf(err, "cannot remove %v %v in cell %v", o.Kind, o.Name, o.Cell)
+		}
+		log.Infof("TopoGC removed %v %v in cell %v: %v", o.Kind, o.Name, o.Cell, o.Reason)
+		switch o.Kind {
+		case vtctldatapb.TopoOrphan_ROUTING_RULE, vtctldatapb.TopoOrphan_SHARD_ROUTING_RULE:
+			rebuildSrvVSchema = true
+		}
+	}
+	if rebuildSrvVSchema {
+		if err := ts.RebuildSrvVSchema(ctx, nil); err != nil {
+			return resp, vterrors.Wrap(err, "cannot rebuild the SrvVSchemas after removing routing rules")
+		}
+	}
+	return resp, nil
+}
+
+// existingShards returns the shards of each keyspace.
+func existingShards(ctx context.Context, ts *topo.Server) (map[string]map[string]bool, error) {
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[string]map[string]bool, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		names, err := ts.GetShardNames(ctx, keyspace)
+		if err != nil && !topo.IsErrType(err, topo.NoNode) {
+			return nil, err
+		}
+		shards[keyspace] = make(map[string]bool, len(names))
+		for _, name := range names {
+			shards[keyspace][name] = true
+		}
+	}
+	return shards, nil
+}
+
+// findCellOrphans finds the tablets, replication graphs and serving graphs of
+// a cell that belong to keyspaces or shards that don't exist.
+func findCellOrphans(ctx context.Context, ts *topo.Server, cell string, shards map[string]map[string]bool) ([]*topoOrphan, error) {
+	var orphans []*topoOrphan
+
+	tablets, err := ts.GetTabletsByCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	tabletsByAlias := make(map[string]*topodatapb.Tablet, len(tablets))
+	for _, ti := range tablets {
+		alias := ti.Alias
+		tabletsByAlias[topoproto.TabletAliasString(alias)] = ti.Tablet
+		if ti.Keyspace == "" || shards[ti.Keyspace][ti.Shard] {
+			continue
+		}
+		orphans = append(orphans, &topoOrphan{
+			TopoOrphan: &vtctldatapb.TopoOrphan{
+				Kind:   vtctldatapb.TopoOrphan_TABLET,
+				Cell:   cell,
+				Name:   topoproto.TabletAliasString(alias),
+				Reason: fmt.Sprintf("shard %v/%v does not exist", ti.Keyspace, ti.Shard),
+			},
+			remove: func(ctx context.Context) error {
+				return ts.DeleteTablet(ctx, alias)
+			},
+		})
+	}
+
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	keyspaces, err := listDirNames(ctx, conn, topo.KeyspacesPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyspace := range keyspaces {
+		keyspace := keyspace
+		files, err := listDirNames(ctx, conn, path.Join(topo.KeyspacesPath, keyspace))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := shards[keyspace]; !ok && slices.Contains(files, topo.SrvKeyspaceFile) {
+			orphans = append(orphans, &topoOrphan{
+				TopoOrphan: &vtctldatapb.TopoOrphan{
+					Kind:   vtctldatapb.TopoOrphan_SRV_KEYSPACE,
+					Cell:   cell,
+					Name:   keyspace,
+					Reason: fmt.Sprintf("keyspace %v does not exist", keyspace),
+				},
+				remove: func(ctx context.Context) error {
+					return ts.DeleteSrvKeyspace(ctx, cell, keyspace)
+				},
+			})
+		}
+
+		shardNames, err := listDirNames(ctx, conn, path.Join(topo.KeyspacesPath, keyspace, topo.ShardsPath))
+		if err != nil {
+			return nil, err
+		}
+		for _, shard := range shardNames {
+			shard := shard
+			sri, err := ts.GetShardReplication(ctx, cell, keyspace, shard)
+			switch {
+			case topo.IsErrType(err, topo.NoNode):
+				continue
+			case err != nil:
+				return nil, err
+			}
+
+			if !shards[keyspace][shard] {
+				orphans = append(orphans, &topoOrphan{
+					TopoOrphan: &vtctldatapb.TopoOrphan{
+						Kind:   vtctldatapb.TopoOrphan_SHARD_REPLICATION,
+						Cell:   cell,
+						Name:   keyspace + "/" + shard,
+						Reason: fmt.Sprintf("shard %v/%v does not exist", keyspace, shard),
+					},
+					remove: func(ctx context.Context) error {
+						return ts.DeleteShardReplication(ctx, cell, keyspace, shard)
+					},
+				})
+				continue
+			}
+
+			for _, node := range sri.Nodes {
+				alias := node.TabletAlias
+				aliasStr := topoproto.TabletAliasString(alias)
+				var reason string
+				switch tablet := tabletsByAlias[aliasStr]; {
+				case tablet == nil:
+					reason = fmt.Sprintf("tablet %v does not exist", aliasStr)
+				case tablet.Keyspace != keyspace || tablet.Shard != shard:
+					reason = fmt.Sprintf("tablet %v is in shard %v/%v", aliasStr, tablet.Keyspace, tablet.Shard)
+				default:
+					continue
+				}
+				orphans = append(orphans, &topoOrphan{
+					TopoOrphan: &vtctldatapb.TopoOrphan{
+						Kind:   vtctldatapb.TopoOrphan_SHARD_REPLICATION_NODE,
+						Cell:   cell,
+						Name:   keyspace + "/" + shard + "/" + aliasStr,
+						Reason: reason,
+					},
+					remove: func(ctx context.Context) error {
+						return topo.RemoveShardReplicationRecord(ctx, ts, cell, keyspace, shard, alias)
+					},
+				})
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// findRoutingRuleOrphans finds the routing rules and shard routing rules that
+// refer to keyspaces that don't exist.
+func findRoutingRuleOrphans(ctx context.Context, ts *topo.Server, shards map[string]map[string]bool) ([]*topoOrphan, error) {
+	var orphans []*topoOrphan
+
+	rules, err := ts.GetRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules.Rules {
+		fromTable := rule.FromTable
+		for _, toTable := range rule.ToTables {
+			keyspace, _, ok := strings.Cut(toTable, ".")
+			if !ok {
+				continue
+			}
+			keyspace, _, _ = strings.Cut(keyspace, "@")
+			if _, ok := shards[keyspace]; ok {
+				continue
+			}
+			orphans = append(orphans, &topoOrphan{
+				TopoOrphan: &vtctldatapb.TopoOrphan{
+					Kind:   vtctldatapb.TopoOrphan_ROUTING_RULE,
+					Cell:   topo.GlobalCell,
+					Name:   fromTable,
+					Reason: fmt.Sprintf("keyspace %v does not exist", keyspace),
+				},
+				remove: func(ctx context.Context) error {
+					return removeRoutingRule(ctx, ts, fromTable)
+				},
+			})
+			break
+		}
+	}
+
+	shardRules, err := ts.GetShardRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range shardRules.Rules {
+		rule := rule
+		var missing []string
+		for _, keyspace := range []string{rule.FromKeyspace, rule.ToKeyspace} {
+			if _, ok := shards[keyspace]; !ok && !slices.Contains(missing, keyspace) {
+				missing = append(missing, keyspace)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		orphans = append(orphans, &topoOrphan{
+			TopoOrphan: &vtctldatapb.TopoOrphan{
+				Kind:   vtctldatapb.TopoOrphan_SHARD_ROUTING_RULE,
+				Cell:   topo.GlobalCell,
+				Name:   rule.FromKeyspace + "." + rule.Shard,
+				Reason: fmt.Sprintf("keyspace %v does not exist", strings.Join(missing, " and ")),
+			},
+			remove: func(ctx context.Context) error {
+				return removeShardRoutingRule(ctx, ts, rule.FromKeyspace, rule.Shard)
+			},
+		})
+	}
+	return orphans, nil
+}
+
+func removeRoutingRule(ctx context.Context, ts *topo.Server, fromTable string) error {
+	rules, err := ts.GetRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	rules.Rules = slices.DeleteFunc(rules.Rules, func(rule *vschemapb.RoutingRule) bool {
+		return rule.FromTable == fromTable
+	})
+	return ts.SaveRoutingRules(ctx, rules)
+}
+
+func removeShardRoutingRule(ctx context.Context, ts *topo.Server, fromKeyspace, shard string) error {
+	rules, err := ts.GetShardRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	rules.Rules = slices.DeleteFunc(rules.Rules, func(rule *vschemapb.ShardRoutingRule) bool {
+		return rule.FromKeyspace == fromKeyspace && rule.Shard == shard
+	})
+	return ts.SaveShardRoutingRules(ctx, rules)
+}
+
+// listDirNames returns the names of the entries of a directory, or nothing if
+// it doesn't exist.
+func listDirNames(ctx context.Context, conn topo.Conn, dirPath string) ([]string, error) {
+	entries, err := conn.ListDir(ctx, dirPath, false /* full */)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return topo.DirEntriesToStringArray(entries), nil
+}
+
+func topoGCStatePath() string {
+	return path.Join(topo.TopoGCPath, topo.TopoGCStateFile)
+}
+
+// getTopoGCState reads the TopoGCState, which is empty if it doesn't exist,
+// in which case the version is nil.
+func getTopoGCState(ctx context.Context, ts *topo.Server) (*topodatapb.TopoGCState, topo.Version, error) {
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, version, err := conn.Get(ctx, topoGCStatePath())
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return &topodatapb.TopoGCState{}, nil, nil
+	case err != nil:
+		return nil, nil, err
+	}
+	state := &topodatapb.TopoGCState{}
+	if err := state.UnmarshalVT(data); err != nil {
+		return nil, nil, vterrors.Wrap(err, "bad TopoGCState data")
+	}
+	return state, version, nil
+}
+
+// saveTopoGCState writes the TopoGCState if it is still at version, and
+// deletes it if it is empty.
+func saveTopoGCState(ctx context.Context, ts *topo.Server, state *topodatapb.TopoGCState, version topo.Version) error {
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(state.FirstFound) == 0 && version == nil:
+		return nil
+	case len(state.FirstFound) == 0:
+		err = conn.Delete(ctx, topoGCStatePath(), version)
+	default:
+		data, merr := state.MarshalVT()
+		if merr != nil {
+			return merr
+		}
+		if version == nil {
+			_, err = conn.Create(ctx, topoGCStatePath(), data)
+		} else {
+			_, err = conn.Update(ctx, topoGCStatePath(), data, version)
+		}
+	}
+	if topo.IsErrType(err, topo.NodeExists) || topo.IsErrType(err, topo.BadVersion) || topo.IsErrType(err, topo.NoNode) {
+		return vterrors.Errorf(vtrpcpb.Code_ABORTED, "another TopoGC ran concurrently, try again: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestTopoGC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "ks",
+		Shard:    "0",
+	}))
+
+	// The orphaned records: a tablet of a shard that doesn't exist, along with
+	// the replication graph of the shard, an entry of the replication graph
+	// for a tablet that doesn't exist, the serving graph of a keyspace that
+	// doesn't exist, and routing rules to it.
+	orphanedTablet := &topodatapb.TabletAlias{Cell: "zone1", Uid: 200}
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    orphanedTablet,
+		Keyspace: "ks",
+		Shard:    "-80",
+	}))
+	require.NoError(t, topo.UpdateShardReplicationRecord(ctx, ts, "ks", "0", &topodatapb.TabletAlias{Cell: "zone1", Uid: 300}))
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "zone1", "gone", &topodatapb.SrvKeyspace{}))
+	require.NoError(t, ts.SaveRoutingRules(ctx, &vschemapb.RoutingRules{Rules: []*vschemapb.RoutingRule{
+		{FromTable: "t1", ToTables: []string{"gone.t1"}},
+		{FromTable: "t2", ToTables: []string{"ks.t2"}},
+	}}))
+	require.NoError(t, ts.SaveShardRoutingRules(ctx, &vschemapb.ShardRoutingRules{Rules: []*vschemapb.ShardRoutingRule{
+		{FromKeyspace: "gone", ToKeyspace: "ks", Shard: "0"},
+	}}))
+
+	orphans := func(resp *vtctldatapb.TopoGCResponse) []string {
+		var names []string
+		for _, orphan := range resp.Orphans {
+			names = append(names, orphan.Kind.String()+":"+orphan.Cell+":"+orphan.Name)
+		}
+		return names
+	}
+	expected := []string{
+		"TABLET:zone1:zone1-0000000200",
+		"SHARD_REPLICATION:zone1:ks/-80",
+		"SHARD_REPLICATION_NODE:zone1:ks/0/zone1-0000000300",
+		"SRV_KEYSPACE:zone1:gone",
+		"ROUTING_RULE:global:t1",
+		"SHARD_ROUTING_RULE:global:gone.0",
+	}
+
+	_, err := TopoGC(ctx, ts, []string{"zone2"}, 0, true)
+	assert.ErrorContains(t, err, "cell zone2 does not exist")
+
+	// A dry run changes nothing.
+	resp, err := TopoGC(ctx, ts, nil, 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, expected, orphans(resp))
+	for _, orphan := range resp.Orphans {
+		assert.True(t, orphan.Removed, orphan.Name)
+	}
+	_, err = ts.GetTablet(ctx, orphanedTablet)
+	require.NoError(t, err)
+	state, version, err := getTopoGCState(ctx, ts)
+	require.NoError(t, err)
+	assert.Nil(t, version)
+	assert.Empty(t, state.FirstFound)
+
+	// Records found for the first time are not removed, but remembered.
+	resp, err = TopoGC(ctx, ts, nil, time.Hour, false)
+	require.NoError(t, err)
+	assert.Equal(t, expected, orphans(resp))
+	firstFound := resp.Orphans[0].FirstFound
+	for _, orphan := range resp.Orphans {
+		assert.False(t, orphan.Removed, orphan.Name)
+	}
+	_, err = ts.GetTablet(ctx, orphanedTablet)
+	require.NoError(t, err)
+
+	resp, err = TopoGC(ctx, ts, nil, time.Hour, false)
+	require.NoError(t, err)
+	assert.Equal(t, firstFound, resp.Orphans[0].FirstFound)
+	assert.False(t, resp.Orphans[0].Removed)
+
+	// Records found long enough ago are removed.
+	resp, err = TopoGC(ctx, ts, nil, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, expected, orphans(resp))
+	for _, orphan := range resp.Orphans {
+		assert.True(t, orphan.Removed, orphan.Name)
+	}
+
+	_, err = ts.GetTablet(ctx, orphanedTablet)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), err)
+	_, err = ts.GetShardReplication(ctx, "zone1", "ks", "-80")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), err)
+	sri, err := ts.GetShardReplication(ctx, "zone1", "ks", "0")
+	require.NoError(t, err)
+	require.Len(t, sri.Nodes, 1)
+	assert.EqualValues(t, 100, sri.Nodes[0].TabletAlias.Uid)
+	_, err = ts.GetSrvKeyspace(ctx, "zone1", "gone")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), err)
+	rules, err := ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 1)
+	assert.Equal(t, "t2", rules.Rules[0].FromTable)
+	shardRules, err := ts.GetShardRoutingRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, shardRules.Rules)
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	assert.Len(t, srvVSchema.RoutingRules.Rules, 1)
+
+	// Once there is nothing left, the state is deleted.
+	resp, err = TopoGC(ctx, ts, nil, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Orphans)
+	state, version, err = getTopoGCState(ctx, ts)
+	require.NoError(t, err)
+	assert.Nil(t, version)
+	assert.Empty(t, state.FirstFound)
+}
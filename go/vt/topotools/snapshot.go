@@ -0,0 +1,331 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+// This file contains the functions to take and restore snapshots of the
+// whole topo, for disaster recovery.
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// TopoSnapshotFormatVersion is the version of the format of the snapshots
+// taken by SnapshotTopo. RestoreTopoSnapshot refuses snapshots of a more
+// recent format.
+const TopoSnapshotFormatVersion = 1
+
+// maxReportedConflicts is the number of conflicting files listed in the
+// error returned when the restore is refused because of conflicts.
+const maxReportedConflicts = 10
+
+// SnapshotTopo reads all the files of the global topo and of the given cells,
+// or of all the cells if none is given. Ephemeral files and directories, like
+// locks and elections, are skipped.
+//
+// Files are read one at a time, so the snapshot is not a point in time view
+// of the topo if it changes while the snapshot is taken.
+func SnapshotTopo(ctx context.Context, ts *topo.Server, cells []string) (*vtctldatapb.TopoSnapshot, error) {
+	allCells, err := ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(cells) == 0 {
+		cells = allCells
+	}
+	for _, cell := range cells {
+		if !slices.Contains(allCells, cell) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "cell %v does not exist", cell)
+		}
+	}
+
+	snapshot := &vtctldatapb.TopoSnapshot{
+		FormatVersion: TopoSnapshotFormatVersion,
+		Time:          protoutil.TimeToProto(time.Now()),
+	}
+	for _, cell := range append([]string{topo.GlobalCell}, cells...) {
+		conn, err := ts.ConnForCell(ctx, cell)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot connect to the topo of cell %v", cell)
+		}
+		files, err := snapshotTopoDir(ctx, conn, cell, "/")
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot read the topo of cell %v", cell)
+		}
+		snapshot.Files = append(snapshot.Files, files...)
+	}
+	return snapshot, nil
+}
+
+func snapshotTopoDir(ctx context.Context, conn topo.Conn, cell string, dir string) ([]*vtctldatapb.TopoSnapshotFile, error) {
+	entries, err := conn.ListDir(ctx, dir, true /* full */)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		// The directory was deleted, or the cell is empty.
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var files []*vtctldatapb.TopoSnapshotFile
+	for _, entry := range entries {
+		filePath := path.Join(dir, entry.Name)
+		switch {
+		case entry.Ephemeral:
+			continue
+		case entry.Type == topo.TypeDirectory:
+			dirFiles, err := snapshotTopoDir(ctx, conn, cell, filePath)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, dirFiles...)
+		default:
+			contents, version, err := conn.Get(ctx, filePath)
+			switch {
+			case topo.IsErrType(err, topo.NoNode):
+				continue
+			case err != nil:
+				return nil, err
+			}
+			files = append(files, &vtctldatapb.TopoSnapshotFile{
+				Cell:     cell,
+				Path:     strings.TrimPrefix(filePath, "/"),
+				Contents: contents,
+				Version:  version.String(),
+			})
+		}
+	}
+	return files, nil
+}
+
+// topoRestoreAction is what RestoreTopoSnapshot does with a file of the
+// snapshot.
+type topoRestoreAction struct {
+	file *vtctldatapb.TopoSnapshotFile
+	// exists is true if the file is in the topo, at version.
+	exists  bool
+	version topo.Version
+	// unchanged is true if the file in the topo has the contents of the
+	// snapshot.
+	unchanged bool
+}
+
+// topoRestorePlan holds the actions of the files of a cell.
+type topoRestorePlan struct {
+	cell    string
+	conn    topo.Conn
+	actions []*topoRestoreAction
+}
+
+// RestoreTopoSnapshot writes the files of a snapshot taken by SnapshotTopo:
+// the ones of the global topo, and the ones of the given cells, or of all the
+// cells of the snapshot if none is given, unless globalOnly is set.
+//
+// Files that exist with different contents are handled according to the
+// conflict policy. With FAIL, all the files are checked before any is
+// written, except for the files of the cells that don't exist before the
+// restore, which are checked once the global topo, and so their CellInfo, is
+// restored.
+func RestoreTopoSnapshot(ctx context.Context, ts *topo.Server, snapshot *vtctldatapb.TopoSnapshot, cells []string, globalOnly bool, policy vtctldatapb.TopoRestoreRequest_ConflictPolicy, dryRun bool) (*vtctldatapb.TopoRestoreResponse, error) {
+	switch {
+	case snapshot == nil || snapshot.FormatVersion == 0:
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "not a topo snapshot")
+	case snapshot.FormatVersion > TopoSnapshotFormatVersion:
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the topo snapshot has format version %d, only versions up to %d are supported", snapshot.FormatVersion, TopoSnapshotFormatVersion)
+	}
+
+	// Group the files by cell, the global topo first.
+	filesByCell := map[string][]*vtctldatapb.TopoSnapshotFile{}
+	snapshotCells := []string{}
+	for _, file := range snapshot.Files {
+		if file.Cell != topo.GlobalCell && !slices.Contains(snapshotCells, file.Cell) {
+			snapshotCells = append(snapshotCells, file.Cell)
+		}
+		filesByCell[file.Cell] = append(filesByCell[file.Cell], file)
+	}
+	switch {
+	case globalOnly:
+		cells = nil
+	case len(cells) == 0:
+		cells = snapshotCells
+	default:
+		for _, cell := range cells {
+			if !slices.Contains(snapshotCells, cell) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the topo snapshot has no file of cell %v", cell)
+			}
+		}
+	}
+
+	resp := &vtctldatapb.TopoRestoreResponse{}
+
+	// Plan the restore of the global topo and of the existing cells.
+	var plans []*topoRestorePlan
+	var newCells []string
+	for _, cell := range append([]string{topo.GlobalCell}, cells...) {
+		plan, err := planTopoRestore(ctx, ts, cell, filesByCell[cell])
+		switch {
+		case cell != topo.GlobalCell && topo.IsErrType(err, topo.NoNode):
+			newCells = append(newCells, cell)
+			continue
+		case err != nil:
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	if err := checkTopoRestoreConflicts(plans, policy); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		for _, plan := range plans {
+			plan.report(resp, policy)
+		}
+		// The new cells are empty, as far as we can tell before their
+		// CellInfo is restored.
+		for _, cell := range newCells {
+			resp.Created += uint32(len(filesByCell[cell]))
+		}
+		return resp, nil
+	}
+
+	for _, plan := range plans {
+		if err := plan.apply(ctx, resp, policy); err != nil {
+			return resp, err
+		}
+	}
+
+	// Restore the cells whose CellInfo was just restored.
+	for _, cell := range newCells {
+		plan, err := planTopoRestore(ctx, ts, cell, filesByCell[cell])
+		if err != nil {
+			return resp, err
+		}
+		if err := checkTopoRestoreConflicts([]*topoRestorePlan{plan}, policy); err != nil {
+			return resp, err
+		}
+		if err := plan.apply(ctx, resp, policy); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// planTopoRestore reads the current state of the files of a cell. It returns
+// a NoNode error if the cell does not exist.
+func planTopoRestore(ctx context.Context, ts *topo.Server, cell string, files []*vtctldatapb.TopoSnapshotFile) (*topoRestorePlan, error) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &topoRestorePlan{cell: cell, conn: conn}
+	for _, file := range files {
+		action := &topoRestoreAction{file: file}
+		contents, version, err := conn.Get(ctx, file.Path)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+		case err != nil:
+			return nil, vterrors.Wrapf(err, "cannot read %v in cell %v", file.Path, cell)
+		default:
+			action.exists = true
+			action.version = version
+			action.unchanged = bytes.Equal(contents, file.Contents)
+		}
+		plan.actions = append(plan.actions, action)
+	}
+	return plan, nil
+}
+
+// checkTopoRestoreConflicts returns an error listing the conflicting files if
+// the policy is FAIL.
+func checkTopoRestoreConflicts(plans []*topoRestorePlan, policy vtctldatapb.TopoRestoreRequest_ConflictPolicy) error {
+	if policy != vtctldatapb.TopoRestoreRequest_FAIL {
+		return nil
+	}
+
+	var conflicts []string
+	for _, plan := range plans {
+		for _, action := range plan.actions {
+			if action.exists && !action.unchanged {
+				conflicts = append(conflicts, plan.cell+":"+action.file.Path)
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	count := len(conflicts)
+	if count > maxReportedConflicts {
+		conflicts = append(conflicts[:maxReportedConflicts], "...")
+	}
+	return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%d files of the topo snapshot exist with different contents, nothing was restored: %v", count, strings.Join(conflicts, ", "))
+}
+
+// report counts the actions of the plan in resp, without applying them.
+func (plan *topoRestorePlan) report(resp *vtctldatapb.TopoRestoreResponse, policy vtctldatapb.TopoRestoreRequest_ConflictPolicy) {
+	for _, action := range plan.actions {
+		plan.count(resp, action, policy)
+	}
+}
+
+func (plan *topoRestorePlan) count(resp *vtctldatapb.TopoRestoreResponse, action *topoRestoreAction, policy vtctldatapb.TopoRestoreRequest_ConflictPolicy) {
+	switch {
+	case !action.exists:
+		resp.Created++
+	case action.unchanged:
+		resp.Unchanged++
+	default:
+		resp.Conflicts = append(resp.Conflicts, plan.cell+":"+action.file.Path)
+		if policy == vtctldatapb.TopoRestoreRequest_OVERWRITE {
+			resp.Overwritten++
+		} else {
+			resp.Skipped++
+		}
+	}
+}
+
+// apply writes the files of the plan. Existing files are only overwritten if
+// they are still at the version that was read when planning, so that
+// concurrent changes are not lost.
+func (plan *topoRestorePlan) apply(ctx context.Context, resp *vtctldatapb.TopoRestoreResponse, policy vtctldatapb.TopoRestoreRequest_ConflictPolicy) error {
+	for _, action := range plan.actions {
+		var err error
+		switch {
+		case !action.exists:
+			_, err = plan.conn.Create(ctx, action.file.Path, action.file.Contents)
+		case action.unchanged || policy != vtctldatapb.TopoRestoreRequest_OVERWRITE:
+		default:
+			_, err = plan.conn.Update(ctx, action.file.Path, action.file.Contents, action.version)
+		}
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot restore %v in cell %v", action.file.Path, plan.cell)
+		}
+		plan.count(resp, action, policy)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestTopoSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := memorytopo.NewServer(ctx, "zone1", "zone2")
+	defer src.Close()
+	require.NoError(t, src.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, src.CreateShard(ctx, "ks", "0"))
+	tabletAlias := &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}
+	require.NoError(t, src.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    tabletAlias,
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_REPLICA,
+	}))
+
+	_, err := SnapshotTopo(ctx, src, []string{"zone3"})
+	assert.ErrorContains(t, err, "cell zone3 does not exist")
+
+	snapshot, err := SnapshotTopo(ctx, src, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, TopoSnapshotFormatVersion, snapshot.FormatVersion)
+	var files []string
+	for _, file := range snapshot.Files {
+		files = append(files, file.Cell+":"+file.Path)
+	}
+	assert.Subset(t, files, []string{
+		"global:cells/zone1/CellInfo",
+		"global:cells/zone2/CellInfo",
+		"global:keyspaces/ks/Keyspace",
+		"global:keyspaces/ks/shards/0/Shard",
+		"zone1:tablets/zone1-0000000100/Tablet",
+	})
+
+	dst := memorytopo.NewServer(ctx, "zone1", "zone2")
+	defer dst.Close()
+
+	// The CellInfo records of the new topo are the same as the ones of the
+	// snapshot.
+	resp, err := RestoreTopoSnapshot(ctx, dst, snapshot, nil, false, vtctldatapb.TopoRestoreRequest_FAIL, true /* dryRun */)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(snapshot.Files)-2, resp.Created)
+	assert.EqualValues(t, 2, resp.Unchanged)
+	_, err = dst.GetKeyspace(ctx, "ks")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "dry run restored the keyspace: %v", err)
+
+	resp, err = RestoreTopoSnapshot(ctx, dst, snapshot, nil, false, vtctldatapb.TopoRestoreRequest_FAIL, false /* dryRun */)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(snapshot.Files)-2, resp.Created)
+	_, err = dst.GetShard(ctx, "ks", "0")
+	require.NoError(t, err)
+	tablet, err := dst.GetTablet(ctx, tabletAlias)
+	require.NoError(t, err)
+	assert.Equal(t, topodatapb.TabletType_REPLICA, tablet.Type)
+
+	resp, err = RestoreTopoSnapshot(ctx, dst, snapshot, nil, false, vtctldatapb.TopoRestoreRequest_FAIL, false /* dryRun */)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(snapshot.Files), resp.Unchanged)
+
+	// Conflicting files.
+	ki, err := dst.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	ki.DurabilityPolicy = "semi_sync"
+	lockCtx, unlock, err := dst.LockKeyspace(ctx, "ks", "TestTopoSnapshot")
+	require.NoError(t, err)
+	err = dst.UpdateKeyspace(lockCtx, ki)
+	unlock(&err)
+	require.NoError(t, err)
+
+	_, err = RestoreTopoSnapshot(ctx, dst, snapshot, nil, false, vtctldatapb.TopoRestoreRequest_FAIL, false /* dryRun */)
+	assert.ErrorContains(t, err, "1 files of the topo snapshot exist with different contents, nothing was restored: global:keyspaces/ks/Keyspace")
+
+	resp, err = RestoreTopoSnapshot(ctx, dst, snapshot, nil, true /* globalOnly */, vtctldatapb.TopoRestoreRequest_SKIP, false /* dryRun */)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.Skipped)
+	assert.Equal(t, []string{"global:keyspaces/ks/Keyspace"}, resp.Conflicts)
+	ki, err = dst.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	assert.Equal(t, "semi_sync", ki.DurabilityPolicy)
+
+	resp, err = RestoreTopoSnapshot(ctx, dst, snapshot, []string{"zone1"}, false, vtctldatapb.TopoRestoreRequest_OVERWRITE, false /* dryRun */)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.Overwritten)
+	ki, err = dst.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	assert.Empty(t, ki.DurabilityPolicy)
+
+	_, err = RestoreTopoSnapshot(ctx, dst, snapshot, []string{"zone3"}, false, vtctldatapb.TopoRestoreRequest_FAIL, false /* dryRun */)
+	assert.ErrorContains(t, err, "the topo snapshot has no file of cell zone3")
+
+	snapshot.FormatVersion = TopoSnapshotFormatVersion + 1
+	_, err = RestoreTopoSnapshot(ctx, dst, snapshot, nil, false, vtctldatapb.TopoRestoreRequest_FAIL, false /* dryRun */)
+	assert.ErrorContains(t, err, "only versions up to 1 are supported")
+}
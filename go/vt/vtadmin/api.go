@@ -32,12 +32,14 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/patrickmn/go-cache"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sets"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtadmin/audit"
 	"vitess.io/vitess/go/vt/vtadmin/cluster"
 	"vitess.io/vitess/go/vt/vtadmin/cluster/dynamic"
 	"vitess.io/vitess/go/vt/vtadmin/errors"
@@ -46,12 +48,15 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/http/debug"
 	"vitess.io/vitess/go/vt/vtadmin/http/experimental"
 	vthandlers "vitess.io/vitess/go/vt/vtadmin/http/handlers"
+	"vitess.io/vitess/go/vt/vtadmin/internal/schemadrift"
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
+	"vitess.io/vitess/go/vt/vtadmin/savedqueries"
 	"vitess.io/vitess/go/vt/vtadmin/sort"
 	"vitess.io/vitess/go/vt/vtadmin/vtadminproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtexplain"
 
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -67,6 +72,9 @@ type API struct {
 	clusters     []*cluster.Cluster
 	clusterMap   map[string]*cluster.Cluster
 	clusterCache *cache.Cache
+	auditLog     *audit.Log
+	savedQueries *savedqueries.Store
+	reports      *savedqueries.Scheduler
 	serv         *grpcserver.Server
 	router       *mux.Router
 
@@ -85,6 +93,15 @@ type Options struct {
 	GRPCOpts grpcserver.Options
 	HTTPOpts vtadminhttp.Options
 	RBAC     *rbac.Config
+	// AuditLog records mutating calls to the API. If nil, the API records them
+	// in a default, in-memory audit log.
+	AuditLog *audit.Log
+	// SavedQueries stores the saved queries of the API. If nil, the API keeps
+	// them in memory, and they are lost on restart.
+	SavedQueries *savedqueries.Store
+	// ReportOpts configures the scheduled runs of saved queries, and the
+	// delivery of their reports.
+	ReportOpts savedqueries.Options
 	// EnableDynamicClusters makes it so that clients can pass clusters dynamically
 	// in a session-like way, either via HTTP cookies or gRPC metadata.
 	EnableDynamicClusters bool
@@ -134,12 +151,28 @@ func NewAPI(clusters []*cluster.Cluster, opts Options) *API {
 		})
 	}
 
+	if opts.AuditLog == nil {
+		opts.AuditLog, _ = audit.New(audit.Options{})
+	}
+
+	// Audit events record the actor, so this must run after authentication.
+	opts.GRPCOpts.UnaryInterceptors = append(opts.GRPCOpts.UnaryInterceptors, audit.UnaryServerInterceptor(opts.AuditLog))
+	opts.HTTPOpts.AuditLog = opts.AuditLog
+
+	if opts.SavedQueries == nil {
+		opts.SavedQueries, _ = savedqueries.NewStore("")
+	}
+
 	api := &API{
-		clusters:   clusters,
-		clusterMap: clusterMap,
-		authz:      authz,
+		clusters:     clusters,
+		clusterMap:   clusterMap,
+		auditLog:     opts.AuditLog,
+		savedQueries: opts.SavedQueries,
+		authz:        authz,
 	}
 
+	api.reports = savedqueries.NewScheduler(opts.SavedQueries, api.runScheduledQuery, opts.ReportOpts)
+
 	if opts.EnableDynamicClusters {
 		api.clusterCache = cache.New(24*time.Hour, 24*time.Hour)
 		api.clusterCache.OnEvicted(api.EjectDynamicCluster)
@@ -215,13 +248,15 @@ func NewAPI(clusters []*cluster.Cluster, opts Options) *API {
 
 	router.Use(middlewares...)
 
+	api.reports.Start()
+
 	return api
 }
 
-// Close closes all the clusters in an API concurrently. Its primary function is
-// to gracefully shutdown cache background goroutines to avoid data races in
-// tests, but needs to be exported to be called by those tests. It does not have
-// any production use case.
+// Close closes all the clusters in an API concurrently, and stops running
+// scheduled queries. Its primary function is to gracefully shutdown cache
+// background goroutines to avoid data races in tests, but needs to be exported
+// to be called by those tests. It does not have any production use case.
 func (api *API) Close() error {
 	var (
 		wg  sync.WaitGroup
@@ -236,6 +271,10 @@ func (api *API) Close() error {
 		}(c)
 	}
 
+	if api.reports != nil {
+		api.reports.Close()
+	}
+
 	wg.Wait()
 	return rec.Error()
 }
@@ -292,10 +331,13 @@ func (api *API) WithCluster(c *cluster.Cluster, id string) dynamic.API {
 	defer api.clusterMu.Unlock()
 
 	dynamicAPI := &API{
-		router:  api.router,
-		serv:    api.serv,
-		authz:   api.authz,
-		options: api.options,
+		router:       api.router,
+		serv:         api.serv,
+		auditLog:     api.auditLog,
+		savedQueries: api.savedQueries,
+		reports:      api.reports,
+		authz:        api.authz,
+		options:      api.options,
 	}
 
 	if c != nil {
@@ -350,11 +392,14 @@ func (api *API) Handler() http.Handler {
 
 	httpAPI := vtadminhttp.NewAPI(api, api.options.HTTPOpts)
 
+	router.HandleFunc("/audit_events", httpAPI.Adapt(vtadminhttp.GetAuditEvents)).Name("API.GetAuditEvents")
 	router.HandleFunc("/backups", httpAPI.Adapt(vtadminhttp.GetBackups)).Name("API.GetBackups")
 	router.HandleFunc("/cells", httpAPI.Adapt(vtadminhttp.GetCellInfos)).Name("API.GetCellInfos")
 	router.HandleFunc("/cells_aliases", httpAPI.Adapt(vtadminhttp.GetCellsAliases)).Name("API.GetCellsAliases")
 	router.HandleFunc("/clusters", httpAPI.Adapt(vtadminhttp.GetClusters)).Name("API.GetClusters")
+	router.HandleFunc("/cluster/{cluster_id}/graph", httpAPI.Adapt(vtadminhttp.GetClusterGraph)).Name("API.GetClusterGraph")
 	router.HandleFunc("/cluster/{cluster_id}/topology", httpAPI.Adapt(vtadminhttp.GetTopologyPath)).Name("API.GetTopologyPath")
+	router.HandleFunc("/cluster_metrics", httpAPI.Adapt(vtadminhttp.GetClusterMetrics)).Name("API.GetClusterMetrics")
 	router.HandleFunc("/cluster/{cluster_id}/validate", httpAPI.Adapt(vtadminhttp.Validate)).Name("API.Validate").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/gates", httpAPI.Adapt(vtadminhttp.GetGates)).Name("API.GetGates")
 	router.HandleFunc("/keyspace/{cluster_id}", httpAPI.Adapt(vtadminhttp.CreateKeyspace)).Name("API.CreateKeyspace").Methods("POST")
@@ -366,8 +411,27 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/keyspace/{cluster_id}/{name}/validate/schema", httpAPI.Adapt(vtadminhttp.ValidateSchemaKeyspace)).Name("API.ValidateSchemaKeyspace").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}/validate/version", httpAPI.Adapt(vtadminhttp.ValidateVersionKeyspace)).Name("API.ValidateVersionKeyspace").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/keyspaces", httpAPI.Adapt(vtadminhttp.GetKeyspaces)).Name("API.GetKeyspaces")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.ApplySchema)).Name("API.ApplySchema").Methods("POST")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/cancel", httpAPI.Adapt(vtadminhttp.CancelSchemaMigration)).Name("API.CancelSchemaMigration").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/cleanup", httpAPI.Adapt(vtadminhttp.CleanupSchemaMigration)).Name("API.CleanupSchemaMigration").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/complete", httpAPI.Adapt(vtadminhttp.CompleteSchemaMigration)).Name("API.CompleteSchemaMigration").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/launch", httpAPI.Adapt(vtadminhttp.LaunchSchemaMigration)).Name("API.LaunchSchemaMigration").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/retry", httpAPI.Adapt(vtadminhttp.RetrySchemaMigration)).Name("API.RetrySchemaMigration").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/movetables/{cluster_id}", httpAPI.Adapt(vtadminhttp.MoveTablesCreate)).Name("API.MoveTablesCreate").Methods("POST")
+	router.HandleFunc("/movetables/{cluster_id}/validate", httpAPI.Adapt(vtadminhttp.ValidateMoveTablesCreate)).Name("API.ValidateMoveTablesCreate").Methods("POST")
+	router.HandleFunc("/migrations", httpAPI.Adapt(vtadminhttp.GetSchemaMigrations)).Name("API.GetSchemaMigrations")
+	router.HandleFunc("/query/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.ExecuteQuery)).Name("API.ExecuteQuery").Methods("POST")
+	router.HandleFunc("/reshard/{cluster_id}", httpAPI.Adapt(vtadminhttp.ReshardCreate)).Name("API.ReshardCreate").Methods("POST")
+	router.HandleFunc("/reshard/{cluster_id}/validate", httpAPI.Adapt(vtadminhttp.ValidateReshardCreate)).Name("API.ValidateReshardCreate").Methods("POST")
+	router.HandleFunc("/reshard/{cluster_id}/{keyspace}/recommend", httpAPI.Adapt(vtadminhttp.ReshardRecommend)).Name("API.ReshardRecommend").Methods("GET")
+	router.HandleFunc("/saved_queries", httpAPI.Adapt(vtadminhttp.GetSavedQueries)).Name("API.GetSavedQueries").Methods("GET")
+	router.HandleFunc("/saved_query/{cluster_id}", httpAPI.Adapt(vtadminhttp.CreateSavedQuery)).Name("API.CreateSavedQuery").Methods("POST")
+	router.HandleFunc("/saved_query/{cluster_id}/{id}", httpAPI.Adapt(vtadminhttp.DeleteSavedQuery)).Name("API.DeleteSavedQuery").Methods("DELETE")
+	router.HandleFunc("/saved_query/{cluster_id}/{id}/run", httpAPI.Adapt(vtadminhttp.RunSavedQuery)).Name("API.RunSavedQuery").Methods("POST")
 	router.HandleFunc("/schema/{table}", httpAPI.Adapt(vtadminhttp.FindSchema)).Name("API.FindSchema")
 	router.HandleFunc("/schema/{cluster_id}/{keyspace}/{table}", httpAPI.Adapt(vtadminhttp.GetSchema)).Name("API.GetSchema")
+	router.HandleFunc("/schema_diff", httpAPI.Adapt(vtadminhttp.DiffSchemas)).Name("API.DiffSchemas").Methods("GET")
+	router.HandleFunc("/schema_drift/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.GetSchemaDrift)).Name("API.GetSchemaDrift").Methods("GET")
 	router.HandleFunc("/schemas", httpAPI.Adapt(vtadminhttp.GetSchemas)).Name("API.GetSchemas")
 	router.HandleFunc("/schemas/reload", httpAPI.Adapt(vtadminhttp.ReloadSchemas)).Name("API.ReloadSchemas").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/shard/{cluster_id}/{keyspace}/{shard}/emergency_failover", httpAPI.Adapt(vtadminhttp.EmergencyFailoverShard)).Name("API.EmergencyFailoverShard").Methods("POST")
@@ -385,6 +449,7 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/tablets", httpAPI.Adapt(vtadminhttp.GetTablets)).Name("API.GetTablets")
 	router.HandleFunc("/tablet/{tablet}", httpAPI.Adapt(vtadminhttp.GetTablet)).Name("API.GetTablet").Methods("GET")
 	router.HandleFunc("/tablet/{tablet}", httpAPI.Adapt(vtadminhttp.DeleteTablet)).Name("API.DeleteTablet").Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/tablet/{tablet}/drain", httpAPI.Adapt(vtadminhttp.DrainTablet)).Name("API.DrainTablet").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tablet/{tablet}/full_status", httpAPI.Adapt(vtadminhttp.GetFullStatus)).Name("API.GetFullStatus").Methods("GET")
 	router.HandleFunc("/tablet/{tablet}/healthcheck", httpAPI.Adapt(vtadminhttp.RunHealthCheck)).Name("API.RunHealthCheck")
 	router.HandleFunc("/tablet/{tablet}/ping", httpAPI.Adapt(vtadminhttp.PingTablet)).Name("API.PingTablet")
@@ -395,12 +460,15 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/tablet/{tablet}/set_read_write", httpAPI.Adapt(vtadminhttp.SetReadWrite)).Name("API.SetReadWrite").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tablet/{tablet}/start_replication", httpAPI.Adapt(vtadminhttp.StartReplication)).Name("API.StartReplication").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tablet/{tablet}/stop_replication", httpAPI.Adapt(vtadminhttp.StopReplication)).Name("API.StopReplication").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/tablet/{tablet}/undrain", httpAPI.Adapt(vtadminhttp.UndrainTablet)).Name("API.UndrainTablet").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tablet/{tablet}/externally_promoted", httpAPI.Adapt(vtadminhttp.TabletExternallyPromoted)).Name("API.TabletExternallyPromoted").Methods("POST")
 	router.HandleFunc("/vschema/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.GetVSchema)).Name("API.GetVSchema")
 	router.HandleFunc("/vschemas", httpAPI.Adapt(vtadminhttp.GetVSchemas)).Name("API.GetVSchemas")
 	router.HandleFunc("/vtctlds", httpAPI.Adapt(vtadminhttp.GetVtctlds)).Name("API.GetVtctlds")
 	router.HandleFunc("/vtexplain", httpAPI.Adapt(vtadminhttp.VTExplain)).Name("API.VTExplain")
 	router.HandleFunc("/workflow/{cluster_id}/{keyspace}/{name}", httpAPI.Adapt(vtadminhttp.GetWorkflow)).Name("API.GetWorkflow")
+	router.HandleFunc("/workflow/{cluster_id}/{keyspace}/{name}/complete", httpAPI.Adapt(vtadminhttp.MoveTablesComplete)).Name("API.MoveTablesComplete").Methods("PUT")
+	router.HandleFunc("/workflow/{cluster_id}/{keyspace}/{name}/switch_traffic", httpAPI.Adapt(vtadminhttp.WorkflowSwitchTraffic)).Name("API.WorkflowSwitchTraffic").Methods("PUT")
 	router.HandleFunc("/workflows", httpAPI.Adapt(vtadminhttp.GetWorkflows)).Name("API.GetWorkflows")
 
 	experimentalRouter := router.PathPrefix("/experimental").Subrouter()
@@ -432,6 +500,82 @@ func (api *API) EjectDynamicCluster(key string, value any) {
 	api.clusters = append(api.clusters[:clusterIndex], api.clusters[clusterIndex+1:]...)
 }
 
+// ApplySchema is part of the vtadminpb.VTAdminServer interface.
+func (api *API) ApplySchema(ctx context.Context, req *vtadminpb.ApplySchemaRequest) (*vtctldatapb.ApplySchemaResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.ApplySchema")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaMigrationResource, rbac.CreateAction) {
+		return nil, fmt.Errorf("%w: cannot apply schema in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ApplySchema(ctx, req.Options)
+}
+
+// CancelSchemaMigration is part of the vtadminpb.VTAdminServer interface.
+func (api *API) CancelSchemaMigration(ctx context.Context, req *vtadminpb.CancelSchemaMigrationRequest) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.CancelSchemaMigration")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaMigrationResource, rbac.CancelSchemaMigrationAction) {
+		return nil, fmt.Errorf("%w: cannot cancel schema migration in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CancelSchemaMigration(ctx, req.Options)
+}
+
+// CleanupSchemaMigration is part of the vtadminpb.VTAdminServer interface.
+func (api *API) CleanupSchemaMigration(ctx context.Context, req *vtadminpb.CleanupSchemaMigrationRequest) (*vtctldatapb.CleanupSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.CleanupSchemaMigration")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaMigrationResource, rbac.CleanupSchemaMigrationAction) {
+		return nil, fmt.Errorf("%w: cannot cleanup schema migration in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CleanupSchemaMigration(ctx, req.Options)
+}
+
+// CompleteSchemaMigration is part of the vtadminpb.VTAdminServer interface.
+func (api *API) CompleteSchemaMigration(ctx context.Context, req *vtadminpb.CompleteSchemaMigrationRequest) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.CompleteSchemaMigration")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaMigrationResource, rbac.CompleteSchemaMigrationAction) {
+		return nil, fmt.Errorf("%w: cannot complete schema migration in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CompleteSchemaMigration(ctx, req.Options)
+}
+
 // CreateKeyspace is part of the vtadminpb.VTAdminServer interface.
 func (api *API) CreateKeyspace(ctx context.Context, req *vtadminpb.CreateKeyspaceRequest) (*vtadminpb.CreateKeyspaceResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.CreateKeyspace")
@@ -458,6 +602,49 @@ func (api *API) CreateKeyspace(ctx context.Context, req *vtadminpb.CreateKeyspac
 	}, nil
 }
 
+// CreateSavedQuery is part of the vtadminpb.VTAdminServer interface.
+//
+// Saving a query also requires permission to run queries in the cluster, since
+// it may then be run on a schedule.
+func (api *API) CreateSavedQuery(ctx context.Context, req *vtadminpb.CreateSavedQueryRequest) (*vtadminpb.SavedQuery, error) {
+	span, ctx := trace.NewSpan(ctx, "API.CreateSavedQuery")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SavedQueryResource, rbac.CreateAction) ||
+		!api.authz.IsAuthorized(ctx, req.ClusterId, rbac.QueryResource, rbac.ExecuteQueryAction) {
+		return nil, fmt.Errorf("%w: cannot save query in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SavedQuery == nil {
+		return nil, fmt.Errorf("%w: saved query is required", errors.ErrInvalidRequest)
+	}
+
+	q := req.SavedQuery.CloneVT()
+	q.ClusterId = c.ID
+	q.Owner = ""
+	q.OwnerRoles = nil
+	q.CreatedAt = protoutil.TimeToProto(time.Now())
+	q.LastRun = nil
+
+	if actor, ok := rbac.FromContext(ctx); ok && actor != nil {
+		q.Owner = actor.Name
+		q.OwnerRoles = sets.List(sets.New[string](actor.Roles...).Insert(actor.ClusterRoles[c.ID]...))
+	}
+
+	if err := api.reports.Validate(q); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrInvalidRequest, err)
+	}
+
+	return api.savedQueries.Create(q)
+}
+
 // CreateShard is part of the vtadminpb.VTAdminServer interface.
 func (api *API) CreateShard(ctx context.Context, req *vtadminpb.CreateShardRequest) (*vtctldatapb.CreateShardResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.CreateShard")
@@ -496,6 +683,34 @@ func (api *API) DeleteKeyspace(ctx context.Context, req *vtadminpb.DeleteKeyspac
 	return c.DeleteKeyspace(ctx, req.Options)
 }
 
+// DeleteSavedQuery is part of the vtadminpb.VTAdminServer interface.
+func (api *API) DeleteSavedQuery(ctx context.Context, req *vtadminpb.DeleteSavedQueryRequest) (*vtadminpb.DeleteSavedQueryResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.DeleteSavedQuery")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+	span.Annotate("id", req.Id)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SavedQueryResource, rbac.DeleteAction) {
+		return nil, fmt.Errorf("%w: cannot delete saved query in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	if _, err := api.getClusterForRequest(req.ClusterId); err != nil {
+		return nil, err
+	}
+
+	deleted, err := api.savedQueries.Delete(req.ClusterId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !deleted {
+		return nil, fmt.Errorf("%w: %s in %s", errors.ErrNoSavedQuery, req.Id, req.ClusterId)
+	}
+
+	return &vtadminpb.DeleteSavedQueryResponse{}, nil
+}
+
 // DeleteShards is part of the vtadminpb.VTAdminServer interface.
 func (api *API) DeleteShards(ctx context.Context, req *vtadminpb.DeleteShardsRequest) (*vtctldatapb.DeleteShardsResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.DeleteShards")
@@ -538,6 +753,74 @@ func (api *API) DeleteTablet(ctx context.Context, req *vtadminpb.DeleteTabletReq
 	}, nil
 }
 
+// DiffSchemas is part of the vtadminpb.VTAdminServer interface.
+func (api *API) DiffSchemas(ctx context.Context, req *vtadminpb.DiffSchemasRequest) (*vtadminpb.DiffSchemasResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.DiffSchemas")
+	defer span.Finish()
+
+	if req.Source == nil || req.Target == nil {
+		return nil, fmt.Errorf("%w: source and target are required", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("source_cluster_id", req.Source.ClusterId)
+	span.Annotate("source_keyspace", req.Source.Keyspace)
+	span.Annotate("source_shard", req.Source.Shard)
+	span.Annotate("target_cluster_id", req.Target.ClusterId)
+	span.Annotate("target_keyspace", req.Target.Keyspace)
+	span.Annotate("target_shard", req.Target.Shard)
+
+	schemas := make([]*tabletmanagerdatapb.SchemaDefinition, 2)
+	targets := make([]*vtadminpb.SchemaDiffTarget, 2)
+
+	for i, target := range []*vtadminpb.SchemaDiffTarget{req.Source, req.Target} {
+		if !api.authz.IsAuthorized(ctx, target.ClusterId, rbac.SchemaResource, rbac.GetAction) {
+			return nil, fmt.Errorf("%w: cannot get schema in %s", errors.ErrUnauthorized, target.ClusterId)
+		}
+
+		c, err := api.getClusterForRequest(target.ClusterId)
+		if err != nil {
+			return nil, err
+		}
+
+		schema, tablet, err := c.GetLiveSchema(ctx, target.Keyspace, target.Shard, req.Tables)
+		if err != nil {
+			return nil, err
+		}
+
+		schemas[i] = schema
+		targets[i] = &vtadminpb.SchemaDiffTarget{
+			ClusterId: target.ClusterId,
+			Keyspace:  target.Keyspace,
+			Shard:     tablet.Tablet.Shard,
+		}
+	}
+
+	diffs, ddls, err := schemadrift.Diff(ctx, schemas[0], schemas[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtadminpb.DiffSchemasResponse{
+		Source: targets[0],
+		Target: targets[1],
+		Diffs:  diffs,
+		Ddls:   ddls,
+	}, nil
+}
+
+// DrainTablet is part of the vtadminpb.VTAdminServer interface.
+func (api *API) DrainTablet(ctx context.Context, req *vtadminpb.DrainTabletRequest) (*vtadminpb.DrainTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.DrainTablet")
+	defer span.Finish()
+
+	tablet, c, err := api.getTabletForAction(ctx, span, rbac.ManageTabletDrainAction, req.Alias, req.ClusterIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DrainTablet(ctx, tablet, req.Reason)
+}
+
 // EmergencyFailoverShard is part of the vtadminpb.VTAdminServer interface.
 func (api *API) EmergencyFailoverShard(ctx context.Context, req *vtadminpb.EmergencyFailoverShardRequest) (*vtadminpb.EmergencyFailoverShardResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.EmergencyFailoverShard")
@@ -555,6 +838,56 @@ func (api *API) EmergencyFailoverShard(ctx context.Context, req *vtadminpb.Emerg
 	return c.EmergencyFailoverShard(ctx, req.Options)
 }
 
+// ExecuteQuery is part of the vtadminpb.VTAdminServer interface.
+//
+// Every query, whether it succeeds, fails or is not authorized, is written to
+// the audit log along with the actor that ran it.
+func (api *API) ExecuteQuery(ctx context.Context, req *vtadminpb.ExecuteQueryRequest) (resp *vtadminpb.ExecuteQueryResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "API.ExecuteQuery")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+	span.Annotate("keyspace", req.Keyspace)
+
+	start := time.Now()
+	defer func() {
+		logQuery(ctx, req, resp, time.Since(start), err)
+	}()
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.QueryResource, rbac.ExecuteQueryAction) {
+		return nil, fmt.Errorf("%w: cannot execute query in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ExecuteQuery(ctx, req)
+}
+
+// logQuery writes an audit log entry for a query console request.
+func logQuery(ctx context.Context, req *vtadminpb.ExecuteQueryRequest, resp *vtadminpb.ExecuteQueryResponse, duration time.Duration, err error) {
+	actor := "unknown"
+	if a, ok := rbac.FromContext(ctx); ok && a != nil {
+		actor = a.Name
+	}
+
+	tabletType := req.TabletType
+	if resp != nil {
+		tabletType = resp.TabletType
+	}
+
+	target := fmt.Sprintf("%s@%s", req.Keyspace, topoproto.TabletTypeLString(tabletType))
+	if err != nil {
+		log.Infof("query console: actor=%q cluster=%s target=%s sql=%q duration=%v error=%q", actor, req.ClusterId, target, req.Sql, duration, err.Error())
+		return
+	}
+
+	log.Infof("query console: actor=%q cluster=%s target=%s sql=%q executed=%q rows=%d truncated=%v duration=%v",
+		actor, req.ClusterId, target, req.Sql, resp.Sql, len(resp.Result.GetRows()), resp.Result.GetTruncated(), duration)
+}
+
 // FindSchema is part of the vtadminpb.VTAdminServer interface.
 func (api *API) FindSchema(ctx context.Context, req *vtadminpb.FindSchemaRequest) (*vtadminpb.Schema, error) {
 	span, _ := trace.NewSpan(ctx, "API.FindSchema")
@@ -624,6 +957,43 @@ func (api *API) FindSchema(ctx context.Context, req *vtadminpb.FindSchemaRequest
 	}
 }
 
+// GetAuditEvents is part of the vtadminpb.VTAdminServer interface.
+//
+// Only events acting on at least one cluster the actor may get the audit log
+// of are returned.
+func (api *API) GetAuditEvents(ctx context.Context, req *vtadminpb.GetAuditEventsRequest) (*vtadminpb.GetAuditEventsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetAuditEvents")
+	defer span.Finish()
+
+	span.Annotate("actor", req.Actor)
+	span.Annotate("method", req.Method)
+	span.Annotate("limit", req.Limit)
+
+	_, ids := api.getClustersForRequest(req.ClusterIds)
+
+	var clusterIDs []string
+	for _, id := range ids {
+		if api.authz.IsAuthorized(ctx, id, rbac.AuditLogResource, rbac.GetAction) {
+			clusterIDs = append(clusterIDs, id)
+		}
+	}
+
+	if len(clusterIDs) == 0 {
+		return &vtadminpb.GetAuditEventsResponse{}, nil
+	}
+
+	return &vtadminpb.GetAuditEventsResponse{
+		Events: api.auditLog.Events(audit.Filter{
+			ClusterIDs: clusterIDs,
+			Actor:      req.Actor,
+			Method:     req.Method,
+			Since:      protoutil.TimeFromProto(req.Since),
+			Until:      protoutil.TimeFromProto(req.Until),
+			Limit:      int(req.Limit),
+		}),
+	}, nil
+}
+
 // GetBackups is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetBackups(ctx context.Context, req *vtadminpb.GetBackupsRequest) (*vtadminpb.GetBackupsResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetBackups")
@@ -791,6 +1161,47 @@ func (api *API) GetClusters(ctx context.Context, req *vtadminpb.GetClustersReque
 	}, nil
 }
 
+// GetClusterGraph is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetClusterGraph(ctx context.Context, req *vtadminpb.GetClusterGraphRequest) (*vtadminpb.ClusterGraph, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetClusterGraph")
+	defer span.Finish()
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.AnnotateSpan(c, span)
+
+	if !api.authz.IsAuthorized(ctx, c.ID, rbac.TopologyResource, rbac.GetAction) {
+		return nil, nil
+	}
+
+	return c.GetGraph(ctx)
+}
+
+// GetClusterMetrics is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetClusterMetrics(ctx context.Context, req *vtadminpb.GetClusterMetricsRequest) (*vtadminpb.GetClusterMetricsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetClusterMetrics")
+	defer span.Finish()
+
+	clusters, _ := api.getClustersForRequest(req.ClusterIds)
+
+	cms := make([]*vtadminpb.ClusterMetrics, 0, len(clusters))
+
+	for _, c := range clusters {
+		if !api.authz.IsAuthorized(ctx, c.ID, rbac.MetricsResource, rbac.GetAction) {
+			continue
+		}
+
+		cms = append(cms, c.GetMetrics(ctx))
+	}
+
+	return &vtadminpb.GetClusterMetricsResponse{
+		Clusters: cms,
+	}, nil
+}
+
 // GetFullStatus is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetFullStatus(ctx context.Context, req *vtadminpb.GetFullStatusRequest) (*vtctldatapb.GetFullStatusResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetFullStatus")
@@ -922,6 +1333,29 @@ func (api *API) GetKeyspaces(ctx context.Context, req *vtadminpb.GetKeyspacesReq
 	}, nil
 }
 
+// GetSavedQueries is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetSavedQueries(ctx context.Context, req *vtadminpb.GetSavedQueriesRequest) (*vtadminpb.GetSavedQueriesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetSavedQueries")
+	defer span.Finish()
+
+	_, ids := api.getClustersForRequest(req.ClusterIds)
+
+	var clusterIDs []string
+	for _, id := range ids {
+		if api.authz.IsAuthorized(ctx, id, rbac.SavedQueryResource, rbac.GetAction) {
+			clusterIDs = append(clusterIDs, id)
+		}
+	}
+
+	if len(clusterIDs) == 0 {
+		return &vtadminpb.GetSavedQueriesResponse{}, nil
+	}
+
+	return &vtadminpb.GetSavedQueriesResponse{
+		SavedQueries: api.savedQueries.List(clusterIDs),
+	}, nil
+}
+
 // GetSchema is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetSchema(ctx context.Context, req *vtadminpb.GetSchemaRequest) (*vtadminpb.Schema, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetSchema")
@@ -961,6 +1395,95 @@ func (api *API) GetSchema(ctx context.Context, req *vtadminpb.GetSchemaRequest)
 	return schema, nil
 }
 
+// GetSchemaDrift is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetSchemaDrift(ctx context.Context, req *vtadminpb.GetSchemaDriftRequest) (*vtadminpb.GetSchemaDriftResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetSchemaDrift")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+	span.Annotate("keyspace", req.Keyspace)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaResource, rbac.GetAction) {
+		return nil, fmt.Errorf("%w: cannot get schema in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSchemaDrift(ctx, req)
+}
+
+// GetSchemaMigrations is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetSchemaMigrations(ctx context.Context, req *vtadminpb.GetSchemaMigrationsRequest) (*vtadminpb.GetSchemaMigrationsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetSchemaMigrations")
+	defer span.Finish()
+
+	clusterRequests := req.ClusterRequests
+	if len(clusterRequests) == 0 {
+		clusters, _ := api.getClustersForRequest(nil)
+		clusterRequests = make([]*vtadminpb.GetSchemaMigrationsRequest_ClusterRequest, 0, len(clusters))
+		for _, c := range clusters {
+			clusterRequests = append(clusterRequests, &vtadminpb.GetSchemaMigrationsRequest_ClusterRequest{
+				ClusterId: c.ID,
+				Options:   &vtctldatapb.GetSchemaMigrationsRequest{},
+			})
+		}
+	}
+
+	clusters := make([]*cluster.Cluster, len(clusterRequests))
+	for i, cr := range clusterRequests {
+		c, err := api.getClusterForRequest(cr.ClusterId)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters[i] = c
+	}
+
+	var (
+		m          sync.Mutex
+		wg         sync.WaitGroup
+		rec        concurrency.AllErrorRecorder
+		migrations []*vtadminpb.SchemaMigration
+	)
+
+	for i, cr := range clusterRequests {
+		c := clusters[i]
+		if !api.authz.IsAuthorized(ctx, c.ID, rbac.SchemaMigrationResource, rbac.GetAction) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(c *cluster.Cluster, opts *vtctldatapb.GetSchemaMigrationsRequest) {
+			defer wg.Done()
+
+			clusterMigrations, err := c.GetSchemaMigrations(ctx, opts)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			migrations = append(migrations, clusterMigrations...)
+		}(c, cr.Options)
+	}
+
+	wg.Wait()
+
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	return &vtadminpb.GetSchemaMigrationsResponse{
+		SchemaMigrations: migrations,
+	}, nil
+}
+
 // GetSchemas is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetSchemas(ctx context.Context, req *vtadminpb.GetSchemasRequest) (*vtadminpb.GetSchemasResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetSchemas")
@@ -1515,6 +2038,63 @@ func (api *API) GetWorkflows(ctx context.Context, req *vtadminpb.GetWorkflowsReq
 	}, nil
 }
 
+// LaunchSchemaMigration is part of the vtadminpb.VTAdminServer interface.
+func (api *API) LaunchSchemaMigration(ctx context.Context, req *vtadminpb.LaunchSchemaMigrationRequest) (*vtctldatapb.LaunchSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.LaunchSchemaMigration")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaMigrationResource, rbac.LaunchSchemaMigrationAction) {
+		return nil, fmt.Errorf("%w: cannot launch schema migration in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.LaunchSchemaMigration(ctx, req.Options)
+}
+
+// MoveTablesComplete is part of the vtadminpb.VTAdminServer interface.
+func (api *API) MoveTablesComplete(ctx context.Context, req *vtadminpb.MoveTablesCompleteRequest) (*vtctldatapb.MoveTablesCompleteResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.MoveTablesComplete")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.CompleteWorkflowAction) {
+		return nil, fmt.Errorf("%w: cannot complete workflow in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MoveTablesComplete(ctx, req.Options)
+}
+
+// MoveTablesCreate is part of the vtadminpb.VTAdminServer interface.
+func (api *API) MoveTablesCreate(ctx context.Context, req *vtadminpb.MoveTablesCreateRequest) (*vtctldatapb.WorkflowStatusResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.MoveTablesCreate")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.CreateAction) {
+		return nil, fmt.Errorf("%w: cannot create workflow in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MoveTablesCreate(ctx, req.Options)
+}
+
 // PingTablet is part of the vtadminpb.VTAdminServer interface.
 func (api *API) PingTablet(ctx context.Context, req *vtadminpb.PingTabletRequest) (*vtadminpb.PingTabletResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.PingTablet")
@@ -1722,6 +2302,63 @@ func (api *API) ReloadSchemaShard(ctx context.Context, req *vtadminpb.ReloadSche
 	}, nil
 }
 
+// ReshardCreate is part of the vtadminpb.VTAdminServer interface.
+func (api *API) ReshardCreate(ctx context.Context, req *vtadminpb.ReshardCreateRequest) (*vtctldatapb.WorkflowStatusResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.ReshardCreate")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.CreateAction) {
+		return nil, fmt.Errorf("%w: cannot create workflow in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ReshardCreate(ctx, req.Options)
+}
+
+// ReshardRecommend is part of the vtadminpb.VTAdminServer interface.
+func (api *API) ReshardRecommend(ctx context.Context, req *vtadminpb.ReshardRecommendRequest) (*vtctldatapb.ReshardRecommendResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.ReshardRecommend")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.GetAction) {
+		return nil, fmt.Errorf("%w: cannot recommend reshard in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ReshardRecommend(ctx, req.Options)
+}
+
+// RetrySchemaMigration is part of the vtadminpb.VTAdminServer interface.
+func (api *API) RetrySchemaMigration(ctx context.Context, req *vtadminpb.RetrySchemaMigrationRequest) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.RetrySchemaMigration")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SchemaMigrationResource, rbac.RetrySchemaMigrationAction) {
+		return nil, fmt.Errorf("%w: cannot retry schema migration in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RetrySchemaMigration(ctx, req.Options)
+}
+
 // RunHealthCheck is part of the vtadminpb.VTAdminServer interface.
 func (api *API) RunHealthCheck(ctx context.Context, req *vtadminpb.RunHealthCheckRequest) (*vtadminpb.RunHealthCheckResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.RunHealthCheck")
@@ -1748,6 +2385,71 @@ func (api *API) RunHealthCheck(ctx context.Context, req *vtadminpb.RunHealthChec
 	}, nil
 }
 
+// RunSavedQuery is part of the vtadminpb.VTAdminServer interface.
+//
+// The query runs through ExecuteQuery, and so also requires permission to run
+// queries in the cluster.
+func (api *API) RunSavedQuery(ctx context.Context, req *vtadminpb.RunSavedQueryRequest) (*vtadminpb.ExecuteQueryResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.RunSavedQuery")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+	span.Annotate("id", req.Id)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.SavedQueryResource, rbac.GetAction) {
+		return nil, fmt.Errorf("%w: cannot run saved query in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	q, ok := api.savedQueries.Get(req.ClusterId, req.Id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s in %s", errors.ErrNoSavedQuery, req.Id, req.ClusterId)
+	}
+
+	sql, err := savedqueries.Bind(q, req.Params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrInvalidRequest, err)
+	}
+
+	return api.ExecuteQuery(ctx, &vtadminpb.ExecuteQueryRequest{
+		ClusterId:  q.ClusterId,
+		Keyspace:   q.Keyspace,
+		TabletType: q.TabletType,
+		Sql:        sql,
+	})
+}
+
+// runScheduledQuery runs a saved query for the report scheduler. Each run is
+// authorized as the owner of the query, with the roles it had when saving the
+// query, so that runs stop once the rules no longer allow the owner to run
+// queries in the cluster.
+func (api *API) runScheduledQuery(ctx context.Context, q *vtadminpb.SavedQuery) (*vtadminpb.ExecuteQueryResponse, error) {
+	var owner *rbac.Actor
+	if q.Owner != "" {
+		owner = &rbac.Actor{Name: q.Owner, Roles: q.OwnerRoles}
+	}
+
+	if !api.authz.IsAuthorized(rbac.NewContext(ctx, owner), q.ClusterId, rbac.QueryResource, rbac.ExecuteQueryAction) {
+		return nil, fmt.Errorf("%w: owner %q cannot execute query in %s", errors.ErrUnauthorized, q.Owner, q.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(q.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, err := savedqueries.Bind(q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ExecuteQuery(ctx, &vtadminpb.ExecuteQueryRequest{
+		ClusterId:  q.ClusterId,
+		Keyspace:   q.Keyspace,
+		TabletType: q.TabletType,
+		Sql:        sql,
+	})
+}
+
 // SetReadOnly is part of the vtadminpb.VTAdminServer interface.
 func (api *API) SetReadOnly(ctx context.Context, req *vtadminpb.SetReadOnlyRequest) (*vtadminpb.SetReadOnlyResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.SetReadOnly")
@@ -1845,6 +2547,19 @@ func (api *API) TabletExternallyPromoted(ctx context.Context, req *vtadminpb.Tab
 	return c.TabletExternallyPromoted(ctx, tablet)
 }
 
+// UndrainTablet is part of the vtadminpb.VTAdminServer interface.
+func (api *API) UndrainTablet(ctx context.Context, req *vtadminpb.UndrainTabletRequest) (*vtadminpb.UndrainTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.UndrainTablet")
+	defer span.Finish()
+
+	tablet, c, err := api.getTabletForAction(ctx, span, rbac.ManageTabletDrainAction, req.Alias, req.ClusterIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UndrainTablet(ctx, tablet, req.TabletType)
+}
+
 // Validate is part of the vtadminpb.VTAdminServer interface.
 func (api *API) Validate(ctx context.Context, req *vtadminpb.ValidateRequest) (*vtctldatapb.ValidateResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.Validate")
@@ -1896,6 +2611,44 @@ func (api *API) ValidateKeyspace(ctx context.Context, req *vtadminpb.ValidateKey
 	return res, nil
 }
 
+// ValidateMoveTablesCreate is part of the vtadminpb.VTAdminServer interface.
+func (api *API) ValidateMoveTablesCreate(ctx context.Context, req *vtadminpb.MoveTablesCreateRequest) (*vtadminpb.ValidateWorkflowCreateResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.ValidateMoveTablesCreate")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.GetAction) {
+		return nil, fmt.Errorf("%w: cannot validate workflow in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ValidateMoveTablesCreate(ctx, req.Options)
+}
+
+// ValidateReshardCreate is part of the vtadminpb.VTAdminServer interface.
+func (api *API) ValidateReshardCreate(ctx context.Context, req *vtadminpb.ReshardCreateRequest) (*vtadminpb.ValidateWorkflowCreateResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.ValidateReshardCreate")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.GetAction) {
+		return nil, fmt.Errorf("%w: cannot validate workflow in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ValidateReshardCreate(ctx, req.Options)
+}
+
 // ValidateSchemaKeyspace is part of the vtadminpb.VTAdminServer interface.
 func (api *API) ValidateSchemaKeyspace(ctx context.Context, req *vtadminpb.ValidateSchemaKeyspaceRequest) (*vtctldatapb.ValidateSchemaKeyspaceResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.ValidateSchemaKeyspace")
@@ -2169,6 +2922,25 @@ func (api *API) VTExplain(ctx context.Context, req *vtadminpb.VTExplainRequest)
 	}, nil
 }
 
+// WorkflowSwitchTraffic is part of the vtadminpb.VTAdminServer interface.
+func (api *API) WorkflowSwitchTraffic(ctx context.Context, req *vtadminpb.WorkflowSwitchTrafficRequest) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.WorkflowSwitchTraffic")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.WorkflowResource, rbac.SwitchWorkflowTrafficAction) {
+		return nil, fmt.Errorf("%w: cannot switch workflow traffic in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.WorkflowSwitchTraffic(ctx, req.Options)
+}
+
 func (api *API) getClusterForRequest(id string) (*cluster.Cluster, error) {
 	api.clusterMu.Lock()
 	defer api.clusterMu.Unlock()
@@ -33,6 +33,7 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
 	"vitess.io/vitess/go/vt/vtadmin/testutil"
 	"vitess.io/vitess/go/vt/vtadmin/vtctldclient/fakevtctldclient"
+	"vitess.io/vitess/go/vt/vtadmin/vtsql/fakevtsql"
 
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
@@ -43,7 +44,7 @@ import (
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
-func TestCreateKeyspace(t *testing.T) {
+func TestApplySchema(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -55,7 +56,7 @@ func TestCreateKeyspace(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Keyspace",
+					Resource: "SchemaMigration",
 					Actions:  []string{"create"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
@@ -82,14 +83,16 @@ func TestCreateKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.CreateKeyspace(ctx, &vtadminpb.CreateKeyspaceRequest{
+		resp, err := api.ApplySchema(ctx, &vtadminpb.ApplySchemaRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.CreateKeyspaceRequest{
-				Name: "test",
+			Options: &vtctldatapb.ApplySchemaRequest{
+				Keyspace:    "test",
+				Sql:         []string{"alter table t1 add column c int"},
+				DdlStrategy: "vitess",
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to CreateKeyspace", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to CreateKeyspace", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to ApplySchema", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ApplySchema", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -101,18 +104,20 @@ func TestCreateKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.CreateKeyspace(ctx, &vtadminpb.CreateKeyspaceRequest{
+		resp, err := api.ApplySchema(ctx, &vtadminpb.ApplySchemaRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.CreateKeyspaceRequest{
-				Name: "test",
+			Options: &vtctldatapb.ApplySchemaRequest{
+				Keyspace:    "test",
+				Sql:         []string{"alter table t1 add column c int"},
+				DdlStrategy: "vitess",
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to CreateKeyspace", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ApplySchema", actor)
 	})
 }
 
-func TestCreateShard(t *testing.T) {
+func TestCancelSchemaMigration(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -124,8 +129,8 @@ func TestCreateShard(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Shard",
-					Actions:  []string{"create"},
+					Resource: "SchemaMigration",
+					Actions:  []string{"cancel_schema_migration"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -151,15 +156,15 @@ func TestCreateShard(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.CreateShard(ctx, &vtadminpb.CreateShardRequest{
+		resp, err := api.CancelSchemaMigration(ctx, &vtadminpb.CancelSchemaMigrationRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.CreateShardRequest{
-				Keyspace:  "test",
-				ShardName: "-",
+			Options: &vtctldatapb.CancelSchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to CreateShard", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to CreateShard", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to CancelSchemaMigration", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to CancelSchemaMigration", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -171,19 +176,19 @@ func TestCreateShard(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.CreateShard(ctx, &vtadminpb.CreateShardRequest{
+		resp, err := api.CancelSchemaMigration(ctx, &vtadminpb.CancelSchemaMigrationRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.CreateShardRequest{
-				Keyspace:  "test",
-				ShardName: "-",
+			Options: &vtctldatapb.CancelSchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to CreateShard", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to CancelSchemaMigration", actor)
 	})
 }
 
-func TestDeleteKeyspace(t *testing.T) {
+func TestCleanupSchemaMigration(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -195,8 +200,8 @@ func TestDeleteKeyspace(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Keyspace",
-					Actions:  []string{"delete"},
+					Resource: "SchemaMigration",
+					Actions:  []string{"cleanup_schema_migration"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -222,14 +227,15 @@ func TestDeleteKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.DeleteKeyspace(ctx, &vtadminpb.DeleteKeyspaceRequest{
+		resp, err := api.CleanupSchemaMigration(ctx, &vtadminpb.CleanupSchemaMigrationRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.DeleteKeyspaceRequest{
+			Options: &vtctldatapb.CleanupSchemaMigrationRequest{
 				Keyspace: "test",
+				Uuid:     "test-uuid",
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to DeleteKeyspace", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteKeyspace", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to CleanupSchemaMigration", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to CleanupSchemaMigration", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -241,18 +247,19 @@ func TestDeleteKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.DeleteKeyspace(ctx, &vtadminpb.DeleteKeyspaceRequest{
+		resp, err := api.CleanupSchemaMigration(ctx, &vtadminpb.CleanupSchemaMigrationRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.DeleteKeyspaceRequest{
+			Options: &vtctldatapb.CleanupSchemaMigrationRequest{
 				Keyspace: "test",
+				Uuid:     "test-uuid",
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to DeleteKeyspace", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to CleanupSchemaMigration", actor)
 	})
 }
 
-func TestDeleteShards(t *testing.T) {
+func TestCompleteSchemaMigration(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -264,8 +271,8 @@ func TestDeleteShards(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Shard",
-					Actions:  []string{"delete"},
+					Resource: "SchemaMigration",
+					Actions:  []string{"complete_schema_migration"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -291,19 +298,15 @@ func TestDeleteShards(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.DeleteShards(ctx, &vtadminpb.DeleteShardsRequest{
+		resp, err := api.CompleteSchemaMigration(ctx, &vtadminpb.CompleteSchemaMigrationRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.DeleteShardsRequest{
-				Shards: []*vtctldatapb.Shard{
-					{
-						Keyspace: "test",
-						Name:     "-",
-					},
-				},
+			Options: &vtctldatapb.CompleteSchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to DeleteShards", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteShards", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to CompleteSchemaMigration", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to CompleteSchemaMigration", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -315,23 +318,19 @@ func TestDeleteShards(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.DeleteShards(ctx, &vtadminpb.DeleteShardsRequest{
+		resp, err := api.CompleteSchemaMigration(ctx, &vtadminpb.CompleteSchemaMigrationRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.DeleteShardsRequest{
-				Shards: []*vtctldatapb.Shard{
-					{
-						Keyspace: "test",
-						Name:     "-",
-					},
-				},
+			Options: &vtctldatapb.CompleteSchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to DeleteShards", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to CompleteSchemaMigration", actor)
 	})
 }
 
-func TestDeleteTablet(t *testing.T) {
+func TestCreateKeyspace(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -343,8 +342,8 @@ func TestDeleteTablet(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Tablet",
-					Actions:  []string{"delete"},
+					Resource: "Keyspace",
+					Actions:  []string{"create"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -370,15 +369,14 @@ func TestDeleteTablet(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.DeleteTablet(ctx, &vtadminpb.DeleteTabletRequest{
-			ClusterIds: []string{"test"},
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
+		resp, err := api.CreateKeyspace(ctx, &vtadminpb.CreateKeyspaceRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.CreateKeyspaceRequest{
+				Name: "test",
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to DeleteTablet", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteTablet", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to CreateKeyspace", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to CreateKeyspace", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -390,19 +388,18 @@ func TestDeleteTablet(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.DeleteTablet(ctx, &vtadminpb.DeleteTabletRequest{
-			ClusterIds: []string{"test"},
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
+		resp, err := api.CreateKeyspace(ctx, &vtadminpb.CreateKeyspaceRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.CreateKeyspaceRequest{
+				Name: "test",
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to DeleteTablet", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to CreateKeyspace", actor)
 	})
 }
 
-func TestEmergencyFailoverShard(t *testing.T) {
+func TestCreateSavedQuery(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -414,8 +411,14 @@ func TestEmergencyFailoverShard(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Shard",
-					Actions:  []string{"emergency_failover_shard"},
+					Resource: "SavedQuery",
+					Actions:  []string{"create"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Query",
+					Actions:  []string{"execute_query"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -441,15 +444,16 @@ func TestEmergencyFailoverShard(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.EmergencyFailoverShard(ctx, &vtadminpb.EmergencyFailoverShardRequest{
+		resp, err := api.CreateSavedQuery(ctx, &vtadminpb.CreateSavedQueryRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.EmergencyReparentShardRequest{
+			SavedQuery: &vtadminpb.SavedQuery{
+				Name:     "test",
 				Keyspace: "test",
-				Shard:    "-",
+				Sql:      "select id from t1",
 			},
 		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to EmergencyFailoverShard", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to CreateSavedQuery", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to CreateSavedQuery", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -461,19 +465,20 @@ func TestEmergencyFailoverShard(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.EmergencyFailoverShard(ctx, &vtadminpb.EmergencyFailoverShardRequest{
+		resp, err := api.CreateSavedQuery(ctx, &vtadminpb.CreateSavedQueryRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.EmergencyReparentShardRequest{
+			SavedQuery: &vtadminpb.SavedQuery{
+				Name:     "test",
 				Keyspace: "test",
-				Shard:    "-",
+				Sql:      "select id from t1",
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to EmergencyFailoverShard", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to CreateSavedQuery", actor)
 	})
 }
 
-func TestFindSchema(t *testing.T) {
+func TestCreateShard(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -485,94 +490,66 @@ func TestFindSchema(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Schema",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "Shard",
+					Actions:  []string{"create"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "Schema",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
-	t.Run("unauthorized actor", func(t *testing.T) {
-		t.Parallel()
-
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
 		}
-
-		resp, err := api.FindSchema(ctx, &vtadminpb.FindSchemaRequest{
-			Table: "t1",
-		})
-		assert.Error(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to FindSchema", actor)
 	})
 
-	t.Run("partial access", func(t *testing.T) {
+	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.FindSchema(ctx, &vtadminpb.FindSchemaRequest{
-			Table: "t1",
+		resp, err := api.CreateShard(ctx, &vtadminpb.CreateShardRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.CreateShardRequest{
+				Keyspace:  "test",
+				ShardName: "-",
+			},
 		})
-		assert.NotEmpty(t, resp, "actor %+v should be permitted to FindSchema", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to CreateShard", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to CreateShard", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.FindSchema(ctx, &vtadminpb.FindSchemaRequest{
-			Table: "t1",
+		resp, err := api.CreateShard(ctx, &vtadminpb.CreateShardRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.CreateShardRequest{
+				Keyspace:  "test",
+				ShardName: "-",
+			},
 		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "multiple schemas found")
-		assert.Nil(t, resp)
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to CreateShard", actor)
 	})
 }
 
-func TestGetBackups(t *testing.T) {
+func TestDeleteKeyspace(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -584,17 +561,11 @@ func TestGetBackups(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Backup",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "Keyspace",
+					Actions:  []string{"delete"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "Backup",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -611,47 +582,43 @@ func TestGetBackups(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, err := api.GetBackups(ctx, &vtadminpb.GetBackupsRequest{})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.Backups, "actor %+v should not be permitted to GetBackups", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetBackups(ctx, &vtadminpb.GetBackupsRequest{})
-		assert.NotEmpty(t, resp.Backups, "actor %+v should be permitted to GetBackups", actor)
-		assert.Len(t, resp.Backups, 3, "'other' actor should be able to see the 3 backups in cluster 'other'")
+		resp, err := api.DeleteKeyspace(ctx, &vtadminpb.DeleteKeyspaceRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.DeleteKeyspaceRequest{
+				Keyspace: "test",
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to DeleteKeyspace", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteKeyspace", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetBackups(ctx, &vtadminpb.GetBackupsRequest{})
-		assert.NotEmpty(t, resp.Backups, "actor %+v should be permitted to GetBackups", actor)
-		assert.Len(t, resp.Backups, 4, "'all' actor should be able to see backups in all clusters")
+		resp, err := api.DeleteKeyspace(ctx, &vtadminpb.DeleteKeyspaceRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.DeleteKeyspaceRequest{
+				Keyspace: "test",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to DeleteKeyspace", actor)
 	})
 }
 
-func TestGetCellInfos(t *testing.T) {
+func TestDeleteSavedQuery(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -663,17 +630,11 @@ func TestGetCellInfos(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "CellInfo",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "SavedQuery",
+					Actions:  []string{"delete"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "CellInfo",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -690,53 +651,40 @@ func TestGetCellInfos(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, err := api.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{
-			NamesOnly: true,
-		})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.CellInfos, "actor %+v should not be permitted to GetCellInfos", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{
-			NamesOnly: true,
+		resp, err := api.DeleteSavedQuery(ctx, &vtadminpb.DeleteSavedQueryRequest{
+			ClusterId: "test",
+			Id:        "test",
 		})
-		assert.NotEmpty(t, resp.CellInfos, "actor %+v should be permitted to GetCellInfos", actor)
-		assert.ElementsMatch(t, resp.CellInfos, []*vtadminpb.ClusterCellInfo{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Name: "other1"}})
+		assert.Error(t, err, "actor %+v should not be permitted to DeleteSavedQuery", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteSavedQuery", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{
-			NamesOnly: true,
+		resp, err := api.DeleteSavedQuery(ctx, &vtadminpb.DeleteSavedQueryRequest{
+			ClusterId: "test",
+			Id:        "test",
 		})
-		assert.NotEmpty(t, resp.CellInfos, "actor %+v should be permitted to GetCellInfos", actor)
-		assert.ElementsMatch(t, resp.CellInfos, []*vtadminpb.ClusterCellInfo{{Cluster: &vtadminpb.Cluster{Id: "test", Name: "test"}, Name: "zone1"}, {Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Name: "other1"}})
+		// the saved queries of the test API start out empty
+		assert.ErrorContains(t, err, "no such saved query", "actor %+v should be permitted to DeleteSavedQuery", actor)
+		assert.Nil(t, resp, "actor %+v should be permitted to DeleteSavedQuery", actor)
 	})
 }
 
-func TestGetCellsAliases(t *testing.T) {
+func TestDeleteShards(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -748,17 +696,11 @@ func TestGetCellsAliases(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "CellsAlias",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "Shard",
+					Actions:  []string{"delete"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "CellsAlias",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -775,47 +717,53 @@ func TestGetCellsAliases(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, err := api.GetCellsAliases(ctx, &vtadminpb.GetCellsAliasesRequest{})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.Aliases, "actor %+v should not be permitted to GetCellsAliases", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetCellsAliases(ctx, &vtadminpb.GetCellsAliasesRequest{})
-		assert.NotEmpty(t, resp.Aliases, "actor %+v should be permitted to GetCellsAliases", actor)
-		assert.ElementsMatch(t, resp.Aliases, []*vtadminpb.ClusterCellsAliases{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Aliases: map[string]*topodatapb.CellsAlias{"other": {Cells: []string{"other1"}}}}})
+		resp, err := api.DeleteShards(ctx, &vtadminpb.DeleteShardsRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.DeleteShardsRequest{
+				Shards: []*vtctldatapb.Shard{
+					{
+						Keyspace: "test",
+						Name:     "-",
+					},
+				},
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to DeleteShards", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteShards", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetCellsAliases(ctx, &vtadminpb.GetCellsAliasesRequest{})
-		assert.NotEmpty(t, resp.Aliases, "actor %+v should be permitted to GetCellsAliases", actor)
-		assert.ElementsMatch(t, resp.Aliases, []*vtadminpb.ClusterCellsAliases{{Cluster: &vtadminpb.Cluster{Id: "test", Name: "test"}, Aliases: map[string]*topodatapb.CellsAlias{"zone": {Cells: []string{"zone1"}}}}, {Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Aliases: map[string]*topodatapb.CellsAlias{"other": {Cells: []string{"other1"}}}}})
+		resp, err := api.DeleteShards(ctx, &vtadminpb.DeleteShardsRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.DeleteShardsRequest{
+				Shards: []*vtctldatapb.Shard{
+					{
+						Keyspace: "test",
+						Name:     "-",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to DeleteShards", actor)
 	})
 }
 
-func TestGetClusters(t *testing.T) {
+func TestDeleteTablet(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -827,8 +775,8 @@ func TestGetClusters(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Cluster",
-					Actions:  []string{"get"},
+					Resource: "Tablet",
+					Actions:  []string{"delete"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -845,19 +793,6 @@ func TestGetClusters(t *testing.T) {
 		}
 	})
 
-	t.Run("unauthenticated", func(t *testing.T) {
-		t.Parallel()
-
-		var actor *rbac.Actor
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, _ := api.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
-		assert.Empty(t, resp.Clusters, "actor %+v should not be permitted to GetClusters", actor)
-	})
-
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
@@ -867,8 +802,15 @@ func TestGetClusters(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
-		assert.Empty(t, resp.Clusters, "actor %+v should not be permitted to GetClusters", actor)
+		resp, err := api.DeleteTablet(ctx, &vtadminpb.DeleteTabletRequest{
+			ClusterIds: []string{"test"},
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to DeleteTablet", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to DeleteTablet", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -880,13 +822,19 @@ func TestGetClusters(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
+		resp, err := api.DeleteTablet(ctx, &vtadminpb.DeleteTabletRequest{
+			ClusterIds: []string{"test"},
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
 		require.NoError(t, err)
-		assert.NotEmpty(t, resp.Clusters, "actor %+v should be permitted to GetClusters", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to DeleteTablet", actor)
 	})
 }
 
-func TestGetGates(t *testing.T) {
+func TestDiffSchemas(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -898,90 +846,74 @@ func TestGetGates(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "VTGate",
+					Resource: "Schema",
 					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "VTGate",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
-	t.Run("unauthorized actor", func(t *testing.T) {
-		t.Parallel()
-
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
 		}
-
-		resp, err := api.GetGates(ctx, &vtadminpb.GetGatesRequest{})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.Gates, "actor %+v should not be permitted to GetGates", actor)
 	})
 
-	t.Run("partial access", func(t *testing.T) {
+	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetGates(ctx, &vtadminpb.GetGatesRequest{})
-		assert.NotEmpty(t, resp.Gates, "actor %+v should be permitted to GetGates", actor)
-		// testutil.BuildCluster creates exactly one gate per cluster
-		assert.Len(t, resp.Gates, 1, "actor %+v should only be able to see VTGate from cluster 'other'", actor)
+		resp, err := api.DiffSchemas(ctx, &vtadminpb.DiffSchemasRequest{
+			Source: &vtadminpb.SchemaDiffTarget{
+				ClusterId: "test",
+				Keyspace:  "test",
+			},
+			Target: &vtadminpb.SchemaDiffTarget{
+				ClusterId: "test",
+				Keyspace:  "test",
+				Shard:     "-",
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to DiffSchemas", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to DiffSchemas", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetGates(ctx, &vtadminpb.GetGatesRequest{})
-		assert.NotEmpty(t, resp.Gates, "actor %+v should be permitted to GetGates", actor)
-		// testutil.BuildCluster creates exactly one gate per cluster
-		assert.Len(t, resp.Gates, 2, "actor %+v should be able to see VTGate from all clusters", actor)
+		resp, err := api.DiffSchemas(ctx, &vtadminpb.DiffSchemasRequest{
+			Source: &vtadminpb.SchemaDiffTarget{
+				ClusterId: "test",
+				Keyspace:  "test",
+			},
+			Target: &vtadminpb.SchemaDiffTarget{
+				ClusterId: "test",
+				Keyspace:  "test",
+				Shard:     "-",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to DiffSchemas", actor)
 	})
 }
 
-func TestGetKeyspace(t *testing.T) {
+func TestDrainTablet(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -993,8 +925,8 @@ func TestGetKeyspace(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Keyspace",
-					Actions:  []string{"get"},
+					Resource: "Tablet",
+					Actions:  []string{"manage_tablet_drain"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -1020,12 +952,14 @@ func TestGetKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetKeyspace(ctx, &vtadminpb.GetKeyspaceRequest{
-			ClusterId: "test",
-			Keyspace:  "test",
+		resp, err := api.DrainTablet(ctx, &vtadminpb.DrainTabletRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
 		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to GetKeyspace", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to DrainTablet", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to DrainTablet", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -1037,16 +971,18 @@ func TestGetKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetKeyspace(ctx, &vtadminpb.GetKeyspaceRequest{
-			ClusterId: "test",
-			Keyspace:  "test",
+		resp, err := api.DrainTablet(ctx, &vtadminpb.DrainTabletRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to GetKeyspace", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to DrainTablet", actor)
 	})
 }
 
-func TestGetKeyspaces(t *testing.T) {
+func TestEmergencyFailoverShard(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1058,17 +994,11 @@ func TestGetKeyspaces(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Keyspace",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "Shard",
+					Actions:  []string{"emergency_failover_shard"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "Keyspace",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -1085,61 +1015,45 @@ func TestGetKeyspaces(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetKeyspaces(ctx, &vtadminpb.GetKeyspacesRequest{})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.Keyspaces, "actor %+v should not be permitted to GetKeyspaces", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, _ := api.GetKeyspaces(ctx, &vtadminpb.GetKeyspacesRequest{})
-		assert.NotEmpty(t, resp.Keyspaces, "actor %+v should be permitted to GetKeyspaces", actor)
-		ksMap := map[string][]string{}
-		for _, ks := range resp.Keyspaces {
-			if _, ok := ksMap[ks.Cluster.Id]; !ok {
-				ksMap[ks.Cluster.Id] = []string{}
-			}
-			ksMap[ks.Cluster.Id] = append(ksMap[ks.Cluster.Id], ks.Keyspace.Name)
-		}
-		assert.Equal(t, ksMap, map[string][]string{"other": {"otherks"}}, "actor %+v should be permitted to GetKeyspaces", actor)
+		resp, err := api.EmergencyFailoverShard(ctx, &vtadminpb.EmergencyFailoverShardRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.EmergencyReparentShardRequest{
+				Keyspace: "test",
+				Shard:    "-",
+			},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to EmergencyFailoverShard", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetKeyspaces(ctx, &vtadminpb.GetKeyspacesRequest{})
-		assert.NotEmpty(t, resp.Keyspaces, "actor %+v should be permitted to GetKeyspaces", actor)
-		ksMap := map[string][]string{}
-		for _, ks := range resp.Keyspaces {
-			if _, ok := ksMap[ks.Cluster.Id]; !ok {
-				ksMap[ks.Cluster.Id] = []string{}
-			}
-			ksMap[ks.Cluster.Id] = append(ksMap[ks.Cluster.Id], ks.Keyspace.Name)
-		}
-		assert.Equal(t, ksMap, map[string][]string{"test": {"test"}, "other": {"otherks"}}, "actor %+v should be permitted to GetKeyspaces", actor)
+		resp, err := api.EmergencyFailoverShard(ctx, &vtadminpb.EmergencyFailoverShardRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.EmergencyReparentShardRequest{
+				Keyspace: "test",
+				Shard:    "-",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to EmergencyFailoverShard", actor)
 	})
 }
 
-func TestGetSchema(t *testing.T) {
+func TestExecuteQuery(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1151,8 +1065,8 @@ func TestGetSchema(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Schema",
-					Actions:  []string{"get"},
+					Resource: "Query",
+					Actions:  []string{"execute_query"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -1162,58 +1076,51 @@ func TestGetSchema(t *testing.T) {
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
 		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetSchema(ctx, &vtadminpb.GetSchemaRequest{
+		resp, err := api.ExecuteQuery(ctx, &vtadminpb.ExecuteQueryRequest{
 			ClusterId: "test",
 			Keyspace:  "test",
-			Table:     "t1",
+			Sql:       "select id from t1",
 		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to GetSchema", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to ExecuteQuery", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ExecuteQuery", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
 		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetSchema(ctx, &vtadminpb.GetSchemaRequest{
+		resp, err := api.ExecuteQuery(ctx, &vtadminpb.ExecuteQueryRequest{
 			ClusterId: "test",
 			Keyspace:  "test",
-			Table:     "t1",
+			Sql:       "select id from t1",
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to GetSchema", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ExecuteQuery", actor)
 	})
 }
 
-func TestGetSchemas(t *testing.T) {
+func TestFindSchema(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1258,9 +1165,11 @@ func TestGetSchemas(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetSchemas(ctx, &vtadminpb.GetSchemasRequest{})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.Schemas, "actor %+v should not be permitted to GetSchemas", actor)
+		resp, err := api.FindSchema(ctx, &vtadminpb.FindSchemaRequest{
+			Table: "t1",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to FindSchema", actor)
 	})
 
 	t.Run("partial access", func(t *testing.T) {
@@ -1279,16 +1188,10 @@ func TestGetSchemas(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetSchemas(ctx, &vtadminpb.GetSchemasRequest{})
-		assert.NotEmpty(t, resp.Schemas, "actor %+v should be permitted to GetSchemas", actor)
-		schemaMap := map[string][]string{}
-		for _, schema := range resp.Schemas {
-			if _, ok := schemaMap[schema.Cluster.Id]; !ok {
-				schemaMap[schema.Cluster.Id] = []string{}
-			}
-			schemaMap[schema.Cluster.Id] = append(schemaMap[schema.Cluster.Id], schema.Keyspace)
-		}
-		assert.Equal(t, schemaMap, map[string][]string{"other": {"otherks"}}, "actor %+v should be permitted to GetSchemas", actor)
+		resp, _ := api.FindSchema(ctx, &vtadminpb.FindSchemaRequest{
+			Table: "t1",
+		})
+		assert.NotEmpty(t, resp, "actor %+v should be permitted to FindSchema", actor)
 	})
 
 	t.Run("full access", func(t *testing.T) {
@@ -1307,20 +1210,16 @@ func TestGetSchemas(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetSchemas(ctx, &vtadminpb.GetSchemasRequest{})
-		assert.NotEmpty(t, resp.Schemas, "actor %+v should be permitted to GetSchemas", actor)
-		schemaMap := map[string][]string{}
-		for _, schema := range resp.Schemas {
-			if _, ok := schemaMap[schema.Cluster.Id]; !ok {
-				schemaMap[schema.Cluster.Id] = []string{}
-			}
-			schemaMap[schema.Cluster.Id] = append(schemaMap[schema.Cluster.Id], schema.Keyspace)
-		}
-		assert.Equal(t, schemaMap, map[string][]string{"test": {"test"}, "other": {"otherks"}}, "actor %+v should be permitted to GetSchemas", actor)
+		resp, err := api.FindSchema(ctx, &vtadminpb.FindSchemaRequest{
+			Table: "t1",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "multiple schemas found")
+		assert.Nil(t, resp)
 	})
 }
 
-func TestGetShardReplicationPositions(t *testing.T) {
+func TestGetAuditEvents(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1332,13 +1231,13 @@ func TestGetShardReplicationPositions(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "ShardReplicationPosition",
+					Resource: "AuditLog",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed-all"},
 					Clusters: []string{"*"},
 				},
 				{
-					Resource: "ShardReplicationPosition",
+					Resource: "AuditLog",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed-other"},
 					Clusters: []string{"other"},
@@ -1349,71 +1248,73 @@ func TestGetShardReplicationPositions(t *testing.T) {
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
-	api := vtadmin.NewAPI(testClusters(t), opts)
-	t.Cleanup(func() {
-		if err := api.Close(); err != nil {
-			t.Logf("api did not close cleanly: %s", err.Error())
-		}
-	})
-
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
 		actor := &rbac.Actor{Name: "unauthorized"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetShardReplicationPositions(ctx, &vtadminpb.GetShardReplicationPositionsRequest{})
+		resp, err := api.GetAuditEvents(ctx, &vtadminpb.GetAuditEventsRequest{})
 		assert.NoError(t, err)
-		assert.Empty(t, resp.ReplicationPositions, "actor %+v should not be permitted to GetShardReplicationPositions", actor)
+		assert.Empty(t, resp.Events, "actor %+v should not be permitted to GetAuditEvents", actor)
 	})
 
 	t.Run("partial access", func(t *testing.T) {
 		t.Parallel()
 
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
 		actor := &rbac.Actor{Name: "allowed-other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetShardReplicationPositions(ctx, &vtadminpb.GetShardReplicationPositionsRequest{})
-		assert.NotEmpty(t, resp.ReplicationPositions, "actor %+v should be permitted to GetShardReplicationPositions", actor)
-		posMap := map[string][]string{}
-		for _, pos := range resp.ReplicationPositions {
-			if _, ok := posMap[pos.Cluster.Id]; !ok {
-				posMap[pos.Cluster.Id] = []string{}
-			}
-			posMap[pos.Cluster.Id] = append(posMap[pos.Cluster.Id], fmt.Sprintf("%s/%s", pos.Keyspace, pos.Shard))
-		}
-		assert.Equal(t, posMap, map[string][]string{"other": {"otherks/-"}}, "actor %+v should be permitted to GetShardReplicationPositions", actor)
+		resp, _ := api.GetAuditEvents(ctx, &vtadminpb.GetAuditEventsRequest{})
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetAuditEvents", actor)
+		// the audit log of the test API starts out empty
+		assert.Empty(t, resp.Events, "actor %+v should be permitted to GetAuditEvents", actor)
 	})
 
 	t.Run("full access", func(t *testing.T) {
 		t.Parallel()
 
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
 		actor := &rbac.Actor{Name: "allowed-all"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetShardReplicationPositions(ctx, &vtadminpb.GetShardReplicationPositionsRequest{})
-		assert.NotEmpty(t, resp.ReplicationPositions, "actor %+v should be permitted to GetShardReplicationPositions", actor)
-		posMap := map[string][]string{}
-		for _, pos := range resp.ReplicationPositions {
-			if _, ok := posMap[pos.Cluster.Id]; !ok {
-				posMap[pos.Cluster.Id] = []string{}
-			}
-			posMap[pos.Cluster.Id] = append(posMap[pos.Cluster.Id], fmt.Sprintf("%s/%s", pos.Keyspace, pos.Shard))
-		}
-		assert.Equal(t, posMap, map[string][]string{"test": {"test/-"}, "other": {"otherks/-"}}, "actor %+v should be permitted to GetShardReplicationPositions", actor)
+		resp, _ := api.GetAuditEvents(ctx, &vtadminpb.GetAuditEventsRequest{})
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetAuditEvents", actor)
+		// the audit log of the test API starts out empty
+		assert.Empty(t, resp.Events, "actor %+v should be permitted to GetAuditEvents", actor)
 	})
 }
 
-func TestGetSrvVSchema(t *testing.T) {
+func TestGetBackups(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1425,11 +1326,17 @@ func TestGetSrvVSchema(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "SrvVSchema",
+					Resource: "Backup",
 					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed"},
+					Subjects: []string{"user:allowed-all"},
 					Clusters: []string{"*"},
 				},
+				{
+					Resource: "Backup",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
 			},
 		},
 	}
@@ -1446,39 +1353,47 @@ func TestGetSrvVSchema(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "other"}
+		actor := &rbac.Actor{Name: "unauthorized"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetSrvVSchema(ctx, &vtadminpb.GetSrvVSchemaRequest{
-			ClusterId: "test",
-			Cell:      "zone1",
-		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to GetSrvVSchema", actor)
+		resp, err := api.GetBackups(ctx, &vtadminpb.GetBackupsRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Backups, "actor %+v should not be permitted to GetBackups", actor)
 	})
 
-	t.Run("authorized actor", func(t *testing.T) {
+	t.Run("partial access", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed"}
+		actor := &rbac.Actor{Name: "allowed-other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetSrvVSchema(ctx, &vtadminpb.GetSrvVSchemaRequest{
-			ClusterId: "test",
-			Cell:      "zone1",
-		})
-		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to GetSrvVSchema", actor)
+		resp, _ := api.GetBackups(ctx, &vtadminpb.GetBackupsRequest{})
+		assert.NotEmpty(t, resp.Backups, "actor %+v should be permitted to GetBackups", actor)
+		assert.Len(t, resp.Backups, 3, "'other' actor should be able to see the 3 backups in cluster 'other'")
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetBackups(ctx, &vtadminpb.GetBackupsRequest{})
+		assert.NotEmpty(t, resp.Backups, "actor %+v should be permitted to GetBackups", actor)
+		assert.Len(t, resp.Backups, 4, "'all' actor should be able to see backups in all clusters")
 	})
 }
 
-func TestGetSrvVSchemas(t *testing.T) {
+func TestGetCellInfos(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1490,13 +1405,13 @@ func TestGetSrvVSchemas(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "SrvVSchema",
+					Resource: "CellInfo",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed-all"},
 					Clusters: []string{"*"},
 				},
 				{
-					Resource: "SrvVSchema",
+					Resource: "CellInfo",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed-other"},
 					Clusters: []string{"other"},
@@ -1523,9 +1438,11 @@ func TestGetSrvVSchemas(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetSrvVSchemas(ctx, &vtadminpb.GetSrvVSchemasRequest{})
-		require.NoError(t, err)
-		assert.Nil(t, resp.SrvVSchemas, "actor %+v should not be permitted to GetSrvVSchemas", actor)
+		resp, err := api.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{
+			NamesOnly: true,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.CellInfos, "actor %+v should not be permitted to GetCellInfos", actor)
 	})
 
 	t.Run("partial access", func(t *testing.T) {
@@ -1537,16 +1454,11 @@ func TestGetSrvVSchemas(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetSrvVSchemas(ctx, &vtadminpb.GetSrvVSchemasRequest{})
-		assert.NotEmpty(t, resp.SrvVSchemas, "actor %+v should be permitted to GetSrvVSchemas", actor)
-		clusterCells := map[string][]string{}
-		for _, svs := range resp.SrvVSchemas {
-			if _, ok := clusterCells[svs.Cluster.Id]; !ok {
-				clusterCells[svs.Cluster.Id] = []string{}
-			}
-			clusterCells[svs.Cluster.Id] = append(clusterCells[svs.Cluster.Id], svs.Cell)
-		}
-		assert.Equal(t, clusterCells, map[string][]string{"other": {"other1"}}, "actor %+v should be permitted to GetSrvVSchemas", actor)
+		resp, _ := api.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{
+			NamesOnly: true,
+		})
+		assert.NotEmpty(t, resp.CellInfos, "actor %+v should be permitted to GetCellInfos", actor)
+		assert.ElementsMatch(t, resp.CellInfos, []*vtadminpb.ClusterCellInfo{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Name: "other1"}})
 	})
 
 	t.Run("full access", func(t *testing.T) {
@@ -1558,20 +1470,15 @@ func TestGetSrvVSchemas(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetSrvVSchemas(ctx, &vtadminpb.GetSrvVSchemasRequest{})
-		assert.NotEmpty(t, resp.SrvVSchemas, "actor %+v should be permitted to GetSrvVSchemas", actor)
-		clusterCells := map[string][]string{}
-		for _, svs := range resp.SrvVSchemas {
-			if _, ok := clusterCells[svs.Cluster.Id]; !ok {
-				clusterCells[svs.Cluster.Id] = []string{}
-			}
-			clusterCells[svs.Cluster.Id] = append(clusterCells[svs.Cluster.Id], svs.Cell)
-		}
-		assert.Equal(t, clusterCells, map[string][]string{"test": {"zone1"}, "other": {"other1"}}, "actor %+v should be permitted to GetSrvVSchemas", actor)
+		resp, _ := api.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{
+			NamesOnly: true,
+		})
+		assert.NotEmpty(t, resp.CellInfos, "actor %+v should be permitted to GetCellInfos", actor)
+		assert.ElementsMatch(t, resp.CellInfos, []*vtadminpb.ClusterCellInfo{{Cluster: &vtadminpb.Cluster{Id: "test", Name: "test"}, Name: "zone1"}, {Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Name: "other1"}})
 	})
 }
 
-func TestGetTablet(t *testing.T) {
+func TestGetCellsAliases(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1583,11 +1490,17 @@ func TestGetTablet(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Tablet",
+					Resource: "CellsAlias",
 					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed"},
+					Subjects: []string{"user:allowed-all"},
 					Clusters: []string{"*"},
 				},
+				{
+					Resource: "CellsAlias",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
 			},
 		},
 	}
@@ -1604,43 +1517,47 @@ func TestGetTablet(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "other"}
+		actor := &rbac.Actor{Name: "unauthorized"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetTablet(ctx, &vtadminpb.GetTabletRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
-			},
-		})
-		assert.Error(t, err, "actor %+v should not be permitted to GetTablet", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to GetTablet", actor)
+		resp, err := api.GetCellsAliases(ctx, &vtadminpb.GetCellsAliasesRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Aliases, "actor %+v should not be permitted to GetCellsAliases", actor)
 	})
 
-	t.Run("authorized actor", func(t *testing.T) {
+	t.Run("partial access", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed"}
+		actor := &rbac.Actor{Name: "allowed-other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetTablet(ctx, &vtadminpb.GetTabletRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
-			},
-		})
-		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to GetTablet", actor)
+		resp, _ := api.GetCellsAliases(ctx, &vtadminpb.GetCellsAliasesRequest{})
+		assert.NotEmpty(t, resp.Aliases, "actor %+v should be permitted to GetCellsAliases", actor)
+		assert.ElementsMatch(t, resp.Aliases, []*vtadminpb.ClusterCellsAliases{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Aliases: map[string]*topodatapb.CellsAlias{"other": {Cells: []string{"other1"}}}}})
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetCellsAliases(ctx, &vtadminpb.GetCellsAliasesRequest{})
+		assert.NotEmpty(t, resp.Aliases, "actor %+v should be permitted to GetCellsAliases", actor)
+		assert.ElementsMatch(t, resp.Aliases, []*vtadminpb.ClusterCellsAliases{{Cluster: &vtadminpb.Cluster{Id: "test", Name: "test"}, Aliases: map[string]*topodatapb.CellsAlias{"zone": {Cells: []string{"zone1"}}}}, {Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Aliases: map[string]*topodatapb.CellsAlias{"other": {Cells: []string{"other1"}}}}})
 	})
 }
 
-func TestGetTablets(t *testing.T) {
+func TestGetClusterGraph(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1652,17 +1569,11 @@ func TestGetTablets(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Tablet",
+					Resource: "Topology",
 					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "Tablet",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -1679,61 +1590,38 @@ func TestGetTablets(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetTablets(ctx, &vtadminpb.GetTabletsRequest{})
+		resp, err := api.GetClusterGraph(ctx, &vtadminpb.GetClusterGraphRequest{
+			ClusterId: "test",
+		})
 		require.NoError(t, err)
-		assert.Nil(t, resp.Tablets, "actor %+v should not be permitted to GetTablets", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, _ := api.GetTablets(ctx, &vtadminpb.GetTabletsRequest{})
-		assert.NotEmpty(t, resp.Tablets, "actor %+v should be permitted to GetTablets", actor)
-		clusterAliases := map[string][]string{}
-		for _, tablet := range resp.Tablets {
-			if _, ok := clusterAliases[tablet.Cluster.Id]; !ok {
-				clusterAliases[tablet.Cluster.Id] = []string{}
-			}
-			clusterAliases[tablet.Cluster.Id] = append(clusterAliases[tablet.Cluster.Id], topoproto.TabletAliasString(tablet.Tablet.Alias))
-		}
-		assert.Equal(t, clusterAliases, map[string][]string{"other": {"other1-0000000100"}}, "actor %+v should be permitted to GetTablets", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetClusterGraph", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetTablets(ctx, &vtadminpb.GetTabletsRequest{})
-		assert.NotEmpty(t, resp.Tablets, "actor %+v should be permitted to GetTablets", actor)
-		clusterAliases := map[string][]string{}
-		for _, tablet := range resp.Tablets {
-			if _, ok := clusterAliases[tablet.Cluster.Id]; !ok {
-				clusterAliases[tablet.Cluster.Id] = []string{}
-			}
-			clusterAliases[tablet.Cluster.Id] = append(clusterAliases[tablet.Cluster.Id], topoproto.TabletAliasString(tablet.Tablet.Alias))
-		}
-		assert.Equal(t, clusterAliases, map[string][]string{"test": {"zone1-0000000100"}, "other": {"other1-0000000100"}}, "actor %+v should be permitted to GetTablets", actor)
+		resp, err := api.GetClusterGraph(ctx, &vtadminpb.GetClusterGraphRequest{
+			ClusterId: "test",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetClusterGraph", actor)
+		assert.NotEmpty(t, resp.Cells, "actor %+v should be permitted to GetClusterGraph", actor)
 	})
 }
 
-func TestGetVSchema(t *testing.T) {
+func TestGetClusterMetrics(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1745,60 +1633,88 @@ func TestGetVSchema(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "VSchema",
+					Resource: "Metrics",
 					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed"},
+					Subjects: []string{"user:allowed-all"},
 					Clusters: []string{"*"},
 				},
+				{
+					Resource: "Metrics",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
 			},
 		},
 	}
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
-	api := vtadmin.NewAPI(testClusters(t), opts)
-	t.Cleanup(func() {
-		if err := api.Close(); err != nil {
-			t.Logf("api did not close cleanly: %s", err.Error())
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
 		}
+
+		resp, err := api.GetClusterMetrics(ctx, &vtadminpb.GetClusterMetricsRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Clusters, "actor %+v should not be permitted to GetClusterMetrics", actor)
 	})
 
-	t.Run("unauthorized actor", func(t *testing.T) {
+	t.Run("partial access", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "other"}
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed-other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetVSchema(ctx, &vtadminpb.GetVSchemaRequest{
-			ClusterId: "test",
-			Keyspace:  "test",
-		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to GetVSchema", actor)
+		resp, _ := api.GetClusterMetrics(ctx, &vtadminpb.GetClusterMetricsRequest{})
+		assert.NotEmpty(t, resp.Clusters, "actor %+v should be permitted to GetClusterMetrics", actor)
+		assert.Len(t, resp.Clusters, 1, "actor %+v should only be able to see metrics from cluster 'other'", actor)
 	})
 
-	t.Run("authorized actor", func(t *testing.T) {
+	t.Run("full access", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed"}
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed-all"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetVSchema(ctx, &vtadminpb.GetVSchemaRequest{
-			ClusterId: "test",
-			Keyspace:  "test",
-		})
-		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to GetVSchema", actor)
+		resp, _ := api.GetClusterMetrics(ctx, &vtadminpb.GetClusterMetricsRequest{})
+		assert.NotEmpty(t, resp.Clusters, "actor %+v should be permitted to GetClusterMetrics", actor)
+		assert.Len(t, resp.Clusters, 2, "actor %+v should be able to see metrics from all clusters", actor)
 	})
 }
 
-func TestGetVSchemas(t *testing.T) {
+func TestGetClusters(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1810,17 +1726,11 @@ func TestGetVSchemas(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "VSchema",
+					Resource: "Cluster",
 					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "VSchema",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -1834,64 +1744,48 @@ func TestGetVSchemas(t *testing.T) {
 		}
 	})
 
-	t.Run("unauthorized actor", func(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
+		var actor *rbac.Actor
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetVSchemas(ctx, &vtadminpb.GetVSchemasRequest{})
-		require.NoError(t, err)
-		assert.Nil(t, resp.VSchemas, "actor %+v should not be permitted to GetVSchemas", actor)
+		resp, _ := api.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
+		assert.Empty(t, resp.Clusters, "actor %+v should not be permitted to GetClusters", actor)
 	})
 
-	t.Run("partial access", func(t *testing.T) {
+	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetVSchemas(ctx, &vtadminpb.GetVSchemasRequest{})
-		assert.NotEmpty(t, resp.VSchemas, "actor %+v should be permitted to GetVSchemas", actor)
-		clusterKeyspaces := map[string][]string{}
-		for _, vs := range resp.VSchemas {
-			if _, ok := clusterKeyspaces[vs.Cluster.Id]; !ok {
-				clusterKeyspaces[vs.Cluster.Id] = []string{}
-			}
-			clusterKeyspaces[vs.Cluster.Id] = append(clusterKeyspaces[vs.Cluster.Id], vs.Name)
-		}
-		assert.Equal(t, clusterKeyspaces, map[string][]string{"other": {"otherks"}}, "actor %+v should be permitted to GetVSchemas", actor)
+		resp, _ := api.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
+		assert.Empty(t, resp.Clusters, "actor %+v should not be permitted to GetClusters", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetVSchemas(ctx, &vtadminpb.GetVSchemasRequest{})
-		assert.NotEmpty(t, resp.VSchemas, "actor %+v should be permitted to GetVSchemas", actor)
-		clusterKeyspaces := map[string][]string{}
-		for _, vs := range resp.VSchemas {
-			if _, ok := clusterKeyspaces[vs.Cluster.Id]; !ok {
-				clusterKeyspaces[vs.Cluster.Id] = []string{}
-			}
-			clusterKeyspaces[vs.Cluster.Id] = append(clusterKeyspaces[vs.Cluster.Id], vs.Name)
-		}
-		assert.Equal(t, clusterKeyspaces, map[string][]string{"test": {"test"}, "other": {"otherks"}}, "actor %+v should be permitted to GetVSchemas", actor)
+		resp, err := api.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Clusters, "actor %+v should be permitted to GetClusters", actor)
 	})
 }
 
-func TestGetVtctlds(t *testing.T) {
+func TestGetGates(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -1903,13 +1797,13 @@ func TestGetVtctlds(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Vtctld",
+					Resource: "VTGate",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed-all"},
 					Clusters: []string{"*"},
 				},
 				{
-					Resource: "Vtctld",
+					Resource: "VTGate",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed-other"},
 					Clusters: []string{"other"},
@@ -1936,9 +1830,9 @@ func TestGetVtctlds(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetVtctlds(ctx, &vtadminpb.GetVtctldsRequest{})
+		resp, err := api.GetGates(ctx, &vtadminpb.GetGatesRequest{})
 		assert.NoError(t, err)
-		assert.Empty(t, resp.Vtctlds, "actor %+v should not be permitted to GetVtctlds", actor)
+		assert.Empty(t, resp.Gates, "actor %+v should not be permitted to GetGates", actor)
 	})
 
 	t.Run("partial access", func(t *testing.T) {
@@ -1957,10 +1851,10 @@ func TestGetVtctlds(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetVtctlds(ctx, &vtadminpb.GetVtctldsRequest{})
-		assert.NotEmpty(t, resp.Vtctlds, "actor %+v should be permitted to GetVtctlds", actor)
+		resp, _ := api.GetGates(ctx, &vtadminpb.GetGatesRequest{})
+		assert.NotEmpty(t, resp.Gates, "actor %+v should be permitted to GetGates", actor)
 		// testutil.BuildCluster creates exactly one gate per cluster
-		assert.Len(t, resp.Vtctlds, 1, "actor %+v should only be able to see Vtctld from cluster 'other'", actor)
+		assert.Len(t, resp.Gates, 1, "actor %+v should only be able to see VTGate from cluster 'other'", actor)
 	})
 
 	t.Run("full access", func(t *testing.T) {
@@ -1979,14 +1873,2050 @@ func TestGetVtctlds(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetVtctlds(ctx, &vtadminpb.GetVtctldsRequest{})
-		assert.NotEmpty(t, resp.Vtctlds, "actor %+v should be permitted to GetVtctlds", actor)
-		// testutil.BuildCluster creates exactly one gate per cluster
-		assert.Len(t, resp.Vtctlds, 2, "actor %+v should be able to see Vtctld from all clusters", actor)
+		resp, _ := api.GetGates(ctx, &vtadminpb.GetGatesRequest{})
+		assert.NotEmpty(t, resp.Gates, "actor %+v should be permitted to GetGates", actor)
+		// testutil.BuildCluster creates exactly one gate per cluster
+		assert.Len(t, resp.Gates, 2, "actor %+v should be able to see VTGate from all clusters", actor)
+	})
+}
+
+func TestGetKeyspace(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Keyspace",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetKeyspace(ctx, &vtadminpb.GetKeyspaceRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetKeyspace", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetKeyspace(ctx, &vtadminpb.GetKeyspaceRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetKeyspace", actor)
+	})
+}
+
+func TestGetKeyspaces(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Keyspace",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Keyspace",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetKeyspaces(ctx, &vtadminpb.GetKeyspacesRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Keyspaces, "actor %+v should not be permitted to GetKeyspaces", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetKeyspaces(ctx, &vtadminpb.GetKeyspacesRequest{})
+		assert.NotEmpty(t, resp.Keyspaces, "actor %+v should be permitted to GetKeyspaces", actor)
+		ksMap := map[string][]string{}
+		for _, ks := range resp.Keyspaces {
+			if _, ok := ksMap[ks.Cluster.Id]; !ok {
+				ksMap[ks.Cluster.Id] = []string{}
+			}
+			ksMap[ks.Cluster.Id] = append(ksMap[ks.Cluster.Id], ks.Keyspace.Name)
+		}
+		assert.Equal(t, ksMap, map[string][]string{"other": {"otherks"}}, "actor %+v should be permitted to GetKeyspaces", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetKeyspaces(ctx, &vtadminpb.GetKeyspacesRequest{})
+		assert.NotEmpty(t, resp.Keyspaces, "actor %+v should be permitted to GetKeyspaces", actor)
+		ksMap := map[string][]string{}
+		for _, ks := range resp.Keyspaces {
+			if _, ok := ksMap[ks.Cluster.Id]; !ok {
+				ksMap[ks.Cluster.Id] = []string{}
+			}
+			ksMap[ks.Cluster.Id] = append(ksMap[ks.Cluster.Id], ks.Keyspace.Name)
+		}
+		assert.Equal(t, ksMap, map[string][]string{"test": {"test"}, "other": {"otherks"}}, "actor %+v should be permitted to GetKeyspaces", actor)
+	})
+}
+
+func TestGetSavedQueries(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SavedQuery",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "SavedQuery",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSavedQueries(ctx, &vtadminpb.GetSavedQueriesRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.SavedQueries, "actor %+v should not be permitted to GetSavedQueries", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSavedQueries(ctx, &vtadminpb.GetSavedQueriesRequest{})
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetSavedQueries", actor)
+		// the saved queries of the test API start out empty
+		assert.Empty(t, resp.SavedQueries, "actor %+v should be permitted to GetSavedQueries", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSavedQueries(ctx, &vtadminpb.GetSavedQueriesRequest{})
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetSavedQueries", actor)
+		// the saved queries of the test API start out empty
+		assert.Empty(t, resp.SavedQueries, "actor %+v should be permitted to GetSavedQueries", actor)
+	})
+}
+
+func TestGetSchema(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Schema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSchema(ctx, &vtadminpb.GetSchemaRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+			Table:     "t1",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetSchema", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSchema(ctx, &vtadminpb.GetSchemaRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+			Table:     "t1",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetSchema", actor)
+	})
+}
+
+func TestGetSchemaDrift(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Schema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSchemaDrift(ctx, &vtadminpb.GetSchemaDriftRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to GetSchemaDrift", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetSchemaDrift", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSchemaDrift(ctx, &vtadminpb.GetSchemaDriftRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetSchemaDrift", actor)
+	})
+}
+
+func TestGetSchemaMigrations(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SchemaMigration",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "SchemaMigration",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSchemaMigrations(ctx, &vtadminpb.GetSchemaMigrationsRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.SchemaMigrations, "actor %+v should not be permitted to GetSchemaMigrations", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSchemaMigrations(ctx, &vtadminpb.GetSchemaMigrationsRequest{})
+		assert.Equal(t, []*vtadminpb.SchemaMigration{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "other-uuid", Keyspace: "otherks"}}}, resp.SchemaMigrations, "actor %+v should be permitted to GetSchemaMigrations", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSchemaMigrations(ctx, &vtadminpb.GetSchemaMigrationsRequest{})
+		assert.Len(t, resp.SchemaMigrations, 2, "actor %+v should be permitted to GetSchemaMigrations", actor)
+	})
+}
+
+func TestGetSchemas(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Schema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Schema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSchemas(ctx, &vtadminpb.GetSchemasRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Schemas, "actor %+v should not be permitted to GetSchemas", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSchemas(ctx, &vtadminpb.GetSchemasRequest{})
+		assert.NotEmpty(t, resp.Schemas, "actor %+v should be permitted to GetSchemas", actor)
+		schemaMap := map[string][]string{}
+		for _, schema := range resp.Schemas {
+			if _, ok := schemaMap[schema.Cluster.Id]; !ok {
+				schemaMap[schema.Cluster.Id] = []string{}
+			}
+			schemaMap[schema.Cluster.Id] = append(schemaMap[schema.Cluster.Id], schema.Keyspace)
+		}
+		assert.Equal(t, schemaMap, map[string][]string{"other": {"otherks"}}, "actor %+v should be permitted to GetSchemas", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSchemas(ctx, &vtadminpb.GetSchemasRequest{})
+		assert.NotEmpty(t, resp.Schemas, "actor %+v should be permitted to GetSchemas", actor)
+		schemaMap := map[string][]string{}
+		for _, schema := range resp.Schemas {
+			if _, ok := schemaMap[schema.Cluster.Id]; !ok {
+				schemaMap[schema.Cluster.Id] = []string{}
+			}
+			schemaMap[schema.Cluster.Id] = append(schemaMap[schema.Cluster.Id], schema.Keyspace)
+		}
+		assert.Equal(t, schemaMap, map[string][]string{"test": {"test"}, "other": {"otherks"}}, "actor %+v should be permitted to GetSchemas", actor)
+	})
+}
+
+func TestGetShardReplicationPositions(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "ShardReplicationPosition",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "ShardReplicationPosition",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetShardReplicationPositions(ctx, &vtadminpb.GetShardReplicationPositionsRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.ReplicationPositions, "actor %+v should not be permitted to GetShardReplicationPositions", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetShardReplicationPositions(ctx, &vtadminpb.GetShardReplicationPositionsRequest{})
+		assert.NotEmpty(t, resp.ReplicationPositions, "actor %+v should be permitted to GetShardReplicationPositions", actor)
+		posMap := map[string][]string{}
+		for _, pos := range resp.ReplicationPositions {
+			if _, ok := posMap[pos.Cluster.Id]; !ok {
+				posMap[pos.Cluster.Id] = []string{}
+			}
+			posMap[pos.Cluster.Id] = append(posMap[pos.Cluster.Id], fmt.Sprintf("%s/%s", pos.Keyspace, pos.Shard))
+		}
+		assert.Equal(t, posMap, map[string][]string{"other": {"otherks/-"}}, "actor %+v should be permitted to GetShardReplicationPositions", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetShardReplicationPositions(ctx, &vtadminpb.GetShardReplicationPositionsRequest{})
+		assert.NotEmpty(t, resp.ReplicationPositions, "actor %+v should be permitted to GetShardReplicationPositions", actor)
+		posMap := map[string][]string{}
+		for _, pos := range resp.ReplicationPositions {
+			if _, ok := posMap[pos.Cluster.Id]; !ok {
+				posMap[pos.Cluster.Id] = []string{}
+			}
+			posMap[pos.Cluster.Id] = append(posMap[pos.Cluster.Id], fmt.Sprintf("%s/%s", pos.Keyspace, pos.Shard))
+		}
+		assert.Equal(t, posMap, map[string][]string{"test": {"test/-"}, "other": {"otherks/-"}}, "actor %+v should be permitted to GetShardReplicationPositions", actor)
+	})
+}
+
+func TestGetSrvVSchema(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SrvVSchema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSrvVSchema(ctx, &vtadminpb.GetSrvVSchemaRequest{
+			ClusterId: "test",
+			Cell:      "zone1",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetSrvVSchema", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSrvVSchema(ctx, &vtadminpb.GetSrvVSchemaRequest{
+			ClusterId: "test",
+			Cell:      "zone1",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetSrvVSchema", actor)
+	})
+}
+
+func TestGetSrvVSchemas(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SrvVSchema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "SrvVSchema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetSrvVSchemas(ctx, &vtadminpb.GetSrvVSchemasRequest{})
+		require.NoError(t, err)
+		assert.Nil(t, resp.SrvVSchemas, "actor %+v should not be permitted to GetSrvVSchemas", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSrvVSchemas(ctx, &vtadminpb.GetSrvVSchemasRequest{})
+		assert.NotEmpty(t, resp.SrvVSchemas, "actor %+v should be permitted to GetSrvVSchemas", actor)
+		clusterCells := map[string][]string{}
+		for _, svs := range resp.SrvVSchemas {
+			if _, ok := clusterCells[svs.Cluster.Id]; !ok {
+				clusterCells[svs.Cluster.Id] = []string{}
+			}
+			clusterCells[svs.Cluster.Id] = append(clusterCells[svs.Cluster.Id], svs.Cell)
+		}
+		assert.Equal(t, clusterCells, map[string][]string{"other": {"other1"}}, "actor %+v should be permitted to GetSrvVSchemas", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetSrvVSchemas(ctx, &vtadminpb.GetSrvVSchemasRequest{})
+		assert.NotEmpty(t, resp.SrvVSchemas, "actor %+v should be permitted to GetSrvVSchemas", actor)
+		clusterCells := map[string][]string{}
+		for _, svs := range resp.SrvVSchemas {
+			if _, ok := clusterCells[svs.Cluster.Id]; !ok {
+				clusterCells[svs.Cluster.Id] = []string{}
+			}
+			clusterCells[svs.Cluster.Id] = append(clusterCells[svs.Cluster.Id], svs.Cell)
+		}
+		assert.Equal(t, clusterCells, map[string][]string{"test": {"zone1"}, "other": {"other1"}}, "actor %+v should be permitted to GetSrvVSchemas", actor)
+	})
+}
+
+func TestGetTablet(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Tablet",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetTablet(ctx, &vtadminpb.GetTabletRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to GetTablet", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetTablet", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetTablet(ctx, &vtadminpb.GetTabletRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetTablet", actor)
+	})
+}
+
+func TestGetTablets(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Tablet",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Tablet",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetTablets(ctx, &vtadminpb.GetTabletsRequest{})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Tablets, "actor %+v should not be permitted to GetTablets", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetTablets(ctx, &vtadminpb.GetTabletsRequest{})
+		assert.NotEmpty(t, resp.Tablets, "actor %+v should be permitted to GetTablets", actor)
+		clusterAliases := map[string][]string{}
+		for _, tablet := range resp.Tablets {
+			if _, ok := clusterAliases[tablet.Cluster.Id]; !ok {
+				clusterAliases[tablet.Cluster.Id] = []string{}
+			}
+			clusterAliases[tablet.Cluster.Id] = append(clusterAliases[tablet.Cluster.Id], topoproto.TabletAliasString(tablet.Tablet.Alias))
+		}
+		assert.Equal(t, clusterAliases, map[string][]string{"other": {"other1-0000000100"}}, "actor %+v should be permitted to GetTablets", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetTablets(ctx, &vtadminpb.GetTabletsRequest{})
+		assert.NotEmpty(t, resp.Tablets, "actor %+v should be permitted to GetTablets", actor)
+		clusterAliases := map[string][]string{}
+		for _, tablet := range resp.Tablets {
+			if _, ok := clusterAliases[tablet.Cluster.Id]; !ok {
+				clusterAliases[tablet.Cluster.Id] = []string{}
+			}
+			clusterAliases[tablet.Cluster.Id] = append(clusterAliases[tablet.Cluster.Id], topoproto.TabletAliasString(tablet.Tablet.Alias))
+		}
+		assert.Equal(t, clusterAliases, map[string][]string{"test": {"zone1-0000000100"}, "other": {"other1-0000000100"}}, "actor %+v should be permitted to GetTablets", actor)
+	})
+}
+
+func TestGetVSchema(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "VSchema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetVSchema(ctx, &vtadminpb.GetVSchemaRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetVSchema", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetVSchema(ctx, &vtadminpb.GetVSchemaRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetVSchema", actor)
+	})
+}
+
+func TestGetVSchemas(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "VSchema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "VSchema",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetVSchemas(ctx, &vtadminpb.GetVSchemasRequest{})
+		require.NoError(t, err)
+		assert.Nil(t, resp.VSchemas, "actor %+v should not be permitted to GetVSchemas", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetVSchemas(ctx, &vtadminpb.GetVSchemasRequest{})
+		assert.NotEmpty(t, resp.VSchemas, "actor %+v should be permitted to GetVSchemas", actor)
+		clusterKeyspaces := map[string][]string{}
+		for _, vs := range resp.VSchemas {
+			if _, ok := clusterKeyspaces[vs.Cluster.Id]; !ok {
+				clusterKeyspaces[vs.Cluster.Id] = []string{}
+			}
+			clusterKeyspaces[vs.Cluster.Id] = append(clusterKeyspaces[vs.Cluster.Id], vs.Name)
+		}
+		assert.Equal(t, clusterKeyspaces, map[string][]string{"other": {"otherks"}}, "actor %+v should be permitted to GetVSchemas", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetVSchemas(ctx, &vtadminpb.GetVSchemasRequest{})
+		assert.NotEmpty(t, resp.VSchemas, "actor %+v should be permitted to GetVSchemas", actor)
+		clusterKeyspaces := map[string][]string{}
+		for _, vs := range resp.VSchemas {
+			if _, ok := clusterKeyspaces[vs.Cluster.Id]; !ok {
+				clusterKeyspaces[vs.Cluster.Id] = []string{}
+			}
+			clusterKeyspaces[vs.Cluster.Id] = append(clusterKeyspaces[vs.Cluster.Id], vs.Name)
+		}
+		assert.Equal(t, clusterKeyspaces, map[string][]string{"test": {"test"}, "other": {"otherks"}}, "actor %+v should be permitted to GetVSchemas", actor)
+	})
+}
+
+func TestGetVtctlds(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Vtctld",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Vtctld",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetVtctlds(ctx, &vtadminpb.GetVtctldsRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Vtctlds, "actor %+v should not be permitted to GetVtctlds", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetVtctlds(ctx, &vtadminpb.GetVtctldsRequest{})
+		assert.NotEmpty(t, resp.Vtctlds, "actor %+v should be permitted to GetVtctlds", actor)
+		// testutil.BuildCluster creates exactly one gate per cluster
+		assert.Len(t, resp.Vtctlds, 1, "actor %+v should only be able to see Vtctld from cluster 'other'", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetVtctlds(ctx, &vtadminpb.GetVtctldsRequest{})
+		assert.NotEmpty(t, resp.Vtctlds, "actor %+v should be permitted to GetVtctlds", actor)
+		// testutil.BuildCluster creates exactly one gate per cluster
+		assert.Len(t, resp.Vtctlds, 2, "actor %+v should be able to see Vtctld from all clusters", actor)
+	})
+}
+
+func TestGetWorkflow(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Workflow",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetWorkflow(ctx, &vtadminpb.GetWorkflowRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+			Name:      "testworkflow",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetWorkflow", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetWorkflow(ctx, &vtadminpb.GetWorkflowRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+			Name:      "testworkflow",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetWorkflow", actor)
+	})
+}
+
+func TestGetWorkflows(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Workflow",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Workflow",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.GetWorkflows(ctx, &vtadminpb.GetWorkflowsRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.WorkflowsByCluster, "actor %+v should not be permitted to GetWorkflows", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetWorkflows(ctx, &vtadminpb.GetWorkflowsRequest{})
+		assert.NotEmpty(t, resp.WorkflowsByCluster, "actor %+v should be permitted to GetWorkflows", actor)
+		assert.Equal(t, resp.WorkflowsByCluster, map[string]*vtadminpb.ClusterWorkflows{"other": {Workflows: []*vtadminpb.Workflow{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Keyspace: "otherks", Workflow: &vtctldatapb.Workflow{Name: "otherks_workflow"}}}}}, "actor %+v should be permitted to GetWorkflows", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.GetWorkflows(ctx, &vtadminpb.GetWorkflowsRequest{})
+		assert.NotEmpty(t, resp.WorkflowsByCluster, "actor %+v should be permitted to GetWorkflows", actor)
+		assert.Equal(t, resp.WorkflowsByCluster, map[string]*vtadminpb.ClusterWorkflows{"test": {Workflows: []*vtadminpb.Workflow{{Cluster: &vtadminpb.Cluster{Id: "test", Name: "test"}, Keyspace: "test", Workflow: &vtctldatapb.Workflow{Name: "testworkflow"}}}}, "other": {Workflows: []*vtadminpb.Workflow{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Keyspace: "otherks", Workflow: &vtctldatapb.Workflow{Name: "otherks_workflow"}}}}}, "actor %+v should be permitted to GetWorkflows", actor)
+	})
+}
+
+func TestLaunchSchemaMigration(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SchemaMigration",
+					Actions:  []string{"launch_schema_migration"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.LaunchSchemaMigration(ctx, &vtadminpb.LaunchSchemaMigrationRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.LaunchSchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to LaunchSchemaMigration", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to LaunchSchemaMigration", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.LaunchSchemaMigration(ctx, &vtadminpb.LaunchSchemaMigrationRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.LaunchSchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to LaunchSchemaMigration", actor)
+	})
+}
+
+func TestMoveTablesComplete(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Workflow",
+					Actions:  []string{"complete_workflow"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.MoveTablesComplete(ctx, &vtadminpb.MoveTablesCompleteRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.MoveTablesCompleteRequest{
+				Workflow:       "testworkflow",
+				TargetKeyspace: "test",
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to MoveTablesComplete", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to MoveTablesComplete", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.MoveTablesComplete(ctx, &vtadminpb.MoveTablesCompleteRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.MoveTablesCompleteRequest{
+				Workflow:       "testworkflow",
+				TargetKeyspace: "test",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to MoveTablesComplete", actor)
+	})
+}
+
+func TestMoveTablesCreate(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Workflow",
+					Actions:  []string{"create"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.MoveTablesCreate(ctx, &vtadminpb.MoveTablesCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "newworkflow",
+				SourceKeyspace: "test",
+				TargetKeyspace: "reshardks",
+				IncludeTables:  []string{"t1"},
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to MoveTablesCreate", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to MoveTablesCreate", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.MoveTablesCreate(ctx, &vtadminpb.MoveTablesCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "newworkflow",
+				SourceKeyspace: "test",
+				TargetKeyspace: "reshardks",
+				IncludeTables:  []string{"t1"},
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to MoveTablesCreate", actor)
+	})
+}
+
+func TestPingTablet(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Tablet",
+					Actions:  []string{"ping"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.PingTablet(ctx, &vtadminpb.PingTabletRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to PingTablet", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to PingTablet", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.PingTablet(ctx, &vtadminpb.PingTabletRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to PingTablet", actor)
+	})
+}
+
+func TestPlannedFailoverShard(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Shard",
+					Actions:  []string{"planned_failover_shard"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.PlannedFailoverShard(ctx, &vtadminpb.PlannedFailoverShardRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.PlannedReparentShardRequest{
+				Keyspace: "test",
+				Shard:    "-",
+			},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to PlannedFailoverShard", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.PlannedFailoverShard(ctx, &vtadminpb.PlannedFailoverShardRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.PlannedReparentShardRequest{
+				Keyspace: "test",
+				Shard:    "-",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to PlannedFailoverShard", actor)
+	})
+}
+
+func TestRefreshState(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Tablet",
+					Actions:  []string{"put"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.RefreshState(ctx, &vtadminpb.RefreshStateRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to RefreshState", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to RefreshState", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.RefreshState(ctx, &vtadminpb.RefreshStateRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to RefreshState", actor)
+	})
+}
+
+func TestRefreshTabletReplicationSource(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Tablet",
+					Actions:  []string{"refresh_tablet_replication_source"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.RefreshTabletReplicationSource(ctx, &vtadminpb.RefreshTabletReplicationSourceRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to RefreshTabletReplicationSource", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to RefreshTabletReplicationSource", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.RefreshTabletReplicationSource(ctx, &vtadminpb.RefreshTabletReplicationSourceRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to RefreshTabletReplicationSource", actor)
+	})
+}
+
+func TestReloadSchemas(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Schema",
+					Actions:  []string{"reload"},
+					Subjects: []string{"user:allowed-all"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Schema",
+					Actions:  []string{"reload"},
+					Subjects: []string{"user:allowed-other"},
+					Clusters: []string{"other"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "unauthorized"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.ReloadSchemas(ctx, &vtadminpb.ReloadSchemasRequest{
+			Keyspaces: []string{
+				"test",
+				"otherks",
+			},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.KeyspaceResults, "actor %+v should not be permitted to ReloadSchemas", actor)
+	})
+
+	t.Run("partial access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.ReloadSchemas(ctx, &vtadminpb.ReloadSchemasRequest{
+			Keyspaces: []string{
+				"test",
+				"otherks",
+			},
+		})
+		assert.NotEmpty(t, resp.KeyspaceResults, "actor %+v should be permitted to ReloadSchemas", actor)
+	})
+
+	t.Run("full access", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed-all"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, _ := api.ReloadSchemas(ctx, &vtadminpb.ReloadSchemasRequest{
+			Keyspaces: []string{
+				"test",
+				"otherks",
+			},
+		})
+		assert.NotEmpty(t, resp.KeyspaceResults, "actor %+v should be permitted to ReloadSchemas", actor)
+	})
+}
+
+func TestReshardCreate(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Workflow",
+					Actions:  []string{"create"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.ReshardCreate(ctx, &vtadminpb.ReshardCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "newworkflow",
+				Keyspace:     "reshardks",
+				SourceShards: []string{"-"},
+				TargetShards: []string{"-80", "80-"},
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to ReshardCreate", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ReshardCreate", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		resp, err := api.ReshardCreate(ctx, &vtadminpb.ReshardCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "newworkflow",
+				Keyspace:     "reshardks",
+				SourceShards: []string{"-"},
+				TargetShards: []string{"-80", "80-"},
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ReshardCreate", actor)
 	})
 }
 
-func TestGetWorkflow(t *testing.T) {
+func TestReshardRecommend(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2025,13 +3955,14 @@ func TestGetWorkflow(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetWorkflow(ctx, &vtadminpb.GetWorkflowRequest{
+		resp, err := api.ReshardRecommend(ctx, &vtadminpb.ReshardRecommendRequest{
 			ClusterId: "test",
-			Keyspace:  "test",
-			Name:      "testworkflow",
+			Options: &vtctldatapb.ReshardRecommendRequest{
+				Keyspace: "test",
+			},
 		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to GetWorkflow", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to ReshardRecommend", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ReshardRecommend", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2043,17 +3974,18 @@ func TestGetWorkflow(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.GetWorkflow(ctx, &vtadminpb.GetWorkflowRequest{
+		resp, err := api.ReshardRecommend(ctx, &vtadminpb.ReshardRecommendRequest{
 			ClusterId: "test",
-			Keyspace:  "test",
-			Name:      "testworkflow",
+			Options: &vtctldatapb.ReshardRecommendRequest{
+				Keyspace: "test",
+			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to GetWorkflow", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ReshardRecommend", actor)
 	})
 }
 
-func TestGetWorkflows(t *testing.T) {
+func TestRetrySchemaMigration(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2065,17 +3997,11 @@ func TestGetWorkflows(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Workflow",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "SchemaMigration",
+					Actions:  []string{"retry_schema_migration"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "Workflow",
-					Actions:  []string{"get"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -2092,47 +4018,45 @@ func TestGetWorkflows(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, err := api.GetWorkflows(ctx, &vtadminpb.GetWorkflowsRequest{})
-		require.NoError(t, err)
-		assert.Empty(t, resp.WorkflowsByCluster, "actor %+v should not be permitted to GetWorkflows", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetWorkflows(ctx, &vtadminpb.GetWorkflowsRequest{})
-		assert.NotEmpty(t, resp.WorkflowsByCluster, "actor %+v should be permitted to GetWorkflows", actor)
-		assert.Equal(t, resp.WorkflowsByCluster, map[string]*vtadminpb.ClusterWorkflows{"other": {Workflows: []*vtadminpb.Workflow{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Keyspace: "otherks", Workflow: &vtctldatapb.Workflow{Name: "otherks_workflow"}}}}}, "actor %+v should be permitted to GetWorkflows", actor)
+		resp, err := api.RetrySchemaMigration(ctx, &vtadminpb.RetrySchemaMigrationRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.RetrySchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to RetrySchemaMigration", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to RetrySchemaMigration", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.GetWorkflows(ctx, &vtadminpb.GetWorkflowsRequest{})
-		assert.NotEmpty(t, resp.WorkflowsByCluster, "actor %+v should be permitted to GetWorkflows", actor)
-		assert.Equal(t, resp.WorkflowsByCluster, map[string]*vtadminpb.ClusterWorkflows{"test": {Workflows: []*vtadminpb.Workflow{{Cluster: &vtadminpb.Cluster{Id: "test", Name: "test"}, Keyspace: "test", Workflow: &vtctldatapb.Workflow{Name: "testworkflow"}}}}, "other": {Workflows: []*vtadminpb.Workflow{{Cluster: &vtadminpb.Cluster{Id: "other", Name: "other"}, Keyspace: "otherks", Workflow: &vtctldatapb.Workflow{Name: "otherks_workflow"}}}}}, "actor %+v should be permitted to GetWorkflows", actor)
+		resp, err := api.RetrySchemaMigration(ctx, &vtadminpb.RetrySchemaMigrationRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.RetrySchemaMigrationRequest{
+				Keyspace: "test",
+				Uuid:     "test-uuid",
+			},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to RetrySchemaMigration", actor)
 	})
 }
 
-func TestPingTablet(t *testing.T) {
+func TestRunHealthCheck(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2145,7 +4069,7 @@ func TestPingTablet(t *testing.T) {
 			}{
 				{
 					Resource: "Tablet",
-					Actions:  []string{"ping"},
+					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2171,14 +4095,14 @@ func TestPingTablet(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.PingTablet(ctx, &vtadminpb.PingTabletRequest{
+		resp, err := api.RunHealthCheck(ctx, &vtadminpb.RunHealthCheckRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to PingTablet", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to PingTablet", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to RunHealthCheck", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to RunHealthCheck", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2190,18 +4114,18 @@ func TestPingTablet(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.PingTablet(ctx, &vtadminpb.PingTabletRequest{
+		resp, err := api.RunHealthCheck(ctx, &vtadminpb.RunHealthCheckRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to PingTablet", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to RunHealthCheck", actor)
 	})
 }
 
-func TestPlannedFailoverShard(t *testing.T) {
+func TestRunSavedQuery(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2213,8 +4137,8 @@ func TestPlannedFailoverShard(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Shard",
-					Actions:  []string{"planned_failover_shard"},
+					Resource: "SavedQuery",
+					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2240,15 +4164,12 @@ func TestPlannedFailoverShard(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.PlannedFailoverShard(ctx, &vtadminpb.PlannedFailoverShardRequest{
+		resp, err := api.RunSavedQuery(ctx, &vtadminpb.RunSavedQueryRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.PlannedReparentShardRequest{
-				Keyspace: "test",
-				Shard:    "-",
-			},
+			Id:        "test",
 		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to PlannedFailoverShard", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to RunSavedQuery", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to RunSavedQuery", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2260,19 +4181,17 @@ func TestPlannedFailoverShard(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.PlannedFailoverShard(ctx, &vtadminpb.PlannedFailoverShardRequest{
+		resp, err := api.RunSavedQuery(ctx, &vtadminpb.RunSavedQueryRequest{
 			ClusterId: "test",
-			Options: &vtctldatapb.PlannedReparentShardRequest{
-				Keyspace: "test",
-				Shard:    "-",
-			},
+			Id:        "test",
 		})
-		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to PlannedFailoverShard", actor)
+		// the saved queries of the test API start out empty
+		assert.ErrorContains(t, err, "no such saved query", "actor %+v should be permitted to RunSavedQuery", actor)
+		assert.Nil(t, resp, "actor %+v should be permitted to RunSavedQuery", actor)
 	})
 }
 
-func TestRefreshState(t *testing.T) {
+func TestSetReadOnly(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2285,7 +4204,7 @@ func TestRefreshState(t *testing.T) {
 			}{
 				{
 					Resource: "Tablet",
-					Actions:  []string{"put"},
+					Actions:  []string{"manage_tablet_writability"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2311,14 +4230,14 @@ func TestRefreshState(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.RefreshState(ctx, &vtadminpb.RefreshStateRequest{
+		resp, err := api.SetReadOnly(ctx, &vtadminpb.SetReadOnlyRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to RefreshState", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to RefreshState", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to SetReadOnly", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to SetReadOnly", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2330,18 +4249,18 @@ func TestRefreshState(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.RefreshState(ctx, &vtadminpb.RefreshStateRequest{
+		resp, err := api.SetReadOnly(ctx, &vtadminpb.SetReadOnlyRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to RefreshState", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to SetReadOnly", actor)
 	})
 }
 
-func TestRefreshTabletReplicationSource(t *testing.T) {
+func TestSetReadWrite(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2354,7 +4273,7 @@ func TestRefreshTabletReplicationSource(t *testing.T) {
 			}{
 				{
 					Resource: "Tablet",
-					Actions:  []string{"refresh_tablet_replication_source"},
+					Actions:  []string{"manage_tablet_writability"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2380,14 +4299,14 @@ func TestRefreshTabletReplicationSource(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.RefreshTabletReplicationSource(ctx, &vtadminpb.RefreshTabletReplicationSourceRequest{
+		resp, err := api.SetReadWrite(ctx, &vtadminpb.SetReadWriteRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to RefreshTabletReplicationSource", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to RefreshTabletReplicationSource", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to SetReadWrite", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to SetReadWrite", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2399,18 +4318,18 @@ func TestRefreshTabletReplicationSource(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.RefreshTabletReplicationSource(ctx, &vtadminpb.RefreshTabletReplicationSourceRequest{
+		resp, err := api.SetReadWrite(ctx, &vtadminpb.SetReadWriteRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to RefreshTabletReplicationSource", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to SetReadWrite", actor)
 	})
 }
 
-func TestReloadSchemas(t *testing.T) {
+func TestStartReplication(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2422,17 +4341,11 @@ func TestReloadSchemas(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Schema",
-					Actions:  []string{"reload"},
-					Subjects: []string{"user:allowed-all"},
+					Resource: "Tablet",
+					Actions:  []string{"manage_tablet_replication"},
+					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
-				{
-					Resource: "Schema",
-					Actions:  []string{"reload"},
-					Subjects: []string{"user:allowed-other"},
-					Clusters: []string{"other"},
-				},
 			},
 		},
 	}
@@ -2449,60 +4362,43 @@ func TestReloadSchemas(t *testing.T) {
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "unauthorized"}
-		ctx := context.Background()
-		if actor != nil {
-			ctx = rbac.NewContext(ctx, actor)
-		}
-
-		resp, err := api.ReloadSchemas(ctx, &vtadminpb.ReloadSchemasRequest{
-			Keyspaces: []string{
-				"test",
-				"otherks",
-			},
-		})
-		require.NoError(t, err)
-		assert.Empty(t, resp.KeyspaceResults, "actor %+v should not be permitted to ReloadSchemas", actor)
-	})
-
-	t.Run("partial access", func(t *testing.T) {
-		t.Parallel()
-
-		actor := &rbac.Actor{Name: "allowed-other"}
+		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.ReloadSchemas(ctx, &vtadminpb.ReloadSchemasRequest{
-			Keyspaces: []string{
-				"test",
-				"otherks",
+		resp, err := api.StartReplication(ctx, &vtadminpb.StartReplicationRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
 			},
 		})
-		assert.NotEmpty(t, resp.KeyspaceResults, "actor %+v should be permitted to ReloadSchemas", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to StartReplication", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to StartReplication", actor)
 	})
 
-	t.Run("full access", func(t *testing.T) {
+	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		actor := &rbac.Actor{Name: "allowed-all"}
+		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, _ := api.ReloadSchemas(ctx, &vtadminpb.ReloadSchemasRequest{
-			Keyspaces: []string{
-				"test",
-				"otherks",
+		resp, err := api.StartReplication(ctx, &vtadminpb.StartReplicationRequest{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
 			},
 		})
-		assert.NotEmpty(t, resp.KeyspaceResults, "actor %+v should be permitted to ReloadSchemas", actor)
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to StartReplication", actor)
 	})
 }
 
-func TestRunHealthCheck(t *testing.T) {
+func TestStopReplication(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2515,7 +4411,7 @@ func TestRunHealthCheck(t *testing.T) {
 			}{
 				{
 					Resource: "Tablet",
-					Actions:  []string{"get"},
+					Actions:  []string{"manage_tablet_replication"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2541,14 +4437,14 @@ func TestRunHealthCheck(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.RunHealthCheck(ctx, &vtadminpb.RunHealthCheckRequest{
+		resp, err := api.StopReplication(ctx, &vtadminpb.StopReplicationRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to RunHealthCheck", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to RunHealthCheck", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to StopReplication", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to StopReplication", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2560,18 +4456,18 @@ func TestRunHealthCheck(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.RunHealthCheck(ctx, &vtadminpb.RunHealthCheckRequest{
+		resp, err := api.StopReplication(ctx, &vtadminpb.StopReplicationRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to RunHealthCheck", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to StopReplication", actor)
 	})
 }
 
-func TestSetReadOnly(t *testing.T) {
+func TestTabletExternallyPromoted(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2583,8 +4479,8 @@ func TestSetReadOnly(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Tablet",
-					Actions:  []string{"manage_tablet_writability"},
+					Resource: "Shard",
+					Actions:  []string{"tablet_externally_promoted"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2610,14 +4506,14 @@ func TestSetReadOnly(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.SetReadOnly(ctx, &vtadminpb.SetReadOnlyRequest{
+		resp, err := api.TabletExternallyPromoted(ctx, &vtadminpb.TabletExternallyPromotedRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to SetReadOnly", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to SetReadOnly", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to TabletExternallyPromoted", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to TabletExternallyPromoted", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2629,18 +4525,18 @@ func TestSetReadOnly(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.SetReadOnly(ctx, &vtadminpb.SetReadOnlyRequest{
+		resp, err := api.TabletExternallyPromoted(ctx, &vtadminpb.TabletExternallyPromotedRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to SetReadOnly", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to TabletExternallyPromoted", actor)
 	})
 }
 
-func TestSetReadWrite(t *testing.T) {
+func TestUndrainTablet(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2653,7 +4549,7 @@ func TestSetReadWrite(t *testing.T) {
 			}{
 				{
 					Resource: "Tablet",
-					Actions:  []string{"manage_tablet_writability"},
+					Actions:  []string{"manage_tablet_drain"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2679,14 +4575,14 @@ func TestSetReadWrite(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.SetReadWrite(ctx, &vtadminpb.SetReadWriteRequest{
+		resp, err := api.UndrainTablet(ctx, &vtadminpb.UndrainTabletRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to SetReadWrite", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to SetReadWrite", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to UndrainTablet", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to UndrainTablet", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2698,18 +4594,18 @@ func TestSetReadWrite(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.SetReadWrite(ctx, &vtadminpb.SetReadWriteRequest{
+		resp, err := api.UndrainTablet(ctx, &vtadminpb.UndrainTabletRequest{
 			Alias: &topodatapb.TabletAlias{
 				Cell: "zone1",
 				Uid:  100,
 			},
 		})
-		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to SetReadWrite", actor)
+		assert.ErrorContains(t, err, "is not drained", "actor %+v should be permitted to undrain the tablet, which is not drained", actor)
+		assert.Nil(t, resp, "actor %+v should be permitted to UndrainTablet", actor)
 	})
 }
 
-func TestStartReplication(t *testing.T) {
+func TestVTExplain(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2721,8 +4617,8 @@ func TestStartReplication(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Tablet",
-					Actions:  []string{"manage_tablet_replication"},
+					Resource: "VTExplain",
+					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2732,53 +4628,56 @@ func TestStartReplication(t *testing.T) {
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
-	api := vtadmin.NewAPI(testClusters(t), opts)
-	t.Cleanup(func() {
-		if err := api.Close(); err != nil {
-			t.Logf("api did not close cleanly: %s", err.Error())
-		}
-	})
-
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
 		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
-
-		resp, err := api.StartReplication(ctx, &vtadminpb.StartReplicationRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
-			},
-		})
-		assert.Error(t, err, "actor %+v should not be permitted to StartReplication", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to StartReplication", actor)
+
+		resp, err := api.VTExplain(ctx, &vtadminpb.VTExplainRequest{
+			Cluster:  "test",
+			Keyspace: "test",
+			Sql:      "select id from t1;"})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to VTExplain", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
+		api := vtadmin.NewAPI(testClusters(t), opts)
+		t.Cleanup(func() {
+			if err := api.Close(); err != nil {
+				t.Logf("api did not close cleanly: %s", err.Error())
+			}
+		})
+
 		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.StartReplication(ctx, &vtadminpb.StartReplicationRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
-			},
-		})
+		resp, err := api.VTExplain(ctx, &vtadminpb.VTExplainRequest{
+			Cluster:  "test",
+			Keyspace: "test",
+			Sql:      "select id from t1;"})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to StartReplication", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to VTExplain", actor)
 	})
 }
 
-func TestStopReplication(t *testing.T) {
+func TestValidateKeyspace(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2790,8 +4689,8 @@ func TestStopReplication(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Tablet",
-					Actions:  []string{"manage_tablet_replication"},
+					Resource: "Keyspace",
+					Actions:  []string{"put"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2817,14 +4716,12 @@ func TestStopReplication(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.StopReplication(ctx, &vtadminpb.StopReplicationRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
-			},
+		resp, err := api.ValidateKeyspace(ctx, &vtadminpb.ValidateKeyspaceRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to StopReplication", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to StopReplication", actor)
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateKeyspace", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2836,18 +4733,16 @@ func TestStopReplication(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.StopReplication(ctx, &vtadminpb.StopReplicationRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
-			},
+		resp, err := api.ValidateKeyspace(ctx, &vtadminpb.ValidateKeyspaceRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to StopReplication", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateKeyspace", actor)
 	})
 }
 
-func TestTabletExternallyPromoted(t *testing.T) {
+func TestValidateMoveTablesCreate(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2859,8 +4754,8 @@ func TestTabletExternallyPromoted(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Shard",
-					Actions:  []string{"tablet_externally_promoted"},
+					Resource: "Workflow",
+					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -2886,14 +4781,17 @@ func TestTabletExternallyPromoted(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.TabletExternallyPromoted(ctx, &vtadminpb.TabletExternallyPromotedRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
+		resp, err := api.ValidateMoveTablesCreate(ctx, &vtadminpb.MoveTablesCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "newworkflow",
+				SourceKeyspace: "test",
+				TargetKeyspace: "reshardks",
+				IncludeTables:  []string{"t1"},
 			},
 		})
-		assert.Error(t, err, "actor %+v should not be permitted to TabletExternallyPromoted", actor)
-		assert.Nil(t, resp, "actor %+v should not be permitted to TabletExternallyPromoted", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to ValidateMoveTablesCreate", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateMoveTablesCreate", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -2905,18 +4803,21 @@ func TestTabletExternallyPromoted(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.TabletExternallyPromoted(ctx, &vtadminpb.TabletExternallyPromotedRequest{
-			Alias: &topodatapb.TabletAlias{
-				Cell: "zone1",
-				Uid:  100,
+		resp, err := api.ValidateMoveTablesCreate(ctx, &vtadminpb.MoveTablesCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "newworkflow",
+				SourceKeyspace: "test",
+				TargetKeyspace: "reshardks",
+				IncludeTables:  []string{"t1"},
 			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to TabletExternallyPromoted", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateMoveTablesCreate", actor)
 	})
 }
 
-func TestVTExplain(t *testing.T) {
+func TestValidateReshardCreate(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -2928,7 +4829,7 @@ func TestVTExplain(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "VTExplain",
+					Resource: "Workflow",
 					Actions:  []string{"get"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
@@ -2939,56 +4840,59 @@ func TestVTExplain(t *testing.T) {
 	err := opts.RBAC.Reify()
 	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
 
+	api := vtadmin.NewAPI(testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
 	t.Run("unauthorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
 		actor := &rbac.Actor{Name: "other"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.VTExplain(ctx, &vtadminpb.VTExplainRequest{
-			Cluster:  "test",
-			Keyspace: "test",
-			Sql:      "select id from t1;"})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to VTExplain", actor)
+		resp, err := api.ValidateReshardCreate(ctx, &vtadminpb.ReshardCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "newworkflow",
+				Keyspace:     "reshardks",
+				SourceShards: []string{"-"},
+				TargetShards: []string{"-80", "80-"},
+			},
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to ValidateReshardCreate", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateReshardCreate", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
 		t.Parallel()
 
-		api := vtadmin.NewAPI(testClusters(t), opts)
-		t.Cleanup(func() {
-			if err := api.Close(); err != nil {
-				t.Logf("api did not close cleanly: %s", err.Error())
-			}
-		})
-
 		actor := &rbac.Actor{Name: "allowed"}
 		ctx := context.Background()
 		if actor != nil {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.VTExplain(ctx, &vtadminpb.VTExplainRequest{
-			Cluster:  "test",
-			Keyspace: "test",
-			Sql:      "select id from t1;"})
+		resp, err := api.ValidateReshardCreate(ctx, &vtadminpb.ReshardCreateRequest{
+			ClusterId: "test",
+			Options: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "newworkflow",
+				Keyspace:     "reshardks",
+				SourceShards: []string{"-"},
+				TargetShards: []string{"-80", "80-"},
+			},
+		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to VTExplain", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateReshardCreate", actor)
 	})
 }
 
-func TestValidateKeyspace(t *testing.T) {
+func TestValidateSchemaKeyspace(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -3027,12 +4931,12 @@ func TestValidateKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.ValidateKeyspace(ctx, &vtadminpb.ValidateKeyspaceRequest{
+		resp, err := api.ValidateSchemaKeyspace(ctx, &vtadminpb.ValidateSchemaKeyspaceRequest{
 			ClusterId: "test",
 			Keyspace:  "test",
 		})
 		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateKeyspace", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateSchemaKeyspace", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -3044,16 +4948,16 @@ func TestValidateKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.ValidateKeyspace(ctx, &vtadminpb.ValidateKeyspaceRequest{
+		resp, err := api.ValidateSchemaKeyspace(ctx, &vtadminpb.ValidateSchemaKeyspaceRequest{
 			ClusterId: "test",
 			Keyspace:  "test",
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateKeyspace", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateSchemaKeyspace", actor)
 	})
 }
 
-func TestValidateSchemaKeyspace(t *testing.T) {
+func TestValidateVersionKeyspace(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -3092,12 +4996,12 @@ func TestValidateSchemaKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.ValidateSchemaKeyspace(ctx, &vtadminpb.ValidateSchemaKeyspaceRequest{
+		resp, err := api.ValidateVersionKeyspace(ctx, &vtadminpb.ValidateVersionKeyspaceRequest{
 			ClusterId: "test",
 			Keyspace:  "test",
 		})
 		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateSchemaKeyspace", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateVersionKeyspace", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -3109,16 +5013,16 @@ func TestValidateSchemaKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.ValidateSchemaKeyspace(ctx, &vtadminpb.ValidateSchemaKeyspaceRequest{
+		resp, err := api.ValidateVersionKeyspace(ctx, &vtadminpb.ValidateVersionKeyspaceRequest{
 			ClusterId: "test",
 			Keyspace:  "test",
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateSchemaKeyspace", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateVersionKeyspace", actor)
 	})
 }
 
-func TestValidateVersionKeyspace(t *testing.T) {
+func TestWorkflowSwitchTraffic(t *testing.T) {
 	t.Parallel()
 
 	opts := vtadmin.Options{
@@ -3130,8 +5034,8 @@ func TestValidateVersionKeyspace(t *testing.T) {
 				Clusters []string
 			}{
 				{
-					Resource: "Keyspace",
-					Actions:  []string{"put"},
+					Resource: "Workflow",
+					Actions:  []string{"switch_workflow_traffic"},
 					Subjects: []string{"user:allowed"},
 					Clusters: []string{"*"},
 				},
@@ -3157,12 +5061,15 @@ func TestValidateVersionKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.ValidateVersionKeyspace(ctx, &vtadminpb.ValidateVersionKeyspaceRequest{
+		resp, err := api.WorkflowSwitchTraffic(ctx, &vtadminpb.WorkflowSwitchTrafficRequest{
 			ClusterId: "test",
-			Keyspace:  "test",
+			Options: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace: "test",
+				Workflow: "testworkflow",
+			},
 		})
-		require.NoError(t, err)
-		assert.Nil(t, resp, "actor %+v should not be permitted to ValidateVersionKeyspace", actor)
+		assert.Error(t, err, "actor %+v should not be permitted to WorkflowSwitchTraffic", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to WorkflowSwitchTraffic", actor)
 	})
 
 	t.Run("authorized actor", func(t *testing.T) {
@@ -3174,12 +5081,15 @@ func TestValidateVersionKeyspace(t *testing.T) {
 			ctx = rbac.NewContext(ctx, actor)
 		}
 
-		resp, err := api.ValidateVersionKeyspace(ctx, &vtadminpb.ValidateVersionKeyspaceRequest{
+		resp, err := api.WorkflowSwitchTraffic(ctx, &vtadminpb.WorkflowSwitchTrafficRequest{
 			ClusterId: "test",
-			Keyspace:  "test",
+			Options: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace: "test",
+				Workflow: "testworkflow",
+			},
 		})
 		require.NoError(t, err)
-		assert.NotNil(t, resp, "actor %+v should be permitted to ValidateVersionKeyspace", actor)
+		assert.NotNil(t, resp, "actor %+v should be permitted to WorkflowSwitchTraffic", actor)
 	})
 }
 
@@ -3191,6 +5101,56 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 				Name: "test",
 			},
 			VtctldClient: &fakevtctldclient.VtctldClient{
+				ApplySchemaResults: map[string]struct {
+					Response *vtctldatapb.ApplySchemaResponse
+					Error    error
+				}{
+					"test": {
+						Response: &vtctldatapb.ApplySchemaResponse{
+							UuidList: []string{"test-uuid"},
+						},
+					},
+				},
+				CancelSchemaMigrationResults: map[string]struct {
+					Response *vtctldatapb.CancelSchemaMigrationResponse
+					Error    error
+				}{
+					"test/test-uuid": {
+						Response: &vtctldatapb.CancelSchemaMigrationResponse{},
+					},
+				},
+				ChangeTabletTagsResults: map[string]struct {
+					Response *vtctldatapb.ChangeTabletTagsResponse
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Response: &vtctldatapb.ChangeTabletTagsResponse{},
+					},
+				},
+				ChangeTabletTypeResults: map[string]struct {
+					Response *vtctldatapb.ChangeTabletTypeResponse
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Response: &vtctldatapb.ChangeTabletTypeResponse{},
+					},
+				},
+				CleanupSchemaMigrationResults: map[string]struct {
+					Response *vtctldatapb.CleanupSchemaMigrationResponse
+					Error    error
+				}{
+					"test/test-uuid": {
+						Response: &vtctldatapb.CleanupSchemaMigrationResponse{},
+					},
+				},
+				CompleteSchemaMigrationResults: map[string]struct {
+					Response *vtctldatapb.CompleteSchemaMigrationResponse
+					Error    error
+				}{
+					"test/test-uuid": {
+						Response: &vtctldatapb.CompleteSchemaMigrationResponse{},
+					},
+				},
 				DeleteShardsResults: map[string]error{
 					"test/-": nil,
 				},
@@ -3223,6 +5183,34 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 							},
 						},
 					},
+					"reshardks": {
+						Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+							Shards: map[string]*vtctldatapb.Shard{
+								"-": {
+									Keyspace: "reshardks",
+									Name:     "-",
+									Shard: &topodatapb.Shard{
+										KeyRange:         &topodatapb.KeyRange{},
+										IsPrimaryServing: true,
+									},
+								},
+								"-80": {
+									Keyspace: "reshardks",
+									Name:     "-80",
+									Shard: &topodatapb.Shard{
+										KeyRange: &topodatapb.KeyRange{End: []byte{0x80}},
+									},
+								},
+								"80-": {
+									Keyspace: "reshardks",
+									Name:     "80-",
+									Shard: &topodatapb.Shard{
+										KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}},
+									},
+								},
+							},
+						},
+					},
 				},
 				GetBackupsResults: map[string]struct {
 					Response *vtctldatapb.GetBackupsResponse
@@ -3267,6 +5255,14 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 							},
 						},
 					},
+					"reshardks": {
+						Response: &vtctldatapb.GetKeyspaceResponse{
+							Keyspace: &vtctldatapb.Keyspace{
+								Name:     "reshardks",
+								Keyspace: &topodatapb.Keyspace{},
+							},
+						},
+					},
 				},
 				GetKeyspacesResults: &struct {
 					Keyspaces []*vtctldatapb.Keyspace
@@ -3288,12 +5284,24 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 							Schema: &tabletmanagerdatapb.SchemaDefinition{
 								TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
 									{Name: "t1", Schema: "create table t1 (id int(11) not null primary key);"},
-									{Name: "t2"},
+									{Name: "t2", Schema: "create table t2 (id int(11) not null primary key);"},
 								},
 							},
 						},
 					},
 				},
+				GetSchemaMigrationsResults: map[string]struct {
+					Response *vtctldatapb.GetSchemaMigrationsResponse
+					Error    error
+				}{
+					"test": {
+						Response: &vtctldatapb.GetSchemaMigrationsResponse{
+							Migrations: []*vtctldatapb.SchemaMigration{
+								{Uuid: "test-uuid", Keyspace: "test"},
+							},
+						},
+					},
+				},
 				GetSrvVSchemaResults: map[string]struct {
 					Response *vtctldatapb.GetSrvVSchemaResponse
 					Error    error
@@ -3334,6 +5342,23 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 							VSchema: &vschemapb.Keyspace{},
 						},
 					},
+					"reshardks": {
+						Response: &vtctldatapb.GetVSchemaResponse{
+							VSchema: &vschemapb.Keyspace{
+								Sharded: true,
+								Tables: map[string]*vschemapb.Table{
+									"t1": {
+										ColumnVindexes: []*vschemapb.ColumnVindex{
+											{
+												Name:   "hash",
+												Column: "id",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 				GetWorkflowsResults: map[string]struct {
 					Response *vtctldatapb.GetWorkflowsResponse
@@ -3347,6 +5372,33 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 								},
 							},
 						}},
+					"reshardks": {
+						Response: &vtctldatapb.GetWorkflowsResponse{},
+					},
+				},
+				LaunchSchemaMigrationResults: map[string]struct {
+					Response *vtctldatapb.LaunchSchemaMigrationResponse
+					Error    error
+				}{
+					"test/test-uuid": {
+						Response: &vtctldatapb.LaunchSchemaMigrationResponse{},
+					},
+				},
+				MoveTablesCompleteResults: map[string]struct {
+					Response *vtctldatapb.MoveTablesCompleteResponse
+					Error    error
+				}{
+					"test/testworkflow": {
+						Response: &vtctldatapb.MoveTablesCompleteResponse{},
+					},
+				},
+				MoveTablesCreateResults: map[string]struct {
+					Response *vtctldatapb.WorkflowStatusResponse
+					Error    error
+				}{
+					"reshardks": {
+						Response: &vtctldatapb.WorkflowStatusResponse{},
+					},
 				},
 				PingTabletResults: map[string]error{
 					"zone1-0000000100": nil,
@@ -3379,6 +5431,30 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 						Response: &vtctldatapb.ReparentTabletResponse{},
 					},
 				},
+				ReshardCreateResults: map[string]struct {
+					Response *vtctldatapb.WorkflowStatusResponse
+					Error    error
+				}{
+					"reshardks": {
+						Response: &vtctldatapb.WorkflowStatusResponse{},
+					},
+				},
+				ReshardRecommendResults: map[string]struct {
+					Response *vtctldatapb.ReshardRecommendResponse
+					Error    error
+				}{
+					"test": {
+						Response: &vtctldatapb.ReshardRecommendResponse{},
+					},
+				},
+				RetrySchemaMigrationResults: map[string]struct {
+					Response *vtctldatapb.RetrySchemaMigrationResponse
+					Error    error
+				}{
+					"test/test-uuid": {
+						Response: &vtctldatapb.RetrySchemaMigrationResponse{},
+					},
+				},
 				RunHealthCheckResults: map[string]error{
 					"zone1-0000000100": nil,
 				},
@@ -3431,6 +5507,14 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 						Response: &vtctldatapb.ValidateVersionKeyspaceResponse{},
 					},
 				},
+				WorkflowSwitchTrafficResults: map[string]struct {
+					Response *vtctldatapb.WorkflowSwitchTrafficResponse
+					Error    error
+				}{
+					"test/testworkflow": {
+						Response: &vtctldatapb.WorkflowSwitchTrafficResponse{},
+					},
+				},
 			},
 			Tablets: []*vtadminpb.Tablet{
 				{
@@ -3441,11 +5525,22 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 							Uid:  100,
 						},
 						Keyspace: "test",
+						Shard:    "-",
 						Type:     topodatapb.TabletType_REPLICA,
 					},
 					State: vtadminpb.Tablet_SERVING,
 				},
 			},
+			DBConfig: testutil.Dbcfg{
+				QueryResults: map[string]map[string]*fakevtsql.QueryResult{
+					"test@replica": {
+						"select id from t1 limit 1001": {
+							Columns: []string{"id"},
+							Rows:    [][]any{{1}, {2}},
+						},
+					},
+				},
+			},
 			Config: &cluster.Config{
 				TopoReadPoolConfig: &cluster.RPCPoolConfig{
 					Size:        100,
@@ -3530,6 +5625,18 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 						},
 					},
 				},
+				GetSchemaMigrationsResults: map[string]struct {
+					Response *vtctldatapb.GetSchemaMigrationsResponse
+					Error    error
+				}{
+					"otherks": {
+						Response: &vtctldatapb.GetSchemaMigrationsResponse{
+							Migrations: []*vtctldatapb.SchemaMigration{
+								{Uuid: "other-uuid", Keyspace: "otherks"},
+							},
+						},
+					},
+				},
 				GetSrvVSchemaResults: map[string]struct {
 					Response *vtctldatapb.GetSrvVSchemaResponse
 					Error    error
@@ -22,14 +22,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/proto"
 
 	_flag "vitess.io/vitess/go/internal/flag"
@@ -40,8 +45,11 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/cluster"
 	"vitess.io/vitess/go/vt/vtadmin/cluster/discovery/fakediscovery"
 	vtadminerrors "vitess.io/vitess/go/vt/vtadmin/errors"
+	"vitess.io/vitess/go/vt/vtadmin/rbac"
+	"vitess.io/vitess/go/vt/vtadmin/savedqueries"
 	vtadmintestutil "vitess.io/vitess/go/vt/vtadmin/testutil"
 	"vitess.io/vitess/go/vt/vtadmin/vtctldclient/fakevtctldclient"
+	"vitess.io/vitess/go/vt/vtadmin/vtsql/fakevtsql"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
 	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
@@ -817,6 +825,209 @@ func TestFindSchema(t *testing.T) {
 	})
 }
 
+func TestGetAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	clusters := []*cluster.Cluster{
+		vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+			Cluster: &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+			VtctldClient: &fakevtctldclient.VtctldClient{
+				DeleteShardsResults: map[string]error{
+					"ks/-80": nil,
+					"ks/80-": fmt.Errorf("%w: shard not found", assert.AnError),
+				},
+			},
+		}),
+		vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+			Cluster:      &vtadminpb.Cluster{Id: "c2", Name: "cluster2"},
+			VtctldClient: &fakevtctldclient.VtctldClient{},
+		}),
+	}
+
+	opts := Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Cluster",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:alice"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Shard",
+					Actions:  []string{"delete"},
+					Subjects: []string{"user:alice"},
+					Clusters: []string{"c1"},
+				},
+				{
+					Resource: "AuditLog",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:auditor"},
+					Clusters: []string{"c1"},
+				},
+				{
+					Resource: "AuditLog",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:admin"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := NewAPI(clusters, opts)
+	defer api.Close()
+
+	serve := func(method string, target string) {
+		req := httptest.NewRequest(method, target, nil)
+		req = req.WithContext(rbac.NewContext(req.Context(), &rbac.Actor{Name: "alice"}))
+
+		api.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(http.MethodDelete, "/api/shards/c1?keyspace_shard=ks/-80")
+	serve(http.MethodDelete, "/api/shards/c1?keyspace_shard=ks/80-")
+	serve(http.MethodDelete, "/api/shards/c2?keyspace_shard=ks/-80")
+	// Read-only calls are not audited.
+	serve(http.MethodGet, "/api/clusters")
+
+	getEvents := func(actor string, req *vtadminpb.GetAuditEventsRequest) []*vtadminpb.AuditEvent {
+		ctx := rbac.NewContext(context.Background(), &rbac.Actor{Name: actor})
+
+		resp, err := api.GetAuditEvents(ctx, req)
+		require.NoError(t, err)
+
+		return resp.Events
+	}
+
+	events := getEvents("admin", &vtadminpb.GetAuditEventsRequest{})
+	require.Len(t, events, 3)
+
+	assert.Equal(t, vtadminpb.AuditEvent_UNAUTHORIZED, events[0].Outcome)
+	assert.Equal(t, []string{"c2"}, events[0].ClusterIds)
+	assert.Equal(t, vtadminpb.AuditEvent_FAILURE, events[1].Outcome)
+	assert.Contains(t, events[1].Error, "shard not found")
+	assert.Equal(t, vtadminpb.AuditEvent_SUCCESS, events[2].Outcome)
+
+	for _, event := range events {
+		assert.Equal(t, "alice", event.Actor)
+		assert.Equal(t, "DeleteShards", event.Method)
+		assert.Equal(t, "Shard", event.Resource)
+		assert.Equal(t, "delete", event.Action)
+		assert.Contains(t, event.Params, "keyspace_shard")
+	}
+
+	events = getEvents("auditor", &vtadminpb.GetAuditEventsRequest{})
+	require.Len(t, events, 2, "auditor should only see events in c1")
+	assert.Equal(t, []string{"c1"}, events[0].ClusterIds)
+	assert.Equal(t, []string{"c1"}, events[1].ClusterIds)
+
+	events = getEvents("auditor", &vtadminpb.GetAuditEventsRequest{ClusterIds: []string{"c2"}})
+	assert.Empty(t, events, "auditor should not see events in c2")
+
+	events = getEvents("admin", &vtadminpb.GetAuditEventsRequest{Actor: "bob"})
+	assert.Empty(t, events)
+
+	events = getEvents("admin", &vtadminpb.GetAuditEventsRequest{Limit: 1})
+	require.Len(t, events, 1)
+	assert.Equal(t, vtadminpb.AuditEvent_UNAUTHORIZED, events[0].Outcome)
+
+	events = getEvents("unauthorized", &vtadminpb.GetAuditEventsRequest{})
+	assert.Empty(t, events)
+}
+
+func TestAuditEventsGRPC(t *testing.T) {
+	t.Parallel()
+
+	clusters := []*cluster.Cluster{
+		vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+			Cluster: &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+			VtctldClient: &fakevtctldclient.VtctldClient{
+				DeleteShardsResults: map[string]error{
+					"ks/-80": nil,
+				},
+			},
+		}),
+	}
+
+	opts := Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "*",
+					Actions:  []string{"*"},
+					Subjects: []string{"user:alice"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	// Stands in for the authentication interceptor, which runs before the
+	// audit interceptor.
+	opts.GRPCOpts.UnaryInterceptors = []grpc.UnaryServerInterceptor{
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(rbac.NewContext(ctx, &rbac.Actor{Name: "alice"}), req)
+		},
+	}
+
+	api := NewAPI(clusters, opts)
+	defer api.Close()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go api.serv.GRPCServer().Serve(lis) // nolint:errcheck
+	defer api.serv.GRPCServer().Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := vtadminpb.NewVTAdminClient(conn)
+
+	_, err = client.DeleteShards(ctx, &vtadminpb.DeleteShardsRequest{
+		ClusterId: "c1",
+		Options: &vtctldatapb.DeleteShardsRequest{
+			Shards: []*vtctldatapb.Shard{{Keyspace: "ks", Name: "-80"}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Read-only calls are not audited.
+	_, err = client.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
+	require.NoError(t, err)
+
+	resp, err := client.GetAuditEvents(ctx, &vtadminpb.GetAuditEventsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+
+	event := resp.Events[0]
+	assert.Equal(t, vtadminpb.AuditEvent_SUCCESS, event.Outcome)
+	assert.Equal(t, "alice", event.Actor)
+	assert.Equal(t, "DeleteShards", event.Method)
+	assert.Equal(t, "Shard", event.Resource)
+	assert.Equal(t, "delete", event.Action)
+	assert.Equal(t, []string{"c1"}, event.ClusterIds)
+	assert.Contains(t, event.Params, `"keyspace":"ks"`)
+}
 func TestGetClusters(t *testing.T) {
 	t.Parallel()
 
@@ -4878,6 +5089,272 @@ func TestGetWorkflows(t *testing.T) {
 	}
 }
 
+func TestSavedQueries(t *testing.T) {
+	t.Parallel()
+
+	clusters := []*cluster.Cluster{
+		vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+			Cluster:      &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+			VtctldClient: &fakevtctldclient.VtctldClient{},
+			Tablets: []*vtadminpb.Tablet{
+				{
+					Cluster: &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+					Tablet: &topodatapb.Tablet{
+						Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+						Keyspace: "ks",
+						Shard:    "-",
+						Type:     topodatapb.TabletType_REPLICA,
+					},
+					State: vtadminpb.Tablet_SERVING,
+				},
+			},
+			DBConfig: vtadmintestutil.Dbcfg{
+				QueryResults: map[string]map[string]*fakevtsql.QueryResult{
+					"ks@replica": {
+						"select id, `name` from users where id = '2' limit 1001": {
+							Columns: []string{"id", "name"},
+							Rows:    [][]any{{2, "bob"}},
+						},
+					},
+				},
+			},
+		}),
+		vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+			Cluster:      &vtadminpb.Cluster{Id: "c2", Name: "cluster2"},
+			VtctldClient: &fakevtctldclient.VtctldClient{},
+		}),
+	}
+
+	opts := Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SavedQuery",
+					Actions:  []string{"*"},
+					Subjects: []string{"user:alice"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "Query",
+					Actions:  []string{"execute_query"},
+					Subjects: []string{"user:alice"},
+					Clusters: []string{"*"},
+				},
+				{
+					Resource: "SavedQuery",
+					Actions:  []string{"get", "create"},
+					Subjects: []string{"user:bob"},
+					Clusters: []string{"c1"},
+				},
+			},
+		},
+		// Keep the scheduler from running the scheduled query saved below.
+		ReportOpts: savedqueries.Options{
+			CheckInterval:       time.Hour,
+			WebhookAllowedHosts: []string{"hooks.example.com"},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := NewAPI(clusters, opts)
+	defer api.Close()
+
+	alice := rbac.NewContext(context.Background(), &rbac.Actor{Name: "alice"})
+	bob := rbac.NewContext(context.Background(), &rbac.Actor{Name: "bob"})
+
+	users := &vtadminpb.SavedQuery{
+		Name:     "user by id",
+		Keyspace: "ks",
+		Sql:      "select id, name from users where id = :id",
+		Parameters: []*vtadminpb.SavedQuery_Parameter{
+			{Name: "id", DefaultValue: "1"},
+		},
+	}
+
+	// Saving a query requires permission to run it.
+	_, err = api.CreateSavedQuery(bob, &vtadminpb.CreateSavedQueryRequest{ClusterId: "c1", SavedQuery: users})
+	assert.ErrorIs(t, err, vtadminerrors.ErrUnauthorized)
+
+	_, err = api.CreateSavedQuery(alice, &vtadminpb.CreateSavedQueryRequest{
+		ClusterId: "c1",
+		SavedQuery: &vtadminpb.SavedQuery{
+			Name:     "cleanup",
+			Keyspace: "ks",
+			Sql:      "delete from users",
+		},
+	})
+	assert.ErrorIs(t, err, vtadminerrors.ErrInvalidRequest)
+
+	saved, err := api.CreateSavedQuery(alice, &vtadminpb.CreateSavedQueryRequest{ClusterId: "c1", SavedQuery: users})
+	require.NoError(t, err)
+	assert.NotEmpty(t, saved.Id)
+	assert.Equal(t, "c1", saved.ClusterId)
+	assert.Equal(t, "alice", saved.Owner)
+	assert.NotNil(t, saved.CreatedAt)
+
+	// Save a scheduled query over HTTP.
+	body := `{"name": "daily users", "keyspace": "ks", "sql": "select count(*) from users", "schedule": {"interval": "24h", "webhook_url": "https://hooks.example.com/reports"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/saved_query/c2", strings.NewReader(body))
+	req = req.WithContext(alice)
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	resp, err := api.GetSavedQueries(alice, &vtadminpb.GetSavedQueriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.SavedQueries, 2)
+	assert.Equal(t, saved.Id, resp.SavedQueries[0].Id)
+	assert.Equal(t, "daily users", resp.SavedQueries[1].Name)
+	assert.EqualValues(t, 24*60*60, resp.SavedQueries[1].Schedule.GetInterval().GetSeconds())
+
+	// Bob only sees the saved queries of c1.
+	resp, err = api.GetSavedQueries(bob, &vtadminpb.GetSavedQueriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.SavedQueries, 1)
+	assert.Equal(t, saved.Id, resp.SavedQueries[0].Id)
+
+	result, err := api.RunSavedQuery(alice, &vtadminpb.RunSavedQueryRequest{
+		ClusterId: "c1",
+		Id:        saved.Id,
+		Params:    map[string]string{"id": "2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "select id, `name` from users where id = '2' limit 1001", result.Sql)
+	assert.Equal(t, []string{"2", "bob"}, result.Result.Rows[0].Values)
+
+	// Running a saved query also requires permission to run queries.
+	_, err = api.RunSavedQuery(bob, &vtadminpb.RunSavedQueryRequest{ClusterId: "c1", Id: saved.Id})
+	assert.ErrorIs(t, err, vtadminerrors.ErrUnauthorized)
+
+	_, err = api.RunSavedQuery(alice, &vtadminpb.RunSavedQueryRequest{
+		ClusterId: "c1",
+		Id:        saved.Id,
+		Params:    map[string]string{"name": "bob"},
+	})
+	assert.ErrorIs(t, err, vtadminerrors.ErrInvalidRequest)
+
+	_, err = api.RunSavedQuery(alice, &vtadminpb.RunSavedQueryRequest{ClusterId: "c2", Id: saved.Id})
+	assert.ErrorIs(t, err, vtadminerrors.ErrNoSavedQuery)
+
+	_, err = api.DeleteSavedQuery(bob, &vtadminpb.DeleteSavedQueryRequest{ClusterId: "c1", Id: saved.Id})
+	assert.ErrorIs(t, err, vtadminerrors.ErrUnauthorized)
+
+	_, err = api.DeleteSavedQuery(alice, &vtadminpb.DeleteSavedQueryRequest{ClusterId: "c1", Id: saved.Id})
+	require.NoError(t, err)
+
+	_, err = api.DeleteSavedQuery(alice, &vtadminpb.DeleteSavedQueryRequest{ClusterId: "c1", Id: saved.Id})
+	assert.ErrorIs(t, err, vtadminerrors.ErrNoSavedQuery)
+
+	resp, err = api.GetSavedQueries(bob, &vtadminpb.GetSavedQueriesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.SavedQueries)
+}
+
+func TestRunScheduledQuery(t *testing.T) {
+	t.Parallel()
+
+	clusters := []*cluster.Cluster{
+		vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+			Cluster:      &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+			VtctldClient: &fakevtctldclient.VtctldClient{},
+			Tablets: []*vtadminpb.Tablet{
+				{
+					Cluster: &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+					Tablet: &topodatapb.Tablet{
+						Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+						Keyspace: "ks",
+						Shard:    "-",
+						Type:     topodatapb.TabletType_REPLICA,
+					},
+					State: vtadminpb.Tablet_SERVING,
+				},
+			},
+			DBConfig: vtadmintestutil.Dbcfg{
+				QueryResults: map[string]map[string]*fakevtsql.QueryResult{
+					"ks@replica": {
+						"select id, `name` from users where id = '1' limit 1001": {
+							Columns: []string{"id", "name"},
+							Rows:    [][]any{{1, "alice"}},
+						},
+					},
+				},
+			},
+		}),
+	}
+
+	opts := Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "SavedQuery",
+					Actions:  []string{"*"},
+					Subjects: []string{"role:dba"},
+					Clusters: []string{"c1"},
+				},
+				{
+					Resource: "Query",
+					Actions:  []string{"execute_query"},
+					Subjects: []string{"role:dba"},
+					Clusters: []string{"c1"},
+				},
+			},
+		},
+		ReportOpts: savedqueries.Options{CheckInterval: time.Hour},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := NewAPI(clusters, opts)
+	defer api.Close()
+
+	alice := rbac.NewContext(context.Background(), &rbac.Actor{
+		Name:         "alice",
+		Roles:        []string{"dev"},
+		ClusterRoles: map[string][]string{"c1": {"dba"}, "c2": {"oncall"}},
+	})
+
+	saved, err := api.CreateSavedQuery(alice, &vtadminpb.CreateSavedQueryRequest{
+		ClusterId: "c1",
+		SavedQuery: &vtadminpb.SavedQuery{
+			Name:       "user by id",
+			Keyspace:   "ks",
+			Sql:        "select id, name from users where id = :id",
+			Parameters: []*vtadminpb.SavedQuery_Parameter{{Name: "id", DefaultValue: "1"}},
+			// Roles sent by the client are ignored.
+			OwnerRoles: []string{"admin"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dba", "dev"}, saved.OwnerRoles)
+
+	// Scheduled runs are authorized as the owner, not as the caller.
+	resp, err := api.runScheduledQuery(context.Background(), saved)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "alice"}, resp.Result.Rows[0].Values)
+
+	revoked := saved.CloneVT()
+	revoked.OwnerRoles = []string{"dev"}
+	_, err = api.runScheduledQuery(context.Background(), revoked)
+	assert.ErrorIs(t, err, vtadminerrors.ErrUnauthorized)
+
+	anonymous := saved.CloneVT()
+	anonymous.Owner = ""
+	anonymous.OwnerRoles = nil
+	_, err = api.runScheduledQuery(context.Background(), anonymous)
+	assert.ErrorIs(t, err, vtadminerrors.ErrUnauthorized)
+}
+
 func TestVTExplain(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,173 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/protoutil"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// Entry collects the authorization checks made while serving a single API
+// call, from which the call's audit event is built once it returns.
+type Entry struct {
+	m      sync.Mutex
+	start  time.Time
+	checks []check
+}
+
+type check struct {
+	actor     string
+	clusterID string
+	resource  string
+	action    string
+	allowed   bool
+}
+
+// NewEntry returns an Entry for an API call starting now.
+func NewEntry() *Entry {
+	return &Entry{start: time.Now()}
+}
+
+type entrykey struct{}
+
+// NewContext returns a context with the given entry stored in it.
+func NewContext(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entrykey{}, entry)
+}
+
+// FromContext extracts an entry from the context, if one exists.
+func FromContext(ctx context.Context) (*Entry, bool) {
+	entry, ok := ctx.Value(entrykey{}).(*Entry)
+	if !ok {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// RecordAuthorization records that the actor was, or was not, allowed to take
+// the action on the resource in the cluster. It is safe to call concurrently,
+// for API calls that check authorization in several clusters at once.
+func (e *Entry) RecordAuthorization(actor string, clusterID string, resource string, action string, allowed bool) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	e.checks = append(e.checks, check{
+		actor:     actor,
+		clusterID: clusterID,
+		resource:  resource,
+		action:    action,
+		allowed:   allowed,
+	})
+}
+
+// Event returns the audit event of the API call, given the error it returned,
+// if any. It returns nil if no authorization was recorded, which means the
+// call did not act on any cluster.
+//
+// A call is UNAUTHORIZED if none of its authorization checks passed, in which
+// case the event lists the clusters it was denied in. Otherwise the event only
+// lists the clusters the call was allowed to act on.
+func (e *Entry) Event(method string, params string, err error) *vtadminpb.AuditEvent {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	if len(e.checks) == 0 {
+		return nil
+	}
+
+	allowed := false
+	for _, c := range e.checks {
+		if c.allowed {
+			allowed = true
+			break
+		}
+	}
+
+	event := &vtadminpb.AuditEvent{
+		Time:     protoutil.TimeToProto(e.start),
+		Method:   method,
+		Params:   params,
+		Duration: protoutil.DurationToProto(time.Since(e.start)),
+	}
+
+	switch {
+	case !allowed:
+		event.Outcome = vtadminpb.AuditEvent_UNAUTHORIZED
+	case err != nil:
+		event.Outcome = vtadminpb.AuditEvent_FAILURE
+	default:
+		event.Outcome = vtadminpb.AuditEvent_SUCCESS
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	seen := map[string]bool{}
+	for _, c := range e.checks {
+		if c.allowed != allowed {
+			continue
+		}
+
+		if event.Resource == "" {
+			event.Actor = c.actor
+			event.Resource = c.resource
+			event.Action = c.action
+		}
+
+		if !seen[c.clusterID] {
+			seen[c.clusterID] = true
+			event.ClusterIds = append(event.ClusterIds, c.clusterID)
+		}
+	}
+
+	return event
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that records an
+// audit event in the given log for each mutating call. It must run after the
+// authentication interceptor, if any, for events to record the actor.
+func UnaryServerInterceptor(l *Log) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		entry := NewEntry()
+		resp, err = handler(NewContext(ctx, entry), req)
+
+		var params string
+		if msg, ok := req.(proto.Message); ok {
+			if data, merr := protojson.Marshal(msg); merr == nil {
+				params = string(data)
+			}
+		}
+
+		if event := entry.Event(path.Base(info.FullMethod), params, err); event != nil {
+			l.Record(event)
+		}
+
+		return resp, err
+	}
+}
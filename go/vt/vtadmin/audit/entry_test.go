@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func TestEntryEvent(t *testing.T) {
+	t.Parallel()
+
+	type check struct {
+		clusterID string
+		allowed   bool
+	}
+
+	tests := []struct {
+		name             string
+		checks           []check
+		err              error
+		expectedOutcome  vtadminpb.AuditEvent_Outcome
+		expectedClusters []string
+		expectedError    string
+	}{
+		{
+			name: "no checks",
+		},
+		{
+			name:             "success",
+			checks:           []check{{"c1", true}},
+			expectedOutcome:  vtadminpb.AuditEvent_SUCCESS,
+			expectedClusters: []string{"c1"},
+		},
+		{
+			name:             "failure",
+			checks:           []check{{"c1", true}},
+			err:              errors.New("vtctld unavailable"),
+			expectedOutcome:  vtadminpb.AuditEvent_FAILURE,
+			expectedClusters: []string{"c1"},
+			expectedError:    "vtctld unavailable",
+		},
+		{
+			name:             "unauthorized",
+			checks:           []check{{"c1", false}, {"c2", false}},
+			err:              errors.New("unauthorized"),
+			expectedOutcome:  vtadminpb.AuditEvent_UNAUTHORIZED,
+			expectedClusters: []string{"c1", "c2"},
+			expectedError:    "unauthorized",
+		},
+		{
+			name:             "only lists allowed clusters",
+			checks:           []check{{"c1", false}, {"c2", true}, {"c2", true}},
+			expectedOutcome:  vtadminpb.AuditEvent_SUCCESS,
+			expectedClusters: []string{"c2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := NewEntry()
+			for _, c := range tt.checks {
+				entry.RecordAuthorization("alice", c.clusterID, "Keyspace", "create", c.allowed)
+			}
+
+			event := entry.Event("CreateKeyspace", `{"cluster_id":"c1"}`, tt.err)
+			if tt.checks == nil {
+				assert.Nil(t, event)
+				return
+			}
+
+			require.NotNil(t, event)
+			assert.Equal(t, tt.expectedOutcome, event.Outcome)
+			assert.Equal(t, tt.expectedClusters, event.ClusterIds)
+			assert.Equal(t, tt.expectedError, event.Error)
+			assert.Equal(t, "alice", event.Actor)
+			assert.Equal(t, "CreateKeyspace", event.Method)
+			assert.Equal(t, "Keyspace", event.Resource)
+			assert.Equal(t, "create", event.Action)
+			assert.NotNil(t, event.Time)
+			assert.NotNil(t, event.Duration)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	l, err := New(Options{})
+	require.NoError(t, err)
+
+	interceptor := UnaryServerInterceptor(l)
+
+	// A read-only call records no authorization, and no event.
+	info := &grpc.UnaryServerInfo{FullMethod: "/vtadmin.VTAdmin/GetKeyspaces"}
+	_, err = interceptor(context.Background(), &vtadminpb.GetKeyspacesRequest{}, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, l.Events(Filter{}))
+
+	info = &grpc.UnaryServerInfo{FullMethod: "/vtadmin.VTAdmin/CreateKeyspace"}
+	_, err = interceptor(context.Background(), &vtadminpb.CreateKeyspaceRequest{ClusterId: "c1"}, info, func(ctx context.Context, req any) (any, error) {
+		entry, ok := FromContext(ctx)
+		require.True(t, ok, "interceptor should store an entry in the context")
+
+		entry.RecordAuthorization("alice", "c1", "Keyspace", "create", true)
+		return nil, errors.New("keyspace already exists")
+	})
+	assert.Error(t, err)
+
+	events := l.Events(Filter{})
+	require.Len(t, events, 1)
+	assert.Equal(t, "CreateKeyspace", events[0].Method)
+	assert.Equal(t, vtadminpb.AuditEvent_FAILURE, events[0].Outcome)
+	assert.Equal(t, "keyspace already exists", events[0].Error)
+	assert.JSONEq(t, `{"clusterId":"c1"}`, events[0].Params)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records mutating calls to the VTAdmin API in a queryable
+// audit log.
+//
+// A call is recorded if it checks authorization for an RBAC action other than
+// "get" or "ping", which the rbac package reports to the Entry stored in the
+// request context. Calls rejected before any authorization check, for example
+// because they name a cluster that does not exist, do not act on any cluster
+// and are not recorded.
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// DefaultMaxEvents is the number of events a Log keeps in memory if
+// Options.MaxEvents is not set.
+const DefaultMaxEvents = 10000
+
+// maxLineSize is the size of the longest line read back from an audit file.
+const maxLineSize = 1024 * 1024
+
+// Options configures a Log.
+type Options struct {
+	// MaxEvents is the number of most recent events kept in memory, and
+	// returned by Events. Defaults to DefaultMaxEvents.
+	MaxEvents int
+	// File, if set, is the path of a file every event is appended to, as a
+	// line of JSON. The most recent events of an existing file are loaded
+	// when the Log is opened, so that they survive restarts.
+	File string
+}
+
+// Filter restricts the events returned by Log.Events. Zero values do not
+// restrict anything.
+type Filter struct {
+	// ClusterIDs restricts events to those acting on at least one of the
+	// clusters.
+	ClusterIDs []string
+	Actor      string
+	Method     string
+	// Since and Until restrict events to those recorded in the time range,
+	// inclusive.
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of events to return.
+	Limit int
+}
+
+// Log is a bounded audit log, held in memory and optionally appended to a
+// file.
+type Log struct {
+	m      sync.Mutex
+	events []*vtadminpb.AuditEvent // oldest first
+	max    int
+	lastID uint64
+	file   *os.File
+}
+
+// New returns a Log configured with the given options. It returns an error if
+// the file cannot be opened or read.
+func New(opts Options) (*Log, error) {
+	l := &Log{
+		max: opts.MaxEvents,
+	}
+
+	if l.max <= 0 {
+		l.max = DefaultMaxEvents
+	}
+
+	if opts.File == "" {
+		return l, nil
+	}
+
+	f, err := os.OpenFile(opts.File, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	if err := l.load(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read audit log %s: %w", opts.File, err)
+	}
+
+	l.file = f
+	return l, nil
+}
+
+// load reads back the events of an existing audit file. Lines which cannot be
+// parsed, such as one left incomplete by a crash, are skipped, and an
+// incomplete last line is terminated so that it does not corrupt the next
+// event.
+func (l *Log) load(f *os.File) error {
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxLineSize)
+
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		event := &vtadminpb.AuditEvent{}
+		if err := protojson.Unmarshal(scanner.Bytes(), event); err != nil {
+			log.Warningf("skipping invalid audit event on line %d of %s: %s", n, f.Name(), err)
+			continue
+		}
+
+		l.append(event)
+		if event.Id > l.lastID {
+			l.lastID = event.Id
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+
+	if last[0] != '\n' {
+		_, err = f.Write([]byte{'\n'})
+	}
+
+	return err
+}
+
+// append adds an event to the in-memory log, dropping the oldest event when
+// the log is full. Callers must hold l.m or have exclusive access to l.
+func (l *Log) append(event *vtadminpb.AuditEvent) {
+	l.events = append(l.events, event)
+	if len(l.events) > l.max {
+		l.events = l.events[len(l.events)-l.max:]
+	}
+}
+
+// Record assigns the event an ID and adds it to the log. Failing to write the
+// event to the file is logged, but the event is still kept in memory.
+func (l *Log) Record(event *vtadminpb.AuditEvent) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.lastID++
+	event.Id = l.lastID
+	l.append(event)
+
+	if l.file == nil {
+		return
+	}
+
+	data, err := protojson.Marshal(event)
+	if err == nil {
+		_, err = l.file.Write(append(data, '\n'))
+	}
+
+	if err != nil {
+		log.Errorf("failed to write audit event %d to %s: %s", event.Id, l.file.Name(), err)
+	}
+}
+
+// Events returns the events matching the filter, most recent first.
+func (l *Log) Events(filter Filter) []*vtadminpb.AuditEvent {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	clusters := make(map[string]bool, len(filter.ClusterIDs))
+	for _, id := range filter.ClusterIDs {
+		clusters[id] = true
+	}
+
+	var events []*vtadminpb.AuditEvent
+	for i := len(l.events) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(events) >= filter.Limit {
+			break
+		}
+
+		event := l.events[i]
+		if !filter.matches(event, clusters) {
+			continue
+		}
+
+		events = append(events, event.CloneVT())
+	}
+
+	return events
+}
+
+func (filter *Filter) matches(event *vtadminpb.AuditEvent, clusters map[string]bool) bool {
+	if filter.Actor != "" && event.Actor != filter.Actor {
+		return false
+	}
+
+	if filter.Method != "" && event.Method != filter.Method {
+		return false
+	}
+
+	t := protoutil.TimeFromProto(event.Time)
+	if !filter.Since.IsZero() && t.Before(filter.Since) {
+		return false
+	}
+
+	if !filter.Until.IsZero() && t.After(filter.Until) {
+		return false
+	}
+
+	if len(clusters) == 0 {
+		return true
+	}
+
+	for _, id := range event.ClusterIds {
+		if clusters[id] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Close closes the file of the log, if any.
+func (l *Log) Close() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func eventIDs(events []*vtadminpb.AuditEvent) []uint64 {
+	ids := make([]uint64, len(events))
+	for i, event := range events {
+		ids[i] = event.Id
+	}
+
+	return ids
+}
+
+func TestLogEvents(t *testing.T) {
+	t.Parallel()
+
+	l, err := New(Options{MaxEvents: 4})
+	require.NoError(t, err)
+	defer l.Close()
+
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := []*vtadminpb.AuditEvent{
+		{Actor: "alice", ClusterIds: []string{"c1"}, Method: "CreateKeyspace"},
+		{Actor: "bob", ClusterIds: []string{"c1"}, Method: "DeleteKeyspace"},
+		{Actor: "alice", ClusterIds: []string{"c2"}, Method: "DeleteKeyspace"},
+		{Actor: "bob", ClusterIds: []string{"c1", "c2"}, Method: "CreateShard"},
+		{Actor: "alice", ClusterIds: []string{"c2"}, Method: "CreateKeyspace"},
+	}
+
+	for i, event := range events {
+		event.Time = protoutil.TimeToProto(start.Add(time.Duration(i) * time.Minute))
+		l.Record(event)
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []uint64
+	}{
+		{
+			name:     "no filter drops events beyond the max",
+			expected: []uint64{5, 4, 3, 2},
+		},
+		{
+			name:     "clusters",
+			filter:   Filter{ClusterIDs: []string{"c1"}},
+			expected: []uint64{4, 2},
+		},
+		{
+			name:     "actor",
+			filter:   Filter{Actor: "alice"},
+			expected: []uint64{5, 3},
+		},
+		{
+			name:     "method",
+			filter:   Filter{Method: "DeleteKeyspace"},
+			expected: []uint64{3, 2},
+		},
+		{
+			name:     "time range",
+			filter:   Filter{Since: start.Add(2 * time.Minute), Until: start.Add(3 * time.Minute)},
+			expected: []uint64{4, 3},
+		},
+		{
+			name:     "limit",
+			filter:   Filter{ClusterIDs: []string{"c2"}, Limit: 2},
+			expected: []uint64{5, 4},
+		},
+		{
+			name:     "no match",
+			filter:   Filter{ClusterIDs: []string{"c3"}},
+			expected: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, eventIDs(l.Events(tt.filter)))
+		})
+	}
+}
+
+func TestLogFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l, err := New(Options{File: path})
+	require.NoError(t, err)
+
+	l.Record(&vtadminpb.AuditEvent{Actor: "alice", ClusterIds: []string{"c1"}, Method: "CreateKeyspace"})
+	l.Record(&vtadminpb.AuditEvent{Actor: "bob", ClusterIds: []string{"c1"}, Method: "DeleteKeyspace"})
+	require.NoError(t, l.Close())
+
+	// Simulate a crash in the middle of writing an event.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"3", "actor":`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Events are reloaded when the file is reopened, and IDs carry on from
+	// the last one.
+	l, err = New(Options{File: path, MaxEvents: 2})
+	require.NoError(t, err)
+
+	events := l.Events(Filter{})
+	require.Len(t, events, 2)
+	assert.Equal(t, "bob", events[0].Actor)
+	assert.Equal(t, "alice", events[1].Actor)
+
+	l.Record(&vtadminpb.AuditEvent{Actor: "carol", ClusterIds: []string{"c1"}, Method: "CreateShard"})
+	assert.Equal(t, []uint64{3, 2}, eventIDs(l.Events(Filter{})))
+
+	require.NoError(t, l.Close())
+
+	l, err = New(Options{File: path})
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, []uint64{3, 2, 1}, eventIDs(l.Events(Filter{})))
+}
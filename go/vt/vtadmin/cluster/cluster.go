@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -36,6 +37,7 @@ import (
 	"vitess.io/vitess/go/textutil"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtadmin/cache"
@@ -43,11 +45,16 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/cluster/internal/caches/schemacache"
 	"vitess.io/vitess/go/vt/vtadmin/debug"
 	"vitess.io/vitess/go/vt/vtadmin/errors"
+	"vitess.io/vitess/go/vt/vtadmin/internal/metrics"
+	"vitess.io/vitess/go/vt/vtadmin/internal/queryconsole"
+	"vitess.io/vitess/go/vt/vtadmin/internal/schemadrift"
 	"vitess.io/vitess/go/vt/vtadmin/vtadminproto"
 	"vitess.io/vitess/go/vt/vtadmin/vtctldclient"
 	"vitess.io/vitess/go/vt/vtadmin/vtsql"
 
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
@@ -80,6 +87,13 @@ type Cluster struct {
 	emergencyFailoverPool *pools.RPCPool // ERS-only
 	failoverPool          *pools.RPCPool // PRS-only
 
+	// schemaMigrationsPool is used for ApplySchema and the schema migration
+	// (Online DDL) control operations.
+	schemaMigrationsPool *pools.RPCPool
+	// workflowPool is used to create, validate and drive VReplication
+	// workflows.
+	workflowPool *pools.RPCPool
+
 	// schemaCache caches schema(s) for different GetSchema(s) requests.
 	//
 	// - if we call GetSchema, then getSchemaCacheRequest.Keyspace will be
@@ -91,6 +105,10 @@ type Cluster struct {
 	//  the interim, we won't pick that up until something refreshes the cache.
 	schemaCache *cache.Cache[schemacache.Key, []*vtadminpb.Schema]
 
+	// metrics scrapes the metrics of the VTGates and tablets in the cluster.
+	// It is nil if the cluster has no metrics scrape interval configured.
+	metrics *metrics.Recorder
+
 	cfg Config
 }
 
@@ -160,6 +178,8 @@ func New(ctx context.Context, cfg Config) (*Cluster, error) {
 
 	cluster.emergencyFailoverPool = cfg.EmergencyFailoverPoolConfig.NewRWPool()
 	cluster.failoverPool = cfg.FailoverPoolConfig.NewRWPool()
+	cluster.schemaMigrationsPool = cfg.SchemaMigrationsPoolConfig.NewRWPool()
+	cluster.workflowPool = cfg.WorkflowPoolConfig.NewRWPool()
 
 	if cluster.cfg.SchemaCacheConfig == nil {
 		cluster.cfg.SchemaCacheConfig = &cache.Config{}
@@ -196,6 +216,14 @@ func New(ctx context.Context, cfg Config) (*Cluster, error) {
 		return []*vtadminpb.Schema{schema}, nil
 	}, *cluster.cfg.SchemaCacheConfig)
 
+	if interval := cfg.MetricsConfig.GetScrapeInterval(); interval > 0 {
+		cluster.metrics = metrics.New(cluster.metricsTargets, metrics.Config{
+			ScrapeInterval: interval,
+			Retention:      cfg.MetricsConfig.GetRetention(),
+		})
+		cluster.metrics.Start()
+	}
+
 	return cluster, nil
 }
 
@@ -221,6 +249,12 @@ func (c *Cluster) Close() error {
 	// concurrently, like we do with the proxies).
 	rec.RecordError(c.schemaCache.Close())
 
+	// Stop scraping metrics before closing the DB, which lists the tablets to
+	// scrape.
+	if c.metrics != nil {
+		rec.RecordError(c.metrics.Close())
+	}
+
 	for _, closer := range []io.Closer{c.DB, c.Vtctld} {
 		wg.Add(1)
 		go func(closer io.Closer) {
@@ -351,6 +385,136 @@ func (c *Cluster) parseTablet(rows *sql.Rows) (*vtadminpb.Tablet, error) {
 	return tablet, nil
 }
 
+// ApplySchema applies a schema change to a keyspace in the given cluster,
+// proxying an ApplySchemaRequest to a vtctld in that cluster. Whether the
+// change is applied directly or submitted as an Online DDL migration depends
+// on the request's DDL strategy.
+func (c *Cluster) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySchemaRequest) (*vtctldatapb.ApplySchemaResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.ApplySchema")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("ddl_strategy", req.DdlStrategy)
+	span.Annotate("migration_context", req.MigrationContext)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if len(req.Sql) == 0 {
+		return nil, fmt.Errorf("%w: at least one sql statement is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("ApplySchema(%+v) failed to acquire schemaMigrationsPool: %w", req, err)
+	}
+	defer c.schemaMigrationsPool.Release()
+
+	return c.Vtctld.ApplySchema(ctx, req)
+}
+
+// CancelSchemaMigration cancels one or all schema migrations in a
+// keyspace in the given cluster, proxying a CancelSchemaMigrationRequest to a
+// vtctld in that cluster.
+func (c *Cluster) CancelSchemaMigration(ctx context.Context, req *vtctldatapb.CancelSchemaMigrationRequest) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.CancelSchemaMigration")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Uuid == "" {
+		return nil, fmt.Errorf("%w: migration uuid is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("CancelSchemaMigration(%+v) failed to acquire schemaMigrationsPool: %w", req, err)
+	}
+	defer c.schemaMigrationsPool.Release()
+
+	return c.Vtctld.CancelSchemaMigration(ctx, req)
+}
+
+// CleanupSchemaMigration marks a schema migration in a keyspace in the
+// given cluster as ready for artifact cleanup, proxying a
+// CleanupSchemaMigrationRequest to a vtctld in that cluster.
+func (c *Cluster) CleanupSchemaMigration(ctx context.Context, req *vtctldatapb.CleanupSchemaMigrationRequest) (*vtctldatapb.CleanupSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.CleanupSchemaMigration")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Uuid == "" {
+		return nil, fmt.Errorf("%w: migration uuid is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("CleanupSchemaMigration(%+v) failed to acquire schemaMigrationsPool: %w", req, err)
+	}
+	defer c.schemaMigrationsPool.Release()
+
+	return c.Vtctld.CleanupSchemaMigration(ctx, req)
+}
+
+// CompleteSchemaMigration completes one or all postponed schema migrations
+// in a keyspace in the given cluster, proxying a CompleteSchemaMigrationRequest
+// to a vtctld in that cluster.
+func (c *Cluster) CompleteSchemaMigration(ctx context.Context, req *vtctldatapb.CompleteSchemaMigrationRequest) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.CompleteSchemaMigration")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Uuid == "" {
+		return nil, fmt.Errorf("%w: migration uuid is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("CompleteSchemaMigration(%+v) failed to acquire schemaMigrationsPool: %w", req, err)
+	}
+	defer c.schemaMigrationsPool.Release()
+
+	return c.Vtctld.CompleteSchemaMigration(ctx, req)
+}
+
 // CreateKeyspace creates a keyspace in the given cluster, proxying a
 // CreateKeyspaceRequest to a vtctld in that cluster.
 func (c *Cluster) CreateKeyspace(ctx context.Context, req *vtctldatapb.CreateKeyspaceRequest) (*vtadminpb.Keyspace, error) {
@@ -493,6 +657,74 @@ func (c *Cluster) DeleteTablets(ctx context.Context, req *vtctldatapb.DeleteTabl
 	return c.Vtctld.DeleteTablets(ctx, req)
 }
 
+const (
+	// DrainedFromTag is the tablet tag in which DrainTablet records the type
+	// the tablet had before it was drained, so UndrainTablet can restore it.
+	DrainedFromTag = "drained_from"
+	// DrainReasonTag is the tablet tag in which DrainTablet records the reason
+	// the tablet was drained, if one was given.
+	DrainReasonTag = "drain_reason"
+)
+
+// DrainTablet takes a tablet out of serving by changing its type to DRAINED.
+// The tablet's previous type and the reason for the drain are recorded in its
+// tags, so that operators can tell why the tablet was drained, and so that
+// UndrainTablet can restore it. Only REPLICA and RDONLY tablets can be drained.
+func (c *Cluster) DrainTablet(ctx context.Context, tablet *vtadminpb.Tablet, reason string) (*vtadminpb.DrainTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.DrainTablet")
+	defer span.Finish()
+
+	alias := topoproto.TabletAliasString(tablet.Tablet.Alias)
+
+	AnnotateSpan(c, span)
+	span.Annotate("tablet_alias", alias)
+	span.Annotate("reason", reason)
+
+	switch tablet.Tablet.Type {
+	case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+	default:
+		return nil, fmt.Errorf("%w: cannot drain %s tablet %s", errors.ErrInvalidRequest, topoproto.TabletTypeLString(tablet.Tablet.Type), alias)
+	}
+
+	if err := c.topoRWPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("DrainTablet(%s) failed to acquire topoRWPool: %w", alias, err)
+	}
+	defer c.topoRWPool.Release()
+
+	_, err := c.Vtctld.ChangeTabletTags(ctx, &vtctldatapb.ChangeTabletTagsRequest{
+		TabletAlias: tablet.Tablet.Alias,
+		Tags: map[string]string{
+			DrainedFromTag: topoproto.TabletTypeLString(tablet.Tablet.Type),
+			DrainReasonTag: reason,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag tablet %s as drained: %w", alias, err)
+	}
+
+	resp, err := c.Vtctld.ChangeTabletType(ctx, &vtctldatapb.ChangeTabletTypeRequest{
+		TabletAlias: tablet.Tablet.Alias,
+		DbType:      topodatapb.TabletType_DRAINED,
+	})
+	if err != nil {
+		// Don't leave the tablet looking drained when it is still serving.
+		if _, terr := c.Vtctld.ChangeTabletTags(ctx, &vtctldatapb.ChangeTabletTagsRequest{
+			TabletAlias: tablet.Tablet.Alias,
+			Tags:        map[string]string{DrainedFromTag: "", DrainReasonTag: ""},
+		}); terr != nil {
+			log.Warningf("failed to remove drain tags from tablet %s: %s", alias, terr)
+		}
+
+		return nil, fmt.Errorf("failed to drain tablet %s: %w", alias, err)
+	}
+
+	return &vtadminpb.DrainTabletResponse{
+		Status:  "ok",
+		Cluster: c.ToProto(),
+		Tablet:  resp.AfterTablet,
+	}, nil
+}
+
 // EmergencyFailoverShard fails over a shard to a new primary. It assumes the
 // old primary is dead or otherwise not responding.
 func (c *Cluster) EmergencyFailoverShard(ctx context.Context, req *vtctldatapb.EmergencyReparentShardRequest) (*vtadminpb.EmergencyFailoverShardResponse, error) {
@@ -530,6 +762,67 @@ func (c *Cluster) EmergencyFailoverShard(ctx context.Context, req *vtctldatapb.E
 	}, nil
 }
 
+// ExecuteQuery runs a single read-only query against the tablets of a given
+// type in a keyspace, through the cluster's vtgate connection. Queries that are
+// not read-only are rejected, and the number of rows returned and the duration
+// of the query are limited by the cluster's QueryConsoleConfig.
+func (c *Cluster) ExecuteQuery(ctx context.Context, req *vtadminpb.ExecuteQueryRequest) (*vtadminpb.ExecuteQueryResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.ExecuteQuery")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	tabletType := req.TabletType
+	if tabletType == topodatapb.TabletType_UNKNOWN {
+		tabletType = topodatapb.TabletType_REPLICA
+	}
+
+	maxRows := c.cfg.QueryConsoleConfig.GetMaxRows()
+	if req.MaxRows > 0 && int(req.MaxRows) < maxRows {
+		maxRows = int(req.MaxRows)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("tablet_type", topoproto.TabletTypeLString(tabletType))
+	span.Annotate("max_rows", maxRows)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	switch tabletType {
+	case topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+	default:
+		return nil, fmt.Errorf("%w: cannot run queries against %s tablets", errors.ErrInvalidRequest, topoproto.TabletTypeLString(tabletType))
+	}
+
+	query, err := queryconsole.Prepare(req.Sql, maxRows)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrInvalidRequest, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.QueryConsoleConfig.GetTimeout())
+	defer cancel()
+
+	target := fmt.Sprintf("%s@%s", req.Keyspace, topoproto.TabletTypeLString(tabletType))
+	result, err := c.DB.Query(ctx, target, query, maxRows)
+	if err != nil {
+		return nil, fmt.Errorf("error running query against %s: %w", target, err)
+	}
+
+	return &vtadminpb.ExecuteQueryResponse{
+		Cluster:    c.ToProto(),
+		Keyspace:   req.Keyspace,
+		TabletType: tabletType,
+		Sql:        query,
+		Result:     result,
+	}, nil
+}
+
 // FindAllShardsInKeyspaceOptions modify the behavior of a cluster's
 // FindAllShardsInKeyspace method.
 type FindAllShardsInKeyspaceOptions struct {
@@ -624,6 +917,9 @@ type FindWorkflowsOptions struct {
 	ActiveOnly      bool
 	IgnoreKeyspaces sets.Set[string]
 	Filter          func(workflow *vtadminpb.Workflow) bool
+	// IncludeCopyProgress requests the copy phase progress summary for
+	// workflows that are still copying.
+	IncludeCopyProgress bool
 }
 
 // FindWorkflows returns a list of Workflows in this cluster, across the given
@@ -731,9 +1027,10 @@ func (c *Cluster) findWorkflows(ctx context.Context, keyspaces []string, opts Fi
 			}
 
 			resp, err := c.Vtctld.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{
-				Keyspace:    ks,
-				ActiveOnly:  opts.ActiveOnly,
-				IncludeLogs: true,
+				Keyspace:            ks,
+				ActiveOnly:          opts.ActiveOnly,
+				IncludeLogs:         true,
+				IncludeCopyProgress: opts.IncludeCopyProgress,
 			})
 			c.workflowReadPool.Release()
 
@@ -1103,6 +1400,168 @@ func (c *Cluster) GetGates(ctx context.Context) ([]*vtadminpb.VTGate, error) {
 	return gates, nil
 }
 
+// GetGraph returns the topology of the cluster as a graph: its cells, the
+// keyspaces, shards and tablets in each of them, and the replication source
+// and serving state of every tablet. It saves clients from fetching the
+// cells, keyspaces and tablets of the cluster separately.
+func (c *Cluster) GetGraph(ctx context.Context) (*vtadminpb.ClusterGraph, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetGraph")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	var (
+		cells     []*vtadminpb.ClusterCellInfo
+		keyspaces []*vtadminpb.Keyspace
+		tablets   []*vtadminpb.Tablet
+		wg        sync.WaitGroup
+		rec       concurrency.AllErrorRecorder
+	)
+
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+
+		var err error
+		cells, err = c.GetCellInfos(ctx, &vtadminpb.GetCellInfosRequest{NamesOnly: true})
+		rec.RecordError(err)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		var err error
+		keyspaces, err = c.GetKeyspaces(ctx)
+		rec.RecordError(err)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		var err error
+		tablets, err = c.GetTablets(ctx)
+		rec.RecordError(err)
+	}()
+
+	wg.Wait()
+
+	if rec.HasErrors() {
+		return nil, fmt.Errorf("GetGraph(cluster = %s): %w", c.ID, rec.Error())
+	}
+
+	return buildGraph(c.ToProto(), cells, keyspaces, tablets), nil
+}
+
+// buildGraph nests the tablets of a cluster under their cell, keyspace and
+// shard. Every cell appears in the graph, but keyspaces and shards only appear
+// under the cells they have tablets in.
+func buildGraph(cpb *vtadminpb.Cluster, cells []*vtadminpb.ClusterCellInfo, keyspaces []*vtadminpb.Keyspace, tablets []*vtadminpb.Tablet) *vtadminpb.ClusterGraph {
+	shards := map[string]*topodatapb.Shard{}
+	for _, ks := range keyspaces {
+		for name, shard := range ks.Shards {
+			shards[topoproto.KeyspaceShardString(ks.Keyspace.Name, name)] = shard.Shard
+		}
+	}
+
+	var (
+		cellNodes     = map[string]*vtadminpb.ClusterGraph_CellNode{}
+		keyspaceNodes = map[string]*vtadminpb.ClusterGraph_KeyspaceNode{}
+		shardNodes    = map[string]*vtadminpb.ClusterGraph_ShardNode{}
+	)
+
+	getCell := func(name string) *vtadminpb.ClusterGraph_CellNode {
+		cell, ok := cellNodes[name]
+		if !ok {
+			cell = &vtadminpb.ClusterGraph_CellNode{Name: name}
+			cellNodes[name] = cell
+		}
+
+		return cell
+	}
+
+	for _, cell := range cells {
+		getCell(cell.Name)
+	}
+
+	for _, tablet := range tablets {
+		var (
+			alias    = tablet.Tablet.Alias
+			keyspace = tablet.Tablet.Keyspace
+			shard    = tablet.Tablet.Shard
+			ksKey    = alias.Cell + "/" + keyspace
+			shardKey = topoproto.KeyspaceShardString(keyspace, shard)
+		)
+
+		ksNode, ok := keyspaceNodes[ksKey]
+		if !ok {
+			cell := getCell(alias.Cell)
+			ksNode = &vtadminpb.ClusterGraph_KeyspaceNode{Name: keyspace}
+			cell.Keyspaces = append(cell.Keyspaces, ksNode)
+			keyspaceNodes[ksKey] = ksNode
+		}
+
+		shardNode, ok := shardNodes[alias.Cell+"/"+shardKey]
+		if !ok {
+			shardNode = &vtadminpb.ClusterGraph_ShardNode{Name: shard}
+			if si, ok := shards[shardKey]; ok && si != nil {
+				shardNode.PrimaryAlias = si.PrimaryAlias
+				shardNode.IsPrimaryServing = si.IsPrimaryServing
+			}
+
+			ksNode.Shards = append(ksNode.Shards, shardNode)
+			shardNodes[alias.Cell+"/"+shardKey] = shardNode
+		}
+
+		tabletNode := &vtadminpb.ClusterGraph_TabletNode{
+			Alias:    alias,
+			Hostname: tablet.Tablet.Hostname,
+			Type:     tablet.Tablet.Type,
+			State:    tablet.State,
+			FQDN:     tablet.FQDN,
+		}
+
+		// Every tablet but a primary replicates from the primary of its shard.
+		primary := shardNode.PrimaryAlias
+		if tablet.Tablet.Type != topodatapb.TabletType_PRIMARY && primary != nil && !topoproto.TabletAliasEqual(alias, primary) {
+			tabletNode.ReplicationSource = primary
+		}
+
+		shardNode.Tablets = append(shardNode.Tablets, tabletNode)
+	}
+
+	graph := &vtadminpb.ClusterGraph{
+		Cluster: cpb,
+		Cells:   make([]*vtadminpb.ClusterGraph_CellNode, 0, len(cellNodes)),
+	}
+
+	for _, cell := range cellNodes {
+		sort.Slice(cell.Keyspaces, func(i, j int) bool {
+			return cell.Keyspaces[i].Name < cell.Keyspaces[j].Name
+		})
+
+		for _, ks := range cell.Keyspaces {
+			sort.Slice(ks.Shards, func(i, j int) bool {
+				return ks.Shards[i].Name < ks.Shards[j].Name
+			})
+
+			for _, shard := range ks.Shards {
+				sort.Slice(shard.Tablets, func(i, j int) bool {
+					return shard.Tablets[i].Alias.Uid < shard.Tablets[j].Alias.Uid
+				})
+			}
+		}
+
+		graph.Cells = append(graph.Cells, cell)
+	}
+
+	sort.Slice(graph.Cells, func(i, j int) bool {
+		return graph.Cells[i].Name < graph.Cells[j].Name
+	})
+
+	return graph
+}
+
 // GetKeyspace returns a single keyspace in the cluster.
 func (c *Cluster) GetKeyspace(ctx context.Context, name string) (*vtadminpb.Keyspace, error) {
 	span, ctx := trace.NewSpan(ctx, "Cluster.GetKeyspace")
@@ -1193,32 +1652,92 @@ func (c *Cluster) GetKeyspaces(ctx context.Context) ([]*vtadminpb.Keyspace, erro
 	return keyspaces, nil
 }
 
-// GetSrvKeyspaces returns all SrvKeyspaces for all keyspaces in a cluster.
-func (c *Cluster) GetSrvKeyspaces(ctx context.Context, cells []string) (map[string]*vtctldatapb.GetSrvKeyspacesResponse, error) {
-	span, ctx := trace.NewSpan(ctx, "Cluster.GetKeyspaces")
+// GetMetrics returns the metrics scraped from the VTGates and tablets in the
+// cluster. If the cluster does not scrape metrics, the returned ClusterMetrics
+// is not enabled, and has no samples.
+func (c *Cluster) GetMetrics(ctx context.Context) *vtadminpb.ClusterMetrics {
+	span, _ := trace.NewSpan(ctx, "Cluster.GetMetrics")
+	defer span.Finish()
+
 	AnnotateSpan(c, span)
 
-	defer span.Finish()
-	keyspaces, err := c.Vtctld.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
-	if err != nil {
-		return nil, fmt.Errorf("GetKeyspaces(cluster = %s): %w", c.ID, err)
+	cm := &vtadminpb.ClusterMetrics{
+		Cluster: c.ToProto(),
 	}
 
-	var (
-		m            sync.Mutex
-		wg           sync.WaitGroup
-		rec          concurrency.AllErrorRecorder
-		srvKeyspaces = make(map[string]*vtctldatapb.GetSrvKeyspacesResponse, len(keyspaces.Keyspaces))
-	)
+	if c.metrics == nil {
+		return cm
+	}
 
-	for _, keyspace := range keyspaces.Keyspaces {
-		wg.Add(1)
+	cm.Enabled = true
+	cm.ScrapeInterval = protoutil.DurationToProto(c.metrics.ScrapeInterval())
+	cm.Samples = c.metrics.Samples()
+	cm.Components = c.metrics.Components()
 
-		go func(keyspace *vtctldatapb.Keyspace) {
-			defer wg.Done()
-			srv_keyspaces, err := c.Vtctld.GetSrvKeyspaces(ctx, &vtctldatapb.GetSrvKeyspacesRequest{Keyspace: keyspace.Name, Cells: cells})
-			if err != nil {
-				rec.RecordError(fmt.Errorf("GetSrvKeyspaces(keyspace = %s): %w", keyspace.Name, err))
+	return cm
+}
+
+// metricsTargets returns the VTGates and serving tablets in the cluster to
+// scrape metrics from.
+func (c *Cluster) metricsTargets(ctx context.Context) ([]metrics.Target, error) {
+	gates, err := c.GetGates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tablets, err := c.FindTablets(ctx, func(tablet *vtadminpb.Tablet) bool {
+		return tablet.State == vtadminpb.Tablet_SERVING
+	}, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]metrics.Target, 0, len(gates)+len(tablets))
+	for _, gate := range gates {
+		targets = append(targets, metrics.Target{
+			Type: vtadminpb.ComponentMetrics_VTGATE,
+			Name: gate.Hostname,
+			Addr: gate.FQDN,
+		})
+	}
+
+	for _, tablet := range tablets {
+		targets = append(targets, metrics.Target{
+			Type: vtadminpb.ComponentMetrics_TABLET,
+			Name: topoproto.TabletAliasString(tablet.Tablet.Alias),
+			Addr: tablet.FQDN,
+		})
+	}
+
+	return targets, nil
+}
+
+// GetSrvKeyspaces returns all SrvKeyspaces for all keyspaces in a cluster.
+func (c *Cluster) GetSrvKeyspaces(ctx context.Context, cells []string) (map[string]*vtctldatapb.GetSrvKeyspacesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetKeyspaces")
+	AnnotateSpan(c, span)
+
+	defer span.Finish()
+	keyspaces, err := c.Vtctld.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("GetKeyspaces(cluster = %s): %w", c.ID, err)
+	}
+
+	var (
+		m            sync.Mutex
+		wg           sync.WaitGroup
+		rec          concurrency.AllErrorRecorder
+		srvKeyspaces = make(map[string]*vtctldatapb.GetSrvKeyspacesResponse, len(keyspaces.Keyspaces))
+	)
+
+	for _, keyspace := range keyspaces.Keyspaces {
+		wg.Add(1)
+
+		go func(keyspace *vtctldatapb.Keyspace) {
+			defer wg.Done()
+			srv_keyspaces, err := c.Vtctld.GetSrvKeyspaces(ctx, &vtctldatapb.GetSrvKeyspacesRequest{Keyspace: keyspace.Name, Cells: cells})
+			if err != nil {
+				rec.RecordError(fmt.Errorf("GetSrvKeyspaces(keyspace = %s): %w", keyspace.Name, err))
 				return
 			}
 
@@ -1256,6 +1775,45 @@ func (c *Cluster) getTablets(ctx context.Context) ([]*vtadminpb.Tablet, error) {
 	return c.parseTablets(rows)
 }
 
+// GetLiveSchema returns the schema of a serving tablet in the given shard of a
+// keyspace, or in any shard of the keyspace if shard is empty, along with the
+// tablet it was read from. The primary tablet of the shard is preferred. The
+// schema includes views, and is read from the tablet rather than the schema
+// cache, so that it can be compared to other live schemas.
+//
+// If tables is not empty, only the schemas of those tables are returned.
+func (c *Cluster) GetLiveSchema(ctx context.Context, keyspace string, shard string, tables []string) (*tabletmanagerdatapb.SchemaDefinition, *vtadminpb.Tablet, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetLiveSchema")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+	span.Annotate("keyspace", keyspace)
+	span.Annotate("shard", shard)
+
+	if keyspace == "" {
+		return nil, nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	tablets, err := c.FindTablets(ctx, func(tablet *vtadminpb.Tablet) bool {
+		return tablet.Tablet.Keyspace == keyspace && (shard == "" || tablet.Tablet.Shard == shard)
+	}, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tablet, err := pickSchemaTablet(keyspace, shard, tablets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema, err := c.getTabletSchema(ctx, tablet, tables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schema, tablet, nil
+}
+
 // GetSchemaOptions contains the options that modify the behavior of the
 // (*Cluster).GetSchema method.
 type GetSchemaOptions struct {
@@ -1705,6 +2263,220 @@ func (c *Cluster) getTabletsToQueryForSchemas(ctx context.Context, keyspace stri
 	return []*vtadminpb.Tablet{randomServingTablet}, nil
 }
 
+// GetSchemaDrift compares the live schema of every serving shard of a keyspace
+// to the schema of a reference shard, which defaults to the first serving
+// shard of the keyspace. Shards whose schemas cannot be read are reported with
+// an error rather than failing the whole request; only failing to read the
+// schema of the reference shard is an error.
+func (c *Cluster) GetSchemaDrift(ctx context.Context, req *vtadminpb.GetSchemaDriftRequest) (*vtadminpb.GetSchemaDriftResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetSchemaDrift")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("reference_shard", req.ReferenceShard)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	shards, err := c.FindAllShardsInKeyspace(ctx, req.Keyspace, FindAllShardsInKeyspaceOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	servingShards := make([]string, 0, len(shards))
+	for name, shard := range shards {
+		if shard.Shard != nil && shard.Shard.IsPrimaryServing {
+			servingShards = append(servingShards, name)
+		}
+	}
+	sort.Strings(servingShards)
+
+	if len(servingShards) == 0 {
+		return nil, fmt.Errorf("%w: keyspace %s has no serving shards", errors.ErrInvalidRequest, req.Keyspace)
+	}
+
+	referenceShard := req.ReferenceShard
+	if referenceShard == "" {
+		referenceShard = servingShards[0]
+	} else if _, ok := shards[referenceShard]; !ok {
+		return nil, fmt.Errorf("%w: shard %s/%s does not exist", errors.ErrInvalidRequest, req.Keyspace, referenceShard)
+	}
+
+	span.Annotate("reference_shard", referenceShard)
+
+	tablets, err := c.FindTablets(ctx, func(tablet *vtadminpb.Tablet) bool {
+		return tablet.Tablet.Keyspace == req.Keyspace
+	}, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	shardNames := servingShards
+	if !slices.Contains(shardNames, referenceShard) {
+		shardNames = append([]string{referenceShard}, shardNames...)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		schemas = make([]*tabletmanagerdatapb.SchemaDefinition, len(shardNames))
+		errs    = make([]error, len(shardNames))
+	)
+
+	for i, shard := range shardNames {
+		wg.Add(1)
+
+		go func(i int, shard string) {
+			defer wg.Done()
+
+			tablet, err := pickSchemaTablet(req.Keyspace, shard, tablets)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			schemas[i], errs[i] = c.getTabletSchema(ctx, tablet, req.Tables)
+		}(i, shard)
+	}
+
+	wg.Wait()
+
+	var reference *tabletmanagerdatapb.SchemaDefinition
+	for i, shard := range shardNames {
+		if shard != referenceShard {
+			continue
+		}
+
+		if errs[i] != nil {
+			return nil, fmt.Errorf("cannot get the schema of reference shard %s/%s: %w", req.Keyspace, referenceShard, errs[i])
+		}
+
+		reference = schemas[i]
+	}
+
+	resp := &vtadminpb.GetSchemaDriftResponse{
+		Cluster:        c.ToProto(),
+		Keyspace:       req.Keyspace,
+		ReferenceShard: referenceShard,
+		Shards:         make([]*vtadminpb.ShardSchemaDrift, 0, len(shardNames)-1),
+	}
+
+	for i, shard := range shardNames {
+		if shard == referenceShard {
+			continue
+		}
+
+		drift := &vtadminpb.ShardSchemaDrift{
+			Shard: shard,
+		}
+		resp.Shards = append(resp.Shards, drift)
+
+		if errs[i] != nil {
+			drift.Error = errs[i].Error()
+			continue
+		}
+
+		drift.Diffs, drift.Ddls, err = schemadrift.Diff(ctx, reference, schemas[i])
+		if err != nil {
+			drift.Error = err.Error()
+		}
+	}
+
+	return resp, nil
+}
+
+// GetSchemaMigrations returns the schema migrations for a keyspace in the
+// given cluster matching the filters in the request. If the request does not
+// specify a keyspace, migrations are fetched for every keyspace in the cluster
+// and the filters are applied to each of them.
+func (c *Cluster) GetSchemaMigrations(ctx context.Context, req *vtctldatapb.GetSchemaMigrationsRequest) ([]*vtadminpb.SchemaMigration, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetSchemaMigrations")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		req = &vtctldatapb.GetSchemaMigrationsRequest{}
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	var keyspaces []string
+	if req.Keyspace != "" {
+		keyspaces = []string{req.Keyspace}
+	} else {
+		if err := c.topoReadPool.Acquire(ctx); err != nil {
+			return nil, fmt.Errorf("GetSchemaMigrations(%+v) failed to acquire topoReadPool: %w", req, err)
+		}
+
+		resp, err := c.Vtctld.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+		c.topoReadPool.Release()
+
+		if err != nil {
+			return nil, err
+		}
+
+		keyspaces = make([]string, 0, len(resp.Keyspaces))
+		for _, ks := range resp.Keyspaces {
+			keyspaces = append(keyspaces, ks.Name)
+		}
+	}
+
+	var (
+		m          sync.Mutex
+		wg         sync.WaitGroup
+		rec        concurrency.AllErrorRecorder
+		migrations []*vtadminpb.SchemaMigration
+	)
+
+	for _, ks := range keyspaces {
+		wg.Add(1)
+		go func(ks string) {
+			defer wg.Done()
+
+			ksReq := req.CloneVT()
+			ksReq.Keyspace = ks
+
+			if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+				rec.RecordError(fmt.Errorf("GetSchemaMigrations(%+v) failed to acquire schemaMigrationsPool: %w", ksReq, err))
+				return
+			}
+
+			resp, err := c.Vtctld.GetSchemaMigrations(ctx, ksReq)
+			c.schemaMigrationsPool.Release()
+
+			if err != nil {
+				rec.RecordError(fmt.Errorf("GetSchemaMigrations(%s): %w", ks, err))
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			for _, migration := range resp.Migrations {
+				migrations = append(migrations, &vtadminpb.SchemaMigration{
+					Cluster:         c.ToProto(),
+					SchemaMigration: migration,
+				})
+			}
+		}(ks)
+	}
+
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	return migrations, nil
+}
+
 // GetShardReplicationPositions returns a ClusterShardReplicationPosition object
 // for each keyspace/shard in the cluster.
 func (c *Cluster) GetShardReplicationPositions(ctx context.Context, req *vtadminpb.GetShardReplicationPositionsRequest) ([]*vtadminpb.ClusterShardReplicationPosition, error) {
@@ -1909,6 +2681,7 @@ func (c *Cluster) GetWorkflow(ctx context.Context, keyspace string, name string,
 		Filter: func(workflow *vtadminpb.Workflow) bool {
 			return workflow.Workflow.Name == name
 		},
+		IncludeCopyProgress: true,
 	})
 	if err != nil {
 		return nil, err
@@ -1956,6 +2729,101 @@ func (c *Cluster) GetWorkflows(ctx context.Context, keyspaces []string, opts Get
 	})
 }
 
+// LaunchSchemaMigration launches one or all postponed schema migrations
+// in a keyspace in the given cluster, proxying a LaunchSchemaMigrationRequest
+// to a vtctld in that cluster.
+func (c *Cluster) LaunchSchemaMigration(ctx context.Context, req *vtctldatapb.LaunchSchemaMigrationRequest) (*vtctldatapb.LaunchSchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.LaunchSchemaMigration")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Uuid == "" {
+		return nil, fmt.Errorf("%w: migration uuid is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("LaunchSchemaMigration(%+v) failed to acquire schemaMigrationsPool: %w", req, err)
+	}
+	defer c.schemaMigrationsPool.Release()
+
+	return c.Vtctld.LaunchSchemaMigration(ctx, req)
+}
+
+// MoveTablesComplete completes a MoveTables or Reshard workflow in the given
+// cluster, once its traffic has been switched, proxying a
+// MoveTablesCompleteRequest to a vtctld in that cluster.
+func (c *Cluster) MoveTablesComplete(ctx context.Context, req *vtctldatapb.MoveTablesCompleteRequest) (*vtctldatapb.MoveTablesCompleteResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.MoveTablesComplete")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("target_keyspace", req.TargetKeyspace)
+	span.Annotate("dry_run", req.DryRun)
+
+	if req.Workflow == "" {
+		return nil, fmt.Errorf("%w: workflow name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.TargetKeyspace == "" {
+		return nil, fmt.Errorf("%w: target keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.workflowPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("MoveTablesComplete(%+v) failed to acquire workflowPool: %w", req, err)
+	}
+	defer c.workflowPool.Release()
+
+	return c.Vtctld.MoveTablesComplete(ctx, req)
+}
+
+// MoveTablesCreate creates a MoveTables workflow in the given cluster,
+// proxying a MoveTablesCreateRequest to a vtctld in that cluster. The workflow
+// is first validated by ValidateMoveTablesCreate, and is not created if the
+// validation finds any errors.
+func (c *Cluster) MoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (*vtctldatapb.WorkflowStatusResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.MoveTablesCreate")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	validation, err := c.ValidateMoveTablesCreate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(validation.Errors) > 0 {
+		return nil, &errors.BadRequest{
+			Err:        fmt.Errorf("%w: MoveTables workflow %s failed validation: %s", errors.ErrInvalidRequest, req.Workflow, strings.Join(validation.Errors, "; ")),
+			ErrDetails: validation,
+		}
+	}
+
+	if err := c.workflowPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("MoveTablesCreate(%+v) failed to acquire workflowPool: %w", req, err)
+	}
+	defer c.workflowPool.Release()
+
+	return c.Vtctld.MoveTablesCreate(ctx, req)
+}
+
 // PlannedFailoverShard fails over the shard either to a new primary or away
 // from an old primary. Both the current and candidate primaries must be
 // reachable and running.
@@ -2297,15 +3165,105 @@ func (c *Cluster) reloadTabletSchemas(ctx context.Context, req *vtadminpb.Reload
 	return results, nil
 }
 
-// SetWritable toggles the writability of a tablet, setting it to either
-// read-write or read-only.
-func (c *Cluster) SetWritable(ctx context.Context, req *vtctldatapb.SetWritableRequest) error {
-	span, ctx := trace.NewSpan(ctx, "Cluster.SetWritable")
+// ReshardCreate creates a Reshard workflow in the given cluster, proxying a
+// ReshardCreateRequest to a vtctld in that cluster. The workflow is first
+// validated by ValidateReshardCreate, and is not created if the validation
+// finds any errors.
+func (c *Cluster) ReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCreateRequest) (*vtctldatapb.WorkflowStatusResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.ReshardCreate")
 	defer span.Finish()
 
 	AnnotateSpan(c, span)
-	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
-	span.Annotate("writable", req.Writable)
+
+	validation, err := c.ValidateReshardCreate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(validation.Errors) > 0 {
+		return nil, &errors.BadRequest{
+			Err:        fmt.Errorf("%w: Reshard workflow %s failed validation: %s", errors.ErrInvalidRequest, req.Workflow, strings.Join(validation.Errors, "; ")),
+			ErrDetails: validation,
+		}
+	}
+
+	if err := c.workflowPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("ReshardCreate(%+v) failed to acquire workflowPool: %w", req, err)
+	}
+	defer c.workflowPool.Release()
+
+	return c.Vtctld.ReshardCreate(ctx, req)
+}
+
+// ReshardRecommend recommends the target shards of a Reshard of a keyspace in
+// the given cluster, proxying a ReshardRecommendRequest to a vtctld in that
+// cluster.
+func (c *Cluster) ReshardRecommend(ctx context.Context, req *vtctldatapb.ReshardRecommendRequest) (*vtctldatapb.ReshardRecommendResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.ReshardRecommend")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard_count", req.ShardCount)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.workflowPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("ReshardRecommend(%+v) failed to acquire workflowPool: %w", req, err)
+	}
+	defer c.workflowPool.Release()
+
+	return c.Vtctld.ReshardRecommend(ctx, req)
+}
+
+// RetrySchemaMigration retries a failed or cancelled schema migration in
+// a keyspace in the given cluster, proxying a RetrySchemaMigrationRequest to a
+// vtctld in that cluster.
+func (c *Cluster) RetrySchemaMigration(ctx context.Context, req *vtctldatapb.RetrySchemaMigrationRequest) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.RetrySchemaMigration")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Uuid == "" {
+		return nil, fmt.Errorf("%w: migration uuid is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.schemaMigrationsPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("RetrySchemaMigration(%+v) failed to acquire schemaMigrationsPool: %w", req, err)
+	}
+	defer c.schemaMigrationsPool.Release()
+
+	return c.Vtctld.RetrySchemaMigration(ctx, req)
+}
+
+// SetWritable toggles the writability of a tablet, setting it to either
+// read-write or read-only.
+func (c *Cluster) SetWritable(ctx context.Context, req *vtctldatapb.SetWritableRequest) error {
+	span, ctx := trace.NewSpan(ctx, "Cluster.SetWritable")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("writable", req.Writable)
 
 	_, err := c.Vtctld.SetWritable(ctx, req)
 	return err
@@ -2365,6 +3323,502 @@ func (c *Cluster) ToggleTabletReplication(ctx context.Context, tablet *vtadminpb
 	return err
 }
 
+// UndrainTablet returns a DRAINED tablet to serving by changing its type back
+// to tabletType, and removes the tags recorded by DrainTablet. If tabletType is
+// UNKNOWN, the type the tablet was drained from is used.
+func (c *Cluster) UndrainTablet(ctx context.Context, tablet *vtadminpb.Tablet, tabletType topodatapb.TabletType) (*vtadminpb.UndrainTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.UndrainTablet")
+	defer span.Finish()
+
+	alias := topoproto.TabletAliasString(tablet.Tablet.Alias)
+
+	AnnotateSpan(c, span)
+	span.Annotate("tablet_alias", alias)
+
+	if tablet.Tablet.Type != topodatapb.TabletType_DRAINED {
+		return nil, fmt.Errorf("%w: tablet %s is not drained (type %s)", errors.ErrInvalidRequest, alias, topoproto.TabletTypeLString(tablet.Tablet.Type))
+	}
+
+	if tabletType == topodatapb.TabletType_UNKNOWN {
+		drainedFrom, ok := tablet.Tablet.Tags[DrainedFromTag]
+		if !ok {
+			return nil, fmt.Errorf("%w: tablet %s has no %s tag, a tablet type is required", errors.ErrInvalidRequest, alias, DrainedFromTag)
+		}
+
+		var err error
+		tabletType, err = topoproto.ParseTabletType(drainedFrom)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tablet %s has an invalid %s tag: %s", errors.ErrInvalidRequest, alias, DrainedFromTag, err)
+		}
+	}
+
+	span.Annotate("tablet_type", topoproto.TabletTypeLString(tabletType))
+
+	switch tabletType {
+	case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+	default:
+		return nil, fmt.Errorf("%w: cannot undrain tablet %s to type %s", errors.ErrInvalidRequest, alias, topoproto.TabletTypeLString(tabletType))
+	}
+
+	if err := c.topoRWPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("UndrainTablet(%s) failed to acquire topoRWPool: %w", alias, err)
+	}
+	defer c.topoRWPool.Release()
+
+	if _, err := c.Vtctld.ChangeTabletType(ctx, &vtctldatapb.ChangeTabletTypeRequest{
+		TabletAlias: tablet.Tablet.Alias,
+		DbType:      tabletType,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to undrain tablet %s: %w", alias, err)
+	}
+
+	resp, err := c.Vtctld.ChangeTabletTags(ctx, &vtctldatapb.ChangeTabletTagsRequest{
+		TabletAlias: tablet.Tablet.Alias,
+		Tags:        map[string]string{DrainedFromTag: "", DrainReasonTag: ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tablet %s was undrained, but its drain tags could not be removed: %w", alias, err)
+	}
+
+	undrained := tablet.Tablet.CloneVT()
+	undrained.Type = tabletType
+	undrained.Tags = resp.AfterTags
+
+	return &vtadminpb.UndrainTabletResponse{
+		Status:  "ok",
+		Cluster: c.ToProto(),
+		Tablet:  undrained,
+	}, nil
+}
+
+// ValidateMoveTablesCreate checks that a MoveTables workflow can be created in
+// the given cluster, without creating it. It checks that the keyspaces, source
+// shards and tables exist, that the tables do not already exist in the target
+// keyspace, that they have a primary vindex if the target keyspace is sharded,
+// and that no workflow with the same name exists in the target keyspace.
+//
+// The problems found are returned in the Errors and Warnings of the response,
+// along with the tables of the source keyspace that can be moved, so that the
+// caller can pick from them. An error is only returned for invalid requests.
+func (c *Cluster) ValidateMoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (*vtadminpb.ValidateWorkflowCreateResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.ValidateMoveTablesCreate")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("source_keyspace", req.SourceKeyspace)
+	span.Annotate("target_keyspace", req.TargetKeyspace)
+
+	if req.Workflow == "" {
+		return nil, fmt.Errorf("%w: workflow name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.SourceKeyspace == "" {
+		return nil, fmt.Errorf("%w: source keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.TargetKeyspace == "" {
+		return nil, fmt.Errorf("%w: target keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	resp := &vtadminpb.ValidateWorkflowCreateResponse{}
+
+	if req.SourceKeyspace == req.TargetKeyspace && req.ExternalClusterName == "" {
+		resp.Errors = append(resp.Errors, "source and target keyspaces must be different")
+	}
+
+	var (
+		sourceTables      sets.Set[string]
+		targetTables      = sets.New[string]()
+		sourceTablesKnown bool
+	)
+
+	if req.ExternalClusterName != "" {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("source keyspace %s is in external cluster %s, and was not validated", req.SourceKeyspace, req.ExternalClusterName))
+	} else {
+		source, err := c.GetKeyspace(ctx, req.SourceKeyspace)
+		if err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("cannot get source keyspace %s: %s", req.SourceKeyspace, err))
+		} else {
+			for _, shard := range req.SourceShards {
+				if _, ok := source.Shards[shard]; !ok {
+					resp.Errors = append(resp.Errors, fmt.Sprintf("source shard %s/%s does not exist", req.SourceKeyspace, shard))
+				}
+			}
+
+			tables, err := c.getTableNames(ctx, req.SourceKeyspace)
+			if err != nil {
+				resp.Errors = append(resp.Errors, fmt.Sprintf("cannot list the tables of source keyspace %s: %s", req.SourceKeyspace, err))
+			} else {
+				sourceTables = sets.New[string](tables...)
+				sourceTablesKnown = true
+			}
+		}
+	}
+
+	var targetVSchema *vschemapb.Keyspace
+	if _, err := c.GetKeyspace(ctx, req.TargetKeyspace); err != nil {
+		resp.Errors = append(resp.Errors, fmt.Sprintf("cannot get target keyspace %s: %s", req.TargetKeyspace, err))
+	} else {
+		tables, err := c.getTableNames(ctx, req.TargetKeyspace)
+		if err != nil {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("cannot list the tables of target keyspace %s: %s", req.TargetKeyspace, err))
+		} else {
+			targetTables.Insert(tables...)
+		}
+
+		vschema, err := c.GetVSchema(ctx, req.TargetKeyspace)
+		if err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("cannot get the vschema of target keyspace %s: %s", req.TargetKeyspace, err))
+		} else {
+			targetVSchema = vschema.VSchema
+		}
+
+		resp.Errors = append(resp.Errors, c.checkWorkflowDoesNotExist(ctx, req.TargetKeyspace, req.Workflow)...)
+	}
+
+	if sourceTablesKnown {
+		resp.AvailableTables = sets.List(sourceTables.Difference(targetTables))
+	}
+
+	var tables []string
+	switch {
+	case req.AllTables && len(req.IncludeTables) > 0:
+		resp.Errors = append(resp.Errors, "all_tables and include_tables cannot both be set")
+	case req.AllTables:
+		if sourceTablesKnown {
+			tables = sets.List(sourceTables.Difference(sets.New[string](req.ExcludeTables...)))
+		}
+	case len(req.IncludeTables) > 0:
+		tables = sets.List(sets.New[string](req.IncludeTables...))
+	default:
+		resp.Errors = append(resp.Errors, "either all_tables or include_tables must be set")
+	}
+
+	for _, table := range tables {
+		if sourceTablesKnown && !sourceTables.Has(table) {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("table %s does not exist in source keyspace %s", table, req.SourceKeyspace))
+		}
+
+		if targetTables.Has(table) {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("table %s already exists in target keyspace %s", table, req.TargetKeyspace))
+		}
+
+		if targetVSchema != nil && targetVSchema.Sharded && !hasPrimaryVindex(targetVSchema.Tables[table]) {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("table %s has no primary vindex in the vschema of sharded target keyspace %s", table, req.TargetKeyspace))
+		}
+	}
+
+	if req.AllTables && sourceTablesKnown && len(tables) == 0 {
+		resp.Errors = append(resp.Errors, fmt.Sprintf("source keyspace %s has no tables to move", req.SourceKeyspace))
+	}
+
+	resp.Tables = tables
+	return resp, nil
+}
+
+// ValidateReshardCreate checks that a Reshard workflow can be created in the
+// given cluster, without creating it. It checks that the source shards exist
+// and are serving, that the target shards exist and are not serving yet, that
+// both cover the same, contiguous, key range, that the vschema of the keyspace
+// is sharded with a primary vindex for every table, and that no workflow with
+// the same name exists in the keyspace.
+//
+// The problems found are returned in the Errors and Warnings of the response.
+// An error is only returned for invalid requests.
+func (c *Cluster) ValidateReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCreateRequest) (*vtadminpb.ValidateWorkflowCreateResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.ValidateReshardCreate")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("source_shards", strings.Join(req.SourceShards, ","))
+	span.Annotate("target_shards", strings.Join(req.TargetShards, ","))
+
+	if req.Workflow == "" {
+		return nil, fmt.Errorf("%w: workflow name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	resp := &vtadminpb.ValidateWorkflowCreateResponse{}
+
+	if len(req.SourceShards) == 0 {
+		resp.Errors = append(resp.Errors, "at least one source shard is required")
+	}
+
+	if len(req.TargetShards) == 0 {
+		resp.Errors = append(resp.Errors, "at least one target shard is required")
+	}
+
+	for _, shard := range sets.List(sets.New[string](req.SourceShards...).Intersection(sets.New[string](req.TargetShards...))) {
+		resp.Errors = append(resp.Errors, fmt.Sprintf("shard %s/%s cannot be both a source and a target shard", req.Keyspace, shard))
+	}
+
+	ks, err := c.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		resp.Errors = append(resp.Errors, fmt.Sprintf("cannot get keyspace %s: %s", req.Keyspace, err))
+		return resp, nil
+	}
+
+	var (
+		sourceRanges []*topodatapb.KeyRange
+		targetRanges []*topodatapb.KeyRange
+	)
+
+	for _, name := range req.SourceShards {
+		shard, ok := ks.Shards[name]
+		switch {
+		case !ok || shard.Shard == nil:
+			resp.Errors = append(resp.Errors, fmt.Sprintf("source shard %s/%s does not exist", req.Keyspace, name))
+			continue
+		case !shard.Shard.IsPrimaryServing:
+			resp.Errors = append(resp.Errors, fmt.Sprintf("source shard %s/%s is not serving", req.Keyspace, name))
+		}
+
+		sourceRanges = append(sourceRanges, shard.Shard.KeyRange)
+	}
+
+	for _, name := range req.TargetShards {
+		shard, ok := ks.Shards[name]
+		switch {
+		case !ok || shard.Shard == nil:
+			resp.Errors = append(resp.Errors, fmt.Sprintf("target shard %s/%s does not exist; it and its tablets must be created first", req.Keyspace, name))
+			continue
+		case shard.Shard.IsPrimaryServing:
+			resp.Errors = append(resp.Errors, fmt.Sprintf("target shard %s/%s is already serving", req.Keyspace, name))
+		}
+
+		targetRanges = append(targetRanges, shard.Shard.KeyRange)
+	}
+
+	if len(sourceRanges) == len(req.SourceShards) && len(targetRanges) == len(req.TargetShards) && len(sourceRanges) > 0 && len(targetRanges) > 0 {
+		sourceRange, sourceOk := unionKeyRanges(sourceRanges)
+		targetRange, targetOk := unionKeyRanges(targetRanges)
+
+		switch {
+		case !sourceOk:
+			resp.Errors = append(resp.Errors, fmt.Sprintf("source shards %v do not cover a contiguous key range", req.SourceShards))
+		case !targetOk:
+			resp.Errors = append(resp.Errors, fmt.Sprintf("target shards %v do not cover a contiguous key range", req.TargetShards))
+		case !key.KeyRangeEqual(sourceRange, targetRange):
+			resp.Errors = append(resp.Errors, fmt.Sprintf("target shards %v cover key range %s, not the key range %s of source shards %v", req.TargetShards, key.KeyRangeString(targetRange), key.KeyRangeString(sourceRange), req.SourceShards))
+		}
+	}
+
+	vschema, err := c.GetVSchema(ctx, req.Keyspace)
+	switch {
+	case err != nil:
+		resp.Errors = append(resp.Errors, fmt.Sprintf("cannot get the vschema of keyspace %s: %s", req.Keyspace, err))
+	case !vschema.VSchema.GetSharded():
+		resp.Errors = append(resp.Errors, fmt.Sprintf("the vschema of keyspace %s is not sharded", req.Keyspace))
+	default:
+		tables := make([]string, 0, len(vschema.VSchema.Tables))
+		for table := range vschema.VSchema.Tables {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+
+		for _, table := range tables {
+			if !hasPrimaryVindex(vschema.VSchema.Tables[table]) {
+				resp.Errors = append(resp.Errors, fmt.Sprintf("table %s has no primary vindex in the vschema of keyspace %s", table, req.Keyspace))
+			}
+		}
+
+		resp.Tables = tables
+	}
+
+	resp.Errors = append(resp.Errors, c.checkWorkflowDoesNotExist(ctx, req.Keyspace, req.Workflow)...)
+
+	return resp, nil
+}
+
+// WorkflowSwitchTraffic switches the traffic of a MoveTables or Reshard
+// workflow in the given cluster, proxying a WorkflowSwitchTrafficRequest to a
+// vtctld in that cluster.
+func (c *Cluster) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.WorkflowSwitchTraffic")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", errors.ErrInvalidRequest)
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("direction", req.Direction)
+	span.Annotate("dry_run", req.DryRun)
+
+	if req.Keyspace == "" {
+		return nil, fmt.Errorf("%w: keyspace name is required", errors.ErrInvalidRequest)
+	}
+
+	if req.Workflow == "" {
+		return nil, fmt.Errorf("%w: workflow name is required", errors.ErrInvalidRequest)
+	}
+
+	if err := c.workflowPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("WorkflowSwitchTraffic(%+v) failed to acquire workflowPool: %w", req, err)
+	}
+	defer c.workflowPool.Release()
+
+	return c.Vtctld.WorkflowSwitchTraffic(ctx, req)
+}
+
+// getTableNames returns the sorted names of the tables of a keyspace.
+func (c *Cluster) getTableNames(ctx context.Context, keyspace string) ([]string, error) {
+	schema, err := c.GetSchema(ctx, keyspace, GetSchemaOptions{
+		BaseRequest: &vtctldatapb.GetSchemaRequest{
+			TableNamesOnly: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]string, 0, len(schema.TableDefinitions))
+	for _, td := range schema.TableDefinitions {
+		tables = append(tables, td.Name)
+	}
+	sort.Strings(tables)
+
+	return tables, nil
+}
+
+// checkWorkflowDoesNotExist returns a validation error if a workflow with the
+// given name exists in the keyspace, or if that cannot be checked.
+func (c *Cluster) checkWorkflowDoesNotExist(ctx context.Context, keyspace string, workflow string) []string {
+	if err := c.workflowReadPool.Acquire(ctx); err != nil {
+		return []string{fmt.Sprintf("cannot check for existing workflows in keyspace %s: %s", keyspace, err)}
+	}
+
+	resp, err := c.Vtctld.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{
+		Keyspace: keyspace,
+		NameOnly: true,
+	})
+	c.workflowReadPool.Release()
+
+	if err != nil {
+		return []string{fmt.Sprintf("cannot check for existing workflows in keyspace %s: %s", keyspace, err)}
+	}
+
+	for _, wf := range resp.Workflows {
+		if wf.Name == workflow {
+			return []string{fmt.Sprintf("workflow %s already exists in keyspace %s", workflow, keyspace)}
+		}
+	}
+
+	return nil
+}
+
+// getTabletSchema returns the schema, including views, of a single tablet.
+func (c *Cluster) getTabletSchema(ctx context.Context, tablet *vtadminpb.Tablet, tables []string) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	if err := c.schemaReadPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("GetSchema(cluster = %s, tablet = %s) failed to acquire schemaReadPool: %w", c.ID, topoproto.TabletAliasString(tablet.Tablet.Alias), err)
+	}
+	defer c.schemaReadPool.Release()
+
+	resp, err := c.Vtctld.GetSchema(ctx, &vtctldatapb.GetSchemaRequest{
+		TabletAlias:  tablet.Tablet.Alias,
+		Tables:       tables,
+		IncludeViews: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetSchema(cluster = %s, tablet = %s) failed: %w", c.ID, topoproto.TabletAliasString(tablet.Tablet.Alias), err)
+	}
+
+	if resp.Schema == nil {
+		return &tabletmanagerdatapb.SchemaDefinition{}, nil
+	}
+
+	return resp.Schema, nil
+}
+
+// pickSchemaTablet picks the tablet to read the schema of a shard from,
+// preferring its primary over the other serving tablets. If shard is empty,
+// any shard of the keyspace may be picked.
+func pickSchemaTablet(keyspace string, shard string, tablets []*vtadminpb.Tablet) (*vtadminpb.Tablet, error) {
+	servingTablets := vtadminproto.FilterTablets(func(tablet *vtadminpb.Tablet) bool {
+		return tablet.Tablet.Keyspace == keyspace && (shard == "" || tablet.Tablet.Shard == shard) && tablet.State == vtadminpb.Tablet_SERVING
+	}, tablets, len(tablets))
+
+	if len(servingTablets) == 0 {
+		if shard == "" {
+			return nil, fmt.Errorf("%w for keyspace %s", errors.ErrNoServingTablet, keyspace)
+		}
+
+		return nil, fmt.Errorf("%w for shard %s/%s", errors.ErrNoServingTablet, keyspace, shard)
+	}
+
+	for _, tablet := range servingTablets {
+		if tablet.Tablet.Type == topodatapb.TabletType_PRIMARY {
+			return tablet, nil
+		}
+	}
+
+	return servingTablets[rand.Intn(len(servingTablets))], nil
+}
+
+// hasPrimaryVindex returns whether a table of a sharded vschema can be
+// routed: it either has a primary vindex, or is a reference or sequence table.
+func hasPrimaryVindex(table *vschemapb.Table) bool {
+	if table == nil {
+		return false
+	}
+
+	switch table.Type {
+	case "reference", "sequence":
+		return true
+	}
+
+	return len(table.ColumnVindexes) > 0
+}
+
+// unionKeyRanges merges the key ranges of a set of shards, returning false if
+// they do not cover a single contiguous range. A nil key range is the full
+// range.
+func unionKeyRanges(ranges []*topodatapb.KeyRange) (*topodatapb.KeyRange, bool) {
+	sorted := make([]*topodatapb.KeyRange, 0, len(ranges))
+	for _, kr := range ranges {
+		if kr == nil {
+			kr = &topodatapb.KeyRange{}
+		}
+
+		sorted = append(sorted, kr)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return key.KeyRangeLess(sorted[i], sorted[j])
+	})
+
+	union := sorted[0]
+	for _, kr := range sorted[1:] {
+		merged, ok := key.KeyRangeAdd(union, kr)
+		if !ok {
+			return nil, false
+		}
+
+		union = merged
+	}
+
+	return union, true
+}
+
 // Debug returns a map of debug information for a cluster.
 func (c *Cluster) Debug() map[string]any {
 	m := map[string]any{
@@ -2378,6 +3832,8 @@ func (c *Cluster) Debug() map[string]any {
 			"workflow_read_pool":      json.RawMessage(c.workflowReadPool.StatsJSON()),
 			"emergency_failover_pool": json.RawMessage(c.emergencyFailoverPool.StatsJSON()),
 			"failover_pool":           json.RawMessage(c.failoverPool.StatsJSON()),
+			"schema_migrations_pool":  json.RawMessage(c.schemaMigrationsPool.StatsJSON()),
+			"workflow_pool":           json.RawMessage(c.workflowPool.StatsJSON()),
 		},
 		"caches": map[string]any{
 			"schemas": c.schemaCache.Debug(),
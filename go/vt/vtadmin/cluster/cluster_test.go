@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strings"
 	"testing"
@@ -37,6 +39,7 @@ import (
 	vtadminerrors "vitess.io/vitess/go/vt/vtadmin/errors"
 	"vitess.io/vitess/go/vt/vtadmin/testutil"
 	"vitess.io/vitess/go/vt/vtadmin/vtctldclient/fakevtctldclient"
+	"vitess.io/vitess/go/vt/vtadmin/vtsql/fakevtsql"
 	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
 
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
@@ -498,6 +501,358 @@ func TestDeleteShards(t *testing.T) {
 	}
 }
 
+func TestDrainTablet(t *testing.T) {
+	t.Parallel()
+
+	testClusterProto := &vtadminpb.Cluster{
+		Id:   "test",
+		Name: "test",
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		cfg       testutil.TestClusterConfig
+		tablet    *vtadminpb.Tablet
+		reason    string
+		expected  *vtadminpb.DrainTabletResponse
+		shouldErr bool
+	}{
+		{
+			name: "ok",
+			cfg: testutil.TestClusterConfig{
+				Cluster: testClusterProto,
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					ChangeTabletTagsResults: map[string]struct {
+						Response *vtctldatapb.ChangeTabletTagsResponse
+						Error    error
+					}{
+						"zone1-0000000100": {
+							Response: &vtctldatapb.ChangeTabletTagsResponse{
+								AfterTags: map[string]string{
+									"drained_from": "replica",
+									"drain_reason": "bad disk",
+								},
+							},
+						},
+					},
+					ChangeTabletTypeResults: map[string]struct {
+						Response *vtctldatapb.ChangeTabletTypeResponse
+						Error    error
+					}{
+						"zone1-0000000100": {
+							Response: &vtctldatapb.ChangeTabletTypeResponse{
+								AfterTablet: &topodatapb.Tablet{
+									Alias: &topodatapb.TabletAlias{
+										Cell: "zone1",
+										Uid:  100,
+									},
+									Type: topodatapb.TabletType_DRAINED,
+									Tags: map[string]string{
+										"drained_from": "replica",
+										"drain_reason": "bad disk",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			tablet: &vtadminpb.Tablet{
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_REPLICA,
+				},
+			},
+			reason: "bad disk",
+			expected: &vtadminpb.DrainTabletResponse{
+				Status:  "ok",
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_DRAINED,
+					Tags: map[string]string{
+						"drained_from": "replica",
+						"drain_reason": "bad disk",
+					},
+				},
+			},
+		},
+		{
+			name: "primary tablet",
+			cfg: testutil.TestClusterConfig{
+				Cluster:      testClusterProto,
+				VtctldClient: &fakevtctldclient.VtctldClient{},
+			},
+			tablet: &vtadminpb.Tablet{
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_PRIMARY,
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "ChangeTabletTags error",
+			cfg: testutil.TestClusterConfig{
+				Cluster: testClusterProto,
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					ChangeTabletTagsResults: map[string]struct {
+						Response *vtctldatapb.ChangeTabletTagsResponse
+						Error    error
+					}{
+						"zone1-0000000100": {
+							Error: assert.AnError,
+						},
+					},
+				},
+			},
+			tablet: &vtadminpb.Tablet{
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_RDONLY,
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "ChangeTabletType error",
+			cfg: testutil.TestClusterConfig{
+				Cluster: testClusterProto,
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					ChangeTabletTagsResults: map[string]struct {
+						Response *vtctldatapb.ChangeTabletTagsResponse
+						Error    error
+					}{
+						"zone1-0000000100": {
+							Response: &vtctldatapb.ChangeTabletTagsResponse{},
+						},
+					},
+					ChangeTabletTypeResults: map[string]struct {
+						Response *vtctldatapb.ChangeTabletTypeResponse
+						Error    error
+					}{
+						"zone1-0000000100": {
+							Error: assert.AnError,
+						},
+					},
+				},
+			},
+			tablet: &vtadminpb.Tablet{
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_REPLICA,
+				},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.BuildCluster(t, tt.cfg)
+			defer c.Close()
+
+			resp, err := c.DrainTablet(ctx, tt.tablet, tt.reason)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestExecuteQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	results := map[string]map[string]*fakevtsql.QueryResult{
+		"ks@replica": {
+			"select id, val from t1 limit 3": {
+				Columns: []string{"id", "val"},
+				Rows:    [][]any{{1, "a"}, {2, nil}, {3, "c"}},
+			},
+			"select id, val from t1 limit 2": {
+				Columns: []string{"id", "val"},
+				Rows:    [][]any{{1, "a"}, {2, nil}, {3, "c"}},
+			},
+		},
+		"ks@rdonly": {
+			"select id from t1 limit 1": {
+				Columns: []string{"id"},
+				Rows:    [][]any{{1}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		req       *vtadminpb.ExecuteQueryRequest
+		expected  *vtadminpb.ExecuteQueryResponse
+		shouldErr bool
+	}{
+		{
+			name: "defaults to replica",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Keyspace: "ks",
+				Sql:      "select id, val from t1",
+			},
+			expected: &vtadminpb.ExecuteQueryResponse{
+				Cluster:    &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+				Keyspace:   "ks",
+				TabletType: topodatapb.TabletType_REPLICA,
+				Sql:        "select id, val from t1 limit 3",
+				Result: &vtadminpb.QueryResult{
+					Columns: []string{"id", "val"},
+					Rows: []*vtadminpb.QueryResult_Row{
+						{Values: []string{"1", "a"}, Nulls: []bool{false, false}},
+						{Values: []string{"2", ""}, Nulls: []bool{false, true}},
+					},
+					Truncated: true,
+				},
+			},
+		},
+		{
+			name: "max rows from request",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Keyspace:   "ks",
+				TabletType: topodatapb.TabletType_REPLICA,
+				Sql:        "select id, val from t1",
+				MaxRows:    1,
+			},
+			expected: &vtadminpb.ExecuteQueryResponse{
+				Cluster:    &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+				Keyspace:   "ks",
+				TabletType: topodatapb.TabletType_REPLICA,
+				Sql:        "select id, val from t1 limit 2",
+				Result: &vtadminpb.QueryResult{
+					Columns: []string{"id", "val"},
+					Rows: []*vtadminpb.QueryResult_Row{
+						{Values: []string{"1", "a"}, Nulls: []bool{false, false}},
+					},
+					Truncated: true,
+				},
+			},
+		},
+		{
+			name: "rdonly",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Keyspace:   "ks",
+				TabletType: topodatapb.TabletType_RDONLY,
+				Sql:        "select id from t1 limit 1",
+			},
+			expected: &vtadminpb.ExecuteQueryResponse{
+				Cluster:    &vtadminpb.Cluster{Id: "c1", Name: "cluster1"},
+				Keyspace:   "ks",
+				TabletType: topodatapb.TabletType_RDONLY,
+				Sql:        "select id from t1 limit 1",
+				Result: &vtadminpb.QueryResult{
+					Columns: []string{"id"},
+					Rows: []*vtadminpb.QueryResult_Row{
+						{Values: []string{"1"}, Nulls: []bool{false}},
+					},
+				},
+			},
+		},
+		{
+			name: "write query",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Keyspace: "ks",
+				Sql:      "delete from t1",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "unsupported tablet type",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Keyspace:   "ks",
+				TabletType: topodatapb.TabletType_BACKUP,
+				Sql:        "select id from t1",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "query error",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Keyspace: "otherks",
+				Sql:      "select id from t1",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "missing keyspace",
+			req: &vtadminpb.ExecuteQueryRequest{
+				Sql: "select id from t1",
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "nil request",
+			req:       nil,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+				Cluster: &vtadminpb.Cluster{
+					Id:   "c1",
+					Name: "cluster1",
+				},
+				DBConfig: testutil.Dbcfg{
+					QueryResults: results,
+				},
+				Config: &cluster.Config{
+					QueryConsoleConfig: &cluster.QueryConsoleConfig{
+						MaxRows: 2,
+					},
+				},
+			})
+			defer c.Close()
+
+			resp, err := c.ExecuteQuery(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestFindTablet(t *testing.T) {
 	t.Parallel()
 
@@ -1578,45 +1933,317 @@ func TestGetCellsAliases(t *testing.T) {
 	}
 }
 
-func TestGetSchema(t *testing.T) {
+func TestGetGraph(t *testing.T) {
 	t.Parallel()
 
-	tests := []struct {
-		name      string
-		vtctld    vtctldclient.VtctldClient
-		req       *vtctldatapb.GetSchemaRequest
-		tablet    *vtadminpb.Tablet
-		expected  *vtadminpb.Schema
-		shouldErr bool
-	}{
-		{
-			name: "success",
-			vtctld: &fakevtctldclient.VtctldClient{
-				GetSchemaResults: map[string]struct {
-					Response *vtctldatapb.GetSchemaResponse
-					Error    error
-				}{
-					"zone1-0000000100": {
-						Response: &vtctldatapb.GetSchemaResponse{
-							Schema: &tabletmanagerdatapb.SchemaDefinition{
-								TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
-									{
-										Name: "some_table",
-									},
-								},
-							},
-						},
-					},
-				},
+	alias := func(cell string, uid uint32) *topodatapb.TabletAlias {
+		return &topodatapb.TabletAlias{Cell: cell, Uid: uid}
+	}
+
+	tablet := func(cell string, uid uint32, shard string, typ topodatapb.TabletType, state vtadminpb.Tablet_ServingState) *vtadminpb.Tablet {
+		return &vtadminpb.Tablet{
+			Tablet: &topodatapb.Tablet{
+				Alias:    alias(cell, uid),
+				Hostname: fmt.Sprintf("%s-%d", cell, uid),
+				Keyspace: "ks",
+				Shard:    shard,
+				Type:     typ,
 			},
-			req: &vtctldatapb.GetSchemaRequest{},
-			tablet: &vtadminpb.Tablet{
-				State: vtadminpb.Tablet_SERVING,
-				Tablet: &topodatapb.Tablet{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
+			State: state,
+		}
+	}
+
+	cpb := &vtadminpb.Cluster{
+		Id:   "c1",
+		Name: "cluster1",
+	}
+
+	vtctld := &fakevtctldclient.VtctldClient{
+		GetCellInfoNamesResults: &struct {
+			Response *vtctldatapb.GetCellInfoNamesResponse
+			Error    error
+		}{
+			Response: &vtctldatapb.GetCellInfoNamesResponse{
+				Names: []string{"zone1", "zone2", "zone3"},
+			},
+		},
+		GetKeyspacesResults: &struct {
+			Keyspaces []*vtctldatapb.Keyspace
+			Error     error
+		}{
+			Keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+		},
+		FindAllShardsInKeyspaceResults: map[string]struct {
+			Response *vtctldatapb.FindAllShardsInKeyspaceResponse
+			Error    error
+		}{
+			"ks": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: map[string]*vtctldatapb.Shard{
+						"-80": {
+							Keyspace: "ks",
+							Name:     "-80",
+							Shard: &topodatapb.Shard{
+								PrimaryAlias:     alias("zone1", 100),
+								IsPrimaryServing: true,
+							},
+						},
+						"80-": {
+							Keyspace: "ks",
+							Name:     "80-",
+							Shard: &topodatapb.Shard{
+								PrimaryAlias: alias("zone1", 200),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+		Cluster:      cpb,
+		VtctldClient: vtctld,
+		Tablets: []*vtadminpb.Tablet{
+			tablet("zone2", 201, "80-", topodatapb.TabletType_REPLICA, vtadminpb.Tablet_SERVING),
+			tablet("zone1", 101, "-80", topodatapb.TabletType_REPLICA, vtadminpb.Tablet_SERVING),
+			tablet("zone1", 100, "-80", topodatapb.TabletType_PRIMARY, vtadminpb.Tablet_SERVING),
+			tablet("zone2", 102, "-80", topodatapb.TabletType_RDONLY, vtadminpb.Tablet_NOT_SERVING),
+			tablet("zone1", 200, "80-", topodatapb.TabletType_PRIMARY, vtadminpb.Tablet_SERVING),
+		},
+	})
+	defer c.Close()
+
+	tabletNode := func(cell string, uid uint32, typ topodatapb.TabletType, state vtadminpb.Tablet_ServingState, source *topodatapb.TabletAlias) *vtadminpb.ClusterGraph_TabletNode {
+		return &vtadminpb.ClusterGraph_TabletNode{
+			Alias:             alias(cell, uid),
+			Hostname:          fmt.Sprintf("%s-%d", cell, uid),
+			Type:              typ,
+			State:             state,
+			ReplicationSource: source,
+		}
+	}
+
+	expected := &vtadminpb.ClusterGraph{
+		Cluster: cpb,
+		Cells: []*vtadminpb.ClusterGraph_CellNode{
+			{
+				Name: "zone1",
+				Keyspaces: []*vtadminpb.ClusterGraph_KeyspaceNode{
+					{
+						Name: "ks",
+						Shards: []*vtadminpb.ClusterGraph_ShardNode{
+							{
+								Name:             "-80",
+								PrimaryAlias:     alias("zone1", 100),
+								IsPrimaryServing: true,
+								Tablets: []*vtadminpb.ClusterGraph_TabletNode{
+									tabletNode("zone1", 100, topodatapb.TabletType_PRIMARY, vtadminpb.Tablet_SERVING, nil),
+									tabletNode("zone1", 101, topodatapb.TabletType_REPLICA, vtadminpb.Tablet_SERVING, alias("zone1", 100)),
+								},
+							},
+							{
+								Name:         "80-",
+								PrimaryAlias: alias("zone1", 200),
+								Tablets: []*vtadminpb.ClusterGraph_TabletNode{
+									tabletNode("zone1", 200, topodatapb.TabletType_PRIMARY, vtadminpb.Tablet_SERVING, nil),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "zone2",
+				Keyspaces: []*vtadminpb.ClusterGraph_KeyspaceNode{
+					{
+						Name: "ks",
+						Shards: []*vtadminpb.ClusterGraph_ShardNode{
+							{
+								Name:             "-80",
+								PrimaryAlias:     alias("zone1", 100),
+								IsPrimaryServing: true,
+								Tablets: []*vtadminpb.ClusterGraph_TabletNode{
+									tabletNode("zone2", 102, topodatapb.TabletType_RDONLY, vtadminpb.Tablet_NOT_SERVING, alias("zone1", 100)),
+								},
+							},
+							{
+								Name:         "80-",
+								PrimaryAlias: alias("zone1", 200),
+								Tablets: []*vtadminpb.ClusterGraph_TabletNode{
+									tabletNode("zone2", 201, topodatapb.TabletType_REPLICA, vtadminpb.Tablet_SERVING, alias("zone1", 200)),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "zone3",
+			},
+		},
+	}
+
+	graph, err := c.GetGraph(context.Background())
+	require.NoError(t, err)
+	utils.MustMatch(t, expected, graph)
+
+	t.Run("error", func(t *testing.T) {
+		c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+			Cluster: cpb,
+			VtctldClient: &fakevtctldclient.VtctldClient{
+				GetCellInfoNamesResults: vtctld.GetCellInfoNamesResults,
+				GetKeyspacesResults: &struct {
+					Keyspaces []*vtctldatapb.Keyspace
+					Error     error
+				}{
+					Error: assert.AnError,
+				},
+			},
+		})
+		defer c.Close()
+
+		_, err := c.GetGraph(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestGetMetrics(t *testing.T) {
+	t.Parallel()
+
+	tabletServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Queries": {"TotalCount": 10}, "HeartbeatCurrentLagNs": 3000000000}`)
+	}))
+	defer tabletServer.Close()
+
+	tablets := []*vtadminpb.Tablet{
+		{
+			Tablet: &topodatapb.Tablet{
+				Alias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				Hostname: tabletServer.Listener.Addr().String(),
+				Keyspace: "ks",
+				Shard:    "-",
+				Type:     topodatapb.TabletType_REPLICA,
+			},
+			State: vtadminpb.Tablet_SERVING,
+		},
+		{
+			Tablet: &topodatapb.Tablet{
+				Alias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  101,
+				},
+				Keyspace: "ks",
+				Shard:    "-",
+				Type:     topodatapb.TabletType_REPLICA,
+			},
+			State: vtadminpb.Tablet_NOT_SERVING,
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+			Cluster: &vtadminpb.Cluster{
+				Id:   "c1",
+				Name: "cluster1",
+			},
+			Tablets: tablets,
+		})
+		defer c.Close()
+
+		cm := c.GetMetrics(context.Background())
+		assert.False(t, cm.Enabled)
+		assert.Empty(t, cm.Samples)
+		assert.Empty(t, cm.Components)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+			Cluster: &vtadminpb.Cluster{
+				Id:   "c1",
+				Name: "cluster1",
+			},
+			Tablets: tablets,
+			Config: &cluster.Config{
+				TabletFQDNTmplStr: "{{ .Tablet.Hostname }}",
+				MetricsConfig: &cluster.MetricsConfig{
+					ScrapeInterval: time.Hour,
+				},
+			},
+		})
+		defer c.Close()
+
+		var cm *vtadminpb.ClusterMetrics
+		require.Eventually(t, func() bool {
+			cm = c.GetMetrics(context.Background())
+			return len(cm.Samples) > 0
+		}, time.Second*10, time.Millisecond*10, "cluster should scrape metrics as soon as it is created")
+
+		assert.True(t, cm.Enabled)
+		interval, _, err := protoutil.DurationFromProto(cm.ScrapeInterval)
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, interval)
+		assert.Equal(t, 3.0, cm.Samples[0].MaxReplicationLagSeconds)
+		// The vtgate built by the testutil has no FQDN, so it cannot be scraped.
+		assert.Equal(t, int32(1), cm.Samples[0].ScrapeErrors)
+
+		// Tablets that are not serving are not scraped.
+		require.Len(t, cm.Components, 2)
+		assert.Equal(t, vtadminpb.ComponentMetrics_VTGATE, cm.Components[0].Type)
+		assert.NotEmpty(t, cm.Components[0].Error)
+		assert.Equal(t, "zone1-0000000100", cm.Components[1].Name)
+		assert.Empty(t, cm.Components[1].Error)
+		assert.Equal(t, 3.0, cm.Components[1].ReplicationLagSeconds)
+	})
+}
+
+func TestGetSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		vtctld    vtctldclient.VtctldClient
+		req       *vtctldatapb.GetSchemaRequest
+		tablet    *vtadminpb.Tablet
+		expected  *vtadminpb.Schema
+		shouldErr bool
+	}{
+		{
+			name: "success",
+			vtctld: &fakevtctldclient.VtctldClient{
+				GetSchemaResults: map[string]struct {
+					Response *vtctldatapb.GetSchemaResponse
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Response: &vtctldatapb.GetSchemaResponse{
+							Schema: &tabletmanagerdatapb.SchemaDefinition{
+								TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+									{
+										Name: "some_table",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.GetSchemaRequest{},
+			tablet: &vtadminpb.Tablet{
+				State: vtadminpb.Tablet_SERVING,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
 					Keyspace: "testkeyspace",
 				},
 			},
@@ -2722,77 +3349,453 @@ func TestGetSchema(t *testing.T) {
 	})
 }
 
-func TestGetShardReplicationPositions(t *testing.T) {
+func TestGetSchemaDrift(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	shard := func(keyspace string, name string, serving bool) *vtctldatapb.Shard {
+		return &vtctldatapb.Shard{
+			Keyspace: keyspace,
+			Name:     name,
+			Shard: &topodatapb.Shard{
+				IsPrimaryServing: serving,
+			},
+		}
+	}
+	tablet := func(keyspace string, shard string, uid uint32, state vtadminpb.Tablet_ServingState) *vtadminpb.Tablet {
+		return &vtadminpb.Tablet{
+			State: state,
+			Tablet: &topodatapb.Tablet{
+				Alias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  uid,
+				},
+				Keyspace: keyspace,
+				Shard:    shard,
+				Type:     topodatapb.TabletType_PRIMARY,
+			},
+		}
+	}
+	schema := func(tables ...*tabletmanagerdatapb.TableDefinition) struct {
+		Response *vtctldatapb.GetSchemaResponse
+		Error    error
+	} {
+		return struct {
+			Response *vtctldatapb.GetSchemaResponse
+			Error    error
+		}{
+			Response: &vtctldatapb.GetSchemaResponse{
+				Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: tables,
+				},
+			},
+		}
+	}
+
+	t1 := &tabletmanagerdatapb.TableDefinition{Name: "t1", Schema: "create table t1 (id int primary key, name varchar(64))"}
+	t1NoName := &tabletmanagerdatapb.TableDefinition{Name: "t1", Schema: "create table t1 (id int primary key)"}
+	t2 := &tabletmanagerdatapb.TableDefinition{Name: "t2", Schema: "create table t2 (id int primary key)"}
+
+	vtctld := &fakevtctldclient.VtctldClient{
+		FindAllShardsInKeyspaceResults: map[string]struct {
+			Response *vtctldatapb.FindAllShardsInKeyspaceResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: map[string]*vtctldatapb.Shard{
+						"-80":   shard("customer", "-80", true),
+						"80-":   shard("customer", "80-", true),
+						"80-c0": shard("customer", "80-c0", false),
+					},
+				},
+			},
+			"commerce": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: map[string]*vtctldatapb.Shard{
+						"-80": shard("commerce", "-80", true),
+						"80-": shard("commerce", "80-", true),
+					},
+				},
+			},
+			"unsharded": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: map[string]*vtctldatapb.Shard{
+						"-": shard("unsharded", "-", false),
+					},
+				},
+			},
+		},
+		GetSchemaResults: map[string]struct {
+			Response *vtctldatapb.GetSchemaResponse
+			Error    error
+		}{
+			"zone1-0000000100": schema(t1, t2),
+			"zone1-0000000200": schema(t1NoName),
+			"zone1-0000000300": schema(t1, t2),
+		},
+	}
+	tablets := []*vtadminpb.Tablet{
+		tablet("customer", "-80", 100, vtadminpb.Tablet_SERVING),
+		tablet("customer", "80-", 200, vtadminpb.Tablet_SERVING),
+		tablet("commerce", "-80", 300, vtadminpb.Tablet_SERVING),
+		tablet("commerce", "80-", 400, vtadminpb.Tablet_NOT_SERVING),
+	}
+	c1 := &vtadminpb.Cluster{
+		Id:   "c1",
+		Name: "cluster1",
+	}
+
 	tests := []struct {
 		name      string
-		cfg       testutil.TestClusterConfig
-		req       *vtadminpb.GetShardReplicationPositionsRequest
-		expected  []*vtadminpb.ClusterShardReplicationPosition
+		req       *vtadminpb.GetSchemaDriftRequest
+		expected  *vtadminpb.GetSchemaDriftResponse
 		shouldErr bool
 	}{
 		{
-			cfg: testutil.TestClusterConfig{
-				Cluster: &vtadminpb.Cluster{
-					Id:   "c1",
-					Name: "cluster1",
-				},
-				VtctldClient: &fakevtctldclient.VtctldClient{
-					GetKeyspaceResults: map[string]struct {
-						Response *vtctldatapb.GetKeyspaceResponse
-						Error    error
-					}{
-						"ks": {
-							Response: &vtctldatapb.GetKeyspaceResponse{
-								Keyspace: &vtctldatapb.Keyspace{
-									Keyspace: &topodatapb.Keyspace{},
-								},
+			name: "first serving shard as reference",
+			req: &vtadminpb.GetSchemaDriftRequest{
+				Keyspace: "customer",
+			},
+			expected: &vtadminpb.GetSchemaDriftResponse{
+				Cluster:        c1,
+				Keyspace:       "customer",
+				ReferenceShard: "-80",
+				Shards: []*vtadminpb.ShardSchemaDrift{
+					{
+						Shard: "80-",
+						Diffs: []*vtadminpb.TableSchemaDiff{
+							{
+								Name:           "t1",
+								Type:           vtadminpb.TableSchemaDiff_CHANGED,
+								MissingColumns: []string{"name"},
+								Ddls:           []string{"ALTER TABLE `t1` ADD COLUMN `name` varchar(64)"},
+							},
+							{
+								Name: "t2",
+								Type: vtadminpb.TableSchemaDiff_MISSING_IN_TARGET,
+								Ddls: []string{"CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)"},
 							},
 						},
+						Ddls: []string{
+							"ALTER TABLE `t1` ADD COLUMN `name` varchar(64)",
+							"CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)",
+						},
 					},
-					FindAllShardsInKeyspaceResults: map[string]struct {
-						Response *vtctldatapb.FindAllShardsInKeyspaceResponse
-						Error    error
-					}{
-						"ks": {
-							Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
-								Shards: map[string]*vtctldatapb.Shard{
-									"-": {
-										Keyspace: "ks",
-										Name:     "-",
-										Shard:    &topodatapb.Shard{},
-									},
-								},
+				},
+			},
+		},
+		{
+			name: "explicit reference shard",
+			req: &vtadminpb.GetSchemaDriftRequest{
+				Keyspace:       "customer",
+				ReferenceShard: "80-",
+			},
+			expected: &vtadminpb.GetSchemaDriftResponse{
+				Cluster:        c1,
+				Keyspace:       "customer",
+				ReferenceShard: "80-",
+				Shards: []*vtadminpb.ShardSchemaDrift{
+					{
+						Shard: "-80",
+						Diffs: []*vtadminpb.TableSchemaDiff{
+							{
+								Name:         "t1",
+								Type:         vtadminpb.TableSchemaDiff_CHANGED,
+								ExtraColumns: []string{"name"},
+								Ddls:         []string{"ALTER TABLE `t1` DROP COLUMN `name`"},
+							},
+							{
+								Name: "t2",
+								Type: vtadminpb.TableSchemaDiff_EXTRA_IN_TARGET,
+								Ddls: []string{"DROP TABLE `t2`"},
 							},
 						},
+						Ddls: []string{
+							"DROP TABLE `t2`",
+							"ALTER TABLE `t1` DROP COLUMN `name`",
+						},
 					},
-					ShardReplicationPositionsResults: map[string]struct {
-						Response *vtctldatapb.ShardReplicationPositionsResponse
-						Error    error
-					}{
-						"ks/-": {
-							Response: &vtctldatapb.ShardReplicationPositionsResponse{
-								ReplicationStatuses: map[string]*replicationdatapb.Status{
-									"zone1-001": {
-										IoState:  int32(replication.ReplicationStateStopped),
-										SqlState: int32(replication.ReplicationStateStopped),
-										Position: "MySQL56/08d0dbbb-be29-11eb-9fea-0aafb9701138:1-109848265",
-									},
-									"zone1-002": { // Note: in reality other fields will be set on replicating hosts as well, but this is sufficient to illustrate in the testing.
-										IoState:  int32(replication.ReplicationStateRunning),
-										SqlState: int32(replication.ReplicationStateRunning),
-										Position: "MySQL56/08d0dbbb-be29-11eb-9fea-0aafb9701138:1-109848265",
-									},
-									"zone1-003": {
-										IoState:  int32(replication.ReplicationStateRunning),
-										SqlState: int32(replication.ReplicationStateRunning),
-										Position: "MySQL56/08d0dbbb-be29-11eb-9fea-0aafb9701138:1-109848265",
-									},
-								},
-								TabletMap: map[string]*topodatapb.Tablet{
-									"zone1-001": {
+				},
+			},
+		},
+		{
+			name: "shard without serving tablet",
+			req: &vtadminpb.GetSchemaDriftRequest{
+				Keyspace: "commerce",
+			},
+			expected: &vtadminpb.GetSchemaDriftResponse{
+				Cluster:        c1,
+				Keyspace:       "commerce",
+				ReferenceShard: "-80",
+				Shards: []*vtadminpb.ShardSchemaDrift{
+					{
+						Shard: "80-",
+						Error: "no such tablet with state=SERVING for shard commerce/80-",
+					},
+				},
+			},
+		},
+		{
+			name: "reference shard without serving tablet",
+			req: &vtadminpb.GetSchemaDriftRequest{
+				Keyspace:       "customer",
+				ReferenceShard: "80-c0",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "nonexistent reference shard",
+			req: &vtadminpb.GetSchemaDriftRequest{
+				Keyspace:       "customer",
+				ReferenceShard: "c0-",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "no serving shards",
+			req: &vtadminpb.GetSchemaDriftRequest{
+				Keyspace: "unsharded",
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "missing keyspace",
+			req:       &vtadminpb.GetSchemaDriftRequest{},
+			shouldErr: true,
+		},
+		{
+			name:      "nil request",
+			req:       nil,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := testutil.BuildCluster(t, testutil.TestClusterConfig{
+				Cluster:      c1,
+				VtctldClient: vtctld,
+				Tablets:      tablets,
+			})
+			defer cluster.Close()
+
+			resp, err := cluster.GetSchemaDrift(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestGetSchemaMigrations(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	vtctld := &fakevtctldclient.VtctldClient{
+		GetKeyspacesResults: &struct {
+			Keyspaces []*vtctldatapb.Keyspace
+			Error     error
+		}{
+			Keyspaces: []*vtctldatapb.Keyspace{
+				{Name: "ks1"},
+				{Name: "ks2"},
+			},
+		},
+		GetSchemaMigrationsResults: map[string]struct {
+			Response *vtctldatapb.GetSchemaMigrationsResponse
+			Error    error
+		}{
+			"ks1": {
+				Response: &vtctldatapb.GetSchemaMigrationsResponse{
+					Migrations: []*vtctldatapb.SchemaMigration{
+						{Uuid: "uuid1", Keyspace: "ks1", Progress: 50},
+					},
+				},
+			},
+			"ks2": {
+				Response: &vtctldatapb.GetSchemaMigrationsResponse{
+					Migrations: []*vtctldatapb.SchemaMigration{
+						{Uuid: "uuid2", Keyspace: "ks2", ComponentThrottled: "vplayer"},
+						{Uuid: "uuid3", Keyspace: "ks2"},
+					},
+				},
+			},
+			"ks3": {
+				Error: assert.AnError,
+			},
+		},
+	}
+	c1 := &vtadminpb.Cluster{
+		Id:   "c1",
+		Name: "cluster1",
+	}
+
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.GetSchemaMigrationsRequest
+		expected  []*vtadminpb.SchemaMigration
+		shouldErr bool
+	}{
+		{
+			name: "single keyspace",
+			req: &vtctldatapb.GetSchemaMigrationsRequest{
+				Keyspace: "ks1",
+			},
+			expected: []*vtadminpb.SchemaMigration{
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid1", Keyspace: "ks1", Progress: 50},
+				},
+			},
+		},
+		{
+			name: "all keyspaces",
+			req:  &vtctldatapb.GetSchemaMigrationsRequest{},
+			expected: []*vtadminpb.SchemaMigration{
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid1", Keyspace: "ks1", Progress: 50},
+				},
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid2", Keyspace: "ks2", ComponentThrottled: "vplayer"},
+				},
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid3", Keyspace: "ks2"},
+				},
+			},
+		},
+		{
+			name: "nil request",
+			req:  nil,
+			expected: []*vtadminpb.SchemaMigration{
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid1", Keyspace: "ks1", Progress: 50},
+				},
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid2", Keyspace: "ks2", ComponentThrottled: "vplayer"},
+				},
+				{
+					Cluster:         c1,
+					SchemaMigration: &vtctldatapb.SchemaMigration{Uuid: "uuid3", Keyspace: "ks2"},
+				},
+			},
+		},
+		{
+			name: "vtctld error",
+			req: &vtctldatapb.GetSchemaMigrationsRequest{
+				Keyspace: "ks3",
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := testutil.BuildCluster(t, testutil.TestClusterConfig{
+				Cluster:      c1,
+				VtctldClient: vtctld,
+			})
+			defer cluster.Close()
+
+			migrations, err := cluster.GetSchemaMigrations(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			testutil.AssertSchemaMigrationSlicesEqual(t, tt.expected, migrations)
+		})
+	}
+}
+
+func TestGetShardReplicationPositions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		cfg       testutil.TestClusterConfig
+		req       *vtadminpb.GetShardReplicationPositionsRequest
+		expected  []*vtadminpb.ClusterShardReplicationPosition
+		shouldErr bool
+	}{
+		{
+			cfg: testutil.TestClusterConfig{
+				Cluster: &vtadminpb.Cluster{
+					Id:   "c1",
+					Name: "cluster1",
+				},
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					GetKeyspaceResults: map[string]struct {
+						Response *vtctldatapb.GetKeyspaceResponse
+						Error    error
+					}{
+						"ks": {
+							Response: &vtctldatapb.GetKeyspaceResponse{
+								Keyspace: &vtctldatapb.Keyspace{
+									Keyspace: &topodatapb.Keyspace{},
+								},
+							},
+						},
+					},
+					FindAllShardsInKeyspaceResults: map[string]struct {
+						Response *vtctldatapb.FindAllShardsInKeyspaceResponse
+						Error    error
+					}{
+						"ks": {
+							Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+								Shards: map[string]*vtctldatapb.Shard{
+									"-": {
+										Keyspace: "ks",
+										Name:     "-",
+										Shard:    &topodatapb.Shard{},
+									},
+								},
+							},
+						},
+					},
+					ShardReplicationPositionsResults: map[string]struct {
+						Response *vtctldatapb.ShardReplicationPositionsResponse
+						Error    error
+					}{
+						"ks/-": {
+							Response: &vtctldatapb.ShardReplicationPositionsResponse{
+								ReplicationStatuses: map[string]*replicationdatapb.Status{
+									"zone1-001": {
+										IoState:  int32(replication.ReplicationStateStopped),
+										SqlState: int32(replication.ReplicationStateStopped),
+										Position: "MySQL56/08d0dbbb-be29-11eb-9fea-0aafb9701138:1-109848265",
+									},
+									"zone1-002": { // Note: in reality other fields will be set on replicating hosts as well, but this is sufficient to illustrate in the testing.
+										IoState:  int32(replication.ReplicationStateRunning),
+										SqlState: int32(replication.ReplicationStateRunning),
+										Position: "MySQL56/08d0dbbb-be29-11eb-9fea-0aafb9701138:1-109848265",
+									},
+									"zone1-003": {
+										IoState:  int32(replication.ReplicationStateRunning),
+										SqlState: int32(replication.ReplicationStateRunning),
+										Position: "MySQL56/08d0dbbb-be29-11eb-9fea-0aafb9701138:1-109848265",
+									},
+								},
+								TabletMap: map[string]*topodatapb.Tablet{
+									"zone1-001": {
 										Keyspace: "ks",
 										Shard:    "-",
 										Type:     topodatapb.TabletType_PRIMARY,
@@ -3378,67 +4381,291 @@ func TestGetWorkflows(t *testing.T) {
 	}
 }
 
-func TestSetWritable(t *testing.T) {
+func TestMoveTablesCreate(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
-	tests := []struct {
-		name              string
-		cfg               testutil.TestClusterConfig
-		req               *vtctldatapb.SetWritableRequest
-		assertion         func(t assert.TestingT, err error, msgAndArgs ...any) bool
-		assertionMsgExtra []any
-	}{
-		{
-			name: "ok",
-			cfg: testutil.TestClusterConfig{
-				Cluster: &vtadminpb.Cluster{
-					Id:   "test",
-					Name: "test",
+	vtctld := &fakevtctldclient.VtctldClient{
+		GetKeyspaceResults: map[string]struct {
+			Response *vtctldatapb.GetKeyspaceResponse
+			Error    error
+		}{
+			"commerce": {
+				Response: &vtctldatapb.GetKeyspaceResponse{
+					Keyspace: &vtctldatapb.Keyspace{Name: "commerce", Keyspace: &topodatapb.Keyspace{}},
 				},
-				VtctldClient: &fakevtctldclient.VtctldClient{
-					SetWritableResults: map[string]error{
-						"zone1-0000000100": nil,
+			},
+			"customer": {
+				Response: &vtctldatapb.GetKeyspaceResponse{
+					Keyspace: &vtctldatapb.Keyspace{Name: "customer", Keyspace: &topodatapb.Keyspace{}},
+				},
+			},
+		},
+		FindAllShardsInKeyspaceResults: map[string]struct {
+			Response *vtctldatapb.FindAllShardsInKeyspaceResponse
+			Error    error
+		}{
+			"commerce": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: map[string]*vtctldatapb.Shard{
+						"0": {Keyspace: "commerce", Name: "0", Shard: &topodatapb.Shard{IsPrimaryServing: true}},
 					},
 				},
 			},
-			req: &vtctldatapb.SetWritableRequest{
-				TabletAlias: &topodatapb.TabletAlias{
-					Cell: "zone1",
-					Uid:  100,
+			"customer": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: map[string]*vtctldatapb.Shard{
+						"-80": {Keyspace: "customer", Name: "-80", Shard: &topodatapb.Shard{IsPrimaryServing: true}},
+						"80-": {Keyspace: "customer", Name: "80-", Shard: &topodatapb.Shard{IsPrimaryServing: true}},
+					},
 				},
-				Writable: true,
 			},
-			assertion: assert.NoError,
 		},
-		{
-			name: "error",
-			cfg: testutil.TestClusterConfig{
-				Cluster: &vtadminpb.Cluster{
-					Id:   "test",
-					Name: "test",
+		GetSchemaResults: map[string]struct {
+			Response *vtctldatapb.GetSchemaResponse
+			Error    error
+		}{
+			"zone1-0000000100": {
+				Response: &vtctldatapb.GetSchemaResponse{
+					Schema: &tabletmanagerdatapb.SchemaDefinition{
+						TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+							{Name: "customer"},
+							{Name: "corder"},
+							{Name: "product"},
+						},
+					},
 				},
-				VtctldClient: &fakevtctldclient.VtctldClient{
-					SetWritableResults: map[string]error{
-						"zone1-0000000100": fmt.Errorf("some error"),
+			},
+			"zone1-0000000200": {
+				Response: &vtctldatapb.GetSchemaResponse{
+					Schema: &tabletmanagerdatapb.SchemaDefinition{
+						TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+							{Name: "product"},
+						},
 					},
 				},
 			},
-			req: &vtctldatapb.SetWritableRequest{
-				TabletAlias: &topodatapb.TabletAlias{
-					Cell: "zone1",
-					Uid:  100,
+		},
+		GetVSchemaResults: map[string]struct {
+			Response *vtctldatapb.GetVSchemaResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.GetVSchemaResponse{
+					VSchema: &vschemapb.Keyspace{
+						Sharded: true,
+						Tables: map[string]*vschemapb.Table{
+							"customer": {
+								ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "hash", Column: "customer_id"}},
+							},
+							"corder": {},
+						},
+					},
 				},
-				Writable: true,
 			},
-			assertion: assert.Error,
 		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-
-		t.Run(tt.name, func(t *testing.T) {
+		GetWorkflowsResults: map[string]struct {
+			Response *vtctldatapb.GetWorkflowsResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.GetWorkflowsResponse{
+					Workflows: []*vtctldatapb.Workflow{{Name: "existing"}},
+				},
+			},
+		},
+		MoveTablesCreateResults: map[string]struct {
+			Response *vtctldatapb.WorkflowStatusResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.WorkflowStatusResponse{
+					TrafficState: "Reads Not Switched. Writes Not Switched",
+				},
+			},
+		},
+	}
+	tablets := []*vtadminpb.Tablet{
+		{
+			State: vtadminpb.Tablet_SERVING,
+			Tablet: &topodatapb.Tablet{
+				Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				Keyspace: "commerce",
+				Shard:    "0",
+			},
+		},
+		{
+			State: vtadminpb.Tablet_SERVING,
+			Tablet: &topodatapb.Tablet{
+				Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+				Keyspace: "customer",
+				Shard:    "-80",
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		req        *vtctldatapb.MoveTablesCreateRequest
+		expected   *vtctldatapb.WorkflowStatusResponse
+		validation *vtadminpb.ValidateWorkflowCreateResponse
+		shouldErr  bool
+	}{
+		{
+			name: "ok",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "commerce2customer",
+				SourceKeyspace: "commerce",
+				TargetKeyspace: "customer",
+				IncludeTables:  []string{"customer"},
+			},
+			expected: &vtctldatapb.WorkflowStatusResponse{
+				TrafficState: "Reads Not Switched. Writes Not Switched",
+			},
+		},
+		{
+			name: "failed validation",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "existing",
+				SourceKeyspace: "commerce",
+				TargetKeyspace: "customer",
+				AllTables:      true,
+				SourceShards:   []string{"1"},
+			},
+			validation: &vtadminpb.ValidateWorkflowCreateResponse{
+				Errors: []string{
+					"source shard commerce/1 does not exist",
+					"workflow existing already exists in keyspace customer",
+					"table corder has no primary vindex in the vschema of sharded target keyspace customer",
+					"table product already exists in target keyspace customer",
+					"table product has no primary vindex in the vschema of sharded target keyspace customer",
+				},
+				AvailableTables: []string{"corder", "customer"},
+				Tables:          []string{"corder", "customer", "product"},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "same keyspace",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:       "noop",
+				SourceKeyspace: "customer",
+				TargetKeyspace: "customer",
+				IncludeTables:  []string{"customer"},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "missing workflow name",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace: "commerce",
+				TargetKeyspace: "customer",
+				AllTables:      true,
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "nil request",
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+				Cluster: &vtadminpb.Cluster{
+					Id:   "c1",
+					Name: "cluster1",
+				},
+				VtctldClient: vtctld,
+				Tablets:      tablets,
+			})
+			defer c.Close()
+
+			resp, err := c.MoveTablesCreate(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+
+				if tt.validation != nil {
+					var badRequest *vtadminerrors.BadRequest
+					require.ErrorAs(t, err, &badRequest)
+					utils.MustMatch(t, tt.validation, badRequest.Details())
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestSetWritable(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name              string
+		cfg               testutil.TestClusterConfig
+		req               *vtctldatapb.SetWritableRequest
+		assertion         func(t assert.TestingT, err error, msgAndArgs ...any) bool
+		assertionMsgExtra []any
+	}{
+		{
+			name: "ok",
+			cfg: testutil.TestClusterConfig{
+				Cluster: &vtadminpb.Cluster{
+					Id:   "test",
+					Name: "test",
+				},
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					SetWritableResults: map[string]error{
+						"zone1-0000000100": nil,
+					},
+				},
+			},
+			req: &vtctldatapb.SetWritableRequest{
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				Writable: true,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "error",
+			cfg: testutil.TestClusterConfig{
+				Cluster: &vtadminpb.Cluster{
+					Id:   "test",
+					Name: "test",
+				},
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					SetWritableResults: map[string]error{
+						"zone1-0000000100": fmt.Errorf("some error"),
+					},
+				},
+			},
+			req: &vtctldatapb.SetWritableRequest{
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				Writable: true,
+			},
+			assertion: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
 			c := testutil.BuildCluster(t, tt.cfg)
@@ -3595,3 +4822,420 @@ func TestToggleTabletReplication(t *testing.T) {
 		})
 	}
 }
+
+func TestUndrainTablet(t *testing.T) {
+	t.Parallel()
+
+	testClusterProto := &vtadminpb.Cluster{
+		Id:   "test",
+		Name: "test",
+	}
+
+	drainedTablet := &vtadminpb.Tablet{
+		Cluster: testClusterProto,
+		Tablet: &topodatapb.Tablet{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Type: topodatapb.TabletType_DRAINED,
+			Tags: map[string]string{
+				"drained_from": "rdonly",
+				"drain_reason": "bad disk",
+				"other":        "tag",
+			},
+		},
+	}
+
+	vtctld := &fakevtctldclient.VtctldClient{
+		ChangeTabletTagsResults: map[string]struct {
+			Response *vtctldatapb.ChangeTabletTagsResponse
+			Error    error
+		}{
+			"zone1-0000000100": {
+				Response: &vtctldatapb.ChangeTabletTagsResponse{
+					AfterTags: map[string]string{
+						"other": "tag",
+					},
+				},
+			},
+		},
+		ChangeTabletTypeResults: map[string]struct {
+			Response *vtctldatapb.ChangeTabletTypeResponse
+			Error    error
+		}{
+			"zone1-0000000100": {
+				Response: &vtctldatapb.ChangeTabletTypeResponse{},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		cfg        testutil.TestClusterConfig
+		tablet     *vtadminpb.Tablet
+		tabletType topodatapb.TabletType
+		expected   *vtadminpb.UndrainTabletResponse
+		shouldErr  bool
+	}{
+		{
+			name: "drained_from tag",
+			cfg: testutil.TestClusterConfig{
+				Cluster:      testClusterProto,
+				VtctldClient: vtctld,
+			},
+			tablet: drainedTablet,
+			expected: &vtadminpb.UndrainTabletResponse{
+				Status:  "ok",
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_RDONLY,
+					Tags: map[string]string{
+						"other": "tag",
+					},
+				},
+			},
+		},
+		{
+			name: "explicit tablet type",
+			cfg: testutil.TestClusterConfig{
+				Cluster:      testClusterProto,
+				VtctldClient: vtctld,
+			},
+			tablet:     drainedTablet,
+			tabletType: topodatapb.TabletType_REPLICA,
+			expected: &vtadminpb.UndrainTabletResponse{
+				Status:  "ok",
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_REPLICA,
+					Tags: map[string]string{
+						"other": "tag",
+					},
+				},
+			},
+		},
+		{
+			name: "not drained",
+			cfg: testutil.TestClusterConfig{
+				Cluster:      testClusterProto,
+				VtctldClient: vtctld,
+			},
+			tablet: &vtadminpb.Tablet{
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_REPLICA,
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "no drained_from tag",
+			cfg: testutil.TestClusterConfig{
+				Cluster:      testClusterProto,
+				VtctldClient: vtctld,
+			},
+			tablet: &vtadminpb.Tablet{
+				Cluster: testClusterProto,
+				Tablet: &topodatapb.Tablet{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_DRAINED,
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "primary tablet type",
+			cfg: testutil.TestClusterConfig{
+				Cluster:      testClusterProto,
+				VtctldClient: vtctld,
+			},
+			tablet:     drainedTablet,
+			tabletType: topodatapb.TabletType_PRIMARY,
+			shouldErr:  true,
+		},
+		{
+			name: "ChangeTabletType error",
+			cfg: testutil.TestClusterConfig{
+				Cluster: testClusterProto,
+				VtctldClient: &fakevtctldclient.VtctldClient{
+					ChangeTabletTypeResults: map[string]struct {
+						Response *vtctldatapb.ChangeTabletTypeResponse
+						Error    error
+					}{
+						"zone1-0000000100": {
+							Error: assert.AnError,
+						},
+					},
+				},
+			},
+			tablet:    drainedTablet,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.BuildCluster(t, tt.cfg)
+			defer c.Close()
+
+			resp, err := c.UndrainTablet(ctx, tt.tablet, tt.tabletType)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestValidateReshardCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	shards := map[string]*vtctldatapb.Shard{
+		"-80": {
+			Keyspace: "customer",
+			Name:     "-80",
+			Shard: &topodatapb.Shard{
+				KeyRange:         &topodatapb.KeyRange{End: []byte{0x80}},
+				IsPrimaryServing: true,
+			},
+		},
+		"80-": {
+			Keyspace: "customer",
+			Name:     "80-",
+			Shard: &topodatapb.Shard{
+				KeyRange:         &topodatapb.KeyRange{Start: []byte{0x80}},
+				IsPrimaryServing: true,
+			},
+		},
+		"80-c0": {
+			Keyspace: "customer",
+			Name:     "80-c0",
+			Shard: &topodatapb.Shard{
+				KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}, End: []byte{0xc0}},
+			},
+		},
+		"c0-": {
+			Keyspace: "customer",
+			Name:     "c0-",
+			Shard: &topodatapb.Shard{
+				KeyRange: &topodatapb.KeyRange{Start: []byte{0xc0}},
+			},
+		},
+	}
+	vtctld := &fakevtctldclient.VtctldClient{
+		GetKeyspaceResults: map[string]struct {
+			Response *vtctldatapb.GetKeyspaceResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.GetKeyspaceResponse{
+					Keyspace: &vtctldatapb.Keyspace{Name: "customer", Keyspace: &topodatapb.Keyspace{}},
+				},
+			},
+		},
+		FindAllShardsInKeyspaceResults: map[string]struct {
+			Response *vtctldatapb.FindAllShardsInKeyspaceResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.FindAllShardsInKeyspaceResponse{
+					Shards: shards,
+				},
+			},
+		},
+		GetVSchemaResults: map[string]struct {
+			Response *vtctldatapb.GetVSchemaResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.GetVSchemaResponse{
+					VSchema: &vschemapb.Keyspace{
+						Sharded: true,
+						Tables: map[string]*vschemapb.Table{
+							"customer": {
+								ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "hash", Column: "customer_id"}},
+							},
+							"corder": {
+								ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "hash", Column: "customer_id"}},
+							},
+							"country": {
+								Type: "reference",
+							},
+						},
+					},
+				},
+			},
+		},
+		GetWorkflowsResults: map[string]struct {
+			Response *vtctldatapb.GetWorkflowsResponse
+			Error    error
+		}{
+			"customer": {
+				Response: &vtctldatapb.GetWorkflowsResponse{
+					Workflows: []*vtctldatapb.Workflow{{Name: "existing"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ReshardCreateRequest
+		expected  *vtadminpb.ValidateWorkflowCreateResponse
+		shouldErr bool
+	}{
+		{
+			name: "ok",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "split80",
+				Keyspace:     "customer",
+				SourceShards: []string{"80-"},
+				TargetShards: []string{"c0-", "80-c0"},
+			},
+			expected: &vtadminpb.ValidateWorkflowCreateResponse{
+				Tables: []string{"corder", "country", "customer"},
+			},
+		},
+		{
+			name: "key ranges do not match",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "split80",
+				Keyspace:     "customer",
+				SourceShards: []string{"80-"},
+				TargetShards: []string{"80-c0"},
+			},
+			expected: &vtadminpb.ValidateWorkflowCreateResponse{
+				Errors: []string{
+					"target shards [80-c0] cover key range 80-c0, not the key range 80- of source shards [80-]",
+				},
+				Tables: []string{"corder", "country", "customer"},
+			},
+		},
+		{
+			name: "non-contiguous shards",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "split80",
+				Keyspace:     "customer",
+				SourceShards: []string{"-80"},
+				TargetShards: []string{"c0-"},
+			},
+			expected: &vtadminpb.ValidateWorkflowCreateResponse{
+				Errors: []string{
+					"target shards [c0-] cover key range c0-, not the key range -80 of source shards [-80]",
+				},
+				Tables: []string{"corder", "country", "customer"},
+			},
+		},
+		{
+			name: "serving and missing shards",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "existing",
+				Keyspace:     "customer",
+				SourceShards: []string{"80-c0", "c0-"},
+				TargetShards: []string{"80-", "-"},
+			},
+			expected: &vtadminpb.ValidateWorkflowCreateResponse{
+				Errors: []string{
+					"source shard customer/80-c0 is not serving",
+					"source shard customer/c0- is not serving",
+					"target shard customer/80- is already serving",
+					"target shard customer/- does not exist; it and its tablets must be created first",
+					"workflow existing already exists in keyspace customer",
+				},
+				Tables: []string{"corder", "country", "customer"},
+			},
+		},
+		{
+			name: "overlapping shards",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "split80",
+				Keyspace:     "customer",
+				SourceShards: []string{"80-"},
+				TargetShards: []string{"80-"},
+			},
+			expected: &vtadminpb.ValidateWorkflowCreateResponse{
+				Errors: []string{
+					"shard customer/80- cannot be both a source and a target shard",
+					"target shard customer/80- is already serving",
+				},
+				Tables: []string{"corder", "country", "customer"},
+			},
+		},
+		{
+			name: "missing keyspace",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow:     "split80",
+				Keyspace:     "commerce",
+				SourceShards: []string{"0"},
+				TargetShards: []string{"-80", "80-"},
+			},
+			expected: &vtadminpb.ValidateWorkflowCreateResponse{
+				Errors: []string{
+					"cannot get keyspace commerce: assert.AnError general error for testing: no result set for keyspace commerce",
+				},
+			},
+		},
+		{
+			name: "missing keyspace name",
+			req: &vtctldatapb.ReshardCreateRequest{
+				Workflow: "split80",
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "nil request",
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+				Cluster: &vtadminpb.Cluster{
+					Id:   "c1",
+					Name: "cluster1",
+				},
+				VtctldClient: vtctld,
+			})
+			defer c.Close()
+
+			resp, err := c.ValidateReshardCreate(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
@@ -48,6 +48,15 @@ var (
 	// DefaultReadPoolWaitTimeout is the pool wait timeout used when creating
 	// read-only RPC pools if a config has no wait timeout set.
 	DefaultReadPoolWaitTimeout = time.Millisecond * 100
+	// DefaultQueryConsoleMaxRows is the maximum number of rows returned by a
+	// query console query if a config has no max rows set.
+	DefaultQueryConsoleMaxRows = 1000
+	// DefaultQueryConsoleTimeout is the maximum duration of a query console
+	// query if a config has no timeout set.
+	DefaultQueryConsoleTimeout = time.Second * 30
+	// DefaultMetricsRetention is how long the metrics scraped from the
+	// components of a cluster are kept for if a config has no retention set.
+	DefaultMetricsRetention = time.Hour
 )
 
 // Config represents the options to configure a vtadmin cluster.
@@ -74,9 +83,26 @@ type Config struct {
 	// PlannedFailoverShard operations. It has the semantics and defaults of an
 	// RW RPCPool.
 	FailoverPoolConfig *RPCPoolConfig
+	// SchemaMigrationsPoolConfig specifies the config for a pool shared by
+	// the schema migration (Online DDL) operations. It has the semantics and
+	// defaults of an RW RPCPool.
+	SchemaMigrationsPoolConfig *RPCPoolConfig
+	// WorkflowPoolConfig specifies the config for a pool shared by the
+	// operations that create, validate and drive VReplication workflows. It
+	// has the semantics and defaults of an RW RPCPool.
+	WorkflowPoolConfig *RPCPoolConfig
 
 	SchemaCacheConfig *cache.Config
 
+	// QueryConsoleConfig specifies the row limit and timeout of the read-only
+	// queries run through the query console.
+	QueryConsoleConfig *QueryConsoleConfig
+
+	// MetricsConfig specifies how often the metrics of the VTGates and tablets
+	// of the cluster are scraped, and how long they are kept for. Metrics are
+	// not scraped unless a scrape interval is set.
+	MetricsConfig *MetricsConfig
+
 	vtctldConfigOpts []vtctldclient.ConfigOption
 	vtsqlConfigOpts  []vtsql.ConfigOption
 }
@@ -193,6 +219,13 @@ func (cfg *Config) MarshalJSON() ([]byte, error) {
 		BackfillQueueSize:       10,
 		BackfillEnqueueWaitTime: cache.DefaultBackfillEnqueueWaitTime,
 	}
+	defaultQueryConsoleConfig := &QueryConsoleConfig{
+		MaxRows: DefaultQueryConsoleMaxRows,
+		Timeout: DefaultQueryConsoleTimeout,
+	}
+	defaultMetricsConfig := &MetricsConfig{
+		Retention: DefaultMetricsRetention,
+	}
 
 	tmp := struct {
 		ID                   string            `json:"id"`
@@ -211,8 +244,14 @@ func (cfg *Config) MarshalJSON() ([]byte, error) {
 
 		EmergencyFailoverPoolConfig *RPCPoolConfig `json:"emergency_failover_pool_config"`
 		FailoverPoolConfig          *RPCPoolConfig `json:"failover_pool_config"`
+		SchemaMigrationsPoolConfig  *RPCPoolConfig `json:"schema_migrations_pool_config"`
+		WorkflowPoolConfig          *RPCPoolConfig `json:"workflow_pool_config"`
 
 		SchemaCacheConfig *cache.Config `json:"schema_cache_config"`
+
+		QueryConsoleConfig *QueryConsoleConfig `json:"query_console_config"`
+
+		MetricsConfig *MetricsConfig `json:"metrics_config"`
 	}{
 		ID:                          cfg.ID,
 		Name:                        cfg.Name,
@@ -227,7 +266,11 @@ func (cfg *Config) MarshalJSON() ([]byte, error) {
 		WorkflowReadPoolConfig:      defaultReadPoolConfig.merge(cfg.WorkflowReadPoolConfig),
 		EmergencyFailoverPoolConfig: defaultRWPoolConfig.merge(cfg.EmergencyFailoverPoolConfig),
 		FailoverPoolConfig:          defaultRWPoolConfig.merge(cfg.FailoverPoolConfig),
+		SchemaMigrationsPoolConfig:  defaultRWPoolConfig.merge(cfg.SchemaMigrationsPoolConfig),
+		WorkflowPoolConfig:          defaultRWPoolConfig.merge(cfg.WorkflowPoolConfig),
 		SchemaCacheConfig:           mergeCacheConfigs(defaultCacheConfig, cfg.SchemaCacheConfig),
+		QueryConsoleConfig:          defaultQueryConsoleConfig.merge(cfg.QueryConsoleConfig),
+		MetricsConfig:               defaultMetricsConfig.merge(cfg.MetricsConfig),
 	}
 
 	return json.Marshal(&tmp)
@@ -251,7 +294,11 @@ func (cfg Config) Merge(override Config) Config {
 		WorkflowReadPoolConfig:      cfg.WorkflowReadPoolConfig.merge(override.WorkflowReadPoolConfig),
 		EmergencyFailoverPoolConfig: cfg.EmergencyFailoverPoolConfig.merge(override.EmergencyFailoverPoolConfig),
 		FailoverPoolConfig:          cfg.FailoverPoolConfig.merge(override.FailoverPoolConfig),
+		SchemaMigrationsPoolConfig:  cfg.SchemaMigrationsPoolConfig.merge(override.SchemaMigrationsPoolConfig),
+		WorkflowPoolConfig:          cfg.WorkflowPoolConfig.merge(override.WorkflowPoolConfig),
 		SchemaCacheConfig:           mergeCacheConfigs(cfg.SchemaCacheConfig, override.SchemaCacheConfig),
+		QueryConsoleConfig:          cfg.QueryConsoleConfig.merge(override.QueryConsoleConfig),
+		MetricsConfig:               cfg.MetricsConfig.merge(override.MetricsConfig),
 	}
 
 	if override.ID != "" {
@@ -461,6 +508,166 @@ func (cfg *RPCPoolConfig) parseFlag(name string, val string) (err error) {
 	return nil
 }
 
+// QueryConsoleConfig specifies the guardrails of the read-only queries run
+// through the query console of a cluster.
+type QueryConsoleConfig struct {
+	// MaxRows is the maximum number of rows a query returns. Queries that do
+	// not limit their results themselves have a LIMIT clause added.
+	MaxRows int `json:"max_rows"`
+	// Timeout is the maximum duration of a query.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// GetMaxRows returns the maximum number of rows a query returns. If the config
+// is nil, or has a non-positive max rows, DefaultQueryConsoleMaxRows is used.
+func (cfg *QueryConsoleConfig) GetMaxRows() int {
+	if cfg == nil || cfg.MaxRows <= 0 {
+		return DefaultQueryConsoleMaxRows
+	}
+
+	return cfg.MaxRows
+}
+
+// GetTimeout returns the maximum duration of a query. If the config is nil, or
+// has a non-positive timeout, DefaultQueryConsoleTimeout is used.
+func (cfg *QueryConsoleConfig) GetTimeout() time.Duration {
+	if cfg == nil || cfg.Timeout <= 0 {
+		return DefaultQueryConsoleTimeout
+	}
+
+	return cfg.Timeout
+}
+
+// merge merges two QueryConsoleConfigs, returning the merged version. Neither
+// of the original configs is modified as a result of merging, and both can be
+// nil.
+func (cfg *QueryConsoleConfig) merge(override *QueryConsoleConfig) *QueryConsoleConfig {
+	if cfg == nil && override == nil {
+		return nil
+	}
+
+	merged := &QueryConsoleConfig{
+		MaxRows: -1,
+		Timeout: -1,
+	}
+
+	for _, c := range []*QueryConsoleConfig{cfg, override} { // First apply the base config, then any overrides.
+		if c != nil {
+			if c.MaxRows > 0 {
+				merged.MaxRows = c.MaxRows
+			}
+
+			if c.Timeout > 0 {
+				merged.Timeout = c.Timeout
+			}
+		}
+	}
+
+	return merged
+}
+
+func (cfg *QueryConsoleConfig) parseFlag(name string, val string) (err error) {
+	switch name {
+	case "max-rows":
+		cfg.MaxRows, err = strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+
+		if cfg.MaxRows <= 0 {
+			return fmt.Errorf("%w: max rows must be positive; got %d", strconv.ErrRange, cfg.MaxRows)
+		}
+	case "timeout":
+		cfg.Timeout, err = time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.ErrNoFlag
+	}
+
+	return nil
+}
+
+// MetricsConfig specifies how the metrics of the VTGates and tablets of a
+// cluster are scraped.
+type MetricsConfig struct {
+	// ScrapeInterval is the interval between two scrapes of the /debug/vars of
+	// every VTGate and serving tablet. A non-positive interval disables
+	// scraping.
+	ScrapeInterval time.Duration `json:"scrape_interval"`
+	// Retention is how long scraped metrics are kept for.
+	Retention time.Duration `json:"retention"`
+}
+
+// GetScrapeInterval returns the interval between two scrapes. If the config is
+// nil, or has a non-positive scrape interval, zero is returned, meaning metrics
+// are not scraped.
+func (cfg *MetricsConfig) GetScrapeInterval() time.Duration {
+	if cfg == nil || cfg.ScrapeInterval <= 0 {
+		return 0
+	}
+
+	return cfg.ScrapeInterval
+}
+
+// GetRetention returns how long scraped metrics are kept for. If the config is
+// nil, or has a non-positive retention, DefaultMetricsRetention is used.
+func (cfg *MetricsConfig) GetRetention() time.Duration {
+	if cfg == nil || cfg.Retention <= 0 {
+		return DefaultMetricsRetention
+	}
+
+	return cfg.Retention
+}
+
+// merge merges two MetricsConfigs, returning the merged version. Neither of
+// the original configs is modified as a result of merging, and both can be
+// nil.
+func (cfg *MetricsConfig) merge(override *MetricsConfig) *MetricsConfig {
+	if cfg == nil && override == nil {
+		return nil
+	}
+
+	merged := &MetricsConfig{
+		ScrapeInterval: -1,
+		Retention:      -1,
+	}
+
+	for _, c := range []*MetricsConfig{cfg, override} { // First apply the base config, then any overrides.
+		if c != nil {
+			if c.ScrapeInterval > 0 {
+				merged.ScrapeInterval = c.ScrapeInterval
+			}
+
+			if c.Retention > 0 {
+				merged.Retention = c.Retention
+			}
+		}
+	}
+
+	return merged
+}
+
+func (cfg *MetricsConfig) parseFlag(name string, val string) (err error) {
+	switch name {
+	case "scrape-interval":
+		cfg.ScrapeInterval, err = time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+	case "retention":
+		cfg.Retention, err = time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.ErrNoFlag
+	}
+
+	return nil
+}
+
 // WithVtctldTestConfigOptions returns a new Config with the given vtctldclient
 // ConfigOptions appended to any existing ConfigOptions in the current Config.
 //
@@ -238,6 +238,50 @@ func parseOne(cfg *Config, name string, val string) error {
 			if err := cfg.FailoverPoolConfig.parseFlag(strings.TrimPrefix(name, "failover-pool-"), val); err != nil {
 				return fmt.Errorf("error parsing %s: %w", name, err)
 			}
+		case strings.HasPrefix(name, "schema-migrations-pool-"):
+			if cfg.SchemaMigrationsPoolConfig == nil {
+				cfg.SchemaMigrationsPoolConfig = &RPCPoolConfig{
+					Size:        -1,
+					WaitTimeout: -1,
+				}
+			}
+
+			if err := cfg.SchemaMigrationsPoolConfig.parseFlag(strings.TrimPrefix(name, "schema-migrations-pool-"), val); err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
+		case strings.HasPrefix(name, "workflow-pool-"):
+			if cfg.WorkflowPoolConfig == nil {
+				cfg.WorkflowPoolConfig = &RPCPoolConfig{
+					Size:        -1,
+					WaitTimeout: -1,
+				}
+			}
+
+			if err := cfg.WorkflowPoolConfig.parseFlag(strings.TrimPrefix(name, "workflow-pool-"), val); err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
+		case strings.HasPrefix(name, "query-console-"):
+			if cfg.QueryConsoleConfig == nil {
+				cfg.QueryConsoleConfig = &QueryConsoleConfig{
+					MaxRows: -1,
+					Timeout: -1,
+				}
+			}
+
+			if err := cfg.QueryConsoleConfig.parseFlag(strings.TrimPrefix(name, "query-console-"), val); err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
+		case strings.HasPrefix(name, "metrics-"):
+			if cfg.MetricsConfig == nil {
+				cfg.MetricsConfig = &MetricsConfig{
+					ScrapeInterval: -1,
+					Retention:      -1,
+				}
+			}
+
+			if err := cfg.MetricsConfig.parseFlag(strings.TrimPrefix(name, "metrics-"), val); err != nil {
+				return fmt.Errorf("error parsing %s: %w", name, err)
+			}
 		case strings.HasPrefix(name, "schema-cache-"):
 			if cfg.SchemaCacheConfig == nil {
 				cfg.SchemaCacheConfig = &cache.Config{
@@ -36,6 +36,9 @@ var (
 	// ErrInvalidRequest occurs when a request is invalid for any reason.
 	// For example, if mandatory parameters are undefined.
 	ErrInvalidRequest = errors.New("Invalid request")
+	// ErrNoSavedQuery occurs when a saved query cannot be found in a given
+	// cluster.
+	ErrNoSavedQuery = errors.New("no such saved query")
 	// ErrNoServingTablet occurs when a tablet with state SERVING cannot be
 	// found for a given set of filter criteria. It is a more specific form of
 	// ErrNoTablet
@@ -23,6 +23,7 @@ import (
 	"vitess.io/vitess/go/sets"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtadmin/audit"
 	"vitess.io/vitess/go/vt/vtadmin/cache"
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
 
@@ -47,6 +48,9 @@ type Options struct {
 	ExperimentalOptions struct {
 		TabletURLTmpl string
 	}
+	// AuditLog, if set, records the mutating calls made through the HTTP API.
+	// It is the same log the gRPC API records its calls in.
+	AuditLog *audit.Log
 }
 
 // API is used to power HTTP endpoint wrappers to the VTAdminServer interface.
@@ -68,6 +72,9 @@ type VTAdminHandler func(ctx context.Context, r Request, api *API) *JSONResponse
 // wrapping the request in a wrapper for some convenience functions and starts
 // a new context, after extracting any potential spans that were set by an
 // upstream middleware in the request context.
+//
+// If the API has an AuditLog, Adapt records the audit event of mutating calls.
+// Calls made over gRPC are audited by audit.UnaryServerInterceptor instead.
 func (api *API) Adapt(handler VTAdminHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.Background()
@@ -89,7 +96,17 @@ func (api *API) Adapt(handler VTAdminHandler) http.HandlerFunc {
 		// Transform any ?cluster query params to ?cluster_id.
 		deprecateQueryParam(r, "cluster_id", "cluster")
 
-		handler(ctx, Request{r}, api).Write(w)
+		if api.opts.AuditLog == nil {
+			handler(ctx, Request{r}, api).Write(w)
+			return
+		}
+
+		entry := audit.NewEntry()
+		params := auditParams(r)
+
+		resp := handler(audit.NewContext(ctx, entry), Request{r}, api)
+		recordAuditEvent(api.opts.AuditLog, r, entry, params, resp)
+		resp.Write(w)
 	}
 }
 
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"vitess.io/vitess/go/vt/vtadmin/audit"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// GetAuditEvents implements the http wrapper for /audit_events.
+//
+// Query params:
+// - cluster_id: repeated, optional
+// - actor: string, optional
+// - method: string, optional, e.g. "CreateKeyspace"
+// - since: RFC3339 timestamp, optional
+// - until: RFC3339 timestamp, optional
+// - limit: uint32, optional; zero returns all events
+func GetAuditEvents(ctx context.Context, r Request, api *API) *JSONResponse {
+	query := r.URL.Query()
+
+	since, err := r.ParseQueryParamAsTime("since")
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	until, err := r.ParseQueryParamAsTime("until")
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	limit, err := r.ParseQueryParamAsUint32("limit", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	resp, err := api.server.GetAuditEvents(ctx, &vtadminpb.GetAuditEventsRequest{
+		ClusterIds: query["cluster_id"],
+		Actor:      query.Get("actor"),
+		Method:     query.Get("method"),
+		Since:      since,
+		Until:      until,
+		Limit:      limit,
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// auditParams returns the parameters of an HTTP request as recorded in audit
+// events: its path variables, query parameters and body. The body is restored
+// for the handler to read.
+func auditParams(r *http.Request) string {
+	params := map[string]any{}
+
+	if vars := mux.Vars(r); len(vars) > 0 {
+		params["path"] = vars
+	}
+
+	if query := r.URL.Query(); len(query) > 0 {
+		params["query"] = query
+	}
+
+	if r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		switch {
+		case err != nil:
+			params["body_error"] = err.Error()
+		case len(body) == 0:
+		case json.Valid(body):
+			params["body"] = json.RawMessage(body)
+		default:
+			params["body"] = string(body)
+		}
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// recordAuditEvent records the audit event of an HTTP request in the log, if
+// the request made a mutating call.
+func recordAuditEvent(l *audit.Log, r *http.Request, entry *audit.Entry, params string, resp *JSONResponse) {
+	method := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil && route.GetName() != "" {
+		method = strings.TrimPrefix(route.GetName(), "API.")
+	}
+
+	var err error
+	if resp.Error != nil {
+		err = errors.New(resp.Error.Message)
+	}
+
+	if event := entry.Event(method, params, err); event != nil {
+		l.Record(event)
+	}
+}
@@ -32,6 +32,26 @@ func GetClusters(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(clusters, err)
 }
 
+// GetClusterGraph implements the http wrapper for /cluster/{cluster_id}/graph
+func GetClusterGraph(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+
+	graph, err := api.server.GetClusterGraph(ctx, &vtadminpb.GetClusterGraphRequest{
+		ClusterId: vars["cluster_id"],
+	})
+	return NewJSONResponse(graph, err)
+}
+
+// GetClusterMetrics implements the http wrapper for
+// /cluster_metrics[?cluster_id=[&cluster_id=]].
+func GetClusterMetrics(ctx context.Context, r Request, api *API) *JSONResponse {
+	metrics, err := api.server.GetClusterMetrics(ctx, &vtadminpb.GetClusterMetricsRequest{
+		ClusterIds: r.URL.Query()["cluster_id"],
+	})
+
+	return NewJSONResponse(metrics, err)
+}
+
 // GetTopologyPath implements the http wrapper for /cluster/{cluster_id}/topology
 //
 // Query params:
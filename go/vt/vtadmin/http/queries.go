@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gorilla/mux"
+
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtadmin/errors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// ExecuteQuery implements the http wrapper for
+// POST /query/{cluster_id}/{keyspace}.
+//
+// The request body is a JSON object with the following fields:
+// - sql: required, a single read-only statement.
+// - tablet_type: the type of tablets to run the query against, e.g. "rdonly".
+// Defaults to "replica".
+// - max_rows: optional, lowers the cluster's maximum number of rows returned.
+func ExecuteQuery(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var params struct {
+		SQL        string `json:"sql"`
+		TabletType string `json:"tablet_type"`
+		MaxRows    uint32 `json:"max_rows"`
+	}
+
+	if err := decoder.Decode(&params); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	var tabletType topodatapb.TabletType
+	if params.TabletType != "" {
+		var err error
+		tabletType, err = topoproto.ParseTabletType(params.TabletType)
+		if err != nil {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err: err,
+			})
+		}
+	}
+
+	resp, err := api.server.ExecuteQuery(ctx, &vtadminpb.ExecuteQueryRequest{
+		ClusterId:  vars["cluster_id"],
+		Keyspace:   vars["keyspace"],
+		TabletType: tabletType,
+		Sql:        params.SQL,
+		MaxRows:    params.MaxRows,
+	})
+
+	return NewJSONResponse(resp, err)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtadmin/errors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// CreateSavedQuery implements the http wrapper for
+// POST /saved_query/{cluster_id}.
+//
+// The request body is a JSON object with the following fields:
+// - name: required.
+// - description: optional.
+// - keyspace: required.
+// - tablet_type: the type of tablets to run the query against, e.g. "rdonly".
+// Defaults to "replica".
+// - sql: required, a single read-only statement.
+// - parameters: optional, a list of {name, description, default_value}
+// objects, one for each bind variable of the query.
+// - schedule: optional, an object with the following fields:
+//   - interval: required, a duration such as "1h".
+//   - webhook_url: optional, the URL to POST the report of each run to.
+//   - email_recipients: optional, the addresses to email the report of each
+//     run to.
+func CreateSavedQuery(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var params struct {
+		Name        string                            `json:"name"`
+		Description string                            `json:"description"`
+		Keyspace    string                            `json:"keyspace"`
+		TabletType  string                            `json:"tablet_type"`
+		SQL         string                            `json:"sql"`
+		Parameters  []*vtadminpb.SavedQuery_Parameter `json:"parameters"`
+		Schedule    *struct {
+			Interval        string   `json:"interval"`
+			WebhookURL      string   `json:"webhook_url"`
+			EmailRecipients []string `json:"email_recipients"`
+		} `json:"schedule"`
+	}
+
+	if err := decoder.Decode(&params); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	var tabletType topodatapb.TabletType
+	if params.TabletType != "" {
+		var err error
+		tabletType, err = topoproto.ParseTabletType(params.TabletType)
+		if err != nil {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err: err,
+			})
+		}
+	}
+
+	var schedule *vtadminpb.SavedQuery_Schedule
+	if params.Schedule != nil {
+		interval, err := time.ParseDuration(params.Schedule.Interval)
+		if err != nil {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err:        err,
+				ErrDetails: "schedule.interval must be a duration, e.g. 1h",
+			})
+		}
+
+		schedule = &vtadminpb.SavedQuery_Schedule{
+			Interval:        protoutil.DurationToProto(interval),
+			WebhookUrl:      params.Schedule.WebhookURL,
+			EmailRecipients: params.Schedule.EmailRecipients,
+		}
+	}
+
+	q, err := api.server.CreateSavedQuery(ctx, &vtadminpb.CreateSavedQueryRequest{
+		ClusterId: vars["cluster_id"],
+		SavedQuery: &vtadminpb.SavedQuery{
+			Name:        params.Name,
+			Description: params.Description,
+			Keyspace:    params.Keyspace,
+			TabletType:  tabletType,
+			Sql:         params.SQL,
+			Parameters:  params.Parameters,
+			Schedule:    schedule,
+		},
+	})
+
+	return NewJSONResponse(q, err)
+}
+
+// DeleteSavedQuery implements the http wrapper for
+// DELETE /saved_query/{cluster_id}/{id}.
+func DeleteSavedQuery(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+
+	resp, err := api.server.DeleteSavedQuery(ctx, &vtadminpb.DeleteSavedQueryRequest{
+		ClusterId: vars["cluster_id"],
+		Id:        vars["id"],
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// GetSavedQueries implements the http wrapper for
+// /saved_queries[?cluster_id=[&cluster_id=]].
+func GetSavedQueries(ctx context.Context, r Request, api *API) *JSONResponse {
+	resp, err := api.server.GetSavedQueries(ctx, &vtadminpb.GetSavedQueriesRequest{
+		ClusterIds: r.URL.Query()["cluster_id"],
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// RunSavedQuery implements the http wrapper for
+// POST /saved_query/{cluster_id}/{id}/run.
+//
+// The request body is optional, and is a JSON object with the following
+// fields:
+// - params: the values of the parameters of the query, by name. Parameters
+// without a value are bound to their default value.
+func RunSavedQuery(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var params struct {
+		Params map[string]string `json:"params"`
+	}
+
+	if err := decoder.Decode(&params); err != nil && err != io.EOF {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	resp, err := api.server.RunSavedQuery(ctx, &vtadminpb.RunSavedQueryRequest{
+		ClusterId: vars["cluster_id"],
+		Id:        vars["id"],
+		Params:    params.Params,
+	})
+
+	return NewJSONResponse(resp, err)
+}
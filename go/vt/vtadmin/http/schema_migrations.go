@@ -0,0 +1,264 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/vtadmin/errors"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// ApplySchema implements the http wrapper for
+// POST /migration/{cluster_id}/{keyspace}.
+//
+// The request body is a JSON object with the following fields:
+// - sql: required, one or more semicolon-separated DDL statements.
+// - ddl_strategy: the Online DDL strategy and flags, e.g. "vitess --postpone-completion".
+// - uuid_list: optional explicit UUIDs for the migrations.
+// - migration_context: optional context string for the migrations.
+func ApplySchema(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var params struct {
+		SQL              string   `json:"sql"`
+		DDLStrategy      string   `json:"ddl_strategy"`
+		UUIDList         []string `json:"uuid_list"`
+		MigrationContext string   `json:"migration_context"`
+	}
+
+	if err := decoder.Decode(&params); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	var sql []string
+	if params.SQL != "" {
+		sql = []string{params.SQL}
+	}
+
+	resp, err := api.server.ApplySchema(ctx, &vtadminpb.ApplySchemaRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.ApplySchemaRequest{
+			Keyspace:         vars["keyspace"],
+			Sql:              sql,
+			DdlStrategy:      params.DDLStrategy,
+			UuidList:         params.UUIDList,
+			MigrationContext: params.MigrationContext,
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// CancelSchemaMigration implements the http wrapper for
+// PUT /migration/{cluster_id}/{keyspace}/cancel?uuid=.
+//
+// The uuid may be "all" to cancel all pending migrations in the keyspace.
+func CancelSchemaMigration(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+
+	resp, err := api.server.CancelSchemaMigration(ctx, &vtadminpb.CancelSchemaMigrationRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.CancelSchemaMigrationRequest{
+			Keyspace: vars["keyspace"],
+			Uuid:     r.URL.Query().Get("uuid"),
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// CleanupSchemaMigration implements the http wrapper for
+// PUT /migration/{cluster_id}/{keyspace}/cleanup?uuid=.
+func CleanupSchemaMigration(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+
+	resp, err := api.server.CleanupSchemaMigration(ctx, &vtadminpb.CleanupSchemaMigrationRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.CleanupSchemaMigrationRequest{
+			Keyspace: vars["keyspace"],
+			Uuid:     r.URL.Query().Get("uuid"),
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// CompleteSchemaMigration implements the http wrapper for
+// PUT /migration/{cluster_id}/{keyspace}/complete?uuid=.
+//
+// The uuid may be "all" to complete all postponed migrations in the keyspace.
+func CompleteSchemaMigration(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+
+	resp, err := api.server.CompleteSchemaMigration(ctx, &vtadminpb.CompleteSchemaMigrationRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.CompleteSchemaMigrationRequest{
+			Keyspace: vars["keyspace"],
+			Uuid:     r.URL.Query().Get("uuid"),
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// GetSchemaMigrations implements the http wrapper for the
+// VTAdminServer.GetSchemaMigrations method.
+//
+// Its route is /migrations, with query params:
+// - cluster_id: repeated, cluster IDs. If unset, all clusters are queried.
+// - keyspace: restricts results to a single keyspace in each cluster.
+// - uuid: returns only the migration with that UUID; other filters are ignored.
+// - migration_context
+// - status: one of the SchemaMigration.Status names, e.g. "running".
+// - recent: a duration, e.g. "24h", to only return recently requested migrations.
+// - order: "ascending" or "descending", by request time.
+// - limit
+// - skip
+func GetSchemaMigrations(ctx context.Context, r Request, api *API) *JSONResponse {
+	query := r.URL.Query()
+
+	opts := &vtctldatapb.GetSchemaMigrationsRequest{
+		Keyspace:         query.Get("keyspace"),
+		Uuid:             query.Get("uuid"),
+		MigrationContext: query.Get("migration_context"),
+	}
+
+	if status := query.Get("status"); status != "" {
+		val, ok := vtctldatapb.SchemaMigration_Status_value[strings.ToUpper(status)]
+		if !ok {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err:        fmt.Errorf("unknown status %q", status),
+				ErrDetails: fmt.Sprintf("could not parse query parameter status (= %v) into a schema migration status", status),
+			})
+		}
+
+		opts.Status = vtctldatapb.SchemaMigration_Status(val)
+	}
+
+	if order := query.Get("order"); order != "" {
+		val, ok := vtctldatapb.QueryOrdering_value[strings.ToUpper(order)]
+		if !ok {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err:        fmt.Errorf("unknown order %q", order),
+				ErrDetails: fmt.Sprintf("could not parse query parameter order (= %v) into a query ordering", order),
+			})
+		}
+
+		opts.Order = vtctldatapb.QueryOrdering(val)
+	}
+
+	if recent := query.Get("recent"); recent != "" {
+		d, err := time.ParseDuration(recent)
+		if err != nil {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err:        err,
+				ErrDetails: fmt.Sprintf("could not parse query parameter recent (= %v) into a duration", recent),
+			})
+		}
+
+		opts.Recent = protoutil.DurationToProto(d)
+	}
+
+	limit, err := r.ParseQueryParamAsUint32("limit", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	skip, err := r.ParseQueryParamAsUint32("skip", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	opts.Limit = uint64(limit)
+	opts.Skip = uint64(skip)
+
+	clusterIDs := query["cluster_id"]
+	if len(clusterIDs) == 0 {
+		// Apply the filters to every cluster, rather than falling back to the
+		// unfiltered "all clusters" behavior of an empty request.
+		clusters, err := api.server.GetClusters(ctx, &vtadminpb.GetClustersRequest{})
+		if err != nil {
+			return NewJSONResponse(nil, err)
+		}
+
+		for _, c := range clusters.Clusters {
+			clusterIDs = append(clusterIDs, c.Id)
+		}
+	}
+
+	clusterRequests := make([]*vtadminpb.GetSchemaMigrationsRequest_ClusterRequest, 0, len(clusterIDs))
+	for _, id := range clusterIDs {
+		clusterRequests = append(clusterRequests, &vtadminpb.GetSchemaMigrationsRequest_ClusterRequest{
+			ClusterId: id,
+			Options:   opts.CloneVT(),
+		})
+	}
+
+	migrations, err := api.server.GetSchemaMigrations(ctx, &vtadminpb.GetSchemaMigrationsRequest{
+		ClusterRequests: clusterRequests,
+	})
+
+	return NewJSONResponse(migrations, err)
+}
+
+// LaunchSchemaMigration implements the http wrapper for
+// PUT /migration/{cluster_id}/{keyspace}/launch?uuid=.
+//
+// The uuid may be "all" to launch all postponed migrations in the keyspace.
+func LaunchSchemaMigration(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+
+	resp, err := api.server.LaunchSchemaMigration(ctx, &vtadminpb.LaunchSchemaMigrationRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.LaunchSchemaMigrationRequest{
+			Keyspace: vars["keyspace"],
+			Uuid:     r.URL.Query().Get("uuid"),
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// RetrySchemaMigration implements the http wrapper for
+// PUT /migration/{cluster_id}/{keyspace}/retry?uuid=.
+func RetrySchemaMigration(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := mux.Vars(r.Request)
+
+	resp, err := api.server.RetrySchemaMigration(ctx, &vtadminpb.RetrySchemaMigrationRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.RetrySchemaMigrationRequest{
+			Keyspace: vars["keyspace"],
+			Uuid:     r.URL.Query().Get("uuid"),
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
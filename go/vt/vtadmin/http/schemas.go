@@ -26,6 +26,33 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/errors"
 )
 
+// DiffSchemas implements the http wrapper for the /schema_diff route, with
+// query params:
+// - source_cluster_id, source_keyspace: required
+// - source_shard: optional; if unset, any serving shard of the keyspace is used
+// - target_cluster_id, target_keyspace: required
+// - target_shard: optional; if unset, any serving shard of the keyspace is used
+// - table: repeated, restricts the comparison to the given tables
+func DiffSchemas(ctx context.Context, r Request, api *API) *JSONResponse {
+	query := r.URL.Query()
+
+	diff, err := api.server.DiffSchemas(ctx, &vtadminpb.DiffSchemasRequest{
+		Source: &vtadminpb.SchemaDiffTarget{
+			ClusterId: query.Get("source_cluster_id"),
+			Keyspace:  query.Get("source_keyspace"),
+			Shard:     query.Get("source_shard"),
+		},
+		Target: &vtadminpb.SchemaDiffTarget{
+			ClusterId: query.Get("target_cluster_id"),
+			Keyspace:  query.Get("target_keyspace"),
+			Shard:     query.Get("target_shard"),
+		},
+		Tables: query["table"],
+	})
+
+	return NewJSONResponse(diff, err)
+}
+
 // FindSchema implements the http wrapper for the
 // /schema/{table}[?cluster_id=[&cluster_id=]] route.
 func FindSchema(ctx context.Context, r Request, api *API) *JSONResponse {
@@ -66,6 +93,25 @@ func GetSchema(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(schema, err)
 }
 
+// GetSchemaDrift implements the http wrapper for the
+// /schema_drift/{cluster_id}/{keyspace} route, with query params:
+// - reference_shard: the shard to compare the other shards to; defaults to
+// the first serving shard of the keyspace
+// - table: repeated, restricts the comparison to the given tables
+func GetSchemaDrift(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	query := r.URL.Query()
+
+	drift, err := api.server.GetSchemaDrift(ctx, &vtadminpb.GetSchemaDriftRequest{
+		ClusterId:      vars["cluster_id"],
+		Keyspace:       vars["keyspace"],
+		ReferenceShard: query.Get("reference_shard"),
+		Tables:         query["table"],
+	})
+
+	return NewJSONResponse(drift, err)
+}
+
 // GetSchemas implements the http wrapper for the /schemas[?cluster_id=[&cluster_id=]
 // route.
 func GetSchemas(ctx context.Context, r Request, api *API) *JSONResponse {
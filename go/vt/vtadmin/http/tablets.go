@@ -19,6 +19,10 @@ package http
 import (
 	"context"
 
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtadmin/errors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
 )
 
@@ -86,6 +90,28 @@ func DeleteTablet(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(deleted, err)
 }
 
+// DrainTablet implements the http wrapper for PUT /tablet/{tablet}/drain.
+//
+// Query params:
+// - cluster_id: the cluster(s) to look for the tablet in
+// - reason: why the tablet is being drained, recorded in its drain_reason tag
+func DrainTablet(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+
+	alias, err := vars.GetTabletAlias("tablet")
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	result, err := api.server.DrainTablet(ctx, &vtadminpb.DrainTabletRequest{
+		Alias:      alias,
+		ClusterIds: r.URL.Query()["cluster_id"],
+		Reason:     r.URL.Query().Get("reason"),
+	})
+
+	return NewJSONResponse(result, err)
+}
+
 // PingTablet checks that the specified tablet is awake and responding to RPCs. This command can be blocked by other in-flight operations.
 func PingTablet(ctx context.Context, r Request, api *API) *JSONResponse {
 	vars := r.Vars()
@@ -229,6 +255,39 @@ func StopReplication(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(result, err)
 }
 
+// UndrainTablet implements the http wrapper for PUT /tablet/{tablet}/undrain.
+//
+// Query params:
+// - cluster_id: the cluster(s) to look for the tablet in
+// - tablet_type: the type to return the tablet to. Defaults to the type the
+// tablet was drained from.
+func UndrainTablet(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+
+	alias, err := vars.GetTabletAlias("tablet")
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	var tabletType topodatapb.TabletType
+	if param := r.URL.Query().Get("tablet_type"); param != "" {
+		tabletType, err = topoproto.ParseTabletType(param)
+		if err != nil {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err: err,
+			})
+		}
+	}
+
+	result, err := api.server.UndrainTablet(ctx, &vtadminpb.UndrainTabletRequest{
+		Alias:      alias,
+		ClusterIds: r.URL.Query()["cluster_id"],
+		TabletType: tabletType,
+	})
+
+	return NewJSONResponse(result, err)
+}
+
 // TabletExternallyPromoted implements the http wrapper for
 // POST /tablet/{tablet}/tablet_externally_promoted.
 //
@@ -18,8 +18,13 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+
+	"vitess.io/vitess/go/vt/vtadmin/errors"
 
 	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
 // GetWorkflow implements the http wrapper for the VTAdminServer.GetWorkflow
@@ -69,3 +74,200 @@ func GetWorkflows(ctx context.Context, r Request, api *API) *JSONResponse {
 
 	return NewJSONResponse(workflows, err)
 }
+
+// MoveTablesComplete implements the http wrapper for
+// PUT /workflow/{cluster_id}/{keyspace}/{name}/complete.
+//
+// The request body is an optional JSON object with the keep_data,
+// keep_routing_rules, rename_tables and dry_run fields of a
+// vtctldata.MoveTablesCompleteRequest. It also completes Reshard workflows.
+func MoveTablesComplete(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req vtctldatapb.MoveTablesCompleteRequest
+	if err := decoder.Decode(&req); err != nil && err != io.EOF {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	req.TargetKeyspace = vars["keyspace"]
+	req.Workflow = vars["name"]
+
+	resp, err := api.server.MoveTablesComplete(ctx, &vtadminpb.MoveTablesCompleteRequest{
+		ClusterId: vars["cluster_id"],
+		Options:   &req,
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// MoveTablesCreate implements the http wrapper for POST /movetables/{cluster_id}.
+//
+// The request body is a JSON-encoded vtctldata.MoveTablesCreateRequest.
+func MoveTablesCreate(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req vtctldatapb.MoveTablesCreateRequest
+	if err := decoder.Decode(&req); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	resp, err := api.server.MoveTablesCreate(ctx, &vtadminpb.MoveTablesCreateRequest{
+		ClusterId: vars["cluster_id"],
+		Options:   &req,
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// ReshardCreate implements the http wrapper for POST /reshard/{cluster_id}.
+//
+// The request body is a JSON-encoded vtctldata.ReshardCreateRequest.
+func ReshardCreate(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req vtctldatapb.ReshardCreateRequest
+	if err := decoder.Decode(&req); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	resp, err := api.server.ReshardCreate(ctx, &vtadminpb.ReshardCreateRequest{
+		ClusterId: vars["cluster_id"],
+		Options:   &req,
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// ReshardRecommend implements the http wrapper for
+// GET /reshard/{cluster_id}/{keyspace}/recommend, with query params:
+// - source_shard: repeated, the shards to reshard
+// - shard_count
+// - max_shard_size_bytes
+// - max_shard_qps
+// - sample_size
+func ReshardRecommend(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+
+	shardCount, err := r.ParseQueryParamAsUint32("shard_count", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	maxShardSizeBytes, err := r.ParseQueryParamAsUint32("max_shard_size_bytes", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	maxShardQPS, err := r.ParseQueryParamAsUint32("max_shard_qps", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	sampleSize, err := r.ParseQueryParamAsUint32("sample_size", 0)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	resp, err := api.server.ReshardRecommend(ctx, &vtadminpb.ReshardRecommendRequest{
+		ClusterId: vars["cluster_id"],
+		Options: &vtctldatapb.ReshardRecommendRequest{
+			Keyspace:          vars["keyspace"],
+			SourceShards:      r.URL.Query()["source_shard"],
+			ShardCount:        shardCount,
+			MaxShardSizeBytes: uint64(maxShardSizeBytes),
+			MaxShardQps:       uint64(maxShardQPS),
+			SampleSize:        sampleSize,
+		},
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// ValidateMoveTablesCreate implements the http wrapper for
+// POST /movetables/{cluster_id}/validate.
+//
+// The request body is a JSON-encoded vtctldata.MoveTablesCreateRequest.
+func ValidateMoveTablesCreate(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req vtctldatapb.MoveTablesCreateRequest
+	if err := decoder.Decode(&req); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	resp, err := api.server.ValidateMoveTablesCreate(ctx, &vtadminpb.MoveTablesCreateRequest{
+		ClusterId: vars["cluster_id"],
+		Options:   &req,
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// ValidateReshardCreate implements the http wrapper for
+// POST /reshard/{cluster_id}/validate.
+//
+// The request body is a JSON-encoded vtctldata.ReshardCreateRequest.
+func ValidateReshardCreate(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req vtctldatapb.ReshardCreateRequest
+	if err := decoder.Decode(&req); err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	resp, err := api.server.ValidateReshardCreate(ctx, &vtadminpb.ReshardCreateRequest{
+		ClusterId: vars["cluster_id"],
+		Options:   &req,
+	})
+
+	return NewJSONResponse(resp, err)
+}
+
+// WorkflowSwitchTraffic implements the http wrapper for
+// PUT /workflow/{cluster_id}/{keyspace}/{name}/switch_traffic.
+//
+// The request body is an optional JSON-encoded
+// vtctldata.WorkflowSwitchTrafficRequest, whose keyspace and workflow are
+// taken from the route.
+func WorkflowSwitchTraffic(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req vtctldatapb.WorkflowSwitchTrafficRequest
+	if err := decoder.Decode(&req); err != nil && err != io.EOF {
+		return NewJSONResponse(nil, &errors.BadRequest{
+			Err: err,
+		})
+	}
+
+	req.Keyspace = vars["keyspace"]
+	req.Workflow = vars["name"]
+
+	resp, err := api.server.WorkflowSwitchTraffic(ctx, &vtadminpb.WorkflowSwitchTrafficRequest{
+		ClusterId: vars["cluster_id"],
+		Options:   &req,
+	})
+
+	return NewJSONResponse(resp, err)
+}
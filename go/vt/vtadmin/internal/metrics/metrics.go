@@ -0,0 +1,337 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics periodically scrapes the /debug/vars of the VTGates and
+// tablets of a cluster, and keeps a time series of the few metrics VTAdmin
+// summarizes: the query and error rates, the replication lag and the
+// utilization of the connection pools.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// maxConcurrentScrapes is the number of components a Recorder scrapes at once.
+const maxConcurrentScrapes = 16
+
+// Target is a VTGate or a tablet whose metrics are scraped.
+type Target struct {
+	Type vtadminpb.ComponentMetrics_Type
+	// Name is the hostname of a VTGate, or the alias of a tablet.
+	Name string
+	// Addr is the address of the HTTP server of the component, with or
+	// without a scheme, e.g. "vttablet-100:15100".
+	Addr string
+}
+
+func (t Target) key() string { return t.Type.String() + "/" + t.Name }
+
+// TargetsFunc returns the components to scrape.
+type TargetsFunc func(ctx context.Context) ([]Target, error)
+
+// Config is the configuration of a Recorder.
+type Config struct {
+	// ScrapeInterval is the interval between two scrapes.
+	ScrapeInterval time.Duration
+	// Retention is how long samples are kept for.
+	Retention time.Duration
+}
+
+// component holds the last metrics scraped from a component.
+type component struct {
+	// time and vars are those of the last successful scrape, from which
+	// rates are computed.
+	time    time.Time
+	vars    *Vars
+	metrics *vtadminpb.ComponentMetrics
+}
+
+// Recorder scrapes the metrics of a set of components at a regular interval,
+// and keeps a bounded time series of their cluster-wide samples.
+type Recorder struct {
+	targets    TargetsFunc
+	client     *http.Client
+	interval   time.Duration
+	maxSamples int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	m          sync.RWMutex
+	samples    []*vtadminpb.MetricsSample
+	components map[string]*component
+}
+
+// New returns a Recorder which scrapes the components returned by targets
+// every cfg.ScrapeInterval once it is started, until it is closed.
+func New(targets TargetsFunc, cfg Config) *Recorder {
+	maxSamples := 1
+	if cfg.ScrapeInterval > 0 && cfg.Retention > cfg.ScrapeInterval {
+		maxSamples = int(cfg.Retention / cfg.ScrapeInterval)
+	}
+
+	r := &Recorder{
+		targets:    targets,
+		client:     &http.Client{},
+		interval:   cfg.ScrapeInterval,
+		maxSamples: maxSamples,
+		components: map[string]*component{},
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+
+	return r
+}
+
+// Start starts scraping in the background. The first scrape happens
+// immediately.
+func (r *Recorder) Start() {
+	r.wg.Add(1)
+	go r.run()
+}
+
+// Close stops the Recorder. It is safe to read its samples afterwards.
+func (r *Recorder) Close() error {
+	r.cancel()
+	r.wg.Wait()
+
+	return nil
+}
+
+// ScrapeInterval returns the interval between two samples.
+func (r *Recorder) ScrapeInterval() time.Duration {
+	return r.interval
+}
+
+// Samples returns the cluster-wide samples, oldest first.
+func (r *Recorder) Samples() []*vtadminpb.MetricsSample {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	// Samples are never modified once recorded, so they can be shared.
+	samples := make([]*vtadminpb.MetricsSample, len(r.samples))
+	copy(samples, r.samples)
+
+	return samples
+}
+
+// Components returns the last metrics of each component, VTGates first, each
+// sorted by name.
+func (r *Recorder) Components() []*vtadminpb.ComponentMetrics {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	components := make([]*vtadminpb.ComponentMetrics, 0, len(r.components))
+	for _, c := range r.components {
+		components = append(components, c.metrics)
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Type != components[j].Type {
+			return components[i].Type < components[j].Type
+		}
+
+		return components[i].Name < components[j].Name
+	})
+
+	return components
+}
+
+func (r *Recorder) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.scrape(r.ctx, time.Now())
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scrape scrapes all the targets, and records a sample taken at now.
+func (r *Recorder) scrape(ctx context.Context, now time.Time) {
+	if r.interval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.interval)
+		defer cancel()
+	}
+
+	targets, err := r.targets(ctx)
+	if err != nil {
+		log.Warningf("failed to find the components to scrape metrics from: %s", err)
+		return
+	}
+
+	var (
+		vars = make([]*Vars, len(targets))
+		errs = make([]error, len(targets))
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, maxConcurrentScrapes)
+	)
+
+	for i, target := range targets {
+		wg.Add(1)
+
+		go func(i int, target Target) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			vars[i], errs[i] = r.fetch(ctx, target)
+		}(i, target)
+	}
+
+	wg.Wait()
+
+	if r.ctx.Err() != nil {
+		// The recorder was closed mid-scrape.
+		return
+	}
+
+	r.record(now, targets, vars, errs)
+}
+
+func (r *Recorder) fetch(ctx context.Context, target Target) (*Vars, error) {
+	if target.Addr == "" {
+		return nil, fmt.Errorf("no address to scrape %s from", target.Name)
+	}
+
+	url := target.Addr
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/debug/vars", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", req.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseVars(target.Type, data)
+}
+
+// record computes the metrics of each target, from the vars scraped from it
+// and the ones of its previous scrape, and appends their sample.
+func (r *Recorder) record(now time.Time, targets []Target, vars []*Vars, errs []error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	sample := &vtadminpb.MetricsSample{
+		Time: protoutil.TimeToProto(now),
+	}
+
+	// Components that are no longer found are dropped.
+	components := make(map[string]*component, len(targets))
+
+	for i, target := range targets {
+		prev := r.components[target.key()]
+		c := &component{
+			metrics: &vtadminpb.ComponentMetrics{
+				Type: target.Type,
+				Name: target.Name,
+				Time: sample.Time,
+			},
+		}
+		components[target.key()] = c
+
+		if errs[i] != nil {
+			c.metrics.Error = errs[i].Error()
+			sample.ScrapeErrors++
+
+			// Keep the last successful scrape, so rates can be computed
+			// again after a transient error.
+			if prev != nil {
+				c.time, c.vars = prev.time, prev.vars
+			}
+
+			continue
+		}
+
+		c.time, c.vars = now, vars[i]
+
+		if prev != nil && prev.vars != nil && now.After(prev.time) {
+			seconds := now.Sub(prev.time).Seconds()
+			c.metrics.Qps = rate(prev.vars.Queries, c.vars.Queries, seconds)
+			c.metrics.ErrorRate = rate(prev.vars.Errors, c.vars.Errors, seconds)
+		}
+
+		switch target.Type {
+		case vtadminpb.ComponentMetrics_VTGATE:
+			sample.VtgateQps += c.metrics.Qps
+			sample.VtgateErrorRate += c.metrics.ErrorRate
+		case vtadminpb.ComponentMetrics_TABLET:
+			c.metrics.ReplicationLagSeconds = c.vars.ReplicationLag.Seconds()
+			c.metrics.PoolUtilization = c.vars.PoolUtilization
+
+			sample.TabletQps += c.metrics.Qps
+			sample.TabletErrorRate += c.metrics.ErrorRate
+			sample.MaxReplicationLagSeconds = max(sample.MaxReplicationLagSeconds, c.metrics.ReplicationLagSeconds)
+			sample.MaxPoolUtilization = max(sample.MaxPoolUtilization, c.metrics.PoolUtilization)
+		}
+	}
+
+	r.components = components
+
+	if len(r.samples) >= r.maxSamples {
+		n := copy(r.samples, r.samples[len(r.samples)-r.maxSamples+1:])
+		r.samples = r.samples[:n]
+	}
+
+	r.samples = append(r.samples, sample)
+}
+
+// rate returns the per-second rate of a counter. A counter that went down was
+// reset by a restart, and has no rate until its next scrape.
+func rate(prev, cur int64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+
+	return float64(cur-prev) / seconds
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func TestParseVars(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		typ       vtadminpb.ComponentMetrics_Type
+		data      string
+		expected  *Vars
+		shouldErr bool
+	}{
+		{
+			name: "vtgate",
+			typ:  vtadminpb.ComponentMetrics_VTGATE,
+			data: `{
+				"VtgateApi": {"TotalCount": 120, "TotalTime": 5000},
+				"VtgateApiErrorCounts": {"Execute.ks.primary.INVALID_ARGUMENT": 3, "Execute.ks.replica.UNAVAILABLE": 2}
+			}`,
+			expected: &Vars{
+				Queries: 120,
+				Errors:  5,
+			},
+		},
+		{
+			name: "tablet with heartbeats",
+			typ:  vtadminpb.ComponentMetrics_TABLET,
+			data: `{
+				"Queries": {"TotalCount": 80},
+				"Errors": {"DEADLINE_EXCEEDED": 1},
+				"HeartbeatCurrentLagNs": 1500000000,
+				"ConnPoolCapacity": 20,
+				"ConnPoolInUse": 5,
+				"TransactionPoolCapacity": 10,
+				"TransactionPoolInUse": 9
+			}`,
+			expected: &Vars{
+				Queries:         80,
+				Errors:          1,
+				ReplicationLag:  1500 * time.Millisecond,
+				PoolUtilization: 0.9,
+			},
+		},
+		{
+			name: "tablet with polled replication lag",
+			typ:  vtadminpb.ComponentMetrics_TABLET,
+			data: `{"replicationLagSec": 3}`,
+			expected: &Vars{
+				ReplicationLag: 3 * time.Second,
+			},
+		},
+		{
+			name:      "invalid json",
+			typ:       vtadminpb.ComponentMetrics_VTGATE,
+			data:      `{`,
+			shouldErr: true,
+		},
+		{
+			name:      "unknown type",
+			typ:       vtadminpb.ComponentMetrics_UNKNOWN,
+			data:      `{}`,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars, err := ParseVars(tt.typ, []byte(tt.data))
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, vars)
+		})
+	}
+}
+
+// fakeComponent serves /debug/vars with a configurable body.
+type fakeComponent struct {
+	m    sync.Mutex
+	vars string
+	fail bool
+}
+
+func (fc *fakeComponent) set(vars string, fail bool) {
+	fc.m.Lock()
+	defer fc.m.Unlock()
+
+	fc.vars, fc.fail = vars, fail
+}
+
+func (fc *fakeComponent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fc.m.Lock()
+	defer fc.m.Unlock()
+
+	if r.URL.Path != "/debug/vars" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if fc.fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	fmt.Fprint(w, fc.vars)
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	gate := &fakeComponent{}
+	gateServer := httptest.NewServer(gate)
+	defer gateServer.Close()
+
+	tablet := &fakeComponent{}
+	tabletServer := httptest.NewServer(tablet)
+	defer tabletServer.Close()
+
+	targets := []Target{
+		{Type: vtadminpb.ComponentMetrics_TABLET, Name: "zone1-0000000100", Addr: tabletServer.Listener.Addr().String()},
+		{Type: vtadminpb.ComponentMetrics_VTGATE, Name: "vtgate1", Addr: gateServer.URL},
+	}
+
+	r := New(func(ctx context.Context) ([]Target, error) {
+		return targets, nil
+	}, Config{
+		ScrapeInterval: time.Second * 10,
+		Retention:      time.Second * 20,
+	})
+	defer r.Close()
+
+	ctx := context.Background()
+	start := time.Now()
+
+	// The first scrape has no previous counters to compute rates from.
+	gate.set(`{"VtgateApi": {"TotalCount": 100}, "VtgateApiErrorCounts": {"a": 10}}`, false)
+	tablet.set(`{"Queries": {"TotalCount": 50}, "HeartbeatCurrentLagNs": 2000000000, "ConnPoolCapacity": 4, "ConnPoolInUse": 1}`, false)
+	r.scrape(ctx, start)
+
+	samples := r.Samples()
+	require.Len(t, samples, 1)
+	assert.Zero(t, samples[0].VtgateQps)
+	assert.Zero(t, samples[0].TabletQps)
+	assert.Equal(t, 2.0, samples[0].MaxReplicationLagSeconds)
+	assert.Equal(t, 0.25, samples[0].MaxPoolUtilization)
+
+	gate.set(`{"VtgateApi": {"TotalCount": 300}, "VtgateApiErrorCounts": {"a": 30}}`, false)
+	tablet.set(`{"Queries": {"TotalCount": 150}, "Errors": {"b": 10}}`, false)
+	r.scrape(ctx, start.Add(time.Second*10))
+
+	samples = r.Samples()
+	require.Len(t, samples, 2)
+	assert.Equal(t, 20.0, samples[1].VtgateQps)
+	assert.Equal(t, 2.0, samples[1].VtgateErrorRate)
+	assert.Equal(t, 10.0, samples[1].TabletQps)
+	assert.Equal(t, 1.0, samples[1].TabletErrorRate)
+	assert.Zero(t, samples[1].ScrapeErrors)
+
+	components := r.Components()
+	require.Len(t, components, 2)
+	assert.Equal(t, vtadminpb.ComponentMetrics_VTGATE, components[0].Type, "vtgates should be listed first")
+	assert.Equal(t, "vtgate1", components[0].Name)
+	assert.Equal(t, 20.0, components[0].Qps)
+	assert.Equal(t, "zone1-0000000100", components[1].Name)
+	assert.Equal(t, 10.0, components[1].Qps)
+
+	// A failed scrape is reported, and rates are computed from the last
+	// successful scrape once the component recovers. Samples older than the
+	// retention are dropped.
+	tablet.set("", true)
+	r.scrape(ctx, start.Add(time.Second*20))
+
+	samples = r.Samples()
+	require.Len(t, samples, 2)
+	assert.Equal(t, int32(1), samples[1].ScrapeErrors)
+	assert.Zero(t, samples[1].TabletQps)
+
+	components = r.Components()
+	require.Len(t, components, 2)
+	assert.NotEmpty(t, components[1].Error)
+
+	tablet.set(`{"Queries": {"TotalCount": 550}}`, false)
+	r.scrape(ctx, start.Add(time.Second*30))
+
+	samples = r.Samples()
+	require.Len(t, samples, 2)
+	assert.Equal(t, 20.0, samples[1].TabletQps)
+	assert.Zero(t, samples[1].ScrapeErrors)
+
+	// A counter that went down was reset, and has no rate.
+	gate.set(`{"VtgateApi": {"TotalCount": 10}}`, false)
+	r.scrape(ctx, start.Add(time.Second*40))
+
+	samples = r.Samples()
+	assert.Zero(t, samples[1].VtgateQps)
+
+	// Components which are no longer found are dropped.
+	targets = targets[1:]
+	r.scrape(ctx, start.Add(time.Second*50))
+
+	components = r.Components()
+	require.Len(t, components, 1)
+	assert.Equal(t, "vtgate1", components[0].Name)
+}
+
+func TestRecorderMissingAddr(t *testing.T) {
+	t.Parallel()
+
+	r := New(func(ctx context.Context) ([]Target, error) {
+		return []Target{{Type: vtadminpb.ComponentMetrics_TABLET, Name: "zone1-0000000100"}}, nil
+	}, Config{ScrapeInterval: time.Second})
+	defer r.Close()
+
+	r.scrape(context.Background(), time.Now())
+
+	components := r.Components()
+	require.Len(t, components, 1)
+	assert.Contains(t, components[0].Error, "no address")
+	assert.Equal(t, int32(1), r.Samples()[0].ScrapeErrors)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// Vars are the metrics read from the /debug/vars of a VTGate or a tablet.
+type Vars struct {
+	// Queries is the number of queries served since the component started.
+	Queries int64
+	// Errors is the number of queries that failed since the component
+	// started.
+	Errors int64
+	// ReplicationLag is the replication lag of a tablet.
+	ReplicationLag time.Duration
+	// PoolUtilization is the highest ratio of in-use connections to capacity
+	// of the query, stream and transaction pools of a tablet.
+	PoolUtilization float64
+}
+
+// timings is the part of a stats.Timings or stats.MultiTimings we read.
+type timings struct {
+	TotalCount int64
+}
+
+type vtgateVars struct {
+	VtgateAPI            timings          `json:"VtgateApi"`
+	VtgateAPIErrorCounts map[string]int64 `json:"VtgateApiErrorCounts"`
+}
+
+type tabletVars struct {
+	Queries timings
+	Errors  map[string]int64
+
+	HeartbeatCurrentLagNs int64
+	ReplicationLagSec     int64 `json:"replicationLagSec"`
+
+	ConnPoolCapacity        int64
+	ConnPoolInUse           int64
+	StreamConnPoolCapacity  int64
+	StreamConnPoolInUse     int64
+	TransactionPoolCapacity int64
+	TransactionPoolInUse    int64
+}
+
+// ParseVars reads the metrics of a component of the given type from the JSON
+// served by its /debug/vars endpoint. Metrics missing from data are zero.
+func ParseVars(typ vtadminpb.ComponentMetrics_Type, data []byte) (*Vars, error) {
+	switch typ {
+	case vtadminpb.ComponentMetrics_VTGATE:
+		var vv vtgateVars
+		if err := json.Unmarshal(data, &vv); err != nil {
+			return nil, err
+		}
+
+		return &Vars{
+			Queries: vv.VtgateAPI.TotalCount,
+			Errors:  sum(vv.VtgateAPIErrorCounts),
+		}, nil
+	case vtadminpb.ComponentMetrics_TABLET:
+		var tv tabletVars
+		if err := json.Unmarshal(data, &tv); err != nil {
+			return nil, err
+		}
+
+		// Tablets report their lag with one of the two, depending on whether
+		// they use heartbeats.
+		lag := time.Duration(tv.HeartbeatCurrentLagNs)
+		if polled := time.Duration(tv.ReplicationLagSec) * time.Second; polled > lag {
+			lag = polled
+		}
+
+		return &Vars{
+			Queries:        tv.Queries.TotalCount,
+			Errors:         sum(tv.Errors),
+			ReplicationLag: lag,
+			PoolUtilization: max(
+				utilization(tv.ConnPoolInUse, tv.ConnPoolCapacity),
+				utilization(tv.StreamConnPoolInUse, tv.StreamConnPoolCapacity),
+				utilization(tv.TransactionPoolInUse, tv.TransactionPoolCapacity),
+			),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported component type %s", typ)
+}
+
+func sum(counts map[string]int64) (n int64) {
+	for _, count := range counts {
+		n += count
+	}
+
+	return n
+}
+
+func utilization(inUse, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+
+	return float64(inUse) / float64(capacity)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package queryconsole implements the guardrails of the VTAdmin query console,
+// which only runs read-only queries with a bounded number of rows.
+package queryconsole
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// ErrNotReadOnly is returned by Prepare for queries that are not read-only.
+var ErrNotReadOnly = errors.New("only read-only queries are allowed")
+
+// Prepare checks that sql is a single read-only statement, and limits the
+// number of rows it returns. It returns the query to run.
+//
+// SELECT and UNION statements without a LIMIT clause, or with a row count
+// above maxRows, are given a row count of maxRows+1, so that callers can tell
+// whether the result was truncated. Other read-only statements (SHOW, DESCRIBE
+// and EXPLAIN) are returned unchanged, and callers must stop reading their rows
+// after maxRows rows.
+func Prepare(sql string, maxRows int) (string, error) {
+	pieces, err := sqlparser.SplitStatementToPieces(sql)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(pieces) {
+	case 0:
+		return "", sqlparser.ErrEmpty
+	case 1:
+	default:
+		return "", fmt.Errorf("%w: found %d statements, expected one", ErrNotReadOnly, len(pieces))
+	}
+
+	stmt, err := sqlparser.Parse(pieces[0])
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkReadOnly(stmt); err != nil {
+		return "", err
+	}
+
+	if sel, ok := stmt.(sqlparser.SelectStatement); ok {
+		limitRows(sel, maxRows)
+	}
+
+	return sqlparser.String(stmt), nil
+}
+
+// checkReadOnly returns an error if stmt can modify data or lock rows.
+func checkReadOnly(stmt sqlparser.Statement) error {
+	switch stmt := stmt.(type) {
+	case sqlparser.SelectStatement:
+		return checkSelect(stmt)
+	case *sqlparser.Show, *sqlparser.ExplainTab:
+		return nil
+	case *sqlparser.ExplainStmt:
+		// EXPLAIN ANALYZE runs the statement it explains.
+		if stmt.Type == sqlparser.AnalyzeType {
+			return fmt.Errorf("%w: EXPLAIN ANALYZE runs the explained statement", ErrNotReadOnly)
+		}
+
+		return checkReadOnly(stmt.Statement)
+	}
+
+	return fmt.Errorf("%w: %s statements are not allowed", ErrNotReadOnly, sqlparser.ASTToStatementType(stmt))
+}
+
+// checkSelect returns an error if a select statement, or any of its subqueries,
+// locks rows or writes its result somewhere.
+func checkSelect(stmt sqlparser.SelectStatement) error {
+	var err error
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		var (
+			lock sqlparser.Lock
+			into *sqlparser.SelectInto
+		)
+
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			lock, into = node.Lock, node.Into
+		case *sqlparser.Union:
+			lock, into = node.Lock, node.Into
+		default:
+			return true, nil
+		}
+
+		switch {
+		case lock != sqlparser.NoLock:
+			err = fmt.Errorf("%w: locking reads are not allowed", ErrNotReadOnly)
+		case into != nil:
+			err = fmt.Errorf("%w: SELECT ... INTO is not allowed", ErrNotReadOnly)
+		}
+
+		return err == nil, nil
+	}, stmt)
+
+	return err
+}
+
+// limitRows sets the row count of a select statement to maxRows+1, unless it
+// already returns at most maxRows rows.
+func limitRows(stmt sqlparser.SelectStatement, maxRows int) {
+	limit := stmt.GetLimit()
+	if limit == nil {
+		stmt.SetLimit(&sqlparser.Limit{
+			Rowcount: sqlparser.NewIntLiteral(strconv.Itoa(maxRows + 1)),
+		})
+		return
+	}
+
+	if lit, ok := limit.Rowcount.(*sqlparser.Literal); ok && lit.Type == sqlparser.IntVal {
+		if n, err := strconv.Atoi(lit.Val); err == nil && n <= maxRows {
+			return
+		}
+	}
+
+	limit.Rowcount = sqlparser.NewIntLiteral(strconv.Itoa(maxRows + 1))
+}
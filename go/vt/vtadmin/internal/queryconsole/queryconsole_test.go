@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queryconsole
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		sql         string
+		expected    string
+		shouldErr   bool
+		notReadOnly bool
+	}{
+		{
+			name:     "select without limit",
+			sql:      "select * from t1 where id > 10",
+			expected: "select * from t1 where id > 10 limit 101",
+		},
+		{
+			name:     "select with lower limit",
+			sql:      "select * from t1 limit 10",
+			expected: "select * from t1 limit 10",
+		},
+		{
+			name:     "select with higher limit",
+			sql:      "select * from t1 limit 5, 1000",
+			expected: "select * from t1 limit 5, 101",
+		},
+		{
+			name:     "select with bind variable limit",
+			sql:      "select * from t1 limit :n",
+			expected: "select * from t1 limit 101",
+		},
+		{
+			name:     "union",
+			sql:      "select id from t1 union select id from t2",
+			expected: "select id from t1 union select id from t2 limit 101",
+		},
+		{
+			name:     "trailing semicolon",
+			sql:      "select 1 from dual;",
+			expected: "select 1 from dual limit 101",
+		},
+		{
+			name:     "show",
+			sql:      "show tables",
+			expected: "show tables",
+		},
+		{
+			name:     "describe",
+			sql:      "describe t1",
+			expected: "explain t1",
+		},
+		{
+			name:     "explain select",
+			sql:      "explain select * from t1",
+			expected: "explain select * from t1",
+		},
+		{
+			name:        "explain analyze",
+			sql:         "explain analyze select * from t1",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "explain delete",
+			sql:         "explain delete from t1",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "delete",
+			sql:         "delete from t1",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "ddl",
+			sql:         "drop table t1",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "set",
+			sql:         "set @@sql_mode = ''",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "select for update",
+			sql:         "select * from t1 for update",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "locking subquery",
+			sql:         "select * from t1 where id in (select id from t2 lock in share mode)",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "select into outfile",
+			sql:         "select * from t1 into outfile '/tmp/t1'",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:        "multiple statements",
+			sql:         "select * from t1; delete from t1",
+			shouldErr:   true,
+			notReadOnly: true,
+		},
+		{
+			name:      "empty",
+			sql:       " ",
+			shouldErr: true,
+		},
+		{
+			name:      "syntax error",
+			sql:       "selec * from t1",
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sql, err := Prepare(tt.sql, 100)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				if tt.notReadOnly {
+					assert.ErrorIs(t, err, ErrNotReadOnly)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sql)
+		})
+	}
+}
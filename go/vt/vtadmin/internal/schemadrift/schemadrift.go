@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schemadrift compares live schemas in VTAdmin. It uses schemadiff to
+// find the tables and views that differ between two schemas, and the DDL
+// statements that bring one of them in line with the other.
+package schemadrift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// Diff compares a target schema to a source schema. It returns how each table
+// and view of the target schema differs from the source schema, sorted by name,
+// and the statements that bring the target schema in line with the source
+// schema, in an order that is safe to apply them in.
+//
+// Tables and views that are identical in both schemas are not returned.
+func Diff(ctx context.Context, source *tabletmanagerdatapb.SchemaDefinition, target *tabletmanagerdatapb.SchemaDefinition) ([]*vtadminpb.TableSchemaDiff, []string, error) {
+	sourceSchema, err := load(source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot load the source schema: %w", err)
+	}
+
+	targetSchema, err := load(target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot load the target schema: %w", err)
+	}
+
+	schemaDiff, err := targetSchema.SchemaDiff(sourceSchema, &schemadiff.DiffHints{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot diff the schemas: %w", err)
+	}
+
+	tableDiffs := map[string]*vtadminpb.TableSchemaDiff{}
+	for _, diff := range schemaDiff.UnorderedDiffs() {
+		name := diff.EntityName()
+
+		tableDiff, ok := tableDiffs[name]
+		if !ok {
+			tableDiff = &vtadminpb.TableSchemaDiff{
+				Name: name,
+			}
+			tableDiffs[name] = tableDiff
+		}
+
+		switch diff.(type) {
+		case *schemadiff.CreateTableEntityDiff, *schemadiff.CreateViewEntityDiff:
+			tableDiff.Type = vtadminpb.TableSchemaDiff_MISSING_IN_TARGET
+		case *schemadiff.DropTableEntityDiff, *schemadiff.DropViewEntityDiff:
+			tableDiff.Type = vtadminpb.TableSchemaDiff_EXTRA_IN_TARGET
+		case *schemadiff.AlterTableEntityDiff:
+			tableDiff.Type = vtadminpb.TableSchemaDiff_CHANGED
+			diffColumns(targetSchema.Table(name), sourceSchema.Table(name), tableDiff)
+		case *schemadiff.AlterViewEntityDiff:
+			tableDiff.Type = vtadminpb.TableSchemaDiff_CHANGED
+		}
+
+		if ok {
+			// A table replaced by a view of the same name, or vice versa, is
+			// both dropped and created.
+			tableDiff.Type = vtadminpb.TableSchemaDiff_CHANGED
+		}
+
+		tableDiff.IsView = sourceSchema.View(name) != nil || targetSchema.View(name) != nil
+
+		for _, d := range schemadiff.AllSubsequent(diff) {
+			tableDiff.Ddls = append(tableDiff.Ddls, d.CanonicalStatementString())
+		}
+	}
+
+	orderedDiffs, err := schemaDiff.OrderedDiffs(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot order the schema diffs: %w", err)
+	}
+
+	var ddls []string
+	for _, diff := range orderedDiffs {
+		for _, d := range schemadiff.AllSubsequent(diff) {
+			ddls = append(ddls, d.CanonicalStatementString())
+		}
+	}
+
+	diffs := make([]*vtadminpb.TableSchemaDiff, 0, len(tableDiffs))
+	for _, tableDiff := range tableDiffs {
+		diffs = append(diffs, tableDiff)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs, ddls, nil
+}
+
+// load builds a schemadiff.Schema from the CREATE statements of the tables and
+// views of a SchemaDefinition.
+func load(sd *tabletmanagerdatapb.SchemaDefinition) (*schemadiff.Schema, error) {
+	queries := make([]string, 0, len(sd.GetTableDefinitions()))
+	for _, td := range sd.GetTableDefinitions() {
+		if td.Schema == "" {
+			return nil, fmt.Errorf("no CREATE statement for table %s", td.Name)
+		}
+
+		queries = append(queries, td.Schema)
+	}
+
+	return schemadiff.NewSchemaFromQueries(queries)
+}
+
+// diffColumns records the columns that differ between the target and source
+// definitions of a table.
+func diffColumns(target *schemadiff.CreateTableEntity, source *schemadiff.CreateTableEntity, tableDiff *vtadminpb.TableSchemaDiff) {
+	if target == nil || source == nil {
+		return
+	}
+
+	targetColumns := columnsByName(target)
+	sourceColumns := columnsByName(source)
+
+	for _, col := range source.TableSpec.Columns {
+		targetCol, ok := targetColumns[col.Name.Lowered()]
+		switch {
+		case !ok:
+			tableDiff.MissingColumns = append(tableDiff.MissingColumns, col.Name.String())
+		case sqlparser.CanonicalString(targetCol) != sqlparser.CanonicalString(col):
+			tableDiff.ChangedColumns = append(tableDiff.ChangedColumns, col.Name.String())
+		}
+	}
+
+	for _, col := range target.TableSpec.Columns {
+		if _, ok := sourceColumns[col.Name.Lowered()]; !ok {
+			tableDiff.ExtraColumns = append(tableDiff.ExtraColumns, col.Name.String())
+		}
+	}
+}
+
+func columnsByName(table *schemadiff.CreateTableEntity) map[string]*sqlparser.ColumnDefinition {
+	columns := make(map[string]*sqlparser.ColumnDefinition, len(table.TableSpec.Columns))
+	for _, col := range table.TableSpec.Columns {
+		columns[col.Name.Lowered()] = col
+	}
+
+	return columns
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadrift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func schema(queries ...string) *tabletmanagerdatapb.SchemaDefinition {
+	sd := &tabletmanagerdatapb.SchemaDefinition{}
+	for i, query := range queries {
+		sd.TableDefinitions = append(sd.TableDefinitions, &tabletmanagerdatapb.TableDefinition{
+			Name:   string(rune('a' + i)),
+			Schema: query,
+		})
+	}
+
+	return sd
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		source        *tabletmanagerdatapb.SchemaDefinition
+		target        *tabletmanagerdatapb.SchemaDefinition
+		expectedDiffs []*vtadminpb.TableSchemaDiff
+		expectedDDLs  []string
+		shouldErr     bool
+	}{
+		{
+			name: "identical",
+			source: schema(
+				"create table t1 (id int primary key)",
+			),
+			target: schema(
+				"create table t1 (id int primary key)",
+			),
+			expectedDiffs: []*vtadminpb.TableSchemaDiff{},
+		},
+		{
+			name: "missing and extra tables",
+			source: schema(
+				"create table t1 (id int primary key)",
+				"create table t2 (id int primary key)",
+			),
+			target: schema(
+				"create table t1 (id int primary key)",
+				"create table t3 (id int primary key)",
+			),
+			expectedDiffs: []*vtadminpb.TableSchemaDiff{
+				{
+					Name: "t2",
+					Type: vtadminpb.TableSchemaDiff_MISSING_IN_TARGET,
+					Ddls: []string{"CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)"},
+				},
+				{
+					Name: "t3",
+					Type: vtadminpb.TableSchemaDiff_EXTRA_IN_TARGET,
+					Ddls: []string{"DROP TABLE `t3`"},
+				},
+			},
+			expectedDDLs: []string{
+				"DROP TABLE `t3`",
+				"CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)",
+			},
+		},
+		{
+			name: "changed columns",
+			source: schema(
+				"create table t1 (id int primary key, name varchar(64), created_at datetime)",
+			),
+			target: schema(
+				"create table t1 (id int primary key, name varchar(32), deleted_at datetime)",
+			),
+			expectedDiffs: []*vtadminpb.TableSchemaDiff{
+				{
+					Name:           "t1",
+					Type:           vtadminpb.TableSchemaDiff_CHANGED,
+					MissingColumns: []string{"created_at"},
+					ExtraColumns:   []string{"deleted_at"},
+					ChangedColumns: []string{"name"},
+					Ddls:           []string{"ALTER TABLE `t1` DROP COLUMN `deleted_at`, MODIFY COLUMN `name` varchar(64), ADD COLUMN `created_at` datetime"},
+				},
+			},
+			expectedDDLs: []string{
+				"ALTER TABLE `t1` DROP COLUMN `deleted_at`, MODIFY COLUMN `name` varchar(64), ADD COLUMN `created_at` datetime",
+			},
+		},
+		{
+			name: "missing view",
+			source: schema(
+				"create table t1 (id int primary key)",
+				"create view v1 as select id from t1",
+			),
+			target: schema(
+				"create table t1 (id int primary key)",
+			),
+			expectedDiffs: []*vtadminpb.TableSchemaDiff{
+				{
+					Name:   "v1",
+					Type:   vtadminpb.TableSchemaDiff_MISSING_IN_TARGET,
+					IsView: true,
+					Ddls:   []string{"CREATE VIEW `v1` AS SELECT `id` FROM `t1`"},
+				},
+			},
+			expectedDDLs: []string{
+				"CREATE VIEW `v1` AS SELECT `id` FROM `t1`",
+			},
+		},
+		{
+			name: "missing create statement",
+			source: &tabletmanagerdatapb.SchemaDefinition{
+				TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+					{Name: "t1"},
+				},
+			},
+			target:    schema(),
+			shouldErr: true,
+		},
+		{
+			name:      "invalid create statement",
+			source:    schema(),
+			target:    schema("create tabel t1"),
+			shouldErr: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diffs, ddls, err := Diff(ctx, tt.source, tt.target)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedDiffs, diffs)
+			assert.Equal(t, tt.expectedDDLs, ddls)
+		})
+	}
+}
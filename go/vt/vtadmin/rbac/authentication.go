@@ -88,10 +88,14 @@ func AuthenticationUnaryInterceptor(authn Authenticator) grpc.UnaryServerInterce
 }
 
 // Actor represents the subject in the "subject action resource" of an
-// authorization check. It has a name and many roles.
+// authorization check. It has a name and many roles, some of which may only be
+// held in particular clusters.
 type Actor struct {
 	Name  string   `json:"name"`
 	Roles []string `json:"roles"`
+	// ClusterRoles are the roles the actor holds in specific clusters, keyed by
+	// cluster ID, in addition to the Roles it holds in every cluster.
+	ClusterRoles map[string][]string `json:"cluster_roles,omitempty"`
 }
 
 type actorkey struct{}
@@ -18,6 +18,8 @@ package rbac
 
 import (
 	"context"
+
+	"vitess.io/vitess/go/vt/vtadmin/audit"
 )
 
 // Authorizer contains a set of rules that determine which actors may take which
@@ -60,8 +62,26 @@ func NewAuthorizer(cfg *Config) (*Authorizer, error) {
 
 // IsAuthorized returns whether an Actor (from the context) is permitted to take
 // the given action on the given resource in the given cluster.
+//
+// Checks for actions other than GetAction and PingAction are recorded in the
+// audit entry of the context, if one exists.
 func (authz *Authorizer) IsAuthorized(ctx context.Context, clusterID string, resource Resource, action Action) bool {
 	actor, _ := FromContext(ctx) // nil is ok here, since rule.Allows handles it
+	allowed := authz.allows(clusterID, resource, action, actor)
+
+	if entry, ok := audit.FromContext(ctx); ok && action != GetAction && action != PingAction {
+		var name string
+		if actor != nil {
+			name = actor.Name
+		}
+
+		entry.RecordAuthorization(name, clusterID, string(resource), string(action), allowed)
+	}
+
+	return allowed
+}
+
+func (authz *Authorizer) allows(clusterID string, resource Resource, action Action, actor *Actor) bool {
 	if p, ok := authz.policies["*"]; ok {
 		// We have policies for the wildcard resource to check first
 		for _, rule := range p {
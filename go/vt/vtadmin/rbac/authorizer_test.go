@@ -93,6 +93,28 @@ func TestIsAuthorized(t *testing.T) {
 			action:       GetAction,
 			isAuthorized: false,
 		},
+		{
+			name: "resource rule with cluster role",
+			actor: &Actor{
+				Name:         "someuser",
+				ClusterRoles: map[string][]string{"c1": {"testrole"}},
+			},
+			clusterID:    "c1",
+			resource:     KeyspaceResource,
+			action:       GetAction,
+			isAuthorized: true,
+		},
+		{
+			name: "cluster role held in another cluster",
+			actor: &Actor{
+				Name:         "someuser",
+				ClusterRoles: map[string][]string{"c2": {"testrole"}},
+			},
+			clusterID:    "c1",
+			resource:     KeyspaceResource,
+			action:       GetAction,
+			isAuthorized: false,
+		},
 		{
 			name:         "wildcard subject",
 			actor:        nil,
@@ -32,7 +32,9 @@ import (
 // cfg.Reify. A config must be reified before first use.
 type Config struct {
 	Authenticator string
-	Rules         []*struct {
+	// OIDC configures the built-in "oidc" authenticator.
+	OIDC  *OIDCConfig
+	Rules []*struct {
 		Resource string
 		Actions  []string
 		Subjects []string
@@ -134,6 +136,13 @@ func (c *Config) Reify() error {
 			return err
 		}
 
+		c.authenticator = authn
+	case c.Authenticator == OIDCAuthenticatorName:
+		authn, err := newOIDCAuthenticator(c.OIDC)
+		if err != nil {
+			return err
+		}
+
 		c.authenticator = authn
 	case c.Authenticator != "":
 		factory, ok := authenticators[c.Authenticator]
@@ -178,9 +187,18 @@ func DefaultConfig() *Config {
 		string(EmergencyFailoverShardAction),
 		string(PlannedFailoverShardAction),
 		string(TabletExternallyPromotedAction),
+		string(ManageTabletDrainAction),
 		string(ManageTabletReplicationAction),
 		string(ManageTabletWritabilityAction),
 		string(RefreshTabletReplicationSourceAction),
+		string(CancelSchemaMigrationAction),
+		string(CleanupSchemaMigrationAction),
+		string(CompleteSchemaMigrationAction),
+		string(LaunchSchemaMigrationAction),
+		string(RetrySchemaMigrationAction),
+		string(CompleteWorkflowAction),
+		string(SwitchWorkflowTrafficAction),
+		string(ExecuteQueryAction),
 	}
 	subjects := []string{"*"}
 	clusters := []string{"*"}
@@ -0,0 +1,360 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"google.golang.org/grpc/metadata"
+
+	"vitess.io/vitess/go/sets"
+	"vitess.io/vitess/go/vt/concurrency"
+)
+
+// OIDCAuthenticatorName is the name of the built-in authenticator that
+// authenticates actors with the ID tokens of an OpenID Connect provider, and
+// resolves their roles from the group claims of those tokens.
+const OIDCAuthenticatorName = "oidc"
+
+const (
+	defaultOIDCUsernameClaim = "sub"
+	defaultOIDCGroupsClaim   = "groups"
+)
+
+// oidcSigningAlgs are the signing algorithms accepted for ID tokens.
+var oidcSigningAlgs = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.PS256, oidc.PS384, oidc.PS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+}
+
+// OIDCConfig configures the OIDC authenticator.
+type OIDCConfig struct {
+	// Issuer is the URL of the OIDC provider. It must match the "iss" claim of
+	// the tokens, and is used to discover the JWKSURL when that is not set.
+	Issuer string
+	// ClientID is the client ID of VTAdmin at the provider. It is required,
+	// and must be one of the audiences of the tokens.
+	ClientID string `mapstructure:"client_id"`
+	// JWKSURL is the URL of the JSON Web Key Set used to verify the signatures
+	// of the tokens.
+	JWKSURL string `mapstructure:"jwks_url"`
+	// UsernameClaim is the claim used as the name of the actor. It defaults
+	// to "sub".
+	UsernameClaim string `mapstructure:"username_claim"`
+	// GroupsClaim is the claim holding the groups of the actor. It defaults to
+	// "groups". Nested claims are addressed with dots, e.g.
+	// "realm_access.roles".
+	GroupsClaim string `mapstructure:"groups_claim"`
+	// Cookie is the name of a cookie holding the token, for HTTP requests
+	// without an Authorization header.
+	Cookie string
+	// GroupMappings map the groups of the actors to their roles.
+	GroupMappings []*OIDCGroupMapping `mapstructure:"group_mappings"`
+}
+
+// OIDCGroupMapping grants roles to the members of a group, in some or all of
+// the clusters.
+type OIDCGroupMapping struct {
+	Group string
+	Roles []string
+	// Clusters the roles are granted in. Empty, or the wildcard ("*"), means
+	// all clusters.
+	Clusters []string
+}
+
+type oidcAuthenticator struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	// roles granted in all clusters, keyed by group.
+	roles map[string][]string
+	// roles granted in specific clusters, keyed by group and then cluster.
+	clusterRoles map[string]map[string][]string
+
+	m sync.Mutex
+	// verifier is created once the key set URL of the provider is known,
+	// either from the config or from the discovery of the provider.
+	verifier *oidc.IDTokenVerifier
+}
+
+// newOIDCAuthenticator validates the config and returns an OIDC authenticator.
+func newOIDCAuthenticator(cfg *OIDCConfig) (*oidcAuthenticator, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%s authenticator requires an oidc config", OIDCAuthenticatorName)
+	}
+
+	rec := concurrency.AllErrorRecorder{}
+	if cfg.Issuer == "" {
+		rec.RecordError(errors.New("oidc: issuer is required"))
+	}
+
+	if cfg.ClientID == "" {
+		rec.RecordError(errors.New("oidc: client_id is required"))
+	}
+
+	authn := &oidcAuthenticator{
+		cfg:          *cfg,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		roles:        map[string][]string{},
+		clusterRoles: map[string]map[string][]string{},
+	}
+
+	if cfg.JWKSURL != "" {
+		keySet := oidc.NewRemoteKeySet(oidc.ClientContext(context.Background(), authn.client), cfg.JWKSURL)
+		authn.verifier = oidc.NewVerifier(cfg.Issuer, keySet, authn.verifierConfig())
+	}
+
+	if authn.cfg.UsernameClaim == "" {
+		authn.cfg.UsernameClaim = defaultOIDCUsernameClaim
+	}
+
+	if authn.cfg.GroupsClaim == "" {
+		authn.cfg.GroupsClaim = defaultOIDCGroupsClaim
+	}
+
+	for i, mapping := range cfg.GroupMappings {
+		if mapping.Group == "" {
+			rec.RecordError(fmt.Errorf("oidc: group mapping %d: group is required", i))
+		}
+
+		if len(mapping.Roles) == 0 {
+			rec.RecordError(fmt.Errorf("oidc: group mapping %d: at least one role is required", i))
+		}
+
+		clusters := sets.New[string](mapping.Clusters...)
+		if clusters.Has("*") && clusters.Len() > 1 {
+			// error to have wildcard and something else
+			rec.RecordError(fmt.Errorf("oidc: group mapping %d: clusters list cannot include wildcard and other clusters, have %v", i, sets.List(clusters)))
+		}
+
+		if clusters.Len() == 0 || clusters.Has("*") {
+			authn.roles[mapping.Group] = append(authn.roles[mapping.Group], mapping.Roles...)
+			continue
+		}
+
+		byCluster, ok := authn.clusterRoles[mapping.Group]
+		if !ok {
+			byCluster = map[string][]string{}
+			authn.clusterRoles[mapping.Group] = byCluster
+		}
+
+		for _, cluster := range sets.List(clusters) {
+			byCluster[cluster] = append(byCluster[cluster], mapping.Roles...)
+		}
+	}
+
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	return authn, nil
+}
+
+// Authenticate is part of the Authenticator interface. It reads the token from
+// the "authorization" metadata of the request.
+func (authn *oidcAuthenticator) Authenticate(ctx context.Context) (*Actor, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, value := range md.Get("authorization") {
+		if token, ok := bearerToken(value); ok {
+			return authn.authenticateToken(ctx, token)
+		}
+	}
+
+	return nil, nil
+}
+
+// AuthenticateHTTP is part of the Authenticator interface. It reads the token
+// from the Authorization header of the request, or else from the configured
+// cookie.
+func (authn *oidcAuthenticator) AuthenticateHTTP(r *http.Request) (*Actor, error) {
+	if token, ok := bearerToken(r.Header.Get("Authorization")); ok {
+		return authn.authenticateToken(r.Context(), token)
+	}
+
+	if authn.cfg.Cookie != "" {
+		if cookie, err := r.Cookie(authn.cfg.Cookie); err == nil && cookie.Value != "" {
+			return authn.authenticateToken(r.Context(), cookie.Value)
+		}
+	}
+
+	// No token: the request is unauthenticated.
+	return nil, nil
+}
+
+func bearerToken(value string) (string, bool) {
+	scheme, token, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// authenticateToken verifies the token and returns the actor it identifies,
+// with the roles mapped from its groups.
+func (authn *oidcAuthenticator) authenticateToken(ctx context.Context, token string) (*Actor, error) {
+	claims, err := authn.verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+
+	name, _ := lookupClaim(claims, authn.cfg.UsernameClaim).(string)
+	if name == "" {
+		return nil, fmt.Errorf("oidc: token has no %s claim", authn.cfg.UsernameClaim)
+	}
+
+	roles := sets.New[string]()
+	clusterRoles := map[string]sets.Set[string]{}
+
+	for _, group := range claimStrings(lookupClaim(claims, authn.cfg.GroupsClaim)) {
+		roles.Insert(authn.roles[group]...)
+
+		for cluster, rs := range authn.clusterRoles[group] {
+			if _, ok := clusterRoles[cluster]; !ok {
+				clusterRoles[cluster] = sets.New[string]()
+			}
+
+			clusterRoles[cluster].Insert(rs...)
+		}
+	}
+
+	actor := &Actor{
+		Name:  name,
+		Roles: sets.List(roles),
+	}
+
+	if len(clusterRoles) > 0 {
+		actor.ClusterRoles = make(map[string][]string, len(clusterRoles))
+		for cluster, rs := range clusterRoles {
+			actor.ClusterRoles[cluster] = sets.List(rs)
+		}
+	}
+
+	return actor, nil
+}
+
+// verify checks the signature, issuer, audience and expiry of the token, and
+// returns its claims.
+func (authn *oidcAuthenticator) verify(ctx context.Context, token string) (map[string]any, error) {
+	verifier, err := authn.getVerifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// getVerifier returns the verifier of the tokens, discovering the key set URL
+// of the provider first if needed. The discovery is made without holding
+// authn.m, so that a slow provider does not block the other requests; requests
+// racing to discover the provider keep the first verifier created.
+func (authn *oidcAuthenticator) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	authn.m.Lock()
+	verifier := authn.verifier
+	authn.m.Unlock()
+
+	if verifier != nil {
+		return verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, authn.client), authn.cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot discover provider: %w", err)
+	}
+
+	// The key set outlives the request, so it must not use its context.
+	verifier = provider.VerifierContext(oidc.ClientContext(context.Background(), authn.client), authn.verifierConfig())
+
+	authn.m.Lock()
+	defer authn.m.Unlock()
+
+	if authn.verifier == nil {
+		authn.verifier = verifier
+	}
+
+	return authn.verifier, nil
+}
+
+func (authn *oidcAuthenticator) verifierConfig() *oidc.Config {
+	return &oidc.Config{
+		ClientID:             authn.cfg.ClientID,
+		SupportedSigningAlgs: oidcSigningAlgs,
+	}
+}
+
+// lookupClaim returns the value of a claim, following dots into nested
+// objects.
+func lookupClaim(claims map[string]any, name string) any {
+	if v, ok := claims[name]; ok {
+		return v
+	}
+
+	var cur any = claims
+	for _, field := range strings.Split(name, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		cur = obj[field]
+	}
+
+	return cur
+}
+
+// claimStrings returns the strings of a claim that is either a single string
+// or a list of strings.
+func claimStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+
+		return strs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,418 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+type testOIDCProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+	kid string
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testOIDCProvider{key: key, kid: "key1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   p.URL,
+			"jwks_uri": p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": p.kid,
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+				},
+			},
+		})
+	})
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func (p *testOIDCProvider) sign(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest.Sum(nil))
+	require.NoError(t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	provider := newTestOIDCProvider(t)
+	authn, err := newOIDCAuthenticator(&OIDCConfig{
+		Issuer:        provider.URL,
+		ClientID:      "vtadmin",
+		UsernameClaim: "email",
+		GroupsClaim:   "realm_access.groups",
+		Cookie:        "id_token",
+		GroupMappings: []*OIDCGroupMapping{
+			{
+				Group: "dbas",
+				Roles: []string{"dba"},
+			},
+			{
+				Group:    "us-east-dbas",
+				Roles:    []string{"dba", "oncall"},
+				Clusters: []string{"iad", "bos"},
+			},
+			{
+				Group:    "devs",
+				Roles:    []string{"dev"},
+				Clusters: []string{"*"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":   provider.URL,
+			"aud":   []string{"vtadmin", "other"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"email": "alice@example.com",
+			"realm_access": map[string]any{
+				"groups": []string{"us-east-dbas", "devs", "unmapped"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		token     func() string
+		useCookie bool
+		noToken   bool
+		expected  *Actor
+		shouldErr bool
+	}{
+		{
+			name:  "valid token",
+			token: func() string { return provider.sign(t, provider.kid, validClaims()) },
+			expected: &Actor{
+				Name:  "alice@example.com",
+				Roles: []string{"dev"},
+				ClusterRoles: map[string][]string{
+					"bos": {"dba", "oncall"},
+					"iad": {"dba", "oncall"},
+				},
+			},
+		},
+		{
+			name:      "valid token in cookie",
+			token:     func() string { return provider.sign(t, provider.kid, validClaims()) },
+			useCookie: true,
+			expected: &Actor{
+				Name:  "alice@example.com",
+				Roles: []string{"dev"},
+				ClusterRoles: map[string][]string{
+					"bos": {"dba", "oncall"},
+					"iad": {"dba", "oncall"},
+				},
+			},
+		},
+		{
+			name: "no mapped groups",
+			token: func() string {
+				claims := validClaims()
+				claims["realm_access"] = map[string]any{"groups": "unmapped"}
+				return provider.sign(t, provider.kid, claims)
+			},
+			expected: &Actor{
+				Name:  "alice@example.com",
+				Roles: []string{},
+			},
+		},
+		{
+			name:     "no token",
+			noToken:  true,
+			expected: nil,
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = now.Add(-time.Hour).Unix()
+				return provider.sign(t, provider.kid, claims)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://evil.example.com"
+				return provider.sign(t, provider.kid, claims)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return provider.sign(t, provider.kid, claims)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "missing username claim",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "email")
+				return provider.sign(t, provider.kid, claims)
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "unknown key",
+			token:     func() string { return provider.sign(t, "key2", validClaims()) },
+			shouldErr: true,
+		},
+		{
+			name: "tampered claims",
+			token: func() string {
+				token := provider.sign(t, provider.kid, validClaims())
+				other := provider.sign(t, provider.kid, map[string]any{"email": "mallory@example.com"})
+
+				// Keep the signed part of one token, with the signature of another.
+				return token[:strings.LastIndex(token, ".")] + other[strings.LastIndex(other, "."):]
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "malformed token",
+			token:     func() string { return "not-a-jwt" },
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/api/clusters", nil)
+			if !tt.noToken {
+				if tt.useCookie {
+					r.AddCookie(&http.Cookie{Name: "id_token", Value: tt.token()})
+				} else {
+					r.Header.Set("Authorization", "Bearer "+tt.token())
+				}
+			}
+
+			actor, err := authn.AuthenticateHTTP(r)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actor)
+		})
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+provider.sign(t, provider.kid, validClaims())))
+		actor, err := authn.Authenticate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", actor.Name)
+
+		actor, err = authn.Authenticate(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, actor)
+	})
+}
+
+func TestOIDCAuthenticatorJWKSURL(t *testing.T) {
+	t.Parallel()
+
+	provider := newTestOIDCProvider(t)
+	authn, err := newOIDCAuthenticator(&OIDCConfig{
+		// The issuer does not serve a provider configuration, so the keys
+		// can only be fetched from the configured key set URL.
+		Issuer:   "https://accounts.example.com",
+		ClientID: "vtadmin",
+		JWKSURL:  provider.URL + "/keys",
+	})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/clusters", nil)
+	r.Header.Set("Authorization", "Bearer "+provider.sign(t, provider.kid, map[string]any{
+		"iss": "https://accounts.example.com",
+		"aud": "vtadmin",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"sub": "alice",
+	}))
+
+	actor, err := authn.AuthenticateHTTP(r)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", actor.Name)
+}
+
+func TestNewOIDCAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		cfg       *OIDCConfig
+		shouldErr bool
+	}{
+		{
+			name:      "nil config",
+			shouldErr: true,
+		},
+		{
+			name:      "missing issuer",
+			cfg:       &OIDCConfig{ClientID: "vtadmin"},
+			shouldErr: true,
+		},
+		{
+			name:      "missing client id",
+			cfg:       &OIDCConfig{Issuer: "https://accounts.example.com"},
+			shouldErr: true,
+		},
+		{
+			name: "mapping without roles",
+			cfg: &OIDCConfig{
+				Issuer:        "https://accounts.example.com",
+				ClientID:      "vtadmin",
+				GroupMappings: []*OIDCGroupMapping{{Group: "dbas"}},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "wildcard and other clusters",
+			cfg: &OIDCConfig{
+				Issuer:   "https://accounts.example.com",
+				ClientID: "vtadmin",
+				GroupMappings: []*OIDCGroupMapping{
+					{Group: "dbas", Roles: []string{"dba"}, Clusters: []string{"*", "iad"}},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "valid",
+			cfg: &OIDCConfig{
+				Issuer:   "https://accounts.example.com",
+				ClientID: "vtadmin",
+				GroupMappings: []*OIDCGroupMapping{
+					{Group: "dbas", Roles: []string{"dba"}, Clusters: []string{"iad"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authn, err := newOIDCAuthenticator(tt.cfg)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, defaultOIDCUsernameClaim, authn.cfg.UsernameClaim)
+			assert.Equal(t, defaultOIDCGroupsClaim, authn.cfg.GroupsClaim)
+		})
+	}
+}
+
+func TestLoadConfigOIDC(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rbac.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+authenticator: oidc
+oidc:
+  issuer: https://accounts.example.com
+  client_id: vtadmin
+  groups_claim: groups
+  group_mappings:
+    - group: us-east-dbas
+      roles: [dba]
+      clusters: [iad]
+rules:
+  - resource: "*"
+    actions: ["*"]
+    subjects: ["role:dba"]
+    clusters: ["*"]
+`), 0o644))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	authn, ok := cfg.GetAuthenticator().(*oidcAuthenticator)
+	require.True(t, ok, "expected an oidc authenticator, got %T", cfg.GetAuthenticator())
+	assert.Equal(t, "vtadmin", authn.cfg.ClientID)
+	assert.Equal(t, map[string]map[string][]string{"us-east-dbas": {"iad": {"dba"}}}, authn.clusterRoles)
+}
@@ -39,7 +39,10 @@ conforms to the expected Authenticator interface, and vtadmin will use that when
 setting up the interceptors/middlewares. Currently, authenticators may be
 registered at runtime via the rbac.RegisterAuthenticator method, or may be set
 as a Go plugin (built via `go build -buildmode=plugin`) by setting the
-authenticator name as a path ending in ".so" in the rbac config.
+authenticator name as a path ending in ".so" in the rbac config. VTAdmin also
+ships an "oidc" authenticator, which verifies OpenID Connect ID tokens and maps
+the groups of their actors to roles, globally or per-cluster, so access follows
+the groups of an identity provider without a static list of actors.
 
 2. Permissions are additive. There is no concept of a negative permission (or
 revocation). To "revoke" a permission from a user or role, structure your rules
@@ -80,9 +83,27 @@ const (
 
 	/* tablet-specific actions */
 
+	ManageTabletDrainAction              Action = "manage_tablet_drain"       // Drain/Undrain
 	ManageTabletReplicationAction        Action = "manage_tablet_replication" // Start/Stop Replication
 	ManageTabletWritabilityAction        Action = "manage_tablet_writability" // SetRead{Only,Write}
 	RefreshTabletReplicationSourceAction Action = "refresh_tablet_replication_source"
+
+	/* schema-migration-specific actions */
+
+	CancelSchemaMigrationAction   Action = "cancel_schema_migration"
+	CleanupSchemaMigrationAction  Action = "cleanup_schema_migration"
+	CompleteSchemaMigrationAction Action = "complete_schema_migration"
+	LaunchSchemaMigrationAction   Action = "launch_schema_migration"
+	RetrySchemaMigrationAction    Action = "retry_schema_migration"
+
+	/* workflow-specific actions */
+
+	CompleteWorkflowAction      Action = "complete_workflow"
+	SwitchWorkflowTrafficAction Action = "switch_workflow_traffic"
+
+	/* query-specific actions */
+
+	ExecuteQueryAction Action = "execute_query"
 )
 
 // Resource is an enum representing all resources managed by vtadmin.
@@ -114,8 +135,13 @@ const (
 
 	/* misc resources */
 
+	AuditLogResource                 Resource = "AuditLog"
 	BackupResource                   Resource = "Backup"
+	MetricsResource                  Resource = "Metrics"
+	QueryResource                    Resource = "Query"
+	SavedQueryResource               Resource = "SavedQuery"
 	SchemaResource                   Resource = "Schema"
+	SchemaMigrationResource          Resource = "SchemaMigration"
 	ShardReplicationPositionResource Resource = "ShardReplicationPosition"
 	WorkflowResource                 Resource = "Workflow"
 
@@ -54,6 +54,12 @@ func (r *Rule) Allows(clusterID string, action Action, actor *Actor) bool {
 					return true
 				}
 			}
+
+			for _, role := range actor.ClusterRoles[clusterID] {
+				if r.subjects.Has(fmt.Sprintf("role:%s", role)) {
+					return true
+				}
+			}
 		}
 	}
 
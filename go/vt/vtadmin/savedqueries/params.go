@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package savedqueries
+
+import (
+	"fmt"
+	"sort"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtadmin/internal/queryconsole"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// checkQuery returns an error if the query of q is not a single read-only
+// statement, or if its parameters are not exactly its bind variables.
+func checkQuery(q *vtadminpb.SavedQuery) error {
+	if _, err := queryconsole.Prepare(q.Sql, 1); err != nil {
+		return err
+	}
+
+	_, bindVars, err := sqlparser.Parse2(q.Sql)
+	if err != nil {
+		return err
+	}
+
+	params := make(map[string]bool, len(q.Parameters))
+	for _, p := range q.Parameters {
+		switch {
+		case p.Name == "":
+			return fmt.Errorf("parameters must have a name")
+		case params[p.Name]:
+			return fmt.Errorf("duplicate parameter %s", p.Name)
+		}
+
+		if _, ok := bindVars[p.Name]; !ok {
+			return fmt.Errorf("parameter %s is not used by the query", p.Name)
+		}
+
+		params[p.Name] = true
+	}
+
+	var missing []string
+	for name := range bindVars {
+		if !params[name] {
+			missing = append(missing, ":"+name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("bind variables %v have no parameter", missing)
+	}
+
+	return nil
+}
+
+// Bind returns the query of q with its parameters bound to the given values,
+// or to their default values for parameters without one. Values are bound as
+// strings, and so are escaped rather than spliced into the query.
+func Bind(q *vtadminpb.SavedQuery, values map[string]string) (string, error) {
+	stmt, _, err := sqlparser.Parse2(q.Sql)
+	if err != nil {
+		return "", err
+	}
+
+	bindVars := make(map[string]*querypb.BindVariable, len(q.Parameters))
+	for _, p := range q.Parameters {
+		value, ok := values[p.Name]
+		if !ok || value == "" {
+			value = p.DefaultValue
+		}
+
+		if value == "" {
+			return "", fmt.Errorf("missing value for parameter %s", p.Name)
+		}
+
+		bindVars[p.Name] = sqltypes.StringBindVariable(value)
+	}
+
+	for name := range values {
+		if _, ok := bindVars[name]; !ok {
+			return "", fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+
+	return sqlparser.NewParsedQuery(stmt).GenerateQuery(bindVars, nil)
+}
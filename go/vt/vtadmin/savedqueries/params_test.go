@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package savedqueries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func TestCheckQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		sql       string
+		params    []string
+		shouldErr bool
+	}{
+		{
+			name:   "no parameters",
+			sql:    "select * from users",
+			params: nil,
+		},
+		{
+			name:   "parameters match bind variables",
+			sql:    "select * from users where id = :id and status = :status",
+			params: []string{"status", "id"},
+		},
+		{
+			name:      "not read-only",
+			sql:       "delete from users where id = :id",
+			params:    []string{"id"},
+			shouldErr: true,
+		},
+		{
+			name:      "bind variable without parameter",
+			sql:       "select * from users where id = :id",
+			shouldErr: true,
+		},
+		{
+			name:      "parameter without bind variable",
+			sql:       "select * from users",
+			params:    []string{"id"},
+			shouldErr: true,
+		},
+		{
+			name:      "duplicate parameter",
+			sql:       "select * from users where id = :id",
+			params:    []string{"id", "id"},
+			shouldErr: true,
+		},
+		{
+			name:      "unnamed parameter",
+			sql:       "select * from users",
+			params:    []string{""},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := &vtadminpb.SavedQuery{Sql: tt.sql}
+			for _, name := range tt.params {
+				q.Parameters = append(q.Parameters, &vtadminpb.SavedQuery_Parameter{Name: name})
+			}
+
+			err := checkQuery(q)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	q := &vtadminpb.SavedQuery{
+		Sql: "select * from users where id = :id and status = :status",
+		Parameters: []*vtadminpb.SavedQuery_Parameter{
+			{Name: "id"},
+			{Name: "status", DefaultValue: "active"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		values    map[string]string
+		expected  string
+		shouldErr bool
+	}{
+		{
+			name:     "default value",
+			values:   map[string]string{"id": "42"},
+			expected: "select * from users where id = '42' and `status` = 'active'",
+		},
+		{
+			name:     "given value",
+			values:   map[string]string{"id": "42", "status": "closed"},
+			expected: "select * from users where id = '42' and `status` = 'closed'",
+		},
+		{
+			name:     "values are escaped",
+			values:   map[string]string{"id": "1' or '1'='1"},
+			expected: "select * from users where id = '1\\' or \\'1\\'=\\'1' and `status` = 'active'",
+		},
+		{
+			name:      "missing value",
+			values:    map[string]string{"status": "closed"},
+			shouldErr: true,
+		},
+		{
+			name:      "unknown parameter",
+			values:    map[string]string{"id": "42", "name": "alice"},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sql, err := Bind(q, tt.values)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sql)
+		})
+	}
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package savedqueries
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+const (
+	// DefaultCheckInterval is how often a Scheduler checks for queries due to
+	// run if Options.CheckInterval is not set.
+	DefaultCheckInterval = 10 * time.Second
+	// MinScheduleInterval is the shortest interval between two scheduled runs
+	// of a query.
+	MinScheduleInterval = time.Minute
+	// webhookTimeout bounds the time spent delivering a report to a webhook.
+	webhookTimeout = 30 * time.Second
+)
+
+// Options configures a Scheduler.
+type Options struct {
+	// CheckInterval is how often the scheduler checks for queries due to run.
+	// Defaults to DefaultCheckInterval.
+	CheckInterval time.Duration
+	// WebhookAllowedHosts are the hostnames of the webhooks reports may be
+	// sent to. Reports cannot be sent to webhooks if it is not set.
+	WebhookAllowedHosts []string
+	SMTP                SMTPOptions
+}
+
+// SMTPOptions configures the delivery of reports by email. Reports cannot be
+// emailed if Addr is not set.
+type SMTPOptions struct {
+	// Addr is the host:port of the SMTP server to send reports through.
+	Addr string
+	// From is the address reports are sent from.
+	From string
+	// Username and Password, if set, authenticate to the server with PLAIN
+	// authentication, which net/smtp only allows over TLS or to localhost.
+	Username string
+	Password string
+}
+
+// RunFunc runs a saved query with the default values of its parameters.
+type RunFunc func(ctx context.Context, q *vtadminpb.SavedQuery) (*vtadminpb.ExecuteQueryResponse, error)
+
+// Scheduler periodically runs the saved queries of a Store which have a
+// schedule, and delivers their reports. Queries due at the same time run one
+// after the other.
+type Scheduler struct {
+	store  *Store
+	run    RunFunc
+	opts   Options
+	client *http.Client
+
+	// sendMail is smtp.SendMail, and is overridden in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewScheduler returns a Scheduler for the queries of store, which runs them
+// with run. It does not run anything until Start is called.
+func NewScheduler(store *Store, run RunFunc, opts Options) *Scheduler {
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = DefaultCheckInterval
+	}
+
+	s := &Scheduler{
+		store:    store,
+		run:      run,
+		opts:     opts,
+		sendMail: smtp.SendMail,
+	}
+
+	s.client = &http.Client{
+		Timeout: webhookTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// Redirects must not lead reports to a host that is not allowed.
+			if err := s.checkWebhookURL(req.URL); err != nil {
+				return err
+			}
+
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+
+			return nil
+		},
+	}
+
+	return s
+}
+
+// Start runs scheduled queries in the background until Close is called.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.opts.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.tick(ctx, now)
+			}
+		}
+	}()
+}
+
+// Close stops the scheduler, interrupting any query it is running, and waits
+// for it to return.
+func (s *Scheduler) Close() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+}
+
+// Validate returns an error if q cannot be saved: if its query is not a
+// single read-only statement, if its parameters do not match the bind
+// variables of the query, if it runs against tablets the query console does
+// not allow, or if its schedule is invalid.
+func (s *Scheduler) Validate(q *vtadminpb.SavedQuery) error {
+	switch {
+	case q.Name == "":
+		return errors.New("name is required")
+	case q.Keyspace == "":
+		return errors.New("keyspace name is required")
+	}
+
+	switch q.TabletType {
+	case topodatapb.TabletType_UNKNOWN, topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+	default:
+		return fmt.Errorf("cannot run queries against %s tablets", topoproto.TabletTypeLString(q.TabletType))
+	}
+
+	if err := checkQuery(q); err != nil {
+		return err
+	}
+
+	if q.Schedule == nil {
+		return nil
+	}
+
+	interval, ok, err := protoutil.DurationFromProto(q.Schedule.Interval)
+	switch {
+	case err != nil:
+		return fmt.Errorf("invalid schedule interval: %w", err)
+	case !ok || interval < MinScheduleInterval:
+		return fmt.Errorf("schedule interval must be at least %v", MinScheduleInterval)
+	}
+
+	for _, p := range q.Parameters {
+		if p.DefaultValue == "" {
+			return fmt.Errorf("parameter %s of a scheduled query must have a default value", p.Name)
+		}
+	}
+
+	if q.Schedule.WebhookUrl == "" && len(q.Schedule.EmailRecipients) == 0 {
+		return errors.New("schedule must have a webhook or email recipients")
+	}
+
+	if q.Schedule.WebhookUrl != "" {
+		u, err := url.Parse(q.Schedule.WebhookUrl)
+		if err != nil {
+			return fmt.Errorf("invalid webhook URL: %w", err)
+		}
+
+		if err := s.checkWebhookURL(u); err != nil {
+			return err
+		}
+	}
+
+	if len(q.Schedule.EmailRecipients) > 0 && s.opts.SMTP.Addr == "" {
+		return errors.New("email delivery is not configured")
+	}
+
+	for _, addr := range q.Schedule.EmailRecipients {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("invalid email recipient %q: %w", addr, err)
+		}
+	}
+
+	return nil
+}
+
+// checkWebhookURL returns an error if reports cannot be sent to u.
+func (s *Scheduler) checkWebhookURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook URL must be http or https, got %q", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return errors.New("webhook URL must have a host")
+	}
+
+	if len(s.opts.WebhookAllowedHosts) == 0 {
+		return errors.New("webhook delivery is not configured")
+	}
+
+	for _, host := range s.opts.WebhookAllowedHosts {
+		if strings.EqualFold(u.Hostname(), host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("webhook host %s is not allowed", u.Hostname())
+}
+
+// tick runs the queries which are due at the given time.
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	for _, q := range s.store.List(nil) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if isDue(q, now) {
+			s.runScheduled(ctx, q, now)
+		}
+	}
+}
+
+// isDue returns whether a query is scheduled to run at the given time. Queries
+// run as soon as they are saved, then every interval after their last run.
+func isDue(q *vtadminpb.SavedQuery, now time.Time) bool {
+	if q.Schedule == nil {
+		return false
+	}
+
+	interval, ok, err := protoutil.DurationFromProto(q.Schedule.Interval)
+	if err != nil || !ok || interval < MinScheduleInterval {
+		return false
+	}
+
+	if q.LastRun == nil {
+		return true
+	}
+
+	return !now.Before(protoutil.TimeFromProto(q.LastRun.Time).Add(interval))
+}
+
+// runScheduled runs a query, delivers its report and records the run.
+func (s *Scheduler) runScheduled(ctx context.Context, q *vtadminpb.SavedQuery, now time.Time) {
+	start := time.Now()
+
+	report := &vtadminpb.SavedQueryReport{
+		SavedQuery: q,
+		Time:       protoutil.TimeToProto(now),
+	}
+	run := &vtadminpb.SavedQuery_Run{
+		Time: report.Time,
+	}
+
+	var errs []error
+
+	resp, err := s.run(ctx, q)
+	if err != nil {
+		report.Error = err.Error()
+		errs = append(errs, err)
+	} else {
+		report.Sql = resp.Sql
+		report.Result = resp.Result
+		run.Rows = uint32(len(resp.Result.GetRows()))
+	}
+
+	if err := s.deliver(ctx, report); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		run.Error = err.Error()
+		log.Warningf("scheduled query: id=%s cluster=%s name=%q owner=%q rows=%d duration=%v error=%q",
+			q.Id, q.ClusterId, q.Name, q.Owner, run.Rows, time.Since(start), run.Error)
+	} else {
+		log.Infof("scheduled query: id=%s cluster=%s name=%q owner=%q rows=%d duration=%v",
+			q.Id, q.ClusterId, q.Name, q.Owner, run.Rows, time.Since(start))
+	}
+
+	if err := s.store.RecordRun(q.Id, run); err != nil {
+		log.Errorf("failed to record run of saved query %s: %s", q.Id, err)
+	}
+}
+
+// deliver sends a report to the webhook and email recipients of its query.
+func (s *Scheduler) deliver(ctx context.Context, report *vtadminpb.SavedQueryReport) error {
+	var errs []error
+
+	schedule := report.SavedQuery.Schedule
+	if schedule.WebhookUrl != "" {
+		if err := s.postWebhook(ctx, schedule.WebhookUrl, report); err != nil {
+			errs = append(errs, fmt.Errorf("failed to deliver report to webhook: %w", err))
+		}
+	}
+
+	if len(schedule.EmailRecipients) > 0 {
+		if err := s.sendEmail(schedule.EmailRecipients, report); err != nil {
+			errs = append(errs, fmt.Errorf("failed to email report: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) postWebhook(ctx context.Context, webhookURL string, report *vtadminpb.SavedQueryReport) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return err
+	}
+
+	// The allowed hosts may have changed since the query was saved.
+	if err := s.checkWebhookURL(u); err != nil {
+		return err
+	}
+
+	data, err := protojson.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain (some of) the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *Scheduler) sendEmail(to []string, report *vtadminpb.SavedQueryReport) error {
+	if s.opts.SMTP.Addr == "" {
+		return errors.New("email delivery is not configured")
+	}
+
+	var auth smtp.Auth
+	if s.opts.SMTP.Username != "" {
+		host, _, err := net.SplitHostPort(s.opts.SMTP.Addr)
+		if err != nil {
+			return err
+		}
+
+		auth = smtp.PlainAuth("", s.opts.SMTP.Username, s.opts.SMTP.Password, host)
+	}
+
+	return s.sendMail(s.opts.SMTP.Addr, auth, s.opts.SMTP.From, to, formatEmail(s.opts.SMTP.From, to, report))
+}
+
+// cellReplacer replaces the characters of a value which would break the
+// tab-separated result of a report.
+var cellReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+// formatEmail returns a plain text email of a report, with its result as
+// tab-separated values.
+func formatEmail(from string, to []string, report *vtadminpb.SavedQueryReport) []byte {
+	q := report.SavedQuery
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	// The subject is encoded so that the name of the query cannot inject
+	// headers.
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "VTAdmin report: "+q.Name))
+	fmt.Fprintf(&b, "Date: %s\r\n", protoutil.TimeFromProto(report.Time).Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+
+	fmt.Fprintf(&b, "Query: %s\r\n", q.Name)
+	fmt.Fprintf(&b, "Cluster: %s\r\n", q.ClusterId)
+	fmt.Fprintf(&b, "Keyspace: %s\r\n", q.Keyspace)
+	fmt.Fprintf(&b, "Time: %s\r\n", protoutil.TimeFromProto(report.Time).UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "SQL: %s\r\n", report.Sql)
+	b.WriteString("\r\n")
+
+	if report.Error != "" {
+		fmt.Fprintf(&b, "Error: %s\r\n", report.Error)
+		return []byte(b.String())
+	}
+
+	b.WriteString(strings.Join(report.Result.GetColumns(), "\t"))
+	b.WriteString("\r\n")
+
+	for _, row := range report.Result.GetRows() {
+		values := make([]string, len(row.Values))
+		for i, v := range row.Values {
+			if i < len(row.Nulls) && row.Nulls[i] {
+				v = "NULL"
+			}
+
+			values[i] = cellReplacer.Replace(v)
+		}
+
+		b.WriteString(strings.Join(values, "\t"))
+		b.WriteString("\r\n")
+	}
+
+	if report.Result.GetTruncated() {
+		b.WriteString("\r\n(results truncated)\r\n")
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package savedqueries
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/protoutil"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	s := NewScheduler(nil, nil, Options{WebhookAllowedHosts: []string{"hooks.example.com"}})
+
+	valid := func() *vtadminpb.SavedQuery {
+		return &vtadminpb.SavedQuery{
+			Name:     "active users",
+			Keyspace: "commerce",
+			Sql:      "select * from users where status = :status",
+			Parameters: []*vtadminpb.SavedQuery_Parameter{
+				{Name: "status", DefaultValue: "active"},
+			},
+			Schedule: &vtadminpb.SavedQuery_Schedule{
+				Interval:   protoutil.DurationToProto(time.Hour),
+				WebhookUrl: "https://hooks.example.com/reports",
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(q *vtadminpb.SavedQuery)
+		shouldErr bool
+	}{
+		{
+			name:   "valid",
+			modify: func(q *vtadminpb.SavedQuery) {},
+		},
+		{
+			name:   "no schedule",
+			modify: func(q *vtadminpb.SavedQuery) { q.Schedule = nil },
+		},
+		{
+			name:      "no name",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Name = "" },
+			shouldErr: true,
+		},
+		{
+			name:      "not read-only",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Sql = "update users set status = :status" },
+			shouldErr: true,
+		},
+		{
+			name:      "interval too short",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Schedule.Interval = protoutil.DurationToProto(time.Second) },
+			shouldErr: true,
+		},
+		{
+			name:      "scheduled parameter without default value",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Parameters[0].DefaultValue = "" },
+			shouldErr: true,
+		},
+		{
+			name:      "no delivery",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Schedule.WebhookUrl = "" },
+			shouldErr: true,
+		},
+		{
+			name:      "webhook host not allowed",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Schedule.WebhookUrl = "https://evil.example.com/reports" },
+			shouldErr: true,
+		},
+		{
+			name:      "webhook scheme",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Schedule.WebhookUrl = "file://hooks.example.com/reports" },
+			shouldErr: true,
+		},
+		{
+			name:      "email without SMTP",
+			modify:    func(q *vtadminpb.SavedQuery) { q.Schedule.EmailRecipients = []string{"ops@example.com"} },
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := valid()
+			tt.modify(q)
+
+			err := s.Validate(q)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	// Webhooks are denied unless some hosts are allowed.
+	err := NewScheduler(nil, nil, Options{}).Validate(valid())
+	assert.ErrorContains(t, err, "webhook delivery is not configured")
+}
+
+func TestSchedulerTick(t *testing.T) {
+	t.Parallel()
+
+	var (
+		m       sync.Mutex
+		reports []*vtadminpb.SavedQueryReport
+		emails  []string
+	)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		report := &vtadminpb.SavedQueryReport{}
+		require.NoError(t, protojson.Unmarshal(body, report))
+
+		m.Lock()
+		defer m.Unlock()
+		reports = append(reports, report)
+	}))
+	defer webhook.Close()
+
+	store, err := NewStore("")
+	require.NoError(t, err)
+
+	run := func(ctx context.Context, q *vtadminpb.SavedQuery) (*vtadminpb.ExecuteQueryResponse, error) {
+		if q.Name == "broken" {
+			return nil, errors.New("no healthy tablets")
+		}
+
+		sql, err := Bind(q, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &vtadminpb.ExecuteQueryResponse{
+			Sql: sql,
+			Result: &vtadminpb.QueryResult{
+				Columns: []string{"id", "name"},
+				Rows: []*vtadminpb.QueryResult_Row{
+					{Values: []string{"1", "alice"}, Nulls: []bool{false, false}},
+					{Values: []string{"2", ""}, Nulls: []bool{false, true}},
+				},
+			},
+		}, nil
+	}
+
+	s := NewScheduler(store, run, Options{
+		WebhookAllowedHosts: []string{"127.0.0.1"},
+		SMTP:                SMTPOptions{Addr: "localhost:25", From: "vtadmin@example.com"},
+	})
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		m.Lock()
+		defer m.Unlock()
+		emails = append(emails, string(msg))
+		return nil
+	}
+
+	hourly := protoutil.DurationToProto(time.Hour)
+
+	users, err := store.Create(&vtadminpb.SavedQuery{
+		ClusterId:  "c1",
+		Name:       "users",
+		Keyspace:   "commerce",
+		Sql:        "select id, name from users where status = :status",
+		Parameters: []*vtadminpb.SavedQuery_Parameter{{Name: "status", DefaultValue: "active"}},
+		Schedule: &vtadminpb.SavedQuery_Schedule{
+			Interval:        hourly,
+			WebhookUrl:      webhook.URL,
+			EmailRecipients: []string{"ops@example.com"},
+		},
+	})
+	require.NoError(t, err)
+
+	broken, err := store.Create(&vtadminpb.SavedQuery{
+		ClusterId: "c1",
+		Name:      "broken",
+		Keyspace:  "commerce",
+		Sql:       "select 1",
+		Schedule:  &vtadminpb.SavedQuery_Schedule{Interval: hourly, WebhookUrl: webhook.URL},
+	})
+	require.NoError(t, err)
+
+	_, err = store.Create(&vtadminpb.SavedQuery{
+		ClusterId: "c1",
+		Name:      "on demand",
+		Keyspace:  "commerce",
+		Sql:       "select 1",
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	// Scheduled queries run as soon as they are saved.
+	s.tick(ctx, now)
+
+	require.Len(t, reports, 2)
+	assert.Equal(t, "broken", reports[0].SavedQuery.Name)
+	assert.Equal(t, "no healthy tablets", reports[0].Error)
+	assert.Equal(t, "users", reports[1].SavedQuery.Name)
+	assert.Equal(t, "select id, `name` from users where `status` = 'active'", reports[1].Sql)
+	assert.Len(t, reports[1].Result.Rows, 2)
+
+	require.Len(t, emails, 1)
+	assert.Contains(t, emails[0], "To: ops@example.com\r\n")
+	assert.Contains(t, emails[0], "Subject: VTAdmin report: users\r\n")
+	assert.Contains(t, emails[0], "id\tname\r\n1\talice\r\n2\tNULL\r\n")
+
+	q, ok := store.Get("c1", users.Id)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, q.LastRun.GetRows())
+	assert.Empty(t, q.LastRun.GetError())
+
+	q, ok = store.Get("c1", broken.Id)
+	require.True(t, ok)
+	assert.Equal(t, "no healthy tablets", q.LastRun.GetError())
+
+	// Nothing is due again until an interval has passed since the last run.
+	s.tick(ctx, now.Add(59*time.Minute))
+	assert.Len(t, reports, 2)
+
+	s.tick(ctx, now.Add(time.Hour))
+	assert.Len(t, reports, 4)
+	assert.Len(t, emails, 2)
+}
+
+func TestSchedulerWebhookFailure(t *testing.T) {
+	t.Parallel()
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer webhook.Close()
+
+	store, err := NewStore("")
+	require.NoError(t, err)
+
+	run := func(ctx context.Context, q *vtadminpb.SavedQuery) (*vtadminpb.ExecuteQueryResponse, error) {
+		return &vtadminpb.ExecuteQueryResponse{Sql: q.Sql, Result: &vtadminpb.QueryResult{}}, nil
+	}
+
+	q, err := store.Create(&vtadminpb.SavedQuery{
+		ClusterId: "c1",
+		Name:      "users",
+		Keyspace:  "commerce",
+		Sql:       "select 1",
+		Schedule: &vtadminpb.SavedQuery_Schedule{
+			Interval:   protoutil.DurationToProto(time.Hour),
+			WebhookUrl: webhook.URL,
+		},
+	})
+	require.NoError(t, err)
+
+	NewScheduler(store, run, Options{WebhookAllowedHosts: []string{"127.0.0.1"}}).tick(context.Background(), time.Now())
+
+	q, ok := store.Get("c1", q.Id)
+	require.True(t, ok)
+	assert.Contains(t, q.LastRun.GetError(), "500 Internal Server Error")
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package savedqueries stores the saved queries of VTAdmin, and runs those
+// with a schedule periodically, delivering their reports to a webhook or by
+// email.
+//
+// Saved queries run through the query console, and so are subject to the same
+// guardrails: only read-only queries can be saved, and their results are
+// bounded by the row limit and timeout of the cluster's query console.
+package savedqueries
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// maxLineSize is the size of the longest line read back from a saved queries
+// file.
+const maxLineSize = 1024 * 1024
+
+// Store holds saved queries in memory, and optionally in a file.
+type Store struct {
+	m       sync.Mutex
+	queries map[string]*vtadminpb.SavedQuery // keyed by ID
+	path    string
+}
+
+// NewStore returns a Store backed by the file at path, loading the queries it
+// already holds. If path is empty, queries are only held in memory and are
+// lost on restart.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		queries: map[string]*vtadminpb.SavedQuery{},
+		path:    path,
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	f, err := os.Open(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to open saved queries: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxLineSize)
+
+	for n := 1; scanner.Scan(); n++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		q := &vtadminpb.SavedQuery{}
+		if err := protojson.Unmarshal(scanner.Bytes(), q); err != nil {
+			return nil, fmt.Errorf("invalid saved query on line %d of %s: %w", n, path, err)
+		}
+
+		s.queries[q.Id] = q
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read saved queries %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// List returns the saved queries of the given clusters, sorted by cluster and
+// name. If no clusters are given, it returns the queries of every cluster.
+func (s *Store) List(clusterIDs []string) []*vtadminpb.SavedQuery {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	clusters := make(map[string]bool, len(clusterIDs))
+	for _, id := range clusterIDs {
+		clusters[id] = true
+	}
+
+	queries := make([]*vtadminpb.SavedQuery, 0, len(s.queries))
+	for _, q := range s.queries {
+		if len(clusters) > 0 && !clusters[q.ClusterId] {
+			continue
+		}
+
+		queries = append(queries, q.CloneVT())
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].ClusterId != queries[j].ClusterId {
+			return queries[i].ClusterId < queries[j].ClusterId
+		}
+
+		if queries[i].Name != queries[j].Name {
+			return queries[i].Name < queries[j].Name
+		}
+
+		return queries[i].Id < queries[j].Id
+	})
+
+	return queries
+}
+
+// Get returns the saved query with the given ID in the given cluster.
+func (s *Store) Get(clusterID string, id string) (*vtadminpb.SavedQuery, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	q, ok := s.queries[id]
+	if !ok || q.ClusterId != clusterID {
+		return nil, false
+	}
+
+	return q.CloneVT(), true
+}
+
+// Create assigns the query a new ID and adds it to the store. It returns the
+// query as stored.
+func (s *Store) Create(q *vtadminpb.SavedQuery) (*vtadminpb.SavedQuery, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	q = q.CloneVT()
+	q.Id = uuid.NewString()
+
+	s.queries[q.Id] = q
+	if err := s.save(); err != nil {
+		delete(s.queries, q.Id)
+		return nil, err
+	}
+
+	return q.CloneVT(), nil
+}
+
+// Delete removes the saved query with the given ID from the given cluster. It
+// returns false if there is no such query.
+func (s *Store) Delete(clusterID string, id string) (bool, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	q, ok := s.queries[id]
+	if !ok || q.ClusterId != clusterID {
+		return false, nil
+	}
+
+	delete(s.queries, id)
+	if err := s.save(); err != nil {
+		s.queries[id] = q
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RecordRun sets the last run of a saved query. It does nothing if the query
+// has been deleted since it ran.
+func (s *Store) RecordRun(id string, run *vtadminpb.SavedQuery_Run) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	q, ok := s.queries[id]
+	if !ok {
+		return nil
+	}
+
+	q.LastRun = run
+	return s.save()
+}
+
+// save rewrites the file of the store, if any, with its current queries. The
+// file is replaced atomically, so that a crash never leaves it incomplete.
+// Callers must hold s.m.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, q := range s.queries {
+		data, err := protojson.Marshal(q)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write saved queries: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write saved queries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package savedqueries
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func queryNames(queries []*vtadminpb.SavedQuery) []string {
+	names := make([]string, len(queries))
+	for i, q := range queries {
+		names[i] = q.ClusterId + "/" + q.Name
+	}
+
+	return names
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewStore("")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, q := range []*vtadminpb.SavedQuery{
+		{ClusterId: "c2", Name: "orders"},
+		{ClusterId: "c1", Name: "users"},
+		{ClusterId: "c1", Name: "accounts"},
+	} {
+		created, err := s.Create(q)
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.Id)
+		assert.Empty(t, q.Id, "Create should not modify its argument")
+
+		ids = append(ids, created.Id)
+	}
+
+	assert.Equal(t, []string{"c1/accounts", "c1/users", "c2/orders"}, queryNames(s.List(nil)))
+	assert.Equal(t, []string{"c1/accounts", "c1/users"}, queryNames(s.List([]string{"c1"})))
+	assert.Empty(t, s.List([]string{"c3"}))
+
+	q, ok := s.Get("c2", ids[0])
+	require.True(t, ok)
+	assert.Equal(t, "orders", q.Name)
+
+	_, ok = s.Get("c1", ids[0])
+	assert.False(t, ok, "queries should only be found in their own cluster")
+
+	deleted, err := s.Delete("c1", ids[0])
+	require.NoError(t, err)
+	assert.False(t, deleted, "queries should only be deleted from their own cluster")
+
+	deleted, err = s.Delete("c2", ids[0])
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	assert.Equal(t, []string{"c1/accounts", "c1/users"}, queryNames(s.List(nil)))
+}
+
+func TestStoreFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "saved_queries.json")
+
+	s, err := NewStore(path)
+	require.NoError(t, err)
+
+	q1, err := s.Create(&vtadminpb.SavedQuery{ClusterId: "c1", Name: "users", Sql: "select * from users"})
+	require.NoError(t, err)
+
+	q2, err := s.Create(&vtadminpb.SavedQuery{ClusterId: "c1", Name: "orders", Sql: "select * from orders"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.RecordRun(q1.Id, &vtadminpb.SavedQuery_Run{Rows: 3}))
+
+	_, err = s.Delete("c1", q2.Id)
+	require.NoError(t, err)
+
+	// Queries, and their last run, survive reopening the store.
+	s, err = NewStore(path)
+	require.NoError(t, err)
+
+	queries := s.List(nil)
+	require.Len(t, queries, 1)
+	assert.Equal(t, q1.Id, queries[0].Id)
+	assert.Equal(t, "select * from users", queries[0].Sql)
+	assert.EqualValues(t, 3, queries[0].LastRun.GetRows())
+
+	// Runs of deleted queries are ignored.
+	require.NoError(t, s.RecordRun(q2.Id, &vtadminpb.SavedQuery_Run{Rows: 1}))
+	assert.Len(t, s.List(nil), 1)
+}
@@ -41,6 +41,9 @@ type ClusterConfig struct {
 	Name                    string                    `json:"name"`
 	FakeVtctldClientResults []*FakeVtctldClientResult `json:"vtctldclient_mock_data"`
 	DBTablets               []*vtadminpb.Tablet       `json:"db_tablet_list"`
+	// DBQueryResults maps a target and a query to the Go source of a
+	// fakevtsql.QueryResult literal, without the surrounding braces.
+	DBQueryResults map[string]map[string]string `json:"db_query_results"`
 }
 
 type Test struct {
@@ -51,6 +51,7 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
 	"vitess.io/vitess/go/vt/vtadmin/testutil"
 	"vitess.io/vitess/go/vt/vtadmin/vtctldclient/fakevtctldclient"
+	"vitess.io/vitess/go/vt/vtadmin/vtsql/fakevtsql"
 
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
@@ -156,12 +157,30 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 							Uid: {{ .Tablet.Alias.Uid }},
 						},
 						Keyspace: "{{ .Tablet.Keyspace }}",
+						{{- with .Tablet.Shard }}
+						Shard: "{{ . }}",
+						{{- end }}
 						Type: topodatapb.TabletType_{{ .Tablet.Type }},
 					},
 					State: vtadminpb.Tablet_{{ .State }},
 				},
 				{{- end }}
 			},
+			{{- with .DBQueryResults }}
+			DBConfig: testutil.Dbcfg{
+				QueryResults: map[string]map[string]*fakevtsql.QueryResult{
+					{{- range $target, $results := . }}
+					"{{ $target }}": {
+						{{- range $query, $value := $results }}
+						{{ printf "%q" $query }}: {
+							{{ $value }}
+						},
+						{{- end }}
+					},
+					{{- end }}
+				},
+			},
+			{{- end }}
 			Config: &cluster.Config{
 				TopoReadPoolConfig: &cluster.RPCPoolConfig{
 					Size: 100,
@@ -50,6 +50,9 @@ import (
 // at the package sql level.
 type Dbcfg struct {
 	ShouldErr bool
+	// QueryResults are the results of the queries run through the DB; see
+	// fakevtsql.Connector.
+	QueryResults map[string]map[string]*fakevtsql.QueryResult
 }
 
 // TestClusterConfig controls the way that a cluster.Cluster object is
@@ -122,7 +125,7 @@ func BuildCluster(t testing.TB, cfg TestClusterConfig) *cluster.Cluster {
 	clusterConf = clusterConf.WithVtctldTestConfigOptions(vtadminvtctldclient.WithDialFunc(func(addr string, ff grpcclient.FailFast, opts ...grpc.DialOption) (vtctldclient.VtctldClient, error) {
 		return cfg.VtctldClient, nil
 	})).WithVtSQLTestConfigOptions(vtsql.WithDialFunc(func(c vitessdriver.Configuration) (*sql.DB, error) {
-		return sql.OpenDB(&fakevtsql.Connector{Tablets: tablets, ShouldErr: cfg.DBConfig.ShouldErr}), nil
+		return sql.OpenDB(&fakevtsql.Connector{Tablets: tablets, QueryResults: cfg.DBConfig.QueryResults, ShouldErr: cfg.DBConfig.ShouldErr}), nil
 	}))
 
 	m.Lock()
@@ -61,6 +61,19 @@ func AssertSchemaSlicesEqual(t *testing.T, expected []*vtadminpb.Schema, actual
 	utils.MustMatch(t, expected, actual)
 }
 
+// AssertSchemaMigrationSlicesEqual is a convenience function to assert that
+// two []*vtadminpb.SchemaMigration slices are equal, irrespective of order.
+func AssertSchemaMigrationSlicesEqual(t *testing.T, expected []*vtadminpb.SchemaMigration, actual []*vtadminpb.SchemaMigration) {
+	t.Helper()
+	sort.Slice(expected, func(i, j int) bool {
+		return fmt.Sprintf("%v", expected[i]) < fmt.Sprintf("%v", expected[j])
+	})
+	sort.Slice(actual, func(i, j int) bool {
+		return fmt.Sprintf("%v", actual[i]) < fmt.Sprintf("%v", actual[j])
+	})
+	utils.MustMatch(t, expected, actual)
+}
+
 // AssertSrvVSchemaSlicesEqual is a convenience function to assert that two
 // []*vtadminpb.SrvVSchema slices are equal
 func AssertSrvVSchemaSlicesEqual(t *testing.T, expected []*vtadminpb.SrvVSchema, actual []*vtadminpb.SrvVSchema) {
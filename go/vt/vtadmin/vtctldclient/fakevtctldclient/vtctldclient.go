@@ -38,6 +38,36 @@ import (
 type VtctldClient struct {
 	vtctldclient.VtctldClient
 
+	// Keyed by keyspace.
+	ApplySchemaResults map[string]struct {
+		Response *vtctldatapb.ApplySchemaResponse
+		Error    error
+	}
+	// Keyed by <keyspace>/<uuid>.
+	CancelSchemaMigrationResults map[string]struct {
+		Response *vtctldatapb.CancelSchemaMigrationResponse
+		Error    error
+	}
+	// Keyed by tablet alias.
+	ChangeTabletTagsResults map[string]struct {
+		Response *vtctldatapb.ChangeTabletTagsResponse
+		Error    error
+	}
+	// Keyed by tablet alias.
+	ChangeTabletTypeResults map[string]struct {
+		Response *vtctldatapb.ChangeTabletTypeResponse
+		Error    error
+	}
+	// Keyed by <keyspace>/<uuid>.
+	CleanupSchemaMigrationResults map[string]struct {
+		Response *vtctldatapb.CleanupSchemaMigrationResponse
+		Error    error
+	}
+	// Keyed by <keyspace>/<uuid>.
+	CompleteSchemaMigrationResults map[string]struct {
+		Response *vtctldatapb.CompleteSchemaMigrationResponse
+		Error    error
+	}
 	CreateKeyspaceShouldErr bool
 	CreateShardShouldErr    bool
 	DeleteKeyspaceShouldErr bool
@@ -81,6 +111,11 @@ type VtctldClient struct {
 		Response *vtctldatapb.GetSchemaResponse
 		Error    error
 	}
+	// Keyed by keyspace.
+	GetSchemaMigrationsResults map[string]struct {
+		Response *vtctldatapb.GetSchemaMigrationsResponse
+		Error    error
+	}
 	GetSrvVSchemaResults map[string]struct {
 		Response *vtctldatapb.GetSrvVSchemaResponse
 		Error    error
@@ -93,6 +128,21 @@ type VtctldClient struct {
 		Response *vtctldatapb.GetWorkflowsResponse
 		Error    error
 	}
+	// Keyed by <keyspace>/<uuid>.
+	LaunchSchemaMigrationResults map[string]struct {
+		Response *vtctldatapb.LaunchSchemaMigrationResponse
+		Error    error
+	}
+	// Keyed by <target_keyspace>/<workflow>.
+	MoveTablesCompleteResults map[string]struct {
+		Response *vtctldatapb.MoveTablesCompleteResponse
+		Error    error
+	}
+	// Keyed by target keyspace.
+	MoveTablesCreateResults map[string]struct {
+		Response *vtctldatapb.WorkflowStatusResponse
+		Error    error
+	}
 	PingTabletResults           map[string]error
 	PlannedReparentShardResults map[string]struct {
 		Response *vtctldatapb.PlannedReparentShardResponse
@@ -115,6 +165,21 @@ type VtctldClient struct {
 		Response *vtctldatapb.ReparentTabletResponse
 		Error    error
 	}
+	// Keyed by keyspace.
+	ReshardCreateResults map[string]struct {
+		Response *vtctldatapb.WorkflowStatusResponse
+		Error    error
+	}
+	// Keyed by keyspace.
+	ReshardRecommendResults map[string]struct {
+		Response *vtctldatapb.ReshardRecommendResponse
+		Error    error
+	}
+	// Keyed by <keyspace>/<uuid>.
+	RetrySchemaMigrationResults map[string]struct {
+		Response *vtctldatapb.RetrySchemaMigrationResponse
+		Error    error
+	}
 	RunHealthCheckResults            map[string]error
 	SetWritableResults               map[string]error
 	ShardReplicationPositionsResults map[string]struct {
@@ -139,6 +204,11 @@ type VtctldClient struct {
 		Response *vtctldatapb.ValidateVersionKeyspaceResponse
 		Error    error
 	}
+	// Keyed by <keyspace>/<workflow>.
+	WorkflowSwitchTrafficResults map[string]struct {
+		Response *vtctldatapb.WorkflowSwitchTrafficResponse
+		Error    error
+	}
 	WorkflowUpdateResults map[string]struct {
 		Response *vtctldatapb.WorkflowUpdateResponse
 		Error    error
@@ -152,6 +222,89 @@ var _ vtctldclient.VtctldClient = (*VtctldClient)(nil)
 // Close is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) Close() error { return nil }
 
+// ApplySchema is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplySchemaResponse, error) {
+	if fake.ApplySchemaResults == nil {
+		return nil, fmt.Errorf("%w: ApplySchemaResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	if result, ok := fake.ApplySchemaResults[req.Keyspace]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.Keyspace)
+}
+
+// CancelSchemaMigration is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) CancelSchemaMigration(ctx context.Context, req *vtctldatapb.CancelSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
+	if fake.CancelSchemaMigrationResults == nil {
+		return nil, fmt.Errorf("%w: CancelSchemaMigrationResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace + "/" + req.Uuid
+	if result, ok := fake.CancelSchemaMigrationResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// ChangeTabletTags is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) ChangeTabletTags(ctx context.Context, req *vtctldatapb.ChangeTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTagsResponse, error) {
+	if fake.ChangeTabletTagsResults == nil {
+		return nil, fmt.Errorf("%w: ChangeTabletTagsResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(req.TabletAlias)
+	if result, ok := fake.ChangeTabletTagsResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// ChangeTabletType is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) ChangeTabletType(ctx context.Context, req *vtctldatapb.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTypeResponse, error) {
+	if fake.ChangeTabletTypeResults == nil {
+		return nil, fmt.Errorf("%w: ChangeTabletTypeResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(req.TabletAlias)
+	if result, ok := fake.ChangeTabletTypeResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// CleanupSchemaMigration is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) CleanupSchemaMigration(ctx context.Context, req *vtctldatapb.CleanupSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CleanupSchemaMigrationResponse, error) {
+	if fake.CleanupSchemaMigrationResults == nil {
+		return nil, fmt.Errorf("%w: CleanupSchemaMigrationResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace + "/" + req.Uuid
+	if result, ok := fake.CleanupSchemaMigrationResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// CompleteSchemaMigration is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) CompleteSchemaMigration(ctx context.Context, req *vtctldatapb.CompleteSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
+	if fake.CompleteSchemaMigrationResults == nil {
+		return nil, fmt.Errorf("%w: CompleteSchemaMigrationResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace + "/" + req.Uuid
+	if result, ok := fake.CompleteSchemaMigrationResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
 // CreateKeyspace is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) CreateKeyspace(ctx context.Context, req *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	if fake.CreateKeyspaceShouldErr {
@@ -365,6 +518,19 @@ func (fake *VtctldClient) GetSchema(ctx context.Context, req *vtctldatapb.GetSch
 	return nil, fmt.Errorf("%w: no result set for tablet alias %s", assert.AnError, key)
 }
 
+// GetSchemaMigrations is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) GetSchemaMigrations(ctx context.Context, req *vtctldatapb.GetSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaMigrationsResponse, error) {
+	if fake.GetSchemaMigrationsResults == nil {
+		return nil, fmt.Errorf("%w: GetSchemaMigrationsResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	if result, ok := fake.GetSchemaMigrationsResults[req.Keyspace]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.Keyspace)
+}
+
 // GetSrvVSchema is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) GetSrvVSchema(ctx context.Context, req *vtctldatapb.GetSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvVSchemaResponse, error) {
 	if fake.GetSrvVSchemaResults == nil {
@@ -429,6 +595,47 @@ func (fake *VtctldClient) GetWorkflows(ctx context.Context, req *vtctldatapb.Get
 	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.Keyspace)
 }
 
+// LaunchSchemaMigration is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) LaunchSchemaMigration(ctx context.Context, req *vtctldatapb.LaunchSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.LaunchSchemaMigrationResponse, error) {
+	if fake.LaunchSchemaMigrationResults == nil {
+		return nil, fmt.Errorf("%w: LaunchSchemaMigrationResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace + "/" + req.Uuid
+	if result, ok := fake.LaunchSchemaMigrationResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// MoveTablesComplete is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) MoveTablesComplete(ctx context.Context, req *vtctldatapb.MoveTablesCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.MoveTablesCompleteResponse, error) {
+	if fake.MoveTablesCompleteResults == nil {
+		return nil, fmt.Errorf("%w: MoveTablesCompleteResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.TargetKeyspace + "/" + req.Workflow
+	if result, ok := fake.MoveTablesCompleteResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// MoveTablesCreate is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) MoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	if fake.MoveTablesCreateResults == nil {
+		return nil, fmt.Errorf("%w: MoveTablesCreateResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	if result, ok := fake.MoveTablesCreateResults[req.TargetKeyspace]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.TargetKeyspace)
+}
+
 // PingTablet is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) PingTablet(ctx context.Context, req *vtctldatapb.PingTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.PingTabletResponse, error) {
 	if fake.PingTabletResults == nil {
@@ -534,6 +741,46 @@ func (fake *VtctldClient) ReparentTablet(ctx context.Context, req *vtctldatapb.R
 	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
 }
 
+// ReshardCreate is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) ReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	if fake.ReshardCreateResults == nil {
+		return nil, fmt.Errorf("%w: ReshardCreateResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	if result, ok := fake.ReshardCreateResults[req.Keyspace]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.Keyspace)
+}
+
+// ReshardRecommend is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) ReshardRecommend(ctx context.Context, req *vtctldatapb.ReshardRecommendRequest, opts ...grpc.CallOption) (*vtctldatapb.ReshardRecommendResponse, error) {
+	if fake.ReshardRecommendResults == nil {
+		return nil, fmt.Errorf("%w: ReshardRecommendResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	if result, ok := fake.ReshardRecommendResults[req.Keyspace]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.Keyspace)
+}
+
+// RetrySchemaMigration is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) RetrySchemaMigration(ctx context.Context, req *vtctldatapb.RetrySchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
+	if fake.RetrySchemaMigrationResults == nil {
+		return nil, fmt.Errorf("%w: RetrySchemaMigrationResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace + "/" + req.Uuid
+	if result, ok := fake.RetrySchemaMigrationResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
 // RunHealthCheck is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) RunHealthCheck(ctx context.Context, req *vtctldatapb.RunHealthCheckRequest, opts ...grpc.CallOption) (*vtctldatapb.RunHealthCheckResponse, error) {
 	if fake.RunHealthCheckResults == nil {
@@ -676,6 +923,20 @@ func (fake *VtctldClient) ValidateVersionKeyspace(ctx context.Context, req *vtct
 	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
 }
 
+// WorkflowSwitchTraffic is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
+	if fake.WorkflowSwitchTrafficResults == nil {
+		return nil, fmt.Errorf("%w: WorkflowSwitchTrafficResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace + "/" + req.Workflow
+	if result, ok := fake.WorkflowSwitchTrafficResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
 // WorkflowUpdate is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) WorkflowUpdate(ctx context.Context, req *vtctldatapb.WorkflowUpdateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowUpdateResponse, error) {
 	if fake.WorkflowUpdateResults == nil {
@@ -43,11 +43,16 @@ var (
 
 type conn struct {
 	tablets   []*vtadminpb.Tablet
+	results   map[string]map[string]*QueryResult
 	shouldErr bool
+
+	// target is the target set by the last USE statement.
+	target string
 }
 
 var (
 	_ driver.Conn           = (*conn)(nil)
+	_ driver.ExecerContext  = (*conn)(nil)
 	_ driver.QueryerContext = (*conn)(nil)
 )
 
@@ -63,6 +68,23 @@ func (c *conn) Prepare(query string) (driver.Stmt, error) {
 	return nil, nil
 }
 
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.shouldErr {
+		return nil, assert.AnError
+	}
+
+	if c == nil {
+		return nil, ErrConnClosed
+	}
+
+	if target, ok := strings.CutPrefix(strings.ToLower(query), "use"); ok {
+		c.target = strings.Trim(strings.TrimSpace(target), "`")
+		return driver.ResultNoRows, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q %v", ErrUnrecognizedQuery, query, args)
+}
+
 func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
 	if c.shouldErr {
 		return nil, assert.AnError
@@ -98,5 +120,18 @@ func (c *conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 		}, nil
 	}
 
+	if result, ok := c.results[c.target][query]; ok {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		return &rows{
+			cols:   result.Columns,
+			vals:   result.Rows,
+			pos:    0,
+			closed: false,
+		}, nil
+	}
+
 	return nil, fmt.Errorf("%w: %q %v", ErrUnrecognizedQuery, query, args)
 }
@@ -25,19 +25,23 @@ import (
 
 type fakedriver struct {
 	tablets   []*vtadminpb.Tablet
+	results   map[string]map[string]*QueryResult
 	shouldErr bool
 }
 
 var _ driver.Driver = (*fakedriver)(nil)
 
 func (d *fakedriver) Open(name string) (driver.Conn, error) {
-	return &conn{tablets: d.tablets, shouldErr: d.shouldErr}, nil
+	return &conn{tablets: d.tablets, results: d.results, shouldErr: d.shouldErr}, nil
 }
 
 // Connector implements the driver.Connector interface, providing a sql-like
 // thing that can respond to vtadmin vtsql queries with mocked data.
 type Connector struct {
 	Tablets []*vtadminpb.Tablet
+	// QueryResults maps targets, as set by USE statements, to the results of
+	// the queries run against them.
+	QueryResults map[string]map[string]*QueryResult
 	// (TODO:@amason) - allow distinction between Query errors and errors on
 	// Rows operations (e.g. Next, Err, Scan).
 	ShouldErr bool
@@ -47,10 +51,17 @@ var _ driver.Connector = (*Connector)(nil)
 
 // Connect is part of the driver.Connector interface.
 func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
-	return &conn{tablets: c.Tablets, shouldErr: c.ShouldErr}, nil
+	return &conn{tablets: c.Tablets, results: c.QueryResults, shouldErr: c.ShouldErr}, nil
+}
+
+// QueryResult is the mocked result of a query.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]any
+	Error   error
 }
 
 // Driver is part of the driver.Connector interface.
 func (c *Connector) Driver() driver.Driver {
-	return &fakedriver{tablets: c.Tablets, shouldErr: c.ShouldErr}
+	return &fakedriver{tablets: c.Tablets, results: c.QueryResults, shouldErr: c.ShouldErr}
 }
@@ -19,6 +19,7 @@ package vtsql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"sync"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vitessdriver"
 	"vitess.io/vitess/go/vt/vtadmin/cluster/resolver"
 	"vitess.io/vitess/go/vt/vtadmin/debug"
@@ -44,6 +46,10 @@ import (
 type DB interface {
 	// ShowTablets executes `SHOW vitess_tablets` and returns the result.
 	ShowTablets(ctx context.Context) (*sql.Rows, error)
+	// Query executes a query against a target, e.g. "commerce@replica", and
+	// returns at most maxRows rows of its result. Callers are responsible for
+	// checking that the query is safe to run.
+	Query(ctx context.Context, target string, query string, maxRows int) (*vtadminpb.QueryResult, error)
 
 	// Ping behaves like (*sql.DB).Ping.
 	Ping() error
@@ -174,6 +180,90 @@ func (vtgate *VTGateProxy) ShowTablets(ctx context.Context) (*sql.Rows, error) {
 	return vtgate.conn.QueryContext(vtgate.getQueryContext(ctx), "SHOW vitess_tablets")
 }
 
+// Query is part of the DB interface.
+//
+// The query runs on a dedicated connection, whose target is set with a USE
+// statement, and reset once the query is done.
+func (vtgate *VTGateProxy) Query(ctx context.Context, target string, query string, maxRows int) (*vtadminpb.QueryResult, error) {
+	span, ctx := trace.NewSpan(ctx, "VTGateProxy.Query")
+	defer span.Finish()
+
+	vtadminproto.AnnotateClusterSpan(vtgate.cluster, span)
+	span.Annotate("target", target)
+	span.Annotate("max_rows", maxRows)
+
+	ctx = vtgate.getQueryContext(ctx)
+
+	conn, err := vtgate.conn.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		// The target is part of the connection's session, so the connection
+		// goes back to the pool only if it is reset to the default target.
+		if _, err := conn.ExecContext(vtgate.getQueryContext(context.Background()), "use"); err != nil {
+			_ = conn.Raw(func(driverConn any) error { return driver.ErrBadConn })
+		}
+
+		conn.Close()
+	}()
+
+	if _, err := conn.ExecContext(ctx, "use "+sqlparser.String(sqlparser.NewIdentifierCS(target))); err != nil {
+		return nil, fmt.Errorf("error setting target %s: %w", target, err)
+	}
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &vtadminpb.QueryResult{
+		Columns: columns,
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if len(result.Rows) >= maxRows {
+			result.Truncated = true
+			break
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := &vtadminpb.QueryResult_Row{
+			Values: make([]string, len(values)),
+			Nulls:  make([]bool, len(values)),
+		}
+
+		for i, val := range values {
+			row.Values[i] = val.String
+			row.Nulls[i] = !val.Valid
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // Ping is part of the DB interface.
 func (vtgate *VTGateProxy) Ping() error {
 	return vtgate.pingContext(context.Background())
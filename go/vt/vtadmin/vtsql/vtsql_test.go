@@ -18,15 +18,19 @@ package vtsql
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/vtadmin/vtsql/fakevtsql"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -80,3 +84,99 @@ func Test_getQueryContext(t *testing.T) {
 	assertEffectiveCaller(t, callerid.EffectiveCallerIDFromContext(outctx), "efuser", "vtadmin", "")
 	assertImmediateCaller(t, callerid.ImmediateCallerIDFromContext(outctx), "imuser")
 }
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	results := map[string]map[string]*fakevtsql.QueryResult{
+		"commerce@replica": {
+			"select id, name from customer": {
+				Columns: []string{"id", "name"},
+				Rows: [][]any{
+					{int64(1), "alice"},
+					{int64(2), nil},
+					{int64(3), []byte("carol")},
+				},
+			},
+			"select broken from customer": {
+				Error: assert.AnError,
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		target    string
+		query     string
+		maxRows   int
+		expected  *vtadminpb.QueryResult
+		shouldErr bool
+	}{
+		{
+			name:    "all rows",
+			target:  "commerce@replica",
+			query:   "select id, name from customer",
+			maxRows: 3,
+			expected: &vtadminpb.QueryResult{
+				Columns: []string{"id", "name"},
+				Rows: []*vtadminpb.QueryResult_Row{
+					{Values: []string{"1", "alice"}, Nulls: []bool{false, false}},
+					{Values: []string{"2", ""}, Nulls: []bool{false, true}},
+					{Values: []string{"3", "carol"}, Nulls: []bool{false, false}},
+				},
+			},
+		},
+		{
+			name:    "truncated",
+			target:  "commerce@replica",
+			query:   "select id, name from customer",
+			maxRows: 2,
+			expected: &vtadminpb.QueryResult{
+				Columns: []string{"id", "name"},
+				Rows: []*vtadminpb.QueryResult_Row{
+					{Values: []string{"1", "alice"}, Nulls: []bool{false, false}},
+					{Values: []string{"2", ""}, Nulls: []bool{false, true}},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name:      "wrong target",
+			target:    "commerce@primary",
+			query:     "select id, name from customer",
+			maxRows:   3,
+			shouldErr: true,
+		},
+		{
+			name:      "query error",
+			target:    "commerce@replica",
+			query:     "select broken from customer",
+			maxRows:   3,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &VTGateProxy{
+				cluster: &vtadminpb.Cluster{Id: "c1", Name: "one"},
+				conn:    sql.OpenDB(&fakevtsql.Connector{QueryResults: results}),
+			}
+			defer db.Close()
+
+			result, err := db.Query(ctx, tt.target, tt.query, tt.maxRows)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+// Checkpointer stores the position of the connector.
+type Checkpointer interface {
+	// Load returns the last saved VGTID, or nil if there is none.
+	Load() (*binlogdatapb.VGtid, error)
+	// Save stores the VGTID up to which all the changes have been published.
+	Save(vgtid *binlogdatapb.VGtid) error
+}
+
+// FileCheckpointer saves the VGTID as JSON in a local file. The file is
+// replaced atomically, so a crash never leaves a partially written checkpoint.
+type FileCheckpointer struct {
+	Path string
+}
+
+// Load is part of the Checkpointer interface.
+func (c *FileCheckpointer) Load() (*binlogdatapb.VGtid, error) {
+	data, err := os.ReadFile(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	if err := protojson.Unmarshal(data, vgtid); err != nil {
+		return nil, fmt.Errorf("cannot parse checkpoint file %s: %w", c.Path, err)
+	}
+	return vgtid, nil
+}
+
+// Save is part of the Checkpointer interface.
+func (c *FileCheckpointer) Save(vgtid *binlogdatapb.VGtid) error {
+	data, err := protojson.Marshal(vgtid)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.Path), filepath.Base(c.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+func TestFileCheckpointer(t *testing.T) {
+	dir := t.TempDir()
+	c := &FileCheckpointer{Path: filepath.Join(dir, "checkpoint.json")}
+
+	vgtid, err := c.Load()
+	require.NoError(t, err)
+	assert.Nil(t, vgtid)
+
+	want := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "ks", Shard: "-80", Gtid: "MySQL56/a:1-10"},
+		{Keyspace: "ks", Shard: "80-", Gtid: "MySQL56/b:1-5"},
+	}}
+	require.NoError(t, c.Save(want))
+	want.ShardGtids[0].Gtid = "MySQL56/a:1-11"
+	require.NoError(t, c.Save(want))
+
+	vgtid, err = c.Load()
+	require.NoError(t, err)
+	utils.MustMatch(t, want, vgtid)
+
+	// Only the checkpoint is left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, os.WriteFile(c.Path, []byte("{"), 0600))
+	_, err = c.Load()
+	assert.ErrorContains(t, err, "cannot parse checkpoint file")
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vtcdc implements a change data capture connector that consumes a
+// VStream from vtgate and publishes the row changes to a message broker,
+// checkpointing the VGTID of the published changes so that it can resume
+// where it left off.
+package vtcdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	eventsPublished = stats.NewCountersWithSingleLabel("VTCDCEventsPublished", "Row change events published, by table", "Table")
+	streamErrors    = stats.NewCounter("VTCDCStreamErrors", "Number of times the VStream or the sink failed and the connector restarted from its checkpoint")
+)
+
+// VStreamer is the part of vtgateconn.VTGateConn used by the connector.
+type VStreamer interface {
+	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+		filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error)
+}
+
+// Config is the configuration of a Connector.
+type Config struct {
+	Keyspace string
+	// Shard restricts the stream to a single shard. All shards are streamed if empty.
+	Shard      string
+	TabletType topodatapb.TabletType
+	// Tables are the tables to stream. An entry is either a table name, a
+	// regular expression starting with "/", or a select query on a single
+	// table. Selecting keyspace_id() in a query makes it the partitioning key
+	// of the messages. All the tables are streamed if empty.
+	Tables []string
+	// Position is the GTID to start from when there is no checkpoint: "current"
+	// to only stream new changes, or empty to copy the tables first.
+	Position string
+	// TopicPrefix is prepended to "<keyspace>.<table>" to build the topic of a table.
+	TopicPrefix string
+	Flags       *vtgatepb.VStreamFlags
+	// RetryDelay is how long to wait before restarting a failed stream.
+	RetryDelay time.Duration
+}
+
+// Connector publishes the row changes of a VStream to a Sink.
+type Connector struct {
+	cfg          Config
+	streamer     VStreamer
+	sink         Sink
+	serializer   Serializer
+	checkpointer Checkpointer
+
+	// fields are the fields of each table, by qualified table name, as of the last field event.
+	fields map[string][]*querypb.Field
+}
+
+// NewConnector returns a Connector.
+func NewConnector(cfg Config, streamer VStreamer, sink Sink, serializer Serializer, checkpointer Checkpointer) *Connector {
+	if cfg.RetryDelay == 0 {
+		cfg.RetryDelay = 5 * time.Second
+	}
+	return &Connector{
+		cfg:          cfg,
+		streamer:     streamer,
+		sink:         sink,
+		serializer:   serializer,
+		checkpointer: checkpointer,
+	}
+}
+
+// Topic returns the topic the changes of a table are published to.
+func (c *Connector) Topic(keyspace, table string) string {
+	return c.cfg.TopicPrefix + keyspace + "." + table
+}
+
+// Run streams and publishes changes until ctx is done or the stream ends.
+// Errors of the stream or the sink are retried, resuming from the last
+// checkpoint, so every change is published at least once.
+func (c *Connector) Run(ctx context.Context) error {
+	filter, err := buildFilter(c.cfg.Tables)
+	if err != nil {
+		return err
+	}
+	for {
+		vgtid, err := c.checkpointer.Load()
+		if err != nil {
+			return fmt.Errorf("cannot load checkpoint: %w", err)
+		}
+		if vgtid == nil {
+			vgtid = &binlogdatapb.VGtid{
+				ShardGtids: []*binlogdatapb.ShardGtid{{
+					Keyspace: c.cfg.Keyspace,
+					Shard:    c.cfg.Shard,
+					Gtid:     c.cfg.Position,
+				}},
+			}
+		}
+		err = c.stream(ctx, vgtid, filter)
+		switch {
+		case err == nil, ctx.Err() != nil:
+			return nil
+		case errors.Is(err, errPermanent):
+			return err
+		}
+		streamErrors.Add(1)
+		log.Warningf("VStream failed, restarting from the last checkpoint in %v: %v", c.cfg.RetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.cfg.RetryDelay):
+		}
+	}
+}
+
+var errPermanent = errors.New("permanent error")
+
+// stream runs a single VStream. Row changes are buffered until the end of
+// their transaction, which vtgate may split over several responses, then
+// published, and the last VGTID received is checkpointed. A VGTID received
+// outside of a transaction is checkpointed right away.
+func (c *Connector) stream(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reader, err := c.streamer.VStream(ctx, c.cfg.TabletType, vgtid, filter, c.cfg.Flags)
+	if err != nil {
+		return err
+	}
+	c.fields = make(map[string][]*querypb.Field)
+	var (
+		msgs          []*Message
+		tables        []string
+		pending       *binlogdatapb.VGtid
+		inTransaction bool
+	)
+	// commit publishes the buffered changes and checkpoints the pending VGTID.
+	commit := func() error {
+		if len(msgs) > 0 {
+			if err := c.sink.Send(ctx, msgs); err != nil {
+				return fmt.Errorf("cannot publish changes: %w", err)
+			}
+			for _, table := range tables {
+				eventsPublished.Add(table, 1)
+			}
+		}
+		if pending != nil {
+			if err := c.checkpointer.Save(pending); err != nil {
+				return fmt.Errorf("cannot save checkpoint: %w", err)
+			}
+		}
+		msgs, tables, pending = nil, nil, nil
+		return nil
+	}
+	for {
+		events, err := reader.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			switch ev.Type {
+			case binlogdatapb.VEventType_BEGIN:
+				inTransaction = true
+			case binlogdatapb.VEventType_FIELD:
+				c.fields[ev.FieldEvent.TableName] = ev.FieldEvent.Fields
+			case binlogdatapb.VEventType_ROW:
+				changes, err := buildChangeEvents(ev, c.fields[ev.RowEvent.TableName])
+				if err != nil {
+					return fmt.Errorf("%w: %v", errPermanent, err)
+				}
+				for _, change := range changes {
+					topic := c.Topic(change.Keyspace, change.Table)
+					value, err := c.serializer.Serialize(ctx, topic, change)
+					if err != nil {
+						return fmt.Errorf("%w: cannot serialize change of table %s: %v", errPermanent, change.Table, err)
+					}
+					msgs = append(msgs, &Message{Topic: topic, Key: change.PartitionKey(), Value: value})
+					tables = append(tables, change.Keyspace+"."+change.Table)
+				}
+			case binlogdatapb.VEventType_VGTID:
+				pending = ev.Vgtid
+				if !inTransaction {
+					if err := commit(); err != nil {
+						return err
+					}
+				}
+			case binlogdatapb.VEventType_COMMIT, binlogdatapb.VEventType_DDL, binlogdatapb.VEventType_OTHER:
+				inTransaction = false
+				if err := commit(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// buildFilter returns the filter that streams the given tables.
+func buildFilter(tables []string) (*binlogdatapb.Filter, error) {
+	if len(tables) == 0 {
+		tables = []string{"/.*"}
+	}
+	filter := &binlogdatapb.Filter{}
+	for _, table := range tables {
+		table = strings.TrimSpace(table)
+		if !strings.HasPrefix(strings.ToLower(table), "select") {
+			filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: table})
+			continue
+		}
+		tableName, err := sqlparser.TableFromStatement(table)
+		if err != nil {
+			return nil, fmt.Errorf("invalid table query %q: %v", table, err)
+		}
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{
+			Match:  tableName.Name.String(),
+			Filter: table,
+		})
+	}
+	return filter, nil
+}
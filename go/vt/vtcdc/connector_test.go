@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+type fakeReader struct {
+	batches [][]*binlogdatapb.VEvent
+	err     error
+}
+
+func (r *fakeReader) Recv() ([]*binlogdatapb.VEvent, error) {
+	if len(r.batches) == 0 {
+		return nil, r.err
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+type fakeStreamer struct {
+	readers []*fakeReader
+	vgtids  []*binlogdatapb.VGtid
+	filter  *binlogdatapb.Filter
+}
+
+func (s *fakeStreamer) VStream(_ context.Context, _ topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+	filter *binlogdatapb.Filter, _ *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
+	s.vgtids = append(s.vgtids, vgtid)
+	s.filter = filter
+	reader := s.readers[0]
+	s.readers = s.readers[1:]
+	return reader, nil
+}
+
+type fakeSink struct {
+	msgs []*Message
+}
+
+func (s *fakeSink) Send(_ context.Context, msgs []*Message) error {
+	s.msgs = append(s.msgs, msgs...)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	return nil
+}
+
+type memoryCheckpointer struct {
+	vgtid *binlogdatapb.VGtid
+	saves int
+}
+
+func (c *memoryCheckpointer) Load() (*binlogdatapb.VGtid, error) {
+	return c.vgtid, nil
+}
+
+func (c *memoryCheckpointer) Save(vgtid *binlogdatapb.VGtid) error {
+	c.vgtid = vgtid
+	c.saves++
+	return nil
+}
+
+var (
+	testFields = sqltypes.MakeTestFields("id|name", "int64|varchar")
+	fieldEvent = &binlogdatapb.VEvent{
+		Type:       binlogdatapb.VEventType_FIELD,
+		FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: testFields},
+	}
+	begin  = &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_BEGIN}
+	commit = &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_COMMIT}
+)
+
+func rowEvent(values string) *binlogdatapb.VEvent {
+	return &binlogdatapb.VEvent{
+		Type: binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{
+			TableName: "ks.t1",
+			Keyspace:  "ks",
+			Shard:     "0",
+			RowChanges: []*binlogdatapb.RowChange{{
+				After: sqltypes.RowToProto3(sqltypes.MakeTestResult(testFields, values).Rows[0]),
+			}},
+		},
+	}
+}
+
+func vgtidEvent(gtid string) *binlogdatapb.VEvent {
+	return &binlogdatapb.VEvent{
+		Type:  binlogdatapb.VEventType_VGTID,
+		Vgtid: &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0", Gtid: gtid}}},
+	}
+}
+
+func TestConnectorRun(t *testing.T) {
+	streamer := &fakeStreamer{readers: []*fakeReader{{
+		batches: [][]*binlogdatapb.VEvent{
+			{begin, fieldEvent, rowEvent("1|a"), vgtidEvent("pos1"), commit},
+			// The stream fails before the transaction is complete.
+			{begin, rowEvent("2|b")},
+		},
+		err: errors.New("stream broken"),
+	}, {
+		batches: [][]*binlogdatapb.VEvent{
+			{begin, fieldEvent, rowEvent("2|b"), vgtidEvent("pos2"), commit},
+			{vgtidEvent("pos3")},
+		},
+		err: io.EOF,
+	}}}
+	sink := &fakeSink{}
+	checkpointer := &memoryCheckpointer{}
+	connector := NewConnector(Config{
+		Keyspace:    "ks",
+		Position:    "current",
+		TopicPrefix: "cdc.",
+		Tables:      []string{"t1", "select id, name from t2"},
+		RetryDelay:  time.Millisecond,
+	}, streamer, sink, JSONSerializer{}, checkpointer)
+
+	require.NoError(t, connector.Run(context.Background()))
+
+	utils.MustMatch(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{
+		{Match: "t1"},
+		{Match: "t2", Filter: "select id, name from t2"},
+	}}, streamer.filter)
+	// The second stream restarts from the checkpoint of the first one.
+	require.Len(t, streamer.vgtids, 2)
+	assert.Equal(t, "current", streamer.vgtids[0].ShardGtids[0].Gtid)
+	assert.Equal(t, "pos1", streamer.vgtids[1].ShardGtids[0].Gtid)
+
+	require.Len(t, sink.msgs, 2)
+	assert.Equal(t, "cdc.ks.t1", sink.msgs[0].Topic)
+	assert.Equal(t, []byte("ks/0"), sink.msgs[0].Key)
+	assert.JSONEq(t, `{"keyspace":"ks","shard":"0","table":"t1","op":"insert","ts":0,"after":{"id":1,"name":"a"}}`, string(sink.msgs[0].Value))
+	assert.JSONEq(t, `{"keyspace":"ks","shard":"0","table":"t1","op":"insert","ts":0,"after":{"id":2,"name":"b"}}`, string(sink.msgs[1].Value))
+
+	assert.Equal(t, "pos3", checkpointer.vgtid.ShardGtids[0].Gtid)
+	assert.Equal(t, 3, checkpointer.saves)
+}
+
+// TestConnectorRunSplitTransaction checks that a transaction that vtgate
+// splits over several responses is only published and checkpointed once it
+// is complete, even though its VGTID is in the first response.
+func TestConnectorRunSplitTransaction(t *testing.T) {
+	streamer := &fakeStreamer{readers: []*fakeReader{{
+		batches: [][]*binlogdatapb.VEvent{
+			{begin, fieldEvent, rowEvent("1|a"), vgtidEvent("pos1")},
+			{rowEvent("2|b")},
+			// The stream fails before the transaction is complete.
+		},
+		err: errors.New("stream broken"),
+	}, {
+		batches: [][]*binlogdatapb.VEvent{
+			{begin, fieldEvent, rowEvent("1|a"), vgtidEvent("pos1")},
+			{rowEvent("2|b")},
+			{rowEvent("3|c"), commit},
+		},
+		err: io.EOF,
+	}}}
+	sink := &fakeSink{}
+	checkpointer := &memoryCheckpointer{}
+	connector := NewConnector(Config{Keyspace: "ks", Position: "current", RetryDelay: time.Millisecond}, streamer, sink, JSONSerializer{}, checkpointer)
+
+	require.NoError(t, connector.Run(context.Background()))
+
+	// Nothing was checkpointed by the first stream, so the second one
+	// restarts from the start position.
+	require.Len(t, streamer.vgtids, 2)
+	assert.Equal(t, "current", streamer.vgtids[1].ShardGtids[0].Gtid)
+
+	require.Len(t, sink.msgs, 3)
+	assert.JSONEq(t, `{"keyspace":"ks","shard":"0","table":"t1","op":"insert","ts":0,"after":{"id":3,"name":"c"}}`, string(sink.msgs[2].Value))
+	assert.Equal(t, "pos1", checkpointer.vgtid.ShardGtids[0].Gtid)
+	assert.Equal(t, 1, checkpointer.saves)
+}
+
+func TestConnectorRunPermanentError(t *testing.T) {
+	streamer := &fakeStreamer{readers: []*fakeReader{{
+		batches: [][]*binlogdatapb.VEvent{{{
+			Type:     binlogdatapb.VEventType_ROW,
+			RowEvent: &binlogdatapb.RowEvent{TableName: "ks.t1", RowChanges: []*binlogdatapb.RowChange{{}}},
+		}}},
+	}}}
+	connector := NewConnector(Config{Keyspace: "ks"}, streamer, &fakeSink{}, JSONSerializer{}, &memoryCheckpointer{})
+	err := connector.Run(context.Background())
+	assert.EqualError(t, err, "permanent error: no field event received for table ks.t1")
+
+	_, err = buildFilter([]string{"select * from t1 join t2"})
+	assert.ErrorContains(t, err, "invalid table query")
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// KeyspaceIDColumn is the name of the column that carries the keyspace_id
+// of a row when the stream filter selects keyspace_id(). It is used as the
+// partitioning key of the published messages and is not part of the row image.
+const KeyspaceIDColumn = "keyspace_id"
+
+// Operations of a ChangeEvent.
+const (
+	OpInsert = "insert"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// ChangeEvent is a single row change as published by the connector.
+type ChangeEvent struct {
+	Keyspace string `json:"keyspace"`
+	Shard    string `json:"shard"`
+	Table    string `json:"table"`
+	Op       string `json:"op"`
+	// Timestamp is the binlog timestamp of the change, in seconds.
+	Timestamp int64 `json:"ts"`
+	// KeyspaceID is the hex encoded keyspace_id of the row, if the stream
+	// filter selected it.
+	KeyspaceID string         `json:"keyspace_id,omitempty"`
+	Before     map[string]any `json:"before,omitempty"`
+	After      map[string]any `json:"after,omitempty"`
+
+	// Fields are the columns of the row images, in table order.
+	Fields []*querypb.Field `json:"-"`
+	// BeforeValues and AfterValues are the row images, in the order of Fields.
+	BeforeValues []sqltypes.Value `json:"-"`
+	AfterValues  []sqltypes.Value `json:"-"`
+
+	keyspaceID []byte
+}
+
+// PartitionKey returns the key used to pick the partition of the message
+// carrying the event. It is the keyspace_id of the row if it is known, so
+// that all changes to a row are ordered, or the shard otherwise.
+func (ev *ChangeEvent) PartitionKey() []byte {
+	if len(ev.keyspaceID) > 0 {
+		return ev.keyspaceID
+	}
+	return []byte(ev.Keyspace + "/" + ev.Shard)
+}
+
+// tableName strips the keyspace qualifier that vtgate adds to table names.
+func tableName(name string) string {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// buildChangeEvents converts a row event into change events, one per row change.
+// fields must be the fields of the last field event of the table.
+func buildChangeEvents(ev *binlogdatapb.VEvent, fields []*querypb.Field) ([]*ChangeEvent, error) {
+	rowEvent := ev.RowEvent
+	table := tableName(rowEvent.TableName)
+	if fields == nil {
+		return nil, fmt.Errorf("no field event received for table %s", rowEvent.TableName)
+	}
+
+	ksidCol := -1
+	rowFields := fields
+	for i, field := range fields {
+		if field.Name == KeyspaceIDColumn {
+			ksidCol = i
+			rowFields = make([]*querypb.Field, 0, len(fields)-1)
+			rowFields = append(rowFields, fields[:i]...)
+			rowFields = append(rowFields, fields[i+1:]...)
+			break
+		}
+	}
+
+	changes := make([]*ChangeEvent, 0, len(rowEvent.RowChanges))
+	for _, rc := range rowEvent.RowChanges {
+		change := &ChangeEvent{
+			Keyspace:  rowEvent.Keyspace,
+			Shard:     rowEvent.Shard,
+			Table:     table,
+			Timestamp: ev.Timestamp,
+			Fields:    rowFields,
+		}
+		switch {
+		case rc.Before == nil && rc.After != nil:
+			change.Op = OpInsert
+		case rc.Before != nil && rc.After != nil:
+			change.Op = OpUpdate
+		case rc.Before != nil && rc.After == nil:
+			change.Op = OpDelete
+		default:
+			continue
+		}
+		var err error
+		if rc.Before != nil {
+			if change.BeforeValues, err = change.splitRow(fields, rc.Before, ksidCol); err != nil {
+				return nil, err
+			}
+			change.Before = rowToMap(rowFields, change.BeforeValues)
+		}
+		if rc.After != nil {
+			if change.AfterValues, err = change.splitRow(fields, rc.After, ksidCol); err != nil {
+				return nil, err
+			}
+			change.After = rowToMap(rowFields, change.AfterValues)
+		}
+		if len(change.keyspaceID) > 0 {
+			change.KeyspaceID = hex.EncodeToString(change.keyspaceID)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// splitRow decodes a row and separates the keyspace_id column, if any, from the row image.
+func (ev *ChangeEvent) splitRow(fields []*querypb.Field, row *querypb.Row, ksidCol int) ([]sqltypes.Value, error) {
+	if len(row.Lengths) != len(fields) {
+		return nil, fmt.Errorf("row for table %s has %d columns, expected %d", ev.Table, len(row.Lengths), len(fields))
+	}
+	values := sqltypes.MakeRowTrusted(fields, row)
+	if ksidCol < 0 {
+		return values, nil
+	}
+	ev.keyspaceID = values[ksidCol].Raw()
+	return append(values[:ksidCol:ksidCol], values[ksidCol+1:]...), nil
+}
+
+func rowToMap(fields []*querypb.Field, values []sqltypes.Value) map[string]any {
+	row := make(map[string]any, len(fields))
+	for i, field := range fields {
+		row[field.Name] = nativeValue(field, values[i])
+	}
+	return row
+}
+
+// nativeValue converts a value to the Go type used to serialize it: int64 for
+// integers that fit, float64 for floating point numbers, []byte for binary
+// columns and string for everything else. NULL is returned as nil.
+func nativeValue(field *querypb.Field, v sqltypes.Value) any {
+	if v.IsNull() {
+		return nil
+	}
+	switch columnKind(field) {
+	case kindLong:
+		if i, err := v.ToInt64(); err == nil {
+			return i
+		}
+	case kindDouble:
+		if f, err := v.ToFloat64(); err == nil {
+			return f
+		}
+	case kindBytes:
+		return v.Raw()
+	}
+	return v.ToString()
+}
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindLong
+	kindDouble
+	kindBytes
+)
+
+// columnKind returns how values of a column are serialized. Unsigned 64 bit
+// integers don't fit in a signed long and are serialized as strings, like decimals.
+func columnKind(field *querypb.Field) valueKind {
+	switch {
+	case sqltypes.IsSigned(field.Type), sqltypes.IsUnsigned(field.Type) && field.Type != querypb.Type_UINT64:
+		return kindLong
+	case sqltypes.IsFloat(field.Type):
+		return kindDouble
+	case sqltypes.IsBinary(field.Type) || field.Type == querypb.Type_BIT:
+		return kindBytes
+	}
+	return kindString
+}
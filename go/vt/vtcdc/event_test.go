@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestBuildChangeEvents(t *testing.T) {
+	fields := sqltypes.MakeTestFields("keyspace_id|id|name|price|data", "varbinary|int64|varchar|float64|blob")
+	row := func(values ...string) *querypb.Row {
+		return sqltypes.RowToProto3(sqltypes.MakeTestResult(fields, values...).Rows[0])
+	}
+	ev := &binlogdatapb.VEvent{
+		Type:      binlogdatapb.VEventType_ROW,
+		Timestamp: 1700000000,
+		RowEvent: &binlogdatapb.RowEvent{
+			TableName: "ks.t1",
+			Keyspace:  "ks",
+			Shard:     "-80",
+			RowChanges: []*binlogdatapb.RowChange{
+				{After: row("\x16k@\xb4J\xbaK\xd6|1|a|1.5|\x01")},
+				{Before: row("\x16k@\xb4J\xbaK\xd6|1|a|1.5|\x01"), After: row("\x16k@\xb4J\xbaK\xd6|1|null|2|\x01")},
+				{Before: row("\x16k@\xb4J\xbaK\xd6|1|null|2|\x01")},
+			},
+		},
+	}
+
+	changes, err := buildChangeEvents(ev, fields)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	insert := changes[0]
+	assert.Equal(t, OpInsert, insert.Op)
+	assert.Equal(t, "t1", insert.Table)
+	assert.Equal(t, "ks", insert.Keyspace)
+	assert.Equal(t, "-80", insert.Shard)
+	assert.EqualValues(t, 1700000000, insert.Timestamp)
+	assert.Equal(t, "166b40b44aba4bd6", insert.KeyspaceID)
+	assert.Equal(t, []byte("\x16k@\xb4J\xbaK\xd6"), insert.PartitionKey())
+	assert.Nil(t, insert.Before)
+	assert.Equal(t, map[string]any{"id": int64(1), "name": "a", "price": 1.5, "data": []byte{1}}, insert.After)
+	assert.Len(t, insert.Fields, 4)
+
+	update := changes[1]
+	assert.Equal(t, OpUpdate, update.Op)
+	assert.Equal(t, "a", update.Before["name"])
+	assert.Nil(t, update.After["name"])
+	assert.Equal(t, float64(2), update.After["price"])
+
+	del := changes[2]
+	assert.Equal(t, OpDelete, del.Op)
+	assert.Nil(t, del.After)
+	assert.Equal(t, int64(1), del.Before["id"])
+
+	// Without a keyspace_id column, the shard is the partition key.
+	fields = sqltypes.MakeTestFields("id|big", "int64|uint64")
+	ev.RowEvent.RowChanges = []*binlogdatapb.RowChange{{After: row("1|18446744073709551615")}}
+	changes, err = buildChangeEvents(ev, fields)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].KeyspaceID)
+	assert.Equal(t, []byte("ks/-80"), changes[0].PartitionKey())
+	assert.Equal(t, map[string]any{"id": int64(1), "big": "18446744073709551615"}, changes[0].After)
+
+	_, err = buildChangeEvents(ev, nil)
+	assert.EqualError(t, err, "no field event received for table ks.t1")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SchemaRegistry registers Avro schemas.
+type SchemaRegistry interface {
+	// Register registers schema under subject and returns its id. Registering
+	// a schema that already exists returns the existing id.
+	Register(ctx context.Context, subject, schema string) (int32, error)
+}
+
+// SchemaRegistryClient is a SchemaRegistry that talks to a Confluent compatible
+// schema registry over its REST API.
+type SchemaRegistryClient struct {
+	url    string
+	client *http.Client
+}
+
+// NewSchemaRegistryClient returns a client for the schema registry at registryURL.
+func NewSchemaRegistryClient(registryURL string) *SchemaRegistryClient {
+	return &SchemaRegistryClient{
+		url:    strings.TrimSuffix(registryURL, "/"),
+		client: http.DefaultClient,
+	}
+}
+
+// Register is part of the SchemaRegistry interface.
+func (c *SchemaRegistryClient) Register(ctx context.Context, subject, schema string) (int32, error) {
+	body, err := json.Marshal(map[string]string{"schema": schema})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/subjects/%s/versions", c.url, url.PathEscape(subject)), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("schema registry returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var result struct {
+		ID int32 `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return 0, fmt.Errorf("cannot parse schema registry response: %w", err)
+	}
+	return result.ID, nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// Supported serialization formats.
+const (
+	FormatJSON = "json"
+	FormatAvro = "avro"
+)
+
+// Serializer encodes change events into message values.
+type Serializer interface {
+	// Serialize returns the encoded value of the event published on topic.
+	Serialize(ctx context.Context, topic string, ev *ChangeEvent) ([]byte, error)
+}
+
+// NewSerializer returns the serializer for the given format. Avro requires
+// the URL of a schema registry.
+func NewSerializer(format, schemaRegistryURL string) (Serializer, error) {
+	switch format {
+	case FormatJSON:
+		return JSONSerializer{}, nil
+	case FormatAvro:
+		if schemaRegistryURL == "" {
+			return nil, fmt.Errorf("a schema registry URL is required for the %s format", FormatAvro)
+		}
+		return NewAvroSerializer(NewSchemaRegistryClient(schemaRegistryURL)), nil
+	}
+	return nil, fmt.Errorf("unsupported format %q, valid values are %s and %s", format, FormatJSON, FormatAvro)
+}
+
+// JSONSerializer encodes change events as JSON documents.
+type JSONSerializer struct{}
+
+// Serialize is part of the Serializer interface.
+func (JSONSerializer) Serialize(_ context.Context, _ string, ev *ChangeEvent) ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+// AvroSerializer encodes change events with Avro, using the Confluent wire
+// format: a zero magic byte, the four byte schema id and the Avro binary
+// encoding of the event. Schemas are derived from the table fields and
+// registered under the "<topic>-value" subject.
+type AvroSerializer struct {
+	registry SchemaRegistry
+
+	mu  sync.Mutex
+	ids map[string]int32
+}
+
+// NewAvroSerializer returns an AvroSerializer that registers its schemas with registry.
+func NewAvroSerializer(registry SchemaRegistry) *AvroSerializer {
+	return &AvroSerializer{
+		registry: registry,
+		ids:      make(map[string]int32),
+	}
+}
+
+// Serialize is part of the Serializer interface.
+func (s *AvroSerializer) Serialize(ctx context.Context, topic string, ev *ChangeEvent) ([]byte, error) {
+	schema, err := avroSchema(ev)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.schemaID(ctx, topic+"-value", schema)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 5, 256)
+	binary.BigEndian.PutUint32(buf[1:], uint32(id))
+	buf = appendAvroString(buf, ev.Keyspace)
+	buf = appendAvroString(buf, ev.Shard)
+	buf = appendAvroString(buf, ev.Table)
+	buf = appendAvroString(buf, ev.Op)
+	buf = appendAvroLong(buf, ev.Timestamp)
+	if ev.KeyspaceID == "" {
+		buf = appendAvroLong(buf, 0)
+	} else {
+		buf = appendAvroLong(buf, 1)
+		buf = appendAvroString(buf, ev.KeyspaceID)
+	}
+	buf = appendAvroRow(buf, ev.Fields, ev.Before)
+	buf = appendAvroRow(buf, ev.Fields, ev.After)
+	return buf, nil
+}
+
+func (s *AvroSerializer) schemaID(ctx context.Context, subject, schema string) (int32, error) {
+	key := subject + "\x00" + schema
+	s.mu.Lock()
+	id, ok := s.ids[key]
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	id, err := s.registry.Register(ctx, subject, schema)
+	if err != nil {
+		return 0, fmt.Errorf("cannot register schema for subject %s: %w", subject, err)
+	}
+	s.mu.Lock()
+	s.ids[key] = id
+	s.mu.Unlock()
+	return id, nil
+}
+
+var invalidAvroNameChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// avroName sanitizes a name so that it's a valid Avro name.
+func avroName(name string) string {
+	name = invalidAvroNameChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+type avroField struct {
+	Name    string `json:"name"`
+	Type    any    `json:"type"`
+	Default any    `json:"default,omitempty"`
+}
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+var avroKindTypes = map[valueKind]string{
+	kindString: "string",
+	kindLong:   "long",
+	kindDouble: "double",
+	kindBytes:  "bytes",
+}
+
+// avroSchema returns the schema of the change events of a table. Row images
+// are nullable records with a nullable field per column.
+func avroSchema(ev *ChangeEvent) (string, error) {
+	row := avroRecord{
+		Type:      "record",
+		Name:      avroName(ev.Table),
+		Namespace: avroName(ev.Keyspace),
+		Fields:    make([]avroField, 0, len(ev.Fields)),
+	}
+	for _, field := range ev.Fields {
+		row.Fields = append(row.Fields, avroField{
+			Name: avroName(field.Name),
+			Type: []string{"null", avroKindTypes[columnKind(field)]},
+		})
+	}
+	schema := avroRecord{
+		Type:      "record",
+		Name:      "ChangeEvent",
+		Namespace: "io.vitess.vtcdc",
+		Fields: []avroField{
+			{Name: "keyspace", Type: "string"},
+			{Name: "shard", Type: "string"},
+			{Name: "table", Type: "string"},
+			{Name: "op", Type: "string"},
+			{Name: "ts", Type: "long"},
+			{Name: "keyspace_id", Type: []string{"null", "string"}},
+			{Name: "before", Type: []any{"null", row}},
+			// The row record is already defined by before.
+			{Name: "after", Type: []string{"null", row.Namespace + "." + row.Name}},
+		},
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// appendAvroRow appends a nullable row image.
+func appendAvroRow(buf []byte, fields []*querypb.Field, row map[string]any) []byte {
+	if row == nil {
+		return appendAvroLong(buf, 0)
+	}
+	buf = appendAvroLong(buf, 1)
+	for _, field := range fields {
+		v := row[field.Name]
+		if v == nil {
+			buf = appendAvroLong(buf, 0)
+			continue
+		}
+		buf = appendAvroLong(buf, 1)
+		switch v := v.(type) {
+		case int64:
+			buf = appendAvroLong(buf, v)
+		case float64:
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		case []byte:
+			buf = appendAvroLong(buf, int64(len(v)))
+			buf = append(buf, v...)
+		case string:
+			buf = appendAvroString(buf, v)
+		default:
+			buf = appendAvroString(buf, fmt.Sprint(v))
+		}
+	}
+	return buf
+}
+
+// appendAvroLong appends a zig-zag encoded variable length long.
+func appendAvroLong(buf []byte, v int64) []byte {
+	return binary.AppendUvarint(buf, uint64((v<<1)^(v>>63)))
+}
+
+func appendAvroString(buf []byte, s string) []byte {
+	buf = appendAvroLong(buf, int64(len(s)))
+	return append(buf, s...)
+}
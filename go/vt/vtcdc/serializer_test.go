@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func testChangeEvent() *ChangeEvent {
+	return &ChangeEvent{
+		Keyspace:   "ks",
+		Shard:      "-",
+		Table:      "t1",
+		Op:         OpInsert,
+		Timestamp:  1,
+		KeyspaceID: "01",
+		After:      map[string]any{"id": int64(-1), "name": "ab", "price": nil},
+		Fields:     sqltypes.MakeTestFields("id|name|price", "int64|varchar|float64"),
+	}
+}
+
+func TestJSONSerializer(t *testing.T) {
+	b, err := JSONSerializer{}.Serialize(context.Background(), "ks.t1", testChangeEvent())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keyspace":"ks","shard":"-","table":"t1","op":"insert","ts":1,"keyspace_id":"01","after":{"id":-1,"name":"ab","price":null}}`, string(b))
+}
+
+type fakeSchemaRegistry struct {
+	schemas []string
+}
+
+func (r *fakeSchemaRegistry) Register(_ context.Context, subject, schema string) (int32, error) {
+	r.schemas = append(r.schemas, subject+":"+schema)
+	return int32(len(r.schemas)), nil
+}
+
+func TestAvroSerializer(t *testing.T) {
+	registry := &fakeSchemaRegistry{}
+	s := NewAvroSerializer(registry)
+	ev := testChangeEvent()
+
+	b, err := s.Serialize(context.Background(), "ks.t1", ev)
+	require.NoError(t, err)
+	want := []byte{
+		0, 0, 0, 0, 1, // magic byte and schema id
+		4, 'k', 's',
+		2, '-',
+		4, 't', '1',
+		12, 'i', 'n', 's', 'e', 'r', 't',
+		2,              // ts
+		2, 4, '0', '1', // keyspace_id
+		0,                          // before is null
+		2, 2, 1, 2, 4, 'a', 'b', 0, // after: id=-1, name=ab, price is null
+	}
+	assert.Equal(t, want, b)
+
+	// The schema is only registered once.
+	_, err = s.Serialize(context.Background(), "ks.t1", ev)
+	require.NoError(t, err)
+	require.Len(t, registry.schemas, 1)
+	assert.Contains(t, registry.schemas[0], `ks.t1-value:{"type":"record","name":"ChangeEvent"`)
+	assert.Contains(t, registry.schemas[0], `{"name":"before","type":["null",{"type":"record","name":"t1","namespace":"ks","fields":[{"name":"id","type":["null","long"]}`)
+	assert.Contains(t, registry.schemas[0], `{"name":"after","type":["null","ks.t1"]}`)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(registry.schemas[0][len("ks.t1-value:"):]), &schema))
+}
+
+func TestAvroName(t *testing.T) {
+	assert.Equal(t, "a_b", avroName("a-b"))
+	assert.Equal(t, "_1a", avroName("1a"))
+	assert.Equal(t, "_", avroName(""))
+}
+
+func TestSchemaRegistryClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subjects/ks.t1-value/versions", r.URL.Path)
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["schema"] == "invalid" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error_code":42201,"message":"Invalid schema"}`))
+			return
+		}
+		w.Write([]byte(`{"id":7}`))
+	}))
+	defer server.Close()
+
+	client := NewSchemaRegistryClient(server.URL + "/")
+	id, err := client.Register(context.Background(), "ks.t1-value", `"string"`)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, id)
+
+	_, err = client.Register(context.Background(), "ks.t1-value", "invalid")
+	assert.ErrorContains(t, err, "Invalid schema")
+}
+
+func TestNewSerializer(t *testing.T) {
+	_, err := NewSerializer(FormatJSON, "")
+	assert.NoError(t, err)
+	_, err = NewSerializer(FormatAvro, "")
+	assert.EqualError(t, err, "a schema registry URL is required for the avro format")
+	_, err = NewSerializer("xml", "")
+	assert.EqualError(t, err, `unsupported format "xml", valid values are json and avro`)
+}
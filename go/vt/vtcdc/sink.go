@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a record published to a topic.
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// Sink publishes messages. Send must only return once all the messages are
+// durably published, since the connector checkpoints its position right after.
+type Sink interface {
+	Send(ctx context.Context, msgs []*Message) error
+	Close() error
+}
+
+// SinkFactory creates a Sink for the given address.
+type SinkFactory func(address string) (Sink, error)
+
+var (
+	sinkFactoriesMu sync.Mutex
+	sinkFactories   = make(map[string]SinkFactory)
+)
+
+// RegisterSink registers a sink implementation under name. It is meant to be
+// called from init functions, so that sinks backed by a native Kafka client,
+// or by any other system, can be plugged into the connector.
+func RegisterSink(name string, factory SinkFactory) {
+	sinkFactoriesMu.Lock()
+	defer sinkFactoriesMu.Unlock()
+	if _, ok := sinkFactories[name]; ok {
+		panic(fmt.Sprintf("sink %s is already registered", name))
+	}
+	sinkFactories[name] = factory
+}
+
+// NewSink creates the sink registered under name.
+func NewSink(name, address string) (Sink, error) {
+	sinkFactoriesMu.Lock()
+	factory, ok := sinkFactories[name]
+	sinkFactoriesMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown sink %q, valid values are %s", name, strings.Join(SinkNames(), ", "))
+	}
+	return factory(address)
+}
+
+// SinkNames returns the names of the registered sinks.
+func SinkNames() []string {
+	sinkFactoriesMu.Lock()
+	defer sinkFactoriesMu.Unlock()
+	names := make([]string, 0, len(sinkFactories))
+	for name := range sinkFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterSink("kafka-rest", func(address string) (Sink, error) {
+		return NewKafkaRESTSink(address)
+	})
+	RegisterSink("stdout", func(string) (Sink, error) {
+		return &writerSink{w: os.Stdout}, nil
+	})
+}
+
+// kafkaRESTTimeout is the timeout of the requests to the Kafka REST Proxy.
+const kafkaRESTTimeout = 30 * time.Second
+
+// KafkaRESTSink publishes messages to Kafka through a Kafka REST Proxy, using
+// its v2 binary embedded format. The proxy hashes the message key to pick the
+// partition, so messages published with the same key keep their order.
+type KafkaRESTSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewKafkaRESTSink returns a sink for the Kafka REST Proxy at proxyURL.
+func NewKafkaRESTSink(proxyURL string) (*KafkaRESTSink, error) {
+	if proxyURL == "" {
+		return nil, fmt.Errorf("the Kafka REST Proxy URL is required")
+	}
+	return &KafkaRESTSink{
+		url:    strings.TrimSuffix(proxyURL, "/"),
+		client: &http.Client{Timeout: kafkaRESTTimeout},
+	}, nil
+}
+
+type kafkaRESTRecord struct {
+	Key   []byte `json:"key,omitempty"`
+	Value []byte `json:"value"`
+}
+
+type kafkaRESTResponse struct {
+	Offsets []struct {
+		Partition int32   `json:"partition"`
+		Offset    int64   `json:"offset"`
+		ErrorCode *int32  `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Send is part of the Sink interface. Messages are sent one request per
+// consecutive run of messages for the same topic, in order.
+func (s *KafkaRESTSink) Send(ctx context.Context, msgs []*Message) error {
+	for start := 0; start < len(msgs); {
+		end := start + 1
+		for end < len(msgs) && msgs[end].Topic == msgs[start].Topic {
+			end++
+		}
+		if err := s.produce(ctx, msgs[start].Topic, msgs[start:end]); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+func (s *KafkaRESTSink) produce(ctx context.Context, topic string, msgs []*Message) error {
+	records := make([]kafkaRESTRecord, 0, len(msgs))
+	for _, msg := range msgs {
+		records = append(records, kafkaRESTRecord{Key: msg.Key, Value: msg.Value})
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/topics/%s", s.url, url.PathEscape(topic)), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.binary.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("producing to topic %s failed with %s: %s", topic, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var result kafkaRESTResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("cannot parse Kafka REST Proxy response: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.Error != nil {
+			return fmt.Errorf("producing to topic %s failed: %s", topic, *offset.Error)
+		}
+	}
+	return nil
+}
+
+// Close is part of the Sink interface.
+func (s *KafkaRESTSink) Close() error {
+	return nil
+}
+
+// writerSink writes messages as JSON lines, which is useful to try out a
+// configuration without a Kafka cluster.
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Send is part of the Sink interface.
+func (s *writerSink) Send(_ context.Context, msgs []*Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	enc := json.NewEncoder(s.w)
+	for _, msg := range msgs {
+		var value any = msg.Value
+		if json.Valid(msg.Value) {
+			value = json.RawMessage(msg.Value)
+		}
+		if err := enc.Encode(map[string]any{
+			"topic": msg.Topic,
+			"key":   msg.Key,
+			"value": value,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is part of the Sink interface.
+func (s *writerSink) Close() error {
+	return nil
+}
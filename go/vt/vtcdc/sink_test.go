@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaRESTSink(t *testing.T) {
+	type request struct {
+		topic   string
+		records []kafkaRESTRecord
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.kafka.binary.v2+json", r.Header.Get("Content-Type"))
+		var body struct {
+			Records []kafkaRESTRecord `json:"records"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, request{topic: r.URL.Path, records: body.Records})
+		if r.URL.Path == "/topics/missing" {
+			w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"Topic not found"}]}`))
+			return
+		}
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`))
+	}))
+	defer server.Close()
+
+	sink, err := NewSink("kafka-rest", server.URL)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	err = sink.Send(context.Background(), []*Message{
+		{Topic: "ks.t1", Key: []byte("k1"), Value: []byte("v1")},
+		{Topic: "ks.t1", Key: []byte("k2"), Value: []byte("v2")},
+		{Topic: "ks.t2", Key: []byte("k3"), Value: []byte("v3")},
+		{Topic: "ks.t1", Key: []byte("k4"), Value: []byte("v4")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []request{
+		{topic: "/topics/ks.t1", records: []kafkaRESTRecord{{Key: []byte("k1"), Value: []byte("v1")}, {Key: []byte("k2"), Value: []byte("v2")}}},
+		{topic: "/topics/ks.t2", records: []kafkaRESTRecord{{Key: []byte("k3"), Value: []byte("v3")}}},
+		{topic: "/topics/ks.t1", records: []kafkaRESTRecord{{Key: []byte("k4"), Value: []byte("v4")}}},
+	}, requests)
+
+	err = sink.Send(context.Background(), []*Message{{Topic: "missing", Value: []byte("v")}})
+	assert.EqualError(t, err, "producing to topic missing failed: Topic not found")
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := &writerSink{w: &buf}
+	err := sink.Send(context.Background(), []*Message{
+		{Topic: "ks.t1", Key: []byte("k"), Value: []byte(`{"op":"insert"}`)},
+		{Topic: "ks.t1", Key: []byte("k"), Value: []byte{0, 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"key":"aw==","topic":"ks.t1","value":{"op":"insert"}}
+{"key":"aw==","topic":"ks.t1","value":"AAE="}
+`, buf.String())
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink("kafka", "")
+	assert.EqualError(t, err, `unknown sink "kafka", valid values are kafka-rest, stdout`)
+	_, err = NewSink("kafka-rest", "")
+	assert.EqualError(t, err, "the Kafka REST Proxy URL is required")
+}
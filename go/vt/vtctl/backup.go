@@ -107,7 +107,9 @@ type backupEventStreamLogger struct {
 func (b *backupEventStreamLogger) Context() context.Context { return b.ctx }
 
 func (b *backupEventStreamLogger) Send(resp *vtctldatapb.BackupResponse) error {
-	logutil.LogEvent(b.logger, resp.Event)
+	if resp.Event != nil {
+		logutil.LogEvent(b.logger, resp.Event)
+	}
 	return nil
 }
 
@@ -101,6 +101,15 @@ func (client *gRPCVtctldClient) BackupShard(ctx context.Context, in *vtctldatapb
 	return client.c.BackupShard(ctx, in, opts...)
 }
 
+// CancelMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) CancelMaintenance(ctx context.Context, in *vtctldatapb.CancelMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelMaintenanceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.CancelMaintenance(ctx, in, opts...)
+}
+
 // CancelSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CancelSchemaMigration(ctx context.Context, in *vtctldatapb.CancelSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -110,6 +119,15 @@ func (client *gRPCVtctldClient) CancelSchemaMigration(ctx context.Context, in *v
 	return client.c.CancelSchemaMigration(ctx, in, opts...)
 }
 
+// ChangeTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ChangeTabletTags(ctx context.Context, in *vtctldatapb.ChangeTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTagsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ChangeTabletTags(ctx, in, opts...)
+}
+
 // ChangeTabletType is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ChangeTabletType(ctx context.Context, in *vtctldatapb.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTypeResponse, error) {
 	if client.c == nil {
@@ -137,6 +155,15 @@ func (client *gRPCVtctldClient) CompleteSchemaMigration(ctx context.Context, in
 	return client.c.CompleteSchemaMigration(ctx, in, opts...)
 }
 
+// ConcludeTransaction is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ConcludeTransaction(ctx context.Context, in *vtctldatapb.ConcludeTransactionRequest, opts ...grpc.CallOption) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ConcludeTransaction(ctx, in, opts...)
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CreateKeyspace(ctx context.Context, in *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	if client.c == nil {
@@ -155,6 +182,24 @@ func (client *gRPCVtctldClient) CreateShard(ctx context.Context, in *vtctldatapb
 	return client.c.CreateShard(ctx, in, opts...)
 }
 
+// DelayedReplicaCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DelayedReplicaCreate(ctx context.Context, in *vtctldatapb.DelayedReplicaCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DelayedReplicaCreate(ctx, in, opts...)
+}
+
+// DelayedReplicaFastForward is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DelayedReplicaFastForward(ctx context.Context, in *vtctldatapb.DelayedReplicaFastForwardRequest, opts ...grpc.CallOption) (*vtctldatapb.DelayedReplicaFastForwardResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DelayedReplicaFastForward(ctx, in, opts...)
+}
+
 // DeleteCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) DeleteCellInfo(ctx context.Context, in *vtctldatapb.DeleteCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteCellInfoResponse, error) {
 	if client.c == nil {
@@ -254,6 +299,24 @@ func (client *gRPCVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in
 	return client.c.FindAllShardsInKeyspace(ctx, in, opts...)
 }
 
+// ForceUnlock is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ForceUnlock(ctx context.Context, in *vtctldatapb.ForceUnlockRequest, opts ...grpc.CallOption) (*vtctldatapb.ForceUnlockResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ForceUnlock(ctx, in, opts...)
+}
+
+// GetAuditLog is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetAuditLog(ctx context.Context, in *vtctldatapb.GetAuditLogRequest, opts ...grpc.CallOption) (*vtctldatapb.GetAuditLogResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetAuditLog(ctx, in, opts...)
+}
+
 // GetBackups is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	if client.c == nil {
@@ -290,6 +353,15 @@ func (client *gRPCVtctldClient) GetCellsAliases(ctx context.Context, in *vtctlda
 	return client.c.GetCellsAliases(ctx, in, opts...)
 }
 
+// GetClusterSetting is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetClusterSetting(ctx context.Context, in *vtctldatapb.GetClusterSettingRequest, opts ...grpc.CallOption) (*vtctldatapb.GetClusterSettingResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetClusterSetting(ctx, in, opts...)
+}
+
 // GetFullStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetFullStatus(ctx context.Context, in *vtctldatapb.GetFullStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetFullStatusResponse, error) {
 	if client.c == nil {
@@ -317,6 +389,24 @@ func (client *gRPCVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatap
 	return client.c.GetKeyspaces(ctx, in, opts...)
 }
 
+// GetLocks is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetLocks(ctx context.Context, in *vtctldatapb.GetLocksRequest, opts ...grpc.CallOption) (*vtctldatapb.GetLocksResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetLocks(ctx, in, opts...)
+}
+
+// GetMaintenances is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetMaintenances(ctx context.Context, in *vtctldatapb.GetMaintenancesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenancesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetMaintenances(ctx, in, opts...)
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
 	if client.c == nil {
@@ -434,6 +524,24 @@ func (client *gRPCVtctldClient) GetTopologyPath(ctx context.Context, in *vtctlda
 	return client.c.GetTopologyPath(ctx, in, opts...)
 }
 
+// GetTopoReadOnly is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTopoReadOnly(ctx context.Context, in *vtctldatapb.GetTopoReadOnlyRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTopoReadOnlyResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTopoReadOnly(ctx, in, opts...)
+}
+
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetUnresolvedTransactions(ctx context.Context, in *vtctldatapb.GetUnresolvedTransactionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetUnresolvedTransactions(ctx, in, opts...)
+}
+
 // GetVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVSchemaResponse, error) {
 	if client.c == nil {
@@ -587,6 +695,15 @@ func (client *gRPCVtctldClient) PlannedReparentShard(ctx context.Context, in *vt
 	return client.c.PlannedReparentShard(ctx, in, opts...)
 }
 
+// PruneBackups is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PruneBackups(ctx context.Context, in *vtctldatapb.PruneBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.PruneBackupsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PruneBackups(ctx, in, opts...)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	if client.c == nil {
@@ -659,6 +776,15 @@ func (client *gRPCVtctldClient) RemoveBackup(ctx context.Context, in *vtctldatap
 	return client.c.RemoveBackup(ctx, in, opts...)
 }
 
+// RemoveCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RemoveCell(ctx context.Context, in *vtctldatapb.RemoveCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveCellResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RemoveCell(ctx, in, opts...)
+}
+
 // RemoveKeyspaceCell is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RemoveKeyspaceCell(ctx context.Context, in *vtctldatapb.RemoveKeyspaceCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveKeyspaceCellResponse, error) {
 	if client.c == nil {
@@ -695,6 +821,15 @@ func (client *gRPCVtctldClient) ReshardCreate(ctx context.Context, in *vtctldata
 	return client.c.ReshardCreate(ctx, in, opts...)
 }
 
+// ReshardRecommend is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ReshardRecommend(ctx context.Context, in *vtctldatapb.ReshardRecommendRequest, opts ...grpc.CallOption) (*vtctldatapb.ReshardRecommendResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ReshardRecommend(ctx, in, opts...)
+}
+
 // RestoreFromBackup is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RestoreFromBackup(ctx context.Context, in *vtctldatapb.RestoreFromBackupRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_RestoreFromBackupClient, error) {
 	if client.c == nil {
@@ -704,6 +839,15 @@ func (client *gRPCVtctldClient) RestoreFromBackup(ctx context.Context, in *vtctl
 	return client.c.RestoreFromBackup(ctx, in, opts...)
 }
 
+// RestoreToPointInTime is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RestoreToPointInTime(ctx context.Context, in *vtctldatapb.RestoreToPointInTimeRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_RestoreToPointInTimeClient, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RestoreToPointInTime(ctx, in, opts...)
+}
+
 // RetrySchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RetrySchemaMigration(ctx context.Context, in *vtctldatapb.RetrySchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -713,6 +857,15 @@ func (client *gRPCVtctldClient) RetrySchemaMigration(ctx context.Context, in *vt
 	return client.c.RetrySchemaMigration(ctx, in, opts...)
 }
 
+// RotateTabletCertificates is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RotateTabletCertificates(ctx context.Context, in *vtctldatapb.RotateTabletCertificatesRequest, opts ...grpc.CallOption) (*vtctldatapb.RotateTabletCertificatesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RotateTabletCertificates(ctx, in, opts...)
+}
+
 // RunHealthCheck is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldatapb.RunHealthCheckRequest, opts ...grpc.CallOption) (*vtctldatapb.RunHealthCheckResponse, error) {
 	if client.c == nil {
@@ -722,6 +875,33 @@ func (client *gRPCVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldat
 	return client.c.RunHealthCheck(ctx, in, opts...)
 }
 
+// ScheduleMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ScheduleMaintenance(ctx context.Context, in *vtctldatapb.ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.ScheduleMaintenanceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ScheduleMaintenance(ctx, in, opts...)
+}
+
+// SetClusterSetting is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetClusterSetting(ctx context.Context, in *vtctldatapb.SetClusterSettingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetClusterSettingResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetClusterSetting(ctx, in, opts...)
+}
+
+// SetKeyspaceFailoverPreferences is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetKeyspaceFailoverPreferences(ctx context.Context, in *vtctldatapb.SetKeyspaceFailoverPreferencesRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceFailoverPreferencesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetKeyspaceFailoverPreferences(ctx, in, opts...)
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceDurabilityPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceDurabilityPolicyResponse, error) {
 	if client.c == nil {
@@ -749,6 +929,24 @@ func (client *gRPCVtctldClient) SetShardTabletControl(ctx context.Context, in *v
 	return client.c.SetShardTabletControl(ctx, in, opts...)
 }
 
+// SetTopoReadOnly is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetTopoReadOnly(ctx context.Context, in *vtctldatapb.SetTopoReadOnlyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetTopoReadOnlyResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetTopoReadOnly(ctx, in, opts...)
+}
+
+// SetVtorcMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetVtorcMaintenance(ctx context.Context, in *vtctldatapb.SetVtorcMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.SetVtorcMaintenanceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetVtorcMaintenance(ctx, in, opts...)
+}
+
 // SetWritable is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) SetWritable(ctx context.Context, in *vtctldatapb.SetWritableRequest, opts ...grpc.CallOption) (*vtctldatapb.SetWritableResponse, error) {
 	if client.c == nil {
@@ -848,6 +1046,33 @@ func (client *gRPCVtctldClient) TabletExternallyReparented(ctx context.Context,
 	return client.c.TabletExternallyReparented(ctx, in, opts...)
 }
 
+// TopoBackup is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) TopoBackup(ctx context.Context, in *vtctldatapb.TopoBackupRequest, opts ...grpc.CallOption) (*vtctldatapb.TopoBackupResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.TopoBackup(ctx, in, opts...)
+}
+
+// TopoGC is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) TopoGC(ctx context.Context, in *vtctldatapb.TopoGCRequest, opts ...grpc.CallOption) (*vtctldatapb.TopoGCResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.TopoGC(ctx, in, opts...)
+}
+
+// TopoRestore is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) TopoRestore(ctx context.Context, in *vtctldatapb.TopoRestoreRequest, opts ...grpc.CallOption) (*vtctldatapb.TopoRestoreResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.TopoRestore(ctx, in, opts...)
+}
+
 // UpdateCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) UpdateCellInfo(ctx context.Context, in *vtctldatapb.UpdateCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateCellInfoResponse, error) {
 	if client.c == nil {
@@ -929,6 +1154,15 @@ func (client *gRPCVtctldClient) Validate(ctx context.Context, in *vtctldatapb.Va
 	return client.c.Validate(ctx, in, opts...)
 }
 
+// ValidateBackup is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ValidateBackup(ctx context.Context, in *vtctldatapb.ValidateBackupRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_ValidateBackupClient, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ValidateBackup(ctx, in, opts...)
+}
+
 // ValidateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ValidateKeyspace(ctx context.Context, in *vtctldatapb.ValidateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateKeyspaceResponse, error) {
 	if client.c == nil {
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"vitess.io/vitess/go/vt/audit"
+	"vitess.io/vitess/go/vt/servenv"
+
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+// InitAuditLog records the calls to the vtctld services of servenv.GRPCServer
+// which may change the cluster, i.e. all but the read-only ones, in the audit
+// log, if it is enabled. It must be called before the server is created, and
+// before InitRBAC so that the denied calls are recorded too.
+func InitAuditLog() {
+	if !audit.Enabled() {
+		return
+	}
+	servenv.AddGRPCServerInterceptors(auditStreamServerInterceptor, auditUnaryServerInterceptor)
+}
+
+// auditedAction returns the name of the method, a full gRPC method name, if
+// its calls are recorded in the audit log.
+func auditedAction(fullMethod string) (string, bool) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	switch {
+	case !ok:
+		return "", false
+	case service == vtctlservicepb.Vtctl_ServiceDesc.ServiceName:
+		return method, true
+	case service == vtctlservicepb.Vtctld_ServiceDesc.ServiceName:
+		return method, rbacMethodRoles[method] != RBACRoleReadOnly
+	}
+	return "", false
+}
+
+func auditUnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	action, ok := auditedAction(info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	entry := &audit.Entry{
+		Time:     time.Now(),
+		Identity: callerIdentity(ctx),
+		Action:   action,
+		Args:     auditArgs(req),
+	}
+	resp, err := handler(ctx, req)
+	recordAuditEntry(entry, err)
+	return resp, err
+}
+
+func auditStreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	action, ok := auditedAction(info.FullMethod)
+	if !ok {
+		return handler(srv, stream)
+	}
+
+	entry := &audit.Entry{
+		Time:     time.Now(),
+		Identity: callerIdentity(stream.Context()),
+		Action:   action,
+	}
+	audited := &auditedServerStream{ServerStream: stream}
+	err := handler(srv, audited)
+	entry.Args = audited.args
+	recordAuditEntry(entry, err)
+	return err
+}
+
+// auditedServerStream keeps the arguments of the request of a streaming call,
+// which is received by its handler.
+type auditedServerStream struct {
+	grpc.ServerStream
+	args string
+}
+
+// RecvMsg is part of the grpc.ServerStream interface.
+func (stream *auditedServerStream) RecvMsg(m any) error {
+	err := stream.ServerStream.RecvMsg(m)
+	if err == nil && stream.args == "" {
+		stream.args = auditArgs(m)
+	}
+	return err
+}
+
+func recordAuditEntry(entry *audit.Entry, err error) {
+	entry.Duration = time.Since(entry.Time)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	audit.Record(entry)
+}
+
+// auditArgs returns the request of a call as JSON, without its bytes fields,
+// which hold files such as the private keys of RotateTabletCertificates.
+func auditArgs(req any) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+
+	msg = proto.Clone(msg)
+	m := msg.ProtoReflect()
+	var redacted []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.Kind() == protoreflect.BytesKind {
+			redacted = append(redacted, fd)
+		}
+		return true
+	})
+	for _, fd := range redacted {
+		m.Clear(fd)
+	}
+
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestAuditedAction(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		action     string
+		audited    bool
+	}{
+		{"/vtctlservice.Vtctld/PlannedReparentShard", "PlannedReparentShard", true},
+		{"/vtctlservice.Vtctld/DeleteKeyspace", "DeleteKeyspace", true},
+		{"/vtctlservice.Vtctl/ExecuteVtctlCommand", "ExecuteVtctlCommand", true},
+		{"/vtctlservice.Vtctld/GetTablets", "GetTablets", false},
+		{"/vtctlservice.Vtctld/GetAuditLog", "GetAuditLog", false},
+		{"/grpc.health.v1.Health/Check", "", false},
+	}
+	for _, tt := range tests {
+		action, audited := auditedAction(tt.fullMethod)
+		assert.Equal(t, tt.audited, audited, tt.fullMethod)
+		if audited {
+			assert.Equal(t, tt.action, action)
+		}
+	}
+}
+
+func TestAuditArgs(t *testing.T) {
+	req := &vtctldatapb.RotateTabletCertificatesRequest{
+		TabletAliases: []*topodatapb.TabletAlias{{Cell: "zone1", Uid: 100}},
+		Cert:          []byte("certificate"),
+		Key:           []byte("private key"),
+	}
+
+	args := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(auditArgs(req)), &args))
+	assert.Contains(t, args, "tabletAliases")
+	assert.NotContains(t, args, "cert", "the bytes fields are left out")
+	assert.NotContains(t, args, "key", "the bytes fields are left out")
+	assert.Equal(t, []byte("private key"), req.Key, "the request is left unchanged")
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+// This file contains the role-based authorization of the vtctld gRPC API:
+// each caller is identified by the username authenticated by the static auth
+// plugin, or else by the common name of its verified client certificate, and
+// is only permitted the RPCs of the roles that the policy grants it. The
+// calls are denied by default, and all but the read-only ones are logged for
+// auditing.
+
+// The roles of the vtctld RBAC policy, which are groups of RPCs. They do not
+// include each other, e.g. an on-call identity is usually granted both
+// RBACRoleReadOnly and RBACRoleEmergency.
+const (
+	// RBACRoleReadOnly is for the RPCs which do not change anything, e.g.
+	// GetTablets, Validate or PingTablet.
+	RBACRoleReadOnly = "read-only"
+	// RBACRoleEmergency is for the RPCs used to fix an outage, e.g. the
+	// reparents, ChangeTabletType or ForceUnlock.
+	RBACRoleEmergency = "emergency"
+	// RBACRoleSchemaChange is for the RPCs which change the schema or the
+	// vschema, and manage the schema migrations.
+	RBACRoleSchemaChange = "schema-change"
+	// RBACRoleAdmin is for all the RPCs, including the ones without another
+	// role and the commands of the legacy vtctl service.
+	RBACRoleAdmin = "admin"
+)
+
+var rbacRoles = []string{RBACRoleReadOnly, RBACRoleEmergency, RBACRoleSchemaChange, RBACRoleAdmin}
+
+// rbacMethodRoles are the roles of the RPCs of the Vtctld service. RBACRoleAdmin
+// is permitted all of them, and is the only role permitted the ones mapped to
+// it. Every RPC must be mapped, so that adding one requires choosing its role.
+var rbacMethodRoles = map[string]string{
+	"FindAllShardsInKeyspace":   RBACRoleReadOnly,
+	"GetAuditLog":               RBACRoleReadOnly,
+	"GetBackups":                RBACRoleReadOnly,
+	"GetCellInfo":               RBACRoleReadOnly,
+	"GetCellInfoNames":          RBACRoleReadOnly,
+	"GetCellsAliases":           RBACRoleReadOnly,
+	"GetClusterSetting":         RBACRoleReadOnly,
+	"GetFullStatus":             RBACRoleReadOnly,
+	"GetKeyspace":               RBACRoleReadOnly,
+	"GetKeyspaces":              RBACRoleReadOnly,
+	"GetLocks":                  RBACRoleReadOnly,
+	"GetMaintenances":           RBACRoleReadOnly,
+	"GetPermissions":            RBACRoleReadOnly,
+	"GetRoutingRules":           RBACRoleReadOnly,
+	"GetSchema":                 RBACRoleReadOnly,
+	"GetSchemaMigrations":       RBACRoleReadOnly,
+	"GetShard":                  RBACRoleReadOnly,
+	"GetShardRoutingRules":      RBACRoleReadOnly,
+	"GetSrvKeyspaceNames":       RBACRoleReadOnly,
+	"GetSrvKeyspaces":           RBACRoleReadOnly,
+	"GetSrvVSchema":             RBACRoleReadOnly,
+	"GetSrvVSchemas":            RBACRoleReadOnly,
+	"GetTablet":                 RBACRoleReadOnly,
+	"GetTablets":                RBACRoleReadOnly,
+	"GetTopologyPath":           RBACRoleReadOnly,
+	"GetTopoReadOnly":           RBACRoleReadOnly,
+	"GetUnresolvedTransactions": RBACRoleReadOnly,
+	"GetVersion":                RBACRoleReadOnly,
+	"GetVSchema":                RBACRoleReadOnly,
+	"GetWorkflows":              RBACRoleReadOnly,
+	"MountList":                 RBACRoleReadOnly,
+	"MountShow":                 RBACRoleReadOnly,
+	"PingTablet":                RBACRoleReadOnly,
+	"ReshardRecommend":          RBACRoleReadOnly,
+	"RunHealthCheck":            RBACRoleReadOnly,
+	"ShardReplicationPositions": RBACRoleReadOnly,
+	"Validate":                  RBACRoleReadOnly,
+	"ValidateBackup":            RBACRoleReadOnly,
+	"ValidateKeyspace":          RBACRoleReadOnly,
+	"ValidateSchemaKeyspace":    RBACRoleReadOnly,
+	"ValidateShard":             RBACRoleReadOnly,
+	"ValidateVersionKeyspace":   RBACRoleReadOnly,
+	"ValidateVersionShard":      RBACRoleReadOnly,
+	"ValidateVSchema":           RBACRoleReadOnly,
+	"VDiffShow":                 RBACRoleReadOnly,
+	"WorkflowStatus":            RBACRoleReadOnly,
+
+	"CancelMaintenance":          RBACRoleEmergency,
+	"ChangeTabletTags":           RBACRoleEmergency,
+	"ChangeTabletType":           RBACRoleEmergency,
+	"EmergencyReparentShard":     RBACRoleEmergency,
+	"ForceUnlock":                RBACRoleEmergency,
+	"PlannedReparentShard":       RBACRoleEmergency,
+	"RefreshState":               RBACRoleEmergency,
+	"RefreshStateByShard":        RBACRoleEmergency,
+	"ReparentTablet":             RBACRoleEmergency,
+	"ScheduleMaintenance":        RBACRoleEmergency,
+	"SetVtorcMaintenance":        RBACRoleEmergency,
+	"SetWritable":                RBACRoleEmergency,
+	"StartReplication":           RBACRoleEmergency,
+	"StopReplication":            RBACRoleEmergency,
+	"TabletExternallyReparented": RBACRoleEmergency,
+	"UpdateThrottlerConfig":      RBACRoleEmergency,
+
+	"ApplySchema":             RBACRoleSchemaChange,
+	"ApplyVSchema":            RBACRoleSchemaChange,
+	"CancelSchemaMigration":   RBACRoleSchemaChange,
+	"CleanupSchemaMigration":  RBACRoleSchemaChange,
+	"CompleteSchemaMigration": RBACRoleSchemaChange,
+	"LaunchSchemaMigration":   RBACRoleSchemaChange,
+	"RebuildVSchemaGraph":     RBACRoleSchemaChange,
+	"ReloadSchema":            RBACRoleSchemaChange,
+	"ReloadSchemaKeyspace":    RBACRoleSchemaChange,
+	"ReloadSchemaShard":       RBACRoleSchemaChange,
+	"RetrySchemaMigration":    RBACRoleSchemaChange,
+
+	"AddCellInfo":                    RBACRoleAdmin,
+	"AddCellsAlias":                  RBACRoleAdmin,
+	"ApplyRoutingRules":              RBACRoleAdmin,
+	"ApplyShardRoutingRules":         RBACRoleAdmin,
+	"Backup":                         RBACRoleAdmin,
+	"BackupShard":                    RBACRoleAdmin,
+	"ConcludeTransaction":            RBACRoleAdmin,
+	"CreateKeyspace":                 RBACRoleAdmin,
+	"CreateShard":                    RBACRoleAdmin,
+	"DelayedReplicaCreate":           RBACRoleAdmin,
+	"DelayedReplicaFastForward":      RBACRoleAdmin,
+	"DeleteCellInfo":                 RBACRoleAdmin,
+	"DeleteCellsAlias":               RBACRoleAdmin,
+	"DeleteKeyspace":                 RBACRoleAdmin,
+	"DeleteShards":                   RBACRoleAdmin,
+	"DeleteSrvVSchema":               RBACRoleAdmin,
+	"DeleteTablets":                  RBACRoleAdmin,
+	"ExecuteFetchAsApp":              RBACRoleAdmin,
+	"ExecuteFetchAsDBA":              RBACRoleAdmin,
+	"ExecuteHook":                    RBACRoleAdmin,
+	"InitShardPrimary":               RBACRoleAdmin,
+	"LookupVindexCreate":             RBACRoleAdmin,
+	"LookupVindexExternalize":        RBACRoleAdmin,
+	"MaterializeCreate":              RBACRoleAdmin,
+	"MigrateCreate":                  RBACRoleAdmin,
+	"MountRegister":                  RBACRoleAdmin,
+	"MountUnregister":                RBACRoleAdmin,
+	"MoveTablesComplete":             RBACRoleAdmin,
+	"MoveTablesCreate":               RBACRoleAdmin,
+	"PruneBackups":                   RBACRoleAdmin,
+	"RebuildKeyspaceGraph":           RBACRoleAdmin,
+	"RemoveBackup":                   RBACRoleAdmin,
+	"RemoveCell":                     RBACRoleAdmin,
+	"RemoveKeyspaceCell":             RBACRoleAdmin,
+	"RemoveShardCell":                RBACRoleAdmin,
+	"ReshardCreate":                  RBACRoleAdmin,
+	"RestoreFromBackup":              RBACRoleAdmin,
+	"RestoreToPointInTime":           RBACRoleAdmin,
+	"RotateTabletCertificates":       RBACRoleAdmin,
+	"SetClusterSetting":              RBACRoleAdmin,
+	"SetKeyspaceDurabilityPolicy":    RBACRoleAdmin,
+	"SetKeyspaceFailoverPreferences": RBACRoleAdmin,
+	"SetShardIsPrimaryServing":       RBACRoleAdmin,
+	"SetShardTabletControl":          RBACRoleAdmin,
+	"SetTopoReadOnly":                RBACRoleAdmin,
+	"ShardReplicationAdd":            RBACRoleAdmin,
+	"ShardReplicationFix":            RBACRoleAdmin,
+	"ShardReplicationRemove":         RBACRoleAdmin,
+	"SleepTablet":                    RBACRoleAdmin,
+	"SourceShardAdd":                 RBACRoleAdmin,
+	"SourceShardDelete":              RBACRoleAdmin,
+	"TopoBackup":                     RBACRoleAdmin,
+	"TopoGC":                         RBACRoleAdmin,
+	"TopoRestore":                    RBACRoleAdmin,
+	"UpdateCellInfo":                 RBACRoleAdmin,
+	"UpdateCellsAlias":               RBACRoleAdmin,
+	"VDiffCreate":                    RBACRoleAdmin,
+	"VDiffDelete":                    RBACRoleAdmin,
+	"VDiffResume":                    RBACRoleAdmin,
+	"VDiffStop":                      RBACRoleAdmin,
+	"WorkflowDelete":                 RBACRoleAdmin,
+	"WorkflowSwitchTraffic":          RBACRoleAdmin,
+	"WorkflowUpdate":                 RBACRoleAdmin,
+}
+
+var (
+	rbacFile string
+
+	rbacDenied = stats.NewCountersWithMultiLabels(
+		"VtctldRBACDenied",
+		"vtctld RPCs denied by the RBAC policy",
+		[]string{"Method", "Identity"})
+)
+
+func init() {
+	for _, cmd := range []string{"vtctld", "vtcombo"} {
+		servenv.OnParseFor(cmd, registerRBACFlags)
+	}
+}
+
+func registerRBACFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&rbacFile, "vtctld_rbac_file", rbacFile, "JSON file with the RBAC policy of the vtctld gRPC API, which grants each identity roles. Everything is permitted if empty.")
+}
+
+// RBACPolicy is the RBAC policy of the vtctld gRPC API, as read from
+// --vtctld_rbac_file.
+type RBACPolicy struct {
+	Identities map[string]*RBACIdentity `json:"identities"`
+}
+
+// RBACIdentity holds the roles granted to an identity, which is a username of
+// the static auth plugin or the common name of a client certificate.
+type RBACIdentity struct {
+	Roles []string `json:"roles"`
+}
+
+// LoadRBACPolicy reads and validates a policy from a JSON file.
+func LoadRBACPolicy(file string) (*RBACPolicy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	policy := &RBACPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("cannot parse the vtctld RBAC policy in %v: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid vtctld RBAC policy in %v: %w", file, err)
+	}
+	return policy, nil
+}
+
+func (policy *RBACPolicy) validate() error {
+	for name, identity := range policy.Identities {
+		if identity == nil {
+			return fmt.Errorf("identity %v has no roles", name)
+		}
+		for _, role := range identity.Roles {
+			if !slices.Contains(rbacRoles, role) {
+				return fmt.Errorf("identity %v has unknown role %q, must be one of %v", name, role, strings.Join(rbacRoles, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// InitRBAC enforces the policy of --vtctld_rbac_file, if any, on the calls to
+// the vtctld services of servenv.GRPCServer. It must be called before the
+// server is created, e.g. in an OnInit hook.
+func InitRBAC() error {
+	if rbacFile == "" {
+		return nil
+	}
+	policy, err := LoadRBACPolicy(rbacFile)
+	if err != nil {
+		return err
+	}
+	rbac := NewRBAC(policy)
+	servenv.AddGRPCServerInterceptors(rbac.StreamServerInterceptor, rbac.UnaryServerInterceptor)
+	log.Infof("vtctld RBAC enabled with the policy of %v for %d identities", rbacFile, len(policy.Identities))
+	return nil
+}
+
+// RBAC authorizes the calls to the vtctld services according to a policy.
+type RBAC struct {
+	policy *RBACPolicy
+}
+
+// NewRBAC returns an RBAC enforcing the policy.
+func NewRBAC(policy *RBACPolicy) *RBAC {
+	return &RBAC{policy: policy}
+}
+
+// UnaryServerInterceptor authorizes the unary calls to the vtctld services.
+func (rbac *RBAC) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := rbac.Authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor authorizes the streaming calls to the vtctld
+// services.
+func (rbac *RBAC) StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := rbac.Authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// Authorize returns an error if the caller of ctx is not permitted the method,
+// a full gRPC method name as "/vtctlservice.Vtctld/GetTablets". The methods
+// of the other services are always permitted.
+func (rbac *RBAC) Authorize(ctx context.Context, fullMethod string) error {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok || (service != vtctlservicepb.Vtctld_ServiceDesc.ServiceName && service != vtctlservicepb.Vtctl_ServiceDesc.ServiceName) {
+		return nil
+	}
+
+	role := RBACRoleAdmin
+	if service == vtctlservicepb.Vtctld_ServiceDesc.ServiceName {
+		if r, ok := rbacMethodRoles[method]; ok {
+			role = r
+		}
+	}
+
+	identity := callerIdentity(ctx)
+	if identity == "" {
+		rbacDenied.Add([]string{method, ""}, 1)
+		log.Warningf("vtctld audit: identity=<none> method=%v role=%v result=denied", method, role)
+		return status.Errorf(codes.Unauthenticated, "the caller of %v is not identified by a client certificate or static auth credentials", method)
+	}
+
+	var roles []string
+	if grant, ok := rbac.policy.Identities[identity]; ok {
+		roles = grant.Roles
+	}
+	if !slices.Contains(roles, role) && !slices.Contains(roles, RBACRoleAdmin) {
+		rbacDenied.Add([]string{method, identity}, 1)
+		log.Warningf("vtctld audit: identity=%v method=%v role=%v result=denied", identity, method, role)
+		return status.Errorf(codes.PermissionDenied, "identity %v is not permitted %v, which requires the %v role", identity, method, role)
+	}
+
+	if role != RBACRoleReadOnly {
+		log.Infof("vtctld audit: identity=%v method=%v role=%v result=permitted", identity, method, role)
+	}
+	return nil
+}
+
+// callerIdentity returns the username authenticated by the static auth
+// plugin, or else the common name of the verified client certificate of the
+// caller, if any.
+func callerIdentity(ctx context.Context) string {
+	if username := servenv.StaticAuthUsernameFromContext(ctx); username != "" {
+		return username
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+func TestRBACMethodRoles(t *testing.T) {
+	methods := map[string]bool{}
+	for _, method := range vtctlservicepb.Vtctld_ServiceDesc.Methods {
+		methods[method.MethodName] = true
+	}
+	for _, stream := range vtctlservicepb.Vtctld_ServiceDesc.Streams {
+		methods[stream.StreamName] = true
+	}
+
+	for method, role := range rbacMethodRoles {
+		assert.True(t, methods[method], "%v is not a method of the Vtctld service", method)
+		assert.Contains(t, rbacRoles, role)
+	}
+	// New methods must be given a role explicitly, even if it is
+	// RBACRoleAdmin, rather than being admin-only by omission.
+	for method := range methods {
+		assert.Contains(t, rbacMethodRoles, method, "%v has no role", method)
+	}
+}
+
+func TestLoadRBACPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	file := path.Join(dir, "rbac.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"identities": {"oncall": {"roles": ["read-only", "emergency"]}}}`), 0o644))
+	policy, err := LoadRBACPolicy(file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{RBACRoleReadOnly, RBACRoleEmergency}, policy.Identities["oncall"].Roles)
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"identities": {"oncall": {"roles": ["superuser"]}}}`), 0o644))
+	_, err = LoadRBACPolicy(file)
+	assert.ErrorContains(t, err, `unknown role "superuser"`)
+}
+
+func TestRBACAuthorize(t *testing.T) {
+	rbac := NewRBAC(&RBACPolicy{
+		Identities: map[string]*RBACIdentity{
+			"dashboard": {Roles: []string{RBACRoleReadOnly}},
+			"oncall":    {Roles: []string{RBACRoleReadOnly, RBACRoleEmergency}},
+			"deployer":  {Roles: []string{RBACRoleSchemaChange}},
+			"admin":     {Roles: []string{RBACRoleAdmin}},
+		},
+	})
+
+	// clientContext returns the context of a call with a verified client
+	// certificate of the common name, if any.
+	clientContext := func(commonName string) context.Context {
+		ctx := context.Background()
+		if commonName == "" {
+			return ctx
+		}
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+		return peer.NewContext(ctx, &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+	}
+
+	tests := []struct {
+		identity string
+		method   string
+		code     codes.Code
+	}{
+		{"dashboard", "/vtctlservice.Vtctld/GetTablets", codes.OK},
+		{"dashboard", "/vtctlservice.Vtctld/PlannedReparentShard", codes.PermissionDenied},
+		{"oncall", "/vtctlservice.Vtctld/EmergencyReparentShard", codes.OK},
+		{"oncall", "/vtctlservice.Vtctld/ApplySchema", codes.PermissionDenied},
+		{"deployer", "/vtctlservice.Vtctld/ApplySchema", codes.OK},
+		{"deployer", "/vtctlservice.Vtctld/GetTablets", codes.PermissionDenied},
+		// The RPCs without a role, and the legacy vtctl service, are only
+		// permitted to the admins.
+		{"oncall", "/vtctlservice.Vtctld/DeleteKeyspace", codes.PermissionDenied},
+		{"oncall", "/vtctlservice.Vtctl/ExecuteVtctlCommand", codes.PermissionDenied},
+		{"admin", "/vtctlservice.Vtctld/DeleteKeyspace", codes.OK},
+		{"admin", "/vtctlservice.Vtctl/ExecuteVtctlCommand", codes.OK},
+		// The unknown and unidentified callers are denied.
+		{"intruder", "/vtctlservice.Vtctld/GetTablets", codes.PermissionDenied},
+		{"", "/vtctlservice.Vtctld/GetTablets", codes.Unauthenticated},
+		// The other services are not authorized by the RBAC.
+		{"", "/grpc.health.v1.Health/Check", codes.OK},
+	}
+	for _, tt := range tests {
+		err := rbac.Authorize(clientContext(tt.identity), tt.method)
+		assert.Equal(t, tt.code, status.Code(err), "%v calling %v: %v", tt.identity, tt.method, err)
+	}
+}
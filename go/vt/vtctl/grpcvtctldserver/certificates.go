@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttls"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// rotateTabletCertificates rotates the certificates of a tablet as requested,
+// then checks that a new connection to the tablet is served the certificate
+// which the tablet reports. The errors are reported in the rotation, rather
+// than returned, so that they do not stop the rotation of the other tablets.
+func (s *VtctldServer) rotateTabletCertificates(ctx context.Context, alias *topodatapb.TabletAlias, req *vtctldatapb.RotateTabletCertificatesRequest) *vtctldatapb.TabletCertificateRotation {
+	rotation := &vtctldatapb.TabletCertificateRotation{TabletAlias: alias}
+
+	ti, err := s.ts.GetTablet(ctx, alias)
+	if err != nil {
+		rotation.Error = err.Error()
+		return rotation
+	}
+
+	resp, err := s.tmc.ReloadTLSCertificates(ctx, ti.Tablet, &tabletmanagerdatapb.ReloadTLSCertificatesRequest{
+		Cert: req.Cert,
+		Key:  req.Key,
+		Ca:   req.Ca,
+	})
+	if err != nil {
+		rotation.Error = err.Error()
+		return rotation
+	}
+	rotation.Certificate = resp.Certificate
+	if rotation.Certificate == nil {
+		rotation.Error = "the tablet did not report the certificate it serves"
+		return rotation
+	}
+
+	served, err := servedTabletCertificate(ctx, ti.Tablet)
+	if err != nil {
+		rotation.Error = vterrors.Wrapf(err, "cannot check the certificate served by %s", topoproto.TabletAliasString(alias)).Error()
+		return rotation
+	}
+	if fingerprint := vttls.Fingerprint(served); fingerprint != rotation.Certificate.Sha256Fingerprint {
+		rotation.Error = fmt.Sprintf("the tablet serves the certificate %s rather than %s", fingerprint, rotation.Certificate.Sha256Fingerprint)
+		return rotation
+	}
+
+	rotation.Verified = true
+	return rotation
+}
+
+// servedTabletCertificate returns the certificate served by the gRPC server of
+// a tablet to a new TLS connection. It is a variable so that the tests can
+// replace it.
+var servedTabletCertificate = func(ctx context.Context, tablet *topodatapb.Tablet) (*x509.Certificate, error) {
+	port, ok := tablet.PortMap["grpc"]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "tablet %s has no grpc port", topoproto.TabletAliasString(tablet.Alias))
+	}
+
+	// The served certificate is only compared with the one reported by the
+	// tablet, so it does not need to be trusted. It is captured as soon as it
+	// is received, since the handshake fails later on if the tablet requires
+	// a client certificate.
+	var served *x509.Certificate
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{"h2"},
+			VerifyConnection: func(cs tls.ConnectionState) error {
+				if len(cs.PeerCertificates) > 0 {
+					served = cs.PeerCertificates[0]
+				}
+				return nil
+			},
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", netutil.JoinHostPort(tablet.Hostname, port))
+	if conn != nil {
+		conn.Close()
+	}
+	if served != nil {
+		return served, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no certificate served by %s", topoproto.TabletAliasString(tablet.Alias))
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// This file contains the scheduler of the maintenances of the shards, which
+// are stored in the global topo by ScheduleMaintenance. Every vtctld runs it,
+// and they claim each maintenance with a versioned update of its record so
+// that only one of them runs it.
+
+var (
+	maintenanceSchedulerInterval = 30 * time.Second
+	maintenanceHistoryRetention  = 7 * 24 * time.Hour
+
+	maintenanceActions = stats.NewCountersWithMultiLabels(
+		"VtctldScheduledMaintenances",
+		"Scheduled maintenances run by this vtctld, by action and outcome",
+		[]string{"Action", "State"})
+)
+
+// maintenanceActionTimeout bounds each action of the scheduler. A planned
+// reparent still running after twice as long was interrupted.
+const maintenanceActionTimeout = 10 * time.Minute
+
+func init() {
+	for _, cmd := range []string{"vtctld", "vtcombo"} {
+		servenv.OnParseFor(cmd, registerMaintenanceFlags)
+	}
+}
+
+func registerMaintenanceFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&maintenanceSchedulerInterval, "maintenance_scheduler_interval", maintenanceSchedulerInterval, "How often vtctld starts and ends the scheduled maintenances whose window starts or ends. The scheduler is disabled if 0.")
+	fs.DurationVar(&maintenanceHistoryRetention, "maintenance_history_retention", maintenanceHistoryRetention, "How long the finished scheduled maintenances are kept in the topo.")
+}
+
+// StartMaintenanceScheduler runs the scheduled maintenances in the
+// background, until the returned function is called.
+func StartMaintenanceScheduler(ts *topo.Server) (stop func()) {
+	if maintenanceSchedulerInterval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s := NewVtctldServer(ts)
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(maintenanceSchedulerInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.runMaintenances(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Warningf("Cannot run the scheduled maintenances: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// runMaintenances starts and ends the scheduled maintenances whose window
+// started or ended at now, and deletes the ones which finished longer ago than
+// --maintenance_history_retention.
+func (s *VtctldServer) runMaintenances(ctx context.Context, now time.Time) error {
+	ids, err := s.ts.GetScheduledMaintenanceIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.runMaintenance(ctx, id, now); err != nil && !topo.IsErrType(err, topo.BadVersion) && !topo.IsErrType(err, topo.NoNode) {
+			// Another vtctld changing the maintenance concurrently is not an
+			// error, it will have handled it.
+			log.Warningf("Cannot run the scheduled maintenance %v: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (s *VtctldServer) runMaintenance(ctx context.Context, id string, now time.Time) error {
+	maintenance, version, err := s.ts.GetScheduledMaintenance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	windowStart := protoutil.TimeFromProto(maintenance.WindowStart)
+	windowEnd := protoutil.TimeFromProto(maintenance.WindowEnd)
+
+	switch maintenance.State {
+	case topodatapb.ScheduledMaintenance_PENDING:
+		switch {
+		case now.Before(windowStart):
+			return nil
+		case !now.Before(windowEnd):
+			finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_MISSED, "the window ended before the maintenance could start", now)
+			_, err = s.ts.UpdateScheduledMaintenance(ctx, maintenance, version)
+			return err
+		}
+
+		// Claim the maintenance first, so that no other vtctld starts it.
+		maintenance.State = topodatapb.ScheduledMaintenance_RUNNING
+		maintenance.Started = protoutil.TimeToProto(now)
+		if version, err = s.ts.UpdateScheduledMaintenance(ctx, maintenance, version); err != nil {
+			return err
+		}
+		log.Infof("Starting the scheduled maintenance %v: %v of %v/%v", id, maintenance.Action, maintenance.Keyspace, maintenance.Shard)
+
+		s.startMaintenance(ctx, maintenance)
+		_, err = s.ts.UpdateScheduledMaintenance(ctx, maintenance, version)
+		return err
+	case topodatapb.ScheduledMaintenance_RUNNING:
+		switch maintenance.Action {
+		case topodatapb.ScheduledMaintenance_DRAIN_TABLET:
+			if now.Before(windowEnd) {
+				return nil
+			}
+			if err := s.undrainMaintenanceTablet(ctx, maintenance); err != nil {
+				finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, fmt.Sprintf("cannot restore the type of tablet %v: %v", topoproto.TabletAliasString(maintenance.TabletAlias), err), now)
+			} else {
+				finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_DONE, "", now)
+			}
+		default:
+			// The vtctld which started it finishes it, unless it was
+			// interrupted.
+			if now.Before(protoutil.TimeFromProto(maintenance.Started).Add(2 * maintenanceActionTimeout)) {
+				return nil
+			}
+			finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, "the vtctld running the maintenance was interrupted", now)
+		}
+		_, err = s.ts.UpdateScheduledMaintenance(ctx, maintenance, version)
+		return err
+	default:
+		if maintenance.Finished == nil || now.Sub(protoutil.TimeFromProto(maintenance.Finished)) < maintenanceHistoryRetention {
+			return nil
+		}
+		return s.ts.DeleteScheduledMaintenance(ctx, id, version)
+	}
+}
+
+// startMaintenance runs the action of a maintenance whose window started, and
+// updates it with the outcome.
+func (s *VtctldServer) startMaintenance(ctx context.Context, maintenance *topodatapb.ScheduledMaintenance) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceActionTimeout)
+	defer cancel()
+
+	switch maintenance.Action {
+	case topodatapb.ScheduledMaintenance_PLANNED_REPARENT:
+		resp, err := s.PlannedReparentShard(ctx, &vtctldatapb.PlannedReparentShardRequest{
+			Keyspace:   maintenance.Keyspace,
+			Shard:      maintenance.Shard,
+			NewPrimary: maintenance.TabletAlias,
+		})
+		if err != nil {
+			finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, err.Error(), time.Now())
+			return
+		}
+		finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_DONE, "promoted "+topoproto.TabletAliasString(resp.PromotedPrimary), time.Now())
+	case topodatapb.ScheduledMaintenance_DRAIN_TABLET:
+		tablet, err := s.ts.GetTablet(ctx, maintenance.TabletAlias)
+		if err != nil {
+			finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, err.Error(), time.Now())
+			return
+		}
+		if tablet.Type == topodatapb.TabletType_DRAINED {
+			finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, "the tablet is already drained", time.Now())
+			return
+		}
+		if _, err := s.ChangeTabletType(ctx, &vtctldatapb.ChangeTabletTypeRequest{
+			TabletAlias: maintenance.TabletAlias,
+			DbType:      topodatapb.TabletType_DRAINED,
+		}); err != nil {
+			finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, err.Error(), time.Now())
+			return
+		}
+		maintenance.DrainedFromType = tablet.Type
+		maintenance.Message = fmt.Sprintf("drained from %v", topoproto.TabletTypeLString(tablet.Type))
+	default:
+		finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_FAILED, fmt.Sprintf("unknown action %v", maintenance.Action), time.Now())
+	}
+}
+
+// undrainMaintenanceTablet changes the tablet drained by a maintenance back to
+// its previous type.
+func (s *VtctldServer) undrainMaintenanceTablet(ctx context.Context, maintenance *topodatapb.ScheduledMaintenance) error {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceActionTimeout)
+	defer cancel()
+
+	_, err := s.ChangeTabletType(ctx, &vtctldatapb.ChangeTabletTypeRequest{
+		TabletAlias: maintenance.TabletAlias,
+		DbType:      maintenance.DrainedFromType,
+	})
+	return err
+}
+
+func finishMaintenance(maintenance *topodatapb.ScheduledMaintenance, state topodatapb.ScheduledMaintenance_State, message string, now time.Time) {
+	maintenance.State = state
+	maintenance.Message = message
+	maintenance.Finished = protoutil.TimeToProto(now)
+
+	maintenanceActions.Add([]string{maintenance.Action.String(), state.String()}, 1)
+	log.Infof("Scheduled maintenance %v of %v/%v finished as %v: %v", maintenance.Id, maintenance.Keyspace, maintenance.Shard, state, message)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestScheduledMaintenances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	s := NewTestVtctldServer(ts, &testutil.TabletManagerClient{TopoServer: ts})
+
+	replica := &topodatapb.TabletAlias{Cell: "zone1", Uid: 101}
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		&topodatapb.Tablet{
+			Alias:    replica,
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+	)
+
+	now := time.Now()
+	schedule := func(action topodatapb.ScheduledMaintenance_Action, alias *topodatapb.TabletAlias, start, end time.Time) (*topodatapb.ScheduledMaintenance, error) {
+		resp, err := s.ScheduleMaintenance(ctx, &vtctldatapb.ScheduleMaintenanceRequest{
+			Keyspace:    "ks",
+			Shard:       "-",
+			Action:      action,
+			TabletAlias: alias,
+			WindowStart: protoutil.TimeToProto(start),
+			WindowEnd:   protoutil.TimeToProto(end),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Maintenance, nil
+	}
+	get := func(id string) *topodatapb.ScheduledMaintenance {
+		maintenance, _, err := ts.GetScheduledMaintenance(ctx, id)
+		require.NoError(t, err)
+		return maintenance
+	}
+	tabletType := func() topodatapb.TabletType {
+		tablet, err := ts.GetTablet(ctx, replica)
+		require.NoError(t, err)
+		return tablet.Type
+	}
+
+	_, err := schedule(topodatapb.ScheduledMaintenance_DRAIN_TABLET, nil, now, now.Add(time.Hour))
+	assert.ErrorContains(t, err, "the tablet to drain is required")
+	_, err = schedule(topodatapb.ScheduledMaintenance_DRAIN_TABLET, &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}, now, now.Add(time.Hour))
+	assert.ErrorContains(t, err, "cannot be drained")
+	_, err = schedule(topodatapb.ScheduledMaintenance_PLANNED_REPARENT, nil, now, now.Add(-time.Hour))
+	assert.ErrorContains(t, err, "before it starts")
+
+	drain, err := schedule(topodatapb.ScheduledMaintenance_DRAIN_TABLET, replica, now.Add(time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	missed, err := schedule(topodatapb.ScheduledMaintenance_PLANNED_REPARENT, nil, now.Add(time.Minute), now.Add(30*time.Minute))
+	require.NoError(t, err)
+	cancelled, err := schedule(topodatapb.ScheduledMaintenance_PLANNED_REPARENT, nil, now.Add(3*time.Hour), now.Add(4*time.Hour))
+	require.NoError(t, err)
+
+	_, err = s.CancelMaintenance(ctx, &vtctldatapb.CancelMaintenanceRequest{Id: cancelled.Id})
+	require.NoError(t, err)
+	assert.Equal(t, topodatapb.ScheduledMaintenance_CANCELLED, get(cancelled.Id).State)
+	_, err = s.CancelMaintenance(ctx, &vtctldatapb.CancelMaintenanceRequest{Id: cancelled.Id})
+	assert.ErrorContains(t, err, "already finished as CANCELLED")
+
+	// Nothing starts before its window.
+	require.NoError(t, s.runMaintenances(ctx, now))
+	assert.Equal(t, topodatapb.ScheduledMaintenance_PENDING, get(drain.Id).State)
+	assert.Equal(t, topodatapb.TabletType_RDONLY, tabletType())
+
+	// The drain starts with its window, and the reparent whose window ended
+	// is missed.
+	require.NoError(t, s.runMaintenances(ctx, now.Add(time.Hour)))
+	assert.Equal(t, topodatapb.ScheduledMaintenance_RUNNING, get(drain.Id).State)
+	assert.Equal(t, topodatapb.TabletType_RDONLY, get(drain.Id).DrainedFromType)
+	assert.Equal(t, topodatapb.TabletType_DRAINED, tabletType())
+	assert.Equal(t, topodatapb.ScheduledMaintenance_MISSED, get(missed.Id).State)
+
+	resp, err := s.GetMaintenances(ctx, &vtctldatapb.GetMaintenancesRequest{
+		Keyspace: "ks",
+		States:   []topodatapb.ScheduledMaintenance_State{topodatapb.ScheduledMaintenance_RUNNING},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Maintenances, 1)
+	assert.Equal(t, drain.Id, resp.Maintenances[0].Id)
+
+	// The drained tablet gets its type back at the end of the window.
+	require.NoError(t, s.runMaintenances(ctx, now.Add(2*time.Hour)))
+	assert.Equal(t, topodatapb.ScheduledMaintenance_DONE, get(drain.Id).State)
+	assert.Equal(t, topodatapb.TabletType_RDONLY, tabletType())
+
+	// The finished maintenances are pruned after the retention.
+	require.NoError(t, s.runMaintenances(ctx, now.Add(2*time.Hour+maintenanceHistoryRetention)))
+	_, _, err = ts.GetScheduledMaintenance(ctx, drain.Id)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "%v", err)
+	resp, err = s.GetMaintenances(ctx, &vtctldatapb.GetMaintenancesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Maintenances)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/onlineddl"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// preflightMigrationRowsPerSecond is the rate at which a vitess migration is
+// estimated to copy the rows of a table, for the preflight reports of
+// ApplySchema. It is a rough estimate: the actual rate depends on the size of
+// the rows, the load of the primary and the throttler.
+const preflightMigrationRowsPerSecond = 10_000
+
+// applySchemaPreflight reports the impact of the statements of an ApplySchema
+// request on the schema of the primary of each shard of the keyspace, without
+// applying them. The statements are applied in order, each to the schema
+// resulting from the previous ones.
+func (s *VtctldServer) applySchemaPreflight(ctx context.Context, req *vtctldatapb.ApplySchemaRequest) (*vtctldatapb.ApplySchemaResponse, error) {
+	stmts := make([]sqlparser.Statement, 0, len(req.Sql))
+	for _, sql := range req.Sql {
+		stmt, err := sqlparser.Parse(sql)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "failed to parse sql: %s, got error: %v", sql, err)
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	shardNames, err := s.ts.GetShardNames(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg            sync.WaitGroup
+		rec           concurrency.AllErrorRecorder
+		shardsImpacts = make([][]*vtctldatapb.SchemaChangeImpact, len(shardNames))
+	)
+	for i, shardName := range shardNames {
+		wg.Add(1)
+		go func(i int, shardName string) {
+			defer wg.Done()
+
+			impacts, err := s.preflightShard(ctx, req.Keyspace, shardName, req.Sql, stmts)
+			if err != nil {
+				rec.RecordError(vterrors.Wrapf(err, "shard %s/%s", req.Keyspace, shardName))
+				return
+			}
+			shardsImpacts[i] = impacts
+		}(i, shardName)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	resp := &vtctldatapb.ApplySchemaResponse{}
+	for _, impacts := range shardsImpacts {
+		resp.PreflightImpacts = append(resp.PreflightImpacts, impacts...)
+	}
+	return resp, nil
+}
+
+// preflightShard reports the impact of the statements on the schema of the
+// primary of a shard.
+func (s *VtctldServer) preflightShard(ctx context.Context, keyspace string, shardName string, sqls []string, stmts []sqlparser.Statement) ([]*vtctldatapb.SchemaChangeImpact, error) {
+	shard, err := s.ts.GetShard(ctx, keyspace, shardName)
+	if err != nil {
+		return nil, err
+	}
+	if !shard.HasPrimary() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard does not have a primary")
+	}
+	primary, err := s.ts.GetTablet(ctx, shard.PrimaryAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	capableOf, err := s.tabletCapableOf(ctx, primary.Tablet)
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := s.tmc.GetSchema(ctx, primary.Tablet, &tabletmanagerdatapb.GetSchemaRequest{IncludeViews: true})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "GetSchema(%s)", topoproto.TabletAliasString(primary.Alias))
+	}
+	tables := make(map[string]*tabletmanagerdatapb.TableDefinition, len(sd.TableDefinitions))
+	queries := make([]string, 0, len(sd.TableDefinitions))
+	for _, td := range sd.TableDefinitions {
+		tables[td.Name] = td
+		queries = append(queries, td.Schema)
+	}
+	schema, err := schemadiff.NewSchemaFromQueries(queries)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot load the schema of %s", topoproto.TabletAliasString(primary.Alias))
+	}
+
+	impacts := make([]*vtctldatapb.SchemaChangeImpact, 0, len(stmts))
+	for i, stmt := range stmts {
+		impact := &vtctldatapb.SchemaChangeImpact{
+			Shard: shardName,
+			Sql:   sqls[i],
+		}
+		impacts = append(impacts, impact)
+
+		ddl, ok := stmt.(sqlparser.DDLStatement)
+		if !ok {
+			impact.Error = "not a table or view DDL statement, its impact is unknown"
+			continue
+		}
+		var names []string
+		for _, table := range ddl.AffectedTables() {
+			names = append(names, table.Name.String())
+		}
+		impact.Table = strings.Join(names, ",")
+
+		applied, err := schema.ApplyStatement(ddl)
+		if err != nil {
+			impact.Error = err.Error()
+			continue
+		}
+		schemaDiff, err := schema.SchemaDiff(applied, &schemadiff.DiffHints{})
+		if err != nil {
+			impact.Error = err.Error()
+			continue
+		}
+		schema = applied
+
+		var (
+			diffs      []string
+			isAlter    bool
+			notInstant bool
+		)
+		for _, diff := range schemaDiff.UnorderedDiffs() {
+			impact.DataLossRisks = append(impact.DataLossRisks, schemadiff.DataLossRisks(diff)...)
+			for _, diff := range schemadiff.AllSubsequent(diff) {
+				diffs = append(diffs, diff.CanonicalStatementString())
+
+				alterDiff, ok := diff.(*schemadiff.AlterTableEntityDiff)
+				if !ok {
+					notInstant = true
+					continue
+				}
+				isAlter = true
+				from, _ := alterDiff.Entities()
+				plan, err := onlineddl.AnalyzeInstantDDL(alterDiff.AlterTable(), from.(*schemadiff.CreateTableEntity).CreateTable, capableOf)
+				if err != nil {
+					return nil, err
+				}
+				if plan == nil {
+					notInstant = true
+				}
+			}
+		}
+		impact.Diff = strings.Join(diffs, "; ")
+		for _, name := range names {
+			if td, ok := tables[name]; ok {
+				impact.TableRows += td.RowCount
+				impact.TableDataLength += td.DataLength
+			}
+		}
+		if !isAlter {
+			continue
+		}
+		impact.InstantPossible = !notInstant
+		impact.EstimatedMigrationDuration = protoutil.DurationToProto(time.Duration(impact.TableRows) * time.Second / preflightMigrationRowsPerSecond)
+	}
+	return impacts, nil
+}
+
+// tabletCapableOf returns the capabilities of the MySQL server of a tablet.
+func (s *VtctldServer) tabletCapableOf(ctx context.Context, tablet *topodatapb.Tablet) (mysql.CapableOf, error) {
+	qr, err := s.tmc.ExecuteFetchAsDba(ctx, tablet, false, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte("select @@global.version"),
+		MaxRows: 1,
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot get the MySQL version of %s", topoproto.TabletAliasString(tablet.Alias))
+	}
+	result := sqltypes.Proto3ToResult(qr)
+	if len(result.Rows) != 1 || len(result.Rows[0]) != 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for the MySQL version of %s: %v", topoproto.TabletAliasString(tablet.Alias), result.Rows)
+	}
+	_, capableOf, _ := mysql.GetFlavor(result.Rows[0][0].ToString(), nil)
+	return capableOf, nil
+}
@@ -25,11 +25,13 @@ import (
 	"net/http"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc"
 
@@ -40,8 +42,11 @@ import (
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/dtids"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
@@ -240,6 +245,12 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 		ctx = callerid.NewContext(ctx, req.CallerId, &querypb.VTGateCallerID{Username: req.CallerId.Principal})
 	}
 
+	if req.Preflight {
+		span.Annotate("preflight", true)
+		resp, err = s.applySchemaPreflight(ctx, req)
+		return resp, err
+	}
+
 	executionUUID, err := schema.CreateUUID()
 	if err != nil {
 		err = vterrors.Wrapf(err, "unable to create execution UUID")
@@ -471,7 +482,15 @@ func (s *VtctldServer) BackupShard(req *vtctldatapb.BackupShardRequest, stream v
 
 	span.Annotate("tablet_alias", topoproto.TabletAliasString(backupTablet.Alias))
 
-	r := &vtctldatapb.BackupRequest{Concurrency: req.Concurrency, AllowPrimary: req.AllowPrimary, UpgradeSafe: req.UpgradeSafe, IncrementalFromPos: req.IncrementalFromPos}
+	r := &vtctldatapb.BackupRequest{
+		Concurrency:           req.Concurrency,
+		AllowPrimary:          req.AllowPrimary,
+		UpgradeSafe:           req.UpgradeSafe,
+		IncrementalFromPos:    req.IncrementalFromPos,
+		CompressionEngine:     req.CompressionEngine,
+		CompressionLevel:      req.CompressionLevel,
+		CompressionDictionary: req.CompressionDictionary,
+	}
 	err = s.backupTablet(ctx, backupTablet, r, stream)
 	return err
 }
@@ -480,27 +499,33 @@ func (s *VtctldServer) backupTablet(ctx context.Context, tablet *topodatapb.Tabl
 	Send(resp *vtctldatapb.BackupResponse) error
 }) error {
 	r := &tabletmanagerdatapb.BackupRequest{
-		Concurrency:        int64(req.Concurrency),
-		AllowPrimary:       req.AllowPrimary,
-		IncrementalFromPos: req.IncrementalFromPos,
-		UpgradeSafe:        req.UpgradeSafe,
+		Concurrency:           int64(req.Concurrency),
+		AllowPrimary:          req.AllowPrimary,
+		IncrementalFromPos:    req.IncrementalFromPos,
+		UpgradeSafe:           req.UpgradeSafe,
+		CompressionEngine:     req.CompressionEngine,
+		CompressionLevel:      req.CompressionLevel,
+		CompressionDictionary: req.CompressionDictionary,
 	}
-	logStream, err := s.tmc.Backup(ctx, tablet, r)
+	backupStream, err := s.tmc.Backup(ctx, tablet, r)
 	if err != nil {
 		return err
 	}
 
 	logger := logutil.NewConsoleLogger()
 	for {
-		event, err := logStream.Recv()
+		br, err := backupStream.Recv()
 		switch err {
 		case nil:
-			logutil.LogEvent(logger, event)
+			if br.Event != nil {
+				logutil.LogEvent(logger, br.Event)
+			}
 			resp := &vtctldatapb.BackupResponse{
 				TabletAlias: tablet.Alias,
 				Keyspace:    tablet.Keyspace,
 				Shard:       tablet.Shard,
-				Event:       event,
+				Event:       br.Event,
+				Progress:    br.Progress,
 			}
 			if err := stream.Send(resp); err != nil {
 				logger.Errorf("failed to send stream response %+v: %v", resp, err)
@@ -513,6 +538,41 @@ func (s *VtctldServer) backupTablet(ctx context.Context, tablet *topodatapb.Tabl
 	}
 }
 
+// CancelMaintenance is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) CancelMaintenance(ctx context.Context, req *vtctldatapb.CancelMaintenanceRequest) (resp *vtctldatapb.CancelMaintenanceResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.CancelMaintenance")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("id", req.Id)
+
+	maintenance, version, err := s.ts.GetScheduledMaintenance(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case maintenance.State == topodatapb.ScheduledMaintenance_PENDING:
+		finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_CANCELLED, "cancelled before it started", time.Now())
+	case maintenance.State == topodatapb.ScheduledMaintenance_RUNNING && maintenance.Action == topodatapb.ScheduledMaintenance_DRAIN_TABLET:
+		if err := s.undrainMaintenanceTablet(ctx, maintenance); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot restore the type of tablet %v", topoproto.TabletAliasString(maintenance.TabletAlias))
+		}
+		finishMaintenance(maintenance, topodatapb.ScheduledMaintenance_CANCELLED, "cancelled before the end of the window", time.Now())
+	case maintenance.State == topodatapb.ScheduledMaintenance_RUNNING:
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "maintenance %v is running a %v, which cannot be cancelled", req.Id, maintenance.Action)
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "maintenance %v already finished as %v", req.Id, maintenance.State)
+	}
+
+	if _, err := s.ts.UpdateScheduledMaintenance(ctx, maintenance, version); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.CancelMaintenanceResponse{Maintenance: maintenance}, nil
+}
+
 // CancelSchemaMigration is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) CancelSchemaMigration(ctx context.Context, req *vtctldatapb.CancelSchemaMigrationRequest) (resp *vtctldatapb.CancelSchemaMigrationResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.CancelSchemaMigration")
@@ -544,6 +604,40 @@ func (s *VtctldServer) CancelSchemaMigration(ctx context.Context, req *vtctldata
 	return resp, nil
 }
 
+// ChangeTabletTags is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ChangeTabletTags(ctx context.Context, req *vtctldatapb.ChangeTabletTagsRequest) (resp *vtctldatapb.ChangeTabletTagsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ChangeTabletTags")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("replace", req.Replace)
+
+	if req.TabletAlias == nil {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet alias is required")
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+
+	tablet, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	changeTagsResp, err := s.tmc.ChangeTags(ctx, tablet.Tablet, req.Tags, req.Replace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ChangeTabletTagsResponse{
+		BeforeTags: tablet.Tags,
+		AfterTags:  changeTagsResp.Tags,
+	}, nil
+}
+
 // ChangeTabletType is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ChangeTabletType(ctx context.Context, req *vtctldatapb.ChangeTabletTypeRequest) (resp *vtctldatapb.ChangeTabletTypeResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ChangeTabletType")
@@ -704,6 +798,87 @@ func (s *VtctldServer) CompleteSchemaMigration(ctx context.Context, req *vtctlda
 	return resp, nil
 }
 
+// ConcludeTransaction is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ConcludeTransaction(ctx context.Context, req *vtctldatapb.ConcludeTransactionRequest) (resp *vtctldatapb.ConcludeTransactionResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ConcludeTransaction")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("dtid", req.Dtid)
+
+	ss, err := dtids.ShardSession(req.Dtid)
+	if err != nil {
+		return nil, err
+	}
+
+	mm, err := s.getShardPrimary(ctx, ss.Target.Keyspace, ss.Target.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	unresolved, err := s.tmc.GetUnresolvedTransactions(ctx, mm.Tablet, &tabletmanagerdatapb.GetUnresolvedTransactionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *querypb.TransactionMetadata
+	for _, transaction := range unresolved.Transactions {
+		if transaction.Dtid == req.Dtid {
+			metadata = transaction
+			break
+		}
+	}
+	if metadata == nil {
+		err = vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "distributed transaction %s not found", req.Dtid)
+		return nil, err
+	}
+
+	span.Annotate("state", metadata.State.String())
+
+	// A transaction without a commit decision is rolled back. The decision is
+	// recorded first, so that its coordinator can no longer commit it.
+	action := tabletmanagerdatapb.ConcludeTransactionRequest_ROLLBACK_PREPARED
+	switch metadata.State {
+	case querypb.TransactionState_COMMIT:
+		action = tabletmanagerdatapb.ConcludeTransactionRequest_COMMIT_PREPARED
+	case querypb.TransactionState_PREPARE:
+		if err = s.concludeTransactionOnShard(ctx, mm.Keyspace, mm.Shard, req.Dtid, tabletmanagerdatapb.ConcludeTransactionRequest_SET_ROLLBACK); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, participant := range metadata.Participants {
+		if err = s.concludeTransactionOnShard(ctx, participant.Keyspace, participant.Shard, req.Dtid, action); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.concludeTransactionOnShard(ctx, mm.Keyspace, mm.Shard, req.Dtid, tabletmanagerdatapb.ConcludeTransactionRequest_CONCLUDE); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ConcludeTransactionResponse{}, nil
+}
+
+// concludeTransactionOnShard performs one step of the resolution of a
+// distributed transaction on the primary of the given shard.
+func (s *VtctldServer) concludeTransactionOnShard(ctx context.Context, keyspace, shard, dtid string, action tabletmanagerdatapb.ConcludeTransactionRequest_Action) error {
+	primary, err := s.getShardPrimary(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.tmc.ConcludeTransaction(ctx, primary.Tablet, &tabletmanagerdatapb.ConcludeTransactionRequest{
+		Dtid:   dtid,
+		Action: action,
+	})
+	if err != nil {
+		return vterrors.Wrapf(err, "%v of %s failed on %s/%s", action, dtid, keyspace, shard)
+	}
+	return nil
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) CreateKeyspace(ctx context.Context, req *vtctldatapb.CreateKeyspaceRequest) (resp *vtctldatapb.CreateKeyspaceResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.CreateKeyspace")
@@ -871,6 +1046,40 @@ func (s *VtctldServer) CreateShard(ctx context.Context, req *vtctldatapb.CreateS
 	}, nil
 }
 
+// DelayedReplicaCreate is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DelayedReplicaCreate(ctx context.Context, req *vtctldatapb.DelayedReplicaCreateRequest) (resp *vtctldatapb.WorkflowStatusResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DelayedReplicaCreate")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("source_keyspace", req.SourceKeyspace)
+	span.Annotate("target_keyspace", req.TargetKeyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("cells", req.Cells)
+	span.Annotate("tablet_types", req.TabletTypes)
+	span.Annotate("delay", req.Delay.String())
+
+	resp, err = s.ws.DelayedReplicaCreate(ctx, req)
+	return resp, err
+}
+
+// DelayedReplicaFastForward is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DelayedReplicaFastForward(ctx context.Context, req *vtctldatapb.DelayedReplicaFastForwardRequest) (resp *vtctldatapb.DelayedReplicaFastForwardResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DelayedReplicaFastForward")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("position", req.Position)
+	span.Annotate("source_shard", req.SourceShard)
+
+	resp, err = s.ws.DelayedReplicaFastForward(ctx, req)
+	return resp, err
+}
+
 // DeleteCellInfo is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) DeleteCellInfo(ctx context.Context, req *vtctldatapb.DeleteCellInfoRequest) (resp *vtctldatapb.DeleteCellInfoResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteCellInfo")
@@ -1088,6 +1297,10 @@ func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldat
 	span.Annotate("prevent_cross_cell_promotion", req.PreventCrossCellPromotion)
 	span.Annotate("wait_for_all_tablets", req.WaitForAllTablets)
 
+	avoidTabletAliases := topoproto.TabletAliasList(req.AvoidTablets).ToStringSlice()
+	span.Annotate("prefer_cell", req.PreferCell)
+	span.Annotate("avoid_tablets", strings.Join(avoidTabletAliases, ","))
+
 	m := sync.RWMutex{}
 	logstream := []*logutilpb.Event{}
 	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
@@ -1106,6 +1319,11 @@ func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldat
 			WaitReplicasTimeout:       waitReplicasTimeout,
 			WaitAllTablets:            req.WaitForAllTablets,
 			PreventCrossCellPromotion: req.PreventCrossCellPromotion,
+			CandidatePreferences: reparentutil.CandidatePreferences{
+				PreferCell:       req.PreferCell,
+				PreferTabletTags: req.PreferTabletTags,
+				AvoidTablets:     sets.New(avoidTabletAliases...),
+			},
 		},
 	)
 
@@ -1256,6 +1474,81 @@ func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctlda
 	}, nil
 }
 
+// ForceUnlock is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ForceUnlock(ctx context.Context, req *vtctldatapb.ForceUnlockRequest) (resp *vtctldatapb.ForceUnlockResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ForceUnlock")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("lock_id", req.LockId)
+
+	switch {
+	case req.Keyspace == "":
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "keyspace must be set")
+	case req.LockId == "":
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "lock id must be set, as returned by GetLocks")
+	}
+
+	holder, err := s.ts.ForceUnlock(ctx, req.Keyspace, req.Shard, req.LockId)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "lock holder %v does not hold the lock anymore: %v", req.LockId, err)
+		}
+		return nil, err
+	}
+
+	return &vtctldatapb.ForceUnlockResponse{
+		Lock: topoLockToProto(holder, time.Now()),
+	}, nil
+}
+
+// GetAuditLog is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetAuditLog(ctx context.Context, req *vtctldatapb.GetAuditLogRequest) (resp *vtctldatapb.GetAuditLogResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetAuditLog")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("limit", req.Limit)
+	span.Annotate("component", req.Component)
+	span.Annotate("identity", req.Identity)
+	span.Annotate("action", req.Action)
+
+	file := audit.File()
+	if file == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the audit log of this vtctld has no file sink, see --audit_log_sinks and --audit_log_file")
+	}
+
+	entries, err := audit.ReadFile(file, &audit.Filter{
+		Component: req.Component,
+		Identity:  req.Identity,
+		Action:    req.Action,
+		Since:     protoutil.TimeFromProto(req.Since),
+	}, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.GetAuditLogResponse{
+		Entries: make([]*vtctldatapb.AuditLogEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &vtctldatapb.AuditLogEntry{
+			Time:      protoutil.TimeToProto(entry.Time),
+			Component: entry.Component,
+			Identity:  entry.Identity,
+			Action:    entry.Action,
+			Args:      entry.Args,
+			Error:     entry.Error,
+			Duration:  protoutil.DurationToProto(entry.Duration),
+		})
+	}
+	return resp, nil
+}
+
 // GetBackups is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBackupsRequest) (resp *vtctldatapb.GetBackupsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackups")
@@ -1306,11 +1599,8 @@ func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBacku
 		bi.Keyspace = req.Keyspace
 		bi.Shard = req.Shard
 
-		if req.Detailed {
-			if i >= backupsToSkipDetails { // nolint:staticcheck
-				// (TODO:@ajm188) Update backupengine/backupstorage implementations
-				// to get Status info for backups.
-			}
+		if req.Detailed && i >= backupsToSkipDetails {
+			s.setBackupDetails(ctx, bi, bh)
 		}
 
 		backups = append(backups, bi)
@@ -1321,6 +1611,34 @@ func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBacku
 	}, nil
 }
 
+// setBackupDetails sets the detailed fields of a backup listed by GetBackups,
+// from its MANIFEST and the verdict of its last ValidateBackup.
+func (s *VtctldServer) setBackupDetails(ctx context.Context, bi *mysqlctlpb.BackupInfo, bh backupstorage.BackupHandle) {
+	details, err := mysqlctl.GetBackupDetails(ctx, bh)
+	switch {
+	case err == mysqlctl.ErrIncompleteBackup:
+		bi.Status = mysqlctlpb.BackupInfo_INCOMPLETE
+		return
+	case err != nil:
+		bi.Status = mysqlctlpb.BackupInfo_INVALID
+		bi.ValidationErrors = []string{err.Error()}
+		return
+	}
+	mysqlctlproto.SetBackupDetails(bi, details)
+
+	validation, err := s.ts.GetBackupValidation(ctx, bi.Keyspace, bi.Shard, bi.Name)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+	case err != nil:
+		log.Warningf("failed to read the validation of backup %v/%v: %v", bi.Directory, bi.Name, err)
+	case validation.Valid:
+		bi.Status = mysqlctlpb.BackupInfo_VALID
+	default:
+		bi.Status = mysqlctlpb.BackupInfo_INVALID
+		bi.ValidationErrors = validation.Errors
+	}
+}
+
 // GetCellInfoNames is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetCellInfoNames(ctx context.Context, req *vtctldatapb.GetCellInfoNamesRequest) (resp *vtctldatapb.GetCellInfoNamesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCellInfoNames")
@@ -1377,6 +1695,50 @@ func (s *VtctldServer) GetCellsAliases(ctx context.Context, req *vtctldatapb.Get
 	return &vtctldatapb.GetCellsAliasesResponse{Aliases: aliases}, nil
 }
 
+// GetClusterSetting is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetClusterSetting(ctx context.Context, req *vtctldatapb.GetClusterSettingRequest) (resp *vtctldatapb.GetClusterSettingResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetClusterSetting")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+
+	defs := clustersettings.All()
+	if req.Name != "" {
+		def, ok := clustersettings.Lookup(req.Name)
+		if !ok {
+			err = vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "unknown cluster setting %s", req.Name)
+			return nil, err
+		}
+		defs = []clustersettings.Definition{def}
+	}
+
+	settings, _, err := s.ts.GetClusterSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.GetClusterSettingResponse{
+		Settings: make([]*vtctldatapb.ClusterSetting, 0, len(defs)),
+	}
+	for _, def := range defs {
+		resp.Settings = append(resp.Settings, clusterSettingToProto(def, settings))
+	}
+	return resp, nil
+}
+
+func clusterSettingToProto(def clustersettings.Definition, settings *topodatapb.ClusterSettings) *vtctldatapb.ClusterSetting {
+	value, ok := settings.Settings[def.Name()]
+	return &vtctldatapb.ClusterSetting{
+		Name:  def.Name(),
+		Type:  string(def.Type()),
+		Help:  def.Help(),
+		IsSet: ok,
+		Value: value,
+	}
+}
+
 // GetFullStatus is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetFullStatus(ctx context.Context, req *vtctldatapb.GetFullStatusRequest) (resp *vtctldatapb.GetFullStatusResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetFullStatus")
@@ -1450,6 +1812,76 @@ func (s *VtctldServer) GetKeyspaces(ctx context.Context, req *vtctldatapb.GetKey
 	return &vtctldatapb.GetKeyspacesResponse{Keyspaces: keyspaces}, nil
 }
 
+// GetLocks is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetLocks(ctx context.Context, req *vtctldatapb.GetLocksRequest) (resp *vtctldatapb.GetLocksResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetLocks")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	holders, err := s.ts.ListLocks(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp = &vtctldatapb.GetLocksResponse{}
+	for _, holder := range holders {
+		resp.Locks = append(resp.Locks, topoLockToProto(holder, now))
+	}
+	return resp, nil
+}
+
+// GetMaintenances is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetMaintenances(ctx context.Context, req *vtctldatapb.GetMaintenancesRequest) (resp *vtctldatapb.GetMaintenancesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetMaintenances")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	if req.Shard != "" && req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a keyspace is required to filter on a shard")
+	}
+
+	ids, err := s.ts.GetScheduledMaintenanceIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.GetMaintenancesResponse{}
+	for _, id := range ids {
+		maintenance, _, err := s.ts.GetScheduledMaintenance(ctx, id)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			// Pruned since it was listed.
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		if req.Keyspace != "" && maintenance.Keyspace != req.Keyspace {
+			continue
+		}
+		if req.Shard != "" && maintenance.Shard != req.Shard {
+			continue
+		}
+		if len(req.States) > 0 && !slices.Contains(req.States, maintenance.State) {
+			continue
+		}
+		resp.Maintenances = append(resp.Maintenances, maintenance)
+	}
+
+	sort.SliceStable(resp.Maintenances, func(i, j int) bool {
+		return protoutil.TimeFromProto(resp.Maintenances[i].WindowStart).Before(protoutil.TimeFromProto(resp.Maintenances[j].WindowStart))
+	})
+	return resp, nil
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetPermissionsRequest) (resp *vtctldatapb.GetPermissionsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetPermissions")
@@ -2147,30 +2579,111 @@ func (s *VtctldServer) GetTopologyPath(ctx context.Context, req *vtctldatapb.Get
 	}, nil
 }
 
-// GetVersion returns the version of a tablet from its debug vars
-func (s *VtctldServer) GetVersion(ctx context.Context, req *vtctldatapb.GetVersionRequest) (resp *vtctldatapb.GetVersionResponse, err error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetVersion")
+// GetTopoReadOnly is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTopoReadOnly(ctx context.Context, req *vtctldatapb.GetTopoReadOnlyRequest) (resp *vtctldatapb.GetTopoReadOnlyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTopoReadOnly")
 	defer span.Finish()
 
 	defer panicHandler(&err)
 
-	tabletAlias := req.TabletAlias
-	tablet, err := s.ts.GetTablet(ctx, tabletAlias)
+	mode, err := s.ts.GetTopoReadOnly(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	version, err := GetVersionFunc()(tablet.Addr())
-	if err != nil {
-		return nil, err
-	}
-	log.Infof("Tablet %v is running version '%v'", topoproto.TabletAliasString(tabletAlias), version)
-	return &vtctldatapb.GetVersionResponse{Version: version}, err
+	return &vtctldatapb.GetTopoReadOnlyResponse{
+		TopoReadOnly: mode,
+	}, nil
 }
 
-// GetVSchema is part of the vtctlservicepb.VtctldServer interface.
-func (s *VtctldServer) GetVSchema(ctx context.Context, req *vtctldatapb.GetVSchemaRequest) (resp *vtctldatapb.GetVSchemaResponse, err error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetVSchema")
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetUnresolvedTransactions(ctx context.Context, req *vtctldatapb.GetUnresolvedTransactionsRequest) (resp *vtctldatapb.GetUnresolvedTransactionsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetUnresolvedTransactions")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("abandon_age", req.AbandonAge)
+
+	shards, err := s.ts.GetShardNames(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		m            sync.Mutex
+		wg           sync.WaitGroup
+		rec          concurrency.AllErrorRecorder
+		transactions []*querypb.TransactionMetadata
+	)
+	for _, shard := range shards {
+		wg.Add(1)
+		go func(shard string) {
+			defer wg.Done()
+
+			primary, err := s.getShardPrimary(ctx, req.Keyspace, shard)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+
+			shardResp, err := s.tmc.GetUnresolvedTransactions(ctx, primary.Tablet, &tabletmanagerdatapb.GetUnresolvedTransactionsRequest{
+				AbandonAge: req.AbandonAge,
+			})
+			if err != nil {
+				rec.RecordError(vterrors.Wrapf(err, "GetUnresolvedTransactions(%v) failed", topoproto.TabletAliasString(primary.Alias)))
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			transactions = append(transactions, shardResp.Transactions...)
+		}(shard)
+	}
+
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].TimeCreated == transactions[j].TimeCreated {
+			return transactions[i].Dtid < transactions[j].Dtid
+		}
+		return transactions[i].TimeCreated < transactions[j].TimeCreated
+	})
+
+	return &vtctldatapb.GetUnresolvedTransactionsResponse{
+		Transactions: transactions,
+	}, nil
+}
+
+// GetVersion returns the version of a tablet from its debug vars
+func (s *VtctldServer) GetVersion(ctx context.Context, req *vtctldatapb.GetVersionRequest) (resp *vtctldatapb.GetVersionResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetVersion")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	tabletAlias := req.TabletAlias
+	tablet, err := s.ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := GetVersionFunc()(tablet.Addr())
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Tablet %v is running version '%v'", topoproto.TabletAliasString(tabletAlias), version)
+	return &vtctldatapb.GetVersionResponse{Version: version}, err
+}
+
+// GetVSchema is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetVSchema(ctx context.Context, req *vtctldatapb.GetVSchemaRequest) (resp *vtctldatapb.GetVSchemaResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetVSchema")
 	defer span.Finish()
 
 	defer panicHandler(&err)
@@ -2556,7 +3069,15 @@ func (s *VtctldServer) MaterializeCreate(ctx context.Context, req *vtctldatapb.M
 	span.Annotate("cells", req.Settings.Cell)
 	span.Annotate("tablet_types", req.Settings.TabletTypes)
 	span.Annotate("table_settings", fmt.Sprintf("%+v", req.Settings.TableSettings))
+	span.Annotate("dry_run", req.DryRun)
 
+	if req.DryRun {
+		report, err := s.ws.MaterializeDryRun(ctx, req.Settings)
+		if err != nil {
+			return nil, err
+		}
+		return &vtctldatapb.MaterializeCreateResponse{DryRunReport: report}, nil
+	}
 	err = s.ws.Materialize(ctx, req.Settings)
 	return resp, err
 }
@@ -2713,6 +3234,10 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 		span.Annotate("new_primary_alias", topoproto.TabletAliasString(req.NewPrimary))
 	}
 
+	avoidTabletAliases := topoproto.TabletAliasList(req.AvoidTablets).ToStringSlice()
+	span.Annotate("prefer_cell", req.PreferCell)
+	span.Annotate("avoid_tablets", strings.Join(avoidTabletAliases, ","))
+
 	m := sync.RWMutex{}
 	logstream := []*logutilpb.Event{}
 	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
@@ -2729,6 +3254,11 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 			AvoidPrimaryAlias:   req.AvoidPrimary,
 			NewPrimaryAlias:     req.NewPrimary,
 			WaitReplicasTimeout: waitReplicasTimeout,
+			CandidatePreferences: reparentutil.CandidatePreferences{
+				PreferCell:       req.PreferCell,
+				PreferTabletTags: req.PreferTabletTags,
+				AvoidTablets:     sets.New(avoidTabletAliases...),
+			},
 		},
 	)
 
@@ -2755,6 +3285,60 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 	return resp, err
 }
 
+// PruneBackups is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PruneBackups(ctx context.Context, req *vtctldatapb.PruneBackupsRequest) (resp *vtctldatapb.PruneBackupsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PruneBackups")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	bucket := mysqlctl.GetBackupDir(req.Keyspace, req.Shard)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("bucket", bucket)
+	span.Annotate("keep_full", req.KeepFull)
+	span.Annotate("keep_daily_days", req.KeepDailyDays)
+	span.Annotate("dry_run", req.DryRun)
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	policy := mysqlctl.BackupRetentionPolicy{
+		KeepFull:      int(req.KeepFull),
+		KeepDailyDays: int(req.KeepDailyDays),
+	}
+	pruning, err := mysqlctl.PruneBackups(ctx, bs, bucket, policy, req.DryRun, logutil.NewConsoleLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.PruneBackupsResponse{
+		Kept:    make([]*mysqlctlpb.BackupInfo, 0, len(pruning.Keep)),
+		Removed: make([]*mysqlctlpb.BackupInfo, 0, len(pruning.Remove)),
+	}
+	for _, bh := range pruning.Keep {
+		bi := mysqlctlproto.BackupHandleToProto(bh)
+		bi.Keyspace, bi.Shard = req.Keyspace, req.Shard
+		resp.Kept = append(resp.Kept, bi)
+	}
+	for _, bh := range pruning.Remove {
+		bi := mysqlctlproto.BackupHandleToProto(bh)
+		bi.Keyspace, bi.Shard = req.Keyspace, req.Shard
+		resp.Removed = append(resp.Removed, bi)
+		if req.DryRun {
+			continue
+		}
+		if err := s.ts.DeleteBackupValidation(ctx, req.Keyspace, req.Shard, bh.Name()); err != nil {
+			log.Warningf("failed to delete the validation of removed backup %v/%v: %v", bucket, bh.Name(), err)
+		}
+	}
+	return resp, nil
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RebuildKeyspaceGraph(ctx context.Context, req *vtctldatapb.RebuildKeyspaceGraphRequest) (resp *vtctldatapb.RebuildKeyspaceGraphResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RebuildKeyspaceGraph")
@@ -2993,10 +3577,193 @@ func (s *VtctldServer) RemoveBackup(ctx context.Context, req *vtctldatapb.Remove
 	if err = bs.RemoveBackup(ctx, bucket, req.Name); err != nil {
 		return nil, err
 	}
+	if err = s.ts.DeleteBackupValidation(ctx, req.Keyspace, req.Shard, req.Name); err != nil {
+		log.Warningf("failed to delete the validation of removed backup %v/%v: %v", bucket, req.Name, err)
+	}
 
 	return &vtctldatapb.RemoveBackupResponse{}, nil
 }
 
+// RemoveCell is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RemoveCell(ctx context.Context, req *vtctldatapb.RemoveCellRequest) (resp *vtctldatapb.RemoveCellResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RemoveCell")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	waitReplicasTimeout, ok, err := protoutil.DurationFromProto(req.WaitReplicasTimeout)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		waitReplicasTimeout = time.Second * 30
+	}
+
+	span.Annotate("cell", req.Cell)
+	span.Annotate("drain", req.Drain)
+	span.Annotate("dry_run", req.DryRun)
+	span.Annotate("wait_replicas_timeout_sec", waitReplicasTimeout.Seconds())
+
+	if req.Cell == "" {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cell must not be empty")
+		return nil, err
+	}
+
+	if _, err = s.ts.GetCellInfo(ctx, req.Cell, true /*strongRead*/); err != nil {
+		return nil, err
+	}
+
+	refs, err := findCellReferences(ctx, s.ts, req.Cell)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.RemoveCellResponse{
+		References: refs.describe(),
+	}
+
+	if len(resp.References) > 0 && !req.Drain && !req.DryRun {
+		err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v is still referenced by: %v; drain the cell to remove these references", req.Cell, strings.Join(resp.References, ", "))
+		return nil, err
+	}
+
+	// The topo has no transactions across records, so the references are
+	// removed one at a time, and the CellInfo is deleted last: if a step
+	// fails, RemoveCell can be run again to finish removing the cell.
+	type step struct {
+		action string
+		run    func(ctx context.Context) error
+	}
+
+	var steps []step
+
+	for _, shard := range refs.primaries {
+		shard := shard
+		steps = append(steps, step{
+			action: fmt.Sprintf("reparent shard %v/%v away from primary %v", shard.Keyspace(), shard.ShardName(), topoproto.TabletAliasString(shard.PrimaryAlias)),
+			run: func(ctx context.Context) error {
+				return s.reparentShardOutOfCell(ctx, shard, req.Cell, waitReplicasTimeout)
+			},
+		})
+	}
+
+	for _, alias := range refs.tablets {
+		alias := alias
+		steps = append(steps, step{
+			action: "delete tablet " + topoproto.TabletAliasString(alias),
+			run: func(ctx context.Context) error {
+				return deleteTablet(ctx, s.ts, alias, false /*allowPrimary*/)
+			},
+		})
+	}
+
+	for _, shard := range refs.replicationShards {
+		shard := shard
+		steps = append(steps, step{
+			action: fmt.Sprintf("delete the replication graph of shard %v/%v", shard.Keyspace(), shard.ShardName()),
+			run: func(ctx context.Context) error {
+				return s.ts.DeleteShardReplication(ctx, req.Cell, shard.Keyspace(), shard.ShardName())
+			},
+		})
+	}
+
+	for _, keyspace := range refs.srvKeyspaces {
+		keyspace := keyspace
+		steps = append(steps, step{
+			action: "delete SrvKeyspace " + keyspace,
+			run: func(ctx context.Context) error {
+				return s.ts.DeleteSrvKeyspace(ctx, req.Cell, keyspace)
+			},
+		})
+	}
+
+	for _, alias := range refs.aliases {
+		alias := alias
+		steps = append(steps, step{
+			action: "remove the cell from cells alias " + alias,
+			run: func(ctx context.Context) error {
+				return removeCellFromCellsAlias(ctx, s.ts, alias, req.Cell)
+			},
+		})
+	}
+
+	if refs.hasSrvVSchema {
+		steps = append(steps, step{
+			action: "delete SrvVSchema",
+			run:    func(ctx context.Context) error { return s.ts.DeleteSrvVSchema(ctx, req.Cell) },
+		})
+	}
+
+	steps = append(steps, step{
+		action: "delete CellInfo",
+		run:    func(ctx context.Context) error { return s.ts.DeleteCellInfo(ctx, req.Cell, false /*force*/) },
+	})
+
+	for _, step := range steps {
+		resp.Actions = append(resp.Actions, step.action)
+		if req.DryRun {
+			continue
+		}
+
+		log.Infof("Removing cell %v: %v", req.Cell, step.action)
+		// Records already deleted by a previous attempt are not an error.
+		if err = step.run(ctx); err != nil && !topo.IsErrType(err, topo.NoNode) {
+			err = fmt.Errorf("cannot %v in cell %v: %w", step.action, req.Cell, err)
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// reparentShardOutOfCell reparents a shard, whose primary is in the cell, to
+// the best replica in another cell.
+func (s *VtctldServer) reparentShardOutOfCell(ctx context.Context, shard *topo.ShardInfo, cell string, waitReplicasTimeout time.Duration) error {
+	// A partial result is fine, as long as a candidate is found, since the
+	// unreachable cell may be the one being removed.
+	tabletMap, err := s.ts.GetTabletMapForShard(ctx, shard.Keyspace(), shard.ShardName())
+	if err != nil && !topo.IsErrType(err, topo.PartialResult) {
+		return err
+	}
+
+	for alias, tablet := range tabletMap {
+		if tablet.Alias.Cell == cell {
+			delete(tabletMap, alias)
+		}
+	}
+
+	durabilityName, err := s.ts.GetKeyspaceDurability(ctx, shard.Keyspace())
+	if err != nil {
+		return err
+	}
+
+	durability, err := reparentutil.GetDurabilityPolicy(durabilityName)
+	if err != nil {
+		return err
+	}
+
+	// ChooseNewPrimary only considers the replicas in the cell of the
+	// current primary, which is the cell being removed.
+	candidateShard := topo.NewShardInfo(shard.Keyspace(), shard.ShardName(), shard.Shard.CloneVT(), nil)
+	candidateShard.PrimaryAlias = nil
+
+	newPrimary, err := reparentutil.ChooseNewPrimary(ctx, s.tmc, candidateShard, tabletMap, nil, reparentutil.CandidatePreferences{}, waitReplicasTimeout, durability, logutil.NewConsoleLogger())
+	if err != nil {
+		return err
+	}
+
+	if newPrimary == nil {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no replica of shard %v/%v outside of cell %v can be promoted", shard.Keyspace(), shard.ShardName(), cell)
+	}
+
+	_, err = s.PlannedReparentShard(ctx, &vtctldatapb.PlannedReparentShardRequest{
+		Keyspace:            shard.Keyspace(),
+		Shard:               shard.ShardName(),
+		NewPrimary:          newPrimary,
+		WaitReplicasTimeout: protoutil.DurationToProto(waitReplicasTimeout),
+	})
+	return err
+}
+
 // RemoveKeyspaceCell is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RemoveKeyspaceCell(ctx context.Context, req *vtctldatapb.RemoveKeyspaceCellRequest) (resp *vtctldatapb.RemoveKeyspaceCellResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RemoveKeyspaceCell")
@@ -3142,6 +3909,22 @@ func (s *VtctldServer) ReshardCreate(ctx context.Context, req *vtctldatapb.Resha
 	resp, err = s.ws.ReshardCreate(ctx, req)
 	return resp, err
 }
+
+// ReshardRecommend is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ReshardRecommend(ctx context.Context, req *vtctldatapb.ReshardRecommendRequest) (resp *vtctldatapb.ReshardRecommendResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ReshardRecommend")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("source_shards", req.SourceShards)
+	span.Annotate("shard_count", req.ShardCount)
+
+	resp, err = s.ws.ReshardRecommend(ctx, req)
+	return resp, err
+}
+
 func (s *VtctldServer) RestoreFromBackup(req *vtctldatapb.RestoreFromBackupRequest, stream vtctlservicepb.Vtctld_RestoreFromBackupServer) (err error) {
 	span, ctx := trace.NewSpan(stream.Context(), "VtctldServer.RestoreFromBackup")
 	defer span.Finish()
@@ -3167,6 +3950,7 @@ func (s *VtctldServer) RestoreFromBackup(req *vtctldatapb.RestoreFromBackupReque
 		RestoreToPos:       req.RestoreToPos,
 		RestoreToTimestamp: req.RestoreToTimestamp,
 		DryRun:             req.DryRun,
+		SourceTabletAlias:  req.SourceTabletAlias,
 	}
 	logStream, err := s.tmc.RestoreFromBackup(ctx, ti.Tablet, r)
 	if err != nil {
@@ -3219,6 +4003,216 @@ func (s *VtctldServer) RestoreFromBackup(req *vtctldatapb.RestoreFromBackupReque
 	}
 }
 
+// RestoreToPointInTime is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RestoreToPointInTime(req *vtctldatapb.RestoreToPointInTimeRequest, stream vtctlservicepb.Vtctld_RestoreToPointInTimeServer) (err error) {
+	span, ctx := trace.NewSpan(stream.Context(), "VtctldServer.RestoreToPointInTime")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("restore_to_pos", req.RestoreToPos)
+	span.Annotate("dry_run", req.DryRun)
+
+	restoreToTimestamp := protoutil.TimeFromProto(req.RestoreToTimestamp).UTC()
+	if !restoreToTimestamp.IsZero() {
+		span.Annotate("restore_to_timestamp", restoreToTimestamp.Format(time.RFC3339))
+	}
+
+	switch {
+	case req.RestoreToPos == "" && restoreToTimestamp.IsZero():
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "one of restore_to_pos or restore_to_timestamp is required")
+	case req.RestoreToPos != "" && !restoreToTimestamp.IsZero():
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "restore_to_pos and restore_to_timestamp are mutually exclusive")
+	case req.BinlogSourceTabletAlias != nil && req.BinlogServer != "":
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "binlog_source_tablet_alias and binlog_server are mutually exclusive")
+	}
+
+	source, sourceAlias, err := s.getRestoreBinlogSource(ctx, req)
+	if err != nil {
+		return err
+	}
+	span.Annotate("binlog_source", fmt.Sprintf("%s:%d", source.Host, source.Port))
+
+	ti, err := s.getRestoreToPointInTimeTablet(ctx, req, sourceAlias)
+	if err != nil {
+		return err
+	}
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(ti.Alias))
+
+	r := &tabletmanagerdatapb.RestoreFromBackupRequest{
+		RestoreToPos:       req.RestoreToPos,
+		RestoreToTimestamp: req.RestoreToTimestamp,
+		DryRun:             req.DryRun,
+		BinlogSource:       source,
+	}
+	logStream, err := s.tmc.RestoreFromBackup(ctx, ti.Tablet, r)
+	if err != nil {
+		return err
+	}
+
+	logger := logutil.NewConsoleLogger()
+
+	for {
+		var event *logutilpb.Event
+		event, err = logStream.Recv()
+		switch err {
+		case nil:
+			logutil.LogEvent(logger, event)
+			resp := &vtctldatapb.RestoreToPointInTimeResponse{
+				TabletAlias: ti.Alias,
+				Keyspace:    ti.Keyspace,
+				Shard:       ti.Shard,
+				Event:       event,
+			}
+			if err = stream.Send(resp); err != nil {
+				logger.Errorf("failed to send stream response %+v: %v", resp, err)
+			}
+		case io.EOF:
+			// Point in time recovery leaves replication disabled on the restored tablet,
+			// so unlike RestoreFromBackup we do not set its replication source.
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// getRestoreBinlogSource returns the binlog source to apply binary logs from in a
+// RestoreToPointInTime, along with the alias of the tablet it belongs to, if any.
+// The binlog server of a source tablet is used over its MySQL server, if it runs one.
+// When neither a binlog server nor a source tablet is given, a tablet of the shard
+// running a binlog server is used, or else the shard primary.
+func (s *VtctldServer) getRestoreBinlogSource(ctx context.Context, req *vtctldatapb.RestoreToPointInTimeRequest) (*tabletmanagerdatapb.RestoreBinlogSource, *topodatapb.TabletAlias, error) {
+	if req.BinlogServer != "" {
+		host, port, err := netutil.SplitHostPort(req.BinlogServer)
+		if err != nil {
+			return nil, nil, vterrors.Wrapf(err, "invalid binlog_server %s", req.BinlogServer)
+		}
+		return &tabletmanagerdatapb.RestoreBinlogSource{Host: host, Port: int32(port)}, nil, nil
+	}
+
+	sourceAlias := req.BinlogSourceTabletAlias
+	if sourceAlias == nil {
+		ti, err := s.getBinlogServerTablet(ctx, req.Keyspace, req.Shard)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ti != nil {
+			return &tabletmanagerdatapb.RestoreBinlogSource{Host: ti.Hostname, Port: ti.PortMap["binlog"]}, ti.Alias, nil
+		}
+
+		si, err := s.ts.GetShard(ctx, req.Keyspace, req.Shard)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !si.HasPrimary() {
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary to use as binlog source", req.Keyspace, req.Shard)
+		}
+		sourceAlias = si.PrimaryAlias
+	}
+
+	sourceTablet, err := s.ts.GetTablet(ctx, sourceAlias)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sourceTablet.Keyspace != req.Keyspace || sourceTablet.Shard != req.Shard {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "binlog source tablet %s is in %s/%s, not in %s/%s", topoproto.TabletAliasString(sourceAlias), sourceTablet.Keyspace, sourceTablet.Shard, req.Keyspace, req.Shard)
+	}
+	if port, ok := sourceTablet.PortMap["binlog"]; ok && port != 0 {
+		return &tabletmanagerdatapb.RestoreBinlogSource{Host: sourceTablet.Hostname, Port: port}, sourceAlias, nil
+	}
+	if sourceTablet.MysqlHostname == "" {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "binlog source tablet %s has no mysql hostname", topoproto.TabletAliasString(sourceAlias))
+	}
+	return &tabletmanagerdatapb.RestoreBinlogSource{Host: sourceTablet.MysqlHostname, Port: sourceTablet.MysqlPort}, sourceAlias, nil
+}
+
+// getBinlogServerTablet returns the tablet of the shard running a binlog server, the
+// first one by alias if there are several. It returns nil if there is none.
+func (s *VtctldServer) getBinlogServerTablet(ctx context.Context, keyspace string, shard string) (*topo.TabletInfo, error) {
+	tabletMap, err := s.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	var binlogServer *topo.TabletInfo
+	for _, ti := range tabletMap {
+		if ti.PortMap["binlog"] == 0 || ti.Hostname == "" {
+			continue
+		}
+		if binlogServer == nil || topoproto.TabletAliasString(ti.Alias) < topoproto.TabletAliasString(binlogServer.Alias) {
+			binlogServer = ti
+		}
+	}
+	return binlogServer, nil
+}
+
+// getRestoreToPointInTimeTablet returns the tablet to restore in a RestoreToPointInTime.
+// When no tablet is given, a non-serving tablet of the shard is preferred over a serving one.
+func (s *VtctldServer) getRestoreToPointInTimeTablet(ctx context.Context, req *vtctldatapb.RestoreToPointInTimeRequest, sourceAlias *topodatapb.TabletAlias) (*topo.TabletInfo, error) {
+	if req.TabletAlias != nil {
+		if topoproto.TabletAliasEqual(req.TabletAlias, sourceAlias) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet %s cannot be restored from its own binary logs", topoproto.TabletAliasString(req.TabletAlias))
+		}
+		ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+		if err != nil {
+			return nil, err
+		}
+		if ti.Keyspace != req.Keyspace || ti.Shard != req.Shard {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet %s is in %s/%s, not in %s/%s", topoproto.TabletAliasString(req.TabletAlias), ti.Keyspace, ti.Shard, req.Keyspace, req.Shard)
+		}
+		if ti.Type == topodatapb.TabletType_PRIMARY {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot restore primary tablet %s to a point in time", topoproto.TabletAliasString(req.TabletAlias))
+		}
+		return ti, nil
+	}
+
+	ti, err := s.getNonServingRestoreTablet(ctx, req.Keyspace, req.Shard, sourceAlias)
+	if err != nil {
+		return nil, err
+	}
+	if ti == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tablet available in %s/%s to restore to a point in time", req.Keyspace, req.Shard)
+	}
+	return ti, nil
+}
+
+// getNonServingRestoreTablet returns the tablet of the shard that is the least
+// disruptive to restore onto, other than exclude: a SPARE, DRAINED, RDONLY or
+// REPLICA tablet, in that order of preference. It returns nil if there is none.
+func (s *VtctldServer) getNonServingRestoreTablet(ctx context.Context, keyspace string, shard string, exclude *topodatapb.TabletAlias) (*topo.TabletInfo, error) {
+	tabletMap, err := s.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	preference := map[topodatapb.TabletType]int{
+		topodatapb.TabletType_SPARE:   0,
+		topodatapb.TabletType_DRAINED: 1,
+		topodatapb.TabletType_RDONLY:  2,
+		topodatapb.TabletType_REPLICA: 3,
+	}
+	var candidates []*topo.TabletInfo
+	for _, ti := range tabletMap {
+		if _, ok := preference[ti.Type]; !ok || topoproto.TabletAliasEqual(ti.Alias, exclude) {
+			continue
+		}
+		candidates = append(candidates, ti)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if pi, pj := preference[candidates[i].Type], preference[candidates[j].Type]; pi != pj {
+			return pi < pj
+		}
+		return topoproto.TabletAliasString(candidates[i].Alias) < topoproto.TabletAliasString(candidates[j].Alias)
+	})
+	return candidates[0], nil
+}
+
 // RetrySchemaMigration is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RetrySchemaMigration(ctx context.Context, req *vtctldatapb.RetrySchemaMigrationRequest) (resp *vtctldatapb.RetrySchemaMigrationResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RetrySchemaMigration")
@@ -3229,47 +4223,203 @@ func (s *VtctldServer) RetrySchemaMigration(ctx context.Context, req *vtctldatap
 	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("uuid", req.Uuid)
 
-	query, err := alterSchemaMigrationQuery("retry", req.Uuid)
-	if err != nil {
-		return nil, err
+	query, err := alterSchemaMigrationQuery("retry", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Calling ApplySchema to retry migration")
+	qr, err := s.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
+		Keyspace:            req.Keyspace,
+		Sql:                 []string{query},
+		WaitReplicasTimeout: protoutil.DurationToProto(DefaultWaitReplicasTimeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.RetrySchemaMigrationResponse{
+		RowsAffectedByShard: qr.RowsAffectedByShard,
+	}
+	return resp, nil
+}
+
+// RotateTabletCertificates is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RotateTabletCertificates(ctx context.Context, req *vtctldatapb.RotateTabletCertificatesRequest) (resp *vtctldatapb.RotateTabletCertificatesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RotateTabletCertificates")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_aliases", strings.Join(topoproto.TabletAliasList(req.TabletAliases).ToStringSlice(), ","))
+	span.Annotate("cert", len(req.Cert) > 0)
+	span.Annotate("key", len(req.Key) > 0)
+	span.Annotate("ca", len(req.Ca) > 0)
+
+	if len(req.TabletAliases) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no tablets to rotate the certificates of")
+	}
+
+	var wg sync.WaitGroup
+	resp = &vtctldatapb.RotateTabletCertificatesResponse{
+		Rotations: make([]*vtctldatapb.TabletCertificateRotation, len(req.TabletAliases)),
+	}
+	for i, alias := range req.TabletAliases {
+		wg.Add(1)
+		go func(i int, alias *topodatapb.TabletAlias) {
+			defer wg.Done()
+			resp.Rotations[i] = s.rotateTabletCertificates(ctx, alias, req)
+		}(i, alias)
+	}
+	wg.Wait()
+
+	return resp, nil
+}
+
+// RunHealthCheck is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RunHealthCheck(ctx context.Context, req *vtctldatapb.RunHealthCheckRequest) (resp *vtctldatapb.RunHealthCheckResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RunHealthCheck")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+
+	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tmc.RunHealthCheck(ctx, ti.Tablet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RunHealthCheckResponse{}, nil
+}
+
+// ScheduleMaintenance is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ScheduleMaintenance(ctx context.Context, req *vtctldatapb.ScheduleMaintenanceRequest) (resp *vtctldatapb.ScheduleMaintenanceResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ScheduleMaintenance")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("action", req.Action.String())
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+
+	now := time.Now()
+	windowStart := protoutil.TimeFromProto(req.WindowStart)
+	if req.WindowStart == nil {
+		windowStart = now
+	}
+	windowEnd := protoutil.TimeFromProto(req.WindowEnd)
+
+	switch {
+	case req.Keyspace == "" || req.Shard == "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a keyspace and a shard are required")
+	case req.WindowEnd == nil:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the end of the window is required")
+	case !windowEnd.After(windowStart):
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the window ends at %v, before it starts at %v", windowEnd, windowStart)
+	case !windowEnd.After(now):
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the window already ended at %v", windowEnd)
+	}
+
+	if _, err := s.ts.GetShard(ctx, req.Keyspace, req.Shard); err != nil {
+		return nil, err
+	}
+
+	switch req.Action {
+	case topodatapb.ScheduledMaintenance_PLANNED_REPARENT:
+	case topodatapb.ScheduledMaintenance_DRAIN_TABLET:
+		if req.TabletAlias == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the tablet to drain is required")
+		}
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown action %v", req.Action)
+	}
+
+	if req.TabletAlias != nil {
+		tablet, err := s.ts.GetTablet(ctx, req.TabletAlias)
+		if err != nil {
+			return nil, err
+		}
+		if tablet.Keyspace != req.Keyspace || tablet.Shard != req.Shard {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet %v is not in shard %v/%v", topoproto.TabletAliasString(req.TabletAlias), req.Keyspace, req.Shard)
+		}
+		if req.Action == topodatapb.ScheduledMaintenance_DRAIN_TABLET && !topo.IsTrivialTypeChange(tablet.Type, topodatapb.TabletType_DRAINED) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "tablet %v of type %v cannot be drained", topoproto.TabletAliasString(req.TabletAlias), tablet.Type)
+		}
+	}
+
+	maintenance := &topodatapb.ScheduledMaintenance{
+		Id:          uuid.New().String(),
+		Keyspace:    req.Keyspace,
+		Shard:       req.Shard,
+		Action:      req.Action,
+		TabletAlias: req.TabletAlias,
+		WindowStart: protoutil.TimeToProto(windowStart),
+		WindowEnd:   protoutil.TimeToProto(windowEnd),
+		State:       topodatapb.ScheduledMaintenance_PENDING,
+		Created:     protoutil.TimeToProto(now),
 	}
+	span.Annotate("id", maintenance.Id)
 
-	log.Info("Calling ApplySchema to retry migration")
-	qr, err := s.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
-		Keyspace:            req.Keyspace,
-		Sql:                 []string{query},
-		WaitReplicasTimeout: protoutil.DurationToProto(DefaultWaitReplicasTimeout),
-	})
-	if err != nil {
+	if err := s.ts.CreateScheduledMaintenance(ctx, maintenance); err != nil {
 		return nil, err
 	}
 
-	resp = &vtctldatapb.RetrySchemaMigrationResponse{
-		RowsAffectedByShard: qr.RowsAffectedByShard,
-	}
-	return resp, nil
+	return &vtctldatapb.ScheduleMaintenanceResponse{Maintenance: maintenance}, nil
 }
 
-// RunHealthCheck is part of the vtctlservicepb.VtctldServer interface.
-func (s *VtctldServer) RunHealthCheck(ctx context.Context, req *vtctldatapb.RunHealthCheckRequest) (resp *vtctldatapb.RunHealthCheckResponse, err error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.RunHealthCheck")
+// SetClusterSetting is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetClusterSetting(ctx context.Context, req *vtctldatapb.SetClusterSettingRequest) (resp *vtctldatapb.SetClusterSettingResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetClusterSetting")
 	defer span.Finish()
 
 	defer panicHandler(&err)
 
-	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("name", req.Name)
+	span.Annotate("value", req.Value)
+	span.Annotate("unset", req.Unset)
 
-	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
-	if err != nil {
+	def, ok := clustersettings.Lookup(req.Name)
+	if !ok {
+		err = vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "unknown cluster setting %s", req.Name)
 		return nil, err
 	}
+	if !req.Unset {
+		if err = def.Validate(req.Value); err != nil {
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", err)
+			return nil, err
+		}
+	}
 
-	err = s.tmc.RunHealthCheck(ctx, ti.Tablet)
+	settings, err := s.ts.UpdateClusterSettings(ctx, func(settings *topodatapb.ClusterSettings) error {
+		value, ok := settings.Settings[req.Name]
+		switch {
+		case req.Unset && !ok, !req.Unset && ok && value == req.Value:
+			return topo.NewError(topo.NoUpdateNeeded, req.Name)
+		case req.Unset:
+			delete(settings.Settings, req.Name)
+		default:
+			if settings.Settings == nil {
+				settings.Settings = map[string]string{}
+			}
+			settings.Settings[req.Name] = req.Value
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &vtctldatapb.RunHealthCheckResponse{}, nil
+	return &vtctldatapb.SetClusterSettingResponse{
+		Setting: clusterSettingToProto(def, settings),
+	}, nil
 }
 
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldServer interface.
@@ -3313,6 +4463,59 @@ func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtc
 	}, nil
 }
 
+// SetKeyspaceFailoverPreferences is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetKeyspaceFailoverPreferences(ctx context.Context, req *vtctldatapb.SetKeyspaceFailoverPreferencesRequest) (resp *vtctldatapb.SetKeyspaceFailoverPreferencesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetKeyspaceFailoverPreferences")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("prefer_same_cell", req.FailoverPreferences.GetPreferSameCell())
+	span.Annotate("never_promote_cells", strings.Join(req.FailoverPreferences.GetNeverPromoteCells(), ","))
+
+	if len(req.FailoverPreferences.GetNeverPromoteCells()) > 0 {
+		var cells []string
+		cells, err = s.ts.GetCellInfoNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, cell := range req.FailoverPreferences.NeverPromoteCells {
+			if !slices.Contains(cells, cell) {
+				err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cell %v does not exist", cell)
+				return nil, err
+			}
+		}
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetKeyspaceFailoverPreferences")
+	if lockErr != nil {
+		err = lockErr
+		return nil, err
+	}
+
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.FailoverPreferences = req.FailoverPreferences
+	if !ki.FailoverPreferences.GetPreferSameCell() && len(ki.FailoverPreferences.GetNeverPromoteCells()) == 0 {
+		ki.FailoverPreferences = nil
+	}
+
+	err = s.ts.UpdateKeyspace(ctx, ki)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetKeyspaceFailoverPreferencesResponse{
+		Keyspace: ki.Keyspace,
+	}, nil
+}
+
 // SetKeyspaceServedFrom is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) SetKeyspaceServedFrom(ctx context.Context, req *vtctldatapb.SetKeyspaceServedFromRequest) (resp *vtctldatapb.SetKeyspaceServedFromResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetKeyspaceServedFrom")
@@ -3436,6 +4639,105 @@ func (s *VtctldServer) SetShardTabletControl(ctx context.Context, req *vtctldata
 	}, nil
 }
 
+// SetTopoReadOnly is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetTopoReadOnly(ctx context.Context, req *vtctldatapb.SetTopoReadOnlyRequest) (resp *vtctldatapb.SetTopoReadOnlyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetTopoReadOnly")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("read_only", req.ReadOnly)
+	span.Annotate("reason", req.Reason)
+
+	if !req.ReadOnly {
+		if err := s.ts.DisableTopoReadOnly(ctx); err != nil {
+			return nil, err
+		}
+		return &vtctldatapb.SetTopoReadOnlyResponse{}, nil
+	}
+
+	mode, err := s.ts.EnableTopoReadOnly(ctx, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetTopoReadOnlyResponse{
+		TopoReadOnly: mode,
+	}, nil
+}
+
+// SetVtorcMaintenance is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetVtorcMaintenance(ctx context.Context, req *vtctldatapb.SetVtorcMaintenanceRequest) (resp *vtctldatapb.SetVtorcMaintenanceResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetVtorcMaintenance")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("enabled", req.Enabled)
+	span.Annotate("reason", req.Reason)
+
+	var maintenance *topodatapb.VtorcMaintenance
+	if req.Enabled {
+		duration, ok, durationErr := protoutil.DurationFromProto(req.Duration)
+		switch {
+		case durationErr != nil:
+			err = vterrors.Wrapf(durationErr, "invalid duration")
+			return nil, err
+		case !ok || duration <= 0:
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a positive duration is required to start a maintenance")
+			return nil, err
+		case req.Reason == "":
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a reason is required to start a maintenance")
+			return nil, err
+		}
+		now := time.Now()
+		maintenance = &topodatapb.VtorcMaintenance{
+			Reason:     req.Reason,
+			Since:      protoutil.TimeToProto(now),
+			ExpireTime: protoutil.TimeToProto(now.Add(duration)),
+		}
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, fmt.Sprintf("SetVtorcMaintenance(%v,%v,%v)", req.Keyspace, req.Shard, req.Enabled))
+	if lockErr != nil {
+		err = lockErr
+		return nil, err
+	}
+
+	defer unlock(&err)
+
+	if req.Shard != "" {
+		_, err = s.ts.UpdateShardFields(ctx, req.Keyspace, req.Shard, func(si *topo.ShardInfo) error {
+			if maintenance == nil && si.VtorcMaintenance == nil {
+				return topo.NewError(topo.NoUpdateNeeded, si.Keyspace()+"/"+si.ShardName())
+			}
+			si.VtorcMaintenance = maintenance
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var ki *topo.KeyspaceInfo
+		ki, err = s.ts.GetKeyspace(ctx, req.Keyspace)
+		if err != nil {
+			return nil, err
+		}
+		if maintenance != nil || ki.VtorcMaintenance != nil {
+			ki.VtorcMaintenance = maintenance
+			if err = s.ts.UpdateKeyspace(ctx, ki); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &vtctldatapb.SetVtorcMaintenanceResponse{
+		VtorcMaintenance: maintenance,
+	}, nil
+}
+
 // SetWritable is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) SetWritable(ctx context.Context, req *vtctldatapb.SetWritableRequest) (resp *vtctldatapb.SetWritableResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetWritable")
@@ -3977,6 +5279,82 @@ func (s *VtctldServer) TabletExternallyReparented(ctx context.Context, req *vtct
 	return resp, nil
 }
 
+// TopoBackup is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) TopoBackup(ctx context.Context, req *vtctldatapb.TopoBackupRequest) (resp *vtctldatapb.TopoBackupResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.TopoBackup")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("store_in_backup_storage", req.StoreInBackupStorage)
+
+	snapshot, err := topotools.SnapshotTopo(ctx, s.ts, req.Cells)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.TopoBackupResponse{Snapshot: snapshot}
+	if req.StoreInBackupStorage {
+		if resp.BackupName, err = storeTopoSnapshot(ctx, snapshot); err != nil {
+			return nil, err
+		}
+		log.Infof("Stored a topo snapshot of %d files in the backup storage as %v", len(snapshot.Files), resp.BackupName)
+	}
+	return resp, nil
+}
+
+// TopoGC is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) TopoGC(ctx context.Context, req *vtctldatapb.TopoGCRequest) (resp *vtctldatapb.TopoGCResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.TopoGC")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("dry_run", req.DryRun)
+
+	minAge, _, err := protoutil.DurationFromProto(req.MinAge)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse MinAge into a valid duration")
+	}
+	span.Annotate("min_age", minAge.String())
+
+	return topotools.TopoGC(ctx, s.ts, req.Cells, minAge, req.DryRun)
+}
+
+// TopoRestore is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) TopoRestore(ctx context.Context, req *vtctldatapb.TopoRestoreRequest) (resp *vtctldatapb.TopoRestoreResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.TopoRestore")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("backup_name", req.BackupName)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("global_only", req.GlobalOnly)
+	span.Annotate("conflict_policy", req.ConflictPolicy.String())
+	span.Annotate("dry_run", req.DryRun)
+
+	snapshot := req.Snapshot
+	switch {
+	case req.BackupName != "" && snapshot != nil:
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "only one of Snapshot and BackupName can be set")
+	case req.BackupName != "":
+		if snapshot, err = readTopoSnapshot(ctx, req.BackupName); err != nil {
+			return nil, err
+		}
+	case snapshot == nil:
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "one of Snapshot and BackupName must be set")
+	}
+
+	resp, err = topotools.RestoreTopoSnapshot(ctx, s.ts, snapshot, req.Cells, req.GlobalOnly, req.ConflictPolicy, req.DryRun)
+	if resp != nil && !req.DryRun {
+		log.Infof("Restored a topo snapshot: %d files created, %d overwritten, %d skipped, %d unchanged", resp.Created, resp.Overwritten, resp.Skipped, resp.Unchanged)
+	}
+	return resp, err
+}
+
 // UpdateCellInfo is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) UpdateCellInfo(ctx context.Context, req *vtctldatapb.UpdateCellInfoRequest) (resp *vtctldatapb.UpdateCellInfoResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.UpdateCellInfo")
@@ -4189,6 +5567,99 @@ func (s *VtctldServer) Validate(ctx context.Context, req *vtctldatapb.ValidateRe
 	return resp, err
 }
 
+// ValidateBackup is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ValidateBackup(req *vtctldatapb.ValidateBackupRequest, stream vtctlservicepb.Vtctld_ValidateBackupServer) (err error) {
+	span, ctx := trace.NewSpan(stream.Context(), "VtctldServer.ValidateBackup")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("checksum_tables", req.ChecksumTables)
+
+	backupTime := protoutil.TimeFromProto(req.BackupTime).UTC()
+	if !backupTime.IsZero() {
+		span.Annotate("backup_time", backupTime.Format(time.RFC3339))
+	}
+
+	ti, err := s.getValidateBackupTablet(ctx, req)
+	if err != nil {
+		return err
+	}
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(ti.Alias))
+
+	r := &tabletmanagerdatapb.ValidateBackupRequest{
+		BackupTime:     req.BackupTime,
+		ChecksumTables: req.ChecksumTables,
+		Concurrency:    req.Concurrency,
+	}
+	validateStream, err := s.tmc.ValidateBackup(ctx, ti.Tablet, r)
+	if err != nil {
+		return err
+	}
+
+	logger := logutil.NewConsoleLogger()
+
+	for {
+		var tmResp *tabletmanagerdatapb.ValidateBackupResponse
+		tmResp, err = validateStream.Recv()
+		switch err {
+		case nil:
+			if tmResp.Event != nil {
+				logutil.LogEvent(logger, tmResp.Event)
+			}
+			if tmResp.Validation != nil && tmResp.Validation.BackupName != "" {
+				// Record the verdict for GetBackups. A failed restore may
+				// not know which backup it was.
+				if err := s.ts.SaveBackupValidation(ctx, ti.Keyspace, ti.Shard, tmResp.Validation); err != nil {
+					logger.Warningf("failed to record the validation of backup %v: %v", tmResp.Validation.BackupName, err)
+				}
+			}
+			resp := &vtctldatapb.ValidateBackupResponse{
+				TabletAlias: ti.Alias,
+				Keyspace:    ti.Keyspace,
+				Shard:       ti.Shard,
+				Event:       tmResp.Event,
+				Validation:  tmResp.Validation,
+			}
+			if err = stream.Send(resp); err != nil {
+				logger.Errorf("failed to send stream response %+v: %v", resp, err)
+			}
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// getValidateBackupTablet returns the tablet that restores the backup in a
+// ValidateBackup. When no tablet is given, a non-serving tablet of the shard is
+// preferred over a serving one, since the trial restore competes with it for
+// disk and IO.
+func (s *VtctldServer) getValidateBackupTablet(ctx context.Context, req *vtctldatapb.ValidateBackupRequest) (*topo.TabletInfo, error) {
+	if req.TabletAlias != nil {
+		ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+		if err != nil {
+			return nil, err
+		}
+		if ti.Keyspace != req.Keyspace || ti.Shard != req.Shard {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet %s is in %s/%s, not in %s/%s", topoproto.TabletAliasString(req.TabletAlias), ti.Keyspace, ti.Shard, req.Keyspace, req.Shard)
+		}
+		return ti, nil
+	}
+
+	ti, err := s.getNonServingRestoreTablet(ctx, req.Keyspace, req.Shard, nil)
+	if err != nil {
+		return nil, err
+	}
+	if ti == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tablet available in %s/%s to validate a backup, pass a tablet alias to use the primary", req.Keyspace, req.Shard)
+	}
+	return ti, nil
+}
+
 // ValidateKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ValidateKeyspace(ctx context.Context, req *vtctldatapb.ValidateKeyspaceRequest) (resp *vtctldatapb.ValidateKeyspaceResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ValidateKeyspace")
@@ -4198,6 +5669,11 @@ func (s *VtctldServer) ValidateKeyspace(ctx context.Context, req *vtctldatapb.Va
 
 	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("ping_tablets", req.PingTablets)
+	span.Annotate("checks", strings.Join(req.Checks, ","))
+
+	if _, _, err = validationCheckOptions(req.Checks, req.MaxReplicationLag, req.MinDiskFreePercent); err != nil {
+		return nil, err
+	}
 
 	resp = &vtctldatapb.ValidateKeyspaceResponse{}
 	getShardNamesCtx, getShardNamesCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
@@ -4221,9 +5697,12 @@ func (s *VtctldServer) ValidateKeyspace(ctx context.Context, req *vtctldatapb.Va
 		go func(shard string) {
 			defer wg.Done()
 			shardResp, err := s.ValidateShard(ctx, &vtctldatapb.ValidateShardRequest{
-				Keyspace:    req.Keyspace,
-				Shard:       shard,
-				PingTablets: req.PingTablets,
+				Keyspace:           req.Keyspace,
+				Shard:              shard,
+				PingTablets:        req.PingTablets,
+				Checks:             req.Checks,
+				MaxReplicationLag:  req.MaxReplicationLag,
+				MinDiskFreePercent: req.MinDiskFreePercent,
 			})
 
 			m.Lock()
@@ -4392,6 +5871,12 @@ func (s *VtctldServer) ValidateShard(ctx context.Context, req *vtctldatapb.Valid
 	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("shard", req.Shard)
 	span.Annotate("ping_tablets", req.PingTablets)
+	span.Annotate("checks", strings.Join(req.Checks, ","))
+
+	maxLag, minDiskFree, err := validationCheckOptions(req.Checks, req.MaxReplicationLag, req.MinDiskFreePercent)
+	if err != nil {
+		return nil, err
+	}
 
 	resp = &vtctldatapb.ValidateShardResponse{}
 	getShardCtx, getShardCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
@@ -4551,6 +6036,21 @@ func (s *VtctldServer) ValidateShard(ctx context.Context, req *vtctldatapb.Valid
 		pingTablets(ctx, tabletMap, results)             // done async, using the waitgroup declared above in the main method body.
 	}
 
+	if len(req.Checks) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp.CheckResults = s.runValidationChecks(ctx, &shardValidation{
+				keyspace:    req.Keyspace,
+				shard:       req.Shard,
+				si:          si,
+				tablets:     tabletMap,
+				maxLag:      maxLag,
+				minDiskFree: minDiskFree,
+			}, req.Checks)
+		}()
+	}
+
 	done := make(chan bool)
 	go func() {
 		for result := range results {
@@ -4958,6 +6458,26 @@ func StartServer(s *grpc.Server, ts *topo.Server) {
 	vtctlservicepb.RegisterVtctldServer(s, NewVtctldServer(ts))
 }
 
+// getShardPrimary returns the primary tablet of the given shard, as recorded
+// in the shard record.
+func (s *VtctldServer) getShardPrimary(ctx context.Context, keyspace, shard string) (*topo.TabletInfo, error) {
+	si, err := s.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	if si.PrimaryAlias == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no primary tablet for shard %v/%v", keyspace, shard)
+	}
+
+	primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+	if err != nil {
+		return nil, fmt.Errorf("cannot lookup primary tablet %v for shard %v/%v: %w", topoproto.TabletAliasString(si.PrimaryAlias), keyspace, shard, err)
+	}
+
+	return primary, nil
+}
+
 // getTopologyCell is a helper method that returns a topology cell given its path.
 func (s *VtctldServer) getTopologyCell(ctx context.Context, cellPath string) (*vtctldatapb.TopologyCell, error) {
 	// extract cell and relative path
@@ -18,10 +18,12 @@ package grpcvtctldserver
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -39,6 +41,7 @@ import (
 	"vitess.io/vitess/go/test/utils"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -47,6 +50,7 @@ import (
 	"vitess.io/vitess/go/vt/vtctl/schematools"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 	"vitess.io/vitess/go/vt/vttablet/tmclienttest"
+	"vitess.io/vitess/go/vt/vttls"
 
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
@@ -343,6 +347,138 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplySchemaPreflight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "testkeyspace",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+			Keyspace: "testkeyspace",
+			Shard:    "80-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+	}
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true}, tablets...)
+
+	tmc := &testutil.TabletManagerClient{
+		ExecuteFetchAsDbaResults: map[string]struct {
+			Response *querypb.QueryResult
+			Error    error
+		}{},
+		GetSchemaResults: map[string]struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{},
+	}
+	for i, tablet := range tablets {
+		alias := topoproto.TabletAliasString(tablet.Alias)
+		tmc.ExecuteFetchAsDbaResults[alias] = struct {
+			Response *querypb.QueryResult
+			Error    error
+		}{
+			Response: sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields("@@global.version", "varchar"), "8.0.34")),
+		}
+		tmc.GetSchemaResults[alias] = struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{
+			Schema: &tabletmanagerdatapb.SchemaDefinition{
+				TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+					{
+						Name:       "t1",
+						Schema:     "CREATE TABLE `t1` (`id` int NOT NULL, `c` varchar(32), PRIMARY KEY (`id`))",
+						Type:       tmutils.TableBaseTable,
+						RowCount:   uint64(20_000 * (i + 1)),
+						DataLength: uint64(1_000_000 * (i + 1)),
+					},
+					{
+						Name:       "t2",
+						Schema:     "CREATE TABLE `t2` (`id` int NOT NULL, PRIMARY KEY (`id`))",
+						Type:       tmutils.TableBaseTable,
+						RowCount:   10,
+						DataLength: 16384,
+					},
+				},
+			},
+		}
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	sqls := []string{
+		"alter table t1 add column name varchar(64)",
+		"alter table t1 modify column id smallint not null, drop column c",
+		"drop table t2",
+		"alter table t3 add column x int",
+	}
+	resp, err := vtctld.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
+		Keyspace:  "testkeyspace",
+		Sql:       sqls,
+		Preflight: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.UuidList)
+	require.Len(t, resp.PreflightImpacts, 2*len(sqls))
+
+	for i, shard := range []string{"-80", "80-"} {
+		impacts := resp.PreflightImpacts[i*len(sqls) : (i+1)*len(sqls)]
+		for j, impact := range impacts {
+			assert.Equal(t, shard, impact.Shard)
+			assert.Equal(t, sqls[j], impact.Sql)
+		}
+		rows := uint64(20_000 * (i + 1))
+
+		// Adding a last column is instant.
+		assert.Equal(t, "t1", impacts[0].Table)
+		assert.Contains(t, impacts[0].Diff, "ADD COLUMN `name` varchar(64)")
+		assert.Equal(t, rows, impacts[0].TableRows)
+		assert.Equal(t, uint64(1_000_000*(i+1)), impacts[0].TableDataLength)
+		assert.True(t, impacts[0].InstantPossible)
+		assert.Equal(t, protoutil.DurationToProto(time.Duration(rows)*time.Second/preflightMigrationRowsPerSecond), impacts[0].EstimatedMigrationDuration)
+		assert.Empty(t, impacts[0].DataLossRisks)
+		assert.Empty(t, impacts[0].Error)
+
+		// Narrowing a column is not.
+		assert.False(t, impacts[1].InstantPossible)
+		assert.Equal(t, []string{
+			"column `c` is dropped",
+			"column `id` changes from int to smallint, which may not hold all of its values",
+		}, impacts[1].DataLossRisks)
+
+		assert.Equal(t, "t2", impacts[2].Table)
+		assert.Equal(t, "DROP TABLE `t2`", impacts[2].Diff)
+		assert.Equal(t, uint64(10), impacts[2].TableRows)
+		assert.Nil(t, impacts[2].EstimatedMigrationDuration)
+		assert.Equal(t, []string{"table `t2` is dropped"}, impacts[2].DataLossRisks)
+
+		assert.Equal(t, "table `t3` not found", impacts[3].Error)
+		assert.Empty(t, impacts[3].Diff)
+	}
+
+	_, err = vtctld.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
+		Keyspace:  "testkeyspace",
+		Sql:       []string{"alter table t1 add column"},
+		Preflight: true,
+	})
+	assert.Error(t, err, "unparsable statements should be rejected")
+
+	_, err = vtctld.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
+		Keyspace:  "otherkeyspace",
+		Sql:       sqls,
+		Preflight: true,
+	})
+	assert.Error(t, err, "keyspaces which do not exist should be rejected")
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -1276,6 +1412,145 @@ func TestCancelSchemaMigration(t *testing.T) {
 	}
 }
 
+func TestChangeTabletTags(t *testing.T) {
+	t.Parallel()
+
+	tablet := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_REPLICA,
+		Tags: map[string]string{
+			"az":    "us-east-1a",
+			"owner": "team-a",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		tmc       func(ts *topo.Server) *testutil.TabletManagerClient
+		req       *vtctldatapb.ChangeTabletTagsRequest
+		expected  *vtctldatapb.ChangeTabletTagsResponse
+		shouldErr bool
+	}{
+		{
+			name: "merge",
+			req: &vtctldatapb.ChangeTabletTagsRequest{
+				TabletAlias: tablet.Alias,
+				Tags: map[string]string{
+					"drained_from": "replica",
+					"owner":        "",
+				},
+			},
+			expected: &vtctldatapb.ChangeTabletTagsResponse{
+				BeforeTags: map[string]string{
+					"az":    "us-east-1a",
+					"owner": "team-a",
+				},
+				AfterTags: map[string]string{
+					"az":           "us-east-1a",
+					"drained_from": "replica",
+				},
+			},
+		},
+		{
+			name: "replace",
+			req: &vtctldatapb.ChangeTabletTagsRequest{
+				TabletAlias: tablet.Alias,
+				Tags: map[string]string{
+					"drained_from": "replica",
+				},
+				Replace: true,
+			},
+			expected: &vtctldatapb.ChangeTabletTagsResponse{
+				BeforeTags: map[string]string{
+					"az":    "us-east-1a",
+					"owner": "team-a",
+				},
+				AfterTags: map[string]string{
+					"drained_from": "replica",
+				},
+			},
+		},
+		{
+			name: "tablet not found",
+			req: &vtctldatapb.ChangeTabletTagsRequest{
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  404,
+				},
+				Tags: map[string]string{
+					"drained_from": "replica",
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "missing tablet alias",
+			req: &vtctldatapb.ChangeTabletTagsRequest{
+				Tags: map[string]string{
+					"drained_from": "replica",
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "tabletmanager failure",
+			tmc: func(ts *topo.Server) *testutil.TabletManagerClient {
+				return &testutil.TabletManagerClient{
+					ChangeTabletTagsResult: map[string]error{
+						"zone1-0000000100": assert.AnError,
+					},
+				}
+			},
+			req: &vtctldatapb.ChangeTabletTagsRequest{
+				TabletAlias: tablet.Alias,
+				Tags: map[string]string{
+					"drained_from": "replica",
+				},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ts := memorytopo.NewServer(ctx, "zone1")
+			tmc := &testutil.TabletManagerClient{
+				TopoServer: ts,
+			}
+			if tt.tmc != nil {
+				tmc = tt.tmc(ts)
+			}
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer { return NewVtctldServer(ts) })
+
+			testutil.AddTablets(ctx, t, ts, nil, tablet.CloneVT())
+
+			resp, err := vtctld.ChangeTabletTags(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+
+			ti, err := ts.GetTablet(ctx, tt.req.TabletAlias)
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected.AfterTags, ti.Tags, "ChangeTabletTags did not cause topo update")
+		})
+	}
+}
+
 func TestChangeTabletType(t *testing.T) {
 	t.Parallel()
 
@@ -1979,6 +2254,155 @@ func TestCompleteSchemaMigration(t *testing.T) {
 	}
 }
 
+func TestConcludeTransaction(t *testing.T) {
+	t.Parallel()
+
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  200,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "80-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+	}
+	participants := []*querypb.Target{{
+		Keyspace:   "testkeyspace",
+		Shard:      "80-",
+		TabletType: topodatapb.TabletType_PRIMARY,
+	}}
+
+	tests := []struct {
+		name        string
+		unresolved  []*querypb.TransactionMetadata
+		tmcResults  map[string]map[tabletmanagerdatapb.ConcludeTransactionRequest_Action]error
+		req         *vtctldatapb.ConcludeTransactionRequest
+		shouldErr   bool
+		errContains string
+	}{
+		{
+			name: "commit decision",
+			unresolved: []*querypb.TransactionMetadata{{
+				Dtid:         "testkeyspace:-80:1",
+				State:        querypb.TransactionState_COMMIT,
+				Participants: participants,
+			}},
+			tmcResults: map[string]map[tabletmanagerdatapb.ConcludeTransactionRequest_Action]error{
+				"zone1-0000000100": {
+					tabletmanagerdatapb.ConcludeTransactionRequest_CONCLUDE: nil,
+				},
+				"zone1-0000000200": {
+					tabletmanagerdatapb.ConcludeTransactionRequest_COMMIT_PREPARED: nil,
+				},
+			},
+			req: &vtctldatapb.ConcludeTransactionRequest{
+				Dtid: "testkeyspace:-80:1",
+			},
+		},
+		{
+			name: "no decision",
+			unresolved: []*querypb.TransactionMetadata{{
+				Dtid:         "testkeyspace:-80:1",
+				State:        querypb.TransactionState_PREPARE,
+				Participants: participants,
+			}},
+			tmcResults: map[string]map[tabletmanagerdatapb.ConcludeTransactionRequest_Action]error{
+				"zone1-0000000100": {
+					tabletmanagerdatapb.ConcludeTransactionRequest_SET_ROLLBACK: nil,
+					tabletmanagerdatapb.ConcludeTransactionRequest_CONCLUDE:     nil,
+				},
+				"zone1-0000000200": {
+					tabletmanagerdatapb.ConcludeTransactionRequest_ROLLBACK_PREPARED: nil,
+				},
+			},
+			req: &vtctldatapb.ConcludeTransactionRequest{
+				Dtid: "testkeyspace:-80:1",
+			},
+		},
+		{
+			name: "participant failure",
+			unresolved: []*querypb.TransactionMetadata{{
+				Dtid:         "testkeyspace:-80:1",
+				State:        querypb.TransactionState_ROLLBACK,
+				Participants: participants,
+			}},
+			tmcResults: map[string]map[tabletmanagerdatapb.ConcludeTransactionRequest_Action]error{
+				"zone1-0000000100": {
+					tabletmanagerdatapb.ConcludeTransactionRequest_CONCLUDE: nil,
+				},
+				"zone1-0000000200": {
+					tabletmanagerdatapb.ConcludeTransactionRequest_ROLLBACK_PREPARED: assert.AnError,
+				},
+			},
+			req: &vtctldatapb.ConcludeTransactionRequest{
+				Dtid: "testkeyspace:-80:1",
+			},
+			shouldErr:   true,
+			errContains: "ROLLBACK_PREPARED of testkeyspace:-80:1 failed on testkeyspace/80-",
+		},
+		{
+			name: "unknown transaction",
+			req: &vtctldatapb.ConcludeTransactionRequest{
+				Dtid: "testkeyspace:-80:1",
+			},
+			shouldErr:   true,
+			errContains: "distributed transaction testkeyspace:-80:1 not found",
+		},
+		{
+			name: "invalid dtid",
+			req: &vtctldatapb.ConcludeTransactionRequest{
+				Dtid: "testkeyspace",
+			},
+			shouldErr:   true,
+			errContains: "invalid parts in dtid",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ts := memorytopo.NewServer(ctx, "zone1")
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+				AlsoSetShardPrimary: true,
+			}, tablets...)
+
+			tmc := &testutil.TabletManagerClient{
+				GetUnresolvedTransactionsResults: map[string]*tabletmanagerdatapb.GetUnresolvedTransactionsResponse{
+					"zone1-0000000100": {Transactions: tt.unresolved},
+				},
+				ConcludeTransactionResults: tt.tmcResults,
+			}
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			_, err := vtctld.ConcludeTransaction(ctx, tt.req)
+			if tt.shouldErr {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestCreateKeyspace(t *testing.T) {
 	t.Parallel()
 
@@ -4809,6 +5233,79 @@ func TestGetBackups(t *testing.T) {
 		assert.Less(t, len(limited.Backups), len(unlimited.Backups), "expected limited backups to be less than unlimited")
 		utils.MustMatch(t, limited.Backups[0], unlimited.Backups[len(unlimited.Backups)-1], "expected limiting to keep N most recent")
 	})
+
+	t.Run("detailed", func(t *testing.T) {
+		testutil.BackupStorage.Backups["ks3/-"] = []string{"1-full", "2-incremental", "3-corrupt", "4-in-progress"}
+		testutil.BackupStorage.Manifests = map[string]string{
+			"ks3/-/1-full": `{
+				"BackupMethod": "builtin",
+				"Position": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+				"BackupTime": "2023-10-08T06:00:00Z",
+				"FinishedTime": "2023-10-08T06:10:00Z",
+				"CompressionEngine": "zstd",
+				"FileEntries": [{"Name": "a", "Size": 100}, {"Name": "b", "Size": 50}]
+			}`,
+			"ks3/-/2-incremental": `{
+				"BackupMethod": "builtin",
+				"Incremental": true,
+				"FromBackup": "1-full",
+				"FromPosition": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+				"Position": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-120",
+				"SkipCompress": true
+			}`,
+			"ks3/-/3-corrupt": `{`,
+		}
+		defer func() { testutil.BackupStorage.Manifests = nil }()
+
+		require.NoError(t, ts.SaveBackupValidation(ctx, "ks3", "-", &tabletmanagerdatapb.BackupValidation{
+			BackupName: "1-full",
+			Valid:      true,
+		}))
+		require.NoError(t, ts.SaveBackupValidation(ctx, "ks3", "-", &tabletmanagerdatapb.BackupValidation{
+			BackupName: "2-incremental",
+			Errors:     []string{"restored position does not match"},
+		}))
+
+		resp, err := vtctld.GetBackups(ctx, &vtctldatapb.GetBackupsRequest{
+			Keyspace:      "ks3",
+			Shard:         "-",
+			Detailed:      true,
+			DetailedLimit: 3,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Backups, 4)
+
+		full := resp.Backups[0]
+		assert.Equal(t, mysqlctlpb.BackupInfo_UNKNOWN, full.Status, "only the 3 most recent backups are detailed")
+		assert.Zero(t, full.Size)
+
+		incremental := resp.Backups[1]
+		assert.Equal(t, mysqlctlpb.BackupInfo_INVALID, incremental.Status)
+		assert.Equal(t, []string{"restored position does not match"}, incremental.ValidationErrors)
+		assert.True(t, incremental.Incremental)
+		assert.Equal(t, "1-full", incremental.FromBackup)
+		assert.Equal(t, "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100", incremental.FromPosition)
+		assert.Equal(t, "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-120", incremental.Position)
+		assert.Empty(t, incremental.CompressionEngine)
+		assert.Nil(t, incremental.Duration)
+
+		assert.Equal(t, mysqlctlpb.BackupInfo_INVALID, resp.Backups[2].Status)
+		assert.Equal(t, mysqlctlpb.BackupInfo_INCOMPLETE, resp.Backups[3].Status)
+
+		resp, err = vtctld.GetBackups(ctx, &vtctldatapb.GetBackupsRequest{
+			Keyspace: "ks3",
+			Shard:    "-",
+			Detailed: true,
+		})
+		require.NoError(t, err)
+		full = resp.Backups[0]
+		assert.Equal(t, mysqlctlpb.BackupInfo_VALID, full.Status)
+		assert.Equal(t, "builtin", full.Engine)
+		assert.Equal(t, int64(150), full.Size)
+		assert.Equal(t, "zstd", full.CompressionEngine)
+		assert.Equal(t, int64(600), full.Duration.Seconds)
+		assert.False(t, full.Incremental)
+	})
 }
 
 func TestGetKeyspace(t *testing.T) {
@@ -4940,6 +5437,50 @@ func TestGetCellsAliases(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClusterSettings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx)
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetClusterSetting(ctx, &vtctldatapb.GetClusterSettingRequest{Name: "vtgate.max_memory_rows"})
+	require.NoError(t, err)
+	require.Len(t, resp.Settings, 1)
+	assert.Equal(t, "int", resp.Settings[0].Type)
+	assert.False(t, resp.Settings[0].IsSet)
+
+	_, err = vtctld.SetClusterSetting(ctx, &vtctldatapb.SetClusterSettingRequest{Name: "vtgate.unknown", Value: "1"})
+	assert.ErrorContains(t, err, "unknown cluster setting vtgate.unknown")
+	_, err = vtctld.SetClusterSetting(ctx, &vtctldatapb.SetClusterSettingRequest{Name: "vtgate.max_memory_rows", Value: "many"})
+	assert.ErrorContains(t, err, "invalid int value")
+	_, err = vtctld.SetClusterSetting(ctx, &vtctldatapb.SetClusterSettingRequest{Name: "vtgate.max_memory_rows", Value: "0"})
+	assert.ErrorContains(t, err, "must be positive")
+
+	set, err := vtctld.SetClusterSetting(ctx, &vtctldatapb.SetClusterSettingRequest{Name: "vtgate.max_memory_rows", Value: "1000"})
+	require.NoError(t, err)
+	assert.True(t, set.Setting.IsSet)
+	assert.Equal(t, "1000", set.Setting.Value)
+	settings, _, err := ts.GetClusterSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"vtgate.max_memory_rows": "1000"}, settings.Settings)
+
+	resp, err = vtctld.GetClusterSetting(ctx, &vtctldatapb.GetClusterSettingRequest{})
+	require.NoError(t, err)
+	assert.Greater(t, len(resp.Settings), 1)
+	for _, setting := range resp.Settings {
+		assert.Equal(t, setting.Name == "vtgate.max_memory_rows", setting.IsSet, setting.Name)
+	}
+
+	set, err = vtctld.SetClusterSetting(ctx, &vtctldatapb.SetClusterSettingRequest{Name: "vtgate.max_memory_rows", Unset: true})
+	require.NoError(t, err)
+	assert.False(t, set.Setting.IsSet)
+	settings, _, err = ts.GetClusterSettings(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, settings.Settings)
+}
+
 func TestGetFullStatus(t *testing.T) {
 	t.Parallel()
 
@@ -5072,20 +5613,89 @@ func TestGetKeyspaces(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestGetPermissions(t *testing.T) {
+func TestGetLocksAndForceUnlock(t *testing.T) {
 	t.Parallel()
 
-	var testGetPermissionsReply = &tabletmanagerdatapb.Permissions{
-		UserPermissions: []*tabletmanagerdatapb.UserPermission{
-			{
-				Host: "host1",
-				User: "user1",
-				Privileges: map[string]string{
-					"create": "yes",
-					"delete": "no",
-				},
-			},
-		},
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetLocks(ctx, &vtctldatapb.GetLocksRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Locks)
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "keyspace1", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "keyspace1", "-80"))
+	require.NoError(t, ts.CreateKeyspace(ctx, "keyspace2", &topodatapb.Keyspace{}))
+
+	// A stuck process holds the lock of a shard.
+	_, unlock, err := ts.LockShard(ctx, "keyspace1", "-80", "StuckAction")
+	require.NoError(t, err)
+	defer unlock(&err)
+	_, unlock2, err := ts.LockKeyspace(ctx, "keyspace2", "OtherAction")
+	require.NoError(t, err)
+	defer unlock2(&err)
+
+	resp, err = vtctld.GetLocks(ctx, &vtctldatapb.GetLocksRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Locks, 2)
+	resp, err = vtctld.GetLocks(ctx, &vtctldatapb.GetLocksRequest{Keyspace: "keyspace1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Locks, 1)
+	lock := resp.Locks[0]
+	assert.Equal(t, "keyspace1", lock.Keyspace)
+	assert.Equal(t, "-80", lock.Shard)
+	assert.Equal(t, "keyspaces/keyspace1/shards/-80", lock.Path)
+	assert.Equal(t, "StuckAction", lock.Action)
+	assert.True(t, lock.Held)
+	assert.NotEmpty(t, lock.Id)
+	assert.NotEmpty(t, lock.HostName)
+	assert.NotNil(t, lock.Time)
+
+	_, err = vtctld.ForceUnlock(ctx, &vtctldatapb.ForceUnlockRequest{Keyspace: "keyspace1", Shard: "-80"})
+	assert.ErrorContains(t, err, "lock id must be set")
+	_, err = vtctld.ForceUnlock(ctx, &vtctldatapb.ForceUnlockRequest{Keyspace: "keyspace1", Shard: "-80", LockId: lock.Id + "0"})
+	assert.ErrorContains(t, err, "does not hold the lock anymore")
+	// The keyspace itself is not locked.
+	_, err = vtctld.ForceUnlock(ctx, &vtctldatapb.ForceUnlockRequest{Keyspace: "keyspace1", LockId: lock.Id})
+	assert.ErrorContains(t, err, "does not hold the lock anymore")
+
+	unlocked, err := vtctld.ForceUnlock(ctx, &vtctldatapb.ForceUnlockRequest{Keyspace: "keyspace1", Shard: "-80", LockId: lock.Id})
+	require.NoError(t, err)
+	assert.Equal(t, lock.Id, unlocked.Lock.Id)
+	assert.Equal(t, "StuckAction", unlocked.Lock.Action)
+
+	// The shard can be locked again.
+	lockCtx, lockCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer lockCancel()
+	_, unlock3, err := ts.LockShard(lockCtx, "keyspace1", "-80", "NewAction")
+	require.NoError(t, err)
+	defer unlock3(&err)
+
+	resp, err = vtctld.GetLocks(ctx, &vtctldatapb.GetLocksRequest{Keyspace: "keyspace1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Locks, 1)
+	assert.Equal(t, "NewAction", resp.Locks[0].Action)
+	assert.NotEqual(t, lock.Id, resp.Locks[0].Id)
+}
+
+func TestGetPermissions(t *testing.T) {
+	t.Parallel()
+
+	var testGetPermissionsReply = &tabletmanagerdatapb.Permissions{
+		UserPermissions: []*tabletmanagerdatapb.UserPermission{
+			{
+				Host: "host1",
+				User: "user1",
+				Privileges: map[string]string{
+					"create": "yes",
+					"delete": "no",
+				},
+			},
+		},
 		DbPermissions: []*tabletmanagerdatapb.DbPermission{
 			{
 				Host: "host2",
@@ -6940,6 +7550,78 @@ func TestGetTopologyPath(t *testing.T) {
 	}
 }
 
+func TestGetUnresolvedTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  200,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "80-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+	}
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardPrimary: true,
+	}, tablets...)
+
+	tmc := &testutil.TabletManagerClient{
+		GetUnresolvedTransactionsResults: map[string]*tabletmanagerdatapb.GetUnresolvedTransactionsResponse{
+			"zone1-0000000100": {
+				Transactions: []*querypb.TransactionMetadata{{
+					Dtid:        "testkeyspace:-80:2",
+					State:       querypb.TransactionState_COMMIT,
+					TimeCreated: 20,
+				}},
+			},
+			"zone1-0000000200": {
+				Transactions: []*querypb.TransactionMetadata{{
+					Dtid:        "testkeyspace:80-:1",
+					State:       querypb.TransactionState_PREPARE,
+					TimeCreated: 10,
+				}},
+			},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetUnresolvedTransactions(ctx, &vtctldatapb.GetUnresolvedTransactionsRequest{
+		Keyspace:   "testkeyspace",
+		AbandonAge: 60,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Transactions, 2)
+	// Transactions are sorted by creation time across shards.
+	assert.Equal(t, "testkeyspace:80-:1", resp.Transactions[0].Dtid)
+	assert.Equal(t, "testkeyspace:-80:2", resp.Transactions[1].Dtid)
+
+	// A shard whose primary cannot be reached fails the whole request.
+	delete(tmc.GetUnresolvedTransactionsResults, "zone1-0000000200")
+	_, err = vtctld.GetUnresolvedTransactions(ctx, &vtctldatapb.GetUnresolvedTransactionsRequest{
+		Keyspace: "testkeyspace",
+	})
+	assert.ErrorContains(t, err, "GetUnresolvedTransactions(zone1-0000000200) failed")
+}
+
 func TestGetVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -7479,6 +8161,80 @@ func TestPlannedReparentShard(t *testing.T) {
 	}
 }
 
+func TestPruneBackups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx)
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	setup := func() {
+		testutil.BackupStorage.Backups = map[string][]string{
+			"testkeyspace/-": {
+				"2023-10-08.060000.zone1-0000000101",
+				"2023-10-08.070000.zone1-0000000101",
+				"2023-10-09.060000.zone1-0000000101",
+				"2023-10-10.060000.zone1-0000000101",
+			},
+		}
+		testutil.BackupStorage.Manifests = map[string]string{
+			"testkeyspace/-/2023-10-08.060000.zone1-0000000101": `{"Incremental": false}`,
+			"testkeyspace/-/2023-10-08.070000.zone1-0000000101": `{"Incremental": true}`,
+			"testkeyspace/-/2023-10-09.060000.zone1-0000000101": `{"Incremental": false}`,
+		}
+	}
+	defer func() { testutil.BackupStorage.Manifests = nil }()
+
+	backupNames := func(bis []*mysqlctlpb.BackupInfo) []string {
+		var names []string
+		for _, bi := range bis {
+			names = append(names, bi.Name)
+		}
+		return names
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		setup()
+		resp, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			KeepFull: 1,
+			DryRun:   true,
+		})
+		require.NoError(t, err)
+		// The incremental backup goes with its full backup, and the
+		// incomplete backup may still be in progress.
+		assert.Equal(t, []string{"2023-10-09.060000.zone1-0000000101", "2023-10-10.060000.zone1-0000000101"}, backupNames(resp.Kept))
+		assert.Equal(t, []string{"2023-10-08.060000.zone1-0000000101", "2023-10-08.070000.zone1-0000000101"}, backupNames(resp.Removed))
+		assert.Equal(t, "testkeyspace", resp.Removed[0].Keyspace)
+		assert.Len(t, testutil.BackupStorage.Backups["testkeyspace/-"], 4)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		setup()
+		resp, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			KeepFull: 1,
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.Removed, 2)
+		assert.Equal(t, []string{"2023-10-09.060000.zone1-0000000101", "2023-10-10.060000.zone1-0000000101"}, testutil.BackupStorage.Backups["testkeyspace/-"])
+	})
+
+	t.Run("keep no full backup", func(t *testing.T) {
+		setup()
+		_, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace:      "testkeyspace",
+			Shard:         "-",
+			KeepDailyDays: 7,
+		})
+		assert.Error(t, err)
+		assert.Len(t, testutil.BackupStorage.Backups["testkeyspace/-"], 4)
+	})
+}
+
 func TestRebuildKeyspaceGraph(t *testing.T) {
 	t.Parallel()
 
@@ -8316,6 +9072,208 @@ func TestRemoveBackup(t *testing.T) {
 	})
 }
 
+func TestRemoveCell(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tablets   []*topodatapb.Tablet
+		req       *vtctldatapb.RemoveCellRequest
+		expected  *vtctldatapb.RemoveCellResponse
+		removed   bool
+		shouldErr bool
+	}{
+		{
+			name: "unreferenced cell",
+			req: &vtctldatapb.RemoveCellRequest{
+				Cell: "zone3",
+			},
+			expected: &vtctldatapb.RemoveCellResponse{
+				Actions: []string{"delete CellInfo"},
+			},
+			removed: true,
+		},
+		{
+			name: "referenced cell",
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_PRIMARY,
+				},
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 200},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			req: &vtctldatapb.RemoveCellRequest{
+				Cell: "zone2",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "dry run",
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_PRIMARY,
+				},
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 200},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			req: &vtctldatapb.RemoveCellRequest{
+				Cell:   "zone2",
+				DryRun: true,
+			},
+			expected: &vtctldatapb.RemoveCellResponse{
+				References: []string{
+					"tablet zone2-0000000200",
+					"SrvKeyspace testkeyspace",
+					"cells alias east",
+				},
+				Actions: []string{
+					"delete tablet zone2-0000000200",
+					"delete the replication graph of shard testkeyspace/-",
+					"delete SrvKeyspace testkeyspace",
+					"remove the cell from cells alias east",
+					"delete SrvVSchema",
+					"delete CellInfo",
+				},
+			},
+		},
+		{
+			name: "drain",
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_PRIMARY,
+				},
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 200},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			req: &vtctldatapb.RemoveCellRequest{
+				Cell:  "zone2",
+				Drain: true,
+			},
+			expected: &vtctldatapb.RemoveCellResponse{
+				References: []string{
+					"tablet zone2-0000000200",
+					"SrvKeyspace testkeyspace",
+					"cells alias east",
+				},
+				Actions: []string{
+					"delete tablet zone2-0000000200",
+					"delete the replication graph of shard testkeyspace/-",
+					"delete SrvKeyspace testkeyspace",
+					"remove the cell from cells alias east",
+					"delete SrvVSchema",
+					"delete CellInfo",
+				},
+			},
+			removed: true,
+		},
+		{
+			name: "drain without a replica to promote in another cell",
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 200},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_PRIMARY,
+				},
+				{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 201},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			req: &vtctldatapb.RemoveCellRequest{
+				Cell:  "zone2",
+				Drain: true,
+			},
+			shouldErr: true,
+		},
+		{
+			name: "cell not found",
+			req: &vtctldatapb.RemoveCellRequest{
+				Cell: "zone4",
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ts := memorytopo.NewServer(ctx, "zone1", "zone2", "zone3")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+				AlsoSetShardPrimary: true,
+			}, tt.tablets...)
+			for _, cell := range []string{"zone1", "zone2"} {
+				err := ts.UpdateSrvKeyspace(ctx, cell, "testkeyspace", &topodatapb.SrvKeyspace{})
+				require.NoError(t, err)
+				err = ts.UpdateSrvVSchema(ctx, cell, &vschemapb.SrvVSchema{})
+				require.NoError(t, err)
+			}
+			err := ts.CreateCellsAlias(ctx, "east", &topodatapb.CellsAlias{
+				Cells: []string{"zone1", "zone2"},
+			})
+			require.NoError(t, err)
+
+			resp, err := vtctld.RemoveCell(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+
+				cells, err := ts.GetCellInfoNames(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"zone1", "zone2", "zone3"}, cells, "cell should not be removed on error")
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+
+			cells, err := ts.GetCellInfoNames(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, !tt.removed, slices.Contains(cells, tt.req.Cell), "unexpected presence of cell %v in %v", tt.req.Cell, cells)
+
+			if tt.removed && len(tt.tablets) > 0 {
+				aliases, err := ts.GetCellsAliases(ctx, true)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"zone1"}, aliases["east"].Cells)
+
+				_, err = ts.GetTablet(ctx, tt.tablets[1].Alias)
+				assert.True(t, topo.IsErrType(err, topo.NoNode), "tablet %v should be deleted, got %v", topoproto.TabletAliasString(tt.tablets[1].Alias), err)
+			}
+		})
+	}
+}
+
 func TestRemoveKeyspaceCell(t *testing.T) {
 	t.Parallel()
 
@@ -9517,13 +10475,253 @@ func TestRestoreFromBackup(t *testing.T) {
 	}
 }
 
-func TestRetrySchemaMigration(t *testing.T) {
-	t.Parallel()
+func TestRestoreToPointInTime(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	tests := []struct {
-		name      string
-		tablets   []*topodatapb.Tablet
-		tmc       *testutil.TabletManagerClient
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  101,
+			},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  200,
+			},
+			Keyspace:      "ks",
+			Shard:         "-",
+			Type:          topodatapb.TabletType_PRIMARY,
+			MysqlHostname: "primary.db",
+			MysqlPort:     3306,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.RestoreToPointInTimeRequest
+		assertion func(t *testing.T, responses []*vtctldatapb.RestoreToPointInTimeResponse, err error)
+	}{
+		{
+			name: "ok",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:     "ks",
+				Shard:        "-",
+				RestoreToPos: "MySQL56/00000000-0000-0000-0000-000000000000:1-10",
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.RestoreToPointInTimeResponse, err error) {
+				assert.ErrorIs(t, err, io.EOF, "expected Recv loop to end with io.EOF")
+				require.Equal(t, 3, len(responses), "expected 3 messages from restoretopointintimeclient stream")
+				// The RDONLY tablet is preferred over the REPLICA one.
+				assert.Equal(t, "zone1-0000000101", topoproto.TabletAliasString(responses[0].TabletAlias))
+			},
+		},
+		{
+			name: "explicit tablet and binlog server",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace: "ks",
+				Shard:    "-",
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				RestoreToTimestamp: protoutil.TimeToProto(time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)),
+				BinlogServer:       "binlogs.db:3306",
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.RestoreToPointInTimeResponse, err error) {
+				assert.ErrorIs(t, err, io.EOF, "expected Recv loop to end with io.EOF")
+				require.Equal(t, 3, len(responses), "expected 3 messages from restoretopointintimeclient stream")
+				assert.Equal(t, "zone1-0000000100", topoproto.TabletAliasString(responses[0].TabletAlias))
+			},
+		},
+		{
+			name: "no restore point",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace: "ks",
+				Shard:    "-",
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.RestoreToPointInTimeResponse, err error) {
+				assert.NotErrorIs(t, err, io.EOF, "expected restoretopointintimeclient stream to close with non-EOF")
+				assert.Zero(t, len(responses), "expected no restoretopointintimeclient messages")
+			},
+		},
+		{
+			name: "primary tablet",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace: "ks",
+				Shard:    "-",
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  200,
+				},
+				RestoreToPos: "MySQL56/00000000-0000-0000-0000-000000000000:1-10",
+				BinlogServer: "binlogs.db:3306",
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.RestoreToPointInTimeResponse, err error) {
+				assert.NotErrorIs(t, err, io.EOF, "expected restoretopointintimeclient stream to close with non-EOF")
+				assert.Zero(t, len(responses), "expected no restoretopointintimeclient messages")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			tmc := &testutil.TabletManagerClient{
+				RestoreFromBackupResults: map[string]struct {
+					Events        []*logutilpb.Event
+					EventInterval time.Duration
+					EventJitter   time.Duration
+					ErrorAfter    time.Duration
+				}{
+					"zone1-0000000100": {
+						Events: []*logutilpb.Event{{}, {}, {}},
+					},
+					"zone1-0000000101": {
+						Events: []*logutilpb.Event{{}, {}, {}},
+					},
+				},
+			}
+			testutil.AddTablets(ctx, t, ts,
+				&testutil.AddTabletOptions{
+					AlsoSetShardPrimary: true,
+				}, tablets...,
+			)
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			client := localvtctldclient.New(vtctld)
+			stream, err := client.RestoreToPointInTime(ctx, tt.req)
+			require.NoError(t, err)
+
+			responses, err := func() (responses []*vtctldatapb.RestoreToPointInTimeResponse, err error) {
+				for {
+					resp, err := stream.Recv()
+					if err != nil {
+						return responses, err
+					}
+
+					responses = append(responses, resp)
+				}
+			}()
+
+			tt.assertion(t, responses, err)
+		})
+	}
+}
+
+func TestGetRestoreBinlogSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	primary := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Keyspace:      "ks",
+		Shard:         "-",
+		Type:          topodatapb.TabletType_PRIMARY,
+		MysqlHostname: "primary.db",
+		MysqlPort:     3306,
+	}
+	binlogServer := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  101,
+		},
+		Keyspace:      "ks",
+		Shard:         "-",
+		Type:          topodatapb.TabletType_REPLICA,
+		Hostname:      "replica.vt",
+		PortMap:       map[string]int32{"binlog": 15991},
+		MysqlHostname: "replica.db",
+		MysqlPort:     3306,
+	}
+
+	tests := []struct {
+		name          string
+		tablets       []*topodatapb.Tablet
+		req           *vtctldatapb.RestoreToPointInTimeRequest
+		expected      *tabletmanagerdatapb.RestoreBinlogSource
+		expectedAlias *topodatapb.TabletAlias
+	}{
+		{
+			name:          "shard primary",
+			tablets:       []*topodatapb.Tablet{primary},
+			req:           &vtctldatapb.RestoreToPointInTimeRequest{Keyspace: "ks", Shard: "-"},
+			expected:      &tabletmanagerdatapb.RestoreBinlogSource{Host: "primary.db", Port: 3306},
+			expectedAlias: primary.Alias,
+		},
+		{
+			name:          "binlog server preferred over the shard primary",
+			tablets:       []*topodatapb.Tablet{primary, binlogServer},
+			req:           &vtctldatapb.RestoreToPointInTimeRequest{Keyspace: "ks", Shard: "-"},
+			expected:      &tabletmanagerdatapb.RestoreBinlogSource{Host: "replica.vt", Port: 15991},
+			expectedAlias: binlogServer.Alias,
+		},
+		{
+			name:    "binlog server of the source tablet",
+			tablets: []*topodatapb.Tablet{primary, binlogServer},
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:                "ks",
+				Shard:                   "-",
+				BinlogSourceTabletAlias: binlogServer.Alias,
+			},
+			expected:      &tabletmanagerdatapb.RestoreBinlogSource{Host: "replica.vt", Port: 15991},
+			expectedAlias: binlogServer.Alias,
+		},
+		{
+			name:    "explicit binlog server",
+			tablets: []*topodatapb.Tablet{primary, binlogServer},
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:     "ks",
+				Shard:        "-",
+				BinlogServer: "binlogs.db:3306",
+			},
+			expected: &tabletmanagerdatapb.RestoreBinlogSource{Host: "binlogs.db", Port: 3306},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			testutil.AddTablets(ctx, t, ts,
+				&testutil.AddTabletOptions{
+					AlsoSetShardPrimary: true,
+				}, tt.tablets...,
+			)
+			vtctld := NewVtctldServer(ts)
+
+			source, alias, err := vtctld.getRestoreBinlogSource(ctx, tt.req)
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, source)
+			utils.MustMatch(t, tt.expectedAlias, alias)
+		})
+	}
+}
+
+func TestRetrySchemaMigration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tablets   []*topodatapb.Tablet
+		tmc       *testutil.TabletManagerClient
 		req       *vtctldatapb.RetrySchemaMigrationRequest
 		expected  *vtctldatapb.RetrySchemaMigrationResponse
 		shouldErr bool
@@ -9719,6 +10917,90 @@ func TestRetrySchemaMigration(t *testing.T) {
 	}
 }
 
+func TestRotateTabletCertificates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddTablets(ctx, t, ts, nil,
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}},
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101}},
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 102}},
+	)
+
+	// Each tablet serves a certificate made of its alias, except zone1-0000000101
+	// which still serves its previous one.
+	certificate := func(name string) *x509.Certificate {
+		return &x509.Certificate{Raw: []byte(name)}
+	}
+	oldServedTabletCertificate := servedTabletCertificate
+	defer func() { servedTabletCertificate = oldServedTabletCertificate }()
+	servedTabletCertificate = func(ctx context.Context, tablet *topodatapb.Tablet) (*x509.Certificate, error) {
+		alias := topoproto.TabletAliasString(tablet.Alias)
+		if alias == "zone1-0000000101" {
+			return certificate("previous"), nil
+		}
+		return certificate(alias), nil
+	}
+
+	reported := func(alias string) *tabletmanagerdatapb.TLSCertificate {
+		return &tabletmanagerdatapb.TLSCertificate{
+			Sha256Fingerprint: vttls.Fingerprint(certificate(alias)),
+			Subject:           "CN=" + alias,
+		}
+	}
+	tmc := &testutil.TabletManagerClient{
+		ReloadTLSCertificatesResults: map[string]struct {
+			Response *tabletmanagerdatapb.ReloadTLSCertificatesResponse
+			Error    error
+		}{
+			"zone1-0000000100": {Response: &tabletmanagerdatapb.ReloadTLSCertificatesResponse{Certificate: reported("zone1-0000000100")}},
+			"zone1-0000000101": {Response: &tabletmanagerdatapb.ReloadTLSCertificatesResponse{Certificate: reported("zone1-0000000101")}},
+			"zone1-0000000102": {Error: assert.AnError},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.RotateTabletCertificates(ctx, &vtctldatapb.RotateTabletCertificatesRequest{})
+	assert.Error(t, err, "the tablets are required")
+
+	resp, err := vtctld.RotateTabletCertificates(ctx, &vtctldatapb.RotateTabletCertificatesRequest{
+		TabletAliases: []*topodatapb.TabletAlias{
+			{Cell: "zone1", Uid: 100},
+			{Cell: "zone1", Uid: 101},
+			{Cell: "zone1", Uid: 102},
+			{Cell: "zone1", Uid: 404},
+		},
+		Cert: []byte("cert"),
+		Key:  []byte("key"),
+	})
+	require.NoError(t, err, "the failures of the tablets are reported in the response")
+	require.Len(t, resp.Rotations, 4)
+
+	rotation := resp.Rotations[0]
+	assert.Equal(t, "zone1-0000000100", topoproto.TabletAliasString(rotation.TabletAlias))
+	assert.True(t, rotation.Verified)
+	assert.Empty(t, rotation.Error)
+	utils.MustMatch(t, reported("zone1-0000000100"), rotation.Certificate)
+
+	rotation = resp.Rotations[1]
+	assert.Equal(t, "zone1-0000000101", topoproto.TabletAliasString(rotation.TabletAlias))
+	assert.False(t, rotation.Verified)
+	assert.Contains(t, rotation.Error, "the tablet serves the certificate "+vttls.Fingerprint(certificate("previous")))
+	utils.MustMatch(t, reported("zone1-0000000101"), rotation.Certificate)
+
+	rotation = resp.Rotations[2]
+	assert.False(t, rotation.Verified)
+	assert.Contains(t, rotation.Error, assert.AnError.Error())
+	assert.Nil(t, rotation.Certificate)
+
+	rotation = resp.Rotations[3]
+	assert.False(t, rotation.Verified)
+	assert.NotEmpty(t, rotation.Error)
+}
+
 func TestRunHealthCheck(t *testing.T) {
 	t.Parallel()
 
@@ -9905,6 +11187,111 @@ func TestSetKeyspaceDurabilityPolicy(t *testing.T) {
 	}
 }
 
+func TestSetKeyspaceFailoverPreferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		keyspaces   []*vtctldatapb.Keyspace
+		req         *vtctldatapb.SetKeyspaceFailoverPreferencesRequest
+		expected    *vtctldatapb.SetKeyspaceFailoverPreferencesResponse
+		expectedErr string
+	}{
+		{
+			name: "ok",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceFailoverPreferencesRequest{
+				Keyspace: "ks1",
+				FailoverPreferences: &topodatapb.FailoverPreferences{
+					PreferSameCell:    true,
+					NeverPromoteCells: []string{"zone2"},
+				},
+			},
+			expected: &vtctldatapb.SetKeyspaceFailoverPreferencesResponse{
+				Keyspace: &topodatapb.Keyspace{
+					FailoverPreferences: &topodatapb.FailoverPreferences{
+						PreferSameCell:    true,
+						NeverPromoteCells: []string{"zone2"},
+					},
+				},
+			},
+		},
+		{
+			name: "clear",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name: "ks1",
+					Keyspace: &topodatapb.Keyspace{
+						FailoverPreferences: &topodatapb.FailoverPreferences{
+							PreferSameCell: true,
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceFailoverPreferencesRequest{
+				Keyspace:            "ks1",
+				FailoverPreferences: &topodatapb.FailoverPreferences{},
+			},
+			expected: &vtctldatapb.SetKeyspaceFailoverPreferencesResponse{
+				Keyspace: &topodatapb.Keyspace{},
+			},
+		},
+		{
+			name: "unknown cell",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceFailoverPreferencesRequest{
+				Keyspace: "ks1",
+				FailoverPreferences: &topodatapb.FailoverPreferences{
+					NeverPromoteCells: []string{"zone3"},
+				},
+			},
+			expectedErr: "cell zone3 does not exist",
+		},
+		{
+			name: "keyspace not found",
+			req: &vtctldatapb.SetKeyspaceFailoverPreferencesRequest{
+				Keyspace: "ks1",
+			},
+			expectedErr: "node doesn't exist: keyspaces/ks1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ts := memorytopo.NewServer(ctx, "zone1", "zone2")
+			testutil.AddKeyspaces(ctx, t, ts, tt.keyspaces...)
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			resp, err := vtctld.SetKeyspaceFailoverPreferences(ctx, tt.req)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestSetShardIsPrimaryServing(t *testing.T) {
 	t.Parallel()
 
@@ -10252,6 +11639,112 @@ func TestSetShardTabletControl(t *testing.T) {
 	}
 }
 
+func TestSetTopoReadOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.SetTopoReadOnly(ctx, &vtctldatapb.SetTopoReadOnlyRequest{ReadOnly: true})
+	assert.ErrorContains(t, err, "a reason is required")
+
+	resp, err := vtctld.SetTopoReadOnly(ctx, &vtctldatapb.SetTopoReadOnlyRequest{ReadOnly: true, Reason: "topo migration"})
+	require.NoError(t, err)
+	assert.Equal(t, "topo migration", resp.TopoReadOnly.Reason)
+	assert.NotNil(t, resp.TopoReadOnly.Since)
+
+	getResp, err := vtctld.GetTopoReadOnly(ctx, &vtctldatapb.GetTopoReadOnlyRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, resp.TopoReadOnly, getResp.TopoReadOnly)
+
+	err = ts.CreateKeyspace(ctx, "keyspace1", &topodatapb.Keyspace{})
+	assert.ErrorContains(t, err, "read-only mode")
+
+	resp, err = vtctld.SetTopoReadOnly(ctx, &vtctldatapb.SetTopoReadOnlyRequest{ReadOnly: false})
+	require.NoError(t, err)
+	assert.Nil(t, resp.TopoReadOnly)
+
+	getResp, err = vtctld.GetTopoReadOnly(ctx, &vtctldatapb.GetTopoReadOnlyRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, getResp.TopoReadOnly)
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "keyspace1", &topodatapb.Keyspace{}))
+}
+
+func TestSetVtorcMaintenance(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{Keyspace: "testkeyspace", Name: "-80"})
+
+	_, err := vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{
+		Keyspace: "testkeyspace",
+		Enabled:  true,
+		Duration: protoutil.DurationToProto(time.Hour),
+	})
+	assert.ErrorContains(t, err, "a reason is required")
+	_, err = vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{
+		Keyspace: "testkeyspace",
+		Enabled:  true,
+		Reason:   "migration",
+	})
+	assert.ErrorContains(t, err, "a positive duration is required")
+
+	// Keyspace maintenance.
+	resp, err := vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{
+		Keyspace: "testkeyspace",
+		Enabled:  true,
+		Reason:   "migration",
+		Duration: protoutil.DurationToProto(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "migration", resp.VtorcMaintenance.Reason)
+	assert.Equal(t, time.Hour, protoutil.TimeFromProto(resp.VtorcMaintenance.ExpireTime).Sub(protoutil.TimeFromProto(resp.VtorcMaintenance.Since)))
+	ki, err := ts.GetKeyspace(ctx, "testkeyspace")
+	require.NoError(t, err)
+	utils.MustMatch(t, resp.VtorcMaintenance, ki.VtorcMaintenance)
+
+	// Shard maintenance.
+	resp, err = vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-80",
+		Enabled:  true,
+		Reason:   "upgrade",
+		Duration: protoutil.DurationToProto(30 * time.Minute),
+	})
+	require.NoError(t, err)
+	si, err := ts.GetShard(ctx, "testkeyspace", "-80")
+	require.NoError(t, err)
+	utils.MustMatch(t, resp.VtorcMaintenance, si.VtorcMaintenance)
+
+	// Ending them.
+	resp, err = vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{Keyspace: "testkeyspace", Shard: "-80"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.VtorcMaintenance)
+	si, err = ts.GetShard(ctx, "testkeyspace", "-80")
+	require.NoError(t, err)
+	assert.Nil(t, si.VtorcMaintenance)
+
+	_, err = vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	ki, err = ts.GetKeyspace(ctx, "testkeyspace")
+	require.NoError(t, err)
+	assert.Nil(t, ki.VtorcMaintenance)
+
+	// Ending a maintenance which is not running is a no-op.
+	_, err = vtctld.SetVtorcMaintenance(ctx, &vtctldatapb.SetVtorcMaintenanceRequest{Keyspace: "testkeyspace", Shard: "-80"})
+	require.NoError(t, err)
+}
+
 func TestSetWritable(t *testing.T) {
 	t.Parallel()
 
@@ -11845,6 +13338,51 @@ func TestTabletExternallyReparented(t *testing.T) {
 	}
 }
 
+func TestTopoBackupAndRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "keyspace1", &topodatapb.Keyspace{}))
+	testutil.AddTablets(ctx, t, ts, nil, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 100},
+		Hostname: "localhost",
+		Keyspace: "keyspace1",
+		Shard:    "0",
+	})
+
+	backup, err := vtctld.TopoBackup(ctx, &vtctldatapb.TopoBackupRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, backup.BackupName)
+	require.NotEmpty(t, backup.Snapshot.Files)
+
+	_, err = vtctld.TopoRestore(ctx, &vtctldatapb.TopoRestoreRequest{})
+	assert.ErrorContains(t, err, "one of Snapshot and BackupName must be set")
+	_, err = vtctld.TopoRestore(ctx, &vtctldatapb.TopoRestoreRequest{Snapshot: backup.Snapshot, BackupName: "backup"})
+	assert.ErrorContains(t, err, "only one of Snapshot and BackupName can be set")
+
+	// Restore into a new topo server.
+	newTS := memorytopo.NewServer(ctx, "cell1")
+	newVtctld := testutil.NewVtctldServerWithTabletManagerClient(t, newTS, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+	resp, err := newVtctld.TopoRestore(ctx, &vtctldatapb.TopoRestoreRequest{Snapshot: backup.Snapshot})
+	require.NoError(t, err)
+	assert.EqualValues(t, len(backup.Snapshot.Files), resp.Created+resp.Unchanged)
+	assert.Empty(t, resp.Conflicts)
+
+	tablet, err := newTS.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "cell1", Uid: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "keyspace1", tablet.Keyspace)
+	_, err = newTS.GetShard(ctx, "keyspace1", "0")
+	require.NoError(t, err)
+}
+
 func TestUpdateCellInfo(t *testing.T) {
 	t.Parallel()
 
@@ -12267,6 +13805,167 @@ func TestValidate(t *testing.T) {
 	}, resp)
 }
 
+func TestValidateBackup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  101,
+			},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  200,
+			},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  300,
+			},
+			Keyspace: "ks2",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+	}
+
+	validation := &tabletmanagerdatapb.BackupValidation{
+		BackupName: "2023-10-11.121314.zone1-0000000101",
+		Valid:      true,
+	}
+
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ValidateBackupRequest
+		assertion func(t *testing.T, responses []*vtctldatapb.ValidateBackupResponse, err error)
+	}{
+		{
+			name: "ok",
+			req: &vtctldatapb.ValidateBackupRequest{
+				Keyspace:       "ks",
+				Shard:          "-",
+				ChecksumTables: true,
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.ValidateBackupResponse, err error) {
+				assert.ErrorIs(t, err, io.EOF, "expected Recv loop to end with io.EOF")
+				require.Equal(t, 3, len(responses), "expected 3 messages from validatebackupclient stream")
+				// The RDONLY tablet is preferred over the REPLICA one.
+				assert.Equal(t, "zone1-0000000101", topoproto.TabletAliasString(responses[0].TabletAlias))
+				utils.MustMatch(t, validation, responses[2].Validation)
+			},
+		},
+		{
+			name: "explicit primary tablet",
+			req: &vtctldatapb.ValidateBackupRequest{
+				Keyspace: "ks",
+				Shard:    "-",
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  200,
+				},
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.ValidateBackupResponse, err error) {
+				assert.ErrorIs(t, err, io.EOF, "expected Recv loop to end with io.EOF")
+				require.Equal(t, 2, len(responses), "expected 2 messages from validatebackupclient stream")
+				assert.Equal(t, "zone1-0000000200", topoproto.TabletAliasString(responses[0].TabletAlias))
+				assert.Nil(t, responses[1].Event)
+				assert.NotNil(t, responses[1].Validation)
+			},
+		},
+		{
+			name: "tablet in another shard",
+			req: &vtctldatapb.ValidateBackupRequest{
+				Keyspace: "ks",
+				Shard:    "-",
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  300,
+				},
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.ValidateBackupResponse, err error) {
+				assert.NotErrorIs(t, err, io.EOF, "expected validatebackupclient stream to close with non-EOF")
+				assert.Zero(t, len(responses), "expected no validatebackupclient messages")
+			},
+		},
+		{
+			name: "no non-primary tablet",
+			req: &vtctldatapb.ValidateBackupRequest{
+				Keyspace: "ks2",
+				Shard:    "-",
+			},
+			assertion: func(t *testing.T, responses []*vtctldatapb.ValidateBackupResponse, err error) {
+				assert.NotErrorIs(t, err, io.EOF, "expected validatebackupclient stream to close with non-EOF")
+				assert.Zero(t, len(responses), "expected no validatebackupclient messages")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			tmc := &testutil.TabletManagerClient{
+				ValidateBackupResults: map[string]struct {
+					Events     []*logutilpb.Event
+					Validation *tabletmanagerdatapb.BackupValidation
+					Error      error
+				}{
+					"zone1-0000000101": {
+						Events:     []*logutilpb.Event{{}, {}},
+						Validation: validation,
+					},
+					"zone1-0000000200": {
+						Events:     []*logutilpb.Event{{}},
+						Validation: validation,
+					},
+				},
+			}
+			testutil.AddTablets(ctx, t, ts,
+				&testutil.AddTabletOptions{
+					AlsoSetShardPrimary: true,
+				}, tablets...,
+			)
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			client := localvtctldclient.New(vtctld)
+			stream, err := client.ValidateBackup(ctx, tt.req)
+			require.NoError(t, err)
+
+			responses, err := func() (responses []*vtctldatapb.ValidateBackupResponse, err error) {
+				for {
+					resp, err := stream.Recv()
+					if err != nil {
+						return responses, err
+					}
+
+					responses = append(responses, resp)
+				}
+			}()
+
+			tt.assertion(t, responses, err)
+		})
+	}
+}
+
 func TestValidateSchemaKeyspace(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -19,7 +19,10 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"io"
+	"path"
 	"sort"
+	"strings"
 
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 )
@@ -30,6 +33,9 @@ type backupStorage struct {
 	// Backups is a mapping of directory to list of backup names stored in that
 	// directory.
 	Backups map[string][]string
+	// Manifests is a mapping of <directory>/<name> to the MANIFEST of that
+	// backup. Backups without a MANIFEST are incomplete.
+	Manifests map[string]string
 	// ListBackupsError is returned from ListBackups when it is non-nil.
 	ListBackupsError error
 }
@@ -45,7 +51,7 @@ func (bs *backupStorage) ListBackups(ctx context.Context, dir string) ([]backups
 	for k, v := range bs.Backups {
 		if k == dir {
 			for _, name := range v {
-				handles = append(handles, &backupHandle{directory: k, name: name})
+				handles = append(handles, &backupHandle{directory: k, name: name, manifests: bs.Manifests})
 			}
 		}
 	}
@@ -87,11 +93,22 @@ type backupHandle struct {
 
 	directory string
 	name      string
+	manifests map[string]string
 }
 
 func (bh *backupHandle) Directory() string { return bh.directory }
 func (bh *backupHandle) Name() string      { return bh.name }
 
+// ReadFile is part of the backupstorage.BackupHandle interface. Only the
+// MANIFEST file can be read.
+func (bh *backupHandle) ReadFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	manifest, ok := bh.manifests[path.Join(bh.directory, bh.name)]
+	if !ok || filename != "MANIFEST" {
+		return nil, fmt.Errorf("no file %s in backup %s/%s", filename, bh.directory, bh.name)
+	}
+	return io.NopCloser(strings.NewReader(manifest)), nil
+}
+
 // handlesByName implements the sort interface for backup handles by Name().
 type handlesByName []backupstorage.BackupHandle
 
@@ -19,6 +19,7 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
@@ -185,6 +186,8 @@ type TabletManagerClient struct {
 		ErrorAfter    time.Duration
 	}
 	// keyed by tablet alias.
+	ChangeTabletTagsResult map[string]error
+	// keyed by tablet alias.
 	ChangeTabletTypeResult map[string]error
 	// keyed by tablet alias.
 	DemotePrimaryDelays map[string]time.Duration
@@ -279,6 +282,11 @@ type TabletManagerClient struct {
 	}
 	// keyed by tablet alias.
 	RefreshStateResults map[string]error
+	// keyed by tablet alias.
+	ReloadTLSCertificatesResults map[string]struct {
+		Response *tabletmanagerdatapb.ReloadTLSCertificatesResponse
+		Error    error
+	}
 	// keyed by `<tablet_alias>/<wait_pos>`.
 	ReloadSchemaDelays map[string]time.Duration
 	// keyed by `<tablet_alias>/<wait_pos>`.
@@ -340,6 +348,12 @@ type TabletManagerClient struct {
 	UndoDemotePrimaryDelays map[string]time.Duration
 	// keyed by tablet alias
 	UndoDemotePrimaryResults map[string]error
+	// keyed by tablet alias.
+	ValidateBackupResults map[string]struct {
+		Events     []*logutilpb.Event
+		Validation *tabletmanagerdatapb.BackupValidation
+		Error      error
+	}
 	// tablet alias => duration
 	VReplicationExecDelays map[string]time.Duration
 	// tablet alias => query string => result
@@ -359,14 +373,18 @@ type TabletManagerClient struct {
 	CheckThrottlerDelays map[string]time.Duration
 	// keyed by tablet alias
 	CheckThrottlerResults map[string]*tabletmanagerdatapb.CheckThrottlerResponse
+	// keyed by tablet alias
+	GetUnresolvedTransactionsResults map[string]*tabletmanagerdatapb.GetUnresolvedTransactionsResponse
+	// keyed by tablet alias and then by action
+	ConcludeTransactionResults map[string]map[tabletmanagerdatapb.ConcludeTransactionRequest_Action]error
 }
 
 type backupStreamAdapter struct {
 	*grpcshim.BidiStream
-	ch chan *logutilpb.Event
+	ch chan *tabletmanagerdatapb.BackupResponse
 }
 
-func (stream *backupStreamAdapter) Recv() (*logutilpb.Event, error) {
+func (stream *backupStreamAdapter) Recv() (*tabletmanagerdatapb.BackupResponse, error) {
 	select {
 	case <-stream.Context().Done():
 		return nil, stream.Context().Err()
@@ -379,7 +397,7 @@ func (stream *backupStreamAdapter) Recv() (*logutilpb.Event, error) {
 	}
 }
 
-func (stream *backupStreamAdapter) Send(msg *logutilpb.Event) error {
+func (stream *backupStreamAdapter) Send(msg *tabletmanagerdatapb.BackupResponse) error {
 	select {
 	case <-stream.Context().Done():
 		return stream.Context().Err()
@@ -391,7 +409,7 @@ func (stream *backupStreamAdapter) Send(msg *logutilpb.Event) error {
 }
 
 // Backup is part of the tmclient.TabletManagerClient interface.
-func (fake *TabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (logutil.EventStream, error) {
+func (fake *TabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (tmclient.BackupStream, error) {
 	if tablet.Type == topodatapb.TabletType_PRIMARY && !req.AllowPrimary {
 		return nil, fmt.Errorf("cannot backup primary with allowPrimary=false")
 	}
@@ -404,7 +422,7 @@ func (fake *TabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.
 
 	stream := &backupStreamAdapter{
 		BidiStream: grpcshim.NewBidiStream(ctx),
-		ch:         make(chan *logutilpb.Event, len(testdata.Events)),
+		ch:         make(chan *tabletmanagerdatapb.BackupResponse, len(testdata.Events)),
 	}
 	go func() {
 		if testdata.EventInterval == 0 {
@@ -443,7 +461,7 @@ func (fake *TabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.
 		defer stream.CloseWithError(nil)
 
 		for _, event := range testdata.Events {
-			stream.ch <- event
+			stream.ch <- &tabletmanagerdatapb.BackupResponse{Event: event}
 			<-ticker.C
 		}
 
@@ -459,6 +477,36 @@ func (fake *TabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.
 	return stream, nil
 }
 
+// ChangeTags is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ChangeTags(ctx context.Context, tablet *topodatapb.Tablet, tabletTags map[string]string, replace bool) (*tabletmanagerdatapb.ChangeTagsResponse, error) {
+	if result, ok := fake.ChangeTabletTagsResult[topoproto.TabletAliasString(tablet.Alias)]; ok {
+		return nil, result
+	}
+
+	if fake.TopoServer == nil {
+		return nil, assert.AnError
+	}
+
+	ti, err := fake.TopoServer.UpdateTabletFields(ctx, tablet.Alias, func(t *topodatapb.Tablet) error {
+		if replace || t.Tags == nil {
+			t.Tags = map[string]string{}
+		}
+		for key, value := range tabletTags {
+			if value == "" {
+				delete(t.Tags, key)
+				continue
+			}
+			t.Tags[key] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tabletmanagerdatapb.ChangeTagsResponse{Tags: ti.Tags}, nil
+}
+
 // ChangeType is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) ChangeType(ctx context.Context, tablet *topodatapb.Tablet, newType topodatapb.TabletType, semiSync bool) error {
 	if result, ok := fake.ChangeTabletTypeResult[topoproto.TabletAliasString(tablet.Alias)]; ok {
@@ -884,6 +932,20 @@ func (fake *TabletManagerClient) ReloadSchema(ctx context.Context, tablet *topod
 	return fmt.Errorf("%w: no ReloadSchema result set for tablet %s", assert.AnError, key)
 }
 
+// ReloadTLSCertificates is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ReloadTLSCertificates(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (*tabletmanagerdatapb.ReloadTLSCertificatesResponse, error) {
+	if fake.ReloadTLSCertificatesResults == nil {
+		return nil, fmt.Errorf("%w: no ReloadTLSCertificates results on fake TabletManagerClient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.ReloadTLSCertificatesResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no ReloadTLSCertificates result set for tablet %s", assert.AnError, key)
+}
+
 // ReplicationStatus is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) ReplicationStatus(ctx context.Context, tablet *topodatapb.Tablet) (*replicationdatapb.Status, error) {
 	if fake.ReplicationStatusResults == nil {
@@ -1349,6 +1411,40 @@ func (fake *TabletManagerClient) UndoDemotePrimary(ctx context.Context, tablet *
 }
 
 // VReplicationExec is part of the tmclient.TabletManagerCLient interface.
+type validateBackupStream struct {
+	responses []*tabletmanagerdatapb.ValidateBackupResponse
+	err       error
+}
+
+func (stream *validateBackupStream) Recv() (*tabletmanagerdatapb.ValidateBackupResponse, error) {
+	if len(stream.responses) == 0 {
+		return nil, stream.err
+	}
+	resp := stream.responses[0]
+	stream.responses = stream.responses[1:]
+	return resp, nil
+}
+
+// ValidateBackup is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ValidateBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ValidateBackupRequest) (tmclient.ValidateBackupStream, error) {
+	key := topoproto.TabletAliasString(tablet.Alias)
+	testdata, ok := fake.ValidateBackupResults[key]
+	if !ok {
+		return nil, fmt.Errorf("no ValidateBackup fake result set for %s", key)
+	}
+
+	stream := &validateBackupStream{err: io.EOF}
+	for _, event := range testdata.Events {
+		stream.responses = append(stream.responses, &tabletmanagerdatapb.ValidateBackupResponse{Event: event})
+	}
+	if testdata.Error != nil {
+		stream.err = testdata.Error
+	} else {
+		stream.responses = append(stream.responses, &tabletmanagerdatapb.ValidateBackupResponse{Validation: testdata.Validation})
+	}
+	return stream, nil
+}
+
 func (fake *TabletManagerClient) VReplicationExec(ctx context.Context, tablet *topodatapb.Tablet, query string) (*querypb.QueryResult, error) {
 	if fake.VReplicationExecResults == nil {
 		return nil, assert.AnError
@@ -1422,3 +1518,33 @@ func (fake *TabletManagerClient) CheckThrottler(ctx context.Context, tablet *top
 
 	return nil, assert.AnError
 }
+
+// GetUnresolvedTransactions is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (*tabletmanagerdatapb.GetUnresolvedTransactionsResponse, error) {
+	if fake.GetUnresolvedTransactionsResults == nil {
+		return nil, fmt.Errorf("%w: no GetUnresolvedTransactions results on fake TabletManagerClient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if resp, ok := fake.GetUnresolvedTransactionsResults[key]; ok {
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("%w: no GetUnresolvedTransactions result set for tablet %s", assert.AnError, key)
+}
+
+// ConcludeTransaction is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ConcludeTransactionRequest) (*tabletmanagerdatapb.ConcludeTransactionResponse, error) {
+	if fake.ConcludeTransactionResults == nil {
+		return nil, fmt.Errorf("%w: no ConcludeTransaction results on fake TabletManagerClient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if results, ok := fake.ConcludeTransactionResults[key]; ok {
+		if err, ok := results[req.Action]; ok {
+			return &tabletmanagerdatapb.ConcludeTransactionResponse{}, err
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no ConcludeTransaction result set for tablet %s and action %v", assert.AnError, key, req.Action)
+}
@@ -19,19 +19,39 @@ package grpcvtctldserver
 import (
 	"context"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
+const (
+	// topoSnapshotBackupDir is the directory of the backup storage in which
+	// TopoBackup stores the topo snapshots, next to the directories of the
+	// keyspaces.
+	topoSnapshotBackupDir = "_topo"
+
+	// topoSnapshotFileName is the file, within a backup, that holds the
+	// topo snapshot.
+	topoSnapshotFileName = "topo_snapshot.json"
+)
+
 func deleteShard(ctx context.Context, ts *topo.Server, keyspace string, shard string, recursive bool, evenIfServing bool, force bool) (err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.deleteShard")
 	defer span.Finish()
@@ -356,3 +376,258 @@ func removeShardCell(ctx context.Context, ts *topo.Server, cell string, keyspace
 
 	return err
 }
+
+// cellReferences are the topo records referencing a cell, which must be
+// removed before the cell itself.
+type cellReferences struct {
+	// primaries are the shards whose primary is in the cell.
+	primaries []*topo.ShardInfo
+	// tablets are the tablets in the cell.
+	tablets []*topodatapb.TabletAlias
+	// srvKeyspaces are the keyspaces served in the cell.
+	srvKeyspaces []string
+	// aliases are the cells aliases including the cell.
+	aliases []string
+
+	// replicationShards are the shards with a replication graph in the
+	// cell. They are not references, as the replication graphs are cell
+	// data, but they must be deleted with the cell.
+	replicationShards []*topo.ShardInfo
+	// hasSrvVSchema is set if the cell has a SrvVSchema.
+	hasSrvVSchema bool
+}
+
+// describe returns a description of each reference.
+func (refs *cellReferences) describe() []string {
+	var references []string
+	for _, shard := range refs.primaries {
+		references = append(references, fmt.Sprintf("primary %v of shard %v/%v", topoproto.TabletAliasString(shard.PrimaryAlias), shard.Keyspace(), shard.ShardName()))
+	}
+	for _, alias := range refs.tablets {
+		references = append(references, "tablet "+topoproto.TabletAliasString(alias))
+	}
+	for _, keyspace := range refs.srvKeyspaces {
+		references = append(references, "SrvKeyspace "+keyspace)
+	}
+	for _, alias := range refs.aliases {
+		references = append(references, "cells alias "+alias)
+	}
+	return references
+}
+
+// findCellReferences finds the topo records referencing a cell.
+func findCellReferences(ctx context.Context, ts *topo.Server, cell string) (*cellReferences, error) {
+	refs := &cellReferences{}
+
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyspace := range keyspaces {
+		shards, err := ts.FindAllShardsInKeyspace(ctx, keyspace)
+		if err != nil {
+			return nil, err
+		}
+		shardNames := make([]string, 0, len(shards))
+		for shardName := range shards {
+			shardNames = append(shardNames, shardName)
+		}
+		sort.Strings(shardNames)
+
+		for _, shardName := range shardNames {
+			shard := shards[shardName]
+			if shard.PrimaryAlias != nil && shard.PrimaryAlias.Cell == cell {
+				refs.primaries = append(refs.primaries, shard)
+			}
+
+			_, err := ts.GetShardReplication(ctx, cell, keyspace, shardName)
+			switch {
+			case err == nil:
+				refs.replicationShards = append(refs.replicationShards, shard)
+			case topo.IsErrType(err, topo.NoNode):
+			default:
+				return nil, err
+			}
+		}
+	}
+
+	refs.tablets, err = ts.GetTabletAliasesByCell(ctx, cell)
+	if err != nil && !topo.IsErrType(err, topo.NoNode) {
+		return nil, err
+	}
+	sort.Slice(refs.tablets, func(i, j int) bool {
+		return topoproto.TabletAliasString(refs.tablets[i]) < topoproto.TabletAliasString(refs.tablets[j])
+	})
+
+	// The keyspaces with only replication graphs in the cell are listed
+	// too, so the SrvKeyspaces are read to make sure they exist.
+	srvKeyspaceNames, err := ts.GetSrvKeyspaceNames(ctx, cell)
+	if err != nil && !topo.IsErrType(err, topo.NoNode) {
+		return nil, err
+	}
+	for _, keyspace := range srvKeyspaceNames {
+		switch _, err := ts.GetSrvKeyspace(ctx, cell, keyspace); {
+		case err == nil:
+			refs.srvKeyspaces = append(refs.srvKeyspaces, keyspace)
+		case topo.IsErrType(err, topo.NoNode):
+		default:
+			return nil, err
+		}
+	}
+
+	aliases, err := ts.GetCellsAliases(ctx, true /*strongRead*/)
+	if err != nil {
+		return nil, err
+	}
+	for alias, cellsAlias := range aliases {
+		if slices.Contains(cellsAlias.Cells, cell) {
+			refs.aliases = append(refs.aliases, alias)
+		}
+	}
+	sort.Strings(refs.aliases)
+
+	switch _, err := ts.GetSrvVSchema(ctx, cell); {
+	case err == nil:
+		refs.hasSrvVSchema = true
+	case topo.IsErrType(err, topo.NoNode):
+	default:
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// removeCellFromCellsAlias removes a cell from a cells alias, and deletes
+// the alias if it was its last cell.
+func removeCellFromCellsAlias(ctx context.Context, ts *topo.Server, alias string, cell string) error {
+	aliases, err := ts.GetCellsAliases(ctx, true /*strongRead*/)
+	if err != nil {
+		return err
+	}
+
+	cellsAlias, ok := aliases[alias]
+	if !ok || !slices.Contains(cellsAlias.Cells, cell) {
+		return nil
+	}
+
+	if len(cellsAlias.Cells) == 1 {
+		return ts.DeleteCellsAlias(ctx, alias)
+	}
+
+	return ts.UpdateCellsAlias(ctx, alias, func(ca *topodatapb.CellsAlias) error {
+		ca.Cells = slices.DeleteFunc(ca.Cells, func(c string) bool { return c == cell })
+		return nil
+	})
+}
+
+// storeTopoSnapshot stores a topo snapshot in the backup storage, and returns
+// its name, the time at which it is stored.
+func storeTopoSnapshot(ctx context.Context, snapshot *vtctldatapb.TopoSnapshot) (name string, err error) {
+	data, err := json2.MarshalPB(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return "", err
+	}
+	defer bs.Close()
+
+	name = time.Now().UTC().Format(mysqlctl.BackupTimestampFormat)
+	bh, err := bs.StartBackup(ctx, topoSnapshotBackupDir, name)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			if abortErr := bh.AbortBackup(ctx); abortErr != nil {
+				log.Warningf("Cannot abort the backup of topo snapshot %v: %v", name, abortErr)
+			}
+		}
+	}()
+
+	wc, err := bh.AddFile(ctx, topoSnapshotFileName, int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+	if _, err := wc.Write(data); err != nil {
+		wc.Close()
+		return "", err
+	}
+	if err := wc.Close(); err != nil {
+		return "", err
+	}
+	if err := bh.EndBackup(ctx); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// readTopoSnapshot reads a topo snapshot stored by storeTopoSnapshot.
+func readTopoSnapshot(ctx context.Context, name string) (*vtctldatapb.TopoSnapshot, error) {
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	bhs, err := bs.ListBackups(ctx, topoSnapshotBackupDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, bh := range bhs {
+		if bh.Name() != name {
+			continue
+		}
+
+		rc, err := bh.ReadFile(ctx, topoSnapshotFileName)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := &vtctldatapb.TopoSnapshot{}
+		if err := json2.Unmarshal(data, snapshot); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot decode topo snapshot %v", name)
+		}
+		return snapshot, nil
+	}
+	return nil, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "no topo snapshot named %v in the backup storage", name)
+}
+
+// topoLockToProto describes a keyspace or shard lock holder.
+func topoLockToProto(holder *topo.LockHolder, now time.Time) *vtctldatapb.TopoLock {
+	lock := &vtctldatapb.TopoLock{
+		Path: holder.Path,
+		Id:   holder.ID,
+		Held: holder.Held,
+	}
+	// The path is keyspaces/<keyspace>, or keyspaces/<keyspace>/shards/<shard>.
+	parts := strings.Split(holder.Path, "/")
+	if len(parts) >= 2 {
+		lock.Keyspace = parts[1]
+	}
+	if len(parts) >= 4 {
+		lock.Shard = parts[3]
+	}
+
+	l, err := topo.ParseLock(holder.Contents)
+	if err != nil {
+		lock.Contents = holder.Contents
+		return lock
+	}
+	lock.Action = l.Action
+	lock.HostName = l.HostName
+	lock.UserName = l.UserName
+	lock.Status = l.Status
+	if t, err := time.Parse(time.RFC3339, l.Time); err == nil {
+		lock.Time = protoutil.TimeToProto(t)
+		lock.Age = protoutil.DurationToProto(now.Sub(t))
+	}
+	return lock
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
+)
+
+// The deep checks of ValidateKeyspace and ValidateShard.
+const (
+	ValidationCheckReplication = "replication"
+	ValidationCheckSemiSync    = "semi_sync"
+	ValidationCheckVSchema     = "vschema"
+	ValidationCheckDisk        = "disk"
+)
+
+const (
+	defaultValidationMaxReplicationLag  = 30 * time.Second
+	defaultValidationMinDiskFreePercent = 10
+)
+
+// shardValidation holds what the deep checks of a shard validate.
+type shardValidation struct {
+	keyspace string
+	shard    string
+	si       *topo.ShardInfo
+	tablets  map[string]*topo.TabletInfo
+	primary  *topo.TabletInfo
+
+	maxLag      time.Duration
+	minDiskFree uint32
+
+	// statuses are the full statuses of the tablets, by alias, or the errors
+	// getting them.
+	statuses     map[string]*replicationdatapb.FullStatus
+	statusErrors map[string]error
+	aliases      []string // sorted
+}
+
+// validationCheckOptions returns the thresholds of the deep checks of a
+// ValidateKeyspace or ValidateShard request, after validating its checks.
+func validationCheckOptions(checks []string, maxLag *vttimepb.Duration, minDiskFree uint32) (time.Duration, uint32, error) {
+	for _, check := range checks {
+		switch check {
+		case ValidationCheckReplication, ValidationCheckSemiSync, ValidationCheckVSchema, ValidationCheckDisk:
+		default:
+			return 0, 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown check %q, expected one of %v", check,
+				[]string{ValidationCheckReplication, ValidationCheckSemiSync, ValidationCheckVSchema, ValidationCheckDisk})
+		}
+	}
+
+	lag, ok, err := protoutil.DurationFromProto(maxLag)
+	switch {
+	case err != nil:
+		return 0, 0, vterrors.Wrapf(err, "invalid max replication lag")
+	case !ok || lag == 0:
+		lag = defaultValidationMaxReplicationLag
+	}
+
+	switch {
+	case minDiskFree == 0:
+		minDiskFree = defaultValidationMinDiskFreePercent
+	case minDiskFree > 100:
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "min disk free percent must be at most 100, got %d", minDiskFree)
+	}
+
+	return lag, minDiskFree, nil
+}
+
+// runValidationChecks runs the deep checks of a shard in parallel, and returns
+// their results in the same order.
+func (s *VtctldServer) runValidationChecks(ctx context.Context, v *shardValidation, checks []string) []*vtctldatapb.ValidationCheckResult {
+	if v.si.PrimaryAlias != nil {
+		v.primary = v.tablets[topoproto.TabletAliasString(v.si.PrimaryAlias)]
+	}
+	for alias := range v.tablets {
+		v.aliases = append(v.aliases, alias)
+	}
+	sort.Strings(v.aliases)
+
+	for _, check := range checks {
+		if check != ValidationCheckVSchema {
+			s.getValidationStatuses(ctx, v)
+			break
+		}
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*vtctldatapb.ValidationCheckResult, len(checks))
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check string) {
+			defer wg.Done()
+
+			var issues []string
+			switch check {
+			case ValidationCheckReplication:
+				issues = s.validateReplicationCheck(v)
+			case ValidationCheckSemiSync:
+				issues = s.validateSemiSyncCheck(ctx, v)
+			case ValidationCheckVSchema:
+				issues = s.validateVSchemaCheck(ctx, v)
+			case ValidationCheckDisk:
+				issues = s.validateDiskCheck(v)
+			}
+			results[i] = &vtctldatapb.ValidationCheckResult{
+				Check:  check,
+				Passed: len(issues) == 0,
+				Issues: issues,
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// getValidationStatuses gets the full statuses of the tablets of the shard in
+// parallel.
+func (s *VtctldServer) getValidationStatuses(ctx context.Context, v *shardValidation) {
+	var (
+		m  sync.Mutex
+		wg sync.WaitGroup
+	)
+	v.statuses = make(map[string]*replicationdatapb.FullStatus, len(v.tablets))
+	v.statusErrors = make(map[string]error)
+	for alias, ti := range v.tablets {
+		wg.Add(1)
+		go func(alias string, ti *topo.TabletInfo) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+			defer cancel()
+
+			status, err := s.tmc.FullStatus(ctx, ti.Tablet)
+
+			m.Lock()
+			defer m.Unlock()
+			if err != nil {
+				v.statusErrors[alias] = err
+				return
+			}
+			v.statuses[alias] = status
+		}(alias, ti)
+	}
+	wg.Wait()
+}
+
+// validateReplicationCheck checks that the replicas replicate from the
+// primary, within the maximum lag, and have no transactions which the primary
+// does not have.
+func (s *VtctldServer) validateReplicationCheck(v *shardValidation) (issues []string) {
+	if v.primary == nil {
+		return []string{fmt.Sprintf("no primary for shard %v/%v", v.keyspace, v.shard)}
+	}
+
+	primaryAlias := topoproto.TabletAliasString(v.primary.Alias)
+	primaryStatus, ok := v.statuses[primaryAlias]
+	if !ok {
+		return []string{fmt.Sprintf("FullStatus(%v) failed on primary: %v", primaryAlias, v.statusErrors[primaryAlias])}
+	}
+
+	var primaryPosition replication.Position
+	if primaryStatus.PrimaryStatus != nil {
+		pos, err := replication.DecodePosition(primaryStatus.PrimaryStatus.Position)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("cannot decode the position %v of primary %v: %v", primaryStatus.PrimaryStatus.Position, primaryAlias, err))
+		}
+		primaryPosition = pos
+	}
+
+	for _, alias := range v.aliases {
+		ti := v.tablets[alias]
+		if alias == primaryAlias || !ti.IsReplicaType() {
+			continue
+		}
+
+		status, ok := v.statuses[alias]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("FullStatus(%v) failed: %v", alias, v.statusErrors[alias]))
+			continue
+		}
+
+		rs := status.ReplicationStatus
+		if rs == nil {
+			issues = append(issues, fmt.Sprintf("replica %v is not replicating", alias))
+			continue
+		}
+
+		if rs.SourceHost != v.primary.MysqlHostname || rs.SourcePort != v.primary.MysqlPort {
+			issues = append(issues, fmt.Sprintf("replica %v replicates from %v:%v rather than from primary %v at %v:%v",
+				alias, rs.SourceHost, rs.SourcePort, primaryAlias, v.primary.MysqlHostname, v.primary.MysqlPort))
+		}
+		if replication.ReplicationState(rs.IoState) != replication.ReplicationStateRunning {
+			issues = append(issues, fmt.Sprintf("replica %v has its IO thread not running, last error: %v", alias, rs.LastIoError))
+		}
+		if replication.ReplicationState(rs.SqlState) != replication.ReplicationStateRunning {
+			issues = append(issues, fmt.Sprintf("replica %v has its SQL thread not running, last error: %v", alias, rs.LastSqlError))
+		}
+
+		switch lag := time.Duration(rs.ReplicationLagSeconds) * time.Second; {
+		case rs.ReplicationLagUnknown:
+			issues = append(issues, fmt.Sprintf("replica %v has an unknown replication lag", alias))
+		case lag > v.maxLag:
+			issues = append(issues, fmt.Sprintf("replica %v lags %v behind the primary, more than %v", alias, lag, v.maxLag))
+		}
+
+		if primaryPosition.IsZero() {
+			continue
+		}
+		pos, err := replication.DecodePosition(rs.Position)
+		switch {
+		case err != nil:
+			issues = append(issues, fmt.Sprintf("cannot decode the position %v of replica %v: %v", rs.Position, alias, err))
+		case !primaryPosition.AtLeast(pos):
+			issues = append(issues, fmt.Sprintf("replica %v has transactions which primary %v does not have: its position is %v, and the one of the primary %v",
+				alias, primaryAlias, rs.Position, primaryStatus.PrimaryStatus.Position))
+		}
+	}
+
+	return issues
+}
+
+// validateSemiSyncCheck checks that the semi-sync settings of the tablets are
+// the ones of the durability policy of the keyspace.
+func (s *VtctldServer) validateSemiSyncCheck(ctx context.Context, v *shardValidation) (issues []string) {
+	if v.primary == nil {
+		return []string{fmt.Sprintf("no primary for shard %v/%v", v.keyspace, v.shard)}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+
+	ki, err := s.ts.GetKeyspace(ctx, v.keyspace)
+	if err != nil {
+		return []string{fmt.Sprintf("TopologyServer.GetKeyspace(%v) failed: %v", v.keyspace, err)}
+	}
+	durability, err := reparentutil.GetDurabilityPolicy(ki.DurabilityPolicy)
+	if err != nil {
+		return []string{fmt.Sprintf("invalid durability policy %q of keyspace %v: %v", ki.DurabilityPolicy, v.keyspace, err)}
+	}
+
+	primaryAlias := topoproto.TabletAliasString(v.primary.Alias)
+	ackers := reparentutil.SemiSyncAckers(durability, v.primary.Tablet)
+	if status, ok := v.statuses[primaryAlias]; !ok {
+		issues = append(issues, fmt.Sprintf("FullStatus(%v) failed on primary: %v", primaryAlias, v.statusErrors[primaryAlias]))
+	} else if status.SemiSyncPrimaryEnabled != (ackers > 0) {
+		issues = append(issues, fmt.Sprintf("primary %v has semi-sync enabled=%v, while durability policy %v requires %d acks",
+			primaryAlias, status.SemiSyncPrimaryEnabled, ki.DurabilityPolicy, ackers))
+	}
+
+	semiSyncReplicas := 0
+	for _, alias := range v.aliases {
+		ti := v.tablets[alias]
+		if alias == primaryAlias || !ti.IsReplicaType() {
+			continue
+		}
+
+		status, ok := v.statuses[alias]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("FullStatus(%v) failed: %v", alias, v.statusErrors[alias]))
+			continue
+		}
+
+		expected := reparentutil.IsReplicaSemiSync(durability, v.primary.Tablet, ti.Tablet)
+		if expected {
+			semiSyncReplicas++
+		}
+		if status.SemiSyncReplicaEnabled != expected {
+			issues = append(issues, fmt.Sprintf("replica %v has semi-sync enabled=%v, while durability policy %v expects %v",
+				alias, status.SemiSyncReplicaEnabled, ki.DurabilityPolicy, expected))
+		}
+	}
+
+	if semiSyncReplicas < ackers {
+		issues = append(issues, fmt.Sprintf("primary %v requires %d semi-sync acks, but only %d of its replicas can send them",
+			primaryAlias, ackers, semiSyncReplicas))
+	}
+
+	return issues
+}
+
+// validateVSchemaCheck checks that the tables of the vschema of the keyspace are
+// in the schema of the primary and, for a sharded keyspace, that the tables of
+// the schema are in the vschema.
+func (s *VtctldServer) validateVSchemaCheck(ctx context.Context, v *shardValidation) (issues []string) {
+	if v.primary == nil {
+		return []string{fmt.Sprintf("no primary for shard %v/%v", v.keyspace, v.shard)}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+
+	vs, err := s.ts.GetVSchema(ctx, v.keyspace)
+	if err != nil {
+		return []string{fmt.Sprintf("TopologyServer.GetVSchema(%v) failed: %v", v.keyspace, err)}
+	}
+
+	primaryAlias := topoproto.TabletAliasString(v.primary.Alias)
+	sd, err := s.tmc.GetSchema(ctx, v.primary.Tablet, &tabletmanagerdatapb.GetSchemaRequest{IncludeViews: true, TableSchemaOnly: true})
+	if err != nil {
+		return []string{fmt.Sprintf("GetSchema(%v) failed: %v", primaryAlias, err)}
+	}
+
+	tables := make(map[string]string, len(sd.TableDefinitions))
+	for _, td := range sd.TableDefinitions {
+		tables[td.Name] = td.Type
+	}
+
+	names := make([]string, 0, len(vs.Tables))
+	for name := range vs.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		table := vs.Tables[name]
+		if _, ok := tables[name]; !ok {
+			issues = append(issues, fmt.Sprintf("table %v of the vschema is not in the schema of primary %v", name, primaryAlias))
+		}
+		if vs.Sharded && table.Type != vindexes.TypeReference && table.Pinned == "" && len(table.ColumnVindexes) == 0 {
+			issues = append(issues, fmt.Sprintf("table %v of the vschema has no primary vindex", name))
+		}
+	}
+
+	if !vs.Sharded {
+		return issues
+	}
+	for _, td := range sd.TableDefinitions {
+		if td.Type != tmutils.TableBaseTable || schema.IsInternalOperationTableName(td.Name) {
+			continue
+		}
+		if _, ok := vs.Tables[td.Name]; !ok {
+			issues = append(issues, fmt.Sprintf("table %v of the schema of primary %v is not in the vschema", td.Name, primaryAlias))
+		}
+	}
+
+	return issues
+}
+
+// validateDiskCheck checks that the tablets have enough free space on the disk
+// of their data directory. The tablets which do not know it are skipped.
+func (s *VtctldServer) validateDiskCheck(v *shardValidation) (issues []string) {
+	for _, alias := range v.aliases {
+		status, ok := v.statuses[alias]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("FullStatus(%v) failed: %v", alias, v.statusErrors[alias]))
+			continue
+		}
+		if status.DiskTotalBytes == 0 {
+			continue
+		}
+
+		free := float64(status.DiskFreeBytes) * 100 / float64(status.DiskTotalBytes)
+		if free < float64(v.minDiskFree) {
+			issues = append(issues, fmt.Sprintf("tablet %v has %.1f%% of its disk free (%d of %d bytes), less than %d%%",
+				alias, free, status.DiskFreeBytes, status.DiskTotalBytes, v.minDiskFree))
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestValidationCheckOptions(t *testing.T) {
+	lag, minDiskFree, err := validationCheckOptions([]string{ValidationCheckReplication, ValidationCheckDisk}, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaultValidationMaxReplicationLag, lag)
+	assert.EqualValues(t, defaultValidationMinDiskFreePercent, minDiskFree)
+
+	lag, minDiskFree, err = validationCheckOptions(nil, protoutil.DurationToProto(time.Minute), 20)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, lag)
+	assert.EqualValues(t, 20, minDiskFree)
+
+	_, _, err = validationCheckOptions([]string{"nope"}, nil, 0)
+	assert.ErrorContains(t, err, `unknown check "nope"`)
+
+	_, _, err = validationCheckOptions(nil, nil, 101)
+	assert.Error(t, err)
+}
+
+func TestValidateReplicationCheck(t *testing.T) {
+	const (
+		uuid  = "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+		other = "4e11fa47-71ca-11e1-9e33-c80aa9429562"
+	)
+	tablet := func(uid uint32, tabletType topodatapb.TabletType) *topo.TabletInfo {
+		return &topo.TabletInfo{Tablet: &topodatapb.Tablet{
+			Alias:         &topodatapb.TabletAlias{Cell: "zone1", Uid: uid},
+			Type:          tabletType,
+			MysqlHostname: "host",
+			MysqlPort:     int32(uid),
+		}}
+	}
+	replicaStatus := func(position string, lag uint32) *replicationdatapb.FullStatus {
+		return &replicationdatapb.FullStatus{ReplicationStatus: &replicationdatapb.Status{
+			Position:              "MySQL56/" + position,
+			SourceHost:            "host",
+			SourcePort:            100,
+			IoState:               int32(replication.ReplicationStateRunning),
+			SqlState:              int32(replication.ReplicationStateRunning),
+			ReplicationLagSeconds: lag,
+		}}
+	}
+
+	v := &shardValidation{
+		keyspace: "ks",
+		shard:    "-",
+		primary:  tablet(100, topodatapb.TabletType_PRIMARY),
+		tablets: map[string]*topo.TabletInfo{
+			"zone1-0000000100": tablet(100, topodatapb.TabletType_PRIMARY),
+			"zone1-0000000101": tablet(101, topodatapb.TabletType_REPLICA),
+			"zone1-0000000102": tablet(102, topodatapb.TabletType_RDONLY),
+			"zone1-0000000103": tablet(103, topodatapb.TabletType_REPLICA),
+		},
+		aliases: []string{"zone1-0000000100", "zone1-0000000101", "zone1-0000000102", "zone1-0000000103"},
+		maxLag:  30 * time.Second,
+		statuses: map[string]*replicationdatapb.FullStatus{
+			"zone1-0000000100": {PrimaryStatus: &replicationdatapb.PrimaryStatus{Position: "MySQL56/" + uuid + ":1-10"}},
+			"zone1-0000000101": replicaStatus(uuid+":1-9", 1),
+			"zone1-0000000102": replicaStatus(uuid+":1-10", 60),
+			"zone1-0000000103": replicaStatus(uuid+":1-10,"+other+":1", 0),
+		},
+	}
+
+	issues := (&VtctldServer{}).validateReplicationCheck(v)
+	require.Len(t, issues, 2)
+	assert.Contains(t, issues[0], "replica zone1-0000000102 lags 1m0s behind the primary")
+	assert.Contains(t, issues[1], "replica zone1-0000000103 has transactions which primary zone1-0000000100 does not have")
+}
+
+func TestValidateDiskCheck(t *testing.T) {
+	v := &shardValidation{
+		aliases:     []string{"zone1-0000000100", "zone1-0000000101", "zone1-0000000102"},
+		minDiskFree: 10,
+		statuses: map[string]*replicationdatapb.FullStatus{
+			"zone1-0000000100": {DiskFreeBytes: 50, DiskTotalBytes: 100},
+			"zone1-0000000101": {DiskFreeBytes: 5, DiskTotalBytes: 100},
+			"zone1-0000000102": {},
+		},
+	}
+
+	issues := (&VtctldServer{}).validateDiskCheck(v)
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0], "tablet zone1-0000000101 has 5.0% of its disk free")
+}
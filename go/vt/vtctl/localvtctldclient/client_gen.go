@@ -161,11 +161,21 @@ func (client *localVtctldClient) BackupShard(ctx context.Context, in *vtctldatap
 	return stream, nil
 }
 
+// CancelMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) CancelMaintenance(ctx context.Context, in *vtctldatapb.CancelMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelMaintenanceResponse, error) {
+	return client.s.CancelMaintenance(ctx, in)
+}
+
 // CancelSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) CancelSchemaMigration(ctx context.Context, in *vtctldatapb.CancelSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
 	return client.s.CancelSchemaMigration(ctx, in)
 }
 
+// ChangeTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ChangeTabletTags(ctx context.Context, in *vtctldatapb.ChangeTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTagsResponse, error) {
+	return client.s.ChangeTabletTags(ctx, in)
+}
+
 // ChangeTabletType is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ChangeTabletType(ctx context.Context, in *vtctldatapb.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTypeResponse, error) {
 	return client.s.ChangeTabletType(ctx, in)
@@ -181,6 +191,11 @@ func (client *localVtctldClient) CompleteSchemaMigration(ctx context.Context, in
 	return client.s.CompleteSchemaMigration(ctx, in)
 }
 
+// ConcludeTransaction is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ConcludeTransaction(ctx context.Context, in *vtctldatapb.ConcludeTransactionRequest, opts ...grpc.CallOption) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	return client.s.ConcludeTransaction(ctx, in)
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) CreateKeyspace(ctx context.Context, in *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	return client.s.CreateKeyspace(ctx, in)
@@ -191,6 +206,16 @@ func (client *localVtctldClient) CreateShard(ctx context.Context, in *vtctldatap
 	return client.s.CreateShard(ctx, in)
 }
 
+// DelayedReplicaCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) DelayedReplicaCreate(ctx context.Context, in *vtctldatapb.DelayedReplicaCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	return client.s.DelayedReplicaCreate(ctx, in)
+}
+
+// DelayedReplicaFastForward is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) DelayedReplicaFastForward(ctx context.Context, in *vtctldatapb.DelayedReplicaFastForwardRequest, opts ...grpc.CallOption) (*vtctldatapb.DelayedReplicaFastForwardResponse, error) {
+	return client.s.DelayedReplicaFastForward(ctx, in)
+}
+
 // DeleteCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) DeleteCellInfo(ctx context.Context, in *vtctldatapb.DeleteCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteCellInfoResponse, error) {
 	return client.s.DeleteCellInfo(ctx, in)
@@ -246,6 +271,16 @@ func (client *localVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in
 	return client.s.FindAllShardsInKeyspace(ctx, in)
 }
 
+// ForceUnlock is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ForceUnlock(ctx context.Context, in *vtctldatapb.ForceUnlockRequest, opts ...grpc.CallOption) (*vtctldatapb.ForceUnlockResponse, error) {
+	return client.s.ForceUnlock(ctx, in)
+}
+
+// GetAuditLog is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetAuditLog(ctx context.Context, in *vtctldatapb.GetAuditLogRequest, opts ...grpc.CallOption) (*vtctldatapb.GetAuditLogResponse, error) {
+	return client.s.GetAuditLog(ctx, in)
+}
+
 // GetBackups is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	return client.s.GetBackups(ctx, in)
@@ -266,6 +301,11 @@ func (client *localVtctldClient) GetCellsAliases(ctx context.Context, in *vtctld
 	return client.s.GetCellsAliases(ctx, in)
 }
 
+// GetClusterSetting is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetClusterSetting(ctx context.Context, in *vtctldatapb.GetClusterSettingRequest, opts ...grpc.CallOption) (*vtctldatapb.GetClusterSettingResponse, error) {
+	return client.s.GetClusterSetting(ctx, in)
+}
+
 // GetFullStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetFullStatus(ctx context.Context, in *vtctldatapb.GetFullStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetFullStatusResponse, error) {
 	return client.s.GetFullStatus(ctx, in)
@@ -281,6 +321,16 @@ func (client *localVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldata
 	return client.s.GetKeyspaces(ctx, in)
 }
 
+// GetLocks is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetLocks(ctx context.Context, in *vtctldatapb.GetLocksRequest, opts ...grpc.CallOption) (*vtctldatapb.GetLocksResponse, error) {
+	return client.s.GetLocks(ctx, in)
+}
+
+// GetMaintenances is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetMaintenances(ctx context.Context, in *vtctldatapb.GetMaintenancesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenancesResponse, error) {
+	return client.s.GetMaintenances(ctx, in)
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
 	return client.s.GetPermissions(ctx, in)
@@ -346,6 +396,16 @@ func (client *localVtctldClient) GetTopologyPath(ctx context.Context, in *vtctld
 	return client.s.GetTopologyPath(ctx, in)
 }
 
+// GetTopoReadOnly is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetTopoReadOnly(ctx context.Context, in *vtctldatapb.GetTopoReadOnlyRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTopoReadOnlyResponse, error) {
+	return client.s.GetTopoReadOnly(ctx, in)
+}
+
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetUnresolvedTransactions(ctx context.Context, in *vtctldatapb.GetUnresolvedTransactionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	return client.s.GetUnresolvedTransactions(ctx, in)
+}
+
 // GetVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVSchemaResponse, error) {
 	return client.s.GetVSchema(ctx, in)
@@ -431,6 +491,11 @@ func (client *localVtctldClient) PlannedReparentShard(ctx context.Context, in *v
 	return client.s.PlannedReparentShard(ctx, in)
 }
 
+// PruneBackups is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) PruneBackups(ctx context.Context, in *vtctldatapb.PruneBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.PruneBackupsResponse, error) {
+	return client.s.PruneBackups(ctx, in)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	return client.s.RebuildKeyspaceGraph(ctx, in)
@@ -471,6 +536,11 @@ func (client *localVtctldClient) RemoveBackup(ctx context.Context, in *vtctldata
 	return client.s.RemoveBackup(ctx, in)
 }
 
+// RemoveCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RemoveCell(ctx context.Context, in *vtctldatapb.RemoveCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveCellResponse, error) {
+	return client.s.RemoveCell(ctx, in)
+}
+
 // RemoveKeyspaceCell is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RemoveKeyspaceCell(ctx context.Context, in *vtctldatapb.RemoveKeyspaceCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveKeyspaceCellResponse, error) {
 	return client.s.RemoveKeyspaceCell(ctx, in)
@@ -491,6 +561,11 @@ func (client *localVtctldClient) ReshardCreate(ctx context.Context, in *vtctldat
 	return client.s.ReshardCreate(ctx, in)
 }
 
+// ReshardRecommend is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ReshardRecommend(ctx context.Context, in *vtctldatapb.ReshardRecommendRequest, opts ...grpc.CallOption) (*vtctldatapb.ReshardRecommendResponse, error) {
+	return client.s.ReshardRecommend(ctx, in)
+}
+
 type restoreFromBackupStreamAdapter struct {
 	*grpcshim.BidiStream
 	ch chan *vtctldatapb.RestoreFromBackupResponse
@@ -542,16 +617,87 @@ func (client *localVtctldClient) RestoreFromBackup(ctx context.Context, in *vtct
 	return stream, nil
 }
 
+type restoreToPointInTimeStreamAdapter struct {
+	*grpcshim.BidiStream
+	ch chan *vtctldatapb.RestoreToPointInTimeResponse
+}
+
+func (stream *restoreToPointInTimeStreamAdapter) Recv() (*vtctldatapb.RestoreToPointInTimeResponse, error) {
+	select {
+	case <-stream.Context().Done():
+		return nil, stream.Context().Err()
+	case <-stream.Closed():
+		// Stream has been closed for future sends. If there are messages that
+		// have already been sent, receive them until there are no more. After
+		// all sent messages have been received, Recv will return the CloseErr.
+		select {
+		case msg := <-stream.ch:
+			return msg, nil
+		default:
+			return nil, stream.CloseErr()
+		}
+	case err := <-stream.ErrCh:
+		return nil, err
+	case msg := <-stream.ch:
+		return msg, nil
+	}
+}
+
+func (stream *restoreToPointInTimeStreamAdapter) Send(msg *vtctldatapb.RestoreToPointInTimeResponse) error {
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-stream.Closed():
+		return grpcshim.ErrStreamClosed
+	case stream.ch <- msg:
+		return nil
+	}
+}
+
+// RestoreToPointInTime is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RestoreToPointInTime(ctx context.Context, in *vtctldatapb.RestoreToPointInTimeRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_RestoreToPointInTimeClient, error) {
+	stream := &restoreToPointInTimeStreamAdapter{
+		BidiStream: grpcshim.NewBidiStream(ctx),
+		ch:         make(chan *vtctldatapb.RestoreToPointInTimeResponse, 1),
+	}
+	go func() {
+		err := client.s.RestoreToPointInTime(in, stream)
+		stream.CloseWithError(err)
+	}()
+
+	return stream, nil
+}
+
 // RetrySchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RetrySchemaMigration(ctx context.Context, in *vtctldatapb.RetrySchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
 	return client.s.RetrySchemaMigration(ctx, in)
 }
 
+// RotateTabletCertificates is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RotateTabletCertificates(ctx context.Context, in *vtctldatapb.RotateTabletCertificatesRequest, opts ...grpc.CallOption) (*vtctldatapb.RotateTabletCertificatesResponse, error) {
+	return client.s.RotateTabletCertificates(ctx, in)
+}
+
 // RunHealthCheck is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldatapb.RunHealthCheckRequest, opts ...grpc.CallOption) (*vtctldatapb.RunHealthCheckResponse, error) {
 	return client.s.RunHealthCheck(ctx, in)
 }
 
+// ScheduleMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ScheduleMaintenance(ctx context.Context, in *vtctldatapb.ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.ScheduleMaintenanceResponse, error) {
+	return client.s.ScheduleMaintenance(ctx, in)
+}
+
+// SetClusterSetting is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetClusterSetting(ctx context.Context, in *vtctldatapb.SetClusterSettingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetClusterSettingResponse, error) {
+	return client.s.SetClusterSetting(ctx, in)
+}
+
+// SetKeyspaceFailoverPreferences is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetKeyspaceFailoverPreferences(ctx context.Context, in *vtctldatapb.SetKeyspaceFailoverPreferencesRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceFailoverPreferencesResponse, error) {
+	return client.s.SetKeyspaceFailoverPreferences(ctx, in)
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceDurabilityPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceDurabilityPolicyResponse, error) {
 	return client.s.SetKeyspaceDurabilityPolicy(ctx, in)
@@ -567,6 +713,16 @@ func (client *localVtctldClient) SetShardTabletControl(ctx context.Context, in *
 	return client.s.SetShardTabletControl(ctx, in)
 }
 
+// SetTopoReadOnly is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetTopoReadOnly(ctx context.Context, in *vtctldatapb.SetTopoReadOnlyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetTopoReadOnlyResponse, error) {
+	return client.s.SetTopoReadOnly(ctx, in)
+}
+
+// SetVtorcMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetVtorcMaintenance(ctx context.Context, in *vtctldatapb.SetVtorcMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.SetVtorcMaintenanceResponse, error) {
+	return client.s.SetVtorcMaintenance(ctx, in)
+}
+
 // SetWritable is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetWritable(ctx context.Context, in *vtctldatapb.SetWritableRequest, opts ...grpc.CallOption) (*vtctldatapb.SetWritableResponse, error) {
 	return client.s.SetWritable(ctx, in)
@@ -622,6 +778,21 @@ func (client *localVtctldClient) TabletExternallyReparented(ctx context.Context,
 	return client.s.TabletExternallyReparented(ctx, in)
 }
 
+// TopoBackup is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) TopoBackup(ctx context.Context, in *vtctldatapb.TopoBackupRequest, opts ...grpc.CallOption) (*vtctldatapb.TopoBackupResponse, error) {
+	return client.s.TopoBackup(ctx, in)
+}
+
+// TopoGC is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) TopoGC(ctx context.Context, in *vtctldatapb.TopoGCRequest, opts ...grpc.CallOption) (*vtctldatapb.TopoGCResponse, error) {
+	return client.s.TopoGC(ctx, in)
+}
+
+// TopoRestore is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) TopoRestore(ctx context.Context, in *vtctldatapb.TopoRestoreRequest, opts ...grpc.CallOption) (*vtctldatapb.TopoRestoreResponse, error) {
+	return client.s.TopoRestore(ctx, in)
+}
+
 // UpdateCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) UpdateCellInfo(ctx context.Context, in *vtctldatapb.UpdateCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateCellInfoResponse, error) {
 	return client.s.UpdateCellInfo(ctx, in)
@@ -667,6 +838,57 @@ func (client *localVtctldClient) Validate(ctx context.Context, in *vtctldatapb.V
 	return client.s.Validate(ctx, in)
 }
 
+type validateBackupStreamAdapter struct {
+	*grpcshim.BidiStream
+	ch chan *vtctldatapb.ValidateBackupResponse
+}
+
+func (stream *validateBackupStreamAdapter) Recv() (*vtctldatapb.ValidateBackupResponse, error) {
+	select {
+	case <-stream.Context().Done():
+		return nil, stream.Context().Err()
+	case <-stream.Closed():
+		// Stream has been closed for future sends. If there are messages that
+		// have already been sent, receive them until there are no more. After
+		// all sent messages have been received, Recv will return the CloseErr.
+		select {
+		case msg := <-stream.ch:
+			return msg, nil
+		default:
+			return nil, stream.CloseErr()
+		}
+	case err := <-stream.ErrCh:
+		return nil, err
+	case msg := <-stream.ch:
+		return msg, nil
+	}
+}
+
+func (stream *validateBackupStreamAdapter) Send(msg *vtctldatapb.ValidateBackupResponse) error {
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-stream.Closed():
+		return grpcshim.ErrStreamClosed
+	case stream.ch <- msg:
+		return nil
+	}
+}
+
+// ValidateBackup is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ValidateBackup(ctx context.Context, in *vtctldatapb.ValidateBackupRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_ValidateBackupClient, error) {
+	stream := &validateBackupStreamAdapter{
+		BidiStream: grpcshim.NewBidiStream(ctx),
+		ch:         make(chan *vtctldatapb.ValidateBackupResponse, 1),
+	}
+	go func() {
+		err := client.s.ValidateBackup(in, stream)
+		stream.CloseWithError(err)
+	}()
+
+	return stream, nil
+}
+
 // ValidateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ValidateKeyspace(ctx context.Context, in *vtctldatapb.ValidateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateKeyspaceResponse, error) {
 	return client.s.ValidateKeyspace(ctx, in)
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"vitess.io/vitess/go/sets"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// CandidatePreferences are the preferences of a reparent for the tablet to
+// promote, when the new primary is not given explicitly.
+type CandidatePreferences struct {
+	// PreferCell is the cell of the tablets to promote in priority.
+	PreferCell string
+	// PreferTabletTags are the tags which the tablets to promote in priority
+	// all have, after the ones in PreferCell.
+	PreferTabletTags map[string]string
+	// AvoidTablets are the aliases of the tablets never to promote.
+	AvoidTablets sets.Set[string]
+	// AvoidCells are the cells whose tablets are never promoted.
+	AvoidCells sets.Set[string]
+}
+
+// FailoverCandidatePreferences returns the candidate preferences which honor
+// the failover preferences of a keyspace, for the failover of a primary in
+// primaryCell, which may be empty if it is unknown.
+func FailoverCandidatePreferences(failoverPrefs *topodatapb.FailoverPreferences, primaryCell string) CandidatePreferences {
+	var prefs CandidatePreferences
+	if failoverPrefs.GetPreferSameCell() {
+		prefs.PreferCell = primaryCell
+	}
+	if len(failoverPrefs.GetNeverPromoteCells()) > 0 {
+		prefs.AvoidCells = sets.New(failoverPrefs.NeverPromoteCells...)
+	}
+	return prefs
+}
+
+// avoids returns true if the tablet must not be promoted.
+func (prefs *CandidatePreferences) avoids(alias *topodatapb.TabletAlias) bool {
+	return prefs.AvoidTablets.Has(topoproto.TabletAliasString(alias))
+}
+
+// avoidsCell returns true if the tablets of the cell must not be promoted.
+func (prefs *CandidatePreferences) avoidsCell(cell string) bool {
+	return prefs.AvoidCells.Has(cell)
+}
+
+// score returns how well the tablet matches the preferences for the cell and
+// the tags. The cell matters more than the tags, and an unset preference is
+// matched by all the tablets.
+func (prefs *CandidatePreferences) score(tablet *topodatapb.Tablet) int {
+	score := 0
+	if prefs.PreferCell == "" || tablet.Alias.Cell == prefs.PreferCell {
+		score += 2
+	}
+
+	tagsMatch := true
+	for k, v := range prefs.PreferTabletTags {
+		if tablet.Tags[k] != v {
+			tagsMatch = false
+			break
+		}
+	}
+	if tagsMatch {
+		score++
+	}
+
+	return score
+}
+
+// preferred returns the candidates which match the preferences for the cell
+// and the tags the best, in the same order.
+func (prefs *CandidatePreferences) preferred(candidates []*topodatapb.Tablet) []*topodatapb.Tablet {
+	best := -1
+	var preferred []*topodatapb.Tablet
+	for _, candidate := range candidates {
+		switch score := prefs.score(candidate); {
+		case score > best:
+			best = score
+			preferred = []*topodatapb.Tablet{candidate}
+		case score == best:
+			preferred = append(preferred, candidate)
+		}
+	}
+	return preferred
+}
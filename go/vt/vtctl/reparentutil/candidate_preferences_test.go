@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/sets"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestFailoverCandidatePreferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		failoverPrefs *topodatapb.FailoverPreferences
+		primaryCell   string
+		want          CandidatePreferences
+	}{
+		{
+			name:        "no preferences",
+			primaryCell: "zone1",
+		}, {
+			name: "prefer same cell",
+			failoverPrefs: &topodatapb.FailoverPreferences{
+				PreferSameCell: true,
+			},
+			primaryCell: "zone1",
+			want:        CandidatePreferences{PreferCell: "zone1"},
+		}, {
+			name: "never promote cells",
+			failoverPrefs: &topodatapb.FailoverPreferences{
+				PreferSameCell:    true,
+				NeverPromoteCells: []string{"zone2", "zone3"},
+			},
+			want: CandidatePreferences{AvoidCells: sets.New("zone2", "zone3")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefs := FailoverCandidatePreferences(tt.failoverPrefs, tt.primaryCell)
+			assert.Equal(t, tt.want, prefs)
+		})
+	}
+}
@@ -60,6 +60,9 @@ type EmergencyReparentOptions struct {
 	WaitAllTablets            bool
 	WaitReplicasTimeout       time.Duration
 	PreventCrossCellPromotion bool
+	// CandidatePreferences are used to choose the new primary when
+	// NewPrimaryAlias is not set.
+	CandidatePreferences
 
 	// Private options managed internally. We use value passing to avoid leaking
 	// these details back out.
@@ -250,6 +253,7 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	// 1. Only keep the tablets which can make progress after being promoted (have sufficient reachable semi-sync ackers)
 	// 2. Remove the tablets with the Must_not promote rule
 	// 3. Remove cross-cell tablets if PreventCrossCellPromotion is specified
+	// 4. Remove the tablets, and the tablets of the cells, to avoid
 	// Our final primary candidate MUST belong to this list of valid candidates
 	validCandidateTablets, err = erp.filterValidCandidates(validCandidateTablets, stoppedReplicationSnapshot.reachableTablets, prevPrimary, opts)
 	if err != nil {
@@ -685,6 +689,9 @@ func (erp *EmergencyReparenter) identifyPrimaryCandidate(
 	// be in a different cell when we have PreventCrossCellPromotion specified, or it could have a promotion rule of
 	// MustNot. Even if it is valid, there could be a tablet with a better promotion rule. This is what we try to
 	// find here.
+	// The candidates which match the preferences of the caller for the cell and the tablet tags the best are
+	// considered first, so that the promotion respects them even over the promotion rules.
+	validCandidates = opts.preferred(validCandidates)
 	// We go over all the promotion rules in descending order of priority and try and find a valid candidate with
 	// that promotion rule.
 	// If the intermediate source has the same promotion rules as some other tablets, then we prioritize using
@@ -745,6 +752,22 @@ func (erp *EmergencyReparenter) filterValidCandidates(validTablets []*topodatapb
 			}
 			continue
 		}
+		// Remove the tablets which the caller asked to avoid
+		if opts.avoids(tablet.Alias) {
+			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it is one of the tablets to avoid", tabletAliasStr)
+			if opts.NewPrimaryAlias != nil && topoproto.TabletAliasEqual(opts.NewPrimaryAlias, tablet.Alias) {
+				return nil, vterrors.Errorf(vtrpc.Code_ABORTED, "proposed primary %s is one of the tablets to avoid", topoproto.TabletAliasString(opts.NewPrimaryAlias))
+			}
+			continue
+		}
+		// Remove the tablets of the cells which the caller asked to avoid
+		if opts.avoidsCell(tablet.Alias.Cell) {
+			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it is in one of the cells to avoid", tabletAliasStr)
+			if opts.NewPrimaryAlias != nil && topoproto.TabletAliasEqual(opts.NewPrimaryAlias, tablet.Alias) {
+				return nil, vterrors.Errorf(vtrpc.Code_ABORTED, "proposed primary %s is in one of the cells to avoid", topoproto.TabletAliasString(opts.NewPrimaryAlias))
+			}
+			continue
+		}
 		// If ERS is configured to prevent cross cell promotions, remove any tablet not from the same cell as the previous primary
 		if opts.PreventCrossCellPromotion && prevPrimary != nil && tablet.Alias.Cell != prevPrimary.Alias.Cell {
 			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it isn't in the same cell as the previous primary", tabletAliasStr)
@@ -4399,6 +4399,28 @@ func TestEmergencyReparenter_filterValidCandidates(t *testing.T) {
 				PreventCrossCellPromotion: true,
 			},
 			filteredTablets: []*topodatapb.Tablet{primaryTablet, replicaTablet},
+		}, {
+			name:             "filter avoided",
+			durability:       "none",
+			validTablets:     allTablets,
+			tabletsReachable: allTablets,
+			opts: EmergencyReparentOptions{
+				CandidatePreferences: CandidatePreferences{
+					AvoidTablets: sets.New("zone-1-0000000002"),
+				},
+			},
+			filteredTablets: []*topodatapb.Tablet{primaryTablet, replicaCrossCellTablet},
+		}, {
+			name:             "filter avoided cells",
+			durability:       "none",
+			validTablets:     allTablets,
+			tabletsReachable: allTablets,
+			opts: EmergencyReparentOptions{
+				CandidatePreferences: CandidatePreferences{
+					AvoidCells: sets.New("zone-2"),
+				},
+			},
+			filteredTablets: []*topodatapb.Tablet{primaryTablet, replicaTablet},
 		}, {
 			name:             "filter establish",
 			durability:       "cross_cell",
@@ -4439,6 +4461,30 @@ func TestEmergencyReparenter_filterValidCandidates(t *testing.T) {
 				NewPrimaryAlias:           replicaCrossCellTablet.Alias,
 			},
 			errShouldContain: "proposed primary zone-2-0000000002 is is a different cell as the previous primary",
+		}, {
+			name:             "error - requested primary avoided",
+			durability:       "none",
+			validTablets:     allTablets,
+			tabletsReachable: allTablets,
+			opts: EmergencyReparentOptions{
+				NewPrimaryAlias: replicaTablet.Alias,
+				CandidatePreferences: CandidatePreferences{
+					AvoidTablets: sets.New("zone-1-0000000002"),
+				},
+			},
+			errShouldContain: "proposed primary zone-1-0000000002 is one of the tablets to avoid",
+		}, {
+			name:             "error - requested primary in avoided cell",
+			durability:       "none",
+			validTablets:     allTablets,
+			tabletsReachable: allTablets,
+			opts: EmergencyReparentOptions{
+				NewPrimaryAlias: replicaCrossCellTablet.Alias,
+				CandidatePreferences: CandidatePreferences{
+					AvoidCells: sets.New("zone-2"),
+				},
+			},
+			errShouldContain: "proposed primary zone-2-0000000002 is in one of the cells to avoid",
 		}, {
 			name:             "error - requested primary cannot establish",
 			durability:       "cross_cell",
@@ -50,10 +50,12 @@ var (
 
 // ChooseNewPrimary finds a tablet that should become a primary after reparent.
 // The criteria for the new primary-elect are (preferably) to be in the same
-// cell as the current primary, and to be different from avoidPrimaryAlias. The
-// tablet with the most advanced replication position is chosen to minimize the
-// amount of time spent catching up with the current primary. Further ties are
-// broken by the durability rules.
+// cell as the current primary, or in the preferred cell, to be different from
+// avoidPrimaryAlias and from the tablets to avoid, and to match the preferences
+// for the cell and the tags the best. Among those, the tablet with the most
+// advanced replication position is chosen to minimize the amount of time spent
+// catching up with the current primary. Further ties are broken by the
+// durability rules.
 // Note that the search for the most advanced replication position will race
 // with transactions being executed on the current primary, so when all tablets
 // are at roughly the same position, then the choice of new primary-elect will
@@ -64,6 +66,7 @@ func ChooseNewPrimary(
 	shardInfo *topo.ShardInfo,
 	tabletMap map[string]*topo.TabletInfo,
 	avoidPrimaryAlias *topodatapb.TabletAlias,
+	prefs CandidatePreferences,
 	waitReplicasTimeout time.Duration,
 	durability Durabler,
 	// (TODO:@ajm188) it's a little gross we need to pass this, maybe embed in the context?
@@ -86,10 +89,14 @@ func ChooseNewPrimary(
 
 	for _, tablet := range tabletMap {
 		switch {
-		case primaryCell != "" && tablet.Alias.Cell != primaryCell:
+		case primaryCell != "" && tablet.Alias.Cell != primaryCell && tablet.Alias.Cell != prefs.PreferCell:
 			continue
 		case avoidPrimaryAlias != nil && topoproto.TabletAliasEqual(tablet.Alias, avoidPrimaryAlias):
 			continue
+		case prefs.avoids(tablet.Alias):
+			continue
+		case prefs.avoidsCell(tablet.Alias.Cell):
+			continue
 		case tablet.Tablet.Type != topodatapb.TabletType_REPLICA:
 			continue
 		}
@@ -118,6 +125,19 @@ func ChooseNewPrimary(
 		return nil, nil
 	}
 
+	// keep the tablets which match the preferences the best
+	if prefs.PreferCell != "" || len(prefs.PreferTabletTags) > 0 {
+		positions := make(map[string]replication.Position, len(validTablets))
+		for i, tablet := range validTablets {
+			positions[topoproto.TabletAliasString(tablet.Alias)] = tabletPositions[i]
+		}
+		validTablets = prefs.preferred(validTablets)
+		tabletPositions = tabletPositions[:0]
+		for _, tablet := range validTablets {
+			tabletPositions = append(tabletPositions, positions[topoproto.TabletAliasString(tablet.Alias)])
+		}
+	}
+
 	// sort the tablets for finding the best primary
 	err = sortTabletsForReparent(validTablets, tabletPositions, durability)
 	if err != nil {
@@ -0,0 +1,319 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// dryRun validates the workflow of the materializer and reports the streams
+// it would create and the tables they would copy, without changing anything.
+// The problems found in the tables are reported rather than returned.
+func (mz *materializer) dryRun() (*vtctldatapb.WorkflowCreateDryRunReport, error) {
+	if err := validateNewWorkflow(mz.ctx, mz.ts, mz.tmc, mz.ms.TargetKeyspace, mz.ms.Workflow); err != nil {
+		return nil, err
+	}
+	if err := mz.buildMaterializer(); err != nil {
+		return nil, err
+	}
+	report := &vtctldatapb.WorkflowCreateDryRunReport{}
+	for _, target := range mz.targetShards {
+		stream := &vtctldatapb.WorkflowCreateDryRunReport_StreamReport{TargetShard: target.ShardName()}
+		for _, source := range mz.filterSourceShards(target) {
+			stream.SourceShards = append(stream.SourceShards, source.ShardName())
+		}
+		report.Streams = append(report.Streams, stream)
+	}
+
+	sourceTables, err := getShardTableDefinitions(mz.ctx, mz.sourceTs, mz.tmc, mz.sourceShards)
+	if err != nil {
+		return nil, err
+	}
+	targetTables, err := getShardTableDefinitions(mz.ctx, mz.ts, mz.tmc, mz.targetShards)
+	if err != nil {
+		return nil, err
+	}
+	for _, ts := range mz.ms.TableSettings {
+		table := &vtctldatapb.WorkflowCreateDryRunReport_TableReport{
+			Name:             ts.TargetTable,
+			SourceExpression: ts.SourceExpression,
+		}
+		report.Tables = append(report.Tables, table)
+
+		sourceTable := ts.TargetTable
+		var sel *sqlparser.Select
+		if ts.SourceExpression != "" {
+			stmt, err := sqlparser.Parse(ts.SourceExpression)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("invalid source expression for table %s: %v", ts.TargetTable, err))
+				continue
+			}
+			var ok bool
+			if sel, ok = stmt.(*sqlparser.Select); !ok {
+				report.Errors = append(report.Errors, fmt.Sprintf("unrecognized statement for table %s: %s", ts.TargetTable, ts.SourceExpression))
+				continue
+			}
+			tableName, err := sqlparser.TableFromStatement(ts.SourceExpression)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("invalid source expression for table %s: %v", ts.TargetTable, err))
+				continue
+			}
+			sourceTable = tableName.Name.String()
+		}
+
+		sourceDefinition := addTableEstimates(report, table, sourceTables, mz.ms.SourceKeyspace, sourceTable, mz.sourceShards)
+
+		if mz.targetVSchema.Keyspace.Sharded && mz.targetVSchema.Tables[ts.TargetTable].Type != vindexes.TypeReference {
+			cv, err := vindexes.FindBestColVindex(mz.targetVSchema.Tables[ts.TargetTable])
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("table %s has no usable vindex in keyspace %s: %v", ts.TargetTable, mz.ms.TargetKeyspace, err))
+			} else {
+				table.Vindex = cv.Name
+				for _, col := range cv.Columns {
+					table.VindexColumns = append(table.VindexColumns, col.String())
+					if sel == nil {
+						continue
+					}
+					if _, err := matchColInSelect(col, sel); err != nil {
+						report.Errors = append(report.Errors, fmt.Sprintf("the sharding key of table %s cannot be computed from its source expression: %v", ts.TargetTable, err))
+					}
+				}
+			}
+		}
+
+		table.ExistsOnTarget = checkTargetTable(report, table, targetTables, mz.ms.TargetKeyspace, mz.targetShards, selectedColumns(sel, sourceDefinition))
+		if !table.ExistsOnTarget {
+			switch {
+			case ts.CreateDdl == "":
+				report.Errors = append(report.Errors, fmt.Sprintf("target table %s does not exist and there is no create ddl defined", ts.TargetTable))
+			case ts.CreateDdl == createDDLAsCopy || ts.CreateDdl == createDDLAsCopyDropConstraint || ts.CreateDdl == createDDLAsCopyDropForeignKeys:
+				if sourceTable != ts.TargetTable {
+					report.Errors = append(report.Errors, fmt.Sprintf("source and target table names must match for copying schema: %v vs %v", sourceTable, ts.TargetTable))
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+// dryRun validates the resharding workflow and reports the streams it would
+// create and the tables they would copy, without changing anything. The
+// problems found in the tables are reported rather than returned.
+func (rs *resharder) dryRun(ctx context.Context, copySchema bool) (*vtctldatapb.WorkflowCreateDryRunReport, error) {
+	report := &vtctldatapb.WorkflowCreateDryRunReport{}
+	for _, target := range rs.targetShards {
+		stream := &vtctldatapb.WorkflowCreateDryRunReport_StreamReport{TargetShard: target.ShardName()}
+		for _, source := range rs.sourceShards {
+			if key.KeyRangeIntersect(target.KeyRange, source.KeyRange) {
+				stream.SourceShards = append(stream.SourceShards, source.ShardName())
+			}
+		}
+		report.Streams = append(report.Streams, stream)
+	}
+
+	sourceTables, err := getShardTableDefinitions(ctx, rs.s.ts, rs.s.tmc, rs.sourceShards)
+	if err != nil {
+		return nil, err
+	}
+	targetTables, err := getShardTableDefinitions(ctx, rs.s.ts, rs.s.tmc, rs.targetShards)
+	if err != nil {
+		return nil, err
+	}
+	// The schema of the target shards is copied from the first source shard.
+	var names []string
+	for name := range sourceTables[rs.sourceShards[0].ShardName()] {
+		if !schema.IsInternalOperationTableName(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		vtable, ok := rs.vschema.Tables[name]
+		if ok && vtable.Type == vindexes.TypeReference {
+			// Reference tables are not copied by the workflow.
+			continue
+		}
+		table := &vtctldatapb.WorkflowCreateDryRunReport_TableReport{Name: name}
+		if transforms, ok := rs.columnTransforms[name]; ok {
+			table.SourceExpression = buildColumnTransformQuery(name, transforms, "")
+		}
+		report.Tables = append(report.Tables, table)
+
+		sourceDefinition := addTableEstimates(report, table, sourceTables, rs.keyspace, name, rs.sourceShards)
+
+		switch {
+		case !ok:
+			report.Errors = append(report.Errors, fmt.Sprintf("table %s not found in vschema for keyspace %s", name, rs.keyspace))
+		case len(vtable.ColumnVindexes) == 0:
+			report.Errors = append(report.Errors, fmt.Sprintf("table %s has no primary vindex in keyspace %s", name, rs.keyspace))
+		default:
+			cv := vtable.ColumnVindexes[0]
+			table.Vindex = cv.Name
+			table.VindexColumns = cv.Columns
+			if cv.Column != "" {
+				table.VindexColumns = []string{cv.Column}
+			}
+			for _, column := range table.VindexColumns {
+				if sourceDefinition != nil && !containsColumn(sourceDefinition.Columns, column) {
+					report.Errors = append(report.Errors, fmt.Sprintf("vindex column %s of table %s does not exist on the source", column, name))
+				}
+			}
+		}
+
+		var columns []string
+		if sourceDefinition != nil {
+			columns = sourceDefinition.Columns
+		}
+		table.ExistsOnTarget = checkTargetTable(report, table, targetTables, rs.keyspace, rs.targetShards, columns)
+		if !table.ExistsOnTarget && !copySchema {
+			report.Errors = append(report.Errors, fmt.Sprintf("table %s does not exist on the target shards and the schema is not copied", name))
+		}
+	}
+	return report, nil
+}
+
+// getShardTableDefinitions returns the definitions of the tables on the
+// primaries of the shards, by shard name and table name.
+func getShardTableDefinitions(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, shards []*topo.ShardInfo) (map[string]map[string]*tabletmanagerdatapb.TableDefinition, error) {
+	var mu sync.Mutex
+	tables := make(map[string]map[string]*tabletmanagerdatapb.TableDefinition, len(shards))
+	err := forAllShards(shards, func(shard *topo.ShardInfo) error {
+		if shard.PrimaryAlias == nil {
+			return fmt.Errorf("shard has no primary: %v", shard.ShardName())
+		}
+		req := &tabletmanagerdatapb.GetSchemaRequest{Tables: []string{"/.*/"}}
+		sd, err := schematools.GetSchema(ctx, ts, tmc, shard.PrimaryAlias, req)
+		if err != nil {
+			return err
+		}
+		shardTables := make(map[string]*tabletmanagerdatapb.TableDefinition, len(sd.TableDefinitions))
+		for _, td := range sd.TableDefinitions {
+			shardTables[td.Name] = td
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		tables[shard.ShardName()] = shardTables
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// addTableEstimates adds the estimated size of the source table on each of
+// the source shards to the report, and returns the definition of the table
+// on one of them.
+func addTableEstimates(report *vtctldatapb.WorkflowCreateDryRunReport, table *vtctldatapb.WorkflowCreateDryRunReport_TableReport,
+	sourceTables map[string]map[string]*tabletmanagerdatapb.TableDefinition, keyspace, name string, shards []*topo.ShardInfo) *tabletmanagerdatapb.TableDefinition {
+	var definition *tabletmanagerdatapb.TableDefinition
+	for _, shard := range shards {
+		td := sourceTables[shard.ShardName()][name]
+		if td == nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("source table %s does not exist on shard %s/%s", name, keyspace, shard.ShardName()))
+			continue
+		}
+		if definition == nil {
+			definition = td
+		}
+		table.EstimatedRows += td.RowCount
+		table.EstimatedBytes += td.DataLength
+	}
+	report.EstimatedRows += table.EstimatedRows
+	report.EstimatedBytes += table.EstimatedBytes
+	return definition
+}
+
+// checkTargetTable reports the given columns that are missing from the table
+// on the target shards it exists on, and returns whether it exists on all of
+// them.
+func checkTargetTable(report *vtctldatapb.WorkflowCreateDryRunReport, table *vtctldatapb.WorkflowCreateDryRunReport_TableReport,
+	targetTables map[string]map[string]*tabletmanagerdatapb.TableDefinition, keyspace string, shards []*topo.ShardInfo, columns []string) bool {
+	exists := true
+	for _, shard := range shards {
+		td := targetTables[shard.ShardName()][table.Name]
+		if td == nil {
+			exists = false
+			continue
+		}
+		for _, column := range columns {
+			if !containsColumn(td.Columns, column) {
+				report.Errors = append(report.Errors, fmt.Sprintf("column %s of table %s does not exist on shard %s/%s", column, table.Name, keyspace, shard.ShardName()))
+			}
+		}
+	}
+	return exists
+}
+
+// selectedColumns returns the names of the columns selected by the source
+// expression of a table, given the definition of the source table. Columns
+// computed by expressions without an alias are skipped.
+func selectedColumns(sel *sqlparser.Select, source *tabletmanagerdatapb.TableDefinition) []string {
+	var columns []string
+	add := func(column string) {
+		if !containsColumn(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	if sel == nil {
+		if source != nil {
+			return source.Columns
+		}
+		return nil
+	}
+	for _, selExpr := range sel.SelectExprs {
+		switch selExpr := selExpr.(type) {
+		case *sqlparser.StarExpr:
+			if source != nil {
+				for _, column := range source.Columns {
+					add(column)
+				}
+			}
+		case *sqlparser.AliasedExpr:
+			if !selExpr.As.IsEmpty() {
+				add(selExpr.As.String())
+			} else if colName, ok := selExpr.Expr.(*sqlparser.ColName); ok {
+				add(colName.Name.String())
+			}
+		}
+	}
+	return columns
+}
+
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
@@ -62,10 +62,17 @@ type materializer struct {
 	isPartial             bool
 	primaryVindexesDiffer bool
 	workflowType          binlogdatapb.VReplicationWorkflowType
+	// tenant is set for multi-tenant MoveTables workflows.
+	tenant *binlogdatapb.VReplicationTenant
 }
 
 func (mz *materializer) getWorkflowSubType() (binlogdatapb.VReplicationWorkflowSubType, error) {
 	switch {
+	case mz.tenant != nil && (mz.isPartial || mz.ms.AtomicCopy):
+		return binlogdatapb.VReplicationWorkflowSubType_None,
+			fmt.Errorf("a tenant cannot be specified together with atomic copy or partial mode for the same workflow")
+	case mz.tenant != nil:
+		return binlogdatapb.VReplicationWorkflowSubType_MultiTenant, nil
 	case mz.isPartial && mz.ms.AtomicCopy:
 		return binlogdatapb.VReplicationWorkflowSubType_None,
 			fmt.Errorf("both atomic copy and partial mode cannot be specified for the same workflow")
@@ -156,14 +163,17 @@ func (mz *materializer) generateInserts(ctx context.Context, sourceShards []*top
 
 	for _, sourceShard := range sourceShards {
 		bls := &binlogdatapb.BinlogSource{
-			Keyspace:        mz.ms.SourceKeyspace,
-			Shard:           sourceShard.ShardName(),
-			Filter:          &binlogdatapb.Filter{},
-			StopAfterCopy:   mz.ms.StopAfterCopy,
-			ExternalCluster: mz.ms.ExternalCluster,
-			SourceTimeZone:  mz.ms.SourceTimeZone,
-			TargetTimeZone:  mz.ms.TargetTimeZone,
-			OnDdl:           binlogdatapb.OnDDLAction(binlogdatapb.OnDDLAction_value[mz.ms.OnDdl]),
+			Keyspace:          mz.ms.SourceKeyspace,
+			Shard:             sourceShard.ShardName(),
+			Filter:            &binlogdatapb.Filter{},
+			StopAfterCopy:     mz.ms.StopAfterCopy,
+			ExternalCluster:   mz.ms.ExternalCluster,
+			SourceTimeZone:    mz.ms.SourceTimeZone,
+			TargetTimeZone:    mz.ms.TargetTimeZone,
+			OnDdl:             binlogdatapb.OnDDLAction(binlogdatapb.OnDDLAction_value[mz.ms.OnDdl]),
+			ThrottlerSettings: mz.ms.ThrottlerSettings,
+			Schedule:          mz.ms.Schedule,
+			ApplyDelaySeconds: mz.ms.ApplyDelaySeconds,
 		}
 		for _, ts := range mz.ms.TableSettings {
 			rule := &binlogdatapb.Rule{
@@ -255,14 +265,18 @@ func (mz *materializer) generateBinlogSources(ctx context.Context, targetShard *
 	blses := make([]*binlogdatapb.BinlogSource, 0, len(mz.sourceShards))
 	for _, sourceShard := range sourceShards {
 		bls := &binlogdatapb.BinlogSource{
-			Keyspace:        mz.ms.SourceKeyspace,
-			Shard:           sourceShard.ShardName(),
-			Filter:          &binlogdatapb.Filter{},
-			StopAfterCopy:   mz.ms.StopAfterCopy,
-			ExternalCluster: mz.ms.ExternalCluster,
-			SourceTimeZone:  mz.ms.SourceTimeZone,
-			TargetTimeZone:  mz.ms.TargetTimeZone,
-			OnDdl:           binlogdatapb.OnDDLAction(binlogdatapb.OnDDLAction_value[mz.ms.OnDdl]),
+			Keyspace:          mz.ms.SourceKeyspace,
+			Shard:             sourceShard.ShardName(),
+			Filter:            &binlogdatapb.Filter{},
+			StopAfterCopy:     mz.ms.StopAfterCopy,
+			ExternalCluster:   mz.ms.ExternalCluster,
+			SourceTimeZone:    mz.ms.SourceTimeZone,
+			TargetTimeZone:    mz.ms.TargetTimeZone,
+			OnDdl:             binlogdatapb.OnDDLAction(binlogdatapb.OnDDLAction_value[mz.ms.OnDdl]),
+			ThrottlerSettings: mz.ms.ThrottlerSettings,
+			Schedule:          mz.ms.Schedule,
+			ApplyDelaySeconds: mz.ms.ApplyDelaySeconds,
+			Tenant:            mz.tenant,
 		}
 		for _, ts := range mz.ms.TableSettings {
 			rule := &binlogdatapb.Rule{
@@ -585,6 +585,202 @@ func TestMoveTablesNoRoutingRules(t *testing.T) {
 	require.Zerof(t, len(rr.Rules), "routing rules should be empty, found %+v", rr.Rules)
 }
 
+func TestMoveTablesColumnTransforms(t *testing.T) {
+	transforms, err := parseColumnTransforms(map[string]string{
+		"t1.val":  "upper(val)",
+		"t1.mail": "md5(mail)",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "select *, md5(mail) as mail, upper(val) as val from t1", buildColumnTransformQuery("t1", transforms["t1"], ""))
+	require.Equal(t, "select *, md5(mail) as mail, upper(val) as val from t1 where in_keyrange('-80')", buildColumnTransformQuery("t1", transforms["t1"], "-80"))
+
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1",
+			SourceExpression: "select * from t1",
+		}},
+	}
+	testCases := []struct {
+		name       string
+		transforms map[string]string
+		wantErr    string
+	}{{
+		name:       "no column",
+		transforms: map[string]string{"t1": "upper(val)"},
+		wantErr:    `invalid column transform "t1", expected <table>.<column>`,
+	}, {
+		name:       "invalid expression",
+		transforms: map[string]string{"t1.val": "upper(val"},
+		wantErr:    "invalid expression for the column transform of t1.val",
+	}, {
+		name:       "table not moved",
+		transforms: map[string]string{"t2.val": "upper(val)"},
+		wantErr:    "column transforms of table t2 which is not moved by the workflow",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+			defer env.close()
+
+			_, err := env.ws.MoveTablesCreate(ctx, &vtctldatapb.MoveTablesCreateRequest{
+				Workflow:         ms.Workflow,
+				SourceKeyspace:   ms.SourceKeyspace,
+				TargetKeyspace:   ms.TargetKeyspace,
+				IncludeTables:    []string{"t1"},
+				ColumnTransforms: tc.transforms,
+			})
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestMoveTablesDryRun(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1",
+			SourceExpression: "select * from t1",
+		}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"-80", "80-"}, []string{"0"})
+	defer env.close()
+	env.tmc.schema["sourceks.t1"].TableDefinitions[0].Columns = []string{"id", "val"}
+	env.tmc.schema["sourceks.t1"].TableDefinitions[0].RowCount = 10
+	env.tmc.schema["sourceks.t1"].TableDefinitions[0].DataLength = 1024
+	env.tmc.schema["targetks.t1"].TableDefinitions[0].Columns = []string{"id"}
+
+	env.tmc.expectVRQuery(200, mzSelectFrozenQuery, &sqltypes.Result{})
+
+	res, err := env.ws.MoveTablesCreate(ctx, &vtctldatapb.MoveTablesCreateRequest{
+		Workflow:       ms.Workflow,
+		SourceKeyspace: ms.SourceKeyspace,
+		TargetKeyspace: ms.TargetKeyspace,
+		IncludeTables:  []string{"t1"},
+		DryRun:         true,
+	})
+	require.NoError(t, err)
+	want := &vtctldatapb.WorkflowCreateDryRunReport{
+		Tables: []*vtctldatapb.WorkflowCreateDryRunReport_TableReport{{
+			Name:             "t1",
+			SourceExpression: "select * from t1",
+			ExistsOnTarget:   true,
+			EstimatedRows:    20,
+			EstimatedBytes:   2048,
+		}},
+		Streams: []*vtctldatapb.WorkflowCreateDryRunReport_StreamReport{{
+			TargetShard:  "0",
+			SourceShards: []string{"-80", "80-"},
+		}},
+		EstimatedRows:  20,
+		EstimatedBytes: 2048,
+		Errors:         []string{"column val of table t1 does not exist on shard targetks/0"},
+	}
+	utils.MustMatch(t, want, res.DryRunReport)
+	env.tmc.verifyQueries(t)
+
+	// Nothing was changed by the dry run.
+	vschema, err := env.topoServ.GetVSchema(ctx, ms.TargetKeyspace)
+	require.NoError(t, err)
+	require.Empty(t, vschema.Tables)
+	rr, err := env.topoServ.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rr.Rules)
+}
+
+func TestMaterializerDryRun(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1",
+			SourceExpression: "select c2, c3 from t1",
+		}, {
+			TargetTable:      "t2",
+			SourceExpression: "select * from t2",
+			CreateDdl:        createDDLAsCopy,
+		}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"-80", "80-"})
+	defer env.close()
+	env.tmc.schema["sourceks.t1"].TableDefinitions[0].Columns = []string{"c1", "c2", "c3"}
+	env.tmc.schema["targetks.t1"].TableDefinitions[0].Columns = []string{"c2", "c3"}
+	env.tmc.schema["sourceks.t2"].TableDefinitions[0].RowCount = 5
+	env.tmc.schema["sourceks.t2"].TableDefinitions[0].DataLength = 512
+	delete(env.tmc.schema, "targetks.t2")
+
+	vs := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"xxhash": {
+				Type: "xxhash",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Column: "c1",
+					Name:   "xxhash",
+				}},
+			},
+			"t2": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Column: "c1",
+					Name:   "xxhash",
+				}},
+			},
+		},
+	}
+	err := env.topoServ.SaveVSchema(ctx, "targetks", vs)
+	require.NoError(t, err)
+	env.tmc.expectVRQuery(200, mzSelectFrozenQuery, &sqltypes.Result{})
+	env.tmc.expectVRQuery(210, mzSelectFrozenQuery, &sqltypes.Result{})
+
+	report, err := env.ws.MaterializeDryRun(ctx, ms)
+	require.NoError(t, err)
+	want := &vtctldatapb.WorkflowCreateDryRunReport{
+		Tables: []*vtctldatapb.WorkflowCreateDryRunReport_TableReport{{
+			Name:             "t1",
+			SourceExpression: "select c2, c3 from t1",
+			ExistsOnTarget:   true,
+			Vindex:           "xxhash",
+			VindexColumns:    []string{"c1"},
+		}, {
+			Name:             "t2",
+			SourceExpression: "select * from t2",
+			Vindex:           "xxhash",
+			VindexColumns:    []string{"c1"},
+			EstimatedRows:    5,
+			EstimatedBytes:   512,
+		}},
+		Streams: []*vtctldatapb.WorkflowCreateDryRunReport_StreamReport{{
+			TargetShard:  "-80",
+			SourceShards: []string{"0"},
+		}, {
+			TargetShard:  "80-",
+			SourceShards: []string{"0"},
+		}},
+		EstimatedRows:  5,
+		EstimatedBytes: 512,
+		Errors: []string{
+			"the sharding key of table t1 cannot be computed from its source expression: could not find vindex column c1",
+		},
+	}
+	utils.MustMatch(t, want, report)
+	env.tmc.verifyQueries(t)
+}
+
 func TestCreateLookupVindexFull(t *testing.T) {
 	ms := &vtctldatapb.MaterializeSettings{
 		Workflow:       "lookup",
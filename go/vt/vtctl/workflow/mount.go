@@ -45,6 +45,7 @@ func (s *Server) MountRegister(ctx context.Context, req *vtctldatapb.MountRegist
 			Server:   req.TopoServer,
 			Root:     req.TopoRoot,
 		},
+		VtgateAddress: req.VtgateAddress,
 	}
 	return &vtctldatapb.MountRegisterResponse{}, s.ts.CreateExternalVitessCluster(ctx, req.Name, vc)
 }
@@ -80,9 +81,10 @@ func (s *Server) MountShow(ctx context.Context, req *vtctldatapb.MountShowReques
 		return &vtctldatapb.MountShowResponse{}, notExistsError(req.Name)
 	}
 	return &vtctldatapb.MountShowResponse{
-		TopoType:   vci.TopoConfig.TopoType,
-		TopoServer: vci.TopoConfig.Server,
-		TopoRoot:   vci.TopoConfig.Root,
-		Name:       req.Name,
+		TopoType:      vci.TopoConfig.TopoType,
+		TopoServer:    vci.TopoConfig.Server,
+		TopoRoot:      vci.TopoConfig.Root,
+		Name:          req.Name,
+		VtgateAddress: vci.VtgateAddress,
 	}, nil
 }
@@ -0,0 +1,467 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// defaultReshardRecommendSampleSize is the default maximum number of rows
+	// sampled per table and source shard.
+	defaultReshardRecommendSampleSize = 1000
+	// reshardRecommendDistributionBuckets is the number of key ranges the key
+	// distribution is reported for: one per value of the first nibble of the
+	// keyspace id.
+	reshardRecommendDistributionBuckets = 16
+)
+
+// keyspaceIDSample is a keyspace id sampled from a source shard, along with
+// the share of the rows, bytes and QPS of the shard it stands for.
+type keyspaceIDSample struct {
+	keyspaceID []byte
+	rows       float64
+	bytes      float64
+	qps        float64
+	load       float64
+}
+
+// ReshardRecommend is part of the vtctlservicepb.VtctldServer interface.
+// It estimates the size and QPS of the key ranges of the source shards from
+// the table sizes and from keyspace ids sampled from the rows of the tables,
+// and recommends target shards that split the estimated load evenly.
+func (s *Server) ReshardRecommend(ctx context.Context, req *vtctldatapb.ReshardRecommendRequest) (*vtctldatapb.ReshardRecommendResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.ReshardRecommend")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("source_shards", req.SourceShards)
+	span.Annotate("shard_count", req.ShardCount)
+	span.Annotate("max_shard_size_bytes", req.MaxShardSizeBytes)
+	span.Annotate("max_shard_qps", req.MaxShardQps)
+	span.Annotate("sample_size", req.SampleSize)
+
+	if req.ShardCount == 0 && req.MaxShardSizeBytes == 0 && req.MaxShardQps == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "one of shard_count, max_shard_size_bytes or max_shard_qps must be specified")
+	}
+	sampleSize := req.SampleSize
+	if sampleSize == 0 {
+		sampleSize = defaultReshardRecommendSampleSize
+	}
+
+	sourceShards, err := s.getReshardRecommendSourceShards(ctx, req.Keyspace, req.SourceShards)
+	if err != nil {
+		return nil, err
+	}
+	keyRange, err := mergeShardKeyRanges(sourceShards)
+	if err != nil {
+		return nil, err
+	}
+
+	vs, err := s.ts.GetVSchema(ctx, req.Keyspace)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "GetVSchema")
+	}
+	kschema, err := vindexes.BuildKeyspaceSchema(vs, req.Keyspace)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "BuildKeyspaceSchema")
+	}
+	if !kschema.Keyspace.Sharded {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %s is not sharded", req.Keyspace)
+	}
+
+	shardTables, err := getShardTableDefinitions(ctx, s.ts, s.tmc, sourceShards)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ReshardRecommendResponse{}
+	var (
+		mu      sync.Mutex
+		samples []*keyspaceIDSample
+		tables  = make(map[string]*vtctldatapb.ReshardRecommendResponse_Table)
+	)
+	err = forAllShards(sourceShards, func(shard *topo.ShardInfo) error {
+		primary, err := s.ts.GetTablet(ctx, shard.PrimaryAlias)
+		if err != nil {
+			return vterrors.Wrapf(err, "GetTablet(%s) failed", topoproto.TabletAliasString(shard.PrimaryAlias))
+		}
+		qps, err := s.getTabletQPS(ctx, primary.Tablet)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to get the QPS of shard %s", shard.ShardName())
+		}
+
+		var shardSamples []*keyspaceIDSample
+		var shardTableReports []*vtctldatapb.ReshardRecommendResponse_Table
+		var warnings []string
+		for name, td := range shardTables[shard.ShardName()] {
+			if schema.IsInternalOperationTableName(name) {
+				continue
+			}
+			vtable, ok := kschema.Tables[name]
+			if ok && vtable.Type == vindexes.TypeReference {
+				// Reference tables are not resharded.
+				continue
+			}
+			table := &vtctldatapb.ReshardRecommendResponse_Table{Name: name, Rows: td.RowCount, Bytes: td.DataLength}
+			shardTableReports = append(shardTableReports, table)
+			if !ok || len(vtable.ColumnVindexes) == 0 {
+				warnings = append(warnings, fmt.Sprintf("table %s has no primary vindex in keyspace %s and its rows were not sampled", name, req.Keyspace))
+				continue
+			}
+			cv := vtable.ColumnVindexes[0]
+			table.Vindex = cv.Name
+			if cv.Vindex.NeedsVCursor() {
+				warnings = append(warnings, fmt.Sprintf("the primary vindex %s of table %s needs to query the keyspace to map rows and the rows were not sampled", cv.Name, name))
+				continue
+			}
+			tableSamples, err := s.sampleKeyspaceIDs(ctx, primary.Tablet, shard.KeyRange, name, td, cv, sampleSize)
+			if err != nil {
+				return vterrors.Wrapf(err, "failed to sample table %s on shard %s", name, shard.ShardName())
+			}
+			table.Samples = uint32(len(tableSamples))
+			shardSamples = append(shardSamples, tableSamples...)
+		}
+		// The QPS of the shard is split between the samples in proportion
+		// to the rows they stand for.
+		var shardRows float64
+		for _, sample := range shardSamples {
+			shardRows += sample.rows
+		}
+		for _, sample := range shardSamples {
+			sample.qps = qps * sample.rows / shardRows
+		}
+		if len(shardSamples) == 0 && qps > 0 {
+			warnings = append(warnings, fmt.Sprintf("no rows were sampled on shard %s and its QPS is not accounted for in the key distribution", shard.ShardName()))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		resp.TotalQps += qps
+		samples = append(samples, shardSamples...)
+		resp.Warnings = append(resp.Warnings, warnings...)
+		for _, st := range shardTableReports {
+			table, ok := tables[st.Name]
+			if !ok {
+				table = &vtctldatapb.ReshardRecommendResponse_Table{Name: st.Name, Vindex: st.Vindex}
+				tables[st.Name] = table
+			}
+			table.Rows += st.Rows
+			table.Bytes += st.Bytes
+			table.Samples += st.Samples
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, shard := range sourceShards {
+		resp.SourceShards = append(resp.SourceShards, shard.ShardName())
+	}
+	for _, table := range tables {
+		resp.TotalBytes += table.Bytes
+		resp.Tables = append(resp.Tables, table)
+	}
+	sort.Slice(resp.Tables, func(i, j int) bool {
+		return resp.Tables[i].Name < resp.Tables[j].Name
+	})
+	sort.Strings(resp.Warnings)
+	if len(samples) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no rows could be sampled from the source shards of keyspace %s", req.Keyspace)
+	}
+
+	var sampledBytes, sampledQPS float64
+	for _, sample := range samples {
+		sampledBytes += sample.bytes
+		sampledQPS += sample.qps
+	}
+	for _, sample := range samples {
+		if sampledBytes > 0 {
+			sample.load += sample.bytes / sampledBytes
+		}
+		if sampledQPS > 0 {
+			sample.load += sample.qps / sampledQPS
+		}
+		if sampledBytes == 0 && sampledQPS == 0 {
+			sample.load = 1
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return bytes.Compare(samples[i].keyspaceID, samples[j].keyspaceID) < 0
+	})
+
+	shardCount := int(req.ShardCount)
+	if shardCount == 0 {
+		if req.MaxShardSizeBytes > 0 {
+			shardCount = int(math.Ceil(float64(resp.TotalBytes) / float64(req.MaxShardSizeBytes)))
+		}
+		if req.MaxShardQps > 0 {
+			shardCount = max(shardCount, int(math.Ceil(resp.TotalQps/float64(req.MaxShardQps))))
+		}
+		shardCount = max(shardCount, 1)
+	}
+	boundaries, err := recommendShardBoundaries(samples, shardCount)
+	if err != nil {
+		return nil, err
+	}
+
+	start := keyRange.Start
+	for i := 0; i <= len(boundaries); i++ {
+		end := keyRange.End
+		if i < len(boundaries) {
+			end = boundaries[i]
+		}
+		kr := &topodatapb.KeyRange{Start: start, End: end}
+		shard := &vtctldatapb.ReshardRecommendResponse_Shard{Name: key.KeyRangeString(kr)}
+		for _, sample := range samples {
+			if key.KeyRangeContains(kr, sample.keyspaceID) {
+				shard.EstimatedRows += uint64(math.Round(sample.rows))
+				shard.EstimatedBytes += uint64(math.Round(sample.bytes))
+				shard.EstimatedQps += sample.qps
+			}
+		}
+		resp.TargetShards = append(resp.TargetShards, shard)
+		start = end
+	}
+	resp.KeyDistribution = keyDistribution(samples, keyRange)
+	return resp, nil
+}
+
+// getReshardRecommendSourceShards returns the given source shards of the
+// keyspace, or its serving shards if none are given, sorted by key range.
+func (s *Server) getReshardRecommendSourceShards(ctx context.Context, keyspace string, names []string) ([]*topo.ShardInfo, error) {
+	var shards []*topo.ShardInfo
+	if len(names) == 0 {
+		var err error
+		if shards, err = s.ts.GetServingShards(ctx, keyspace); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		si, err := s.ts.GetShard(ctx, keyspace, name)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "GetShard(%s) failed", name)
+		}
+		if !si.IsPrimaryServing {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "source shard %v is not in serving state", name)
+		}
+		shards = append(shards, si)
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return key.KeyRangeLess(shards[i].KeyRange, shards[j].KeyRange)
+	})
+	return shards, nil
+}
+
+// mergeShardKeyRanges returns the key range that the shards, sorted by key
+// range, cover together. It fails if they don't cover a contiguous range.
+func mergeShardKeyRanges(shards []*topo.ShardInfo) (*topodatapb.KeyRange, error) {
+	var keyRange *topodatapb.KeyRange
+	for i, shard := range shards {
+		if i == 0 {
+			keyRange = shard.KeyRange
+			continue
+		}
+		merged, ok := key.KeyRangeAdd(keyRange, shard.KeyRange)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "source shards must cover a contiguous key range: %s does not follow %s",
+				shard.ShardName(), shards[i-1].ShardName())
+		}
+		keyRange = merged
+	}
+	if keyRange == nil {
+		keyRange = &topodatapb.KeyRange{}
+	}
+	return keyRange, nil
+}
+
+// getTabletQPS returns the average QPS of the tablet's MySQL server since it
+// was started.
+func (s *Server) getTabletQPS(ctx context.Context, tablet *topodatapb.Tablet) (float64, error) {
+	p3qr, err := s.tmc.ExecuteFetchAsDba(ctx, tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte("show global status where variable_name in ('Questions', 'Uptime')"),
+		MaxRows: 2,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var questions, uptime float64
+	for _, row := range sqltypes.Proto3ToResult(p3qr).Rows {
+		value, err := strconv.ParseFloat(row[1].ToString(), 64)
+		if err != nil {
+			return 0, err
+		}
+		switch strings.ToLower(row[0].ToString()) {
+		case "questions":
+			questions = value
+		case "uptime":
+			uptime = value
+		}
+	}
+	if uptime == 0 {
+		return 0, nil
+	}
+	return questions / uptime, nil
+}
+
+// sampleKeyspaceIDs samples up to sampleSize rows of the table on the tablet
+// and maps them to keyspace ids with the vindex. The rows that don't belong
+// to the key range of the shard are ignored. Each sample stands for an equal
+// share of the estimated rows and bytes of the table.
+func (s *Server) sampleKeyspaceIDs(ctx context.Context, tablet *topodatapb.Tablet, keyRange *topodatapb.KeyRange, table string, td *tabletmanagerdatapb.TableDefinition,
+	cv *vindexes.ColumnVindex, sampleSize uint32) ([]*keyspaceIDSample, error) {
+	columns := make([]string, 0, len(cv.Columns))
+	for _, col := range cv.Columns {
+		columns = append(columns, sqlescape.EscapeID(col.String()))
+	}
+	query := fmt.Sprintf("select %s from %s", strings.Join(columns, ", "), sqlescape.EscapeID(table))
+	if td.RowCount > uint64(sampleSize) {
+		query += fmt.Sprintf(" where rand() <= %v", float64(sampleSize)/float64(td.RowCount))
+	}
+	query += fmt.Sprintf(" limit %d", sampleSize)
+	p3qr, err := s.tmc.ExecuteFetchAsDba(ctx, tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(query),
+		DbName:  topoproto.TabletDbName(tablet),
+		MaxRows: uint64(sampleSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	qr := sqltypes.Proto3ToResult(p3qr)
+	if len(qr.Rows) == 0 {
+		return nil, nil
+	}
+	destinations, err := vindexes.Map(ctx, cv.Vindex, nil, qr.Rows)
+	if err != nil {
+		return nil, err
+	}
+	var samples []*keyspaceIDSample
+	for _, destination := range destinations {
+		if ksid, ok := destination.(key.DestinationKeyspaceID); ok && key.KeyRangeContains(keyRange, ksid) {
+			samples = append(samples, &keyspaceIDSample{keyspaceID: ksid})
+		}
+	}
+	// The estimated row count of small tables can be lower than the number of
+	// rows that were read.
+	rows := math.Max(float64(td.RowCount), float64(len(qr.Rows)))
+	for _, sample := range samples {
+		sample.rows = rows / float64(len(samples))
+		sample.bytes = float64(td.DataLength) / float64(len(samples))
+	}
+	return samples, nil
+}
+
+// recommendShardBoundaries returns the keyspace ids that split the key range
+// into shardCount shards of equal estimated load. The samples must be sorted
+// by keyspace id and within the key range. Each boundary is truncated to its
+// shortest prefix that keeps the same samples on each side of it, to get
+// short shard names.
+func recommendShardBoundaries(samples []*keyspaceIDSample, shardCount int) ([][]byte, error) {
+	var totalLoad float64
+	for _, sample := range samples {
+		totalLoad += sample.load
+	}
+	var boundaries [][]byte
+	var cumulative float64
+	// last and next are the indexes of the first samples of the current and
+	// of the next shard: each shard contains at least one sample.
+	last, next := 0, 0
+	for i := 1; i < shardCount; i++ {
+		target := totalLoad * float64(i) / float64(shardCount)
+		for next < len(samples) && (next <= last || cumulative+samples[next].load <= target) {
+			cumulative += samples[next].load
+			next++
+		}
+		if next >= len(samples) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "not enough rows were sampled to recommend %d shards", shardCount)
+		}
+		// The boundary must be above the last sample of the current shard.
+		boundary := shortestBoundary(samples[next].keyspaceID, samples[next-1].keyspaceID)
+		if boundary == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "not enough distinct keyspace ids were sampled to recommend %d shards", shardCount)
+		}
+		boundaries = append(boundaries, boundary)
+		last = next
+	}
+	return boundaries, nil
+}
+
+// shortestBoundary returns the shortest prefix of the keyspace id, of at
+// least one byte and at most eight, that is greater than the previous
+// keyspace id, or nil if there is none.
+func shortestBoundary(keyspaceID, previous []byte) []byte {
+	for l := 1; l <= min(len(keyspaceID), 8); l++ {
+		prefix := keyspaceID[:l]
+		if bytes.Compare(prefix, previous) > 0 {
+			return append([]byte(nil), prefix...)
+		}
+	}
+	return nil
+}
+
+// keyDistribution returns the fraction of the estimated load of the samples
+// in each sixteenth of the keyspace id range that overlaps the key range.
+func keyDistribution(samples []*keyspaceIDSample, keyRange *topodatapb.KeyRange) []*vtctldatapb.ReshardRecommendResponse_KeyRangeWeight {
+	var totalLoad float64
+	for _, sample := range samples {
+		totalLoad += sample.load
+	}
+	var distribution []*vtctldatapb.ReshardRecommendResponse_KeyRangeWeight
+	for i := 0; i < reshardRecommendDistributionBuckets; i++ {
+		bucket := &topodatapb.KeyRange{}
+		if i > 0 {
+			bucket.Start = []byte{byte(i << 4)}
+		}
+		if i < reshardRecommendDistributionBuckets-1 {
+			bucket.End = []byte{byte((i + 1) << 4)}
+		}
+		if !key.KeyRangeIntersect(bucket, keyRange) {
+			continue
+		}
+		weight := &vtctldatapb.ReshardRecommendResponse_KeyRangeWeight{KeyRange: key.KeyRangeString(bucket)}
+		for _, sample := range samples {
+			if key.KeyRangeContains(bucket, sample.keyspaceID) {
+				weight.Weight += sample.load / totalLoad
+			}
+		}
+		distribution = append(distribution, weight)
+	}
+	return distribution
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestReshardRecommend(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "ks",
+		TargetKeyspace: "ks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable: "t1",
+		}, {
+			TargetTable: "ref",
+		}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, nil)
+	defer env.close()
+	env.tmc.schema["ks.t1"].TableDefinitions[0].RowCount = 4
+	env.tmc.schema["ks.t1"].TableDefinitions[0].DataLength = 4000
+	env.tmc.schema["ks.ref"].TableDefinitions[0].RowCount = 100
+
+	err := env.topoServ.SaveVSchema(ctx, "ks", &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}},
+			},
+			"ref": {Type: "reference"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = env.ws.ReshardRecommend(ctx, &vtctldatapb.ReshardRecommendRequest{Keyspace: "ks"})
+	assert.ErrorContains(t, err, "one of shard_count, max_shard_size_bytes or max_shard_qps must be specified")
+
+	env.tmc.expectVRQuery(100, "show global status where variable_name in ('Questions', 'Uptime')",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("Variable_name|Value", "varchar|varchar"), "Questions|1000", "Uptime|10"))
+	// The keyspace ids of the rows are 166b40b4..., 06e7ea22..., 4eb190c9...
+	// and d2fd8867....
+	env.tmc.expectVRQuery(100, "select `id` from `t1` limit 1000",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2", "3", "4"))
+
+	resp, err := env.ws.ReshardRecommend(ctx, &vtctldatapb.ReshardRecommendRequest{
+		Keyspace:          "ks",
+		MaxShardSizeBytes: 2000,
+	})
+	require.NoError(t, err)
+	want := &vtctldatapb.ReshardRecommendResponse{
+		SourceShards: []string{"0"},
+		TargetShards: []*vtctldatapb.ReshardRecommendResponse_Shard{
+			{Name: "-4e", EstimatedRows: 2, EstimatedBytes: 2000, EstimatedQps: 50},
+			{Name: "4e-", EstimatedRows: 2, EstimatedBytes: 2000, EstimatedQps: 50},
+		},
+		Tables: []*vtctldatapb.ReshardRecommendResponse_Table{
+			{Name: "t1", Rows: 4, Bytes: 4000, Samples: 4, Vindex: "hash"},
+		},
+		KeyDistribution: []*vtctldatapb.ReshardRecommendResponse_KeyRangeWeight{
+			{KeyRange: "-10", Weight: 0.25},
+			{KeyRange: "10-20", Weight: 0.25},
+			{KeyRange: "20-30"},
+			{KeyRange: "30-40"},
+			{KeyRange: "40-50", Weight: 0.25},
+			{KeyRange: "50-60"},
+			{KeyRange: "60-70"},
+			{KeyRange: "70-80"},
+			{KeyRange: "80-90"},
+			{KeyRange: "90-a0"},
+			{KeyRange: "a0-b0"},
+			{KeyRange: "b0-c0"},
+			{KeyRange: "c0-d0"},
+			{KeyRange: "d0-e0", Weight: 0.25},
+			{KeyRange: "e0-f0"},
+			{KeyRange: "f0-"},
+		},
+		TotalBytes: 4000,
+		TotalQps:   100,
+	}
+	utils.MustMatch(t, want, resp)
+	env.tmc.verifyQueries(t)
+
+	// Each shard must contain at least one sampled row.
+	env.tmc.expectVRQuery(100, "show global status where variable_name in ('Questions', 'Uptime')", &sqltypes.Result{})
+	env.tmc.expectVRQuery(100, "select `id` from `t1` limit 1000",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2", "3", "4"))
+	_, err = env.ws.ReshardRecommend(ctx, &vtctldatapb.ReshardRecommendRequest{
+		Keyspace:   "ks",
+		ShardCount: 5,
+	})
+	assert.ErrorContains(t, err, "not enough rows were sampled to recommend 5 shards")
+	env.tmc.verifyQueries(t)
+}
+
+func TestMergeShardKeyRanges(t *testing.T) {
+	shardInfo := func(name string) *topo.ShardInfo {
+		_, keyRange, err := topo.ValidateShardName(name)
+		require.NoError(t, err)
+		return topo.NewShardInfo("ks", name, &topodatapb.Shard{KeyRange: keyRange}, nil)
+	}
+	testcases := []struct {
+		shards []string
+		want   string
+		err    string
+	}{{
+		shards: []string{"-40", "40-80", "80-"},
+		want:   "-",
+	}, {
+		shards: []string{"40-80", "80-c0"},
+		want:   "40-c0",
+	}, {
+		shards: []string{"-40", "80-"},
+		err:    "source shards must cover a contiguous key range: 80- does not follow -40",
+	}}
+	for _, tc := range testcases {
+		var shards []*topo.ShardInfo
+		for _, name := range tc.shards {
+			shards = append(shards, shardInfo(name))
+		}
+		keyRange, err := mergeShardKeyRanges(shards)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, key.KeyRangeString(keyRange))
+	}
+}
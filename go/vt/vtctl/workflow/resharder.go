@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -38,6 +41,7 @@ import (
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 type resharder struct {
@@ -56,7 +60,11 @@ type resharder struct {
 	tabletTypes        string
 	stopAfterCopy      bool
 	onDDL              string
+	throttlerSettings  *binlogdatapb.VReplicationThrottlerSettings
+	schedule           *binlogdatapb.VReplicationSchedule
 	deferSecondaryKeys bool
+	// columnTransforms holds the transformed columns of each table.
+	columnTransforms map[string]sqlparser.SelectExprs
 }
 
 type refStream struct {
@@ -260,6 +268,30 @@ func (rs *resharder) copySchema(ctx context.Context) error {
 	return err
 }
 
+// validateColumnTransforms checks that the transformed columns are not used
+// to compute the keyspace ids of the rows, which must not change while the
+// rows are copied to the target shards.
+func (rs *resharder) validateColumnTransforms() error {
+	for tableName, transforms := range rs.columnTransforms {
+		table, ok := rs.vschema.Tables[tableName]
+		if !ok {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column transforms of table %s which is not in the vschema of keyspace %s", tableName, rs.keyspace)
+		}
+		if table.Type == vindexes.TypeReference {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column transforms of reference table %s are not supported", tableName)
+		}
+		for _, transform := range transforms {
+			column := transform.(*sqlparser.AliasedExpr).As
+			for _, cv := range table.ColumnVindexes {
+				if column.EqualString(cv.Column) || slices.ContainsFunc(cv.Columns, column.EqualString) {
+					return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "vindex column %s of table %s cannot be transformed", column.String(), tableName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (rs *resharder) createStreams(ctx context.Context) error {
 	var excludeRules []*binlogdatapb.Rule
 	for tableName, table := range rs.vschema.Tables {
@@ -270,6 +302,11 @@ func (rs *resharder) createStreams(ctx context.Context) error {
 			})
 		}
 	}
+	transformedTables := make([]string, 0, len(rs.columnTransforms))
+	for tableName := range rs.columnTransforms {
+		transformedTables = append(transformedTables, tableName)
+	}
+	sort.Strings(transformedTables)
 
 	err := rs.forAll(rs.targetShards, func(target *topo.ShardInfo) error {
 		targetPrimary := rs.targetPrimaries[target.ShardName()]
@@ -278,6 +315,13 @@ func (rs *resharder) createStreams(ctx context.Context) error {
 
 		// copy excludeRules to prevent data race.
 		copyExcludeRules := append([]*binlogdatapb.Rule(nil), excludeRules...)
+		// The rules of the transformed tables come before the catch-all rule.
+		for _, tableName := range transformedTables {
+			copyExcludeRules = append(copyExcludeRules, &binlogdatapb.Rule{
+				Match:  tableName,
+				Filter: buildColumnTransformQuery(tableName, rs.columnTransforms[tableName], key.KeyRangeString(target.KeyRange)),
+			})
+		}
 		for _, source := range rs.sourceShards {
 			if !key.KeyRangeIntersect(target.KeyRange, source.KeyRange) {
 				continue
@@ -289,11 +333,13 @@ func (rs *resharder) createStreams(ctx context.Context) error {
 				}),
 			}
 			bls := &binlogdatapb.BinlogSource{
-				Keyspace:      rs.keyspace,
-				Shard:         source.ShardName(),
-				Filter:        filter,
-				StopAfterCopy: rs.stopAfterCopy,
-				OnDdl:         binlogdatapb.OnDDLAction(binlogdatapb.OnDDLAction_value[rs.onDDL]),
+				Keyspace:          rs.keyspace,
+				Shard:             source.ShardName(),
+				Filter:            filter,
+				StopAfterCopy:     rs.stopAfterCopy,
+				OnDdl:             binlogdatapb.OnDDLAction(binlogdatapb.OnDDLAction_value[rs.onDDL]),
+				ThrottlerSettings: rs.throttlerSettings,
+				Schedule:          rs.schedule,
 			}
 			ig.AddRow(rs.workflow, bls, "", rs.cell, rs.tabletTypes,
 				binlogdatapb.VReplicationWorkflowType_Reshard,
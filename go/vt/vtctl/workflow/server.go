@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
@@ -111,6 +112,13 @@ const (
 	cannotSwitchHighLag             = "replication lag %ds is higher than allowed lag %ds"
 	cannotSwitchFailedTabletRefresh = "could not refresh all of the tablets involved in the operation:\n%s"
 	cannotSwitchFrozen              = "workflow is frozen"
+	cannotSwitchUnresolvedError     = "stream %d on shard %s has an unresolved error: %s"
+	cannotSwitchNoVDiff             = "no vdiff found on shard %s"
+	cannotSwitchVDiffMismatch       = "the last vdiff %s found differences on shard %s"
+	cannotSwitchVDiffFailed         = "the last vdiff %s failed on shard %s: %s"
+	cannotSwitchVDiffIncomplete     = "the last vdiff %s is %s on shard %s"
+	cannotSwitchVDiffDiffers        = "the last vdiff %s on shard %s is not the last vdiff %s on shard %s"
+	cannotSwitchVDiffTooOld         = "the last vdiff %s completed on shard %s %v ago, more than the allowed %v"
 
 	// Number of LOCK TABLES cycles to perform on the sources during SwitchWrites.
 	lockTablesCycles = 2
@@ -119,6 +127,10 @@ const (
 
 	// Default duration used for lag, timeout, etc.
 	defaultDuration = 30 * time.Second
+
+	// Number of times the creation of an auto retried VDiff is attempted on a
+	// target shard before giving up.
+	vdiffCreateAttempts = 5
 )
 
 var (
@@ -135,6 +147,10 @@ var (
 	ErrMultipleTargetKeyspaces   = errors.New("multiple target keyspaces for a single workflow")
 	ErrWorkflowNotFullySwitched  = errors.New("cannot complete workflow because you have not yet switched all read and write traffic")
 	ErrWorkflowPartiallySwitched = errors.New("cannot cancel workflow because you have already switched some or all read and write traffic")
+
+	// vdiffCreateRetryDelay is the delay before the first retry of the creation
+	// of an auto retried VDiff. It doubles after every attempt.
+	vdiffCreateRetryDelay = 1 * time.Second
 )
 
 // Server provides an API to work with Vitess workflows, like vreplication
@@ -368,6 +384,7 @@ func (s *Server) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflows
 	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("active_only", req.ActiveOnly)
 	span.Annotate("include_logs", req.IncludeLogs)
+	span.Annotate("include_copy_progress", req.IncludeCopyProgress)
 
 	where := ""
 	predicates := []string{}
@@ -922,11 +939,50 @@ ORDER BY
 	// Wait for all the log fetchers to finish.
 	fetchLogsWG.Wait()
 
+	if req.IncludeCopyProgress {
+		for _, workflow := range workflows {
+			if !isCopying(workflow) {
+				continue
+			}
+			workflow.CopyProgress, err = s.getWorkflowCopyProgress(ctx, req.Keyspace, workflow.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return &vtctldatapb.GetWorkflowsResponse{
 		Workflows: workflows,
 	}, nil
 }
 
+// isCopying returns true if any of the streams of the workflow are still in
+// the copy phase.
+func isCopying(workflow *vtctldatapb.Workflow) bool {
+	for _, shardStreams := range workflow.ShardStreams {
+		for _, stream := range shardStreams.Streams {
+			if stream.State == binlogdatapb.VReplicationWorkflowState_Copying.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getWorkflowCopyProgress returns the summarized copy progress of the given
+// workflow, or nil if none of its tables are still being copied.
+func (s *Server) getWorkflowCopyProgress(ctx context.Context, keyspace, workflow string) (*vtctldatapb.WorkflowCopyProgress, error) {
+	ts, state, err := s.getWorkflowState(ctx, keyspace, workflow)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.GetCopyProgress(ctx, ts, state)
+	if err != nil || progress == nil {
+		return nil, err
+	}
+	return s.summarizeCopyProgress(ctx, ts, *progress)
+}
+
 func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowName string) (*trafficSwitcher, *State, error) {
 	ts, err := s.buildTrafficSwitcher(ctx, targetKeyspace, workflowName)
 
@@ -977,7 +1033,14 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 		}
 		table := ts.Tables()[0]
 
-		if ts.isPartialMigration { // shard level traffic switching is all or nothing
+		if ts.tenant != nil { // the reads and writes of a tenant are switched together
+			state.TenantID = ts.tenant.Id
+			tenantRoutingRules, err := s.ts.GetTenantRoutingRules(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			state.WritesSwitched = isTenantSwitched(tenantRoutingRules.Rules, ts.tenant, targetKeyspace)
+		} else if ts.isPartialMigration { // shard level traffic switching is all or nothing
 			shardRoutingRules, err := s.ts.GetShardRoutingRules(ctx)
 			if err != nil {
 				return nil, nil, err
@@ -1043,8 +1106,11 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 			state.WritesSwitched = true
 		}
 	}
-	if ts.workflowType == binlogdatapb.VReplicationWorkflowType_Migrate {
+	switch ts.workflowType {
+	case binlogdatapb.VReplicationWorkflowType_Migrate:
 		state.WorkflowType = TypeMigrate
+	case binlogdatapb.VReplicationWorkflowType_DelayedReplica:
+		state.WorkflowType = TypeDelayedReplica
 	}
 
 	return ts, state, nil
@@ -1240,10 +1306,18 @@ func (s *Server) LookupVindexExternalize(ctx context.Context, req *vtctldatapb.L
 // Materialize performs the steps needed to materialize a list of
 // tables based on the materialization specs.
 func (s *Server) Materialize(ctx context.Context, ms *vtctldatapb.MaterializeSettings) error {
+	sourceTs := s.ts
+	if ms.ExternalCluster != "" {
+		externalTopo, err := s.ts.OpenExternalVitessClusterServer(ctx, ms.ExternalCluster)
+		if err != nil {
+			return err
+		}
+		sourceTs = externalTopo
+	}
 	mz := &materializer{
 		ctx:      ctx,
 		ts:       s.ts,
-		sourceTs: s.ts,
+		sourceTs: sourceTs,
 		tmc:      s.tmc,
 		ms:       ms,
 	}
@@ -1255,15 +1329,36 @@ func (s *Server) Materialize(ctx context.Context, ms *vtctldatapb.MaterializeSet
 	return mz.startStreams(ctx)
 }
 
+// MaterializeDryRun validates the Materialize workflow and reports the
+// streams it would create, without creating them.
+func (s *Server) MaterializeDryRun(ctx context.Context, ms *vtctldatapb.MaterializeSettings) (*vtctldatapb.WorkflowCreateDryRunReport, error) {
+	sourceTs := s.ts
+	if ms.ExternalCluster != "" {
+		externalTopo, err := s.ts.OpenExternalVitessClusterServer(ctx, ms.ExternalCluster)
+		if err != nil {
+			return nil, err
+		}
+		sourceTs = externalTopo
+	}
+	mz := &materializer{
+		ctx:      ctx,
+		ts:       s.ts,
+		sourceTs: sourceTs,
+		tmc:      s.tmc,
+		ms:       ms,
+	}
+	return mz.dryRun()
+}
+
 // MoveTablesCreate is part of the vtctlservicepb.VtctldServer interface.
 // It passes the embedded TabletRequest object to the given keyspace's
 // target primary tablets that will be executing the workflow.
 func (s *Server) MoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (res *vtctldatapb.WorkflowStatusResponse, err error) {
-	return s.moveTablesCreate(ctx, req, binlogdatapb.VReplicationWorkflowType_MoveTables)
+	return s.moveTablesCreate(ctx, req, binlogdatapb.VReplicationWorkflowType_MoveTables, 0)
 }
 
 func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest,
-	workflowType binlogdatapb.VReplicationWorkflowType) (res *vtctldatapb.WorkflowStatusResponse, err error) {
+	workflowType binlogdatapb.VReplicationWorkflowType, applyDelaySeconds int64) (res *vtctldatapb.WorkflowStatusResponse, err error) {
 
 	span, ctx := trace.NewSpan(ctx, "workflow.Server.MoveTablesCreate")
 	defer span.Finish()
@@ -1273,6 +1368,7 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 	span.Annotate("cells", req.Cells)
 	span.Annotate("tablet_types", req.TabletTypes)
 	span.Annotate("on_ddl", req.OnDdl)
+	span.Annotate("dry_run", req.DryRun)
 
 	sourceKeyspace := req.SourceKeyspace
 	targetKeyspace := req.TargetKeyspace
@@ -1335,12 +1431,31 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tables to move")
 	}
 	log.Infof("Found tables to move: %s", strings.Join(tables, ","))
-
-	if !vschema.Sharded {
-		// Save the original in case we need to restore it for a late failure
-		// in the defer().
-		origVSchema = vschema.CloneVT()
-		if err := s.addTablesToVSchema(ctx, sourceKeyspace, vschema, tables, externalTopo == nil); err != nil {
+	columnTransforms, err := parseColumnTransforms(req.ColumnTransforms)
+	if err != nil {
+		return nil, err
+	}
+	for table := range columnTransforms {
+		if !slices.Contains(tables, table) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column transforms of table %s which is not moved by the workflow", table)
+		}
+	}
+	var (
+		tenant         *binlogdatapb.VReplicationTenant
+		sourceVSchema  *vschemapb.Keyspace
+		sourceKSSchema *vindexes.KeyspaceSchema
+	)
+	if req.TenantId != "" {
+		if externalTopo != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a single tenant cannot be moved from an external cluster")
+		}
+		if sourceVSchema, err = s.ts.GetVSchema(ctx, sourceKeyspace); err != nil {
+			return nil, err
+		}
+		if sourceKSSchema, err = vindexes.BuildKeyspaceSchema(sourceVSchema, sourceKeyspace); err != nil {
+			return nil, err
+		}
+		if tenant, err = buildTenant(ctx, sourceKSSchema, tables, req.TenantId); err != nil {
 			return nil, err
 		}
 	}
@@ -1359,6 +1474,9 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 		OnDdl:                     req.OnDdl,
 		DeferSecondaryKeys:        req.DeferSecondaryKeys,
 		AtomicCopy:                req.AtomicCopy,
+		ThrottlerSettings:         req.ThrottlerSettings,
+		Schedule:                  req.Schedule,
+		ApplyDelaySeconds:         applyDelaySeconds,
 	}
 	if req.SourceTimeZone != "" {
 		ms.SourceTimeZone = req.SourceTimeZone
@@ -1370,14 +1488,25 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 	}
 
 	for _, table := range tables {
-		buf := sqlparser.NewTrackedBuffer(nil)
-		buf.Myprintf("select * from %v", sqlparser.NewIdentifierCS(table))
+		sourceExpression := buildColumnTransformQuery(table, columnTransforms[table], "")
+		if tenant != nil {
+			if sourceExpression, err = addTenantFilter(sourceExpression, sourceKSSchema, tenant, table); err != nil {
+				return nil, err
+			}
+		}
 		ms.TableSettings = append(ms.TableSettings, &vtctldatapb.TableMaterializeSettings{
 			TargetTable:      table,
-			SourceExpression: buf.String(),
+			SourceExpression: sourceExpression,
 			CreateDdl:        createDDLMode,
 		})
 	}
+	// The queries of the tenant are routed to the target keyspace using the
+	// keyspace id computed in the source keyspace, so a sharded target keyspace
+	// must shard the tables the same way.
+	if tenant != nil && vschema.Sharded && primaryVindexesDiffer(ms, sourceVSchema, vschema) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the tables must have the same primary vindexes in the %s and %s keyspaces to move a single tenant",
+			sourceKeyspace, targetKeyspace)
+	}
 	mz := &materializer{
 		ctx:          ctx,
 		ts:           s.ts,
@@ -1385,7 +1514,27 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 		tmc:          s.tmc,
 		ms:           ms,
 		workflowType: workflowType,
+		tenant:       tenant,
+	}
+	if req.DryRun {
+		report, err := mz.dryRun()
+		if err != nil {
+			return nil, err
+		}
+		return &vtctldatapb.WorkflowStatusResponse{DryRunReport: report}, nil
 	}
+
+	// The tables of a DelayedReplica workflow are only added to the target
+	// vschema when the workflow is completed.
+	if !vschema.Sharded && workflowType != binlogdatapb.VReplicationWorkflowType_DelayedReplica {
+		// Save the original in case we need to restore it for a late failure
+		// in the defer().
+		origVSchema = vschema.CloneVT()
+		if err := s.addTablesToVSchema(ctx, sourceKeyspace, vschema, tables, externalTopo == nil); err != nil {
+			return nil, err
+		}
+	}
+
 	err = mz.createMoveTablesStreams(req)
 	if err != nil {
 		return nil, err
@@ -1416,6 +1565,10 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 	if externalTopo == nil {
 		if req.NoRoutingRules {
 			log.Warningf("Found --no-routing-rules flag, not creating routing rules for workflow %s.%s", targetKeyspace, req.Workflow)
+		} else if tenant != nil {
+			// The tables keep serving the other tenants in both keyspaces, only
+			// the queries of the tenant are routed when its traffic is switched.
+			log.Infof("Not creating routing rules for the tenant %s of workflow %s.%s", tenant.Id, targetKeyspace, req.Workflow)
 		} else {
 			// Save routing rules before vschema. If we save vschema first, and routing
 			// rules fails to save, we may generate duplicate table errors.
@@ -1520,7 +1673,9 @@ func (s *Server) MoveTablesComplete(ctx context.Context, req *vtctldatapb.MoveTa
 	}
 	var dryRunResults *[]string
 
-	if state.WorkflowType == TypeMigrate {
+	// Migrate and DelayedReplica workflows never switch traffic. Completing
+	// them stops the replication and adds the tables to the target vschema.
+	if state.WorkflowType == TypeMigrate || state.WorkflowType == TypeDelayedReplica {
 		dryRunResults, err = s.finalizeMigrateWorkflow(ctx, req.TargetKeyspace, req.Workflow, strings.Join(ts.tables, ","),
 			false, req.KeepData, req.KeepRoutingRules, req.DryRun)
 		if err != nil {
@@ -1571,6 +1726,7 @@ func (s *Server) ReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCrea
 	span.Annotate("cells", req.Cells)
 	span.Annotate("tablet_types", req.TabletTypes)
 	span.Annotate("on_ddl", req.OnDdl)
+	span.Annotate("dry_run", req.DryRun)
 
 	keyspace := req.Keyspace
 	cells := req.Cells
@@ -1586,8 +1742,23 @@ func (s *Server) ReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCrea
 		return nil, vterrors.Wrap(err, "buildResharder")
 	}
 	rs.onDDL = req.OnDdl
+	rs.throttlerSettings = req.ThrottlerSettings
+	rs.schedule = req.Schedule
+	if rs.columnTransforms, err = parseColumnTransforms(req.ColumnTransforms); err != nil {
+		return nil, err
+	}
+	if err := rs.validateColumnTransforms(); err != nil {
+		return nil, err
+	}
 	rs.stopAfterCopy = req.StopAfterCopy
 	rs.deferSecondaryKeys = req.DeferSecondaryKeys
+	if req.DryRun {
+		report, err := rs.dryRun(ctx, !req.SkipSchemaCopy)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "dryRun")
+		}
+		return &vtctldatapb.WorkflowStatusResponse{DryRunReport: report}, nil
+	}
 	if !req.SkipSchemaCopy {
 		if err := rs.copySchema(ctx); err != nil {
 			return nil, vterrors.Wrap(err, "copySchema")
@@ -1622,6 +1793,7 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 	span.Annotate("tablet_types", req.TabletTypes)
 	span.Annotate("tables", req.Tables)
 	span.Annotate("auto_retry", req.AutoRetry)
+	span.Annotate("schedule_interval", req.ScheduleInterval.GetSeconds())
 
 	tabletTypesStr := topoproto.MakeStringTypeCSV(req.TabletTypes)
 	if req.TabletSelectionPreference == tabletmanagerdatapb.TabletSelectionPreference_INORDER {
@@ -1641,6 +1813,9 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 			TimeoutSeconds:        req.FilteredReplicationWaitTime.Seconds,
 			MaxExtraRowsToCompare: req.MaxExtraRowsToCompare,
 			UpdateTableStats:      req.UpdateTableStats,
+			// A scheduled VDiff keeps running for as long as the workflow does.
+			ScheduleIntervalSeconds: req.ScheduleInterval.GetSeconds(),
+			HistoryRetention:        req.HistoryRetention,
 		},
 		ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{
 			OnlyPks:    req.OnlyPKs,
@@ -1666,8 +1841,7 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 	}
 
 	err = ts.ForAllTargets(func(target *MigrationTarget) error {
-		_, err := s.tmc.VDiff(ctx, target.GetPrimary().Tablet, tabletreq)
-		return err
+		return s.createVDiffOnTarget(ctx, target, tabletreq, req.AutoRetry)
 	})
 	if err != nil {
 		log.Errorf("Error executing vdiff create action: %v", err)
@@ -1679,6 +1853,39 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 	}, nil
 }
 
+// createVDiffOnTarget creates the VDiff on the target shard's primary tablet. When
+// autoRetry is set, a failed creation is attempted again, with an exponential
+// backoff, so that a shard which could not be reached is not left without the
+// VDiff.
+func (s *Server) createVDiffOnTarget(ctx context.Context, target *MigrationTarget, req *tabletmanagerdatapb.VDiffRequest, autoRetry bool) error {
+	backoff := vdiffCreateRetryDelay
+	for attempt := 1; ; attempt++ {
+		_, err := s.tmc.VDiff(ctx, target.GetPrimary().Tablet, req)
+		switch {
+		case err == nil:
+			return nil
+		case attempt > 1 && isVDiffExistsErr(err):
+			// A previous attempt succeeded even though we did not get the response.
+			return nil
+		case !autoRetry || isVDiffExistsErr(err) || attempt == vdiffCreateAttempts:
+			return err
+		}
+		log.Infof("Retrying vdiff create on shard %s in %v after error: %v", target.GetShard().ShardName(), backoff, err)
+		select {
+		case <-ctx.Done():
+			return vterrors.Wrapf(err, "giving up on vdiff create on shard %s: %v", target.GetShard().ShardName(), ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isVDiffExistsErr returns true if the error returned by a tablet means that
+// the VDiff being created already exists on it.
+func isVDiffExistsErr(err error) bool {
+	return topo.IsErrType(err, topo.NodeExists) || vterrors.Code(err) == vtrpcpb.Code_ALREADY_EXISTS
+}
+
 // VDiffDelete is part of the vtctlservicepb.VtctldServer interface.
 func (s *Server) VDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDeleteRequest) (*vtctldatapb.VDiffDeleteResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "workflow.Server.VDiffDelete")
@@ -1753,12 +1960,14 @@ func (s *Server) VDiffShow(ctx context.Context, req *vtctldatapb.VDiffShowReques
 	span.Annotate("keyspace", req.TargetKeyspace)
 	span.Annotate("workflow", req.Workflow)
 	span.Annotate("argument", req.Arg)
+	span.Annotate("include_history", req.IncludeHistory)
 
 	tabletreq := &tabletmanagerdatapb.VDiffRequest{
-		Keyspace:  req.TargetKeyspace,
-		Workflow:  req.Workflow,
-		Action:    string(vdiff.ShowAction),
-		ActionArg: req.Arg,
+		Keyspace:       req.TargetKeyspace,
+		Workflow:       req.Workflow,
+		Action:         string(vdiff.ShowAction),
+		ActionArg:      req.Arg,
+		IncludeHistory: req.IncludeHistory,
 	}
 
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
@@ -1913,12 +2122,24 @@ func (s *Server) WorkflowStatus(ctx context.Context, req *vtctldatapb.WorkflowSt
 			resp.TableCopyState[table].BytesTotal = progress.SourceTableSize
 			resp.TableCopyState[table].BytesPercentage = tableSizePct
 		}
+		resp.CopyProgress, err = s.summarizeCopyProgress(ctx, ts, *copyProgress)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	workflow, err := s.GetWorkflow(ctx, req.Keyspace, req.Workflow, false)
 	if err != nil {
 		return nil, err
 	}
+	conflicts, err := s.getWorkflowConflicts(ctx, req.Keyspace, workflow)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := s.getWorkflowMetrics(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
 
 	// The stream key is target keyspace/tablet alias, e.g. 0/test-0000000100.
 	// We sort the keys for intuitive and consistent output.
@@ -1959,6 +2180,14 @@ func (s *Server) WorkflowStatus(ctx context.Context, req *vtctldatapb.WorkflowSt
 					info = append(info, fmt.Sprintf("; Tx time: %s.", time.Unix(st.TransactionTimestamp.Seconds, 0).Format(time.ANSIC)))
 				}
 			}
+			if n := conflicts[streamKey][st.Id]; n > 0 {
+				info = append(info, fmt.Sprintf("Conflicts: %d", n))
+				ts.Conflicts = n
+			}
+			if m := metrics[streamKey][int32(st.Id)]; m != nil {
+				ts.Metrics = m
+				resp.Metrics = mergeVReplicationMetrics(resp.Metrics, m)
+			}
 			ts.Id = int32(st.Id)
 			ts.Tablet = st.Tablet
 			ts.SourceShard = fmt.Sprintf("%s/%s", st.BinlogSource.Keyspace, st.BinlogSource.Shard)
@@ -1972,6 +2201,120 @@ func (s *Server) WorkflowStatus(ctx context.Context, req *vtctldatapb.WorkflowSt
 	return resp, nil
 }
 
+// getWorkflowConflicts returns the number of conflicts that were logged by
+// the streams of the workflow that detect conflicts, such as the reverse
+// streams created when traffic is switched. The counts are keyed by stream
+// key, e.g. 0/test-0000000100, and then by stream id.
+func (s *Server) getWorkflowConflicts(ctx context.Context, keyspace string, workflow *vtctldatapb.Workflow) (map[string]map[int64]int64, error) {
+	detectsConflicts := false
+	for _, shardStreams := range workflow.ShardStreams {
+		for _, st := range shardStreams.Streams {
+			if st.BinlogSource.GetDetectConflicts() {
+				detectsConflicts = true
+			}
+		}
+	}
+	if !detectsConflicts {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("select vrepl_id, sum(count) as conflicts from _vt.vreplication_log where type = %s group by vrepl_id",
+		encodeString(vreplication.LogConflict))
+	results, err := vexec.NewVExec(keyspace, workflow.Name, s.ts, s.tmc).QueryContext(ctx, query)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to read the conflicts of the %s workflow", workflow.Name)
+	}
+	conflicts := make(map[string]map[int64]int64, len(results))
+	for target, p3qr := range results {
+		streamKey := fmt.Sprintf("%s/%s", target.Shard, target.AliasString())
+		conflicts[streamKey] = make(map[int64]int64)
+		for _, row := range sqltypes.Proto3ToResult(p3qr).Named().Rows {
+			id, err := row["vrepl_id"].ToCastInt64()
+			if err != nil {
+				return nil, err
+			}
+			n, err := row["conflicts"].ToCastInt64()
+			if err != nil {
+				return nil, err
+			}
+			conflicts[streamKey][id] = n
+		}
+	}
+	return conflicts, nil
+}
+
+// getWorkflowMetrics returns the metrics of the running streams of the
+// workflow, read from the target primaries that run them. The metrics are
+// keyed by stream key, e.g. 0/test-0000000100, and then by stream id.
+func (s *Server) getWorkflowMetrics(ctx context.Context, workflow *vtctldatapb.Workflow) (map[string]map[int32]*tabletmanagerdatapb.VReplicationMetrics, error) {
+	metrics := make(map[string]map[int32]*tabletmanagerdatapb.VReplicationMetrics, len(workflow.ShardStreams))
+	for streamKey, shardStreams := range workflow.ShardStreams {
+		if len(shardStreams.Streams) == 0 {
+			continue
+		}
+		// All the streams of a shard are run by its primary.
+		tablet, err := s.ts.GetTablet(ctx, shardStreams.Streams[0].Tablet)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.tmc.ReadVReplicationWorkflow(ctx, tablet.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow:       workflow.Name,
+			IncludeMetrics: true,
+		})
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read the metrics of the %s workflow on %s", workflow.Name, topoproto.TabletAliasString(tablet.Alias))
+		}
+		metrics[streamKey] = make(map[int32]*tabletmanagerdatapb.VReplicationMetrics, len(res.GetStreams()))
+		for _, stream := range res.GetStreams() {
+			if stream.Metrics != nil {
+				metrics[streamKey][stream.Id] = stream.Metrics
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// mergeVReplicationMetrics adds the metrics of a stream to the aggregated
+// metrics, which are returned. The rates are summed since the streams run
+// concurrently.
+func mergeVReplicationMetrics(aggregated, metrics *tabletmanagerdatapb.VReplicationMetrics) *tabletmanagerdatapb.VReplicationMetrics {
+	if aggregated == nil {
+		return metrics.CloneVT()
+	}
+	if h := metrics.LagHistogram; h != nil {
+		switch {
+		case aggregated.LagHistogram == nil:
+			aggregated.LagHistogram = h.CloneVT()
+		case slices.Equal(aggregated.LagHistogram.Cutoffs, h.Cutoffs):
+			for i, count := range h.Counts {
+				aggregated.LagHistogram.Counts[i] += count
+			}
+			aggregated.LagHistogram.Total += h.Total
+		}
+	}
+	if aggregated.EventCounts == nil {
+		aggregated.EventCounts = make(map[string]int64, len(metrics.EventCounts))
+	}
+	for typ, count := range metrics.EventCounts {
+		aggregated.EventCounts[typ] += count
+	}
+	if aggregated.EventRates == nil {
+		aggregated.EventRates = make(map[string]float64, len(metrics.EventRates))
+	}
+	for typ, rate := range metrics.EventRates {
+		aggregated.EventRates[typ] += rate
+	}
+	aggregated.CopyRowCount += metrics.CopyRowCount
+	aggregated.CopyRowRate += metrics.CopyRowRate
+	if aggregated.ErrorCounts == nil {
+		aggregated.ErrorCounts = make(map[string]int64, len(metrics.ErrorCounts))
+	}
+	for class, count := range metrics.ErrorCounts {
+		aggregated.ErrorCounts[class] += count
+	}
+	return aggregated
+}
+
 // GetCopyProgress returns the progress of all tables being copied in the
 // workflow.
 func (s *Server) GetCopyProgress(ctx context.Context, ts *trafficSwitcher, state *State) (*copyProgress, error) {
@@ -2103,6 +2446,82 @@ func (s *Server) GetCopyProgress(ctx context.Context, ts *trafficSwitcher, state
 	return &copyProgress, nil
 }
 
+// getCopyThroughput returns the average number of rows per second that the
+// streams of the workflow which are still in the copy phase have copied
+// since their copy phase started.
+func (s *Server) getCopyThroughput(ctx context.Context, ts *trafficSwitcher, now time.Time) (float64, error) {
+	getThroughputQuery := "select vr.id, vr.rows_copied, unix_timestamp(min(vl.created_at)) from _vt.vreplication vr, _vt.vreplication_log vl " +
+		"where vl.vrepl_id = vr.id and vr.id in (%s) and vr.state = %s and vl.type = %s group by vr.id, vr.rows_copied"
+	var rowsPerSecond float64
+	for _, target := range ts.targets {
+		if len(target.Sources) == 0 {
+			continue
+		}
+		ids := make([]int, 0, len(target.Sources))
+		for id := range target.Sources {
+			ids = append(ids, int(id))
+		}
+		sort.Ints(ids)
+		idList := make([]string, 0, len(ids))
+		for _, id := range ids {
+			idList = append(idList, strconv.Itoa(id))
+		}
+		query := fmt.Sprintf(getThroughputQuery, strings.Join(idList, ","),
+			encodeString(binlogdatapb.VReplicationWorkflowState_Copying.String()), encodeString(vreplication.LogCopyStart))
+		p3qr, err := s.tmc.ExecuteFetchAsDba(ctx, target.GetPrimary().Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+			Query:   []byte(query),
+			MaxRows: uint64(len(ids)),
+		})
+		if err != nil {
+			return 0, err
+		}
+		qr := sqltypes.Proto3ToResult(p3qr)
+		for _, row := range qr.Rows {
+			rowsCopied, err := row[1].ToCastInt64()
+			if err != nil {
+				return 0, err
+			}
+			startedAt, err := row[2].ToCastInt64()
+			if err != nil {
+				return 0, err
+			}
+			elapsed := now.Sub(time.Unix(startedAt, 0)).Seconds()
+			if elapsed <= 0 {
+				continue
+			}
+			rowsPerSecond += float64(rowsCopied) / elapsed
+		}
+	}
+	return rowsPerSecond, nil
+}
+
+// summarizeCopyProgress aggregates the per table copy progress of the
+// workflow and, using the current copy throughput, estimates how long it
+// will take for the copy phase to complete.
+func (s *Server) summarizeCopyProgress(ctx context.Context, ts *trafficSwitcher, progress copyProgress) (*vtctldatapb.WorkflowCopyProgress, error) {
+	summary := &vtctldatapb.WorkflowCopyProgress{}
+	var rowsLeft int64
+	for _, table := range progress {
+		summary.RowsCopied += table.TargetRowCount
+		summary.RowsTotal += table.SourceRowCount
+		if table.SourceRowCount > table.TargetRowCount {
+			rowsLeft += table.SourceRowCount - table.TargetRowCount
+		}
+	}
+	if summary.RowsTotal > 0 {
+		summary.RowsPercentage = float32(100.0 * float64(summary.RowsCopied) / float64(summary.RowsTotal))
+	}
+	rowsPerSecond, err := s.getCopyThroughput(ctx, ts, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	summary.RowsPerSecond = rowsPerSecond
+	if rowsPerSecond > 0 {
+		summary.Eta = protoutil.DurationToProto(time.Duration(float64(rowsLeft) / rowsPerSecond * float64(time.Second)))
+	}
+	return summary, nil
+}
+
 // WorkflowUpdate is part of the vtctlservicepb.VtctldServer interface.
 // It passes the embedded TabletRequest object to the given keyspace's
 // target primary tablets that are participating in the given workflow.
@@ -2115,6 +2534,8 @@ func (s *Server) WorkflowUpdate(ctx context.Context, req *vtctldatapb.WorkflowUp
 	span.Annotate("cells", req.TabletRequest.Cells)
 	span.Annotate("tablet_types", req.TabletRequest.TabletTypes)
 	span.Annotate("on_ddl", req.TabletRequest.OnDdl)
+	span.Annotate("throttler_settings", req.TabletRequest.ThrottlerSettings)
+	span.Annotate("schedule", req.TabletRequest.Schedule)
 	span.Annotate("state", req.TabletRequest.State)
 
 	vx := vexec.NewVExec(req.Keyspace, req.TabletRequest.Workflow, s.ts, s.tmc)
@@ -2375,7 +2796,7 @@ func (s *Server) DropTargets(ctx context.Context, targetKeyspace, workflow strin
 		return nil, ErrWorkflowPartiallySwitched
 	}
 
-	if state.WorkflowType == TypeMigrate {
+	if state.WorkflowType == TypeMigrate || state.WorkflowType == TypeDelayedReplica {
 		_, err := s.finalizeMigrateWorkflow(ctx, targetKeyspace, workflow, "", true, keepData, keepRoutingRules, dryRun)
 		return nil, err
 	}
@@ -2466,6 +2887,7 @@ func (s *Server) buildTrafficSwitcher(ctx context.Context, targetKeyspace, workf
 				ts.sourceTimeZone = bls.SourceTimeZone
 				ts.targetTimeZone = bls.TargetTimeZone
 				ts.externalCluster = bls.ExternalCluster
+				ts.tenant = bls.Tenant
 				if ts.externalCluster != "" {
 					externalTopo, err := s.ts.OpenExternalVitessClusterServer(ctx, ts.externalCluster)
 					if err != nil {
@@ -2854,8 +3276,8 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 		return nil, err
 	}
 
-	if startState.WorkflowType == TypeMigrate {
-		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid action for Migrate workflow: SwitchTraffic")
+	if startState.WorkflowType == TypeMigrate || startState.WorkflowType == TypeDelayedReplica {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid action for %s workflow: SwitchTraffic", startState.WorkflowType)
 	}
 
 	maxReplicationLagAllowed, set, err := protoutil.DurationFromProto(req.MaxReplicationLagAllowed)
@@ -2873,10 +3295,20 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 			return nil, err
 		}
 	}
-	reason, err := s.canSwitch(ctx, ts, startState, direction, int64(maxReplicationLagAllowed.Seconds()))
+	maxVDiffAge, _, err := protoutil.DurationFromProto(req.MaxVdiffAge)
 	if err != nil {
+		err = vterrors.Wrapf(err, "unable to parse MaxVdiffAge into a valid duration")
 		return nil, err
 	}
+	reason, err := s.canSwitch(ctx, ts, startState, direction, int64(maxReplicationLagAllowed.Seconds()), req.Force, req.RequireNoStreamErrors)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" && req.RequireVdiff && !req.Force && direction == DirectionForward && !startState.WritesSwitched {
+		if reason, err = s.checkLastVDiff(ctx, req.Keyspace, req.Workflow, maxVDiffAge); err != nil {
+			return nil, err
+		}
+	}
 	if reason != "" {
 		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot switch traffic for workflow %s at this time: %s", startState.Workflow, reason)
 	}
@@ -2884,6 +3316,9 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	if err != nil {
 		return nil, err
 	}
+	if ts.tenant != nil && !hasPrimary {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the reads and writes of tenant %s are switched together, so the tablet types must include PRIMARY", ts.tenant.Id)
+	}
 	if hasReplica || hasRdonly {
 		if rdDryRunResults, err = s.switchReads(ctx, req, ts, startState, timeout, false, direction); err != nil {
 			return nil, err
@@ -3020,6 +3455,8 @@ func (s *Server) switchReads(ctx context.Context, req *vtctldatapb.WorkflowSwitc
 	if ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
 		if ts.isPartialMigration {
 			ts.Logger().Infof("Partial migration, skipping switchTableReads as traffic is all or nothing per shard and overridden for reads AND writes in the ShardRoutingRule created when switching writes.")
+		} else if ts.tenant != nil {
+			ts.Logger().Infof("Multi-tenant migration, skipping switchTableReads as the reads AND writes of the tenant are routed by the TenantRoutingRule created when switching writes.")
 		} else if err := sw.switchTableReads(ctx, cells, req.TabletTypes, direction); err != nil {
 			return handleError("failed to switch read traffic for the tables", err)
 		}
@@ -3224,7 +3661,11 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 	return ts.id, sw.logs(), nil
 }
 
-func (s *Server) canSwitch(ctx context.Context, ts *trafficSwitcher, state *State, direction TrafficSwitchDirection, maxAllowedReplLagSecs int64) (reason string, err error) {
+// canSwitch returns the reason why traffic cannot be switched for the
+// workflow, or an empty string if it can. If force is set, the replication
+// lag and the errors of the streams are not checked. The messages of the
+// running streams are only checked if requireNoStreamErrors is set.
+func (s *Server) canSwitch(ctx context.Context, ts *trafficSwitcher, state *State, direction TrafficSwitchDirection, maxAllowedReplLagSecs int64, force, requireNoStreamErrors bool) (reason string, err error) {
 	if direction == DirectionForward && state.WritesSwitched ||
 		direction == DirectionBackward && !state.WritesSwitched {
 		log.Infof("writes already switched no need to check lag")
@@ -3239,16 +3680,24 @@ func (s *Server) canSwitch(ctx context.Context, ts *trafficSwitcher, state *Stat
 			if st.Message == Frozen {
 				return cannotSwitchFrozen, nil
 			}
+			if st.State == binlogdatapb.VReplicationWorkflowState_Copying.String() {
+				return cannotSwitchCopyIncomplete, nil
+			}
+			if force {
+				continue
+			}
 			// If no new events have been replicated after the copy phase then it will be 0.
 			if vreplLag := time.Now().Unix() - st.TimeUpdated.Seconds; vreplLag > maxAllowedReplLagSecs {
 				return fmt.Sprintf(cannotSwitchHighLag, vreplLag, maxAllowedReplLagSecs), nil
 			}
-			switch st.State {
-			case binlogdatapb.VReplicationWorkflowState_Copying.String():
-				return cannotSwitchCopyIncomplete, nil
-			case binlogdatapb.VReplicationWorkflowState_Error.String():
+			if st.State == binlogdatapb.VReplicationWorkflowState_Error.String() {
 				return cannotSwitchError, nil
 			}
+			if requireNoStreamErrors {
+				if msg := unresolvedStreamError(st); msg != "" {
+					return fmt.Sprintf(cannotSwitchUnresolvedError, st.Id, st.Shard, msg), nil
+				}
+			}
 		}
 	}
 
@@ -3281,6 +3730,78 @@ func (s *Server) canSwitch(ctx context.Context, ts *trafficSwitcher, state *Stat
 	return "", nil
 }
 
+// unresolvedStreamError returns the error that a running stream is retrying
+// after, or an empty string if it has none. The stream message is reset when
+// the stream makes progress, and is otherwise either informational or the
+// last error of the stream.
+func unresolvedStreamError(st *vtctldatapb.Workflow_Stream) string {
+	switch {
+	case st.State != binlogdatapb.VReplicationWorkflowState_Running.String(),
+		st.Message == "",
+		strings.HasPrefix(st.Message, "Picked source tablet: "),
+		strings.HasPrefix(st.Message, "Copy phase paused by the workflow schedule"):
+		return ""
+	}
+	return st.Message
+}
+
+// checkLastVDiff returns the reason why traffic cannot be switched for the
+// workflow according to its last VDiff, or an empty string if that VDiff
+// completed on all of the target shards without finding any differences,
+// and less than maxAge ago if it is set.
+func (s *Server) checkLastVDiff(ctx context.Context, keyspace, workflow string, maxAge time.Duration) (string, error) {
+	resp, err := s.VDiffShow(ctx, &vtctldatapb.VDiffShowRequest{
+		TargetKeyspace: keyspace,
+		Workflow:       workflow,
+		Arg:            vdiff.LastActionArg,
+	})
+	if err != nil {
+		return "", err
+	}
+	return lastVDiffReason(resp, maxAge, time.Now())
+}
+
+// lastVDiffReason implements checkLastVDiff for the responses of the target
+// shards to the VDiff show action.
+func lastVDiffReason(resp *vtctldatapb.VDiffShowResponse, maxAge time.Duration, now time.Time) (string, error) {
+	shards := maps.Keys(resp.TabletResponses)
+	sort.Strings(shards)
+	var uuid, uuidShard string
+	for _, shard := range shards {
+		tabletResp := resp.TabletResponses[shard]
+		if tabletResp == nil || tabletResp.VdiffUuid == "" || tabletResp.Output == nil {
+			return fmt.Sprintf(cannotSwitchNoVDiff, shard), nil
+		}
+		if uuid == "" {
+			uuid, uuidShard = tabletResp.VdiffUuid, shard
+		} else if tabletResp.VdiffUuid != uuid {
+			return fmt.Sprintf(cannotSwitchVDiffDiffers, tabletResp.VdiffUuid, shard, uuid, uuidShard), nil
+		}
+		for _, row := range sqltypes.Proto3ToResult(tabletResp.Output).Named().Rows {
+			if lastError := row.AsString("last_error", ""); lastError != "" {
+				return fmt.Sprintf(cannotSwitchVDiffFailed, uuid, shard, lastError), nil
+			}
+			if state := vdiff.VDiffState(strings.ToLower(row.AsString("vdiff_state", ""))); state != vdiff.CompletedState {
+				return fmt.Sprintf(cannotSwitchVDiffIncomplete, uuid, state, shard), nil
+			}
+			if mismatch, _ := row.ToBool("has_mismatch"); mismatch {
+				return fmt.Sprintf(cannotSwitchVDiffMismatch, uuid, shard), nil
+			}
+			if maxAge == 0 {
+				continue
+			}
+			completedAt, err := time.Parse(sqltypes.TimestampFormat, row.AsString("completed_at", ""))
+			if err != nil {
+				return "", vterrors.Wrapf(err, "invalid completion time of vdiff %s on shard %s", uuid, shard)
+			}
+			if age := now.Sub(completedAt); age > maxAge {
+				return fmt.Sprintf(cannotSwitchVDiffTooOld, uuid, shard, age.Truncate(time.Second), maxAge), nil
+			}
+		}
+	}
+	return "", nil
+}
+
 // VReplicationExec executes a query remotely using the DBA pool.
 func (s *Server) VReplicationExec(ctx context.Context, tabletAlias *topodatapb.TabletAlias, query string) (*querypb.QueryResult, error) {
 	ti, err := s.ts.GetTablet(ctx, tabletAlias)
@@ -3767,5 +4288,138 @@ func (s *Server) MigrateCreate(ctx context.Context, req *vtctldatapb.MigrateCrea
 		AutoStart:                 req.AutoStart,
 		NoRoutingRules:            req.NoRoutingRules,
 	}
-	return s.moveTablesCreate(ctx, moveTablesCreateRequest, binlogdatapb.VReplicationWorkflowType_Migrate)
+	return s.moveTablesCreate(ctx, moveTablesCreateRequest, binlogdatapb.VReplicationWorkflowType_Migrate, 0)
+}
+
+// DelayedReplicaCreate is part of the vtctlservicepb.VtctldServer interface.
+// It creates a workflow which copies all the tables of the source keyspace to
+// the target keyspace, and then applies the changes made to them on the
+// source once they are older than the requested delay.
+func (s *Server) DelayedReplicaCreate(ctx context.Context, req *vtctldatapb.DelayedReplicaCreateRequest) (*vtctldatapb.WorkflowStatusResponse, error) {
+	delay, _, err := protoutil.DurationFromProto(req.Delay)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse Delay into a valid duration")
+	}
+	if delay < time.Second {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the delay of a DelayedReplica workflow must be at least one second, got %v", delay)
+	}
+	moveTablesCreateRequest := &vtctldatapb.MoveTablesCreateRequest{
+		Workflow:                  req.Workflow,
+		SourceKeyspace:            req.SourceKeyspace,
+		TargetKeyspace:            req.TargetKeyspace,
+		Cells:                     req.Cells,
+		TabletTypes:               req.TabletTypes,
+		TabletSelectionPreference: req.TabletSelectionPreference,
+		AllTables:                 true,
+		ExcludeTables:             req.ExcludeTables,
+		OnDdl:                     req.OnDdl,
+		DeferSecondaryKeys:        req.DeferSecondaryKeys,
+		AutoStart:                 req.AutoStart,
+		// The target keyspace is not meant to serve traffic while the
+		// workflow is running.
+		NoRoutingRules: true,
+	}
+	return s.moveTablesCreate(ctx, moveTablesCreateRequest, binlogdatapb.VReplicationWorkflowType_DelayedReplica, int64(delay/time.Second))
+}
+
+// DelayedReplicaFastForward is part of the vtctlservicepb.VtctldServer interface.
+// It removes the delay of the streams of a DelayedReplica workflow and sets
+// their stop position, so that they catch up to that position and stop there.
+func (s *Server) DelayedReplicaFastForward(ctx context.Context, req *vtctldatapb.DelayedReplicaFastForwardRequest) (*vtctldatapb.DelayedReplicaFastForwardResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.DelayedReplicaFastForward")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("position", req.Position)
+	span.Annotate("source_shard", req.SourceShard)
+
+	if _, err := binlogplayer.DecodePosition(req.Position); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid position %s", req.Position)
+	}
+
+	// Read the streams of the workflow first, so that nothing is changed if
+	// the request does not match them.
+	type stream struct {
+		id  int64
+		bls *binlogdatapb.BinlogSource
+	}
+	var mu sync.Mutex
+	streams := make(map[string][]*stream)
+	sourceShards := make(map[string]bool)
+	vx := vexec.NewVExec(req.Keyspace, req.Workflow, s.ts, s.tmc)
+	callback := func(ctx context.Context, tablet *topo.TabletInfo) (*querypb.QueryResult, error) {
+		query := fmt.Sprintf("select id, source, workflow_type from _vt.vreplication where workflow = %s and db_name = %s",
+			encodeString(req.Workflow), encodeString(tablet.DbName()))
+		qr, err := s.tmc.VReplicationExec(ctx, tablet.Tablet, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range sqltypes.Proto3ToResult(qr).Named().Rows {
+			workflowType, _ := row["workflow_type"].ToInt32()
+			if workflowType != int32(binlogdatapb.VReplicationWorkflowType_DelayedReplica) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%s is not a DelayedReplica workflow", req.Workflow)
+			}
+			id, _ := row["id"].ToInt64()
+			bls := &binlogdatapb.BinlogSource{}
+			if err := prototext.Unmarshal(row.AsBytes("source", nil), bls); err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			sourceShards[bls.Shard] = true
+			if req.SourceShard == "" || bls.Shard == req.SourceShard {
+				streams[tablet.AliasString()] = append(streams[tablet.AliasString()], &stream{id: id, bls: bls})
+			}
+			mu.Unlock()
+		}
+		return qr, nil
+	}
+	if _, err := vx.CallbackContext(ctx, callback); err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return nil, vterrors.Wrapf(err, "%s keyspace does not exist", req.Keyspace)
+		}
+		return nil, err
+	}
+	switch {
+	case len(sourceShards) == 0:
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s workflow does not exist in the %s keyspace", req.Workflow, req.Keyspace)
+	case req.SourceShard == "" && len(sourceShards) > 1:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the %s workflow has more than one source shard, a source shard must be specified", req.Workflow)
+	case req.SourceShard != "" && !sourceShards[req.SourceShard]:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the %s workflow has no streams for the %s source shard", req.Workflow, req.SourceShard)
+	}
+
+	callback = func(ctx context.Context, tablet *topo.TabletInfo) (*querypb.QueryResult, error) {
+		res := &querypb.QueryResult{}
+		for _, st := range streams[tablet.AliasString()] {
+			st.bls.ApplyDelaySeconds = 0
+			source, err := prototext.Marshal(st.bls)
+			if err != nil {
+				return nil, err
+			}
+			query := fmt.Sprintf("update _vt.vreplication set state = %s, message = '', stop_pos = %s, source = %s where id = %d",
+				encodeString(binlogdatapb.VReplicationWorkflowState_Running.String()), encodeString(req.Position), encodeString(string(source)), st.id)
+			qr, err := s.tmc.VReplicationExec(ctx, tablet.Tablet, query)
+			if err != nil {
+				return nil, err
+			}
+			res.RowsAffected += qr.RowsAffected
+		}
+		return res, nil
+	}
+	res, err := vx.CallbackContext(ctx, callback)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &vtctldatapb.DelayedReplicaFastForwardResponse{
+		Summary: fmt.Sprintf("Successfully fast-forwarded the %s workflow on (%d) target primary tablets in the %s keyspace to %s", req.Workflow, len(res), req.Keyspace, req.Position),
+	}
+	for tinfo, tres := range res {
+		response.Details = append(response.Details, &vtctldatapb.WorkflowUpdateResponse_TabletInfo{
+			Tablet:  tinfo.Alias,
+			Changed: tres.RowsAffected > 0,
+		})
+	}
+	return response, nil
 }
@@ -20,24 +20,46 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/prototext"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 type fakeTMC struct {
 	tmclient.TabletManagerClient
 	vrepQueriesByTablet map[string]map[string]*querypb.QueryResult
+	workflowsByTablet   map[string]*tabletmanagerdatapb.ReadVReplicationWorkflowResponse
+}
+
+func (fake *fakeTMC) ReadVReplicationWorkflow(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReadVReplicationWorkflowRequest) (*tabletmanagerdatapb.ReadVReplicationWorkflowResponse, error) {
+	alias := topoproto.TabletAliasString(tablet.Alias)
+	res, ok := fake.workflowsByTablet[alias]
+	if !ok {
+		return nil, fmt.Errorf("no workflow registered on fake for %s", alias)
+	}
+	return res, nil
+}
+
+func (fake *fakeTMC) ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, req *tabletmanagerdatapb.ExecuteFetchAsDbaRequest) (*querypb.QueryResult, error) {
+	return fake.VReplicationExec(ctx, tablet, string(req.Query))
 }
 
 func (fake *fakeTMC) VReplicationExec(ctx context.Context, tablet *topodatapb.Tablet, query string) (*querypb.QueryResult, error) {
@@ -163,3 +185,363 @@ func TestCheckReshardingJournalExistsOnTablet(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarizeCopyProgress(t *testing.T) {
+	ctx := context.Background()
+	tablet := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+	}
+	now := time.Now()
+	startedAt := now.Add(-100 * time.Second).Unix()
+	query := "select vr.id, vr.rows_copied, unix_timestamp(min(vl.created_at)) from _vt.vreplication vr, _vt.vreplication_log vl " +
+		"where vl.vrepl_id = vr.id and vr.id in (1,2) and vr.state = 'Copying' and vl.type = 'Started Copy Phase' group by vr.id, vr.rows_copied"
+	tmc := &fakeTMC{
+		vrepQueriesByTablet: map[string]map[string]*querypb.QueryResult{
+			topoproto.TabletAliasString(tablet.Alias): {
+				query: sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+					"id|rows_copied|started_at",
+					"int64|int64|int64"),
+					fmt.Sprintf("1|600|%d", startedAt),
+					fmt.Sprintf("2|400|%d", startedAt),
+				)),
+			},
+		},
+	}
+	ts := &trafficSwitcher{
+		targets: map[string]*MigrationTarget{
+			"0": {
+				primary: &topo.TabletInfo{Tablet: tablet},
+				Sources: map[int32]*binlogdatapb.BinlogSource{
+					2: {Keyspace: "source", Shard: "-80"},
+					1: {Keyspace: "source", Shard: "80-"},
+				},
+			},
+		},
+	}
+	s := NewServer(nil, tmc)
+
+	rowsPerSecond, err := s.getCopyThroughput(ctx, ts, now)
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, rowsPerSecond, 0.5)
+
+	summary, err := s.summarizeCopyProgress(ctx, ts, copyProgress{
+		"t1": {TargetRowCount: 1000, SourceRowCount: 2000},
+		"t2": {TargetRowCount: 500, SourceRowCount: 500},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1500, summary.RowsCopied)
+	assert.EqualValues(t, 2500, summary.RowsTotal)
+	assert.InDelta(t, 60.0, summary.RowsPercentage, 0.01)
+	eta, ok, err := protoutil.DurationFromProto(summary.Eta)
+	require.NoError(t, err)
+	require.True(t, ok)
+	// 1000 rows left at ~10 rows/s.
+	assert.InDelta(t, 100*time.Second, eta, float64(10*time.Second))
+}
+
+func TestGetWorkflowMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	tablets := []*topodatapb.Tablet{
+		{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}, Keyspace: "target", Shard: "-80"},
+		{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 200}, Keyspace: "target", Shard: "80-"},
+	}
+	for _, tablet := range tablets {
+		require.NoError(t, ts.CreateTablet(ctx, tablet))
+	}
+	lagHistogram := func(counts ...int64) *tabletmanagerdatapb.VReplicationMetrics_Histogram {
+		return &tabletmanagerdatapb.VReplicationMetrics_Histogram{Cutoffs: []int64{1, 10}, Counts: counts, Total: counts[1] * 5}
+	}
+	tmc := &fakeTMC{
+		workflowsByTablet: map[string]*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+			"zone1-0000000100": {Streams: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{{
+				Id: 1,
+				Metrics: &tabletmanagerdatapb.VReplicationMetrics{
+					LagHistogram: lagHistogram(3, 1, 0),
+					EventCounts:  map[string]int64{"ROW": 10},
+					EventRates:   map[string]float64{"ROW": 2},
+					CopyRowCount: 100,
+					CopyRowRate:  5,
+					ErrorCounts:  map[string]int64{"Apply": 1},
+				},
+			}, {
+				// Not running.
+				Id: 2,
+			}}},
+			"zone1-0000000200": {Streams: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{{
+				Id: 1,
+				Metrics: &tabletmanagerdatapb.VReplicationMetrics{
+					LagHistogram: lagHistogram(1, 2, 1),
+					EventCounts:  map[string]int64{"ROW": 5, "COMMIT": 5},
+					EventRates:   map[string]float64{"ROW": 1, "COMMIT": 1},
+					CopyRowCount: 50,
+					CopyRowRate:  2.5,
+				},
+			}}},
+		},
+	}
+	workflow := &vtctldatapb.Workflow{
+		Name: "wf",
+		ShardStreams: map[string]*vtctldatapb.Workflow_ShardStream{
+			"-80/zone1-0000000100": {Streams: []*vtctldatapb.Workflow_Stream{{Id: 1, Tablet: tablets[0].Alias}, {Id: 2, Tablet: tablets[0].Alias}}},
+			"80-/zone1-0000000200": {Streams: []*vtctldatapb.Workflow_Stream{{Id: 1, Tablet: tablets[1].Alias}}},
+		},
+	}
+	s := NewServer(ts, tmc)
+
+	metrics, err := s.getWorkflowMetrics(ctx, workflow)
+	require.NoError(t, err)
+	require.Len(t, metrics, 2)
+	require.Len(t, metrics["-80/zone1-0000000100"], 1)
+	assert.EqualValues(t, 100, metrics["-80/zone1-0000000100"][1].CopyRowCount)
+	assert.EqualValues(t, 50, metrics["80-/zone1-0000000200"][1].CopyRowCount)
+
+	aggregated := mergeVReplicationMetrics(nil, metrics["-80/zone1-0000000100"][1])
+	aggregated = mergeVReplicationMetrics(aggregated, metrics["80-/zone1-0000000200"][1])
+	assert.Equal(t, []int64{4, 3, 1}, aggregated.LagHistogram.Counts)
+	assert.EqualValues(t, 15, aggregated.LagHistogram.Total)
+	assert.Equal(t, map[string]int64{"ROW": 15, "COMMIT": 5}, aggregated.EventCounts)
+	assert.Equal(t, map[string]float64{"ROW": 3, "COMMIT": 1}, aggregated.EventRates)
+	assert.EqualValues(t, 150, aggregated.CopyRowCount)
+	assert.InDelta(t, 7.5, aggregated.CopyRowRate, 0.01)
+	assert.Equal(t, map[string]int64{"Apply": 1}, aggregated.ErrorCounts)
+	// The metrics of the streams are not modified.
+	assert.Equal(t, []int64{3, 1, 0}, metrics["-80/zone1-0000000100"][1].LagHistogram.Counts)
+}
+
+func TestDelayedReplicaFastForward(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms := &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+	}
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"-80", "80-"}, []string{"0"})
+	defer env.close()
+
+	const (
+		workflow = "delayed"
+		position = "MySQL56/e9ba1ec8-6a79-11ee-9b83-0242ac120002:1-10"
+		selectQ  = "select id, source, workflow_type from _vt.vreplication where workflow = 'delayed' and db_name = 'vt_targetks'"
+	)
+	source := func(shard string) string {
+		bls, err := prototext.Marshal(&binlogdatapb.BinlogSource{Keyspace: "sourceks", Shard: shard, ApplyDelaySeconds: 3600})
+		require.NoError(t, err)
+		return string(bls)
+	}
+	streams := func(workflowType binlogdatapb.VReplicationWorkflowType) *sqltypes.Result {
+		return sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|source|workflow_type", "int64|varchar|int64"),
+			fmt.Sprintf("1|%s|%d", source("-80"), workflowType),
+			fmt.Sprintf("2|%s|%d", source("80-"), workflowType))
+	}
+	req := &vtctldatapb.DelayedReplicaFastForwardRequest{Keyspace: "targetks", Workflow: workflow, Position: position}
+
+	_, err := env.ws.DelayedReplicaFastForward(ctx, &vtctldatapb.DelayedReplicaFastForwardRequest{Keyspace: "targetks", Workflow: workflow, Position: "invalid"})
+	assert.ErrorContains(t, err, "invalid position invalid")
+
+	env.tmc.expectVRQuery(200, selectQ, &sqltypes.Result{})
+	_, err = env.ws.DelayedReplicaFastForward(ctx, req)
+	assert.EqualError(t, err, "the delayed workflow does not exist in the targetks keyspace")
+
+	env.tmc.expectVRQuery(200, selectQ, streams(binlogdatapb.VReplicationWorkflowType_MoveTables))
+	_, err = env.ws.DelayedReplicaFastForward(ctx, req)
+	assert.ErrorContains(t, err, "delayed is not a DelayedReplica workflow")
+
+	env.tmc.expectVRQuery(200, selectQ, streams(binlogdatapb.VReplicationWorkflowType_DelayedReplica))
+	_, err = env.ws.DelayedReplicaFastForward(ctx, req)
+	assert.EqualError(t, err, "the delayed workflow has more than one source shard, a source shard must be specified")
+
+	req.SourceShard = "c0-"
+	env.tmc.expectVRQuery(200, selectQ, streams(binlogdatapb.VReplicationWorkflowType_DelayedReplica))
+	_, err = env.ws.DelayedReplicaFastForward(ctx, req)
+	assert.EqualError(t, err, "the delayed workflow has no streams for the c0- source shard")
+
+	// Only the stream of the given source shard is fast-forwarded, and its delay is removed.
+	req.SourceShard = "80-"
+	env.tmc.expectVRQuery(200, selectQ, streams(binlogdatapb.VReplicationWorkflowType_DelayedReplica))
+	env.tmc.expectVRQuery(200, `/update _vt.vreplication set state = 'Running', message = '', stop_pos = 'MySQL56/e9ba1ec8-6a79-11ee-9b83-0242ac120002:1-10', source = 'keyspace:\\"sourceks\\"\s+shard:\\"80-\\"' where id = 2`, &sqltypes.Result{RowsAffected: 1})
+	resp, err := env.ws.DelayedReplicaFastForward(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "Successfully fast-forwarded the delayed workflow on (1) target primary tablets in the targetks keyspace to "+position, resp.Summary)
+	require.Len(t, resp.Details, 1)
+	assert.True(t, resp.Details[0].Changed)
+	env.tmc.verifyQueries(t)
+}
+
+func TestUnresolvedStreamError(t *testing.T) {
+	running := binlogdatapb.VReplicationWorkflowState_Running.String()
+	testcases := []struct {
+		state   string
+		message string
+		want    string
+	}{
+		{state: running},
+		{state: running, message: "Picked source tablet: cell-0000000100"},
+		{state: running, message: "Copy phase paused by the workflow schedule until 2023-10-01T00:00:00Z"},
+		{state: running, message: "error in applying event: Duplicate entry '1' for key 'PRIMARY'", want: "error in applying event: Duplicate entry '1' for key 'PRIMARY'"},
+		{state: binlogdatapb.VReplicationWorkflowState_Stopped.String(), message: "Stopped after copy."},
+	}
+	for _, tc := range testcases {
+		got := unresolvedStreamError(&vtctldatapb.Workflow_Stream{State: tc.state, Message: tc.message})
+		assert.Equal(t, tc.want, got, "state %s, message %q", tc.state, tc.message)
+	}
+}
+
+func TestLastVDiffReason(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	fields := sqltypes.MakeTestFields("vdiff_state|last_error|table_name|completed_at|has_mismatch", "varchar|varchar|varchar|timestamp|int64")
+	shardResponse := func(uuid string, rows ...string) *tabletmanagerdatapb.VDiffResponse {
+		return &tabletmanagerdatapb.VDiffResponse{
+			VdiffUuid: uuid,
+			Output:    sqltypes.ResultToProto3(sqltypes.MakeTestResult(fields, rows...)),
+		}
+	}
+	testcases := []struct {
+		name      string
+		responses map[string]*tabletmanagerdatapb.VDiffResponse
+		maxAge    time.Duration
+		want      string
+	}{{
+		name: "completed",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "completed||t1|2023-10-01 11:00:00|0", "completed||t2|2023-10-01 11:00:00|0"),
+			"80-": shardResponse("u1", "completed||t1|2023-10-01 11:30:00|0", "completed||t2|2023-10-01 11:30:00|0"),
+		},
+		maxAge: 2 * time.Hour,
+	}, {
+		name: "too old",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "completed||t1|2023-10-01 11:00:00|0"),
+			"80-": shardResponse("u1", "completed||t1|2023-10-01 11:30:00|0"),
+		},
+		maxAge: 45 * time.Minute,
+		want:   "the last vdiff u1 completed on shard -80 1h0m0s ago, more than the allowed 45m0s",
+	}, {
+		name: "no vdiff",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "completed||t1|2023-10-01 11:00:00|0"),
+			"80-": {},
+		},
+		want: "no vdiff found on shard 80-",
+	}, {
+		name: "different vdiffs",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "completed||t1|2023-10-01 11:00:00|0"),
+			"80-": shardResponse("u2", "completed||t1|2023-10-01 11:00:00|0"),
+		},
+		want: "the last vdiff u2 on shard 80- is not the last vdiff u1 on shard -80",
+	}, {
+		name: "mismatch",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "completed||t1|2023-10-01 11:00:00|0", "completed||t2|2023-10-01 11:00:00|1"),
+		},
+		want: "the last vdiff u1 found differences on shard -80",
+	}, {
+		name: "started",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "started||t1||0"),
+		},
+		want: "the last vdiff u1 is started on shard -80",
+	}, {
+		name: "error",
+		responses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"-80": shardResponse("u1", "error|lost connection|t1||0"),
+		},
+		want: "the last vdiff u1 failed on shard -80: lost connection",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := lastVDiffReason(&vtctldatapb.VDiffShowResponse{TabletResponses: tc.responses}, tc.maxAge, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// vdiffCreateTMC returns the given errors, in order, to the VDiff calls.
+type vdiffCreateTMC struct {
+	tmclient.TabletManagerClient
+	errs  []error
+	calls int
+}
+
+func (tmc *vdiffCreateTMC) VDiff(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.VDiffRequest) (*tabletmanagerdatapb.VDiffResponse, error) {
+	tmc.calls++
+	if len(tmc.errs) == 0 {
+		return &tabletmanagerdatapb.VDiffResponse{}, nil
+	}
+	err := tmc.errs[0]
+	tmc.errs = tmc.errs[1:]
+	return nil, err
+}
+
+func TestCreateVDiffOnTarget(t *testing.T) {
+	defer func(delay time.Duration) {
+		vdiffCreateRetryDelay = delay
+	}(vdiffCreateRetryDelay)
+	vdiffCreateRetryDelay = time.Millisecond
+
+	unavailable := vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "tablet is not reachable")
+	exists := vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "vdiff with UUID u1 already exists on tablet zone1-0000000100")
+	testcases := []struct {
+		name      string
+		autoRetry bool
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{{
+		name:      "created",
+		autoRetry: true,
+		wantCalls: 1,
+	}, {
+		name:      "no retry",
+		errs:      []error{unavailable},
+		wantCalls: 1,
+		wantErr:   unavailable,
+	}, {
+		name:      "created after retries",
+		autoRetry: true,
+		errs:      []error{unavailable, unavailable},
+		wantCalls: 3,
+	}, {
+		name:      "created by an attempt whose response was lost",
+		autoRetry: true,
+		errs:      []error{unavailable, exists},
+		wantCalls: 2,
+	}, {
+		name:      "already exists",
+		autoRetry: true,
+		errs:      []error{exists},
+		wantCalls: 1,
+		wantErr:   exists,
+	}, {
+		name:      "created by an attempt whose response was lost, topo error",
+		autoRetry: true,
+		errs:      []error{unavailable, topo.NewError(topo.NodeExists, "u1")},
+		wantCalls: 2,
+	}, {
+		name:      "gives up",
+		autoRetry: true,
+		errs:      []error{unavailable, unavailable, unavailable, unavailable, unavailable, unavailable},
+		wantCalls: vdiffCreateAttempts,
+		wantErr:   unavailable,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmc := &vdiffCreateTMC{errs: tc.errs}
+			s := NewServer(nil, tmc)
+			target := &MigrationTarget{
+				si:      topo.NewShardInfo("ks", "-80", &topodatapb.Shard{}, nil),
+				primary: &topo.TabletInfo{Tablet: &topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}}},
+			}
+			err := s.createVDiffOnTarget(context.Background(), target, &tabletmanagerdatapb.VDiffRequest{VdiffUuid: "u1"}, tc.autoRetry)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCalls, tmc.calls)
+		})
+	}
+}
@@ -31,6 +31,7 @@ const (
 	MoveTablesWorkflow = VReplicationWorkflowType(iota)
 	ReshardWorkflow
 	MigrateWorkflow
+	DelayedReplicaWorkflow
 )
 
 // Type is the type of a workflow as a string and maps directly
@@ -39,20 +40,23 @@ type Type string
 
 // Workflow string types.
 const (
-	TypeMoveTables Type = "MoveTables"
-	TypeReshard    Type = "Reshard"
-	TypeMigrate    Type = "Migrate"
+	TypeMoveTables     Type = "MoveTables"
+	TypeReshard        Type = "Reshard"
+	TypeMigrate        Type = "Migrate"
+	TypeDelayedReplica Type = "DelayedReplica"
 )
 
 var TypeStrMap = map[VReplicationWorkflowType]Type{
-	MoveTablesWorkflow: TypeMoveTables,
-	ReshardWorkflow:    TypeReshard,
-	MigrateWorkflow:    TypeMigrate,
+	MoveTablesWorkflow:     TypeMoveTables,
+	ReshardWorkflow:        TypeReshard,
+	MigrateWorkflow:        TypeMigrate,
+	DelayedReplicaWorkflow: TypeDelayedReplica,
 }
 var TypeIntMap = map[Type]VReplicationWorkflowType{
-	TypeMoveTables: MoveTablesWorkflow,
-	TypeReshard:    ReshardWorkflow,
-	TypeMigrate:    MigrateWorkflow,
+	TypeMoveTables:     MoveTablesWorkflow,
+	TypeReshard:        ReshardWorkflow,
+	TypeMigrate:        MigrateWorkflow,
+	TypeDelayedReplica: DelayedReplicaWorkflow,
 }
 
 // State represents the state of a workflow.
@@ -74,10 +78,22 @@ type State struct {
 	IsPartialMigration    bool
 	ShardsAlreadySwitched []string
 	ShardsNotYetSwitched  []string
+
+	// Multi-tenant MoveTables info
+	TenantID string
 }
 
 func (s *State) String() string {
 	var stateInfo []string
+	if s.TenantID != "" {
+		// The reads and writes of a tenant are switched together.
+		if s.WritesSwitched {
+			stateInfo = append(stateInfo, "All Reads Switched", "Writes Switched")
+		} else {
+			stateInfo = append(stateInfo, "Reads Not Switched", "Writes Not Switched")
+		}
+		return strings.Join(stateInfo, ". ")
+	}
 	if !s.IsPartialMigration { // shard level traffic switching is all or nothing
 		if len(s.RdonlyCellsNotSwitched) == 0 && len(s.ReplicaCellsNotSwitched) == 0 && len(s.ReplicaCellsSwitched) > 0 {
 			stateInfo = append(stateInfo, "All Reads Switched")
@@ -107,6 +107,10 @@ func (dr *switcherDryRun) allowTargetWrites(ctx context.Context) error {
 func (dr *switcherDryRun) changeRouting(ctx context.Context) error {
 	dr.drLog.Logf("Switch routing from keyspace %s to keyspace %s", dr.ts.SourceKeyspaceName(), dr.ts.TargetKeyspaceName())
 	var deleteLogs, addLogs []string
+	if dr.ts.tenant != nil {
+		dr.drLog.Logf("Tenant routing rule for tenant %s will be updated", dr.ts.tenant.Id)
+		return nil
+	}
 	if dr.ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
 		tables := strings.Join(dr.ts.Tables(), ",")
 		dr.drLog.Logf("Routing rules for tables [%s] will be updated", tables)
@@ -225,6 +229,10 @@ func (dr *switcherDryRun) lockKeyspace(ctx context.Context, keyspace, _ string)
 }
 
 func (dr *switcherDryRun) removeSourceTables(ctx context.Context, removalType TableRemovalType) error {
+	if dr.ts.tenant != nil {
+		dr.drLog.Logf("Deleting the rows of tenant %s from tables [%s] in keyspace %s", dr.ts.tenant.Id, strings.Join(dr.ts.Tables(), ","), dr.ts.SourceKeyspaceName())
+		return nil
+	}
 	logs := make([]string, 0)
 	for _, source := range dr.ts.Sources() {
 		for _, tableName := range dr.ts.Tables() {
@@ -329,6 +337,10 @@ func (dr *switcherDryRun) logs() *[]string {
 }
 
 func (dr *switcherDryRun) removeTargetTables(ctx context.Context) error {
+	if dr.ts.tenant != nil {
+		dr.drLog.Logf("Deleting the rows of tenant %s from tables [%s] in keyspace %s", dr.ts.tenant.Id, strings.Join(dr.ts.Tables(), ","), dr.ts.TargetKeyspaceName())
+		return nil
+	}
 	logs := make([]string, 0)
 	for _, target := range dr.ts.Targets() {
 		for _, tableName := range dr.ts.Tables() {
@@ -0,0 +1,311 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// buildTenant validates that the tables of a multi-tenant MoveTables workflow
+// can be moved one tenant at a time, and returns the tenant of the workflow.
+// The tenant column of each table is the column of its primary vindex in the
+// sharded source keyspace, and all the tables must use the same primary
+// vindex, so that all the rows of the tenant map to a single keyspace id.
+func buildTenant(ctx context.Context, sourceKSSchema *vindexes.KeyspaceSchema, tables []string, tenantID string) (*binlogdatapb.VReplicationTenant, error) {
+	if !sourceKSSchema.Keyspace.Sharded {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the source keyspace %s must be sharded to move a single tenant", sourceKSSchema.Keyspace.Name)
+	}
+	tenant := &binlogdatapb.VReplicationTenant{
+		Id:      tenantID,
+		Columns: make(map[string]string, len(tables)),
+	}
+	var vindex *vindexes.ColumnVindex
+	for _, table := range tables {
+		vtable, ok := sourceKSSchema.Tables[table]
+		if !ok || vtable.Type == vindexes.TypeReference || len(vtable.ColumnVindexes) == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table %s has no primary vindex in the %s keyspace", table, sourceKSSchema.Keyspace.Name)
+		}
+		cv := vtable.ColumnVindexes[0]
+		if len(cv.Columns) != 1 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the primary vindex of table %s must have a single column to be used as the tenant column", table)
+		}
+		if cv.Vindex.NeedsVCursor() {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the primary vindex %s of table %s is not supported for tenant migrations", cv.Name, table)
+		}
+		if vindex == nil {
+			vindex = cv
+		} else if cv.Name != vindex.Name {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the tables must have the same primary vindex to move a single tenant: table %s uses %s instead of %s", table, cv.Name, vindex.Name)
+		}
+		tenant.Columns[table] = cv.Columns[0].String()
+	}
+	id, err := tenantIDValue(sourceKSSchema, tables[0], tenantID)
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := vindexes.Map(ctx, vindex.Vindex, nil, [][]sqltypes.Value{{id}})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to compute the keyspace id of tenant %s", tenantID)
+	}
+	ksid, ok := destinations[0].(key.DestinationKeyspaceID)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "tenant %s does not map to a single keyspace id", tenantID)
+	}
+	tenant.KeyspaceId = ksid
+	return tenant, nil
+}
+
+// numericVindexTypes are the types of the vindexes that only map integer ids.
+var numericVindexTypes = map[string]bool{
+	"hash":               true,
+	"numeric":            true,
+	"numeric_static_map": true,
+	"reverse_bits":       true,
+}
+
+// tenantColumnType returns the type of the tenant column of the given table:
+// its type in the VSchema if it is declared, and otherwise the type of the
+// ids mapped by the primary vindex of the table.
+func tenantColumnType(ksSchema *vindexes.KeyspaceSchema, table string) (querypb.Type, error) {
+	vtable, ok := ksSchema.Tables[table]
+	if !ok || len(vtable.ColumnVindexes) == 0 || len(vtable.ColumnVindexes[0].Columns) != 1 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table %s has no primary vindex in the %s keyspace", table, ksSchema.Keyspace.Name)
+	}
+	cv := vtable.ColumnVindexes[0]
+	for _, col := range vtable.Columns {
+		if col.Name.Equal(cv.Columns[0]) && col.Type != sqltypes.Null {
+			return col.Type, nil
+		}
+	}
+	if numericVindexTypes[cv.Type] {
+		return sqltypes.Uint64, nil
+	}
+	return sqltypes.VarBinary, nil
+}
+
+// tenantIDValue returns the tenant id as a value of the type of the tenant
+// column of the given table.
+func tenantIDValue(ksSchema *vindexes.KeyspaceSchema, table string, tenantID string) (sqltypes.Value, error) {
+	typ, err := tenantColumnType(ksSchema, table)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	switch {
+	case sqltypes.IsSigned(typ):
+		id, err := strconv.ParseInt(tenantID, 10, 64)
+		if err != nil {
+			return sqltypes.Value{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tenant id %s is not a valid %v value for the tenant column of table %s", tenantID, typ, table)
+		}
+		return sqltypes.NewInt64(id), nil
+	case sqltypes.IsUnsigned(typ):
+		id, err := strconv.ParseUint(tenantID, 10, 64)
+		if err != nil {
+			return sqltypes.Value{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tenant id %s is not a valid %v value for the tenant column of table %s", tenantID, typ, table)
+		}
+		return sqltypes.NewUint64(id), nil
+	}
+	return sqltypes.MakeTrusted(typ, []byte(tenantID)), nil
+}
+
+// tenantCondition returns the condition that selects the rows of the tenant
+// in the given table.
+func tenantCondition(ksSchema *vindexes.KeyspaceSchema, tenant *binlogdatapb.VReplicationTenant, table string) (sqlparser.Expr, error) {
+	id, err := tenantIDValue(ksSchema, table, tenant.Id)
+	if err != nil {
+		return nil, err
+	}
+	var val sqlparser.Expr
+	if id.IsIntegral() {
+		val = sqlparser.NewIntLiteral(id.ToString())
+	} else {
+		val = sqlparser.NewStrLiteral(id.ToString())
+	}
+	return &sqlparser.ComparisonExpr{
+		Operator: sqlparser.EqualOp,
+		Left:     sqlparser.NewColName(tenant.Columns[table]),
+		Right:    val,
+	}, nil
+}
+
+// addTenantFilter restricts the select query of a stream to the rows of the
+// tenant.
+func addTenantFilter(query string, ksSchema *vindexes.KeyspaceSchema, tenant *binlogdatapb.VReplicationTenant, table string) (string, error) {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return "", fmt.Errorf("unrecognized statement: %s", query)
+	}
+	cond, err := tenantCondition(ksSchema, tenant, table)
+	if err != nil {
+		return "", err
+	}
+	sel.AddWhere(cond)
+	return sqlparser.String(sel), nil
+}
+
+// isTenantSwitched returns true if a tenant routing rule routes the queries of
+// the tenant to the target keyspace.
+func isTenantSwitched(rules []*vschemapb.TenantRoutingRule, tenant *binlogdatapb.VReplicationTenant, targetKeyspace string) bool {
+	for _, rule := range rules {
+		if bytes.Equal(rule.KeyspaceId, tenant.KeyspaceId) && rule.ToKeyspace == targetKeyspace {
+			return true
+		}
+	}
+	return false
+}
+
+// switchTenantRoutingRules returns the tenant routing rules after the queries
+// of the tenant are switched from the source to the target keyspace. The
+// rules that routed the tenant to the source keyspace are updated to route it
+// to the target keyspace, the rules that would route it away from the target
+// keyspace are removed and a rule from the source to the target keyspace is
+// added.
+func switchTenantRoutingRules(rules []*vschemapb.TenantRoutingRule, tenant *binlogdatapb.VReplicationTenant, sourceKeyspace, targetKeyspace string) []*vschemapb.TenantRoutingRule {
+	switched := make([]*vschemapb.TenantRoutingRule, 0, len(rules)+1)
+	for _, rule := range rules {
+		if !bytes.Equal(rule.KeyspaceId, tenant.KeyspaceId) {
+			switched = append(switched, rule)
+			continue
+		}
+		if rule.FromKeyspace == sourceKeyspace || rule.FromKeyspace == targetKeyspace {
+			continue
+		}
+		if rule.ToKeyspace == sourceKeyspace {
+			rule.ToKeyspace = targetKeyspace
+		}
+		switched = append(switched, rule)
+	}
+	return append(switched, &vschemapb.TenantRoutingRule{
+		FromKeyspace: sourceKeyspace,
+		ToKeyspace:   targetKeyspace,
+		KeyspaceId:   tenant.KeyspaceId,
+		TenantId:     tenant.Id,
+	})
+}
+
+// changeTenantRouting routes the queries of the tenant to the target keyspace
+// and then allows the writes of the other tenants on the source shards again.
+func (ts *trafficSwitcher) changeTenantRouting(ctx context.Context) error {
+	trr, err := ts.TopoServer().GetTenantRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	trr.Rules = switchTenantRoutingRules(trr.Rules, ts.tenant, ts.SourceKeyspaceName(), ts.TargetKeyspaceName())
+	if err := ts.TopoServer().SaveTenantRoutingRules(ctx, trr); err != nil {
+		return err
+	}
+	ts.Logger().Infof("Routed tenant %s from keyspace %s to keyspace %s", ts.tenant.Id, ts.SourceKeyspaceName(), ts.TargetKeyspaceName())
+	return ts.changeTenantSourceWrites(ctx, allowWrites)
+}
+
+// changeTenantSourceWrites denies or allows the writes to the tables of the
+// workflow on the source shard that holds the rows of the tenant. The other
+// source shards are not affected.
+func (ts *trafficSwitcher) changeTenantSourceWrites(ctx context.Context, access accessType) error {
+	err := ts.ForAllSources(func(source *MigrationSource) error {
+		if !key.KeyRangeContains(source.GetShard().KeyRange, ts.tenant.KeyspaceId) {
+			return nil
+		}
+		if _, err := ts.TopoServer().UpdateShardFields(ctx, ts.SourceKeyspaceName(), source.GetShard().ShardName(), func(si *topo.ShardInfo) error {
+			return si.UpdateDeniedTables(ctx, topodatapb.TabletType_PRIMARY, nil, access == allowWrites /* remove */, ts.Tables())
+		}); err != nil {
+			return err
+		}
+		rtbsCtx, cancel := context.WithTimeout(ctx, shardTabletRefreshTimeout)
+		defer cancel()
+		isPartial, partialDetails, err := topotools.RefreshTabletsByShard(rtbsCtx, ts.TopoServer(), ts.TabletManagerClient(), source.GetShard(), nil, ts.Logger())
+		if isPartial {
+			err = fmt.Errorf("failed to successfully refresh all tablets in the %s/%s source shard (%v):\n  %v",
+				source.GetShard().Keyspace(), source.GetShard().ShardName(), err, partialDetails)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
+}
+
+// deleteTenantRows deletes the rows of the tenant from the tables of the
+// workflow on the given primary tablet.
+func (ts *trafficSwitcher) deleteTenantRows(ctx context.Context, primary *topo.TabletInfo) error {
+	for _, table := range ts.Tables() {
+		cond, err := tenantCondition(ts.sourceKSSchema, ts.tenant, table)
+		if err != nil {
+			return err
+		}
+		query := fmt.Sprintf("delete from %s.%s where %s",
+			sqlescape.EscapeID(sqlescape.UnescapeID(primary.DbName())),
+			sqlescape.EscapeID(sqlescape.UnescapeID(table)),
+			sqlparser.String(cond))
+		ts.Logger().Infof("%s: Deleting the rows of tenant %s from table %s.%s", primary.String(), ts.tenant.Id, primary.DbName(), table)
+		if _, err := ts.ws.tmc.ExecuteFetchAsDba(ctx, primary.Tablet, false, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+			Query:   []byte(query),
+			MaxRows: 1,
+		}); err != nil {
+			ts.Logger().Errorf("%s: Error deleting the rows of tenant %s from table %s: %v", primary.String(), ts.tenant.Id, table, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// removeTenantSourceRows deletes the rows of the tenant from the source
+// shards.
+func (ts *trafficSwitcher) removeTenantSourceRows(ctx context.Context) error {
+	return ts.ForAllSources(func(source *MigrationSource) error {
+		return ts.deleteTenantRows(ctx, source.GetPrimary())
+	})
+}
+
+// removeTenantTargetRows stops the workflow streams, so that they do not copy
+// the rows of the tenant again, and deletes the rows of the tenant from the
+// target shards.
+func (ts *trafficSwitcher) removeTenantTargetRows(ctx context.Context) error {
+	if err := ts.ForAllUIDs(func(target *MigrationTarget, uid int32) error {
+		_, err := ts.TabletManagerClient().VReplicationExec(ctx, target.GetPrimary().Tablet, binlogplayer.StopVReplication(uid, "stopped to delete the rows of the tenant"))
+		return err
+	}); err != nil {
+		return err
+	}
+	return ts.ForAllTargets(func(target *MigrationTarget) error {
+		return ts.deleteTenantRows(ctx, target.GetPrimary())
+	})
+}
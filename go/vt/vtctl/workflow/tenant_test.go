@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestBuildTenant(t *testing.T) {
+	sourceVSchema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash":   {Type: "hash"},
+			"xxhash": {Type: "xxhash"},
+			"md5":    {Type: "binary_md5"},
+			"lookup": {Type: "lookup_unique", Params: map[string]string{"table": "lkp", "from": "c1", "to": "keyspace_id"}},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+			"t2": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "customer_id", Name: "hash"}}},
+			"t3": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "xxhash"}}},
+			"t4": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "c1", Name: "lookup"}}},
+			"t5": {ColumnVindexes: []*vschemapb.ColumnVindex{{Columns: []string{"c1", "c2"}, Name: "hash"}}},
+			"t6": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "md5"}},
+				Columns:        []*vschemapb.Column{{Name: "id", Type: querypb.Type_INT64}},
+			},
+			"ref": {Type: "reference"},
+		},
+	}
+	sourceKSSchema, err := vindexes.BuildKeyspaceSchema(sourceVSchema, "sourceks")
+	require.NoError(t, err)
+
+	tenant, err := buildTenant(context.Background(), sourceKSSchema, []string{"t1", "t2"}, "1")
+	require.NoError(t, err)
+	ksid, err := hex.DecodeString("166b40b44aba4bd6")
+	require.NoError(t, err)
+	utils.MustMatch(t, &binlogdatapb.VReplicationTenant{
+		Id:         "1",
+		KeyspaceId: ksid,
+		Columns:    map[string]string{"t1": "id", "t2": "customer_id"},
+	}, tenant)
+
+	filter, err := addTenantFilter("select * from t2 where in_keyrange('-80')", sourceKSSchema, tenant, "t2")
+	require.NoError(t, err)
+	assert.Equal(t, "select * from t2 where in_keyrange('-80') and customer_id = 1", filter)
+
+	// The tenant id is typed from the tenant column, not from its text.
+	xxhashTenant, err := buildTenant(context.Background(), sourceKSSchema, []string{"t3"}, "007")
+	require.NoError(t, err)
+	xxhashTenant7, err := buildTenant(context.Background(), sourceKSSchema, []string{"t3"}, "7")
+	require.NoError(t, err)
+	assert.NotEqual(t, xxhashTenant7.KeyspaceId, xxhashTenant.KeyspaceId)
+	filter, err = addTenantFilter("select * from t3", sourceKSSchema, xxhashTenant, "t3")
+	require.NoError(t, err)
+	assert.Equal(t, "select * from t3 where id = '007'", filter)
+
+	md5Tenant, err := buildTenant(context.Background(), sourceKSSchema, []string{"t6"}, "007")
+	require.NoError(t, err)
+	md5Tenant7, err := buildTenant(context.Background(), sourceKSSchema, []string{"t6"}, "7")
+	require.NoError(t, err)
+	assert.Equal(t, md5Tenant7.KeyspaceId, md5Tenant.KeyspaceId)
+	filter, err = addTenantFilter("select * from t6", sourceKSSchema, md5Tenant, "t6")
+	require.NoError(t, err)
+	assert.Equal(t, "select * from t6 where id = 7", filter)
+
+	testcases := []struct {
+		tables []string
+		id     string
+		err    string
+	}{{
+		tables: []string{"t1", "t3"},
+		id:     "1",
+		err:    "the tables must have the same primary vindex to move a single tenant: table t3 uses xxhash instead of hash",
+	}, {
+		tables: []string{"t4"},
+		id:     "1",
+		err:    "the primary vindex lookup of table t4 is not supported for tenant migrations",
+	}, {
+		tables: []string{"t5"},
+		id:     "1",
+		err:    "the primary vindex of table t5 must have a single column to be used as the tenant column",
+	}, {
+		tables: []string{"ref"},
+		id:     "1",
+		err:    "table ref has no primary vindex in the sourceks keyspace",
+	}, {
+		tables: []string{"t1"},
+		id:     "acme",
+		err:    "tenant id acme is not a valid UINT64 value for the tenant column of table t1",
+	}}
+	for _, tc := range testcases {
+		_, err := buildTenant(context.Background(), sourceKSSchema, tc.tables, tc.id)
+		assert.ErrorContains(t, err, tc.err)
+	}
+
+	unsharded, err := vindexes.BuildKeyspaceSchema(&vschemapb.Keyspace{}, "unsharded")
+	require.NoError(t, err)
+	_, err = buildTenant(context.Background(), unsharded, []string{"t1"}, "1")
+	assert.EqualError(t, err, "the source keyspace unsharded must be sharded to move a single tenant")
+}
+
+func TestSwitchTenantRoutingRules(t *testing.T) {
+	tenant := &binlogdatapb.VReplicationTenant{Id: "1", KeyspaceId: []byte{0x16}}
+	other := &vschemapb.TenantRoutingRule{FromKeyspace: "ks1", ToKeyspace: "ks3", KeyspaceId: []byte{0x80}, TenantId: "2"}
+	rule := func(from, to string) *vschemapb.TenantRoutingRule {
+		return &vschemapb.TenantRoutingRule{FromKeyspace: from, ToKeyspace: to, KeyspaceId: []byte{0x16}, TenantId: "1"}
+	}
+
+	// Switch the tenant from ks1 to ks2.
+	rules := switchTenantRoutingRules([]*vschemapb.TenantRoutingRule{other}, tenant, "ks1", "ks2")
+	utils.MustMatch(t, []*vschemapb.TenantRoutingRule{other, rule("ks1", "ks2")}, rules)
+	assert.True(t, isTenantSwitched(rules, tenant, "ks2"))
+
+	// Move it on from ks2 to ks3: the queries sent to ks1 follow it.
+	rules = switchTenantRoutingRules(rules, tenant, "ks2", "ks3")
+	utils.MustMatch(t, []*vschemapb.TenantRoutingRule{other, rule("ks1", "ks3"), rule("ks2", "ks3")}, rules)
+	assert.False(t, isTenantSwitched(rules, tenant, "ks2"))
+
+	// Reverse the traffic from ks3 to ks2.
+	rules = switchTenantRoutingRules(rules, tenant, "ks3", "ks2")
+	utils.MustMatch(t, []*vschemapb.TenantRoutingRule{other, rule("ks1", "ks2"), rule("ks3", "ks2")}, rules)
+	assert.True(t, isTenantSwitched(rules, tenant, "ks2"))
+	assert.False(t, isTenantSwitched(rules, tenant, "ks3"))
+}
+
+func TestMoveTablesTenant(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1",
+			SourceExpression: "select * from t1",
+		}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"-80", "80-"}, []string{"0"})
+	defer env.close()
+	env.tmc.schema["sourceks.t1"].TableDefinitions[0].Columns = []string{"id", "val"}
+	env.tmc.schema["targetks.t1"].TableDefinitions[0].Columns = []string{"id", "val"}
+
+	req := &vtctldatapb.MoveTablesCreateRequest{
+		Workflow:       ms.Workflow,
+		SourceKeyspace: ms.SourceKeyspace,
+		TargetKeyspace: ms.TargetKeyspace,
+		IncludeTables:  []string{"t1"},
+		TenantId:       "1",
+		DryRun:         true,
+	}
+	_, err := env.ws.MoveTablesCreate(ctx, req)
+	assert.EqualError(t, err, "the source keyspace sourceks must be sharded to move a single tenant")
+
+	err = env.topoServ.SaveVSchema(ctx, ms.SourceKeyspace, &vschemapb.Keyspace{
+		Sharded:  true,
+		Vindexes: map[string]*vschemapb.Vindex{"hash": {Type: "hash"}},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	env.tmc.expectVRQuery(200, mzSelectFrozenQuery, &sqltypes.Result{})
+	res, err := env.ws.MoveTablesCreate(ctx, req)
+	require.NoError(t, err)
+	want := &vtctldatapb.WorkflowCreateDryRunReport{
+		Tables: []*vtctldatapb.WorkflowCreateDryRunReport_TableReport{{
+			Name:             "t1",
+			SourceExpression: "select * from t1 where id = 1",
+			ExistsOnTarget:   true,
+		}},
+		Streams: []*vtctldatapb.WorkflowCreateDryRunReport_StreamReport{{
+			TargetShard:  "0",
+			SourceShards: []string{"-80", "80-"},
+		}},
+	}
+	utils.MustMatch(t, want, res.DryRunReport)
+	env.tmc.verifyQueries(t)
+
+	// A sharded target keyspace must shard the tables of the tenant the same way.
+	err = env.topoServ.SaveVSchema(ctx, ms.TargetKeyspace, &vschemapb.Keyspace{
+		Sharded:  true,
+		Vindexes: map[string]*vschemapb.Vindex{"xxhash": {Type: "xxhash"}},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "xxhash"}}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = env.ws.MoveTablesCreate(ctx, req)
+	assert.EqualError(t, err, "the tables must have the same primary vindexes in the sourceks and targetks keyspaces to move a single tenant")
+
+	state := &State{TenantID: "1"}
+	assert.Equal(t, "Reads Not Switched. Writes Not Switched", state.String())
+	state.WritesSwitched = true
+	assert.Equal(t, "All Reads Switched. Writes Switched", state.String())
+}
@@ -233,6 +233,7 @@ type trafficSwitcher struct {
 	targetTimeZone   string
 	workflowType     binlogdatapb.VReplicationWorkflowType
 	workflowSubType  binlogdatapb.VReplicationWorkflowSubType
+	tenant           *binlogdatapb.VReplicationTenant // set for multi-tenant MoveTables workflows
 }
 
 func (ts *trafficSwitcher) TopoServer() *topo.Server                          { return ts.ws.ts }
@@ -398,6 +399,11 @@ func (ts *trafficSwitcher) addParticipatingTablesToKeyspace(ctx context.Context,
 }
 
 func (ts *trafficSwitcher) deleteRoutingRules(ctx context.Context) error {
+	if ts.tenant != nil {
+		// No routing rules are created for the tables of a tenant, and the
+		// tenant routing rule is kept once the tenant is moved.
+		return nil
+	}
 	rules, err := topotools.GetRoutingRules(ctx, ts.TopoServer())
 	if err != nil {
 		return err
@@ -488,6 +494,9 @@ func (ts *trafficSwitcher) dropParticipatingTablesFromKeyspace(ctx context.Conte
 }
 
 func (ts *trafficSwitcher) removeSourceTables(ctx context.Context, removalType TableRemovalType) error {
+	if ts.tenant != nil {
+		return ts.removeTenantSourceRows(ctx)
+	}
 	err := ts.ForAllSources(func(source *MigrationSource) error {
 		for _, tableName := range ts.Tables() {
 			query := fmt.Sprintf("drop table %s.%s",
@@ -701,6 +710,9 @@ func (ts *trafficSwitcher) changeRouting(ctx context.Context) error {
 }
 
 func (ts *trafficSwitcher) changeWriteRoute(ctx context.Context) error {
+	if ts.tenant != nil {
+		return ts.changeTenantRouting(ctx)
+	}
 	if ts.isPartialMigration {
 		srr, err := topotools.GetShardRoutingRules(ctx, ts.TopoServer())
 		if err != nil {
@@ -831,13 +843,16 @@ func (ts *trafficSwitcher) createReverseVReplication(ctx context.Context) error
 		bls := target.Sources[uid]
 		source := ts.Sources()[bls.Shard]
 		reverseBls := &binlogdatapb.BinlogSource{
-			Keyspace:       ts.TargetKeyspaceName(),
-			Shard:          target.GetShard().ShardName(),
-			TabletType:     bls.TabletType,
-			Filter:         &binlogdatapb.Filter{},
-			OnDdl:          bls.OnDdl,
-			SourceTimeZone: bls.TargetTimeZone,
-			TargetTimeZone: bls.SourceTimeZone,
+			Keyspace:          ts.TargetKeyspaceName(),
+			Shard:             target.GetShard().ShardName(),
+			TabletType:        bls.TabletType,
+			Filter:            &binlogdatapb.Filter{},
+			OnDdl:             bls.OnDdl,
+			SourceTimeZone:    bls.TargetTimeZone,
+			TargetTimeZone:    bls.SourceTimeZone,
+			ThrottlerSettings: bls.ThrottlerSettings,
+			DetectConflicts:   true,
+			Tenant:            bls.Tenant,
 		}
 
 		for _, rule := range bls.Filter.Rules {
@@ -876,6 +891,12 @@ func (ts *trafficSwitcher) createReverseVReplication(ctx context.Context) error
 					}
 				}
 				filter = fmt.Sprintf("select * from %s%s", sqlescape.EscapeID(rule.Match), inKeyrange)
+				if ts.tenant != nil {
+					var err error
+					if filter, err = addTenantFilter(filter, ts.sourceKSSchema, ts.tenant, rule.Match); err != nil {
+						return err
+					}
+				}
 			}
 			reverseBls.Filter.Rules = append(reverseBls.Filter.Rules, &binlogdatapb.Rule{
 				Match:  rule.Match,
@@ -940,7 +961,9 @@ func (ts *trafficSwitcher) waitForCatchup(ctx context.Context, filteredReplicati
 
 func (ts *trafficSwitcher) stopSourceWrites(ctx context.Context) error {
 	var err error
-	if ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
+	if ts.tenant != nil {
+		err = ts.changeTenantSourceWrites(ctx, disallowWrites)
+	} else if ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
 		err = ts.changeTableSourceWrites(ctx, disallowWrites)
 	} else {
 		err = ts.changeShardsAccess(ctx, ts.SourceKeyspaceName(), ts.SourceShards(), disallowWrites)
@@ -989,7 +1012,9 @@ func (ts *trafficSwitcher) changeTableSourceWrites(ctx context.Context, access a
 
 func (ts *trafficSwitcher) cancelMigration(ctx context.Context, sm *StreamMigrator) {
 	var err error
-	if ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
+	if ts.tenant != nil {
+		err = ts.changeTenantSourceWrites(ctx, allowWrites)
+	} else if ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
 		err = ts.changeTableSourceWrites(ctx, allowWrites)
 	} else {
 		err = ts.changeShardsAccess(ctx, ts.SourceKeyspaceName(), ts.SourceShards(), allowWrites)
@@ -1059,6 +1084,9 @@ func (ts *trafficSwitcher) dropSourceReverseVReplicationStreams(ctx context.Cont
 }
 
 func (ts *trafficSwitcher) removeTargetTables(ctx context.Context) error {
+	if ts.tenant != nil {
+		return ts.removeTenantTargetRows(ctx)
+	}
 	log.Flush()
 	err := ts.ForAllTargets(func(target *MigrationTarget) error {
 		log.Infof("ForAllTargets: %+v", target)
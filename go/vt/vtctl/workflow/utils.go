@@ -261,10 +261,12 @@ func forAllShards(shards []*topo.ShardInfo, f func(*topo.ShardInfo) error) error
 }
 
 func matchColInSelect(col sqlparser.IdentifierCI, sel *sqlparser.Select) (*sqlparser.ColName, error) {
+	star := false
 	for _, selExpr := range sel.SelectExprs {
 		switch selExpr := selExpr.(type) {
 		case *sqlparser.StarExpr:
-			return &sqlparser.ColName{Name: col}, nil
+			// The expressions that follow the '*' can transform the column.
+			star = true
 		case *sqlparser.AliasedExpr:
 			match := selExpr.As
 			if match.IsEmpty() {
@@ -286,6 +288,9 @@ func matchColInSelect(col sqlparser.IdentifierCI, sel *sqlparser.Select) (*sqlpa
 			return nil, fmt.Errorf("unsupported select expression: %v", sqlparser.String(selExpr))
 		}
 	}
+	if star {
+		return &sqlparser.ColName{Name: col}, nil
+	}
 	return nil, fmt.Errorf("could not find vindex column %v", sqlparser.String(col))
 }
 
@@ -309,6 +314,50 @@ func shouldInclude(table string, excludes []string) bool {
 	return true
 }
 
+// parseColumnTransforms parses the column transformations of a workflow,
+// which are keyed by <table>.<column>, and returns the transformations of
+// each table as aliased expressions, ordered by column.
+func parseColumnTransforms(transforms map[string]string) (map[string]sqlparser.SelectExprs, error) {
+	keys := make([]string, 0, len(transforms))
+	for key := range transforms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tableTransforms := make(map[string]sqlparser.SelectExprs)
+	for _, key := range keys {
+		table, column, ok := strings.Cut(key, ".")
+		if !ok || table == "" || column == "" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid column transform %q, expected <table>.<column>", key)
+		}
+		expr, err := sqlparser.ParseExpr(transforms[key])
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid expression for the column transform of %s", key)
+		}
+		tableTransforms[table] = append(tableTransforms[table], &sqlparser.AliasedExpr{
+			Expr: expr,
+			As:   sqlparser.NewIdentifierCI(column),
+		})
+	}
+	return tableTransforms, nil
+}
+
+// buildColumnTransformQuery returns the filter query that streams the rows
+// of a table with its columns transformed, e.g.
+// "select *, sha2(email, 256) as email from t". The filter of a Reshard
+// stream additionally restricts the rows to the target key range.
+func buildColumnTransformQuery(table string, transforms sqlparser.SelectExprs, keyRange string) string {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("select *")
+	for _, transform := range transforms {
+		buf.Myprintf(", %v", transform)
+	}
+	buf.Myprintf(" from %v", sqlparser.NewIdentifierCS(table))
+	if keyRange != "" {
+		buf.Myprintf(" where in_keyrange(%v)", sqlparser.NewStrLiteral(keyRange))
+	}
+	return buf.String()
+}
+
 // getMigrationID produces a reproducible hash based on the input parameters.
 func getMigrationID(targetKeyspace string, shardTablets []string) (int64, error) {
 	sort.Strings(shardTablets)
@@ -20,8 +20,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/servenv"
@@ -143,3 +145,30 @@ func initAPI(hc discovery.HealthCheck) {
 		return nil, fmt.Errorf("cannot find health for: %s", itemPath)
 	})
 }
+
+// initConnectionsAPI serves the MySQL connections of vtgate, with the
+// connection attributes sent by their clients, at /api/connections/, and
+// a single connection at /api/connections/<id>.
+func initConnectionsAPI(vh *vtgateHandler) {
+	handleCollection("connections", func(r *http.Request) (any, error) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			return nil, err
+		}
+		processes := vh.ProcessList()
+
+		itemPath := getItemPath(r.URL.Path)
+		if itemPath == "" {
+			return processes, nil
+		}
+		id, err := strconv.ParseUint(itemPath, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection id: %q", itemPath)
+		}
+		for _, process := range processes {
+			if process.ID == uint32(id) {
+				return process, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown connection id: %d", id)
+	})
+}
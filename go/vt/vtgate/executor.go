@@ -40,6 +40,8 @@ import (
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -73,6 +75,9 @@ var (
 
 	queriesProcessedByTable = stats.NewCountersWithMultiLabels("QueriesProcessedByTable", "Queries processed at vtgate by plan type, keyspace and table", []string{"Plan", "Keyspace", "Table"})
 	queriesRoutedByTable    = stats.NewCountersWithMultiLabels("QueriesRoutedByTable", "Queries routed from vtgate to vttablet by plan type, keyspace and table", []string{"Plan", "Keyspace", "Table"})
+
+	queryTimingsByTable       = stats.NewMultiTimings("VtgateQueryTimingsByTable", "Query timings at vtgate by plan type, keyspace and table, for up to --query-metrics-max-tables tables", []string{"Plan", "Keyspace", "Table"})
+	queryTimingsByFingerprint = stats.NewMultiTimings("VtgateQueryTimingsByFingerprint", "Query timings at vtgate by query fingerprint, for up to --query-metrics-max-fingerprints fingerprints", []string{"Fingerprint"})
 )
 
 const (
@@ -122,6 +127,16 @@ type Executor struct {
 
 	warmingReadsPercent int
 	warmingReadsChannel chan bool
+
+	// tableLimiter and fingerprintLimiter cap the cardinality of the query
+	// timings by table and by fingerprint.
+	tableLimiter       *stats.LabelLimiter
+	fingerprintLimiter *stats.LabelLimiter
+
+	// userUsage tracks the resources used by each MySQL user, if enabled.
+	userUsage *userUsageTracker
+	// slowQueryLog logs the queries slower than their threshold, if enabled.
+	slowQueryLog *slowQueryLog
 }
 
 var executorOnce sync.Once
@@ -129,6 +144,7 @@ var executorOnce sync.Once
 const pathQueryPlans = "/debug/query_plans"
 const pathScatterStats = "/debug/scatter_stats"
 const pathVSchema = "/debug/vschema"
+const pathQueryFingerprints = "/debug/query_fingerprints"
 
 type PlanCacheKey = theine.HashKey256
 type PlanCache = theine.Store[PlanCacheKey, *engine.Plan]
@@ -168,6 +184,9 @@ func NewExecutor(
 		plans:               plans,
 		warmingReadsPercent: warmingReadsPercent,
 		warmingReadsChannel: make(chan bool, warmingReadsConcurrency),
+		tableLimiter:        stats.NewLabelLimiter(queryMetricsMaxTables),
+		fingerprintLimiter:  stats.NewLabelLimiter(queryMetricsMaxFingerprints),
+		userUsage:           newUserUsageTracker(userMetricsMaxUsers, userUsageReportInterval, userUsageReportFile),
 	}
 
 	vschemaacl.Init()
@@ -202,6 +221,7 @@ func NewExecutor(
 		servenv.HTTPHandle(pathQueryPlans, e)
 		servenv.HTTPHandle(pathScatterStats, e)
 		servenv.HTTPHandle(pathVSchema, e)
+		servenv.HTTPHandle(pathQueryFingerprints, e)
 	})
 	return e
 }
@@ -214,6 +234,9 @@ func (e *Executor) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars)
+	logStats.QueryAttributes = safeSession.GetOptions().GetQueryAttributes()
+	logStats.ConnectionAttributes = callinfo.MysqlConnectionAttributes(ctx)
+	e.slowQueryLog.prepare(logStats)
 	stmtType, result, err := e.execute(ctx, mysqlCtx, safeSession, sql, bindVars, logStats)
 	logStats.Error = err
 	if result == nil {
@@ -221,17 +244,24 @@ func (e *Executor) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	} else {
 		saveSessionStats(safeSession, stmtType, result.RowsAffected, result.InsertID, len(result.Rows), err)
 	}
-	if result != nil && len(result.Rows) > warnMemoryRows {
+	warnRows := clustersettings.VTGateWarnMemoryRows.GetOr(warnMemoryRows)
+	if result != nil && len(result.Rows) > warnRows {
 		warnings.Add("ResultsExceeded", 1)
 		piiSafeSQL, err := sqlparser.RedactSQLQuery(sql)
 		if err != nil {
 			piiSafeSQL = logStats.StmtType
 		}
-		log.Warningf("%q exceeds warning threshold of max memory rows: %v. Actual memory rows: %v", piiSafeSQL, warnMemoryRows, len(result.Rows))
+		log.Warningf("%q exceeds warning threshold of max memory rows: %v. Actual memory rows: %v", piiSafeSQL, warnRows, len(result.Rows))
 	}
 
 	logStats.SaveEndTime()
 	e.queryLogger.Send(logStats)
+	rowsReturned := 0
+	if result != nil {
+		rowsReturned = len(result.Rows)
+	}
+	e.userUsage.record(logStats, rowsReturned)
+	e.slowQueryLog.log(logStats, safeSession.GetOptions().GetWorkload().String(), rowsReturned)
 	err = vterrors.TruncateError(err, truncateErrorLen)
 	return result, err
 }
@@ -273,6 +303,9 @@ func (e *Executor) StreamExecute(
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars)
+	logStats.QueryAttributes = safeSession.GetOptions().GetQueryAttributes()
+	logStats.ConnectionAttributes = callinfo.MysqlConnectionAttributes(ctx)
+	e.slowQueryLog.prepare(logStats)
 	srr := &streaminResultReceiver{callback: callback}
 	var err error
 
@@ -347,6 +380,7 @@ func (e *Executor) StreamExecute(
 		logStats.ActiveKeyspace = vc.keyspace
 
 		e.updateQueryCounts(plan.Instructions.RouteType(), plan.Instructions.GetKeyspaceName(), plan.Instructions.GetTableName(), int64(logStats.ShardQueries))
+		e.updateQueryTimings(plan, logStats)
 
 		return err
 	}
@@ -355,17 +389,20 @@ func (e *Executor) StreamExecute(
 
 	logStats.Error = err
 	saveSessionStats(safeSession, srr.stmtType, srr.rowsAffected, srr.insertID, srr.rowsReturned, err)
-	if srr.rowsReturned > warnMemoryRows {
+	warnRows := clustersettings.VTGateWarnMemoryRows.GetOr(warnMemoryRows)
+	if srr.rowsReturned > warnRows {
 		warnings.Add("ResultsExceeded", 1)
 		piiSafeSQL, err := sqlparser.RedactSQLQuery(sql)
 		if err != nil {
 			piiSafeSQL = logStats.StmtType
 		}
-		log.Warningf("%q exceeds warning threshold of max memory rows: %v. Actual memory rows: %v", piiSafeSQL, warnMemoryRows, srr.rowsReturned)
+		log.Warningf("%q exceeds warning threshold of max memory rows: %v. Actual memory rows: %v", piiSafeSQL, warnRows, srr.rowsReturned)
 	}
 
 	logStats.SaveEndTime()
 	e.queryLogger.Send(logStats)
+	e.userUsage.record(logStats, srr.rowsReturned)
+	e.slowQueryLog.log(logStats, safeSession.GetOptions().GetWorkload().String(), srr.rowsReturned)
 	return vterrors.TruncateError(err, truncateErrorLen)
 
 }
@@ -676,6 +713,68 @@ func (e *Executor) handleKill(ctx context.Context, mysqlCtx vtgateservice.MySQLC
 	return &sqltypes.Result{}, nil
 }
 
+// isShowProcesslist returns true if the statement is SHOW [FULL] PROCESSLIST.
+func isShowProcesslist(stmt sqlparser.Statement) bool {
+	show, ok := stmt.(*sqlparser.Show)
+	if !ok {
+		return false
+	}
+	other, ok := show.Internal.(*sqlparser.ShowOther)
+	return ok && strings.EqualFold(other.Command, "processlist")
+}
+
+// handleShowProcesslist lists the MySQL connections of vtgate, as SHOW FULL PROCESSLIST
+// does in MySQL, along with the connection attributes sent by their clients.
+func (e *Executor) handleShowProcesslist(mysqlCtx vtgateservice.MySQLConnection, logStats *logstats.LogStats) (*sqltypes.Result, error) {
+	execStart := time.Now()
+	logStats.PlanTime = execStart.Sub(logStats.StartTime)
+	e.updateQueryCounts("Show", "", "", 0)
+	defer func() {
+		logStats.ExecuteTime = time.Since(execStart)
+	}()
+
+	fields := buildVarCharFields("Id", "User", "Host", "db", "Command", "Time", "State", "Info", "Connection_attributes")
+	fields[0].Type = sqltypes.Uint64
+	fields[0].Charset = uint32(collations.CollationBinaryID)
+	fields[0].Flags |= uint32(querypb.MySqlFlag_UNSIGNED_FLAG)
+	fields[5].Type = sqltypes.Int64
+	fields[5].Charset = uint32(collations.CollationBinaryID)
+	for _, i := range []int{3, 7} {
+		fields[i].Flags &^= uint32(querypb.MySqlFlag_NOT_NULL_FLAG)
+	}
+
+	result := &sqltypes.Result{Fields: fields}
+	for _, process := range mysqlCtx.ProcessList() {
+		connectionAttributes := process.ConnectionAttributes
+		if connectionAttributes == nil {
+			connectionAttributes = map[string]string{}
+		}
+		attrs, err := json.Marshal(connectionAttributes)
+		if err != nil {
+			return nil, err
+		}
+		row := []sqltypes.Value{
+			sqltypes.NewUint64(uint64(process.ID)),
+			sqltypes.NewVarChar(process.User),
+			sqltypes.NewVarChar(process.Host),
+			sqltypes.NULL,
+			sqltypes.NewVarChar(process.Command),
+			sqltypes.NewInt64(int64(time.Since(process.Since).Seconds())),
+			sqltypes.NewVarChar(""),
+			sqltypes.NULL,
+			sqltypes.NewVarChar(string(attrs)),
+		}
+		if process.DB != "" {
+			row[3] = sqltypes.NewVarChar(process.DB)
+		}
+		if process.Info != "" {
+			row[7] = sqltypes.NewVarChar(process.Info)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
 // CloseSession releases the current connection, which rollbacks open transactions and closes reserved connections.
 // It is called then the MySQL servers closes the connection to its client.
 func (e *Executor) CloseSession(ctx context.Context, safeSession *SafeSession) error {
@@ -1176,6 +1275,19 @@ func (e *Executor) debugCacheEntries() (items map[string]*engine.Plan) {
 	return
 }
 
+// debugQueryFingerprints returns the queries of the plan cache with their
+// own timings in VtgateQueryTimingsByFingerprint, by fingerprint.
+func (e *Executor) debugQueryFingerprints() (items map[string]string) {
+	items = make(map[string]string)
+	e.ForEachPlan(func(plan *engine.Plan) bool {
+		if fingerprint := sqlparser.QueryFingerprint(plan.Original); e.fingerprintLimiter.Tracked(fingerprint) {
+			items[fingerprint] = plan.Original
+		}
+		return true
+	})
+	return
+}
+
 // ServeHTTP shows the current plans in the query cache.
 func (e *Executor) ServeHTTP(response http.ResponseWriter, request *http.Request) {
 	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
@@ -1190,6 +1302,8 @@ func (e *Executor) ServeHTTP(response http.ResponseWriter, request *http.Request
 		returnAsJSON(response, e.VSchema())
 	case pathScatterStats:
 		e.WriteScatterStats(response)
+	case pathQueryFingerprints:
+		returnAsJSON(response, e.debugQueryFingerprints())
 	default:
 		response.WriteHeader(http.StatusNotFound)
 	}
@@ -1231,6 +1345,24 @@ func (e *Executor) updateQueryCounts(planType, keyspace, tableName string, shard
 	}
 }
 
+// updateQueryTimings records the timings of a query by table and by
+// fingerprint, if enabled.
+func (e *Executor) updateQueryTimings(plan *engine.Plan, logStats *logstats.LogStats) {
+	if queryMetricsMaxTables > 0 {
+		if tableName := plan.Instructions.GetTableName(); tableName != "" {
+			keyspace := plan.Instructions.GetKeyspaceName()
+			if e.tableLimiter.Limit(keyspace+"."+tableName) == stats.OverflowLabel {
+				keyspace, tableName = stats.OverflowLabel, stats.OverflowLabel
+			}
+			queryTimingsByTable.Record([]string{plan.Instructions.RouteType(), keyspace, tableName}, logStats.StartTime)
+		}
+	}
+	if queryMetricsMaxFingerprints > 0 {
+		fingerprint := e.fingerprintLimiter.Limit(sqlparser.QueryFingerprint(plan.Original))
+		queryTimingsByFingerprint.Record([]string{fingerprint}, logStats.StartTime)
+	}
+}
+
 // VSchemaStats returns the loaded vschema stats.
 func (e *Executor) VSchemaStats() *VSchemaStats {
 	e.mu.Lock()
@@ -1538,4 +1670,6 @@ func (e *Executor) Close() {
 	}
 	topo.Close()
 	e.plans.Close()
+	e.userUsage.close()
+	e.slowQueryLog.close()
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
@@ -2913,9 +2914,49 @@ func TestExecutorKillStmt(t *testing.T) {
 	}
 }
 
+func TestExecutorShowProcesslist(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	mysqlCtx := &fakeMysqlConnection{Processes: []*vtgateservice.MySQLProcess{{
+		ProcessInfo: mysql.ProcessInfo{
+			User:    "user1",
+			DB:      "TestExecutor",
+			Command: mysql.ProcessCommandQuery,
+			Since:   time.Now().Add(-5 * time.Second),
+			Info:    "select 1 from dual",
+		},
+		ID:                   1,
+		Host:                 "127.0.0.1:3306",
+		ConnectionAttributes: map[string]string{"program_name": "app"},
+	}, {
+		ProcessInfo: mysql.ProcessInfo{
+			User:    "user2",
+			Command: mysql.ProcessCommandSleep,
+			Since:   time.Now(),
+		},
+		ID:   2,
+		Host: "127.0.0.1:3307",
+	}}}
+
+	for _, query := range []string{"show processlist", "show full processlist"} {
+		t.Run(query, func(t *testing.T) {
+			qr, err := executor.Execute(context.Background(), mysqlCtx, "TestExecutorShowProcesslist", NewAutocommitSession(&vtgatepb.Session{}), query, nil)
+			require.NoError(t, err)
+			require.Len(t, qr.Fields, 9)
+			assert.Equal(t, "Connection_attributes", qr.Fields[8].Name)
+			assert.Equal(t, `[[UINT64(1) VARCHAR("user1") VARCHAR("127.0.0.1:3306") VARCHAR("TestExecutor") VARCHAR("Query") INT64(5) VARCHAR("") VARCHAR("select 1 from dual") VARCHAR("{\"program_name\":\"app\"}")] `+
+				`[UINT64(2) VARCHAR("user2") VARCHAR("127.0.0.1:3307") NULL VARCHAR("Sleep") INT64(0) VARCHAR("") NULL VARCHAR("{}")]]`, fmt.Sprintf("%v", qr.Rows))
+		})
+	}
+}
+
 type fakeMysqlConnection struct {
 	ErrMsg string
 	Log    []string
+	// Files are the chunks of the local files sent by the client, by name.
+	Files map[string][]string
+	// Processes are the connections listed by ProcessList.
+	Processes []*vtgateservice.MySQLProcess
 }
 
 func (f *fakeMysqlConnection) KillQuery(connID uint32) error {
@@ -2934,6 +2975,27 @@ func (f *fakeMysqlConnection) KillConnection(ctx context.Context, connID uint32)
 	return nil
 }
 
+func (f *fakeMysqlConnection) ReadLocalInfile(fileName string, callback func([]byte) error) error {
+	if f.ErrMsg != "" {
+		return errors.New(f.ErrMsg)
+	}
+	f.Log = append(f.Log, fmt.Sprintf("read local infile: %s", fileName))
+	chunks, ok := f.Files[fileName]
+	if !ok {
+		return fmt.Errorf("file not found: %s", fileName)
+	}
+	for _, chunk := range chunks {
+		if err := callback([]byte(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeMysqlConnection) ProcessList() []*vtgateservice.MySQLProcess {
+	return f.Processes
+}
+
 var _ vtgateservice.MySQLConnection = (*fakeMysqlConnection)(nil)
 
 func exec(executor *Executor, session *SafeSession, sql string) (*sqltypes.Result, error) {
@@ -2943,3 +3005,62 @@ func exec(executor *Executor, session *SafeSession, sql string) (*sqltypes.Resul
 func makeComments(text string) sqlparser.MarginComments {
 	return sqlparser.MarginComments{Trailing: text}
 }
+
+func TestExecutorQueryTimings(t *testing.T) {
+	defer func(maxTables, maxFingerprints int) {
+		queryMetricsMaxTables, queryMetricsMaxFingerprints = maxTables, maxFingerprints
+	}(queryMetricsMaxTables, queryMetricsMaxFingerprints)
+	queryMetricsMaxTables, queryMetricsMaxFingerprints = 1, 1
+	queryTimingsByTable.Reset()
+	queryTimingsByFingerprint.Reset()
+
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	executor.normalize = true
+	session := &vtgatepb.Session{TargetString: "@primary"}
+	for _, query := range []string{
+		"select id from user where id = 1",
+		"select id from user where id = 2",
+		"select id from music where id = 1",
+	} {
+		_, err := executorExec(ctx, executor, session, query, nil)
+		require.NoError(t, err)
+	}
+
+	byTable := make(map[string]int64)
+	for labels, histogram := range queryTimingsByTable.Histograms() {
+		byTable[labels] = histogram.Count()
+	}
+	assert.Equal(t, map[string]int64{
+		"EqualUnique.TestExecutor.`user`": 2,
+		"VindexLookup.other.other":        1,
+	}, byTable)
+
+	// The queries of the user table only differ by their values.
+	require.Len(t, executor.fingerprintLimiter.Values(), 1)
+	fingerprint := executor.fingerprintLimiter.Values()[0]
+	byFingerprint := make(map[string]int64)
+	for labels, histogram := range queryTimingsByFingerprint.Histograms() {
+		byFingerprint[labels] = histogram.Count()
+	}
+	assert.Equal(t, map[string]int64{fingerprint: 2, "other": 1}, byFingerprint)
+	assert.Equal(t, map[string]string{fingerprint: "select id from `user` where id = :id /* INT64 */"}, executor.debugQueryFingerprints())
+}
+
+func TestExecutorUserUsage(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	executor.userUsage = newUserUsageTracker(10, 0, "")
+	ctx = callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("executor_usage_user"))
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	_, err := executorExec(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	_, err = executorStream(ctx, executor, "select id from music_user_map where id = 1")
+	require.NoError(t, err)
+	_, err = executorExec(ctx, executor, session, "select id from unknown_table", nil)
+	require.Error(t, err)
+
+	assert.EqualValues(t, 3, userQueries.Counts()["executor_usage_user"])
+	assert.EqualValues(t, 1, userErrors.Counts()["executor_usage_user"])
+	assert.EqualValues(t, 2, userRowsReturned.Counts()["executor_usage_user"])
+	assert.EqualValues(t, 2, userShardQueries.Counts()["executor_usage_user"])
+}
@@ -43,6 +43,8 @@ var (
 	ca   string
 	crl  string
 	name string
+
+	resultCompression []string
 )
 
 func init() {
@@ -50,9 +52,11 @@ func init() {
 
 	for _, cmd := range []string{
 		"vtbench",
+		"vtcdc",
 		"vtclient",
 		"vtcombo",
 		"vtctl",
+		"vttablet",
 		"vttestserver",
 	} {
 		servenv.OnParseFor(cmd, registerFlags)
@@ -65,6 +69,7 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&ca, "vtgate_grpc_ca", "", "the server ca to use to validate servers when connecting")
 	fs.StringVar(&crl, "vtgate_grpc_crl", "", "the server crl to use to validate server certificates when connecting")
 	fs.StringVar(&name, "vtgate_grpc_server_name", "", "the server name to use to validate server certificate")
+	fs.StringSliceVar(&resultCompression, "vtgate_grpc_result_compression", resultCompression, "comma-separated list of gRPC compressors (snappy, zstd) accepted for query results sent by vtgate. vtgate picks one of them if it is also allowed by its --grpc-result-compression flag.")
 }
 
 type vtgateConn struct {
@@ -85,6 +90,11 @@ func Dial(opts ...grpc.DialOption) vtgateconn.DialerFunc {
 		}
 
 		opts = append(opts, opt)
+		compressionOpts, err := grpcclient.ResultCompressionDialOptions(resultCompression)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, compressionOpts...)
 
 		cc, err := grpcclient.DialContext(ctx, address, grpcclient.FailFast(false), opts...)
 		if err != nil {
@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/grpccommon"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -62,6 +63,8 @@ func registerFlags(fs *pflag.FlagSet) {
 func init() {
 	servenv.OnParseFor("vtgate", registerFlags)
 	servenv.OnParseFor("vtcombo", registerFlags)
+	servenv.OnParseFor("vtgate", grpccommon.RegisterResultCompressionFlags)
+	servenv.OnParseFor("vtcombo", grpccommon.RegisterResultCompressionFlags)
 }
 
 // VTGate is the public structure that is exported via gRPC
@@ -146,8 +149,10 @@ func (vtg *VTGate) Execute(ctx context.Context, request *vtgatepb.ExecuteRequest
 		session = &vtgatepb.Session{Autocommit: true}
 	}
 	session, result, err := vtg.server.Execute(ctx, nil, session, request.Query.Sql, request.Query.BindVariables)
+	qr := sqltypes.ResultToProto3(result)
+	grpccommon.MaybeCompressResult(ctx, qr.SizeVT())
 	return &vtgatepb.ExecuteResponse{
-		Result:  sqltypes.ResultToProto3(result),
+		Result:  qr,
 		Session: session,
 		Error:   vterrors.ToVTRPC(err),
 	}, nil
@@ -169,8 +174,14 @@ func (vtg *VTGate) ExecuteBatch(ctx context.Context, request *vtgatepb.ExecuteBa
 		session = &vtgatepb.Session{Autocommit: true}
 	}
 	session, results, err := vtg.server.ExecuteBatch(ctx, session, sqlQueries, bindVars)
+	qrs := sqltypes.QueryResponsesToProto3(results)
+	size := 0
+	for _, qr := range qrs {
+		size += qr.SizeVT()
+	}
+	grpccommon.MaybeCompressResult(ctx, size)
 	return &vtgatepb.ExecuteBatchResponse{
-		Results: sqltypes.QueryResponsesToProto3(results),
+		Results: qrs,
 		Session: session,
 		Error:   vterrors.ToVTRPC(err),
 	}, nil
@@ -187,6 +198,7 @@ func (vtg *VTGate) StreamExecute(request *vtgatepb.StreamExecuteRequest, stream
 		session = &vtgatepb.Session{Autocommit: true}
 	}
 
+	grpccommon.MaybeCompressStream(ctx)
 	session, vtgErr := vtg.server.StreamExecute(ctx, nil, session, request.Query.Sql, request.Query.BindVariables, func(value *sqltypes.Result) error {
 		// Send is not safe to call concurrently, but vtgate
 		// guarantees that it's not.
@@ -0,0 +1,392 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// handleLoadData executes a LOAD DATA LOCAL INFILE statement: it reads the file
+// from the client, splits it into rows according to the FIELDS and LINES clauses
+// of the statement, and inserts the rows with INSERT statements of
+// --load-data-batch-size rows, which are routed by the vindexes of the table like
+// any other. Unless the session is already in a transaction, the rows are
+// inserted in one, so that the file is loaded entirely or not at all.
+func (e *Executor) handleLoadData(ctx context.Context, mysqlCtx vtgateservice.MySQLConnection, safeSession *SafeSession, load *sqlparser.Load, logStats *logstats.LogStats) (*sqltypes.Result, error) {
+	execStart := time.Now()
+	logStats.PlanTime = execStart.Sub(logStats.StartTime)
+	e.updateQueryCounts("LoadData", "", "", 0)
+	defer func() {
+		logStats.ExecuteTime = time.Since(execStart)
+	}()
+
+	if mysqlCtx == nil {
+		return nil, vterrors.VT12001("LOAD DATA LOCAL INFILE works with access through mysql protocol")
+	}
+	parser, err := newLoadDataParser(load)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sqltypes.Result{}
+	batch := newLoadDataBatch(load)
+	insertBatch := func() error {
+		if batch.empty() {
+			return nil
+		}
+		query, bindVars := batch.insert()
+		qr, err := e.Execute(ctx, mysqlCtx, "LoadData", safeSession, query, bindVars)
+		if err != nil {
+			return err
+		}
+		result.RowsAffected += qr.RowsAffected
+		batch.reset()
+		return nil
+	}
+	addRow := func(row []sqltypes.Value) error {
+		if err := batch.add(row, parser.line); err != nil {
+			return err
+		}
+		if batch.len() >= loadDataBatchSize {
+			return insertBatch()
+		}
+		return nil
+	}
+
+	err = e.insideTransaction(ctx, safeSession, logStats, func() error {
+		err := mysqlCtx.ReadLocalInfile(load.FileName, func(chunk []byte) error {
+			return parser.write(chunk, false, addRow)
+		})
+		if err != nil {
+			return err
+		}
+		if err := parser.write(nil, true, addRow); err != nil {
+			return err
+		}
+		return insertBatch()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadDataBatch builds the INSERT statements of the rows of a LOAD DATA statement.
+type loadDataBatch struct {
+	load     *sqlparser.Load
+	rows     sqlparser.Values
+	bindVars map[string]*querypb.BindVariable
+}
+
+func newLoadDataBatch(load *sqlparser.Load) *loadDataBatch {
+	return &loadDataBatch{
+		load:     load,
+		bindVars: make(map[string]*querypb.BindVariable),
+	}
+}
+
+// add adds a row of the file to the batch. When the statement has a column
+// list, the row must have a value for each column, as in the strict SQL mode
+// of MySQL.
+func (b *loadDataBatch) add(row []sqltypes.Value, line int) error {
+	if columns := b.load.Columns; columns != nil {
+		switch {
+		case len(row) < len(columns):
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueCountOnRow, "Row %d doesn't contain data for all columns", line)
+		case len(row) > len(columns):
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueCountOnRow, "Row %d was truncated; it contained more data than there were input columns", line)
+		}
+	}
+	tuple := make(sqlparser.ValTuple, 0, len(row))
+	for _, value := range row {
+		if value.IsNull() {
+			tuple = append(tuple, &sqlparser.NullVal{})
+			continue
+		}
+		name := "v" + strconv.Itoa(len(b.bindVars)+1)
+		b.bindVars[name] = sqltypes.ValueBindVariable(value)
+		tuple = append(tuple, sqlparser.NewArgument(name))
+	}
+	b.rows = append(b.rows, tuple)
+	return nil
+}
+
+func (b *loadDataBatch) len() int {
+	return len(b.rows)
+}
+
+func (b *loadDataBatch) empty() bool {
+	return len(b.rows) == 0
+}
+
+// insert returns the INSERT statement of the rows of the batch.
+func (b *loadDataBatch) insert() (string, map[string]*querypb.BindVariable) {
+	ins := &sqlparser.Insert{
+		Action:  b.load.Action,
+		Ignore:  b.load.Ignore,
+		Table:   sqlparser.NewAliasedTableExpr(b.load.Table, ""),
+		Columns: b.load.Columns,
+		Rows:    b.rows,
+	}
+	return sqlparser.String(ins), b.bindVars
+}
+
+func (b *loadDataBatch) reset() {
+	b.rows = nil
+	b.bindVars = make(map[string]*querypb.BindVariable)
+}
+
+// loadDataParser splits the content of the file of a LOAD DATA statement into
+// rows, according to the FIELDS and LINES clauses of the statement. The file is
+// written to the parser in chunks, as the client sends it.
+type loadDataParser struct {
+	fieldsTerminatedBy []byte
+	fieldsEnclosedBy   byte
+	fieldsEscapedBy    byte
+	linesStartingBy    []byte
+	linesTerminatedBy  []byte
+	ignoreLines        int
+
+	// buf holds the start of the row not entirely written yet.
+	buf []byte
+	// line is the number of the last row read.
+	line int
+}
+
+func newLoadDataParser(load *sqlparser.Load) (*loadDataParser, error) {
+	if load.Charset.Name != "" {
+		return nil, vterrors.VT12001("LOAD DATA with CHARACTER SET")
+	}
+	p := &loadDataParser{
+		fieldsTerminatedBy: []byte("\t"),
+		fieldsEscapedBy:    '\\',
+		linesTerminatedBy:  []byte("\n"),
+	}
+	if opts := load.Options; opts != nil {
+		if opts.FieldsTerminatedBy != nil {
+			p.fieldsTerminatedBy = []byte(opts.FieldsTerminatedBy.Val)
+		}
+		if opts.FieldsEnclosedBy != nil {
+			enclosedBy, err := loadDataSingleChar("ENCLOSED BY", opts.FieldsEnclosedBy.Val)
+			if err != nil {
+				return nil, err
+			}
+			p.fieldsEnclosedBy = enclosedBy
+		}
+		if opts.FieldsEscapedBy != nil {
+			escapedBy, err := loadDataSingleChar("ESCAPED BY", opts.FieldsEscapedBy.Val)
+			if err != nil {
+				return nil, err
+			}
+			p.fieldsEscapedBy = escapedBy
+		}
+		if opts.LinesStartingBy != nil {
+			p.linesStartingBy = []byte(opts.LinesStartingBy.Val)
+		}
+		if opts.LinesTerminatedBy != nil {
+			p.linesTerminatedBy = []byte(opts.LinesTerminatedBy.Val)
+		}
+	}
+	if len(p.fieldsTerminatedBy) == 0 || len(p.linesTerminatedBy) == 0 {
+		return nil, vterrors.VT12001("LOAD DATA with an empty FIELDS TERMINATED BY or LINES TERMINATED BY")
+	}
+	if load.IgnoreLines != nil {
+		ignoreLines, err := strconv.Atoi(load.IgnoreLines.Val)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid number of lines to ignore: %s", load.IgnoreLines.Val)
+		}
+		p.ignoreLines = ignoreLines
+	}
+	return p, nil
+}
+
+// loadDataSingleChar returns the character of the ENCLOSED BY and ESCAPED BY
+// options, which can only be empty or a single character.
+func loadDataSingleChar(option, value string) (byte, error) {
+	switch len(value) {
+	case 0:
+		return 0, nil
+	case 1:
+		return value[0], nil
+	}
+	return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Field separator argument is not what is expected; check the manual: %s must be a single character", option)
+}
+
+// write parses the rows completed by the chunk, and calls the callback with
+// each of them. atEOF is set for the last call, after the whole file is written,
+// for the last row which has no line terminator.
+func (p *loadDataParser) write(chunk []byte, atEOF bool, callback func([]sqltypes.Value) error) error {
+	p.buf = append(p.buf, chunk...)
+	pos := 0
+	for pos < len(p.buf) {
+		n, row := p.parseRow(p.buf[pos:], atEOF)
+		if n == 0 {
+			break
+		}
+		pos += n
+		if row == nil {
+			continue
+		}
+		p.line++
+		if p.line <= p.ignoreLines {
+			continue
+		}
+		if err := callback(row); err != nil {
+			return err
+		}
+	}
+	p.buf = append(p.buf[:0], p.buf[pos:]...)
+	return nil
+}
+
+// parseRow parses the row at the start of data. It returns the length of the
+// row, or 0 if its end is not written yet. The row is nil if the data has no
+// line starting with LINES STARTING BY.
+func (p *loadDataParser) parseRow(data []byte, atEOF bool) (int, []sqltypes.Value) {
+	pos := 0
+	if len(p.linesStartingBy) > 0 {
+		// Anything before the prefix is skipped, as well as the lines without it.
+		i := bytes.Index(data, p.linesStartingBy)
+		if i < 0 {
+			if atEOF {
+				return len(data), nil
+			}
+			return 0, nil
+		}
+		pos = i + len(p.linesStartingBy)
+	}
+
+	var row []sqltypes.Value
+	for {
+		value, next, ok := p.parseField(data, pos, atEOF)
+		if !ok {
+			return 0, nil
+		}
+		row = append(row, value)
+		pos = next
+		switch {
+		case bytes.HasPrefix(data[pos:], p.fieldsTerminatedBy):
+			pos += len(p.fieldsTerminatedBy)
+		case bytes.HasPrefix(data[pos:], p.linesTerminatedBy):
+			return pos + len(p.linesTerminatedBy), row
+		default:
+			// The last row of the file.
+			return len(data), row
+		}
+	}
+}
+
+// parseField parses the field starting at pos. It returns its value and the
+// position of the terminator following it, or false if the end of the field
+// is not written yet.
+func (p *loadDataParser) parseField(data []byte, pos int, atEOF bool) (sqltypes.Value, int, bool) {
+	start := pos
+	enclosed := p.fieldsEnclosedBy != 0 && pos < len(data) && data[pos] == p.fieldsEnclosedBy
+	if enclosed {
+		pos++
+	}
+
+	var field []byte
+	for pos < len(data) {
+		c := data[pos]
+		switch {
+		case p.fieldsEscapedBy != 0 && c == p.fieldsEscapedBy:
+			if pos+1 == len(data) {
+				if !atEOF {
+					return sqltypes.Value{}, 0, false
+				}
+				field = append(field, c)
+				pos++
+				continue
+			}
+			field = append(field, loadDataUnescape(data[pos+1]))
+			pos += 2
+		case enclosed && c == p.fieldsEnclosedBy:
+			rest := data[pos+1:]
+			switch {
+			case len(rest) > 0 && rest[0] == p.fieldsEnclosedBy:
+				// A doubled enclosing character stands for itself.
+				field = append(field, c)
+				pos += 2
+			case bytes.HasPrefix(rest, p.fieldsTerminatedBy), bytes.HasPrefix(rest, p.linesTerminatedBy), len(rest) == 0 && atEOF:
+				return sqltypes.NewVarChar(string(field)), pos + 1, true
+			case !atEOF && (bytes.HasPrefix(p.fieldsTerminatedBy, rest) || bytes.HasPrefix(p.linesTerminatedBy, rest)):
+				// The terminator may not be entirely written yet.
+				return sqltypes.Value{}, 0, false
+			default:
+				field = append(field, c)
+				pos++
+			}
+		case !enclosed && (bytes.HasPrefix(data[pos:], p.fieldsTerminatedBy) || bytes.HasPrefix(data[pos:], p.linesTerminatedBy)):
+			return p.unenclosedValue(data[start:pos], field), pos, true
+		default:
+			field = append(field, c)
+			pos++
+		}
+	}
+	if !atEOF {
+		return sqltypes.Value{}, 0, false
+	}
+	if enclosed {
+		return sqltypes.NewVarChar(string(field)), pos, true
+	}
+	return p.unenclosedValue(data[start:pos], field), pos, true
+}
+
+// unenclosedValue returns the value of a field not enclosed by FIELDS ENCLOSED BY,
+// which is NULL if written as \N, or as NULL when the fields can be enclosed.
+func (p *loadDataParser) unenclosedValue(raw, field []byte) sqltypes.Value {
+	if p.fieldsEscapedBy != 0 && len(raw) == 2 && raw[0] == p.fieldsEscapedBy && raw[1] == 'N' {
+		return sqltypes.NULL
+	}
+	if p.fieldsEnclosedBy != 0 && string(raw) == "NULL" {
+		return sqltypes.NULL
+	}
+	return sqltypes.NewVarChar(string(field))
+}
+
+// loadDataUnescape returns the character written as the given one following
+// the escape character.
+func loadDataUnescape(c byte) byte {
+	switch c {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 26
+	}
+	return c
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestLoadDataParser(t *testing.T) {
+	testcases := []struct {
+		name    string
+		options string
+		chunks  []string
+		want    [][]string
+	}{{
+		name:   "default options",
+		chunks: []string{"1\ta\n2\tb\n"},
+		want:   [][]string{{"1", "a"}, {"2", "b"}},
+	}, {
+		name:   "last line without terminator",
+		chunks: []string{"1\ta\n2\tb"},
+		want:   [][]string{{"1", "a"}, {"2", "b"}},
+	}, {
+		name:   "rows split across chunks",
+		chunks: []string{"1\tab", "c\n2", "\t", "d\n"},
+		want:   [][]string{{"1", "abc"}, {"2", "d"}},
+	}, {
+		name:   "escapes and NULL",
+		chunks: []string{"1\\ta\t\\N\n2\tb\\\\\\n\tc\n"},
+		want:   [][]string{{"1\ta", "<null>"}, {"2", "b\\\n", "c"}},
+	}, {
+		name:    "csv",
+		options: ` fields terminated by ',' optionally enclosed by '"' lines terminated by '\r\n'`,
+		chunks:  []string{"1,\"a,b\"\r\n2,\"c\"", "\"d\"\r", "\n3,NULL\r\n"},
+		want:    [][]string{{"1", "a,b"}, {"2", "c\"d"}, {"3", "<null>"}},
+	}, {
+		name:    "lines starting by",
+		options: ` lines starting by 'xxx'`,
+		chunks:  []string{"xxx1\ta\nfoo\nbarxxx2\tb\n"},
+		want:    [][]string{{"1", "a"}, {"2", "b"}},
+	}, {
+		name:    "ignore lines",
+		options: ` ignore 1 lines`,
+		chunks:  []string{"id\tname\n1\ta\n"},
+		want:    [][]string{{"1", "a"}},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			stmt, err := sqlparser.Parse("load data local infile 'data.txt' into table t" + tc.options)
+			require.NoError(t, err)
+			parser, err := newLoadDataParser(stmt.(*sqlparser.Load))
+			require.NoError(t, err)
+
+			var got [][]string
+			callback := func(row []sqltypes.Value) error {
+				var fields []string
+				for _, value := range row {
+					if value.IsNull() {
+						fields = append(fields, "<null>")
+						continue
+					}
+					fields = append(fields, value.ToString())
+				}
+				got = append(got, fields)
+				return nil
+			}
+			for _, chunk := range tc.chunks {
+				require.NoError(t, parser.write([]byte(chunk), false, callback))
+			}
+			require.NoError(t, parser.write(nil, true, callback))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLoadDataParserOptionErrors(t *testing.T) {
+	testcases := []struct {
+		options string
+		errStr  string
+	}{{
+		options: ` character set latin1`,
+		errStr:  "VT12001: unsupported: LOAD DATA with CHARACTER SET",
+	}, {
+		options: ` fields terminated by ''`,
+		errStr:  "VT12001: unsupported: LOAD DATA with an empty FIELDS TERMINATED BY or LINES TERMINATED BY",
+	}, {
+		options: ` fields enclosed by '""'`,
+		errStr:  "ENCLOSED BY must be a single character",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.options, func(t *testing.T) {
+			stmt, err := sqlparser.Parse("load data local infile 'data.txt' into table t" + tc.options)
+			require.NoError(t, err)
+			_, err = newLoadDataParser(stmt.(*sqlparser.Load))
+			require.ErrorContains(t, err, tc.errStr)
+		})
+	}
+}
+
+func TestExecutorLoadData(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	defer func(batchSize int) {
+		loadDataBatchSize = batchSize
+	}(loadDataBatchSize)
+	loadDataBatchSize = 2
+
+	mysqlCtx := &fakeMysqlConnection{Files: map[string][]string{
+		"data.csv": {"1,a\n3,", "b\n1,\\N\n"},
+	}}
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	qr, err := executor.Execute(ctx, mysqlCtx, "TestExecute", session, "load data local infile 'data.csv' into table user_extra fields terminated by ',' (user_id, col)", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, qr.RowsAffected)
+	assert.Equal(t, []string{"read local infile: data.csv"}, mysqlCtx.Log)
+	assert.False(t, session.InTransaction())
+
+	assertQueries(t, sbc1, []*querypb.BoundQuery{{
+		Sql: "insert into user_extra(user_id, col) values (:_user_id_0, :v2)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_0": sqltypes.StringBindVariable("1"),
+			"v2":         sqltypes.StringBindVariable("a"),
+		},
+	}, {
+		Sql: "insert into user_extra(user_id, col) values (:_user_id_0, null)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_0": sqltypes.StringBindVariable("1"),
+		},
+	}})
+	assertQueries(t, sbc2, []*querypb.BoundQuery{{
+		Sql: "insert into user_extra(user_id, col) values (:_user_id_1, :v4)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_1": sqltypes.StringBindVariable("3"),
+			"v4":         sqltypes.StringBindVariable("b"),
+		},
+	}})
+}
+
+func TestExecutorLoadDataErrors(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	_, err := executor.Execute(ctx, nil, "TestExecute", session, "load data local infile 'data.csv' into table user_extra", nil)
+	require.EqualError(t, err, "VT12001: unsupported: LOAD DATA LOCAL INFILE works with access through mysql protocol")
+
+	mysqlCtx := &fakeMysqlConnection{Files: map[string][]string{
+		"data.csv": {"1\ta\n3\n"},
+	}}
+	_, err = executor.Execute(ctx, mysqlCtx, "TestExecute", session, "load data local infile 'data.csv' into table user_extra (user_id, col)", nil)
+	require.EqualError(t, err, "Row 2 doesn't contain data for all columns")
+	assert.False(t, session.InTransaction())
+	assertQueries(t, sbc1, nil)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/google/safehtml"
@@ -32,6 +33,7 @@ import (
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
@@ -57,6 +59,18 @@ type LogStats struct {
 	SessionUUID    string
 	CachedPlan     bool
 	ActiveKeyspace string // ActiveKeyspace is the selected keyspace `use ks`
+	// QueryAttributes are the query attributes sent by the MySQL client with the query.
+	QueryAttributes map[string]string
+	// ConnectionAttributes are the connection attributes, such as program_name,
+	// sent by the MySQL client when it connected.
+	ConnectionAttributes map[string]string
+	// Plan is the plan the query was executed with, if it was planned.
+	Plan *engine.Plan
+
+	// shardQueries counts the queries sent to each shard, by keyspace/shard,
+	// if the breakdown was enabled with EnableShardBreakdown.
+	shardQueriesMu sync.Mutex
+	shardQueries   map[string]uint64
 }
 
 // NewLogStats constructs a new LogStats with supplied Method and ctx
@@ -72,6 +86,38 @@ func NewLogStats(ctx context.Context, methodName, sql, sessionUUID string, bindV
 	}
 }
 
+// EnableShardBreakdown makes LogStats count the queries sent to each shard.
+// It must be called before the query is executed.
+func (stats *LogStats) EnableShardBreakdown() {
+	stats.shardQueries = make(map[string]uint64)
+}
+
+// AddShardQuery counts a query sent to a shard, if the breakdown by shard
+// is enabled.
+func (stats *LogStats) AddShardQuery(keyspace, shard string) {
+	if stats.shardQueries == nil {
+		return
+	}
+	stats.shardQueriesMu.Lock()
+	defer stats.shardQueriesMu.Unlock()
+	stats.shardQueries[keyspace+"/"+shard]++
+}
+
+// ShardBreakdown returns the number of queries sent to each shard, by
+// keyspace/shard, or nil if the breakdown by shard is not enabled.
+func (stats *LogStats) ShardBreakdown() map[string]uint64 {
+	if stats.shardQueries == nil {
+		return nil
+	}
+	stats.shardQueriesMu.Lock()
+	defer stats.shardQueriesMu.Unlock()
+	breakdown := make(map[string]uint64, len(stats.shardQueries))
+	for shard, count := range stats.shardQueries {
+		breakdown[shard] = count
+	}
+	return breakdown
+}
+
 // SaveEndTime sets the end time of this request to now
 func (stats *LogStats) SaveEndTime() {
 	stats.EndTime = time.Now()
@@ -152,9 +198,9 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 	var fmtString string
 	switch streamlog.GetQueryLogFormat() {
 	case streamlog.QueryLogFormatText:
-		fmtString = "%v\t%v\t%v\t'%v'\t'%v'\t%v\t%v\t%.6f\t%.6f\t%.6f\t%.6f\t%v\t%q\t%v\t%v\t%v\t%q\t%q\t%q\t%v\t%v\t%q\n"
+		fmtString = "%v\t%v\t%v\t'%v'\t'%v'\t%v\t%v\t%.6f\t%.6f\t%.6f\t%.6f\t%v\t%q\t%v\t%v\t%v\t%q\t%q\t%q\t%v\t%v\t%q\t%v\t%v\n"
 	case streamlog.QueryLogFormatJSON:
-		fmtString = "{\"Method\": %q, \"RemoteAddr\": %q, \"Username\": %q, \"ImmediateCaller\": %q, \"Effective Caller\": %q, \"Start\": \"%v\", \"End\": \"%v\", \"TotalTime\": %.6f, \"PlanTime\": %v, \"ExecuteTime\": %v, \"CommitTime\": %v, \"StmtType\": %q, \"SQL\": %q, \"BindVars\": %v, \"ShardQueries\": %v, \"RowsAffected\": %v, \"Error\": %q, \"TabletType\": %q, \"SessionUUID\": %q, \"Cached Plan\": %v, \"TablesUsed\": %v, \"ActiveKeyspace\": %q}\n"
+		fmtString = "{\"Method\": %q, \"RemoteAddr\": %q, \"Username\": %q, \"ImmediateCaller\": %q, \"Effective Caller\": %q, \"Start\": \"%v\", \"End\": \"%v\", \"TotalTime\": %.6f, \"PlanTime\": %v, \"ExecuteTime\": %v, \"CommitTime\": %v, \"StmtType\": %q, \"SQL\": %q, \"BindVars\": %v, \"ShardQueries\": %v, \"RowsAffected\": %v, \"Error\": %q, \"TabletType\": %q, \"SessionUUID\": %q, \"Cached Plan\": %v, \"TablesUsed\": %v, \"ActiveKeyspace\": %q, \"QueryAttributes\": %v, \"ConnectionAttributes\": %v}\n"
 	}
 
 	tables := stats.TablesUsed
@@ -165,6 +211,25 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 	if marshalErr != nil {
 		return marshalErr
 	}
+	formattedQueryAttributes := []byte("\"[REDACTED]\"")
+	if !streamlog.GetRedactDebugUIQueries() {
+		queryAttributes := stats.QueryAttributes
+		if queryAttributes == nil {
+			queryAttributes = map[string]string{}
+		}
+		formattedQueryAttributes, marshalErr = json.Marshal(queryAttributes)
+		if marshalErr != nil {
+			return marshalErr
+		}
+	}
+	connectionAttributes := stats.ConnectionAttributes
+	if connectionAttributes == nil {
+		connectionAttributes = map[string]string{}
+	}
+	formattedConnectionAttributes, marshalErr := json.Marshal(connectionAttributes)
+	if marshalErr != nil {
+		return marshalErr
+	}
 	_, err := fmt.Fprintf(
 		w,
 		fmtString,
@@ -190,6 +255,8 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 		stats.CachedPlan,
 		string(tablesUsed),
 		stats.ActiveKeyspace,
+		string(formattedQueryAttributes),
+		string(formattedConnectionAttributes),
 	)
 
 	return err
@@ -212,6 +212,15 @@ func (e *Executor) handleTransactions(
 		return qr, err
 	case sqlparser.StmtKill:
 		return e.handleKill(ctx, mysqlCtx, stmt, logStats)
+	case sqlparser.StmtShow:
+		// The processes are the MySQL connections of vtgate, not the ones of the tablets.
+		if isShowProcesslist(stmt) && mysqlCtx != nil {
+			return e.handleShowProcesslist(mysqlCtx, logStats)
+		}
+	case sqlparser.StmtOther:
+		if load, ok := stmt.(*sqlparser.Load); ok && load.Local {
+			return e.handleLoadData(ctx, mysqlCtx, safeSession, load, logStats)
+		}
 	}
 	return nil, nil
 }
@@ -350,6 +359,7 @@ func (e *Executor) logExecutionEnd(logStats *logstats.LogStats, execStart time.T
 	logStats.ExecuteTime = time.Since(execStart)
 
 	e.updateQueryCounts(plan.Instructions.RouteType(), plan.Instructions.GetKeyspaceName(), plan.Instructions.GetTableName(), int64(logStats.ShardQueries))
+	e.updateQueryTimings(plan, logStats)
 
 	var errCount uint64
 	if err != nil {
@@ -366,6 +376,7 @@ func (e *Executor) logPlanningFinished(logStats *logstats.LogStats, plan *engine
 	execStart := time.Now()
 	if plan != nil {
 		logStats.StmtType = plan.Type.String()
+		logStats.Plan = plan
 	}
 	logStats.PlanTime = execStart.Sub(logStats.StartTime)
 	return execStart
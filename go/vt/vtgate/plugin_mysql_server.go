@@ -18,11 +18,14 @@ package vtgate
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"os/signal"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -46,6 +49,7 @@ import (
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 	"vitess.io/vitess/go/vt/vttls"
 )
 
@@ -64,6 +68,12 @@ var (
 	mysqlSslCrl                       string
 	mysqlSslServerCA                  string
 	mysqlTLSMinVersion                string
+	mysqlTLSHardening                 = vttls.ServerHardening{OCSPTimeout: 5 * time.Second}
+	mysqlCachingSha2RSAKey            string
+	mysqlCompressionAlgorithms        []string
+	mysqlZlibCompressionLevel         = mysql.DefaultZlibCompressionLevel
+	mysqlLocalInfile                  bool
+	mysqlSessionTrack                 bool
 
 	mysqlKeepAlivePeriod          time.Duration
 	mysqlConnReadTimeout          time.Duration
@@ -89,7 +99,17 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&mysqlSslKey, "mysql_server_ssl_key", mysqlSslKey, "Path to ssl key for mysql server plugin SSL")
 	fs.StringVar(&mysqlSslCa, "mysql_server_ssl_ca", mysqlSslCa, "Path to ssl CA for mysql server plugin SSL. If specified, server will require and validate client certs.")
 	fs.StringVar(&mysqlSslCrl, "mysql_server_ssl_crl", mysqlSslCrl, "Path to ssl CRL for mysql server plugin SSL")
+	fs.StringVar(&mysqlCachingSha2RSAKey, "mysql_server_caching_sha2_rsa_key", mysqlCachingSha2RSAKey, "Path to the RSA private key in PEM format which clients encrypt their password with for caching_sha2_password authentication over connections without TLS. If not set, caching_sha2_password is only offered over TLS connections and Unix sockets")
+	fs.StringSliceVar(&mysqlCompressionAlgorithms, "mysql_server_compression_algorithms", mysqlCompressionAlgorithms, "Compression algorithms of the MySQL protocol offered to the clients, among zlib and zstd. The connections are not compressed if empty. With zstd, the compression level requested by the client is used")
+	fs.IntVar(&mysqlZlibCompressionLevel, "mysql_server_zlib_compression_level", mysqlZlibCompressionLevel, "Level of the zlib compression of the MySQL protocol, between 1 and 9")
+	fs.BoolVar(&mysqlLocalInfile, "mysql_server_local_infile", mysqlLocalInfile, "If set, the server allows LOAD DATA LOCAL INFILE: the clients enabling it send their local files, whose rows are inserted in batches routed by the vindexes of the table")
+	fs.BoolVar(&mysqlSessionTrack, "mysql_server_session_track", mysqlSessionTrack, "If set, the server sends the changes of the current database, of the system variables and of the transaction state in the OK packets to the clients asking for them with CLIENT_SESSION_TRACK")
 	fs.StringVar(&mysqlTLSMinVersion, "mysql_server_tls_min_version", mysqlTLSMinVersion, "Configures the minimal TLS version negotiated when SSL is enabled. Defaults to TLSv1.2. Options: TLSv1.0, TLSv1.1, TLSv1.2, TLSv1.3.")
+	fs.StringSliceVar(&mysqlTLSHardening.CipherSuites, "mysql_server_tls_cipher_suites", mysqlTLSHardening.CipherSuites, "Cipher suites allowed for TLSv1.2 and below when SSL is enabled, by their Go name, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Defaults to the secure cipher suites of Go. The cipher suites of TLSv1.3 cannot be configured.")
+	fs.StringSliceVar(&mysqlTLSHardening.SNICertificates, "mysql_server_ssl_sni_certs", mysqlTLSHardening.SNICertificates, "Additional certificates for mysql server plugin SSL, as cert:key pairs of paths in PEM format, served to the clients asking for one of their names with SNI instead of mysql_server_ssl_cert")
+	fs.BoolVar(&mysqlTLSHardening.OCSP, "mysql_server_ssl_ocsp", mysqlTLSHardening.OCSP, "If set, the client certs validated with mysql_server_ssl_ca are checked with OCSP, and rejected if revoked or if their status cannot be obtained from the responder")
+	fs.StringVar(&mysqlTLSHardening.OCSPResponder, "mysql_server_ssl_ocsp_responder", mysqlTLSHardening.OCSPResponder, "URL of the OCSP responder to check the client certs with when mysql_server_ssl_ocsp is set, instead of the responder of the certs. The certs without responder are accepted if not set")
+	fs.DurationVar(&mysqlTLSHardening.OCSPTimeout, "mysql_server_ssl_ocsp_timeout", mysqlTLSHardening.OCSPTimeout, "Timeout of the requests to the OCSP responder when mysql_server_ssl_ocsp is set")
 	fs.StringVar(&mysqlSslServerCA, "mysql_server_ssl_server_ca", mysqlSslServerCA, "path to server CA in PEM format, which will be combine with server cert, return full certificate chain to clients")
 	fs.DurationVar(&mysqlSlowConnectWarnThreshold, "mysql_slow_connect_warn_threshold", mysqlSlowConnectWarnThreshold, "Warn if it takes more than the given threshold for a mysql connection to establish")
 	fs.DurationVar(&mysqlConnReadTimeout, "mysql_server_read_timeout", mysqlConnReadTimeout, "connection read timeout")
@@ -132,6 +152,23 @@ func (vh *vtgateHandler) numConnections() int {
 }
 
 func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
+	// As in MySQL, the current database survives the reset.
+	targetString := vh.session(c).TargetString
+	vh.resetSession(c)
+	session := vh.session(c)
+	session.TargetString = targetString
+	fillInTxStatusFlags(c, session)
+}
+
+func (vh *vtgateHandler) ComChangeUser(c *mysql.Conn) {
+	vh.resetSession(c)
+	fillInTxStatusFlags(c, vh.session(c))
+}
+
+// resetSession rolls back any open transaction, releases the reserved
+// connections of the session and drops it, so that the next request
+// starts from a new session.
+func (vh *vtgateHandler) resetSession(c *mysql.Conn) {
 	ctx := context.Background()
 	session := vh.session(c)
 	if session.InTransaction {
@@ -141,6 +178,7 @@ func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	if err != nil {
 		log.Errorf("Error happened in transaction rollback: %v", err)
 	}
+	c.ClientData = nil
 }
 
 func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
@@ -238,24 +276,45 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 			vh.busyConnections.Add(-1)
 		}
 	}()
+	setQueryAttributes(c, session)
+	defer clearQueryAttributes(session)
+	defer func() {
+		c.SetProcessDB(session.TargetString)
+	}()
+	tracked := newTrackedSessionState(c, session)
 
 	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
-		session, err := vh.vtg.StreamExecute(ctx, vh, session, query, make(map[string]*querypb.BindVariable), callback)
+		session, err := vh.vtg.StreamExecute(ctx, mysqlConnection{vh, c}, session, query, make(map[string]*querypb.BindVariable), tracked.wrapCallback(c, session, callback))
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
 		fillInTxStatusFlags(c, session)
+		tracked.track(c, session)
 		return nil
 	}
-	session, result, err := vh.vtg.Execute(ctx, vh, session, query, make(map[string]*querypb.BindVariable))
+	session, result, err := vh.vtg.Execute(ctx, mysqlConnection{vh, c}, session, query, make(map[string]*querypb.BindVariable))
 
 	if err := sqlerror.NewSQLErrorFromError(err); err != nil {
 		return err
 	}
 	fillInTxStatusFlags(c, session)
+	tracked.track(c, session)
 	return callback(result)
 }
 
+// setQueryAttributes sets the query attributes sent by the client with the
+// query in the session options, so that they are seen by the executor, and
+// passed to the tablets along with the query.
+func setQueryAttributes(c *mysql.Conn, session *vtgatepb.Session) {
+	session.Options.QueryAttributes = c.QueryAttributes
+}
+
+// clearQueryAttributes clears the query attributes of the query which ran,
+// as they don't apply to the next queries of the session.
+func clearQueryAttributes(session *vtgatepb.Session) {
+	session.Options.QueryAttributes = nil
+}
+
 func fillInTxStatusFlags(c *mysql.Conn, session *vtgatepb.Session) {
 	if session.InTransaction {
 		c.StatusFlags |= mysql.ServerStatusInTrans
@@ -269,6 +328,107 @@ func fillInTxStatusFlags(c *mysql.Conn, session *vtgatepb.Session) {
 	}
 }
 
+// trackedSessionState is the part of the session state whose changes are
+// sent to the clients asking for them with CLIENT_SESSION_TRACK.
+type trackedSessionState struct {
+	targetString    string
+	inTransaction   bool
+	autocommit      bool
+	systemVariables map[string]string
+}
+
+// newTrackedSessionState returns the tracked state of the session before a
+// statement runs, or nil if the client doesn't track it.
+func newTrackedSessionState(c *mysql.Conn, session *vtgatepb.Session) *trackedSessionState {
+	if !c.SessionTrackEnabled() {
+		return nil
+	}
+	ts := &trackedSessionState{}
+	ts.update(session)
+	return ts
+}
+
+// update takes the state of the session as the state to compare with.
+func (ts *trackedSessionState) update(session *vtgatepb.Session) {
+	ts.targetString = session.TargetString
+	ts.inTransaction = session.InTransaction
+	ts.autocommit = session.Autocommit
+	ts.systemVariables = maps.Clone(session.SystemVariables)
+}
+
+// wrapCallback returns the callback of a streaming execution recording the
+// changes of the session state before a result without fields, as it is
+// written to the client as the OK packet of the statement.
+func (ts *trackedSessionState) wrapCallback(c *mysql.Conn, session *vtgatepb.Session, callback func(*sqltypes.Result) error) func(*sqltypes.Result) error {
+	if ts == nil {
+		return callback
+	}
+	return func(qr *sqltypes.Result) error {
+		if len(qr.Fields) == 0 {
+			ts.track(c, session)
+		}
+		return callback(qr)
+	}
+}
+
+// track records the changes of the session state made by the statement
+// being run, to be sent to the client in the OK packet. The changes are
+// only recorded once.
+func (ts *trackedSessionState) track(c *mysql.Conn, session *vtgatepb.Session) {
+	if ts == nil {
+		return
+	}
+	defer ts.update(session)
+	changed := false
+	if session.TargetString != ts.targetString {
+		c.TrackSchema(session.TargetString)
+		changed = true
+	}
+	if session.Autocommit != ts.autocommit {
+		value := "OFF"
+		if session.Autocommit {
+			value = "ON"
+		}
+		c.TrackSystemVariable("autocommit", value)
+		changed = true
+	}
+	names := make([]string, 0, len(session.SystemVariables))
+	for name, expr := range session.SystemVariables {
+		if previous, ok := ts.systemVariables[name]; !ok || previous != expr {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.TrackSystemVariable(name, systemVariableValue(session.SystemVariables[name]))
+		changed = true
+	}
+	if session.InTransaction != ts.inTransaction {
+		state := mysql.TransactionStateNone
+		if session.InTransaction {
+			state = mysql.TransactionStateExplicit
+		}
+		c.TrackTransactionState(state)
+		changed = true
+	}
+	if changed {
+		c.TrackStateChange()
+	}
+}
+
+// systemVariableValue returns the value of a system variable of the session,
+// from the expression it was set to, e.g. utf8mb4 for 'utf8mb4'.
+func systemVariableValue(expr string) string {
+	parsed, err := sqlparser.ParseExpr(expr)
+	if err != nil {
+		return expr
+	}
+	if lit, ok := parsed.(*sqlparser.Literal); ok {
+		return lit.Val
+	}
+	return expr
+}
+
 // ComPrepare is the handler for command prepare.
 func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string, bindVars map[string]*querypb.BindVariable) ([]*querypb.Field, error) {
 	var ctx context.Context
@@ -344,20 +504,28 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 			vh.busyConnections.Add(-1)
 		}
 	}()
+	setQueryAttributes(c, session)
+	defer clearQueryAttributes(session)
+	defer func() {
+		c.SetProcessDB(session.TargetString)
+	}()
+	tracked := newTrackedSessionState(c, session)
 
 	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
-		_, err := vh.vtg.StreamExecute(ctx, vh, session, prepare.PrepareStmt, prepare.BindVars, callback)
+		_, err := vh.vtg.StreamExecute(ctx, mysqlConnection{vh, c}, session, prepare.PrepareStmt, prepare.BindVars, tracked.wrapCallback(c, session, callback))
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
 		fillInTxStatusFlags(c, session)
+		tracked.track(c, session)
 		return nil
 	}
-	_, qr, err := vh.vtg.Execute(ctx, vh, session, prepare.PrepareStmt, prepare.BindVars)
+	_, qr, err := vh.vtg.Execute(ctx, mysqlConnection{vh, c}, session, prepare.PrepareStmt, prepare.BindVars)
 	if err != nil {
 		return sqlerror.NewSQLErrorFromError(err)
 	}
 	fillInTxStatusFlags(c, session)
+	tracked.track(c, session)
 
 	return callback(qr)
 }
@@ -412,6 +580,44 @@ func (vh *vtgateHandler) KillQuery(connectionID uint32) error {
 	return nil
 }
 
+// ProcessList returns the MySQL connections of vtgate, sorted by connection id.
+func (vh *vtgateHandler) ProcessList() []*vtgateservice.MySQLProcess {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+
+	processes := make([]*vtgateservice.MySQLProcess, 0, len(vh.connections))
+	for id, c := range vh.connections {
+		process := &vtgateservice.MySQLProcess{
+			ProcessInfo: c.ProcessInfo(),
+			ID:          id,
+			Host:        c.RemoteAddr().String(),
+		}
+		// The connection attributes are only read once the handshake,
+		// which sets them, is over.
+		if process.Command != mysql.ProcessCommandConnect {
+			process.ConnectionAttributes = c.ConnectionAttributes
+		}
+		processes = append(processes, process)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ID < processes[j].ID
+	})
+	return processes
+}
+
+// mysqlConnection is the vtgateservice.MySQLConnection of the queries run on
+// a connection: it kills queries and connections through the handler, and
+// reads the local files of LOAD DATA LOCAL INFILE from the client.
+type mysqlConnection struct {
+	*vtgateHandler
+	c *mysql.Conn
+}
+
+// ReadLocalInfile requests the content of a local file from the client.
+func (mc mysqlConnection) ReadLocalInfile(fileName string, callback func([]byte) error) error {
+	return mc.c.ReadLocalInfile(fileName, callback)
+}
+
 func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 	session, _ := c.ClientData.(*vtgatepb.Session)
 	if session == nil {
@@ -445,8 +651,19 @@ type mysqlServer struct {
 }
 
 // initTLSConfig inits tls config for the given mysql listener
-func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA string, mysqlServerRequireSecureTransport bool, mysqlMinTLSVersion uint16) error {
-	serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlMinTLSVersion)
+func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA string, mysqlServerRequireSecureTransport bool, mysqlMinTLSVersion uint16, hardening *vttls.ServerHardening) error {
+	newServerConfig := func() (*tls.Config, error) {
+		serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlMinTLSVersion)
+		if err != nil {
+			return nil, err
+		}
+		if err := hardening.Apply(serverConfig); err != nil {
+			return nil, err
+		}
+		return serverConfig, nil
+	}
+
+	serverConfig, err := newServerConfig()
 	if err != nil {
 		log.Exitf("grpcutils.TLSServerConfig failed: %v", err)
 		return err
@@ -461,7 +678,7 @@ func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSsl
 			case <-ctx.Done():
 				return
 			case <-srv.sigChan:
-				serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlMinTLSVersion)
+				serverConfig, err := newServerConfig()
 				if err != nil {
 					log.Errorf("grpcutils.TLSServerConfig failed: %v", err)
 				} else {
@@ -510,6 +727,7 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 	var err error
 	srv := &mysqlServer{}
 	srv.vtgateHandle = newVtgateHandler(vtgate)
+	initConnectionsAPI(srv.vtgateHandle)
 	if mysqlServerPort >= 0 {
 		srv.tcpListener, err = mysql.NewListener(
 			mysqlTCPVersion,
@@ -532,9 +750,29 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 				log.Exitf("mysql.NewListener failed: %v", err)
 			}
 
-			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion)
+			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion, &mysqlTLSHardening)
 		}
 		srv.tcpListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		if mysqlCachingSha2RSAKey != "" {
+			rsaKey, err := mysql.LoadCachingSha2RSAKey(mysqlCachingSha2RSAKey)
+			if err != nil {
+				log.Exitf("mysql.LoadCachingSha2RSAKey failed: %v", err)
+			}
+			srv.tcpListener.CachingSha2RSAKey.Store(rsaKey)
+		}
+		for _, algorithm := range mysqlCompressionAlgorithms {
+			level := 0
+			if algorithm == mysql.CompressionZlib {
+				level = mysqlZlibCompressionLevel
+			}
+			if err := mysql.ValidateCompression(algorithm, level); err != nil {
+				log.Exitf("invalid --mysql_server_compression_algorithms: %v", err)
+			}
+		}
+		srv.tcpListener.CompressionAlgorithms = mysqlCompressionAlgorithms
+		srv.tcpListener.ZlibCompressionLevel = mysqlZlibCompressionLevel
+		srv.tcpListener.AllowLocalInfile = mysqlLocalInfile
+		srv.tcpListener.SessionTrack = mysqlSessionTrack
 		// Check for the connection threshold
 		if mysqlSlowConnectWarnThreshold != 0 {
 			log.Infof("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold)
@@ -554,6 +792,8 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 			log.Exitf("mysql.NewListener failed: %v", err)
 			return nil
 		}
+		srv.unixListener.AllowLocalInfile = mysqlLocalInfile
+		srv.unixListener.SessionTrack = mysqlSessionTrack
 		// Listen for unix socket
 		go srv.unixListener.Accept()
 	}
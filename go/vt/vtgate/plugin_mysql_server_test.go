@@ -39,6 +39,7 @@ import (
 	"vitess.io/vitess/go/trace"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/tlstest"
+	"vitess.io/vitess/go/vt/vttls"
 )
 
 type testHandler struct {
@@ -287,7 +288,7 @@ func testInitTLSConfig(t *testing.T, serverCA bool) {
 	}
 
 	srv := &mysqlServer{tcpListener: &mysql.Listener{}}
-	if err := initTLSConfig(ctx, srv, path.Join(root, "server-cert.pem"), path.Join(root, "server-key.pem"), path.Join(root, "ca-cert.pem"), path.Join(root, "ca-crl.pem"), serverCACert, true, tls.VersionTLS12); err != nil {
+	if err := initTLSConfig(ctx, srv, path.Join(root, "server-cert.pem"), path.Join(root, "server-key.pem"), path.Join(root, "ca-cert.pem"), path.Join(root, "ca-crl.pem"), serverCACert, true, tls.VersionTLS12, &vttls.ServerHardening{}); err != nil {
 		t.Fatalf("init tls config failure due to: +%v", err)
 	}
 
@@ -342,3 +343,129 @@ func TestKillMethods(t *testing.T) {
 	require.EqualError(t, cancelCtx.Err(), "context canceled")
 	require.True(t, mysqlConn.IsMarkedForClose())
 }
+
+// TestResetConnection tests that the session is reset on COM_RESET_CONNECTION
+// and COM_CHANGE_USER, and that only the former keeps the current database.
+func TestResetConnection(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(&VTGate{executor: executor})
+
+	mysqlConn := mysql.GetTestConn()
+	session := vh.session(mysqlConn)
+	session.TargetString = KsTestUnsharded
+	session.UserDefinedVariables = map[string]*querypb.BindVariable{"foo": sqltypes.Int64BindVariable(1)}
+	session.Autocommit = false
+
+	vh.ComResetConnection(mysqlConn)
+	session = vh.session(mysqlConn)
+	assert.Equal(t, KsTestUnsharded, session.TargetString)
+	assert.Empty(t, session.UserDefinedVariables)
+	assert.True(t, session.Autocommit)
+	assert.NotZero(t, mysqlConn.StatusFlags&mysql.ServerStatusAutocommit)
+
+	session.UserDefinedVariables = map[string]*querypb.BindVariable{"foo": sqltypes.Int64BindVariable(1)}
+	vh.ComChangeUser(mysqlConn)
+	session = vh.session(mysqlConn)
+	assert.Empty(t, session.TargetString)
+	assert.Empty(t, session.UserDefinedVariables)
+}
+
+// TestSessionTrack tests that the changes of the session state are sent in the
+// OK packets to the clients asking for them.
+func TestSessionTrack(t *testing.T) {
+	for _, workload := range []querypb.ExecuteOptions_Workload{querypb.ExecuteOptions_OLTP, querypb.ExecuteOptions_OLAP} {
+		t.Run(workload.String(), func(t *testing.T) {
+			mysqlDefaultWorkload = int32(workload)
+			defer func() { mysqlDefaultWorkload = int32(querypb.ExecuteOptions_OLTP) }()
+			testSessionTrack(t)
+		})
+	}
+}
+
+func testSessionTrack(t *testing.T) {
+	vtg, _, _ := createVtgateEnv(t)
+
+	unixSocket, err := os.CreateTemp("", "mysql_vitess_test.sock")
+	require.NoError(t, err)
+	os.Remove(unixSocket.Name())
+
+	l, err := newMysqlUnixSocket(unixSocket.Name(), newTestAuthServerStatic(), newVtgateHandler(vtg))
+	require.NoError(t, err)
+	defer l.Close()
+	l.SessionTrack = true
+	go l.Accept()
+
+	c, err := mysql.Connect(context.Background(), &mysql.ConnParams{
+		UnixSocket: unixSocket.Name(),
+		Uname:      "user1",
+		Pass:       "password1",
+		Flags:      mysql.CapabilityClientSessionTrack,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	execute := func(query string) []byte {
+		require.NoError(t, c.WriteComQuery(query))
+		data, err := c.ReadPacket()
+		require.NoError(t, err)
+		require.EqualValues(t, mysql.OKPacket, data[0], "expected OK packet, got %v", data)
+		return data
+	}
+
+	data := execute("use " + KsTestUnsharded)
+	assert.Contains(t, string(data), "\x01\x0e\x0d"+KsTestUnsharded)
+
+	data = execute("set autocommit = 0")
+	assert.Contains(t, string(data), "\x0aautocommit\x03OFF")
+
+	data = execute("begin")
+	assert.Contains(t, string(data), "\x05\x09\x08"+mysql.TransactionStateExplicit)
+	assert.Contains(t, string(data), "\x02\x011")
+
+	data = execute("rollback")
+	assert.Contains(t, string(data), "\x05\x09\x08"+mysql.TransactionStateNone)
+
+	// Nothing is sent when the session doesn't change.
+	data = execute("rollback")
+	assert.NotContains(t, string(data), mysql.TransactionStateNone)
+}
+
+// TestProcessList tests that SHOW PROCESSLIST lists the MySQL connections of
+// vtgate, with what they are doing.
+func TestProcessList(t *testing.T) {
+	vtg, _, _ := createVtgateEnv(t)
+
+	unixSocket, err := os.CreateTemp("", "mysql_vitess_test.sock")
+	require.NoError(t, err)
+	os.Remove(unixSocket.Name())
+
+	vh := newVtgateHandler(vtg)
+	l, err := newMysqlUnixSocket(unixSocket.Name(), newTestAuthServerStatic(), vh)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	c, err := mysql.Connect(context.Background(), &mysql.ConnParams{
+		UnixSocket: unixSocket.Name(),
+		Uname:      "user1",
+		Pass:       "password1",
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.ExecuteFetch("use "+KsTestUnsharded, 1, false)
+	require.NoError(t, err)
+
+	qr, err := c.ExecuteFetch("show processlist", 10, true)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "user1", qr.Rows[0][1].ToString())
+	assert.Equal(t, KsTestUnsharded, qr.Rows[0][3].ToString())
+	assert.Equal(t, mysql.ProcessCommandQuery, qr.Rows[0][4].ToString())
+	assert.Equal(t, "show processlist", qr.Rows[0][7].ToString())
+
+	processes := vh.ProcessList()
+	require.Len(t, processes, 1)
+	assert.Equal(t, c.ConnectionID, processes[0].ID)
+	assert.Equal(t, "user1", processes[0].User)
+}
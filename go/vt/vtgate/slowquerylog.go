@@ -0,0 +1,321 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/ratelimiter"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+var (
+	slowQueriesLogged  = stats.NewCounter("VtgateSlowQueriesLogged", "Queries written to the slow query log")
+	slowQueriesDropped = stats.NewCounter("VtgateSlowQueriesDropped", "Slow queries not written to the slow query log because of --slow-query-log-max-records-per-second")
+)
+
+// slowQueryLogBufferSize is the number of records waiting to be written to
+// the sinks, the records being dropped when it is full.
+const slowQueryLogBufferSize = 100
+
+// SlowQueryRecord is a record of the slow query log. Unlike the query log,
+// it describes the plan of the query and the queries it sent to each shard.
+type SlowQueryRecord struct {
+	Time            time.Time
+	Method          string
+	User            string
+	EffectiveCaller string
+	Workload        string
+	SessionUUID     string
+	StmtType        string
+	SQL             string
+	ActiveKeyspace  string
+	TabletType      string
+	Threshold       time.Duration
+	TotalTime       time.Duration
+	PlanTime        time.Duration
+	ExecuteTime     time.Duration
+	CommitTime      time.Duration
+	RowsAffected    uint64
+	RowsReturned    uint64
+	ShardQueries    uint64
+	Shards          map[string]uint64
+	TablesUsed      []string
+	Plan            *engine.PrimitiveDescription `json:",omitempty"`
+	Error           string                       `json:",omitempty"`
+}
+
+// SlowQueryLogSink receives the records of the slow query log. Log is called
+// by a single goroutine.
+type SlowQueryLogSink interface {
+	Log(record *SlowQueryRecord) error
+	Close() error
+}
+
+// SlowQueryLogSinkFactory creates a sink of the slow query log.
+type SlowQueryLogSinkFactory func() (SlowQueryLogSink, error)
+
+var slowQueryLogSinkFactories = map[string]SlowQueryLogSinkFactory{
+	"log":  newLogSlowQueryLogSink,
+	"file": newFileSlowQueryLogSink,
+}
+
+// RegisterSlowQueryLogSink registers a sink of the slow query log, which can
+// then be enabled with --slow-query-log-sinks. It must be called during init().
+func RegisterSlowQueryLogSink(name string, factory SlowQueryLogSinkFactory) {
+	if _, ok := slowQueryLogSinkFactories[name]; ok {
+		log.Fatalf("slow query log sink %s is already registered", name)
+	}
+	slowQueryLogSinkFactories[name] = factory
+}
+
+// slowQueryLog writes the queries slower than their threshold to the sinks
+// of the slow query log. The threshold of a query is the one of its user
+// in --slow-query-log-threshold-by-user, or else the one of its workload in
+// --slow-query-log-threshold-by-workload, or else --slow-query-log-threshold.
+type slowQueryLog struct {
+	threshold           time.Duration
+	thresholdByUser     map[string]time.Duration
+	thresholdByWorkload map[string]time.Duration
+
+	limiter *ratelimiter.RateLimiter
+	records chan *SlowQueryRecord
+	sinks   []SlowQueryLogSink
+	done    sync.WaitGroup
+	closed  sync.Once
+}
+
+// newSlowQueryLog returns the slow query log configured by the flags, or nil
+// if no threshold is set.
+func newSlowQueryLog() (*slowQueryLog, error) {
+	thresholdByUser, err := parseSlowQueryThresholds("--slow-query-log-threshold-by-user", slowQueryLogThresholdByUser)
+	if err != nil {
+		return nil, err
+	}
+	thresholdByWorkload, err := parseSlowQueryThresholds("--slow-query-log-threshold-by-workload", slowQueryLogThresholdByWorkload)
+	if err != nil {
+		return nil, err
+	}
+	if slowQueryLogThreshold <= 0 && len(thresholdByUser) == 0 && len(thresholdByWorkload) == 0 {
+		return nil, nil
+	}
+	if slowQueryLogMaxRecordsPerSecond <= 0 {
+		return nil, fmt.Errorf("--slow-query-log-max-records-per-second must be positive")
+	}
+
+	sl := &slowQueryLog{
+		threshold:           slowQueryLogThreshold,
+		thresholdByUser:     thresholdByUser,
+		thresholdByWorkload: thresholdByWorkload,
+		limiter:             ratelimiter.NewRateLimiter(slowQueryLogMaxRecordsPerSecond, time.Second),
+		records:             make(chan *SlowQueryRecord, slowQueryLogBufferSize),
+	}
+	for _, name := range slowQueryLogSinks {
+		factory, ok := slowQueryLogSinkFactories[name]
+		if !ok {
+			sl.closeSinks()
+			return nil, fmt.Errorf("unknown slow query log sink %q in --slow-query-log-sinks", name)
+		}
+		sink, err := factory()
+		if err != nil {
+			sl.closeSinks()
+			return nil, fmt.Errorf("failed to create the %s slow query log sink: %v", name, err)
+		}
+		sl.sinks = append(sl.sinks, sink)
+	}
+
+	sl.done.Add(1)
+	go sl.run()
+	return sl, nil
+}
+
+func parseSlowQueryThresholds(flag string, values map[string]string) (map[string]time.Duration, error) {
+	thresholds := make(map[string]time.Duration, len(values))
+	for name, value := range values {
+		threshold, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold %q for %s in %s: %v", value, name, flag, err)
+		}
+		thresholds[name] = threshold
+	}
+	return thresholds, nil
+}
+
+// prepare prepares the LogStats of a query to be described in the slow query
+// log, by counting the queries it sends to each shard.
+func (sl *slowQueryLog) prepare(logStats *logstats.LogStats) {
+	if sl == nil {
+		return
+	}
+	logStats.EnableShardBreakdown()
+}
+
+// thresholdFor returns the threshold of the queries of a user and workload,
+// 0 meaning that they are not logged.
+func (sl *slowQueryLog) thresholdFor(user, workload string) time.Duration {
+	if threshold, ok := sl.thresholdByUser[user]; ok {
+		return threshold
+	}
+	if threshold, ok := sl.thresholdByWorkload[workload]; ok {
+		return threshold
+	}
+	return sl.threshold
+}
+
+// log queues the record of a query if it is slower than its threshold. The
+// records beyond --slow-query-log-max-records-per-second are dropped.
+func (sl *slowQueryLog) log(logStats *logstats.LogStats, workload string, rowsReturned int) {
+	if sl == nil {
+		return
+	}
+	user := logStats.ImmediateCaller()
+	threshold := sl.thresholdFor(user, workload)
+	if threshold <= 0 || logStats.TotalTime() < threshold {
+		return
+	}
+	if !sl.limiter.Allow() {
+		slowQueriesDropped.Add(1)
+		return
+	}
+
+	record := &SlowQueryRecord{
+		Time:            logStats.StartTime,
+		Method:          logStats.Method,
+		User:            user,
+		EffectiveCaller: logStats.EffectiveCaller(),
+		Workload:        workload,
+		SessionUUID:     logStats.SessionUUID,
+		StmtType:        logStats.StmtType,
+		SQL:             logStats.SQL,
+		ActiveKeyspace:  logStats.ActiveKeyspace,
+		TabletType:      logStats.TabletType,
+		Threshold:       threshold,
+		TotalTime:       logStats.TotalTime(),
+		PlanTime:        logStats.PlanTime,
+		ExecuteTime:     logStats.ExecuteTime,
+		CommitTime:      logStats.CommitTime,
+		RowsAffected:    logStats.RowsAffected,
+		RowsReturned:    uint64(rowsReturned),
+		ShardQueries:    logStats.ShardQueries,
+		Shards:          logStats.ShardBreakdown(),
+		TablesUsed:      logStats.TablesUsed,
+		Error:           logStats.ErrorStr(),
+	}
+	if logStats.Plan != nil && logStats.Plan.Instructions != nil {
+		description := engine.PrimitiveToPlanDescription(logStats.Plan.Instructions)
+		record.Plan = &description
+	}
+
+	select {
+	case sl.records <- record:
+		slowQueriesLogged.Add(1)
+	default:
+		slowQueriesDropped.Add(1)
+	}
+}
+
+func (sl *slowQueryLog) run() {
+	defer sl.done.Done()
+	for record := range sl.records {
+		for _, sink := range sl.sinks {
+			if err := sink.Log(record); err != nil {
+				log.Errorf("Failed to write to the slow query log: %v", err)
+			}
+		}
+	}
+}
+
+// close writes the queued records and closes the sinks.
+func (sl *slowQueryLog) close() {
+	if sl == nil {
+		return
+	}
+	sl.closed.Do(func() {
+		close(sl.records)
+		sl.done.Wait()
+		sl.closeSinks()
+	})
+}
+
+func (sl *slowQueryLog) closeSinks() {
+	for _, sink := range sl.sinks {
+		if err := sink.Close(); err != nil {
+			log.Errorf("Failed to close the slow query log: %v", err)
+		}
+	}
+}
+
+// logSlowQueryLogSink writes the records of the slow query log to the logs.
+type logSlowQueryLogSink struct{}
+
+func newLogSlowQueryLogSink() (SlowQueryLogSink, error) {
+	return logSlowQueryLogSink{}, nil
+}
+
+// Log is part of the SlowQueryLogSink interface.
+func (logSlowQueryLogSink) Log(record *SlowQueryRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	log.Warningf("Slow query: %s", data)
+	return nil
+}
+
+// Close is part of the SlowQueryLogSink interface.
+func (logSlowQueryLogSink) Close() error {
+	return nil
+}
+
+// fileSlowQueryLogSink appends the records of the slow query log to
+// --slow-query-log-file, as lines of JSON.
+type fileSlowQueryLogSink struct {
+	file *os.File
+}
+
+func newFileSlowQueryLogSink() (SlowQueryLogSink, error) {
+	if slowQueryLogFile == "" {
+		return nil, fmt.Errorf("--slow-query-log-file is required by the file sink")
+	}
+	f, err := os.OpenFile(slowQueryLogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSlowQueryLogSink{file: f}, nil
+}
+
+// Log is part of the SlowQueryLogSink interface.
+func (s *fileSlowQueryLogSink) Log(record *SlowQueryRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close is part of the SlowQueryLogSink interface.
+func (s *fileSlowQueryLogSink) Close() error {
+	return s.file.Close()
+}
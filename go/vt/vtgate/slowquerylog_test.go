@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+type fakeSlowQueryLogSink struct {
+	mu      sync.Mutex
+	records []*SlowQueryRecord
+	closed  bool
+}
+
+func (s *fakeSlowQueryLogSink) Log(record *SlowQueryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *fakeSlowQueryLogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// setSlowQueryLogFlags sets the flags of the slow query log for the duration
+// of the test, and returns the fake sink they enable.
+func setSlowQueryLogFlags(t *testing.T, threshold time.Duration, byWorkload, byUser map[string]string, maxRecordsPerSecond int) *fakeSlowQueryLogSink {
+	sink := &fakeSlowQueryLogSink{}
+	slowQueryLogSinkFactories["fake"] = func() (SlowQueryLogSink, error) {
+		return sink, nil
+	}
+	oldThreshold, oldByWorkload, oldByUser := slowQueryLogThreshold, slowQueryLogThresholdByWorkload, slowQueryLogThresholdByUser
+	oldMaxRecordsPerSecond, oldSinks := slowQueryLogMaxRecordsPerSecond, slowQueryLogSinks
+	slowQueryLogThreshold, slowQueryLogThresholdByWorkload, slowQueryLogThresholdByUser = threshold, byWorkload, byUser
+	slowQueryLogMaxRecordsPerSecond, slowQueryLogSinks = maxRecordsPerSecond, []string{"fake"}
+	t.Cleanup(func() {
+		delete(slowQueryLogSinkFactories, "fake")
+		slowQueryLogThreshold, slowQueryLogThresholdByWorkload, slowQueryLogThresholdByUser = oldThreshold, oldByWorkload, oldByUser
+		slowQueryLogMaxRecordsPerSecond, slowQueryLogSinks = oldMaxRecordsPerSecond, oldSinks
+	})
+	return sink
+}
+
+func slowQueryLogStats(user string, totalTime time.Duration) *logstats.LogStats {
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("principal", "", ""), callerid.NewImmediateCallerID(user))
+	logStats := logstats.NewLogStats(ctx, "Execute", "select 1 from dual", "uuid", nil)
+	logStats.EndTime = logStats.StartTime.Add(totalTime)
+	return logStats
+}
+
+func TestSlowQueryLogThresholds(t *testing.T) {
+	sink := setSlowQueryLogFlags(t, time.Second, map[string]string{"OLAP": "1m"}, map[string]string{"batch": "0", "debug": "1ms"}, 100)
+	sl, err := newSlowQueryLog()
+	require.NoError(t, err)
+
+	sl.log(slowQueryLogStats("app", 2*time.Second), "OLTP", 0)
+	sl.log(slowQueryLogStats("app", 500*time.Millisecond), "OLTP", 0)
+	sl.log(slowQueryLogStats("app", 2*time.Second), "OLAP", 0)
+	sl.log(slowQueryLogStats("batch", time.Hour), "OLTP", 0)
+	sl.log(slowQueryLogStats("debug", 10*time.Millisecond), "OLAP", 0)
+	sl.close()
+
+	require.Len(t, sink.records, 2)
+	assert.Equal(t, "app", sink.records[0].User)
+	assert.Equal(t, time.Second, sink.records[0].Threshold)
+	assert.Equal(t, "debug", sink.records[1].User)
+	assert.Equal(t, time.Millisecond, sink.records[1].Threshold)
+	assert.True(t, sink.closed)
+}
+
+func TestSlowQueryLogRateLimit(t *testing.T) {
+	sink := setSlowQueryLogFlags(t, time.Millisecond, nil, nil, 2)
+	sl, err := newSlowQueryLog()
+	require.NoError(t, err)
+
+	dropped := slowQueriesDropped.Get()
+	for i := 0; i < 5; i++ {
+		sl.log(slowQueryLogStats("app", time.Second), "OLTP", 0)
+	}
+	sl.close()
+
+	assert.Len(t, sink.records, 2)
+	assert.EqualValues(t, 3, slowQueriesDropped.Get()-dropped)
+}
+
+func TestSlowQueryLogFlags(t *testing.T) {
+	setSlowQueryLogFlags(t, 0, nil, nil, 10)
+	sl, err := newSlowQueryLog()
+	require.NoError(t, err)
+	assert.Nil(t, sl)
+	// The methods of a disabled slow query log are no-ops.
+	sl.prepare(slowQueryLogStats("app", time.Second))
+	sl.log(slowQueryLogStats("app", time.Second), "OLTP", 0)
+	sl.close()
+
+	slowQueryLogThresholdByWorkload = map[string]string{"OLAP": "slow"}
+	_, err = newSlowQueryLog()
+	assert.ErrorContains(t, err, "--slow-query-log-threshold-by-workload")
+
+	slowQueryLogThresholdByWorkload = nil
+	slowQueryLogThreshold = time.Second
+	slowQueryLogSinks = []string{"unknown"}
+	_, err = newSlowQueryLog()
+	assert.ErrorContains(t, err, `unknown slow query log sink "unknown"`)
+}
+
+func TestSlowQueryLogFileSink(t *testing.T) {
+	setSlowQueryLogFlags(t, time.Millisecond, nil, nil, 10)
+	slowQueryLogSinks = []string{"file"}
+	_, err := newSlowQueryLog()
+	assert.ErrorContains(t, err, "--slow-query-log-file")
+
+	oldFile := slowQueryLogFile
+	defer func() { slowQueryLogFile = oldFile }()
+	slowQueryLogFile = path.Join(t.TempDir(), "slow.json")
+	sl, err := newSlowQueryLog()
+	require.NoError(t, err)
+	logStats := slowQueryLogStats("app", time.Second)
+	logStats.Error = errors.New("failed")
+	sl.log(logStats, "OLTP", 3)
+	sl.close()
+
+	data, err := os.ReadFile(slowQueryLogFile)
+	require.NoError(t, err)
+	var record SlowQueryRecord
+	require.NoError(t, json.Unmarshal(data, &record))
+	assert.Equal(t, "app", record.User)
+	assert.Equal(t, "principal", record.EffectiveCaller)
+	assert.Equal(t, "OLTP", record.Workload)
+	assert.Equal(t, "select 1 from dual", record.SQL)
+	assert.Equal(t, time.Second, record.TotalTime)
+	assert.EqualValues(t, 3, record.RowsReturned)
+	assert.Equal(t, "failed", record.Error)
+}
+
+func TestExecutorSlowQueryLog(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	sink := setSlowQueryLogFlags(t, 0, nil, map[string]string{"executor_slow_user": "1ns"}, 100)
+	sl, err := newSlowQueryLog()
+	require.NoError(t, err)
+	executor.slowQueryLog = sl
+	ctx = callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("executor_slow_user"))
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	_, err = executorExec(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+	_, err = executorStream(ctx, executor, "select id from music_user_map where id = 1")
+	require.NoError(t, err)
+	sl.close()
+
+	require.Len(t, sink.records, 2)
+	record := sink.records[0]
+	assert.Equal(t, "TestExecute", record.Method)
+	assert.Equal(t, "SELECT", record.StmtType)
+	assert.Equal(t, "select id from `user`", record.SQL)
+	assert.EqualValues(t, 8, record.ShardQueries)
+	assert.Len(t, record.Shards, 8)
+	assert.EqualValues(t, 1, record.Shards["TestExecutor/-20"])
+	assert.Equal(t, []string{"TestExecutor.user"}, record.TablesUsed)
+	require.NotNil(t, record.Plan)
+	assert.Equal(t, "Route", record.Plan.OperatorType)
+
+	record = sink.records[1]
+	assert.Equal(t, "TestExecuteStream", record.Method)
+	assert.EqualValues(t, 1, record.ShardQueries)
+	assert.Len(t, record.Shards, 1)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+var (
+	userQueries      = stats.NewCountersWithSingleLabel("VtgateUserQueries", "Queries at vtgate by MySQL user, for up to --user-metrics-max-users users", "User")
+	userErrors       = stats.NewCountersWithSingleLabel("VtgateUserErrors", "Failed queries at vtgate by MySQL user, for up to --user-metrics-max-users users", "User")
+	userRowsReturned = stats.NewCountersWithSingleLabel("VtgateUserRowsReturned", "Rows returned by vtgate by MySQL user, for up to --user-metrics-max-users users", "User")
+	userRowsAffected = stats.NewCountersWithSingleLabel("VtgateUserRowsAffected", "Rows affected at vtgate by MySQL user, for up to --user-metrics-max-users users", "User")
+	userShardQueries = stats.NewCountersWithSingleLabel("VtgateUserShardQueries", "Queries sent by vtgate to the tablets by MySQL user, for up to --user-metrics-max-users users", "User")
+	userQueryTimesNs = stats.NewCountersWithSingleLabel("VtgateUserQueryTimesNs", "Total time of the queries at vtgate in nanoseconds by MySQL user, for up to --user-metrics-max-users users", "User")
+)
+
+// UserUsage is the resources used by the queries of a MySQL user. The number
+// of queries sent to the tablets and the query time estimate the cost of the
+// queries of the user for the cluster.
+type UserUsage struct {
+	User         string
+	Queries      int64
+	Errors       int64
+	RowsReturned int64
+	RowsAffected int64
+	ShardQueries int64
+	QueryTime    time.Duration
+}
+
+// userUsageReport is the usage of a user over a report interval, written as
+// a line of JSON.
+type userUsageReport struct {
+	Start time.Time
+	End   time.Time
+	UserUsage
+}
+
+// userUsageTracker tracks the resources used by the queries of each MySQL
+// user, for chargeback and showback in multi-tenant clusters. The totals are
+// exported as metrics, and the usage over each --user-usage-report-interval
+// is written to --user-usage-report-file, or to the logs.
+type userUsageTracker struct {
+	limiter *stats.LabelLimiter
+
+	mu     sync.Mutex
+	start  time.Time
+	usages map[string]*UserUsage
+
+	ticks  *timer.Timer
+	report io.WriteCloser
+}
+
+func newUserUsageTracker(maxUsers int, reportInterval time.Duration, reportFile string) *userUsageTracker {
+	if maxUsers <= 0 {
+		return nil
+	}
+	t := &userUsageTracker{
+		limiter: stats.NewLabelLimiter(maxUsers),
+		start:   time.Now(),
+		usages:  make(map[string]*UserUsage),
+	}
+	if reportInterval > 0 {
+		if reportFile != "" {
+			f, err := os.OpenFile(reportFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+			if err != nil {
+				log.Errorf("Failed to open --user-usage-report-file, writing the user usage reports to the logs instead: %v", err)
+			} else {
+				t.report = f
+			}
+		}
+		t.ticks = timer.NewTimer(reportInterval)
+		t.ticks.Start(t.flush)
+	}
+	return t
+}
+
+// record records the resources used by a query.
+func (t *userUsageTracker) record(logStats *logstats.LogStats, rowsReturned int) {
+	if t == nil {
+		return
+	}
+	user := t.limiter.Limit(logStats.ImmediateCaller())
+	var errCount int64
+	if logStats.Error != nil {
+		errCount = 1
+	}
+	queryTime := logStats.TotalTime()
+
+	userQueries.Add(user, 1)
+	userErrors.Add(user, errCount)
+	userRowsReturned.Add(user, int64(rowsReturned))
+	userRowsAffected.Add(user, int64(logStats.RowsAffected))
+	userShardQueries.Add(user, int64(logStats.ShardQueries))
+	userQueryTimesNs.Add(user, int64(queryTime))
+
+	if t.ticks == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	usage, ok := t.usages[user]
+	if !ok {
+		usage = &UserUsage{User: user}
+		t.usages[user] = usage
+	}
+	usage.Queries++
+	usage.Errors += errCount
+	usage.RowsReturned += int64(rowsReturned)
+	usage.RowsAffected += int64(logStats.RowsAffected)
+	usage.ShardQueries += int64(logStats.ShardQueries)
+	usage.QueryTime += queryTime
+}
+
+// flush writes the usage of the users since the previous report, sorted by
+// user, and starts a new report interval.
+func (t *userUsageTracker) flush() {
+	t.mu.Lock()
+	start, end := t.start, time.Now()
+	usages := t.usages
+	t.start = end
+	t.usages = make(map[string]*UserUsage, len(usages))
+	t.mu.Unlock()
+
+	users := make([]string, 0, len(usages))
+	for user := range usages {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	for _, user := range users {
+		line, err := json.Marshal(userUsageReport{Start: start, End: end, UserUsage: *usages[user]})
+		if err != nil {
+			log.Errorf("Failed to marshal the user usage report of %s: %v", user, err)
+			continue
+		}
+		if t.report == nil {
+			log.Infof("User usage: %s", line)
+			continue
+		}
+		if _, err := t.report.Write(append(line, '\n')); err != nil {
+			log.Errorf("Failed to write the user usage report of %s: %v", user, err)
+		}
+	}
+}
+
+// close stops the reports, writing the usage since the last one.
+func (t *userUsageTracker) close() {
+	if t == nil || t.ticks == nil {
+		return
+	}
+	t.ticks.Stop()
+	t.flush()
+	if t.report != nil {
+		t.report.Close()
+	}
+}
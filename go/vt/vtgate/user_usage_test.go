@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+func TestUserUsageTracker(t *testing.T) {
+	reportFile := path.Join(t.TempDir(), "user_usage.json")
+	tracker := newUserUsageTracker(2, time.Hour, reportFile)
+
+	query := func(user string, rowsReturned int, shardQueries uint64, err error) {
+		ctx := callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID(user))
+		logStats := logstats.NewLogStats(ctx, "Execute", "select 1 from dual", "", nil)
+		logStats.EndTime = logStats.StartTime.Add(time.Millisecond)
+		logStats.ShardQueries = shardQueries
+		logStats.Error = err
+		tracker.record(logStats, rowsReturned)
+	}
+	query("usage_alice", 10, 1, nil)
+	query("usage_alice", 5, 2, errors.New("failed"))
+	query("usage_bob", 1, 4, nil)
+	query("usage_carol", 2, 8, nil)
+
+	assert.EqualValues(t, 2, userQueries.Counts()["usage_alice"])
+	assert.EqualValues(t, 1, userErrors.Counts()["usage_alice"])
+	assert.EqualValues(t, 15, userRowsReturned.Counts()["usage_alice"])
+	assert.EqualValues(t, 3, userShardQueries.Counts()["usage_alice"])
+	assert.EqualValues(t, 2*time.Millisecond, userQueryTimesNs.Counts()["usage_alice"])
+	assert.NotContains(t, userQueries.Counts(), "usage_carol")
+	assert.EqualValues(t, 8, userShardQueries.Counts()[stats.OverflowLabel])
+
+	tracker.close()
+	data, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	var reports []userUsageReport
+	for _, line := range lines {
+		var report userUsageReport
+		require.NoError(t, json.Unmarshal([]byte(line), &report))
+		reports = append(reports, report)
+	}
+	assert.Equal(t, UserUsage{User: stats.OverflowLabel, Queries: 1, RowsReturned: 2, ShardQueries: 8, QueryTime: time.Millisecond}, reports[0].UserUsage)
+	assert.Equal(t, UserUsage{User: "usage_alice", Queries: 2, Errors: 1, RowsReturned: 15, ShardQueries: 3, QueryTime: 2 * time.Millisecond}, reports[1].UserUsage)
+	assert.Equal(t, "usage_bob", reports[2].User)
+	assert.False(t, reports[0].End.Before(reports[0].Start))
+}
+
+func TestUserUsageTrackerDisabled(t *testing.T) {
+	tracker := newUserUsageTracker(0, time.Hour, "")
+	assert.Nil(t, tracker)
+	// A nil tracker records nothing.
+	tracker.record(logstats.NewLogStats(context.Background(), "Execute", "select 1 from dual", "", nil), 1)
+	tracker.close()
+}
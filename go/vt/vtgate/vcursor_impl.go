@@ -32,6 +32,7 @@ import (
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/key"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -205,15 +206,16 @@ func (vc *vcursorImpl) TimeZone() *time.Location {
 	return vc.safeSession.TimeZone()
 }
 
-// MaxMemoryRows returns the maxMemoryRows flag value.
+// MaxMemoryRows returns the maxMemoryRows flag value, unless the
+// vtgate.max_memory_rows cluster setting is set.
 func (vc *vcursorImpl) MaxMemoryRows() int {
-	return maxMemoryRows
+	return clustersettings.VTGateMaxMemoryRows.GetOr(maxMemoryRows)
 }
 
 // ExceedsMaxMemoryRows returns a boolean indicating whether the maxMemoryRows value has been exceeded.
 // Returns false if the max memory rows override directive is set to true.
 func (vc *vcursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
-	return !vc.ignoreMaxMemoryRows && numRows > maxMemoryRows
+	return !vc.ignoreMaxMemoryRows && numRows > clustersettings.VTGateMaxMemoryRows.GetOr(maxMemoryRows)
 }
 
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
@@ -553,6 +555,7 @@ const txRollback = "Rollback Transaction"
 func (vc *vcursorImpl) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, canAutocommit bool) (*sqltypes.Result, []error) {
 	noOfShards := len(rss)
 	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(noOfShards))
+	vc.logShardQueries(rss)
 	err := vc.markSavepoint(ctx, rollbackOnError && (noOfShards > 1), map[string]*querypb.BindVariable{})
 	if err != nil {
 		return nil, []error{err}
@@ -568,6 +571,7 @@ func (vc *vcursorImpl) ExecuteMultiShard(ctx context.Context, primitive engine.P
 func (vc *vcursorImpl) StreamExecuteMulti(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, rollbackOnError bool, autocommit bool, callback func(reply *sqltypes.Result) error) []error {
 	noOfShards := len(rss)
 	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(noOfShards))
+	vc.logShardQueries(rss)
 	err := vc.markSavepoint(ctx, rollbackOnError && (noOfShards > 1), map[string]*querypb.BindVariable{})
 	if err != nil {
 		return []error{err}
@@ -579,6 +583,13 @@ func (vc *vcursorImpl) StreamExecuteMulti(ctx context.Context, primitive engine.
 	return errs
 }
 
+// logShardQueries counts the queries sent to each shard, for the slow query log.
+func (vc *vcursorImpl) logShardQueries(rss []*srvtopo.ResolvedShard) {
+	for _, rs := range rss {
+		vc.logStats.AddShardQuery(rs.Target.Keyspace, rs.Target.Shard)
+	}
+}
+
 // ExecuteLock is for executing advisory lock statements.
 func (vc *vcursorImpl) ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error) {
 	query.Sql = vc.marginComments.Leading + query.Sql + vc.marginComments.Trailing
@@ -680,8 +691,83 @@ func (vc *vcursorImpl) fixupPartiallyMovedShards(rss []*srvtopo.ResolvedShard) (
 	return rss, nil
 }
 
+// tenantKeyspaces returns the keyspace each destination is routed to by the
+// tenant routing rules, or nil if none of the destinations is routed to
+// another keyspace. Only keyspace id destinations are routed.
+func (vc *vcursorImpl) tenantKeyspaces(keyspace string, destinations []key.Destination) []string {
+	if len(vc.vschema.TenantRoutingRules) == 0 {
+		return nil
+	}
+	var keyspaces []string
+	for i, destination := range destinations {
+		ksid, ok := destination.(key.DestinationKeyspaceID)
+		if !ok {
+			continue
+		}
+		routed := vc.vschema.FindRoutedTenant(keyspace, ksid)
+		if routed == keyspace {
+			continue
+		}
+		if keyspaces == nil {
+			keyspaces = make([]string, len(destinations))
+			for j := range keyspaces {
+				keyspaces[j] = keyspace
+			}
+		}
+		keyspaces[i] = routed
+	}
+	return keyspaces
+}
+
+// resolveTenantDestinations resolves the destinations routed to each keyspace
+// separately, and returns the resolved shards of all the keyspaces.
+func resolveTenantDestinations[T any](keyspaces []string, ids []T, destinations []key.Destination,
+	resolve func(keyspace string, ids []T, destinations []key.Destination) ([]*srvtopo.ResolvedShard, [][]T, error)) ([]*srvtopo.ResolvedShard, [][]T, error) {
+	var order []string
+	groups := make(map[string][]int)
+	for i, keyspace := range keyspaces {
+		if _, ok := groups[keyspace]; !ok {
+			order = append(order, keyspace)
+		}
+		groups[keyspace] = append(groups[keyspace], i)
+	}
+	var (
+		rss    []*srvtopo.ResolvedShard
+		values [][]T
+	)
+	for _, keyspace := range order {
+		var groupIds []T
+		groupDestinations := make([]key.Destination, 0, len(groups[keyspace]))
+		for _, i := range groups[keyspace] {
+			if ids != nil {
+				groupIds = append(groupIds, ids[i])
+			}
+			groupDestinations = append(groupDestinations, destinations[i])
+		}
+		groupRss, groupValues, err := resolve(keyspace, groupIds, groupDestinations)
+		if err != nil {
+			return nil, nil, err
+		}
+		rss = append(rss, groupRss...)
+		values = append(values, groupValues...)
+	}
+	return rss, values, nil
+}
+
 func (vc *vcursorImpl) ResolveDestinations(ctx context.Context, keyspace string, ids []*querypb.Value, destinations []key.Destination) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
-	rss, values, err := vc.resolver.ResolveDestinations(ctx, keyspace, vc.tabletType, ids, destinations)
+	var (
+		rss    []*srvtopo.ResolvedShard
+		values [][]*querypb.Value
+		err    error
+	)
+	if keyspaces := vc.tenantKeyspaces(keyspace, destinations); keyspaces != nil {
+		rss, values, err = resolveTenantDestinations(keyspaces, ids, destinations,
+			func(keyspace string, ids []*querypb.Value, destinations []key.Destination) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
+				return vc.resolver.ResolveDestinations(ctx, keyspace, vc.tabletType, ids, destinations)
+			})
+	} else {
+		rss, values, err = vc.resolver.ResolveDestinations(ctx, keyspace, vc.tabletType, ids, destinations)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -695,7 +781,19 @@ func (vc *vcursorImpl) ResolveDestinations(ctx context.Context, keyspace string,
 }
 
 func (vc *vcursorImpl) ResolveDestinationsMultiCol(ctx context.Context, keyspace string, ids [][]sqltypes.Value, destinations []key.Destination) ([]*srvtopo.ResolvedShard, [][][]sqltypes.Value, error) {
-	rss, values, err := vc.resolver.ResolveDestinationsMultiCol(ctx, keyspace, vc.tabletType, ids, destinations)
+	var (
+		rss    []*srvtopo.ResolvedShard
+		values [][][]sqltypes.Value
+		err    error
+	)
+	if keyspaces := vc.tenantKeyspaces(keyspace, destinations); keyspaces != nil {
+		rss, values, err = resolveTenantDestinations(keyspaces, ids, destinations,
+			func(keyspace string, ids [][]sqltypes.Value, destinations []key.Destination) ([]*srvtopo.ResolvedShard, [][][]sqltypes.Value, error) {
+				return vc.resolver.ResolveDestinationsMultiCol(ctx, keyspace, vc.tabletType, ids, destinations)
+			})
+	} else {
+		rss, values, err = vc.resolver.ResolveDestinationsMultiCol(ctx, keyspace, vc.tabletType, ids, destinations)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -314,3 +314,41 @@ func TestFirstSortedKeyspace(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, ks3Schema.Keyspace, ks)
 }
+
+func TestResolveTenantDestinations(t *testing.T) {
+	vschema := &vindexes.VSchema{
+		Keyspaces: map[string]*vindexes.KeyspaceSchema{
+			"ks1": {Keyspace: &vindexes.Keyspace{Name: "ks1", Sharded: true}},
+			"ks2": {Keyspace: &vindexes.Keyspace{Name: "ks2", Sharded: true}},
+		},
+		TenantRoutingRules: map[string]string{
+			"ks1.deadbeef": "ks2",
+		},
+	}
+	vc, err := newVCursorImpl(NewSafeSession(nil), sqlparser.MarginComments{}, nil, nil, &fakeVSchemaOperator{vschema: vschema}, vschema, srvtopo.NewResolver(&fakeTopoServer{}, nil, ""), nil, false, querypb.ExecuteOptions_Gen4)
+	require.NoError(t, err)
+
+	routed, _ := hex.DecodeString("deadbeef")
+	notRouted, _ := hex.DecodeString("10")
+	ids := []*querypb.Value{{Value: []byte("1")}, {Value: []byte("2")}, {Value: []byte("3")}}
+	destinations := []key.Destination{key.DestinationKeyspaceID(routed), key.DestinationKeyspaceID(notRouted), key.DestinationKeyspaceID(routed)}
+	rss, values, err := vc.ResolveDestinations(context.Background(), "ks1", ids, destinations)
+	require.NoError(t, err)
+	require.Len(t, rss, 2)
+	require.Equal(t, "ks2", rss[0].Target.Keyspace)
+	require.Equal(t, "80-", rss[0].Target.Shard)
+	require.Equal(t, []*querypb.Value{ids[0], ids[2]}, values[0])
+	require.Equal(t, "ks1", rss[1].Target.Keyspace)
+	require.Equal(t, "-80", rss[1].Target.Shard)
+	require.Equal(t, []*querypb.Value{ids[1]}, values[1])
+
+	// The queries of the other keyspaces and the other destinations are not routed.
+	rss, _, err = vc.ResolveDestinations(context.Background(), "ks2", nil, []key.Destination{key.DestinationKeyspaceID(routed)})
+	require.NoError(t, err)
+	require.Len(t, rss, 1)
+	require.Equal(t, "ks2", rss[0].Target.Keyspace)
+	rss, _, err = vc.ResolveDestinations(context.Background(), "ks1", nil, []key.Destination{key.DestinationAllShards{}})
+	require.NoError(t, err)
+	require.Len(t, rss, 2)
+	require.Equal(t, "ks1", rss[0].Target.Keyspace)
+}
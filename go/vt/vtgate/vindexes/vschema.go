@@ -70,6 +70,9 @@ type VSchema struct {
 	uniqueVindexes    map[string]Vindex
 	Keyspaces         map[string]*KeyspaceSchema `json:"keyspaces"`
 	ShardRoutingRules map[string]string          `json:"shard_routing_rules"`
+	// TenantRoutingRules maps a keyspace and the hex encoded keyspace id of
+	// a tenant to the keyspace the queries of the tenant are routed to.
+	TenantRoutingRules map[string]string `json:"tenant_routing_rules"`
 	// created is the time when the VSchema object was created. Used to detect if a cached
 	// copy of the vschema is stale.
 	created time.Time
@@ -286,6 +289,7 @@ func BuildVSchema(source *vschemapb.SrvVSchema) (vschema *VSchema) {
 	buildReferences(source, vschema)
 	buildRoutingRule(source, vschema)
 	buildShardRoutingRule(source, vschema)
+	buildTenantRoutingRule(source, vschema)
 	// Resolve auto-increments after routing rules are built since sequence tables also obey routing rules.
 	resolveAutoIncrement(source, vschema)
 	return vschema
@@ -916,6 +920,16 @@ func buildShardRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
 	}
 }
 
+func buildTenantRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	if source.TenantRoutingRules == nil || len(source.TenantRoutingRules.Rules) == 0 {
+		return
+	}
+	vschema.TenantRoutingRules = make(map[string]string)
+	for _, rule := range source.TenantRoutingRules.Rules {
+		vschema.TenantRoutingRules[getTenantRoutingRulesKey(rule.FromKeyspace, rule.KeyspaceId)] = rule.ToKeyspace
+	}
+}
+
 // FindTable returns a pointer to the Table. If a keyspace is specified, only tables
 // from that keyspace are searched. If the specified keyspace is unsharded
 // and no tables matched, it's considered valid: FindTable will construct a table
@@ -1166,6 +1180,22 @@ func (vschema *VSchema) FindRoutedShard(keyspace, shard string) (string, error)
 	return keyspace, nil
 }
 
+func getTenantRoutingRulesKey(keyspace string, ksid []byte) string {
+	return fmt.Sprintf("%s.%s", keyspace, hex.EncodeToString(ksid))
+}
+
+// FindRoutedTenant looks up tenant routing rules and returns the keyspace the
+// queries of the tenant with the given keyspace id are routed to.
+func (vschema *VSchema) FindRoutedTenant(keyspace string, ksid []byte) string {
+	if len(vschema.TenantRoutingRules) == 0 {
+		return keyspace
+	}
+	if ks, ok := vschema.TenantRoutingRules[getTenantRoutingRulesKey(keyspace, ksid)]; ok {
+		return ks
+	}
+	return keyspace
+}
+
 // GetCreated returns the time when the VSchema was created.
 func (vschema *VSchema) GetCreated() time.Time {
 	return vschema.created
@@ -3167,3 +3167,21 @@ func assertColumn(t *testing.T, col Column, expectedName string, expectedType qu
 	assert.Equal(t, expectedType, col.Type, "column type does not match")
 
 }
+
+func TestFindRoutedTenant(t *testing.T) {
+	ksid := []byte{0x16, 0x6b, 0x40, 0xb4}
+	vschema := BuildVSchema(&vschemapb.SrvVSchema{
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{
+			Rules: []*vschemapb.TenantRoutingRule{{
+				FromKeyspace: "ks1",
+				ToKeyspace:   "ks2",
+				KeyspaceId:   ksid,
+				TenantId:     "1",
+			}},
+		},
+	})
+	assert.Equal(t, map[string]string{"ks1.166b40b4": "ks2"}, vschema.TenantRoutingRules)
+	assert.Equal(t, "ks2", vschema.FindRoutedTenant("ks1", ksid))
+	assert.Equal(t, "ks1", vschema.FindRoutedTenant("ks1", []byte{0x16}))
+	assert.Equal(t, "ks2", vschema.FindRoutedTenant("ks2", ksid))
+}
@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/discovery"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 
 	"vitess.io/vitess/go/vt/log"
@@ -38,6 +39,7 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vstreamcheckpoint"
 )
 
 // vstreamManager manages vstream requests.
@@ -53,6 +55,18 @@ type vstreamManager struct {
 // maxSkewTimeoutSeconds is the maximum allowed skew between two streams when the MinimizeSkew flag is set
 const maxSkewTimeoutSeconds = 10 * 60
 
+// vstreamStatementTypes are the valid values for the StatementTypes flag.
+var vstreamStatementTypes = map[string]bool{
+	"insert":   true,
+	"update":   true,
+	"delete":   true,
+	"create":   true,
+	"alter":    true,
+	"drop":     true,
+	"rename":   true,
+	"truncate": true,
+}
+
 // vstream contains the metadata for one VStream request.
 type vstream struct {
 	// mu protects parts of vgtid, the semantics of a send, and journaler.
@@ -91,6 +105,16 @@ type vstream struct {
 	// default behavior is to automatically migrate the resharded streams from the old to the new shards
 	stopOnReshard bool
 
+	// these flags are set by the client, default false
+	// if excludeDDLEvents is true DDL events are not sent to the client
+	// if includeJournalEvents is true all journal events are sent to the client, not only those of
+	// a reshard when stopOnReshard is set
+	excludeDDLEvents     bool
+	includeJournalEvents bool
+
+	// if set by the client, only row changes and DDLs of these statement types are sent to the client
+	statementTypes map[string]bool
+
 	// mutex used to synchronize access to skew detection parameters
 	skewMu sync.Mutex
 	// channel is created whenever there is a skew detected. closing it implies the current skew has been fixed
@@ -107,6 +131,15 @@ type vstream struct {
 	// the shard map tracking the copy completion, keyed by streamId. streamId is of the form <keyspace>.<shard>
 	copyCompletedShard map[string]struct{}
 
+	// the tables whose copy is completed on the shards still in their copy phase, keyed by streamId.
+	// They are not in the TablePKs of the vgtid, but are sent to the tablets when a stream is restarted
+	// so that they are not copied again.
+	copiedTables map[string][]string
+
+	// this flag is set by the client, default false
+	// if true the VGTID events are sent with a resume token that also encodes the copied tables
+	resumeTokens bool
+
 	vsm *vstreamManager
 
 	eventCh           chan []*binlogdatapb.VEvent
@@ -153,28 +186,41 @@ func (vsm *vstreamManager) VStream(ctx context.Context, tabletType topodatapb.Ta
 		log.Errorf("unable to get topo server in VStream()")
 		return fmt.Errorf("unable to get topo server")
 	}
+	var statementTypes map[string]bool
+	if len(flags.GetStatementTypes()) > 0 {
+		statementTypes = make(map[string]bool, len(flags.GetStatementTypes()))
+		for _, statementType := range flags.GetStatementTypes() {
+			statementTypes[strings.ToLower(statementType)] = true
+		}
+	}
 	vs := &vstream{
-		vgtid:              vgtid,
-		tabletType:         tabletType,
-		optCells:           flags.Cells,
-		filter:             filter,
-		send:               send,
-		resolver:           vsm.resolver,
-		journaler:          make(map[int64]*journalEvent),
-		minimizeSkew:       flags.GetMinimizeSkew(),
-		stopOnReshard:      flags.GetStopOnReshard(),
-		skewTimeoutSeconds: maxSkewTimeoutSeconds,
-		timestamps:         make(map[string]int64),
-		vsm:                vsm,
-		eventCh:            make(chan []*binlogdatapb.VEvent),
-		heartbeatInterval:  flags.GetHeartbeatInterval(),
-		ts:                 ts,
-		copyCompletedShard: make(map[string]struct{}),
+		vgtid:                vgtid,
+		tabletType:           tabletType,
+		optCells:             flags.Cells,
+		filter:               filter,
+		send:                 send,
+		resolver:             vsm.resolver,
+		journaler:            make(map[int64]*journalEvent),
+		minimizeSkew:         flags.GetMinimizeSkew(),
+		stopOnReshard:        flags.GetStopOnReshard(),
+		excludeDDLEvents:     flags.GetExcludeDdlEvents(),
+		includeJournalEvents: flags.GetIncludeJournalEvents(),
+		statementTypes:       statementTypes,
+		skewTimeoutSeconds:   maxSkewTimeoutSeconds,
+		timestamps:           make(map[string]int64),
+		vsm:                  vsm,
+		eventCh:              make(chan []*binlogdatapb.VEvent),
+		heartbeatInterval:    flags.GetHeartbeatInterval(),
+		ts:                   ts,
+		copyCompletedShard:   make(map[string]struct{}),
+		copiedTables:         make(map[string][]string),
+		resumeTokens:         flags.GetResumeTokens(),
 		tabletPickerOptions: discovery.TabletPickerOptions{
 			CellPreference: flags.GetCellPreference(),
 			TabletOrder:    flags.GetTabletOrder(),
 		},
 	}
+	vs.initCopiedTables()
 	return vs.stream(ctx)
 }
 
@@ -196,6 +242,11 @@ func (vsm *vstreamManager) resolveParams(ctx context.Context, tabletType topodat
 	if vgtid == nil || len(vgtid.ShardGtids) == 0 {
 		return nil, nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "vgtid must have at least one value with a starting position")
 	}
+	for _, statementType := range flags.StatementTypes {
+		if !vstreamStatementTypes[strings.ToLower(statementType)] {
+			return nil, nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid statement type: %s", statementType)
+		}
+	}
 	// To fetch from all keyspaces, the input must contain a single ShardGtid
 	// that has an empty keyspace, and the Gtid must be "current".
 	// Or the input must contain a single ShardGtid that has keyspace wildcards.
@@ -265,6 +316,74 @@ func (vsm *vstreamManager) resolveParams(ctx context.Context, tabletType topodat
 	return newvgtid, filter, flags, nil
 }
 
+// initCopiedTables moves the tables whose copy is completed out of the TablePKs of the
+// vgtid, so that the VGTID events only hold the tables being copied.
+func (vs *vstream) initCopiedTables() {
+	for _, sgtid := range vs.vgtid.ShardGtids {
+		var tablePKs []*binlogdatapb.TableLastPK
+		for _, tablePK := range sgtid.TablePKs {
+			if tablePK.Completed {
+				streamID := fmt.Sprintf("%s/%s", sgtid.Keyspace, sgtid.Shard)
+				vs.copiedTables[streamID] = append(vs.copiedTables[streamID], tablePK.TableName)
+				continue
+			}
+			tablePKs = append(tablePKs, tablePK)
+		}
+		if len(tablePKs) != len(sgtid.TablePKs) {
+			sgtid.TablePKs = tablePKs
+		}
+	}
+}
+
+// tableLastPKs returns the copy state of the tables of the shard to start streaming from:
+// the last PKs of the tables being copied, and the tables whose copy is completed.
+func (vs *vstream) tableLastPKs(sgtid *binlogdatapb.ShardGtid) []*binlogdatapb.TableLastPK {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	copiedTables := vs.copiedTables[fmt.Sprintf("%s/%s", sgtid.Keyspace, sgtid.Shard)]
+	if len(copiedTables) == 0 {
+		return sgtid.TablePKs
+	}
+	tablePKs := make([]*binlogdatapb.TableLastPK, 0, len(sgtid.TablePKs)+len(copiedTables))
+	tablePKs = append(tablePKs, sgtid.TablePKs...)
+	for _, table := range copiedTables {
+		tablePKs = append(tablePKs, &binlogdatapb.TableLastPK{
+			TableName: table,
+			Completed: true,
+		})
+	}
+	return tablePKs
+}
+
+// newVGtidEvent returns a VGTID event for the current vgtid, with its resume token if requested.
+// It must be called while holding the lock.
+func (vs *vstream) newVGtidEvent(keyspace, shard string) (*binlogdatapb.VEvent, error) {
+	event := &binlogdatapb.VEvent{
+		Type:     binlogdatapb.VEventType_VGTID,
+		Vgtid:    vs.vgtid.CloneVT(),
+		Keyspace: keyspace,
+		Shard:    shard,
+	}
+	if !vs.resumeTokens {
+		return event, nil
+	}
+	vgtid := vs.vgtid.CloneVT()
+	for _, sgtid := range vgtid.ShardGtids {
+		for _, table := range vs.copiedTables[fmt.Sprintf("%s/%s", sgtid.Keyspace, sgtid.Shard)] {
+			sgtid.TablePKs = append(sgtid.TablePKs, &binlogdatapb.TableLastPK{
+				TableName: table,
+				Completed: true,
+			})
+		}
+	}
+	token, err := vstreamcheckpoint.EncodeResumeToken(vgtid)
+	if err != nil {
+		return nil, err
+	}
+	event.ResumeToken = token
+	return event, nil
+}
+
 func (vsm *vstreamManager) RecordStreamDelay() {
 	vstreamSkewDelayCount.Add(1)
 }
@@ -543,7 +662,7 @@ func (vs *vstream) streamFromTablet(ctx context.Context, sgtid *binlogdatapb.Sha
 			Target:       target,
 			Position:     sgtid.Gtid,
 			Filter:       vs.filter,
-			TableLastPKs: sgtid.TablePKs,
+			TableLastPKs: vs.tableLastPKs(sgtid),
 		}
 		var vstreamCreatedOnce sync.Once
 		err = tabletConn.VStream(ctx, req, func(events []*binlogdatapb.VEvent) error {
@@ -584,9 +703,19 @@ func (vs *vstream) streamFromTablet(ctx context.Context, sgtid *binlogdatapb.Sha
 					// Update table names and send.
 					ev := event.CloneVT()
 					ev.RowEvent.TableName = sgtid.Keyspace + "." + ev.RowEvent.TableName
+					if vs.statementTypes != nil {
+						// Only send the row changes of the requested statement types.
+						ev.RowEvent.RowChanges = vs.filterRowChanges(ev.RowEvent.RowChanges)
+						if len(ev.RowEvent.RowChanges) == 0 {
+							break
+						}
+					}
 					sendevents = append(sendevents, ev)
 				case binlogdatapb.VEventType_COMMIT, binlogdatapb.VEventType_DDL, binlogdatapb.VEventType_OTHER:
-					sendevents = append(sendevents, event)
+					// A filtered DDL still ends the transaction so that its position is sent.
+					if event.Type != binlogdatapb.VEventType_DDL || vs.sendDDL(event) {
+						sendevents = append(sendevents, event)
+					}
 					eventss = append(eventss, sendevents)
 
 					if err := vs.alignStreams(ctx, event, sgtid.Keyspace, sgtid.Shard); err != nil {
@@ -624,8 +753,9 @@ func (vs *vstream) streamFromTablet(ctx context.Context, sgtid *binlogdatapb.Sha
 
 				case binlogdatapb.VEventType_JOURNAL:
 					journal := event.Journal
-					// Journal events are not sent to clients by default, but only when StopOnReshard is set
-					if vs.stopOnReshard && journal.MigrationType == binlogdatapb.MigrationType_SHARDS {
+					// Journal events are not sent to clients by default, but only when StopOnReshard
+					// or IncludeJournalEvents is set
+					if (vs.stopOnReshard && journal.MigrationType == binlogdatapb.MigrationType_SHARDS) || vs.includeJournalEvents {
 						sendevents = append(sendevents, event)
 						eventss = append(eventss, sendevents)
 						if err := vs.sendAll(ctx, sgtid, eventss); err != nil {
@@ -683,6 +813,42 @@ func (vs *vstream) streamFromTablet(ctx context.Context, sgtid *binlogdatapb.Sha
 	}
 }
 
+// filterRowChanges returns the row changes that are of the statement types requested
+// by the client.
+func (vs *vstream) filterRowChanges(rowChanges []*binlogdatapb.RowChange) []*binlogdatapb.RowChange {
+	filtered := rowChanges[:0]
+	for _, rowChange := range rowChanges {
+		var statementType string
+		switch {
+		case rowChange.Before == nil:
+			statementType = "insert"
+		case rowChange.After == nil:
+			statementType = "delete"
+		default:
+			statementType = "update"
+		}
+		if vs.statementTypes[statementType] {
+			filtered = append(filtered, rowChange)
+		}
+	}
+	return filtered
+}
+
+// sendDDL returns true if the DDL event must be sent to the client.
+func (vs *vstream) sendDDL(event *binlogdatapb.VEvent) bool {
+	if vs.excludeDDLEvents {
+		return false
+	}
+	if vs.statementTypes == nil {
+		return true
+	}
+	words := strings.Fields(sqlparser.StripLeadingComments(event.Statement))
+	if len(words) == 0 {
+		return false
+	}
+	return vs.statementTypes[strings.ToLower(words[0])]
+}
+
 // sendAll sends a group of events together while holding the lock.
 func (vs *vstream) sendAll(ctx context.Context, sgtid *binlogdatapb.ShardGtid, eventss [][]*binlogdatapb.VEvent) error {
 	vs.mu.Lock()
@@ -698,12 +864,11 @@ func (vs *vstream) sendAll(ctx context.Context, sgtid *binlogdatapb.ShardGtid, e
 			if event.Type == binlogdatapb.VEventType_GTID {
 				// Update the VGtid and send that instead.
 				sgtid.Gtid = event.Gtid
-				events[j] = &binlogdatapb.VEvent{
-					Type:     binlogdatapb.VEventType_VGTID,
-					Vgtid:    vs.vgtid.CloneVT(),
-					Keyspace: event.Keyspace,
-					Shard:    event.Shard,
+				vgtidEvent, err := vs.newVGtidEvent(event.Keyspace, event.Shard)
+				if err != nil {
+					return err
 				}
+				events[j] = vgtidEvent
 			} else if event.Type == binlogdatapb.VEventType_LASTPK {
 				var foundIndex = -1
 				eventTablePK := event.LastPKEvent.TableLastPK
@@ -713,6 +878,10 @@ func (vs *vstream) sendAll(ctx context.Context, sgtid *binlogdatapb.ShardGtid, e
 						break
 					}
 				}
+				if event.LastPKEvent.Completed {
+					streamID := fmt.Sprintf("%s/%s", sgtid.Keyspace, sgtid.Shard)
+					vs.copiedTables[streamID] = append(vs.copiedTables[streamID], eventTablePK.TableName)
+				}
 				if foundIndex == -1 {
 					if !event.LastPKEvent.Completed {
 						sgtid.TablePKs = append(sgtid.TablePKs, eventTablePK)
@@ -727,12 +896,11 @@ func (vs *vstream) sendAll(ctx context.Context, sgtid *binlogdatapb.ShardGtid, e
 						sgtid.TablePKs[foundIndex] = eventTablePK
 					}
 				}
-				events[j] = &binlogdatapb.VEvent{
-					Type:     binlogdatapb.VEventType_VGTID,
-					Vgtid:    vs.vgtid.CloneVT(),
-					Keyspace: event.Keyspace,
-					Shard:    event.Shard,
+				vgtidEvent, err := vs.newVGtidEvent(event.Keyspace, event.Shard)
+				if err != nil {
+					return err
 				}
+				events[j] = vgtidEvent
 			}
 		}
 		select {
@@ -751,7 +919,10 @@ func (vs *vstream) isCopyFullyCompleted(ctx context.Context, sgtid *binlogdatapb
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
 
-	vs.copyCompletedShard[fmt.Sprintf("%s/%s", event.Keyspace, event.Shard)] = struct{}{}
+	streamID := fmt.Sprintf("%s/%s", event.Keyspace, event.Shard)
+	vs.copyCompletedShard[streamID] = struct{}{}
+	// The shard is out of its copy phase.
+	delete(vs.copiedTables, streamID)
 
 	for _, shard := range vs.vgtid.ShardGtids {
 		if _, ok := vs.copyCompletedShard[fmt.Sprintf("%s/%s", shard.Keyspace, shard.Shard)]; !ok {
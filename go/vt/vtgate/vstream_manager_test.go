@@ -35,9 +35,11 @@ import (
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vstreamcheckpoint"
 	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -200,6 +202,244 @@ func TestVStreamEvents(t *testing.T) {
 	<-ch
 }
 
+func TestVStreamResumeTokens(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cell := "aa"
+	ks := "TestVStream"
+	_ = createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-20"})
+
+	vsm := newTestVStreamManager(ctx, hc, st, cell)
+	sbc0 := hc.AddTestTablet(cell, "1.1.1.1", 1001, ks, "-20", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-20", sbc0.Tablet())
+
+	lastPK := &querypb.QueryResult{Rows: []*querypb.Row{{Lengths: []int64{1}, Values: []byte("2")}}}
+	send1 := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_LASTPK, LastPKEvent: &binlogdatapb.LastPKEvent{
+			TableLastPK: &binlogdatapb.TableLastPK{TableName: "t1"},
+			Completed:   true,
+		}},
+		{Type: binlogdatapb.VEventType_LASTPK, LastPKEvent: &binlogdatapb.LastPKEvent{
+			TableLastPK: &binlogdatapb.TableLastPK{TableName: "t2", Lastpk: lastPK},
+		}},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}
+	sbc0.AddVStreamEvents(send1, nil)
+
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "pos",
+			TablePKs: []*binlogdatapb.TableLastPK{
+				{TableName: "t0", Completed: true},
+				{TableName: "t1", Lastpk: lastPK},
+			},
+		}},
+	}
+	ch := startVStream(ctx, t, vsm, vgtid, &vtgatepb.VStreamFlags{ResumeTokens: true})
+	response := <-ch
+	require.Len(t, response.Events, 3)
+
+	// The VGTID events only hold the tables being copied, the resume tokens also
+	// hold the tables whose copy is completed.
+	event := response.Events[1]
+	require.Equal(t, binlogdatapb.VEventType_VGTID, event.Type)
+	wantVgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "pos",
+			TablePKs: []*binlogdatapb.TableLastPK{
+				{TableName: "t2", Lastpk: lastPK},
+			},
+		}},
+	}
+	assert.True(t, proto.Equal(wantVgtid, event.Vgtid), "got %v, want %v", event.Vgtid, wantVgtid)
+	resumeVgtid, err := vstreamcheckpoint.DecodeResumeToken(event.ResumeToken)
+	require.NoError(t, err)
+	wantVgtid.ShardGtids[0].TablePKs = append(wantVgtid.ShardGtids[0].TablePKs,
+		&binlogdatapb.TableLastPK{TableName: "t0", Completed: true},
+		&binlogdatapb.TableLastPK{TableName: "t1", Completed: true},
+	)
+	assert.True(t, proto.Equal(wantVgtid, resumeVgtid), "got %v, want %v", resumeVgtid, wantVgtid)
+}
+
+func TestVStreamTableLastPKs(t *testing.T) {
+	lastPK := &binlogdatapb.TableLastPK{TableName: "t1", Lastpk: &querypb.QueryResult{}}
+	sgtid := &binlogdatapb.ShardGtid{
+		Keyspace: "ks",
+		Shard:    "-80",
+		Gtid:     "pos",
+		TablePKs: []*binlogdatapb.TableLastPK{{TableName: "t0", Completed: true}, lastPK},
+	}
+	vs := &vstream{
+		vgtid:        &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{sgtid}},
+		copiedTables: make(map[string][]string),
+	}
+	vs.initCopiedTables()
+	assert.Equal(t, []*binlogdatapb.TableLastPK{lastPK}, sgtid.TablePKs)
+	assert.Equal(t, map[string][]string{"ks/-80": {"t0"}}, vs.copiedTables)
+
+	want := []*binlogdatapb.TableLastPK{lastPK, {TableName: "t0", Completed: true}}
+	assert.Equal(t, want, vs.tableLastPKs(sgtid))
+	assert.Equal(t, []*binlogdatapb.TableLastPK{lastPK}, vs.tableLastPKs(&binlogdatapb.ShardGtid{
+		Keyspace: "ks",
+		Shard:    "80-",
+		TablePKs: []*binlogdatapb.TableLastPK{lastPK},
+	}))
+}
+
+func TestVStreamEventFiltering(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cell := "aa"
+	ks := "TestVStream"
+	_ = createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-20"})
+
+	vsm := newTestVStreamManager(ctx, hc, st, cell)
+	sbc0 := hc.AddTestTablet(cell, "1.1.1.1", 1001, ks, "-20", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-20", sbc0.Tablet())
+
+	insert := &binlogdatapb.RowChange{After: &querypb.Row{}}
+	update := &binlogdatapb.RowChange{Before: &querypb.Row{}, After: &querypb.Row{}}
+	del := &binlogdatapb.RowChange{Before: &querypb.Row{}}
+	send1 := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid01"},
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t0", RowChanges: []*binlogdatapb.RowChange{insert, update, del}}},
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t1", RowChanges: []*binlogdatapb.RowChange{update}}},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}
+	want1 := &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: ks,
+				Shard:    "-20",
+				Gtid:     "gtid01",
+			}},
+		}},
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "TestVStream.t0", RowChanges: []*binlogdatapb.RowChange{insert, del}}},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}}
+	sbc0.AddVStreamEvents(send1, nil)
+
+	// The DDL is filtered out but its position is still sent.
+	send2 := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid02"},
+		{Type: binlogdatapb.VEventType_DDL, Statement: "/* comment */ alter table t0 add column c int"},
+	}
+	want2 := &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: ks,
+				Shard:    "-20",
+				Gtid:     "gtid02",
+			}},
+		}},
+	}}
+	sbc0.AddVStreamEvents(send2, nil)
+
+	send3 := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid03"},
+		{Type: binlogdatapb.VEventType_DDL, Statement: "create table t2(id int)"},
+	}
+	want3 := &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: ks,
+				Shard:    "-20",
+				Gtid:     "gtid03",
+			}},
+		}},
+		{Type: binlogdatapb.VEventType_DDL, Statement: "create table t2(id int)"},
+	}}
+	sbc0.AddVStreamEvents(send3, nil)
+
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "pos",
+		}},
+	}
+	flags := &vtgatepb.VStreamFlags{StatementTypes: []string{"insert", "DELETE", "create"}}
+	ch := make(chan *binlogdatapb.VStreamResponse)
+	go func() {
+		err := vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, vgtid, nil, flags, func(events []*binlogdatapb.VEvent) error {
+			ch <- &binlogdatapb.VStreamResponse{Events: events}
+			return nil
+		})
+		wantErr := "context canceled"
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("vstream end: %v, must contain %v", err.Error(), wantErr)
+		}
+		ch <- nil
+	}()
+	verifyEvents(t, ch, want1, want2, want3)
+
+	// Ensure the go func error return was verified.
+	cancel()
+	<-ch
+}
+
+func TestVStreamExcludeDDLEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cell := "aa"
+	ks := "TestVStream"
+	_ = createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-20"})
+
+	vsm := newTestVStreamManager(ctx, hc, st, cell)
+	sbc0 := hc.AddTestTablet(cell, "1.1.1.1", 1001, ks, "-20", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-20", sbc0.Tablet())
+
+	send1 := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid01"},
+		{Type: binlogdatapb.VEventType_DDL, Statement: "create table t2(id int)"},
+	}
+	want1 := &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: ks,
+				Shard:    "-20",
+				Gtid:     "gtid01",
+			}},
+		}},
+	}}
+	sbc0.AddVStreamEvents(send1, nil)
+
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "pos",
+		}},
+	}
+	ch := make(chan *binlogdatapb.VStreamResponse)
+	go func() {
+		err := vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, vgtid, nil, &vtgatepb.VStreamFlags{ExcludeDdlEvents: true}, func(events []*binlogdatapb.VEvent) error {
+			ch <- &binlogdatapb.VStreamResponse{Events: events}
+			return nil
+		})
+		wantErr := "context canceled"
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("vstream end: %v, must contain %v", err.Error(), wantErr)
+		}
+		ch <- nil
+	}()
+	verifyEvents(t, ch, want1)
+
+	// Ensure the go func error return was verified.
+	cancel()
+	<-ch
+}
+
 // TestVStreamChunks ensures that a transaction that's broken
 // into chunks is sent together.
 func TestVStreamChunks(t *testing.T) {
@@ -1133,6 +1373,18 @@ func TestResolveVStreamParams(t *testing.T) {
 		})
 	}
 
+	t.Run("resolveParams invalid StatementTypes", func(t *testing.T) {
+		flags := &vtgatepb.VStreamFlags{StatementTypes: []string{"insert", "select"}}
+		vgtid := &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: "TestVStream",
+				Shard:    "-20",
+				Gtid:     "current",
+			}},
+		}
+		_, _, _, err := vsm.resolveParams(context.Background(), topodatapb.TabletType_REPLICA, vgtid, nil, flags)
+		require.ErrorContains(t, err, "invalid statement type: select")
+	})
 }
 
 func TestVStreamIdleHeartbeat(t *testing.T) {
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vstreamcheckpoint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// Store saves the resume token of a stream.
+type Store interface {
+	// Load returns the saved resume token, or an empty string if there is none.
+	Load(ctx context.Context) (string, error)
+	// Save saves the resume token.
+	Save(ctx context.Context, token string) error
+}
+
+// FileStore is a Store that saves the resume token in a file.
+type FileStore struct {
+	Path string
+}
+
+// Load is part of the Store interface.
+func (fs *FileStore) Load(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(fs.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Save is part of the Store interface. The token is written to a temporary
+// file that is renamed, so that a crash never leaves a partial token.
+func (fs *FileStore) Save(ctx context.Context, token string) error {
+	tmp := fs.Path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(token), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fs.Path)
+}
+
+// Streamer starts VStreams. It is implemented by vtgateconn.VTGateConn.
+type Streamer interface {
+	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+		filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error)
+}
+
+// Stream runs a VStream from the resume token saved in the store, or from
+// the given VGTID if the store has none, and passes the events to the
+// handler. The resume token of the last VGTID event of each batch of events
+// is saved after the handler has processed the batch, so that the stream
+// can be resumed by calling Stream again, including in the middle of its
+// copy phase, without losing events: the events after the saved token may
+// be received again, but the tables whose copy is completed are not copied
+// again. Stream returns nil when the stream ends.
+func Stream(ctx context.Context, streamer Streamer, store Store, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, handler func([]*binlogdatapb.VEvent) error) error {
+	token, err := store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		if vgtid, err = DecodeResumeToken(token); err != nil {
+			return err
+		}
+	}
+	if flags == nil {
+		flags = &vtgatepb.VStreamFlags{}
+	} else {
+		flags = flags.CloneVT()
+	}
+	flags.ResumeTokens = true
+
+	reader, err := streamer.VStream(ctx, tabletType, vgtid, filter, flags)
+	if err != nil {
+		return err
+	}
+	for {
+		events, err := reader.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := handler(events); err != nil {
+			return err
+		}
+		token = ""
+		for _, event := range events {
+			if event.Type == binlogdatapb.VEventType_VGTID && event.ResumeToken != "" {
+				token = event.ResumeToken
+			}
+		}
+		if token == "" {
+			continue
+		}
+		if err := store.Save(ctx, token); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vstreamcheckpoint
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+type fakeStreamer struct {
+	vgtid  *binlogdatapb.VGtid
+	flags  *vtgatepb.VStreamFlags
+	events [][]*binlogdatapb.VEvent
+}
+
+func (fs *fakeStreamer) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
+	fs.vgtid = vgtid
+	fs.flags = flags
+	return fs, nil
+}
+
+func (fs *fakeStreamer) Recv() ([]*binlogdatapb.VEvent, error) {
+	if len(fs.events) == 0 {
+		return nil, io.EOF
+	}
+	events := fs.events[0]
+	fs.events = fs.events[1:]
+	return events, nil
+}
+
+func vgtidEvent(t *testing.T, gtid string) *binlogdatapb.VEvent {
+	vgtid := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0", Gtid: gtid}}}
+	token, err := EncodeResumeToken(vgtid)
+	require.NoError(t, err)
+	return &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_VGTID, Vgtid: vgtid, ResumeToken: token}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "token")}
+	token, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	require.NoError(t, store.Save(ctx, "token1"))
+	require.NoError(t, store.Save(ctx, "token2"))
+	token, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token2", token)
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "token")}
+	vgtid := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0"}}}
+	flags := &vtgatepb.VStreamFlags{MinimizeSkew: true}
+
+	streamer := &fakeStreamer{events: [][]*binlogdatapb.VEvent{
+		{vgtidEvent(t, "pos1"), {Type: binlogdatapb.VEventType_COMMIT}},
+		{vgtidEvent(t, "pos2"), vgtidEvent(t, "pos3")},
+		{{Type: binlogdatapb.VEventType_HEARTBEAT}},
+	}}
+	var received int
+	err := Stream(ctx, streamer, store, topodatapb.TabletType_PRIMARY, vgtid, nil, flags, func(events []*binlogdatapb.VEvent) error {
+		received += len(events)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, received)
+	assert.Equal(t, vgtid, streamer.vgtid)
+	assert.True(t, streamer.flags.ResumeTokens)
+	assert.True(t, streamer.flags.MinimizeSkew)
+	assert.False(t, flags.ResumeTokens)
+
+	// The stream is resumed from the last saved token.
+	streamer = &fakeStreamer{}
+	err = Stream(ctx, streamer, store, topodatapb.TabletType_PRIMARY, vgtid, nil, flags, func(events []*binlogdatapb.VEvent) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pos3", streamer.vgtid.ShardGtids[0].Gtid)
+
+	// The token of a batch is not saved if the handler fails.
+	streamer = &fakeStreamer{events: [][]*binlogdatapb.VEvent{{vgtidEvent(t, "pos4")}}}
+	err = Stream(ctx, streamer, store, topodatapb.TabletType_PRIMARY, vgtid, nil, flags, func(events []*binlogdatapb.VEvent) error {
+		return io.ErrUnexpectedEOF
+	})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	token, err := store.Load(ctx)
+	require.NoError(t, err)
+	resumeVgtid, err := DecodeResumeToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "pos3", resumeVgtid.ShardGtids[0].Gtid)
+}
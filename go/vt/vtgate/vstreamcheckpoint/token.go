@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vstreamcheckpoint implements the resume tokens of the VStream API,
+// and helpers for clients to checkpoint their streams with them.
+package vstreamcheckpoint
+
+import (
+	"encoding/base64"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// EncodeResumeToken returns the resume token of the given VGTID. The copy
+// state of the tables is encoded in the TablePKs of its ShardGtids: the last
+// primary key of the tables being copied, and the tables whose copy is
+// completed.
+func EncodeResumeToken(vgtid *binlogdatapb.VGtid) (string, error) {
+	data, err := vgtid.MarshalVT()
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeResumeToken returns the VGTID of the resume token, which a VStream
+// can be started from to resume the stream the token was sent by.
+func DecodeResumeToken(token string) (*binlogdatapb.VGtid, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid resume token %q", token)
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	if err := vgtid.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid resume token %q", token)
+	}
+	if len(vgtid.ShardGtids) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "resume token %q has no shard positions", token)
+	}
+	return vgtid, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vstreamcheckpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestResumeToken(t *testing.T) {
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: "ks",
+			Shard:    "-80",
+			Gtid:     "MySQL56/89c2a3a3-7d69-11ee-b584-0242ac120002:1-10",
+			TablePKs: []*binlogdatapb.TableLastPK{
+				{TableName: "t1", Lastpk: &querypb.QueryResult{Rows: []*querypb.Row{{Lengths: []int64{1}, Values: []byte("5")}}}},
+				{TableName: "t2", Completed: true},
+			},
+		}, {
+			Keyspace: "ks",
+			Shard:    "80-",
+			Gtid:     "MySQL56/89c2a3a3-7d69-11ee-b584-0242ac120003:1-20",
+		}},
+	}
+	token, err := EncodeResumeToken(vgtid)
+	require.NoError(t, err)
+	got, err := DecodeResumeToken(token)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(vgtid, got), "got %v, want %v", got, vgtid)
+
+	_, err = DecodeResumeToken("not a token")
+	assert.ErrorContains(t, err, "invalid resume token")
+	token, err = EncodeResumeToken(&binlogdatapb.VGtid{})
+	require.NoError(t, err)
+	_, err = DecodeResumeToken(token)
+	assert.ErrorContains(t, err, "has no shard positions")
+}
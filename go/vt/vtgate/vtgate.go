@@ -34,6 +34,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/tb"
+	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
@@ -112,9 +113,32 @@ var (
 	// allowKillStmt to allow execution of kill statement.
 	allowKillStmt bool
 
+	// loadDataBatchSize is the number of rows of LOAD DATA LOCAL INFILE inserted per statement.
+	loadDataBatchSize = 500
+
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	// queryMetricsMaxTables and queryMetricsMaxFingerprints cap the number of
+	// tables and query fingerprints with their own query timings.
+	queryMetricsMaxTables       int
+	queryMetricsMaxFingerprints int
+
+	// userMetricsMaxUsers caps the number of MySQL users with their own
+	// resource usage metrics, which are reported every userUsageReportInterval.
+	userMetricsMaxUsers     int
+	userUsageReportInterval time.Duration
+	userUsageReportFile     string
+
+	// The queries slower than their threshold are written to the sinks of the
+	// slow query log, see slowQueryLog.
+	slowQueryLogThreshold           time.Duration
+	slowQueryLogThresholdByWorkload map[string]string
+	slowQueryLogThresholdByUser     map[string]string
+	slowQueryLogMaxRecordsPerSecond = 10
+	slowQueryLogSinks               = []string{"log"}
+	slowQueryLogFile                string
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -148,9 +172,21 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&messageStreamGracePeriod, "message_stream_grace_period", messageStreamGracePeriod, "the amount of time to give for a vttablet to resume if it ends a message stream, usually because of a reparent.")
 	fs.BoolVar(&enableViews, "enable-views", enableViews, "Enable views support in vtgate.")
 	fs.BoolVar(&allowKillStmt, "allow-kill-statement", allowKillStmt, "Allows the execution of kill statement")
+	fs.IntVar(&loadDataBatchSize, "load-data-batch-size", loadDataBatchSize, "Number of rows of the files of LOAD DATA LOCAL INFILE inserted by each of the INSERT statements they are split into")
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	fs.IntVar(&queryMetricsMaxTables, "query-metrics-max-tables", queryMetricsMaxTables, "Maximum number of tables with their own query timings in VtgateQueryTimingsByTable, the queries of the other tables being recorded under the \"other\" table. 0 disables the metric.")
+	fs.IntVar(&queryMetricsMaxFingerprints, "query-metrics-max-fingerprints", queryMetricsMaxFingerprints, "Maximum number of query fingerprints with their own query timings in VtgateQueryTimingsByFingerprint, the other queries being recorded under the \"other\" fingerprint. 0 disables the metric.")
+	fs.IntVar(&userMetricsMaxUsers, "user-metrics-max-users", userMetricsMaxUsers, "Maximum number of MySQL users with their own resource usage metrics, such as VtgateUserQueries, the other users being recorded under the \"other\" user. 0 disables the per-user metrics and reports.")
+	fs.DurationVar(&userUsageReportInterval, "user-usage-report-interval", userUsageReportInterval, "Interval of the reports of the resources used by each MySQL user, written as JSON lines to --user-usage-report-file. Requires --user-metrics-max-users. 0 disables the reports.")
+	fs.StringVar(&userUsageReportFile, "user-usage-report-file", userUsageReportFile, "File the user usage reports are appended to. The reports are written to the logs if empty.")
+	fs.DurationVar(&slowQueryLogThreshold, "slow-query-log-threshold", slowQueryLogThreshold, "Queries slower than this threshold are written to the slow query log, with their plan and the number of queries sent to each shard. 0 disables the slow query log, unless a threshold is set by workload or user.")
+	fs.StringToStringVar(&slowQueryLogThresholdByWorkload, "slow-query-log-threshold-by-workload", slowQueryLogThresholdByWorkload, "Thresholds of the slow query log by workload, overriding --slow-query-log-threshold, e.g. OLAP=30s,OLTP=100ms. 0 disables the slow query log for a workload.")
+	fs.StringToStringVar(&slowQueryLogThresholdByUser, "slow-query-log-threshold-by-user", slowQueryLogThresholdByUser, "Thresholds of the slow query log by MySQL user, overriding --slow-query-log-threshold and --slow-query-log-threshold-by-workload, e.g. batch=1m. 0 disables the slow query log for a user.")
+	fs.IntVar(&slowQueryLogMaxRecordsPerSecond, "slow-query-log-max-records-per-second", slowQueryLogMaxRecordsPerSecond, "Maximum number of records written to the slow query log per second, the other slow queries being counted in VtgateSlowQueriesDropped.")
+	fs.StringSliceVar(&slowQueryLogSinks, "slow-query-log-sinks", slowQueryLogSinks, "Sinks the slow query log is written to: log writes the records to the vtgate logs, file appends them as JSON lines to --slow-query-log-file.")
+	fs.StringVar(&slowQueryLogFile, "slow-query-log-file", slowQueryLogFile, "File the file sink of the slow query log appends the records to.")
 
 	_ = fs.String("schema_change_signal_user", "", "User to be used to send down query to vttablet to retrieve schema changes")
 	_ = fs.MarkDeprecated("schema_change_signal_user", "schema tracking uses an internal api and does not require a user to be specified")
@@ -332,6 +368,11 @@ func Init(
 	if err := executor.defaultQueryLogger(); err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
 	}
+	slowQueryLog, err := newSlowQueryLog()
+	if err != nil {
+		log.Fatalf("error initializing slow query log: %v", err)
+	}
+	executor.slowQueryLog = slowQueryLog
 
 	// connect the schema tracker with the vschema manager
 	if enableSchemaChangeSignal {
@@ -448,7 +489,7 @@ func (vtg *VTGate) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	// In this context, we don't care if we can't fully parse destination
 	destKeyspace, destTabletType, _, _ := vtg.executor.ParseDestinationTarget(session.TargetString)
 	statsKey := []string{"Execute", destKeyspace, topoproto.TabletTypeLString(destTabletType)}
-	defer vtg.timings.Record(statsKey, time.Now())
+	defer vtg.timings.RecordWithExemplar(statsKey, time.Now(), trace.TraceID(ctx))
 
 	if bvErr := sqltypes.ValidateBindVariables(bindVariables); bvErr != nil {
 		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", bvErr)
@@ -477,7 +518,7 @@ func (vtg *VTGate) ExecuteBatch(ctx context.Context, session *vtgatepb.Session,
 	// In this context, we don't care if we can't fully parse destination
 	destKeyspace, destTabletType, _, _ := vtg.executor.ParseDestinationTarget(session.TargetString)
 	statsKey := []string{"ExecuteBatch", destKeyspace, topoproto.TabletTypeLString(destTabletType)}
-	defer vtg.timings.Record(statsKey, time.Now())
+	defer vtg.timings.RecordWithExemplar(statsKey, time.Now(), trace.TraceID(ctx))
 
 	for _, bindVariables := range bindVariablesList {
 		if bvErr := sqltypes.ValidateBindVariables(bindVariables); bvErr != nil {
@@ -507,7 +548,7 @@ func (vtg *VTGate) StreamExecute(ctx context.Context, mysqlCtx vtgateservice.MyS
 	destKeyspace, destTabletType, _, _ := vtg.executor.ParseDestinationTarget(session.TargetString)
 	statsKey := []string{"StreamExecute", destKeyspace, topoproto.TabletTypeLString(destTabletType)}
 
-	defer vtg.timings.Record(statsKey, time.Now())
+	defer vtg.timings.RecordWithExemplar(statsKey, time.Now(), trace.TraceID(ctx))
 
 	safeSession := NewSafeSession(session)
 	var err error
@@ -556,7 +597,7 @@ func (vtg *VTGate) Prepare(ctx context.Context, session *vtgatepb.Session, sql s
 	// In this context, we don't care if we can't fully parse destination
 	destKeyspace, destTabletType, _, _ := vtg.executor.ParseDestinationTarget(session.TargetString)
 	statsKey := []string{"Prepare", destKeyspace, topoproto.TabletTypeLString(destTabletType)}
-	defer vtg.timings.Record(statsKey, time.Now())
+	defer vtg.timings.RecordWithExemplar(statsKey, time.Now(), trace.TraceID(ctx))
 
 	if bvErr := sqltypes.ValidateBindVariables(bindVariables); bvErr != nil {
 		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", bvErr)
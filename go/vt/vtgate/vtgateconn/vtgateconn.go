@@ -42,6 +42,7 @@ func registerFlags(fs *pflag.FlagSet) {
 
 func init() {
 	servenv.OnParseFor("vttablet", registerFlags)
+	servenv.OnParseFor("vtcdc", registerFlags)
 	servenv.OnParseFor("vtclient", registerFlags)
 }
 
@@ -21,6 +21,7 @@ package vtgateservice
 import (
 	"context"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -54,10 +55,29 @@ type VTGateService interface {
 }
 
 // MySQLConnection is an interface that allows to execute operations on the provided connection id.
-// This is used by vtgate executor to execute kill queries.
+// This is used by vtgate executor to execute kill queries, SHOW PROCESSLIST, and LOAD DATA LOCAL
+// INFILE on the connection running the query.
 type MySQLConnection interface {
 	// KillQuery stops the an executing query on the connection.
 	KillQuery(uint32) error
 	// KillConnection closes the connection and also stops any executing query on it.
 	KillConnection(context.Context, uint32) error
+	// ReadLocalInfile requests the content of a file from the client of the connection running
+	// the query, and calls the callback with each chunk of it, for LOAD DATA LOCAL INFILE.
+	ReadLocalInfile(fileName string, callback func([]byte) error) error
+	// ProcessList returns the MySQL connections of vtgate, sorted by connection id.
+	ProcessList() []*MySQLProcess
+}
+
+// MySQLProcess describes a MySQL connection of vtgate, as shown by SHOW PROCESSLIST.
+type MySQLProcess struct {
+	mysql.ProcessInfo
+
+	// ID is the connection id.
+	ID uint32
+	// Host is the address of the client.
+	Host string
+	// ConnectionAttributes are the connection attributes sent by the
+	// client, such as program_name.
+	ConnectionAttributes map[string]string
 }
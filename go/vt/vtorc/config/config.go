@@ -63,6 +63,16 @@ var (
 	recoveryPollDuration           = 1 * time.Second
 	ersEnabled                     = true
 	convertTabletsWithErrantGTIDs  = false
+	preRecoveryHooks               []string
+	postRecoveryHooks              []string
+	recoveryHooksTimeout           = 10 * time.Second
+	analysisRulesFile              = ""
+	analysisRulesReloadInterval    = 30 * time.Second
+	maxConcurrentRecoveries        = 0
+	maxConcurrentRecoveriesPerCell = 0
+	circuitBreakerMaxShards        = 0
+	circuitBreakerWindow           = 10 * time.Minute
+	allowFailureInjection          = false
 )
 
 // RegisterFlags registers the flags required by VTOrc
@@ -82,6 +92,16 @@ func RegisterFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&recoveryPollDuration, "recovery-poll-duration", recoveryPollDuration, "Timer duration on which VTOrc polls its database to run a recovery")
 	fs.BoolVar(&ersEnabled, "allow-emergency-reparent", ersEnabled, "Whether VTOrc should be allowed to run emergency reparent operation when it detects a dead primary")
 	fs.BoolVar(&convertTabletsWithErrantGTIDs, "change-tablets-with-errant-gtid-to-drained", convertTabletsWithErrantGTIDs, "Whether VTOrc should be changing the type of tablets with errant GTIDs to DRAINED")
+	fs.StringSliceVar(&preRecoveryHooks, "pre-recovery-hooks", preRecoveryHooks, "Comma-separated list of webhook URLs (http:// or https://) and shell commands that VTOrc runs in order, with a JSON description of the analysis, before running a recovery. A failing hook aborts the recovery")
+	fs.StringSliceVar(&postRecoveryHooks, "post-recovery-hooks", postRecoveryHooks, "Comma-separated list of webhook URLs (http:// or https://) and shell commands that VTOrc runs with a JSON description of the analysis and the outcome, after a recovery or after a pre-recovery hook aborted it")
+	fs.DurationVar(&recoveryHooksTimeout, "recovery-hooks-timeout", recoveryHooksTimeout, "Timeout of each pre-recovery and post-recovery hook")
+	fs.StringVar(&analysisRulesFile, "analysis-rules-file", analysisRulesFile, "YAML or JSON file with rules making some analyses alert-only or ignored, per keyspace and shard, and overriding the reasonable replication lag. It is reloaded periodically")
+	fs.DurationVar(&analysisRulesReloadInterval, "analysis-rules-reload-interval", analysisRulesReloadInterval, "Timer duration on which VTOrc reloads the analysis rules file")
+	fs.IntVar(&maxConcurrentRecoveries, "max-concurrent-recoveries", maxConcurrentRecoveries, "Maximum number of recoveries which act on the tablets that VTOrc runs concurrently. The recoveries above the limit are skipped and retried later. 0 means no limit")
+	fs.IntVar(&maxConcurrentRecoveriesPerCell, "max-concurrent-recoveries-per-cell", maxConcurrentRecoveriesPerCell, "Maximum number of recoveries which act on the tablets that VTOrc runs concurrently on the tablets of each cell. 0 means no limit")
+	fs.IntVar(&circuitBreakerMaxShards, "recovery-circuit-breaker-max-shards", circuitBreakerMaxShards, "Maximum number of shards VTOrc starts recoveries on within --recovery-circuit-breaker-window. Above it, VTOrc stops running recoveries until the breaker is reset through /api/reset-recovery-circuit-breaker. 0 disables the circuit breaker")
+	fs.DurationVar(&circuitBreakerWindow, "recovery-circuit-breaker-window", circuitBreakerWindow, "Time window in which the shards recovered are counted by the recovery circuit breaker")
+	fs.BoolVar(&allowFailureInjection, "allow-failure-injection", allowFailureInjection, "Whether to allow injecting simulated failures on the tablets through the /api/inject-failure endpoint, to run failover game days. VTOrc recovers from the injected failures as from real ones")
 }
 
 // Configuration makes for vtorc configuration input, which can be provided by user via JSON formatted file.
@@ -153,6 +173,86 @@ func SetConvertTabletWithErrantGTIDs(val bool) {
 	convertTabletsWithErrantGTIDs = val
 }
 
+// PreRecoveryHooks returns the hooks VTOrc runs before a recovery.
+func PreRecoveryHooks() []string {
+	return preRecoveryHooks
+}
+
+// PostRecoveryHooks returns the hooks VTOrc runs after a recovery.
+func PostRecoveryHooks() []string {
+	return postRecoveryHooks
+}
+
+// SetRecoveryHooks sets the hooks VTOrc runs before and after a recovery. This should only be used from tests.
+func SetRecoveryHooks(pre, post []string) {
+	preRecoveryHooks = pre
+	postRecoveryHooks = post
+}
+
+// RecoveryHooksTimeout returns the timeout of each recovery hook.
+func RecoveryHooksTimeout() time.Duration {
+	return recoveryHooksTimeout
+}
+
+// AnalysisRulesFile returns the file VTOrc reads the analysis rules from.
+func AnalysisRulesFile() string {
+	return analysisRulesFile
+}
+
+// SetAnalysisRulesFile sets the file VTOrc reads the analysis rules from. This should only be used from tests.
+func SetAnalysisRulesFile(fileName string) {
+	analysisRulesFile = fileName
+}
+
+// AnalysisRulesReloadInterval returns the interval on which VTOrc reloads the analysis rules file.
+func AnalysisRulesReloadInterval() time.Duration {
+	return analysisRulesReloadInterval
+}
+
+// MaxConcurrentRecoveries returns the maximum number of concurrent recoveries, or 0 if there is no limit.
+func MaxConcurrentRecoveries() int {
+	return maxConcurrentRecoveries
+}
+
+// MaxConcurrentRecoveriesPerCell returns the maximum number of concurrent recoveries per cell, or 0 if there is no limit.
+func MaxConcurrentRecoveriesPerCell() int {
+	return maxConcurrentRecoveriesPerCell
+}
+
+// SetMaxConcurrentRecoveries sets the maximum number of concurrent recoveries, in total and per cell. This should only be used from tests.
+func SetMaxConcurrentRecoveries(total, perCell int) {
+	maxConcurrentRecoveries = total
+	maxConcurrentRecoveriesPerCell = perCell
+}
+
+// RecoveryCircuitBreakerMaxShards returns the maximum number of shards recovered within the circuit breaker window,
+// or 0 if the circuit breaker is disabled.
+func RecoveryCircuitBreakerMaxShards() int {
+	return circuitBreakerMaxShards
+}
+
+// RecoveryCircuitBreakerWindow returns the time window in which the circuit breaker counts the shards recovered.
+func RecoveryCircuitBreakerWindow() time.Duration {
+	return circuitBreakerWindow
+}
+
+// SetRecoveryCircuitBreaker sets the maximum number of shards recovered within the circuit breaker window, and the window.
+// This should only be used from tests.
+func SetRecoveryCircuitBreaker(maxShards int, window time.Duration) {
+	circuitBreakerMaxShards = maxShards
+	circuitBreakerWindow = window
+}
+
+// FailureInjectionAllowed reports whether failures can be injected on the tablets.
+func FailureInjectionAllowed() bool {
+	return allowFailureInjection
+}
+
+// SetFailureInjectionAllowed sets the value for the allowFailureInjection variable. This should only be used from tests.
+func SetFailureInjectionAllowed(val bool) {
+	allowFailureInjection = val
+}
+
 // LogConfigValues is used to log the config values.
 func LogConfigValues() {
 	b, _ := json.MarshalIndent(Config, "", "\t")
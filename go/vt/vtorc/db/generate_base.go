@@ -370,6 +370,10 @@ CREATE TABLE vitess_keyspace (
 	keyspace varchar(128) NOT NULL,
 	keyspace_type smallint(5) NOT NULL,
 	durability_policy varchar(512) NOT NULL,
+	vtorc_maintenance_reason varchar(512) NOT NULL DEFAULT '',
+	vtorc_maintenance_expire_time bigint NOT NULL DEFAULT 0,
+	failover_prefer_same_cell tinyint NOT NULL DEFAULT 0,
+	failover_never_promote_cells varchar(1024) NOT NULL DEFAULT '',
 	PRIMARY KEY (keyspace)
 )`,
 	`
@@ -381,6 +385,9 @@ CREATE TABLE vitess_shard (
 	shard varchar(128) NOT NULL,
 	primary_alias varchar(512) NOT NULL,
 	primary_timestamp varchar(512) NOT NULL,
+	semi_sync_fallback_alias varchar(512) NOT NULL DEFAULT '',
+	vtorc_maintenance_reason varchar(512) NOT NULL DEFAULT '',
+	vtorc_maintenance_expire_time bigint NOT NULL DEFAULT 0,
 	PRIMARY KEY (keyspace, shard)
 )`,
 	`
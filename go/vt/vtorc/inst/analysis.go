@@ -17,6 +17,7 @@
 package inst
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
@@ -40,6 +41,7 @@ const (
 	PrimaryIsReadOnly                      AnalysisCode = "PrimaryIsReadOnly"
 	PrimarySemiSyncMustBeSet               AnalysisCode = "PrimarySemiSyncMustBeSet"
 	PrimarySemiSyncMustNotBeSet            AnalysisCode = "PrimarySemiSyncMustNotBeSet"
+	PrimarySemiSyncFallback                AnalysisCode = "PrimarySemiSyncFallback"
 	ReplicaIsWritable                      AnalysisCode = "ReplicaIsWritable"
 	NotConnectedToPrimary                  AnalysisCode = "NotConnectedToPrimary"
 	ConnectedToWrongPrimary                AnalysisCode = "ConnectedToWrongPrimary"
@@ -105,7 +107,10 @@ type ReplicationAnalysis struct {
 	AnalyzedKeyspace             string
 	AnalyzedShard                string
 	// ShardPrimaryTermTimestamp is the primary term start time stored in the shard record.
-	ShardPrimaryTermTimestamp                 string
+	ShardPrimaryTermTimestamp string
+	// ShardSemiSyncFallbackAlias is the alias of the primary that has semi-sync disabled by its
+	// semi-sync monitor, as stored in the shard record.
+	ShardSemiSyncFallbackAlias                string
 	AnalyzedInstancePhysicalEnvironment       string
 	AnalyzedInstanceBinlogCoordinates         BinlogCoordinates
 	IsPrimary                                 bool
@@ -150,6 +155,9 @@ type ReplicationAnalysis struct {
 	MaxReplicaGTIDMode                        string
 	MaxReplicaGTIDErrant                      string
 	IsReadOnly                                bool
+	// SecondsSinceLastSeen is how long ago the analyzed tablet was last polled successfully. It is not valid
+	// if the tablet was never polled successfully.
+	SecondsSinceLastSeen sql.NullInt64
 }
 
 func (replicationAnalysis *ReplicationAnalysis) MarshalJSON() ([]byte, error) {
@@ -68,11 +68,14 @@ func GetReplicationAnalysis(keyspace string, shard string, hints *ReplicationAna
 		if analysis.Analysis == NoProblem && len(analysis.StructureAnalysis) == 0 {
 			return
 		}
+		if GetAnalysisAction(analysis.Analysis, analysis.AnalyzedKeyspace, analysis.AnalyzedShard) == AnalysisActionIgnore {
+			return
+		}
 		result = append(result, analysis)
 	}
 
 	// TODO(sougou); deprecate ReduceReplicationAnalysisCount
-	args := sqlutils.Args(config.Config.ReasonableReplicationLagSeconds, ValidSecondsFromSeenToLastAttemptedCheck(), config.Config.ReasonableReplicationLagSeconds, keyspace, shard)
+	args := sqlutils.Args(reasonableReplicationLagSeconds(), ValidSecondsFromSeenToLastAttemptedCheck(), reasonableReplicationLagSeconds(), keyspace, shard)
 	query := `
 	SELECT
 		vitess_tablet.info AS tablet_info,
@@ -85,6 +88,7 @@ func GetReplicationAnalysis(keyspace string, shard string, hints *ReplicationAna
 		vitess_keyspace.keyspace_type AS keyspace_type,
 		vitess_keyspace.durability_policy AS durability_policy,
 		vitess_shard.primary_timestamp AS shard_primary_term_timestamp,
+		vitess_shard.semi_sync_fallback_alias AS shard_semi_sync_fallback_alias,
 		primary_instance.read_only AS read_only,
 		MIN(primary_instance.gtid_errant) AS gtid_errant, 
 		MIN(primary_instance.alias) IS NULL AS is_invalid,
@@ -109,6 +113,7 @@ func GetReplicationAnalysis(keyspace string, shard string, hints *ReplicationAna
 		/* To be considered a primary, traditional async replication must not be present/valid AND the host should either */
 		/* not be a replication group member OR be the primary of the replication group */
 		MIN(primary_instance.last_check_partial_success) as last_check_partial_success,
+		MIN(unix_timestamp() - unix_timestamp(primary_instance.last_seen)) AS seconds_since_last_seen,
 		MIN(
 			(
 				primary_instance.source_host IN ('', '_')
@@ -333,6 +338,7 @@ func GetReplicationAnalysis(keyspace string, shard string, hints *ReplicationAna
 		}
 
 		a.ShardPrimaryTermTimestamp = m.GetString("shard_primary_term_timestamp")
+		a.ShardSemiSyncFallbackAlias = m.GetString("shard_semi_sync_fallback_alias")
 		a.IsPrimary = m.GetBool("is_primary")
 		countCoPrimaryReplicas := m.GetUint("count_co_primary_replicas")
 		a.IsCoPrimary = m.GetBool("is_co_primary") || (countCoPrimaryReplicas > 0)
@@ -354,6 +360,7 @@ func GetReplicationAnalysis(keyspace string, shard string, hints *ReplicationAna
 		a.GTIDMode = m.GetString("gtid_mode")
 		a.LastCheckValid = m.GetBool("is_last_check_valid")
 		a.LastCheckPartialSuccess = m.GetBool("last_check_partial_success")
+		a.SecondsSinceLastSeen = m.GetNullInt64("seconds_since_last_seen")
 		a.CountReplicas = m.GetUint("count_replicas")
 		a.CountValidReplicas = m.GetUint("count_valid_replicas")
 		a.CountValidReplicatingReplicas = m.GetUint("count_valid_replicating_replicas")
@@ -469,6 +476,12 @@ func GetReplicationAnalysis(keyspace string, shard string, hints *ReplicationAna
 			a.Analysis = PrimaryIsReadOnly
 			a.Description = "Primary is read-only"
 			//
+		} else if a.IsClusterPrimary && reparentutil.SemiSyncAckers(ca.durability, tablet) != 0 && !a.SemiSyncPrimaryEnabled && a.ShardSemiSyncFallbackAlias == a.AnalyzedInstanceAlias {
+			// The semi-sync monitor of the primary disabled semi-sync on purpose, and will enable
+			// it again once enough semi-sync replicas are connected. We shouldn't fight it.
+			a.Analysis = PrimarySemiSyncFallback
+			a.Description = "Primary semi-sync is temporarily disabled because not enough semi-sync replicas are connected"
+			//
 		} else if a.IsClusterPrimary && reparentutil.SemiSyncAckers(ca.durability, tablet) != 0 && !a.SemiSyncPrimaryEnabled {
 			a.Analysis = PrimarySemiSyncMustBeSet
 			a.Description = "Primary semi-sync must be set"
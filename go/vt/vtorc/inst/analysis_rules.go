@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtorc/config"
+)
+
+// AnalysisAction is what VTOrc does with the problems found by an analysis.
+type AnalysisAction string
+
+const (
+	// AnalysisActionRecover detects the problems and recovers from them. It is the default.
+	AnalysisActionRecover AnalysisAction = "recover"
+	// AnalysisActionAlert detects the problems, which are audited and exported in the metrics,
+	// but never recovers from them.
+	AnalysisActionAlert AnalysisAction = "alert"
+	// AnalysisActionIgnore drops the problems from the analysis entirely.
+	AnalysisActionIgnore AnalysisAction = "ignore"
+)
+
+// AnalysisRule sets the action VTOrc takes for an analysis, on all the keyspaces or only on a
+// keyspace or a shard.
+type AnalysisRule struct {
+	Analysis AnalysisCode   `json:"analysis"`
+	Keyspace string         `json:"keyspace,omitempty"`
+	Shard    string         `json:"shard,omitempty"`
+	Action   AnalysisAction `json:"action"`
+}
+
+// AnalysisRules are the rules read from the analysis rules file.
+type AnalysisRules struct {
+	Rules []*AnalysisRule `json:"rules"`
+	// ReasonableReplicationLag, if set, overrides the replication lag above which the
+	// lag-based analyses consider a replica to be lagging.
+	ReasonableReplicationLag string `json:"reasonable_replication_lag,omitempty"`
+
+	reasonableReplicationLagSeconds int
+	// data is the content the rules were parsed from.
+	data []byte
+}
+
+// analysisRules are the rules in use. They are nil if there is no analysis rules file.
+var analysisRules atomic.Pointer[AnalysisRules]
+
+// ParseAnalysisRules parses and validates analysis rules in YAML or JSON.
+func ParseAnalysisRules(data []byte) (*AnalysisRules, error) {
+	rules := &AnalysisRules{data: data}
+	if err := yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, err
+	}
+	for _, rule := range rules.Rules {
+		if rule.Analysis == "" {
+			return nil, fmt.Errorf("analysis rule %+v has no analysis", *rule)
+		}
+		if rule.Shard != "" && rule.Keyspace == "" {
+			return nil, fmt.Errorf("analysis rule for %v sets a shard without a keyspace", rule.Analysis)
+		}
+		switch rule.Action {
+		case AnalysisActionRecover, AnalysisActionAlert, AnalysisActionIgnore:
+		default:
+			return nil, fmt.Errorf("analysis rule for %v has an invalid action %q, must be one of recover, alert or ignore", rule.Analysis, rule.Action)
+		}
+	}
+	if rules.ReasonableReplicationLag != "" {
+		lag, err := time.ParseDuration(rules.ReasonableReplicationLag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reasonable_replication_lag: %v", err)
+		}
+		if lag < time.Second {
+			return nil, fmt.Errorf("reasonable_replication_lag must be at least 1s, got %v", lag)
+		}
+		rules.reasonableReplicationLagSeconds = int(lag / time.Second)
+	}
+	return rules, nil
+}
+
+// LoadAnalysisRules reads the analysis rules file, and uses its rules from then on. Without an
+// analysis rules file, all the analyses are recovered from. If the file can't be read or is
+// invalid, the rules in use are kept and the error is returned.
+func LoadAnalysisRules() error {
+	fileName := config.AnalysisRulesFile()
+	if fileName == "" {
+		analysisRules.Store(nil)
+		return nil
+	}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	rules, err := ParseAnalysisRules(data)
+	if err != nil {
+		return fmt.Errorf("invalid analysis rules file %v: %v", fileName, err)
+	}
+	if previous := analysisRules.Swap(rules); previous == nil || !bytes.Equal(previous.data, rules.data) {
+		log.Infof("Loaded %d analysis rules from %v", len(rules.Rules), fileName)
+	}
+	return nil
+}
+
+// GetAnalysisAction returns the action VTOrc takes for the analysis on the given shard. The rule for
+// the shard takes precedence over the rule for the keyspace, which takes precedence over the rule
+// for all the keyspaces.
+func GetAnalysisAction(analysis AnalysisCode, keyspace string, shard string) AnalysisAction {
+	rules := analysisRules.Load()
+	if rules == nil {
+		return AnalysisActionRecover
+	}
+	action := AnalysisActionRecover
+	bestMatch := -1
+	for _, rule := range rules.Rules {
+		if rule.Analysis != analysis {
+			continue
+		}
+		var match int
+		switch {
+		case rule.Keyspace == "":
+			match = 0
+		case rule.Keyspace != keyspace:
+			continue
+		case rule.Shard == "":
+			match = 1
+		case rule.Shard != shard:
+			continue
+		default:
+			match = 2
+		}
+		if match > bestMatch {
+			bestMatch = match
+			action = rule.Action
+		}
+	}
+	return action
+}
+
+// reasonableReplicationLagSeconds returns the replication lag above which a replica is considered
+// to be lagging, from the analysis rules if they override it.
+func reasonableReplicationLagSeconds() int {
+	if rules := analysisRules.Load(); rules != nil && rules.reasonableReplicationLagSeconds > 0 {
+		return rules.reasonableReplicationLagSeconds
+	}
+	return config.Config.ReasonableReplicationLagSeconds
+}
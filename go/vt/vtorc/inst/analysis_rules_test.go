@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtorc/config"
+)
+
+func TestParseAnalysisRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			data: `
+rules:
+- analysis: ReplicationStopped
+  action: alert
+reasonable_replication_lag: 1m
+`,
+		}, {
+			name: "json",
+			data: `{"rules": [{"analysis": "ReplicationStopped", "keyspace": "ks", "shard": "0", "action": "ignore"}]}`,
+		}, {
+			name:    "unknown field",
+			data:    `{"rules": [{"analysis": "ReplicationStopped", "actions": "ignore"}]}`,
+			wantErr: "unknown field",
+		}, {
+			name:    "invalid action",
+			data:    `{"rules": [{"analysis": "ReplicationStopped", "action": "fix"}]}`,
+			wantErr: "invalid action",
+		}, {
+			name:    "no analysis",
+			data:    `{"rules": [{"action": "alert"}]}`,
+			wantErr: "has no analysis",
+		}, {
+			name:    "shard without keyspace",
+			data:    `{"rules": [{"analysis": "ReplicationStopped", "shard": "0", "action": "alert"}]}`,
+			wantErr: "without a keyspace",
+		}, {
+			name:    "invalid lag",
+			data:    `{"reasonable_replication_lag": "100ms"}`,
+			wantErr: "at least 1s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAnalysisRules([]byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadAnalysisRules(t *testing.T) {
+	oldReasonableReplicationLagSeconds := config.Config.ReasonableReplicationLagSeconds
+	defer func() {
+		config.Config.ReasonableReplicationLagSeconds = oldReasonableReplicationLagSeconds
+		config.SetAnalysisRulesFile("")
+		require.NoError(t, LoadAnalysisRules())
+	}()
+	config.Config.ReasonableReplicationLagSeconds = 10
+
+	fileName := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(`
+rules:
+- analysis: ReplicationStopped
+  action: alert
+- analysis: ReplicationStopped
+  keyspace: ks
+  action: ignore
+- analysis: ReplicationStopped
+  keyspace: ks
+  shard: "80-"
+  action: recover
+reasonable_replication_lag: 30s
+`), 0o644))
+	config.SetAnalysisRulesFile(fileName)
+	require.NoError(t, LoadAnalysisRules())
+
+	assert.Equal(t, AnalysisActionAlert, GetAnalysisAction(ReplicationStopped, "other", "0"))
+	assert.Equal(t, AnalysisActionIgnore, GetAnalysisAction(ReplicationStopped, "ks", "-80"))
+	assert.Equal(t, AnalysisActionRecover, GetAnalysisAction(ReplicationStopped, "ks", "80-"))
+	assert.Equal(t, AnalysisActionRecover, GetAnalysisAction(DeadPrimary, "ks", "-80"))
+	assert.Equal(t, 30, reasonableReplicationLagSeconds())
+
+	// An invalid file keeps the rules in use.
+	require.NoError(t, os.WriteFile(fileName, []byte(`rules: [{analysis: DeadPrimary, action: panic}]`), 0o644))
+	assert.ErrorContains(t, LoadAnalysisRules(), "invalid action")
+	assert.Equal(t, AnalysisActionAlert, GetAnalysisAction(ReplicationStopped, "other", "0"))
+
+	config.SetAnalysisRulesFile("")
+	require.NoError(t, LoadAnalysisRules())
+	assert.Equal(t, AnalysisActionRecover, GetAnalysisAction(ReplicationStopped, "other", "0"))
+	assert.Equal(t, 10, reasonableReplicationLagSeconds())
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtorc/config"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+)
+
+// FailureType is a failure which can be injected on a tablet, to run failover game days against real
+// clusters. The injected failures only change what VTOrc reads from the tablets, but the recoveries
+// VTOrc runs to fix them act on the tablets as usual.
+type FailureType string
+
+const (
+	// FailureDeadPrimary makes the primary tablet unreachable, and its replicas unable to replicate from it.
+	FailureDeadPrimary FailureType = "DeadPrimary"
+	// FailureErrantGTID adds an errant GTID to the executed GTID set of a replica.
+	FailureErrantGTID FailureType = "ErrantGTID"
+	// FailureReplicationStopped makes the replication of a replica stopped.
+	FailureReplicationStopped FailureType = "ReplicationStopped"
+
+	// injectedErrantGTIDSet is the errant GTID set added by FailureErrantGTID.
+	injectedErrantGTIDSet = "00000000-0000-0000-0000-00000000fa11:1"
+)
+
+// InjectedFailure is a failure injected on a tablet.
+type InjectedFailure struct {
+	TabletAlias string      `json:"tablet_alias"`
+	Failure     FailureType `json:"failure"`
+	ExpireAt    time.Time   `json:"expire_at"`
+
+	mysqlHostname string
+	mysqlPort     int32
+}
+
+var (
+	injectedFailuresMu sync.Mutex
+	// injectedFailures are the failures injected, by tablet alias.
+	injectedFailures = make(map[string]*InjectedFailure)
+)
+
+// InjectFailure injects a failure on the tablet, for the given duration. It replaces the failure
+// previously injected on the tablet, if any.
+func InjectFailure(tabletAlias string, failure FailureType, duration time.Duration) error {
+	if !config.FailureInjectionAllowed() {
+		return fmt.Errorf("failure injection is not allowed, it must be enabled with --allow-failure-injection")
+	}
+	switch failure {
+	case FailureDeadPrimary, FailureErrantGTID, FailureReplicationStopped:
+	default:
+		return fmt.Errorf("unknown failure %q, must be one of %v, %v or %v", failure, FailureDeadPrimary, FailureErrantGTID, FailureReplicationStopped)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("the duration of the failure must be positive")
+	}
+	tablet, err := ReadTablet(tabletAlias)
+	if err != nil {
+		return err
+	}
+	if tablet == nil {
+		return fmt.Errorf("tablet %v not found", tabletAlias)
+	}
+
+	injectedFailuresMu.Lock()
+	defer injectedFailuresMu.Unlock()
+	injectedFailures[tabletAlias] = &InjectedFailure{
+		TabletAlias:   tabletAlias,
+		Failure:       failure,
+		ExpireAt:      time.Now().Add(duration),
+		mysqlHostname: tablet.MysqlHostname,
+		mysqlPort:     tablet.MysqlPort,
+	}
+	log.Warningf("Injected %v failure on %v for %v", failure, tabletAlias, duration)
+	_ = AuditOperation("inject-failure", tabletAlias, fmt.Sprintf("Injected %v failure for %v", failure, duration))
+	return nil
+}
+
+// ClearInjectedFailures clears the failure injected on the tablet, or on all the tablets if the
+// tablet alias is empty.
+func ClearInjectedFailures(tabletAlias string) {
+	injectedFailuresMu.Lock()
+	defer injectedFailuresMu.Unlock()
+	if tabletAlias == "" {
+		injectedFailures = make(map[string]*InjectedFailure)
+	} else {
+		delete(injectedFailures, tabletAlias)
+	}
+	log.Infof("Cleared the failures injected on %q", tabletAlias)
+	_ = AuditOperation("clear-injected-failures", tabletAlias, "Cleared the injected failures")
+}
+
+// GetInjectedFailures returns the failures currently injected, sorted by tablet alias.
+func GetInjectedFailures() []*InjectedFailure {
+	injectedFailuresMu.Lock()
+	defer injectedFailuresMu.Unlock()
+	expireInjectedFailures()
+	failures := make([]*InjectedFailure, 0, len(injectedFailures))
+	for _, failure := range injectedFailures {
+		failures = append(failures, failure)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].TabletAlias < failures[j].TabletAlias
+	})
+	return failures
+}
+
+// expireInjectedFailures removes the expired failures. injectedFailuresMu must be held.
+func expireInjectedFailures() {
+	now := time.Now()
+	for tabletAlias, failure := range injectedFailures {
+		if now.After(failure.ExpireAt) {
+			delete(injectedFailures, tabletAlias)
+			log.Infof("The %v failure injected on %v expired", failure.Failure, tabletAlias)
+		}
+	}
+}
+
+// applyInjectedFailures changes the full status read from the tablet according to the failures
+// injected on it, or on the primary it replicates from.
+func applyInjectedFailures(tabletAlias string, fullStatus *replicationdatapb.FullStatus) (*replicationdatapb.FullStatus, error) {
+	injectedFailuresMu.Lock()
+	defer injectedFailuresMu.Unlock()
+	if len(injectedFailures) == 0 {
+		return fullStatus, nil
+	}
+	expireInjectedFailures()
+
+	fullStatus = proto.Clone(fullStatus).(*replicationdatapb.FullStatus)
+	if failure, ok := injectedFailures[tabletAlias]; ok {
+		switch failure.Failure {
+		case FailureDeadPrimary:
+			return nil, fmt.Errorf("injected %v failure on %v", failure.Failure, tabletAlias)
+		case FailureReplicationStopped:
+			if fullStatus.ReplicationStatus != nil {
+				fullStatus.ReplicationStatus.IoState = int32(replication.ReplicationStateStopped)
+				fullStatus.ReplicationStatus.SqlState = int32(replication.ReplicationStateStopped)
+			}
+		case FailureErrantGTID:
+			if fullStatus.ReplicationStatus != nil && fullStatus.PrimaryStatus != nil {
+				position, err := addInjectedErrantGTID(fullStatus.PrimaryStatus.Position)
+				if err != nil {
+					return nil, err
+				}
+				fullStatus.PrimaryStatus.Position = position
+			}
+		}
+	}
+	if fullStatus.ReplicationStatus != nil {
+		for _, failure := range injectedFailures {
+			if failure.Failure == FailureDeadPrimary &&
+				failure.mysqlHostname == fullStatus.ReplicationStatus.SourceHost && failure.mysqlPort == fullStatus.ReplicationStatus.SourcePort {
+				fullStatus.ReplicationStatus.IoState = int32(replication.ReplicationStateConnecting)
+				fullStatus.ReplicationStatus.LastIoError = fmt.Sprintf("injected %v failure on %v", failure.Failure, failure.TabletAlias)
+			}
+		}
+	}
+	return fullStatus, nil
+}
+
+// addInjectedErrantGTID adds the injected errant GTID set to the encoded position.
+func addInjectedErrantGTID(encodedPosition string) (string, error) {
+	position, err := replication.DecodePosition(encodedPosition)
+	if err != nil {
+		return "", err
+	}
+	errantGTIDSet, err := replication.ParseMysql56GTIDSet(injectedErrantGTIDSet)
+	if err != nil {
+		return "", err
+	}
+	if position.GTIDSet == nil {
+		position.GTIDSet = errantGTIDSet
+	} else {
+		position.GTIDSet = errantGTIDSet.Union(position.GTIDSet)
+	}
+	return replication.EncodePosition(position), nil
+}
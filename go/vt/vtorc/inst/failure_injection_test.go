@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/db"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestInjectFailures(t *testing.T) {
+	defer func() {
+		db.ClearVTOrcDatabase()
+		ClearInjectedFailures("")
+		config.SetFailureInjectionAllowed(false)
+	}()
+
+	for _, tablet := range []*topodatapb.Tablet{{
+		Alias:         &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace:      "ks",
+		Shard:         "0",
+		Type:          topodatapb.TabletType_PRIMARY,
+		MysqlHostname: "localhost",
+		MysqlPort:     1100,
+	}, {
+		Alias:         &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace:      "ks",
+		Shard:         "0",
+		Type:          topodatapb.TabletType_REPLICA,
+		MysqlHostname: "localhost",
+		MysqlPort:     1101,
+	}} {
+		require.NoError(t, SaveTablet(tablet))
+	}
+	replicaStatus := func() *replicationdatapb.FullStatus {
+		return &replicationdatapb.FullStatus{
+			ReplicationStatus: &replicationdatapb.Status{
+				SourceHost: "localhost",
+				SourcePort: 1100,
+				IoState:    int32(replication.ReplicationStateRunning),
+				SqlState:   int32(replication.ReplicationStateRunning),
+			},
+			PrimaryStatus: &replicationdatapb.PrimaryStatus{
+				Position: "MySQL56/00000000-0000-0000-0000-000000000100:1-10",
+			},
+		}
+	}
+
+	err := InjectFailure("zone1-0000000100", FailureDeadPrimary, time.Minute)
+	require.ErrorContains(t, err, "--allow-failure-injection")
+	config.SetFailureInjectionAllowed(true)
+	err = InjectFailure("zone1-0000000100", "Meteor", time.Minute)
+	require.ErrorContains(t, err, "unknown failure")
+
+	t.Run("dead primary", func(t *testing.T) {
+		defer ClearInjectedFailures("")
+		require.NoError(t, InjectFailure("zone1-0000000100", FailureDeadPrimary, time.Minute))
+
+		_, err := applyInjectedFailures("zone1-0000000100", &replicationdatapb.FullStatus{})
+		assert.ErrorContains(t, err, "injected DeadPrimary failure")
+		status := replicaStatus()
+		fullStatus, err := applyInjectedFailures("zone1-0000000101", status)
+		require.NoError(t, err)
+		assert.EqualValues(t, replication.ReplicationStateConnecting, fullStatus.ReplicationStatus.IoState)
+		assert.EqualValues(t, replication.ReplicationStateRunning, fullStatus.ReplicationStatus.SqlState)
+		// The status read from the tablet is left untouched.
+		assert.EqualValues(t, replication.ReplicationStateRunning, status.ReplicationStatus.IoState)
+	})
+
+	t.Run("replication stopped", func(t *testing.T) {
+		defer ClearInjectedFailures("")
+		require.NoError(t, InjectFailure("zone1-0000000101", FailureReplicationStopped, time.Minute))
+
+		fullStatus, err := applyInjectedFailures("zone1-0000000101", replicaStatus())
+		require.NoError(t, err)
+		assert.EqualValues(t, replication.ReplicationStateStopped, fullStatus.ReplicationStatus.IoState)
+		assert.EqualValues(t, replication.ReplicationStateStopped, fullStatus.ReplicationStatus.SqlState)
+	})
+
+	t.Run("errant GTID", func(t *testing.T) {
+		defer ClearInjectedFailures("")
+		require.NoError(t, InjectFailure("zone1-0000000101", FailureErrantGTID, time.Minute))
+
+		fullStatus, err := applyInjectedFailures("zone1-0000000101", replicaStatus())
+		require.NoError(t, err)
+		assert.Equal(t, "MySQL56/00000000-0000-0000-0000-000000000100:1-10,00000000-0000-0000-0000-00000000fa11:1", fullStatus.PrimaryStatus.Position)
+	})
+
+	t.Run("expired failures", func(t *testing.T) {
+		defer ClearInjectedFailures("")
+		require.NoError(t, InjectFailure("zone1-0000000101", FailureReplicationStopped, time.Minute))
+		require.NoError(t, InjectFailure("zone1-0000000100", FailureDeadPrimary, time.Nanosecond))
+		time.Sleep(time.Millisecond)
+
+		failures := GetInjectedFailures()
+		require.Len(t, failures, 1)
+		assert.Equal(t, "zone1-0000000101", failures[0].TabletAlias)
+		_, err := applyInjectedFailures("zone1-0000000100", &replicationdatapb.FullStatus{})
+		assert.NoError(t, err)
+	})
+}
@@ -18,6 +18,7 @@ package inst
 
 import (
 	"errors"
+	"strings"
 
 	"vitess.io/vitess/go/vt/external/golib/sqlutils"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -38,7 +39,9 @@ func ReadKeyspace(keyspaceName string) (*topo.KeyspaceInfo, error) {
 	query := `
 		select
 			keyspace_type,
-			durability_policy
+			durability_policy,
+			failover_prefer_same_cell,
+			failover_never_promote_cells
 		from
 			vitess_keyspace
 		where keyspace=?
@@ -50,6 +53,12 @@ func ReadKeyspace(keyspaceName string) (*topo.KeyspaceInfo, error) {
 	err := db.QueryVTOrc(query, args, func(row sqlutils.RowMap) error {
 		keyspace.KeyspaceType = topodatapb.KeyspaceType(row.GetInt32("keyspace_type"))
 		keyspace.DurabilityPolicy = row.GetString("durability_policy")
+		if preferSameCell, neverPromoteCells := row.GetBool("failover_prefer_same_cell"), row.GetString("failover_never_promote_cells"); preferSameCell || neverPromoteCells != "" {
+			keyspace.FailoverPreferences = &topodatapb.FailoverPreferences{PreferSameCell: preferSameCell}
+			if neverPromoteCells != "" {
+				keyspace.FailoverPreferences.NeverPromoteCells = strings.Split(neverPromoteCells, ",")
+			}
+		}
 		keyspace.SetKeyspaceName(keyspaceName)
 		return nil
 	})
@@ -67,14 +76,19 @@ func SaveKeyspace(keyspace *topo.KeyspaceInfo) error {
 	_, err := db.ExecVTOrc(`
 		replace
 			into vitess_keyspace (
-				keyspace, keyspace_type, durability_policy
+				keyspace, keyspace_type, durability_policy, vtorc_maintenance_reason, vtorc_maintenance_expire_time,
+				failover_prefer_same_cell, failover_never_promote_cells
 			) values (
-				?, ?, ?
+				?, ?, ?, ?, ?, ?, ?
 			)
 		`,
 		keyspace.KeyspaceName(),
 		int(keyspace.KeyspaceType),
 		keyspace.GetDurabilityPolicy(),
+		keyspace.GetVtorcMaintenance().GetReason(),
+		getVtorcMaintenanceExpireTime(keyspace.GetVtorcMaintenance()),
+		keyspace.GetFailoverPreferences().GetPreferSameCell(),
+		strings.Join(keyspace.GetFailoverPreferences().GetNeverPromoteCells(), ","),
 	)
 	return err
 }
@@ -87,3 +101,12 @@ func GetDurabilityPolicy(keyspace string) (reparentutil.Durabler, error) {
 	}
 	return reparentutil.GetDurabilityPolicy(ki.DurabilityPolicy)
 }
+
+// GetFailoverPreferences gets the failover preferences for the given keyspace.
+func GetFailoverPreferences(keyspace string) (*topodatapb.FailoverPreferences, error) {
+	ki, err := ReadKeyspace(keyspace)
+	if err != nil {
+		return nil, err
+	}
+	return ki.FailoverPreferences, nil
+}
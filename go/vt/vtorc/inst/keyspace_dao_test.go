@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	_ "modernc.org/sqlite"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -81,6 +82,18 @@ func TestSaveAndReadKeyspace(t *testing.T) {
 				DurabilityPolicy: "none",
 			},
 			semiSyncAckersWanted: 0,
+		}, {
+			name:         "Success with failover preferences",
+			keyspaceName: "ks6",
+			keyspace: &topodatapb.Keyspace{
+				KeyspaceType:     topodatapb.KeyspaceType_NORMAL,
+				DurabilityPolicy: "none",
+				FailoverPreferences: &topodatapb.FailoverPreferences{
+					PreferSameCell:    true,
+					NeverPromoteCells: []string{"zone2", "zone3"},
+				},
+			},
+			semiSyncAckersWanted: 0,
 		}, {
 			name:           "No keyspace found",
 			keyspaceName:   "ks5",
@@ -111,6 +124,7 @@ func TestSaveAndReadKeyspace(t *testing.T) {
 			}
 			require.NoError(t, err)
 			require.True(t, topotools.KeyspaceEquality(tt.keyspaceWanted, readKeyspaceInfo.Keyspace))
+			require.True(t, proto.Equal(tt.keyspaceWanted.FailoverPreferences, readKeyspaceInfo.FailoverPreferences))
 			require.Equal(t, tt.keyspaceName, readKeyspaceInfo.KeyspaceName())
 			if tt.keyspace.KeyspaceType == topodatapb.KeyspaceType_SNAPSHOT {
 				return
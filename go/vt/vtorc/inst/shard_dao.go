@@ -67,15 +67,18 @@ func SaveShard(shard *topo.ShardInfo) error {
 	_, err := db.ExecVTOrc(`
 		replace
 			into vitess_shard (
-				keyspace, shard, primary_alias, primary_timestamp
+				keyspace, shard, primary_alias, primary_timestamp, semi_sync_fallback_alias, vtorc_maintenance_reason, vtorc_maintenance_expire_time
 			) values (
-				?, ?, ?, ?
+				?, ?, ?, ?, ?, ?, ?
 			)
 		`,
 		shard.Keyspace(),
 		shard.ShardName(),
 		getShardPrimaryAliasString(shard),
 		getShardPrimaryTermStartTimeString(shard),
+		getShardSemiSyncFallbackAliasString(shard),
+		shard.GetVtorcMaintenance().GetReason(),
+		getVtorcMaintenanceExpireTime(shard.GetVtorcMaintenance()),
 	)
 	return err
 }
@@ -88,6 +91,15 @@ func getShardPrimaryAliasString(shard *topo.ShardInfo) string {
 	return topoproto.TabletAliasString(shard.PrimaryAlias)
 }
 
+// getShardSemiSyncFallbackAliasString gets the alias of the primary that has semi-sync disabled by its
+// semi-sync monitor, to be stored as a string in the database.
+func getShardSemiSyncFallbackAliasString(shard *topo.ShardInfo) string {
+	if shard.SemiSyncFallback == nil || shard.SemiSyncFallback.PrimaryAlias == nil {
+		return ""
+	}
+	return topoproto.TabletAliasString(shard.SemiSyncFallback.PrimaryAlias)
+}
+
 // getShardPrimaryAliasString gets the shard primary term start time to be stored as a string in the database.
 func getShardPrimaryTermStartTimeString(shard *topo.ShardInfo) string {
 	if shard.PrimaryTermStartTime == nil {
@@ -60,7 +60,11 @@ func FullStatus(tabletAlias string) (*replicationdatapb.FullStatus, error) {
 	tmc := tmclient.NewTabletManagerClient()
 	tmcCtx, tmcCancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
 	defer tmcCancel()
-	return tmc.FullStatus(tmcCtx, tablet)
+	fullStatus, err := tmc.FullStatus(tmcCtx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	return applyInjectedFailures(tabletAlias, fullStatus)
 }
 
 // ReadTablet reads the vitess tablet record.
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/external/golib/sqlutils"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/vtorc/db"
+)
+
+// ReadVtorcMaintenance reads the maintenance which disables the recoveries on the shard, from the shard
+// and keyspace records. If both are in maintenance, the one which expires last is returned. It returns
+// nil if neither is in maintenance, or if their maintenances expired.
+func ReadVtorcMaintenance(keyspaceName, shardName string) (*topodatapb.VtorcMaintenance, error) {
+	query := `
+		select
+			vtorc_maintenance_reason, vtorc_maintenance_expire_time
+		from
+			vitess_keyspace
+		where keyspace=? and vtorc_maintenance_expire_time>?
+		union all
+		select
+			vtorc_maintenance_reason, vtorc_maintenance_expire_time
+		from
+			vitess_shard
+		where keyspace=? and shard=? and vtorc_maintenance_expire_time>?
+		order by vtorc_maintenance_expire_time desc
+		limit 1
+		`
+	now := time.Now().Unix()
+	var maintenance *topodatapb.VtorcMaintenance
+	err := db.QueryVTOrc(query, sqlutils.Args(keyspaceName, now, keyspaceName, shardName, now), func(row sqlutils.RowMap) error {
+		maintenance = &topodatapb.VtorcMaintenance{
+			Reason:     row.GetString("vtorc_maintenance_reason"),
+			ExpireTime: protoutil.TimeToProto(time.Unix(row.GetInt64("vtorc_maintenance_expire_time"), 0)),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return maintenance, nil
+}
+
+// getVtorcMaintenanceExpireTime gets the expiry of a maintenance to be stored in the database, as a unix
+// timestamp, or 0 if there is no maintenance.
+func getVtorcMaintenanceExpireTime(maintenance *topodatapb.VtorcMaintenance) int64 {
+	if maintenance.GetExpireTime() == nil {
+		return 0
+	}
+	return protoutil.TimeFromProto(maintenance.ExpireTime).Unix()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"vitess.io/vitess/go/protoutil"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtorc/db"
+)
+
+func TestReadVtorcMaintenance(t *testing.T) {
+	// Clear the database after the test. The easiest way to do that is to run all the initialization commands again.
+	defer func() {
+		db.ClearVTOrcDatabase()
+	}()
+	now := time.Now().Truncate(time.Second)
+	maintenance := func(reason string, expire time.Time) *topodatapb.VtorcMaintenance {
+		return &topodatapb.VtorcMaintenance{
+			Reason:     reason,
+			ExpireTime: protoutil.TimeToProto(expire),
+		}
+	}
+	saveKeyspace := func(m *topodatapb.VtorcMaintenance) {
+		keyspaceInfo := &topo.KeyspaceInfo{Keyspace: &topodatapb.Keyspace{VtorcMaintenance: m}}
+		keyspaceInfo.SetKeyspaceName("ks1")
+		require.NoError(t, SaveKeyspace(keyspaceInfo))
+	}
+	saveShard := func(m *topodatapb.VtorcMaintenance) {
+		require.NoError(t, SaveShard(topo.NewShardInfo("ks1", "-80", &topodatapb.Shard{VtorcMaintenance: m}, nil)))
+	}
+
+	tests := []struct {
+		name     string
+		keyspace *topodatapb.VtorcMaintenance
+		shard    *topodatapb.VtorcMaintenance
+		want     *topodatapb.VtorcMaintenance
+		// wantOtherShard is the maintenance of another shard of the keyspace.
+		wantOtherShard *topodatapb.VtorcMaintenance
+	}{
+		{
+			name: "No maintenance",
+		}, {
+			name:  "Shard maintenance",
+			shard: maintenance("upgrade", now.Add(time.Hour)),
+			want:  maintenance("upgrade", now.Add(time.Hour)),
+		}, {
+			name:           "Keyspace maintenance",
+			keyspace:       maintenance("migration", now.Add(time.Hour)),
+			want:           maintenance("migration", now.Add(time.Hour)),
+			wantOtherShard: maintenance("migration", now.Add(time.Hour)),
+		}, {
+			name:           "The maintenance which expires last wins",
+			keyspace:       maintenance("migration", now.Add(time.Hour)),
+			shard:          maintenance("upgrade", now.Add(2*time.Hour)),
+			want:           maintenance("upgrade", now.Add(2*time.Hour)),
+			wantOtherShard: maintenance("migration", now.Add(time.Hour)),
+		}, {
+			name:     "Expired maintenances are ignored",
+			keyspace: maintenance("migration", now.Add(-time.Hour)),
+			shard:    maintenance("upgrade", now.Add(-time.Minute)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saveKeyspace(tt.keyspace)
+			saveShard(tt.shard)
+
+			got, err := ReadVtorcMaintenance("ks1", "-80")
+			require.NoError(t, err)
+			require.EqualValues(t, tt.want, got)
+
+			got, err = ReadVtorcMaintenance("ks1", "80-")
+			require.NoError(t, err)
+			require.EqualValues(t, tt.wantOtherShard, got)
+		})
+	}
+}
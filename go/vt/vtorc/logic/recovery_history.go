@@ -0,0 +1,292 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/external/golib/sqlutils"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtorc/db"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// historyTimeFormat is the format of the timestamps written by the now() of
+// the VTOrc database, which the time ranges of the filters are compared with.
+const historyTimeFormat = "2006-01-02 15:04:05"
+
+// HistoryFilter filters the recoveries and the failure detections read by
+// ReadRecoveryHistory and ReadDetectionHistory. Its zero value matches all of
+// them.
+type HistoryFilter struct {
+	Keyspace string
+	// Shard can only be set along with Keyspace.
+	Shard    string
+	Analysis inst.AnalysisCode
+	// Since and Until, if set, bound the time at which the recoveries or
+	// the detections started.
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of entries to read, the most recent ones.
+	// Zero reads all of them.
+	Limit int
+}
+
+func (filter *HistoryFilter) whereClause() (string, []any) {
+	var conditions []string
+	var args []any
+	if filter.Keyspace != "" {
+		conditions = append(conditions, "keyspace = ?")
+		args = append(args, filter.Keyspace)
+	}
+	if filter.Shard != "" {
+		conditions = append(conditions, "shard = ?")
+		args = append(args, filter.Shard)
+	}
+	if filter.Analysis != "" {
+		conditions = append(conditions, "analysis = ?")
+		args = append(args, string(filter.Analysis))
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "start_active_period >= ?")
+		args = append(args, filter.Since.UTC().Format(historyTimeFormat))
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "start_active_period <= ?")
+		args = append(args, filter.Until.UTC().Format(historyTimeFormat))
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "where " + strings.Join(conditions, " and "), args
+}
+
+func (filter *HistoryFilter) limitClause(args []any) (string, []any) {
+	if filter.Limit <= 0 {
+		return "", args
+	}
+	return "limit ?", append(args, filter.Limit)
+}
+
+// RecoveryRecord is a recovery run by VTOrc, as returned by its recoveries API.
+type RecoveryRecord struct {
+	ID                    int64             `json:"id"`
+	UID                   string            `json:"uid"`
+	Analysis              inst.AnalysisCode `json:"analysis"`
+	TabletAlias           string            `json:"tablet_alias"`
+	Keyspace              string            `json:"keyspace"`
+	Shard                 string            `json:"shard"`
+	CountAffectedReplicas uint              `json:"count_affected_replicas"`
+	StartTime             string            `json:"start_time"`
+	EndTime               string            `json:"end_time,omitempty"`
+	IsActive              bool              `json:"is_active"`
+	IsSuccessful          bool              `json:"is_successful"`
+	PromotedTabletAlias   string            `json:"promoted_tablet_alias,omitempty"`
+	Errors                []string          `json:"errors,omitempty"`
+	// DetectionID is the ID of the failure detection which led to the recovery.
+	DetectionID        int64                 `json:"detection_id"`
+	ProcessingNode     string                `json:"processing_node"`
+	Acknowledged       bool                  `json:"acknowledged"`
+	AcknowledgedAt     string                `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy     string                `json:"acknowledged_by,omitempty"`
+	AcknowledgeComment string                `json:"acknowledge_comment,omitempty"`
+	Steps              []*RecoveryStepRecord `json:"steps"`
+}
+
+// RecoveryStepRecord is a step of a RecoveryRecord.
+type RecoveryStepRecord struct {
+	Time    string `json:"time"`
+	Message string `json:"message"`
+}
+
+// DetectionRecord is a failure detected by VTOrc, as returned by its
+// detections API.
+type DetectionRecord struct {
+	ID                    int64             `json:"id"`
+	Analysis              inst.AnalysisCode `json:"analysis"`
+	TabletAlias           string            `json:"tablet_alias"`
+	Keyspace              string            `json:"keyspace"`
+	Shard                 string            `json:"shard"`
+	CountAffectedReplicas uint              `json:"count_affected_replicas"`
+	// IsActionable is true if the problem is fixed by a recovery which acts
+	// on the tablets.
+	IsActionable   bool   `json:"is_actionable"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time,omitempty"`
+	IsActive       bool   `json:"is_active"`
+	ProcessingNode string `json:"processing_node"`
+}
+
+// ReadRecoveryHistory reads the recoveries which match the filter, with their
+// steps, from the most recent one.
+func ReadRecoveryHistory(filter *HistoryFilter) ([]*RecoveryRecord, error) {
+	whereClause, args := filter.whereClause()
+	limitClause, args := filter.limitClause(args)
+	query := fmt.Sprintf(`
+		select
+			recovery_id,
+			uid,
+			analysis,
+			alias,
+			keyspace,
+			shard,
+			count_affected_replicas,
+			start_active_period,
+			end_recovery,
+			(IFNULL(end_active_period_unixtime, 0) = 0) as is_active,
+			is_successful,
+			ifnull(successor_alias, '') as successor_alias,
+			all_errors,
+			last_detection_id,
+			processing_node_hostname,
+			acknowledged,
+			acknowledged_at,
+			acknowledged_by,
+			acknowledge_comment
+		from
+			topology_recovery
+		%s
+		order by
+			recovery_id desc
+		%s
+		`, whereClause, limitClause)
+
+	recoveries := []*RecoveryRecord{}
+	recoveriesByUID := map[string]*RecoveryRecord{}
+	err := db.QueryVTOrc(query, args, func(m sqlutils.RowMap) error {
+		recovery := &RecoveryRecord{
+			ID:                    m.GetInt64("recovery_id"),
+			UID:                   m.GetString("uid"),
+			Analysis:              inst.AnalysisCode(m.GetString("analysis")),
+			TabletAlias:           m.GetString("alias"),
+			Keyspace:              m.GetString("keyspace"),
+			Shard:                 m.GetString("shard"),
+			CountAffectedReplicas: m.GetUint("count_affected_replicas"),
+			StartTime:             m.GetString("start_active_period"),
+			EndTime:               m.GetString("end_recovery"),
+			IsActive:              m.GetBool("is_active"),
+			IsSuccessful:          m.GetBool("is_successful"),
+			PromotedTabletAlias:   m.GetString("successor_alias"),
+			DetectionID:           m.GetInt64("last_detection_id"),
+			ProcessingNode:        m.GetString("processing_node_hostname"),
+			Acknowledged:          m.GetBool("acknowledged"),
+			AcknowledgedAt:        m.GetString("acknowledged_at"),
+			AcknowledgedBy:        m.GetString("acknowledged_by"),
+			AcknowledgeComment:    m.GetString("acknowledge_comment"),
+			Steps:                 []*RecoveryStepRecord{},
+		}
+		if allErrors := m.GetString("all_errors"); allErrors != "" {
+			recovery.Errors = strings.Split(allErrors, "\n")
+		}
+		recoveries = append(recoveries, recovery)
+		recoveriesByUID[recovery.UID] = recovery
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if len(recoveries) == 0 {
+		return recoveries, nil
+	}
+
+	uids := make([]any, 0, len(recoveries))
+	for _, recovery := range recoveries {
+		uids = append(uids, recovery.UID)
+	}
+	query = fmt.Sprintf(`
+		select
+			recovery_uid,
+			audit_at,
+			message
+		from
+			topology_recovery_steps
+		where
+			recovery_uid in (%s)
+		order by
+			recovery_step_id
+		`, strings.TrimSuffix(strings.Repeat("?, ", len(uids)), ", "))
+	err = db.QueryVTOrc(query, uids, func(m sqlutils.RowMap) error {
+		if recovery, ok := recoveriesByUID[m.GetString("recovery_uid")]; ok {
+			recovery.Steps = append(recovery.Steps, &RecoveryStepRecord{
+				Time:    m.GetString("audit_at"),
+				Message: m.GetString("message"),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return recoveries, nil
+}
+
+// ReadDetectionHistory reads the failure detections which match the filter,
+// from the most recent one.
+func ReadDetectionHistory(filter *HistoryFilter) ([]*DetectionRecord, error) {
+	whereClause, args := filter.whereClause()
+	limitClause, args := filter.limitClause(args)
+	query := fmt.Sprintf(`
+		select
+			detection_id,
+			analysis,
+			alias,
+			keyspace,
+			shard,
+			count_affected_replicas,
+			is_actionable,
+			start_active_period,
+			end_active_period_unixtime,
+			in_active_period,
+			processing_node_hostname
+		from
+			topology_failure_detection
+		%s
+		order by
+			detection_id desc
+		%s
+		`, whereClause, limitClause)
+
+	detections := []*DetectionRecord{}
+	err := db.QueryVTOrc(query, args, func(m sqlutils.RowMap) error {
+		detection := &DetectionRecord{
+			ID:                    m.GetInt64("detection_id"),
+			Analysis:              inst.AnalysisCode(m.GetString("analysis")),
+			TabletAlias:           m.GetString("alias"),
+			Keyspace:              m.GetString("keyspace"),
+			Shard:                 m.GetString("shard"),
+			CountAffectedReplicas: m.GetUint("count_affected_replicas"),
+			IsActionable:          m.GetBool("is_actionable"),
+			StartTime:             m.GetString("start_active_period"),
+			IsActive:              m.GetBool("in_active_period"),
+			ProcessingNode:        m.GetString("processing_node_hostname"),
+		}
+		if end := m.GetInt64("end_active_period_unixtime"); end > 0 {
+			detection.EndTime = time.Unix(end, 0).UTC().Format(time.RFC3339)
+		}
+		detections = append(detections, detection)
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return detections, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtorc/db"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+func TestReadHistory(t *testing.T) {
+	orcDb, err := db.OpenVTOrc()
+	require.NoError(t, err)
+	defer func() {
+		for _, table := range []string{"topology_recovery", "topology_recovery_steps", "topology_failure_detection"} {
+			_, err = orcDb.Exec("delete from " + table)
+			require.NoError(t, err)
+		}
+	}()
+
+	_, err = db.ExecVTOrc(`insert into topology_recovery (
+			recovery_id, uid, alias, start_active_period, end_active_period_unixtime, end_recovery, processing_node_hostname,
+			processcing_node_token, successor_alias, analysis, keyspace, shard, is_successful, all_errors, last_detection_id
+		) values
+			(1, 'uid1', 'zone1-0000000100', '2023-10-01 10:00:00', 1696154460, '2023-10-01 10:00:05', 'vtorc1', 'token', 'zone1-0000000101', ?, 'ks', '0', 1, '', 1),
+			(2, 'uid2', 'zone1-0000000200', '2023-10-02 10:00:00', 0, NULL, 'vtorc1', 'token', NULL, ?, 'ks', '80-', 0, ?, 2)`,
+		string(inst.DeadPrimary), string(inst.DeadPrimary), "failed\nagain")
+	require.NoError(t, err)
+	_, err = db.ExecVTOrc(`insert into topology_recovery_steps (recovery_step_id, recovery_uid, audit_at, message) values
+			(1, 'uid1', '2023-10-01 10:00:01', 'first'),
+			(2, 'uid1', '2023-10-01 10:00:02', 'second'),
+			(3, 'uid2', '2023-10-02 10:00:01', 'other')`)
+	require.NoError(t, err)
+	_, err = db.ExecVTOrc(`insert into topology_failure_detection (
+			detection_id, alias, in_active_period, start_active_period, end_active_period_unixtime, processing_node_hostname,
+			processcing_node_token, analysis, keyspace, shard, count_affected_replicas, is_actionable
+		) values
+			(1, 'zone1-0000000100', 0, '2023-10-01 09:59:59', 1696154460, 'vtorc1', 'token', ?, 'ks', '0', 2, 1),
+			(2, 'zone1-0000000200', 1, '2023-10-02 09:59:59', 0, 'vtorc1', 'token', ?, 'ks', '80-', 1, 1),
+			(3, 'zone1-0000000201', 1, '2023-10-02 10:30:00', 0, 'vtorc1', 'token', ?, 'ks', '80-', 0, 0)`,
+		string(inst.DeadPrimary), string(inst.DeadPrimary), string(inst.ReplicationStopped))
+	require.NoError(t, err)
+
+	recoveries, err := ReadRecoveryHistory(&HistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, recoveries, 2)
+	assert.EqualValues(t, 2, recoveries[0].ID)
+	assert.True(t, recoveries[0].IsActive)
+	assert.Equal(t, []string{"failed", "again"}, recoveries[0].Errors)
+	assert.Len(t, recoveries[0].Steps, 1)
+
+	assert.EqualValues(t, 1, recoveries[1].ID)
+	assert.False(t, recoveries[1].IsActive)
+	assert.True(t, recoveries[1].IsSuccessful)
+	assert.Equal(t, "zone1-0000000101", recoveries[1].PromotedTabletAlias)
+	assert.EqualValues(t, 1, recoveries[1].DetectionID)
+	assert.Empty(t, recoveries[1].Errors)
+	require.Len(t, recoveries[1].Steps, 2)
+	assert.Equal(t, "first", recoveries[1].Steps[0].Message)
+	assert.Equal(t, "second", recoveries[1].Steps[1].Message)
+
+	recoveries, err = ReadRecoveryHistory(&HistoryFilter{Keyspace: "ks", Shard: "0"})
+	require.NoError(t, err)
+	require.Len(t, recoveries, 1)
+	assert.EqualValues(t, 1, recoveries[0].ID)
+
+	recoveries, err = ReadRecoveryHistory(&HistoryFilter{Since: time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	require.Len(t, recoveries, 1)
+	assert.EqualValues(t, 2, recoveries[0].ID)
+
+	recoveries, err = ReadRecoveryHistory(&HistoryFilter{Until: time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	require.Len(t, recoveries, 1)
+	assert.EqualValues(t, 1, recoveries[0].ID)
+
+	recoveries, err = ReadRecoveryHistory(&HistoryFilter{Analysis: inst.ReplicationStopped})
+	require.NoError(t, err)
+	assert.Empty(t, recoveries)
+
+	detections, err := ReadDetectionHistory(&HistoryFilter{Analysis: inst.DeadPrimary})
+	require.NoError(t, err)
+	require.Len(t, detections, 2)
+	assert.EqualValues(t, 2, detections[0].ID)
+	assert.True(t, detections[0].IsActive)
+	assert.Empty(t, detections[0].EndTime)
+	assert.EqualValues(t, 1, detections[1].ID)
+	assert.Equal(t, "2023-10-01T10:01:00Z", detections[1].EndTime)
+	assert.EqualValues(t, 2, detections[1].CountAffectedReplicas)
+
+	detections, err = ReadDetectionHistory(&HistoryFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, detections, 1)
+	assert.EqualValues(t, 3, detections[0].ID)
+	assert.False(t, detections[0].IsActionable)
+}
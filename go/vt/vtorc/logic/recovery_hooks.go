@@ -0,0 +1,186 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+	"vitess.io/vitess/go/vt/vtorc/process"
+)
+
+// RecoveryHookPhase is when a recovery hook runs.
+type RecoveryHookPhase string
+
+const (
+	PreRecoveryHookPhase  RecoveryHookPhase = "pre-recovery"
+	PostRecoveryHookPhase RecoveryHookPhase = "post-recovery"
+)
+
+// recoveryHookFailuresCounter counts the recovery hooks which failed, by phase.
+var recoveryHookFailuresCounter = stats.NewCountersWithSingleLabel("RecoveryHookFailures", "Count of the recovery hooks which failed", "Phase",
+	string(PreRecoveryHookPhase), string(PostRecoveryHookPhase))
+
+// RecoveryHookPayload is the JSON document which VTOrc POSTs to the webhooks,
+// and writes to the standard input of the commands, of the recovery hooks.
+type RecoveryHookPayload struct {
+	Phase        RecoveryHookPhase `json:"phase"`
+	Recovery     string            `json:"recovery"`
+	Analysis     inst.AnalysisCode `json:"analysis"`
+	Description  string            `json:"description,omitempty"`
+	TabletAlias  string            `json:"tablet_alias"`
+	TabletType   string            `json:"tablet_type"`
+	PrimaryAlias string            `json:"primary_alias,omitempty"`
+	Keyspace     string            `json:"keyspace"`
+	Shard        string            `json:"shard"`
+	VTOrc        string            `json:"vtorc"`
+	StartTime    time.Time         `json:"start_time"`
+
+	// The following fields are only set in the post-recovery phase.
+
+	// Attempted is false if the recovery was not run, e.g. because a
+	// pre-recovery hook aborted it or another recovery is active on the shard.
+	Attempted           bool     `json:"attempted"`
+	Successful          bool     `json:"successful"`
+	PromotedTabletAlias string   `json:"promoted_tablet_alias,omitempty"`
+	Errors              []string `json:"errors,omitempty"`
+	Duration            string   `json:"duration,omitempty"`
+}
+
+func newRecoveryHookPayload(phase RecoveryHookPhase, recoveryName string, analysisEntry *inst.ReplicationAnalysis, start time.Time) *RecoveryHookPayload {
+	return &RecoveryHookPayload{
+		Phase:        phase,
+		Recovery:     recoveryName,
+		Analysis:     analysisEntry.Analysis,
+		Description:  analysisEntry.Description,
+		TabletAlias:  analysisEntry.AnalyzedInstanceAlias,
+		TabletType:   analysisEntry.TabletType.String(),
+		PrimaryAlias: analysisEntry.AnalyzedInstancePrimaryAlias,
+		Keyspace:     analysisEntry.AnalyzedKeyspace,
+		Shard:        analysisEntry.AnalyzedShard,
+		VTOrc:        process.ThisHostname,
+		StartTime:    start,
+	}
+}
+
+// runPreRecoveryHooks runs the pre-recovery hooks in order. It stops at, and
+// returns the error of, the first hook which fails, which aborts the recovery.
+func runPreRecoveryHooks(ctx context.Context, recoveryName string, analysisEntry *inst.ReplicationAnalysis, start time.Time) error {
+	payload := newRecoveryHookPayload(PreRecoveryHookPhase, recoveryName, analysisEntry, start)
+	for _, hook := range config.PreRecoveryHooks() {
+		if err := runRecoveryHook(ctx, hook, payload); err != nil {
+			recoveryHookFailuresCounter.Add(string(PreRecoveryHookPhase), 1)
+			return fmt.Errorf("pre-recovery hook %v aborted %v on %v: %w", hook, recoveryName, analysisEntry.AnalyzedInstanceAlias, err)
+		}
+	}
+	return nil
+}
+
+// runPostRecoveryHooks runs all the post-recovery hooks, with the outcome of
+// the recovery. Their failures are only logged, since the recovery is over.
+func runPostRecoveryHooks(recoveryName string, analysisEntry *inst.ReplicationAnalysis, start time.Time, attempted bool, topologyRecovery *TopologyRecovery, recoveryErr error) {
+	hooks := config.PostRecoveryHooks()
+	if len(hooks) == 0 {
+		return
+	}
+
+	payload := newRecoveryHookPayload(PostRecoveryHookPhase, recoveryName, analysisEntry, start)
+	payload.Attempted = attempted
+	payload.Successful = attempted && recoveryErr == nil
+	payload.Duration = time.Since(start).String()
+	if recoveryErr != nil {
+		payload.Errors = append(payload.Errors, recoveryErr.Error())
+	}
+	if topologyRecovery != nil {
+		payload.PromotedTabletAlias = topologyRecovery.SuccessorAlias
+		payload.Errors = append(payload.Errors, topologyRecovery.AllErrors...)
+	}
+
+	for _, hook := range hooks {
+		if err := runRecoveryHook(context.Background(), hook, payload); err != nil {
+			recoveryHookFailuresCounter.Add(string(PostRecoveryHookPhase), 1)
+			log.Errorf("post-recovery hook %v failed for %v on %v: %v", hook, recoveryName, analysisEntry.AnalyzedInstanceAlias, err)
+		}
+	}
+}
+
+// runRecoveryHook runs a single hook, within the timeout of the hooks. A hook
+// which is an http:// or https:// URL is a webhook, to which the payload is
+// POSTed, and which fails unless it responds with a 2xx status. Any other hook
+// is a shell command, which gets the payload on its standard input, and the
+// main fields of the payload in VTORC_* environment variables, and which
+// fails unless it exits with status 0.
+func runRecoveryHook(ctx context.Context, hook string, payload *RecoveryHookPayload) error {
+	ctx, cancel := context.WithTimeout(ctx, config.RecoveryHooksTimeout())
+	defer cancel()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Running %v hook %v for %v on %v", payload.Phase, hook, payload.Recovery, payload.TabletAlias)
+	if strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://") {
+		return runRecoveryWebhook(ctx, hook, data)
+	}
+	return runRecoveryCommand(ctx, hook, data, payload)
+}
+
+func runRecoveryWebhook(ctx context.Context, url string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%v returned %v", url, resp.Status)
+	}
+	return nil
+}
+
+func runRecoveryCommand(ctx context.Context, command string, data []byte, payload *RecoveryHookPayload) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		"VTORC_HOOK_PHASE="+string(payload.Phase),
+		"VTORC_RECOVERY="+payload.Recovery,
+		"VTORC_ANALYSIS="+string(payload.Analysis),
+		"VTORC_TABLET_ALIAS="+payload.TabletAlias,
+		"VTORC_KEYSPACE="+payload.Keyspace,
+		"VTORC_SHARD="+payload.Shard,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
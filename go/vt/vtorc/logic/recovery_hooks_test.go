@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+func TestRecoveryHooks(t *testing.T) {
+	defer config.SetRecoveryHooks(nil, nil)
+
+	var (
+		mu       sync.Mutex
+		payloads []RecoveryHookPayload
+		status   = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var payload RecoveryHookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	// reset clears the received payloads, and sets the status of the webhook.
+	reset := func(code int) {
+		mu.Lock()
+		defer mu.Unlock()
+		payloads = nil
+		status = code
+	}
+	received := func() []RecoveryHookPayload {
+		mu.Lock()
+		defer mu.Unlock()
+		return payloads
+	}
+
+	output := filepath.Join(t.TempDir(), "hook")
+	command := `cat > ` + output + ` && test "$VTORC_TABLET_ALIAS" = zone1-0000000100`
+
+	analysisEntry := &inst.ReplicationAnalysis{
+		Analysis:                     inst.DeadPrimary,
+		AnalyzedInstanceAlias:        "zone1-0000000100",
+		AnalyzedInstancePrimaryAlias: "zone1-0000000101",
+		TabletType:                   topodatapb.TabletType_PRIMARY,
+		AnalyzedKeyspace:             "ks",
+		AnalyzedShard:                "0",
+	}
+	start := time.Now()
+
+	t.Run("pre-recovery", func(t *testing.T) {
+		config.SetRecoveryHooks([]string{server.URL, command}, nil)
+		require.NoError(t, runPreRecoveryHooks(context.Background(), RecoverDeadPrimaryRecoveryName, analysisEntry, start))
+
+		payloads := received()
+		require.Len(t, payloads, 1)
+		assert.Equal(t, PreRecoveryHookPhase, payloads[0].Phase)
+		assert.Equal(t, RecoverDeadPrimaryRecoveryName, payloads[0].Recovery)
+		assert.Equal(t, inst.DeadPrimary, payloads[0].Analysis)
+		assert.Equal(t, "PRIMARY", payloads[0].TabletType)
+		assert.Equal(t, "zone1-0000000101", payloads[0].PrimaryAlias)
+
+		data, err := os.ReadFile(output)
+		require.NoError(t, err)
+		var payload RecoveryHookPayload
+		require.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, payloads[0], payload)
+	})
+
+	t.Run("failing pre-recovery hooks abort the recovery", func(t *testing.T) {
+		reset(http.StatusServiceUnavailable)
+		defer reset(http.StatusOK)
+
+		config.SetRecoveryHooks([]string{server.URL, "exit 1"}, nil)
+		err := runPreRecoveryHooks(context.Background(), RecoverDeadPrimaryRecoveryName, analysisEntry, start)
+		assert.ErrorContains(t, err, "503 Service Unavailable")
+
+		config.SetRecoveryHooks([]string{"echo frozen; exit 1", server.URL}, nil)
+		err = runPreRecoveryHooks(context.Background(), RecoverDeadPrimaryRecoveryName, analysisEntry, start)
+		assert.ErrorContains(t, err, "frozen")
+		// The hooks after the failing one don't run.
+		assert.Len(t, received(), 1)
+	})
+
+	t.Run("post-recovery", func(t *testing.T) {
+		reset(http.StatusOK)
+		config.SetRecoveryHooks(nil, []string{"exit 1", server.URL})
+		topologyRecovery := &TopologyRecovery{
+			SuccessorAlias: "zone1-0000000101",
+			AllErrors:      []string{"zone1-0000000102 is unreachable"},
+		}
+		runPostRecoveryHooks(RecoverDeadPrimaryRecoveryName, analysisEntry, start, true, topologyRecovery, nil)
+
+		// A failing post-recovery hook doesn't prevent the others from running.
+		payloads := received()
+		require.Len(t, payloads, 1)
+		assert.Equal(t, PostRecoveryHookPhase, payloads[0].Phase)
+		assert.True(t, payloads[0].Attempted)
+		assert.True(t, payloads[0].Successful)
+		assert.Equal(t, "zone1-0000000101", payloads[0].PromotedTabletAlias)
+		assert.Equal(t, []string{"zone1-0000000102 is unreachable"}, payloads[0].Errors)
+		assert.NotEmpty(t, payloads[0].Duration)
+
+		reset(http.StatusOK)
+		runPostRecoveryHooks(RecoverDeadPrimaryRecoveryName, analysisEntry, start, false, nil, errors.New("aborted"))
+		payloads = received()
+		require.Len(t, payloads, 1)
+		assert.False(t, payloads[0].Attempted)
+		assert.False(t, payloads[0].Successful)
+		assert.Equal(t, []string{"aborted"}, payloads[0].Errors)
+	})
+}
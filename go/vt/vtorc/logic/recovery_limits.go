@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// recoveryLimiter caps the recoveries which act on the tablets, so that a topo or network blip which
+// makes VTOrc see problems on many shards at once can't trigger recoveries across the whole fleet:
+//   - the number of concurrent recoveries, in total and per cell, is limited.
+//   - a circuit breaker trips when recoveries are started on more than a given number of shards within
+//     a time window. Once tripped, no recovery is started until it is reset by an operator.
+type recoveryLimiter struct {
+	mu             sync.Mutex
+	active         int
+	activePerCell  map[string]int
+	recentShards   map[string]time.Time
+	breakerTripped bool
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+var (
+	recoveries = newRecoveryLimiter()
+
+	// recoveryCircuitBreakerTripped is 1 while the recovery circuit breaker is tripped.
+	recoveryCircuitBreakerTripped = stats.NewGaugeFunc("RecoveryCircuitBreakerTripped", "Whether the recovery circuit breaker is tripped, which prevents VTOrc from running any recovery", func() int64 {
+		if RecoveryCircuitBreakerTripped() {
+			return 1
+		}
+		return 0
+	})
+)
+
+func newRecoveryLimiter() *recoveryLimiter {
+	return &recoveryLimiter{
+		activePerCell: make(map[string]int),
+		recentShards:  make(map[string]time.Time),
+		now:           time.Now,
+	}
+}
+
+// acquire reserves a slot for a recovery of the given shard, on a tablet in the given cell. If the
+// recovery can't run, it returns the reason for which it is skipped. Otherwise, the returned function
+// must be called once the recovery is done.
+func (limiter *recoveryLimiter) acquire(keyspace, shard, cell string) (release func(), skipReason string) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if limiter.breakerTripped {
+		return nil, skipReasonCircuitBreakerTripped
+	}
+	if maxRecoveries := config.MaxConcurrentRecoveries(); maxRecoveries > 0 && limiter.active >= maxRecoveries {
+		return nil, skipReasonConcurrencyLimit
+	}
+	if maxRecoveries := config.MaxConcurrentRecoveriesPerCell(); maxRecoveries > 0 && limiter.activePerCell[cell] >= maxRecoveries {
+		return nil, skipReasonConcurrencyLimit
+	}
+	if maxShards := config.RecoveryCircuitBreakerMaxShards(); maxShards > 0 {
+		now := limiter.now()
+		for recentShard, recoveredAt := range limiter.recentShards {
+			if now.Sub(recoveredAt) > config.RecoveryCircuitBreakerWindow() {
+				delete(limiter.recentShards, recentShard)
+			}
+		}
+		keyspaceShard := topoproto.KeyspaceShardString(keyspace, shard)
+		if _, ok := limiter.recentShards[keyspaceShard]; !ok && len(limiter.recentShards) >= maxShards {
+			log.Errorf("Recovery circuit breaker tripped: recoveries were started on %d shards in the last %v, not recovering %v nor any other shard until the breaker is reset",
+				len(limiter.recentShards), config.RecoveryCircuitBreakerWindow(), keyspaceShard)
+			limiter.breakerTripped = true
+			return nil, skipReasonCircuitBreakerTripped
+		}
+		limiter.recentShards[keyspaceShard] = now
+	}
+
+	limiter.active++
+	limiter.activePerCell[cell]++
+	return func() {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		limiter.active--
+		limiter.activePerCell[cell]--
+		if limiter.activePerCell[cell] == 0 {
+			delete(limiter.activePerCell, cell)
+		}
+	}, ""
+}
+
+func (limiter *recoveryLimiter) tripped() bool {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.breakerTripped
+}
+
+func (limiter *recoveryLimiter) resetBreaker() {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.breakerTripped = false
+	limiter.recentShards = make(map[string]time.Time)
+}
+
+// acquireRecoverySlot reserves a slot for the recovery of the analysis, as acquire does.
+func acquireRecoverySlot(analysisEntry *inst.ReplicationAnalysis) (release func(), skipReason string) {
+	var cell string
+	if alias, err := topoproto.ParseTabletAlias(analysisEntry.AnalyzedInstanceAlias); err == nil {
+		cell = alias.Cell
+	}
+	return recoveries.acquire(analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard, cell)
+}
+
+// RecoveryCircuitBreakerTripped returns whether the recovery circuit breaker is tripped.
+func RecoveryCircuitBreakerTripped() bool {
+	return recoveries.tripped()
+}
+
+// ResetRecoveryCircuitBreaker resets the recovery circuit breaker, so that VTOrc runs recoveries again,
+// and forgets about the shards recovered before.
+func ResetRecoveryCircuitBreaker() {
+	recoveries.resetBreaker()
+	log.Infof("Recovery circuit breaker reset")
+	_ = inst.AuditOperation("reset-recovery-circuit-breaker", "", "Recovery circuit breaker reset")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtorc/config"
+)
+
+func TestRecoveryLimiterConcurrency(t *testing.T) {
+	defer config.SetMaxConcurrentRecoveries(0, 0)
+	config.SetMaxConcurrentRecoveries(3, 2)
+	limiter := newRecoveryLimiter()
+
+	release1, skipReason := limiter.acquire("ks", "-80", "zone1")
+	require.Empty(t, skipReason)
+	release2, skipReason := limiter.acquire("ks", "80-", "zone1")
+	require.Empty(t, skipReason)
+
+	// The cell limit is reached for zone1, but not the total limit.
+	_, skipReason = limiter.acquire("ks2", "0", "zone1")
+	assert.Equal(t, skipReasonConcurrencyLimit, skipReason)
+	release3, skipReason := limiter.acquire("ks2", "0", "zone2")
+	require.Empty(t, skipReason)
+
+	// The total limit is reached.
+	_, skipReason = limiter.acquire("ks3", "0", "zone3")
+	assert.Equal(t, skipReasonConcurrencyLimit, skipReason)
+
+	release1()
+	release4, skipReason := limiter.acquire("ks3", "0", "zone1")
+	require.Empty(t, skipReason)
+	release2()
+	release3()
+	release4()
+	assert.Zero(t, limiter.active)
+	assert.Empty(t, limiter.activePerCell)
+}
+
+func TestRecoveryLimiterCircuitBreaker(t *testing.T) {
+	defer config.SetRecoveryCircuitBreaker(0, 10*time.Minute)
+	config.SetRecoveryCircuitBreaker(2, 10*time.Minute)
+	now := time.Now()
+	limiter := newRecoveryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	acquire := func(shard string) string {
+		release, skipReason := limiter.acquire("ks", shard, "zone1")
+		if release != nil {
+			release()
+		}
+		return skipReason
+	}
+
+	require.Empty(t, acquire("-40"))
+	require.Empty(t, acquire("40-80"))
+	// Recovering the same shards again doesn't count towards the limit.
+	require.Empty(t, acquire("-40"))
+
+	// The shards recovered outside of the window are forgotten.
+	now = now.Add(11 * time.Minute)
+	require.Empty(t, acquire("80-c0"))
+	now = now.Add(time.Minute)
+	require.Empty(t, acquire("c0-"))
+
+	// A third shard trips the breaker, which then prevents any recovery.
+	assert.Equal(t, skipReasonCircuitBreakerTripped, acquire("-40"))
+	assert.True(t, limiter.tripped())
+	assert.Equal(t, skipReasonCircuitBreakerTripped, acquire("c0-"))
+	now = now.Add(time.Hour)
+	assert.Equal(t, skipReasonCircuitBreakerTripped, acquire("c0-"))
+
+	limiter.resetBreaker()
+	assert.False(t, limiter.tripped())
+	assert.Empty(t, acquire("-40"))
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// The reasons for which a recovery is skipped, as reported by skippedRecoveriesCounter.
+const (
+	skipReasonRecoveryDisabled      = "RecoveryDisabled"
+	skipReasonMaintenance           = "Maintenance"
+	skipReasonAlertOnly             = "AlertOnly"
+	skipReasonConcurrencyLimit      = "ConcurrencyLimit"
+	skipReasonCircuitBreakerTripped = "CircuitBreakerTripped"
+	skipReasonShardLockFailed       = "ShardLockFailed"
+	skipReasonValidationFailed      = "ValidationFailed"
+	skipReasonAlreadyFixed          = "AlreadyFixed"
+	skipReasonPreRecoveryHookFailed = "PreRecoveryHookFailed"
+	// skipReasonNotAttempted is reported when the recovery function itself decided not to run, e.g.
+	// because of a recent recovery on the shard, or because emergency reparents are not allowed.
+	skipReasonNotAttempted = "NotAttempted"
+)
+
+var (
+	problemLabels = []string{"Analysis", "Keyspace", "Shard"}
+
+	// detectionLatency measures the time to detect the problems, from the last successful poll of the
+	// analyzed tablet: for the problems which make the tablet unreachable, this is how long ago it was
+	// last seen, and for the others, how long ago the poll which showed the problem was done.
+	detectionLatency = stats.NewMultiTimings("FailureDetectionLatency", "Time from the last successful poll of a tablet to the detection of a problem on it", problemLabels)
+
+	// analysisLatency measures the time to analyze the problems, from the analysis which found them to
+	// the start of their recovery, which includes locking the shard, refreshing its tablets and checking
+	// that the problem still needs to be fixed.
+	analysisLatency = stats.NewMultiTimings("RecoveryAnalysisLatency", "Time from the analysis which found a problem to the start of its recovery", problemLabels)
+
+	// recoveryLatency measures the time to recover from the problems, including the recovery hooks.
+	recoveryLatency = stats.NewMultiTimings("RecoveryLatency", "Time taken by the recoveries, including the recovery hooks", problemLabels)
+
+	// skippedRecoveriesCounter counts the recoveries which were not run, by reason.
+	skippedRecoveriesCounter = stats.NewCountersWithMultiLabels("SkippedRecoveries", "Count of the recoveries which were not run, by reason", append(problemLabels, "Reason"))
+)
+
+func problemLabelValues(analysisEntry *inst.ReplicationAnalysis) []string {
+	return []string{string(analysisEntry.Analysis), analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard}
+}
+
+// recordDetection records the time it took to detect the problem of the analysis.
+func recordDetection(analysisEntry *inst.ReplicationAnalysis) {
+	if !analysisEntry.SecondsSinceLastSeen.Valid {
+		return
+	}
+	detectionLatency.Add(problemLabelValues(analysisEntry), time.Duration(analysisEntry.SecondsSinceLastSeen.Int64)*time.Second)
+}
+
+// recordSkippedRecovery records that the recovery of the analysis was not run, for the given reason.
+// Only the recoveries which act on the tablets are counted.
+func recordSkippedRecovery(analysisEntry *inst.ReplicationAnalysis, reason string) {
+	if !analysisEntry.IsActionableRecovery {
+		return
+	}
+	skippedRecoveriesCounter.Add(append(problemLabelValues(analysisEntry), reason), 1)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+func TestRecoveryMetrics(t *testing.T) {
+	analysisEntry := &inst.ReplicationAnalysis{
+		Analysis:             inst.DeadPrimary,
+		AnalyzedKeyspace:     "ks",
+		AnalyzedShard:        "metrics",
+		IsActionableRecovery: true,
+		SecondsSinceLastSeen: sql.NullInt64{Int64: 7, Valid: true},
+	}
+
+	recordDetection(analysisEntry)
+	assert.EqualValues(t, 1, detectionLatency.Counts()["DeadPrimary.ks.metrics"])
+	assert.EqualValues(t, 7e9, detectionLatency.Histograms()["DeadPrimary.ks.metrics"].Total())
+
+	// Tablets which were never polled successfully have no detection latency.
+	analysisEntry.SecondsSinceLastSeen = sql.NullInt64{}
+	recordDetection(analysisEntry)
+	assert.EqualValues(t, 1, detectionLatency.Counts()["DeadPrimary.ks.metrics"])
+
+	recordSkippedRecovery(analysisEntry, skipReasonMaintenance)
+	recordSkippedRecovery(analysisEntry, skipReasonMaintenance)
+	recordSkippedRecovery(analysisEntry, skipReasonAlreadyFixed)
+	assert.EqualValues(t, 2, skippedRecoveriesCounter.Counts()["DeadPrimary.ks.metrics.Maintenance"])
+	assert.EqualValues(t, 1, skippedRecoveriesCounter.Counts()["DeadPrimary.ks.metrics.AlreadyFixed"])
+
+	// Only the recoveries which act on the tablets are counted.
+	analysisEntry.IsActionableRecovery = false
+	recordSkippedRecovery(analysisEntry, skipReasonMaintenance)
+	assert.EqualValues(t, 2, skippedRecoveriesCounter.Counts()["DeadPrimary.ks.metrics.Maintenance"])
+}
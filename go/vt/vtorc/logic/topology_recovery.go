@@ -25,7 +25,9 @@ import (
 
 	"github.com/patrickmn/go-cache"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
@@ -230,6 +232,10 @@ func runEmergencyReparentOp(ctx context.Context, analysisEntry *inst.Replication
 	if err != nil {
 		return false, nil, err
 	}
+	failoverPrefs, err := inst.GetFailoverPreferences(tablet.Keyspace)
+	if err != nil {
+		return false, nil, err
+	}
 
 	topologyRecovery, err = AttemptRecoveryRegistration(analysisEntry, true, true)
 	if topologyRecovery == nil {
@@ -265,6 +271,8 @@ func runEmergencyReparentOp(ctx context.Context, analysisEntry *inst.Replication
 			WaitReplicasTimeout:       time.Duration(config.Config.WaitReplicasTimeoutSeconds) * time.Second,
 			PreventCrossCellPromotion: config.Config.PreventCrossDataCenterPrimaryFailover,
 			WaitAllTablets:            waitForAllTablets,
+			// The failed primary is the analyzed tablet.
+			CandidatePreferences: reparentutil.FailoverCandidatePreferences(failoverPrefs, tablet.Alias.Cell),
 		},
 	)
 	if err != nil {
@@ -392,6 +400,7 @@ func checkAndExecuteFailureDetectionProcesses(analysisEntry *inst.ReplicationAna
 		return false, false, nil
 	}
 	log.Infof("topology_recovery: detected %+v failure on %+v", analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias)
+	recordDetection(analysisEntry)
 	return true, false, nil
 }
 
@@ -445,6 +454,8 @@ func getCheckAndRecoverFunctionCode(analysisCode inst.AnalysisCode, tabletAlias
 		return recoverGenericProblemFunc
 	case inst.UnreachablePrimary:
 		return recoverGenericProblemFunc
+	case inst.PrimarySemiSyncFallback:
+		return recoverGenericProblemFunc
 	case inst.UnreachablePrimaryWithLaggingReplicas:
 		return recoverGenericProblemFunc
 	case inst.AllPrimaryReplicasNotReplicating:
@@ -588,6 +599,7 @@ func runEmergentOperations(analysisEntry *inst.ReplicationAnalysis) {
 func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (err error) {
 	countPendingRecoveries.Add(1)
 	defer countPendingRecoveries.Add(-1)
+	analysisTime := time.Now()
 
 	checkAndRecoverFunctionCode := getCheckAndRecoverFunctionCode(analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias)
 	isActionableRecovery := hasActionableRecovery(checkAndRecoverFunctionCode)
@@ -623,6 +635,16 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 
 	// We're about to embark on recovery shortly...
 
+	// Check for the analysis being alert-only in the analysis rules
+	if inst.GetAnalysisAction(analysisEntry.Analysis, analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard) == inst.AnalysisActionAlert {
+		if util.ClearToLog("executeCheckAndRecoverFunction: alert", analysisEntry.AnalyzedInstanceAlias) {
+			log.Infof("CheckAndRecover: Analysis: %+v, Tablet: %+v: NOT Recovering host (alert-only analysis)",
+				analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias)
+		}
+		recordSkippedRecovery(analysisEntry, skipReasonAlertOnly)
+		return nil
+	}
+
 	// Check for recovery being disabled globally
 	if recoveryDisabledGlobally, err := IsRecoveryDisabled(); err != nil {
 		// Unexpected. Shouldn't get this
@@ -630,13 +652,34 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 	} else if recoveryDisabledGlobally {
 		log.Infof("CheckAndRecover: Analysis: %+v, Tablet: %+v: NOT Recovering host (disabled globally)",
 			analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias)
+		recordSkippedRecovery(analysisEntry, skipReasonRecoveryDisabled)
 
 		return err
 	}
 
+	// Check for recovery being disabled on the keyspace or shard
+	if isInVtorcMaintenance(analysisEntry) {
+		recordSkippedRecovery(analysisEntry, skipReasonMaintenance)
+		return nil
+	}
+
+	// Check for the recovery being over the concurrency limits or the circuit breaker being tripped.
+	// The recoveries skipped because of the concurrency limits are retried on the next analysis.
+	if isActionableRecovery {
+		release, skipReason := acquireRecoverySlot(analysisEntry)
+		if skipReason != "" {
+			log.Warningf("CheckAndRecover: Analysis: %+v, Tablet: %+v: NOT Recovering host (%v)",
+				analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias, skipReason)
+			recordSkippedRecovery(analysisEntry, skipReason)
+			return nil
+		}
+		defer release()
+	}
+
 	// We lock the shard here and then refresh the tablets information
 	ctx, unlock, err := LockShard(context.Background(), analysisEntry.AnalyzedInstanceAlias, getLockAction(analysisEntry.AnalyzedInstanceAlias, analysisEntry.Analysis))
 	if err != nil {
+		recordSkippedRecovery(analysisEntry, skipReasonShardLockFailed)
 		return err
 	}
 	defer unlock(&err)
@@ -654,8 +697,14 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 		// a change in the recovery we run.
 		err = RefreshKeyspaceAndShard(analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard)
 		if err != nil {
+			recordSkippedRecovery(analysisEntry, skipReasonValidationFailed)
 			return err
 		}
+		// The keyspace or shard could have been put in maintenance since we last refreshed them.
+		if isInVtorcMaintenance(analysisEntry) {
+			recordSkippedRecovery(analysisEntry, skipReasonMaintenance)
+			return nil
+		}
 		// If we are about to run a cluster-wide recovery, it is imperative to first refresh all the tablets
 		// of a shard because a new tablet could have been promoted, and we need to have this visibility before we
 		// run a cluster operation of our own.
@@ -680,6 +729,7 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 			if err != nil {
 				log.Errorf("executeCheckAndRecoverFunction: Analysis: %+v, Tablet: %+v: error while finding the shard primary: %v",
 					analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias, err)
+				recordSkippedRecovery(analysisEntry, skipReasonValidationFailed)
 				return err
 			}
 			primaryTabletAlias := topoproto.TabletAliasString(primaryTablet.Alias)
@@ -693,10 +743,12 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 		if err != nil {
 			log.Errorf("executeCheckAndRecoverFunction: Analysis: %+v, Tablet: %+v: error while trying to find if the problem is already fixed: %v",
 				analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias, err)
+			recordSkippedRecovery(analysisEntry, skipReasonValidationFailed)
 			return err
 		}
 		if alreadyFixed {
 			log.Infof("Analysis: %v on tablet %v - No longer valid, some other agent must have fixed the problem.", analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias)
+			recordSkippedRecovery(analysisEntry, skipReasonAlreadyFixed)
 			return nil
 		}
 	}
@@ -705,11 +757,32 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 	if isActionableRecovery || util.ClearToLog("executeCheckAndRecoverFunction: recovery", analysisEntry.AnalyzedInstanceAlias) {
 		log.Infof("executeCheckAndRecoverFunction: proceeding with %+v recovery on %+v; isRecoverable?: %+v", analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias, isActionableRecovery)
 	}
+	recoveryStart := time.Now()
+	recoveryName := getRecoverFunctionName(checkAndRecoverFunctionCode)
+	if isActionableRecovery {
+		analysisLatency.Add(problemLabelValues(analysisEntry), recoveryStart.Sub(analysisTime))
+	}
+	// The hooks only run around the recoveries which act on the tablets, so that they can, e.g., page,
+	// drain traffic or check for a change freeze. The post-recovery hooks run even if a pre-recovery hook
+	// aborted the recovery, so that they can undo what the previous pre-recovery hooks did.
+	if isActionableRecovery {
+		if err := runPreRecoveryHooks(ctx, recoveryName, analysisEntry, recoveryStart); err != nil {
+			log.Errorf("executeCheckAndRecoverFunction: %v", err)
+			runPostRecoveryHooks(recoveryName, analysisEntry, recoveryStart, false, nil, err)
+			recordSkippedRecovery(analysisEntry, skipReasonPreRecoveryHookFailed)
+			return err
+		}
+	}
 	recoveryAttempted, topologyRecovery, err := getCheckAndRecoverFunction(checkAndRecoverFunctionCode)(ctx, analysisEntry)
+	if isActionableRecovery {
+		runPostRecoveryHooks(recoveryName, analysisEntry, recoveryStart, recoveryAttempted, topologyRecovery, err)
+	}
 	if !recoveryAttempted {
+		recordSkippedRecovery(analysisEntry, skipReasonNotAttempted)
 		return err
 	}
-	recoveryName := getRecoverFunctionName(checkAndRecoverFunctionCode)
+	recoveryLatency.Record(problemLabelValues(analysisEntry), recoveryStart)
+	auditRecovery(recoveryName, analysisEntry, recoveryStart, err)
 	recoveriesCounter.Add(recoveryName, 1)
 	if err != nil {
 		recoveriesFailureCounter.Add(recoveryName, 1)
@@ -739,6 +812,51 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 	return err
 }
 
+// auditRecovery records an attempted recovery in the audit log, if it is
+// enabled.
+func auditRecovery(recoveryName string, analysisEntry *inst.ReplicationAnalysis, start time.Time, err error) {
+	if !audit.Enabled() {
+		return
+	}
+	entry := &audit.Entry{
+		Time:     start,
+		Identity: "vtorc",
+		Action:   recoveryName,
+		Duration: time.Since(start),
+	}
+	if args, marshalErr := json.Marshal(map[string]string{
+		"analysis": string(analysisEntry.Analysis),
+		"tablet":   analysisEntry.AnalyzedInstanceAlias,
+		"keyspace": analysisEntry.AnalyzedKeyspace,
+		"shard":    analysisEntry.AnalyzedShard,
+	}); marshalErr == nil {
+		entry.Args = string(args)
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	audit.Record(entry)
+}
+
+// isInVtorcMaintenance checks whether the keyspace or shard of the analysis is in a maintenance, during
+// which no recovery is run.
+func isInVtorcMaintenance(analysisEntry *inst.ReplicationAnalysis) bool {
+	maintenance, err := inst.ReadVtorcMaintenance(analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard)
+	if err != nil {
+		// Unexpected. We don't block the recoveries if we can't tell whether they are disabled.
+		log.Errorf("Unable to determine if %v/%v is in maintenance: %v", analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard, err)
+		return false
+	}
+	if maintenance == nil {
+		return false
+	}
+	if util.ClearToLog("isInVtorcMaintenance", analysisEntry.AnalyzedInstanceAlias) {
+		log.Infof("CheckAndRecover: Analysis: %+v, Tablet: %+v: NOT Recovering host (in maintenance until %v: %v)",
+			analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias, protoutil.TimeFromProto(maintenance.ExpireTime).UTC(), maintenance.Reason)
+	}
+	return true
+}
+
 // checkIfAlreadyFixed checks whether the problem that the analysis entry represents has already been fixed by another agent or not
 func checkIfAlreadyFixed(analysisEntry *inst.ReplicationAnalysis) (bool, error) {
 	// Run a replication analysis again. We will check if the problem persisted
@@ -834,6 +952,10 @@ func electNewPrimary(ctx context.Context, analysisEntry *inst.ReplicationAnalysi
 	if err != nil {
 		return false, topologyRecovery, err
 	}
+	failoverPrefs, err := inst.GetFailoverPreferences(analyzedTablet.Keyspace)
+	if err != nil {
+		return false, topologyRecovery, err
+	}
 	_ = AuditTopologyRecovery(topologyRecovery, "starting PlannedReparentShard for electing new primary.")
 
 	ev, err := reparentutil.NewPlannedReparenter(ts, tmclient.NewTabletManagerClient(), logutil.NewCallbackLogger(func(event *logutilpb.Event) {
@@ -852,6 +974,8 @@ func electNewPrimary(ctx context.Context, analysisEntry *inst.ReplicationAnalysi
 		analyzedTablet.Shard,
 		reparentutil.PlannedReparentOptions{
 			WaitReplicasTimeout: time.Duration(config.Config.WaitReplicasTimeoutSeconds) * time.Second,
+			// There is no previous primary to stay in the cell of.
+			CandidatePreferences: reparentutil.FailoverCandidatePreferences(failoverPrefs, ""),
 		},
 	)
 
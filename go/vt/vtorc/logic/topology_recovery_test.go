@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtorc/config"
@@ -106,6 +107,8 @@ func TestElectNewPrimaryPanic(t *testing.T) {
 		ts = oldTs
 		_, err = orcDb.Exec("delete from vitess_tablet")
 		require.NoError(t, err)
+		_, err = orcDb.Exec("delete from vitess_keyspace")
+		require.NoError(t, err)
 	}()
 
 	tablet := &topodatapb.Tablet{
@@ -122,6 +125,10 @@ func TestElectNewPrimaryPanic(t *testing.T) {
 	}
 	err = inst.SaveTablet(tablet)
 	require.NoError(t, err)
+	keyspaceInfo := &topo.KeyspaceInfo{Keyspace: &topodatapb.Keyspace{}}
+	keyspaceInfo.SetKeyspaceName("ks")
+	err = inst.SaveKeyspace(keyspaceInfo)
+	require.NoError(t, err)
 	analysisEntry := &inst.ReplicationAnalysis{
 		AnalyzedInstanceAlias: topoproto.TabletAliasString(tablet.Alias),
 	}
@@ -241,6 +248,11 @@ func TestGetCheckAndRecoverFunctionCode(t *testing.T) {
 			ersEnabled:           false,
 			analysisCode:         inst.PrimarySemiSyncMustBeSet,
 			wantRecoveryFunction: fixPrimaryFunc,
+		}, {
+			name:                 "PrimarySemiSyncFallback",
+			ersEnabled:           false,
+			analysisCode:         inst.PrimarySemiSyncFallback,
+			wantRecoveryFunction: recoverGenericProblemFunc,
 		}, {
 			name:                         "ErrantGTIDDetected",
 			ersEnabled:                   false,
@@ -115,11 +115,19 @@ func acceptSighupSignal() {
 			log.Infof("Received SIGHUP. Reloading configuration")
 			_ = inst.AuditOperation("reload-configuration", "", "Triggered via SIGHUP")
 			config.Reload()
+			reloadAnalysisRules()
 			discoveryMetrics.SetExpirePeriod(time.Duration(config.DiscoveryCollectionRetentionSeconds) * time.Second)
 		}
 	}()
 }
 
+// reloadAnalysisRules reloads the analysis rules file, keeping the rules in use if it is invalid.
+func reloadAnalysisRules() {
+	if err := inst.LoadAnalysisRules(); err != nil {
+		log.Errorf("Not reloading the analysis rules: %v", err)
+	}
+}
+
 // closeVTOrc runs all the operations required to cleanly shutdown VTOrc
 func closeVTOrc() {
 	log.Infof("Starting VTOrc shutdown")
@@ -345,6 +353,7 @@ func ContinuousDiscovery() {
 	caretakingTick := time.Tick(time.Minute)
 	recoveryTick := time.Tick(time.Duration(config.Config.RecoveryPollSeconds) * time.Second)
 	tabletTopoTick := OpenTabletDiscovery()
+	analysisRulesTick := time.Tick(config.AnalysisRulesReloadInterval())
 	var recoveryEntrance int64
 	var snapshotTopologiesTick <-chan time.Time
 	if config.Config.SnapshotTopologiesIntervalHours > 0 {
@@ -358,6 +367,9 @@ func ContinuousDiscovery() {
 	go func() {
 		_ = ometrics.InitMetrics()
 	}()
+	if err := inst.LoadAnalysisRules(); err != nil {
+		log.Fatal(err)
+	}
 	go acceptSighupSignal()
 	// On termination of the server, we should close VTOrc cleanly
 	servenv.OnTermSync(closeVTOrc)
@@ -408,6 +420,8 @@ func ContinuousDiscovery() {
 					}()
 				}
 			}()
+		case <-analysisRulesTick:
+			go reloadAnalysisRules()
 		case <-snapshotTopologiesTick:
 			go func() {
 				if IsLeaderOrActive() {
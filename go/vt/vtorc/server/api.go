@@ -19,6 +19,7 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -47,9 +48,16 @@ const (
 	replicationAnalysisAPI        = "/api/replication-analysis"
 	healthAPI                     = "/debug/health"
 	AggregatedDiscoveryMetricsAPI = "/api/aggregated-discovery-metrics"
+	recoveriesAPI                 = "/api/recoveries"
+	detectionsAPI                 = "/api/detections"
+	resetCircuitBreakerAPI        = "/api/reset-recovery-circuit-breaker"
+	injectFailureAPI              = "/api/inject-failure"
+	clearInjectedFailuresAPI      = "/api/clear-injected-failures"
+	injectedFailuresAPI           = "/api/injected-failures"
 
 	shardWithoutKeyspaceFilteringErrorStr = "Filtering by shard without keyspace isn't supported"
 	notAValidValueForSeconds              = "Invalid value for seconds"
+	notAValidValueForLimit                = "Invalid value for limit"
 )
 
 var (
@@ -62,6 +70,12 @@ var (
 		replicationAnalysisAPI,
 		healthAPI,
 		AggregatedDiscoveryMetricsAPI,
+		recoveriesAPI,
+		detectionsAPI,
+		resetCircuitBreakerAPI,
+		injectFailureAPI,
+		clearInjectedFailuresAPI,
+		injectedFailuresAPI,
 	}
 )
 
@@ -78,6 +92,14 @@ func (v *vtorcAPI) ServeHTTP(response http.ResponseWriter, request *http.Request
 		disableGlobalRecoveriesAPIHandler(response)
 	case enableGlobalRecoveriesAPI:
 		enableGlobalRecoveriesAPIHandler(response)
+	case resetCircuitBreakerAPI:
+		resetCircuitBreakerAPIHandler(response)
+	case injectFailureAPI:
+		injectFailureAPIHandler(response, request)
+	case clearInjectedFailuresAPI:
+		clearInjectedFailuresAPIHandler(response, request)
+	case injectedFailuresAPI:
+		injectedFailuresAPIHandler(response)
 	case healthAPI:
 		healthAPIHandler(response, request)
 	case problemsAPI:
@@ -88,6 +110,10 @@ func (v *vtorcAPI) ServeHTTP(response http.ResponseWriter, request *http.Request
 		replicationAnalysisAPIHandler(response, request)
 	case AggregatedDiscoveryMetricsAPI:
 		AggregatedDiscoveryMetricsAPIHandler(response, request)
+	case recoveriesAPI:
+		recoveriesAPIHandler(response, request)
+	case detectionsAPI:
+		detectionsAPIHandler(response, request)
 	default:
 		// This should be unreachable. Any endpoint which isn't registered is automatically redirected to /debug/status.
 		// This code will only be reachable if we register an API but don't handle it here. That will be a bug.
@@ -100,10 +126,16 @@ func getACLPermissionLevelForAPI(apiEndpoint string) string {
 	switch apiEndpoint {
 	case problemsAPI, errantGTIDsAPI:
 		return acl.MONITORING
-	case disableGlobalRecoveriesAPI, enableGlobalRecoveriesAPI:
+	case disableGlobalRecoveriesAPI, enableGlobalRecoveriesAPI, resetCircuitBreakerAPI:
 		return acl.ADMIN
+	case injectFailureAPI, clearInjectedFailuresAPI:
+		return acl.ADMIN
+	case injectedFailuresAPI:
+		return acl.MONITORING
 	case replicationAnalysisAPI:
 		return acl.MONITORING
+	case recoveriesAPI, detectionsAPI:
+		return acl.MONITORING
 	case healthAPI:
 		return acl.MONITORING
 	}
@@ -211,6 +243,48 @@ func enableGlobalRecoveriesAPIHandler(response http.ResponseWriter) {
 	writePlainTextResponse(response, "Global recoveries enabled", http.StatusOK)
 }
 
+// resetCircuitBreakerAPIHandler is the handler for the resetCircuitBreakerAPI endpoint
+func resetCircuitBreakerAPIHandler(response http.ResponseWriter) {
+	logic.ResetRecoveryCircuitBreaker()
+	writePlainTextResponse(response, "Recovery circuit breaker reset", http.StatusOK)
+}
+
+// injectFailureAPIHandler is the handler for the injectFailureAPI endpoint. It injects the failure on the
+// tablet for the duration, 5 minutes by default.
+func injectFailureAPIHandler(response http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	tabletAlias := query.Get("tablet")
+	if tabletAlias == "" {
+		http.Error(response, "tablet must be specified", http.StatusBadRequest)
+		return
+	}
+	duration := 5 * time.Minute
+	if durationStr := query.Get("duration"); durationStr != "" {
+		var err error
+		if duration, err = time.ParseDuration(durationStr); err != nil {
+			http.Error(response, fmt.Sprintf("invalid value for duration: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := inst.InjectFailure(tabletAlias, inst.FailureType(query.Get("failure")), duration); err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writePlainTextResponse(response, "Failure injected", http.StatusOK)
+}
+
+// clearInjectedFailuresAPIHandler is the handler for the clearInjectedFailuresAPI endpoint. Without a
+// tablet, it clears the failures injected on all the tablets.
+func clearInjectedFailuresAPIHandler(response http.ResponseWriter, request *http.Request) {
+	inst.ClearInjectedFailures(request.URL.Query().Get("tablet"))
+	writePlainTextResponse(response, "Injected failures cleared", http.StatusOK)
+}
+
+// injectedFailuresAPIHandler is the handler for the injectedFailuresAPI endpoint
+func injectedFailuresAPIHandler(response http.ResponseWriter) {
+	returnAsJSON(response, http.StatusOK, inst.GetInjectedFailures())
+}
+
 // replicationAnalysisAPIHandler is the handler for the replicationAnalysisAPI endpoint
 func replicationAnalysisAPIHandler(response http.ResponseWriter, request *http.Request) {
 	// This api also supports filtering by shard and keyspace provided.
@@ -231,6 +305,69 @@ func replicationAnalysisAPIHandler(response http.ResponseWriter, request *http.R
 	returnAsJSON(response, http.StatusOK, analysis)
 }
 
+// recoveriesAPIHandler is the handler for the recoveriesAPI endpoint
+func recoveriesAPIHandler(response http.ResponseWriter, request *http.Request) {
+	filter, err := getHistoryFilter(request)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recoveries, err := logic.ReadRecoveryHistory(filter)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	returnAsJSON(response, http.StatusOK, recoveries)
+}
+
+// detectionsAPIHandler is the handler for the detectionsAPI endpoint
+func detectionsAPIHandler(response http.ResponseWriter, request *http.Request) {
+	filter, err := getHistoryFilter(request)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	detections, err := logic.ReadDetectionHistory(filter)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	returnAsJSON(response, http.StatusOK, detections)
+}
+
+// getHistoryFilter returns the filter of the recoveriesAPI and detectionsAPI endpoints. They support
+// filtering by keyspace and shard, by analysis, and by the time range, in RFC 3339 format, in which
+// the recoveries or detections started. The limit is the maximum number of entries to return.
+func getHistoryFilter(request *http.Request) (*logic.HistoryFilter, error) {
+	query := request.URL.Query()
+	filter := &logic.HistoryFilter{
+		Keyspace: query.Get("keyspace"),
+		Shard:    query.Get("shard"),
+		Analysis: inst.AnalysisCode(query.Get("analysis")),
+	}
+	if filter.Shard != "" && filter.Keyspace == "" {
+		return nil, errors.New(shardWithoutKeyspaceFilteringErrorStr)
+	}
+
+	var err error
+	if since := query.Get("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return nil, fmt.Errorf("invalid value for since: %v", err)
+		}
+	}
+	if until := query.Get("until"); until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return nil, fmt.Errorf("invalid value for until: %v", err)
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return nil, errors.New(notAValidValueForLimit)
+		}
+	}
+	return filter, nil
+}
+
 // healthAPIHandler is the handler for the healthAPI endpoint
 func healthAPIHandler(response http.ResponseWriter, request *http.Request) {
 	health, err := process.HealthTest()
@@ -1,11 +1,15 @@
 package server
 
 import (
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+	"vitess.io/vitess/go/vt/vtorc/logic"
 )
 
 func TestGetACLPermissionLevelForAPI(t *testing.T) {
@@ -31,6 +35,24 @@ func TestGetACLPermissionLevelForAPI(t *testing.T) {
 		}, {
 			apiEndpoint: healthAPI,
 			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: recoveriesAPI,
+			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: detectionsAPI,
+			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: resetCircuitBreakerAPI,
+			want:        acl.ADMIN,
+		}, {
+			apiEndpoint: injectFailureAPI,
+			want:        acl.ADMIN,
+		}, {
+			apiEndpoint: clearInjectedFailuresAPI,
+			want:        acl.ADMIN,
+		}, {
+			apiEndpoint: injectedFailuresAPI,
+			want:        acl.MONITORING,
 		}, {
 			apiEndpoint: "gibberish",
 			want:        acl.ADMIN,
@@ -43,3 +65,51 @@ func TestGetACLPermissionLevelForAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHistoryFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    *logic.HistoryFilter
+		wantErr string
+	}{
+		{
+			query: "",
+			want:  &logic.HistoryFilter{},
+		}, {
+			query: "keyspace=ks&shard=0&analysis=DeadPrimary&since=2023-10-01T10:00:00Z&until=2023-10-02T10:00:00%2B02:00&limit=10",
+			want: &logic.HistoryFilter{
+				Keyspace: "ks",
+				Shard:    "0",
+				Analysis: inst.DeadPrimary,
+				Since:    time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC),
+				Until:    time.Date(2023, 10, 2, 8, 0, 0, 0, time.UTC),
+				Limit:    10,
+			},
+		}, {
+			query:   "shard=0",
+			wantErr: shardWithoutKeyspaceFilteringErrorStr,
+		}, {
+			query:   "since=yesterday",
+			wantErr: "invalid value for since",
+		}, {
+			query:   "limit=-1",
+			wantErr: notAValidValueForLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter, err := getHistoryFilter(httptest.NewRequest("GET", recoveriesAPI+"?"+tt.query, nil))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.Keyspace, filter.Keyspace)
+			require.Equal(t, tt.want.Shard, filter.Shard)
+			require.Equal(t, tt.want.Analysis, filter.Analysis)
+			require.True(t, tt.want.Since.Equal(filter.Since))
+			require.True(t, tt.want.Until.Equal(filter.Until))
+			require.Equal(t, tt.want.Limit, filter.Limit)
+		})
+	}
+}
@@ -34,6 +34,7 @@ type InfoForRecoveryAnalysis struct {
 	Keyspace                                  string
 	Shard                                     string
 	ShardPrimaryTermTimestamp                 string
+	ShardSemiSyncFallbackAlias                string
 	KeyspaceType                              int
 	DurabilityPolicy                          string
 	IsInvalid                                 int
@@ -128,6 +129,7 @@ func (info *InfoForRecoveryAnalysis) ConvertToRowMap() sqlutils.RowMap {
 	rowMap["keyspace"] = sqlutils.CellData{String: info.Keyspace, Valid: true}
 	rowMap["shard"] = sqlutils.CellData{String: info.Shard, Valid: true}
 	rowMap["shard_primary_term_timestamp"] = sqlutils.CellData{String: info.ShardPrimaryTermTimestamp, Valid: true}
+	rowMap["shard_semi_sync_fallback_alias"] = sqlutils.CellData{String: info.ShardSemiSyncFallbackAlias, Valid: true}
 	rowMap["last_check_partial_success"] = sqlutils.CellData{String: fmt.Sprintf("%v", info.LastCheckPartialSuccess), Valid: true}
 	rowMap["max_replica_gtid_errant"] = sqlutils.CellData{String: info.MaxReplicaGTIDErrant, Valid: true}
 	rowMap["max_replica_gtid_mode"] = sqlutils.CellData{String: info.MaxReplicaGTIDMode, Valid: true}
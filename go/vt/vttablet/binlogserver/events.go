@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"vitess.io/vitess/go/mysql"
+)
+
+// Types of the events generated by the binlog server.
+const (
+	rotateEventType    = 4
+	heartbeatEventType = 27
+)
+
+// newEvent returns an event generated by the binlog server, rather than read
+// from a binary log, with a checksum if the format has one.
+func newEvent(format mysql.BinlogFormat, typ byte, serverID uint32, logPos uint32, flags uint16, data []byte) mysql.BinlogEvent {
+	length := mysql.BinlogFixedHeaderLen + len(data)
+	if format.ChecksumAlgorithm == mysql.BinlogChecksumAlgCRC32 {
+		length += mysql.BinlogCRC32ChecksumLen
+	}
+
+	buf := make([]byte, length)
+	// The timestamp of generated events is 0.
+	buf[mysql.BinlogEventTypeOffset] = typ
+	binary.LittleEndian.PutUint32(buf[5:9], serverID)
+	binary.LittleEndian.PutUint32(buf[mysql.BinlogEventLenOffset:], uint32(length))
+	binary.LittleEndian.PutUint32(buf[13:17], logPos)
+	binary.LittleEndian.PutUint16(buf[17:19], flags)
+	copy(buf[mysql.BinlogFixedHeaderLen:], data)
+	if format.ChecksumAlgorithm == mysql.BinlogChecksumAlgCRC32 {
+		checksum := crc32.ChecksumIEEE(buf[:length-mysql.BinlogCRC32ChecksumLen])
+		binary.LittleEndian.PutUint32(buf[length-mysql.BinlogCRC32ChecksumLen:], checksum)
+	}
+	return mysql.NewMysql56BinlogEvent(buf)
+}
+
+// newRotateEvent returns the artificial ROTATE event starting the dump of a
+// file, as mysqld sends.
+func newRotateEvent(format mysql.BinlogFormat, serverID uint32, name string) mysql.BinlogEvent {
+	data := make([]byte, 8+len(name))
+	binary.LittleEndian.PutUint64(data, uint64(len(mysql.BinglogMagicNumber)))
+	copy(data[8:], name)
+	return newEvent(format, rotateEventType, serverID, 0, mysql.FlagLogEventArtificial, data)
+}
+
+// newHeartbeatEvent returns a HEARTBEAT event, at the given offset of a file.
+func newHeartbeatEvent(format mysql.BinlogFormat, serverID uint32, name string, offset int64) mysql.BinlogEvent {
+	return newEvent(format, heartbeatEventType, serverID, uint32(offset), 0, []byte(name))
+}
+
+// rotateLogFile returns the name of the file a ROTATE event switches to, ""
+// if the event is too short.
+func rotateLogFile(ev mysql.BinlogEvent, checksumAlgorithm byte) string {
+	data := ev.Bytes()
+	end := len(data)
+	if checksumAlgorithm == mysql.BinlogChecksumAlgCRC32 {
+		end -= mysql.BinlogCRC32ChecksumLen
+	}
+	if end < mysql.BinlogFixedHeaderLen+8 {
+		return ""
+	}
+	return string(data[mysql.BinlogFixedHeaderLen+8 : end])
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/servenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// handler serves the queries replicas run before dumping binary logs, and
+// the dumps.
+type handler struct {
+	mysql.UnimplementedHandler
+	bs *Server
+}
+
+// connState is the state of a connection, in its ClientData.
+type connState struct {
+	heartbeatPeriod time.Duration
+}
+
+var _ mysql.Handler = (*handler)(nil)
+
+func (h *handler) NewConnection(c *mysql.Conn) {
+	c.ClientData = &connState{}
+}
+
+// ComQuery implements mysql.Handler.
+func (h *handler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	q := strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query), ";")))
+	switch {
+	case strings.HasPrefix(q, "use "):
+		// The binlog server has no database, but serves the users
+		// connecting with one.
+		return callback(&sqltypes.Result{})
+	case strings.HasPrefix(q, "set "):
+		h.set(c, q[len("set "):])
+		return callback(&sqltypes.Result{})
+	case q == "select unix_timestamp()":
+		return callback(singleValueResult("unix_timestamp()", strconv.FormatInt(time.Now().Unix(), 10)))
+	case strings.HasPrefix(q, "select @"):
+		name := strings.TrimSpace(strings.TrimSuffix(q[len("select "):], "limit 1"))
+		value, ok := h.variable(c, strings.TrimLeft(strings.TrimPrefix(strings.TrimPrefix(name, "@@"), "global."), "@"))
+		if !ok {
+			return sqlerror.NewSQLError(sqlerror.ERUnknownSystemVariable, sqlerror.SSUnknownSQLState, "Unknown system variable '%s'", name)
+		}
+		return callback(singleValueResult(name, value))
+	case strings.HasPrefix(q, "show variables like ") || strings.HasPrefix(q, "show global variables like "):
+		pattern := strings.Trim(q[strings.Index(q, " like ")+len(" like "):], "'\"")
+		name := strings.ReplaceAll(pattern, "%", "")
+		result := &sqltypes.Result{Fields: varcharFields("Variable_name", "Value")}
+		if value, ok := h.variable(c, name); ok {
+			result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar(name), sqltypes.NewVarChar(value)})
+		}
+		return callback(result)
+	case q == "show binary logs" || q == "show master logs":
+		result := &sqltypes.Result{Fields: varcharFields("Log_name", "File_size")}
+		for _, file := range h.bs.store.Files() {
+			result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar(file.Name), sqltypes.NewVarChar(strconv.FormatInt(file.Size, 10))})
+		}
+		return callback(result)
+	case q == "show master status" || q == "show binary log status":
+		result := &sqltypes.Result{Fields: varcharFields("File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set")}
+		if files := h.bs.store.Files(); len(files) > 0 {
+			last := files[len(files)-1]
+			result.Rows = append(result.Rows, []sqltypes.Value{
+				sqltypes.NewVarChar(last.Name),
+				sqltypes.NewVarChar(strconv.FormatInt(last.Size, 10)),
+				sqltypes.NewVarChar(""),
+				sqltypes.NewVarChar(""),
+				sqltypes.NewVarChar(h.bs.store.Position().String()),
+			})
+		}
+		return callback(result)
+	}
+	return sqlerror.NewSQLError(sqlerror.ERNotSupportedYet, sqlerror.SSUnknownSQLState, "the binlog server does not support the query: %s", query)
+}
+
+// set records the heartbeat period replicas set, and ignores the other
+// variables.
+func (h *handler) set(c *mysql.Conn, assignments string) {
+	for _, assignment := range strings.Split(assignments, ",") {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "@master_heartbeat_period", "@source_heartbeat_period":
+			// The period is in nanoseconds.
+			if period, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+				c.ClientData.(*connState).heartbeatPeriod = time.Duration(period)
+			}
+		}
+	}
+}
+
+// variable returns the value of a variable, as the replicas expect it from
+// the source.
+func (h *handler) variable(c *mysql.Conn, name string) (string, bool) {
+	switch name {
+	case "gtid_executed":
+		return h.bs.store.Position().String(), true
+	case "gtid_purged":
+		return h.bs.store.Purged().String(), true
+	case "gtid_mode":
+		return "ON", true
+	case "server_id":
+		return strconv.FormatUint(uint64(h.bs.serverID), 10), true
+	case "server_uuid":
+		return h.bs.serverUUID, true
+	case "binlog_checksum", "master_binlog_checksum", "source_binlog_checksum":
+		if h.bs.store.ChecksumAlgorithm() == mysql.BinlogChecksumAlgCRC32 {
+			return "CRC32", true
+		}
+		return "NONE", true
+	case "version":
+		return servenv.MySQLServerVersion(), true
+	case "version_comment":
+		return "Vitess binlog server", true
+	case "binlog_row_image":
+		return "FULL", true
+	case "collation_server":
+		return "utf8mb4_0900_ai_ci", true
+	case "time_zone":
+		return "SYSTEM", true
+	}
+	return "", false
+}
+
+// ComRegisterReplica implements mysql.Handler. The binlog server does not
+// track its replicas.
+func (h *handler) ComRegisterReplica(c *mysql.Conn, replicaHost string, replicaPort uint16, replicaUser string, replicaPassword string) error {
+	return nil
+}
+
+// ComBinlogDump implements mysql.Handler. The binlog server only serves
+// dumps from GTID sets, as its files are not the ones of the MySQL server.
+func (h *handler) ComBinlogDump(c *mysql.Conn, logFile string, binlogPos uint32) error {
+	return sqlerror.NewSQLError(sqlerror.ERNotSupportedYet, sqlerror.SSUnknownSQLState, "the binlog server only supports COM_BINLOG_DUMP_GTID")
+}
+
+// ComBinlogDumpGTID implements mysql.Handler.
+func (h *handler) ComBinlogDumpGTID(c *mysql.Conn, logFile string, logPos uint64, gtidSet replication.GTIDSet) error {
+	requested, ok := gtidSet.(replication.Mysql56GTIDSet)
+	if !ok && gtidSet != nil {
+		return sqlerror.NewSQLError(sqlerror.ERMasterFatalReadingBinlog, sqlerror.SSUnknownSQLState, "the binlog server only supports MySQL GTID sets")
+	}
+	if requested == nil {
+		requested = replication.Mysql56GTIDSet{}
+	}
+
+	dumps.Add(1)
+	defer dumps.Add(-1)
+	err := h.bs.store.Dump(h.bs.ctx, requested, h.bs.serverID, c.ClientData.(*connState).heartbeatPeriod, func(ev mysql.BinlogEvent) error {
+		return c.WriteBinlogEvent(ev, false)
+	})
+	if errors.Is(err, errBinlogPurged) {
+		return sqlerror.NewSQLError(sqlerror.ERMasterFatalReadingBinlog, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	return err
+}
+
+// ComPrepare implements mysql.Handler.
+func (h *handler) ComPrepare(c *mysql.Conn, query string, bindVars map[string]*querypb.BindVariable) ([]*querypb.Field, error) {
+	return nil, sqlerror.NewSQLError(sqlerror.ERNotSupportedYet, sqlerror.SSUnknownSQLState, "the binlog server does not support prepared statements")
+}
+
+// ComStmtExecute implements mysql.Handler.
+func (h *handler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	return sqlerror.NewSQLError(sqlerror.ERNotSupportedYet, sqlerror.SSUnknownSQLState, "the binlog server does not support prepared statements")
+}
+
+// WarningCount implements mysql.Handler.
+func (h *handler) WarningCount(c *mysql.Conn) uint16 {
+	return 0
+}
+
+func varcharFields(names ...string) []*querypb.Field {
+	fields := make([]*querypb.Field, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, &querypb.Field{Name: name, Type: sqltypes.VarChar})
+	}
+	return fields
+}
+
+func singleValueResult(name, value string) *sqltypes.Result {
+	return &sqltypes.Result{
+		Fields: varcharFields(name),
+		Rows:   [][]sqltypes.Value{{sqltypes.NewVarChar(value)}},
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package binlogserver implements the binlog server mode of vttablet: it pulls
+the binary logs of the MySQL server of the tablet into a directory, retains
+them independently of the retention of the MySQL server, and serves them on a
+MySQL protocol port to replicas, as the MySQL server would.
+
+The port is registered in the topology, as the "binlog" port of the tablet, so
+that point in time recoveries can apply the binary logs of the shard from the
+binlog server, and VReplication streams can catch up from it once the MySQL
+servers of the shard purged the binary logs they need.
+*/
+package binlogserver
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/binlog"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttls"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// PortName is the name of the port of the binlog server in the port map of
+// the tablet record.
+const PortName = "binlog"
+
+var (
+	port      int
+	dir       string
+	retention = 7 * 24 * time.Hour
+	maxSize   int64
+	serverID  uint32
+	sslCert   string
+	sslKey    string
+	sslCA     string
+
+	pullRetryDelay = 5 * time.Second
+	purgeInterval  = time.Minute
+
+	eventsPulled = stats.NewCounter("BinlogServerEventsPulled", "Number of binary log events pulled by the binlog server")
+	pullErrors   = stats.NewCounter("BinlogServerPullErrors", "Number of errors pulling binary logs into the binlog server")
+	filesPurged  = stats.NewCounter("BinlogServerFilesPurged", "Number of binary log files purged by the binlog server")
+	dumps        = stats.NewGauge("BinlogServerDumps", "Number of binary log dumps served by the binlog server")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&port, "binlog-server-port", port, "If set, vttablet retains the binary logs of its MySQL server in --binlog-server-dir and serves them to replicas on this MySQL protocol port, registered in the topology.")
+	fs.StringVar(&dir, "binlog-server-dir", dir, "Directory in which the binlog server retains binary logs.")
+	fs.DurationVar(&retention, "binlog-server-retention", retention, "How long the binlog server retains binary logs, independently of the retention of the MySQL server.")
+	fs.Int64Var(&maxSize, "binlog-server-max-size", maxSize, "If set, maximum size in bytes of the binary logs retained by the binlog server, the oldest ones being purged first.")
+	fs.Uint32Var(&serverID, "binlog-server-id", serverID, "Server ID of the binlog server, which must differ from the ones of its replicas. If not set, a random one is used.")
+	fs.StringVar(&sslCert, "binlog-server-ssl-cert", sslCert, "If set, the binlog server accepts TLS connections with this certificate.")
+	fs.StringVar(&sslKey, "binlog-server-ssl-key", sslKey, "Key of --binlog-server-ssl-cert.")
+	fs.StringVar(&sslCA, "binlog-server-ssl-ca", sslCA, "If set, the binlog server requires client certificates signed by this CA.")
+}
+
+func init() {
+	servenv.OnParseFor("vttablet", registerFlags)
+}
+
+// Enabled returns true if vttablet runs a binlog server.
+func Enabled() bool {
+	return port != 0
+}
+
+// Port returns the MySQL protocol port of the binlog server.
+func Port() int {
+	return port
+}
+
+// Server pulls the binary logs of a MySQL server into a Store, purges them
+// once past their retention, and serves them to replicas.
+type Server struct {
+	store      *Store
+	source     dbconfigs.Connector
+	serverID   uint32
+	serverUUID string
+
+	listener *mysql.Listener
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewServer returns a Server for the store, pulling the binary logs of the
+// source, if set. A serverID of 0 picks a random one.
+func NewServer(store *Store, source *dbconfigs.Connector, serverID uint32) *Server {
+	if serverID == 0 {
+		serverID = uint32(rand.Int63n(math.MaxInt32-1)) + 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bs := &Server{
+		store:      store,
+		serverID:   serverID,
+		serverUUID: uuid.NewString(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	if source != nil {
+		bs.source = *source
+	}
+	return bs
+}
+
+// NewServerFromFlags opens the store of --binlog-server-dir, and returns a
+// Server pulling the binary logs of the MySQL server of the tablet into it,
+// and serving them on --binlog-server-port to the replication, dba and
+// filtered users of the tablet.
+func NewServerFromFlags(dbcfgs *dbconfigs.DBConfigs) (*Server, error) {
+	if dir == "" {
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "--binlog-server-dir is required with --binlog-server-port")
+	}
+	store, err := OpenStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	source := dbcfgs.DbaConnector()
+	bs := NewServer(store, &source, serverID)
+
+	authServer, err := newAuthServer(dbcfgs.ReplConnector(), dbcfgs.DbaConnector(), dbcfgs.FilteredWithDB())
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	var tlsConfig *tls.Config
+	if sslCert != "" {
+		if tlsConfig, err = vttls.ServerConfig(sslCert, sslKey, sslCA, "", "", tls.VersionTLS12); err != nil {
+			store.Close()
+			return nil, err
+		}
+	}
+	if err := bs.Listen(net.JoinHostPort("", strconv.Itoa(port)), authServer, tlsConfig); err != nil {
+		store.Close()
+		return nil, err
+	}
+	bs.Open()
+	return bs, nil
+}
+
+// newAuthServer returns an AuthServer accepting the users of the connectors.
+func newAuthServer(connectors ...dbconfigs.Connector) (mysql.AuthServer, error) {
+	entries := make(map[string][]*mysql.AuthServerStaticEntry)
+	for _, connector := range connectors {
+		params, err := connector.MysqlParams()
+		if err != nil {
+			return nil, err
+		}
+		if params.Uname == "" {
+			continue
+		}
+		entries[params.Uname] = append(entries[params.Uname], &mysql.AuthServerStaticEntry{Password: params.Pass})
+	}
+	config, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	return mysql.NewAuthServerStatic("", string(config), 0), nil
+}
+
+// Listen starts serving the binary logs of the store on the address.
+func (bs *Server) Listen(address string, authServer mysql.AuthServer, tlsConfig *tls.Config) error {
+	listener, err := mysql.NewListener("tcp", address, authServer, &handler{bs: bs}, 0, 0, false, false, 0)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		listener.TLSConfig.Store(tlsConfig)
+	}
+	bs.listener = listener
+	go listener.Accept()
+	log.Infof("Binlog server listening on %v", listener.Addr())
+	return nil
+}
+
+// Addr returns the address the binlog server listens on.
+func (bs *Server) Addr() net.Addr {
+	return bs.listener.Addr()
+}
+
+// Open starts pulling the binary logs of the source, and purging the ones
+// past their retention.
+func (bs *Server) Open() {
+	if bs.source != (dbconfigs.Connector{}) {
+		bs.wg.Add(1)
+		go func() {
+			defer bs.wg.Done()
+			bs.pull()
+		}()
+	}
+	bs.wg.Add(1)
+	go func() {
+		defer bs.wg.Done()
+		bs.purge()
+	}()
+}
+
+// Close stops the binlog server.
+func (bs *Server) Close() {
+	if bs.listener != nil {
+		bs.listener.Close()
+	}
+	bs.cancel()
+	bs.wg.Wait()
+	if err := bs.store.Close(); err != nil {
+		log.Errorf("Cannot close the binlog store: %v", err)
+	}
+}
+
+// pull streams the binary logs of the source into the store, until the
+// server is closed.
+func (bs *Server) pull() {
+	for {
+		err := bs.pullOnce()
+		if bs.ctx.Err() != nil {
+			return
+		}
+		pullErrors.Add(1)
+		log.Errorf("Binlog server: cannot pull binary logs, retrying in %v: %v", pullRetryDelay, err)
+		select {
+		case <-bs.ctx.Done():
+			return
+		case <-time.After(pullRetryDelay):
+		}
+	}
+}
+
+// pullOnce streams the binary logs of the source into the store from its
+// position, or from the oldest binary log of the source if the store is
+// empty, until an error occurs.
+func (bs *Server) pullOnce() error {
+	conn, err := binlog.NewBinlogConnection(bs.source)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if conn.IsMariaDB() {
+		return vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, "the binlog server does not support MariaDB")
+	}
+
+	qr, err := conn.ExecuteFetch("SELECT @@global.binlog_checksum", 1, false)
+	if err != nil {
+		return err
+	}
+	checksumAlgorithm := byte(mysql.BinlogChecksumAlgOff)
+	if len(qr.Rows) == 1 && strings.EqualFold(qr.Rows[0][0].ToString(), "CRC32") {
+		checksumAlgorithm = mysql.BinlogChecksumAlgCRC32
+	}
+
+	pos := replication.Position{GTIDSet: bs.store.Position()}
+	if bs.store.Empty() {
+		if pos, err = conn.GetGTIDPurged(); err != nil {
+			return err
+		}
+	}
+	log.Infof("Binlog server: pulling binary logs from %v", pos)
+	events, errs, err := conn.StartBinlogDumpFromPosition(bs.ctx, "", pos)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return fmt.Errorf("binlog stream ended")
+			}
+			if err := bs.append(ev, checksumAlgorithm); err != nil {
+				return err
+			}
+			eventsPulled.Add(1)
+		case err := <-errs:
+			return err
+		case <-bs.ctx.Done():
+			return bs.ctx.Err()
+		}
+	}
+}
+
+// append writes an event of the source to the store.
+func (bs *Server) append(ev mysql.BinlogEvent, checksumAlgorithm byte) error {
+	if !ev.IsValid() {
+		return fmt.Errorf("invalid binlog event: %v", ev.Bytes())
+	}
+	switch {
+	case ev.IsHeartbeat():
+		return nil
+	case ev.IsRotate():
+		// The ROTATE events ending the binary logs of the source are
+		// written, unlike the artificial ones starting their dumps.
+		if ev.NextPosition() != 0 {
+			if err := bs.store.Append(ev); err != nil {
+				return err
+			}
+		}
+		return bs.store.Rotate(rotateLogFile(ev, checksumAlgorithm))
+	}
+	return bs.store.Append(ev)
+}
+
+// purge purges the binary logs past their retention, until the server is
+// closed.
+func (bs *Server) purge() {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		purged, err := bs.store.Purge(time.Now().Add(-retention), maxSize)
+		if len(purged) > 0 {
+			filesPurged.Add(int64(len(purged)))
+			log.Infof("Binlog server: purged %v", purged)
+		}
+		if err != nil {
+			log.Errorf("Binlog server: cannot purge binary logs: %v", err)
+		}
+		select {
+		case <-bs.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
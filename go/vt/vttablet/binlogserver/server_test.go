@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/vt/binlog"
+	"vitess.io/vitess/go/vt/dbconfigs"
+)
+
+// startServer starts a binlog server of the store, pulling from the source
+// if set, and returns the parameters to connect to it.
+func startServer(t *testing.T, store *Store, source *dbconfigs.Connector) (*Server, *mysql.ConnParams) {
+	t.Helper()
+	params := &mysql.ConnParams{Uname: "repl", Pass: "password"}
+	authServer, err := newAuthServer(dbconfigs.New(params))
+	require.NoError(t, err)
+
+	bs := NewServer(store, source, 0)
+	require.NoError(t, bs.Listen("127.0.0.1:0", authServer, nil))
+	bs.Open()
+	t.Cleanup(bs.Close)
+
+	params.Host = "127.0.0.1"
+	params.Port = bs.Addr().(*net.TCPAddr).Port
+	return bs, params
+}
+
+func TestServerQueries(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	src := newTestSource()
+	require.NoError(t, store.Rotate("binlog.000001"))
+	appendEvents(t, store, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1")), src.transaction(2))
+	bs, params := startServer(t, store, nil)
+
+	ctx := context.Background()
+	conn, err := mysql.Connect(ctx, params)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	pos, err := conn.PrimaryPosition()
+	require.NoError(t, err)
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-2", pos.GTIDSet.String())
+	purged, err := conn.GetGTIDPurged()
+	require.NoError(t, err)
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1", purged.GTIDSet.String())
+	uuid, err := conn.GetServerUUID()
+	require.NoError(t, err)
+	assert.Equal(t, bs.serverUUID, uuid)
+
+	qr, err := conn.ExecuteFetch("SELECT @@global.binlog_checksum", 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "CRC32", qr.Rows[0][0].ToString())
+	qr, err = conn.ExecuteFetch("SHOW BINARY LOGS", 10, false)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "binlog.000001", qr.Rows[0][0].ToString())
+	qr, err = conn.ExecuteFetch("SHOW GLOBAL VARIABLES LIKE 'gtid_mode'", 10, false)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "ON", qr.Rows[0][1].ToString())
+
+	_, err = conn.ExecuteFetch("SELECT @@global.innodb_buffer_pool_size", 1, false)
+	assert.ErrorContains(t, err, "Unknown system variable")
+	_, err = conn.ExecuteFetch("SELECT 1 FROM dual", 1, false)
+	assert.Equal(t, sqlerror.ERNotSupportedYet, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Number())
+}
+
+func TestServerDump(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	src := newTestSource()
+	require.NoError(t, store.Rotate("binlog.000001"))
+	appendEvents(t, store, src.startFile(replication.Mysql56GTIDSet{}), src.transaction(1), src.transaction(2))
+	_, params := startServer(t, store, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := binlog.NewBinlogConnection(dbconfigs.New(params))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	events, errs, err := conn.StartBinlogDumpFromPosition(ctx, "", replication.Position{GTIDSet: gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1")})
+	require.NoError(t, err)
+	var gnos []int64
+	for len(gnos) < 2 {
+		select {
+		case ev := <-events:
+			if ev.IsGTID() {
+				gnos = append(gnos, dumpedGNOs(t, []mysql.BinlogEvent{ev})...)
+				if len(gnos) == 1 {
+					appendEvents(t, store, src.transaction(3))
+				}
+			}
+		case err := <-errs:
+			require.NoError(t, err)
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+	}
+	assert.Equal(t, []int64{2, 3}, gnos)
+}
+
+func TestServerDumpPurged(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	src := newTestSource()
+	require.NoError(t, store.Rotate("binlog.000001"))
+	appendEvents(t, store, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-5")), src.transaction(6))
+	_, params := startServer(t, store, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := binlog.NewBinlogConnection(dbconfigs.New(params))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	events, errs, err := conn.StartBinlogDumpFromPosition(ctx, "", replication.Position{GTIDSet: gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-3")})
+	require.NoError(t, err)
+	for {
+		select {
+		case <-events:
+			continue
+		case err := <-errs:
+			require.Error(t, err)
+			assert.Equal(t, sqlerror.ERMasterFatalReadingBinlog, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Number())
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+		return
+	}
+}
+
+func TestServerPull(t *testing.T) {
+	// A binlog server pulls the binary logs of another one, as it would the
+	// ones of a MySQL server.
+	upstream, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	src := newTestSource()
+	require.NoError(t, upstream.Rotate("binlog.000001"))
+	appendEvents(t, upstream, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1")), src.transaction(2))
+	appendEvents(t, upstream, []mysql.BinlogEvent{mysql.NewRotateEvent(src.format, src.stream, 4, "binlog.000002")})
+	require.NoError(t, upstream.Rotate("binlog.000002"))
+	appendEvents(t, upstream, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-2")), src.transaction(3))
+	_, params := startServer(t, upstream, nil)
+
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	source := dbconfigs.New(params)
+	startServer(t, store, &source)
+
+	require.Eventually(t, func() bool {
+		return store.Position().String() == "01020304-0506-0708-090a-0b0c0d0e0f10:1-3"
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, upstream.Files(), store.Files())
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1", store.Purged().String())
+
+	appendEvents(t, upstream, src.transaction(4))
+	require.Eventually(t, func() bool {
+		return store.Position().String() == "01020304-0506-0708-090a-0b0c0d0e0f10:1-4"
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, upstream.Files(), store.Files())
+}
@@ -0,0 +1,585 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/log"
+)
+
+var (
+	// errBinlogPurged is returned by Dump when the store purged binary logs
+	// with transactions not in the requested GTID set.
+	errBinlogPurged = errors.New("the binlog server has purged binary logs containing GTIDs that the replica requires")
+
+	// errStoreClosed is returned by the methods of a closed Store.
+	errStoreClosed = errors.New("binlog store is closed")
+)
+
+// binlogFile is a binary log file of a Store.
+type binlogFile struct {
+	name string
+	// size is the size of the complete events of the file. Readers never
+	// read past it.
+	size int64
+
+	format    mysql.BinlogFormat
+	hasFormat bool
+	// previousGTIDs is the GTID set executed before the file, from its
+	// PREVIOUS_GTIDS event.
+	previousGTIDs    replication.Mysql56GTIDSet
+	hasPreviousGTIDs bool
+	// gtids is the GTID set of the transactions of the file.
+	gtids replication.Mysql56GTIDSet
+	// lastGTIDOffset is the offset of the last GTID event of the file, 0
+	// if it has none.
+	lastGTIDOffset int64
+	// lastTimestamp is the timestamp of the last event of the file.
+	lastTimestamp uint32
+}
+
+// add updates the metadata of the file with an event written at its end.
+func (f *binlogFile) add(ev mysql.BinlogEvent) error {
+	switch {
+	case ev.IsFormatDescription():
+		format, err := ev.Format()
+		if err != nil {
+			return err
+		}
+		f.format, f.hasFormat = format, true
+	case ev.IsPreviousGTIDs():
+		stripped, _, err := ev.StripChecksum(f.format)
+		if err != nil {
+			return err
+		}
+		pos, err := stripped.PreviousGTIDs(f.format)
+		if err != nil {
+			return err
+		}
+		gtidSet, ok := pos.GTIDSet.(replication.Mysql56GTIDSet)
+		if !ok {
+			return fmt.Errorf("unexpected previous GTIDs in %s: %v", f.name, pos)
+		}
+		f.previousGTIDs, f.hasPreviousGTIDs = gtidSet, true
+	case ev.IsGTID():
+		gtid, _, err := ev.GTID(f.format)
+		if err != nil {
+			return err
+		}
+		f.gtids = f.gtids.AddGTID(gtid).(replication.Mysql56GTIDSet)
+		f.lastGTIDOffset = f.size
+	}
+	if ts := ev.Timestamp(); ts != 0 {
+		f.lastTimestamp = ts
+	}
+	f.size += int64(len(ev.Bytes()))
+	return nil
+}
+
+// BinlogFileInfo describes a binary log file of a Store.
+type BinlogFileInfo struct {
+	Name string
+	Size int64
+}
+
+// Store retains binary logs in a directory, in files mirroring the ones of
+// the MySQL server they are pulled from. It has a single writer, appending
+// the events of the source as they come, and any number of readers, dumping
+// them to replicas. Only the MySQL 5.6+ GTID flavor is supported.
+type Store struct {
+	dir string
+
+	mu    sync.Mutex
+	files []*binlogFile
+	// executed is the GTID set of the store: the GTIDs executed before its
+	// first file, and the ones of all its files.
+	executed replication.Mysql56GTIDSet
+	// current is the last file, open for writing, nil until the first Rotate.
+	current *os.File
+	// changed is closed, and replaced, whenever the store changes, to
+	// wake up the readers waiting for new events.
+	changed chan struct{}
+	closed  bool
+}
+
+// OpenStore opens the store of the directory, creating it if needed. The
+// last file is truncated before its last transaction, which may not have
+// been completely written: the writer fetches it again from the source.
+func OpenStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		dir:      dir,
+		executed: replication.Mysql56GTIDSet{},
+		changed:  make(chan struct{}),
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		file, err := scanFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if file != nil {
+			s.files = append(s.files, file)
+		}
+	}
+	if len(s.files) == 0 {
+		return s, nil
+	}
+
+	last := s.files[len(s.files)-1]
+	if last.lastGTIDOffset != 0 {
+		// Rescan the file, so that its metadata matches its truncated content.
+		if err := os.Truncate(s.path(last.name), last.lastGTIDOffset); err != nil {
+			return nil, err
+		}
+		if last, err = scanFile(s.path(last.name)); err != nil {
+			return nil, err
+		}
+		s.files[len(s.files)-1] = last
+	} else if err := os.Truncate(s.path(last.name), last.size); err != nil {
+		return nil, err
+	}
+	if s.current, err = os.OpenFile(s.path(last.name), os.O_WRONLY|os.O_APPEND, 0); err != nil {
+		return nil, err
+	}
+
+	if s.files[0].hasPreviousGTIDs {
+		s.executed = s.files[0].previousGTIDs
+	}
+	for _, file := range s.files {
+		s.executed = s.executed.Union(file.gtids).(replication.Mysql56GTIDSet)
+	}
+	log.Infof("Opened binlog store %s with %d files, at %v", dir, len(s.files), s.executed)
+	return s, nil
+}
+
+// scanFile reads the metadata of a binary log file, nil if the file is not
+// a binary log. A partially written event at the end of the file is ignored.
+func scanFile(path string) (*binlogFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReaderSize(f, 64*1024)
+	magic := make([]byte, len(mysql.BinglogMagicNumber))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, mysql.BinglogMagicNumber) {
+		return nil, nil
+	}
+
+	file := &binlogFile{
+		name:  filepath.Base(path),
+		size:  int64(len(mysql.BinglogMagicNumber)),
+		gtids: replication.Mysql56GTIDSet{},
+	}
+	for {
+		buf, err := readEvent(r)
+		if err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, err
+			}
+			return file, nil
+		}
+		if err := file.add(mysql.NewMysql56BinlogEvent(buf)); err != nil {
+			return nil, fmt.Errorf("cannot read %s: %v", path, err)
+		}
+	}
+}
+
+// readEvent reads the next event of a binary log file.
+func readEvent(r io.Reader) ([]byte, error) {
+	header := make([]byte, mysql.BinlogFixedHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length := binary.LittleEndian.Uint32(header[mysql.BinlogEventLenOffset:])
+	if length < mysql.BinlogFixedHeaderLen {
+		return nil, io.ErrUnexpectedEOF
+	}
+	buf := make([]byte, length)
+	copy(buf, header)
+	if _, err := io.ReadFull(r, buf[mysql.BinlogFixedHeaderLen:]); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// notify wakes up the readers. It must be called with the lock held.
+func (s *Store) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Rotate makes the named file the one Append writes to, creating it if it
+// is not already the last file of the store.
+func (s *Store) Rotate(name string) error {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return fmt.Errorf("invalid binary log name %q", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errStoreClosed
+	}
+	if len(s.files) > 0 {
+		last := s.files[len(s.files)-1]
+		if last.name == name {
+			return nil
+		}
+		if name < last.name {
+			return fmt.Errorf("binary log %s is older than the last one of the store, %s", name, last.name)
+		}
+	}
+
+	f, err := os.OpenFile(s.path(name), os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(mysql.BinglogMagicNumber); err != nil {
+		f.Close()
+		return err
+	}
+	if s.current != nil {
+		if err := s.current.Sync(); err != nil {
+			log.Warningf("Cannot sync binary log %s: %v", s.current.Name(), err)
+		}
+		s.current.Close()
+	}
+	s.current = f
+	s.files = append(s.files, &binlogFile{
+		name:  name,
+		size:  int64(len(mysql.BinglogMagicNumber)),
+		gtids: replication.Mysql56GTIDSet{},
+	})
+	s.notify()
+	return nil
+}
+
+// Append writes the event at the end of the last file. The FORMAT_DESCRIPTION
+// and PREVIOUS_GTIDS events sent again by the source when resuming the dump
+// of a file are skipped.
+func (s *Store) Append(ev mysql.BinlogEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errStoreClosed
+	}
+	if s.current == nil {
+		return errors.New("no binary log to append to")
+	}
+	file := s.files[len(s.files)-1]
+	if (ev.IsFormatDescription() && file.hasFormat) || (ev.IsPreviousGTIDs() && file.hasPreviousGTIDs) {
+		return nil
+	}
+
+	if _, err := s.current.Write(ev.Bytes()); err != nil {
+		return err
+	}
+	if err := file.add(ev); err != nil {
+		return err
+	}
+	switch {
+	case ev.IsPreviousGTIDs():
+		s.executed = s.executed.Union(file.previousGTIDs).(replication.Mysql56GTIDSet)
+	case ev.IsGTID():
+		s.executed = s.executed.Union(file.gtids).(replication.Mysql56GTIDSet)
+	}
+	s.notify()
+	return nil
+}
+
+// Position returns the GTID set of the store.
+func (s *Store) Position() replication.Mysql56GTIDSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.executed
+}
+
+// Purged returns the GTID set of the transactions purged from the store,
+// or never pulled into it.
+func (s *Store) Purged() replication.Mysql56GTIDSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.files) == 0 || !s.files[0].hasPreviousGTIDs {
+		return replication.Mysql56GTIDSet{}
+	}
+	return s.files[0].previousGTIDs
+}
+
+// Empty returns true if the store has no binary log.
+func (s *Store) Empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.files) == 0
+}
+
+// Files returns the binary logs of the store, oldest first.
+func (s *Store) Files() []BinlogFileInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make([]BinlogFileInfo, 0, len(s.files))
+	for _, file := range s.files {
+		files = append(files, BinlogFileInfo{Name: file.name, Size: file.size})
+	}
+	return files
+}
+
+// ChecksumAlgorithm returns the checksum algorithm of the events of the
+// last file, CRC32 if there is none yet.
+func (s *Store) ChecksumAlgorithm() byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.files) - 1; i >= 0; i-- {
+		if s.files[i].hasFormat {
+			return s.files[i].format.ChecksumAlgorithm
+		}
+	}
+	return mysql.BinlogChecksumAlgCRC32
+}
+
+// Purge removes the oldest files whose last event is older than before, and
+// then the ones exceeding maxSize in total, if set. The last file is never
+// removed. It returns the names of the removed files.
+func (s *Store) Purge(before time.Time, maxSize int64) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errStoreClosed
+	}
+
+	var total int64
+	for _, file := range s.files {
+		total += file.size
+	}
+	var purged []string
+	for len(s.files) > 1 {
+		file := s.files[0]
+		expired := file.lastTimestamp != 0 && int64(file.lastTimestamp) < before.Unix()
+		if !expired && (maxSize <= 0 || total <= maxSize) {
+			break
+		}
+		if err := os.Remove(s.path(file.name)); err != nil {
+			return purged, err
+		}
+		purged = append(purged, file.name)
+		total -= file.size
+		s.files = s.files[1:]
+	}
+	return purged, nil
+}
+
+// Close closes the store, ending the dumps.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.notify()
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Close()
+}
+
+// Dump sends the events of the store, skipping the transactions of gtidSet,
+// as mysqld does for COM_BINLOG_DUMP_GTID: it starts with the last file
+// whose previous GTIDs are all in gtidSet, and fails with errBinlogPurged if
+// there is none. Each file starts with an artificial ROTATE event, with its
+// name. At the end of the last file, Dump waits for new events, sending a
+// HEARTBEAT event every heartbeatPeriod if set, until ctx is done, the store
+// is closed or send fails.
+func (s *Store) Dump(ctx context.Context, gtidSet replication.Mysql56GTIDSet, serverID uint32, heartbeatPeriod time.Duration, send func(mysql.BinlogEvent) error) error {
+	file, err := s.firstDumpFile(ctx, gtidSet)
+	if err != nil {
+		return err
+	}
+	for {
+		if err := s.dumpFile(ctx, file, gtidSet, serverID, heartbeatPeriod, send); err != nil {
+			return err
+		}
+		if file = s.nextFile(file.name); file == nil {
+			// The files after the dumped one were purged in the meantime.
+			return errBinlogPurged
+		}
+	}
+}
+
+// firstDumpFile returns the file a dump of gtidSet starts with, waiting for
+// the first file of an empty store.
+func (s *Store) firstDumpFile(ctx context.Context, gtidSet replication.Mysql56GTIDSet) (*binlogFile, error) {
+	for {
+		s.mu.Lock()
+		files, changed, closed := s.files, s.changed, s.closed
+		s.mu.Unlock()
+		if closed {
+			return nil, errStoreClosed
+		}
+		if len(files) > 0 && files[0].hasPreviousGTIDs {
+			if !gtidSet.Contains(files[0].previousGTIDs) {
+				return nil, errBinlogPurged
+			}
+			first := files[0]
+			for _, file := range files[1:] {
+				if !file.hasPreviousGTIDs || !gtidSet.Contains(file.previousGTIDs) {
+					break
+				}
+				first = file
+			}
+			return first, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// nextFile returns the file following the named one, nil if there is none.
+func (s *Store) nextFile(name string) *binlogFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, file := range s.files {
+		if file.name > name {
+			return file
+		}
+	}
+	return nil
+}
+
+// dumpFile sends the events of a file, returning once all its events are
+// sent and it is not the last file anymore.
+func (s *Store) dumpFile(ctx context.Context, file *binlogFile, gtidSet replication.Mysql56GTIDSet, serverID uint32, heartbeatPeriod time.Duration, send func(mysql.BinlogEvent) error) error {
+	f, err := os.Open(s.path(file.name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errBinlogPurged
+		}
+		return err
+	}
+	defer f.Close()
+
+	var (
+		offset   = int64(len(mysql.BinglogMagicNumber))
+		format   mysql.BinlogFormat
+		rotated  bool
+		skipping bool
+	)
+	sendEvent := func(buf []byte) error {
+		ev := mysql.NewMysql56BinlogEvent(buf)
+		switch {
+		case ev.IsFormatDescription():
+			if format, err = ev.Format(); err != nil {
+				return err
+			}
+			if !rotated {
+				if err := send(newRotateEvent(format, serverID, file.name)); err != nil {
+					return err
+				}
+				rotated = true
+			}
+			skipping = false
+		case ev.IsPreviousGTIDs(), ev.IsRotate(), ev.IsStop():
+			skipping = false
+		case ev.IsGTID():
+			gtid, _, err := ev.GTID(format)
+			if err != nil {
+				return err
+			}
+			skipping = gtidSet.ContainsGTID(gtid)
+		}
+		if skipping {
+			return nil
+		}
+		return send(ev)
+	}
+
+	var heartbeat <-chan time.Time
+	if heartbeatPeriod > 0 {
+		ticker := time.NewTicker(heartbeatPeriod)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	for {
+		s.mu.Lock()
+		size, last, changed, closed := file.size, s.files[len(s.files)-1] == file, s.changed, s.closed
+		s.mu.Unlock()
+		if closed {
+			return errStoreClosed
+		}
+
+		if offset < size {
+			r := bufio.NewReaderSize(io.NewSectionReader(f, offset, size-offset), 64*1024)
+			for offset < size {
+				buf, err := readEvent(r)
+				if err != nil {
+					return fmt.Errorf("cannot read %s at %d: %v", file.name, offset, err)
+				}
+				if err := sendEvent(buf); err != nil {
+					return err
+				}
+				offset += int64(len(buf))
+			}
+			continue
+		}
+		if !last {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-heartbeat:
+			if rotated {
+				if err := send(newHeartbeatEvent(format, serverID, file.name, offset)); err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
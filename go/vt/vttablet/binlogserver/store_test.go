@@ -0,0 +1,288 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+)
+
+var testSID = replication.SID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+// testSource generates the binary logs of a MySQL server.
+type testSource struct {
+	format mysql.BinlogFormat
+	stream *mysql.FakeBinlogStream
+}
+
+func newTestSource() *testSource {
+	stream := mysql.NewFakeBinlogStream()
+	// The binary logs are recent, so that the binlog servers do not purge them.
+	stream.Timestamp = uint32(time.Now().Unix())
+	return &testSource{
+		format: mysql.NewMySQL56BinlogFormat(),
+		stream: stream,
+	}
+}
+
+// startFile returns the events starting a binary log.
+func (ts *testSource) startFile(previousGTIDs replication.Mysql56GTIDSet) []mysql.BinlogEvent {
+	return []mysql.BinlogEvent{
+		mysql.NewFormatDescriptionEvent(ts.format, ts.stream),
+		mysql.NewMySQL56PreviousGTIDsEvent(ts.format, ts.stream, previousGTIDs),
+	}
+}
+
+// transaction returns the events of a transaction.
+func (ts *testSource) transaction(gno int64) []mysql.BinlogEvent {
+	return []mysql.BinlogEvent{
+		mysql.NewMySQL56GTIDEvent(ts.format, ts.stream, replication.Mysql56GTID{Server: testSID, Sequence: gno}),
+		mysql.NewQueryEvent(ts.format, ts.stream, mysql.Query{SQL: "BEGIN"}),
+		mysql.NewXIDEvent(ts.format, ts.stream),
+	}
+}
+
+func appendEvents(t *testing.T, s *Store, events ...[]mysql.BinlogEvent) {
+	t.Helper()
+	for _, evs := range events {
+		for _, ev := range evs {
+			require.NoError(t, s.Append(ev))
+		}
+	}
+}
+
+func gtidSet(t *testing.T, s string) replication.Mysql56GTIDSet {
+	t.Helper()
+	set, err := replication.ParseMysql56GTIDSet(s)
+	require.NoError(t, err)
+	return set
+}
+
+// dump returns the events a Dump of the store sends from the GTID set,
+// until it sends the GTID event of lastGNO.
+func dump(t *testing.T, s *Store, from replication.Mysql56GTIDSet, lastGNO int64) ([]mysql.BinlogEvent, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	format := mysql.NewMySQL56BinlogFormat()
+	var events []mysql.BinlogEvent
+	err := s.Dump(ctx, from, 1, 0, func(ev mysql.BinlogEvent) error {
+		events = append(events, ev)
+		if ev.IsGTID() {
+			gtid, _, err := ev.GTID(format)
+			require.NoError(t, err)
+			if gtid.(replication.Mysql56GTID).Sequence == lastGNO {
+				cancel()
+			}
+		}
+		return nil
+	})
+	if err == context.Canceled {
+		err = nil
+	}
+	return events, err
+}
+
+// dumpedGNOs returns the sequence numbers of the GTID events of a dump.
+func dumpedGNOs(t *testing.T, events []mysql.BinlogEvent) []int64 {
+	format := mysql.NewMySQL56BinlogFormat()
+	var gnos []int64
+	for _, ev := range events {
+		if ev.IsGTID() {
+			gtid, _, err := ev.GTID(format)
+			require.NoError(t, err)
+			gnos = append(gnos, gtid.(replication.Mysql56GTID).Sequence)
+		}
+	}
+	return gnos
+}
+
+func TestStoreAppend(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenStore(dir)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.True(t, s.Empty())
+
+	src := newTestSource()
+	assert.Error(t, s.Append(src.transaction(1)[0]), "no file to append to")
+	require.NoError(t, s.Rotate("binlog.000001"))
+	appendEvents(t, s, src.startFile(replication.Mysql56GTIDSet{}), src.transaction(1), src.transaction(2))
+	// The source sends the first events of a file again when resuming its dump.
+	appendEvents(t, s, src.startFile(replication.Mysql56GTIDSet{}))
+
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-2", s.Position().String())
+	files := s.Files()
+	require.Len(t, files, 1)
+	info, err := os.Stat(filepath.Join(dir, "binlog.000001"))
+	require.NoError(t, err)
+	assert.Equal(t, BinlogFileInfo{Name: "binlog.000001", Size: info.Size()}, files[0])
+
+	require.NoError(t, s.Rotate("binlog.000001"), "rotating to the last file is a no-op")
+	assert.Error(t, s.Rotate("binlog.000000"))
+	assert.Error(t, s.Rotate("../binlog.000002"))
+	require.NoError(t, s.Rotate("binlog.000002"))
+	assert.Len(t, s.Files(), 2)
+}
+
+func TestStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenStore(dir)
+	require.NoError(t, err)
+
+	src := newTestSource()
+	require.NoError(t, s.Rotate("binlog.000001"))
+	appendEvents(t, s, src.startFile(replication.Mysql56GTIDSet{}), src.transaction(1))
+	size := s.Files()[0].Size
+	// The last transaction may not have been completely written.
+	appendEvents(t, s, src.transaction(2)[:2])
+	require.NoError(t, s.Close())
+
+	// An incomplete event is ignored as well.
+	f, err := os.OpenFile(filepath.Join(dir, "binlog.000001"), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.Write(src.transaction(3)[0].Bytes()[:10])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	// Other files are not binary logs.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("binary logs"), 0o644))
+
+	s, err = OpenStore(dir)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1", s.Position().String())
+	assert.Equal(t, []BinlogFileInfo{{Name: "binlog.000001", Size: size}}, s.Files())
+
+	appendEvents(t, s, src.transaction(2))
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-2", s.Position().String())
+	events, err := dump(t, s, replication.Mysql56GTIDSet{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, dumpedGNOs(t, events))
+}
+
+func TestStoreDump(t *testing.T) {
+	s, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	defer s.Close()
+
+	src := newTestSource()
+	require.NoError(t, s.Rotate("binlog.000001"))
+	appendEvents(t, s, src.startFile(replication.Mysql56GTIDSet{}), src.transaction(1), src.transaction(2))
+	appendEvents(t, s, []mysql.BinlogEvent{mysql.NewRotateEvent(src.format, src.stream, 4, "binlog.000002")})
+	require.NoError(t, s.Rotate("binlog.000002"))
+	appendEvents(t, s, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-2")), src.transaction(3))
+
+	tests := []struct {
+		name      string
+		from      string
+		wantFiles []string
+		wantGNOs  []int64
+	}{{
+		name:      "from the start",
+		from:      "",
+		wantFiles: []string{"binlog.000001", "binlog.000002"},
+		wantGNOs:  []int64{1, 2, 3},
+	}, {
+		name:      "skipping executed transactions",
+		from:      "01020304-0506-0708-090a-0b0c0d0e0f10:1",
+		wantFiles: []string{"binlog.000001", "binlog.000002"},
+		wantGNOs:  []int64{2, 3},
+	}, {
+		name:      "from the last file",
+		from:      "01020304-0506-0708-090a-0b0c0d0e0f10:1-2",
+		wantFiles: []string{"binlog.000002"},
+		wantGNOs:  []int64{3},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := dump(t, s, gtidSet(t, tt.from), 3)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGNOs, dumpedGNOs(t, events))
+
+			// Each file starts with an artificial ROTATE event with its name,
+			// followed by its FORMAT_DESCRIPTION event.
+			var files []string
+			for i, ev := range events {
+				if ev.IsRotate() && ev.NextPosition() == 0 {
+					files = append(files, rotateLogFile(ev, mysql.BinlogChecksumAlgCRC32))
+					require.Greater(t, len(events), i+1)
+					assert.True(t, events[i+1].IsFormatDescription())
+				}
+			}
+			assert.Equal(t, tt.wantFiles, files)
+		})
+	}
+
+	t.Run("waiting for new events", func(t *testing.T) {
+		done := make(chan []mysql.BinlogEvent)
+		go func() {
+			events, err := dump(t, s, gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-3"), 4)
+			assert.NoError(t, err)
+			done <- events
+		}()
+		appendEvents(t, s, src.transaction(4))
+		assert.Equal(t, []int64{4}, dumpedGNOs(t, <-done))
+	})
+}
+
+func TestStorePurge(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenStore(dir)
+	require.NoError(t, err)
+	defer s.Close()
+
+	src := newTestSource()
+	require.NoError(t, s.Rotate("binlog.000001"))
+	appendEvents(t, s, src.startFile(replication.Mysql56GTIDSet{}), src.transaction(1))
+	require.NoError(t, s.Rotate("binlog.000002"))
+	appendEvents(t, s, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1")), src.transaction(2))
+	require.NoError(t, s.Rotate("binlog.000003"))
+	appendEvents(t, s, src.startFile(gtidSet(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-2")), src.transaction(3))
+
+	// The events of the test source are not older than the retention.
+	purged, err := s.Purge(time.Unix(int64(src.stream.Timestamp), 0), 0)
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	// The size of the two last files fits.
+	files := s.Files()
+	purged, err = s.Purge(time.Unix(int64(src.stream.Timestamp), 0), files[1].Size+files[2].Size)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"binlog.000001"}, purged)
+	assert.NoFileExists(t, filepath.Join(dir, "binlog.000001"))
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1", s.Purged().String())
+
+	_, err = dump(t, s, replication.Mysql56GTIDSet{}, 3)
+	assert.ErrorIs(t, err, errBinlogPurged)
+
+	// The last file is never purged.
+	purged, err = s.Purge(time.Now().Add(time.Hour), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"binlog.000002"}, purged)
+	assert.Equal(t, []BinlogFileInfo{{Name: "binlog.000003", Size: files[2].Size}}, s.Files())
+	assert.Equal(t, "01020304-0506-0708-090a-0b0c0d0e0f10:1-3", s.Position().String())
+}
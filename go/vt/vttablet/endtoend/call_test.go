@@ -71,28 +71,34 @@ var procSQL = []string{
 func TestCallProcedure(t *testing.T) {
 	client := framework.NewClient()
 	type testcases struct {
-		query   string
-		wantErr bool
+		query      string
+		resultSets int
+		wantFields bool
 	}
 	tcases := []testcases{{
-		query:   "call proc_select1()",
-		wantErr: true,
+		query:      "call proc_select1()",
+		resultSets: 2,
+		wantFields: true,
 	}, {
-		query:   "call proc_select4()",
-		wantErr: true,
+		query:      "call proc_select4()",
+		resultSets: 5,
+		wantFields: true,
 	}, {
-		query: "call proc_dml()",
+		query:      "call proc_dml()",
+		resultSets: 1,
 	}}
 
 	for _, tc := range tcases {
 		t.Run(tc.query, func(t *testing.T) {
-			_, err := client.Execute(tc.query, nil)
-			if tc.wantErr {
-				require.EqualError(t, err, "Multi-Resultset not supported in stored procedure (CallerID: dev)")
-				return
-			}
+			qr, err := client.Execute(tc.query, nil)
 			require.NoError(t, err)
-
+			require.Len(t, qr.MoreResults, tc.resultSets-1)
+			if tc.wantFields {
+				require.Equal(t, "intval", qr.Fields[0].Name)
+				for _, more := range qr.MoreResults[:len(qr.MoreResults)-1] {
+					require.Equal(t, "intval", more.Fields[0].Name)
+				}
+			}
 		})
 	}
 }
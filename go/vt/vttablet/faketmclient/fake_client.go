@@ -145,6 +145,11 @@ func (client *FakeTabletManagerClient) ChangeType(ctx context.Context, tablet *t
 	return nil
 }
 
+// ChangeTags is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ChangeTags(ctx context.Context, tablet *topodatapb.Tablet, tabletTags map[string]string, replace bool) (*tabletmanagerdatapb.ChangeTagsResponse, error) {
+	return &tabletmanagerdatapb.ChangeTagsResponse{Tags: tabletTags}, nil
+}
+
 // RefreshState is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) RefreshState(ctx context.Context, tablet *topodatapb.Tablet) error {
 	return nil
@@ -333,9 +338,15 @@ func (e *eofEventStream) Recv() (*logutilpb.Event, error) {
 	return nil, io.EOF
 }
 
+type eofBackupStream struct{}
+
+func (e *eofBackupStream) Recv() (*tabletmanagerdatapb.BackupResponse, error) {
+	return nil, io.EOF
+}
+
 // Backup is part of the tmclient.TabletManagerClient interface.
-func (client *FakeTabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (logutil.EventStream, error) {
-	return &eofEventStream{}, nil
+func (client *FakeTabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (tmclient.BackupStream, error) {
+	return &eofBackupStream{}, nil
 }
 
 // RestoreFromBackup is part of the tmclient.TabletManagerClient interface.
@@ -343,12 +354,51 @@ func (client *FakeTabletManagerClient) RestoreFromBackup(ctx context.Context, ta
 	return &eofEventStream{}, nil
 }
 
+type eofStreamBackupStream struct{}
+
+func (e *eofStreamBackupStream) Recv() (*tabletmanagerdatapb.StreamBackupResponse, error) {
+	return nil, io.EOF
+}
+
+// StreamBackup is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) StreamBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.StreamBackupRequest) (tmclient.StreamBackupStream, error) {
+	return &eofStreamBackupStream{}, nil
+}
+
+type eofValidateBackupStream struct{}
+
+func (e *eofValidateBackupStream) Recv() (*tabletmanagerdatapb.ValidateBackupResponse, error) {
+	return nil, io.EOF
+}
+
+// ValidateBackup is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ValidateBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ValidateBackupRequest) (tmclient.ValidateBackupStream, error) {
+	return &eofValidateBackupStream{}, nil
+}
+
+// ReloadTLSCertificates is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ReloadTLSCertificates(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (*tabletmanagerdatapb.ReloadTLSCertificatesResponse, error) {
+	return &tabletmanagerdatapb.ReloadTLSCertificatesResponse{}, nil
+}
+
 // Throttler related methods
 
 func (client *FakeTabletManagerClient) CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
 	return &tabletmanagerdatapb.CheckThrottlerResponse{}, nil
 }
 
+// Distributed transaction related methods
+
+// GetUnresolvedTransactions is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (*tabletmanagerdatapb.GetUnresolvedTransactionsResponse, error) {
+	return &tabletmanagerdatapb.GetUnresolvedTransactionsResponse{}, nil
+}
+
+// ConcludeTransaction is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.ConcludeTransactionRequest) (*tabletmanagerdatapb.ConcludeTransactionResponse, error) {
+	return &tabletmanagerdatapb.ConcludeTransactionResponse{}, nil
+}
+
 //
 // Management related methods
 //
@@ -24,6 +24,8 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/grpccommon"
+	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 
@@ -41,6 +43,10 @@ type query struct {
 
 var _ queryservicepb.QueryServer = (*query)(nil)
 
+func init() {
+	servenv.OnParseFor("vttablet", grpccommon.RegisterResultCompressionFlags)
+}
+
 // Execute is part of the queryservice.QueryServer interface
 func (q *query) Execute(ctx context.Context, request *querypb.ExecuteRequest) (response *querypb.ExecuteResponse, err error) {
 	defer q.server.HandlePanic(&err)
@@ -52,8 +58,10 @@ func (q *query) Execute(ctx context.Context, request *querypb.ExecuteRequest) (r
 	if err != nil {
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	grpccommon.MaybeCompressResult(ctx, qr.SizeVT())
 	return &querypb.ExecuteResponse{
-		Result: sqltypes.ResultToProto3(result),
+		Result: qr,
 	}, nil
 }
 
@@ -64,6 +72,7 @@ func (q *query) StreamExecute(request *querypb.StreamExecuteRequest, stream quer
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	grpccommon.MaybeCompressStream(ctx)
 	err = q.server.StreamExecute(ctx, request.Target, request.Query.Sql, request.Query.BindVariables, request.TransactionId, request.ReservedId, request.Options, func(reply *sqltypes.Result) error {
 		return stream.Send(&querypb.StreamExecuteResponse{
 			Result: sqltypes.ResultToProto3(reply),
@@ -252,8 +261,10 @@ func (q *query) BeginExecute(ctx context.Context, request *querypb.BeginExecuteR
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	grpccommon.MaybeCompressResult(ctx, qr.SizeVT())
 	return &querypb.BeginExecuteResponse{
-		Result:              sqltypes.ResultToProto3(result),
+		Result:              qr,
 		TransactionId:       state.TransactionID,
 		TabletAlias:         state.TabletAlias,
 		SessionStateChanges: state.SessionStateChanges,
@@ -267,6 +278,7 @@ func (q *query) BeginStreamExecute(request *querypb.BeginStreamExecuteRequest, s
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	grpccommon.MaybeCompressStream(ctx)
 	state, err := q.server.BeginStreamExecute(ctx, request.Target, request.PreQueries, request.Query.Sql, request.Query.BindVariables, request.ReservedId, request.Options, func(reply *sqltypes.Result) error {
 		return stream.Send(&querypb.BeginStreamExecuteResponse{
 			Result: sqltypes.ResultToProto3(reply),
@@ -392,8 +404,10 @@ func (q *query) ReserveExecute(ctx context.Context, request *querypb.ReserveExec
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	grpccommon.MaybeCompressResult(ctx, qr.SizeVT())
 	return &querypb.ReserveExecuteResponse{
-		Result:      sqltypes.ResultToProto3(result),
+		Result:      qr,
 		ReservedId:  state.ReservedID,
 		TabletAlias: state.TabletAlias,
 	}, nil
@@ -406,6 +420,7 @@ func (q *query) ReserveStreamExecute(request *querypb.ReserveStreamExecuteReques
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	grpccommon.MaybeCompressStream(ctx)
 	state, err := q.server.ReserveStreamExecute(ctx, request.Target, request.PreQueries, request.Query.Sql, request.Query.BindVariables, request.TransactionId, request.Options, func(reply *sqltypes.Result) error {
 		return stream.Send(&querypb.ReserveStreamExecuteResponse{
 			Result: sqltypes.ResultToProto3(reply),
@@ -444,8 +459,10 @@ func (q *query) ReserveBeginExecute(ctx context.Context, request *querypb.Reserv
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	grpccommon.MaybeCompressResult(ctx, qr.SizeVT())
 	return &querypb.ReserveBeginExecuteResponse{
-		Result:              sqltypes.ResultToProto3(result),
+		Result:              qr,
 		TransactionId:       state.TransactionID,
 		ReservedId:          state.ReservedID,
 		TabletAlias:         state.TabletAlias,
@@ -460,6 +477,7 @@ func (q *query) ReserveBeginStreamExecute(request *querypb.ReserveBeginStreamExe
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	grpccommon.MaybeCompressStream(ctx)
 	state, err := q.server.ReserveBeginStreamExecute(ctx, request.Target, request.PreQueries, request.PostBeginQueries, request.Query.Sql, request.Query.BindVariables, request.Options, func(reply *sqltypes.Result) error {
 		return stream.Send(&querypb.ReserveBeginStreamExecuteResponse{
 			Result: sqltypes.ResultToProto3(reply),
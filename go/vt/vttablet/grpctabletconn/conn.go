@@ -46,6 +46,8 @@ var (
 	ca   string
 	crl  string
 	name string
+
+	resultCompression []string
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -54,6 +56,7 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&ca, "tablet_grpc_ca", ca, "the server ca to use to validate servers when connecting")
 	fs.StringVar(&crl, "tablet_grpc_crl", crl, "the server crl to use to validate server certificates when connecting")
 	fs.StringVar(&name, "tablet_grpc_server_name", name, "the server name to use to validate server certificate")
+	fs.StringSliceVar(&resultCompression, "tablet_grpc_result_compression", resultCompression, "comma-separated list of gRPC compressors (snappy, zstd) accepted for query results sent by tablets. The tablet picks one of them if it is also allowed by its --grpc-result-compression flag.")
 }
 
 func init() {
@@ -95,7 +98,11 @@ func DialTablet(tablet *topodatapb.Tablet, failFast grpcclient.FailFast) (querys
 	if err != nil {
 		return nil, err
 	}
-	cc, err := grpcclient.Dial(addr, failFast, opt)
+	opts, err := grpcclient.ResultCompressionDialOptions(resultCompression)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := grpcclient.Dial(addr, failFast, append(opts, opt)...)
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +35,7 @@ import (
 	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
@@ -327,6 +328,19 @@ func (client *Client) ChangeType(ctx context.Context, tablet *topodatapb.Tablet,
 	return err
 }
 
+// ChangeTags is part of the tmclient.TabletManagerClient interface.
+func (client *Client) ChangeTags(ctx context.Context, tablet *topodatapb.Tablet, tabletTags map[string]string, replace bool) (*tabletmanagerdatapb.ChangeTagsResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return c.ChangeTags(ctx, &tabletmanagerdatapb.ChangeTagsRequest{
+		Tags:    tabletTags,
+		Replace: replace,
+	})
+}
+
 // RefreshState is part of the tmclient.TabletManagerClient interface.
 func (client *Client) RefreshState(ctx context.Context, tablet *topodatapb.Tablet) error {
 	c, closer, err := client.dialer.dial(ctx, tablet)
@@ -783,7 +797,7 @@ func (client *Client) VDiff(ctx context.Context, tablet *topodatapb.Tablet, req
 	defer closer.Close()
 	response, err := c.VDiff(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, vterrors.FromGRPC(err)
 	}
 	return response, nil
 }
@@ -974,17 +988,17 @@ type backupStreamAdapter struct {
 	closer io.Closer
 }
 
-func (e *backupStreamAdapter) Recv() (*logutilpb.Event, error) {
+func (e *backupStreamAdapter) Recv() (*tabletmanagerdatapb.BackupResponse, error) {
 	br, err := e.stream.Recv()
 	if err != nil {
 		e.closer.Close()
 		return nil, err
 	}
-	return br.Event, nil
+	return br, nil
 }
 
 // Backup is part of the tmclient.TabletManagerClient interface.
-func (client *Client) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (logutil.EventStream, error) {
+func (client *Client) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (tmclient.BackupStream, error) {
 	c, closer, err := client.dialer.dial(ctx, tablet)
 	if err != nil {
 		return nil, err
@@ -1001,6 +1015,20 @@ func (client *Client) Backup(ctx context.Context, tablet *topodatapb.Tablet, req
 	}, nil
 }
 
+// ReloadTLSCertificates is part of the tmclient.TabletManagerClient interface.
+func (client *Client) ReloadTLSCertificates(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (*tabletmanagerdatapb.ReloadTLSCertificatesResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	response, err := c.ReloadTLSCertificates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // CheckThrottler is part of the tmclient.TabletManagerClient interface.
 func (client *Client) CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
 	c, closer, err := client.dialer.dial(ctx, tablet)
@@ -1015,6 +1043,26 @@ func (client *Client) CheckThrottler(ctx context.Context, tablet *topodatapb.Tab
 	return response, nil
 }
 
+// GetUnresolvedTransactions is part of the tmclient.TabletManagerClient interface.
+func (client *Client) GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (*tabletmanagerdatapb.GetUnresolvedTransactionsResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return c.GetUnresolvedTransactions(ctx, req)
+}
+
+// ConcludeTransaction is part of the tmclient.TabletManagerClient interface.
+func (client *Client) ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ConcludeTransactionRequest) (*tabletmanagerdatapb.ConcludeTransactionResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return c.ConcludeTransaction(ctx, req)
+}
+
 type restoreFromBackupStreamAdapter struct {
 	stream tabletmanagerservicepb.TabletManager_RestoreFromBackupClient
 	closer io.Closer
@@ -1047,6 +1095,70 @@ func (client *Client) RestoreFromBackup(ctx context.Context, tablet *topodatapb.
 	}, nil
 }
 
+type streamBackupStreamAdapter struct {
+	stream tabletmanagerservicepb.TabletManager_StreamBackupClient
+	closer io.Closer
+}
+
+func (e *streamBackupStreamAdapter) Recv() (*tabletmanagerdatapb.StreamBackupResponse, error) {
+	br, err := e.stream.Recv()
+	if err != nil {
+		e.closer.Close()
+		return nil, err
+	}
+	return br, nil
+}
+
+// StreamBackup is part of the tmclient.TabletManagerClient interface.
+func (client *Client) StreamBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.StreamBackupRequest) (tmclient.StreamBackupStream, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.StreamBackup(ctx, req)
+	if err != nil {
+		closer.Close()
+		return nil, err
+	}
+	return &streamBackupStreamAdapter{
+		stream: stream,
+		closer: closer,
+	}, nil
+}
+
+type validateBackupStreamAdapter struct {
+	stream tabletmanagerservicepb.TabletManager_ValidateBackupClient
+	closer io.Closer
+}
+
+func (e *validateBackupStreamAdapter) Recv() (*tabletmanagerdatapb.ValidateBackupResponse, error) {
+	br, err := e.stream.Recv()
+	if err != nil {
+		e.closer.Close()
+		return nil, err
+	}
+	return br, nil
+}
+
+// ValidateBackup is part of the tmclient.TabletManagerClient interface.
+func (client *Client) ValidateBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ValidateBackupRequest) (tmclient.ValidateBackupStream, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.ValidateBackup(ctx, req)
+	if err != nil {
+		closer.Close()
+		return nil, err
+	}
+	return &validateBackupStreamAdapter{
+		stream: stream,
+		closer: closer,
+	}, nil
+}
+
 // Close is part of the tmclient.TabletManagerClient interface.
 func (client *Client) Close() {
 	client.dialer.Close()
@@ -18,6 +18,7 @@ package grpctmserver
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -123,6 +124,16 @@ func (s *server) ChangeType(ctx context.Context, request *tabletmanagerdatapb.Ch
 	return response, s.tm.ChangeType(ctx, request.TabletType, request.GetSemiSync())
 }
 
+func (s *server) ChangeTags(ctx context.Context, request *tabletmanagerdatapb.ChangeTagsRequest) (response *tabletmanagerdatapb.ChangeTagsResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "ChangeTags", request, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	tags, err := s.tm.ChangeTags(ctx, request.Tags, request.Replace)
+	if err != nil {
+		return nil, err
+	}
+	return &tabletmanagerdatapb.ChangeTagsResponse{Tags: tags}, nil
+}
+
 func (s *server) RefreshState(ctx context.Context, request *tabletmanagerdatapb.RefreshStateRequest) (response *tabletmanagerdatapb.RefreshStateResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "RefreshState", request, response, true /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
@@ -400,7 +411,7 @@ func (s *server) VDiff(ctx context.Context, request *tabletmanagerdatapb.VDiffRe
 	defer s.tm.HandleRPCPanic(ctx, "VDiff", request, response, true /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
 	response, err = s.tm.VDiff(ctx, request)
-	return response, err
+	return response, vterrors.ToGRPC(err)
 }
 
 //
@@ -513,17 +524,30 @@ func (s *server) Backup(request *tabletmanagerdatapb.BackupRequest, stream table
 	defer s.tm.HandleRPCPanic(ctx, "Backup", request, nil, true /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
 
+	// Progress is reported from its own goroutine, so sends are serialized.
+	var mu sync.Mutex
+	send := func(resp *tabletmanagerdatapb.BackupResponse) {
+		mu.Lock()
+		defer mu.Unlock()
+		stream.Send(resp)
+	}
+
 	// create a logger, send the result back to the caller
 	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
 		// If the client disconnects, we will just fail
 		// to send the log events, but won't interrupt
 		// the backup.
-		stream.Send(&tabletmanagerdatapb.BackupResponse{
+		send(&tabletmanagerdatapb.BackupResponse{
 			Event: e,
 		})
 	})
+	progress := func(p *tabletmanagerdatapb.BackupProgress) {
+		send(&tabletmanagerdatapb.BackupResponse{
+			Progress: p,
+		})
+	}
 
-	return s.tm.Backup(ctx, logger, request)
+	return s.tm.Backup(ctx, logger, progress, request)
 }
 
 func (s *server) RestoreFromBackup(request *tabletmanagerdatapb.RestoreFromBackupRequest, stream tabletmanagerservicepb.TabletManager_RestoreFromBackupServer) (err error) {
@@ -544,6 +568,62 @@ func (s *server) RestoreFromBackup(request *tabletmanagerdatapb.RestoreFromBacku
 	return s.tm.RestoreFromBackup(ctx, logger, request)
 }
 
+func (s *server) StreamBackup(request *tabletmanagerdatapb.StreamBackupRequest, stream tabletmanagerservicepb.TabletManager_StreamBackupServer) (err error) {
+	ctx := stream.Context()
+	defer s.tm.HandleRPCPanic(ctx, "StreamBackup", request, nil, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+
+	// Files are sent from several goroutines, so sends are serialized.
+	var mu sync.Mutex
+	send := func(resp *tabletmanagerdatapb.StreamBackupResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(resp)
+	}
+
+	// create a logger, send the result back to the caller
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		// Failing to send the files fails the backup, failing to
+		// send the log events does not.
+		send(&tabletmanagerdatapb.StreamBackupResponse{
+			Event: e,
+		})
+	})
+
+	return s.tm.StreamBackup(ctx, logger, request, send)
+}
+
+func (s *server) ValidateBackup(request *tabletmanagerdatapb.ValidateBackupRequest, stream tabletmanagerservicepb.TabletManager_ValidateBackupServer) (err error) {
+	ctx := stream.Context()
+	defer s.tm.HandleRPCPanic(ctx, "ValidateBackup", request, nil, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+
+	// create a logger, send the result back to the caller
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		// If the client disconnects, we will just fail
+		// to send the log events, but won't interrupt
+		// the validation.
+		stream.Send(&tabletmanagerdatapb.ValidateBackupResponse{
+			Event: e,
+		})
+	})
+
+	validation, err := s.tm.ValidateBackup(ctx, logger, request)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&tabletmanagerdatapb.ValidateBackupResponse{
+		Validation: validation,
+	})
+}
+
+func (s *server) ReloadTLSCertificates(ctx context.Context, request *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (response *tabletmanagerdatapb.ReloadTLSCertificatesResponse, err error) {
+	// The request is not logged, as it may hold a private key.
+	defer s.tm.HandleRPCPanic(ctx, "ReloadTLSCertificates", nil, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.ReloadTLSCertificates(ctx, request)
+}
+
 func (s *server) CheckThrottler(ctx context.Context, request *tabletmanagerdatapb.CheckThrottlerRequest) (response *tabletmanagerdatapb.CheckThrottlerResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "CheckThrottler", request, response, false /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
@@ -551,6 +631,18 @@ func (s *server) CheckThrottler(ctx context.Context, request *tabletmanagerdatap
 	return response, err
 }
 
+func (s *server) GetUnresolvedTransactions(ctx context.Context, request *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (response *tabletmanagerdatapb.GetUnresolvedTransactionsResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetUnresolvedTransactions", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.GetUnresolvedTransactions(ctx, request)
+}
+
+func (s *server) ConcludeTransaction(ctx context.Context, request *tabletmanagerdatapb.ConcludeTransactionRequest) (response *tabletmanagerdatapb.ConcludeTransactionResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "ConcludeTransaction", request, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.ConcludeTransaction(ctx, request)
+}
+
 // registration glue
 
 func init() {
@@ -56,6 +56,7 @@ import (
 var (
 	restoreFromBackup      bool
 	restoreFromBackupTsStr string
+	restoreFromTablet      string
 	restoreConcurrency     = 4
 	waitForBackupInterval  time.Duration
 
@@ -66,6 +67,7 @@ var (
 func registerRestoreFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&restoreFromBackup, "restore_from_backup", restoreFromBackup, "(init restore parameter) will check BackupStorage for a recent backup at startup and start there")
 	fs.StringVar(&restoreFromBackupTsStr, "restore_from_backup_ts", restoreFromBackupTsStr, "(init restore parameter) if set, restore the latest backup taken at or before this timestamp. Example: '2021-04-29.133050'")
+	fs.StringVar(&restoreFromTablet, "restore-from-tablet", restoreFromTablet, "(init restore parameter) if set, restore at startup from a backup that this tablet of the same shard takes and streams directly, instead of from BackupStorage")
 	fs.IntVar(&restoreConcurrency, "restore_concurrency", restoreConcurrency, "(init restore parameter) how many concurrent files to restore at once")
 	fs.DurationVar(&waitForBackupInterval, "wait_for_backup_interval", waitForBackupInterval, "(init restore parameter) if this is greater than 0, instead of starting up empty when no backups are found, keep checking at this interval for a backup to appear")
 }
@@ -180,6 +182,12 @@ func (tm *TabletManager) RestoreData(
 		RestoreToPos:       restoreToPos,
 		RestoreToTimestamp: protoutil.TimeToProto(restoreToTimetamp),
 	}
+	if restoreFromTablet != "" {
+		req.SourceTabletAlias, err = topoproto.ParseTabletAlias(restoreFromTablet)
+		if err != nil {
+			return vterrors.Wrapf(err, "invalid --restore-from-tablet")
+		}
+	}
 	err = tm.restoreDataLocked(ctx, logger, waitForBackupInterval, deleteBeforeRestore, req)
 	if err != nil {
 		return err
@@ -245,6 +253,30 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 		// Restore to given timestamp
 		params.RestoreToTimestamp = restoreToTimestamp
 	}
+	pointInTimeRecovery := params.IsIncrementalRecovery()
+	var (
+		binlogRestoreToPos       replication.Position
+		binlogRestoreToTimestamp time.Time
+	)
+	if request.BinlogSource != nil {
+		// With a binlog source, we restore a single full backup and apply the binary logs
+		// of the source on top of it, rather than restoring incremental backups.
+		if !pointInTimeRecovery {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a binlog source requires either --restore-to-pos or --restore-to-timestamp")
+		}
+		binlogRestoreToPos, binlogRestoreToTimestamp = params.RestoreToPos, params.RestoreToTimestamp
+		params.RestoreToPos, params.RestoreToTimestamp = replication.Position{}, time.Time{}
+		if !binlogRestoreToTimestamp.IsZero() {
+			params.StartTime = binlogRestoreToTimestamp
+		}
+	}
+	if request.SourceTabletAlias != nil {
+		// The source tablet takes a new full backup, which can neither be chosen
+		// by its time nor be followed by incremental backups.
+		if pointInTimeRecovery || !startTime.IsZero() {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "restoring from a tablet cannot be combined with a backup time or a point in time recovery")
+		}
+	}
 	params.Logger.Infof("Restore: original tablet type=%v", originalType)
 
 	// Check whether we're going to restore before changing to RESTORE type,
@@ -268,7 +300,11 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 	// Loop until a backup exists, unless we were told to give up immediately.
 	var backupManifest *mysqlctl.BackupManifest
 	for {
-		backupManifest, err = mysqlctl.Restore(ctx, params)
+		if request.SourceTabletAlias != nil {
+			backupManifest, err = tm.restoreFromTablet(ctx, params, request.SourceTabletAlias)
+		} else {
+			backupManifest, err = mysqlctl.Restore(ctx, params)
+		}
 		if backupManifest != nil {
 			statsRestoreBackupPosition.Set(replication.EncodePosition(backupManifest.Position))
 			statsRestoreBackupTime.Set(backupManifest.BackupTime)
@@ -308,7 +344,13 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 	case err == nil && backupManifest != nil:
 		// Starting from here we won't be able to recover if we get stopped by a cancelled
 		// context. Thus we use the background context to get through to the finish.
-		if params.IsIncrementalRecovery() && !params.DryRun {
+		if pointInTimeRecovery && !params.DryRun {
+			if request.BinlogSource != nil {
+				params.Logger.Infof("Restore: applying binary logs from %s:%d", request.BinlogSource.Host, request.BinlogSource.Port)
+				if err := tm.restoreFromBinlogSource(context.Background(), pos, request.BinlogSource, binlogRestoreToPos, binlogRestoreToTimestamp); err != nil {
+					return err
+				}
+			}
 			// The whole point of point-in-time recovery is that we want to restore up to a given position,
 			// and to NOT proceed from that position. We want to disable replication and NOT let the replica catch
 			// up with the primary.
@@ -350,7 +392,7 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 			originalType = initType
 		}
 	}
-	if params.IsIncrementalRecovery() && !params.DryRun {
+	if pointInTimeRecovery && !params.DryRun {
 		// override
 		params.Logger.Infof("Restore: will set tablet type to DRAINED as this is a point in time recovery")
 		originalType = topodatapb.TabletType_DRAINED
@@ -361,6 +403,43 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 	return tm.tmState.ChangeTabletType(bgCtx, originalType, DBActionNone)
 }
 
+// restoreFromTablet restores a backup that the given tablet of the same shard
+// takes and streams, instead of a backup from the BackupStorage. The streamed
+// backup is staged in the tablet directory while it is received.
+func (tm *TabletManager) restoreFromTablet(ctx context.Context, params mysqlctl.RestoreParams, alias *topodatapb.TabletAlias) (*mysqlctl.BackupManifest, error) {
+	if topoproto.TabletAliasEqual(alias, tm.tabletAlias) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a tablet cannot restore from itself")
+	}
+	source, err := tm.TopoServer.GetTablet(ctx, alias)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot read source tablet %v", topoproto.TabletAliasString(alias))
+	}
+	tablet := tm.Tablet()
+	if source.Keyspace != tablet.Keyspace || source.Shard != tablet.Shard {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "source tablet %v is in shard %v/%v, not %v/%v", topoproto.TabletAliasString(alias), source.Keyspace, source.Shard, tablet.Keyspace, tablet.Shard)
+	}
+	params.Logger.Infof("Restore: restoring a backup streamed by tablet %v", topoproto.TabletAliasString(alias))
+	if params.DryRun {
+		return nil, nil
+	}
+
+	tmc := tmclient.NewTabletManagerClient()
+	defer tmc.Close()
+	stream, err := tmc.StreamBackup(ctx, source.Tablet, &tabletmanagerdatapb.StreamBackupRequest{
+		Concurrency: int64(params.Concurrency),
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot stream a backup from tablet %v", topoproto.TabletAliasString(alias))
+	}
+	bs, err := mysqlctl.ReceiveStreamedBackup(ctx, params.Cnf, stream.Recv, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+	params.BackupStorage = bs
+	return mysqlctl.Restore(ctx, params)
+}
+
 // restoreToTimeFromBinlog restores to the snapshot time of the keyspace
 // currently this works with mysql based database only (as it uses mysql specific queries for restoring)
 func (tm *TabletManager) restoreToTimeFromBinlog(ctx context.Context, pos replication.Position, restoreTime *vttime.Time) error {
@@ -370,10 +449,88 @@ func (tm *TabletManager) restoreToTimeFromBinlog(ctx context.Context, pos replic
 		return nil
 	}
 
+	return tm.restoreToTimeFromBinlogSource(ctx, binlogServerConnParams(), pos, restoreTime)
+}
+
+// restoreFromBinlogSource applies the binary logs of the given binlog source on top of a backup
+// restored at pos, either up to and including restoreToPos, or up to and excluding restoreToTimestamp.
+func (tm *TabletManager) restoreFromBinlogSource(ctx context.Context, pos replication.Position, source *tabletmanagerdatapb.RestoreBinlogSource, restoreToPos replication.Position, restoreToTimestamp time.Time) error {
+	connParams, err := tm.binlogSourceConnParams(source)
+	if err != nil {
+		return err
+	}
+	if restoreToPos.IsZero() {
+		return tm.restoreToTimeFromBinlogSource(ctx, connParams, pos, protoutil.TimeToProto(restoreToTimestamp))
+	}
+	if pos.AtLeast(restoreToPos) {
+		if restoreToPos.AtLeast(pos) {
+			// The backup is exactly at the requested position.
+			return nil
+		}
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "restored backup position %v is beyond the requested position %v", pos, restoreToPos)
+	}
+	timeoutCtx, cancelFnc := context.WithTimeout(ctx, timeoutForGTIDLookup)
+	defer cancelFnc()
+
+	log.Infof("going to restore upto the position - %v", restoreToPos)
+	startCmd := fmt.Sprintf("START SLAVE UNTIL SQL_AFTER_GTIDS = '%s'", restoreToPos.GTIDSet.String())
+	if err := tm.replicateFromBinlogSource(timeoutCtx, connParams, startCmd, restoreToPos); err != nil {
+		return vterrors.Wrapf(err, "unable to replicate upto desired position : %v", restoreToPos)
+	}
+	return nil
+}
+
+// binlogServerConnParams returns the connection parameters of the binlog server
+// set with the --binlog_* flags.
+func binlogServerConnParams() *mysql.ConnParams {
+	connParams := &mysql.ConnParams{
+		Host:       binlogHost,
+		Port:       binlogPort,
+		Uname:      binlogUser,
+		SslCa:      binlogSslCa,
+		SslCert:    binlogSslCert,
+		SslKey:     binlogSslKey,
+		ServerName: binlogSslServerName,
+	}
+	if binlogPwd != "" {
+		connParams.Pass = binlogPwd
+	}
+	if binlogSslCa != "" || binlogSslCert != "" {
+		connParams.EnableSSL()
+	}
+	return connParams
+}
+
+// binlogSourceConnParams returns the connection parameters of the binlog source of a
+// point in time recovery. When --binlog_user is set, the credentials and TLS settings
+// of the --binlog_* flags are used, otherwise the replication credentials of this tablet.
+func (tm *TabletManager) binlogSourceConnParams(source *tabletmanagerdatapb.RestoreBinlogSource) (*mysql.ConnParams, error) {
+	var connParams *mysql.ConnParams
+	if binlogUser != "" {
+		connParams = binlogServerConnParams()
+	} else {
+		if tm.DBConfigs == nil {
+			return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, "no replication credentials to connect to the binlog source, set --binlog_user")
+		}
+		replParams, err := tm.DBConfigs.ReplConnector().MysqlParams()
+		if err != nil {
+			return nil, err
+		}
+		connParams = replParams
+		connParams.UnixSocket = ""
+	}
+	connParams.Host = source.Host
+	connParams.Port = int(source.Port)
+	return connParams, nil
+}
+
+// restoreToTimeFromBinlogSource applies the binary logs of the MySQL server at connParams
+// on top of a backup restored at pos, up to the given time.
+func (tm *TabletManager) restoreToTimeFromBinlogSource(ctx context.Context, connParams *mysql.ConnParams, pos replication.Position, restoreTime *vttime.Time) error {
 	timeoutCtx, cancelFnc := context.WithTimeout(ctx, timeoutForGTIDLookup)
 	defer cancelFnc()
 
-	afterGTIDPos, beforeGTIDPos, err := tm.getGTIDFromTimestamp(timeoutCtx, pos, restoreTime.Seconds)
+	afterGTIDPos, beforeGTIDPos, err := tm.getGTIDFromTimestamp(timeoutCtx, connParams, pos, restoreTime.Seconds)
 	if err != nil {
 		return err
 	}
@@ -390,7 +547,7 @@ func (tm *TabletManager) restoreToTimeFromBinlog(ctx context.Context, pos replic
 	if beforeGTIDPos == "" {
 		beforeGTIDPos = pos.GTIDSet.Last()
 	}
-	err = tm.catchupToGTID(timeoutCtx, afterGTIDPos, beforeGTIDPos)
+	err = tm.catchupToGTID(timeoutCtx, connParams, afterGTIDPos, beforeGTIDPos)
 	if err != nil {
 		return vterrors.Wrapf(err, "unable to replicate upto desired GTID : %s", afterGTIDPos)
 	}
@@ -403,22 +560,7 @@ func (tm *TabletManager) restoreToTimeFromBinlog(ctx context.Context, pos replic
 // beforePos is the GTID of the last event before restoreTime. This is the GTID upto which replication will be applied
 // afterPos can be used directly in the query `START SLAVE UNTIL SQL_BEFORE_GTIDS = ”`
 // beforePos will be used to check if replication was able to catch up from the binlog server
-func (tm *TabletManager) getGTIDFromTimestamp(ctx context.Context, pos replication.Position, restoreTime int64) (afterPos string, beforePos string, err error) {
-	connParams := &mysql.ConnParams{
-		Host:       binlogHost,
-		Port:       binlogPort,
-		Uname:      binlogUser,
-		SslCa:      binlogSslCa,
-		SslCert:    binlogSslCert,
-		SslKey:     binlogSslKey,
-		ServerName: binlogSslServerName,
-	}
-	if binlogPwd != "" {
-		connParams.Pass = binlogPwd
-	}
-	if binlogSslCa != "" || binlogSslCert != "" {
-		connParams.EnableSSL()
-	}
+func (tm *TabletManager) getGTIDFromTimestamp(ctx context.Context, connParams *mysql.ConnParams, pos replication.Position, restoreTime int64) (afterPos string, beforePos string, err error) {
 	dbCfgs := &dbconfigs.DBConfigs{
 		Host: connParams.Host,
 		Port: connParams.Port,
@@ -490,7 +632,7 @@ func (tm *TabletManager) getGTIDFromTimestamp(ctx context.Context, pos replicati
 // copies the data from binlog server by pointing to as replica
 // waits till all events to GTID replicated
 // once done, it will reset the replication
-func (tm *TabletManager) catchupToGTID(ctx context.Context, afterGTIDPos string, beforeGTIDPos string) error {
+func (tm *TabletManager) catchupToGTID(ctx context.Context, connParams *mysql.ConnParams, afterGTIDPos string, beforeGTIDPos string) error {
 	var afterGTIDStr string
 	if afterGTIDPos != "" {
 		afterGTIDParsed, err := replication.DecodePosition(afterGTIDPos)
@@ -505,62 +647,72 @@ func (tm *TabletManager) catchupToGTID(ctx context.Context, afterGTIDPos string,
 		return err
 	}
 
+	startCmd := "START SLAVE"
+	if afterGTIDPos != "" { // when the there is no afterPos, that means need to replicate completely
+		startCmd = fmt.Sprintf("START SLAVE UNTIL SQL_BEFORE_GTIDS = '%s'", afterGTIDStr)
+	}
+	log.Infof("Waiting for position to reach %v", beforeGTIDPosParsed.GTIDSet.Last())
+	return tm.replicateFromBinlogSource(ctx, connParams, startCmd, beforeGTIDPosParsed)
+}
+
+// replicateFromBinlogSource points replication to the MySQL server at connParams, starts it
+// with startCmd and waits until the replicated position reaches waitPos. Once done, it resets
+// the replication.
+func (tm *TabletManager) replicateFromBinlogSource(ctx context.Context, connParams *mysql.ConnParams, startCmd string, waitPos replication.Position) error {
 	// it uses mysql specific queries here
 	cmds := []string{
 		"STOP SLAVE FOR CHANNEL '' ",
 		"STOP SLAVE IO_THREAD FOR CHANNEL ''",
 	}
 
-	if binlogSslCa != "" || binlogSslCert != "" {
+	if connParams.SslCa != "" || connParams.SslCert != "" {
 		// We need to use TLS
-		cmd := fmt.Sprintf("CHANGE MASTER TO MASTER_HOST='%s', MASTER_PORT=%d, MASTER_USER='%s', MASTER_PASSWORD='%s', MASTER_AUTO_POSITION=1, MASTER_SSL=1", binlogHost, binlogPort, binlogUser, binlogPwd)
-		if binlogSslCa != "" {
-			cmd += fmt.Sprintf(", MASTER_SSL_CA='%s'", binlogSslCa)
+		cmd := fmt.Sprintf("CHANGE MASTER TO MASTER_HOST='%s', MASTER_PORT=%d, MASTER_USER='%s', MASTER_PASSWORD='%s', MASTER_AUTO_POSITION=1, MASTER_SSL=1", connParams.Host, connParams.Port, connParams.Uname, connParams.Pass)
+		if connParams.SslCa != "" {
+			cmd += fmt.Sprintf(", MASTER_SSL_CA='%s'", connParams.SslCa)
 		}
-		if binlogSslCert != "" {
-			cmd += fmt.Sprintf(", MASTER_SSL_CERT='%s'", binlogSslCert)
+		if connParams.SslCert != "" {
+			cmd += fmt.Sprintf(", MASTER_SSL_CERT='%s'", connParams.SslCert)
 		}
-		if binlogSslKey != "" {
-			cmd += fmt.Sprintf(", MASTER_SSL_KEY='%s'", binlogSslKey)
+		if connParams.SslKey != "" {
+			cmd += fmt.Sprintf(", MASTER_SSL_KEY='%s'", connParams.SslKey)
 		}
 		cmds = append(cmds, cmd+";")
 	} else {
 		// No TLS
-		cmds = append(cmds, fmt.Sprintf("CHANGE MASTER TO MASTER_HOST='%s', MASTER_PORT=%d, MASTER_USER='%s', MASTER_PASSWORD='%s', MASTER_AUTO_POSITION=1;", binlogHost, binlogPort, binlogUser, binlogPwd))
-	}
-
-	if afterGTIDPos == "" { // when the there is no afterPos, that means need to replicate completely
-		cmds = append(cmds, "START SLAVE")
-	} else {
-		cmds = append(cmds, fmt.Sprintf("START SLAVE UNTIL SQL_BEFORE_GTIDS = '%s'", afterGTIDStr))
+		cmds = append(cmds, fmt.Sprintf("CHANGE MASTER TO MASTER_HOST='%s', MASTER_PORT=%d, MASTER_USER='%s', MASTER_PASSWORD='%s', MASTER_AUTO_POSITION=1;", connParams.Host, connParams.Port, connParams.Uname, connParams.Pass))
 	}
+	cmds = append(cmds, startCmd)
 
 	if err := tm.MysqlDaemon.ExecuteSuperQueryList(ctx, cmds); err != nil {
-		return vterrors.Wrap(err, fmt.Sprintf("failed to restart the replication until %s GTID", afterGTIDStr))
+		return vterrors.Wrap(err, fmt.Sprintf("failed to restart the replication with %q", startCmd))
 	}
-	log.Infof("Waiting for position to reach", beforeGTIDPosParsed.GTIDSet.Last())
-	// Could not use `agent.MysqlDaemon.WaitSourcePos` as replication is stopped with `START SLAVE UNTIL SQL_BEFORE_GTIDS`
+	// Could not use `agent.MysqlDaemon.WaitSourcePos` as replication is stopped with `START SLAVE UNTIL ...`
 	// this is as per https://dev.mysql.com/doc/refman/5.6/en/start-slave.html
-	// We need to wait until replication catches upto the specified afterGTIDPos
-	chGTIDCaughtup := make(chan bool)
+	// We need to wait until replication catches upto the specified position
+	chGTIDCaughtup := make(chan bool, 1)
 	go func() {
 		timeToWait := time.Now().Add(timeoutForGTIDLookup)
 		for time.Now().Before(timeToWait) {
 			pos, err := tm.MysqlDaemon.PrimaryPosition()
 			if err != nil {
 				chGTIDCaughtup <- false
+				return
 			}
 
-			if pos.AtLeast(beforeGTIDPosParsed) {
+			if pos.AtLeast(waitPos) {
 				chGTIDCaughtup <- true
+				return
 			}
 			select {
 			case <-ctx.Done():
 				chGTIDCaughtup <- false
+				return
 			default:
 				time.Sleep(300 * time.Millisecond)
 			}
 		}
+		chGTIDCaughtup <- false
 	}()
 	select {
 	case resp := <-chGTIDCaughtup:
@@ -574,10 +726,10 @@ func (tm *TabletManager) catchupToGTID(ctx context.Context, afterGTIDPos string,
 			}
 			return nil
 		}
-		return vterrors.Wrap(err, "error while fetching the current GTID position")
+		return vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "error while waiting for the replicated position to reach %v", waitPos)
 	case <-ctx.Done():
 		log.Warningf("Could not copy up to GTID.")
-		return vterrors.Wrapf(err, "context timeout while restoring up to specified GTID - %s", beforeGTIDPos)
+		return vterrors.Wrapf(ctx.Err(), "context timeout while restoring up to specified GTID - %v", waitPos)
 	}
 }
 
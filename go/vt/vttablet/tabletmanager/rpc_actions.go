@@ -90,6 +90,16 @@ func (tm *TabletManager) ChangeType(ctx context.Context, tabletType topodatapb.T
 	return tm.changeTypeLocked(ctx, tabletType, DBActionNone, semiSyncAction)
 }
 
+// ChangeTags changes the tablet tags
+func (tm *TabletManager) ChangeTags(ctx context.Context, tabletTags map[string]string, replace bool) (map[string]string, error) {
+	if err := tm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer tm.unlock()
+
+	return tm.tmState.ChangeTags(ctx, tabletTags, replace), nil
+}
+
 // ChangeType changes the tablet type
 func (tm *TabletManager) changeTypeLocked(ctx context.Context, tabletType topodatapb.TabletType, action DBAction, semiSync SemiSyncAction) error {
 	// We don't want to allow multiple callers to claim a tablet as drained.
@@ -49,6 +49,8 @@ type RPCTM interface {
 
 	ChangeType(ctx context.Context, tabletType topodatapb.TabletType, semiSync bool) error
 
+	ChangeTags(ctx context.Context, tabletTags map[string]string, replace bool) (map[string]string, error)
+
 	Sleep(ctx context.Context, duration time.Duration)
 
 	ExecuteHook(ctx context.Context, hk *hook.Hook) *hook.HookResult
@@ -137,14 +139,25 @@ type RPCTM interface {
 
 	// Backup / restore related methods
 
-	Backup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.BackupRequest) error
+	Backup(ctx context.Context, logger logutil.Logger, progress func(*tabletmanagerdatapb.BackupProgress), request *tabletmanagerdatapb.BackupRequest) error
 
 	RestoreFromBackup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.RestoreFromBackupRequest) error
 
+	StreamBackup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.StreamBackupRequest, send func(*tabletmanagerdatapb.StreamBackupResponse) error) error
+
+	ValidateBackup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.ValidateBackupRequest) (*tabletmanagerdatapb.BackupValidation, error)
+
 	// HandleRPCPanic is to be called in a defer statement in each
 	// RPC input point.
 	HandleRPCPanic(ctx context.Context, name string, args, reply any, verbose bool, err *error)
 
+	ReloadTLSCertificates(ctx context.Context, request *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (*tabletmanagerdatapb.ReloadTLSCertificatesResponse, error)
+
 	// Throttler
 	CheckThrottler(ctx context.Context, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error)
+
+	// Distributed transactions
+	GetUnresolvedTransactions(ctx context.Context, request *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (*tabletmanagerdatapb.GetUnresolvedTransactionsResponse, error)
+
+	ConcludeTransaction(ctx context.Context, request *tabletmanagerdatapb.ConcludeTransactionRequest) (*tabletmanagerdatapb.ConcludeTransactionResponse, error)
 }
@@ -19,19 +19,29 @@ package tabletmanager
 import (
 	"context"
 	"fmt"
+	"net"
+	"os"
 	"time"
 
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/env"
+	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstats"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 const (
@@ -39,8 +49,41 @@ const (
 	backupModeOffline = "offline"
 )
 
-// Backup takes a db backup and sends it to the BackupStorage.
-func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, req *tabletmanagerdatapb.BackupRequest) error {
+var (
+	// validateBackupDir is the directory under which ValidateBackup restores backups
+	validateBackupDir string
+)
+
+func init() {
+	servenv.OnParseFor("vttablet", registerValidateBackupFlags)
+}
+
+func registerValidateBackupFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&validateBackupDir, "validate-backup-dir", validateBackupDir, "Directory under which ValidateBackup restores backups into scratch MySQL instances. Defaults to VTDATAROOT. It needs as much disk space as the tablet's data.")
+}
+
+// Backup takes a db backup and sends it to the BackupStorage. Its progress is
+// reported to progress, if not nil.
+func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, progress func(*tabletmanagerdatapb.BackupProgress), req *tabletmanagerdatapb.BackupRequest) error {
+	return tm.backup(ctx, logger, progress, req, nil)
+}
+
+// StreamBackup takes a db backup and sends its files to send, instead of the
+// BackupStorage, so that another tablet restores it.
+func (tm *TabletManager) StreamBackup(ctx context.Context, logger logutil.Logger, req *tabletmanagerdatapb.StreamBackupRequest, send func(*tabletmanagerdatapb.StreamBackupResponse) error) error {
+	if req.Concurrency <= 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid concurrency %v", req.Concurrency)
+	}
+	backupReq := &tabletmanagerdatapb.BackupRequest{
+		Concurrency:  req.Concurrency,
+		AllowPrimary: req.AllowPrimary,
+	}
+	return tm.backup(ctx, logger, nil, backupReq, mysqlctl.NewStreamingBackupStorage(send))
+}
+
+// backup takes a db backup and stores it in bs, or in the BackupStorage if bs
+// is nil.
+func (tm *TabletManager) backup(ctx context.Context, logger logutil.Logger, progress func(*tabletmanagerdatapb.BackupProgress), req *tabletmanagerdatapb.BackupRequest, bs backupstorage.BackupStorage) error {
 	if tm.Cnf == nil {
 		return fmt.Errorf("cannot perform backup without my.cnf, please restart vttablet with a my.cnf file specified")
 	}
@@ -162,6 +205,12 @@ func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, req
 		BackupTime:         time.Now(),
 		Stats:              backupstats.BackupStats(),
 		UpgradeSafe:        req.UpgradeSafe,
+
+		CompressionEngine:         req.CompressionEngine,
+		CompressionLevel:          int(req.CompressionLevel),
+		CompressionDictionaryPath: req.CompressionDictionary,
+		ProgressReporter:          progress,
+		BackupStorage:             bs,
 	}
 
 	returnErr := mysqlctl.Backup(ctx, backupParams)
@@ -197,6 +246,95 @@ func (tm *TabletManager) RestoreFromBackup(ctx context.Context, logger logutil.L
 	return err
 }
 
+// ValidateBackup restores a backup into a scratch MySQL instance next to the
+// tablet's own, checks the restored data and returns the verdict. The tablet
+// keeps serving and its data is not touched.
+func (tm *TabletManager) ValidateBackup(ctx context.Context, logger logutil.Logger, req *tabletmanagerdatapb.ValidateBackupRequest) (*tabletmanagerdatapb.BackupValidation, error) {
+	if tm.Cnf == nil {
+		return nil, fmt.Errorf("cannot validate backup without my.cnf, please restart vttablet with a my.cnf file specified")
+	}
+	tablet := tm.Tablet()
+
+	// Create the logger: tee to console and source.
+	l := logutil.NewTeeLogger(logutil.NewConsoleLogger(), logger)
+
+	root := validateBackupDir
+	if root == "" {
+		root = env.VtDataRoot()
+	}
+	dir, err := os.MkdirTemp(root, "vt_validate_backup_")
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot create scratch directory")
+	}
+	port, err := freeLocalPort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, vterrors.Wrap(err, "cannot find a port for the scratch mysqld")
+	}
+	mysqld, cnf, err := mysqlctl.CreateScratchMysqldAndMycnf(dir, tablet.Alias.Uid, port, tm.DBConfigs)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	defer func() {
+		// Be careful not to use the original context, so the scratch
+		// instance is torn down even if the validation timed out.
+		teardownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		l.Infof("ValidateBackup: removing scratch MySQL instance in %v", dir)
+		if err := mysqld.Teardown(teardownCtx, cnf, true /* force */); err != nil {
+			l.Warningf("ValidateBackup: failed to tear down scratch MySQL instance: %v", err)
+		}
+		mysqld.Close()
+		if err := os.RemoveAll(dir); err != nil {
+			l.Warningf("ValidateBackup: failed to remove %v: %v", dir, err)
+		}
+	}()
+	if err := mysqld.InitConfig(cnf); err != nil {
+		return nil, vterrors.Wrap(err, "cannot initialize scratch MySQL instance")
+	}
+
+	concurrency := int(req.Concurrency)
+	if concurrency <= 0 {
+		concurrency = restoreConcurrency
+	}
+	l.Infof("ValidateBackup: restoring backup of %v/%v into scratch MySQL instance in %v", tablet.Keyspace, tablet.Shard, dir)
+	validation, err := mysqlctl.ValidateBackup(ctx, mysqlctl.ValidateBackupParams{
+		Cnf:            cnf,
+		Mysqld:         mysqld,
+		Logger:         l,
+		Concurrency:    concurrency,
+		HookExtraEnv:   tm.hookExtraEnv(),
+		DbName:         topoproto.TabletDbName(tablet),
+		Keyspace:       tablet.Keyspace,
+		Shard:          tablet.Shard,
+		StartTime:      protoutil.TimeFromProto(req.BackupTime).UTC(),
+		ChecksumTables: req.ChecksumTables,
+		Stats:          backupstats.RestoreStats(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tabletmanagerdatapb.BackupValidation{
+		BackupName:       validation.BackupName,
+		Position:         replication.EncodePosition(validation.Position),
+		RestoredPosition: replication.EncodePosition(validation.RestoredPosition),
+		TableChecksums:   validation.TableChecksums,
+		Errors:           validation.Errors,
+		Valid:            validation.Valid(),
+	}, nil
+}
+
+// freeLocalPort returns a TCP port that is not in use on this host.
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
 func (tm *TabletManager) beginBackup(backupMode string) error {
 	tm.mutex.Lock()
 	defer tm.mutex.Unlock()
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"syscall"
 	"time"
 
 	"vitess.io/vitess/go/mysql/replication"
@@ -139,6 +140,9 @@ func (tm *TabletManager) FullStatus(ctx context.Context) (*replicationdatapb.Ful
 	// Semi sync settings - "show status like 'rpl_semi_sync_%'
 	semiSyncTimeout, semiSyncNumReplicas := tm.MysqlDaemon.SemiSyncSettings()
 
+	// Disk space of the data directory - "statfs <datadir>"
+	diskFree, diskTotal := tm.diskSpace()
+
 	return &replicationdatapb.FullStatus{
 		ServerId:                    serverID,
 		ServerUuid:                  serverUUID,
@@ -161,9 +165,26 @@ func (tm *TabletManager) FullStatus(ctx context.Context) (*replicationdatapb.Ful
 		SemiSyncPrimaryTimeout:      semiSyncTimeout,
 		SemiSyncWaitForReplicaCount: semiSyncNumReplicas,
 		SuperReadOnly:               superReadOnly,
+		DiskFreeBytes:               diskFree,
+		DiskTotalBytes:              diskTotal,
 	}, nil
 }
 
+// diskSpace returns the free and total bytes of the disk of the data directory
+// of mysqld, or zeros if it is unknown, e.g. for an external mysqld.
+func (tm *TabletManager) diskSpace() (free uint64, total uint64) {
+	if tm.Cnf == nil || tm.Cnf.DataDir == "" {
+		return 0, 0
+	}
+
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(tm.Cnf.DataDir, &stat); err != nil {
+		log.Warningf("Cannot get the disk space of %v: %v", tm.Cnf.DataDir, err)
+		return 0, 0
+	}
+	return stat.Bavail * uint64(stat.Bsize), stat.Blocks * uint64(stat.Bsize)
+}
+
 // PrimaryStatus returns the replication status for a primary tablet.
 func (tm *TabletManager) PrimaryStatus(ctx context.Context) (*replicationdatapb.PrimaryStatus, error) {
 	status, err := tm.MysqlDaemon.PrimaryStatus(ctx)
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vttls"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// ReloadTLSCertificates installs the certificate, key and CA of the request,
+// if any, as the ones of the gRPC server, or else re-reads them from their
+// files. It returns the certificate served after the reload.
+func (tm *TabletManager) ReloadTLSCertificates(ctx context.Context, req *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (*tabletmanagerdatapb.ReloadTLSCertificatesResponse, error) {
+	var err error
+	if len(req.Cert) > 0 || len(req.Key) > 0 || len(req.Ca) > 0 {
+		err = servenv.UpdateGRPCServerCertificates(req.Cert, req.Key, req.Ca)
+	} else {
+		err = servenv.ReloadGRPCServerCertificates()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := servenv.GRPCServerCertificate()
+	if err != nil {
+		return nil, err
+	}
+	resp := &tabletmanagerdatapb.ReloadTLSCertificatesResponse{}
+	if cert != nil {
+		resp.Certificate = &tabletmanagerdatapb.TLSCertificate{
+			Sha256Fingerprint: vttls.Fingerprint(cert),
+			Subject:           cert.Subject.String(),
+			Issuer:            cert.Issuer.String(),
+			SerialNumber:      cert.SerialNumber.String(),
+			NotBefore:         protoutil.TimeToProto(cert.NotBefore),
+			NotAfter:          protoutil.TimeToProto(cert.NotAfter),
+		}
+		log.Infof("Serving the gRPC certificate %s (%s), valid until %v", resp.Certificate.Sha256Fingerprint, resp.Certificate.Subject, cert.NotAfter)
+	}
+	return resp, nil
+}
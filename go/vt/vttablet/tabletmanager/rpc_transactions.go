@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"time"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// GetUnresolvedTransactions returns the distributed transactions whose metadata
+// is stored on this tablet and that are older than the requested abandon age.
+func (tm *TabletManager) GetUnresolvedTransactions(ctx context.Context, req *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (*tabletmanagerdatapb.GetUnresolvedTransactionsResponse, error) {
+	transactions, err := tm.QueryServiceControl.UnresolvedTransactions(ctx, tm.transactionTarget(), time.Duration(req.AbandonAge)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &tabletmanagerdatapb.GetUnresolvedTransactionsResponse{Transactions: transactions}, nil
+}
+
+// ConcludeTransaction performs one step of the resolution of a distributed
+// transaction: participants commit or roll back their prepared transaction,
+// while the metadata manager records the rollback decision or deletes the
+// transaction metadata.
+func (tm *TabletManager) ConcludeTransaction(ctx context.Context, req *tabletmanagerdatapb.ConcludeTransactionRequest) (*tabletmanagerdatapb.ConcludeTransactionResponse, error) {
+	qs := tm.QueryServiceControl.QueryService()
+	target := tm.transactionTarget()
+	var err error
+	switch req.Action {
+	case tabletmanagerdatapb.ConcludeTransactionRequest_ROLLBACK_PREPARED:
+		err = qs.RollbackPrepared(ctx, target, req.Dtid, 0)
+	case tabletmanagerdatapb.ConcludeTransactionRequest_COMMIT_PREPARED:
+		err = qs.CommitPrepared(ctx, target, req.Dtid)
+	case tabletmanagerdatapb.ConcludeTransactionRequest_SET_ROLLBACK:
+		err = qs.SetRollback(ctx, target, req.Dtid, 0)
+	case tabletmanagerdatapb.ConcludeTransactionRequest_CONCLUDE:
+		err = qs.ConcludeTransaction(ctx, target, req.Dtid)
+	default:
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown conclude transaction action %v", req.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tabletmanagerdatapb.ConcludeTransactionResponse{}, nil
+}
+
+func (tm *TabletManager) transactionTarget() *querypb.Target {
+	tablet := tm.Tablet()
+	return &querypb.Target{Keyspace: tablet.Keyspace, Shard: tablet.Shard, TabletType: tablet.Type}
+}
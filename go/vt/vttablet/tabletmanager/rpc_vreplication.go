@@ -221,6 +221,9 @@ func (tm *TabletManager) ReadVReplicationWorkflow(ctx context.Context, req *tabl
 		}
 		streams[i].TimeThrottled = &vttime.Time{Seconds: timeThrottled}
 		streams[i].ComponentThrottled = row["component_throttled"].ToString()
+		if req.IncludeMetrics {
+			streams[i].Metrics = tm.VREngine.StreamMetrics(streams[i].Id)
+		}
 	}
 	resp.Streams = streams
 
@@ -294,6 +297,25 @@ func (tm *TabletManager) UpdateVReplicationWorkflow(ctx context.Context, req *ta
 	if !textutil.ValueIsSimulatedNull(req.OnDdl) {
 		bls.OnDdl = req.OnDdl
 	}
+	if req.ThrottlerSettings != nil {
+		// Only the throttler settings that are not simulated NULL values
+		// are updated, the others keep their existing values.
+		if bls.ThrottlerSettings == nil {
+			bls.ThrottlerSettings = &binlogdatapb.VReplicationThrottlerSettings{}
+		}
+		if !textutil.ValueIsSimulatedNull(req.ThrottlerSettings.AppName) {
+			bls.ThrottlerSettings.AppName = req.ThrottlerSettings.AppName
+		}
+		if !textutil.ValueIsSimulatedNull(req.ThrottlerSettings.Ratio) {
+			bls.ThrottlerSettings.Ratio = req.ThrottlerSettings.Ratio
+		}
+		if !textutil.ValueIsSimulatedNull(req.ThrottlerSettings.Priority) {
+			bls.ThrottlerSettings.Priority = req.ThrottlerSettings.Priority
+		}
+	}
+	if req.Schedule != nil {
+		bls.Schedule = req.Schedule
+	}
 	source, err = prototext.Marshal(bls)
 	if err != nil {
 		return nil, err
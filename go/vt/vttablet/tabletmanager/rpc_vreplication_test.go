@@ -438,10 +438,23 @@ func TestMoveTables(t *testing.T) {
 				"id|workflow|source|pos|stop_pos|max_replication_log|state|db_name|time_updated|transaction_timestamp|message|tags|workflow_type|workflow_sub_type|time_heartbeat|defer_secondary_keys|component_throttled|time_throttled|rows_copied",
 				"int64|varchar|blob|varchar|varchar|int64|varchar|varchar|int64|int64|varchar|varchar|int64|int64|int64|int64|varchar|int64|int64",
 			),
-			fmt.Sprintf("1|%s|%s|%s|NULL|0|running|vt_%s|1686577659|0|||1|0|0|0||0|10", workflow.ReverseWorkflowName(wf), bls, position, sourceKs),
+			// The stream is retrying after an error, which only prevents
+			// switching traffic when RequireNoStreamErrors is set.
+			fmt.Sprintf("1|%s|%s|%s|NULL|0|Running|vt_%s|1686577659|0|Lock wait timeout exceeded; try restarting transaction||1|0|0|0||0|10", workflow.ReverseWorkflowName(wf), bls, position, sourceKs),
 		),
 	)
 
+	_, err = ws.WorkflowSwitchTraffic(ctx, &vtctldatapb.WorkflowSwitchTrafficRequest{
+		Keyspace:                 targetKs,
+		Workflow:                 wf,
+		Cells:                    tenv.cells,
+		MaxReplicationLagAllowed: &vttime.Duration{Seconds: 922337203},
+		EnableReverseReplication: true,
+		Direction:                int32(workflow.DirectionBackward),
+		RequireNoStreamErrors:    true,
+	})
+	require.ErrorContains(t, err, "has an unresolved error: Lock wait timeout exceeded; try restarting transaction")
+
 	_, err = ws.WorkflowSwitchTraffic(ctx, &vtctldatapb.WorkflowSwitchTrafficRequest{
 		Keyspace:                 targetKs,
 		Workflow:                 wf,
@@ -495,10 +508,22 @@ func TestUpdateVReplicationWorkflow(t *testing.T) {
 		fmt.Sprintf("%d", vreplID),
 	)
 
+	throttledBlsStr := blsStr + ` throttler_settings:{app_name:"critical" ratio:0.5 priority:High}`
+	throttledSelectRes := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|source|cell|tablet_types",
+			"int64|varchar|varchar|varchar",
+		),
+		fmt.Sprintf("%d|%s|%s|%s", vreplID, throttledBlsStr, cells[0], tabletTypes[0]),
+	)
+
 	tests := []struct {
 		name    string
 		request *tabletmanagerdatapb.UpdateVReplicationWorkflowRequest
-		query   string
+		// selectRes is the current config of the workflow, which defaults
+		// to the one without throttler settings.
+		selectRes *sqltypes.Result
+		query     string
 	}{
 		{
 			name: "update cells",
@@ -560,6 +585,47 @@ func TestUpdateVReplicationWorkflow(t *testing.T) {
 			query: fmt.Sprintf(`update _vt.vreplication set state = 'Stopped', source = 'keyspace:\"%s\" shard:\"%s\" filter:{rules:{match:\"customer\" filter:\"select * from customer\"} rules:{match:\"corder\" filter:\"select * from corder\"}} on_ddl:%s', cell = '%s', tablet_types = '%s' where id in (%d)`,
 				keyspace, shard, binlogdatapb.OnDDLAction_EXEC_IGNORE.String(), "zone1,zone2,zone3", "rdonly,replica,primary", vreplID),
 		},
+		{
+			name: "update throttler_settings",
+			request: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+				Workflow: workflow,
+				ThrottlerSettings: &binlogdatapb.VReplicationThrottlerSettings{
+					AppName:  "critical",
+					Ratio:    0.5,
+					Priority: binlogdatapb.VReplicationThrottlerPriority_High,
+				},
+			},
+			query: fmt.Sprintf(`update _vt.vreplication set state = 'Stopped', source = 'keyspace:\"%s\" shard:\"%s\" filter:{rules:{match:\"customer\" filter:\"select * from customer\"} rules:{match:\"corder\" filter:\"select * from corder\"}} throttler_settings:{app_name:\"critical\" ratio:0.5 priority:High}', cell = '', tablet_types = '' where id in (%d)`,
+				keyspace, shard, vreplID),
+		},
+		{
+			name: "update throttler ratio, NULL app name and priority",
+			request: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+				Workflow:    workflow,
+				Cells:       textutil.SimulatedNullStringSlice,
+				TabletTypes: []topodatapb.TabletType{topodatapb.TabletType(textutil.SimulatedNullInt)},
+				ThrottlerSettings: &binlogdatapb.VReplicationThrottlerSettings{
+					AppName:  textutil.SimulatedNullString,
+					Ratio:    0.25,
+					Priority: binlogdatapb.VReplicationThrottlerPriority(textutil.SimulatedNullInt),
+				},
+			},
+			selectRes: throttledSelectRes, // So keep the current app name and priority
+			query: fmt.Sprintf(`update _vt.vreplication set state = 'Stopped', source = 'keyspace:\"%s\" shard:\"%s\" filter:{rules:{match:\"customer\" filter:\"select * from customer\"} rules:{match:\"corder\" filter:\"select * from corder\"}} throttler_settings:{app_name:\"critical\" ratio:0.25 priority:High}', cell = '%s', tablet_types = '%s' where id in (%d)`,
+				keyspace, shard, cells[0], tabletTypes[0], vreplID),
+		},
+		{
+			name: "update schedule",
+			request: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+				Workflow: workflow,
+				Schedule: &binlogdatapb.VReplicationSchedule{
+					CopyWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "22:00", End: "06:00"}},
+					TimeZone:    "UTC",
+				},
+			},
+			query: fmt.Sprintf(`update _vt.vreplication set state = 'Stopped', source = 'keyspace:\"%s\" shard:\"%s\" filter:{rules:{match:\"customer\" filter:\"select * from customer\"} rules:{match:\"corder\" filter:\"select * from corder\"}} schedule:{copy_windows:{start:\"22:00\" end:\"06:00\"} time_zone:\"UTC\"}', cell = '', tablet_types = '' where id in (%d)`,
+				keyspace, shard, vreplID),
+		},
 	}
 
 	for _, tt := range tests {
@@ -579,7 +645,11 @@ func TestUpdateVReplicationWorkflow(t *testing.T) {
 
 			// These are the same for each RPC call.
 			tenv.tmc.tablets[tabletUID].vrdbClient.ExpectRequest(fmt.Sprintf("use %s", sidecar.DefaultName), &sqltypes.Result{}, nil)
-			tenv.tmc.tablets[tabletUID].vrdbClient.ExpectRequest(selectQuery, selectRes, nil)
+			res := selectRes
+			if tt.selectRes != nil {
+				res = tt.selectRes
+			}
+			tenv.tmc.tablets[tabletUID].vrdbClient.ExpectRequest(selectQuery, res, nil)
 			tenv.tmc.tablets[tabletUID].vrdbClient.ExpectRequest(fmt.Sprintf("use %s", sidecar.DefaultName), &sqltypes.Result{}, nil)
 			tenv.tmc.tablets[tabletUID].vrdbClient.ExpectRequest(idQuery, idRes, nil)
 
@@ -0,0 +1,298 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	// semiSyncMonitorActionAlert only exports stats and logs when the primary
+	// doesn't have enough semi-sync replicas for too long.
+	semiSyncMonitorActionAlert = "alert"
+	// semiSyncMonitorActionFallback additionally disables semi-sync on the
+	// primary until enough semi-sync replicas are connected again.
+	semiSyncMonitorActionFallback = "fallback"
+)
+
+var (
+	semiSyncMonitorInterval      time.Duration
+	semiSyncMonitorFallbackAfter = 30 * time.Second
+	semiSyncMonitorAction        = semiSyncMonitorActionAlert
+
+	// statsSemiSyncMonitorUnhealthy is set to 1 while the primary has fewer
+	// semi-sync replicas connected than its durability policy requires.
+	statsSemiSyncMonitorUnhealthy = stats.NewGauge("SemiSyncMonitorUnhealthy", "Whether the primary has fewer semi-sync replicas connected than its durability policy requires (1 = true / 0 = false)")
+	// statsSemiSyncMonitorFallbackActive is set to 1 while semi-sync is disabled by the monitor.
+	statsSemiSyncMonitorFallbackActive = stats.NewGauge("SemiSyncMonitorFallbackActive", "Whether semi-sync is currently disabled by the semi-sync monitor (1 = true / 0 = false)")
+	// statsSemiSyncMonitorFallbacks counts the number of times the monitor disabled semi-sync.
+	statsSemiSyncMonitorFallbacks = stats.NewCounter("SemiSyncMonitorFallbacks", "Number of times the semi-sync monitor disabled semi-sync on the primary")
+)
+
+func registerSemiSyncMonitorFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&semiSyncMonitorInterval, "semi-sync-monitor-interval", semiSyncMonitorInterval, "How often the primary checks that enough semi-sync replicas are connected to acknowledge its writes. A value of 0 disables the semi-sync monitor.")
+	fs.DurationVar(&semiSyncMonitorFallbackAfter, "semi-sync-monitor-fallback-after", semiSyncMonitorFallbackAfter, "How long the primary can have fewer semi-sync replicas than its durability policy requires before the semi-sync monitor takes its configured action.")
+	fs.StringVar(&semiSyncMonitorAction, "semi-sync-monitor-action", semiSyncMonitorAction, "Action the semi-sync monitor takes once semi-sync has been unhealthy for longer than --semi-sync-monitor-fallback-after. 'alert' only exports stats and logs, 'fallback' also disables semi-sync on the primary and records this in the shard record until enough semi-sync replicas reconnect.")
+}
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerSemiSyncMonitorFlags)
+	servenv.OnParseFor("vttablet", registerSemiSyncMonitorFlags)
+}
+
+// semiSyncMonitorState is the state kept by the semi-sync monitor loop
+// between two checks.
+type semiSyncMonitorState struct {
+	// initialized is set once the monitor has reconciled its state with
+	// the shard record after becoming primary.
+	initialized bool
+	// unhealthySince is the time at which the primary was first seen
+	// without enough semi-sync replicas. It is zero while healthy.
+	unhealthySince time.Time
+	// alerted is set once we logged that the unhealthy period exceeded
+	// the fallback threshold, so we don't log it on every check.
+	alerted bool
+	// fallbackActive is set while the monitor has semi-sync disabled.
+	fallbackActive bool
+}
+
+// reset clears the state and the exported stats.
+func (s *semiSyncMonitorState) reset() {
+	*s = semiSyncMonitorState{}
+	statsSemiSyncMonitorUnhealthy.Set(0)
+	statsSemiSyncMonitorFallbackActive.Set(0)
+}
+
+// semiSyncMonitorLoop periodically checks the health of semi-sync on the
+// primary, until the context is cancelled.
+func (tm *TabletManager) semiSyncMonitorLoop(ctx context.Context, interval time.Duration, doneChan chan<- struct{}) {
+	defer close(doneChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	state := &semiSyncMonitorState{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := tm.checkSemiSyncHealth(ctx, state, time.Now()); err != nil {
+			log.Errorf("Semi-sync monitor check failed: %v", err)
+		}
+	}
+}
+
+// checkSemiSyncHealth runs a single check of the semi-sync monitor.
+//
+// A primary is unhealthy when semi-sync is enabled on it, but fewer semi-sync
+// replicas are connected than its durability policy requires. In that state,
+// MySQL blocks all commits until a replica acknowledges them. Once that has
+// lasted longer than --semi-sync-monitor-fallback-after, the configured
+// action is taken.
+func (tm *TabletManager) checkSemiSyncHealth(ctx context.Context, state *semiSyncMonitorState, now time.Time) error {
+	// Don't race with reparent operations, which also change the semi-sync
+	// settings. If an action is running, we'll check again next time.
+	if !tm.actionSema.TryAcquire(1) {
+		return nil
+	}
+	defer tm.unlock()
+
+	tablet := tm.Tablet()
+	if tablet.Type != topodatapb.TabletType_PRIMARY {
+		// Demotion takes care of the semi-sync settings, we only
+		// need to clean up the shard record.
+		if state.fallbackActive {
+			if err := tm.updateSemiSyncFallback(ctx, nil); err != nil {
+				return err
+			}
+		}
+		state.reset()
+		return nil
+	}
+
+	durabilityName, err := tm.TopoServer.GetKeyspaceDurability(ctx, tablet.Keyspace)
+	if err != nil {
+		return err
+	}
+	durability, err := reparentutil.GetDurabilityPolicy(durabilityName)
+	if err != nil {
+		return err
+	}
+	required := reparentutil.SemiSyncAckers(durability, tablet)
+	connected := int(tm.MysqlDaemon.SemiSyncClients())
+	primaryEnabled, replicaEnabled := tm.MysqlDaemon.SemiSyncEnabled()
+
+	if !state.initialized {
+		if err := tm.initSemiSyncMonitorState(ctx, state, primaryEnabled, required, connected); err != nil {
+			return err
+		}
+	}
+
+	if state.fallbackActive {
+		if primaryEnabled || required == 0 {
+			// Someone else already enabled semi-sync again, or the durability
+			// policy doesn't require it anymore.
+			log.Infof("Semi-sync fallback is not needed anymore (semi-sync enabled: %v, required ackers: %d)", primaryEnabled, required)
+		} else if connected >= required {
+			log.Infof("%d semi-sync replicas are connected again, re-enabling semi-sync on the primary", connected)
+			if err := tm.MysqlDaemon.SetSemiSyncEnabled(true, replicaEnabled); err != nil {
+				return err
+			}
+		} else {
+			return nil
+		}
+		if err := tm.updateSemiSyncFallback(ctx, nil); err != nil {
+			return err
+		}
+		state.reset()
+		state.initialized = true
+		return nil
+	}
+
+	if required == 0 || !primaryEnabled || connected >= required {
+		// Writes can't get blocked waiting for acks.
+		state.unhealthySince = time.Time{}
+		state.alerted = false
+		statsSemiSyncMonitorUnhealthy.Set(0)
+		return nil
+	}
+
+	statsSemiSyncMonitorUnhealthy.Set(1)
+	if state.unhealthySince.IsZero() {
+		log.Warningf("Primary has %d semi-sync replicas connected, but its durability policy %v requires %d", connected, durabilityName, required)
+		state.unhealthySince = now
+	}
+	if now.Sub(state.unhealthySince) < semiSyncMonitorFallbackAfter {
+		return nil
+	}
+
+	if semiSyncMonitorAction != semiSyncMonitorActionFallback {
+		if !state.alerted {
+			log.Errorf("Primary has not had enough semi-sync replicas for %v, writes may be blocked (connected: %d, required: %d)", now.Sub(state.unhealthySince), connected, required)
+			state.alerted = true
+		}
+		return nil
+	}
+
+	log.Warningf("Primary has not had enough semi-sync replicas for %v (connected: %d, required: %d), disabling semi-sync", now.Sub(state.unhealthySince), connected, required)
+	if err := tm.MysqlDaemon.SetSemiSyncEnabled(false, replicaEnabled); err != nil {
+		return err
+	}
+	state.fallbackActive = true
+	statsSemiSyncMonitorFallbacks.Add(1)
+	statsSemiSyncMonitorFallbackActive.Set(1)
+	return tm.updateSemiSyncFallback(ctx, &topodatapb.Shard_SemiSyncFallback{
+		PrimaryAlias:      tablet.Alias,
+		StartTime:         protoutil.TimeToProto(now),
+		RequiredAckers:    int32(required),
+		ConnectedReplicas: int32(connected),
+	})
+}
+
+// initSemiSyncMonitorState reconciles the monitor state with the shard record
+// the first time we check as a primary. If a previous run of this tablet
+// disabled semi-sync and it is still disabled, we keep the fallback active.
+// Otherwise we clean up any stale record left for this tablet.
+func (tm *TabletManager) initSemiSyncMonitorState(ctx context.Context, state *semiSyncMonitorState, primaryEnabled bool, required, connected int) error {
+	tablet := tm.Tablet()
+	si, err := tm.TopoServer.GetShard(ctx, tablet.Keyspace, tablet.Shard)
+	if err != nil {
+		return err
+	}
+	if fallback := si.SemiSyncFallback; fallback != nil && topoproto.TabletAliasEqual(fallback.PrimaryAlias, tablet.Alias) {
+		if !primaryEnabled && required > connected {
+			state.fallbackActive = true
+			statsSemiSyncMonitorFallbackActive.Set(1)
+		} else if err := tm.updateSemiSyncFallback(ctx, nil); err != nil {
+			return err
+		}
+	}
+	state.initialized = true
+	return nil
+}
+
+// updateSemiSyncFallback records the semi-sync fallback of this tablet in the
+// shard record. If fallback is nil, a record left by this tablet is cleared.
+func (tm *TabletManager) updateSemiSyncFallback(ctx context.Context, fallback *topodatapb.Shard_SemiSyncFallback) error {
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+
+	tablet := tm.Tablet()
+	_, err := tm.TopoServer.UpdateShardFields(ctx, tablet.Keyspace, tablet.Shard, func(si *topo.ShardInfo) error {
+		if fallback == nil && (si.SemiSyncFallback == nil || !topoproto.TabletAliasEqual(si.SemiSyncFallback.PrimaryAlias, tablet.Alias)) {
+			return topo.NewError(topo.NoUpdateNeeded, si.ShardName())
+		}
+		si.SemiSyncFallback = fallback
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update semi-sync fallback in shard record: %w", err)
+	}
+	return nil
+}
+
+func (tm *TabletManager) startSemiSyncMonitor() {
+	if semiSyncMonitorInterval <= 0 {
+		return
+	}
+	switch semiSyncMonitorAction {
+	case semiSyncMonitorActionAlert, semiSyncMonitorActionFallback:
+	default:
+		log.Errorf("Invalid --semi-sync-monitor-action %q, using %q", semiSyncMonitorAction, semiSyncMonitorActionAlert)
+		semiSyncMonitorAction = semiSyncMonitorActionAlert
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm._semiSyncMonitorDone = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	tm._semiSyncMonitorCancel = cancel
+
+	go tm.semiSyncMonitorLoop(ctx, semiSyncMonitorInterval, tm._semiSyncMonitorDone)
+}
+
+func (tm *TabletManager) stopSemiSyncMonitor() {
+	var doneChan <-chan struct{}
+
+	tm.mutex.Lock()
+	if tm._semiSyncMonitorCancel != nil {
+		tm._semiSyncMonitorCancel()
+	}
+	doneChan = tm._semiSyncMonitorDone
+	tm.mutex.Unlock()
+
+	// If the monitor was running, wait for it to fully stop.
+	if doneChan != nil {
+		<-doneChan
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestCheckSemiSyncHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{DurabilityPolicy: "semi_sync"})
+	require.NoError(t, err)
+	tm := newTestTM(t, ts, 100, keyspace, shard)
+	defer tm.Stop()
+
+	err = tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_PRIMARY, DBActionSetReadWrite)
+	require.NoError(t, err)
+
+	oldAction := semiSyncMonitorAction
+	semiSyncMonitorAction = semiSyncMonitorActionFallback
+	defer func() {
+		semiSyncMonitorAction = oldAction
+	}()
+
+	mysqld := tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon)
+	mysqld.SemiSyncPrimaryEnabled = true
+	mysqld.SemiSyncReplicaEnabled = true
+	mysqld.SemiSyncPrimaryClients = 0
+
+	state := &semiSyncMonitorState{}
+	now := time.Now()
+
+	// The first unhealthy check only starts the clock.
+	require.NoError(t, tm.checkSemiSyncHealth(ctx, state, now))
+	assert.True(t, mysqld.SemiSyncPrimaryEnabled)
+	assert.False(t, state.fallbackActive)
+	assert.EqualValues(t, 1, statsSemiSyncMonitorUnhealthy.Get())
+
+	// Once the threshold is exceeded, semi-sync gets disabled and recorded in the shard record.
+	require.NoError(t, tm.checkSemiSyncHealth(ctx, state, now.Add(semiSyncMonitorFallbackAfter)))
+	assert.False(t, mysqld.SemiSyncPrimaryEnabled)
+	assert.True(t, mysqld.SemiSyncReplicaEnabled)
+	assert.True(t, state.fallbackActive)
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	require.NoError(t, err)
+	require.NotNil(t, si.SemiSyncFallback)
+	assert.True(t, topoproto.TabletAliasEqual(tm.tabletAlias, si.SemiSyncFallback.PrimaryAlias))
+	assert.EqualValues(t, 1, si.SemiSyncFallback.RequiredAckers)
+	assert.EqualValues(t, 0, si.SemiSyncFallback.ConnectedReplicas)
+
+	// Nothing changes while the replicas are still missing.
+	require.NoError(t, tm.checkSemiSyncHealth(ctx, state, now.Add(2*semiSyncMonitorFallbackAfter)))
+	assert.False(t, mysqld.SemiSyncPrimaryEnabled)
+	assert.True(t, state.fallbackActive)
+
+	// When a semi-sync replica is back, semi-sync is enabled again and the record cleared.
+	mysqld.SemiSyncPrimaryClients = 1
+	require.NoError(t, tm.checkSemiSyncHealth(ctx, state, now.Add(3*semiSyncMonitorFallbackAfter)))
+	assert.True(t, mysqld.SemiSyncPrimaryEnabled)
+	assert.False(t, state.fallbackActive)
+	assert.EqualValues(t, 0, statsSemiSyncMonitorUnhealthy.Get())
+	si, err = ts.GetShard(ctx, keyspace, shard)
+	require.NoError(t, err)
+	assert.Nil(t, si.SemiSyncFallback)
+}
+
+func TestCheckSemiSyncHealthAlertOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{DurabilityPolicy: "semi_sync"})
+	require.NoError(t, err)
+	tm := newTestTM(t, ts, 100, keyspace, shard)
+	defer tm.Stop()
+
+	err = tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_PRIMARY, DBActionSetReadWrite)
+	require.NoError(t, err)
+
+	mysqld := tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon)
+	mysqld.SemiSyncPrimaryEnabled = true
+
+	state := &semiSyncMonitorState{}
+	now := time.Now()
+	require.NoError(t, tm.checkSemiSyncHealth(ctx, state, now))
+	require.NoError(t, tm.checkSemiSyncHealth(ctx, state, now.Add(semiSyncMonitorFallbackAfter)))
+	assert.True(t, state.alerted)
+	assert.False(t, state.fallbackActive)
+	assert.True(t, mysqld.SemiSyncPrimaryEnabled)
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	require.NoError(t, err)
+	assert.Nil(t, si.SemiSyncFallback)
+}
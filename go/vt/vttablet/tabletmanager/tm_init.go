@@ -184,6 +184,13 @@ type TabletManager struct {
 	// _shardSyncCancel is the function to stop the background shard sync goroutine.
 	_shardSyncCancel context.CancelFunc
 
+	// _semiSyncMonitorDone is a channel for waiting until the semi-sync monitor
+	// goroutine has really finished after _semiSyncMonitorCancel was called.
+	_semiSyncMonitorDone chan struct{}
+
+	// _semiSyncMonitorCancel is the function to stop the semi-sync monitor goroutine.
+	_semiSyncMonitorCancel context.CancelFunc
+
 	// _rebuildKeyspaceDone is a channel for waiting until the current keyspace
 	// has been rebuilt
 	_rebuildKeyspaceDone chan struct{}
@@ -390,6 +397,7 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, healthCheckInterval ti
 	// The following initializations don't need to be done
 	// in any specific order.
 	tm.startShardSync()
+	tm.startSemiSyncMonitor()
 	tm.exportStats()
 	servenv.OnRun(tm.registerTabletManager)
 
@@ -418,6 +426,7 @@ func (tm *TabletManager) Close() {
 	// rather than registering it as an OnTerm hook so the shard sync loop keeps
 	// running during lame duck.
 	tm.stopShardSync()
+	tm.stopSemiSyncMonitor()
 	tm.stopRebuildKeyspace()
 
 	// cleanup initialized fields in the tablet entry
@@ -449,6 +458,7 @@ func (tm *TabletManager) Stop() {
 	// Stop the shard sync loop and wait for it to exit. This needs to be done
 	// here in addition to in Close() because tests do not call Close().
 	tm.stopShardSync()
+	tm.stopSemiSyncMonitor()
 	tm.stopRebuildKeyspace()
 
 	if tm.QueryServiceControl != nil {
@@ -767,12 +777,15 @@ func (tm *TabletManager) handleRestore(ctx context.Context) (bool, error) {
 	if tm.Cnf == nil && restoreFromBackup {
 		return false, fmt.Errorf("you cannot enable --restore_from_backup without a my.cnf file")
 	}
+	if tm.Cnf == nil && restoreFromTablet != "" {
+		return false, fmt.Errorf("you cannot set --restore-from-tablet without a my.cnf file")
+	}
 	if restoreToTimestampStr != "" && restoreToPos != "" {
 		return false, fmt.Errorf("--restore-to-timestamp and --restore-to-pos are mutually exclusive")
 	}
 
 	// Restore in the background
-	if restoreFromBackup {
+	if restoreFromBackup || restoreFromTablet != "" {
 		go func() {
 			// Open the state manager after restore is done.
 			defer tm.tmState.Open()
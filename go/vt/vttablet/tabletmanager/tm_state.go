@@ -19,6 +19,7 @@ package tabletmanager
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 	"sync"
 	"syscall"
@@ -247,6 +248,30 @@ func (ts *tmState) SetMysqlPort(mport int32) {
 	ts.publishStateLocked(ts.ctx)
 }
 
+// ChangeTags merges the given tags into the tags of the tablet, removing the
+// ones with an empty value, or replaces them if replace is set, and publishes
+// the tablet record. It returns the tags of the tablet after the change.
+func (ts *tmState) ChangeTags(ctx context.Context, tags map[string]string, replace bool) map[string]string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	log.Infof("Changing Tablet Tags: %v (replace: %v) for %s", tags, replace, ts.tablet.Alias.String())
+
+	if replace || ts.tablet.Tags == nil {
+		ts.tablet.Tags = make(map[string]string, len(tags))
+	}
+	for key, value := range tags {
+		if value == "" {
+			delete(ts.tablet.Tags, key)
+			continue
+		}
+		ts.tablet.Tags[key] = value
+	}
+
+	ts.publishForDisplay()
+	ts.publishStateLocked(ctx)
+	return maps.Clone(ts.tablet.Tags)
+}
+
 // UpdateTablet must be called during initialization only.
 func (ts *tmState) UpdateTablet(update func(tablet *topodatapb.Tablet)) {
 	ts.mu.Lock()
@@ -391,6 +391,37 @@ func TestStateChangeTabletType(t *testing.T) {
 	assert.Equal(t, int64(2), statsTabletTypeCount.Counts()["replica"])
 }
 
+func TestStateChangeTags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 2, "ks", "0")
+	defer tm.Stop()
+
+	alias := &topodatapb.TabletAlias{
+		Cell: "cell1",
+		Uid:  2,
+	}
+
+	tags := tm.tmState.ChangeTags(ctx, map[string]string{"drained_from": "replica", "reason": "disk"}, false)
+	assert.Equal(t, "replica", tags["drained_from"])
+	assert.Equal(t, "disk", tags["reason"])
+	ti, err := ts.GetTablet(ctx, alias)
+	require.NoError(t, err)
+	assert.Equal(t, tags, ti.Tags)
+	assert.Equal(t, tags, tm.Tablet().Tags)
+
+	tags = tm.tmState.ChangeTags(ctx, map[string]string{"drained_from": ""}, false)
+	assert.NotContains(t, tags, "drained_from")
+	assert.Equal(t, "disk", tags["reason"])
+
+	tags = tm.tmState.ChangeTags(ctx, map[string]string{"owner": "team-a"}, true)
+	assert.Equal(t, map[string]string{"owner": "team-a"}, tags)
+	ti, err = ts.GetTablet(ctx, alias)
+	require.NoError(t, err)
+	assert.Equal(t, tags, ti.Tags)
+}
+
 /*
 	This test verifies, even if SetServingType returns error we should still publish
 
@@ -119,6 +119,19 @@ func (vde *Engine) getVDiffSummary(vdiffID int64, dbClient binlogplayer.DBClient
 
 }
 
+// getVDiffHistory returns the reports of the previous runs of a scheduled vdiff, most recent first.
+func (vde *Engine) getVDiffHistory(vdiffID int64, dbClient binlogplayer.DBClient) (*query.QueryResult, error) {
+	query, err := sqlparser.ParseAndBind(sqlGetVDiffHistory, sqltypes.Int64BindVariable(vdiffID))
+	if err != nil {
+		return nil, err
+	}
+	qr, err := dbClient.ExecuteFetch(query, -1)
+	if err != nil {
+		return nil, err
+	}
+	return sqltypes.ResultToProto3(qr), nil
+}
+
 // Validate vdiff options. Also setup defaults where applicable.
 func (vde *Engine) fixupOptions(options *tabletmanagerdatapb.VDiffOptions) (*tabletmanagerdatapb.VDiffOptions, error) {
 	// Assign defaults to sourceCell and targetCell if not specified.
@@ -175,7 +188,7 @@ func (vde *Engine) handleCreateResumeAction(ctx context.Context, dbClient binlog
 	}
 	recordFound := len(qr.Rows) == 1
 	if recordFound && action == CreateAction {
-		return fmt.Errorf("vdiff with UUID %s already exists on tablet %v",
+		return vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "vdiff with UUID %s already exists on tablet %v",
 			req.VdiffUuid, vde.thisTablet.Alias)
 	} else if action == ResumeAction {
 		if !recordFound {
@@ -315,6 +328,11 @@ func (vde *Engine) handleShowAction(ctx context.Context, dbClient binlogplayer.D
 			if err != nil {
 				return err
 			}
+			if req.IncludeHistory {
+				if resp.History, err = vde.getVDiffHistory(vdiffID, dbClient); err != nil {
+					return err
+				}
+			}
 		default:
 			return fmt.Errorf("too many vdiffs found (%d) for UUID %s keyspace %s and workflow %s on tablet %v",
 				len(qr.Rows), vdiffUUID, req.Keyspace, req.Workflow, vde.thisTablet.Alias)
@@ -169,6 +169,10 @@ func (vde *Engine) openLocked(ctx context.Context) error {
 
 var openRetryInterval = 1 * time.Second
 
+// defaultVDiffHistoryRetention is the number of reports of previous runs of a
+// scheduled vdiff that are kept when no retention is specified.
+const defaultVDiffHistoryRetention = 10
+
 func (vde *Engine) retry(ctx context.Context, err error) {
 	log.Errorf("Error starting vdiff engine: %v, will keep retrying.", err)
 	for {
@@ -363,6 +367,108 @@ func (vde *Engine) retryVDiffs(ctx context.Context) error {
 	return nil
 }
 
+// runScheduledVDiffs restarts the scheduled vdiffs whose schedule interval has passed
+// since they last ran, as long as their workflow is still running. The report of the
+// previous run is archived in the vdiff_history table before the vdiff is reset.
+func (vde *Engine) runScheduledVDiffs(ctx context.Context) error {
+	vde.mu.Lock()
+	defer vde.mu.Unlock()
+	dbClient := vde.dbClientFactoryFiltered()
+	if err := dbClient.Connect(); err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	qr, err := dbClient.ExecuteFetch(sqlGetScheduledVDiffsToRun, -1)
+	if err != nil {
+		return err
+	}
+	for _, row := range qr.Named().Rows {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		uuid := row.AsString("vdiff_uuid", "")
+		id, err := row.ToInt64("id")
+		if err != nil {
+			return err
+		}
+		query, err := sqlparser.ParseAndBind(sqlGetRunningVReplicationStreams,
+			sqltypes.StringBindVariable(row.AsString("workflow", "")),
+			sqltypes.StringBindVariable(row.AsString("db_name", "")),
+		)
+		if err != nil {
+			return err
+		}
+		streams, err := dbClient.ExecuteFetch(query, 1)
+		if err != nil {
+			return err
+		}
+		if len(streams.Rows) == 0 || streams.Named().Row().AsInt64("cnt", 0) == 0 {
+			// The workflow is no longer running, e.g. it was stopped or completed.
+			continue
+		}
+		options := &tabletmanagerdata.VDiffOptions{}
+		if err := json.Unmarshal(row.AsBytes("options", []byte("{}")), options); err != nil {
+			return err
+		}
+		log.Infof("Running scheduled vdiff %s", uuid)
+		if err := vde.archiveVDiff(dbClient, id, options.GetCoreOptions().GetHistoryRetention()); err != nil {
+			return err
+		}
+		query, err = sqlparser.ParseAndBind(sqlResetScheduledVDiff, sqltypes.Int64BindVariable(id))
+		if err != nil {
+			return err
+		}
+		if _, err = dbClient.ExecuteFetch(query, 1); err != nil {
+			return err
+		}
+		if err := vde.addController(row, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveVDiff saves the report of the last run of the vdiff in the vdiff_history table
+// and removes any reports beyond the given retention.
+func (vde *Engine) archiveVDiff(dbClient binlogplayer.DBClient, id, retention int64) error {
+	if retention <= 0 {
+		retention = defaultVDiffHistoryRetention
+	}
+	query, err := sqlparser.ParseAndBind(sqlArchiveVDiff, sqltypes.Int64BindVariable(id))
+	if err != nil {
+		return err
+	}
+	if _, err = dbClient.ExecuteFetch(query, 1); err != nil {
+		return err
+	}
+	query, err = sqlparser.ParseAndBind(sqlGetVDiffHistoryPruneID,
+		sqltypes.Int64BindVariable(id),
+		sqltypes.Int64BindVariable(retention),
+	)
+	if err != nil {
+		return err
+	}
+	qr, err := dbClient.ExecuteFetch(query, 1)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) == 0 {
+		return nil
+	}
+	query, err = sqlparser.ParseAndBind(sqlPruneVDiffHistory,
+		sqltypes.Int64BindVariable(id),
+		sqltypes.Int64BindVariable(qr.Named().Row().AsInt64("id", 0)),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = dbClient.ExecuteFetch(query, -1)
+	return err
+}
+
 func (vde *Engine) retryErroredVDiffs() {
 	tkr := time.NewTicker(time.Second * 30)
 	defer tkr.Stop()
@@ -377,6 +483,9 @@ func (vde *Engine) retryErroredVDiffs() {
 		if err := vde.retryVDiffs(vde.ctx); err != nil {
 			log.Errorf("Error retrying vdiffs: %v", err)
 		}
+		if err := vde.runScheduledVDiffs(vde.ctx); err != nil {
+			log.Errorf("Error running scheduled vdiffs: %v", err)
+		}
 	}
 }
 
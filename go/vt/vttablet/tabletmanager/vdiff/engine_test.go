@@ -285,3 +285,80 @@ func TestEngineRetryErroredVDiffs(t *testing.T) {
 	}
 
 }
+
+func TestEngineRunScheduledVDiffs(t *testing.T) {
+	vdenv := newTestVDiffEnv(t)
+	defer vdenv.close()
+	UUID := uuid.New().String()
+	expectedControllerCnt := 0
+	tests := []struct {
+		name                string
+		scheduledResults    *sqltypes.Result
+		runningStreamsCount int
+		expectRun           bool
+	}{
+		{
+			name:             "nothing scheduled",
+			scheduledResults: noResults,
+		},
+		{
+			name: "workflow not running",
+			scheduledResults: sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+				vdiffTestCols,
+				vdiffTestColTypes,
+			),
+				fmt.Sprintf("1|%s|%s|%s|%s|%s|completed|%s|", UUID, vdiffenv.workflow, tstenv.KeyspaceName, tstenv.ShardName, vdiffDBName, optionsJS),
+			),
+		},
+		{
+			name: "scheduled run",
+			scheduledResults: sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+				vdiffTestCols,
+				vdiffTestColTypes,
+			),
+				fmt.Sprintf("1|%s|%s|%s|%s|%s|completed|%s|", UUID, vdiffenv.workflow, tstenv.KeyspaceName, tstenv.ShardName, vdiffDBName, optionsJS),
+			),
+			runningStreamsCount: 1,
+			expectRun:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vdiffenv.dbClient.ExpectRequestRE("(?s)select \\* from _vt.vdiff where state in \\('completed', 'error'\\).*schedule_interval_seconds.*", tt.scheduledResults, nil)
+
+			for _, row := range tt.scheduledResults.Rows {
+				id := row[0].ToString()
+				vdiffenv.dbClient.ExpectRequest(fmt.Sprintf("select count(*) as cnt from _vt.vreplication where workflow = '%s' and db_name = '%s' and state = 'Running'", vdiffenv.workflow, vdiffDBName),
+					sqltypes.MakeTestResult(sqltypes.MakeTestFields("cnt", "int64"), fmt.Sprintf("%d", tt.runningStreamsCount)), nil)
+				if tt.expectRun {
+					vdiffenv.dbClient.ExpectRequestRE("(?s)insert into _vt.vdiff_history\\(vdiff_id, state, last_error, started_at, completed_at, report\\).*", singleRowAffected, nil)
+					vdiffenv.dbClient.ExpectRequest(fmt.Sprintf("select id as id from _vt.vdiff_history where vdiff_id = %s order by id desc limit %d, 1", id, defaultVDiffHistoryRetention), noResults, nil)
+					vdiffenv.dbClient.ExpectRequestRE("(?s)update _vt.vdiff as vd left join _vt.vdiff_table as vdt on \\(vd.id = vdt.vdiff_id\\) set vd.state = 'pending'.*vdt.lastpk = NULL.*", singleRowAffected, nil)
+					vdiffenv.dbClient.ExpectRequest(fmt.Sprintf("select * from _vt.vdiff where id = %s", id), sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+						vdiffTestCols,
+						vdiffTestColTypes,
+					),
+						fmt.Sprintf("%s|%s|%s|%s|%s|%s|pending|%s|", id, UUID, vdiffenv.workflow, tstenv.KeyspaceName, tstenv.ShardName, vdiffDBName, optionsJS),
+					), nil)
+					vdiffenv.dbClient.ExpectRequest(fmt.Sprintf("select * from _vt.vreplication where workflow = '%s' and db_name = '%s'", vdiffenv.workflow, vdiffDBName), sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+						"id|workflow|source|pos|stop_pos|max_tps|max_replication_lag|cell|tablet_types|time_updated|transaction_timestamp|state|message|db_name|rows_copied|tags|time_heartbeat|workflow_type|time_throttled|component_throttled|workflow_sub_type",
+						"int64|varbinary|blob|varbinary|varbinary|int64|int64|varbinary|varbinary|int64|int64|varbinary|varbinary|varbinary|int64|varbinary|int64|int64|int64|varchar|int64",
+					),
+						fmt.Sprintf("%s|%s|%s|%s||9223372036854775807|9223372036854775807||PRIMARY,REPLICA|1669511347|0|Running||%s|200||1669511347|1|0||1", id, vdiffenv.workflow, vreplSource, vdiffSourceGtid, vdiffDBName),
+					), nil)
+
+					// At this point we know that we kicked off the scheduled run so we can short circuit the vdiff.
+					shortCircuitTestAfterQuery(fmt.Sprintf("update _vt.vdiff set state = 'started', last_error = '' , started_at = utc_timestamp() where id = %s", id), vdiffenv.dbClient)
+
+					expectedControllerCnt++
+				}
+			}
+
+			err := vdiffenv.vde.runScheduledVDiffs(vdiffenv.vde.ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedControllerCnt, len(vdiffenv.vde.controllers))
+			vdiffenv.dbClient.Wait()
+		})
+	}
+}
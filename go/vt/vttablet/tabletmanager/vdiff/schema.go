@@ -27,10 +27,12 @@ const (
 	sqlGetVDiffByKeyspaceWorkflowUUID = "select * from _vt.vdiff where keyspace = %a and workflow = %a and vdiff_uuid = %a"
 	sqlGetMostRecentVDiff             = "select * from _vt.vdiff where keyspace = %a and workflow = %a order by id desc limit 1"
 	sqlGetVDiffByID                   = "select * from _vt.vdiff where id = %a"
-	sqlDeleteVDiffs                   = `delete from vd, vdt, vdl using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+	sqlDeleteVDiffs                   = `delete from vd, vdt, vdl, vdh using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
 										left join _vt.vdiff_log as vdl on (vd.id = vdl.vdiff_id)
+										left join _vt.vdiff_history as vdh on (vd.id = vdh.vdiff_id)
 										where vd.keyspace = %a and vd.workflow = %a`
-	sqlDeleteVDiffByUUID = `delete from vd, vdt using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+	sqlDeleteVDiffByUUID = `delete from vd, vdt, vdh using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+							left join _vt.vdiff_history as vdh on (vd.id = vdh.vdiff_id)
 							where vd.vdiff_uuid = %a`
 	sqlVDiffSummary = `select vd.state as vdiff_state, vd.last_error as last_error, vdt.table_name as table_name,
 						vd.vdiff_uuid as 'uuid', vdt.state as table_state, vdt.table_rows as table_rows,
@@ -63,4 +65,23 @@ const (
 	sqlUpdateTableMismatch       = "update _vt.vdiff_table set mismatch = true where vdiff_id = %a and table_name = %a"
 
 	sqlGetIncompleteTables = "select table_name as table_name from _vt.vdiff_table where vdiff_id = %a and state != 'completed'"
+
+	// What scheduled VDiffs are due to be run again.
+	sqlGetScheduledVDiffsToRun = `select * from _vt.vdiff where state in ('completed', 'error')
+							and json_extract(options, '$.core_options.schedule_interval_seconds') > 0
+							and timestampdiff(second, coalesce(completed_at, liveness_timestamp, started_at, created_at), now()) >=
+							json_extract(options, '$.core_options.schedule_interval_seconds')`
+	sqlResetScheduledVDiff = `update _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id) set vd.state = 'pending',
+							vd.last_error = '', vd.started_at = NULL, vd.completed_at = NULL, vdt.state = 'pending', vdt.lastpk = NULL,
+							vdt.rows_compared = 0, vdt.mismatch = false, vdt.report = NULL where vd.id = %a and vd.state in ('completed', 'error')`
+	sqlGetRunningVReplicationStreams = "select count(*) as cnt from _vt.vreplication where workflow = %a and db_name = %a and state = 'Running'"
+
+	sqlArchiveVDiff = `insert into _vt.vdiff_history(vdiff_id, state, last_error, started_at, completed_at, report)
+						select vd.id, vd.state, vd.last_error, vd.started_at, vd.completed_at,
+						(select json_objectagg(vdt.table_name, vdt.report) from _vt.vdiff_table as vdt where vdt.vdiff_id = vd.id)
+						from _vt.vdiff as vd where vd.id = %a`
+	sqlGetVDiffHistory = `select id as id, state as state, last_error as last_error, started_at as started_at,
+						completed_at as completed_at, report as report from _vt.vdiff_history where vdiff_id = %a order by id desc`
+	sqlGetVDiffHistoryPruneID = "select id as id from _vt.vdiff_history where vdiff_id = %a order by id desc limit %a, 1"
+	sqlPruneVDiffHistory      = "delete from _vt.vdiff_history where vdiff_id = %a and id <= %a"
 )
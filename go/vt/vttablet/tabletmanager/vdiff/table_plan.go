@@ -77,16 +77,26 @@ func (td *tableDiffer) buildTablePlan(dbClient binlogplayer.DBClient, dbName str
 	targetSelect := &sqlparser.Select{}
 	// aggregates is the list of Aggregate functions, if any.
 	var aggregates []*engine.AggregateParams
+	// starColumns are the positions of the columns expanded from a '*'.
+	starColumns := make(map[string]int)
 	for _, selExpr := range sel.SelectExprs {
 		switch selExpr := selExpr.(type) {
 		case *sqlparser.StarExpr:
 			// If it's a '*' expression, expand column list from the schema.
 			for _, fld := range tp.table.Fields {
 				aliased := &sqlparser.AliasedExpr{Expr: &sqlparser.ColName{Name: sqlparser.NewIdentifierCI(fld.Name)}}
+				starColumns[strings.ToLower(fld.Name)] = len(sourceSelect.SelectExprs)
 				sourceSelect.SelectExprs = append(sourceSelect.SelectExprs, aliased)
 				targetSelect.SelectExprs = append(targetSelect.SelectExprs, aliased)
 			}
 		case *sqlparser.AliasedExpr:
+			// In "select *, sha2(email, 256) as email", the expression
+			// transforms a column of the '*', which the source must select
+			// transformed rather than as is.
+			if i, ok := starColumns[selExpr.As.Lowered()]; ok && !selExpr.As.IsEmpty() {
+				sourceSelect.SelectExprs[i] = selExpr
+				continue
+			}
 			var targetCol *sqlparser.ColName
 			if !selExpr.As.IsEmpty() {
 				targetCol = &sqlparser.ColName{Name: selExpr.As}
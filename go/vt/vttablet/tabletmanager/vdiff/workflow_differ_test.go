@@ -96,6 +96,27 @@ func TestBuildPlanSuccess(t *testing.T) {
 				Direction: sqlparser.AscOrder,
 			}},
 		},
+	}, {
+		// '*' with a column transformation
+		input: &binlogdatapb.Rule{
+			Match:  "t1",
+			Filter: "select *, c2 * 2 as c2 from t1",
+		},
+		table: "t1",
+		tablePlan: &tablePlan{
+			dbName:      vdiffDBName,
+			table:       testSchema.TableDefinitions[tableDefMap["t1"]],
+			sourceQuery: "select c1, c2 * 2 as c2 from t1 order by c1 asc",
+			targetQuery: "select c1, c2 from t1 order by c1 asc",
+			compareCols: []compareColInfo{{0, collations.Local().LookupByName(sqltypes.NULL.String()), true, "c1"}, {1, collations.Local().LookupByName(sqltypes.NULL.String()), false, "c2"}},
+			comparePKs:  []compareColInfo{{0, collations.Local().LookupByName(sqltypes.NULL.String()), true, "c1"}},
+			pkCols:      []int{0},
+			selectPks:   []int{0},
+			orderBy: sqlparser.OrderBy{&sqlparser.Order{
+				Expr:      &sqlparser.ColName{Name: sqlparser.NewIdentifierCI("c1")},
+				Direction: sqlparser.AscOrder,
+			}},
+		},
 	}, {
 		input: &binlogdatapb.Rule{
 			Match:  "t1",
@@ -62,6 +62,9 @@ type controller struct {
 	source       *binlogdatapb.BinlogSource
 	stopPos      string
 	tabletPicker *discovery.TabletPicker
+	// vtgateConnector is set if the source is an external Vitess
+	// cluster that is streamed through one of its vtgates.
+	vtgateConnector *vtgateConnector
 
 	cancel context.CancelFunc
 	done   chan struct{}
@@ -126,16 +129,39 @@ func newController(ctx context.Context, params map[string]string, dbClientFactor
 
 		sourceTopo := ts
 		if ct.source.ExternalCluster != "" {
-			sourceTopo, err = sourceTopo.OpenExternalVitessClusterServer(ctx, ct.source.ExternalCluster)
+			vci, err := ts.GetExternalVitessCluster(ctx, ct.source.ExternalCluster)
 			if err != nil {
 				return nil, err
 			}
+			if vci == nil {
+				return nil, fmt.Errorf("no vitess cluster found with name %s", ct.source.ExternalCluster)
+			}
+			if vtgateAddress := vci.GetVtgateAddress(); vtgateAddress != "" {
+				tabletTypes, _, err := discovery.ParseTabletTypesAndOrder(tabletTypesStr)
+				if err != nil {
+					return nil, err
+				}
+				tabletType := topodatapb.TabletType_PRIMARY
+				if len(tabletTypes) > 0 {
+					tabletType = tabletTypes[0]
+				}
+				log.Infof("streaming source keyspace/shard %v/%v of external cluster %v through vtgate %v with tabletType: %v",
+					ct.source.Keyspace, ct.source.Shard, ct.source.ExternalCluster, vtgateAddress, tabletType)
+				ct.vtgateConnector = newVTGateConnector(vtgateAddress, ct.source.Keyspace, ct.source.Shard, tabletType, params["cell"])
+			} else {
+				sourceTopo, err = sourceTopo.OpenExternalVitessClusterServer(ctx, ct.source.ExternalCluster)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
-		tp, err := discovery.NewTabletPicker(ctx, sourceTopo, cells, ct.vre.cell, ct.source.Keyspace, ct.source.Shard, tabletTypesStr, discovery.TabletPickerOptions{})
-		if err != nil {
-			return nil, err
+		if ct.vtgateConnector == nil {
+			tp, err := discovery.NewTabletPicker(ctx, sourceTopo, cells, ct.vre.cell, ct.source.Keyspace, ct.source.Shard, tabletTypesStr, discovery.TabletPickerOptions{})
+			if err != nil {
+				return nil, err
+			}
+			ct.tabletPicker = tp
 		}
-		ct.tabletPicker = tp
 	}
 
 	ctx, ct.cancel = context.WithCancel(ctx)
@@ -247,6 +273,8 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 			if err != nil {
 				return err
 			}
+		} else if ct.vtgateConnector != nil {
+			vsClient = ct.vtgateConnector
 		} else {
 			vsClient = newTabletConnector(tablet)
 		}
@@ -294,10 +322,10 @@ func (ct *controller) setMessage(dbClient binlogplayer.DBClient, message string)
 }
 
 // pickSourceTablet picks a healthy serving tablet to source for
-// the vreplication stream. If the source is marked as external, it
-// returns nil.
+// the vreplication stream. If the source is marked as external, or is
+// streamed through a vtgate, it returns nil.
 func (ct *controller) pickSourceTablet(ctx context.Context, dbClient binlogplayer.DBClient) (*topodatapb.Tablet, error) {
-	if ct.source.GetExternalMysql() != "" {
+	if ct.source.GetExternalMysql() != "" || ct.vtgateConnector != nil {
 		return nil, nil
 	}
 	log.Infof("Trying to find an eligible source tablet for vreplication stream id %d for workflow: %s",
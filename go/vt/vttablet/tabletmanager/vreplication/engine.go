@@ -38,6 +38,7 @@ import (
 	"vitess.io/vitess/go/vt/mysqlctl"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -105,6 +106,10 @@ type Engine struct {
 	ec        *externalConnector
 
 	throttlerClient *throttle.Client
+	// highPriorityThrottledAt is the last time, in unix nanoseconds, that a
+	// high priority workflow was throttled. Normal priority workflows yield
+	// for a while after that.
+	highPriorityThrottledAt atomic.Int64
 
 	// This should only be set in Test Engines in order to short
 	// curcuit functions as needed in unit tests. It's automatically
@@ -737,6 +742,18 @@ func (vre *Engine) transitionJournal(je *journalEvent) {
 	log.Infof("Completed transition for journal:workload %v", je)
 }
 
+// StreamMetrics returns the metrics of the stream, or nil if the stream
+// is not running.
+func (vre *Engine) StreamMetrics(id int32) *tabletmanagerdatapb.VReplicationMetrics {
+	vre.mu.Lock()
+	defer vre.mu.Unlock()
+	ct, ok := vre.controllers[id]
+	if !ok {
+		return nil
+	}
+	return streamMetrics(ct.blpStats)
+}
+
 // WaitForPos waits for the replication to reach the specified position.
 func (vre *Engine) WaitForPos(ctx context.Context, id int32, pos string) error {
 	start := time.Now()
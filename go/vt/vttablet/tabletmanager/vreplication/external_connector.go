@@ -54,7 +54,9 @@ type VStreamerClient interface {
 	VStreamRows(ctx context.Context, query string, lastpk *querypb.QueryResult, send func(*binlogdatapb.VStreamRowsResponse) error) error
 
 	// VStreamTables streams rows of a table from the specified starting point.
-	VStreamTables(ctx context.Context, send func(*binlogdatapb.VStreamTablesResponse) error) error
+	// When a filter is specified only its tables are streamed, up to parallelism of
+	// them concurrently.
+	VStreamTables(ctx context.Context, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, parallelism int, send func(*binlogdatapb.VStreamTablesResponse) error) error
 }
 
 type externalConnector struct {
@@ -145,8 +147,8 @@ func (c *mysqlConnector) VStreamRows(ctx context.Context, query string, lastpk *
 	return c.vstreamer.StreamRows(ctx, query, row, send)
 }
 
-func (c *mysqlConnector) VStreamTables(ctx context.Context, send func(response *binlogdatapb.VStreamTablesResponse) error) error {
-	return c.vstreamer.StreamTables(ctx, send)
+func (c *mysqlConnector) VStreamTables(ctx context.Context, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, parallelism int, send func(response *binlogdatapb.VStreamTablesResponse) error) error {
+	return c.vstreamer.StreamTables(ctx, tablePKs, filter, parallelism, send)
 }
 
 //-----------------------------------------------------------
@@ -188,7 +190,12 @@ func (tc *tabletConnector) VStreamRows(ctx context.Context, query string, lastpk
 	return tc.qs.VStreamRows(ctx, req, send)
 }
 
-func (tc *tabletConnector) VStreamTables(ctx context.Context, send func(*binlogdatapb.VStreamTablesResponse) error) error {
-	req := &binlogdatapb.VStreamTablesRequest{Target: tc.target}
+func (tc *tabletConnector) VStreamTables(ctx context.Context, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, parallelism int, send func(*binlogdatapb.VStreamTablesResponse) error) error {
+	req := &binlogdatapb.VStreamTablesRequest{
+		Target:       tc.target,
+		TableLastPKs: tablePKs,
+		Filter:       filter,
+		Parallelism:  int64(parallelism),
+	}
 	return tc.qs.VStreamTables(ctx, req, send)
 }
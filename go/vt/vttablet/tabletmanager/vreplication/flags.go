@@ -37,8 +37,9 @@ var (
 
 	vreplicationHeartbeatUpdateInterval = 1
 
-	vreplicationStoreCompressedGTID   = false
-	vreplicationParallelInsertWorkers = 1
+	vreplicationStoreCompressedGTID      = false
+	vreplicationParallelInsertWorkers    = 1
+	vreplicationParallelTableCopyWorkers = 1
 )
 
 func registerVReplicationFlags(fs *pflag.FlagSet) {
@@ -68,6 +69,7 @@ func registerVReplicationFlags(fs *pflag.FlagSet) {
 	fs.Duration("vreplication_healthcheck_timeout", 1*time.Minute, "healthcheck retry delay")
 
 	fs.IntVar(&vreplicationParallelInsertWorkers, "vreplication-parallel-insert-workers", vreplicationParallelInsertWorkers, "Number of parallel insertion workers to use during copy phase. Set <= 1 to disable parallelism, or > 1 to enable concurrent insertion during copy phase.")
+	fs.IntVar(&vreplicationParallelTableCopyWorkers, "vreplication-parallel-table-copy-workers", vreplicationParallelTableCopyWorkers, "Number of tables to copy in parallel, from a single snapshot of the source, during copy phase. Set <= 1 to copy one table at a time, or > 1 to enable concurrent copying of tables during copy phase.")
 }
 
 func init() {
@@ -340,6 +340,11 @@ func (ftc *fakeTabletConn) VStreamRows(ctx context.Context, request *binlogdatap
 	})
 }
 
+// VStreamTables directly calls into the pre-initialized engine.
+func (ftc *fakeTabletConn) VStreamTables(ctx context.Context, request *binlogdatapb.VStreamTablesRequest, send func(*binlogdatapb.VStreamTablesResponse) error) error {
+	return streamerEngine.StreamTables(ctx, request.TableLastPKs, request.Filter, int(request.Parallelism), send)
+}
+
 //--------------------------------------
 // Binlog Client to TabletManager
 
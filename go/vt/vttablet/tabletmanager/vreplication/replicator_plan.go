@@ -192,9 +192,13 @@ type TablePlan struct {
 	// If the plan is an insertIgnore type, then Insert
 	// and Update contain 'insert ignore' statements and
 	// Delete is nil.
-	Insert           *sqlparser.ParsedQuery
-	Update           *sqlparser.ParsedQuery
-	Delete           *sqlparser.ParsedQuery
+	Insert *sqlparser.ParsedQuery
+	Update *sqlparser.ParsedQuery
+	Delete *sqlparser.ParsedQuery
+	// ConflictCheck is only set when the stream detects conflicts.
+	// It selects the target row if it matches the before image of
+	// a row change.
+	ConflictCheck    *sqlparser.ParsedQuery
 	Fields           []*querypb.Field
 	EnumValuesMap    map[string](map[string]string)
 	ConvertIntToEnum map[string]bool
@@ -220,25 +224,27 @@ type TablePlan struct {
 // MarshalJSON performs a custom JSON Marshalling.
 func (tp *TablePlan) MarshalJSON() ([]byte, error) {
 	v := struct {
-		TargetName   string
-		SendRule     string
-		InsertFront  *sqlparser.ParsedQuery `json:",omitempty"`
-		InsertValues *sqlparser.ParsedQuery `json:",omitempty"`
-		InsertOnDup  *sqlparser.ParsedQuery `json:",omitempty"`
-		Insert       *sqlparser.ParsedQuery `json:",omitempty"`
-		Update       *sqlparser.ParsedQuery `json:",omitempty"`
-		Delete       *sqlparser.ParsedQuery `json:",omitempty"`
-		PKReferences []string               `json:",omitempty"`
+		TargetName    string
+		SendRule      string
+		InsertFront   *sqlparser.ParsedQuery `json:",omitempty"`
+		InsertValues  *sqlparser.ParsedQuery `json:",omitempty"`
+		InsertOnDup   *sqlparser.ParsedQuery `json:",omitempty"`
+		Insert        *sqlparser.ParsedQuery `json:",omitempty"`
+		Update        *sqlparser.ParsedQuery `json:",omitempty"`
+		Delete        *sqlparser.ParsedQuery `json:",omitempty"`
+		ConflictCheck *sqlparser.ParsedQuery `json:",omitempty"`
+		PKReferences  []string               `json:",omitempty"`
 	}{
-		TargetName:   tp.TargetName,
-		SendRule:     tp.SendRule.Match,
-		InsertFront:  tp.BulkInsertFront,
-		InsertValues: tp.BulkInsertValues,
-		InsertOnDup:  tp.BulkInsertOnDup,
-		Insert:       tp.Insert,
-		Update:       tp.Update,
-		Delete:       tp.Delete,
-		PKReferences: tp.PKReferences,
+		TargetName:    tp.TargetName,
+		SendRule:      tp.SendRule.Match,
+		InsertFront:   tp.BulkInsertFront,
+		InsertValues:  tp.BulkInsertValues,
+		InsertOnDup:   tp.BulkInsertOnDup,
+		Insert:        tp.Insert,
+		Update:        tp.Update,
+		Delete:        tp.Delete,
+		ConflictCheck: tp.ConflictCheck,
+		PKReferences:  tp.PKReferences,
 	}
 	return json.Marshal(&v)
 }
@@ -361,13 +367,8 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 	bindvars := make(map[string]*querypb.BindVariable, len(tp.Fields))
 	if rowChange.Before != nil {
 		before = true
-		vals := sqltypes.MakeRowTrusted(tp.Fields, rowChange.Before)
-		for i, field := range tp.Fields {
-			bindVar, err := tp.bindFieldVal(field, &vals[i])
-			if err != nil {
-				return nil, err
-			}
-			bindvars["b_"+field.Name] = bindVar
+		if err := tp.bindBeforeValues(rowChange.Before, bindvars); err != nil {
+			return nil, err
 		}
 	}
 	if rowChange.After != nil {
@@ -444,6 +445,37 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 	return nil, nil
 }
 
+// hasConflict returns true if the target row of an update or a delete does not
+// match the before image of the row change, which means that the row was
+// changed outside of the stream. It returns false if the stream does not
+// detect conflicts, or if the row change does not hold a full before image.
+func (tp *TablePlan) hasConflict(rowChange *binlogdatapb.RowChange, executor func(string) (*sqltypes.Result, error)) (bool, error) {
+	if tp.ConflictCheck == nil || rowChange.Before == nil || tp.isPartial(rowChange) {
+		return false, nil
+	}
+	bindvars := make(map[string]*querypb.BindVariable, len(tp.Fields))
+	if err := tp.bindBeforeValues(rowChange.Before, bindvars); err != nil {
+		return false, err
+	}
+	qr, err := execParsedQuery(tp.ConflictCheck, bindvars, executor)
+	if err != nil {
+		return false, err
+	}
+	return len(qr.Rows) == 0, nil
+}
+
+func (tp *TablePlan) bindBeforeValues(row *querypb.Row, bindvars map[string]*querypb.BindVariable) error {
+	vals := sqltypes.MakeRowTrusted(tp.Fields, row)
+	for i, field := range tp.Fields {
+		bindVar, err := tp.bindFieldVal(field, &vals[i])
+		if err != nil {
+			return err
+		}
+		bindvars["b_"+field.Name] = bindVar
+	}
+	return nil
+}
+
 func getQuery(pq *sqlparser.ParsedQuery, bindvars map[string]*querypb.BindVariable) (string, error) {
 	sql, err := pq.GenerateQuery(bindvars, nil)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -90,6 +91,44 @@ func TestBuildPlayerPlan(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		// '*' with column transformations
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select *, sha2(c2, 256) as c2 from t1",
+			}},
+		},
+		plan: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: "select *, sha2(c2, 256) as c2 from t1",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t1": {
+					TargetName: "t1",
+					SendRule:   "t1",
+				},
+			},
+		},
+		planpk: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: "select *, sha2(c2, 256) as c2 from t1",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t1": {
+					TargetName: "t1",
+					SendRule:   "t1",
+				},
+			},
+		},
 	}, {
 		// Regular with keyrange
 		input: &binlogdatapb.Filter{
@@ -654,14 +693,75 @@ func TestBuildPlayerPlan(t *testing.T) {
 		},
 		err: "expression needs an alias: hour(c1)",
 	}, {
-		// only count(*)
+		// count of a column
 		input: &binlogdatapb.Filter{
 			Rules: []*binlogdatapb.Rule{{
 				Match:  "t1",
-				Filter: "select count(c1) as c from t1",
+				Filter: "select c1, count(c2) as c2 from t1 group by c1",
 			}},
 		},
-		err: "only count(*) is supported: count(c1)",
+		plan: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: "select c1, c2 from t1",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t1": {
+					TargetName:   "t1",
+					SendRule:     "t1",
+					PKReferences: []string{"c1"},
+					InsertFront:  "insert into t1(c1,c2)",
+					InsertValues: "(:a_c1,if(:a_c2 is null, 0, 1))",
+					InsertOnDup:  "on duplicate key update c2=c2+ifnull(values(c2), 0)",
+					Insert:       "insert into t1(c1,c2) values (:a_c1,if(:a_c2 is null, 0, 1)) on duplicate key update c2=c2+ifnull(values(c2), 0)",
+					Update:       "update t1 set c2=c2-if(:b_c2 is null, 0, 1)+if(:a_c2 is null, 0, 1) where c1=:b_c1",
+					Delete:       "update t1 set c2=c2-if(:b_c2 is null, 0, 1) where c1=:b_c1",
+				},
+			},
+		},
+		planpk: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: "select c1, c2, pk1, pk2 from t1",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t1": {
+					TargetName:   "t1",
+					SendRule:     "t1",
+					PKReferences: []string{"c1", "pk1", "pk2"},
+					InsertFront:  "insert into t1(c1,c2)",
+					InsertValues: "(:a_c1,if(:a_c2 is null, 0, 1))",
+					InsertOnDup:  "on duplicate key update c2=c2+ifnull(values(c2), 0)",
+					Insert:       "insert into t1(c1,c2) select :a_c1, if(:a_c2 is null, 0, 1) from dual where (:a_pk1,:a_pk2) <= (1,'aaa') on duplicate key update c2=c2+ifnull(values(c2), 0)",
+					Update:       "update t1 set c2=c2-if(:b_c2 is null, 0, 1)+if(:a_c2 is null, 0, 1) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+					Delete:       "update t1 set c2=c2-if(:b_c2 is null, 0, 1) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+				},
+			},
+		},
+	}, {
+		// no min or max
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, min(c2) as c2 from t1 group by c1",
+			}},
+		},
+		err: "min and max aggregates are not supported, as they cannot be maintained when source rows are updated or deleted: min(c2)",
+	}, {
+		// no complex expr in count
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select count(a + b) as c from t1",
+			}},
+		},
+		err: "unexpected: count(a + b)",
 	}, {
 		// no sum(*)
 		input: &binlogdatapb.Filter{
@@ -821,3 +921,61 @@ func TestBuildPlayerPlanExclude(t *testing.T) {
 	wantPlan, _ := json.Marshal(want)
 	assert.Equal(t, string(gotPlan), string(wantPlan))
 }
+
+func TestBuildPlayerPlanConflictCheck(t *testing.T) {
+	colInfos := map[string][]*ColumnInfo{
+		"t1": {{Name: "c1", IsPK: true}, {Name: "c2"}, {Name: "c3"}, {Name: "c4"}},
+	}
+	source := &binlogdatapb.BinlogSource{
+		Filter: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select * from t1",
+			}},
+		},
+		DetectConflicts: true,
+	}
+	plan, err := buildReplicatorPlan(source, colInfos, nil, binlogplayer.NewStats())
+	require.NoError(t, err)
+	fields := sqltypes.MakeTestFields("c1|c2|c3|c4", "int64|varchar|json|float64")
+	tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{TableName: "t1", Fields: fields})
+	require.NoError(t, err)
+	// JSON and floating point columns are not compared.
+	assert.Equal(t, "select 1 from t1 where c1=:b_c1 and c2 <=> :b_c2", tp.ConflictCheck.Query)
+
+	row := sqltypes.RowToProto3(sqltypes.MakeTestResult(fields, `1|a|{}|1.5`).Rows[0])
+	var queries []string
+	result := &sqltypes.Result{}
+	executor := func(query string) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		return result, nil
+	}
+
+	// Inserts are not checked.
+	conflict, err := tp.hasConflict(&binlogdatapb.RowChange{After: row}, executor)
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Empty(t, queries)
+
+	// The target row does not match the before image.
+	conflict, err = tp.hasConflict(&binlogdatapb.RowChange{Before: row, After: row}, executor)
+	require.NoError(t, err)
+	assert.True(t, conflict)
+	assert.Equal(t, []string{"select 1 from t1 where c1=1 and c2 <=> 'a'"}, queries)
+
+	result = sqltypes.MakeTestResult(sqltypes.MakeTestFields("1", "int64"), "1")
+	conflict, err = tp.hasConflict(&binlogdatapb.RowChange{Before: row}, executor)
+	require.NoError(t, err)
+	assert.False(t, conflict)
+
+	// Streams that do not detect conflicts have no conflict check.
+	source.DetectConflicts = false
+	plan, err = buildReplicatorPlan(source, colInfos, nil, binlogplayer.NewStats())
+	require.NoError(t, err)
+	tp, err = plan.buildExecutionPlan(&binlogdatapb.FieldEvent{TableName: "t1", Fields: fields})
+	require.NoError(t, err)
+	assert.Nil(t, tp.ConflictCheck)
+	conflict, err = tp.hasConflict(&binlogdatapb.RowChange{Before: row, After: row}, executor)
+	require.NoError(t, err)
+	assert.False(t, conflict)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const day = 24 * time.Hour
+
+// scheduleWindow is a window of the schedule of a workflow. The start and
+// end of the window are offsets from midnight.
+type scheduleWindow struct {
+	start, end        time.Duration
+	maxBytesPerSecond int64
+}
+
+// workflowSchedule enforces the schedule of a workflow: the copy phase only
+// runs within the copy windows, and the rows are copied and replicated no
+// faster than the limit of the current bandwidth window. A nil schedule
+// never pauses the workflow.
+type workflowSchedule struct {
+	copyWindows      []scheduleWindow
+	bandwidthWindows []scheduleWindow
+	location         *time.Location
+
+	mu sync.Mutex
+	// next is the time at which the bytes streamed so far are within the
+	// bandwidth limit.
+	next time.Time
+}
+
+func newWorkflowSchedule(schedule *binlogdatapb.VReplicationSchedule) (*workflowSchedule, error) {
+	if len(schedule.GetCopyWindows()) == 0 && len(schedule.GetBandwidthWindows()) == 0 {
+		return nil, nil
+	}
+	ws := &workflowSchedule{location: time.UTC}
+	if tz := schedule.GetTimeZone(); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid time zone %q in the workflow schedule", tz)
+		}
+		ws.location = location
+	}
+	for _, window := range schedule.GetCopyWindows() {
+		w, err := parseScheduleWindow(window)
+		if err != nil {
+			return nil, err
+		}
+		ws.copyWindows = append(ws.copyWindows, w)
+	}
+	for _, window := range schedule.GetBandwidthWindows() {
+		w, err := parseScheduleWindow(window)
+		if err != nil {
+			return nil, err
+		}
+		if w.maxBytesPerSecond <= 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "bandwidth window %s-%s of the workflow schedule has no bandwidth limit", window.Start, window.End)
+		}
+		ws.bandwidthWindows = append(ws.bandwidthWindows, w)
+	}
+	return ws, nil
+}
+
+func parseScheduleWindow(window *binlogdatapb.VReplicationScheduleWindow) (scheduleWindow, error) {
+	parse := func(s string) (time.Duration, error) {
+		t, err := time.Parse("15:04", s)
+		if err != nil {
+			return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid time %q in the workflow schedule, expected HH:MM", s)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	start, err := parse(window.Start)
+	if err != nil {
+		return scheduleWindow{}, err
+	}
+	end, err := parse(window.End)
+	if err != nil {
+		return scheduleWindow{}, err
+	}
+	return scheduleWindow{start: start, end: end, maxBytesPerSecond: window.MaxBytesPerSecond}, nil
+}
+
+// occurrences returns the start and end times of the occurrences of the
+// window that started on the day before now, on the day of now and on the
+// day after now.
+func (w scheduleWindow) occurrences(now time.Time) [][2]time.Time {
+	length := (w.end - w.start + day) % day
+	if length == 0 {
+		length = day
+	}
+	y, m, d := now.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	var occurrences [][2]time.Time
+	for _, shift := range []int{-1, 0, 1} {
+		start := midnight.AddDate(0, 0, shift).Add(w.start)
+		occurrences = append(occurrences, [2]time.Time{start, start.Add(length)})
+	}
+	return occurrences
+}
+
+// copyWindow returns whether the copy phase can run at the given time and
+// the time at which that changes: the end of the current copy window when
+// the copy phase can run, and the start of the next one when it cannot.
+// That time is zero when the workflow has no copy windows.
+func (ws *workflowSchedule) copyWindow(now time.Time) (bool, time.Time) {
+	if ws == nil || len(ws.copyWindows) == 0 {
+		return true, time.Time{}
+	}
+	now = now.In(ws.location)
+	var closesAt, opensAt time.Time
+	for _, w := range ws.copyWindows {
+		for _, occurrence := range w.occurrences(now) {
+			start, end := occurrence[0], occurrence[1]
+			switch {
+			case !now.Before(start) && now.Before(end):
+				if end.After(closesAt) {
+					closesAt = end
+				}
+			case start.After(now):
+				if opensAt.IsZero() || start.Before(opensAt) {
+					opensAt = start
+				}
+			}
+		}
+	}
+	if !closesAt.IsZero() {
+		return true, closesAt
+	}
+	return false, opensAt
+}
+
+// maxBytesPerSecond returns the bandwidth limit at the given time, which is
+// the lowest limit of the bandwidth windows that contain it. It returns 0
+// when the bandwidth is not limited.
+func (ws *workflowSchedule) maxBytesPerSecond(now time.Time) int64 {
+	if ws == nil {
+		return 0
+	}
+	now = now.In(ws.location)
+	var limit int64
+	for _, w := range ws.bandwidthWindows {
+		for _, occurrence := range w.occurrences(now) {
+			if now.Before(occurrence[0]) || !now.Before(occurrence[1]) {
+				continue
+			}
+			if limit == 0 || w.maxBytesPerSecond < limit {
+				limit = w.maxBytesPerSecond
+			}
+		}
+	}
+	return limit
+}
+
+// waitForBandwidth accounts for the given number of bytes and, when the
+// bandwidth is limited, sleeps until they are within the limit.
+func (ws *workflowSchedule) waitForBandwidth(ctx context.Context, bytes int64) error {
+	now := time.Now()
+	limit := ws.maxBytesPerSecond(now)
+	if limit == 0 || bytes <= 0 {
+		return nil
+	}
+	ws.mu.Lock()
+	if ws.next.Before(now) {
+		ws.next = now
+	}
+	ws.next = ws.next.Add(time.Duration(float64(bytes) / float64(limit) * float64(time.Second)))
+	wait := ws.next.Sub(now)
+	ws.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// copyPhaseDuration returns how long a copy cycle can run for before it is
+// interrupted to catch up: the configured copy phase duration, cut short at
+// the end of the current copy window.
+func (ws *workflowSchedule) copyPhaseDuration(now time.Time, copyPhaseDuration time.Duration) time.Duration {
+	open, closesAt := ws.copyWindow(now)
+	if !open || closesAt.IsZero() {
+		return copyPhaseDuration
+	}
+	if untilClose := closesAt.Sub(now); untilClose < copyPhaseDuration {
+		return untilClose
+	}
+	return copyPhaseDuration
+}
+
+func rowsSize(rows []*querypb.Row) int64 {
+	var size int64
+	for _, row := range rows {
+		size += int64(len(row.Values))
+	}
+	return size
+}
+
+// itemsSize returns the size of the rows of the events fetched from the
+// relay log.
+func itemsSize(items [][]*binlogdatapb.VEvent) int64 {
+	var size int64
+	for _, events := range items {
+		size += int64(eventsSize(events))
+	}
+	return size
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+func TestNewWorkflowSchedule(t *testing.T) {
+	ws, err := newWorkflowSchedule(nil)
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+
+	testCases := []struct {
+		name     string
+		schedule *binlogdatapb.VReplicationSchedule
+		wantErr  string
+	}{{
+		name: "invalid time",
+		schedule: &binlogdatapb.VReplicationSchedule{
+			CopyWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "22:00", End: "25:00"}},
+		},
+		wantErr: `invalid time "25:00" in the workflow schedule`,
+	}, {
+		name: "invalid time zone",
+		schedule: &binlogdatapb.VReplicationSchedule{
+			CopyWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "22:00", End: "06:00"}},
+			TimeZone:    "Nowhere/Special",
+		},
+		wantErr: `invalid time zone "Nowhere/Special"`,
+	}, {
+		name: "bandwidth window without a limit",
+		schedule: &binlogdatapb.VReplicationSchedule{
+			BandwidthWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "09:00", End: "17:00"}},
+		},
+		wantErr: "bandwidth window 09:00-17:00 of the workflow schedule has no bandwidth limit",
+	}, {
+		name: "valid",
+		schedule: &binlogdatapb.VReplicationSchedule{
+			CopyWindows:      []*binlogdatapb.VReplicationScheduleWindow{{Start: "22:00", End: "06:00"}},
+			BandwidthWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "09:00", End: "17:00", MaxBytesPerSecond: 1024}},
+			TimeZone:         "America/New_York",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ws, err := newWorkflowSchedule(tc.schedule)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, ws)
+		})
+	}
+}
+
+func TestWorkflowScheduleCopyWindow(t *testing.T) {
+	ws, err := newWorkflowSchedule(&binlogdatapb.VReplicationSchedule{
+		CopyWindows: []*binlogdatapb.VReplicationScheduleWindow{
+			{Start: "22:00", End: "06:00"},
+			{Start: "12:00", End: "13:00"},
+		},
+	})
+	require.NoError(t, err)
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2023, 5, 10, hour, minute, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		now        time.Time
+		wantOpen   bool
+		wantChange time.Time
+	}{
+		{now: at(23, 0), wantOpen: true, wantChange: at(30, 0)},
+		{now: at(2, 0), wantOpen: true, wantChange: at(6, 0)},
+		{now: at(6, 0), wantOpen: false, wantChange: at(12, 0)},
+		{now: at(12, 30), wantOpen: true, wantChange: at(13, 0)},
+		{now: at(15, 0), wantOpen: false, wantChange: at(22, 0)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.now.Format(time.Kitchen), func(t *testing.T) {
+			open, change := ws.copyWindow(tc.now)
+			assert.Equal(t, tc.wantOpen, open)
+			assert.True(t, tc.wantChange.Equal(change), "got %v, want %v", change, tc.wantChange)
+		})
+	}
+
+	open, change := (*workflowSchedule)(nil).copyWindow(at(15, 0))
+	assert.True(t, open)
+	assert.True(t, change.IsZero())
+
+	assert.Equal(t, 10*time.Minute, ws.copyPhaseDuration(at(5, 50), time.Hour))
+	assert.Equal(t, time.Hour, ws.copyPhaseDuration(at(23, 0), time.Hour))
+	assert.Equal(t, time.Hour, ws.copyPhaseDuration(at(15, 0), time.Hour))
+}
+
+func TestWorkflowScheduleTimeZone(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	ws, err := newWorkflowSchedule(&binlogdatapb.VReplicationSchedule{
+		CopyWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "22:00", End: "06:00"}},
+		TimeZone:    "Asia/Tokyo",
+	})
+	require.NoError(t, err)
+
+	// 14:00 UTC is 23:00 in Tokyo.
+	open, closesAt := ws.copyWindow(time.Date(2023, 5, 10, 14, 0, 0, 0, time.UTC))
+	assert.True(t, open)
+	assert.True(t, time.Date(2023, 5, 11, 6, 0, 0, 0, location).Equal(closesAt))
+}
+
+func TestWorkflowScheduleBandwidth(t *testing.T) {
+	ws, err := newWorkflowSchedule(&binlogdatapb.VReplicationSchedule{
+		BandwidthWindows: []*binlogdatapb.VReplicationScheduleWindow{
+			{Start: "09:00", End: "17:00", MaxBytesPerSecond: 1000},
+			{Start: "12:00", End: "13:00", MaxBytesPerSecond: 100},
+		},
+	})
+	require.NoError(t, err)
+
+	at := func(hour int) time.Time {
+		return time.Date(2023, 5, 10, hour, 0, 0, 0, time.UTC)
+	}
+	assert.EqualValues(t, 0, ws.maxBytesPerSecond(at(8)))
+	assert.EqualValues(t, 1000, ws.maxBytesPerSecond(at(9)))
+	assert.EqualValues(t, 100, ws.maxBytesPerSecond(at(12)))
+	assert.EqualValues(t, 0, ws.maxBytesPerSecond(at(17)))
+	assert.EqualValues(t, 0, (*workflowSchedule)(nil).maxBytesPerSecond(at(12)))
+	require.NoError(t, (*workflowSchedule)(nil).waitForBandwidth(context.Background(), 1000))
+
+	ws, err = newWorkflowSchedule(&binlogdatapb.VReplicationSchedule{
+		BandwidthWindows: []*binlogdatapb.VReplicationScheduleWindow{{Start: "00:00", End: "00:00", MaxBytesPerSecond: 1000}},
+	})
+	require.NoError(t, err)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, ws.waitForBandwidth(context.Background(), 50))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, ws.waitForBandwidth(ctx, 1000), context.Canceled)
+}
@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/servenv"
 
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
@@ -403,6 +404,21 @@ func (st *vrStats) register() {
 			}
 			return result
 		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationConflictCounts",
+		"vreplication row changes applied to target rows that were changed outside of the stream, per table per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts", "table"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for table, count := range ct.blpStats.ConflictCounts.Counts() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+fmt.Sprintf("%v", ct.id)+"."+table] = count
+				}
+			}
+			return result
+		})
 	stats.NewCountersFuncWithMultiLabels(
 		"VReplicationPartialQueryCount",
 		"count of partial queries per stream",
@@ -434,6 +450,91 @@ func (st *vrStats) register() {
 			return result
 		})
 
+	stats.NewHistogramsFuncWithMultiLabels(
+		"VReplicationWorkflowLagSeconds",
+		"vreplication seconds behind primary distribution per workflow",
+		[]string{"workflow"},
+		binlogplayer.LagHistogramCutoffs,
+		func() map[string]*stats.Histogram {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]*stats.Histogram)
+			for _, ct := range st.controllers {
+				h, ok := result[ct.workflow]
+				if !ok {
+					h = stats.NewHistogram("", "", binlogplayer.LagHistogramCutoffs)
+					result[ct.workflow] = h
+				}
+				h.Merge(ct.blpStats.LagHistogram)
+			}
+			return result
+		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationWorkflowEventCount",
+		"vreplication events received from the source per type per workflow",
+		[]string{"workflow", "type"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64)
+			for _, ct := range st.controllers {
+				for typ, count := range ct.blpStats.EventCounts.Counts() {
+					result[ct.workflow+"."+typ] += count
+				}
+			}
+			return result
+		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationWorkflowCopyRowCount",
+		"vreplication rows copied in copy phase per workflow",
+		[]string{"workflow"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64)
+			for _, ct := range st.controllers {
+				result[ct.workflow] += ct.blpStats.CopyRowCount.Get()
+			}
+			return result
+		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationWorkflowErrors",
+		"Errors during vreplication per workflow",
+		[]string{"workflow", "type"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64)
+			for _, ct := range st.controllers {
+				for key, val := range ct.blpStats.ErrorCounts.Counts() {
+					result[ct.workflow+"."+key] += val
+				}
+			}
+			return result
+		})
+}
+
+// streamMetrics returns the metrics of a stream from its stats.
+func streamMetrics(bps *binlogplayer.Stats) *tabletmanagerdatapb.VReplicationMetrics {
+	metrics := &tabletmanagerdatapb.VReplicationMetrics{
+		LagHistogram: &tabletmanagerdatapb.VReplicationMetrics_Histogram{
+			Cutoffs: bps.LagHistogram.Cutoffs(),
+			Counts:  bps.LagHistogram.Buckets(),
+			Total:   bps.LagHistogram.Total(),
+		},
+		EventCounts:  bps.EventCounts.Counts(),
+		EventRates:   make(map[string]float64),
+		CopyRowCount: bps.CopyRowCount.Get(),
+		CopyRowRate:  bps.CopyRowRates.TotalRate(),
+		ErrorCounts:  bps.ErrorCounts.Counts(),
+	}
+	for typ, rates := range bps.EventRates.Get() {
+		if typ == "All" || len(rates) == 0 {
+			continue
+		}
+		metrics.EventRates[typ] = rates[len(rates)-1]
+	}
+	return metrics
 }
 
 func (st *vrStats) numControllers() int64 {
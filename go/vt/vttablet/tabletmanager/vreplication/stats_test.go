@@ -178,3 +178,23 @@ func TestVReplicationStats(t *testing.T) {
 	blpStats.RecordHeartbeat(tm)
 	require.Equal(t, tm, blpStats.Heartbeat())
 }
+
+func TestStreamMetrics(t *testing.T) {
+	blpStats := binlogplayer.NewStats()
+	defer blpStats.Stop()
+
+	blpStats.LagHistogram.Add(0)
+	blpStats.LagHistogram.Add(45)
+	blpStats.EventCounts.Add("ROW", 3)
+	blpStats.EventCounts.Add("COMMIT", 1)
+	blpStats.CopyRowCount.Add(200)
+	blpStats.ErrorCounts.Add([]string{"Apply"}, 2)
+
+	metrics := streamMetrics(blpStats)
+	require.Equal(t, binlogplayer.LagHistogramCutoffs, metrics.LagHistogram.Cutoffs)
+	require.Equal(t, []int64{1, 0, 0, 0, 1, 0, 0, 0, 0, 0}, metrics.LagHistogram.Counts)
+	require.Equal(t, int64(45), metrics.LagHistogram.Total)
+	require.Equal(t, map[string]int64{"ROW": 3, "COMMIT": 1}, metrics.EventCounts)
+	require.Equal(t, int64(200), metrics.CopyRowCount)
+	require.Equal(t, map[string]int64{"Apply": 2}, metrics.ErrorCounts)
+}
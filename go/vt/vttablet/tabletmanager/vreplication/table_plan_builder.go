@@ -69,6 +69,7 @@ type colExpr struct {
 	// operation==opExpr: full expression is set
 	// operation==opCount: nothing is set.
 	// operation==opSum: for 'sum(a)', expr is set to 'a'.
+	// operation==opCountCol: for 'count(a)', expr is set to 'a'.
 	operation operation
 	// expr stores the expected field name from vstreamer and dictates
 	// the generated bindvar names, like a_col or b_col.
@@ -90,6 +91,7 @@ const (
 	opExpr = operation(iota)
 	opCount
 	opSum
+	opCountCol
 )
 
 // insertType describes the type of insert statement to generate.
@@ -229,12 +231,18 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 	if expr, ok := sel.SelectExprs[0].(*sqlparser.StarExpr); ok {
 		// If it's a "select *", we return a partial plan, and complete
 		// it when we get back field info from the stream.
-		if len(sel.SelectExprs) != 1 {
-			return nil, fmt.Errorf("unexpected: %v", sqlparser.String(sel))
-		}
 		if !expr.TableName.IsEmpty() {
 			return nil, fmt.Errorf("unsupported qualifier for '*' expression: %v", sqlparser.String(expr))
 		}
+		// The expressions that follow the '*' are column transformations,
+		// like "select *, sha2(email, 256) as email from t". They are
+		// evaluated by the source, so the stream carries the transformed
+		// values of the columns.
+		for _, selExpr := range sel.SelectExprs[1:] {
+			if aliased, ok := selExpr.(*sqlparser.AliasedExpr); !ok || aliased.As.IsEmpty() {
+				return nil, fmt.Errorf("unexpected: %v", sqlparser.String(sel))
+			}
+		}
 		sendRule.Filter = query
 		tablePlan := &TablePlan{
 			TargetName:       tableName,
@@ -361,6 +369,7 @@ func (tpb *tablePlanBuilder) generate() *TablePlan {
 		Insert:                  tpb.generateInsertStatement(),
 		Update:                  tpb.generateUpdateStatement(),
 		Delete:                  tpb.generateDeleteStatement(),
+		ConflictCheck:           tpb.generateConflictCheckStatement(),
 		PKReferences:            pkrefs,
 		PKIndices:               tpb.pkIndices,
 		Stats:                   tpb.stats,
@@ -474,13 +483,15 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 			return nil, fmt.Errorf("unexpected: %v", sqlparser.String(expr))
 		}
 		switch fname := expr.AggrName(); fname {
-		case "count":
-			if _, ok := expr.(*sqlparser.CountStar); !ok {
-				return nil, fmt.Errorf("only count(*) is supported: %v", sqlparser.String(expr))
+		case "min", "max":
+			// The minimum or maximum of a group cannot be recomputed on the target
+			// when the source row holding it is updated or deleted.
+			return nil, fmt.Errorf("min and max aggregates are not supported, as they cannot be maintained when source rows are updated or deleted: %v", sqlparser.String(expr))
+		case "count", "sum":
+			if _, ok := expr.(*sqlparser.CountStar); ok {
+				cexpr.operation = opCount
+				return cexpr, nil
 			}
-			cexpr.operation = opCount
-			return cexpr, nil
-		case "sum":
 			if len(expr.GetArgs()) != 1 {
 				return nil, fmt.Errorf("unexpected: %v", sqlparser.String(expr))
 			}
@@ -491,7 +502,12 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 			if !innerCol.Qualifier.IsEmpty() {
 				return nil, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(innerCol))
 			}
-			cexpr.operation = opSum
+			switch fname {
+			case "count":
+				cexpr.operation = opCountCol
+			case "sum":
+				cexpr.operation = opSum
+			}
 			cexpr.expr = innerCol
 			tpb.addCol(innerCol.Name)
 			cexpr.references[innerCol.Name.String()] = true
@@ -720,6 +736,9 @@ func (tpb *tablePlanBuilder) generateValuesPart(buf *sqlparser.TrackedBuffer, bv
 		case opSum:
 			// NULL values must be treated as 0 for SUM.
 			buf.Myprintf("ifnull(%v, 0)", cexpr.expr)
+		case opCountCol:
+			// NULL values are not counted.
+			buf.Myprintf("if(%v is null, 0, 1)", cexpr.expr)
 		}
 	}
 	buf.Myprintf(")")
@@ -743,6 +762,8 @@ func (tpb *tablePlanBuilder) generateSelectPart(buf *sqlparser.TrackedBuffer, bv
 			buf.WriteString("1")
 		case opSum:
 			buf.Myprintf("ifnull(%v, 0)", cexpr.expr)
+		case opCountCol:
+			buf.Myprintf("if(%v is null, 0, 1)", cexpr.expr)
 		}
 	}
 	buf.WriteString(" from dual where ")
@@ -777,7 +798,7 @@ func (tpb *tablePlanBuilder) generateOnDupPart(buf *sqlparser.TrackedBuffer) *sq
 			buf.Myprintf("values(%v)", cexpr.colName)
 		case opCount:
 			buf.Myprintf("%v+1", cexpr.colName)
-		case opSum:
+		case opSum, opCountCol:
 			buf.Myprintf("%v", cexpr.colName)
 			buf.Myprintf("+ifnull(values(%v), 0)", cexpr.colName)
 		}
@@ -831,6 +852,12 @@ func (tpb *tablePlanBuilder) generateUpdateStatement() *sqlparser.ParsedQuery {
 			buf.Myprintf("-ifnull(%v, 0)", cexpr.expr)
 			bvf.mode = bvAfter
 			buf.Myprintf("+ifnull(%v, 0)", cexpr.expr)
+		case opCountCol:
+			buf.Myprintf("%v", cexpr.colName)
+			bvf.mode = bvBefore
+			buf.Myprintf("-if(%v is null, 0, 1)", cexpr.expr)
+			bvf.mode = bvAfter
+			buf.Myprintf("+if(%v is null, 0, 1)", cexpr.expr)
 		}
 	}
 	tpb.generateWhere(buf, bvf)
@@ -861,6 +888,8 @@ func (tpb *tablePlanBuilder) generateDeleteStatement() *sqlparser.ParsedQuery {
 				buf.Myprintf("%v-1", cexpr.colName)
 			case opSum:
 				buf.Myprintf("%v-ifnull(%v, 0)", cexpr.colName, cexpr.expr)
+			case opCountCol:
+				buf.Myprintf("%v-if(%v is null, 0, 1)", cexpr.colName, cexpr.expr)
 			}
 		}
 		tpb.generateWhere(buf, bvf)
@@ -870,6 +899,39 @@ func (tpb *tablePlanBuilder) generateDeleteStatement() *sqlparser.ParsedQuery {
 	return buf.ParsedQuery()
 }
 
+// generateConflictCheckStatement generates the query that checks that the
+// target row of an update or a delete still matches the before image of the
+// source row. It is only generated for streams that detect conflicts, once the
+// table has been copied. JSON and floating point columns are not compared, as
+// their values may not be equal after a round trip through the binlog.
+func (tpb *tablePlanBuilder) generateConflictCheckStatement() *sqlparser.ParsedQuery {
+	if !tpb.source.GetDetectConflicts() || tpb.onInsert != insertNormal || tpb.lastpk != nil {
+		return nil
+	}
+	bvf := &bindvarFormatter{}
+	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
+	buf.Myprintf("select 1 from %v", tpb.name)
+	tpb.generateWhere(buf, bvf)
+	for _, cexpr := range tpb.colExprs {
+		if cexpr.isPK || cexpr.operation != opExpr || tpb.isColumnGenerated(cexpr.colName) {
+			continue
+		}
+		if _, ok := cexpr.expr.(*sqlparser.ColName); !ok {
+			continue
+		}
+		switch cexpr.colType {
+		case querypb.Type_JSON, querypb.Type_FLOAT32, querypb.Type_FLOAT64:
+			continue
+		case querypb.Type_DATETIME:
+			if tpb.source.SourceTimeZone != "" && tpb.source.TargetTimeZone != "" {
+				continue
+			}
+		}
+		buf.Myprintf(" and %v <=> %v", cexpr.colName, cexpr.expr)
+	}
+	return buf.ParsedQuery()
+}
+
 func (tpb *tablePlanBuilder) generateWhere(buf *sqlparser.TrackedBuffer, bvf *bindvarFormatter) {
 	buf.WriteString(" where ")
 	bvf.mode = bvBefore
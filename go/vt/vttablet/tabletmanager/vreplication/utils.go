@@ -52,6 +52,8 @@ const (
 	LogCopyEnd = "Ended Copy Phase"
 	// LogStateChange is used when the state of the stream changes.
 	LogStateChange = "State Changed"
+	// LogConflict is used when a row change is applied to a target row that was changed outside of the stream.
+	LogConflict = "Conflict"
 
 	// TODO: LogError is not used atm. Currently irrecoverable errors, resumable errors and informational messages
 	//  are all treated the same: the message column is updated and state left as Running.
@@ -298,14 +298,12 @@ func (vc *vcopier) copyNext(ctx context.Context, settings binlogplayer.VRSetting
 	if err != nil {
 		return err
 	}
-	var tableToCopy string
+	var tablesToCopy []string
 	copyState := make(map[string]*sqltypes.Result)
 	for _, row := range qr.Rows {
 		tableName := row[0].ToString()
 		lastpk := row[1].ToString()
-		if tableToCopy == "" {
-			tableToCopy = tableName
-		}
+		tablesToCopy = append(tablesToCopy, tableName)
 		copyState[tableName] = nil
 		if lastpk != "" {
 			var r querypb.QueryResult
@@ -321,7 +319,10 @@ func (vc *vcopier) copyNext(ctx context.Context, settings binlogplayer.VRSetting
 	if err := vc.catchup(ctx, copyState); err != nil {
 		return err
 	}
-	return vc.copyTable(ctx, tableToCopy, copyState)
+	if parallelism := getTableCopyParallelism(); parallelism > 1 && len(tablesToCopy) > 1 && vc.supportsParallelTableCopy() {
+		return vc.copyTables(ctx, tablesToCopy, copyState, parallelism)
+	}
+	return vc.copyTable(ctx, tablesToCopy[0], copyState)
 }
 
 // catchup replays events to the subset of the tables that have been copied
@@ -395,7 +396,7 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 		return fmt.Errorf("plan not found for table: %s, current plans are: %#v", tableName, plan.TargetTables)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, vttablet.CopyPhaseDuration)
+	ctx, cancel := context.WithTimeout(ctx, vc.vr.schedule.copyPhaseDuration(time.Now(), vttablet.CopyPhaseDuration))
 	defer cancel()
 
 	var lastpkpb *querypb.QueryResult
@@ -467,12 +468,15 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 				return nil
 			}
 			// verify throttler is happy, otherwise keep looping
-			if vc.vr.vre.throttlerClient.ThrottleCheckOKOrWaitAppName(ctx, throttlerapp.Name(vc.throttlerAppName)) {
+			if vc.vr.throttleCheckOKOrWait(ctx, throttlerapp.Name(vc.throttlerAppName)) {
 				break // out of 'for' loop
 			} else { // we're throttled
 				_ = vc.vr.updateTimeThrottled(throttlerapp.VCopierName)
 			}
 		}
+		if err := vc.vr.schedule.waitForBandwidth(ctx, rowsSize(rows.Rows)); err != nil {
+			return io.EOF
+		}
 		if !copyWorkQueue.isOpen {
 			if len(rows.Fields) == 0 {
 				return fmt.Errorf("expecting field event first, got: %v", rows)
@@ -656,16 +660,27 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 		return nil
 	default:
 	}
+	if serr == errCopySnapshotChanged {
+		// The copy continues from a new snapshot, once caught up.
+		log.Infof("Copy of %v stopped at lastpk: %v: %v", tableName, lastpkbv, serr)
+		return nil
+	}
 	if serr != nil {
 		return serr
 	}
 
+	log.Infof("Copy of %v finished at lastpk: %v", tableName, lastpkbv)
+	return vc.completeTableCopy(ctx, tableName)
+}
+
+// completeTableCopy performs the post copy actions of a table that was fully
+// copied, and then removes the table from the copy state.
+func (vc *vcopier) completeTableCopy(ctx context.Context, tableName string) error {
 	// Perform any post copy actions
 	if err := vc.vr.execPostCopyActions(ctx, tableName); err != nil {
 		return vterrors.Wrapf(err, "failed to execute post copy actions for table %q", tableName)
 	}
 
-	log.Infof("Copy of %v finished at lastpk: %v", tableName, lastpkbv)
 	buf := sqlparser.NewTrackedBuffer(nil)
 	buf.Myprintf(
 		"delete cs, pca from _vt.%s as cs left join _vt.%s as pca on cs.vrepl_id=pca.vrepl_id and cs.table_name=pca.table_name where cs.vrepl_id=%d and cs.table_name=%s",
@@ -1210,3 +1225,9 @@ func getInsertParallelism() int {
 	parallelism := int(math.Max(1, float64(vreplicationParallelInsertWorkers)))
 	return parallelism
 }
+
+// getTableCopyParallelism returns the number of tables to copy in parallel during the copy phase.
+func getTableCopyParallelism() int {
+	parallelism := int(math.Max(1, float64(vreplicationParallelTableCopyWorkers)))
+	return parallelism
+}
@@ -79,7 +79,7 @@ func (vc *vcopier) copyAll(ctx context.Context, settings binlogplayer.VRSettings
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, vttablet.CopyPhaseDuration)
+	ctx, cancel := context.WithTimeout(ctx, vc.vr.schedule.copyPhaseDuration(time.Now(), vttablet.CopyPhaseDuration))
 	defer cancel()
 
 	rowsCopiedTicker := time.NewTicker(rowsCopiedUpdateInterval)
@@ -102,7 +102,7 @@ func (vc *vcopier) copyAll(ctx context.Context, settings binlogplayer.VRSettings
 	var prevCh <-chan *vcopierCopyTaskResult
 	var gtid string
 
-	serr := vc.vr.sourceVStreamer.VStreamTables(ctx, func(resp *binlogdatapb.VStreamTablesResponse) error {
+	serr := vc.vr.sourceVStreamer.VStreamTables(ctx, nil, nil, 0, func(resp *binlogdatapb.VStreamTablesResponse) error {
 		defer vc.vr.stats.PhaseTimings.Record("copy", time.Now())
 		defer vc.vr.stats.CopyLoopCount.Add(1)
 		log.Infof("VStreamTablesResponse: received table %s, #fields %d, #rows %d, gtid %s, lastpk %+v",
@@ -181,6 +181,9 @@ func (vc *vcopier) copyAll(ctx context.Context, settings binlogplayer.VRSettings
 		if len(resp.Rows) == 0 {
 			return nil
 		}
+		if err := vc.vr.schedule.waitForBandwidth(ctx, rowsSize(resp.Rows)); err != nil {
+			return io.EOF
+		}
 		// Get the last committed pk into a loggable form.
 		lastpkbuf, merr := prototext.Marshal(&querypb.QueryResult{
 			Fields: pkfields,
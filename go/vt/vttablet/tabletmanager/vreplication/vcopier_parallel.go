@@ -0,0 +1,327 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+/*
+This file is similar to vcopier.go: it handles a copy cycle in which several tables are copied in parallel.
+The source streams up to --vreplication-parallel-table-copy-workers tables concurrently, all of them from a
+single snapshot, so that the target only needs to be fast-forwarded once to the position of that snapshot.
+On the target each table gets its own work queue, whose tasks are sequenced in the same way as in copyTable
+so that the copy_state of every table always holds the last pk that was committed for it.
+*/
+
+// parallelCopyTable holds the copy progress of one of the tables of a
+// parallel copy cycle.
+type parallelCopyTable struct {
+	name      string
+	queue     *vcopierCopyWorkQueue
+	prevCh    <-chan *vcopierCopyTaskResult
+	lastpk    *querypb.Row
+	pkfields  []*querypb.Field
+	completed bool
+}
+
+// supportsParallelTableCopy returns true if the source can stream several
+// tables concurrently from a single snapshot.
+func (vc *vcopier) supportsParallelTableCopy() bool {
+	_, isVTGate := vc.vr.sourceVStreamer.(*vtgateConnector)
+	return !isVTGate
+}
+
+// copyTables copies the tables concurrently until they are all copied, or
+// until the copy phase duration elapses. Tables that are not fully copied by
+// then are resumed from their lastpk by the next call to copyNext.
+func (vc *vcopier) copyTables(ctx context.Context, tableNames []string, copyState map[string]*sqltypes.Result, parallelism int) error {
+	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats)
+	if err != nil {
+		return err
+	}
+	filter := &binlogdatapb.Filter{}
+	var tablePKs []*binlogdatapb.TableLastPK
+	tables := make(map[string]*parallelCopyTable, len(tableNames))
+	for _, tableName := range tableNames {
+		initialPlan, ok := plan.TargetTables[tableName]
+		if !ok {
+			return fmt.Errorf("plan not found for table: %s, current plans are: %#v", tableName, plan.TargetTables)
+		}
+		sourceName := initialPlan.SendRule.Match
+		if _, ok := tables[sourceName]; ok {
+			// The responses of the source are keyed by source table, so the
+			// target tables that are copied from the same source table have
+			// to be copied one at a time.
+			return vc.copyTable(ctx, tableNames[0], copyState)
+		}
+		tables[sourceName] = &parallelCopyTable{name: tableName}
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{
+			Match:  sourceName,
+			Filter: initialPlan.SendRule.Filter,
+		})
+		if lastpk := copyState[tableName]; lastpk != nil {
+			tablePKs = append(tablePKs, &binlogdatapb.TableLastPK{
+				TableName: sourceName,
+				Lastpk:    sqltypes.ResultToProto3(lastpk),
+			})
+		}
+	}
+
+	defer vc.vr.dbClient.Rollback()
+	defer vc.vr.stats.PhaseTimings.Record("copy", time.Now())
+	defer vc.vr.stats.CopyLoopCount.Add(1)
+
+	log.Infof("Copying %d tables with %d workers: %v", len(tableNames), parallelism, tableNames)
+
+	ctx, cancel := context.WithTimeout(ctx, vc.vr.schedule.copyPhaseDuration(time.Now(), vttablet.CopyPhaseDuration))
+	defer cancel()
+
+	rowsCopiedTicker := time.NewTicker(rowsCopiedUpdateInterval)
+	defer rowsCopiedTicker.Stop()
+	copyStateGCTicker := time.NewTicker(copyStateGCInterval)
+	defer copyStateGCTicker.Stop()
+
+	// Every table is copied by its own workers, each of which uses its own
+	// connection so that the tables are inserted concurrently.
+	insertParallelism := getInsertParallelism()
+	copyWorkerFactory := vc.newCopyWorkerFactory(parallelism)
+	defer func() {
+		for _, table := range tables {
+			if table.queue != nil {
+				table.queue.close()
+			}
+		}
+	}()
+
+	// Task errors and throttling are reported by the workers, and handled
+	// while processing the responses of the source.
+	terrs := &concurrency.AllErrorRecorder{}
+	var throttled atomic.Bool
+	fastForwarded := false
+
+	serr := vc.vr.sourceVStreamer.VStreamTables(ctx, tablePKs, filter, parallelism, func(resp *binlogdatapb.VStreamTablesResponse) error {
+		select {
+		case <-rowsCopiedTicker.C:
+			update := binlogplayer.GenerateUpdateRowsCopied(vc.vr.id, vc.vr.stats.CopyRowCount.Get())
+			_, _ = vc.vr.dbClient.Execute(update)
+		case <-copyStateGCTicker.C:
+			// Garbage collect the older copy_state rows of all the tables with
+			// a new connection, see copyTable.
+			go func() {
+				gcQuery := fmt.Sprintf("delete from _vt.copy_state where vrepl_id = %d and id not in (select maxid from (select max(id) as maxid from _vt.copy_state where vrepl_id = %d group by table_name) as depsel)",
+					vc.vr.id, vc.vr.id)
+				dbClient := vc.vr.vre.getDBClient(false)
+				if err := dbClient.Connect(); err != nil {
+					log.Errorf("Error while garbage collecting older copy_state rows, could not connect to database: %v", err)
+					return
+				}
+				defer dbClient.Close()
+				if _, err := dbClient.ExecuteFetch(gcQuery, -1); err != nil {
+					log.Errorf("Error while garbage collecting older copy_state rows with query %q: %v", gcQuery, err)
+				}
+			}()
+		case <-ctx.Done():
+			return io.EOF
+		default:
+		}
+		if throttled.CompareAndSwap(true, false) {
+			_ = vc.vr.updateTimeThrottled(throttlerapp.VCopierName)
+		}
+		if terrs.HasErrors() {
+			return vterrors.Wrapf(terrs.AggrError(vterrors.Aggregate), "task error")
+		}
+
+		table, ok := tables[resp.TableName]
+		if !ok {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected table %s in the copy of tables %v", resp.TableName, tableNames)
+		}
+		if table.completed {
+			// A late heartbeat of the row streamer of the table.
+			return nil
+		}
+		if !fastForwarded {
+			if err := vc.fastForward(ctx, copyState, resp.Gtid); err != nil {
+				return err
+			}
+			fastForwarded = true
+		}
+		if resp.Completed {
+			return vc.completeParallelTableCopy(ctx, table)
+		}
+		if table.queue == nil {
+			if len(resp.Fields) == 0 {
+				return fmt.Errorf("expecting field event first, got: %v", resp)
+			}
+			fieldEvent := &binlogdatapb.FieldEvent{
+				TableName: resp.TableName,
+			}
+			for _, f := range resp.Fields {
+				fieldEvent.Fields = append(fieldEvent.Fields, f.CloneVT())
+			}
+			tablePlan, err := plan.buildExecutionPlan(fieldEvent)
+			if err != nil {
+				return err
+			}
+			for _, f := range resp.Pkfields {
+				table.pkfields = append(table.pkfields, f.CloneVT())
+			}
+			buf := sqlparser.NewTrackedBuffer(nil)
+			buf.Myprintf(
+				"insert into _vt.copy_state (lastpk, vrepl_id, table_name) values (%a, %s, %s)", ":lastpk",
+				strconv.Itoa(int(vc.vr.id)),
+				encodeString(table.name))
+			table.queue = newVCopierCopyWorkQueue(true /* concurrent */, insertParallelism, copyWorkerFactory)
+			table.queue.open(buf.ParsedQuery(), table.pkfields, tablePlan)
+		}
+		// Throttled responses and heartbeats of the row streamers carry no rows.
+		if len(resp.Rows) == 0 {
+			return nil
+		}
+		if err := vc.vr.schedule.waitForBandwidth(ctx, rowsSize(resp.Rows)); err != nil {
+			return io.EOF
+		}
+
+		// Clone rows, since pointer values will change while async work is
+		// happening.
+		resp = resp.CloneVT()
+
+		// Prepare a vcopierCopyTask for the current batch of work, and sequence
+		// it with the previous task of the same table, see copyTable.
+		currCh := make(chan *vcopierCopyTaskResult, 1)
+		currT := newVCopierCopyTask(newVCopierCopyTaskArgs(resp.Rows, resp.Lastpk))
+		currT.lifecycle.onResult().sendTo(currCh)
+		if table.prevCh != nil {
+			currT.lifecycle.before(vcopierCopyTaskInsertCopyState).awaitCompletion(table.prevCh)
+		}
+		table.prevCh = currCh
+
+		// Every worker checks the throttler before copying its batch, so that
+		// the workers of the tables are throttled independently.
+		currT.lifecycle.before(vcopierCopyTaskBegin).do(func(ctx context.Context, _ *vcopierCopyTaskArgs) error {
+			for !vc.vr.throttleCheckOKOrWait(ctx, throttlerapp.Name(vc.throttlerAppName)) {
+				throttled.Store(true)
+				if ctx.Err() != nil {
+					return vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
+				}
+			}
+			return nil
+		})
+
+		tableName := table.name
+		currT.lifecycle.onResult().do(func(_ context.Context, result *vcopierCopyTaskResult) {
+			if result.state == vcopierCopyTaskFail {
+				vc.vr.stats.ErrorCounts.Add([]string{"Copy"}, 1)
+				terrs.RecordError(result.err)
+			}
+			if result.state == vcopierCopyTaskComplete {
+				vc.vr.stats.CopyRowCount.Add(int64(len(result.args.rows)))
+				vc.vr.stats.QueryCount.Add("copy", 1)
+				vc.vr.stats.TableCopyRowCounts.Add(tableName, int64(len(result.args.rows)))
+				vc.vr.stats.TableCopyTimings.Add(tableName, time.Since(result.startedAt))
+			}
+		})
+
+		if err := table.queue.enqueue(ctx, currT); err != nil {
+			log.Warningf("failed to enqueue task in workflow %s: %s", vc.vr.WorkflowName, err.Error())
+			return err
+		}
+		return nil
+	})
+
+	// Wait until the workers of all the tables are returned to their pools.
+	for _, table := range tables {
+		if table.queue != nil {
+			table.queue.close()
+		}
+	}
+	if terrs.HasErrors() {
+		terr := terrs.AggrError(vterrors.Aggregate)
+		log.Warningf("task error in workflow %s: %v", vc.vr.WorkflowName, terr)
+		return vterrors.Wrapf(terr, "task error")
+	}
+
+	// A context expiration was probably caused by a PlannedReparentShard or an
+	// elapsed copy phase duration. Those are normal, non-error interruptions
+	// of a copy phase.
+	select {
+	case <-ctx.Done():
+		for _, table := range tables {
+			if !table.completed {
+				log.Infof("Copy of %v stopped", table.name)
+			}
+		}
+		return nil
+	default:
+	}
+	if serr != nil {
+		return serr
+	}
+	for _, table := range tables {
+		if !table.completed {
+			return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "stream ended before the copy of table %s completed", table.name)
+		}
+	}
+	return nil
+}
+
+// completeParallelTableCopy waits for the workers of a table whose rows have
+// all been streamed, and completes the copy of the table once all its rows
+// have been committed.
+func (vc *vcopier) completeParallelTableCopy(ctx context.Context, table *parallelCopyTable) error {
+	table.completed = true
+	if table.queue != nil {
+		table.queue.close()
+	}
+	if table.prevCh != nil {
+		// All the tasks of the table are done once its queue is closed, and
+		// the last task only completes if all the previous ones did.
+		select {
+		case result := <-table.prevCh:
+			switch result.state {
+			case vcopierCopyTaskComplete:
+				table.lastpk = result.args.lastpk
+			case vcopierCopyTaskCancel:
+				return io.EOF
+			default:
+				return vterrors.Wrapf(result.err, "task error")
+			}
+		default:
+			return io.EOF
+		}
+	}
+	log.Infof("Copy of %v finished at lastpk: %v", table.name, table.lastpk)
+	return vc.completeTableCopy(ctx, table.name)
+}
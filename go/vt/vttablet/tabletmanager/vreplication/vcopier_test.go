@@ -663,6 +663,86 @@ func testPlayerCopyTables(t *testing.T) {
 
 }
 
+// TestPlayerCopyTablesParallel tests that the tables are all copied from a
+// single snapshot when the tables are copied in parallel.
+func TestPlayerCopyTablesParallel(t *testing.T) {
+	doNotLogDBQueries = true
+	defer func() { doNotLogDBQueries = false }()
+	oldVreplicationParallelTableCopyWorkers := vreplicationParallelTableCopyWorkers
+	vreplicationParallelTableCopyWorkers = 2
+	defer func() { vreplicationParallelTableCopyWorkers = oldVreplicationParallelTableCopyWorkers }()
+
+	defer deleteTablet(addTablet(100))
+
+	execStatements(t, []string{
+		"create table src1(id int, val varbinary(128), primary key(id))",
+		"insert into src1 values(1, 'aaa'), (2, 'bbb'), (3, 'ccc')",
+		fmt.Sprintf("create table %s.dst1(id int, val varbinary(128), primary key(id))", vrepldb),
+		"create table src2(id int, val varbinary(128), primary key(id))",
+		"insert into src2 values(1, 'ddd'), (2, 'eee')",
+		fmt.Sprintf("create table %s.dst2(id int, val varbinary(128), primary key(id))", vrepldb),
+		"create table src3(id int, val varbinary(128), primary key(id))",
+		fmt.Sprintf("create table %s.dst3(id int, val varbinary(128), primary key(id))", vrepldb),
+	})
+	defer execStatements(t, []string{
+		"drop table src1",
+		fmt.Sprintf("drop table %s.dst1", vrepldb),
+		"drop table src2",
+		fmt.Sprintf("drop table %s.dst2", vrepldb),
+		"drop table src3",
+		fmt.Sprintf("drop table %s.dst3", vrepldb),
+	})
+	env.SchemaEngine.Reload(context.Background())
+
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "dst1",
+			Filter: "select * from src1",
+		}, {
+			Match:  "dst2",
+			Filter: "select * from src2",
+		}, {
+			Match:  "dst3",
+			Filter: "select * from src3",
+		}},
+	}
+	bls := &binlogdatapb.BinlogSource{
+		Keyspace: env.KeyspaceName,
+		Shard:    env.ShardName,
+		Filter:   filter,
+		OnDdl:    binlogdatapb.OnDDLAction_IGNORE,
+	}
+	query := binlogplayer.CreateVReplicationState("test", bls, "", binlogdatapb.VReplicationWorkflowState_Init, playerEngine.dbName, 0, 0)
+	qr, err := playerEngine.Exec(query)
+	require.NoError(t, err)
+	defer func() {
+		query := fmt.Sprintf("delete from _vt.vreplication where id = %d", qr.InsertID)
+		_, err := playerEngine.Exec(query)
+		require.NoError(t, err)
+	}()
+
+	expectData(t, "dst1", [][]string{
+		{"1", "aaa"},
+		{"2", "bbb"},
+		{"3", "ccc"},
+	})
+	expectData(t, "dst2", [][]string{
+		{"1", "ddd"},
+		{"2", "eee"},
+	})
+	expectData(t, "dst3", [][]string{})
+	expectData(t, "_vt.copy_state", [][]string{})
+	validateCopyRowCountStat(t, 5)
+
+	// The replication phase starts from the position of the snapshot.
+	execStatements(t, []string{
+		"insert into src3 values(1, 'fff')",
+	})
+	expectData(t, "dst3", [][]string{
+		{"1", "fff"},
+	})
+}
+
 // TestPlayerCopyBigTable ensures the copy-catchup back-and-forth loop works correctly.
 func TestPlayerCopyBigTable(t *testing.T) {
 	testVcopierTestCases(t, testPlayerCopyBigTable, commonVcopierTestCases())
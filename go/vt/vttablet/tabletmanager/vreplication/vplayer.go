@@ -67,6 +67,11 @@ type vplayer struct {
 
 	phase string
 
+	// applyDelay is how old the events must be on the source before they
+	// are applied. It is only set in the replicate phase of DelayedReplica
+	// workflows.
+	applyDelay time.Duration
+
 	throttlerAppName string
 
 	// See updateFKCheck for more details on how the two fields below are used.
@@ -104,6 +109,10 @@ func newVPlayer(vr *vreplicator, settings binlogplayer.VRSettings, copyState map
 		settings.StopPos = pausePos
 		saveStop = false
 	}
+	var applyDelay time.Duration
+	if phase == "replicate" {
+		applyDelay = time.Duration(vr.source.ApplyDelaySeconds) * time.Second
+	}
 	return &vplayer{
 		vr:               vr,
 		startPos:         settings.StartPos,
@@ -114,6 +123,7 @@ func newVPlayer(vr *vreplicator, settings binlogplayer.VRSettings, copyState map
 		timeLastSaved:    time.Now(),
 		tablePlans:       make(map[string]*TablePlan),
 		phase:            phase,
+		applyDelay:       applyDelay,
 		throttlerAppName: throttlerapp.VCopierName.ConcatenateString(vr.throttlerAppName()),
 	}
 }
@@ -266,23 +276,41 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 	if tplan == nil {
 		return fmt.Errorf("unexpected event on table %s", rowEvent.TableName)
 	}
+	executor := func(sql string) (*sqltypes.Result, error) {
+		stats := NewVrLogStats("ROWCHANGE")
+		start := time.Now()
+		qr, err := vp.vr.dbClient.ExecuteWithRetry(ctx, sql)
+		vp.vr.stats.QueryCount.Add(vp.phase, 1)
+		vp.vr.stats.QueryTimings.Record(vp.phase, start)
+		stats.Send(sql)
+		return qr, err
+	}
 	for _, change := range rowEvent.RowChanges {
-		_, err := tplan.applyChange(change, func(sql string) (*sqltypes.Result, error) {
-			stats := NewVrLogStats("ROWCHANGE")
-			start := time.Now()
-			qr, err := vp.vr.dbClient.ExecuteWithRetry(ctx, sql)
-			vp.vr.stats.QueryCount.Add(vp.phase, 1)
-			vp.vr.stats.QueryTimings.Record(vp.phase, start)
-			stats.Send(sql)
-			return qr, err
-		})
+		conflict, err := tplan.hasConflict(change, executor)
 		if err != nil {
 			return err
 		}
+		if conflict {
+			if err := vp.recordConflict(tplan.TargetName); err != nil {
+				return err
+			}
+		}
+		if _, err := tplan.applyChange(change, executor); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// recordConflict counts a row change that is applied to a target row that was
+// changed outside of the stream. The change is still applied: the conflict is
+// logged in the same transaction, so that it can be reported by the workflow
+// status, and counted in the stream metrics.
+func (vp *vplayer) recordConflict(table string) error {
+	vp.vr.stats.ConflictCounts.Add(table, 1)
+	return vp.vr.insertLog(LogConflict, fmt.Sprintf("Row changes were applied to rows of table %s that had been changed outside of the workflow", table))
+}
+
 func (vp *vplayer) updatePos(ts int64) (posReached bool, err error) {
 	vp.numAccumulatedHeartbeats = 0
 	update := binlogplayer.GenerateUpdatePos(vp.vr.id, vp.pos, time.Now().Unix(), ts, vp.vr.stats.CopyRowCount.Get(), vreplicationStoreCompressedGTID)
@@ -381,7 +409,7 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 			return ctx.Err()
 		}
 		// check throttler.
-		if !vp.vr.vre.throttlerClient.ThrottleCheckOKOrWaitAppName(ctx, throttlerapp.Name(vp.throttlerAppName)) {
+		if !vp.vr.throttleCheckOKOrWait(ctx, throttlerapp.Name(vp.throttlerAppName)) {
 			_ = vp.vr.updateTimeThrottled(throttlerapp.VPlayerName)
 			continue
 		}
@@ -390,12 +418,16 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 		if err != nil {
 			return err
 		}
+		if err := vp.vr.schedule.waitForBandwidth(ctx, itemsSize(items)); err != nil {
+			return err
+		}
 		// No events were received. This likely means that there's a network partition.
 		// So, we should assume we're falling behind.
 		if len(items) == 0 {
 			behind := time.Now().UnixNano() - vp.lastTimestampNs - vp.timeOffsetNs
 			vp.vr.stats.ReplicationLagSeconds.Store(behind / 1e9)
 			vp.vr.stats.VReplicationLags.Add(strconv.Itoa(int(vp.vr.id)), time.Duration(behind/1e9)*time.Second)
+			vp.vr.stats.LagHistogram.Add(behind / 1e9)
 		}
 		// Empty transactions are saved at most once every idleTimeout.
 		// This covers two situations:
@@ -420,6 +452,10 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 					vp.timeOffsetNs = time.Now().UnixNano() - event.CurrentTime
 					sbm = event.CurrentTime/1e9 - event.Timestamp
 				}
+				vp.vr.stats.EventCounts.Add(event.Type.String(), 1)
+				if err := vp.waitForApplyDelay(ctx, event); err != nil {
+					return err
+				}
 				mustSave := false
 				switch event.Type {
 				case binlogdatapb.VEventType_COMMIT:
@@ -435,7 +471,9 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 					// applying the next set of events as part of the current transaction. This approach
 					// also handles the case where the last transaction is partial. In that case,
 					// we only group the transactions with commits we've seen so far.
-					if hasAnotherCommit(items, i, j+1) {
+					// Transactions are not grouped when they are applied with a delay, so
+					// that we never wait for the delay with an open transaction.
+					if vp.applyDelay == 0 && hasAnotherCommit(items, i, j+1) {
 						continue
 					}
 				}
@@ -452,11 +490,34 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 		if sbm >= 0 {
 			vp.vr.stats.ReplicationLagSeconds.Store(sbm)
 			vp.vr.stats.VReplicationLags.Add(strconv.Itoa(int(vp.vr.id)), time.Duration(sbm)*time.Second)
+			vp.vr.stats.LagHistogram.Add(sbm)
 		}
 
 	}
 }
 
+// waitForApplyDelay waits until the event is at least applyDelay old on
+// the source. It returns immediately if there is no delay to apply.
+func (vp *vplayer) waitForApplyDelay(ctx context.Context, event *binlogdatapb.VEvent) error {
+	if vp.applyDelay == 0 || event.Timestamp == 0 || event.Type == binlogdatapb.VEventType_HEARTBEAT {
+		return nil
+	}
+	// The timestamp of the event is relative to the clock of the source.
+	applyAt := time.Unix(event.Timestamp, 0).Add(vp.applyDelay).Add(time.Duration(vp.timeOffsetNs))
+	wait := time.Until(applyAt)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func hasAnotherCommit(items [][]*binlogdatapb.VEvent, i, j int) bool {
 	for i < len(items) {
 		for j < len(items[i]) {
@@ -1518,6 +1518,81 @@ func TestPlayerRowMove(t *testing.T) {
 	validateQueryCountStat(t, "replicate", 5)
 }
 
+func TestPlayerRollup(t *testing.T) {
+	defer deleteTablet(addTablet(100))
+
+	execStatements(t, []string{
+		"create table src(id int, grp int, val int, primary key(id))",
+		fmt.Sprintf("create table %s.dst(grp int, vcount int, primary key(grp))", vrepldb),
+	})
+	defer execStatements(t, []string{
+		"drop table src",
+		fmt.Sprintf("drop table %s.dst", vrepldb),
+	})
+	env.SchemaEngine.Reload(context.Background())
+
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "dst",
+			Filter: "select grp, count(val) as vcount from src group by grp",
+		}},
+	}
+	bls := &binlogdatapb.BinlogSource{
+		Keyspace: env.KeyspaceName,
+		Shard:    env.ShardName,
+		Filter:   filter,
+		OnDdl:    binlogdatapb.OnDDLAction_IGNORE,
+	}
+	cancel, _ := startVReplication(t, bls, "")
+	defer cancel()
+
+	execStatements(t, []string{
+		"insert into src values(1, 1, 5), (2, 1, null), (3, 2, 7)",
+	})
+	expectDBClientQueries(t, qh.Expect(
+		"begin",
+		"insert into dst(grp,vcount) values (1,if(5 is null, 0, 1)) on duplicate key update vcount=vcount+ifnull(values(vcount), 0)",
+		"insert into dst(grp,vcount) values (1,if(null is null, 0, 1)) on duplicate key update vcount=vcount+ifnull(values(vcount), 0)",
+		"insert into dst(grp,vcount) values (2,if(7 is null, 0, 1)) on duplicate key update vcount=vcount+ifnull(values(vcount), 0)",
+		"/update _vt.vreplication set pos=",
+		"commit",
+	))
+	expectData(t, "dst", [][]string{
+		{"1", "1"},
+		{"2", "1"},
+	})
+
+	execStatements(t, []string{
+		"insert into src values(4, 1, 2), (5, 1, 9)",
+	})
+	expectDBClientQueries(t, qh.Expect(
+		"begin",
+		"insert into dst(grp,vcount) values (1,if(2 is null, 0, 1)) on duplicate key update vcount=vcount+ifnull(values(vcount), 0)",
+		"insert into dst(grp,vcount) values (1,if(9 is null, 0, 1)) on duplicate key update vcount=vcount+ifnull(values(vcount), 0)",
+		"/update _vt.vreplication set pos=",
+		"commit",
+	))
+	expectData(t, "dst", [][]string{
+		{"1", "3"},
+		{"2", "1"},
+	})
+
+	// Deletes are reflected in the counts.
+	execStatements(t, []string{
+		"delete from src where id=5",
+	})
+	expectDBClientQueries(t, qh.Expect(
+		"begin",
+		"update dst set vcount=vcount-if(9 is null, 0, 1) where grp=1",
+		"/update _vt.vreplication set pos=",
+		"commit",
+	))
+	expectData(t, "dst", [][]string{
+		{"1", "2"},
+		{"2", "1"},
+	})
+}
+
 func TestPlayerTypes(t *testing.T) {
 	defer deleteTablet(addTablet(100))
 	execStatements(t, []string{
@@ -3169,3 +3244,23 @@ func startVReplication(t *testing.T, bls *binlogdatapb.BinlogSource, pos string)
 		})
 	}, int(qr.InsertID)
 }
+
+func TestPlayerWaitForApplyDelay(t *testing.T) {
+	vp := &vplayer{applyDelay: time.Hour}
+	now := time.Now().Unix()
+
+	// Events without a timestamp, heartbeats and events older than the delay are applied right away.
+	require.NoError(t, vp.waitForApplyDelay(context.Background(), &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_ROW}))
+	require.NoError(t, vp.waitForApplyDelay(context.Background(), &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_HEARTBEAT, Timestamp: now}))
+	require.NoError(t, vp.waitForApplyDelay(context.Background(), &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_ROW, Timestamp: now - 7200}))
+
+	// Recent events are held back until the delay has passed or the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := vp.waitForApplyDelay(ctx, &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_ROW, Timestamp: now})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Without a delay, nothing is held back.
+	vp.applyDelay = 0
+	require.NoError(t, vp.waitForApplyDelay(context.Background(), &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_ROW, Timestamp: now}))
+}
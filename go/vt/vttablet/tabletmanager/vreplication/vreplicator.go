@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
@@ -57,6 +58,13 @@ var (
 	// vreplicationMinimumHeartbeatUpdateInterval overrides vreplicationHeartbeatUpdateInterval if the latter is higher than this
 	// to ensure that it satisfies liveness criteria implicitly expected by internal processes like Online DDL
 	vreplicationMinimumHeartbeatUpdateInterval = 60
+
+	// highPriorityYieldDuration is how long normal priority workflows yield
+	// after a high priority workflow on the same tablet was throttled.
+	highPriorityYieldDuration = time.Second
+	// throttlerYieldDuration is how long a workflow sleeps when it yields
+	// because of its throttler settings.
+	throttlerYieldDuration = 250 * time.Millisecond
 )
 
 const (
@@ -113,6 +121,9 @@ type vreplicator struct {
 	WorkflowName    string
 
 	throttleUpdatesRateLimiter *timer.RateLimiter
+
+	// schedule enforces the copy and bandwidth windows of the workflow.
+	schedule *workflowSchedule
 }
 
 // newVReplicator creates a new vreplicator. The valid fields from the source are:
@@ -245,6 +256,10 @@ func (vr *vreplicator) replicate(ctx context.Context) error {
 	vr.throttleUpdatesRateLimiter = timer.NewRateLimiter(time.Second)
 	defer vr.throttleUpdatesRateLimiter.Stop()
 
+	if vr.schedule, err = newWorkflowSchedule(vr.source.Schedule); err != nil {
+		return err
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -270,6 +285,12 @@ func (vr *vreplicator) replicate(ctx context.Context) error {
 		}
 		switch {
 		case numTablesToCopy != 0:
+			if open, opensAt := vr.schedule.copyWindow(time.Now()); !open {
+				if err := vr.waitForCopyWindow(ctx, opensAt); err != nil {
+					return err
+				}
+				continue
+			}
 			if err := vr.clearFKCheck(vr.dbClient); err != nil {
 				log.Warningf("Unable to clear FK check %v", err)
 				return err
@@ -564,14 +585,50 @@ func (vr *vreplicator) setSQLMode(ctx context.Context, dbClient *vdbClient) (fun
 //     the worflow by either /throttler/throttle-app?app=vreplication and/or /throttler/throttle-app?app=online-ddl
 //     This is useful when we want to throttle all migrations. We throttle "online-ddl" and that applies to both vreplication
 //     migrations as well as gh-ost migrations.
+//   - prefixed with "<app-name>:" for flows with a throttler app name in their
+//     throttler settings, which makes it possible to throttle or exempt that
+//     particular workflow.
 func (vr *vreplicator) throttlerAppName() string {
 	names := []string{vr.WorkflowName, throttlerapp.VReplicationName.String()}
+	if appName := vr.source.GetThrottlerSettings().GetAppName(); appName != "" {
+		names = append([]string{appName}, names...)
+	}
 	if vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_OnlineDDL) {
 		names = append(names, throttlerapp.OnlineDDLName.String())
 	}
 	return throttlerapp.Concatenate(names...)
 }
 
+// throttleCheckOKOrWait checks the throttler for the workflow, taking its
+// throttler settings into account. Just like the throttler client, it
+// returns true right away when the workflow can proceed and otherwise
+// briefly sleeps and returns false.
+func (vr *vreplicator) throttleCheckOKOrWait(ctx context.Context, appName throttlerapp.Name) bool {
+	settings := vr.source.GetThrottlerSettings()
+	highPriority := settings.GetPriority() == binlogdatapb.VReplicationThrottlerPriority_High
+	yield := false
+	if settings.GetRatio() > 0 && rand.Float64() < settings.GetRatio() {
+		yield = true
+	}
+	if !highPriority && time.Since(time.Unix(0, vr.vre.highPriorityThrottledAt.Load())) < highPriorityYieldDuration {
+		yield = true
+	}
+	if yield {
+		select {
+		case <-ctx.Done():
+		case <-time.After(throttlerYieldDuration):
+		}
+		return false
+	}
+	if !vr.vre.throttlerClient.ThrottleCheckOKOrWaitAppName(ctx, appName) {
+		if highPriority {
+			vr.vre.highPriorityThrottledAt.Store(time.Now().UnixNano())
+		}
+		return false
+	}
+	return true
+}
+
 func (vr *vreplicator) updateTimeThrottled(appThrottled throttlerapp.Name) error {
 	err := vr.throttleUpdatesRateLimiter.Do(func() error {
 		tm := time.Now().Unix()
@@ -587,6 +644,33 @@ func (vr *vreplicator) updateTimeThrottled(appThrottled throttlerapp.Name) error
 	return err
 }
 
+// waitForCopyWindow pauses the copy phase until the next copy window of the
+// workflow schedule opens. The heartbeat of the workflow keeps being updated
+// while the copy phase is paused.
+func (vr *vreplicator) waitForCopyWindow(ctx context.Context, opensAt time.Time) error {
+	message := fmt.Sprintf("Copy phase paused by the workflow schedule until %s", opensAt.Format(time.RFC3339))
+	log.Infof("VReplication stream %d: %s", vr.id, message)
+	if err := vr.setMessage(message); err != nil {
+		return err
+	}
+	timer := time.NewTimer(time.Until(opensAt))
+	defer timer.Stop()
+	heartbeat := time.NewTicker(time.Duration(vreplicationHeartbeatUpdateInterval) * time.Second)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if err := vr.updateHeartbeatTime(time.Now().Unix()); err != nil {
+				return err
+			}
+		case <-timer.C:
+			return vr.setMessage("")
+		}
+	}
+}
+
 func (vr *vreplicator) updateHeartbeatTime(tm int64) error {
 	update, err := binlogplayer.GenerateUpdateHeartbeat(vr.id, tm)
 	if err != nil {
@@ -1011,10 +1095,11 @@ func (vr *vreplicator) execPostCopyActions(ctx context.Context, tableName string
 
 // supportsDeferredSecondaryKeys tells you if related work should be done
 // for the workflow. Deferring secondary index generation is only supported
-// with MoveTables, Migrate, and Reshard.
+// with MoveTables, Migrate, DelayedReplica, and Reshard.
 func (vr *vreplicator) supportsDeferredSecondaryKeys() bool {
 	return vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_MoveTables) ||
 		vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_Migrate) ||
+		vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_DelayedReplica) ||
 		vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_Reshard)
 }
 
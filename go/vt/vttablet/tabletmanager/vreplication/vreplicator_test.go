@@ -752,3 +752,38 @@ func waitForQueryResult(t *testing.T, dbc binlogplayer.DBClient, query, val stri
 		}
 	}
 }
+
+func TestThrottleCheckOKOrWait(t *testing.T) {
+	ctx := context.Background()
+	oldYieldDuration := throttlerYieldDuration
+	throttlerYieldDuration = time.Millisecond
+	defer func() {
+		throttlerYieldDuration = oldYieldDuration
+	}()
+
+	vre := &Engine{}
+	newVR := func(settings *binlogdatapb.VReplicationThrottlerSettings) *vreplicator {
+		return &vreplicator{
+			vre:    vre,
+			source: &binlogdatapb.BinlogSource{ThrottlerSettings: settings},
+		}
+	}
+	normal := newVR(nil)
+	bulk := newVR(&binlogdatapb.VReplicationThrottlerSettings{Ratio: 1})
+	critical := newVR(&binlogdatapb.VReplicationThrottlerSettings{AppName: "critical", Priority: binlogdatapb.VReplicationThrottlerPriority_High})
+
+	require.True(t, normal.throttleCheckOKOrWait(ctx, "test"))
+	require.True(t, critical.throttleCheckOKOrWait(ctx, "test"))
+	// A ratio of 1 fails all the checks.
+	require.False(t, bulk.throttleCheckOKOrWait(ctx, "test"))
+
+	// Normal priority workflows yield after a high priority workflow was throttled.
+	vre.highPriorityThrottledAt.Store(time.Now().UnixNano())
+	require.False(t, normal.throttleCheckOKOrWait(ctx, "test"))
+	require.True(t, critical.throttleCheckOKOrWait(ctx, "test"))
+	vre.highPriorityThrottledAt.Store(time.Now().Add(-highPriorityYieldDuration).UnixNano())
+	require.True(t, normal.throttleCheckOKOrWait(ctx, "test"))
+
+	critical.WorkflowName = "wf"
+	assert.Equal(t, "critical:wf:vreplication", critical.throttlerAppName())
+}
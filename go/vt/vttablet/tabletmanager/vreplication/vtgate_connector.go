@@ -0,0 +1,289 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var _ VStreamerClient = (*vtgateConnector)(nil)
+
+// errCopySnapshotChanged is returned by vtgateConnector.VStreamRows when the
+// copy phase of the vtgate moves to a new snapshot. The vtgate then sends the
+// changes from the previous snapshot, and the rows copied afterwards are not
+// consistent with the position of the first response, so the vcopier must
+// catch up by itself and restart the copy from the last copied pk.
+var errCopySnapshotChanged = vterrors.New(vtrpcpb.Code_ABORTED, "the copy phase of the vtgate moved to a new snapshot")
+
+// vtgateStreamer is the part of the vtgateconn.VTGateConn API used by the vtgateConnector.
+type vtgateStreamer interface {
+	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+		filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error)
+	Close()
+}
+
+// vtgateConnector streams a shard of an external Vitess cluster through
+// one of the vtgates of that cluster. The VGTID events sent by the vtgate
+// are translated back to the GTID events expected by the vreplicator, and
+// journal events are passed through so that the vplayer can follow a
+// reshard of the source keyspace.
+type vtgateConnector struct {
+	address    string
+	keyspace   string
+	shard      string
+	tabletType topodatapb.TabletType
+	cells      string
+
+	dial func(ctx context.Context, address string) (vtgateStreamer, error)
+	conn vtgateStreamer
+}
+
+func newVTGateConnector(address, keyspace, shard string, tabletType topodatapb.TabletType, cells string) *vtgateConnector {
+	return &vtgateConnector{
+		address:    address,
+		keyspace:   keyspace,
+		shard:      shard,
+		tabletType: tabletType,
+		cells:      cells,
+		dial: func(ctx context.Context, address string) (vtgateStreamer, error) {
+			return vtgateconn.Dial(ctx, address)
+		},
+	}
+}
+
+func (vc *vtgateConnector) Open(ctx context.Context) error {
+	var err error
+	vc.conn, err = vc.dial(ctx, vc.address)
+	if err != nil {
+		return vterrors.Wrapf(err, "failed to connect to vtgate %s", vc.address)
+	}
+	return nil
+}
+
+func (vc *vtgateConnector) Close(ctx context.Context) error {
+	if vc.conn != nil {
+		vc.conn.Close()
+		vc.conn = nil
+	}
+	return nil
+}
+
+func (vc *vtgateConnector) VStream(ctx context.Context, startPos string, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error {
+	reader, err := vc.stream(ctx, startPos, tablePKs, filter)
+	if err != nil {
+		return err
+	}
+	for {
+		events, err := reader.Recv()
+		if err != nil {
+			return err
+		}
+		translated := make([]*binlogdatapb.VEvent, 0, len(events))
+		for _, event := range events {
+			switch event.Type {
+			case binlogdatapb.VEventType_VGTID:
+				sgtid, err := vc.shardGtid(event.Vgtid)
+				if err != nil {
+					return err
+				}
+				event = &binlogdatapb.VEvent{
+					Type:        binlogdatapb.VEventType_GTID,
+					Gtid:        sgtid.Gtid,
+					Timestamp:   event.Timestamp,
+					CurrentTime: event.CurrentTime,
+					Keyspace:    event.Keyspace,
+					Shard:       event.Shard,
+				}
+			case binlogdatapb.VEventType_FIELD:
+				event.FieldEvent.TableName = vc.tableName(event.FieldEvent.TableName)
+			case binlogdatapb.VEventType_ROW:
+				event.RowEvent.TableName = vc.tableName(event.RowEvent.TableName)
+			}
+			translated = append(translated, event)
+		}
+		if len(translated) == 0 {
+			continue
+		}
+		if err := send(translated); err != nil {
+			return err
+		}
+	}
+}
+
+// VStreamRows copies the table of the query with the copy phase of a vtgate
+// VStream. The stream is stopped once the copy of the table is completed, or
+// with errCopySnapshotChanged once the vtgate moves to a new snapshot: only
+// the rows of the first snapshot are sent, since the vtgate streams changes
+// between its snapshots.
+func (vc *vtgateConnector) VStreamRows(ctx context.Context, query string, lastpk *querypb.QueryResult, send func(*binlogdatapb.VStreamRowsResponse) error) error {
+	table, err := sqlparser.TableFromStatement(query)
+	if err != nil {
+		return err
+	}
+	tableName := table.Name.String()
+	var tablePKs []*binlogdatapb.TableLastPK
+	if lastpk != nil {
+		tablePKs = []*binlogdatapb.TableLastPK{{TableName: tableName, Lastpk: lastpk}}
+	}
+	filter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: tableName, Filter: query}}}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	reader, err := vc.stream(ctx, "", tablePKs, filter)
+	if err != nil {
+		return err
+	}
+
+	var (
+		fields    []*querypb.Field
+		gtid      string
+		rows      []*querypb.Row
+		sentFirst bool
+	)
+	// The first response must carry the fields, the pk fields and the
+	// snapshot position of the copy.
+	sendRows := func(pk *querypb.QueryResult) error {
+		if fields == nil {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "no field event received for table %s", tableName)
+		}
+		resp := &binlogdatapb.VStreamRowsResponse{Rows: rows}
+		if pk != nil && len(pk.Rows) == 1 {
+			resp.Lastpk = pk.Rows[0]
+		}
+		if !sentFirst {
+			resp.Fields = fields
+			resp.Gtid = gtid
+			if pk != nil {
+				resp.Pkfields = pk.Fields
+			}
+			sentFirst = true
+		}
+		rows = nil
+		return send(resp)
+	}
+	for {
+		events, err := reader.Recv()
+		if err == io.EOF {
+			return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "stream ended before the copy of table %s completed", tableName)
+		}
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			switch event.Type {
+			case binlogdatapb.VEventType_FIELD:
+				if vc.tableName(event.FieldEvent.TableName) == tableName {
+					fields = event.FieldEvent.Fields
+				}
+			case binlogdatapb.VEventType_ROW:
+				if vc.tableName(event.RowEvent.TableName) != tableName {
+					continue
+				}
+				for _, change := range event.RowEvent.RowChanges {
+					// Updates and deletes are replicated changes, which are
+					// only streamed between two snapshots.
+					if change.Before != nil {
+						return errCopySnapshotChanged
+					}
+					rows = append(rows, change.After)
+				}
+			case binlogdatapb.VEventType_VGTID:
+				sgtid, err := vc.shardGtid(event.Vgtid)
+				if err != nil {
+					return err
+				}
+				switch {
+				case gtid == "":
+					gtid = sgtid.Gtid
+				case sgtid.Gtid != gtid:
+					// The rows received since the last batch were inserted by
+					// a replicated transaction, not copied.
+					return errCopySnapshotChanged
+				}
+				if len(rows) == 0 {
+					continue
+				}
+				// The VGTID that follows a batch of rows holds the lastpk of the batch.
+				var pk *querypb.QueryResult
+				for _, tablePK := range sgtid.TablePKs {
+					if vc.tableName(tablePK.TableName) == tableName {
+						pk = tablePK.Lastpk
+					}
+				}
+				if err := sendRows(pk); err != nil {
+					return err
+				}
+			case binlogdatapb.VEventType_COPY_COMPLETED:
+				if !sentFirst || len(rows) > 0 {
+					if err := sendRows(nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+	}
+}
+
+func (vc *vtgateConnector) VStreamTables(ctx context.Context, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, parallelism int, send func(*binlogdatapb.VStreamTablesResponse) error) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "VStreamTables is not supported when streaming through vtgate %s", vc.address)
+}
+
+func (vc *vtgateConnector) stream(ctx context.Context, startPos string, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter) (vtgateconn.VStreamReader, error) {
+	if vc.conn == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "connection to vtgate %s is not open", vc.address)
+	}
+	vgtid := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{
+		Keyspace: vc.keyspace,
+		Shard:    vc.shard,
+		Gtid:     startPos,
+		TablePKs: tablePKs,
+	}}}
+	flags := &vtgatepb.VStreamFlags{
+		StopOnReshard:        true,
+		IncludeJournalEvents: true,
+		Cells:                vc.cells,
+	}
+	return vc.conn.VStream(ctx, vc.tabletType, vgtid, filter, flags)
+}
+
+// shardGtid returns the position of the streamed shard from a VGTID.
+func (vc *vtgateConnector) shardGtid(vgtid *binlogdatapb.VGtid) (*binlogdatapb.ShardGtid, error) {
+	for _, sgtid := range vgtid.GetShardGtids() {
+		if sgtid.Keyspace == vc.keyspace && sgtid.Shard == vc.shard {
+			return sgtid, nil
+		}
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "shard %s/%s not found in vgtid %v", vc.keyspace, vc.shard, vgtid)
+}
+
+// tableName strips the keyspace qualifier added by vtgate to table names.
+func (vc *vtgateConnector) tableName(name string) string {
+	return strings.TrimPrefix(name, vc.keyspace+".")
+}
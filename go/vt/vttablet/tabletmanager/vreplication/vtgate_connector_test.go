@@ -0,0 +1,311 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+type fakeVStreamReader struct {
+	batches [][]*binlogdatapb.VEvent
+}
+
+func (r *fakeVStreamReader) Recv() ([]*binlogdatapb.VEvent, error) {
+	if len(r.batches) == 0 {
+		return nil, io.EOF
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+type fakeVTGateStreamer struct {
+	reader *fakeVStreamReader
+	vgtid  *binlogdatapb.VGtid
+	filter *binlogdatapb.Filter
+	flags  *vtgatepb.VStreamFlags
+}
+
+func (s *fakeVTGateStreamer) VStream(_ context.Context, _ topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
+	s.vgtid, s.filter, s.flags = vgtid, filter, flags
+	return s.reader, nil
+}
+
+func (s *fakeVTGateStreamer) Close() {}
+
+func newTestVTGateConnector(t *testing.T, batches [][]*binlogdatapb.VEvent) (*vtgateConnector, *fakeVTGateStreamer) {
+	streamer := &fakeVTGateStreamer{reader: &fakeVStreamReader{batches: batches}}
+	vc := newVTGateConnector("ext:15991", "ks", "-80", topodatapb.TabletType_REPLICA, "")
+	vc.dial = func(context.Context, string) (vtgateStreamer, error) {
+		return streamer, nil
+	}
+	require.NoError(t, vc.Open(context.Background()))
+	return vc, streamer
+}
+
+func testVGtidEvent(gtid string, tablePKs ...*binlogdatapb.TableLastPK) *binlogdatapb.VEvent {
+	return &binlogdatapb.VEvent{
+		Type: binlogdatapb.VEventType_VGTID,
+		Vgtid: &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+			{Keyspace: "ks", Shard: "-80", Gtid: gtid, TablePKs: tablePKs},
+		}},
+	}
+}
+
+func TestVTGateConnectorVStream(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|val", "int64|varchar")
+	journal := &binlogdatapb.VEvent{
+		Type: binlogdatapb.VEventType_JOURNAL,
+		Journal: &binlogdatapb.Journal{
+			Id:            1,
+			MigrationType: binlogdatapb.MigrationType_SHARDS,
+			ShardGtids:    []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "-40", Gtid: "pos3"}},
+		},
+	}
+	vc, streamer := newTestVTGateConnector(t, [][]*binlogdatapb.VEvent{{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields}},
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "ks.t1"}},
+		testVGtidEvent("pos2"),
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}, {
+		journal,
+	}})
+
+	var events []*binlogdatapb.VEvent
+	tablePKs := []*binlogdatapb.TableLastPK{{TableName: "t1"}}
+	filter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: "select * from t1"}}}
+	err := vc.VStream(context.Background(), "pos1", tablePKs, filter, func(evs []*binlogdatapb.VEvent) error {
+		events = append(events, evs...)
+		return nil
+	})
+	assert.Equal(t, io.EOF, err)
+
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "ks", Shard: "-80", Gtid: "pos1", TablePKs: tablePKs},
+	}}, streamer.vgtid)
+	utils.MustMatch(t, filter, streamer.filter)
+	assert.True(t, streamer.flags.StopOnReshard)
+
+	utils.MustMatch(t, []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "t1", Fields: fields}},
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t1"}},
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "pos2"},
+		{Type: binlogdatapb.VEventType_COMMIT},
+		journal,
+	}, events)
+}
+
+func TestVTGateConnectorVStreamRows(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|val", "int64|varchar")
+	pkfields := sqltypes.MakeTestFields("id", "int64")
+	rowEvent := func(values string) *binlogdatapb.VEvent {
+		return &binlogdatapb.VEvent{
+			Type: binlogdatapb.VEventType_ROW,
+			RowEvent: &binlogdatapb.RowEvent{
+				TableName: "ks.t1",
+				RowChanges: []*binlogdatapb.RowChange{{
+					After: sqltypes.RowToProto3(sqltypes.MakeTestResult(fields, values).Rows[0]),
+				}},
+			},
+		}
+	}
+	lastPK := func(id string) *binlogdatapb.TableLastPK {
+		return &binlogdatapb.TableLastPK{
+			TableName: "t1",
+			Lastpk:    sqltypes.ResultToProto3(sqltypes.MakeTestResult(pkfields, id)),
+		}
+	}
+	vc, streamer := newTestVTGateConnector(t, [][]*binlogdatapb.VEvent{{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields}},
+		testVGtidEvent("snapshot", lastPK("1")),
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}, {
+		{Type: binlogdatapb.VEventType_BEGIN},
+		rowEvent("2|a"),
+		rowEvent("3|b"),
+		testVGtidEvent("snapshot", lastPK("3")),
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}, {
+		{Type: binlogdatapb.VEventType_BEGIN},
+		rowEvent("4|c"),
+		testVGtidEvent("snapshot", lastPK("4")),
+		{Type: binlogdatapb.VEventType_COMMIT},
+		testVGtidEvent("snapshot"),
+		{Type: binlogdatapb.VEventType_COPY_COMPLETED},
+	}})
+
+	var responses []*binlogdatapb.VStreamRowsResponse
+	query := "select id, val from t1"
+	lastpk := lastPK("1").Lastpk
+	err := vc.VStreamRows(context.Background(), query, lastpk, func(resp *binlogdatapb.VStreamRowsResponse) error {
+		responses = append(responses, resp)
+		return nil
+	})
+	require.NoError(t, err)
+
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "ks", Shard: "-80", TablePKs: []*binlogdatapb.TableLastPK{{TableName: "t1", Lastpk: lastpk}}},
+	}}, streamer.vgtid)
+	utils.MustMatch(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: query}}}, streamer.filter)
+
+	row := func(values string) *querypb.Row {
+		return sqltypes.RowToProto3(sqltypes.MakeTestResult(fields, values).Rows[0])
+	}
+	pkRow := func(id string) *querypb.Row {
+		return sqltypes.RowToProto3(sqltypes.MakeTestResult(pkfields, id).Rows[0])
+	}
+	utils.MustMatch(t, []*binlogdatapb.VStreamRowsResponse{{
+		Fields:   fields,
+		Pkfields: pkfields,
+		Gtid:     "snapshot",
+		Rows:     []*querypb.Row{row("2|a"), row("3|b")},
+		Lastpk:   pkRow("3"),
+	}, {
+		Rows:   []*querypb.Row{row("4|c")},
+		Lastpk: pkRow("4"),
+	}}, responses)
+}
+
+// TestVTGateConnectorVStreamRowsSnapshotChanged checks that only the rows
+// of the first snapshot are copied: the changes that the vtgate streams
+// before moving to a new snapshot are caught up by the vcopier itself.
+func TestVTGateConnectorVStreamRowsSnapshotChanged(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|val", "int64|varchar")
+	pkfields := sqltypes.MakeTestFields("id", "int64")
+	row := func(values string) *querypb.Row {
+		return sqltypes.RowToProto3(sqltypes.MakeTestResult(fields, values).Rows[0])
+	}
+	rowEvent := func(changes ...*binlogdatapb.RowChange) *binlogdatapb.VEvent {
+		return &binlogdatapb.VEvent{
+			Type:     binlogdatapb.VEventType_ROW,
+			RowEvent: &binlogdatapb.RowEvent{TableName: "ks.t1", RowChanges: changes},
+		}
+	}
+	lastPK := func(id string) *binlogdatapb.TableLastPK {
+		return &binlogdatapb.TableLastPK{
+			TableName: "t1",
+			Lastpk:    sqltypes.ResultToProto3(sqltypes.MakeTestResult(pkfields, id)),
+		}
+	}
+	firstSnapshot := [][]*binlogdatapb.VEvent{{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields}},
+		testVGtidEvent("snapshot1"),
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}, {
+		{Type: binlogdatapb.VEventType_BEGIN},
+		rowEvent(&binlogdatapb.RowChange{After: row("1|a")}),
+		rowEvent(&binlogdatapb.RowChange{After: row("2|b")}),
+		testVGtidEvent("snapshot1", lastPK("2")),
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}}
+	secondSnapshot := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields}},
+		testVGtidEvent("snapshot2", lastPK("2")),
+		{Type: binlogdatapb.VEventType_COMMIT},
+		{Type: binlogdatapb.VEventType_BEGIN},
+		rowEvent(&binlogdatapb.RowChange{After: row("3|c")}),
+		testVGtidEvent("snapshot2", lastPK("3")),
+		{Type: binlogdatapb.VEventType_COMMIT},
+		{Type: binlogdatapb.VEventType_COPY_COMPLETED},
+	}
+	testcases := []struct {
+		name    string
+		changes []*binlogdatapb.RowChange
+	}{{
+		name:    "update",
+		changes: []*binlogdatapb.RowChange{{Before: row("1|a"), After: row("1|x")}},
+	}, {
+		name:    "delete",
+		changes: []*binlogdatapb.RowChange{{Before: row("2|b")}},
+	}, {
+		name:    "insert",
+		changes: []*binlogdatapb.RowChange{{After: row("0|y")}},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The vtgate streams the change made after the first snapshot
+			// before copying the next rows from a second snapshot.
+			batches := append([][]*binlogdatapb.VEvent{}, firstSnapshot...)
+			batches = append(batches, []*binlogdatapb.VEvent{
+				{Type: binlogdatapb.VEventType_BEGIN},
+				rowEvent(tc.changes...),
+				testVGtidEvent("pos2", lastPK("2")),
+				{Type: binlogdatapb.VEventType_COMMIT},
+			}, secondSnapshot)
+			vc, _ := newTestVTGateConnector(t, batches)
+
+			var responses []*binlogdatapb.VStreamRowsResponse
+			err := vc.VStreamRows(context.Background(), "select id, val from t1", nil, func(resp *binlogdatapb.VStreamRowsResponse) error {
+				responses = append(responses, resp)
+				return nil
+			})
+			assert.Equal(t, errCopySnapshotChanged, err)
+			utils.MustMatch(t, []*binlogdatapb.VStreamRowsResponse{{
+				Fields:   fields,
+				Pkfields: pkfields,
+				Gtid:     "snapshot1",
+				Rows:     []*querypb.Row{row("1|a"), row("2|b")},
+				Lastpk:   sqltypes.RowToProto3(sqltypes.MakeTestResult(pkfields, "2").Rows[0]),
+			}}, responses)
+		})
+	}
+}
+
+func TestVTGateConnectorVStreamRowsEmptyTable(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|val", "int64|varchar")
+	vc, _ := newTestVTGateConnector(t, [][]*binlogdatapb.VEvent{{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields}},
+		testVGtidEvent("snapshot"),
+		{Type: binlogdatapb.VEventType_COMMIT},
+		{Type: binlogdatapb.VEventType_COPY_COMPLETED},
+	}})
+
+	var responses []*binlogdatapb.VStreamRowsResponse
+	err := vc.VStreamRows(context.Background(), "select id, val from t1", nil, func(resp *binlogdatapb.VStreamRowsResponse) error {
+		responses = append(responses, resp)
+		return nil
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*binlogdatapb.VStreamRowsResponse{{Fields: fields, Gtid: "snapshot"}}, responses)
+
+	vc, _ = newTestVTGateConnector(t, nil)
+	err = vc.VStreamRows(context.Background(), "select id, val from t1", nil, func(*binlogdatapb.VStreamRowsResponse) error {
+		return nil
+	})
+	assert.ErrorContains(t, err, "stream ended before the copy of table t1 completed")
+}
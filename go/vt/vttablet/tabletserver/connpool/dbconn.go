@@ -164,7 +164,9 @@ func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfi
 	defer dbc.stats.MySQLTimings.Record("Exec", time.Now())
 
 	done, wg := dbc.setDeadline(ctx)
+	dbc.conn.QueryAttributes = tabletenv.QueryAttributesFromContext(ctx)
 	qr, err := dbc.conn.ExecuteFetch(query, maxrows, wantfields)
+	dbc.conn.QueryAttributes = nil
 
 	if done != nil {
 		close(done)
@@ -255,7 +257,9 @@ func (dbc *Conn) streamOnce(ctx context.Context, query string, callback func(*sq
 	defer dbc.current.Store("")
 
 	done, wg := dbc.setDeadline(ctx)
+	dbc.conn.QueryAttributes = tabletenv.QueryAttributesFromContext(ctx)
 	err := dbc.conn.ExecuteStreamFetch(query, callback, alloc, streamBufferSize)
+	dbc.conn.QueryAttributes = nil
 
 	if done != nil {
 		close(done)
@@ -285,6 +289,49 @@ func (dbc *Conn) StreamOnce(ctx context.Context, query string, callback func(*sq
 	)
 }
 
+// lastStatementResourcesSQL reads the cost of the last statement that
+// completed on the current connection from the performance_schema statement
+// history. The thread id is looked up through the threads table to also
+// support versions without PS_CURRENT_THREAD_ID(). The bytes read by InnoDB
+// from its data files are summed from the file I/O wait events nested in the
+// statement, which are only available when the events_waits_history_long
+// consumer and the wait/io/file/innodb instruments are enabled.
+const lastStatementResourcesSQL = "select s.rows_examined, s.created_tmp_tables, s.created_tmp_disk_tables, s.sort_rows, s.select_scan + s.select_full_join, " +
+	"(select sum(w.number_of_bytes) from performance_schema.events_waits_history_long w " +
+	"where w.thread_id = s.thread_id and w.event_id between s.event_id and s.end_event_id " +
+	"and w.event_name like 'wait/io/file/innodb/%' and w.operation = 'read') " +
+	"from performance_schema.events_statements_history s " +
+	"where s.thread_id = (select thread_id from performance_schema.threads where processlist_id = connection_id()) " +
+	"order by s.event_id desc limit 1"
+
+// LastStatementResources returns the MySQL-side cost of the last statement
+// executed on the connection. It must be called right after that statement,
+// before anything else runs on the connection. As it is a statement of its
+// own, it resets the session state that describes the last statement, such as
+// its warnings, ROW_COUNT() and FOUND_ROWS(), so it must not be called on
+// connections that are reserved or in a transaction.
+func (dbc *Conn) LastStatementResources(ctx context.Context) (tabletenv.QueryResources, error) {
+	var resources tabletenv.QueryResources
+	qr, err := dbc.execOnce(ctx, lastStatementResourcesSQL, 1, false)
+	if err != nil {
+		return resources, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 6 {
+		return resources, nil
+	}
+	values := []*uint64{&resources.RowsExamined, &resources.TmpTables, &resources.TmpDiskTables, &resources.SortRows, &resources.FullScans, &resources.InnoDBReadBytes}
+	for i, v := range qr.Rows[0] {
+		if v.IsNull() {
+			continue
+		}
+		*values[i], err = v.ToCastUint64()
+		if err != nil {
+			return tabletenv.QueryResources{}, err
+		}
+	}
+	return resources, nil
+}
+
 var (
 	getModeSQL    = "select @@global.sql_mode"
 	getAutocommit = "select @@autocommit"
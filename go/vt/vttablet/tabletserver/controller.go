@@ -92,6 +92,10 @@ type Controller interface {
 
 	// CheckThrottler
 	CheckThrottler(ctx context.Context, appName string, flags *throttle.CheckFlags) *throttle.CheckResult
+
+	// UnresolvedTransactions returns the distributed transactions whose metadata
+	// is stored on this tablet and that have been unresolved for at least abandonAge.
+	UnresolvedTransactions(ctx context.Context, target *querypb.Target, abandonAge time.Duration) ([]*querypb.TransactionMetadata, error)
 }
 
 // Ensure TabletServer satisfies Controller interface.
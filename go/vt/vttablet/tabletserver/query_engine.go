@@ -36,11 +36,13 @@ import (
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/clustersettings"
 	"vitess.io/vitess/go/vt/dbconnpool"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/tableacl"
 	tacl "vitess.io/vitess/go/vt/tableacl/acl"
@@ -188,6 +190,11 @@ type QueryEngine struct {
 	// stats
 	// Note: queryErrorCountsWithCode is similar to queryErrorCounts except it contains error code as an additional dimension
 	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned *stats.CountersWithMultiLabels
+	// MySQL-side costs by fingerprint, only recorded with --queryserver-enable-resource-accounting.
+	queryRowsExamined, queryTmpTables, queryTmpDiskTables, querySortRows, queryFullScans, queryInnoDBReadBytes *stats.CountersWithMultiLabels
+	// Query timings by table and fingerprint, with capped cardinalities.
+	queryTimingsByTable, queryTimingsByFingerprint *servenv.MultiTimingsWrapper
+	tableLimiter, fingerprintLimiter               *stats.LabelLimiter
 
 	// stats flags
 	enablePerWorkloadTableMetrics bool
@@ -207,6 +214,8 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 		se:                            se,
 		queryRuleSources:              rules.NewMap(),
 		enablePerWorkloadTableMetrics: config.EnablePerWorkloadTableMetrics,
+		tableLimiter:                  stats.NewLabelLimiter(config.QueryMetricsMaxTables),
+		fingerprintLimiter:            stats.NewLabelLimiter(config.QueryMetricsMaxFingerprints),
 	}
 
 	// Cache for query plans: user configured size with a doorkeeper by default to prevent one-off queries
@@ -263,8 +272,8 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 
 	qe.accessCheckerLogger = logutil.NewThrottledLogger("accessChecker", 1*time.Second)
 
-	env.Exporter().NewGaugeFunc("MaxResultSize", "Query engine max result size", qe.maxResultSize.Load)
-	env.Exporter().NewGaugeFunc("WarnResultSize", "Query engine warn result size", qe.warnResultSize.Load)
+	env.Exporter().NewGaugeFunc("MaxResultSize", "Query engine max result size", qe.getMaxResultSize)
+	env.Exporter().NewGaugeFunc("WarnResultSize", "Query engine warn result size", qe.getWarnResultSize)
 	env.Exporter().NewGaugeFunc("StreamBufferSize", "Query engine stream buffer size", qe.streamBufferSize.Load)
 	env.Exporter().NewCounterFunc("TableACLExemptCount", "Query engine table ACL exempt count", qe.tableaclExemptCount.Load)
 
@@ -293,6 +302,19 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	qe.queryRowsReturned = env.Exporter().NewCountersWithMultiLabels("QueryRowsReturned", "query rows returned", labels)
 	qe.queryErrorCounts = env.Exporter().NewCountersWithMultiLabels("QueryErrorCounts", "query error counts", labels)
 	qe.queryErrorCountsWithCode = env.Exporter().NewCountersWithMultiLabels("QueryErrorCountsWithCode", "query error counts with error code", []string{"Table", "Plan", "Code"})
+	fingerprintLabels := []string{"Fingerprint"}
+	qe.queryRowsExamined = env.Exporter().NewCountersWithMultiLabels("QueryRowsExamined", "query rows examined by MySQL, by query fingerprint", fingerprintLabels)
+	qe.queryTmpTables = env.Exporter().NewCountersWithMultiLabels("QueryTmpTables", "query temporary tables created by MySQL, by query fingerprint", fingerprintLabels)
+	qe.queryTmpDiskTables = env.Exporter().NewCountersWithMultiLabels("QueryTmpDiskTables", "query on-disk temporary tables created by MySQL, by query fingerprint", fingerprintLabels)
+	qe.querySortRows = env.Exporter().NewCountersWithMultiLabels("QuerySortRows", "query rows sorted by MySQL, by query fingerprint", fingerprintLabels)
+	qe.queryFullScans = env.Exporter().NewCountersWithMultiLabels("QueryFullScans", "query full table scans and full joins done by MySQL, by query fingerprint", fingerprintLabels)
+	qe.queryInnoDBReadBytes = env.Exporter().NewCountersWithMultiLabels("QueryInnoDBReadBytes", "query bytes read by InnoDB from its data files, by query fingerprint", fingerprintLabels)
+	if config.QueryMetricsMaxTables > 0 {
+		qe.queryTimingsByTable = env.Exporter().NewMultiTimings("QueryTimingsByTable", "query timings by table and plan, for up to --queryserver-query-metrics-max-tables tables", []string{"Table", "Plan"})
+	}
+	if config.QueryMetricsMaxFingerprints > 0 {
+		qe.queryTimingsByFingerprint = env.Exporter().NewMultiTimings("QueryTimingsByFingerprint", "query timings by query fingerprint, for up to --queryserver-query-metrics-max-fingerprints fingerprints", []string{"Fingerprint"})
+	}
 
 	env.Exporter().HandleFunc("/debug/hotrows", qe.txSerializer.ServeHTTP)
 	env.Exporter().HandleFunc("/debug/tablet_plans", qe.handleHTTPQueryPlans)
@@ -589,8 +611,33 @@ func (qe *QueryEngine) AddStats(planType planbuilder.PlanType, tableName, worklo
 	}
 }
 
+// AddQueryTimings records the timings of a query by table and by fingerprint,
+// if enabled.
+func (qe *QueryEngine) AddQueryTimings(plan *TabletPlan, tableName string, duration time.Duration) {
+	if qe.queryTimingsByTable != nil {
+		qe.queryTimingsByTable.Add([]string{qe.tableLimiter.Limit(tableName), plan.PlanID.String()}, duration)
+	}
+	if qe.queryTimingsByFingerprint != nil {
+		qe.queryTimingsByFingerprint.Add([]string{qe.fingerprintLimiter.Limit(sqlparser.QueryFingerprint(plan.Original))}, duration)
+	}
+}
+
+// AddResourceStats adds the MySQL-side costs of a query to the query engine
+// stats, by the fingerprint of the query. The fingerprints are capped like the
+// ones of the query timings.
+func (qe *QueryEngine) AddResourceStats(plan *TabletPlan, resources tabletenv.QueryResources) {
+	keys := []string{qe.fingerprintLimiter.Limit(sqlparser.QueryFingerprint(plan.Original))}
+	qe.queryRowsExamined.Add(keys, int64(resources.RowsExamined))
+	qe.queryTmpTables.Add(keys, int64(resources.TmpTables))
+	qe.queryTmpDiskTables.Add(keys, int64(resources.TmpDiskTables))
+	qe.querySortRows.Add(keys, int64(resources.SortRows))
+	qe.queryFullScans.Add(keys, int64(resources.FullScans))
+	qe.queryInnoDBReadBytes.Add(keys, int64(resources.InnoDBReadBytes))
+}
+
 type perQueryStats struct {
 	Query        string
+	Fingerprint  string
 	Table        string
 	Plan         planbuilder.PlanType
 	QueryCount   uint64
@@ -601,6 +648,18 @@ type perQueryStats struct {
 	ErrorCount   uint64
 }
 
+// getMaxResultSize returns the max result size, which the
+// vttablet.max_result_size cluster setting overrides.
+func (qe *QueryEngine) getMaxResultSize() int64 {
+	return int64(clustersettings.VTTabletMaxResultSize.GetOr(int(qe.maxResultSize.Load())))
+}
+
+// getWarnResultSize returns the warn result size, which the
+// vttablet.warn_result_size cluster setting overrides.
+func (qe *QueryEngine) getWarnResultSize() int64 {
+	return int64(clustersettings.VTTabletWarnResultSize.GetOr(int(qe.warnResultSize.Load())))
+}
+
 func (qe *QueryEngine) handleHTTPQueryPlans(response http.ResponseWriter, request *http.Request) {
 	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
 		acl.SendError(response, err)
@@ -630,6 +689,7 @@ func (qe *QueryEngine) handleHTTPQueryStats(response http.ResponseWriter, reques
 	qe.ForEachPlan(func(plan *TabletPlan) bool {
 		var pqstats perQueryStats
 		pqstats.Query = unicoded(sqlparser.TruncateForUI(plan.Original))
+		pqstats.Fingerprint = sqlparser.QueryFingerprint(plan.Original)
 		pqstats.Table = plan.TableName().String()
 		pqstats.Plan = plan.PlanID
 		pqstats.QueryCount, pqstats.Time, pqstats.MysqlTime, pqstats.RowsAffected, pqstats.RowsReturned, pqstats.ErrorCount = plan.Stats()
@@ -844,6 +844,42 @@ func TestAddQueryStats(t *testing.T) {
 	}
 }
 
+func TestAddQueryTimings(t *testing.T) {
+	config := tabletenv.NewDefaultConfig()
+	config.DB = newDBConfigs(fakesqldb.New(t))
+	config.QueryMetricsMaxTables = 1
+	config.QueryMetricsMaxFingerprints = 1
+	env := tabletenv.NewEnv(config, "TestAddQueryTimings")
+	se := schema.NewEngine(env)
+	qe := NewQueryEngine(env, se)
+
+	selectA := &TabletPlan{Plan: &planbuilder.Plan{PlanID: planbuilder.PlanSelect}, Original: "select * from a where id = :id"}
+	selectB := &TabletPlan{Plan: &planbuilder.Plan{PlanID: planbuilder.PlanSelect}, Original: "select * from b where id = :id"}
+	qe.AddQueryTimings(selectA, "a", time.Millisecond)
+	qe.AddQueryTimings(selectA, "a", time.Millisecond)
+	qe.AddQueryTimings(selectB, "b", time.Millisecond)
+
+	assert.Equal(t, map[string]int64{
+		"All":                              3,
+		"TestAddQueryTimings.a.Select":     2,
+		"TestAddQueryTimings.other.Select": 1,
+	}, qe.queryTimingsByTable.Counts())
+	assert.Equal(t, map[string]int64{
+		"All": 3,
+		"TestAddQueryTimings." + sqlparser.QueryFingerprint(selectA.Original): 2,
+		"TestAddQueryTimings.other": 1,
+	}, qe.queryTimingsByFingerprint.Counts())
+
+	// The metrics are disabled by default.
+	config = tabletenv.NewDefaultConfig()
+	config.DB = newDBConfigs(fakesqldb.New(t))
+	env = tabletenv.NewEnv(config, "TestAddQueryTimingsDisabled")
+	qe = NewQueryEngine(env, schema.NewEngine(env))
+	qe.AddQueryTimings(selectA, "a", time.Millisecond)
+	assert.Nil(t, qe.queryTimingsByTable)
+	assert.Nil(t, qe.queryTimingsByFingerprint)
+}
+
 func TestPlanPoolUnsafe(t *testing.T) {
 	tcases := []struct {
 		name, query, err string
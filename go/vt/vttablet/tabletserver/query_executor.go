@@ -121,7 +121,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	qre.logStats.PlanType = planName
 	defer func(start time.Time) {
 		duration := time.Since(start)
-		qre.tsv.stats.QueryTimings.Add(planName, duration)
+		qre.tsv.stats.QueryTimings.AddWithExemplar(planName, duration, trace.TraceID(qre.ctx))
 		qre.tsv.stats.QueryTimingsByTabletType.Add(qre.tabletType.String(), duration)
 		qre.recordUserQuery("Execute", int64(duration))
 
@@ -130,6 +130,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		if tableName == "" {
 			tableName = "Join"
 		}
+		qre.tsv.qe.AddQueryTimings(qre.plan, tableName, duration)
 
 		var errCode string
 		vtErrorCode := vterrors.Code(err)
@@ -143,6 +144,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 
 		qre.tsv.qe.AddStats(qre.plan.PlanID, tableName, qre.options.GetWorkloadName(), qre.tabletType, 1, duration, mysqlTime, int64(reply.RowsAffected), int64(len(reply.Rows)), 0, errCode)
 		qre.plan.AddStats(1, duration, mysqlTime, reply.RowsAffected, uint64(len(reply.Rows)), 0)
+		qre.addResourceStats()
 		qre.logStats.RowsAffected = int(reply.RowsAffected)
 		qre.logStats.Rows = reply.Rows
 		qre.tsv.Stats().ResultHistogram.Add(int64(len(reply.Rows)))
@@ -308,13 +310,17 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 }
 
 // Stream performs a streaming query execution.
-func (qre *QueryExecutor) Stream(callback StreamCallback) error {
+func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 	qre.logStats.PlanType = qre.plan.PlanID.String()
 
 	defer func(start time.Time) {
-		qre.tsv.stats.QueryTimings.Record(qre.plan.PlanID.String(), start)
+		qre.tsv.stats.QueryTimings.RecordWithExemplar(qre.plan.PlanID.String(), start, trace.TraceID(qre.ctx))
 		qre.tsv.stats.QueryTimingsByTabletType.Record(qre.tabletType.String(), start)
 		qre.recordUserQuery("Stream", int64(time.Since(start)))
+		qre.tsv.qe.AddQueryTimings(qre.plan, qre.plan.TableName().String(), time.Since(start))
+		if err == nil {
+			qre.addResourceStats()
+		}
 	}(time.Now())
 
 	if err := qre.checkPermissions(); err != nil {
@@ -333,6 +339,13 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		return err
 	}
 
+	limiter := qre.newStreamLimiter()
+	defer limiter.cancel()
+	callback = limiter.wrap(callback)
+	defer func() {
+		err = limiter.check(err)
+	}()
+
 	var replaceKeyspace string
 	if sqltypes.IncludeFieldsOrDefault(qre.options) == querypb.ExecuteOptions_ALL && qre.tsv.sm.target.Keyspace != qre.tsv.config.DB.DBName {
 		replaceKeyspace = qre.tsv.sm.target.Keyspace
@@ -396,6 +409,87 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 	})
 }
 
+// streamLimiter kills a streaming query once it exceeds the limits
+// configured for its caller.
+type streamLimiter struct {
+	qre    *QueryExecutor
+	limits tabletenv.StreamLimits
+	// parent is the context of the query before the limiter wrapped it.
+	parent context.Context
+	cancel context.CancelFunc
+
+	rows     int64
+	bytes    int64
+	exceeded error
+}
+
+// newStreamLimiter replaces the context of the query executor with one
+// that gets canceled, which kills the query, as soon as a limit is exceeded.
+// The limits are looked up by the immediate caller username, which is set by
+// vtgate and cannot be overridden by the client.
+func (qre *QueryExecutor) newStreamLimiter() *streamLimiter {
+	username := callerid.GetUsername(callerid.ImmediateCallerIDFromContext(qre.ctx))
+	sl := &streamLimiter{
+		qre:    qre,
+		limits: qre.tsv.config.Olap.StreamLimitsForUser(username),
+		parent: qre.ctx,
+	}
+	if sl.limits.MaxDuration > 0 {
+		qre.ctx, sl.cancel = context.WithTimeout(qre.ctx, sl.limits.MaxDuration)
+	} else {
+		qre.ctx, sl.cancel = context.WithCancel(qre.ctx)
+	}
+	return sl
+}
+
+// wrap returns a callback that accounts for the rows and bytes sent to the
+// client before calling the given callback.
+func (sl *streamLimiter) wrap(callback StreamCallback) StreamCallback {
+	if sl.limits.MaxRows <= 0 && sl.limits.MaxBytes <= 0 {
+		return callback
+	}
+	return func(result *sqltypes.Result) error {
+		sl.rows += int64(len(result.Rows))
+		for _, row := range result.Rows {
+			for _, value := range row {
+				sl.bytes += int64(value.Len())
+			}
+		}
+		switch {
+		case sl.limits.MaxRows > 0 && sl.rows > sl.limits.MaxRows:
+			return sl.exceed("Rows", "row count exceeded %d", sl.limits.MaxRows)
+		case sl.limits.MaxBytes > 0 && sl.bytes > sl.limits.MaxBytes:
+			return sl.exceed("Bytes", "result size exceeded %d bytes", sl.limits.MaxBytes)
+		}
+		return callback(result)
+	}
+}
+
+func (sl *streamLimiter) exceed(limit string, format string, args ...any) error {
+	if sl.exceeded == nil {
+		callerID := callerid.ImmediateCallerIDFromContext(sl.parent)
+		sl.exceeded = vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "caller id: %s: streaming query "+format, append([]any{callerID.Username}, args...)...)
+		sl.qre.tsv.Stats().StreamLimitKills.Add(limit, 1)
+		sl.cancel()
+	}
+	return sl.exceeded
+}
+
+// check returns the error to send to the client once the query is done:
+// the limit that was exceeded, if any, or the error of the query.
+func (sl *streamLimiter) check(err error) error {
+	if err == nil {
+		return nil
+	}
+	if sl.exceeded != nil {
+		return sl.exceeded
+	}
+	if sl.limits.MaxDuration > 0 && sl.qre.ctx.Err() == context.DeadlineExceeded && sl.parent.Err() == nil {
+		return sl.exceed("Duration", "duration exceeded %v", sl.limits.MaxDuration)
+	}
+	return err
+}
+
 // MessageStream streams messages from a message table.
 func (qre *QueryExecutor) MessageStream(callback StreamCallback) error {
 	qre.logStats.OriginalSQL = qre.query
@@ -443,7 +537,7 @@ func (qre *QueryExecutor) checkPermissions() error {
 		username = ci.Username()
 	}
 
-	action, ruleCancelCtx, timeout, desc := qre.plan.Rules.GetAction(remoteAddr, username, qre.bindVars, qre.marginComments)
+	action, ruleCancelCtx, timeout, desc := qre.plan.Rules.GetAction(remoteAddr, username, qre.bindVars, qre.marginComments, qre.options.GetQueryAttributes())
 
 	bufferingTimeoutCtx, cancel := context.WithTimeout(qre.ctx, timeout) // aborts buffering at given timeout
 	defer cancel()
@@ -704,6 +798,9 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 			} else {
 				defer conn.Recycle()
 				res, err := qre.execDBConn(conn.Conn, sql, true)
+				if err == nil {
+					qre.recordResources(conn.Conn)
+				}
 				q.SetResult(res)
 				q.SetErr(err)
 			}
@@ -727,11 +824,12 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	qre.recordResources(conn.Conn)
 	return res, nil
 }
 
 func (qre *QueryExecutor) execDMLLimit(conn *StatefulConnection) (*sqltypes.Result, error) {
-	maxrows := qre.tsv.qe.maxResultSize.Load()
+	maxrows := qre.tsv.qe.getMaxResultSize()
 	qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
 	result, err := qre.txFetch(conn, true)
 	if err != nil {
@@ -750,7 +848,7 @@ func (qre *QueryExecutor) verifyRowCount(count, maxrows int64) error {
 		callerID := callerid.ImmediateCallerIDFromContext(qre.ctx)
 		return vterrors.Errorf(vtrpcpb.Code_ABORTED, "caller id: %s: row count exceeded %d", callerID.Username, maxrows)
 	}
-	warnThreshold := qre.tsv.qe.warnResultSize.Load()
+	warnThreshold := qre.tsv.qe.getWarnResultSize()
 	if warnThreshold > 0 && count > warnThreshold {
 		callerID := callerid.ImmediateCallerIDFromContext(qre.ctx)
 		qre.tsv.Stats().Warnings.Add("ResultsExceeded", 1)
@@ -765,7 +863,12 @@ func (qre *QueryExecutor) execOther() (*sqltypes.Result, error) {
 		return nil, err
 	}
 	defer conn.Recycle()
-	return qre.execDBConn(conn.Conn, qre.query, true)
+	qr, err := qre.execDBConn(conn.Conn, qre.query, true)
+	if err != nil {
+		return nil, err
+	}
+	qre.recordResources(conn.Conn)
+	return qr, nil
 }
 
 func (qre *QueryExecutor) getConn() (*connpool.PooledConn, error) {
@@ -878,18 +981,16 @@ func (qre *QueryExecutor) execCallProc() (*sqltypes.Result, error) {
 	if err != nil {
 		return nil, rewriteOUTParamError(err)
 	}
-	if !qr.IsMoreResultsExists() {
-		if qr.IsInTransaction() {
-			conn.Close()
-			return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
-		}
-		return qr, nil
-	}
-	err = qre.drainResultSetOnConn(conn.Conn)
+	last, err := qre.fetchMoreResults(conn.Conn, qr)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
-	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
+	if last.IsInTransaction() {
+		conn.Close()
+		return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
+	}
+	return qr, nil
 }
 
 func (qre *QueryExecutor) execProc(conn *StatefulConnection) (*sqltypes.Result, error) {
@@ -902,19 +1003,17 @@ func (qre *QueryExecutor) execProc(conn *StatefulConnection) (*sqltypes.Result,
 	if err != nil {
 		return nil, rewriteOUTParamError(err)
 	}
-	if !qr.IsMoreResultsExists() {
-		afterInTx := qr.IsInTransaction()
-		if beforeInTx != afterInTx {
-			conn.Close()
-			return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
-		}
-		return qr, nil
-	}
-	err = qre.drainResultSetOnConn(conn.UnderlyingDBConn().Conn)
+	last, err := qre.fetchMoreResults(conn.UnderlyingDBConn().Conn, qr)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
-	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
+	afterInTx := last.IsInTransaction()
+	if beforeInTx != afterInTx {
+		conn.Close()
+		return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
+	}
+	return qr, nil
 }
 
 func (qre *QueryExecutor) execAlterMigration() (*sqltypes.Result, error) {
@@ -1047,20 +1146,24 @@ func (qre *QueryExecutor) execShowThrottlerStatus() (*sqltypes.Result, error) {
 	return result, nil
 }
 
-func (qre *QueryExecutor) drainResultSetOnConn(conn *connpool.Conn) error {
-	more := true
-	for more {
-		qr, err := conn.FetchNext(qre.ctx, int(qre.getSelectLimit()), true)
+// fetchMoreResults reads the result sets following qr on the connection into
+// qr.MoreResults. It returns the last result set, whose status flags are the
+// ones of the connection once the query is done.
+func (qre *QueryExecutor) fetchMoreResults(conn *connpool.Conn, qr *sqltypes.Result) (*sqltypes.Result, error) {
+	last := qr
+	for last.IsMoreResultsExists() {
+		next, err := conn.FetchNext(qre.ctx, int(qre.getSelectLimit()), true)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		more = qr.IsMoreResultsExists()
+		qr.MoreResults = append(qr.MoreResults, next)
+		last = next
 	}
-	return nil
+	return last, nil
 }
 
 func (qre *QueryExecutor) getSelectLimit() int64 {
-	return qre.tsv.qe.maxResultSize.Load()
+	return qre.tsv.qe.getMaxResultSize()
 }
 
 func (qre *QueryExecutor) execDBConn(conn *connpool.Conn, sql string, wantfields bool) (*sqltypes.Result, error) {
@@ -1073,7 +1176,7 @@ func (qre *QueryExecutor) execDBConn(conn *connpool.Conn, sql string, wantfields
 	qre.tsv.statelessql.Add(qd)
 	defer qre.tsv.statelessql.Remove(qd)
 
-	return conn.Exec(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
+	return conn.Exec(ctx, sql, int(qre.tsv.qe.getMaxResultSize()), wantfields)
 }
 
 func (qre *QueryExecutor) execStatefulConn(conn *StatefulConnection, sql string, wantfields bool) (*sqltypes.Result, error) {
@@ -1086,7 +1189,7 @@ func (qre *QueryExecutor) execStatefulConn(conn *StatefulConnection, sql string,
 	qre.tsv.statefulql.Add(qd)
 	defer qre.tsv.statefulql.Remove(qd)
 
-	return conn.Exec(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
+	return conn.Exec(ctx, sql, int(qre.tsv.qe.getMaxResultSize()), wantfields)
 }
 
 func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction bool, sql string, callback func(*sqltypes.Result) error) error {
@@ -1106,14 +1209,49 @@ func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction
 	// This change will ensure that long-running streaming stateful queries get gracefully shutdown during ServingTypeChange
 	// once their grace period is over.
 	qd := NewQueryDetail(qre.logStats.Ctx, conn.Conn)
+	var err error
 	if isTransaction {
 		qre.tsv.statefulql.Add(qd)
 		defer qre.tsv.statefulql.Remove(qd)
-		return conn.Conn.StreamOnce(ctx, sql, callBackClosingSpan, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+		err = conn.Conn.StreamOnce(ctx, sql, callBackClosingSpan, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+	} else {
+		qre.tsv.olapql.Add(qd)
+		defer qre.tsv.olapql.Remove(qd)
+		err = conn.Conn.Stream(ctx, sql, callBackClosingSpan, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+	}
+	if err != nil {
+		return err
+	}
+	if !isTransaction {
+		qre.recordResources(conn.Conn)
+	}
+	return nil
+}
+
+// recordResources adds the MySQL-side cost of the statement that just ran on
+// the given pooled connection to the query log stats, if resource accounting
+// is on. It must only be used with connections that are neither reserved nor
+// in a transaction, whose session state can't be observed by later queries.
+func (qre *QueryExecutor) recordResources(conn *connpool.Conn) {
+	if !qre.tsv.config.EnableResourceAccounting {
+		return
+	}
+	resources, err := conn.LastStatementResources(qre.ctx)
+	if err != nil {
+		qre.tsv.Stats().InternalErrors.Add("ResourceAccounting", 1)
+		logResourceAccounting.Warningf("cannot read the resources of the last statement from performance_schema: %v", err)
+		return
+	}
+	qre.logStats.Resources.Add(resources)
+}
+
+// addResourceStats adds the MySQL-side cost of the query to the query engine
+// stats, if resource accounting is on.
+func (qre *QueryExecutor) addResourceStats() {
+	if !qre.tsv.config.EnableResourceAccounting {
+		return
 	}
-	qre.tsv.olapql.Add(qd)
-	defer qre.tsv.olapql.Remove(qd)
-	return conn.Conn.Stream(ctx, sql, callBackClosingSpan, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+	qre.tsv.qe.AddResourceStats(qre.plan, qre.logStats.Resources)
 }
 
 func (qre *QueryExecutor) recordUserQuery(queryType string, duration int64) {
@@ -31,11 +31,13 @@ import (
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/fakesqldb"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/callinfo/fakecallinfo"
 	"vitess.io/vitess/go/vt/sidecardb"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/tableacl"
 	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
@@ -1296,6 +1298,153 @@ func TestReplaceSchemaName(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorStreamLimits(t *testing.T) {
+	query := "select * from test_table"
+	result := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt32(1), sqltypes.NewInt32(10), sqltypes.NewInt32(100)},
+			{sqltypes.NewInt32(2), sqltypes.NewInt32(20), sqltypes.NewInt32(200)},
+			{sqltypes.NewInt32(3), sqltypes.NewInt32(30), sqltypes.NewInt32(300)},
+		},
+	}
+	maxRows := int64(10)
+	testcases := []struct {
+		name        string
+		maxRows     int
+		maxBytes    int64
+		maxDuration time.Duration
+		user        string
+		err         string
+		limit       string
+	}{{
+		name: "no limits",
+	}, {
+		name:    "under the row limit",
+		maxRows: 3,
+	}, {
+		name:    "over the row limit",
+		maxRows: 2,
+		err:     "caller id: d: streaming query row count exceeded 2",
+		limit:   "Rows",
+	}, {
+		name:     "over the byte limit",
+		maxBytes: 10,
+		err:      "caller id: d: streaming query result size exceeded 10 bytes",
+		limit:    "Bytes",
+	}, {
+		name:        "over the duration limit",
+		maxDuration: 10 * time.Millisecond,
+		err:         "caller id: d: streaming query duration exceeded 10ms",
+		limit:       "Duration",
+	}, {
+		name:    "user override",
+		maxRows: 2,
+		user:    "etl",
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			db := setUpQueryExecutorTest(t)
+			defer db.Close()
+			db.AddQuery(query, result)
+			if tcase.maxDuration > 0 {
+				db.SetBeforeFunc(query, func() {
+					time.Sleep(10 * tcase.maxDuration)
+				})
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			tsv := newTestTabletServer(ctx, noFlags, db)
+			defer tsv.StopService()
+			tsv.config.Olap.MaxRows = tcase.maxRows
+			tsv.config.Olap.MaxBytes = tcase.maxBytes
+			tsv.config.Olap.MaxDuration = tcase.maxDuration
+			tsv.config.Olap.UserLimits = tabletenv.StreamLimitOverrides{"etl": {MaxRows: &maxRows}}
+
+			user := "d"
+			if tcase.user != "" {
+				user = tcase.user
+			}
+			ctx = callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID(user))
+			qre := newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+			killsBefore := tsv.stats.StreamLimitKills.Counts()[tcase.limit]
+
+			var rows int
+			err := qre.Stream(func(qr *sqltypes.Result) error {
+				rows += len(qr.Rows)
+				return nil
+			})
+			if tcase.err != "" {
+				require.EqualError(t, err, tcase.err)
+				assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+				assert.Equal(t, killsBefore+1, tsv.stats.StreamLimitKills.Counts()[tcase.limit])
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 3, rows)
+		})
+	}
+}
+
+func TestQueryExecutorResourceAccounting(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table"
+	db.AddQuery("select * from test_table limit 10001", &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt32(1), sqltypes.NewInt32(10), sqltypes.NewInt32(100)},
+		},
+	})
+	db.AddQuery(query, &sqltypes.Result{Fields: getTestTableFields()})
+	db.AddQueryPattern("select s.rows_examined, .* from performance_schema.events_statements_history .*", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("rows_examined|created_tmp_tables|created_tmp_disk_tables|sort_rows|scans|innodb_read_bytes", "uint64|uint64|uint64|uint64|uint64|decimal"),
+		"100|1|1|50|1|16384",
+	))
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.fingerprintLimiter = stats.NewLabelLimiter(10)
+	fingerprint := sqlparser.QueryFingerprint(query)
+
+	// Nothing is recorded unless resource accounting is enabled.
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, tabletenv.QueryResources{}, qre.logStats.Resources)
+
+	tsv.config.EnableResourceAccounting = true
+	want := tabletenv.QueryResources{RowsExamined: 100, TmpTables: 1, TmpDiskTables: 1, SortRows: 50, FullScans: 1, InnoDBReadBytes: 16384}
+
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, qre.logStats.Resources)
+	assert.Equal(t, fmt.Sprintf(`{"%s": 100}`, fingerprint), tsv.qe.queryRowsExamined.String())
+	assert.Equal(t, fmt.Sprintf(`{"%s": 16384}`, fingerprint), tsv.qe.queryInnoDBReadBytes.String())
+
+	// The stream of the same query has the same fingerprint.
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	err = qre.Stream(func(*sqltypes.Result) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, want, qre.logStats.Resources)
+	assert.Equal(t, fmt.Sprintf(`{"%s": 200}`, fingerprint), tsv.qe.queryRowsExamined.String())
+
+	// Nothing is read from performance_schema on transaction connections, as
+	// it would reset the session state of their last statement.
+	txID := newTransaction(tsv, nil)
+	db.ResetQueryLog()
+	qre = newTestQueryExecutor(ctx, tsv, query, txID)
+	_, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, tabletenv.QueryResources{}, qre.logStats.Resources)
+	assert.NotContains(t, db.QueryLog(), "performance_schema")
+	_, err = tsv.Rollback(ctx, tsv.sm.Target(), txID)
+	require.NoError(t, err)
+}
+
 func TestQueryExecutorShouldConsolidate(t *testing.T) {
 	testCases := []struct {
 		// whether or not the consolidator is enabled by default on the tablet
@@ -176,13 +176,14 @@ func (qrs *Rules) GetAction(
 	user string,
 	bindVars map[string]*querypb.BindVariable,
 	marginComments sqlparser.MarginComments,
+	queryAttributes map[string]string,
 ) (
 	action Action,
 	cancelCtx context.Context,
 	timeout time.Duration,
 	desc string) {
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
+		if act := qr.GetAction(ip, user, bindVars, marginComments, queryAttributes); act != QRContinue {
 			return act, qr.cancelCtx, qr.timeout, qr.Description
 		}
 	}
@@ -217,6 +218,10 @@ type Rule struct {
 	// All BindVar conditions have to be fulfilled to make this true (AND)
 	bindVarConds []BindVarCond
 
+	// Regexp conditions on the query attributes sent by the MySQL client,
+	// by attribute name. All of them have to match (AND).
+	queryAttributes map[string]namedRegexp
+
 	// Action to be performed on trigger
 	act Action
 
@@ -273,9 +278,22 @@ func (qr *Rule) Equal(other *Rule) bool {
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
+		queryAttributesEqual(qr.queryAttributes, other.queryAttributes) &&
 		qr.act == other.act)
 }
 
+func queryAttributesEqual(a, b map[string]namedRegexp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, re := range a {
+		if otherRe, ok := b[name]; !ok || !re.Equal(otherRe) {
+			return false
+		}
+	}
+	return true
+}
+
 // Copy performs a deep copy of a Rule.
 func (qr *Rule) Copy() (newqr *Rule) {
 	newqr = &Rule{
@@ -302,6 +320,12 @@ func (qr *Rule) Copy() (newqr *Rule) {
 		newqr.bindVarConds = make([]BindVarCond, len(qr.bindVarConds))
 		copy(newqr.bindVarConds, qr.bindVarConds)
 	}
+	if qr.queryAttributes != nil {
+		newqr.queryAttributes = make(map[string]namedRegexp, len(qr.queryAttributes))
+		for name, re := range qr.queryAttributes {
+			newqr.queryAttributes[name] = re
+		}
+	}
 	return newqr
 }
 
@@ -334,6 +358,9 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.bindVarConds != nil {
 		safeEncode(b, `,"BindVarConds":`, qr.bindVarConds)
 	}
+	if qr.queryAttributes != nil {
+		safeEncode(b, `,"QueryAttributes":`, qr.queryAttributes)
+	}
 	if qr.act != QRContinue {
 		safeEncode(b, `,"Action":`, qr.act)
 	}
@@ -395,6 +422,22 @@ func (qr *Rule) SetTrailingCommentCond(pattern string) (err error) {
 	return
 }
 
+// AddQueryAttributeCond adds a regular expression condition for the value
+// of a query attribute sent by the MySQL client with the query. A query
+// without the attribute doesn't match the condition.
+func (qr *Rule) AddQueryAttributeCond(name, pattern string) (err error) {
+	re := namedRegexp{name: pattern}
+	re.Regexp, err = regexp.Compile(makeExact(pattern))
+	if err != nil {
+		return err
+	}
+	if qr.queryAttributes == nil {
+		qr.queryAttributes = make(map[string]namedRegexp)
+	}
+	qr.queryAttributes[name] = re
+	return nil
+}
+
 // makeExact forces a full string match for the regex instead of substring
 func makeExact(pattern string) string {
 	return fmt.Sprintf("^%s$", pattern)
@@ -487,6 +530,7 @@ func (qr *Rule) GetAction(
 	user string,
 	bindVars map[string]*querypb.BindVariable,
 	marginComments sqlparser.MarginComments,
+	queryAttributes map[string]string,
 ) Action {
 	if qr.cancelCtx != nil {
 		select {
@@ -515,6 +559,11 @@ func (qr *Rule) GetAction(
 			return QRContinue
 		}
 	}
+	for name, re := range qr.queryAttributes {
+		if value, ok := queryAttributes[name]; !ok || !re.MatchString(value) {
+			return QRContinue
+		}
+	}
 	return qr.act
 }
 
@@ -875,6 +924,7 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 	for k, v := range ruleInfo {
 		var sv string
 		var lv []any
+		var mv map[string]any
 		var ok bool
 		switch k {
 		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment":
@@ -887,6 +937,11 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "QueryAttributes":
+			mv, ok = v.(map[string]any)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want json object for %s", k)
+			}
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -951,6 +1006,17 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 					return nil, err
 				}
 			}
+		case "QueryAttributes":
+			for name, pattern := range mv {
+				pv, ok := pattern.(string)
+				if !ok {
+					return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for QueryAttributes")
+				}
+				err = qr.AddQueryAttributeCond(name, pv)
+				if err != nil {
+					return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "could not set QueryAttributes condition: %v", pv)
+				}
+			}
 		case "Action":
 			switch sv {
 			case "FAIL":
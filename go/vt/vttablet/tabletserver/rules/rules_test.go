@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -543,23 +544,23 @@ func TestAction(t *testing.T) {
 		Trailing: "other trailing comments",
 	}
 
-	action, cancelCtx, timeout, desc := qrs.GetAction("123", "user1", bv, mc)
+	action, cancelCtx, timeout, desc := qrs.GetAction("123", "user1", bv, mc, nil)
 	assert.Equalf(t, action, QRFail, "expected fail, got %v", action)
 	assert.Equalf(t, timeout, time.Duration(0), "expected zero timeout")
 	assert.Equalf(t, desc, "rule 1", "want rule 1, got %s", desc)
 	assert.Nil(t, cancelCtx)
 
-	action, cancelCtx, timeout, desc = qrs.GetAction("1234", "user", bv, mc)
+	action, cancelCtx, timeout, desc = qrs.GetAction("1234", "user", bv, mc, nil)
 	assert.Equalf(t, action, QRFailRetry, "want fail_retry, got: %s", action)
 	assert.Equalf(t, timeout, time.Duration(0), "expected zero timeout")
 	assert.Equalf(t, desc, "rule 2", "want rule 2, got %s", desc)
 	assert.Nil(t, cancelCtx)
 
-	action, _, _, _ = qrs.GetAction("1234", "user1", bv, mc)
+	action, _, _, _ = qrs.GetAction("1234", "user1", bv, mc, nil)
 	assert.Equalf(t, action, QRContinue, "want continue, got %s", action)
 
 	bv["a"] = sqltypes.Uint64BindVariable(1)
-	action, _, _, desc = qrs.GetAction("1234", "user1", bv, mc)
+	action, _, _, desc = qrs.GetAction("1234", "user1", bv, mc, nil)
 	assert.Equalf(t, action, QRFail, "want fail, got %s", action)
 	assert.Equalf(t, desc, "rule 3", "want rule 3, got %s", desc)
 
@@ -572,7 +573,7 @@ func TestAction(t *testing.T) {
 	newQrs := qrs.Copy()
 	newQrs.Add(qr4)
 
-	action, _, _, desc = newQrs.GetAction("1234", "user1", bv, mc)
+	action, _, _, desc = newQrs.GetAction("1234", "user1", bv, mc, nil)
 	assert.Equalf(t, action, QRFail, "want fail, got %s", action)
 	assert.Equalf(t, desc, "rule 4", "want rule 4, got %s", desc)
 
@@ -581,9 +582,23 @@ func TestAction(t *testing.T) {
 
 	newQrs = qrs.Copy()
 	newQrs.Add(qr5)
-	action, _, _, desc = newQrs.GetAction("1234", "user1", bv, mc)
+	action, _, _, desc = newQrs.GetAction("1234", "user1", bv, mc, nil)
 	assert.Equalf(t, action, QRFail, "want fail, got %s", action)
 	assert.Equalf(t, desc, "rule 5", "want rule 5, got %s", desc)
+
+	qr6 := NewQueryRule("rule 6", "r6", QRFail)
+	err := qr6.AddQueryAttributeCond("app", "batch.*")
+	require.NoError(t, err)
+
+	newQrs = qrs.Copy()
+	newQrs.Add(qr6)
+	action, _, _, desc = newQrs.GetAction("1234", "user1", bv, mc, map[string]string{"app": "batch-reports"})
+	assert.Equalf(t, action, QRFail, "want fail, got %s", action)
+	assert.Equalf(t, desc, "rule 6", "want rule 6, got %s", desc)
+	action, _, _, _ = newQrs.GetAction("1234", "user1", bv, mc, map[string]string{"app": "web"})
+	assert.Equalf(t, action, QRContinue, "want continue, got %s", action)
+	action, _, _, _ = newQrs.GetAction("1234", "user1", bv, mc, nil)
+	assert.Equalf(t, action, QRContinue, "want continue, got %s", action)
 }
 
 func TestImport(t *testing.T) {
@@ -607,6 +622,7 @@ func TestImport(t *testing.T) {
 			"Operator": "==",
 			"Value": 123
 		}],
+		"QueryAttributes": {"app": "batch.*"},
 		"Action": "FAIL_RETRY"
 	},{
 		"Description": "desc2",
@@ -20,6 +20,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -146,6 +149,10 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&currentConfig.SignalWhenSchemaChange, "queryserver-config-schema-change-signal", defaultConfig.SignalWhenSchemaChange, "query server schema signal, will signal connected vtgates that schema has changed whenever this is detected. VTGates will need to have -schema_change_signal enabled for this to work")
 	currentConfig.Olap.TxTimeoutSeconds = defaultConfig.Olap.TxTimeoutSeconds.Clone()
 	fs.Var(&currentConfig.Olap.TxTimeoutSeconds, defaultConfig.Olap.TxTimeoutSeconds.Name(), "query server transaction timeout (in seconds), after which a transaction in an OLAP session will be killed")
+	fs.IntVar(&currentConfig.Olap.MaxRows, "queryserver-config-stream-max-rows", defaultConfig.Olap.MaxRows, "query server stream max rows, a streaming query will be killed if it returns more than this many rows (0 means unlimited)")
+	fs.Int64Var(&currentConfig.Olap.MaxBytes, "queryserver-config-stream-max-bytes", defaultConfig.Olap.MaxBytes, "query server stream max bytes, a streaming query will be killed if it returns more than this many bytes of row data (0 means unlimited)")
+	fs.DurationVar(&currentConfig.Olap.MaxDuration, "queryserver-config-stream-max-duration", defaultConfig.Olap.MaxDuration, "query server stream max duration, a streaming query will be killed if it runs for longer than this (0 means unlimited)")
+	fs.Var(&currentConfig.Olap.UserLimits, "queryserver-config-stream-user-limits", "comma-separated list of per-user overrides of the streaming query limits, in the form user:max_rows:max_bytes:max_duration. The user is the immediate caller (vtgate) username. An empty field keeps the global limit, 0 means unlimited. E.g. etl:1000000::1h,reports:::10m")
 	currentConfig.Oltp.QueryTimeoutSeconds = defaultConfig.Oltp.QueryTimeoutSeconds.Clone()
 	fs.Var(&currentConfig.Oltp.QueryTimeoutSeconds, currentConfig.Oltp.QueryTimeoutSeconds.Name(), "query server query timeout (in seconds), this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
 	currentConfig.OltpReadPool.TimeoutSeconds = defaultConfig.OltpReadPool.TimeoutSeconds.Clone()
@@ -227,6 +234,10 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&currentConfig.EnableViews, "queryserver-enable-views", false, "Enable views support in vttablet.")
 
 	fs.BoolVar(&currentConfig.EnablePerWorkloadTableMetrics, "enable-per-workload-table-metrics", defaultConfig.EnablePerWorkloadTableMetrics, "If true, query counts and query error metrics include a label that identifies the workload")
+	fs.IntVar(&currentConfig.QueryMetricsMaxTables, "queryserver-query-metrics-max-tables", defaultConfig.QueryMetricsMaxTables, "Maximum number of tables with their own query timings in QueryTimingsByTable, the queries of the other tables being recorded under the \"other\" table. 0 disables the metric.")
+	fs.IntVar(&currentConfig.QueryMetricsMaxFingerprints, "queryserver-query-metrics-max-fingerprints", defaultConfig.QueryMetricsMaxFingerprints, "Maximum number of query fingerprints with their own query timings in QueryTimingsByFingerprint, the other queries being recorded under the \"other\" fingerprint. 0 disables the metric.")
+	fs.BoolVar(&currentConfig.EnableResourceAccounting, "queryserver-enable-resource-accounting", defaultConfig.EnableResourceAccounting, "If true, the MySQL-side cost of each query run outside of transactions and reserved connections (rows examined, temporary tables, sorted rows, full scans, InnoDB read bytes) is read from performance_schema after it runs, and recorded in the query log and query stats. This requires the performance_schema statement history to be enabled, and costs one extra round trip per query.")
+	fs.StringSliceVar(&currentConfig.ForwardedQueryAttributes, "queryserver-forwarded-query-attributes", defaultConfig.ForwardedQueryAttributes, "Comma-separated list of the names of the query attributes, sent by the MySQL clients of vtgate with their queries, which are sent to MySQL with the queries. Use * to forward all of them.")
 }
 
 var (
@@ -376,6 +387,17 @@ type TabletConfig struct {
 	EnableViews bool `json:"-"`
 
 	EnablePerWorkloadTableMetrics bool `json:"-"`
+
+	// QueryMetricsMaxTables and QueryMetricsMaxFingerprints cap the number of
+	// tables and query fingerprints with their own query timings.
+	QueryMetricsMaxTables       int `json:"-"`
+	QueryMetricsMaxFingerprints int `json:"-"`
+
+	EnableResourceAccounting bool `json:"-"`
+
+	// ForwardedQueryAttributes are the names of the query attributes, sent by the
+	// MySQL clients with their queries, which are sent to MySQL with the queries.
+	ForwardedQueryAttributes []string `json:"-"`
 }
 
 func (cfg *TabletConfig) MarshalJSON() ([]byte, error) {
@@ -441,6 +463,12 @@ func (cfg *ConnPoolConfig) MarshalJSON() ([]byte, error) {
 // OlapConfig contains the config for olap settings.
 type OlapConfig struct {
 	TxTimeoutSeconds flagutil.DeprecatedFloat64Seconds `json:"txTimeoutSeconds,omitempty"`
+	// MaxRows, MaxBytes and MaxDuration limit streaming queries. Zero means unlimited.
+	MaxRows     int           `json:"maxRows,omitempty"`
+	MaxBytes    int64         `json:"maxBytes,omitempty"`
+	MaxDuration time.Duration `json:"maxDuration,omitempty"`
+	// UserLimits overrides the streaming query limits for specific users.
+	UserLimits StreamLimitOverrides `json:"-"`
 }
 
 func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
@@ -449,6 +477,7 @@ func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
 	tmp := struct {
 		Proxy
 		TxTimeoutSeconds string `json:"txTimeoutSeconds,omitempty"`
+		MaxDuration      string `json:"maxDuration,omitempty"`
 	}{
 		Proxy: Proxy(*cfg),
 	}
@@ -457,9 +486,125 @@ func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
 		tmp.TxTimeoutSeconds = d.String()
 	}
 
+	if d := cfg.MaxDuration; d != 0 {
+		tmp.MaxDuration = d.String()
+	}
+
 	return json.Marshal(&tmp)
 }
 
+// StreamLimitsForUser returns the streaming query limits that apply to the given user.
+func (cfg *OlapConfig) StreamLimitsForUser(user string) StreamLimits {
+	limits := StreamLimits{
+		MaxRows:     int64(cfg.MaxRows),
+		MaxBytes:    cfg.MaxBytes,
+		MaxDuration: cfg.MaxDuration,
+	}
+	override, ok := cfg.UserLimits[user]
+	if !ok {
+		return limits
+	}
+	if override.MaxRows != nil {
+		limits.MaxRows = *override.MaxRows
+	}
+	if override.MaxBytes != nil {
+		limits.MaxBytes = *override.MaxBytes
+	}
+	if override.MaxDuration != nil {
+		limits.MaxDuration = *override.MaxDuration
+	}
+	return limits
+}
+
+// StreamLimits are the limits enforced on a streaming query. Zero means unlimited.
+type StreamLimits struct {
+	MaxRows     int64
+	MaxBytes    int64
+	MaxDuration time.Duration
+}
+
+// StreamLimitOverride overrides some of the streaming query limits for a user.
+// A nil field keeps the global limit.
+type StreamLimitOverride struct {
+	MaxRows     *int64
+	MaxBytes    *int64
+	MaxDuration *time.Duration
+}
+
+// StreamLimitOverrides maps a username to its streaming query limits. It
+// implements pflag.Value, see the --queryserver-config-stream-user-limits flag.
+type StreamLimitOverrides map[string]StreamLimitOverride
+
+// Set is part of the pflag.Value interface.
+func (o *StreamLimitOverrides) Set(arg string) error {
+	if *o == nil {
+		*o = StreamLimitOverrides{}
+	}
+	for _, entry := range strings.Split(arg, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, ":")
+		if len(fields) != 4 || fields[0] == "" {
+			return fmt.Errorf("invalid stream limit override %q, expected user:max_rows:max_bytes:max_duration", entry)
+		}
+		var override StreamLimitOverride
+		if fields[1] != "" {
+			v, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid max_rows in stream limit override %q: %v", entry, err)
+			}
+			override.MaxRows = &v
+		}
+		if fields[2] != "" {
+			v, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid max_bytes in stream limit override %q: %v", entry, err)
+			}
+			override.MaxBytes = &v
+		}
+		if fields[3] != "" {
+			v, err := time.ParseDuration(fields[3])
+			if err != nil {
+				return fmt.Errorf("invalid max_duration in stream limit override %q: %v", entry, err)
+			}
+			override.MaxDuration = &v
+		}
+		(*o)[fields[0]] = override
+	}
+	return nil
+}
+
+// String is part of the pflag.Value interface.
+func (o *StreamLimitOverrides) String() string {
+	users := make([]string, 0, len(*o))
+	for user := range *o {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+
+	entries := make([]string, 0, len(users))
+	for _, user := range users {
+		override := (*o)[user]
+		var rows, bytes, duration string
+		if override.MaxRows != nil {
+			rows = strconv.FormatInt(*override.MaxRows, 10)
+		}
+		if override.MaxBytes != nil {
+			bytes = strconv.FormatInt(*override.MaxBytes, 10)
+		}
+		if override.MaxDuration != nil {
+			duration = override.MaxDuration.String()
+		}
+		entries = append(entries, strings.Join([]string{user, rows, bytes, duration}, ":"))
+	}
+	return strings.Join(entries, ",")
+}
+
+// Type is part of the pflag.Value interface.
+func (o *StreamLimitOverrides) Type() string { return "string" }
+
 // OltpConfig contains the config for oltp settings.
 type OltpConfig struct {
 	QueryTimeoutSeconds flagutil.DeprecatedFloat64Seconds `json:"queryTimeoutSeconds,omitempty"`
@@ -328,6 +328,22 @@ func TestFlags(t *testing.T) {
 	assert.Equal(t, want, currentConfig)
 }
 
+func TestStreamLimitOverrides(t *testing.T) {
+	var o StreamLimitOverrides
+	require.NoError(t, o.Set("etl:1000::1h,reports:::10m"))
+	assert.Equal(t, "etl:1000::1h0m0s,reports:::10m0s", o.String())
+
+	cfg := OlapConfig{MaxRows: 10, MaxBytes: 100, MaxDuration: time.Minute, UserLimits: o}
+	assert.Equal(t, StreamLimits{MaxRows: 1000, MaxBytes: 100, MaxDuration: time.Hour}, cfg.StreamLimitsForUser("etl"))
+	assert.Equal(t, StreamLimits{MaxRows: 10, MaxBytes: 100, MaxDuration: 10 * time.Minute}, cfg.StreamLimitsForUser("reports"))
+	assert.Equal(t, StreamLimits{MaxRows: 10, MaxBytes: 100, MaxDuration: time.Minute}, cfg.StreamLimitsForUser("other"))
+
+	assert.Error(t, o.Set("etl:1000"))
+	assert.Error(t, o.Set(":1:2:3s"))
+	assert.Error(t, o.Set("etl:x::"))
+	assert.Error(t, o.Set("etl:::forever"))
+}
+
 func TestTxThrottlerConfigFlag(t *testing.T) {
 	f := NewTxThrottlerConfigFlag()
 	defaultMaxReplicationLagModuleConfig := throttler.DefaultMaxReplicationLagModuleConfig().Configuration
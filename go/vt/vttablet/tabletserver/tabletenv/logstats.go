@@ -18,6 +18,7 @@ package tabletenv
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -62,6 +63,32 @@ type LogStats struct {
 	ReservedID           int64
 	Error                error
 	CachedPlan           bool
+	// Resources is the MySQL-side cost of the query, only recorded when
+	// --queryserver-enable-resource-accounting is set.
+	Resources QueryResources
+	// QueryAttributes are the query attributes sent by the MySQL client with the query.
+	QueryAttributes map[string]string
+}
+
+// QueryResources is the MySQL-side cost of one or more statements, as
+// reported by performance_schema.
+type QueryResources struct {
+	RowsExamined    uint64
+	TmpTables       uint64
+	TmpDiskTables   uint64
+	SortRows        uint64
+	FullScans       uint64
+	InnoDBReadBytes uint64
+}
+
+// Add adds the resources of another statement.
+func (r *QueryResources) Add(other QueryResources) {
+	r.RowsExamined += other.RowsExamined
+	r.TmpTables += other.TmpTables
+	r.TmpDiskTables += other.TmpDiskTables
+	r.SortRows += other.SortRows
+	r.FullScans += other.FullScans
+	r.InnoDBReadBytes += other.InnoDBReadBytes
 }
 
 // NewLogStats constructs a new LogStats with supplied Method and ctx
@@ -202,9 +229,22 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 	var fmtString string
 	switch streamlog.GetQueryLogFormat() {
 	case streamlog.QueryLogFormatText:
-		fmtString = "%v\t%v\t%v\t'%v'\t'%v'\t%v\t%v\t%.6f\t%v\t%q\t%v\t%v\t%q\t%v\t%.6f\t%.6f\t%v\t%v\t%v\t%q\t\n"
+		fmtString = "%v\t%v\t%v\t'%v'\t'%v'\t%v\t%v\t%.6f\t%v\t%q\t%v\t%v\t%q\t%v\t%.6f\t%.6f\t%v\t%v\t%v\t%q\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n"
 	case streamlog.QueryLogFormatJSON:
-		fmtString = "{\"Method\": %q, \"CallInfo\": %q, \"Username\": %q, \"ImmediateCaller\": %q, \"Effective Caller\": %q, \"Start\": \"%v\", \"End\": \"%v\", \"TotalTime\": %.6f, \"PlanType\": %q, \"OriginalSQL\": %q, \"BindVars\": %v, \"Queries\": %v, \"RewrittenSQL\": %q, \"QuerySources\": %q, \"MysqlTime\": %.6f, \"ConnWaitTime\": %.6f, \"RowsAffected\": %v,\"TransactionID\": %v,\"ResponseSize\": %v, \"Error\": %q}\n"
+		fmtString = "{\"Method\": %q, \"CallInfo\": %q, \"Username\": %q, \"ImmediateCaller\": %q, \"Effective Caller\": %q, \"Start\": \"%v\", \"End\": \"%v\", \"TotalTime\": %.6f, \"PlanType\": %q, \"OriginalSQL\": %q, \"BindVars\": %v, \"Queries\": %v, \"RewrittenSQL\": %q, \"QuerySources\": %q, \"MysqlTime\": %.6f, \"ConnWaitTime\": %.6f, \"RowsAffected\": %v,\"TransactionID\": %v,\"ResponseSize\": %v, \"Error\": %q, \"RowsExamined\": %v, \"TmpTables\": %v, \"TmpDiskTables\": %v, \"SortRows\": %v, \"FullScans\": %v, \"InnoDBReadBytes\": %v, \"QueryAttributes\": %v}\n"
+	}
+
+	formattedQueryAttributes := []byte("\"[REDACTED]\"")
+	if !streamlog.GetRedactDebugUIQueries() {
+		queryAttributes := stats.QueryAttributes
+		if queryAttributes == nil {
+			queryAttributes = map[string]string{}
+		}
+		var err error
+		formattedQueryAttributes, err = json.Marshal(queryAttributes)
+		if err != nil {
+			return err
+		}
 	}
 
 	_, err := fmt.Fprintf(
@@ -230,6 +270,13 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 		stats.TransactionID,
 		stats.SizeOfResponse(),
 		stats.ErrorStr(),
+		stats.Resources.RowsExamined,
+		stats.Resources.TmpTables,
+		stats.Resources.TmpDiskTables,
+		stats.Resources.SortRows,
+		stats.Resources.FullScans,
+		stats.Resources.InnoDBReadBytes,
+		string(formattedQueryAttributes),
 	)
 	return err
 }
@@ -0,0 +1,39 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletenv
+
+import (
+	"context"
+)
+
+type queryAttributesContextKey int
+
+// WithQueryAttributes returns a context carrying the query attributes to
+// send to MySQL with the queries run with it.
+func WithQueryAttributes(ctx context.Context, queryAttributes map[string]string) context.Context {
+	if len(queryAttributes) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, queryAttributesContextKey(0), queryAttributes)
+}
+
+// QueryAttributesFromContext returns the query attributes to send to MySQL
+// with the queries run with the context, if any.
+func QueryAttributesFromContext(ctx context.Context) map[string]string {
+	queryAttributes, _ := ctx.Value(queryAttributesContextKey(0)).(map[string]string)
+	return queryAttributes
+}
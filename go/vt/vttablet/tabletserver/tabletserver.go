@@ -79,6 +79,9 @@ var logPoolFull = logutil.NewThrottledLogger("PoolFull", 1*time.Minute)
 
 var logComputeRowSerializerKey = logutil.NewThrottledLogger("ComputeRowSerializerKey", 1*time.Minute)
 
+// logResourceAccounting is for throttling errors reading query resources from performance_schema.
+var logResourceAccounting = logutil.NewThrottledLogger("ResourceAccounting", 1*time.Minute)
+
 // TabletServer implements the RPC interface for the query service.
 // TabletServer is initialized in the following sequence:
 // NewTabletServer->InitDBConfig->SetServingType.
@@ -744,6 +747,26 @@ func (tsv *TabletServer) ReadTransaction(ctx context.Context, target *querypb.Ta
 	return metadata, err
 }
 
+// UnresolvedTransactions returns the distributed transactions whose metadata
+// is stored on this tablet and that have been unresolved for at least abandonAge.
+func (tsv *TabletServer) UnresolvedTransactions(ctx context.Context, target *querypb.Target, abandonAge time.Duration) (transactions []*querypb.TransactionMetadata, err error) {
+	err = tsv.execRequest(
+		ctx, tsv.loadQueryTimeout(),
+		"UnresolvedTransactions", "unresolved_transactions", nil,
+		target, nil, true, /* allowOnShutdown */
+		func(ctx context.Context, logStats *tabletenv.LogStats) error {
+			txe := &TxExecutor{
+				ctx:      ctx,
+				logStats: logStats,
+				te:       tsv.te,
+			}
+			transactions, err = txe.UnresolvedTransactions(abandonAge)
+			return err
+		},
+	)
+	return transactions, err
+}
+
 // Execute executes the query and returns the result as response.
 func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (result *sqltypes.Result, err error) {
 	span, ctx := trace.NewSpan(ctx, "TabletServer.Execute")
@@ -1168,7 +1191,7 @@ func (tsv *TabletServer) VStreamTables(ctx context.Context, request *binlogdatap
 	if err := tsv.sm.VerifyTarget(ctx, request.Target); err != nil {
 		return err
 	}
-	return tsv.vstreamer.StreamTables(ctx, send)
+	return tsv.vstreamer.StreamTables(ctx, request.TableLastPKs, request.Filter, int(request.Parallelism), send)
 }
 
 // VStreamResults streams rows from the specified starting point.
@@ -1481,6 +1504,7 @@ func (tsv *TabletServer) execRequest(
 	logStats.Target = target
 	logStats.OriginalSQL = sql
 	logStats.BindVariables = sqltypes.CopyBindVariables(bindVariables)
+	logStats.QueryAttributes = options.GetQueryAttributes()
 	defer tsv.handlePanicAndSendLogStats(sql, bindVariables, logStats)
 
 	if err = tsv.sm.StartRequest(ctx, target, allowOnShutdown); err != nil {
@@ -1493,6 +1517,7 @@ func (tsv *TabletServer) execRequest(
 		tsv.sm.EndRequest()
 	}()
 
+	ctx = tabletenv.WithQueryAttributes(ctx, tsv.forwardedQueryAttributes(options))
 	err = exec(ctx, logStats)
 	if err != nil {
 		return tsv.convertAndLogError(ctx, sql, bindVariables, err, logStats)
@@ -1500,6 +1525,28 @@ func (tsv *TabletServer) execRequest(
 	return nil
 }
 
+// forwardedQueryAttributes returns the query attributes of the request
+// which are configured to be sent to MySQL with its queries.
+func (tsv *TabletServer) forwardedQueryAttributes(options *querypb.ExecuteOptions) map[string]string {
+	queryAttributes := options.GetQueryAttributes()
+	if len(queryAttributes) == 0 {
+		return nil
+	}
+	var forwarded map[string]string
+	for _, name := range tsv.config.ForwardedQueryAttributes {
+		if name == "*" {
+			return queryAttributes
+		}
+		if value, ok := queryAttributes[name]; ok {
+			if forwarded == nil {
+				forwarded = make(map[string]string)
+			}
+			forwarded[name] = value
+		}
+	}
+	return forwarded
+}
+
 func (tsv *TabletServer) handlePanicAndSendLogStats(
 	sql string,
 	bindVariables map[string]*querypb.BindVariable,
@@ -1975,9 +2022,10 @@ func (tsv *TabletServer) SetMaxResultSize(val int) {
 	tsv.qe.maxResultSize.Store(int64(val))
 }
 
-// MaxResultSize returns the max result size.
+// MaxResultSize returns the max result size, which the
+// vttablet.max_result_size cluster setting overrides.
 func (tsv *TabletServer) MaxResultSize() int {
-	return int(tsv.qe.maxResultSize.Load())
+	return int(tsv.qe.getMaxResultSize())
 }
 
 // SetWarnResultSize changes the warn result size to the specified value.
@@ -1985,9 +2033,10 @@ func (tsv *TabletServer) SetWarnResultSize(val int) {
 	tsv.qe.warnResultSize.Store(int64(val))
 }
 
-// WarnResultSize returns the warn result size.
+// WarnResultSize returns the warn result size, which the
+// vttablet.warn_result_size cluster setting overrides.
 func (tsv *TabletServer) WarnResultSize() int {
-	return int(tsv.qe.warnResultSize.Load())
+	return int(tsv.qe.getWarnResultSize())
 }
 
 // SetThrottleMetricThreshold changes the throttler metric threshold
@@ -2239,6 +2239,20 @@ func TestReserveStats(t *testing.T) {
 	assert.NotEmpty(t, tsv.te.txPool.env.Stats().UserReservedTimesNs.Counts()["test"])
 }
 
+func TestForwardedQueryAttributes(t *testing.T) {
+	tsv := &TabletServer{config: tabletenv.NewDefaultConfig()}
+	options := &querypb.ExecuteOptions{
+		QueryAttributes: map[string]string{"app": "billing", "trace_id": "abc"},
+	}
+
+	assert.Nil(t, tsv.forwardedQueryAttributes(options))
+	tsv.config.ForwardedQueryAttributes = []string{"trace_id", "span_id"}
+	assert.Equal(t, map[string]string{"trace_id": "abc"}, tsv.forwardedQueryAttributes(options))
+	assert.Nil(t, tsv.forwardedQueryAttributes(nil))
+	tsv.config.ForwardedQueryAttributes = []string{"*"}
+	assert.Equal(t, options.QueryAttributes, tsv.forwardedQueryAttributes(options))
+}
+
 func TestDatabaseNameReplaceByKeyspaceNameExecuteMethod(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -18,6 +18,7 @@ package tabletserver
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
@@ -243,6 +244,38 @@ func (txe *TxExecutor) ReadTransaction(dtid string) (*querypb.TransactionMetadat
 	return txe.te.twoPC.ReadTransaction(txe.ctx, dtid)
 }
 
+// UnresolvedTransactions returns the metadata of the distributed transactions
+// that were created more than abandonAge ago and are still unresolved,
+// sorted by creation time.
+func (txe *TxExecutor) UnresolvedTransactions(abandonAge time.Duration) ([]*querypb.TransactionMetadata, error) {
+	if !txe.te.twopcEnabled {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "2pc is not enabled")
+	}
+	abandoned, err := txe.te.twoPC.ReadAbandoned(txe.ctx, time.Now().Add(-abandonAge))
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "Could not read unresolved transactions: %v", err)
+	}
+	transactions := make([]*querypb.TransactionMetadata, 0, len(abandoned))
+	for dtid := range abandoned {
+		metadata, err := txe.te.twoPC.ReadTransaction(txe.ctx, dtid)
+		if err != nil {
+			return nil, err
+		}
+		// The transaction may have been concluded in the meantime.
+		if metadata.Dtid == "" {
+			continue
+		}
+		transactions = append(transactions, metadata)
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].TimeCreated == transactions[j].TimeCreated {
+			return transactions[i].Dtid < transactions[j].Dtid
+		}
+		return transactions[i].TimeCreated < transactions[j].TimeCreated
+	})
+	return transactions, nil
+}
+
 // ReadTwopcInflight returns info about all in-flight 2pc transactions.
 func (txe *TxExecutor) ReadTwopcInflight() (distributed []*tx.DistributedTx, prepared, failed []*tx.PreparedTx, err error) {
 	if !txe.te.twopcEnabled {
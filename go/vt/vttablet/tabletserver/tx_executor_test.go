@@ -26,6 +26,7 @@ import (
 
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
@@ -433,6 +434,69 @@ func TestExecutorReadAllTransactions(t *testing.T) {
 	}
 }
 
+func TestExecutorUnresolvedTransactions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	txe, tsv, db := newTestTxExecutor(t, ctx)
+	defer db.Close()
+	defer tsv.StopService()
+
+	db.AddQueryPattern("select dtid, time_created from _vt\\.dt_state where time_created < .*", &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Type: sqltypes.VarChar},
+			{Type: sqltypes.Int64},
+		},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewVarBinary("aa"),
+			sqltypes.NewInt64(2),
+		}, {
+			sqltypes.NewVarBinary("bb"),
+			sqltypes.NewInt64(1),
+		}, {
+			sqltypes.NewVarBinary("cc"),
+			sqltypes.NewInt64(3),
+		}},
+	})
+	for i, dtid := range []string{"aa", "bb"} {
+		db.AddQuery(fmt.Sprintf("select dtid, state, time_created from _vt.dt_state where dtid = '%s'", dtid), &sqltypes.Result{
+			Fields: []*querypb.Field{
+				{Type: sqltypes.VarChar},
+				{Type: sqltypes.Int64},
+				{Type: sqltypes.Int64},
+			},
+			Rows: [][]sqltypes.Value{{
+				sqltypes.NewVarBinary(dtid),
+				sqltypes.NewInt64(int64(querypb.TransactionState_COMMIT)),
+				sqltypes.NewInt64(int64(2 - i)),
+			}},
+		})
+		db.AddQuery(fmt.Sprintf("select keyspace, shard from _vt.dt_participant where dtid = '%s'", dtid), &sqltypes.Result{
+			Fields: []*querypb.Field{
+				{Type: sqltypes.VarChar},
+				{Type: sqltypes.VarChar},
+			},
+			Rows: [][]sqltypes.Value{{
+				sqltypes.NewVarBinary("test1"),
+				sqltypes.NewVarBinary("0"),
+			}},
+		})
+	}
+	// cc was concluded after it was listed.
+	db.AddQuery("select dtid, state, time_created from _vt.dt_state where dtid = 'cc'", &sqltypes.Result{})
+
+	got, err := txe.UnresolvedTransactions(time.Minute)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "bb", got[0].Dtid)
+	assert.Equal(t, "aa", got[1].Dtid)
+	assert.Equal(t, querypb.TransactionState_COMMIT, got[0].State)
+	assert.Equal(t, []*querypb.Target{{
+		Keyspace:   "test1",
+		Shard:      "0",
+		TabletType: topodatapb.TabletType_PRIMARY,
+	}}, got[0].Participants)
+}
+
 // These vars and types are used only for TestExecutorResolveTransaction
 var dtidCh = make(chan string)
 
@@ -312,8 +312,11 @@ func (vse *Engine) StreamRows(ctx context.Context, query string, lastpk []sqltyp
 	return rowStreamer.Stream()
 }
 
-// StreamTables streams all tables.
-func (vse *Engine) StreamTables(ctx context.Context, send func(*binlogdatapb.VStreamTablesResponse) error) error {
+// StreamTables streams all tables, or only the tables of the filter if one is
+// specified. The tables of a filter are resumed from their tablePKs, and up
+// to parallelism of them are streamed concurrently.
+func (vse *Engine) StreamTables(ctx context.Context, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, parallelism int,
+	send func(*binlogdatapb.VStreamTablesResponse) error) error {
 	// Ensure vschema is initialized and the watcher is started.
 	// Starting of the watcher is delayed till the first call to StreamTables
 	// so that this overhead is incurred only if someone uses this feature.
@@ -328,7 +331,7 @@ func (vse *Engine) StreamTables(ctx context.Context, send func(*binlogdatapb.VSt
 		vse.mu.Lock()
 		defer vse.mu.Unlock()
 
-		tableStreamer := newTableStreamer(ctx, vse.env.Config().DB.FilteredWithDB(), vse.se, vse.lvschema, send, vse)
+		tableStreamer := newTableStreamer(ctx, vse.env.Config().DB.FilteredWithDB(), vse.se, vse.lvschema, tablePKs, filter, parallelism, send, vse)
 		idx := vse.streamIdx
 		vse.tableStreamers[idx] = tableStreamer
 		vse.streamIdx++
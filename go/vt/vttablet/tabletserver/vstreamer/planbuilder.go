@@ -78,6 +78,9 @@ const (
 	NotEqual
 	// IsNotNull is used to filter a column if it is NULL
 	IsNotNull
+	// Expression is used to filter a row on an arbitrary expression,
+	// evaluated by the evalengine against the values of the row
+	Expression
 )
 
 // Filter contains opcodes for filtering.
@@ -86,6 +89,10 @@ type Filter struct {
 	ColNum int
 	Value  sqltypes.Value
 
+	// Expr is the expression evaluated for Expression filters.
+	// Its columns refer to the column numbers of the table.
+	Expr evalengine.Expr
+
 	// Parameters for VindexMatch.
 	// Vindex, VindexColumns and KeyRange, if set, will be used
 	// to filter the row.
@@ -111,6 +118,11 @@ type ColExpr struct {
 	Field *querypb.Field
 
 	FixedValue sqltypes.Value
+
+	// Expr, if set, is evaluated by the evalengine against the values of the
+	// row to generate the value of the column. If so, ColNum is ignored.
+	// Its columns refer to the column numbers of the table.
+	Expr evalengine.Expr
 }
 
 // Table contains the metadata for a table.
@@ -227,6 +239,16 @@ func (plan *Plan) filter(values, result []sqltypes.Value, charsets []collations.
 			if values[filter.ColNum].IsNull() {
 				return false, nil
 			}
+		case Expression:
+			env := evalengine.EmptyExpressionEnv()
+			env.Row = values
+			res, err := env.Evaluate(filter.Expr)
+			if err != nil {
+				return false, err
+			}
+			if !res.ToBoolean() {
+				return false, nil
+			}
 		default:
 			match, err := compare(filter.Opcode, values[filter.ColNum], filter.Value, charsets[filter.ColNum])
 			if err != nil {
@@ -238,6 +260,16 @@ func (plan *Plan) filter(values, result []sqltypes.Value, charsets []collations.
 		}
 	}
 	for i, colExpr := range plan.ColExprs {
+		if colExpr.Expr != nil {
+			env := evalengine.EmptyExpressionEnv()
+			env.Row = values
+			res, err := env.Evaluate(colExpr.Expr)
+			if err != nil {
+				return false, err
+			}
+			result[i] = res.Value(collations.Default())
+			continue
+		}
 		if colExpr.ColNum == -1 {
 			result[i] = colExpr.FixedValue
 			continue
@@ -509,13 +541,17 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 	for _, expr := range exprs {
 		switch expr := expr.(type) {
 		case *sqlparser.ComparisonExpr:
+			// Comparisons of a column with an integer or string literal are
+			// evaluated natively, anything else by the evalengine.
 			opcode, err := getOpcode(expr)
-			if err != nil {
-				return err
-			}
-			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			qualifiedName, isColumn := expr.Left.(*sqlparser.ColName)
+			val, isLiteral := expr.Right.(*sqlparser.Literal)
+			//StrVal is varbinary, we do not support varchar since we would have to implement all collation types
+			if err != nil || !isColumn || !isLiteral || (val.Type != sqlparser.IntVal && val.Type != sqlparser.StrVal) {
+				if err := plan.analyzeExpression(expr); err != nil {
+					return err
+				}
+				continue
 			}
 			if !qualifiedName.Qualifier.IsEmpty() {
 				return fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
@@ -524,14 +560,6 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 			if err != nil {
 				return err
 			}
-			val, ok := expr.Right.(*sqlparser.Literal)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
-			}
-			//StrVal is varbinary, we do not support varchar since we would have to implement all collation types
-			if val.Type != sqlparser.IntVal && val.Type != sqlparser.StrVal {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
-			}
 			pv, err := evalengine.Translate(val, nil)
 			if err != nil {
 				return err
@@ -548,14 +576,20 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 			})
 		case *sqlparser.FuncExpr:
 			if !expr.Name.EqualString("in_keyrange") {
-				return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
+				if err := plan.analyzeExpression(expr); err != nil {
+					return err
+				}
+				continue
 			}
 			if err := plan.analyzeInKeyRange(vschema, expr.Exprs); err != nil {
 				return err
 			}
 		case *sqlparser.IsExpr: // Needed for CreateLookupVindex with ignore_nulls
 			if expr.Right != sqlparser.IsNotNullOp {
-				return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
+				if err := plan.analyzeExpression(expr); err != nil {
+					return err
+				}
+				continue
 			}
 			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
 			if !ok {
@@ -573,12 +607,118 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 				ColNum: colnum,
 			})
 		default:
-			return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
+			if err := plan.analyzeExpression(expr); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// analyzeExpression adds a filter that evaluates expr with the evalengine,
+// for the constraints that are not natively supported, e.g. `tenant_id in (1, 2)`.
+func (plan *Plan) analyzeExpression(expr sqlparser.Expr) error {
+	if !isEvaluable(expr) {
+		return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
+	}
+	eexpr, err := evalengine.Translate(expr, plan.evalengineConfig())
+	if err != nil {
+		return vterrors.Wrapf(err, "unsupported constraint: %v", sqlparser.String(expr))
+	}
+	plan.Filters = append(plan.Filters, Filter{
+		Opcode: Expression,
+		Expr:   eexpr,
+	})
+	return nil
+}
+
+// evalengineConfig returns the configuration used to translate the
+// expressions of the plan, whose columns resolve to the columns of the table.
+func (plan *Plan) evalengineConfig() *evalengine.Config {
+	return &evalengine.Config{
+		ResolveColumn: func(col *sqlparser.ColName) (int, error) {
+			if !col.Qualifier.IsEmpty() {
+				return 0, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(col))
+			}
+			return findColumn(plan.Table, col.Name)
+		},
+		ResolveType: func(expr sqlparser.Expr) (evalengine.Type, bool) {
+			col, ok := expr.(*sqlparser.ColName)
+			if !ok {
+				return evalengine.Type{}, false
+			}
+			colnum := plan.Table.FindColumn(col.Name)
+			if colnum < 0 {
+				return evalengine.Type{}, false
+			}
+			field := plan.Table.Fields[colnum]
+			return evalengine.Type{Type: field.Type, Coll: collations.ID(field.Charset), Nullable: true}, true
+		},
+		Collation: collations.Default(),
+	}
+}
+
+// analyzeColumnExpression returns a column whose value is the result of an
+// arbitrary expression, e.g. `sha2(email, 256) as email`, evaluated with the
+// evalengine against the values of the row. This is how the columns are
+// transformed on the source, both when they are copied and replicated.
+func (plan *Plan) analyzeColumnExpression(aliased *sqlparser.AliasedExpr) (ColExpr, error) {
+	if aliased.As.IsEmpty() {
+		return ColExpr{}, fmt.Errorf("expression needs an alias: %v", sqlparser.String(aliased))
+	}
+	if !isEvaluable(aliased.Expr) {
+		return ColExpr{}, fmt.Errorf("unsupported: %v", sqlparser.String(aliased.Expr))
+	}
+	// The lastpk of the copy phase holds the values of the primary key of
+	// the source table, so they cannot be transformed.
+	if colnum := plan.Table.FindColumn(aliased.As); colnum >= 0 {
+		if plan.Table.Fields[colnum].Flags&uint32(querypb.MySqlFlag_PRI_KEY_FLAG) != 0 {
+			return ColExpr{}, fmt.Errorf("primary key column %v cannot be transformed: %v", sqlparser.String(aliased.As), sqlparser.String(aliased))
+		}
+	}
+	eexpr, err := evalengine.Translate(aliased.Expr, plan.evalengineConfig())
+	if err != nil {
+		return ColExpr{}, vterrors.Wrapf(err, "unsupported: %v", sqlparser.String(aliased.Expr))
+	}
+	typ, _, err := evalengine.EmptyExpressionEnv().TypeOf(eexpr, plan.Table.Fields)
+	if err != nil {
+		// The type can depend on the values of the row, e.g. for a CASE
+		// expression with branches of different types.
+		typ = sqltypes.VarChar
+	}
+	field := &querypb.Field{
+		Name:    aliased.As.String(),
+		Type:    typ,
+		Charset: collations.CollationBinaryID,
+	}
+	if sqltypes.IsText(typ) {
+		field.Charset = uint32(collations.Default())
+	}
+	return ColExpr{
+		ColNum: -1,
+		Field:  field,
+		Expr:   eexpr,
+	}, nil
+}
+
+// isEvaluable returns false if expr contains constructs that cannot be
+// evaluated against a single row, like aggregates, subqueries and in_keyrange.
+func isEvaluable(expr sqlparser.Expr) bool {
+	evaluable := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case sqlparser.AggrFunc, *sqlparser.Subquery, *sqlparser.ExistsExpr:
+			evaluable = false
+		case *sqlparser.FuncExpr:
+			if node.Name.EqualString("in_keyrange") {
+				evaluable = false
+			}
+		}
+		return evaluable, nil
+	}, expr)
+	return evaluable
+}
+
 // splitAndExpression breaks up the Expr into AND-separated conditions
 // and appends them to filters, which can be shuffled and recombined
 // as needed.
@@ -604,14 +744,29 @@ func (plan *Plan) analyzeExprs(vschema *localVSchema, selExprs sqlparser.SelectE
 			plan.ColExprs = append(plan.ColExprs, cExpr)
 		}
 	} else {
-		if len(selExprs) != 1 {
-			return fmt.Errorf("unsupported: %v", sqlparser.String(selExprs))
-		}
 		plan.ColExprs = make([]ColExpr, len(plan.Table.Fields))
 		for i, col := range plan.Table.Fields {
 			plan.ColExprs[i].ColNum = i
 			plan.ColExprs[i].Field = col
 		}
+		// The expressions that follow the '*' transform the columns they
+		// are aliased as, e.g. "select *, sha2(email, 256) as email from t".
+		for _, expr := range selExprs[1:] {
+			aliased, ok := expr.(*sqlparser.AliasedExpr)
+			if !ok || aliased.As.IsEmpty() {
+				return fmt.Errorf("unsupported: %v", sqlparser.String(selExprs))
+			}
+			colnum, err := findColumn(plan.Table, aliased.As)
+			if err != nil {
+				return err
+			}
+			cExpr, err := plan.analyzeColumnExpression(aliased)
+			if err != nil {
+				return err
+			}
+			cExpr.Field.Name = plan.Table.Fields[colnum].Name
+			plan.ColExprs[colnum] = cExpr
+		}
 	}
 	return nil
 }
@@ -699,7 +854,7 @@ func (plan *Plan) analyzeExpr(vschema *localVSchema, selExpr sqlparser.SelectExp
 				Field:  field,
 			}, nil
 		default:
-			return ColExpr{}, fmt.Errorf("unsupported function: %v", sqlparser.String(inner))
+			return plan.analyzeColumnExpression(aliased)
 		}
 	case *sqlparser.Literal:
 		//allow only intval 1
@@ -754,8 +909,7 @@ func (plan *Plan) analyzeExpr(vschema *localVSchema, selExpr sqlparser.SelectExp
 			Field:  field,
 		}, nil
 	default:
-		log.Infof("Unsupported expression: %v", inner)
-		return ColExpr{}, fmt.Errorf("unsupported: %v", sqlparser.String(aliased.Expr))
+		return plan.analyzeColumnExpression(aliased)
 	}
 }
 
@@ -617,7 +617,7 @@ func TestPlanBuilder(t *testing.T) {
 	}, {
 		inTable: t1,
 		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id+1, val from t1"},
-		outErr:  `unsupported: id + 1`,
+		outErr:  `expression needs an alias: id + 1`,
 	}, {
 		inTable: t1,
 		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select t1.id, val from t1"},
@@ -746,6 +746,189 @@ func TestPlanBuilderFilterComparison(t *testing.T) {
 	}
 }
 
+func TestPlanBuilderFilterExpression(t *testing.T) {
+	t1 := &Table{
+		Name: "t1",
+		Fields: []*querypb.Field{{
+			Name:    "id",
+			Type:    sqltypes.Int64,
+			Charset: collations.CollationBinaryID,
+			Flags:   uint32(querypb.MySqlFlag_BINARY_FLAG | querypb.MySqlFlag_NUM_FLAG),
+		}, {
+			Name:    "tenant_id",
+			Type:    sqltypes.Int64,
+			Charset: collations.CollationBinaryID,
+			Flags:   uint32(querypb.MySqlFlag_BINARY_FLAG | querypb.MySqlFlag_NUM_FLAG),
+		}, {
+			Name:    "name",
+			Type:    sqltypes.VarChar,
+			Charset: uint32(collations.CollationUtf8mb4ID),
+		}},
+	}
+	row := func(id, tenantID int64, name string) []sqltypes.Value {
+		tenant := sqltypes.NewInt64(tenantID)
+		if tenantID < 0 {
+			tenant = sqltypes.NULL
+		}
+		return []sqltypes.Value{sqltypes.NewInt64(id), tenant, sqltypes.NewVarChar(name)}
+	}
+	testcases := []struct {
+		name     string
+		inFilter string
+		outErr   string
+		match    [][]sqltypes.Value
+		noMatch  [][]sqltypes.Value
+	}{{
+		name:     "in",
+		inFilter: "select * from t1 where tenant_id in (1, 3)",
+		match:    [][]sqltypes.Value{row(1, 1, "a"), row(2, 3, "b")},
+		noMatch:  [][]sqltypes.Value{row(3, 2, "c"), row(4, -1, "d")},
+	}, {
+		name:     "or",
+		inFilter: "select * from t1 where tenant_id = 1 or name like 'x%'",
+		match:    [][]sqltypes.Value{row(1, 1, "a"), row(2, 2, "xyz")},
+		noMatch:  [][]sqltypes.Value{row(3, 2, "abc")},
+	}, {
+		name:     "native-and-expression",
+		inFilter: "select * from t1 where id > 1 and id % 2 = 0",
+		match:    [][]sqltypes.Value{row(2, 1, "a"), row(4, 1, "a")},
+		noMatch:  [][]sqltypes.Value{row(1, 1, "a"), row(3, 1, "a")},
+	}, {
+		name:     "is-null",
+		inFilter: "select * from t1 where tenant_id is null",
+		match:    [][]sqltypes.Value{row(1, -1, "a")},
+		noMatch:  [][]sqltypes.Value{row(2, 1, "a")},
+	}, {
+		name:     "function",
+		inFilter: "select * from t1 where lower(name) = 'abc'",
+		match:    [][]sqltypes.Value{row(1, 1, "ABC")},
+		noMatch:  [][]sqltypes.Value{row(2, 1, "abd")},
+	}, {
+		name:     "unknown-column",
+		inFilter: "select * from t1 where tenant_id in (1, 2) or foo = 1",
+		outErr:   "unsupported constraint: tenant_id in (1, 2) or foo = 1: column foo not found in table t1",
+	}, {
+		name:     "in-keyrange-in-expression",
+		inFilter: "select * from t1 where in_keyrange(id, 'hash', '-80') or tenant_id = 1",
+		outErr:   "unsupported constraint: in_keyrange(id, 'hash', '-80') or tenant_id = 1",
+	}, {
+		name:     "subquery",
+		inFilter: "select * from t1 where tenant_id in (select id from t2)",
+		outErr:   "unsupported constraint: tenant_id in (select id from t2)",
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			plan, err := buildPlan(t1, testLocalVSchema, &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: tcase.inFilter}},
+			})
+			if tcase.outErr != "" {
+				assert.EqualError(t, err, tcase.outErr)
+				return
+			}
+			require.NoError(t, err)
+			result := make([]sqltypes.Value, len(plan.ColExprs))
+			charsets := []collations.ID{collations.CollationBinaryID, collations.CollationBinaryID, collations.CollationUtf8mb4ID}
+			for _, values := range tcase.match {
+				ok, err := plan.filter(values, result, charsets)
+				require.NoError(t, err)
+				assert.Truef(t, ok, "row %v should match", values)
+			}
+			for _, values := range tcase.noMatch {
+				ok, err := plan.filter(values, result, charsets)
+				require.NoError(t, err)
+				assert.Falsef(t, ok, "row %v should not match", values)
+			}
+		})
+	}
+}
+
+func TestPlanBuilderColumnExpression(t *testing.T) {
+	t1 := &Table{
+		Name: "t1",
+		Fields: []*querypb.Field{{
+			Name:    "id",
+			Type:    sqltypes.Int64,
+			Charset: collations.CollationBinaryID,
+			Flags:   uint32(querypb.MySqlFlag_BINARY_FLAG | querypb.MySqlFlag_NUM_FLAG | querypb.MySqlFlag_PRI_KEY_FLAG),
+		}, {
+			Name:    "email",
+			Type:    sqltypes.VarChar,
+			Charset: uint32(collations.CollationUtf8mb4ID),
+		}, {
+			Name:    "name",
+			Type:    sqltypes.VarChar,
+			Charset: uint32(collations.CollationUtf8mb4ID),
+		}},
+	}
+	values := []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("jane@example.com"), sqltypes.NewVarChar("jane")}
+	testcases := []struct {
+		name      string
+		inFilter  string
+		outErr    string
+		outFields []string
+		outResult []sqltypes.Value
+	}{{
+		name:      "star-with-transform",
+		inFilter:  "select *, upper(email) as email from t1",
+		outFields: []string{"id", "email", "name"},
+		outResult: []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("JANE@EXAMPLE.COM"), sqltypes.NewVarChar("jane")},
+	}, {
+		name:      "star-with-transforms",
+		inFilter:  "select *, concat(name, '-', id) as name, md5(email) as email from t1",
+		outFields: []string{"id", "email", "name"},
+		outResult: []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("9e26471d35a78862c17e467d87cddedf"), sqltypes.NewVarChar("jane-1")},
+	}, {
+		name:      "columns-with-expressions",
+		inFilter:  "select id, concat(name, '@') as handle, id * 10 as score from t1",
+		outFields: []string{"id", "handle", "score"},
+		outResult: []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("jane@"), sqltypes.NewInt64(10)},
+	}, {
+		name:     "transform-of-unknown-column",
+		inFilter: "select *, upper(email) as mail from t1",
+		outErr:   "column mail not found in table t1",
+	}, {
+		name:     "transform-without-alias",
+		inFilter: "select *, upper(email) from t1",
+		outErr:   "unsupported: *, upper(email)",
+	}, {
+		name:     "transform-of-primary-key",
+		inFilter: "select *, id + 1 as id from t1",
+		outErr:   "primary key column id cannot be transformed: id + 1 as id",
+	}, {
+		name:     "subquery",
+		inFilter: "select *, (select 1 from dual) as name from t1",
+		outErr:   "unsupported: (select 1 from dual)",
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			plan, err := buildPlan(t1, testLocalVSchema, &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: tcase.inFilter}},
+			})
+			if tcase.outErr != "" {
+				assert.EqualError(t, err, tcase.outErr)
+				return
+			}
+			require.NoError(t, err)
+			var fields []string
+			for _, field := range plan.fields() {
+				fields = append(fields, field.Name)
+			}
+			assert.Equal(t, tcase.outFields, fields)
+
+			result := make([]sqltypes.Value, len(plan.ColExprs))
+			charsets := []collations.ID{collations.CollationBinaryID, collations.CollationUtf8mb4ID, collations.CollationUtf8mb4ID}
+			ok, err := plan.filter(values, result, charsets)
+			require.NoError(t, err)
+			require.True(t, ok)
+			for i, want := range tcase.outResult {
+				assert.Equalf(t, want.ToString(), result[i].ToString(), "column %s", fields[i])
+			}
+		})
+	}
+}
+
 func TestCompare(t *testing.T) {
 	type testcase struct {
 		opcode                   Opcode
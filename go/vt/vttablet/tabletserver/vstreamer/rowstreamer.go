@@ -61,6 +61,9 @@ type RowStreamerMode int32
 const (
 	RowStreamerModeSingleTable RowStreamerMode = iota
 	RowStreamerModeAllTables
+	// RowStreamerModeFilteredTables streams the tables of a filter from an already created
+	// snapshot, like RowStreamerModeAllTables, but runs the rewritten query that resumes from lastpk.
+	RowStreamerModeFilteredTables
 )
 
 // rowStreamer is used for copying the existing rows of a table
@@ -344,6 +347,11 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 		if rotatedLog {
 			rs.vse.vstreamerFlushedBinlogs.Add(1)
 		}
+	} else if rs.mode == RowStreamerModeFilteredTables {
+		// The snapshot is shared by the tables of the filter, each one resuming from its lastpk.
+		if err := rs.conn.ExecuteStreamFetch(rs.sendQuery); err != nil {
+			return err
+		}
 	} else {
 		// Comes here when we stream all tables. The snapshot is created just once at the start.
 		if err := rs.conn.ExecuteStreamFetch(rs.query); err != nil {
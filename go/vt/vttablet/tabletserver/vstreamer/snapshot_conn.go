@@ -19,6 +19,7 @@ package vstreamer
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -262,3 +263,50 @@ func (conn *snapshotConn) startSnapshotAllTables(ctx context.Context) (gtid stri
 	}
 	return replication.EncodePosition(mpos), nil
 }
+
+// startSharedSnapshot starts a transaction with a consistent snapshot on each of
+// the connections while the tables are read locked, so that all the connections
+// see the tables as of the returned GTID set.
+func startSharedSnapshot(ctx context.Context, cp dbconfigs.Connector, tables []string, conns []*snapshotConn) (gtid string, err error) {
+	lockConn, err := mysqlConnect(ctx, cp)
+	if err != nil {
+		return "", err
+	}
+	// To be safe, always unlock tables, even if lock tables might fail.
+	defer func() {
+		_, err := lockConn.ExecuteFetch("unlock tables", 0, false)
+		if err != nil {
+			log.Warning("Unlock tables failed: %v", err)
+		} else {
+			log.Infof("Tables unlocked: %v", tables)
+		}
+		lockConn.Close()
+	}()
+
+	locks := make([]string, 0, len(tables))
+	for _, table := range tables {
+		locks = append(locks, sqlparser.String(sqlparser.NewIdentifierCS(table))+" read")
+	}
+	log.Infof("Locking tables %v for copying", tables)
+	if _, err := lockConn.ExecuteFetch("lock tables "+strings.Join(locks, ", "), 1, false); err != nil {
+		log.Infof("Error locking tables %v to read", tables)
+		return "", err
+	}
+	mpos, err := lockConn.PrimaryPosition()
+	if err != nil {
+		return "", err
+	}
+
+	for _, conn := range conns {
+		if _, err := conn.ExecuteFetch("set transaction isolation level repeatable read", 1, false); err != nil {
+			return "", err
+		}
+		if _, err := conn.ExecuteFetch("start transaction with consistent snapshot", 1, false); err != nil {
+			return "", err
+		}
+		if _, err := conn.ExecuteFetch("set @@session.time_zone = '+00:00'", 1, false); err != nil {
+			return "", err
+		}
+	}
+	return replication.EncodePosition(mpos), nil
+}
@@ -21,8 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"sync/atomic"
 
+	"golang.org/x/sync/errgroup"
+
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet"
 
 	"vitess.io/vitess/go/sqlescape"
@@ -32,6 +36,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	schema2 "vitess.io/vitess/go/vt/schema"
 )
 
@@ -39,6 +44,10 @@ import (
 	TableStreamer is a VStreamer that streams all tables in a keyspace. It iterates through all tables in a keyspace
 	and streams them one by one. It is not resilient: if there is any error that breaks the stream, for example,
 	reparenting or a network error, it will not recover and a new workflow will have to be created.
+
+	When a filter is specified only the tables of the filter are streamed, each one resuming from its lastpk, and
+	up to parallelism tables are streamed concurrently from the same snapshot. A response with completed set is
+	sent once a table has been fully streamed, so that the caller can resume the copy of the remaining tables.
 */
 
 // TableStreamer exposes an externally usable interface to tableStreamer.
@@ -60,19 +69,29 @@ type tableStreamer struct {
 	snapshotConn *snapshotConn
 	tables       []string
 	gtid         string
+
+	tablePKs    []*binlogdatapb.TableLastPK
+	filter      *binlogdatapb.Filter
+	parallelism int
+	// sendMu serializes the responses of the tables streamed concurrently.
+	sendMu sync.Mutex
 }
 
 func newTableStreamer(ctx context.Context, cp dbconfigs.Connector, se *schema.Engine, vschema *localVSchema,
+	tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter, parallelism int,
 	send func(response *binlogdatapb.VStreamTablesResponse) error, vse *Engine) *tableStreamer {
 	ctx, cancel := context.WithCancel(ctx)
 	return &tableStreamer{
-		ctx:     ctx,
-		cancel:  cancel,
-		cp:      cp,
-		se:      se,
-		send:    send,
-		vschema: vschema,
-		vse:     vse,
+		ctx:         ctx,
+		cancel:      cancel,
+		cp:          cp,
+		se:          se,
+		send:        send,
+		vschema:     vschema,
+		vse:         vse,
+		tablePKs:    tablePKs,
+		filter:      filter,
+		parallelism: parallelism,
 	}
 }
 
@@ -87,6 +106,9 @@ func (ts *tableStreamer) Stream() error {
 	if err = ts.se.Open(); err != nil {
 		return err
 	}
+	if ts.filter != nil {
+		return ts.streamFilteredTables()
+	}
 
 	conn, err := snapshotConnect(ts.ctx, ts.cp)
 	if err != nil {
@@ -106,14 +128,7 @@ func (ts *tableStreamer) Stream() error {
 	if err != nil {
 		return err
 	}
-
-	if _, err := conn.ExecuteFetch("set names 'binary'", 1, false); err != nil {
-		return err
-	}
-	if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@session.net_read_timeout = %v", vttablet.VReplicationNetReadTimeout), 1, false); err != nil {
-		return err
-	}
-	if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@session.net_write_timeout = %v", vttablet.VReplicationNetWriteTimeout), 1, false); err != nil {
+	if err := prepareTableStreamerConn(conn); err != nil {
 		return err
 	}
 
@@ -141,18 +156,108 @@ func (ts *tableStreamer) Stream() error {
 	return nil
 }
 
-func (ts *tableStreamer) newRowStreamer(ctx context.Context, query string, lastpk []sqltypes.Value,
+// streamFilteredTables streams the tables of the filter. Each table is
+// streamed by one of parallelism connections whose snapshots were all started
+// while the tables were locked, so that every table is streamed as of the
+// same gtid.
+func (ts *tableStreamer) streamFilteredTables() error {
+	lastpks := make(map[string][]sqltypes.Value, len(ts.tablePKs))
+	for _, tablePK := range ts.tablePKs {
+		if tablePK.GetLastpk() == nil {
+			continue
+		}
+		r := sqltypes.Proto3ToResult(tablePK.Lastpk)
+		if len(r.Rows) != 1 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected lastpk input for table %s: %v", tablePK.TableName, tablePK.Lastpk)
+		}
+		lastpks[tablePK.TableName] = r.Rows[0]
+	}
+	for _, rule := range ts.filter.Rules {
+		ts.tables = append(ts.tables, rule.Match)
+	}
+	if len(ts.tables) == 0 {
+		return nil
+	}
+
+	parallelism := min(max(ts.parallelism, 1), len(ts.tables))
+	conns := make([]*snapshotConn, 0, parallelism)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	for i := 0; i < parallelism; i++ {
+		conn, err := snapshotConnect(ts.ctx, ts.cp)
+		if err != nil {
+			return err
+		}
+		conns = append(conns, conn)
+		if err := prepareTableStreamerConn(conn); err != nil {
+			return err
+		}
+	}
+	var err error
+	if ts.gtid, err = startSharedSnapshot(ts.ctx, ts.cp, ts.tables, conns); err != nil {
+		return err
+	}
+
+	log.Infof("Streaming %d tables with %d connections: %s", len(ts.tables), parallelism, strings.Join(ts.tables, ", "))
+	rules := make(chan *binlogdatapb.Rule, len(ts.filter.Rules))
+	for _, rule := range ts.filter.Rules {
+		rules <- rule
+	}
+	close(rules)
+	g, ctx := errgroup.WithContext(ts.ctx)
+	for _, conn := range conns {
+		conn := conn
+		g.Go(func() error {
+			for rule := range rules {
+				log.Infof("Streaming table %s, lastpk: %v", rule.Match, lastpks[rule.Match])
+				if err := ts.streamTableWithConn(ctx, conn, rule.Match, rule.Filter, lastpks[rule.Match], ts.safeSend); err != nil {
+					return err
+				}
+				if err := ts.safeSend(&binlogdatapb.VStreamTablesResponse{
+					TableName: rule.Match,
+					Gtid:      ts.gtid,
+					Completed: true,
+				}); err != nil {
+					return err
+				}
+				log.Infof("Finished streaming table %s", rule.Match)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	log.Infof("Finished streaming %d tables", len(ts.tables))
+	return nil
+}
+
+// safeSend sends a response of one of the concurrently streamed tables.
+func (ts *tableStreamer) safeSend(response *binlogdatapb.VStreamTablesResponse) error {
+	ts.sendMu.Lock()
+	defer ts.sendMu.Unlock()
+	return ts.send(response)
+}
+
+func (ts *tableStreamer) newRowStreamer(ctx context.Context, conn *snapshotConn, query string, lastpk []sqltypes.Value,
 	send func(*binlogdatapb.VStreamRowsResponse) error) (*rowStreamer, func(), error) {
 
 	vse := ts.vse
 	if atomic.LoadInt32(&vse.isOpen) == 0 {
 		return nil, nil, errors.New("VStreamer is not open")
 	}
+	mode := RowStreamerModeAllTables
+	if ts.filter != nil {
+		mode = RowStreamerModeFilteredTables
+	}
 	vse.mu.Lock()
 	defer vse.mu.Unlock()
 
 	rowStreamer := newRowStreamer(ctx, vse.env.Config().DB.FilteredWithDB(), vse.se, query, lastpk, vse.lvschema,
-		send, vse, RowStreamerModeAllTables, ts.snapshotConn)
+		send, vse, mode, conn)
 
 	idx := vse.streamIdx
 	vse.rowStreamers[idx] = rowStreamer
@@ -173,9 +278,13 @@ func (ts *tableStreamer) newRowStreamer(ctx context.Context, query string, lastp
 
 func (ts *tableStreamer) streamTable(ctx context.Context, tableName string) error {
 	query := fmt.Sprintf("select * from %s", sqlescape.EscapeID(tableName))
+	return ts.streamTableWithConn(ctx, ts.snapshotConn, tableName, query, nil, ts.send)
+}
 
+func (ts *tableStreamer) streamTableWithConn(ctx context.Context, conn *snapshotConn, tableName, query string, lastpk []sqltypes.Value,
+	sendResponse func(*binlogdatapb.VStreamTablesResponse) error) error {
 	send := func(response *binlogdatapb.VStreamRowsResponse) error {
-		return ts.send(&binlogdatapb.VStreamTablesResponse{
+		return sendResponse(&binlogdatapb.VStreamTablesResponse{
 			TableName: tableName,
 			Fields:    response.GetFields(),
 			Pkfields:  response.GetPkfields(),
@@ -184,16 +293,29 @@ func (ts *tableStreamer) streamTable(ctx context.Context, tableName string) erro
 			Lastpk:    response.Lastpk,
 		})
 	}
-	rs, cancel, err := ts.newRowStreamer(ctx, query, nil, send)
+	rs, cancel, err := ts.newRowStreamer(ctx, conn, query, lastpk, send)
 	if err != nil {
 		return err
 	}
 	defer cancel()
 
-	if rs.Stream() != nil {
+	if err := rs.Stream(); err != nil {
 		return err
 	}
 	rs.vse.tableStreamerNumTables.Add(int64(1))
 
 	return nil
 }
+
+func prepareTableStreamerConn(conn *snapshotConn) error {
+	if _, err := conn.ExecuteFetch("set names 'binary'", 1, false); err != nil {
+		return err
+	}
+	if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@session.net_read_timeout = %v", vttablet.VReplicationNetReadTimeout), 1, false); err != nil {
+		return err
+	}
+	if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@session.net_write_timeout = %v", vttablet.VReplicationNetWriteTimeout), 1, false); err != nil {
+		return err
+	}
+	return nil
+}
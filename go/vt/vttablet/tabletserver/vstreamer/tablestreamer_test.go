@@ -19,10 +19,13 @@ package vstreamer
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/sqltypes"
+
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 )
 
@@ -64,7 +67,7 @@ func TestTableStreamer(t *testing.T) {
 		"table_name:\"t4\" rows:{lengths:1 lengths:1 lengths:1 lengths:3 values:\"123aaa\"} rows:{lengths:1 lengths:1 lengths:1 lengths:3 values:\"234bbb\"} lastpk:{lengths:1 lengths:1 lengths:1 values:\"234\"}",
 	}
 	var gotStream []string
-	err := engine.StreamTables(ctx, func(response *binlogdatapb.VStreamTablesResponse) error {
+	err := engine.StreamTables(ctx, nil, nil, 0, func(response *binlogdatapb.VStreamTablesResponse) error {
 		response.Gtid = ""
 		for _, fld := range response.Fields {
 			fld.ColumnType = ""
@@ -76,3 +79,68 @@ func TestTableStreamer(t *testing.T) {
 	require.EqualValues(t, wantStream, gotStream)
 	require.Equal(t, int64(4), engine.tableStreamerNumTables.Get())
 }
+
+func TestTableStreamerFilter(t *testing.T) {
+	ctx := context.Background()
+	execStatements(t, []string{
+		"create table t1(id int, val varbinary(128), primary key(id))",
+		"insert into t1 values (1, 'aaa'), (2, 'bbb'), (3, 'ccc')",
+		"create table t2(id int, val varbinary(128), primary key(id))",
+		"insert into t2 values (1, 'ddd')",
+		"create table t3(id int, val varbinary(128), primary key(id))",
+		"insert into t3 values (1, 'eee')",
+	})
+	defer execStatements(t, []string{
+		"drop table t1",
+		"drop table t2",
+		"drop table t3",
+	})
+	engine.se.Reload(context.Background())
+
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select id, val from t1",
+		}, {
+			Match:  "t2",
+			Filter: "select val from t2",
+		}},
+	}
+	tablePKs := []*binlogdatapb.TableLastPK{{
+		TableName: "t1",
+		Lastpk:    sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int32"), "1")),
+	}}
+
+	// The tables are streamed concurrently, so the responses are only
+	// ordered per table.
+	wantStream := map[string][]string{
+		"t1": {
+			"table_name:\"t1\" rows:{lengths:1 lengths:3 values:\"2bbb\"} rows:{lengths:1 lengths:3 values:\"3ccc\"} lastpk:{lengths:1 values:\"3\"}",
+			"table_name:\"t1\" completed:true",
+		},
+		"t2": {
+			"table_name:\"t2\" rows:{lengths:3 values:\"ddd\"} lastpk:{lengths:1 values:\"1\"}",
+			"table_name:\"t2\" completed:true",
+		},
+	}
+	var mu sync.Mutex
+	gtids := make(map[string]bool)
+	gotStream := make(map[string][]string)
+	err := engine.StreamTables(ctx, tablePKs, filter, 2, func(response *binlogdatapb.VStreamTablesResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		gtids[response.Gtid] = true
+		response.Gtid = ""
+		if len(response.Rows) == 0 && !response.Completed {
+			// Skip the fields, which are covered by TestTableStreamer, and
+			// the heartbeats.
+			return nil
+		}
+		gotStream[response.TableName] = append(gotStream[response.TableName], fmt.Sprintf("%v", response))
+		return nil
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, wantStream, gotStream)
+	// All the tables are streamed from the same snapshot.
+	require.Len(t, gtids, 1)
+}
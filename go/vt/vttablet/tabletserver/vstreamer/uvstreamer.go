@@ -167,6 +167,10 @@ func (uvs *uvstreamer) buildTablePlan() error {
 			},
 		}
 		tablePK, ok := tableLastPKs[tableName]
+		if ok && tablePK.Completed {
+			// The copy of the table was completed before the stream was resumed.
+			continue
+		}
 		if !ok {
 			tablePK = &binlogdatapb.TableLastPK{
 				TableName: tableName,
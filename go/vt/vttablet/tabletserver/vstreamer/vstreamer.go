@@ -29,7 +29,9 @@ import (
 	"vitess.io/vitess/go/mysql"
 	mysqlbinlog "vitess.io/vitess/go/mysql/binlog"
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
 	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/binlog"
@@ -183,18 +185,68 @@ func (vs *vstreamer) replicate(ctx context.Context) error {
 		return wrapError(err, vs.pos, vs.vse)
 	}
 
-	conn, err := binlog.NewBinlogConnection(vs.cp)
+	err := vs.replicateFrom(vs.cp)
+	if sqlErr, ok := err.(*sqlerror.SQLError); ok && sqlErr.Number() == sqlerror.ERMasterFatalReadingBinlog {
+		// The MySQL server purged binary logs the stream requires: catch
+		// up from a binlog server of the shard, if there is one.
+		cp, cpErr := vs.binlogServerConnector()
+		if cpErr != nil {
+			log.Warningf("Cannot look up a binlog server to stream from %v: %v", vs.pos, cpErr)
+		} else if cp != nil {
+			log.Infof("Binary logs purged from the MySQL server, streaming from %v from a binlog server: %v", vs.pos, err)
+			err = vs.replicateFrom(*cp)
+		}
+	}
+	return wrapError(err, vs.pos, vs.vse)
+}
+
+// replicateFrom streams the binary logs of the MySQL or binlog server of cp
+// from the current position.
+func (vs *vstreamer) replicateFrom(cp dbconfigs.Connector) error {
+	conn, err := binlog.NewBinlogConnection(cp)
 	if err != nil {
-		return wrapError(err, vs.pos, vs.vse)
+		return err
 	}
 	defer conn.Close()
 
 	events, errs, err := conn.StartBinlogDumpFromPosition(vs.ctx, "", vs.pos)
 	if err != nil {
-		return wrapError(err, vs.pos, vs.vse)
+		return err
 	}
-	err = vs.parseEvents(vs.ctx, events, errs)
-	return wrapError(err, vs.pos, vs.vse)
+	return vs.parseEvents(vs.ctx, events, errs)
+}
+
+// binlogServerConnector returns the connector of a binlog server of the shard,
+// with the credentials of the stream, or nil if there is none.
+func (vs *vstreamer) binlogServerConnector() (*dbconfigs.Connector, error) {
+	if vs.vse.ts == nil || vs.vse.keyspace == "" {
+		return nil, nil
+	}
+	ts, err := vs.vse.ts.GetTopoServer()
+	if err != nil {
+		return nil, err
+	}
+	tablets, err := ts.GetTabletMapForShard(vs.ctx, vs.vse.keyspace, vs.vse.shard)
+	if err != nil {
+		return nil, err
+	}
+	for _, ti := range tablets {
+		port := ti.PortMap["binlog"]
+		if port == 0 || ti.Hostname == "" {
+			continue
+		}
+		params, err := vs.cp.MysqlParams()
+		if err != nil {
+			return nil, err
+		}
+		cp := *params
+		cp.Host = ti.Hostname
+		cp.Port = int(port)
+		cp.UnixSocket = ""
+		connector := dbconfigs.New(&cp)
+		return &connector, nil
+	}
+	return nil, nil
 }
 
 // parseEvents parses and sends events.
@@ -868,7 +920,7 @@ func (vs *vstreamer) processJournalEvent(vevents []*binlogdatapb.VEvent, plan *s
 	}
 nextrow:
 	for _, row := range rows.Rows {
-		afterOK, afterValues, _, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns)
+		afterOK, afterValues, _, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -906,11 +958,20 @@ nextrow:
 func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *streamerPlan, rows mysql.Rows) ([]*binlogdatapb.VEvent, error) {
 	rowChanges := make([]*binlogdatapb.RowChange, 0, len(rows.Rows))
 	for _, row := range rows.Rows {
-		beforeOK, beforeValues, _, err := vs.extractRowAndFilter(plan, row.Identify, rows.IdentifyColumns, row.NullIdentifyColumns)
+		beforeOK, beforeValues, _, err := vs.extractRowAndFilter(plan, row.Identify, rows.IdentifyColumns, row.NullIdentifyColumns, nil)
 		if err != nil {
 			return nil, err
 		}
-		afterOK, afterValues, partial, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns)
+		var partialJSON *partialJSONUpdate
+		if row.JSONPartialValues.Count() > 0 {
+			// The JSON diffs of the after image are applied to the documents
+			// of the before image to rebuild the full values of the columns.
+			partialJSON = &partialJSONUpdate{partialValues: row.JSONPartialValues}
+			if partialJSON.before, err = extractJSONDocuments(plan, row.Identify, rows.IdentifyColumns, row.NullIdentifyColumns); err != nil {
+				return nil, err
+			}
+		}
+		afterOK, afterValues, partial, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns, partialJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -971,17 +1032,31 @@ func (vs *vstreamer) rebuildPlans() error {
 	return nil
 }
 
+// partialJSONUpdate holds what is needed to rebuild the values of the JSON
+// columns of a row that were logged as JSON diffs, which MySQL does when
+// binlog_row_value_options is set to PARTIAL_JSON.
+type partialJSONUpdate struct {
+	// partialValues describes which of the present JSON columns of the
+	// after image hold JSON diffs.
+	partialValues mysql.Bitmap
+	// before has the JSON documents of the before image, by column.
+	before []*json.Value
+}
+
 // extractRowAndFilter takes the data and bitmaps from the binlog events and returns the following
 //   - true, if row needs to be skipped because of workflow filter rules
 //   - data values, array of one value per column
 //   - true, if the row image was partial (i.e. binlog_row_image=noblob and dml doesn't update one or more blob/text columns)
-func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataColumns, nullColumns mysql.Bitmap) (bool, []sqltypes.Value, bool, error) {
+//
+// The JSON diffs of a partial JSON update are applied to the before image documents of partialJSON, if any.
+func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataColumns, nullColumns mysql.Bitmap, partialJSON *partialJSONUpdate) (bool, []sqltypes.Value, bool, error) {
 	if len(data) == 0 {
 		return false, nil, false, nil
 	}
 	values := make([]sqltypes.Value, dataColumns.Count())
 	charsets := make([]collations.ID, len(values))
 	valueIndex := 0
+	jsonIndex := 0
 	pos := 0
 	partial := false
 	for colNum := 0; colNum < dataColumns.Count(); colNum++ {
@@ -993,11 +1068,23 @@ func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataCo
 			}
 			continue
 		}
+		isJSONDiff := false
+		if plan.TableMap.Types[colNum] == mysqlbinlog.TypeJSON {
+			isJSONDiff = partialJSON != nil && partialJSON.partialValues.Bit(jsonIndex)
+			jsonIndex++
+		}
 		if nullColumns.Bit(valueIndex) {
 			valueIndex++
 			continue
 		}
-		value, l, err := mysqlbinlog.CellValue(data, pos, plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum], plan.Table.Fields[colNum])
+		var value sqltypes.Value
+		var l int
+		var err error
+		if isJSONDiff {
+			value, l, err = mysqlbinlog.JSONDiffValue(data, pos, partialJSON.before[colNum])
+		} else {
+			value, l, err = mysqlbinlog.CellValue(data, pos, plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum], plan.Table.Fields[colNum])
+		}
 		if err != nil {
 			log.Errorf("extractRowAndFilter: %s, table: %s, colNum: %d, fields: %+v, current values: %+v",
 				err, plan.Table.Name, colNum, plan.Table.Fields, values)
@@ -1014,6 +1101,36 @@ func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataCo
 	return ok, filtered, partial, err
 }
 
+// extractJSONDocuments returns the JSON documents of a row image, by column.
+func extractJSONDocuments(plan *streamerPlan, data []byte, dataColumns, nullColumns mysql.Bitmap) ([]*json.Value, error) {
+	docs := make([]*json.Value, dataColumns.Count())
+	valueIndex := 0
+	pos := 0
+	for colNum := 0; colNum < dataColumns.Count(); colNum++ {
+		if !dataColumns.Bit(colNum) {
+			continue
+		}
+		if nullColumns.Bit(valueIndex) {
+			valueIndex++
+			continue
+		}
+		typ, metadata := plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum]
+		l, err := mysqlbinlog.CellLength(data, pos, typ, metadata)
+		if err != nil {
+			return nil, err
+		}
+		if typ == mysqlbinlog.TypeJSON {
+			// The document follows its length, which is stored on metadata bytes.
+			if docs[colNum], err = mysqlbinlog.ParseBinaryJSON(data[pos+int(metadata) : pos+l]); err != nil {
+				return nil, err
+			}
+		}
+		pos += l
+		valueIndex++
+	}
+	return docs, nil
+}
+
 func wrapError(err error, stopPos replication.Position, vse *Engine) error {
 	if err != nil {
 		vse.vstreamersEndedWithErrors.Add(1)
@@ -1908,6 +1908,50 @@ func TestJSON(t *testing.T) {
 	runCases(t, nil, testcases, "", nil)
 }
 
+// TestJSONPartialUpdates confirms that the full values of the JSON columns are
+// streamed when MySQL logs the updates of these columns as JSON diffs.
+func TestJSONPartialUpdates(t *testing.T) {
+	if err := env.Mysqld.ExecuteSuperQuery(context.Background(), "set @@global.binlog_row_value_options='PARTIAL_JSON'"); err != nil {
+		// MySQL versions older than 8.0 do not log partial JSON updates.
+		if strings.Contains(err.Error(), "Unknown system variable") {
+			return
+		}
+		t.Fatal(err)
+	}
+	defer execStatement(t, "set @@global.binlog_row_value_options=''")
+	execStatements(t, []string{
+		"create table vitess_json_partial(id int, val json, primary key(id))",
+	})
+	defer execStatement(t, "drop table vitess_json_partial")
+	engine.se.Reload(context.Background())
+
+	row := func(val string) string {
+		return fmt.Sprintf(`{lengths:1 lengths:%d values:"1%s"}`, len(val), strings.ReplaceAll(val, "\"", "\\\""))
+	}
+	inserted := `{"a": 1, "b": [1, 2]}`
+	set := `{"a": 2, "b": [1, 2], "c": "x"}`
+	removed := `{"a": 2, "b": [2], "c": "x"}`
+	testcases := []testcase{{
+		input: []string{
+			"begin",
+			fmt.Sprintf("insert into vitess_json_partial values (1, %s)", encodeString(inserted)),
+			`update vitess_json_partial set val = json_set(val, '$.a', 2, '$.c', 'x') where id = 1`,
+			`update vitess_json_partial set val = json_remove(val, '$.b[0]') where id = 1`,
+			"commit",
+		},
+		output: [][]string{{
+			`begin`,
+			`type:FIELD field_event:{table_name:"vitess_json_partial" fields:{name:"id" type:INT32 table:"vitess_json_partial" org_table:"vitess_json_partial" database:"vttest" org_name:"id" column_length:11 charset:63 column_type:"int(11)"} fields:{name:"val" type:JSON table:"vitess_json_partial" org_table:"vitess_json_partial" database:"vttest" org_name:"val" column_length:4294967295 charset:63 column_type:"json"}}`,
+			fmt.Sprintf(`type:ROW row_event:{table_name:"vitess_json_partial" row_changes:{after:%s}}`, row(inserted)),
+			fmt.Sprintf(`type:ROW row_event:{table_name:"vitess_json_partial" row_changes:{before:%s after:%s}}`, row(inserted), row(set)),
+			fmt.Sprintf(`type:ROW row_event:{table_name:"vitess_json_partial" row_changes:{before:%s after:%s}}`, row(set), row(removed)),
+			`gtid`,
+			`commit`,
+		}},
+	}}
+	runCases(t, nil, testcases, "", nil)
+}
+
 func TestExternalTable(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
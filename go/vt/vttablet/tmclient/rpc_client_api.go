@@ -59,6 +59,30 @@ func init() {
 	}
 }
 
+// BackupStream streams the logs and the progress of a backup.
+type BackupStream interface {
+	// Recv returns the next message of the stream, or io.EOF once the
+	// backup is over. Each message has either a log event or the progress
+	// of the backup set.
+	Recv() (*tabletmanagerdatapb.BackupResponse, error)
+}
+
+// StreamBackupStream streams the logs and the files of a backup taken by a
+// tablet.
+type StreamBackupStream interface {
+	// Recv returns the next message of the stream, or io.EOF once the
+	// whole backup was sent.
+	Recv() (*tabletmanagerdatapb.StreamBackupResponse, error)
+}
+
+// ValidateBackupStream streams the logs of a backup validation, followed by
+// its verdict.
+type ValidateBackupStream interface {
+	// Recv returns the next message of the stream, or io.EOF once the
+	// validation is over. The verdict is set on the last message.
+	Recv() (*tabletmanagerdatapb.ValidateBackupResponse, error)
+}
+
 // TabletManagerClient defines the interface used to talk to a remote tablet
 type TabletManagerClient interface {
 	//
@@ -90,6 +114,9 @@ type TabletManagerClient interface {
 	// ChangeType asks the remote tablet to change its type
 	ChangeType(ctx context.Context, tablet *topodatapb.Tablet, dbType topodatapb.TabletType, semiSync bool) error
 
+	// ChangeTags asks the remote tablet to change its tags
+	ChangeTags(ctx context.Context, tablet *topodatapb.Tablet, tabletTags map[string]string, replace bool) (*tabletmanagerdatapb.ChangeTagsResponse, error)
+
 	// Sleep will sleep for a duration (used for tests)
 	Sleep(ctx context.Context, tablet *topodatapb.Tablet, duration time.Duration) error
 
@@ -241,14 +268,37 @@ type TabletManagerClient interface {
 	//
 
 	// Backup creates a database backup
-	Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (logutil.EventStream, error)
+	Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (BackupStream, error)
 
 	// RestoreFromBackup deletes local data and restores database from backup
 	RestoreFromBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromBackupRequest) (logutil.EventStream, error)
 
+	// StreamBackup takes a database backup and streams it, so that another
+	// tablet can restore it without going through the backup storage
+	StreamBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.StreamBackupRequest) (StreamBackupStream, error)
+
+	// ValidateBackup restores a backup into a scratch MySQL instance and
+	// checks the restored data
+	ValidateBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ValidateBackupRequest) (ValidateBackupStream, error)
+
+	// ReloadTLSCertificates replaces the certificate, key or CA of the
+	// tablet's gRPC server, or re-reads them from their files
+	ReloadTLSCertificates(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReloadTLSCertificatesRequest) (*tabletmanagerdatapb.ReloadTLSCertificatesResponse, error)
+
 	// Throttler
 	CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error)
 
+	//
+	// Distributed transaction related methods
+	//
+
+	// GetUnresolvedTransactions returns the unresolved distributed transactions
+	// whose metadata is stored on the tablet.
+	GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (*tabletmanagerdatapb.GetUnresolvedTransactionsResponse, error)
+
+	// ConcludeTransaction resolves the tablet's part of a distributed transaction.
+	ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.ConcludeTransactionRequest) (*tabletmanagerdatapb.ConcludeTransactionResponse, error)
+
 	//
 	// Management methods
 	//
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vttls
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// ocspChecker checks the revocation status of certificates with OCSP,
+// caching the responses until their next update.
+type ocspChecker struct {
+	// responder is the URL of the responder to ask, instead of the ones
+	// of the certificates, if set.
+	responder string
+	client    *http.Client
+
+	mu        sync.Mutex
+	responses map[string]*ocsp.Response
+}
+
+func verifyPeerCertificateWithOCSP(responder string, timeout time.Duration) verifyPeerCertificateFunc {
+	checker := &ocspChecker{
+		responder: responder,
+		client:    &http.Client{Timeout: timeout},
+		responses: make(map[string]*ocsp.Response),
+	}
+
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			if len(chain) < 2 {
+				continue
+			}
+			cert, issuer := chain[0], chain[1]
+			status, err := checker.status(cert, issuer)
+			if err != nil {
+				return fmt.Errorf("Cannot check the revocation of the certificate with OCSP: CommonName=%v: %v", cert.Subject.CommonName, err)
+			}
+			if status == ocsp.Revoked {
+				return fmt.Errorf("Certificate revoked: CommonName=%v", cert.Subject.CommonName)
+			}
+		}
+		return nil
+	}
+}
+
+// status returns the OCSP status of the certificate, ocsp.Unknown if there
+// is no responder to ask.
+func (oc *ocspChecker) status(cert, issuer *x509.Certificate) (int, error) {
+	key := Fingerprint(issuer) + "/" + cert.SerialNumber.String()
+	oc.mu.Lock()
+	resp, ok := oc.responses[key]
+	oc.mu.Unlock()
+	if ok && time.Now().Before(resp.NextUpdate) {
+		return resp.Status, nil
+	}
+
+	responders := cert.OCSPServer
+	if oc.responder != "" {
+		responders = []string{oc.responder}
+	}
+	if len(responders) == 0 {
+		return ocsp.Unknown, nil
+	}
+
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return ocsp.Unknown, err
+	}
+	for _, responder := range responders {
+		resp, err = oc.query(responder, req, cert, issuer)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return ocsp.Unknown, err
+	}
+
+	if !resp.NextUpdate.IsZero() {
+		oc.mu.Lock()
+		oc.responses[key] = resp
+		oc.mu.Unlock()
+	}
+	return resp.Status, nil
+}
+
+// query asks the responder for the status of the certificate.
+func (oc *ocspChecker) query(responder string, req []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	httpResp, err := oc.client.Post(responder, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder %s returned %s", responder, httpResp.Status)
+	}
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ocsp.ParseResponseForCert(body, cert, issuer)
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package vttls
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -223,6 +226,127 @@ func ServerConfig(cert, key, ca, crl, serverCA string, minTLSVersion uint16) (*t
 	return config, nil
 }
 
+// ReloadServerConfig is like ServerConfig, but it first reloads the
+// certificates, key and CAs from their files, rather than using the ones
+// loaded by the previous calls, so that a server can serve renewed
+// certificates without a restart. If they cannot be loaded, the ones loaded
+// previously are kept and an error is returned.
+func ReloadServerConfig(cert, key, ca, crl, serverCA string, minTLSVersion uint16) (*tls.Config, error) {
+	var err error
+	if serverCA != "" {
+		err = doLoadAndCombineTLSCertificates(serverCA, cert, key)
+	} else {
+		err = doLoadTLSCertificate(cert, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ca != "" {
+		if err := doLoadx509CertPool(ca); err != nil {
+			return nil, err
+		}
+	}
+
+	return ServerConfig(cert, key, ca, crl, serverCA, minTLSVersion)
+}
+
+// ServerHardening are the options of the TLS config of a server beyond its
+// certificates, CAs and minimum TLS version, which security policies commonly
+// require.
+type ServerHardening struct {
+	// CipherSuites are the names of the cipher suites allowed for TLS 1.2
+	// and below, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. The secure
+	// cipher suites of Go are allowed if empty. The cipher suites of
+	// TLS 1.3 cannot be configured.
+	CipherSuites []string
+	// SNICertificates are additional certificates, as cert:key pairs of
+	// paths, served to the clients asking for one of their names with SNI.
+	// The certificate of the config is served to the other clients.
+	SNICertificates []string
+	// OCSP enables checking the client certificates with OCSP. They are
+	// rejected if revoked, or if their status cannot be obtained from the
+	// responder.
+	OCSP bool
+	// OCSPResponder is the URL of the OCSP responder to ask, instead of
+	// the ones of the certificates. The certificates without responder
+	// are accepted if it is empty.
+	OCSPResponder string
+	// OCSPTimeout is the timeout of the requests to the OCSP responder.
+	OCSPTimeout time.Duration
+}
+
+// Apply sets the options on a config returned by ServerConfig.
+func (h *ServerHardening) Apply(config *tls.Config) error {
+	if len(h.CipherSuites) > 0 {
+		cipherSuites, err := CipherSuitesToNumbers(h.CipherSuites)
+		if err != nil {
+			return err
+		}
+		config.CipherSuites = cipherSuites
+	}
+
+	if len(h.SNICertificates) > 0 {
+		var sniCertificates []tls.Certificate
+		for _, pair := range h.SNICertificates {
+			cert, key, ok := strings.Cut(pair, ":")
+			if !ok {
+				return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid SNI certificate %q, expected cert:key", pair)
+			}
+			certificates, err := loadTLSCertificate(cert, key)
+			if err != nil {
+				return err
+			}
+			sniCertificates = append(sniCertificates, *certificates...)
+		}
+		config.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			for i := range sniCertificates {
+				if hello.SupportsCertificate(&sniCertificates[i]) == nil {
+					return &sniCertificates[i], nil
+				}
+			}
+			// Serve the certificate of the config.
+			return nil, nil
+		}
+	}
+
+	if h.OCSP {
+		ocspFunc := verifyPeerCertificateWithOCSP(h.OCSPResponder, h.OCSPTimeout)
+		crlFunc := config.VerifyPeerCertificate
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if crlFunc != nil {
+				if err := crlFunc(rawCerts, verifiedChains); err != nil {
+					return err
+				}
+			}
+			return ocspFunc(rawCerts, verifiedChains)
+		}
+	}
+	return nil
+}
+
+// CipherSuitesToNumbers converts the names of cipher suites to the internal
+// Go number representation.
+func CipherSuitesToNumbers(names []string) ([]uint16, error) {
+	ids := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		ids[suite.Name] = suite.ID
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		ids[suite.Name] = suite.ID
+	}
+
+	cipherSuites := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := ids[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Invalid cipher suite specified: %s", name)
+		}
+		cipherSuites = append(cipherSuites, id)
+	}
+	return cipherSuites, nil
+}
+
 var certPools = sync.Map{}
 
 func loadx509CertPool(ca string) (*x509.CertPool, error) {
@@ -364,3 +488,10 @@ func doLoadAndCombineTLSCertificates(ca, cert, key string) error {
 
 	return nil
 }
+
+// Fingerprint returns the hex encoded SHA-256 digest of the DER encoding of
+// a certificate, which identifies it.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
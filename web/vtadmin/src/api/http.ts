@@ -377,6 +377,41 @@ export const stopReplication = async ({ clusterID, alias }: StopReplicationParam
 
     return pb.StopReplicationResponse.create(result);
 };
+
+export interface DrainTabletParams {
+    clusterID?: string;
+    alias: string;
+    reason?: string;
+}
+
+export const drainTablet = async ({ clusterID, alias, reason }: DrainTabletParams) => {
+    const req = new URLSearchParams();
+    req.append('cluster', clusterID || '');
+
+    if (reason) {
+        req.append('reason', reason);
+    }
+
+    const { result } = await vtfetch(`/api/tablet/${alias}/drain?${req}`, { method: 'put' });
+    const err = pb.DrainTabletResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.DrainTabletResponse.create(result);
+};
+
+export interface UndrainTabletParams {
+    clusterID?: string;
+    alias: string;
+}
+
+export const undrainTablet = async ({ clusterID, alias }: UndrainTabletParams) => {
+    const { result } = await vtfetch(`/api/tablet/${alias}/undrain?cluster=${clusterID}`, { method: 'put' });
+    const err = pb.UndrainTabletResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.UndrainTabletResponse.create(result);
+};
+
 export interface TabletDebugVarsResponse {
     params: FetchTabletParams;
     data?: TabletDebugVars;
@@ -769,6 +804,19 @@ export const getTopologyPath = async (params: GetTopologyPathParams) => {
 
     return vtctldata.GetTopologyPathResponse.create(result);
 };
+
+export interface FetchClusterGraphParams {
+    clusterID: string;
+}
+
+export const fetchClusterGraph = async ({ clusterID }: FetchClusterGraphParams) => {
+    const { result } = await vtfetch(`/api/cluster/${clusterID}/graph`);
+
+    const err = pb.ClusterGraph.verify(result);
+    if (err) throw Error(err);
+
+    return pb.ClusterGraph.create(result);
+};
 export interface ValidateParams {
     clusterID: string;
     pingTablets: boolean;
@@ -840,3 +888,434 @@ export const validateVersionShard = async (params: ValidateVersionShardParams) =
 
     return vtctldata.ValidateVersionShardResponse.create(result);
 };
+
+export interface FetchSchemaMigrationsParams {
+    clusterIDs?: string[];
+    keyspace?: string;
+    status?: string;
+    recent?: string;
+}
+
+export const fetchSchemaMigrations = async (params: FetchSchemaMigrationsParams = {}) => {
+    const req = new URLSearchParams();
+    (params.clusterIDs || []).forEach((id) => req.append('cluster_id', id));
+    if (params.keyspace) req.append('keyspace', params.keyspace);
+    if (params.status) req.append('status', params.status);
+    if (params.recent) req.append('recent', params.recent);
+
+    const { result } = await vtfetch(`/api/migrations?${req.toString()}`);
+
+    const err = pb.GetSchemaMigrationsResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.GetSchemaMigrationsResponse.create(result);
+};
+
+export interface ApplySchemaParams {
+    clusterID: string;
+    keyspace: string;
+    sql: string;
+    ddlStrategy: string;
+    migrationContext?: string;
+}
+
+export const applySchema = async (params: ApplySchemaParams) => {
+    const { result } = await vtfetch(`/api/migration/${params.clusterID}/${params.keyspace}`, {
+        method: 'post',
+        body: JSON.stringify({
+            sql: params.sql,
+            ddl_strategy: params.ddlStrategy,
+            migration_context: params.migrationContext,
+        }),
+    });
+
+    const err = vtctldata.ApplySchemaResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.ApplySchemaResponse.create(result);
+};
+
+export interface SchemaMigrationActionParams {
+    clusterID: string;
+    keyspace: string;
+    // uuid may be "all" for the cancel, complete, and launch actions.
+    uuid: string;
+}
+
+const schemaMigrationAction = async (action: string, params: SchemaMigrationActionParams) => {
+    const req = new URLSearchParams();
+    req.append('uuid', params.uuid);
+
+    const { result } = await vtfetch(`/api/migration/${params.clusterID}/${params.keyspace}/${action}?${req}`, {
+        method: 'put',
+    });
+
+    return result;
+};
+
+export const cancelSchemaMigration = async (params: SchemaMigrationActionParams) => {
+    const result = await schemaMigrationAction('cancel', params);
+
+    const err = vtctldata.CancelSchemaMigrationResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.CancelSchemaMigrationResponse.create(result);
+};
+
+export const cleanupSchemaMigration = async (params: SchemaMigrationActionParams) => {
+    const result = await schemaMigrationAction('cleanup', params);
+
+    const err = vtctldata.CleanupSchemaMigrationResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.CleanupSchemaMigrationResponse.create(result);
+};
+
+export const completeSchemaMigration = async (params: SchemaMigrationActionParams) => {
+    const result = await schemaMigrationAction('complete', params);
+
+    const err = vtctldata.CompleteSchemaMigrationResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.CompleteSchemaMigrationResponse.create(result);
+};
+
+export const launchSchemaMigration = async (params: SchemaMigrationActionParams) => {
+    const result = await schemaMigrationAction('launch', params);
+
+    const err = vtctldata.LaunchSchemaMigrationResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.LaunchSchemaMigrationResponse.create(result);
+};
+
+export const retrySchemaMigration = async (params: SchemaMigrationActionParams) => {
+    const result = await schemaMigrationAction('retry', params);
+
+    const err = vtctldata.RetrySchemaMigrationResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.RetrySchemaMigrationResponse.create(result);
+};
+
+export interface MoveTablesCreateParams {
+    clusterID: string;
+    options: vtctldata.IMoveTablesCreateRequest;
+}
+
+export const validateMoveTablesCreate = async (params: MoveTablesCreateParams) => {
+    const { result } = await vtfetch(`/api/movetables/${params.clusterID}/validate`, {
+        body: JSON.stringify(params.options),
+        method: 'post',
+    });
+
+    const err = pb.ValidateWorkflowCreateResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.ValidateWorkflowCreateResponse.create(result);
+};
+
+export const moveTablesCreate = async (params: MoveTablesCreateParams) => {
+    const { result } = await vtfetch(`/api/movetables/${params.clusterID}`, {
+        body: JSON.stringify(params.options),
+        method: 'post',
+    });
+
+    const err = vtctldata.WorkflowStatusResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.WorkflowStatusResponse.create(result);
+};
+
+export interface ReshardCreateParams {
+    clusterID: string;
+    options: vtctldata.IReshardCreateRequest;
+}
+
+export const validateReshardCreate = async (params: ReshardCreateParams) => {
+    const { result } = await vtfetch(`/api/reshard/${params.clusterID}/validate`, {
+        body: JSON.stringify(params.options),
+        method: 'post',
+    });
+
+    const err = pb.ValidateWorkflowCreateResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.ValidateWorkflowCreateResponse.create(result);
+};
+
+export const reshardCreate = async (params: ReshardCreateParams) => {
+    const { result } = await vtfetch(`/api/reshard/${params.clusterID}`, {
+        body: JSON.stringify(params.options),
+        method: 'post',
+    });
+
+    const err = vtctldata.WorkflowStatusResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.WorkflowStatusResponse.create(result);
+};
+
+export interface FetchReshardRecommendationParams {
+    clusterID: string;
+    keyspace: string;
+    sourceShards?: string[];
+    shardCount?: number;
+}
+
+export const fetchReshardRecommendation = async (params: FetchReshardRecommendationParams) => {
+    const req = new URLSearchParams();
+    (params.sourceShards || []).forEach((shard) => req.append('source_shard', shard));
+    if (params.shardCount) req.append('shard_count', String(params.shardCount));
+
+    const { result } = await vtfetch(`/api/reshard/${params.clusterID}/${params.keyspace}/recommend?${req}`);
+
+    const err = vtctldata.ReshardRecommendResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.ReshardRecommendResponse.create(result);
+};
+
+export interface WorkflowSwitchTrafficParams {
+    clusterID: string;
+    keyspace: string;
+    name: string;
+    options?: vtctldata.IWorkflowSwitchTrafficRequest;
+}
+
+export const workflowSwitchTraffic = async (params: WorkflowSwitchTrafficParams) => {
+    const path = `/api/workflow/${params.clusterID}/${params.keyspace}/${params.name}/switch_traffic`;
+    const { result } = await vtfetch(path, {
+        body: JSON.stringify(params.options || {}),
+        method: 'put',
+    });
+
+    const err = vtctldata.WorkflowSwitchTrafficResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.WorkflowSwitchTrafficResponse.create(result);
+};
+
+export interface MoveTablesCompleteParams {
+    clusterID: string;
+    // keyspace is the target keyspace of the workflow.
+    keyspace: string;
+    name: string;
+    options?: vtctldata.IMoveTablesCompleteRequest;
+}
+
+export const moveTablesComplete = async (params: MoveTablesCompleteParams) => {
+    const path = `/api/workflow/${params.clusterID}/${params.keyspace}/${params.name}/complete`;
+    const { result } = await vtfetch(path, {
+        body: JSON.stringify(params.options || {}),
+        method: 'put',
+    });
+
+    const err = vtctldata.MoveTablesCompleteResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.MoveTablesCompleteResponse.create(result);
+};
+
+export interface SchemaDiffTargetParams {
+    clusterID: string;
+    keyspace: string;
+
+    // Optional; a shard of the keyspace to read the schema from, e.g. "-80".
+    shard?: string;
+}
+
+export interface DiffSchemasParams {
+    source: SchemaDiffTargetParams;
+    target: SchemaDiffTargetParams;
+    tables?: string[];
+}
+
+export const diffSchemas = async (params: DiffSchemasParams) => {
+    const req = new URLSearchParams();
+    req.append('source_cluster_id', params.source.clusterID);
+    req.append('source_keyspace', params.source.keyspace);
+    if (params.source.shard) req.append('source_shard', params.source.shard);
+    req.append('target_cluster_id', params.target.clusterID);
+    req.append('target_keyspace', params.target.keyspace);
+    if (params.target.shard) req.append('target_shard', params.target.shard);
+    (params.tables || []).forEach((table) => req.append('table', table));
+
+    const { result } = await vtfetch(`/api/schema_diff?${req}`);
+
+    const err = pb.DiffSchemasResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.DiffSchemasResponse.create(result);
+};
+
+export interface FetchSchemaDriftParams {
+    clusterID: string;
+    keyspace: string;
+
+    // Optional; defaults to the first serving shard of the keyspace.
+    referenceShard?: string;
+    tables?: string[];
+}
+
+export const fetchSchemaDrift = async (params: FetchSchemaDriftParams) => {
+    const req = new URLSearchParams();
+    if (params.referenceShard) req.append('reference_shard', params.referenceShard);
+    (params.tables || []).forEach((table) => req.append('table', table));
+
+    const { result } = await vtfetch(`/api/schema_drift/${params.clusterID}/${params.keyspace}?${req}`);
+
+    const err = pb.GetSchemaDriftResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.GetSchemaDriftResponse.create(result);
+};
+
+export interface ExecuteQueryParams {
+    clusterID: string;
+    keyspace: string;
+    sql: string;
+
+    // Optional; defaults to "replica".
+    tabletType?: string;
+    // Optional; lowers the cluster's maximum number of rows returned.
+    maxRows?: number;
+}
+
+export const executeQuery = async (params: ExecuteQueryParams) => {
+    const { result } = await vtfetch(`/api/query/${params.clusterID}/${params.keyspace}`, {
+        method: 'post',
+        body: JSON.stringify({
+            sql: params.sql,
+            tablet_type: params.tabletType,
+            max_rows: params.maxRows,
+        }),
+    });
+
+    const err = pb.ExecuteQueryResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.ExecuteQueryResponse.create(result);
+};
+
+export const fetchClusterMetrics = async () =>
+    vtfetchEntities({
+        endpoint: '/api/cluster_metrics',
+        extract: (res) => res.result.clusters,
+        transform: (e) => {
+            const err = pb.ClusterMetrics.verify(e);
+            if (err) throw Error(err);
+            return pb.ClusterMetrics.create(e);
+        },
+    });
+
+export interface FetchAuditEventsParams {
+    clusterIDs?: string[];
+    actor?: string;
+    method?: string;
+    // RFC3339 timestamps.
+    since?: string;
+    until?: string;
+    limit?: number;
+}
+
+export const fetchAuditEvents = async (params: FetchAuditEventsParams = {}) => {
+    const req = new URLSearchParams();
+    (params.clusterIDs || []).forEach((id) => req.append('cluster_id', id));
+    if (params.actor) req.append('actor', params.actor);
+    if (params.method) req.append('method', params.method);
+    if (params.since) req.append('since', params.since);
+    if (params.until) req.append('until', params.until);
+    if (params.limit) req.append('limit', params.limit.toString());
+
+    const { result } = await vtfetch(`/api/audit_events?${req.toString()}`);
+
+    const err = pb.GetAuditEventsResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.GetAuditEventsResponse.create(result);
+};
+
+export const fetchSavedQueries = async () =>
+    vtfetchEntities({
+        endpoint: '/api/saved_queries',
+        extract: (res) => res.result.saved_queries,
+        transform: (e) => {
+            const err = pb.SavedQuery.verify(e);
+            if (err) throw Error(err);
+            return pb.SavedQuery.create(e);
+        },
+    });
+
+export interface CreateSavedQueryParams {
+    clusterID: string;
+    name: string;
+    description?: string;
+    keyspace: string;
+    // Optional; defaults to "replica".
+    tabletType?: string;
+    sql: string;
+    parameters?: pb.SavedQuery.IParameter[];
+    schedule?: {
+        // A duration, e.g. "1h".
+        interval: string;
+        webhookURL?: string;
+        emailRecipients?: string[];
+    };
+}
+
+export const createSavedQuery = async (params: CreateSavedQueryParams) => {
+    const { result } = await vtfetch(`/api/saved_query/${params.clusterID}`, {
+        method: 'post',
+        body: JSON.stringify({
+            name: params.name,
+            description: params.description,
+            keyspace: params.keyspace,
+            tablet_type: params.tabletType,
+            sql: params.sql,
+            parameters: params.parameters,
+            schedule: params.schedule && {
+                interval: params.schedule.interval,
+                webhook_url: params.schedule.webhookURL,
+                email_recipients: params.schedule.emailRecipients,
+            },
+        }),
+    });
+
+    const err = pb.SavedQuery.verify(result);
+    if (err) throw Error(err);
+
+    return pb.SavedQuery.create(result);
+};
+
+export interface SavedQueryParams {
+    clusterID: string;
+    id: string;
+}
+
+export const deleteSavedQuery = async (params: SavedQueryParams) => {
+    const { result } = await vtfetch(`/api/saved_query/${params.clusterID}/${params.id}`, { method: 'delete' });
+
+    const err = pb.DeleteSavedQueryResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.DeleteSavedQueryResponse.create(result);
+};
+
+export interface RunSavedQueryParams extends SavedQueryParams {
+    // The values of the parameters of the query, by name. Parameters without
+    // a value are bound to their default value.
+    params?: { [name: string]: string };
+}
+
+export const runSavedQuery = async (params: RunSavedQueryParams) => {
+    const { result } = await vtfetch(`/api/saved_query/${params.clusterID}/${params.id}/run`, {
+        method: 'post',
+        body: JSON.stringify({ params: params.params }),
+    });
+
+    const err = pb.ExecuteQueryResponse.verify(result);
+    if (err) throw Error(err);
+
+    return pb.ExecuteQueryResponse.create(result);
+};
@@ -40,6 +40,15 @@ import { isReadOnlyMode } from '../util/env';
 import { CreateKeyspace } from './routes/createKeyspace/CreateKeyspace';
 import { Topology } from './routes/topology/Topology';
 import { ClusterTopology } from './routes/topology/ClusterTopology';
+import { ClusterGraph } from './routes/topology/ClusterGraph';
+import { SchemaMigrations } from './routes/SchemaMigrations';
+import { CreateSchemaMigration } from './routes/createSchemaMigration/CreateSchemaMigration';
+import { QueryConsole } from './routes/QueryConsole';
+import { Metrics } from './routes/metrics/Metrics';
+import { AuditLog } from './routes/AuditLog';
+import { SavedQueries } from './routes/SavedQueries';
+import { CreateSavedQuery } from './routes/createSavedQuery/CreateSavedQuery';
+import { SavedQuery } from './routes/savedQuery/SavedQuery';
 
 export const App = () => {
     return (
@@ -51,6 +60,10 @@ export const App = () => {
                 <SnackbarContainer />
                 <div className={style.mainContainer}>
                     <Switch>
+                        <Route path="/audit">
+                            <AuditLog />
+                        </Route>
+
                         <Route path="/backups">
                             <Backups />
                         </Route>
@@ -73,6 +86,20 @@ export const App = () => {
                             </Route>
                         )}
 
+                        <Route path="/metrics">
+                            <Metrics />
+                        </Route>
+
+                        <Route exact path="/migrations">
+                            <SchemaMigrations />
+                        </Route>
+
+                        {!isReadOnlyMode() && (
+                            <Route exact path="/migrations/create">
+                                <CreateSchemaMigration />
+                            </Route>
+                        )}
+
                         <Route path="/keyspace/:clusterID/:keyspace/shard/:shard">
                             <Shard />
                         </Route>
@@ -81,6 +108,24 @@ export const App = () => {
                             <Keyspace />
                         </Route>
 
+                        <Route path="/query">
+                            <QueryConsole />
+                        </Route>
+
+                        <Route exact path="/saved-queries">
+                            <SavedQueries />
+                        </Route>
+
+                        {!isReadOnlyMode() && (
+                            <Route exact path="/saved-queries/create">
+                                <CreateSavedQuery />
+                            </Route>
+                        )}
+
+                        <Route path="/saved-queries/:clusterID/:id">
+                            <SavedQuery />
+                        </Route>
+
                         <Route path="/schemas">
                             <Schemas />
                         </Route>
@@ -117,6 +162,10 @@ export const App = () => {
                             <Workflow />
                         </Route>
 
+                        <Route path="/topology/:clusterID/graph">
+                            <ClusterGraph />
+                        </Route>
+
                         <Route path="/topology/:clusterID">
                             <ClusterTopology />
                         </Route>
@@ -39,6 +39,7 @@ export const Icon = React.forwardRef<any, Props>(({ icon, ...props }, ref) => {
 export enum Icons {
     alertFail = 'alertFail',
     bug = 'bug',
+    chart = 'chart',
     checkSuccess = 'checkSuccess',
     chevronDown = 'chevronDown',
     chevronUp = 'chevronUp',
@@ -50,6 +50,9 @@ export const NavRail = () => {
                     <li>
                         <NavRailLink hotkey="K" text="Keyspaces" to="/keyspaces" count={keyspaces.length} />
                     </li>
+                    <li>
+                        <NavRailLink hotkey="M" text="Migrations" to="/migrations" />
+                    </li>
                     <li>
                         <NavRailLink hotkey="S" text="Schemas" to="/schemas" count={tds.length} />
                     </li>
@@ -65,12 +68,24 @@ export const NavRail = () => {
                 </ul>
 
                 <ul className={style.navList}>
+                    <li>
+                        <NavRailLink icon={Icons.info} text="Audit Log" to="/audit" />
+                    </li>
                     <li>
                         <NavRailLink icon={Icons.download} text="Backups" to="/backups" />
                     </li>
+                    <li>
+                        <NavRailLink icon={Icons.chart} text="Metrics" to="/metrics" />
+                    </li>
                     <li>
                         <NavRailLink icon={Icons.runQuery} text="VTExplain" to="/vtexplain" />
                     </li>
+                    <li>
+                        <NavRailLink icon={Icons.runQuery} text="Query Console" to="/query" />
+                    </li>
+                    <li>
+                        <NavRailLink icon={Icons.runQuery} text="Saved Queries" to="/saved-queries" />
+                    </li>
                     <li>
                         <NavRailLink icon={Icons.topology} text="Topology" to="/topology" />
                     </li>
@@ -0,0 +1,65 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { vtadmin as pb } from '../proto/vtadmin';
+import { TABLET_TYPES } from '../util/tablets';
+import { DataCell } from './dataTable/DataCell';
+import { DataTable } from './dataTable/DataTable';
+
+interface Props {
+    response: pb.IExecuteQueryResponse;
+}
+
+/**
+ * QueryResults renders the result of a query run through the query console.
+ */
+export const QueryResults = ({ response }: Props) => {
+    const result = response.result;
+    if (!result) return null;
+
+    const tabletType = (TABLET_TYPES[response.tablet_type || 0] || '').toLowerCase();
+
+    const renderRows = (rows: pb.QueryResult.IRow[]) =>
+        rows.map((row, rdx) => (
+            <tr key={rdx}>
+                {(row.values || []).map((value, vdx) => (
+                    <DataCell className="font-mono" key={vdx}>
+                        {row.nulls?.[vdx] ? <span className="text-secondary">NULL</span> : value}
+                    </DataCell>
+                ))}
+            </tr>
+        ));
+
+    return (
+        <div>
+            <div className="text-sm text-secondary mb-4">
+                Ran <code>{response.sql}</code> against{' '}
+                <code>
+                    {response.keyspace}@{tabletType}
+                </code>
+                .
+            </div>
+
+            {result.truncated && (
+                <p className="text-warning">
+                    Only the first {(result.rows || []).length} rows are shown. Add a narrower WHERE or LIMIT clause to
+                    see the rest.
+                </p>
+            )}
+
+            <DataTable columns={result.columns || []} data={result.rows || []} renderRows={renderRows} />
+        </div>
+    );
+};
@@ -0,0 +1,38 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { Pip, PipState } from './Pip';
+import { vtctldata } from '../../proto/vtadmin';
+
+interface Props {
+    status: vtctldata.SchemaMigration.Status | null | undefined;
+}
+
+const STATUS_STATES: { [s in vtctldata.SchemaMigration.Status]: PipState } = {
+    [vtctldata.SchemaMigration.Status.UNKNOWN]: null,
+    [vtctldata.SchemaMigration.Status.REQUESTED]: 'primary',
+    [vtctldata.SchemaMigration.Status.CANCELLED]: 'warning',
+    [vtctldata.SchemaMigration.Status.QUEUED]: 'primary',
+    [vtctldata.SchemaMigration.Status.READY]: 'primary',
+    [vtctldata.SchemaMigration.Status.RUNNING]: 'primary',
+    [vtctldata.SchemaMigration.Status.COMPLETE]: 'success',
+    [vtctldata.SchemaMigration.Status.FAILED]: 'danger',
+};
+
+export const SchemaMigrationStatusPip = ({ status }: Props) => {
+    const state = status ? STATUS_STATES[status] : null;
+    return <Pip state={state} />;
+};
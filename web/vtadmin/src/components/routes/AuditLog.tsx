@@ -0,0 +1,193 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import * as React from 'react';
+
+import { useAuditEvents } from '../../hooks/api';
+import { useDocumentTitle } from '../../hooks/useDocumentTitle';
+import { useSyncedURLParam } from '../../hooks/useSyncedURLParam';
+import { vtadmin as pb } from '../../proto/vtadmin';
+import { filterNouns } from '../../util/filterNouns';
+import { formatDateTime, formatRelativeTime } from '../../util/time';
+import { DataCell } from '../dataTable/DataCell';
+import { DataFilter } from '../dataTable/DataFilter';
+import { DataTable } from '../dataTable/DataTable';
+import { ContentContainer } from '../layout/ContentContainer';
+import { WorkspaceHeader } from '../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../layout/WorkspaceTitle';
+import { QueryErrorPlaceholder } from '../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../placeholders/QueryLoadingPlaceholder';
+
+const OUTCOME_CLASSNAMES: { [k in pb.AuditEvent.Outcome]?: string } = {
+    [pb.AuditEvent.Outcome.SUCCESS]: 'text-success',
+    [pb.AuditEvent.Outcome.FAILURE]: 'text-danger',
+    [pb.AuditEvent.Outcome.UNAUTHORIZED]: 'text-warning',
+};
+
+const EXPORT_FIELDS = [
+    'id',
+    'time',
+    'actor',
+    'clusters',
+    'method',
+    'resource',
+    'action',
+    'outcome',
+    'error',
+    'params',
+] as const;
+
+type ExportRow = { [k in typeof EXPORT_FIELDS[number]]: string };
+
+// escapeCSV quotes a CSV field if it contains a delimiter, a quote or a newline.
+const escapeCSV = (value: string): string => (/[",\n\r]/.test(value) ? `"${value.replace(/"/g, '""')}"` : value);
+
+const toCSV = (rows: ExportRow[]): string => {
+    const lines = rows.map((row) => EXPORT_FIELDS.map((f) => escapeCSV(row[f])).join(','));
+    return [EXPORT_FIELDS.join(','), ...lines].join('\n');
+};
+
+const download = (filename: string, type: string, data: string) => {
+    const url = URL.createObjectURL(new Blob([data], { type }));
+    const a = document.createElement('a');
+    a.href = url;
+    a.download = filename;
+    a.click();
+    URL.revokeObjectURL(url);
+};
+
+export const AuditLog = () => {
+    useDocumentTitle('Audit Log');
+    const query = useAuditEvents();
+
+    const { value: filter, updateValue: updateFilter } = useSyncedURLParam('filter');
+
+    const rows = React.useMemo(() => {
+        const mapped = (query.data?.events || []).map((e) => ({
+            id: `${e.id}`,
+            seconds: Number(e.time?.seconds || 0),
+            actor: e.actor || '',
+            clusters: (e.cluster_ids || []).join(' '),
+            method: e.method || '',
+            resource: e.resource || '',
+            action: e.action || '',
+            outcome: pb.AuditEvent.Outcome[e.outcome || 0].toLowerCase(),
+            error: e.error || '',
+            params: e.params || '',
+            event: e,
+        }));
+
+        // Events are already sorted, most recent first.
+        return filterNouns(filter, mapped);
+    }, [query.data, filter]);
+
+    const exportRows = (): ExportRow[] =>
+        rows.map((row) => ({
+            id: row.id,
+            time: new Date(row.seconds * 1000).toISOString(),
+            actor: row.actor,
+            clusters: row.clusters,
+            method: row.method,
+            resource: row.resource,
+            action: row.action,
+            outcome: row.outcome,
+            error: row.error,
+            params: row.params,
+        }));
+
+    const exportCSV = () => download('vtadmin-audit-log.csv', 'text/csv', toCSV(exportRows()));
+
+    const exportJSON = () =>
+        download('vtadmin-audit-log.json', 'application/json', JSON.stringify(exportRows(), null, 2));
+
+    const renderRows = (rs: typeof rows) =>
+        rs.map((row) => (
+            <tr key={row.id}>
+                <DataCell>
+                    <div className="font-sans whitespace-nowrap">{formatDateTime(row.seconds)}</div>
+                    <div className="font-sans text-sm text-secondary">{formatRelativeTime(row.seconds)}</div>
+                </DataCell>
+                <DataCell>{row.actor || <span className="text-secondary">-</span>}</DataCell>
+                <DataCell>
+                    <div className="font-bold">{row.method}</div>
+                    <div className="text-sm text-secondary">
+                        {row.action} · {row.resource}
+                    </div>
+                </DataCell>
+                <DataCell>{row.clusters}</DataCell>
+                <DataCell>
+                    <div className={OUTCOME_CLASSNAMES[row.event.outcome || 0]}>{row.outcome}</div>
+                    {row.error && <div className="text-sm text-secondary">{row.error}</div>}
+                </DataCell>
+                <DataCell>
+                    <div className="text-sm text-secondary font-mono truncate max-w-md" title={row.params}>
+                        {row.params}
+                    </div>
+                </DataCell>
+            </tr>
+        ));
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <div className="flex items-top justify-between">
+                    <WorkspaceTitle>Audit Log</WorkspaceTitle>
+                    <div className="flex gap-4">
+                        <button
+                            className="btn btn-secondary btn-md"
+                            disabled={!rows.length}
+                            onClick={exportCSV}
+                            type="button"
+                        >
+                            Export CSV
+                        </button>
+                        <button
+                            className="btn btn-secondary btn-md"
+                            disabled={!rows.length}
+                            onClick={exportJSON}
+                            type="button"
+                        >
+                            Export JSON
+                        </button>
+                    </div>
+                </div>
+            </WorkspaceHeader>
+
+            <ContentContainer>
+                <p className="text-secondary max-w-screen-md">
+                    Every call to the VTAdmin API that changes, or attempts to change, a cluster is recorded here,
+                    along with the actor that made it and its outcome. Exports include the rows matching the filter.
+                </p>
+
+                <DataFilter
+                    autoFocus
+                    onChange={(e) => updateFilter(e.target.value)}
+                    onClear={() => updateFilter('')}
+                    placeholder="Filter audit events, e.g. actor:alice outcome:unauthorized"
+                    value={filter || ''}
+                />
+
+                <DataTable
+                    columns={['Time', 'Actor', 'Method', 'Clusters', 'Outcome', 'Params']}
+                    data={rows}
+                    renderRows={renderRows}
+                />
+
+                <QueryLoadingPlaceholder query={query} />
+                <QueryErrorPlaceholder query={query} title="Couldn't load the audit log" />
+            </ContentContainer>
+        </div>
+    );
+};
@@ -0,0 +1,150 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { orderBy } from 'lodash-es';
+import React, { useState } from 'react';
+
+import { useExecuteQuery, useKeyspaces } from '../../hooks/api';
+import { useDocumentTitle } from '../../hooks/useDocumentTitle';
+import { QUERY_TABLET_TYPES } from '../../util/tablets';
+import { FormError } from '../forms/FormError';
+import { Label } from '../inputs/Label';
+import { Select } from '../inputs/Select';
+import { ContentContainer } from '../layout/ContentContainer';
+import { WorkspaceHeader } from '../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../layout/WorkspaceTitle';
+import { QueryResults } from '../QueryResults';
+
+interface FormData {
+    clusterID: string;
+    keyspace: string;
+    tabletType: string;
+    sql: string;
+}
+
+const DEFAULT_FORM_DATA: FormData = {
+    clusterID: '',
+    keyspace: '',
+    tabletType: 'replica',
+    sql: '',
+};
+
+export const QueryConsole = () => {
+    useDocumentTitle('Query Console');
+
+    const [formData, setFormData] = useState<FormData>(DEFAULT_FORM_DATA);
+
+    const { data: keyspaces = [], ...keyspacesQuery } = useKeyspaces();
+
+    const mutation = useExecuteQuery({
+        clusterID: formData.clusterID,
+        keyspace: formData.keyspace,
+        tabletType: formData.tabletType,
+        sql: formData.sql,
+    });
+
+    const selectedKeyspace =
+        keyspaces.find((ks) => ks.cluster?.id === formData.clusterID && ks.keyspace?.name === formData.keyspace) ||
+        null;
+
+    const isValid = !!selectedKeyspace && !!formData.sql.trim();
+    const isDisabled = !isValid || mutation.isLoading;
+
+    const onSubmit: React.FormEventHandler<HTMLFormElement> = (e) => {
+        e.preventDefault();
+        mutation.mutate();
+    };
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <WorkspaceTitle>Query Console</WorkspaceTitle>
+            </WorkspaceHeader>
+
+            <ContentContainer>
+                <form className="max-w-screen-sm" onSubmit={onSubmit}>
+                    <p className="text-secondary">
+                        Only read-only queries (SELECT, SHOW, DESCRIBE and EXPLAIN) are allowed. The number of rows
+                        returned and the duration of each query are limited, and every query is audit logged.
+                    </p>
+
+                    <div className="flex gap-8 my-8">
+                        <Select
+                            className="block w-full"
+                            disabled={keyspacesQuery.isLoading}
+                            inputClassName="block w-full"
+                            itemToString={(ks) => ks?.keyspace?.name || ''}
+                            items={orderBy(keyspaces, ['keyspace.name', 'cluster.id'])}
+                            label="Keyspace"
+                            onChange={(ks) =>
+                                setFormData({
+                                    ...formData,
+                                    clusterID: ks?.cluster?.id || '',
+                                    keyspace: ks?.keyspace?.name || '',
+                                })
+                            }
+                            placeholder={keyspacesQuery.isLoading ? 'Loading keyspaces...' : 'Select a keyspace'}
+                            renderItem={(ks) => `${ks?.keyspace?.name} (${ks?.cluster?.id})`}
+                            selectedItem={selectedKeyspace}
+                        />
+
+                        <Select
+                            className="block w-full"
+                            inputClassName="block w-full"
+                            items={QUERY_TABLET_TYPES}
+                            label="Tablet Type"
+                            onChange={(tabletType) => setFormData({ ...formData, tabletType: tabletType || 'replica' })}
+                            placeholder="Select a tablet type"
+                            selectedItem={formData.tabletType}
+                        />
+                    </div>
+
+                    {keyspacesQuery.isError && (
+                        <FormError
+                            error={keyspacesQuery.error}
+                            title="Couldn't load keyspaces. Please reload the page to try again."
+                        />
+                    )}
+
+                    <Label className="block my-8" label="SQL">
+                        <textarea
+                            className="block w-full font-mono border-2 border-gray-300 rounded-lg p-4"
+                            onChange={(e) => setFormData({ ...formData, sql: e.target.value })}
+                            placeholder="SELECT ..."
+                            rows={8}
+                            value={formData.sql}
+                        />
+                    </Label>
+
+                    {mutation.isError && !mutation.isLoading && (
+                        <FormError error={mutation.error} title="Couldn't run query." />
+                    )}
+
+                    <div className="my-8">
+                        <button className="btn" disabled={isDisabled} type="submit">
+                            {mutation.isLoading ? 'Running Query...' : 'Run Query'}
+                        </button>
+                    </div>
+                </form>
+
+                {mutation.isSuccess && (
+                    <div className="my-12">
+                        <QueryResults response={mutation.data} />
+                    </div>
+                )}
+            </ContentContainer>
+        </div>
+    );
+};
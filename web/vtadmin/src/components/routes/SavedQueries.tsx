@@ -0,0 +1,149 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import * as React from 'react';
+import { Link } from 'react-router-dom';
+
+import { useSavedQueries } from '../../hooks/api';
+import { useDocumentTitle } from '../../hooks/useDocumentTitle';
+import { useSyncedURLParam } from '../../hooks/useSyncedURLParam';
+import { filterNouns } from '../../util/filterNouns';
+import { formatInterval } from '../../util/savedQueries';
+import { formatDateTime } from '../../util/time';
+import { DataCell } from '../dataTable/DataCell';
+import { DataFilter } from '../dataTable/DataFilter';
+import { DataTable } from '../dataTable/DataTable';
+import { ContentContainer } from '../layout/ContentContainer';
+import { WorkspaceHeader } from '../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../layout/WorkspaceTitle';
+import { KeyspaceLink } from '../links/KeyspaceLink';
+import { QueryErrorPlaceholder } from '../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../placeholders/QueryLoadingPlaceholder';
+import { ReadOnlyGate } from '../ReadOnlyGate';
+
+export const SavedQueries = () => {
+    useDocumentTitle('Saved Queries');
+    const query = useSavedQueries();
+
+    const { value: filter, updateValue: updateFilter } = useSyncedURLParam('filter');
+
+    const rows = React.useMemo(() => {
+        const mapped = (query.data || []).map((q) => ({
+            id: q.id,
+            name: q.name,
+            description: q.description,
+            cluster: q.cluster_id,
+            keyspace: q.keyspace,
+            owner: q.owner,
+            interval: formatInterval(q.schedule),
+            webhook: !!q.schedule?.webhook_url,
+            emails: (q.schedule?.email_recipients || []).length,
+            lastRun: q.last_run,
+        }));
+
+        // Saved queries are already sorted by cluster and name.
+        return filterNouns(filter, mapped);
+    }, [query.data, filter]);
+
+    const renderRows = (rs: typeof rows) =>
+        rs.map((row) => {
+            const lastRunSeconds = Number(row.lastRun?.time?.seconds || 0);
+            const destinations = [
+                row.webhook && 'webhook',
+                row.emails && `${row.emails} email recipient${row.emails === 1 ? '' : 's'}`,
+            ].filter(Boolean);
+
+            return (
+                <tr key={row.id}>
+                    <DataCell>
+                        <Link className="font-bold" to={`/saved-queries/${row.cluster}/${row.id}`}>
+                            {row.name}
+                        </Link>
+                        {row.description && <div className="text-sm text-secondary">{row.description}</div>}
+                    </DataCell>
+                    <DataCell>
+                        <KeyspaceLink clusterID={row.cluster} name={row.keyspace}>
+                            {row.keyspace}
+                        </KeyspaceLink>
+                        <div className="text-sm text-secondary">{row.cluster}</div>
+                    </DataCell>
+                    <DataCell>
+                        {row.interval ? (
+                            <>
+                                <div>Every {row.interval}</div>
+                                <div className="text-sm text-secondary">{destinations.join(', ')}</div>
+                            </>
+                        ) : (
+                            <span className="text-secondary">On demand</span>
+                        )}
+                    </DataCell>
+                    <DataCell>
+                        {row.lastRun ? (
+                            <>
+                                <div className="font-sans whitespace-nowrap">{formatDateTime(lastRunSeconds)}</div>
+                                <div className={row.lastRun.error ? 'text-sm text-danger' : 'text-sm text-secondary'}>
+                                    {row.lastRun.error || `${row.lastRun.rows || 0} rows`}
+                                </div>
+                            </>
+                        ) : (
+                            <span className="text-secondary">-</span>
+                        )}
+                    </DataCell>
+                    <DataCell>{row.owner || <span className="text-secondary">-</span>}</DataCell>
+                </tr>
+            );
+        });
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <div className="flex items-top justify-between">
+                    <WorkspaceTitle>Saved Queries</WorkspaceTitle>
+                    <ReadOnlyGate>
+                        <div>
+                            <Link className="btn btn-secondary btn-md" to="/saved-queries/create">
+                                Save a Query
+                            </Link>
+                        </div>
+                    </ReadOnlyGate>
+                </div>
+            </WorkspaceHeader>
+
+            <ContentContainer>
+                <p className="text-secondary max-w-screen-md">
+                    Saved queries are read-only queries that can be run on demand, with parameters, or on a schedule.
+                    The report of each scheduled run is sent to a webhook or emailed.
+                </p>
+
+                <DataFilter
+                    autoFocus
+                    onChange={(e) => updateFilter(e.target.value)}
+                    onClear={() => updateFilter('')}
+                    placeholder="Filter saved queries"
+                    value={filter || ''}
+                />
+
+                <DataTable
+                    columns={['Name', 'Keyspace', 'Schedule', 'Last Run', 'Owner']}
+                    data={rows}
+                    renderRows={renderRows}
+                />
+
+                <QueryLoadingPlaceholder query={query} />
+                <QueryErrorPlaceholder query={query} title="Couldn't load saved queries" />
+            </ContentContainer>
+        </div>
+    );
+};
@@ -0,0 +1,157 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { orderBy } from 'lodash-es';
+import * as React from 'react';
+import { Link } from 'react-router-dom';
+
+import { useSchemaMigrations } from '../../hooks/api';
+import { useDocumentTitle } from '../../hooks/useDocumentTitle';
+import { useSyncedURLParam } from '../../hooks/useSyncedURLParam';
+import { vtctldata } from '../../proto/vtadmin';
+import { isReadOnlyMode } from '../../util/env';
+import { filterNouns } from '../../util/filterNouns';
+import { formatDateTime, formatRelativeTime } from '../../util/time';
+import { DataCell } from '../dataTable/DataCell';
+import { DataFilter } from '../dataTable/DataFilter';
+import { DataTable } from '../dataTable/DataTable';
+import { ContentContainer } from '../layout/ContentContainer';
+import { WorkspaceHeader } from '../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../layout/WorkspaceTitle';
+import { KeyspaceLink } from '../links/KeyspaceLink';
+import { SchemaMigrationStatusPip } from '../pips/SchemaMigrationStatusPip';
+import { QueryLoadingPlaceholder } from '../placeholders/QueryLoadingPlaceholder';
+import { ReadOnlyGate } from '../ReadOnlyGate';
+import SchemaMigrationActions from './schemaMigrations/SchemaMigrationActions';
+
+export const SchemaMigrations = () => {
+    useDocumentTitle('Schema Migrations');
+    const migrationsQuery = useSchemaMigrations();
+
+    const { value: filter, updateValue: updateFilter } = useSyncedURLParam('filter');
+
+    const rows = React.useMemo(() => {
+        const mapped = (migrationsQuery.data?.schema_migrations || []).map((m) => ({
+            clusterID: m.cluster?.id,
+            clusterName: m.cluster?.name,
+            keyspace: m.schema_migration?.keyspace,
+            shard: m.schema_migration?.shard,
+            uuid: m.schema_migration?.uuid,
+            table: m.schema_migration?.table,
+            statement: m.schema_migration?.migration_statement,
+            status: vtctldata.SchemaMigration.Status[m.schema_migration?.status || 0]?.toLowerCase(),
+            migration: m.schema_migration,
+            requestedAt: m.schema_migration?.requested_at?.seconds,
+        }));
+        const filtered = filterNouns(filter, mapped);
+        return orderBy(filtered, ['requestedAt', 'uuid', 'shard'], ['desc', 'asc', 'asc']);
+    }, [migrationsQuery.data, filter]);
+
+    const renderRows = (rs: typeof rows) =>
+        rs.map((row, idx) => {
+            const m = row.migration;
+            const progress = typeof m?.progress === 'number' ? Math.round(m.progress) : null;
+            const lastThrottledAt = m?.last_throttled_at?.seconds;
+
+            return (
+                <tr key={idx}>
+                    <DataCell>
+                        <div className="font-bold font-mono">{row.uuid}</div>
+                        <div className="text-sm text-secondary">
+                            {row.table} {m?.ddl_action && `(${m.ddl_action})`}
+                        </div>
+                        <div className="text-sm text-secondary font-mono truncate max-w-md">{row.statement}</div>
+                    </DataCell>
+                    <DataCell>
+                        <KeyspaceLink clusterID={row.clusterID} name={row.keyspace}>
+                            {row.keyspace}
+                        </KeyspaceLink>
+                        <div className="text-sm text-secondary">
+                            {row.shard} · {row.clusterName}
+                        </div>
+                    </DataCell>
+                    <DataCell>
+                        <div className="whitespace-nowrap">
+                            <SchemaMigrationStatusPip status={m?.status} /> {row.status}
+                        </div>
+                        {m?.ready_to_complete && <div className="text-sm text-success">ready to complete</div>}
+                        {m?.message && <div className="text-sm text-secondary">{m.message}</div>}
+                    </DataCell>
+                    <DataCell>
+                        {progress !== null && <div>{progress}%</div>}
+                        {!!m?.eta_seconds && <div className="text-sm text-secondary">ETA {m.eta_seconds}s</div>}
+                        {!!m?.rows_copied && (
+                            <div className="text-sm text-secondary">{m.rows_copied.toString()} rows copied</div>
+                        )}
+                    </DataCell>
+                    <DataCell>
+                        {m?.component_throttled ? (
+                            <>
+                                <div className="text-warning">{m.component_throttled}</div>
+                                <div className="text-sm text-secondary">{formatRelativeTime(lastThrottledAt)}</div>
+                            </>
+                        ) : (
+                            <span className="text-secondary">-</span>
+                        )}
+                    </DataCell>
+                    <DataCell>
+                        <div className="font-sans whitespace-nowrap">{formatDateTime(row.requestedAt)}</div>
+                        <div className="font-sans text-sm text-secondary">{formatRelativeTime(row.requestedAt)}</div>
+                    </DataCell>
+                    <ReadOnlyGate>
+                        <DataCell>
+                            {row.clusterID && m && <SchemaMigrationActions clusterID={row.clusterID} migration={m} />}
+                        </DataCell>
+                    </ReadOnlyGate>
+                </tr>
+            );
+        });
+
+    const columns = ['Migration', 'Keyspace', 'Status', 'Progress', 'Throttled', 'Requested'];
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <div className="flex items-top justify-between">
+                    <WorkspaceTitle>Schema Migrations</WorkspaceTitle>
+                    <ReadOnlyGate>
+                        <div>
+                            <Link className="btn btn-secondary btn-md" to="/migrations/create">
+                                Create a Schema Migration
+                            </Link>
+                        </div>
+                    </ReadOnlyGate>
+                </div>
+            </WorkspaceHeader>
+            <ContentContainer>
+                <DataFilter
+                    autoFocus
+                    onChange={(e) => updateFilter(e.target.value)}
+                    onClear={() => updateFilter('')}
+                    placeholder="Filter schema migrations"
+                    value={filter || ''}
+                />
+
+                <DataTable
+                    columns={isReadOnlyMode() ? columns : [...columns, 'Actions']}
+                    data={rows}
+                    renderRows={renderRows}
+                />
+
+                <QueryLoadingPlaceholder query={migrationsQuery} />
+            </ContentContainer>
+        </div>
+    );
+};
@@ -0,0 +1,271 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { orderBy } from 'lodash-es';
+import React, { useState } from 'react';
+import { useQueryClient } from 'react-query';
+import { Link, useHistory } from 'react-router-dom';
+
+import { useCreateSavedQuery, useKeyspaces } from '../../../hooks/api';
+import { useDocumentTitle } from '../../../hooks/useDocumentTitle';
+import { findParameters } from '../../../util/savedQueries';
+import { QUERY_TABLET_TYPES } from '../../../util/tablets';
+import { FormError } from '../../forms/FormError';
+import { Label } from '../../inputs/Label';
+import { Select } from '../../inputs/Select';
+import { ContentContainer } from '../../layout/ContentContainer';
+import { NavCrumbs } from '../../layout/NavCrumbs';
+import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
+import { success } from '../../Snackbar';
+import { TextInput } from '../../TextInput';
+import Toggle from '../../toggle/Toggle';
+
+interface FormData {
+    clusterID: string;
+    keyspace: string;
+    tabletType: string;
+    name: string;
+    description: string;
+    sql: string;
+    // Default values of the parameters of the query, by name.
+    defaults: { [name: string]: string };
+    scheduled: boolean;
+    interval: string;
+    webhookURL: string;
+    // Comma-separated email addresses.
+    emailRecipients: string;
+}
+
+const DEFAULT_FORM_DATA: FormData = {
+    clusterID: '',
+    keyspace: '',
+    tabletType: 'replica',
+    name: '',
+    description: '',
+    sql: '',
+    defaults: {},
+    scheduled: false,
+    interval: '24h',
+    webhookURL: '',
+    emailRecipients: '',
+};
+
+export const CreateSavedQuery = () => {
+    useDocumentTitle('Save a Query');
+
+    const queryClient = useQueryClient();
+    const history = useHistory();
+
+    const [formData, setFormData] = useState<FormData>(DEFAULT_FORM_DATA);
+
+    const { data: keyspaces = [], ...keyspacesQuery } = useKeyspaces();
+
+    const parameters = findParameters(formData.sql);
+
+    const emailRecipients = formData.emailRecipients
+        .split(',')
+        .map((addr) => addr.trim())
+        .filter(Boolean);
+
+    const mutation = useCreateSavedQuery(
+        {
+            clusterID: formData.clusterID,
+            name: formData.name,
+            description: formData.description || undefined,
+            keyspace: formData.keyspace,
+            tabletType: formData.tabletType,
+            sql: formData.sql,
+            parameters: parameters.map((name) => ({ name, default_value: formData.defaults[name] || '' })),
+            schedule: formData.scheduled
+                ? {
+                      interval: formData.interval,
+                      webhookURL: formData.webhookURL || undefined,
+                      emailRecipients,
+                  }
+                : undefined,
+        },
+        {
+            onSuccess: (res) => {
+                queryClient.invalidateQueries('saved-queries');
+                success(`Saved query ${res.name}`, { autoClose: 1600 });
+                history.push(`/saved-queries/${res.cluster_id}/${res.id}`);
+            },
+        }
+    );
+
+    const selectedKeyspace =
+        keyspaces.find((ks) => ks.cluster?.id === formData.clusterID && ks.keyspace?.name === formData.keyspace) ||
+        null;
+
+    const hasDelivery = !!formData.webhookURL.trim() || emailRecipients.length > 0;
+    const isScheduleValid = !formData.scheduled || (!!formData.interval.trim() && hasDelivery);
+    const isValid = !!selectedKeyspace && !!formData.name.trim() && !!formData.sql.trim() && isScheduleValid;
+    const isDisabled = !isValid || mutation.isLoading;
+
+    const onSubmit: React.FormEventHandler<HTMLFormElement> = (e) => {
+        e.preventDefault();
+        mutation.mutate();
+    };
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <NavCrumbs>
+                    <Link to="/saved-queries">Saved Queries</Link>
+                </NavCrumbs>
+
+                <WorkspaceTitle>Save a Query</WorkspaceTitle>
+            </WorkspaceHeader>
+
+            <ContentContainer className="max-w-screen-sm">
+                <form onSubmit={onSubmit}>
+                    <Label className="block my-8" label="Name">
+                        <TextInput
+                            onChange={(e) => setFormData({ ...formData, name: e.target.value })}
+                            value={formData.name}
+                        />
+                    </Label>
+
+                    <Label className="block my-8" label="Description">
+                        <TextInput
+                            onChange={(e) => setFormData({ ...formData, description: e.target.value })}
+                            value={formData.description}
+                        />
+                    </Label>
+
+                    <div className="flex gap-8 my-8">
+                        <Select
+                            className="block w-full"
+                            disabled={keyspacesQuery.isLoading}
+                            inputClassName="block w-full"
+                            itemToString={(ks) => ks?.keyspace?.name || ''}
+                            items={orderBy(keyspaces, ['keyspace.name', 'cluster.id'])}
+                            label="Keyspace"
+                            onChange={(ks) =>
+                                setFormData({
+                                    ...formData,
+                                    clusterID: ks?.cluster?.id || '',
+                                    keyspace: ks?.keyspace?.name || '',
+                                })
+                            }
+                            placeholder={keyspacesQuery.isLoading ? 'Loading keyspaces...' : 'Select a keyspace'}
+                            renderItem={(ks) => `${ks?.keyspace?.name} (${ks?.cluster?.id})`}
+                            selectedItem={selectedKeyspace}
+                        />
+
+                        <Select
+                            className="block w-full"
+                            inputClassName="block w-full"
+                            items={QUERY_TABLET_TYPES}
+                            label="Tablet Type"
+                            onChange={(tabletType) => setFormData({ ...formData, tabletType: tabletType || 'replica' })}
+                            placeholder="Select a tablet type"
+                            selectedItem={formData.tabletType}
+                        />
+                    </div>
+
+                    {keyspacesQuery.isError && (
+                        <FormError
+                            error={keyspacesQuery.error}
+                            title="Couldn't load keyspaces. Please reload the page to try again."
+                        />
+                    )}
+
+                    <Label className="block my-8" label="SQL">
+                        <textarea
+                            className="block w-full font-mono border-2 border-gray-300 rounded-lg p-4"
+                            onChange={(e) => setFormData({ ...formData, sql: e.target.value })}
+                            placeholder="SELECT * FROM users WHERE status = :status"
+                            rows={8}
+                            value={formData.sql}
+                        />
+                    </Label>
+
+                    <p className="text-secondary">
+                        Only read-only queries are allowed. Bind variables such as <code>:status</code> are parameters
+                        of the query, given a value each time it runs. Scheduled runs use their default values.
+                    </p>
+
+                    {parameters.map((name) => (
+                        <Label className="block my-8" key={name} label={`Default value of :${name}`}>
+                            <TextInput
+                                onChange={(e) =>
+                                    setFormData({
+                                        ...formData,
+                                        defaults: { ...formData.defaults, [name]: e.target.value },
+                                    })
+                                }
+                                value={formData.defaults[name] || ''}
+                            />
+                        </Label>
+                    ))}
+
+                    <div className="flex items-center my-8">
+                        <Toggle
+                            className="mr-2"
+                            enabled={formData.scheduled}
+                            onChange={() => setFormData({ ...formData, scheduled: !formData.scheduled })}
+                        />
+                        <Label label="Run on a schedule" />
+                    </div>
+
+                    {formData.scheduled && (
+                        <>
+                            <Label className="block my-8" label="Interval">
+                                <TextInput
+                                    onChange={(e) => setFormData({ ...formData, interval: e.target.value })}
+                                    placeholder="24h"
+                                    value={formData.interval}
+                                />
+                            </Label>
+
+                            <Label className="block my-8" label="Webhook URL">
+                                <TextInput
+                                    onChange={(e) => setFormData({ ...formData, webhookURL: e.target.value })}
+                                    placeholder="https://"
+                                    value={formData.webhookURL}
+                                />
+                            </Label>
+
+                            <Label className="block my-8" label="Email Recipients">
+                                <TextInput
+                                    onChange={(e) => setFormData({ ...formData, emailRecipients: e.target.value })}
+                                    placeholder="ops@example.com, dba@example.com"
+                                    value={formData.emailRecipients}
+                                />
+                            </Label>
+
+                            <p className="text-secondary">
+                                The report of each run is sent as JSON to the webhook, and as a table to the email
+                                recipients. At least one of them is required.
+                            </p>
+                        </>
+                    )}
+
+                    {mutation.isError && !mutation.isLoading && (
+                        <FormError error={mutation.error} title="Couldn't save query. Please try again." />
+                    )}
+
+                    <div className="my-12">
+                        <button className="btn" disabled={isDisabled} type="submit">
+                            {mutation.isLoading ? 'Saving Query...' : 'Save Query'}
+                        </button>
+                    </div>
+                </form>
+            </ContentContainer>
+        </div>
+    );
+};
@@ -0,0 +1,164 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { orderBy } from 'lodash-es';
+import React, { useState } from 'react';
+import { useQueryClient } from 'react-query';
+import { Link, useHistory } from 'react-router-dom';
+
+import { useApplySchema, useKeyspaces } from '../../../hooks/api';
+import { useDocumentTitle } from '../../../hooks/useDocumentTitle';
+import { Label } from '../../inputs/Label';
+import { Select } from '../../inputs/Select';
+import { ContentContainer } from '../../layout/ContentContainer';
+import { NavCrumbs } from '../../layout/NavCrumbs';
+import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
+import { TextInput } from '../../TextInput';
+import { success } from '../../Snackbar';
+import { FormError } from '../../forms/FormError';
+
+interface FormData {
+    clusterID: string;
+    keyspace: string;
+    sql: string;
+    ddlStrategy: string;
+    migrationContext: string;
+}
+
+const DEFAULT_FORM_DATA: FormData = {
+    clusterID: '',
+    keyspace: '',
+    sql: '',
+    ddlStrategy: 'vitess',
+    migrationContext: '',
+};
+
+export const CreateSchemaMigration = () => {
+    useDocumentTitle('Create a Schema Migration');
+
+    const queryClient = useQueryClient();
+    const history = useHistory();
+
+    const [formData, setFormData] = useState<FormData>(DEFAULT_FORM_DATA);
+
+    const { data: keyspaces = [], ...keyspacesQuery } = useKeyspaces();
+
+    const mutation = useApplySchema(
+        {
+            clusterID: formData.clusterID,
+            keyspace: formData.keyspace,
+            sql: formData.sql,
+            ddlStrategy: formData.ddlStrategy,
+            migrationContext: formData.migrationContext || undefined,
+        },
+        {
+            onSuccess: (res) => {
+                queryClient.invalidateQueries('schema-migrations');
+                success(`Submitted schema migration ${(res?.uuid_list || []).join(', ')}`, { autoClose: 1600 });
+                history.push('/migrations');
+            },
+        }
+    );
+
+    const selectedKeyspace =
+        keyspaces.find((ks) => ks.cluster?.id === formData.clusterID && ks.keyspace?.name === formData.keyspace) ||
+        null;
+
+    const isValid = !!selectedKeyspace && !!formData.sql.trim();
+    const isDisabled = !isValid || mutation.isLoading;
+
+    const onSubmit: React.FormEventHandler<HTMLFormElement> = (e) => {
+        e.preventDefault();
+        mutation.mutate();
+    };
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <NavCrumbs>
+                    <Link to="/migrations">Schema Migrations</Link>
+                </NavCrumbs>
+
+                <WorkspaceTitle>Create a Schema Migration</WorkspaceTitle>
+            </WorkspaceHeader>
+
+            <ContentContainer className="max-w-screen-sm">
+                <form onSubmit={onSubmit}>
+                    <Select
+                        className="block w-full"
+                        disabled={keyspacesQuery.isLoading}
+                        inputClassName="block w-full"
+                        itemToString={(ks) => ks?.keyspace?.name || ''}
+                        items={orderBy(keyspaces, ['keyspace.name', 'cluster.id'])}
+                        label="Keyspace"
+                        onChange={(ks) =>
+                            setFormData({
+                                ...formData,
+                                clusterID: ks?.cluster?.id || '',
+                                keyspace: ks?.keyspace?.name || '',
+                            })
+                        }
+                        placeholder={keyspacesQuery.isLoading ? 'Loading keyspaces...' : 'Select a keyspace'}
+                        renderItem={(ks) => `${ks?.keyspace?.name} (${ks?.cluster?.id})`}
+                        selectedItem={selectedKeyspace}
+                    />
+
+                    {keyspacesQuery.isError && (
+                        <FormError
+                            error={keyspacesQuery.error}
+                            title="Couldn't load keyspaces. Please reload the page to try again."
+                        />
+                    )}
+
+                    <Label className="block my-8" label="SQL">
+                        <textarea
+                            className="block w-full font-mono border-2 border-gray-300 rounded-lg p-4"
+                            onChange={(e) => setFormData({ ...formData, sql: e.target.value })}
+                            placeholder="ALTER TABLE ..."
+                            rows={8}
+                            value={formData.sql}
+                        />
+                    </Label>
+
+                    <Label className="block my-8" label="DDL Strategy">
+                        <TextInput
+                            onChange={(e) => setFormData({ ...formData, ddlStrategy: e.target.value })}
+                            placeholder="vitess --postpone-completion"
+                            value={formData.ddlStrategy}
+                        />
+                    </Label>
+
+                    <Label className="block my-8" label="Migration Context">
+                        <TextInput
+                            onChange={(e) => setFormData({ ...formData, migrationContext: e.target.value })}
+                            value={formData.migrationContext}
+                        />
+                    </Label>
+
+                    {mutation.isError && !mutation.isLoading && (
+                        <FormError error={mutation.error} title="Couldn't submit schema migration. Please try again." />
+                    )}
+
+                    <div className="my-12">
+                        <button className="btn" disabled={isDisabled} type="submit">
+                            {mutation.isLoading ? 'Submitting Migration...' : 'Submit Migration'}
+                        </button>
+                    </div>
+                </form>
+            </ContentContainer>
+        </div>
+    );
+};
@@ -30,6 +30,7 @@ import { Tab } from '../../tabs/Tab';
 import { TabContainer } from '../../tabs/TabContainer';
 import { Advanced } from './Advanced';
 import style from './Keyspace.module.scss';
+import { KeyspaceSchemaDrift } from './KeyspaceSchemaDrift';
 import { KeyspaceShards } from './KeyspaceShards';
 import { KeyspaceVSchema } from './KeyspaceVSchema';
 
@@ -93,6 +94,7 @@ export const Keyspace = () => {
                 <TabContainer>
                     <Tab text="Shards" to={`${url}/shards`} />
                     <Tab text="VSchema" to={`${url}/vschema`} />
+                    <Tab text="Schema Drift" to={`${url}/schema_drift`} />
                     <Tab text="JSON" to={`${url}/json`} />
 
                     <ReadOnlyGate>
@@ -109,6 +111,10 @@ export const Keyspace = () => {
                         <KeyspaceVSchema clusterID={clusterID} name={name} />
                     </Route>
 
+                    <Route path={`${path}/schema_drift`}>
+                        <KeyspaceSchemaDrift clusterID={clusterID} name={name} />
+                    </Route>
+
                     <Route path={`${path}/json`}>
                         <QueryLoadingPlaceholder query={kq} />
                         <Code code={JSON.stringify(keyspace, null, 2)} />
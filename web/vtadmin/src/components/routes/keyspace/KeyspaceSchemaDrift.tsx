@@ -0,0 +1,105 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import { useSchemaDrift } from '../../../hooks/api';
+import { vtadmin as pb } from '../../../proto/vtadmin';
+import { Code } from '../../Code';
+import { DataCell } from '../../dataTable/DataCell';
+import { DataTable } from '../../dataTable/DataTable';
+import { Pip } from '../../pips/Pip';
+import { QueryErrorPlaceholder } from '../../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../../placeholders/QueryLoadingPlaceholder';
+
+interface Props {
+    clusterID: string;
+    name: string;
+}
+
+const TABLE_COLUMNS = ['Table', 'Drift', 'Columns'];
+
+const DIFF_TYPE_LABELS: { [t in pb.TableSchemaDiff.Type]: string } = {
+    [pb.TableSchemaDiff.Type.UNKNOWN]: 'Unknown',
+    [pb.TableSchemaDiff.Type.MISSING_IN_TARGET]: 'Missing',
+    [pb.TableSchemaDiff.Type.EXTRA_IN_TARGET]: 'Extra',
+    [pb.TableSchemaDiff.Type.CHANGED]: 'Changed',
+};
+
+export const KeyspaceSchemaDrift = ({ clusterID, name }: Props) => {
+    const query = useSchemaDrift({ clusterID, keyspace: name });
+    const shards = query.data?.shards || [];
+
+    const renderRows = (rows: pb.ITableSchemaDiff[]) =>
+        rows.map((row) => (
+            <tr key={row.name}>
+                <DataCell>
+                    <div className="font-bold">{row.name}</div>
+                    {row.is_view && <div className="text-sm text-secondary">View</div>}
+                </DataCell>
+                <DataCell>{DIFF_TYPE_LABELS[row.type || pb.TableSchemaDiff.Type.UNKNOWN]}</DataCell>
+                <DataCell className="text-sm">
+                    {!!row.missing_columns?.length && <div>Missing: {row.missing_columns.join(', ')}</div>}
+                    {!!row.extra_columns?.length && <div>Extra: {row.extra_columns.join(', ')}</div>}
+                    {!!row.changed_columns?.length && <div>Changed: {row.changed_columns.join(', ')}</div>}
+                </DataCell>
+            </tr>
+        ));
+
+    return (
+        <div className="pt-4">
+            <QueryLoadingPlaceholder query={query} />
+            <QueryErrorPlaceholder query={query} title="Couldn't load schema drift" />
+
+            {query.isSuccess && (
+                <>
+                    <p className="text-secondary">
+                        Schemas are compared to the schema of reference shard{' '}
+                        <code>
+                            {name}/{query.data.reference_shard}
+                        </code>
+                        .
+                    </p>
+
+                    {!shards.length && <p>There are no other serving shards in this keyspace.</p>}
+
+                    {shards.map((shard) => (
+                        <div className="my-8" key={shard.shard}>
+                            <h3 className="flex items-center">
+                                <Pip state={shard.error ? 'danger' : shard.diffs?.length ? 'warning' : 'success'} />
+                                <span className="ml-2 font-mono">
+                                    {name}/{shard.shard}
+                                </span>
+                            </h3>
+
+                            {shard.error && <p className="text-danger">{shard.error}</p>}
+
+                            {!shard.error && !shard.diffs?.length && (
+                                <p className="text-secondary">The schema matches the reference shard.</p>
+                            )}
+
+                            {!!shard.diffs?.length && (
+                                <>
+                                    <DataTable columns={TABLE_COLUMNS} data={shard.diffs} renderRows={renderRows} />
+                                    <div className="text-sm mt-3 mb-4">DDL to bring this shard in line:</div>
+                                    <Code code={(shard.ddls || []).map((ddl) => `${ddl};`).join('\n')} />
+                                </>
+                            )}
+                        </div>
+                    ))}
+                </>
+            )}
+        </div>
+    );
+};
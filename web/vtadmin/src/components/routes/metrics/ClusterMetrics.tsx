@@ -0,0 +1,136 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import Highcharts from 'highcharts';
+import { useMemo } from 'react';
+
+import { vtadmin as pb, vttime } from '../../../proto/vtadmin';
+import { mergeOptions } from '../../charts/chartOptions';
+import { Timeseries } from '../../charts/Timeseries';
+import { DataCell } from '../../dataTable/DataCell';
+import { DataTable } from '../../dataTable/DataTable';
+
+interface Props {
+    metrics: pb.ClusterMetrics;
+}
+
+type SampleField = Exclude<keyof pb.IMetricsSample, 'time'>;
+
+interface SeriesDefinition {
+    field: SampleField;
+    name: string;
+    // Optional; scales every value of the series, e.g. to turn ratios into percentages.
+    scale?: number;
+}
+
+const CHARTS: { title: string; series: SeriesDefinition[] }[] = [
+    {
+        title: 'Queries per second',
+        series: [
+            { field: 'vtgate_qps', name: 'VTGates' },
+            { field: 'tablet_qps', name: 'Tablets' },
+        ],
+    },
+    {
+        title: 'Errors per second',
+        series: [
+            { field: 'vtgate_error_rate', name: 'VTGates' },
+            { field: 'tablet_error_rate', name: 'Tablets' },
+        ],
+    },
+    {
+        title: 'Max replication lag (seconds)',
+        series: [{ field: 'max_replication_lag_seconds', name: 'Replication lag' }],
+    },
+    {
+        title: 'Max connection pool utilization (%)',
+        series: [{ field: 'max_pool_utilization', name: 'Pool utilization', scale: 100 }],
+    },
+];
+
+const toMillis = (t: vttime.ITime | null | undefined): number =>
+    parseInt(`${t?.seconds || 0}`, 10) * 1000 + Math.floor((t?.nanoseconds || 0) / 1e6);
+
+const formatNumber = (n: number | null | undefined): string => (n || 0).toFixed(2);
+
+export const ClusterMetrics = ({ metrics }: Props) => {
+    const samples = useMemo(() => metrics.samples || [], [metrics.samples]);
+
+    const charts = useMemo(
+        () =>
+            CHARTS.map(({ title, series }) => {
+                const options = mergeOptions({
+                    series: series.map(
+                        ({ field, name, scale = 1 }): Highcharts.SeriesOptionsType => ({
+                            data: samples.map((s) => [toMillis(s.time), ((s[field] as number) || 0) * scale]),
+                            name,
+                            type: 'line',
+                        })
+                    ),
+                });
+
+                return { title, options };
+            }),
+        [samples]
+    );
+
+    const renderRows = (rows: pb.IComponentMetrics[]) =>
+        rows.map((c) => {
+            const isTablet = c.type === pb.ComponentMetrics.Type.TABLET;
+
+            return (
+                <tr key={`${c.type}-${c.name}`}>
+                    <DataCell>{isTablet ? 'Tablet' : 'VTGate'}</DataCell>
+                    <DataCell className="font-mono">{c.name}</DataCell>
+                    <DataCell>{formatNumber(c.qps)}</DataCell>
+                    <DataCell>{formatNumber(c.error_rate)}</DataCell>
+                    <DataCell>{isTablet ? `${formatNumber(c.replication_lag_seconds)}s` : '-'}</DataCell>
+                    <DataCell>{isTablet ? `${formatNumber((c.pool_utilization || 0) * 100)}%` : '-'}</DataCell>
+                    <DataCell>{c.error ? <span className="text-danger">{c.error}</span> : 'OK'}</DataCell>
+                </tr>
+            );
+        });
+
+    return (
+        <div className="my-12">
+            <h2>{metrics.cluster?.name}</h2>
+
+            {!metrics.enabled ? (
+                <p className="text-secondary">
+                    VTAdmin does not scrape metrics for this cluster. Set a <code>metrics-scrape-interval</code> in
+                    its configuration to enable them.
+                </p>
+            ) : (
+                <>
+                    {charts.map(({ title, options }) => (
+                        <div className="mt-12 mb-16" key={title}>
+                            <h3>{title}</h3>
+                            <div className="mt-8">
+                                <Timeseries options={options} />
+                            </div>
+                        </div>
+                    ))}
+
+                    <DataTable
+                        columns={['Type', 'Name', 'QPS', 'Errors/s', 'Replication Lag', 'Pool Utilization', 'Scrape']}
+                        data={metrics.components || []}
+                        renderRows={renderRows}
+                        title="Components"
+                    />
+                </>
+            )}
+        </div>
+    );
+};
@@ -0,0 +1,62 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { orderBy } from 'lodash-es';
+import { useMemo } from 'react';
+
+import { useClusterMetrics } from '../../../hooks/api';
+import { useDocumentTitle } from '../../../hooks/useDocumentTitle';
+import { ContentContainer } from '../../layout/ContentContainer';
+import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
+import { QueryErrorPlaceholder } from '../../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../../placeholders/QueryLoadingPlaceholder';
+import { ClusterMetrics } from './ClusterMetrics';
+
+// VTAdmin scrapes metrics in the background, so polling more often than the
+// shortest scrape interval only returns the same samples.
+export const METRICS_REFETCH_INTERVAL = 15000;
+
+export const Metrics = () => {
+    useDocumentTitle('Metrics');
+
+    const query = useClusterMetrics({
+        refetchInterval: METRICS_REFETCH_INTERVAL,
+    });
+
+    const clusters = useMemo(() => orderBy(query.data, ['cluster.name']), [query.data]);
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <WorkspaceTitle>Metrics</WorkspaceTitle>
+            </WorkspaceHeader>
+
+            <ContentContainer>
+                <p className="text-secondary max-w-screen-md">
+                    Query and error rates, replication lag and connection pool utilization, scraped by VTAdmin from
+                    the VTGates and serving tablets of each cluster.
+                </p>
+
+                {clusters.map((cm) => (
+                    <ClusterMetrics key={cm.cluster?.id} metrics={cm} />
+                ))}
+
+                <QueryLoadingPlaceholder query={query} />
+                <QueryErrorPlaceholder query={query} title="Couldn't load metrics" />
+            </ContentContainer>
+        </div>
+    );
+};
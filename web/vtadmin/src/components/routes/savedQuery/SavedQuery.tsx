@@ -0,0 +1,236 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import React, { useState } from 'react';
+import { useQueryClient } from 'react-query';
+import { Link, useHistory, useParams } from 'react-router-dom';
+
+import { useDeleteSavedQuery, useRunSavedQuery, useSavedQueries } from '../../../hooks/api';
+import { useDocumentTitle } from '../../../hooks/useDocumentTitle';
+import { formatInterval } from '../../../util/savedQueries';
+import { TABLET_TYPES } from '../../../util/tablets';
+import { formatDateTime } from '../../../util/time';
+import { Code } from '../../Code';
+import { FormError } from '../../forms/FormError';
+import { Label } from '../../inputs/Label';
+import { ContentContainer } from '../../layout/ContentContainer';
+import { NavCrumbs } from '../../layout/NavCrumbs';
+import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
+import { KeyspaceLink } from '../../links/KeyspaceLink';
+import { QueryErrorPlaceholder } from '../../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../../placeholders/QueryLoadingPlaceholder';
+import { QueryResults } from '../../QueryResults';
+import { ReadOnlyGate } from '../../ReadOnlyGate';
+import { success } from '../../Snackbar';
+import { TextInput } from '../../TextInput';
+import KeyspaceAction from '../keyspaces/KeyspaceAction';
+
+interface RouteParams {
+    clusterID: string;
+    id: string;
+}
+
+export const SavedQuery = () => {
+    const { clusterID, id } = useParams<RouteParams>();
+
+    const queryClient = useQueryClient();
+    const history = useHistory();
+
+    const query = useSavedQueries();
+    const savedQuery = (query.data || []).find((q) => q.cluster_id === clusterID && q.id === id);
+
+    useDocumentTitle(savedQuery?.name || 'Saved Query');
+
+    // The values of the parameters to run the query with, by name. Parameters
+    // left blank are bound to their default value.
+    const [values, setValues] = useState<{ [name: string]: string }>({});
+    const [isDeleteOpen, setIsDeleteOpen] = useState(false);
+
+    const runMutation = useRunSavedQuery({ clusterID, id, params: values });
+
+    const deleteMutation = useDeleteSavedQuery(
+        { clusterID, id },
+        {
+            onSuccess: () => {
+                queryClient.invalidateQueries('saved-queries');
+                success(`Deleted saved query ${savedQuery?.name}`, { autoClose: 1600 });
+                history.push('/saved-queries');
+            },
+        }
+    );
+
+    const onSubmit: React.FormEventHandler<HTMLFormElement> = (e) => {
+        e.preventDefault();
+        runMutation.mutate();
+    };
+
+    if (query.isSuccess && !savedQuery) {
+        return (
+            <ContentContainer>
+                <h1>Saved query not found</h1>
+                <p>
+                    No saved query found with id <code>{id}</code> in cluster <code>{clusterID}</code>.
+                </p>
+                <p>
+                    <Link to="/saved-queries">← All saved queries</Link>
+                </p>
+            </ContentContainer>
+        );
+    }
+
+    const schedule = savedQuery?.schedule;
+    const interval = formatInterval(schedule);
+    const lastRun = savedQuery?.last_run;
+    // Saved queries without a tablet type run against replicas.
+    const tabletType = savedQuery?.tablet_type ? TABLET_TYPES[savedQuery.tablet_type].toLowerCase() : 'replica';
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <NavCrumbs>
+                    <Link to="/saved-queries">Saved Queries</Link>
+                </NavCrumbs>
+
+                <div className="flex items-top justify-between">
+                    <WorkspaceTitle>{savedQuery?.name || id}</WorkspaceTitle>
+                    {savedQuery && (
+                        <ReadOnlyGate>
+                            <div>
+                                <button
+                                    className="btn btn-secondary btn-danger btn-md"
+                                    onClick={() => setIsDeleteOpen(true)}
+                                    type="button"
+                                >
+                                    Delete
+                                </button>
+                            </div>
+                        </ReadOnlyGate>
+                    )}
+                </div>
+
+                {savedQuery && (
+                    <div className="flex gap-8 text-secondary">
+                        <span>
+                            Cluster: <code>{clusterID}</code>
+                        </span>
+                        <span>
+                            Keyspace:{' '}
+                            <KeyspaceLink clusterID={clusterID} name={savedQuery.keyspace}>
+                                <code>
+                                    {savedQuery.keyspace}@{tabletType}
+                                </code>
+                            </KeyspaceLink>
+                        </span>
+                        {savedQuery.owner && (
+                            <span>
+                                Owner: <code>{savedQuery.owner}</code>
+                            </span>
+                        )}
+                    </div>
+                )}
+            </WorkspaceHeader>
+
+            <ContentContainer>
+                <QueryLoadingPlaceholder query={query} />
+                <QueryErrorPlaceholder query={query} title="Couldn't load saved queries" />
+
+                {savedQuery && (
+                    <>
+                        {savedQuery.description && <p className="max-w-screen-md">{savedQuery.description}</p>}
+
+                        <Code code={savedQuery.sql} />
+
+                        <h3 className="mt-12 mb-4">Schedule</h3>
+                        {interval ? (
+                            <div className="text-secondary">
+                                <p>
+                                    Runs every <code>{interval}</code> with the default values of its parameters.
+                                </p>
+                                {schedule?.webhook_url && (
+                                    <p>
+                                        Reports are sent to <code>{schedule.webhook_url}</code>.
+                                    </p>
+                                )}
+                                {!!schedule?.email_recipients?.length && (
+                                    <p>
+                                        Reports are emailed to <code>{schedule.email_recipients.join(', ')}</code>.
+                                    </p>
+                                )}
+                                {lastRun ? (
+                                    <p className={lastRun.error ? 'text-danger' : undefined}>
+                                        Last ran {formatDateTime(Number(lastRun.time?.seconds || 0))}:{' '}
+                                        {lastRun.error || `${lastRun.rows || 0} rows`}
+                                    </p>
+                                ) : (
+                                    <p>Not yet run.</p>
+                                )}
+                            </div>
+                        ) : (
+                            <p className="text-secondary">Runs on demand only.</p>
+                        )}
+
+                        <h3 className="mt-12 mb-4">Run</h3>
+                        <form className="max-w-screen-sm" onSubmit={onSubmit}>
+                            {(savedQuery.parameters || []).map((p) => (
+                                <Label className="block my-8" key={p.name} label={`:${p.name}`}>
+                                    {p.description && <p className="text-secondary">{p.description}</p>}
+                                    <TextInput
+                                        onChange={(e) => setValues({ ...values, [p.name as string]: e.target.value })}
+                                        placeholder={p.default_value || undefined}
+                                        value={values[p.name as string] || ''}
+                                    />
+                                </Label>
+                            ))}
+
+                            {runMutation.isError && !runMutation.isLoading && (
+                                <FormError error={runMutation.error} title="Couldn't run query." />
+                            )}
+
+                            <div className="my-8">
+                                <button className="btn" disabled={runMutation.isLoading} type="submit">
+                                    {runMutation.isLoading ? 'Running Query...' : 'Run Query'}
+                                </button>
+                            </div>
+                        </form>
+
+                        {runMutation.isSuccess && (
+                            <div className="my-12">
+                                <QueryResults response={runMutation.data} />
+                            </div>
+                        )}
+
+                        <KeyspaceAction
+                            title="Delete Saved Query"
+                            confirmText="Delete"
+                            loadingText="Deleting"
+                            mutation={deleteMutation}
+                            successText="Deleted saved query"
+                            errorText={`Error deleting saved query ${savedQuery.name}`}
+                            closeDialog={() => setIsDeleteOpen(false)}
+                            isOpen={isDeleteOpen}
+                            body={
+                                <div className="text-sm mt-3">
+                                    Delete <span className="font-mono bg-gray-300">{savedQuery.name}</span>, and stop
+                                    its scheduled runs.
+                                </div>
+                            }
+                        />
+                    </>
+                )}
+            </ContentContainer>
+        </div>
+    );
+};
@@ -0,0 +1,156 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import React, { useState } from 'react';
+import { useQueryClient } from 'react-query';
+
+import Dropdown from '../../dropdown/Dropdown';
+import MenuItem from '../../dropdown/MenuItem';
+import { Icons } from '../../Icon';
+import { Intent } from '../../intent';
+import {
+    useCancelSchemaMigration,
+    useCleanupSchemaMigration,
+    useCompleteSchemaMigration,
+    useLaunchSchemaMigration,
+    useRetrySchemaMigration,
+} from '../../../hooks/api';
+import { vtctldata } from '../../../proto/vtadmin';
+import KeyspaceAction from '../keyspaces/KeyspaceAction';
+
+interface SchemaMigrationActionsProps {
+    clusterID: string;
+    migration: vtctldata.ISchemaMigration;
+}
+
+const { Status } = vtctldata.SchemaMigration;
+
+const PENDING_STATUSES = [Status.REQUESTED, Status.QUEUED, Status.READY, Status.RUNNING];
+const TERMINAL_STATUSES = [Status.COMPLETE, Status.FAILED, Status.CANCELLED];
+
+const SchemaMigrationActions: React.FC<SchemaMigrationActionsProps> = ({ clusterID, migration }) => {
+    const queryClient = useQueryClient();
+
+    const [currentDialog, setCurrentDialog] = useState<string>('');
+    const closeDialog = () => setCurrentDialog('');
+
+    const params = { clusterID, keyspace: migration.keyspace as string, uuid: migration.uuid as string };
+    const options = { onSuccess: () => queryClient.invalidateQueries('schema-migrations') };
+
+    const cancelMutation = useCancelSchemaMigration(params, options);
+    const cleanupMutation = useCleanupSchemaMigration(params, options);
+    const completeMutation = useCompleteSchemaMigration(params, options);
+    const launchMutation = useLaunchSchemaMigration(params, options);
+    const retryMutation = useRetrySchemaMigration(params, options);
+
+    const status = migration.status as vtctldata.SchemaMigration.Status;
+    const isPending = PENDING_STATUSES.includes(status);
+    const isTerminal = TERMINAL_STATUSES.includes(status);
+
+    const canCancel = isPending;
+    const canComplete = isPending && !!migration.postpone_completion;
+    const canLaunch = isPending && !!migration.postpone_launch;
+    const canRetry = status === Status.FAILED || status === Status.CANCELLED;
+    const canCleanup = isTerminal && !migration.cleaned_up_at;
+
+    if (!canCancel && !canComplete && !canLaunch && !canRetry && !canCleanup) {
+        return null;
+    }
+
+    const uuid = <span className="font-mono bg-gray-300">{migration.uuid}</span>;
+
+    return (
+        <div className="w-min inline-block">
+            <Dropdown dropdownButton={Icons.info} position="bottom-right">
+                {canLaunch && <MenuItem onClick={() => setCurrentDialog('Launch')}>Launch</MenuItem>}
+                {canComplete && <MenuItem onClick={() => setCurrentDialog('Complete')}>Complete</MenuItem>}
+                {canRetry && <MenuItem onClick={() => setCurrentDialog('Retry')}>Retry</MenuItem>}
+                {canCleanup && <MenuItem onClick={() => setCurrentDialog('Cleanup')}>Cleanup</MenuItem>}
+                {canCancel && (
+                    <MenuItem intent={Intent.danger} onClick={() => setCurrentDialog('Cancel')}>
+                        Cancel
+                    </MenuItem>
+                )}
+            </Dropdown>
+            <KeyspaceAction
+                title="Launch Migration"
+                confirmText="Launch"
+                loadingText="Launching"
+                mutation={launchMutation}
+                successText="Launched migration"
+                errorText="Error launching migration"
+                closeDialog={closeDialog}
+                isOpen={currentDialog === 'Launch'}
+                body={<div className="text-sm mt-3">Launches the postponed migration {uuid}.</div>}
+            />
+            <KeyspaceAction
+                title="Complete Migration"
+                confirmText="Complete"
+                loadingText="Completing"
+                mutation={completeMutation}
+                successText="Completed migration"
+                errorText="Error completing migration"
+                closeDialog={closeDialog}
+                isOpen={currentDialog === 'Complete'}
+                body={
+                    <div className="text-sm mt-3">
+                        Cuts over the postponed migration {uuid}. If the migration is still copying rows, it will
+                        complete as soon as it is ready.
+                    </div>
+                }
+            />
+            <KeyspaceAction
+                title="Retry Migration"
+                confirmText="Retry"
+                loadingText="Retrying"
+                mutation={retryMutation}
+                successText="Retried migration"
+                errorText="Error retrying migration"
+                closeDialog={closeDialog}
+                isOpen={currentDialog === 'Retry'}
+                body={<div className="text-sm mt-3">Retries the failed or cancelled migration {uuid}.</div>}
+            />
+            <KeyspaceAction
+                title="Cleanup Migration"
+                confirmText="Cleanup"
+                loadingText="Cleaning up"
+                mutation={cleanupMutation}
+                successText="Marked migration for cleanup"
+                errorText="Error marking migration for cleanup"
+                closeDialog={closeDialog}
+                isOpen={currentDialog === 'Cleanup'}
+                body={
+                    <div className="text-sm mt-3">
+                        Marks the artifacts (e.g. shadow tables) of migration {uuid} as ready to be garbage
+                        collected.
+                    </div>
+                }
+            />
+            <KeyspaceAction
+                title="Cancel Migration"
+                confirmText="Cancel Migration"
+                loadingText="Cancelling"
+                mutation={cancelMutation}
+                successText="Cancelled migration"
+                errorText="Error cancelling migration"
+                closeDialog={closeDialog}
+                isOpen={currentDialog === 'Cancel'}
+                body={<div className="text-sm mt-3">Cancels the migration {uuid}, terminating it if running.</div>}
+            />
+        </div>
+    );
+};
+
+export default SchemaMigrationActions;
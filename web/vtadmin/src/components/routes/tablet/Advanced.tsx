@@ -14,22 +14,30 @@
  * limitations under the License.
  */
 
-import React from 'react';
-import { UseMutationResult } from 'react-query';
+import React, { useState } from 'react';
+import { UseMutationResult, useQueryClient } from 'react-query';
 import { useHistory } from 'react-router-dom';
 import { DeleteTabletParams } from '../../../api/http';
 import {
     useDeleteTablet,
+    useDrainTablet,
     useRefreshTabletReplicationSource,
+    useReloadSchema,
     useSetReadOnly,
     useSetReadWrite,
     useStartReplication,
     useStopReplication,
+    useUndrainTablet,
 } from '../../../hooks/api';
 import { topodata, vtadmin } from '../../../proto/vtadmin';
 import { isPrimary } from '../../../util/tablets';
 import ActionPanel from '../../ActionPanel';
 import { success, warn } from '../../Snackbar';
+import { TextInput } from '../../TextInput';
+
+// The tablet tags set by VTAdmin when draining a tablet.
+const DRAINED_FROM_TAG = 'drained_from';
+const DRAIN_REASON_TAG = 'drain_reason';
 
 interface AdvancedProps {
     alias: string;
@@ -39,7 +47,12 @@ interface AdvancedProps {
 
 const Advanced: React.FC<AdvancedProps> = ({ alias, clusterID, tablet }) => {
     const history = useHistory();
+    const queryClient = useQueryClient();
     const primary = isPrimary(tablet);
+    const drained = tablet?.tablet?.type === topodata.TabletType.DRAINED;
+    const tags = tablet?.tablet?.tags || {};
+
+    const [drainReason, setDrainReason] = useState('');
 
     const deleteParams: DeleteTabletParams = { alias, clusterID };
     if (tablet?.tablet?.type === topodata.TabletType.PRIMARY) {
@@ -56,6 +69,28 @@ const Advanced: React.FC<AdvancedProps> = ({ alias, clusterID, tablet }) => {
         onError: (error) => warn(`There was an error deleting tablet: ${error}`),
     });
 
+    const drainTabletMutation = useDrainTablet(
+        { alias, clusterID, reason: drainReason },
+        {
+            onSuccess: () => {
+                success(`Successfully drained tablet ${alias}.`, { autoClose: 7000 });
+                queryClient.invalidateQueries('tablet');
+            },
+            onError: (error) => warn(`There was an error draining tablet ${alias}: ${error}`),
+        }
+    );
+
+    const undrainTabletMutation = useUndrainTablet(
+        { alias, clusterID },
+        {
+            onSuccess: () => {
+                success(`Successfully undrained tablet ${alias}.`, { autoClose: 7000 });
+                queryClient.invalidateQueries('tablet');
+            },
+            onError: (error) => warn(`There was an error undraining tablet ${alias}: ${error}`),
+        }
+    );
+
     const refreshTabletReplicationSourceMutation = useRefreshTabletReplicationSource(
         { alias, clusterID },
         {
@@ -69,6 +104,14 @@ const Advanced: React.FC<AdvancedProps> = ({ alias, clusterID, tablet }) => {
         }
     );
 
+    const reloadSchemaMutation = useReloadSchema(
+        { clusterIDs: [clusterID], tablets: [alias] },
+        {
+            onSuccess: () => success(`Successfully reloaded the schema on tablet ${alias}.`, { autoClose: 1600 }),
+            onError: (error) => warn(`There was an error reloading the schema on tablet ${alias}: ${error}`),
+        }
+    );
+
     const setReadOnlyMutation = useSetReadOnly(
         { alias, clusterID },
         {
@@ -109,6 +152,25 @@ const Advanced: React.FC<AdvancedProps> = ({ alias, clusterID, tablet }) => {
 
     return (
         <div className="pt-4">
+            <div className="my-8">
+                <h3 className="mb-4">Schema</h3>
+                <div>
+                    <ActionPanel
+                        description={
+                            <>
+                                Reloads the schema on tablet <span className="font-bold">{alias}</span>, so that its
+                                schema cache reflects the tables and columns in the underlying database.
+                            </>
+                        }
+                        documentationLink="https://vitess.io/docs/reference/programs/vtctl/schema-version-permissions/#reloadschema"
+                        loadedText="Reload Schema"
+                        loadingText="Reloading schema..."
+                        mutation={reloadSchemaMutation as UseMutationResult}
+                        title="Reload Schema"
+                    />
+                </div>
+            </div>
+
             <div className="my-8">
                 <h3 className="mb-4">Replication</h3>
                 <div>
@@ -214,6 +276,60 @@ const Advanced: React.FC<AdvancedProps> = ({ alias, clusterID, tablet }) => {
                             />
                         </>
                     )}
+                    {!primary && !drained && (
+                        <ActionPanel
+                            confirmationValue={alias}
+                            danger
+                            description={
+                                <>
+                                    Drain tablet <span className="font-bold">{alias}</span> by changing its type to{' '}
+                                    <span className="font-mono text-sm p-1 bg-gray-100">drained</span>, so that it
+                                    stops serving queries. Its current type and the reason are recorded in its tags.
+                                </>
+                            }
+                            documentationLink="https://vitess.io/docs/reference/programs/vtctl/tablets/#changetablettype"
+                            loadingText="Draining..."
+                            loadedText="Drain"
+                            mutation={drainTabletMutation as UseMutationResult}
+                            title="Drain Tablet"
+                            warnings={[`Queries will no longer be routed to tablet ${alias}.`]}
+                            body={
+                                <div className="w-1/2 mt-4">
+                                    <p className="text-base">
+                                        <strong>Reason</strong> <br />
+                                        Why the tablet is being drained (optional):
+                                    </p>
+                                    <TextInput value={drainReason} onChange={(e) => setDrainReason(e.target.value)} />
+                                </div>
+                            }
+                        />
+                    )}
+                    {drained && (
+                        <ActionPanel
+                            confirmationValue={alias}
+                            danger
+                            description={
+                                <>
+                                    Return tablet <span className="font-bold">{alias}</span> to serving as a{' '}
+                                    <span className="font-mono text-sm p-1 bg-gray-100">
+                                        {tags[DRAINED_FROM_TAG] || 'unknown'}
+                                    </span>{' '}
+                                    tablet.
+                                    {tags[DRAIN_REASON_TAG] && <> It was drained because: {tags[DRAIN_REASON_TAG]}</>}
+                                </>
+                            }
+                            disabled={!tags[DRAINED_FROM_TAG]}
+                            documentationLink="https://vitess.io/docs/reference/programs/vtctl/tablets/#changetablettype"
+                            loadingText="Undraining..."
+                            loadedText="Undrain"
+                            mutation={undrainTabletMutation as UseMutationResult}
+                            title="Undrain Tablet"
+                            warnings={[
+                                !tags[DRAINED_FROM_TAG] &&
+                                    `Tablet ${alias} has no ${DRAINED_FROM_TAG} tag. Use ChangeTabletType to undrain it.`,
+                            ]}
+                        />
+                    )}
                     <ActionPanel
                         confirmationValue={alias}
                         danger
@@ -0,0 +1,83 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { useEffect } from 'react';
+import { Link, useParams } from 'react-router-dom';
+import ReactFlow, { Background, Controls, MiniMap, useEdgesState, useNodesState } from 'react-flow-renderer';
+
+import { useClusterGraph } from '../../../hooks/api';
+import { useDocumentTitle } from '../../../hooks/useDocumentTitle';
+import { ContentContainer } from '../../layout/ContentContainer';
+import { NavCrumbs } from '../../layout/NavCrumbs';
+import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
+import { QueryErrorPlaceholder } from '../../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../../placeholders/QueryLoadingPlaceholder';
+import { generateClusterGraph } from './GraphNodes';
+
+interface RouteParams {
+    clusterID: string;
+}
+
+export const ClusterGraph = () => {
+    const { clusterID } = useParams<RouteParams>();
+    useDocumentTitle(`${clusterID} Graph`);
+
+    const query = useClusterGraph({ clusterID });
+
+    const [nodes, setNodes, onNodesChange] = useNodesState([]);
+    const [edges, setEdges, onEdgesChange] = useEdgesState([]);
+
+    useEffect(() => {
+        const { nodes: graphNodes, edges: graphEdges } = query.data
+            ? generateClusterGraph(query.data)
+            : { nodes: [], edges: [] };
+        setNodes(graphNodes);
+        setEdges(graphEdges);
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [query.data]);
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <NavCrumbs>
+                    <Link to="/topology">Topology</Link>
+                </NavCrumbs>
+
+                <WorkspaceTitle className="font-mono">{clusterID}</WorkspaceTitle>
+            </WorkspaceHeader>
+
+            <ContentContainer className="lg:w-[1400px] lg:h-[1200px] md:w-[900px] md:h-[800px]">
+                <QueryLoadingPlaceholder query={query} />
+                <QueryErrorPlaceholder query={query} title="Couldn't load the cluster graph" />
+
+                {query.isSuccess && (
+                    <ReactFlow
+                        nodes={nodes}
+                        edges={edges}
+                        onNodesChange={onNodesChange}
+                        onEdgesChange={onEdgesChange}
+                        fitView
+                        attributionPosition="top-right"
+                    >
+                        <MiniMap nodeBorderRadius={2} />
+                        <Controls />
+                        <Background color="#aaa" gap={16} />
+                    </ReactFlow>
+                )}
+            </ContentContainer>
+        </div>
+    );
+};
@@ -0,0 +1,142 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import React from 'react';
+import { Edge, MarkerType, Node } from 'react-flow-renderer';
+
+import { topodata, vtadmin as pb } from '../../../proto/vtadmin';
+import { formatAlias, TABLET_TYPES } from '../../../util/tablets';
+
+const COLUMN_WIDTH = 250;
+const ROW_HEIGHT = 80;
+
+const NOT_SERVING_STYLE = { background: '#fee2e2' };
+
+/**
+ * generateClusterGraph lays out the cells, keyspaces, shards and tablets of a
+ * cluster graph as a tree, one column per level, and adds an edge from the
+ * primary of each shard to every tablet replicating from it.
+ */
+export const generateClusterGraph = (graph: pb.IClusterGraph): { nodes: Array<Node>; edges: Array<Edge> } => {
+    const nodes: Array<Node> = [];
+    const edges: Array<Edge> = [];
+    const tabletIDs = new Set<string>();
+
+    let row = 0;
+
+    // addNode adds a node centered on its children, which must already be laid out
+    // starting at firstRow, or on the next row if it has no children.
+    const addNode = (id: string, depth: number, firstRow: number, label: React.ReactNode, style?: object) => {
+        const lastRow = Math.max(firstRow, row - 1);
+        if (row === firstRow) {
+            row++;
+        }
+
+        nodes.push({
+            id,
+            data: { label },
+            position: { x: depth * COLUMN_WIDTH, y: ((firstRow + lastRow) / 2) * ROW_HEIGHT },
+            style,
+        });
+    };
+
+    const addEdge = (source: string, target: string) => {
+        edges.push({
+            id: `${source}-${target}`,
+            source,
+            target,
+            markerEnd: { type: MarkerType.ArrowClosed },
+        });
+    };
+
+    (graph.cells || []).forEach((cell) => {
+        const cellID = `cell/${cell.name}`;
+        const cellRow = row;
+
+        (cell.keyspaces || []).forEach((ks) => {
+            const ksID = `${cellID}/${ks.name}`;
+            const ksRow = row;
+
+            (ks.shards || []).forEach((shard) => {
+                const shardID = `${ksID}/${shard.name}`;
+                const shardRow = row;
+
+                (shard.tablets || []).forEach((tablet) => {
+                    const alias = formatAlias(tablet.alias) as string;
+                    const isServing = tablet.state === pb.Tablet.ServingState.SERVING;
+
+                    tabletIDs.add(alias);
+                    addNode(
+                        `tablet/${alias}`,
+                        3,
+                        row,
+                        <div>
+                            <div className="font-bold font-mono">{alias}</div>
+                            <div className="text-sm">
+                                {TABLET_TYPES[tablet.type || topodata.TabletType.UNKNOWN]}
+                                {!isServing && ' (not serving)'}
+                            </div>
+                        </div>,
+                        isServing ? undefined : NOT_SERVING_STYLE
+                    );
+                    addEdge(shardID, `tablet/${alias}`);
+                });
+
+                addNode(
+                    shardID,
+                    2,
+                    shardRow,
+                    <div>
+                        <div className="font-bold">{shard.name}</div>
+                        {!shard.is_primary_serving && <div className="text-sm">primary not serving</div>}
+                    </div>,
+                    shard.is_primary_serving ? undefined : NOT_SERVING_STYLE
+                );
+                addEdge(ksID, shardID);
+            });
+
+            addNode(ksID, 1, ksRow, <div className="font-bold">{ks.name}</div>);
+            addEdge(cellID, ksID);
+        });
+
+        addNode(cellID, 0, cellRow, <div className="font-bold">{cell.name}</div>);
+    });
+
+    // Replication edges are only drawn when the source tablet is in the graph.
+    (graph.cells || []).forEach((cell) =>
+        (cell.keyspaces || []).forEach((ks) =>
+            (ks.shards || []).forEach((shard) =>
+                (shard.tablets || []).forEach((tablet) => {
+                    const source = formatAlias(tablet.replication_source);
+                    if (!source || !tabletIDs.has(source)) {
+                        return;
+                    }
+
+                    const target = formatAlias(tablet.alias);
+                    edges.push({
+                        id: `replication/${source}-${target}`,
+                        source: `tablet/${source}`,
+                        target: `tablet/${target}`,
+                        animated: true,
+                        label: 'replicates',
+                        markerEnd: { type: MarkerType.ArrowClosed },
+                    });
+                })
+            )
+        )
+    );
+
+    return { nodes, edges };
+};
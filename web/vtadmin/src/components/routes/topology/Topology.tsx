@@ -26,9 +26,9 @@ import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
 import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
 import { Link } from 'react-router-dom';
 
-const TopologyLink: React.FC<{ clusterID: string }> = ({ clusterID, children }) => {
+const TopologyLink: React.FC<{ clusterID: string; graph?: boolean }> = ({ clusterID, graph, children }) => {
     const to = {
-        pathname: `/topology/${clusterID}`,
+        pathname: graph ? `/topology/${clusterID}/graph` : `/topology/${clusterID}`,
     };
 
     return (
@@ -53,6 +53,9 @@ export const Topology = () => {
                 <DataCell>
                     <TopologyLink clusterID={cluster.id}>View Topology</TopologyLink>
                 </DataCell>
+                <DataCell>
+                    <TopologyLink clusterID={cluster.id} graph>View Graph</TopologyLink>
+                </DataCell>
             </tr>
         ));
 
@@ -65,7 +68,7 @@ export const Topology = () => {
             <ContentContainer>
                 <div className="max-w-screen-sm">
                     <div className="text-xl font-bold">Clusters</div>
-                    <DataTable columns={['Name', 'Id', 'Topology']} data={rows} renderRows={renderRows} />
+                    <DataTable columns={['Name', 'Id', 'Topology', 'Graph']} data={rows} renderRows={renderRows} />
                 </div>
             </ContentContainer>
         </div>
@@ -49,6 +49,8 @@ import {
     refreshTabletReplicationSource,
     startReplication,
     stopReplication,
+    drainTablet,
+    undrainTablet,
     setReadOnly,
     setReadWrite,
     ValidateKeyspaceParams,
@@ -70,6 +72,8 @@ import {
     createShard,
     GetTopologyPathParams,
     getTopologyPath,
+    fetchClusterGraph,
+    FetchClusterGraphParams,
     validate,
     ValidateParams,
     validateShard,
@@ -78,6 +82,43 @@ import {
     GetFullStatusParams,
     validateVersionShard,
     ValidateVersionShardParams,
+    fetchSchemaMigrations,
+    FetchSchemaMigrationsParams,
+    applySchema,
+    cancelSchemaMigration,
+    cleanupSchemaMigration,
+    completeSchemaMigration,
+    launchSchemaMigration,
+    retrySchemaMigration,
+    SchemaMigrationActionParams,
+    validateMoveTablesCreate,
+    moveTablesCreate,
+    MoveTablesCreateParams,
+    validateReshardCreate,
+    reshardCreate,
+    ReshardCreateParams,
+    fetchReshardRecommendation,
+    FetchReshardRecommendationParams,
+    workflowSwitchTraffic,
+    WorkflowSwitchTrafficParams,
+    moveTablesComplete,
+    MoveTablesCompleteParams,
+    diffSchemas,
+    DiffSchemasParams,
+    fetchSchemaDrift,
+    FetchSchemaDriftParams,
+    executeQuery,
+    ExecuteQueryParams,
+    fetchClusterMetrics,
+    fetchAuditEvents,
+    FetchAuditEventsParams,
+    fetchSavedQueries,
+    createSavedQuery,
+    CreateSavedQueryParams,
+    deleteSavedQuery,
+    SavedQueryParams,
+    runSavedQuery,
+    RunSavedQueryParams,
 } from '../api/http';
 import { vtadmin as pb, vtctldata } from '../proto/vtadmin';
 import { formatAlias } from '../util/tablets';
@@ -254,6 +295,30 @@ export const useStopReplication = (
     }, options);
 };
 
+/**
+ * useDrainTablet takes the specified tablet out of serving by changing its type to DRAINED.
+ */
+export const useDrainTablet = (
+    params: Parameters<typeof drainTablet>[0],
+    options: UseMutationOptions<Awaited<ReturnType<typeof drainTablet>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof drainTablet>>, Error>(() => {
+        return drainTablet(params);
+    }, options);
+};
+
+/**
+ * useUndrainTablet returns the specified drained tablet to serving, as the type it was drained from.
+ */
+export const useUndrainTablet = (
+    params: Parameters<typeof undrainTablet>[0],
+    options: UseMutationOptions<Awaited<ReturnType<typeof undrainTablet>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof undrainTablet>>, Error>(() => {
+        return undrainTablet(params);
+    }, options);
+};
+
 /**
  * usePingTablet is a query hook that pings a single tablet by tablet alias and (optionally) cluster id.
  */
@@ -574,6 +639,15 @@ export const useTopologyPath = (
 ) => {
     return useQuery(['topology-path', params], () => getTopologyPath(params));
 };
+
+/**
+ * useClusterGraph is a query hook that fetches the cells, keyspaces, shards
+ * and tablets of a cluster as a single graph.
+ */
+export const useClusterGraph = (
+    params: FetchClusterGraphParams,
+    options?: UseQueryOptions<pb.ClusterGraph, Error> | undefined
+) => useQuery(['cluster-graph', params], () => fetchClusterGraph(params), options);
 /**
  * useValidate is a mutate hook that validates that all nodes reachable from the global replication graph,
  * as well as all tablets in discoverable cells, are consistent.
@@ -619,3 +693,266 @@ export const useValidateVersionShard = (
         return validateVersionShard(params);
     }, options);
 };
+
+/**
+ * useSchemaMigrations is a query hook that fetches schema migrations across
+ * every cluster, optionally filtered by keyspace, status, and recency.
+ */
+export const useSchemaMigrations = (
+    params: FetchSchemaMigrationsParams = {},
+    options?: UseQueryOptions<pb.GetSchemaMigrationsResponse, Error> | undefined
+) => useQuery(['schema-migrations', params], () => fetchSchemaMigrations(params), options);
+
+/**
+ * useApplySchema is a mutation query hook that submits a schema change,
+ * typically as an Online DDL migration.
+ */
+export const useApplySchema = (
+    params: Parameters<typeof applySchema>[0],
+    options: UseMutationOptions<Awaited<ReturnType<typeof applySchema>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof applySchema>>, Error>(() => {
+        return applySchema(params);
+    }, options);
+};
+
+/**
+ * useCancelSchemaMigration is a mutate hook that cancels a schema migration.
+ */
+export const useCancelSchemaMigration = (
+    params: SchemaMigrationActionParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof cancelSchemaMigration>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof cancelSchemaMigration>>, Error>(() => {
+        return cancelSchemaMigration(params);
+    }, options);
+};
+
+/**
+ * useCleanupSchemaMigration is a mutate hook that marks a schema migration's
+ * artifacts as ready for cleanup.
+ */
+export const useCleanupSchemaMigration = (
+    params: SchemaMigrationActionParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof cleanupSchemaMigration>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof cleanupSchemaMigration>>, Error>(() => {
+        return cleanupSchemaMigration(params);
+    }, options);
+};
+
+/**
+ * useCompleteSchemaMigration is a mutate hook that completes a schema
+ * migration that was started with --postpone-completion.
+ */
+export const useCompleteSchemaMigration = (
+    params: SchemaMigrationActionParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof completeSchemaMigration>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof completeSchemaMigration>>, Error>(() => {
+        return completeSchemaMigration(params);
+    }, options);
+};
+
+/**
+ * useLaunchSchemaMigration is a mutate hook that launches a schema migration
+ * that was submitted with --postpone-launch.
+ */
+export const useLaunchSchemaMigration = (
+    params: SchemaMigrationActionParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof launchSchemaMigration>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof launchSchemaMigration>>, Error>(() => {
+        return launchSchemaMigration(params);
+    }, options);
+};
+
+/**
+ * useRetrySchemaMigration is a mutate hook that retries a failed or cancelled
+ * schema migration.
+ */
+export const useRetrySchemaMigration = (
+    params: SchemaMigrationActionParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof retrySchemaMigration>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof retrySchemaMigration>>, Error>(() => {
+        return retrySchemaMigration(params);
+    }, options);
+};
+
+/**
+ * useValidateMoveTablesCreate is a mutation query hook that checks whether a
+ * MoveTables workflow can be created, without creating it.
+ */
+export const useValidateMoveTablesCreate = (
+    options?: UseMutationOptions<Awaited<ReturnType<typeof validateMoveTablesCreate>>, Error, MoveTablesCreateParams>
+) => {
+    return useMutation<Awaited<ReturnType<typeof validateMoveTablesCreate>>, Error, MoveTablesCreateParams>(
+        (params) => validateMoveTablesCreate(params),
+        options
+    );
+};
+
+/**
+ * useMoveTablesCreate is a mutation query hook that creates a MoveTables workflow.
+ */
+export const useMoveTablesCreate = (
+    params: MoveTablesCreateParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof moveTablesCreate>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof moveTablesCreate>>, Error>(() => {
+        return moveTablesCreate(params);
+    }, options);
+};
+
+/**
+ * useValidateReshardCreate is a mutation query hook that checks whether a
+ * Reshard workflow can be created, without creating it.
+ */
+export const useValidateReshardCreate = (
+    options?: UseMutationOptions<Awaited<ReturnType<typeof validateReshardCreate>>, Error, ReshardCreateParams>
+) => {
+    return useMutation<Awaited<ReturnType<typeof validateReshardCreate>>, Error, ReshardCreateParams>(
+        (params) => validateReshardCreate(params),
+        options
+    );
+};
+
+/**
+ * useReshardCreate is a mutation query hook that creates a Reshard workflow.
+ */
+export const useReshardCreate = (
+    params: ReshardCreateParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof reshardCreate>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof reshardCreate>>, Error>(() => {
+        return reshardCreate(params);
+    }, options);
+};
+
+/**
+ * useReshardRecommendation is a query hook that fetches a suggested set of
+ * target shards for resharding a keyspace.
+ */
+export const useReshardRecommendation = (
+    params: FetchReshardRecommendationParams,
+    options?: UseQueryOptions<vtctldata.ReshardRecommendResponse, Error> | undefined
+) => useQuery(['reshard-recommendation', params], () => fetchReshardRecommendation(params), options);
+
+/**
+ * useWorkflowSwitchTraffic is a mutation query hook that switches the traffic
+ * of a MoveTables or Reshard workflow.
+ */
+export const useWorkflowSwitchTraffic = (
+    params: WorkflowSwitchTrafficParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof workflowSwitchTraffic>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof workflowSwitchTraffic>>, Error>(() => {
+        return workflowSwitchTraffic(params);
+    }, options);
+};
+
+/**
+ * useMoveTablesComplete is a mutation query hook that completes a MoveTables
+ * or Reshard workflow whose traffic has been switched.
+ */
+export const useMoveTablesComplete = (
+    params: MoveTablesCompleteParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof moveTablesComplete>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof moveTablesComplete>>, Error>(() => {
+        return moveTablesComplete(params);
+    }, options);
+};
+
+/**
+ * useDiffSchemas is a query hook that compares the live schemas of two
+ * keyspaces or shards, possibly in different clusters.
+ */
+export const useDiffSchemas = (
+    params: DiffSchemasParams,
+    options?: UseQueryOptions<pb.DiffSchemasResponse, Error> | undefined
+) => useQuery(['schema-diff', params], () => diffSchemas(params), options);
+
+/**
+ * useSchemaDrift is a query hook that compares the schema of every serving
+ * shard of a keyspace to the schema of a reference shard.
+ */
+export const useSchemaDrift = (
+    params: FetchSchemaDriftParams,
+    options?: UseQueryOptions<pb.GetSchemaDriftResponse, Error> | undefined
+) => useQuery(['schema-drift', params], () => fetchSchemaDrift(params), options);
+
+/**
+ * useExecuteQuery is a mutation query hook that runs a read-only query
+ * through the query console.
+ */
+export const useExecuteQuery = (
+    params: ExecuteQueryParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof executeQuery>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof executeQuery>>, Error>(() => {
+        return executeQuery(params);
+    }, options);
+};
+
+/**
+ * useClusterMetrics is a query hook that fetches the metrics VTAdmin scrapes
+ * from the VTGates and tablets of every cluster.
+ */
+export const useClusterMetrics = (options?: UseQueryOptions<pb.ClusterMetrics[], Error> | undefined) =>
+    useQuery(['cluster-metrics'], fetchClusterMetrics, options);
+
+/**
+ * useAuditEvents is a query hook that fetches the audit log of mutating
+ * VTAdmin API calls, most recent first.
+ */
+export const useAuditEvents = (
+    params: FetchAuditEventsParams = {},
+    options?: UseQueryOptions<pb.GetAuditEventsResponse, Error> | undefined
+) => useQuery(['audit-events', params], () => fetchAuditEvents(params), options);
+
+/**
+ * useSavedQueries is a query hook that fetches the saved queries of every
+ * cluster.
+ */
+export const useSavedQueries = (options?: UseQueryOptions<pb.SavedQuery[], Error> | undefined) =>
+    useQuery(['saved-queries'], fetchSavedQueries, options);
+
+/**
+ * useCreateSavedQuery is a mutation query hook that saves a read-only query.
+ */
+export const useCreateSavedQuery = (
+    params: CreateSavedQueryParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof createSavedQuery>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof createSavedQuery>>, Error>(() => {
+        return createSavedQuery(params);
+    }, options);
+};
+
+/**
+ * useDeleteSavedQuery is a mutation query hook that deletes a saved query,
+ * and its schedule.
+ */
+export const useDeleteSavedQuery = (
+    params: SavedQueryParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof deleteSavedQuery>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof deleteSavedQuery>>, Error>(() => {
+        return deleteSavedQuery(params);
+    }, options);
+};
+
+/**
+ * useRunSavedQuery is a mutation query hook that runs a saved query through
+ * the query console.
+ */
+export const useRunSavedQuery = (
+    params: RunSavedQueryParams,
+    options?: UseMutationOptions<Awaited<ReturnType<typeof runSavedQuery>>, Error>
+) => {
+    return useMutation<Awaited<ReturnType<typeof runSavedQuery>>, Error>(() => {
+        return runSavedQuery(params);
+    }, options);
+};
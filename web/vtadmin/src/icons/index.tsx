@@ -1,5 +1,6 @@
 export { ReactComponent as AlertFail } from './alertFail.svg';
 export { ReactComponent as Bug } from './bug.svg';
+export { ReactComponent as Chart } from './chart.svg';
 export { ReactComponent as CheckSuccess } from './checkSuccess.svg';
 export { ReactComponent as ChevronDown } from './chevronDown.svg';
 export { ReactComponent as ChevronUp } from './chevronUp.svg';
@@ -0,0 +1,64 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { describe, expect, test } from 'vitest';
+
+import { findParameters, formatInterval } from './savedQueries';
+
+describe('findParameters', () => {
+    const tests: {
+        name: string;
+        sql: string;
+        expected: string[];
+    }[] = [
+        {
+            name: 'no parameters',
+            sql: 'select * from users',
+            expected: [],
+        },
+        {
+            name: 'parameters in order of first appearance',
+            sql: 'select * from users where status = :status and (id = :id or parent_id = :id)',
+            expected: ['status', 'id'],
+        },
+        {
+            name: 'ignores times',
+            sql: "select * from users where created_at > '2023-01-01 12:30:00' and id = :id",
+            expected: ['id'],
+        },
+    ];
+
+    test.each(tests.map(Object.values))('%s', (name: string, sql: string, expected: string[]) => {
+        expect(findParameters(sql)).toEqual(expected);
+    });
+});
+
+describe('formatInterval', () => {
+    const tests: {
+        name: string;
+        seconds: number;
+        expected: string | null;
+    }[] = [
+        { name: 'days', seconds: 2 * 24 * 60 * 60, expected: '2d' },
+        { name: 'hours', seconds: 60 * 60, expected: '1h' },
+        { name: 'minutes', seconds: 90 * 60, expected: '90m' },
+        { name: 'seconds', seconds: 90, expected: '90s' },
+        { name: 'unset', seconds: 0, expected: null },
+    ];
+
+    test.each(tests.map(Object.values))('%s', (name: string, seconds: number, expected: string | null) => {
+        expect(formatInterval({ interval: { seconds } })).toEqual(expected);
+    });
+});
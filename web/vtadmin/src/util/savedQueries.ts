@@ -0,0 +1,52 @@
+/**
+ * Copyright 2023 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { vtadmin as pb } from '../proto/vtadmin';
+
+const BIND_VARIABLE = /(^|[^:\w]):([a-zA-Z_]\w*)/g;
+
+/**
+ * findParameters returns the names of the bind variables of a query, such as
+ * "id" for ":id", in order of first appearance.
+ */
+export const findParameters = (sql: string): string[] => {
+    const names: string[] = [];
+    for (const match of sql.matchAll(BIND_VARIABLE)) {
+        if (!names.includes(match[2])) names.push(match[2]);
+    }
+
+    return names;
+};
+
+const INTERVAL_UNITS: [string, number][] = [
+    ['d', 24 * 60 * 60],
+    ['h', 60 * 60],
+    ['m', 60],
+];
+
+/**
+ * formatInterval formats the interval of a saved query's schedule, e.g. "1h"
+ * or "90m".
+ */
+export const formatInterval = (schedule: pb.SavedQuery.ISchedule | null | undefined): string | null => {
+    const seconds = Number(schedule?.interval?.seconds || 0);
+    if (!seconds) return null;
+
+    for (const [unit, size] of INTERVAL_UNITS) {
+        if (seconds % size === 0) return `${seconds / size}${unit}`;
+    }
+
+    return `${seconds}s`;
+};
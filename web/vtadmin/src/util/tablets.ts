@@ -28,6 +28,12 @@ export const TABLET_TYPES = Object.entries(invertBy(topodata.TabletType)).reduce
     return acc;
 }, {} as { [k: string]: string });
 
+/**
+ * QUERY_TABLET_TYPES are the tablet types the query console can run queries
+ * against, in order of preference.
+ */
+export const QUERY_TABLET_TYPES = ['replica', 'rdonly', 'primary'];
+
 /**
  * formatAlias formats a tablet.alias object as a single string, The Vitess Way™.
  */